@@ -86,6 +86,12 @@ func parseFlags(cmd *command) {
 		if f.Name == "optimize" || f.Name == "opt" {
 			optimizeSet = true
 		}
+		if f.Name == "dedupe" {
+			dedupeSet = true
+		}
+		if f.Name == "tag" {
+			tagSet = true
+		}
 	})
 }
 
@@ -151,10 +157,24 @@ func (m commandMap) process(cmdPrefix string, command string) (string, error) {
 	conf.OwnerPW = opw
 	conf.UserPW = upw
 
+	if certFile != "" || keyFile != "" {
+		if err := setRecipientKey(conf); err != nil {
+			return command, err
+		}
+	}
+
 	if offlineSet {
 		conf.Offline = offline
 	}
 
+	if objStreams != "" {
+		m, err := model.ParseObjectStreamMode(objStreams)
+		if err != nil {
+			return command, err
+		}
+		conf.ObjectStreamMode = m
+	}
+
 	if m[cmdStr].handler != nil {
 
 		if conf.Version != model.VersionStr && cmdStr != "reset" {
@@ -25,8 +25,13 @@ import (
 func initAnnotsCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"list":   {processListAnnotationsCommand, nil, "", ""},
-		"remove": {processRemoveAnnotationsCommand, nil, "", ""},
+		"add":      {processAddAnnotationsCommand, nil, "", ""},
+		"autolink": {processAddAutoLinksCommand, nil, "", ""},
+		"export":   {processExportAnnotationsCommand, nil, "", ""},
+		"flatten":  {processFlattenAnnotationsCommand, nil, "", ""},
+		"import":   {processImportAnnotationsCommand, nil, "", ""},
+		"list":     {processListAnnotationsCommand, nil, "", ""},
+		"remove":   {processRemoveAnnotationsCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -51,6 +56,7 @@ func initBookmarksCmdMap() commandMap {
 	for k, v := range map[string]command{
 		"list":   {processListBookmarksCommand, nil, "", ""},
 		"import": {processImportBookmarksCommand, nil, "", ""},
+		"detect": {processDetectBookmarksCommand, nil, "", ""},
 		"export": {processExportBookmarksCommand, nil, "", ""},
 		"remove": {processRemoveBookmarksCommand, nil, "", ""},
 	} {
@@ -95,6 +101,27 @@ func initConfigCmdMap() commandMap {
 	return m
 }
 
+func initConvertCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"cmyk": {processConvertToCMYKCommand, nil, "", ""},
+		"gray": {processConvertToGrayCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initFlattenCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"transparency": {processFlattenTransparencyCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initFontsCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -151,8 +178,9 @@ func initKeywordsCmdMap() commandMap {
 func initPagesCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
-		"insert": {processInsertPagesCommand, nil, "", ""},
-		"remove": {processRemovePagesCommand, nil, "", ""},
+		"insert":  {processInsertPagesCommand, nil, "", ""},
+		"remove":  {processRemovePagesCommand, nil, "", ""},
+		"analyze": {processAnalyzePagesCommand, nil, "", ""},
 	} {
 		m.register(k, v)
 	}
@@ -170,11 +198,35 @@ func initPermissionsCmdMap() commandMap {
 	return m
 }
 
+func initMetadataCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":  {processListMetadataCommand, nil, "", ""},
+		"set":   {processSetMetadataCommand, nil, "", ""},
+		"check": {processCheckMetadataCommand, nil, "", ""},
+		"sync":  {processSyncMetadataCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initPipelineCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"run": {processRunPipelineCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initPortfolioCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
 		"list":    {processListAttachmentsCommand, nil, "", ""},
 		"add":     {processAddAttachmentsPortfolioCommand, nil, "", ""},
+		"create":  {processCreatePortfolioCommand, nil, "", ""},
 		"remove":  {processRemoveAttachmentsCommand, nil, "", ""},
 		"extract": {processExtractAttachmentsCommand, nil, "", ""},
 	} {
@@ -219,6 +271,32 @@ func initWatermarkCmdMap() commandMap {
 	return m
 }
 
+func initLayersCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":    {processListLayersCommand, nil, "", ""},
+		"show":    {processShowLayersCommand, nil, "", ""},
+		"hide":    {processHideLayersCommand, nil, "", ""},
+		"remove":  {processRemoveLayersCommand, nil, "", ""},
+		"flatten": {processFlattenLayersCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initOutputIntentsCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":    {processListOutputIntentsCommand, nil, "", ""},
+		"add":     {processAddOutputIntentCommand, nil, "", ""},
+		"replace": {processReplaceOutputIntentCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initPageModeCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -231,6 +309,31 @@ func initPageModeCmdMap() commandMap {
 	return m
 }
 
+func initTransitionsCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":       {processListPageTransitionsCommand, nil, "", ""},
+		"set":        {processSetPageTransitionsCommand, nil, "", ""},
+		"remove":     {processRemovePageTransitionsCommand, nil, "", ""},
+		"fullscreen": {processSetFullScreenCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
+func initPageLabelsCmdMap() commandMap {
+	m := newCommandMap()
+	for k, v := range map[string]command{
+		"list":   {processListPageLabelsCommand, nil, "", ""},
+		"set":    {processSetPageLabelsCommand, nil, "", ""},
+		"remove": {processRemovePageLabelsCommand, nil, "", ""},
+	} {
+		m.register(k, v)
+	}
+	return m
+}
+
 func initPageLayoutCmdMap() commandMap {
 	m := newCommandMap()
 	for k, v := range map[string]command{
@@ -273,12 +376,18 @@ func initCommandMap() {
 	boxesCmdMap := initBoxesCmdMap()
 	certificatesCmdMap := initCertificatesCmdMap()
 	configCmdMap := initConfigCmdMap()
+	convertCmdMap := initConvertCmdMap()
+	flattenCmdMap := initFlattenCmdMap()
 	fontsCmdMap := initFontsCmdMap()
 	formCmdMap := initFormCmdMap()
 	imagesCmdMap := initImagesCmdMap()
 	keywordsCmdMap := initKeywordsCmdMap()
+	layersCmdMap := initLayersCmdMap()
+	metadataCmdMap := initMetadataCmdMap()
+	outputIntentsCmdMap := initOutputIntentsCmdMap()
 	pagesCmdMap := initPagesCmdMap()
 	permissionsCmdMap := initPermissionsCmdMap()
+	pipelineCmdMap := initPipelineCmdMap()
 	portfolioCmdMap := initPortfolioCmdMap()
 	propertiesCmdMap := initPropertiesCmdMap()
 	signaturesCmdMap := initSignaturesCmdMap()
@@ -286,6 +395,8 @@ func initCommandMap() {
 	watermarkCmdMap := initWatermarkCmdMap()
 	pageModeCmdMap := initPageModeCmdMap()
 	pageLayoutCmdMap := initPageLayoutCmdMap()
+	pageLabelsCmdMap := initPageLabelsCmdMap()
+	transitionsCmdMap := initTransitionsCmdMap()
 	viewerPrefsCmdMap := initViewerPreferencesCmdMap()
 
 	cmdMap = newCommandMap()
@@ -293,47 +404,67 @@ func initCommandMap() {
 	for k, v := range map[string]command{
 		"annotations":   {nil, annotsCmdMap, usageAnnots, usageLongAnnots},
 		"attachments":   {nil, attachCmdMap, usageAttach, usageLongAttach},
+		"bates":         {processBatesCommand, nil, usageBates, usageLongBates},
 		"bookmarks":     {nil, bookmarksCmdMap, usageBookmarks, usageLongBookmarks},
+		"bleed":         {processBleedCommand, nil, usageBleed, usageLongBleed},
 		"booklet":       {processBookletCommand, nil, usageBooklet, usageLongBooklet},
 		"boxes":         {nil, boxesCmdMap, usageBoxes, usageLongBoxes},
 		"certificates":  {nil, certificatesCmdMap, usageCertificates, usageLongCertificates},
 		"changeopw":     {processChangeOwnerPasswordCommand, nil, usageChangeOwnerPW, usageLongChangeOwnerPW},
 		"changeupw":     {processChangeUserPasswordCommand, nil, usageChangeUserPW, usageLongChangeUserPW},
 		"collect":       {processCollectCommand, nil, usageCollect, usageLongCollect},
+		"compare":       {processCompareCommand, nil, usageCompare, usageLongCompare},
 		"config":        {nil, configCmdMap, usageConfig, usageLongConfig},
+		"convert":       {nil, convertCmdMap, usageConvert, usageLongConvert},
 		"create":        {processCreateCommand, nil, usageCreate, usageLongCreate},
+		"cover":         {processCreateCoverCommand, nil, usageCover, usageLongCover},
 		"crop":          {processCropCommand, nil, usageCrop, usageLongCrop},
 		"cut":           {processCutCommand, nil, usageCut, usageLongCut},
 		"decrypt":       {processDecryptCommand, nil, usageDecrypt, usageLongDecrypt},
 		"dump":          {processDumpCommand, nil, "", ""},
+		"duplex":        {processManualDuplexCommand, nil, usageDuplex, usageLongDuplex},
 		"encrypt":       {processEncryptCommand, nil, usageEncrypt, usageLongEncrypt},
 		"extract":       {processExtractCommand, nil, usageExtract, usageLongExtract},
+		"flatten":       {nil, flattenCmdMap, usageFlatten, usageLongFlatten},
 		"fonts":         {nil, fontsCmdMap, usageFonts, usageLongFonts},
 		"form":          {nil, formCmdMap, usageForm, usageLongForm},
 		"grid":          {processGridCommand, nil, usageGrid, usageLongGrid},
+		"headerfooter":  {processHeaderFooterCommand, nil, usageHeaderFooter, usageLongHeaderFooter},
 		"help":          {printHelp, nil, "", ""},
 		"images":        {nil, imagesCmdMap, usageImages, usageLongImages},
 		"import":        {processImportImagesCommand, nil, usageImportImages, usageLongImportImages},
+		"impose":        {processImposeCommand, nil, usageImpose, usageLongImpose},
 		"info":          {processInfoCommand, nil, usageInfo, usageLongInfo},
+		"invoice":       {processInvoiceCommand, nil, usageInvoice, usageLongInvoice},
 		"keywords":      {nil, keywordsCmdMap, usageKeywords, usageLongKeywords},
+		"layers":        {nil, layersCmdMap, usageLayers, usageLongLayers},
 		"merge":         {processMergeCommand, nil, usageMerge, usageLongMerge},
+		"metadata":      {nil, metadataCmdMap, usageMetadata, usageLongMetadata},
 		"ndown":         {processNDownCommand, nil, usageNDown, usageLongNDown},
 		"nup":           {processNUpCommand, nil, usageNUp, usageLongNUp},
+		"ocr":           {processOCRCommand, nil, usageOCR, usageLongOCR},
 		"optimize":      {processOptimizeCommand, nil, usageOptimize, usageLongOptimize},
+		"outputintents": {nil, outputIntentsCmdMap, usageOutputIntents, usageLongOutputIntents},
+		"pagelabels":    {nil, pageLabelsCmdMap, usagePageLabels, usageLongPageLabels},
 		"pagelayout":    {nil, pageLayoutCmdMap, usagePageLayout, usageLongPageLayout},
 		"pagemode":      {nil, pageModeCmdMap, usagePageMode, usageLongPageMode},
 		"pages":         {nil, pagesCmdMap, usagePages, usageLongPages},
 		"paper":         {printPaperSizes, nil, usagePaper, usageLongPaper},
 		"permissions":   {nil, permissionsCmdMap, usagePerm, usageLongPerm},
+		"pipeline":      {nil, pipelineCmdMap, usagePipeline, usageLongPipeline},
 		"portfolio":     {nil, portfolioCmdMap, usagePortfolio, usageLongPortfolio},
 		"poster":        {processPosterCommand, nil, usagePoster, usageLongPoster},
 		"properties":    {nil, propertiesCmdMap, usageProperties, usageLongProperties},
+		"repair":        {processRepairCommand, nil, usageRepair, usageLongRepair},
 		"resize":        {processResizeCommand, nil, usageResize, usageLongResize},
 		"rotate":        {processRotateCommand, nil, usageRotate, usageLongRotate},
+		"sanitize":      {processSanitizeCommand, nil, usageSanitize, usageLongSanitize},
 		"selectedpages": {printSelectedPages, nil, usageSelectedPages, usageLongSelectedPages},
 		"signatures":    {nil, signaturesCmdMap, usageSignatures, usageLongSignatures},
 		"split":         {processSplitCommand, nil, usageSplit, usageLongSplit},
 		"stamp":         {nil, stampCmdMap, usageStamp, usageLongStamp},
+		"toc":           {processTOCCommand, nil, usageTOC, usageLongTOC},
+		"transitions":   {nil, transitionsCmdMap, usageTransitions, usageLongTransitions},
 		"trim":          {processTrimCommand, nil, usageTrim, usageLongTrim},
 		"validate":      {processValidateCommand, nil, usageValidate, usageLongValidate},
 		"watermark":     {nil, watermarkCmdMap, usageWatermark, usageLongWatermark},
@@ -362,9 +493,15 @@ func initFlags() {
 	flag.BoolVar(&dividerPage, "dividerPage", false, dividerPageUsage)
 	flag.BoolVar(&dividerPage, "d", false, dividerPageUsage)
 
+	visualUsage := "compare: locate changed regions"
+	flag.BoolVar(&visual, "visual", false, visualUsage)
+
 	fontsUsage := "include font info"
 	flag.BoolVar(&fonts, "fonts", false, fontsUsage)
 
+	resourcesUsage := "include per page resource report"
+	flag.BoolVar(&resources, "resources", false, resourcesUsage)
+
 	flag.BoolVar(&full, "full", false, "")
 	flag.BoolVar(&full, "f", false, "")
 
@@ -372,6 +509,12 @@ func initFlags() {
 	flag.BoolVar(&json, "json", false, jsonUsage)
 	flag.BoolVar(&json, "j", false, jsonUsage)
 
+	continueUsage := "validate: continue after errors"
+	flag.BoolVar(&continueOnError, "continue", false, continueUsage)
+	flag.BoolVar(&continueOnError, "cont", false, continueUsage)
+
+	flag.BoolVar(&sarif, "sarif", false, "validate: produce SARIF output")
+
 	keyUsage := "encrypt: 40|128|256"
 	flag.StringVar(&key, "key", "256", keyUsage)
 	flag.StringVar(&key, "k", "256", keyUsage)
@@ -380,7 +523,7 @@ func initFlags() {
 	flag.BoolVar(&links, "links", false, linksUsage)
 	flag.BoolVar(&links, "l", false, linksUsage)
 
-	modeUsage := "validate: strict|relaxed; extract: image|font|content|page|meta; encrypt: rc4|aes; stamp:text|image/pdf"
+	modeUsage := "validate: strict|relaxed; extract: image|font|content|page|meta; encrypt: rc4|aes; stamp:text|image/pdf; duplex: reverse|forward"
 	flag.StringVar(&mode, "mode", "", modeUsage)
 	flag.StringVar(&mode, "m", "", modeUsage)
 
@@ -388,6 +531,15 @@ func initFlags() {
 	flag.BoolVar(&offline, "off", false, "")
 	flag.BoolVar(&offline, "o", false, "")
 
+	dropUsage := "split, merge, optimize: remove blank|duplicates pages"
+	flag.StringVar(&dropPages, "drop", "", dropUsage)
+
+	dedupeUsage := "merge: collapse identical fonts, images and ICC profiles"
+	flag.BoolVar(&dedupe, "dedupe", false, dedupeUsage)
+
+	tagUsage := "create, toc, annotations flatten: tag generated content"
+	flag.BoolVar(&tag, "tag", false, tagUsage)
+
 	optimizeUsage := "merge: optimize before writing"
 	flag.BoolVar(&optimize, "optimize", false, optimizeUsage)
 	flag.BoolVar(&optimize, "opt", false, optimizeUsage)
@@ -413,13 +565,25 @@ func initFlags() {
 	statsUsage := "optimize: create a csv file for stats"
 	flag.StringVar(&fileStats, "stats", "", statsUsage)
 
+	subsetUsage := "optimize: subset fully embedded fonts"
+	flag.BoolVar(&subsetFonts, "subset", false, subsetUsage)
+
 	unitUsage := "info: po|in|cm|mm"
 	flag.StringVar(&unit, "unit", "", unitUsage)
 	flag.StringVar(&unit, "u", "", unitUsage)
 
+	objStreamsUsage := "object streams and xref streams: auto|pack|expand"
+	flag.StringVar(&objStreams, "objstm", "", objStreamsUsage)
+
 	flag.StringVar(&upw, "upw", "", "user password")
 	flag.StringVar(&opw, "opw", "", "owner password")
 
+	flag.StringVar(&certFile, "cert", "", "recipient certificate")
+	flag.StringVar(&keyFile, "pkey", "", "recipient private key")
+
+	recipientsUsage := "encrypt: comma separated list of recipient certificate files"
+	flag.StringVar(&recipients, "recipients", "", recipientsUsage)
+
 	flag.BoolVar(&verbose, "verbose", false, "")
 	flag.BoolVar(&verbose, "v", false, "")
 	flag.BoolVar(&veryVerbose, "vv", false, "")
@@ -27,14 +27,22 @@ import (
 var (
 	fileStats, mode, selectedPages           string
 	upw, opw, key, perm, unit, conf          string
+	certFile, keyFile, recipients            string // Public key encryption
+	objStreams                               string
 	verbose, veryVerbose                     bool
 	links, quiet, offline                    bool
-	replaceBookmarks                         bool // Import Bookmarks
-	all                                      bool // List Viewer Preferences
-	full                                     bool // eg. signature validation output
-	fonts                                    bool // Info
-	json                                     bool // List Viewer Preferences, Info
-	bookmarks, dividerPage, optimize, sorted bool // Merge
+	replaceBookmarks                         bool   // Import Bookmarks
+	all                                      bool   // List Viewer Preferences
+	full                                     bool   // eg. signature validation output
+	fonts, resources                         bool   // Info
+	json                                     bool   // List Viewer Preferences, Info, Validate
+	continueOnError, sarif                   bool   // Validate
+	visual                                   bool   // Compare
+	subsetFonts                              bool   // Optimize
+	dropPages                                string // Split, Merge, Optimize
+	bookmarks, dividerPage, optimize, sorted bool   // Merge
+	dedupe, dedupeSet                        bool   // Merge
+	tag, tagSet                              bool   // Create, TOC, Annotations flatten
 	bookmarksSet, offlineSet, optimizeSet    bool
 	needStackTrace                           = true
 	cmdMap                                   commandMap
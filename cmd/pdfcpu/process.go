@@ -33,6 +33,7 @@ import (
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/cli"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -70,6 +71,11 @@ func ensureJSONExtension(filename string) {
 	}
 }
 
+func hasYAMLExtension(filename string) bool {
+	s := strings.ToLower(filename)
+	return strings.HasSuffix(s, ".yaml") || strings.HasSuffix(s, ".yml")
+}
+
 func hasCSVExtension(filename string) bool {
 	return strings.HasSuffix(strings.ToLower(filename), ".csv")
 }
@@ -367,12 +373,73 @@ func processValidateCommand(conf *model.Configuration) {
 		conf.Optimize = optimize
 	}
 
+	format := ""
+	if json {
+		format = "json"
+	}
+	if sarif {
+		format = "sarif"
+	}
+
+	if continueOnError || format != "" {
+		if format != "" {
+			log.SetCLILogger(nil)
+		}
+		process(cli.ValidationResultCommand(inFiles, continueOnError, format, conf))
+		return
+	}
+
 	process(cli.ValidateCommand(inFiles, conf))
 }
 
-func processOptimizeCommand(conf *model.Configuration) {
+func processCompareCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageCompare)
+		os.Exit(1)
+	}
+
+	inFile1, inFile2 := flag.Arg(0), flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile1)
+		ensurePDFExtension(inFile2)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.CompareCommand(inFile1, inFile2, outFile, visual, json, conf))
+}
+
+func processRepairCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageOptimize)
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageRepair)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := inFile
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.RepairCommand(inFile, outFile, conf))
+}
+
+func processSanitizeCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageSanitize)
 		os.Exit(1)
 	}
 
@@ -387,14 +454,89 @@ func processOptimizeCommand(conf *model.Configuration) {
 		ensurePDFExtension(outFile)
 	}
 
+	process(cli.SanitizeCommand(inFile, outFile, conf))
+}
+
+func processOCRCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageOCR)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := inFile
+	ocrFiles := args[1:]
+	if len(ocrFiles) > 1 && hasPDFExtension(ocrFiles[len(ocrFiles)-1]) {
+		outFile = ocrFiles[len(ocrFiles)-1]
+		ocrFiles = ocrFiles[:len(ocrFiles)-1]
+	}
+
+	process(cli.AddOCRTextCommand(inFile, ocrFiles, outFile, conf))
+}
+
+func processOptimizeCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageOptimize)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	if !hasPDFExtension(args[0]) && len(args) > 1 {
+		imo, err := pdfcpu.ParseImageOptimization(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+		conf.ImageOptimization = imo
+		args = args[1:]
+	}
+
+	if len(args) > 2 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageOptimize)
+		os.Exit(1)
+	}
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := inFile
+	if len(args) == 2 {
+		outFile = args[1]
+		ensurePDFExtension(outFile)
+	}
+
 	conf.StatsFileName = fileStats
 	if len(fileStats) > 0 {
 		fmt.Fprintf(os.Stdout, "stats will be appended to %s\n", fileStats)
 	}
 
+	conf.SubsetFonts = subsetFonts
+
+	setPageCleanup(conf)
+
 	process(cli.OptimizeCommand(inFile, outFile, conf))
 }
 
+func setPageCleanup(conf *model.Configuration) {
+	if dropPages == "" {
+		return
+	}
+	pc, err := pdfcpu.ParsePageCleanup(dropPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+	conf.PageCleanup = pc
+}
+
 func processSplitByPageNumberCommand(inFile, outDir string, conf *model.Configuration) {
 	if len(flag.Args()) == 2 {
 		fmt.Fprintln(os.Stderr, "split: missing page numbers")
@@ -437,6 +579,8 @@ func processSplitCommand(conf *model.Configuration) {
 
 	outDir := flag.Arg(1)
 
+	setPageCleanup(conf)
+
 	if mode == "page" {
 		processSplitByPageNumberCommand(inFile, outDir, conf)
 		return
@@ -569,6 +713,16 @@ func processMergeCommand(conf *model.Configuration) {
 		conf.OptimizeBeforeWriting = optimize
 	}
 
+	if dedupeSet {
+		conf.DedupeResources = dedupe
+	}
+
+	if tagSet {
+		conf.TagContent = tag
+	}
+
+	setPageCleanup(conf)
+
 	cmd := mergeCommandVariation(inFiles, outFile, dividerPage, conf)
 	if cmd == nil {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageMerge)
@@ -593,7 +747,7 @@ func modeCompletion(modePrefix string, modes []string) string {
 }
 
 func processExtractCommand(conf *model.Configuration) {
-	mode = modeCompletion(mode, []string{"image", "font", "page", "content", "meta"})
+	mode = modeCompletion(mode, []string{"image", "lossless", "font", "page", "content", "vector", "table", "meta"})
 	if len(flag.Args()) != 2 || mode == "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageExtract)
 		os.Exit(1)
@@ -618,6 +772,9 @@ func processExtractCommand(conf *model.Configuration) {
 	case "image":
 		cmd = cli.ExtractImagesCommand(inFile, outDir, pages, conf)
 
+	case "lossless":
+		cmd = cli.ExtractImagesLosslessCommand(inFile, outDir, pages, conf)
+
 	case "font":
 		cmd = cli.ExtractFontsCommand(inFile, outDir, pages, conf)
 
@@ -627,6 +784,12 @@ func processExtractCommand(conf *model.Configuration) {
 	case "content":
 		cmd = cli.ExtractContentCommand(inFile, outDir, pages, conf)
 
+	case "vector":
+		cmd = cli.ExtractVectorPathsCommand(inFile, outDir, pages, json, conf)
+
+	case "table":
+		cmd = cli.ExtractTablesCommand(inFile, outDir, pages, json, conf)
+
 	case "meta":
 		cmd = cli.ExtractMetadataCommand(inFile, outDir, conf)
 
@@ -948,6 +1111,39 @@ func validateEncryptFlags() {
 	}
 }
 
+func setRecipients(conf *model.Configuration) error {
+	for _, fileName := range strings.Split(recipients, ",") {
+		certs, err := pdfcpu.LoadCertificates(strings.TrimSpace(fileName))
+		if err != nil {
+			return errors.Wrapf(err, "recipient certificate %s", fileName)
+		}
+		conf.Recipients = append(conf.Recipients, certs...)
+	}
+	return nil
+}
+
+func setRecipientKey(conf *model.Configuration) error {
+	if certFile == "" || keyFile == "" {
+		return errors.New("please provide -cert and -pkey")
+	}
+
+	certs, err := pdfcpu.LoadCertificates(certFile)
+	if err != nil {
+		return err
+	}
+	if len(certs) == 0 {
+		return errors.Errorf("no certificate found in %s", certFile)
+	}
+
+	key, err := pdfcpu.LoadPrivateKey(keyFile)
+	if err != nil {
+		return err
+	}
+
+	conf.RecipientCert, conf.RecipientKey = certs[0], key
+	return nil
+}
+
 func processEncryptCommand(conf *model.Configuration) {
 	if perm != "" {
 		perm = permCompletion(perm)
@@ -958,8 +1154,15 @@ func processEncryptCommand(conf *model.Configuration) {
 		os.Exit(1)
 	}
 
-	if conf.OwnerPW == "" {
-		fmt.Fprintln(os.Stderr, "missing non-empty owner password!")
+	if recipients != "" {
+		if err := setRecipients(conf); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if conf.OwnerPW == "" && len(conf.Recipients) == 0 {
+		fmt.Fprintln(os.Stderr, "missing non-empty owner password or recipients!")
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageEncrypt)
 		os.Exit(1)
 	}
@@ -1058,8 +1261,8 @@ func addWatermarks(conf *model.Configuration, onTop bool) {
 		os.Exit(1)
 	}
 
-	if mode != "text" && mode != "image" && mode != "pdf" {
-		fmt.Fprintln(os.Stderr, "mode has to be one of: text, image or pdf")
+	if mode != "text" && mode != "image" && mode != "pdf" && mode != "svg" && mode != "qrcode" && mode != "barcode" {
+		fmt.Fprintln(os.Stderr, "mode has to be one of: text, image, pdf, svg, qrcode or barcode")
 		os.Exit(1)
 	}
 
@@ -1079,6 +1282,12 @@ func addWatermarks(conf *model.Configuration, onTop bool) {
 
 	case "pdf":
 		wm, err = pdfcpu.ParsePDFWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "svg":
+		wm, err = pdfcpu.ParseSVGWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "qrcode":
+		wm, err = pdfcpu.ParseQRCodeWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "barcode":
+		wm, err = pdfcpu.ParseBarcodeWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
 	default:
 		err = errors.Errorf("unsupported wm type: %s\n", mode)
 	}
@@ -1127,7 +1336,7 @@ func updateWatermarks(conf *model.Configuration, onTop bool) {
 		os.Exit(1)
 	}
 
-	if mode != "text" && mode != "image" && mode != "pdf" {
+	if mode != "text" && mode != "image" && mode != "pdf" && mode != "svg" && mode != "qrcode" && mode != "barcode" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", u)
 		os.Exit(1)
 	}
@@ -1146,6 +1355,12 @@ func updateWatermarks(conf *model.Configuration, onTop bool) {
 		wm, err = pdfcpu.ParseImageWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
 	case "pdf":
 		wm, err = pdfcpu.ParsePDFWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "svg":
+		wm, err = pdfcpu.ParseSVGWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "qrcode":
+		wm, err = pdfcpu.ParseQRCodeWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
+	case "barcode":
+		wm, err = pdfcpu.ParseBarcodeWatermarkDetails(flag.Arg(0), flag.Arg(1), onTop, conf.Unit)
 	default:
 		err = errors.Errorf("unsupported wm type: %s\n", mode)
 	}
@@ -1212,6 +1427,15 @@ func removeWatermarks(conf *model.Configuration, onTop bool) {
 		ensurePDFExtension(outFile)
 	}
 
+	if mode != "" {
+		if modeCompletion(mode, []string{"detect"}) == "" {
+			fmt.Fprintln(os.Stderr, "mode has to be: detect")
+			os.Exit(1)
+		}
+		process(cli.RemoveDetectedWatermarksCommand(inFile, outFile, selectedPages, conf))
+		return
+	}
+
 	process(cli.RemoveWatermarksCommand(inFile, outFile, selectedPages, conf))
 }
 
@@ -1373,9 +1597,9 @@ func processRemovePagesCommand(conf *model.Configuration) {
 	process(cli.RemovePagesCommand(inFile, outFile, pages, conf))
 }
 
-func processRotateCommand(conf *model.Configuration) {
-	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageRotate)
+func processAnalyzePagesCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePagesAnalyze)
 		os.Exit(1)
 	}
 
@@ -1384,12 +1608,24 @@ func processRotateCommand(conf *model.Configuration) {
 		ensurePDFExtension(inFile)
 	}
 
-	rotation, err := strconv.Atoi(flag.Arg(1))
-	if err != nil || abs(rotation)%90 > 0 {
-		fmt.Fprintf(os.Stderr, "rotation must be a multiple of 90: %s\n", flag.Arg(1))
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.AnalyzePagesCommand(inFile, json, conf))
+}
+
+func processRotateCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageRotate)
 		os.Exit(1)
 	}
 
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
 	outFile := ""
 	if len(flag.Args()) == 3 {
 		outFile = flag.Arg(2)
@@ -1402,6 +1638,17 @@ func processRotateCommand(conf *model.Configuration) {
 		os.Exit(1)
 	}
 
+	if strings.ToLower(flag.Arg(1)) == "auto" {
+		process(cli.AutoRotateCommand(inFile, outFile, selectedPages, conf))
+		return
+	}
+
+	rotation, err := strconv.Atoi(flag.Arg(1))
+	if err != nil || abs(rotation)%90 > 0 {
+		fmt.Fprintf(os.Stderr, "rotation must be a multiple of 90: %s\n", flag.Arg(1))
+		os.Exit(1)
+	}
+
 	process(cli.RotateCommand(inFile, outFile, rotation, selectedPages, conf))
 }
 
@@ -1652,7 +1899,7 @@ func processInfoCommand(conf *model.Configuration) {
 		log.SetCLILogger(nil)
 	}
 
-	process(cli.InfoCommand(inFiles, selectedPages, fonts, json, conf))
+	process(cli.InfoCommand(inFiles, selectedPages, fonts, resources, json, conf))
 }
 
 func processListFontsCommand(conf *model.Configuration) {
@@ -1666,7 +1913,8 @@ func processInstallFontsCommand(conf *model.Configuration) {
 		os.Exit(1)
 	}
 	for _, arg := range flag.Args() {
-		if !types.MemberOf(filepath.Ext(arg), []string{".ttf", ".ttc"}) {
+		fn, _ := font.SplitFontFileName(arg)
+		if !types.MemberOf(filepath.Ext(fn), []string{".ttf", ".ttc"}) {
 			continue
 		}
 		fileNames = append(fileNames, arg)
@@ -1971,7 +2219,17 @@ func processCropCommand(conf *model.Configuration) {
 
 	processDisplayUnit(conf)
 
-	box, err := api.Box(flag.Arg(0), conf.Unit)
+	var (
+		box *model.Box
+		ac  *model.AutoCrop
+		err error
+	)
+
+	if model.IsAutoCrop(flag.Arg(0)) {
+		ac, err = model.ParseAutoCrop(flag.Arg(0), conf.Unit)
+	} else {
+		box, err = api.Box(flag.Arg(0), conf.Unit)
+	}
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "problem parsing box definition: %v\n", err)
 		os.Exit(1)
@@ -1994,6 +2252,11 @@ func processCropCommand(conf *model.Configuration) {
 		os.Exit(1)
 	}
 
+	if ac != nil {
+		process(cli.AutoCropCommand(inFile, outFile, selectedPages, ac, conf))
+		return
+	}
+
 	process(cli.CropCommand(inFile, outFile, selectedPages, box, conf))
 }
 
@@ -2063,43 +2326,55 @@ func processRemoveAnnotationsCommand(conf *model.Configuration) {
 	process(cli.RemoveAnnotationsCommand(inFile, outFile, selectedPages, idsAndTypes, objNrs, conf))
 }
 
-func processListImagesCommand(conf *model.Configuration) {
+func processFlattenAnnotationsCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 {
-		fmt.Fprintf(os.Stderr, "usage: %s\n", usageImagesList)
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsFlatten)
 		os.Exit(1)
 	}
 
-	inFiles := []string{}
-	for _, arg := range flag.Args() {
-		if strings.Contains(arg, "*") {
-			matches, err := filepath.Glob(arg)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "%s", err)
-				os.Exit(1)
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	inFile, outFile := "", ""
+
+	var (
+		idsAndTypes []string
+		objNrs      []int
+	)
+
+	for i, arg := range flag.Args() {
+		if i == 0 {
+			inFile = arg
+			if conf.CheckFileNameExt {
+				ensurePDFExtension(inFile)
 			}
-			// TODO check extension
-			inFiles = append(inFiles, matches...)
 			continue
 		}
-		if conf.CheckFileNameExt {
-			ensurePDFExtension(arg)
+		if i == 1 {
+			if hasPDFExtension(arg) {
+				outFile = arg
+				continue
+			}
 		}
-		inFiles = append(inFiles, arg)
-	}
 
-	selectedPages, err := api.ParsePageSelection(selectedPages)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+		j, err := strconv.Atoi(arg)
+		if err != nil {
+			// strings args may be and id or annotType
+			idsAndTypes = append(idsAndTypes, arg)
+			continue
+		}
+		objNrs = append(objNrs, j)
 	}
 
-	process(cli.ListImagesCommand(inFiles, selectedPages, conf))
+	process(cli.FlattenAnnotationsCommand(inFile, outFile, selectedPages, idsAndTypes, objNrs, conf))
 }
 
-func processExtractImagesCommand(conf *model.Configuration) {
-	// See also processExtractCommand
-	if len(flag.Args()) != 2 {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesExtract)
+func processAddAnnotationsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsAdd)
 		os.Exit(1)
 	}
 
@@ -2107,21 +2382,22 @@ func processExtractImagesCommand(conf *model.Configuration) {
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
-	outDir := flag.Arg(1)
 
-	pages, err := api.ParsePageSelection(selectedPages)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
-		os.Exit(1)
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
 	}
 
-	process(cli.ExtractImagesCommand(inFile, outDir, pages, conf))
+	process(cli.AddAnnotationsCommand(inFile, inFileJSON, outFile, conf))
 }
 
-func processUpdateImagesCommand(conf *model.Configuration) {
-	argCount := len(flag.Args())
-	if argCount < 2 || argCount > 5 {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesUpdate)
+func processExportAnnotationsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsExport)
 		os.Exit(1)
 	}
 
@@ -2130,10 +2406,141 @@ func processUpdateImagesCommand(conf *model.Configuration) {
 		ensurePDFExtension(inFile)
 	}
 
-	imageFile := flag.Arg(1)
-	ensureImageExtension(imageFile)
+	outFileJSON := strings.TrimSuffix(inFile, filepath.Ext(inFile)) + "_annotations.json"
+	if len(flag.Args()) == 2 {
+		outFileJSON = flag.Arg(1)
+	}
+	ensureJSONExtension(outFileJSON)
 
-	outFile := ""
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ExportAnnotationsCommand(inFile, outFileJSON, selectedPages, conf))
+}
+
+func processImportAnnotationsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsImport)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ImportAnnotationsCommand(inFile, inFileJSON, outFile, conf))
+}
+
+func processAddAutoLinksCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageAnnotsAutoLink)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.AddAutoLinksCommand(inFile, outFile, selectedPages, conf))
+}
+
+func processListImagesCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageImagesList)
+		os.Exit(1)
+	}
+
+	inFiles := []string{}
+	for _, arg := range flag.Args() {
+		if strings.Contains(arg, "*") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s", err)
+				os.Exit(1)
+			}
+			// TODO check extension
+			inFiles = append(inFiles, matches...)
+			continue
+		}
+		if conf.CheckFileNameExt {
+			ensurePDFExtension(arg)
+		}
+		inFiles = append(inFiles, arg)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ListImagesCommand(inFiles, selectedPages, conf))
+}
+
+func processExtractImagesCommand(conf *model.Configuration) {
+	// See also processExtractCommand
+	if len(flag.Args()) != 2 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesExtract)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	outDir := flag.Arg(1)
+
+	pages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ExtractImagesCommand(inFile, outDir, pages, conf))
+}
+
+func processUpdateImagesCommand(conf *model.Configuration) {
+	argCount := len(flag.Args())
+	if argCount < 2 || argCount > 5 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageImagesUpdate)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	imageFile := flag.Arg(1)
+	ensureImageExtension(imageFile)
+
+	outFile := ""
 	objNrOrPageNr := 0
 	id := ""
 
@@ -2496,6 +2903,40 @@ func processResizeCommand(conf *model.Configuration) {
 	process(cli.ResizeCommand(inFile, outFile, selectedPages, rc, conf))
 }
 
+func processBleedCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n", usageBleed)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	bc, err := pdfcpu.ParseBleedConfig(flag.Arg(0), conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.BleedCommand(inFile, outFile, selectedPages, bc, conf))
+}
+
 func processPosterCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
 		fmt.Fprintf(os.Stderr, "%s\n", usagePoster)
@@ -2685,7 +3126,9 @@ func processImportBookmarksCommand(conf *model.Configuration) {
 	}
 
 	inFileJSON := flag.Arg(1)
-	ensureJSONExtension(inFileJSON)
+	if !hasYAMLExtension(inFileJSON) {
+		ensureJSONExtension(inFileJSON)
+	}
 
 	outFile := ""
 	if len(flag.Args()) == 3 {
@@ -2696,6 +3139,26 @@ func processImportBookmarksCommand(conf *model.Configuration) {
 	process(cli.ImportBookmarksCommand(inFile, inFileJSON, outFile, replaceBookmarks, conf))
 }
 
+func processDetectBookmarksCommand(conf *model.Configuration) {
+	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksDetect)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.DetectBookmarksCommand(inFile, outFile, replaceBookmarks, conf))
+}
+
 func processRemoveBookmarksCommand(conf *model.Configuration) {
 	if len(flag.Args()) == 0 || len(flag.Args()) > 2 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageBookmarksExport)
@@ -2810,9 +3273,9 @@ func processResetPageModeCommand(conf *model.Configuration) {
 	process(cli.ResetPageModeCommand(inFile, "", conf))
 }
 
-func processListViewerPreferencesCommand(conf *model.Configuration) {
+func processListPageLabelsCommand(conf *model.Configuration) {
 	if len(flag.Args()) != 1 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesList)
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLabelsList)
 		os.Exit(1)
 	}
 
@@ -2820,17 +3283,12 @@ func processListViewerPreferencesCommand(conf *model.Configuration) {
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
-
-	if json {
-		log.SetCLILogger(nil)
-	}
-
-	process(cli.ListViewerPreferencesCommand(inFile, all, json, conf))
+	process(cli.ListPageLabelsCommand(inFile, conf))
 }
 
-func processSetViewerPreferencesCommand(conf *model.Configuration) {
-	if len(flag.Args()) != 2 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesSet)
+func processSetPageLabelsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLabelsSet)
 		os.Exit(1)
 	}
 
@@ -2839,21 +3297,25 @@ func processSetViewerPreferencesCommand(conf *model.Configuration) {
 		ensurePDFExtension(inFile)
 	}
 
-	inFileJSON, stringJSON := "", ""
+	v := flag.Arg(1)
 
-	s := flag.Arg(1)
-	if hasJSONExtension(s) {
-		inFileJSON = s
-	} else {
-		stringJSON = s
+	if _, err := pdfcpu.ParsePageLabels(v); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
 	}
 
-	process(cli.SetViewerPreferencesCommand(inFile, inFileJSON, "", stringJSON, conf))
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.SetPageLabelsCommand(inFile, outFile, v, conf))
 }
 
-func processResetViewerPreferencesCommand(conf *model.Configuration) {
-	if len(flag.Args()) != 1 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesReset)
+func processRemovePageLabelsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usagePageLabelsRemove)
 		os.Exit(1)
 	}
 
@@ -2861,32 +3323,52 @@ func processResetViewerPreferencesCommand(conf *model.Configuration) {
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
-	process(cli.ResetViewerPreferencesCommand(inFile, "", conf))
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.RemovePageLabelsCommand(inFile, outFile, conf))
 }
 
-func processZoomCommand(conf *model.Configuration) {
-	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
-		fmt.Fprintf(os.Stderr, "%s\n", usageZoom)
+func processListPageTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsList)
 		os.Exit(1)
 	}
 
-	processDisplayUnit(conf)
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
 
-	zc, err := pdfcpu.ParseZoomConfig(flag.Arg(0), conf.Unit)
+	selectedPages, err := api.ParsePageSelection(selectedPages)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "%v\n", err)
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
 		os.Exit(1)
 	}
 
-	inFile := flag.Arg(1)
+	process(cli.ListPageTransitionsCommand(inFile, selectedPages, conf))
+}
+
+func processSetPageTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsSet)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
 	if conf.CheckFileNameExt {
 		ensurePDFExtension(inFile)
 	}
 
-	outFile := ""
-	if len(flag.Args()) == 3 {
-		outFile = flag.Arg(2)
-		ensurePDFExtension(outFile)
+	v := flag.Arg(1)
+
+	if _, err := pdfcpu.ParsePageTransition(v); err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
 	}
 
 	selectedPages, err := api.ParsePageSelection(selectedPages)
@@ -2895,21 +3377,161 @@ func processZoomCommand(conf *model.Configuration) {
 		os.Exit(1)
 	}
 
-	process(cli.ZoomCommand(inFile, outFile, selectedPages, zc, conf))
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.SetPageTransitionsCommand(inFile, outFile, selectedPages, v, conf))
 }
 
-func processListCertificatesCommand(conf *model.Configuration) {
-	if len(flag.Args()) > 1 || selectedPages != "" {
-		fmt.Fprintf(os.Stderr, "%s\n\n", usageCertificatesList)
+func processRemovePageTransitionsCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsRemove)
 		os.Exit(1)
 	}
-	if json {
-		log.SetCLILogger(nil)
-	}
-	process(cli.ListCertificatesCommand(json, conf))
-}
 
-func processInspectCertificatesCommand(conf *model.Configuration) {
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.RemovePageTransitionsCommand(inFile, outFile, selectedPages, conf))
+}
+
+func processSetFullScreenCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageTransitionsFullScreen)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.SetFullScreenCommand(inFile, outFile, conf))
+}
+
+func processListViewerPreferencesCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesList)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	if json {
+		log.SetCLILogger(nil)
+	}
+
+	process(cli.ListViewerPreferencesCommand(inFile, all, json, conf))
+}
+
+func processSetViewerPreferencesCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesSet)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON, stringJSON := "", ""
+
+	s := flag.Arg(1)
+	if hasJSONExtension(s) {
+		inFileJSON = s
+	} else {
+		stringJSON = s
+	}
+
+	process(cli.SetViewerPreferencesCommand(inFile, inFileJSON, "", stringJSON, conf))
+}
+
+func processResetViewerPreferencesCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageViewerPreferencesReset)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ResetViewerPreferencesCommand(inFile, "", conf))
+}
+
+func processZoomCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n", usageZoom)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	zc, err := pdfcpu.ParseZoomConfig(flag.Arg(0), conf.Unit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ZoomCommand(inFile, outFile, selectedPages, zc, conf))
+}
+
+func processListCertificatesCommand(conf *model.Configuration) {
+	if len(flag.Args()) > 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageCertificatesList)
+		os.Exit(1)
+	}
+	if json {
+		log.SetCLILogger(nil)
+	}
+	process(cli.ListCertificatesCommand(json, conf))
+}
+
+func processInspectCertificatesCommand(conf *model.Configuration) {
 	if len(flag.Args()) < 1 || selectedPages != "" {
 		fmt.Fprintf(os.Stderr, "%s\n\n", usageCertificatesInspect)
 		os.Exit(1)
@@ -2996,3 +3618,536 @@ func processValidateSignaturesCommand(conf *model.Configuration) {
 
 	process(cli.ValidateSignaturesCommand(inFile, all, full, conf))
 }
+
+func processImposeCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 3 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageImpose)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileJSON := flag.Arg(1)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := flag.Arg(2)
+	ensurePDFExtension(outFile)
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ImposeCommand(inFile, inFileJSON, outFile, selectedPages, conf))
+}
+
+func processManualDuplexCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageDuplex)
+		os.Exit(1)
+	}
+
+	reverseBacks := true
+	switch mode {
+	case "", "reverse":
+	case "forward":
+		reverseBacks = false
+	default:
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageDuplex)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := flag.Arg(1)
+	ensurePDFExtension(outFile)
+
+	outFileBacks := ""
+	if len(flag.Args()) == 3 {
+		outFileBacks = flag.Arg(2)
+		ensurePDFExtension(outFileBacks)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.ManualDuplexCommand(inFile, outFile, outFileBacks, selectedPages, reverseBacks, conf))
+}
+
+func processCreateCoverCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageCover)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	cover, err := pdfcpu.ParseCoverConfig(flag.Arg(0), conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	outFile := flag.Arg(1)
+	ensurePDFExtension(outFile)
+
+	process(cli.CreateCoverCommand(outFile, cover, conf))
+}
+
+func processBatesCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 4 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageBates)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	b, err := pdfcpu.ParseBatesConfig(flag.Arg(0), flag.Arg(1), conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	outDir := flag.Arg(2)
+
+	inFiles := []string{}
+	for _, arg := range flag.Args()[3:] {
+		if strings.Contains(arg, "*") {
+			matches, err := filepath.Glob(arg)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s", err)
+				os.Exit(1)
+			}
+			inFiles = append(inFiles, matches...)
+			continue
+		}
+		if conf.CheckFileNameExt {
+			ensurePDFExtension(arg)
+		}
+		inFiles = append(inFiles, arg)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	manifestFile := filepath.Join(outDir, "bates.csv")
+
+	process(cli.AddBatesNumbersCommand(inFiles, outDir, manifestFile, selectedPages, b, conf))
+}
+
+func processHeaderFooterCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 3 || len(flag.Args()) > 4 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageHeaderFooter)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	hf, err := pdfcpu.ParseHeaderFooterConfig(flag.Arg(0), flag.Arg(1), conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(2)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 4 {
+		outFile = flag.Arg(3)
+		ensurePDFExtension(outFile)
+	}
+
+	selectedPages, err := api.ParsePageSelection(selectedPages)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "problem with flag selectedPages: %v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.AddHeadersAndFootersCommand(inFile, outFile, selectedPages, hf, conf))
+}
+
+func processTOCCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageTOC)
+		os.Exit(1)
+	}
+
+	processDisplayUnit(conf)
+
+	args := flag.Args()
+	description := ""
+	if len(args) == 3 || (len(args) == 2 && !hasPDFExtension(args[0])) {
+		description, args = args[0], args[1:]
+	}
+
+	toc, err := pdfcpu.ParseTOCConfig(description, conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(args) == 2 {
+		outFile = args[1]
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddTOCCommand(inFile, outFile, toc, conf))
+}
+
+func processCreatePortfolioCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePortfolioCreate)
+		os.Exit(1)
+	}
+
+	inFileJSON := flag.Arg(0)
+	ensureJSONExtension(inFileJSON)
+
+	outFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.CreatePortfolioCommand(inFileJSON, outFile, conf))
+}
+
+func processRunPipelineCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usagePipelineRun)
+		os.Exit(1)
+	}
+
+	jobFile := flag.Arg(0)
+	if !hasJSONExtension(jobFile) && !hasYAMLExtension(jobFile) {
+		fmt.Fprintf(os.Stderr, "%s needs extension \".json\", \".yaml\" or \".yml\".\n", jobFile)
+		os.Exit(1)
+	}
+
+	process(cli.RunPipelineCommand(jobFile, conf))
+}
+
+func processInvoiceCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 4 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageInvoice)
+		os.Exit(1)
+	}
+
+	args := flag.Args()
+	description := ""
+	if !hasPDFExtension(args[0]) {
+		description, args = args[0], args[1:]
+	}
+
+	if len(args) < 2 {
+		fmt.Fprintf(os.Stderr, "%s\n\n", usageInvoice)
+		os.Exit(1)
+	}
+
+	inv, err := pdfcpu.ParseInvoiceConfig(description, conf)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := args[0]
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	inFileXML := args[1]
+
+	outFile := ""
+	if len(args) == 3 {
+		outFile = args[2]
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.AddInvoiceCommand(inFile, inFileXML, outFile, inv, conf))
+}
+
+func processListMetadataCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageMetadataList)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListMetadataCommand(inFile, conf))
+}
+
+func processSetMetadataCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageMetadataSet)
+		os.Exit(1)
+	}
+
+	var inFile string
+	properties := map[string]string{}
+
+	for i, arg := range flag.Args() {
+		if i == 0 {
+			inFile = arg
+			if conf.CheckFileNameExt {
+				ensurePDFExtension(inFile)
+			}
+			continue
+		}
+		// Ensure key value pair.
+		ss := strings.SplitN(arg, "=", 2)
+		if len(ss) != 2 {
+			fmt.Fprintf(os.Stderr, "nameValuePair = 'name = value'\n")
+			fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageMetadataSet)
+			os.Exit(1)
+		}
+		properties[strings.TrimSpace(ss[0])] = strings.TrimSpace(ss[1])
+	}
+
+	x, err := pdfcpu.ParseXMPProperties(properties)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	process(cli.SetMetadataCommand(inFile, "", x, conf))
+}
+
+func processCheckMetadataCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageMetadataCheck)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.CheckMetadataCommand(inFile, conf))
+}
+
+func processSyncMetadataCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageMetadataSync)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	preferXMP := false
+	if len(flag.Args()) == 2 {
+		switch strings.ToLower(flag.Arg(1)) {
+		case "info":
+		case "xmp":
+			preferXMP = true
+		default:
+			fmt.Fprintf(os.Stderr, "usage: %s\n", usageMetadataSync)
+			os.Exit(1)
+		}
+	}
+
+	process(cli.SyncMetadataCommand(inFile, "", preferXMP, conf))
+}
+
+func processConvertToCMYKCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageConvertCMYK)
+		os.Exit(1)
+	}
+
+	cc, err := pdfcpu.ParseCMYKConversion(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ConvertToCMYKCommand(inFile, outFile, cc, conf))
+}
+
+func processConvertToGrayCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageConvertGray)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.ConvertToGrayCommand(inFile, outFile, conf))
+}
+
+func processFlattenTransparencyCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageFlattenTransparency)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.FlattenTransparencyCommand(inFile, outFile, conf))
+}
+
+func processListLayersCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageLayersList)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListLayersCommand(inFile, conf))
+}
+
+func parseLayerArgs(usage string, conf *model.Configuration) (string, []string) {
+	if len(flag.Args()) < 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	return inFile, flag.Args()[1:]
+}
+
+func processShowLayersCommand(conf *model.Configuration) {
+	inFile, names := parseLayerArgs(usageLayersShow, conf)
+	process(cli.ShowLayersCommand(inFile, "", names, conf))
+}
+
+func processHideLayersCommand(conf *model.Configuration) {
+	inFile, names := parseLayerArgs(usageLayersHide, conf)
+	process(cli.HideLayersCommand(inFile, "", names, conf))
+}
+
+func processRemoveLayersCommand(conf *model.Configuration) {
+	inFile, names := parseLayerArgs(usageLayersRemove, conf)
+	process(cli.RemoveLayersCommand(inFile, "", names, conf))
+}
+
+func processFlattenLayersCommand(conf *model.Configuration) {
+	if len(flag.Args()) < 1 || len(flag.Args()) > 2 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usageLayersFlatten)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 2 {
+		outFile = flag.Arg(1)
+		ensurePDFExtension(outFile)
+	}
+
+	process(cli.FlattenLayersCommand(inFile, outFile, conf))
+}
+
+func processListOutputIntentsCommand(conf *model.Configuration) {
+	if len(flag.Args()) != 1 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n", usageOutputIntentsList)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(0)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+	process(cli.ListOutputIntentsCommand(inFile, conf))
+}
+
+func parseOutputIntentArgs(usage string, conf *model.Configuration) (*model.OutputIntent, string, string) {
+	if len(flag.Args()) < 2 || len(flag.Args()) > 3 || selectedPages != "" {
+		fmt.Fprintf(os.Stderr, "usage: %s\n\n", usage)
+		os.Exit(1)
+	}
+
+	oi, err := pdfcpu.ParseOutputIntent(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	inFile := flag.Arg(1)
+	if conf.CheckFileNameExt {
+		ensurePDFExtension(inFile)
+	}
+
+	outFile := ""
+	if len(flag.Args()) == 3 {
+		outFile = flag.Arg(2)
+		ensurePDFExtension(outFile)
+	}
+
+	return oi, inFile, outFile
+}
+
+func processAddOutputIntentCommand(conf *model.Configuration) {
+	oi, inFile, outFile := parseOutputIntentArgs(usageOutputIntentsAdd, conf)
+	process(cli.AddOutputIntentCommand(inFile, outFile, oi, conf))
+}
+
+func processReplaceOutputIntentCommand(conf *model.Configuration) {
+	oi, inFile, outFile := parseOutputIntentArgs(usageOutputIntentsReplace, conf)
+	process(cli.ReplaceOutputIntentCommand(inFile, outFile, oi, conf))
+}
@@ -27,45 +27,65 @@ The commands are:
 
    annotations   list, remove page annotations
    attachments   list, add, remove, extract embedded file attachments
+   bates         stamp consecutive Bates numbers across files
+   bleed         add bleed to selected pages by mirroring or smearing page edges
    booklet       arrange pages onto larger sheets of paper to make a booklet or zine
-   bookmarks     list, import, export, remove bookmarks
+   bookmarks     list, import, detect, export, remove bookmarks
    boxes         list, add, remove page boundaries for selected pages
    certificates  list, inspect, import, reset certificates
    changeopw     change owner password
    changeupw     change user password
    collect       create custom sequence of selected pages
+   compare       compare PDF files structurally and visually
    config        list, reset configuration
+   convert       convert colors to CMYK using ICC profiles or to gray
+   cover         create the cover spread of a perfect bound book
    create        create PDF content including forms via JSON
    crop          set cropbox for selected pages
    cut           custom cut pages horizontally or vertically
    decrypt       remove password protection
+   duplex        reorder pages for double sided printing without a duplexer
    encrypt       set password protection		
    extract       extract images, fonts, content, pages or metadata
+   flatten       flatten transparency
    fonts         install, list supported fonts, create cheat sheets
    form          list, remove fields, lock, unlock, reset, export, fill form via JSON or CSV
    grid          rearrange pages or images for enhanced browsing experience
+   headerfooter  add headers and footers with page numbers, filename, date or bookmark
    images        list, extract, update images
    import        import/convert images to PDF
+   impose        arrange pages onto sheets using a JSON imposition template
    info          print file info
+   invoice       embed XML invoice producing a Factur-X / ZUGFeRD hybrid invoice
    keywords      list, add, remove keywords
+   layers        list, show, hide, remove, flatten layers
    merge         concatenate PDFs
+   metadata      list, set XMP metadata, check, sync with document properties
    ndown         cut selected pages into n pages symmetrically
    nup           rearrange pages or images for reduced number of pages
+   ocr           make scanned pages searchable using OCR results
    optimize      optimize PDF by getting rid of redundant page resources
+   outputintents list, add, replace output intents
+   pagelabels    list, set, remove page labels
    pagelayout    list, set, reset page layout for opened document
    pagemode      list, set, reset page mode for opened document
    pages         insert, remove selected pages
    paper         print list of supported paper sizes
    permissions   list, set user access permissions
-   portfolio     list, add, remove, extract portfolio entries with optional description
+   pipeline      run a sequence of operations described by a JSON or YAML job file
+   portfolio     list, add, remove, extract portfolio entries with optional description, create portfolio
    poster        cut selected pages into poster by paper size or dimensions
    properties    list, add, remove document properties
+   repair        rebuild damaged PDF files
    resize        scale selected pages
    rotate        rotate selected pages
+   sanitize      remove risky and private content for external release
    selectedpages print definition of the -pages flag
    signatures    validate signatures
    split         split up a PDF by span or bookmark
    stamp         add, remove, update Unicode text, image or PDF stamps for selected pages
+   toc           insert table of contents pages with links generated from bookmarks
+   transitions   list, set, remove page transitions, set full screen mode for presentations
    trim          create trimmed version of selected pages
    validate      validate PDF against PDF 32000-1:2008 (PDF 1.7) + basic PDF 2.0 validation
    version       print version
@@ -85,20 +105,26 @@ common flags: -v(erbose)  ... turn on logging
               -q(uiet)    ... disable output
               -o(ffline)  ... disable http traffic
               -c(onf)     ... set or disable config dir: $path|disable
+              -objstm     ... object streams & xref streams: auto|pack|expand
               -opw        ... owner password
               -upw        ... user password
+              -cert       ... recipient certificate
+              -pkey       ... recipient private key
               -u(nit)     ... display unit: po(ints) ... points
                                             in(ches) ... inches
                                                   cm ... centimetres
                                                   mm ... millimetres`
 
-	usageValidate = "usage: pdfcpu validate [-m(ode) strict|relaxed] [-l(inks) -opt(imize)] -- inFile..." + generalFlags
+	usageValidate = "usage: pdfcpu validate [-m(ode) strict|relaxed] [-l(inks) -opt(imize)] [-cont(inue)] [-j(son) | -sarif] -- inFile..." + generalFlags
 
 	usageLongValidate = `Check inFile for specification compliance.
 
       mode ... validation mode
      links ... check for broken links
   optimize ... optimize resources (fonts, forms, images)
+  continue ... report all findings instead of stopping at the first error
+      json ... output findings as JSON
+     sarif ... output findings as SARIF 2.1.0
     inFile ... input PDF file
 		
 The validation modes are:
@@ -108,18 +134,61 @@ The validation modes are:
 Validation turns off optimization unless in verbose mode.
 You can enforce optimization using -opt=true.`
 
-	usageOptimize     = "usage: pdfcpu optimize [-stats csvFile] -- inFile [outFile]" + generalFlags
+	usageOptimize     = "usage: pdfcpu optimize [-stats csvFile] [-subset] [-drop blank,duplicates] -- [description] inFile [outFile]" + generalFlags
 	usageLongOptimize = `Read inFile, remove redundant page resources like embedded fonts and images and write the result to outFile.
 
-     stats ... appends a stats line to a csv file with information about the usage of root and page entries.
-               useful for batch optimization and debugging PDFs.
-    inFile ... input PDF file
-   outFile ... output PDF file`
+      stats ... appends a stats line to a csv file with information about the usage of root and page entries.
+                useful for batch optimization and debugging PDFs.
+     subset ... replace fully embedded TrueType and CID-keyed CFF fonts by subsets containing the glyphs in use.
+       drop ... remove blank and/or duplicate pages, see "pdfcpu pages analyze"
+description ... re-encode images: dpi, quality, dct, gray, mrc
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  Images are only re-encoded if a description is given.
+  <description> is a comma separated configuration string containing these optional entries:
+
+      (defaults: "dpi:150, quality:75, dct:off, gray:off, mrc:off")
+
+      dpi:       downsample images exceeding this resolution based on their placement size, off
+      quality:   JPEG quality 1..100 used for recompressing JPEG images
+      dct:       convert lossless images exceeding this size to JPEG, eg. 500KB, 1MB, off
+      gray:      convert RGB, CMYK and ICC based color images to DeviceGray, on/off true/false
+      mrc:       split scanned pages into a CCITT text mask and low resolution JPEG layers, on/off true/false
+
+  Placement sizes are taken from page content including form XObjects.
+  Black and white images (scans) are CCITT Group 4 encoded and never downsampled.
+  CMYK JPEGs and images using a decode array are left alone.
+  JPEG 2000 images are recompressed as JPEG, CMYK ones losslessly.
+  Images which would not shrink in size are left alone unless converted to gray.
+
+Examples:
+
+   pdfcpu optimize -- "dpi:150" in.pdf out.pdf
+      Downsample images placed at more than 150 DPI and recompress JPEG images at quality 75.
 
-	usageSplit     = "usage: pdfcpu split [-m(ode) span|bookmark|page] -- inFile outDir [span|pageNr...]" + generalFlags
+   pdfcpu optimize -- "dpi:200, quality:60, dct:500KB" in.pdf
+      Also convert lossless images larger than 500 KB to JPEG.
+
+   pdfcpu optimize -- "dpi:off, gray:on" in.pdf out.pdf
+      Convert color images to grayscale without downsampling.
+
+   pdfcpu optimize -- "mrc:on" scan.pdf out.pdf
+      Compress pages consisting of a single scanned gray or RGB image using mixed raster content.
+
+   pdfcpu optimize -subset in.pdf out.pdf
+      Subset fully embedded fonts.
+      Fonts used for filling in form fields are left alone.
+
+   pdfcpu optimize -drop blank,dup scan.pdf out.pdf
+      Remove blank and duplicate pages.
+`
+
+	usageSplit     = "usage: pdfcpu split [-m(ode) span|bookmark|page] [-drop blank,duplicates] -- inFile outDir [span|pageNr...]" + generalFlags
 	usageLongSplit = `Generate a set of PDFs for the input file in outDir according to given span value or along bookmarks or page numbers.
 
       mode ... split mode (defaults to span)
+      drop ... remove blank and/or duplicate pages before splitting, see "pdfcpu pages analyze"
     inFile ... input PDF file
     outDir ... output directory
       span ... split span in pages (default: 1) for mode "span"
@@ -160,14 +229,16 @@ Eg. pdfcpu split test.pdf .      (= pdfcpu split -m span test.pdf . 1)
          test_4-9.pdf
          test_10-20.pdf`
 
-	usageMerge     = "usage: pdfcpu merge [-m(ode) create|append|zip] [ -s(ort) -b(ookmarks) -d(ivider) -opt(imize)] -- outFile inFile..." + generalFlags
+	usageMerge     = "usage: pdfcpu merge [-m(ode) create|append|zip] [ -s(ort) -b(ookmarks) -d(ivider) -dedupe -opt(imize) -drop blank,duplicates] -- outFile inFile..." + generalFlags
 	usageLongMerge = `Concatenate a sequence of PDFs/inFiles into outFile.
 
       mode ... merge mode (defaults to create)
       sort ... sort inFiles by file name
  bookmarks ... create bookmarks
    divider ... insert blank page between merged documents
+    dedupe ... collapse identical fonts, images and ICC profiles (default: true)
   optimize ... optimize before writing (default: true)
+      drop ... remove blank and/or duplicate pages of the merged result, see "pdfcpu pages analyze"
    outFile ... output PDF file
     inFile ... a list of PDF files subject to concatenation.
     
@@ -182,6 +253,8 @@ The merge modes are:
                
 Skip bookmark creation: -b(ookmarks)=false
 
+Skip resource deduplication: -dedupe=false
+
 Skip optimization before writing: -opt(imize)=false`
 
 	usagePageSelection = `'-pages' selects pages for processing and is a comma separated list of expressions:
@@ -204,21 +277,27 @@ Skip optimization before writing: -opt(imize)=false`
 
         e.g. -3,5,7- or 4-7,!6 or 1-,!5 or odd,n1`
 
-	usageExtract     = "usage: pdfcpu extract -m(ode) i(mage)|f(ont)|c(ontent)|p(age)|m(eta) [-p(ages) selectedPages] -- inFile outDir" + generalFlags
-	usageLongExtract = `Export inFile's images, fonts, content or pages into outDir.
+	usageExtract     = "usage: pdfcpu extract -m(ode) i(mage)|l(ossless)|f(ont)|c(ontent)|v(ector)|t(able)|p(age)|m(eta) [-p(ages) selectedPages] [-j(son)] -- inFile outDir" + generalFlags
+	usageLongExtract = `Export inFile's images, fonts, content, vector paths, tables or pages into outDir.
 
       mode ... extraction mode
      pages ... Please refer to "pdfcpu selectedpages"
+      json ... write vector paths as JSON instead of SVG, tables as JSON instead of CSV
     inFile ... input PDF file
     outDir ... output directory
 
  The extraction modes are:
 
-  image ... extract images
-   font ... extract font files (supported font types: TrueType)
-content ... extract raw page content
-   page ... extract single page PDFs
-   meta ... extract all metadata (page selection does not apply)
+   image ... extract images (images with soft masks are written as PNG with alpha channel)
+lossless ... extract images without recompression (DCT as jpg, JPX as jp2) and a JSON manifest per image
+             listing page, object number, placements, effective resolution, colorspace and filters
+    font ... extract font files (ttf, otf, pfb) and a manifest of pages using them
+ content ... extract raw page content
+  vector ... extract page vector paths with transformations, colors and line styles resolved
+   table ... extract tables detected by ruling lines or white space between columns as CSV,
+             or as JSON including cell coordinates
+    page ... extract single page PDFs
+    meta ... extract all metadata (page selection does not apply)
    
 `
 
@@ -254,24 +333,56 @@ content ... extract raw page content
 	usagePortfolioAdd     = "pdfcpu portfolio add     inFile file[,desc]..."
 	usagePortfolioRemove  = "pdfcpu portfolio remove  inFile [file...]"
 	usagePortfolioExtract = "pdfcpu portfolio extract inFile outDir [file...]"
+	usagePortfolioCreate  = "pdfcpu portfolio create  inFileJSON outFile"
 
 	usagePortfolio = "usage: " + usagePortfolioList +
 		"\n       " + usagePortfolioAdd +
 		"\n       " + usagePortfolioRemove +
-		"\n       " + usagePortfolioExtract + generalFlags
+		"\n       " + usagePortfolioExtract +
+		"\n       " + usagePortfolioCreate + generalFlags
 
-	usageLongPortfolio = `Manage portfolio entries.
+	usageLongPortfolio = `Manage portfolio entries or create a portfolio.
 
-    inFile ... input PDF file
-      file ... attachment
-      desc ... description (optional)
-    outDir ... output directory
+        inFile ... input PDF file
+          file ... attachment
+          desc ... description (optional)
+        outDir ... output directory
+    inFileJSON ... portfolio description, relative paths are resolved against its directory
+       outFile ... output PDF file
     
     Adding attachments to portfolio: 
            pdfcpu portfolio add test.pdf test.mp3 test.mkv
 
     Adding attachments to portfolio with description: 
            pdfcpu portfolio add test.pdf "test.mp3, Test sound file" "test.mkv, Test video file"
+
+    Create a portfolio with folders, a custom column and an initial view:
+           pdfcpu portfolio create portfolio.json out.pdf
+
+    portfolio.json:
+    {
+      "cover": "cover.pdf",
+      "view": "details",
+      "initial": "docs/readme.pdf",
+      "sort": {"field": "Project", "ascending": true},
+      "fields": [
+        {"key": "FileName", "name": "Name", "type": "filename", "order": 1},
+        {"key": "Project", "name": "Project", "type": "text", "order": 2},
+        {"key": "Size", "name": "Size", "type": "size", "order": 3}
+      ],
+      "files": [
+        {"path": "readme.pdf", "folder": "docs", "description": "Read me first", "values": {"Project": "Alpha"}},
+        {"path": "images", "folder": "media"}
+      ]
+    }
+
+    cover ... PDF file serving as cover sheet, omit for a generated cover sheet
+     view ... details, tile, hidden (default: details)
+  initial ... file within the portfolio to be displayed initially
+     sort ... initial sort order by field key
+   fields ... columns, types: filename, description, size, moddate, creationdate, text, date, number
+    files ... files or directories to embed into an optional folder, directories are embedded recursively
+              values are supplied for fields of type text, date and number
     `
 
 	usagePermList = "pdfcpu permissions list [-upw userpw] [-opw ownerpw] -- inFile..."
@@ -308,19 +419,26 @@ content ... extract raw page content
      11: Assemble document (security handlers >= rev.3)
      12: Print (security handlers >= rev.3)`
 
-	usageEncrypt     = "usage: pdfcpu encrypt [-m(ode) rc4|aes] [-key 40|128|256] [-perm none|print|all] [-upw userpw] -opw ownerpw  -- inFile [outFile]" + generalFlags
-	usageLongEncrypt = `Setup password protection based on user and owner password.
+	usageEncrypt = "usage: pdfcpu encrypt [-m(ode) rc4|aes] [-key 40|128|256] [-perm none|print|all] [-upw userpw] -opw ownerpw  -- inFile [outFile]" +
+		"\n       pdfcpu encrypt [-m(ode) rc4|aes] [-key 128|256] [-perm none|print|all] -recipients certFile... -- inFile [outFile]" + generalFlags
+	usageLongEncrypt = `Setup password protection based on user and owner password
+or encrypt for a set of recipients using their certificates.
 
       mode ... algorithm (default=aes)
        key ... key length in bits (default=256)
       perm ... user access permissions
+recipients ... comma separated list of certificate files (.pem, .crt, .cer, .p7c)
     inFile ... input PDF file
    outFile ... output PDF file
    
-   PDF 2.0 files have to be encrypted using aes/256.`
+   PDF 2.0 files have to be encrypted using aes/256.
+
+   Files encrypted for recipients are opened using a recipient certificate and its private key:
 
-	usageDecrypt     = "usage: pdfcpu decrypt [-upw userpw] [-opw ownerpw] -- inFile [outFile]" + generalFlags
-	usageLongDecrypt = `Remove password protection and reset permissions.
+   pdfcpu decrypt -cert alice.pem -pkey alice.key in.pdf out.pdf`
+
+	usageDecrypt     = "usage: pdfcpu decrypt [-upw userpw] [-opw ownerpw] [-cert certFile -pkey keyFile] -- inFile [outFile]" + generalFlags
+	usageLongDecrypt = `Remove password protection or public key encryption and reset permissions.
 
     inFile ... input PDF file
    outFile ... output PDF file`
@@ -341,7 +459,7 @@ content ... extract raw page content
     opwOld ... old owner password (provide user password on initial changeopw)
     opwNew ... new owner password`
 
-	usageStampMode = `There are 3 different kinds of stamps:
+	usageStampMode = `There are 6 different kinds of stamps:
 
    1) text based:
       -mode text string			
@@ -350,6 +468,12 @@ content ... extract raw page content
                %p ... current page number
                %P ... total pages
          eg. pdfcpu stamp add -mode text -- "Page %p of %P" "scale:1.0 abs, pos:bc, rot:0" in.pdf out.pdf
+         Use the following inline style tags (core fonts only):
+               <b>..</b>            ... bold
+               <i>..</i>            ... italic
+               <color=#FF0000>..</color> ... fill color
+               <size=12>..</size>   ... font size in points
+         eg. pdfcpu stamp add -mode text -- "<b>CONFIDENTIAL</b>\nCase <color=#C00000>4711</color>" "aligntext:c, leading:1.2" in.pdf out.pdf
    
    2) image based
       -mode image imageFileName
@@ -369,9 +493,28 @@ content ... extract raw page content
          Customize your multistamp by starting with startPage#Src of a stamp PDF file.
          Apply repeatedly pages of the stamp file to inFile starting at startPage#Dest.
          Eg: pdfcpu stamp add -mode pdf -- "stamp.pdf:2:3" "" in.pdf out.pdf ... multistamp starting with page 2 of stamp.pdf onto page 3 of in.pdf
+
+   4) QR code based
+      -mode qrcode string
+         Render string as QR code using vector graphics.
+         Use the same format strings as for text based stamps for per page QR codes.
+         eg. pdfcpu stamp add -mode qrcode -- "https://example.com/doc/4711?page=%p" "pos:br, off:-20 20, size:60" in.pdf out.pdf
+
+   5) barcode based
+      -mode barcode string
+         Render string as linear barcode using vector graphics.
+         Use the same format strings as for text based stamps for per page barcodes.
+         eg. pdfcpu stamp add -mode barcode -- "TRK-4711-%p" "sym:code128, pos:bl, off:20 20, size:200, height:40" in.pdf out.pdf
+
+   6) SVG based
+      -mode svg svgFileName
+         Render a vector logo without rasterizing.
+         Supported are paths, basic shapes, solid fills, strokes and transforms.
+         Text, images, gradients, clipping, masking and opacity are ignored.
+         eg. pdfcpu stamp add -mode svg -- "logo.svg" "pos:tr, off:-20 -20, scale:.2" in.pdf out.pdf
    `
 
-	usageWatermarkMode = `There are 3 different kinds of watermarks:
+	usageWatermarkMode = `There are 6 different kinds of watermarks:
 
    1) text based:
       -mode text string			
@@ -380,6 +523,12 @@ content ... extract raw page content
                %p ... current page number
                %P ... total pages
          eg. pdfcpu watermark add -mode text -- "Page %p of %P" "scale:1.0 abs, pos:bc, rot:0" in.pdf out.pdf
+         Use the following inline style tags (core fonts only):
+               <b>..</b>            ... bold
+               <i>..</i>            ... italic
+               <color=#FF0000>..</color> ... fill color
+               <size=12>..</size>   ... font size in points
+         eg. pdfcpu watermark add -mode text -- "<b>CONFIDENTIAL</b>\nCase <color=#C00000>4711</color>" "aligntext:c, leading:1.2" in.pdf out.pdf
    
    2) image based
       -mode image imageFileName
@@ -400,6 +549,25 @@ content ... extract raw page content
          Apply repeatedly pages of the watermark file to inFile starting at startPage#Dest.
          Eg: pdfcpu watermark add -mode pdf -- "watermark.pdf:2:3" "" in.pdf out.pdf ... multiwatermark starting with page 2 of watermark.pdf onto page 3 of in.pdf
 
+   4) QR code based
+      -mode qrcode string
+         Render string as QR code using vector graphics.
+         Use the same format strings as for text based watermarks for per page QR codes.
+         eg. pdfcpu watermark add -mode qrcode -- "Page %p of %P" "pos:tl, size:50, err:H" in.pdf out.pdf
+
+   5) barcode based
+      -mode barcode string
+         Render string as linear barcode using vector graphics.
+         Use the same format strings as for text based watermarks for per page barcodes.
+         eg. pdfcpu watermark add -mode barcode -- "400638133393" "sym:ean13, pos:br, scale:.3" in.pdf out.pdf
+
+   6) SVG based
+      -mode svg svgFileName
+         Render a vector logo without rasterizing.
+         Supported are paths, basic shapes, solid fills, strokes and transforms.
+         Text, images, gradients, clipping, masking and opacity are ignored.
+         eg. pdfcpu watermark add -mode svg -- "logo.svg" "scale:.5, op:.3" in.pdf out.pdf
+
    A watermark is the first content that gets rendered for a page.
    The visibility of the watermark depends on the transparency of all layers rendered on top.
 `
@@ -423,6 +591,9 @@ content ... extract raw page content
 
    rtl:              render right to left (on/off, true/false, t/f)
 
+   vertical:         render top to bottom, lines progress from right to left (on/off, true/false, t/f)
+                     embeds a font subset using Identity-V, user fonts only eg. for Japanese or Chinese
+
    position:         one of the anchors:
 
                            tl|top-left     tc|top-center      tr|top-right
@@ -435,6 +606,8 @@ content ... extract raw page content
                     
    aligntext:        l|left, c|center, r|right, j|justified (for text watermarks only)
 
+   leading:          line spacing factor for multi-line text > 0, eg. 1.5 (core fonts only)
+
    fillcolor:        color value to be used when rendering text, see also rendermode
                      for backwards compatibility "color" is also accepted.
    
@@ -473,6 +646,23 @@ content ... extract raw page content
 
    url:              Add link annotation for stamps only (omit https://)
 
+   size:             edge length of QR codes or width of barcodes including the quiet zone in given display unit, overrides scalefactor.
+
+   errorcorrection:  QR code error correction level (defaults to M)
+                     L ... recovers  7% of data
+                     M ... recovers 15% of data
+                     Q ... recovers 25% of data
+                     H ... recovers 30% of data
+
+   symbology:        barcode symbology (defaults to code128)
+                     code128 ... alphanumeric (ASCII)
+                     ean13   ... 12 digits (check digit computed) or 13 digits
+                     code39  ... 0-9, A-Z, space and -.$/+%
+
+   height:           barcode height in given display unit (defaults to 30% of the barcode width)
+
+   QR codes and barcodes are rendered using fillcolor (defaults to black) onto bgcolor (defaults to white).
+
 A color value: 3 color intensities, where 0.0 < i < 1.0, eg 1.0, 
                or the hex RGB value: #RRGGBB, eg #FF0000 = red
 
@@ -485,9 +675,9 @@ e.g. "pos:bl, off: 20 5"   "rot:45"                 "op:0.5, scale:0.5 abs, rot:
 
 `
 
-	usageStampAdd    = "pdfcpu stamp add    [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageStampUpdate = "pdfcpu stamp update [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageStampRemove = "pdfcpu stamp remove [-p(ages) selectedPages] -- inFile [outFile]"
+	usageStampAdd    = "pdfcpu stamp add    [-p(ages) selectedPages] -m(ode) text|image|pdf|svg|qrcode|barcode -- string|file description inFile [outFile]"
+	usageStampUpdate = "pdfcpu stamp update [-p(ages) selectedPages] -m(ode) text|image|pdf|svg|qrcode|barcode -- string|file description inFile [outFile]"
+	usageStampRemove = "pdfcpu stamp remove [-p(ages) selectedPages] [-m(ode) detect] -- inFile [outFile]"
 
 	usageStamp = "usage: " + usageStampAdd +
 		"\n       " + usageStampUpdate +
@@ -498,19 +688,24 @@ e.g. "pos:bl, off: 20 5"   "rot:45"                 "op:0.5, scale:0.5 abs, rot:
       pages ... Please refer to "pdfcpu selectedpages"
         upw ... user password
         opw ... owner password
-       mode ... text, image, PDF
-     string ... display string for text based watermarks
-       file ... image or PDF file
-description ... fontname, points, position, offset, scalefactor, aligntext, rotation, 
+       mode ... text, image, PDF, SVG, qrcode, barcode
+     string ... display string for text based watermarks or payload for QR codes and barcodes
+       file ... image, PDF or SVG file
+description ... fontname, points, position, offset, scalefactor, aligntext, leading, rotation, 
                 diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
      inFile ... input PDF file
     outFile ... output PDF file
 
+Use "remove -mode detect" for stamps added by other tools:
+   Removes content marked as watermark plus overlays repeated on at least half of the selected pages
+   which use transparency or rotation like diagonal DRAFT or CONFIDENTIAL stamps.
+   eg. pdfcpu stamp remove -mode detect -- in.pdf out.pdf
+
 ` + usageStampMode + usageWMDescription
 
-	usageWatermarkAdd    = "pdfcpu watermark add    [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageWatermarkUpdate = "pdfcpu watermark update [-p(ages) selectedPages] -m(ode) text|image|pdf -- string|file description inFile [outFile]"
-	usageWatermarkRemove = "pdfcpu watermark remove [-p(ages) selectedPages] -- inFile [outFile]"
+	usageWatermarkAdd    = "pdfcpu watermark add    [-p(ages) selectedPages] -m(ode) text|image|pdf|svg|qrcode|barcode -- string|file description inFile [outFile]"
+	usageWatermarkUpdate = "pdfcpu watermark update [-p(ages) selectedPages] -m(ode) text|image|pdf|svg|qrcode|barcode -- string|file description inFile [outFile]"
+	usageWatermarkRemove = "pdfcpu watermark remove [-p(ages) selectedPages] [-m(ode) detect] -- inFile [outFile]"
 
 	usageWatermark = "usage: " + usageWatermarkAdd +
 		"\n       " + usageWatermarkUpdate +
@@ -519,20 +714,25 @@ description ... fontname, points, position, offset, scalefactor, aligntext, rota
 	usageLongWatermark = `Process watermarking for selected pages. 
 
       pages ... Please refer to "pdfcpu selectedpages"
-       mode ... text, image, PDF
-     string ... display string for text based watermarks
-       file ... image or PDF file
-description ... fontname, points, position, offset, scalefactor, aligntext, rotation,
+       mode ... text, image, PDF, SVG, qrcode, barcode
+     string ... display string for text based watermarks or payload for QR codes and barcodes
+       file ... image, PDF or SVG file
+description ... fontname, points, position, offset, scalefactor, aligntext, leading, rotation,
                 diagonal, opacity, rendermode, strokecolor, fillcolor, bgcolor, margins, border
      inFile ... input PDF file
     outFile ... output PDF file
 
+Use "remove -mode detect" for watermarks added by other tools:
+   Removes content marked as watermark plus overlays repeated on at least half of the selected pages
+   which use transparency or rotation like diagonal DRAFT or CONFIDENTIAL stamps.
+   eg. pdfcpu watermark remove -mode detect -- in.pdf out.pdf
+
 ` + usageWatermarkMode + usageWMDescription
 
 	usageImportImages     = "usage: pdfcpu import -- [description] outFile imageFile..." + generalFlags
 	usageLongImportImages = `Turn image files into a PDF page sequence and write the result to outFile.
 If outFile already exists the page sequence will be appended.
-Each imageFile will be rendered to a separate page.
+Each imageFile will be rendered to a separate page, multi-page TIFF files to one page per image.
 In its simplest form this converts an image into a PDF: "pdfcpu import img.pdf img.jpg"
 
 description ... dimensions, formsize, position, offset, scale factor, boxes
@@ -563,7 +763,7 @@ description ... dimensions, formsize, position, offset, scale factor, boxes
 
   scalefactor:     0.0 <= x <= 1.0 followed by optional 'abs|rel' or 'a|r'
 
-  dpi:             apply desired dpi
+  dpi:             apply desired dpi, TIFF images default to their own resolution
 
   gray:            Convert to grayscale (on/off, true/false, t/f)
 
@@ -573,6 +773,7 @@ description ... dimensions, formsize, position, offset, scale factor, boxes
   
   Only one of dimensions or formsize is allowed.
   position: full => image dimensions equal page dimensions.
+  Bilevel CCITT Group 4 TIFF images are embedded without recompression.
   
   All configuration string parameters support completion.
 
@@ -581,10 +782,12 @@ description ... dimensions, formsize, position, offset, scale factor, boxes
        "pos:full"                                   ... render the image to a page with corresponding dimensions.
        "f:A4, pos:c, dpi:300"                       ... render the image centered on A4 respecting a destination resolution of 300 dpi.`
 
-	usagePagesInsert = "pdfcpu pages insert [-p(ages) selectedPages] [-m(ode) before|after] -- [description] inFile [outFile]"
-	usagePagesRemove = "pdfcpu pages remove  -p(ages) selectedPages -- inFile [outFile]"
-	usagePages       = "usage: " + usagePagesInsert +
-		"\n       " + usagePagesRemove + generalFlags
+	usagePagesInsert  = "pdfcpu pages insert [-p(ages) selectedPages] [-m(ode) before|after] -- [description] inFile [outFile]"
+	usagePagesRemove  = "pdfcpu pages remove  -p(ages) selectedPages -- inFile [outFile]"
+	usagePagesAnalyze = "pdfcpu pages analyze [-j(son)] inFile"
+	usagePages        = "usage: " + usagePagesInsert +
+		"\n       " + usagePagesRemove +
+		"\n       " + usagePagesAnalyze + generalFlags
 
 	usageLongPages = `Manage pages.
 
@@ -622,6 +825,67 @@ description ... dimensions, formsize
                   pdfcpu pages remove -p odd in.pdf out.pdf
                   pdfcpu pages remove -pages=odd in.pdf out.pdf
                   Remove all odd pages.
+
+                  pdfcpu pages analyze scan.pdf
+                  Report the ink coverage of all pages and identify blank and duplicate pages.
+
+   analyze hashes the normalized content of each page to detect exact duplicates
+   and estimates the ink coverage from painted glyphs, paths and images.
+   Pages without text covering at most 0.1% of the page box are considered blank.
+   Use -drop blank,duplicates with split, merge or optimize to remove these pages.
+`
+
+	usageCompare     = "usage: pdfcpu compare [-visual] [-j(son)] inFile1 inFile2 [outFile]" + generalFlags
+	usageLongCompare = `Compare inFile1 with inFile2 and report differing document properties, page counts and pages.
+Pages are compared by hashing their content, resources and page boundaries.
+
+     visual ... locate the changed regions of differing pages by matching text, vector paths and images
+       json ... output the comparison report as JSON
+    inFile1 ... input PDF file
+    inFile2 ... input PDF file to be compared against inFile1
+    outFile ... copy of inFile2 with changed regions highlighted:
+                red: removed, green: added
+
+Pages are not rasterized, changes get located by what is painted where.
+
+Examples: pdfcpu compare v1.pdf v2.pdf
+          pdfcpu compare -visual v1.pdf v2.pdf changes.pdf
+`
+
+	usageRepair     = "usage: pdfcpu repair inFile [outFile]" + generalFlags
+	usageLongRepair = `Read a damaged inFile and write a consistent PDF to outFile.
+A broken or missing cross reference table gets rebuilt by scanning inFile for objects,
+stream lengths get corrected and an unusable page tree gets rebuilt from all pages found.
+All applied repairs are listed.
+
+     inFile ... input PDF file
+    outFile ... output PDF file
+`
+
+	usageSanitize     = "usage: pdfcpu sanitize inFile [outFile]" + generalFlags
+	usageLongSanitize = `Prepare inFile for external release by removing
+JavaScript and launch actions, embedded files, hidden layers,
+document properties, XMP metadata, review annotations and incremental updates.
+Everything removed is listed.
+
+     inFile ... input PDF file
+    outFile ... output PDF file
+`
+
+	usageOCR     = "usage: pdfcpu ocr inFile ocrFile... [outFile]" + generalFlags
+	usageLongOCR = `Make scanned pages searchable by overlaying invisible text
+at the word positions reported by an OCR engine.
+OCR pages are assigned to the pages of inFile in order starting with page 1.
+
+     inFile ... input PDF file
+    ocrFile ... hOCR or ALTO XML file containing one or more OCR pages
+    outFile ... output PDF file
+
+The word coordinates are scaled from the OCR page size to the visible page,
+therefore OCR results of page images at any resolution may be used.
+
+Examples: pdfcpu ocr scan.pdf scan.hocr
+          pdfcpu ocr scan.pdf page1.xml page2.xml scan_searchable.pdf
 `
 
 	usageRotate     = "usage: pdfcpu rotate [-p(ages) selectedPages] -- inFile rotation [outFile]" + generalFlags
@@ -630,8 +894,14 @@ description ... dimensions, formsize
       pages ... Please refer to "pdfcpu selectedpages"
      inFile ... input PDF file
    rotation ... a multiple of 90 degrees for clockwise rotation
+                or auto for displaying text upright based on its detected orientation
     outFile ... output PDF file
 
+Examples:
+   pdfcpu rotate in.pdf 90     ... rotate all pages clockwise by 90 degrees
+   pdfcpu rotate in.pdf auto   ... fix the rotation of pages with sideways or upside down text
+                                   Scans need a text layer, eg. from "pdfcpu ocr".
+
 `
 
 	usageNUp     = "usage: pdfcpu nup [-p(ages) selectedPages] -- [description] outFile n inFile|imageFiles..." + generalFlags
@@ -685,6 +955,26 @@ description ... dimensions, formsize, orientation
     backgroundcolor: background color for margin > 0.
                      "bgcolor" is also accepted.
 
+    sheetmarks:      printer's marks drawn outside the cell areas, any of:
+                        crop ... corner crop marks at the trim box of each cell
+                        reg  ... registration targets along the sheet edges
+                        fold ... fold/score marks between cells
+                        slug ... slug line with filename and date
+                     or "all", eg. "sheetmarks:crop fold". Combine with margin > 0.
+
+    rotatecells:     rotate the content of individual cells by 180 degrees for work-and-turn
+                     and work-and-tumble layouts, a list of cell numbers in grid order, eg. "rotatecells:2 4"
+                     or "tumble" for all cells of the bottom half of the sheet.
+
+    trimsize:        common trim size all input pages get normalized to before imposition,
+                     a paper size eg. A5 or (width,height) in given display unit eg. '148 210'.
+                     Use this for input files with mixed page sizes. Applies to PDF input files only.
+
+    fit:             how to normalize pages to the trim size:
+                        scale  ... scale to fit (=default)
+                        center ... center without scaling, clip oversized pages
+                        clip   ... align upper left corner without scaling, clip oversized pages
+
 All configuration string parameters support completion.
     
 Examples: pdfcpu nup out.pdf 4 in.pdf
@@ -769,7 +1059,7 @@ The last signature may be shorter, e.g. for a booklet of 120 pages with signatur
 
 <description> is a comma separated configuration string containing these optional entries:
 
-   (defaults: "dim:595 842, formsize:A4, btype: booklet, binding: long, multifolio: false, border:off, guides:off, margin:0")
+   (defaults: "dim:595 842, formsize:A4, btype: booklet, binding: long, rtl: off, multifolio: false, border:off, guides:off, margin:0")
 
    dimensions:       (width,height) of the output sheet in given display unit eg. '400 200'
    formsize:         The output sheet size, eg. A4, Letter, Legal...
@@ -780,6 +1070,8 @@ The last signature may be shorter, e.g. for a booklet of 120 pages with signatur
                      "papersize" is also accepted.
    btype:            The method for arranging pages into a booklet. (booklet, bookletadvanced, perfectbound)
    binding:          The edge of the paper which has the binding. (long, short)
+   rtl:              Right-to-left reading direction eg. for Hebrew, Arabic or Japanese books (on/off, true/false, t/f)
+                     Mirrors the page ordering so the binding ends up on the right.
    multifolio:       Generate multi folio booklet (on/off, true/false, t/f) for n=2 and PDF input only.
    foliosize:        folio size for multi folio booklets only (default:8)
    border:           Print border (on/off, true/false, t/f) 
@@ -787,6 +1079,10 @@ The last signature may be shorter, e.g. for a booklet of 120 pages with signatur
    margin:           Apply content margin (float >= 0 in given display unit)
    backgroundcolor:  sheet background color for margin > 0.
                      "bgcolor" is also accepted.
+   sheetmarks:       printer's marks drawn outside the cell areas, any of: crop reg fold slug (or all)
+                     eg. "sheetmarks:crop reg". Combine with margin > 0.
+   trimsize:         common trim size all input pages get normalized to, eg. A5 or '148 210' (PDF input only)
+   fit:              how to normalize pages to the trim size: scale (=default), center, clip
 
 All configuration string parameters support completion.
 
@@ -811,6 +1107,9 @@ Examples:
       Arrange pages of in.pdf 2 per sheetside as sequence of folios covering 4*foliosize pages each.
       See also: https://www.instructables.com/How-to-bind-your-own-Hardback-Book/
 
+   pdfcpu booklet -- "formsize:A4, rtl:on" out.pdf 2 in.pdf
+      Arrange pages of in.pdf 2 per sheet side for a right-to-left booklet with the binding on the right, onto out.pdf
+
    pdfcpu booklet -- "formsize:A4, btype:perfectbound" out.pdf 2 in.pdf
       Arrange pages of in.pdf 2 per sheet side, arranged for perfect binding, onto out.pdf
   
@@ -948,16 +1247,17 @@ Examples: pdfcpu grid out.pdf 1 10 in.pdf
 	usageSelectedPages     = "usage: pdfcpu selectedpages"
 	usageLongSelectedPages = "Print definition of the -pages flag."
 
-	usageInfo     = "usage: pdfcpu info [-p(ages) selectedPages] [-fonts -j(son)] -- inFile..." + generalFlags
+	usageInfo     = "usage: pdfcpu info [-p(ages) selectedPages] [-fonts -resources -j(son)] -- inFile..." + generalFlags
 	usageLongInfo = `Print info about a PDF file.
    
-   pages ... Please refer to "pdfcpu selectedpages"
-   fonts ... include font info
-    json ... output JSON
-  inFile ... a list of PDF input files`
+       pages ... Please refer to "pdfcpu selectedpages"
+       fonts ... include font info
+   resources ... include a per page report of fonts, images, color spaces, transparency and annotations
+        json ... output JSON
+      inFile ... a list of PDF input files`
 
 	usageFontsList       = "pdfcpu fonts list"
-	usageFontsInstall    = "pdfcpu fonts install fontFiles..."
+	usageFontsInstall    = "pdfcpu fonts install fontFile[:face]..."
 	usageFontsCheatSheet = "pdfcpu fonts cheatsheet fontFiles..."
 
 	usageFonts = "usage: " + usageFontsList +
@@ -965,7 +1265,20 @@ Examples: pdfcpu grid out.pdf 1 10 in.pdf
 		"\n       " + usageFontsCheatSheet
 	usageLongFonts = `Print a list of supported fonts (includes the 14 PDF core fonts).
 Install given True Type fonts(.ttf) or True Type collections(.ttc) for usage in stamps/watermarks.
-Create single page PDF cheat sheets in current dir.`
+Create single page PDF cheat sheets in current dir.
+
+   fontFile ... a True Type font (.ttf) or True Type collection (.ttc)
+       face ... optional, for collections: face index or PostScript name
+                          for variable fonts: named instance (eg. Bold)
+
+    Eg. install all faces of a collection:
+           pdfcpu fonts install NotoSansCJK.ttc
+
+        install the second face of a collection:
+           pdfcpu fonts install NotoSansCJK.ttc:1
+
+        install the bold instance of a variable font:
+           pdfcpu fonts install RobotoFlex.ttf:Bold`
 
 	usageKeywordsList   = "pdfcpu keywords list    inFile"
 	usageKeywordsAdd    = "pdfcpu keywords add     inFile keyword..."
@@ -1005,6 +1318,49 @@ nameValuePair ... 'name = value'
          adding two properties: pdfcpu properties add test.pdf 'key1 = val1' 'key2 = val2'
 
          remove all properties: pdfcpu properties remove test.pdf
+     `
+	usageMetadataList  = "pdfcpu metadata list  inFile"
+	usageMetadataSet   = "pdfcpu metadata set   inFile nameValuePair..."
+	usageMetadataCheck = "pdfcpu metadata check inFile"
+	usageMetadataSync  = "pdfcpu metadata sync  inFile [info|xmp]"
+
+	usageMetadata = "usage: " + usageMetadataList +
+		"\n       " + usageMetadataSet +
+		"\n       " + usageMetadataCheck +
+		"\n       " + usageMetadataSync + generalFlags
+
+	usageLongMetadata = `Manage the document's XMP metadata.
+
+       inFile ... input PDF file
+nameValuePair ... 'name = value'
+         name ... one of:
+
+             title, creator, description, subject, publisher, rights   (Dublin Core)
+             producer, keywords, trapped                               (Adobe PDF)
+             creatortool, createdate, modifydate                       (XMP basic)
+
+Multiple creators, subjects and publishers are separated by semicolons.
+Dates are given as eg. 2025-03-01 or 2025-03-01T12:30:00Z, trapped is true or false.
+
+Any other schemas and properties of the XMP packet are preserved.
+xmp:MetadataDate is updated with each change.
+The document info dictionary is not affected, use "pdfcpu properties" to manage it.
+
+Title, Author, Subject, Keywords and Creator of the document info dictionary
+correspond to dc:title, dc:creator, dc:description, pdf:Keywords and xmp:CreatorTool.
+Changes made with "pdfcpu properties" and "pdfcpu keywords" are applied to both.
+
+check ... list entries of the document info dictionary disagreeing with the XMP metadata
+ sync ... fix these mismatches, missing values are taken from the other side,
+          conflicting values are resolved in favor of info (default) or xmp
+
+Examples:
+
+   pdfcpu metadata list in.pdf
+
+   pdfcpu metadata set in.pdf 'title = Annual Report' 'creator = Jane Doe; John Doe'
+
+   pdfcpu metadata sync in.pdf xmp
      `
 	usageCollect     = "usage: pdfcpu collect -p(ages) selectedPages -- inFile [outFile]" + generalFlags
 	usageLongCollect = `Create custom sequence of selected pages. 
@@ -1063,13 +1419,20 @@ box:
 	usageLongCrop = `Set crop box for selected pages. 
 
         pages ... Please refer to "pdfcpu selectedpages"
-  description ... crop box definition abs. or rel. to media box
+  description ... crop box definition abs. or rel. to media box or auto crop definition
        inFile ... input PDF file
       outFile ... output PDF file
 
 Examples:
    pdfcpu crop -- "[0 0 500 500]" in.pdf ... crop a 500x500 points region located in lower left corner
    pdfcpu crop -u mm -- "20" in.pdf      ... crop relative to media box using a 20mm margin
+   pdfcpu crop -- "auto" in.pdf          ... crop to the bounding box of the page content
+   pdfcpu crop -u mm -- "auto 5" in.pdf  ... crop to the page content using a 5mm margin
+   pdfcpu crop -- "auto 10 media" in.pdf ... also set the media box to the page content using a 10 points margin
+
+Auto crop: auto [margin] [media]
+   Trims white space by cropping to the ink bounding box of the page content.
+   Annotations are ignored and blank pages remain unchanged.
 
 ` + usageBoxDescription
 
@@ -1105,20 +1468,32 @@ Examples:
      
 ` + usageBoxDescription
 
-	usageAnnotsList   = "pdfcpu annotations list   [-p(ages) selectedPages] -- inFile"
-	usageAnnotsRemove = "pdfcpu annotations remove [-p(ages) selectedPages] -- inFile [outFile] [objNr|annotId|annotType]..."
+	usageAnnotsList     = "pdfcpu annotations list     [-p(ages) selectedPages] -- inFile"
+	usageAnnotsAdd      = "pdfcpu annotations add      inFile inFileJSON [outFile]"
+	usageAnnotsRemove   = "pdfcpu annotations remove   [-p(ages) selectedPages] -- inFile [outFile] [objNr|annotId|annotType]..."
+	usageAnnotsAutoLink = "pdfcpu annotations autolink [-p(ages) selectedPages] -- inFile [outFile]"
+	usageAnnotsExport   = "pdfcpu annotations export   [-p(ages) selectedPages] -- inFile [outFileJSON]"
+	usageAnnotsImport   = "pdfcpu annotations import   inFile inFileJSON [outFile]"
+	usageAnnotsFlatten  = "pdfcpu annotations flatten  [-p(ages) selectedPages -tag] -- inFile [outFile] [objNr|annotId|annotType]..."
 
 	usageAnnots = "usage: " + usageAnnotsList +
-		"\n       " + usageAnnotsRemove + generalFlags
+		"\n       " + usageAnnotsAdd +
+		"\n       " + usageAnnotsRemove +
+		"\n       " + usageAnnotsAutoLink +
+		"\n       " + usageAnnotsExport +
+		"\n       " + usageAnnotsImport +
+		"\n       " + usageAnnotsFlatten + generalFlags
 
 	usageLongAnnots = `Manage annotations.
    
-      pages ... Please refer to "pdfcpu selectedpages"
-     inFile ... input PDF file
-      objNr ... obj# from "pdfcpu annotations list"
-    annotId ... id from "pdfcpu annotations list"
-  annotType ... Text, Link, FreeText, Line, Square, Circle, Polygon, PolyLine, HighLight, Underline, Squiggly, StrikeOut, Stamp,
-                Caret, Ink, Popup, FileAttachment, Sound, Movie, Widget, Screen, PrinterMark, TrapNet, Watermark, 3D, Redact
+       pages ... Please refer to "pdfcpu selectedpages"
+      inFile ... input PDF file
+  inFileJSON ... input JSON file describing the annotations to add or import
+ outFileJSON ... output JSON file, defaults to inFile_annotations.json
+       objNr ... obj# from "pdfcpu annotations list"
+     annotId ... id from "pdfcpu annotations list"
+   annotType ... Text, Link, FreeText, Line, Square, Circle, Polygon, PolyLine, HighLight, Underline, Squiggly, StrikeOut, Stamp,
+                 Caret, Ink, Popup, FileAttachment, Sound, Movie, Widget, Screen, PrinterMark, TrapNet, Watermark, 3D, Redact
    
    Examples:
 
@@ -1128,6 +1503,40 @@ Examples:
       List annotation of first two pages:
          pdfcpu annot list -pages 1-2 in.pdf
 
+      Add the annotations described in annots.json and write to out.pdf:
+         pdfcpu annot add in.pdf annots.json out.pdf
+
+         annots.json:
+         {
+            "annotations": {
+               "1": [
+                  {"type": "Highlight", "quadPoints": [100, 720, 300, 720, 100, 700, 300, 700]},
+                  {"type": "FreeText", "rect": [100, 600, 300, 650], "text": "Note", "fontSize": 14, "color": "#FFFFCC"},
+                  {"type": "Stamp", "rect": [350, 700, 500, 750], "name": "Approved", "color": "Green"}
+               ]
+            }
+         }
+
+         Supported types: Highlight, Underline, StrikeOut, Squiggly, Ink, Line, Polygon, PolyLine,
+                          FreeText, Square, Circle, FileAttachment, Stamp, RichMedia, 3D
+
+      Embed a video playing when the page becomes visible, showing poster.jpg until then:
+         {"type": "RichMedia", "rect": [100, 400, 420, 580], "file": "clip.mp4", "poster": "poster.jpg",
+          "activate": "pageVisible", "toolbar": true}
+
+         RichMedia files: .mp4, .m4v, .mov, .mp3, .m4a, .aac
+         activate:   click, pageOpen, pageVisible
+         deactivate: click, pageClose, pageInvisible
+
+      Embed U3D or PRC artwork activated on page open with a predefined default view:
+         {"type": "3D", "rect": [100, 400, 420, 640], "file": "part.u3d", "activate": "pageOpen", "toolbar": true,
+          "views": [{"name": "Front", "camera": [1, 0, 0, 0, 0, -1, 0, 1, 0, 0, -100, 0], "centerOfOrbit": 100,
+                     "lighting": "CAD", "renderMode": "Solid", "background": "#FFFFFF"}]}
+
+         lighting:   Artwork, None, White, Day, Night, Hard, Primary, Blue, Red, Cube, CAD, Headlamp
+         renderMode: Solid, SolidWireframe, Transparent, TransparentWireframe, BoundingBox, Wireframe,
+                     ShadedWireframe, HiddenWireframe, Vertices, Illustration, SolidOutline, ...
+
       Remove all page annotations and write to out.pdf:
          pdfcpu annot remove in.pdf out.pdf
       
@@ -1145,6 +1554,30 @@ Examples:
 
       Remove annotations by type, id and obj# and write to out.pdf:
          pdfcpu annot remove in.pdf out.pdf Link 30 Text someId
+
+      Turn URLs and email addresses found in the page text into links and write to out.pdf:
+         pdfcpu annot autolink in.pdf out.pdf
+
+      Export the annotations including replies and review states of two reviewers
+      and merge them into the final version of the document:
+         pdfcpu annot export reviewer1.pdf r1.json
+         pdfcpu annot export reviewer2.pdf r2.json
+         pdfcpu annot import final.pdf r1.json
+         pdfcpu annot import final.pdf r2.json
+
+         Annotations already present in final.pdf (same id) are not imported again,
+         their new replies are merged.
+
+      Burn all markup annotations into the page content and write to out.pdf:
+         pdfcpu annot flatten in.pdf out.pdf
+
+      Flatten all Highlight and Stamp annotations on page 1:
+         pdfcpu annot flatten -pages 1 in.pdf Highlight Stamp
+
+         Flattened annotations are removed including their popups and replies.
+
+      Flatten all markup annotations and tag their appearances as Annot structure elements:
+         pdfcpu annot flatten -tag in.pdf out.pdf
       `
 
 	usageImagesList    = "pdfcpu images list    [-p(ages) selectedPages] -- inFile..."
@@ -1191,15 +1624,20 @@ Examples:
              pdfcpu images update gallery.pdf logo.jpg out.pdf 1 Im0
     `
 
-	usageCreate     = "usage: pdfcpu create inFileJSON [inFile] outFile" + generalFlags
+	usageCreate     = "usage: pdfcpu create [-tag] -- inFileJSON [inFile] outFile" + generalFlags
 	usageLongCreate = `Create page content corresponding to declarations in inFileJSON.
 Append new page content to existing page content in inFile and write result to outFile.
 If inFile is absent outFile will be overwritten.
 
+          tag ... build a structure tree for the generated content (tagged PDF)
    inFileJSON ... input json file
        inFile ... optional input PDF file 
       outFile ... output PDF file
 
+Using -tag text boxes are tagged as P or their optional "tag" (mapped to "role" if non standard),
+images as Figure using their optional "alt" description and tables as Table.
+Backgrounds, borders, headers and footers are marked as artifacts.
+
 A minimalistic sample json:
 {
    "pages": {
@@ -1328,7 +1766,7 @@ Supported usecases:
 	usageLongResize = `Resize existing pages.
 
       pages ... please refer to "pdfcpu selectedpages"
-description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
+description ... scalefactor, dimensions, formsize, enforce, border, bgcolor, fit
      inFile ... input PDF file
     outFile ... output PDF file
 
@@ -1352,6 +1790,12 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
       border:       if dimensions set only, draw content region border (on/off, true/false, t/f).
 
       bgcolor:      if dimensions set only, background color value for unused page regions.
+
+      fit:          if dimensions or formsize set only, transform content, page boundaries and annotations
+                    to the new page size adapting to the page orientation, one of:
+                        contain ... scale to fit, centered
+                        cover   ... scale to cover, centered, clip overflowing content
+                        stretch ... scale width and height independently
    
       
    Examples: 
@@ -1376,12 +1820,49 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
 
          pdfcpu resize "dim:400 200, enforce:true" in.pdf out.pdf
             Resize pages to 400 x 200 points, enforce orientation.
+
+         pdfcpu resize "form:A4, fit:contain" in.pdf out.pdf
+            Convert pages of any size to A4 including annotations, keep orientation.
+`
+	usageBleed     = "usage: pdfcpu bleed [-p(ages) selectedPages] -- description inFile [outFile]" + generalFlags
+	usageLongBleed = `Add bleed to borderless pages.
+
+      pages ... please refer to "pdfcpu selectedpages"
+description ... width, mode, fillcol
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+    Enlarges the media box by the bleed width and fills the new margin.
+    The former crop box becomes the trim box, the enlarged media box the bleed box.
+
+    <description> is a comma separated configuration string containing:
+
+      width:        bleed width in given display unit (required).
+
+      mode:         how to fill the bleed, one of:
+                        mirror ... reflect the page content at the page edges (default)
+                        smear  ... stretch the outermost strip of the page content
+                        color  ... fill with a solid color
+
+      fillcol:      fill color for mode color, implies mode color (default: white)
+                        eg. #RRGGBB or one of: black, darkgray, lightgray, white, red, green, blue
+
+   Examples:
+
+         pdfcpu bleed -u mm -- "width:3" in.pdf out.pdf
+            Add a 3mm bleed mirroring the page content.
+
+         pdfcpu bleed -- "w:9, mode:smear" in.pdf out.pdf
+            Add a 9 points bleed smearing the page edges.
+
+         pdfcpu bleed -u mm -- "w:3, fillcol:#00A0E0" in.pdf out.pdf
+            Add a 3mm bleed using a solid color.
 `
 	usagePoster     = "usage: pdfcpu poster [-p(ages) selectedPages] -- description inFile outDir [outFileName]" + generalFlags
 	usageLongPoster = `Create a poster using paper size.
 
          pages ... Please refer to "pdfcpu selectedpages"
-   description ... formsize(=papersize), dimensions, scalefactor, margin, bgcolor, border
+   description ... formsize(=papersize), dimensions, scalefactor, margin, bgcolor, border, overlap, labels
         inFile ... input PDF file
         outDir ... output directory
    outFileName ... output file name
@@ -1406,6 +1887,12 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
       bgcolor:      color value for visualization of margin / glue area.
 
       border:       if margin set, draw content region border (on/off, true/false, t/f) 
+
+      overlap:      Repeat content along the edges of adjacent tiles as glue area (float >= 0 in given display unit)
+                        Tiles keep their form size. Use either overlap or margin.
+
+      labels:       Stamp tile coordinates eg. B3 (column B, row 3) onto each tile
+                        and alignment marks into the overlap areas (on/off, true/false, t/f)
    
    
    Examples:
@@ -1420,6 +1907,9 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
 
          pdfcpu poster -u cm -- "dim:15 10, margin:1, bgcol:DarkGray, border:on" in.pdf outDir
             Generate a poster via a corresponding grid with cell size 15x10 cm and provide a glue area of 1 cm.
+
+         pdfcpu poster -u cm -- "f:A4, overlap:1, labels:on" in.pdf outDir
+            Generate a poster via a grid of A4 pages overlapping by 1 cm, labelled for easy assembly.
             
    See also the related commands: ndown, cut`
 
@@ -1515,20 +2005,211 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
 
 	usageBookmarksList   = "pdfcpu bookmarks list   inFile"
 	usageBookmarksImport = "pdfcpu bookmarks import [-r(eplace)] -- inFile inFileJSON [outFile]"
+	usageBookmarksDetect = "pdfcpu bookmarks detect [-r(eplace)] -- inFile [outFile]"
 	usageBookmarksExport = "pdfcpu bookmarks export inFile [outFileJSON]"
 	usageBookmarksRemove = "pdfcpu bookmarks remove inFile [outFile]"
 
 	usageBookmarks = "usage: " + usageBookmarksList +
 		"\n       " + usageBookmarksImport +
+		"\n       " + usageBookmarksDetect +
 		"\n       " + usageBookmarksExport +
 		"\n       " + usageBookmarksRemove + generalFlags
 
 	usageLongBookmarks = `Manage bookmarks.
 
            inFile ... input PDF file
-       inFileJSON ... input JSON file
+       inFileJSON ... input JSON or YAML file
           outFile ... output PDF file
       outFileJSON ... output PDF file
+
+   A bookmark consists of title, page, optional destination (type, left, bottom, right, top, zoom),
+   style (bold, italic, color) and kids (or children).
+
+   detect builds bookmarks from headings set in a font larger than the body text.
+   The font size determines the bookmark level.
+
+   Examples:
+
+      Export bookmarks, edit and import them again:
+         pdfcpu bookmarks export in.pdf bookmarks.json
+         pdfcpu bookmarks import -replace in.pdf bookmarks.json
+
+      Create bookmarks for detected headings:
+         pdfcpu bookmarks detect in.pdf out.pdf
+`
+
+	usageOutputIntentsList    = "pdfcpu outputintents list    inFile"
+	usageOutputIntentsAdd     = "pdfcpu outputintents add     -- description inFile [outFile]"
+	usageOutputIntentsReplace = "pdfcpu outputintents replace -- description inFile [outFile]"
+
+	usageOutputIntents = "usage: " + usageOutputIntentsList +
+		"\n       " + usageOutputIntentsAdd +
+		"\n       " + usageOutputIntentsReplace + generalFlags
+
+	usageLongOutputIntents = `Manage output intents describing the color characteristics of the intended output device.
+Output intents are a prerequisite for PDF/A and PDF/X.
+
+description ... subtype, output condition, registry, ICC profile
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  <description> is a comma separated configuration string containing these entries:
+
+      (defaults: "subtype:pdfx")
+
+      subtype:    pdfx, pdfa, pdfe or any other output intent subtype
+      id:         output condition identifier, eg. FOGRA39 or sRGB IEC61966-2.1, defaults to the profile description
+      condition:  human readable description of the output condition
+      registry:   registry the output condition identifier is defined in, eg. http://www.color.org
+      info:       further information about the output condition
+      profile:    gray, RGB or CMYK ICC profile file embedded as destination output profile
+
+  A profile is required unless id refers to a standard production condition of the registry.
+  PDF/A output intents always require a profile.
+
+  add fails if there is an output intent of the same subtype,
+  replace replaces the output intent of the same subtype or adds the output intent if there is none.
+
+Examples:
+
+   pdfcpu outputintents list in.pdf
+      List all output intents.
+
+   pdfcpu outputintents add -- "id:FOGRA39, registry:http://www.color.org, profile:coated.icc" in.pdf out.pdf
+      Add a PDF/X output intent.
+
+   pdfcpu outputintents replace -- "subtype:pdfa, id:sRGB IEC61966-2.1, profile:sRGB.icc" in.pdf
+      Set the PDF/A output intent of in.pdf.
+`
+
+	usageLayersList    = "pdfcpu layers list    inFile"
+	usageLayersShow    = "pdfcpu layers show    inFile layer..."
+	usageLayersHide    = "pdfcpu layers hide    inFile layer..."
+	usageLayersRemove  = "pdfcpu layers remove  inFile layer..."
+	usageLayersFlatten = "pdfcpu layers flatten inFile [outFile]"
+
+	usageLayers = "usage: " + usageLayersList +
+		"\n       " + usageLayersShow +
+		"\n       " + usageLayersHide +
+		"\n       " + usageLayersRemove +
+		"\n       " + usageLayersFlatten + generalFlags
+
+	usageLongLayers = `Manage layers (optional content groups).
+
+     inFile ... input PDF file
+      layer ... layer name
+    outFile ... output PDF file
+
+  show and hide set the visibility of layers when opening the file in a viewer.
+
+  remove deletes layers including all content, XObjects and annotations depending on them.
+
+  flatten turns layers into regular content as visible by default:
+  Content of hidden layers is removed and all layers are deleted.
+
+Examples:
+
+   pdfcpu layers list in.pdf
+      List all layers.
+
+   pdfcpu layers hide in.pdf Dimensions 'Hidden lines'
+      Hide two layers by default.
+
+   pdfcpu layers remove in.pdf Watermark
+      Delete the layer Watermark and its content.
+
+   pdfcpu layers flatten in.pdf out.pdf
+      Create a version of in.pdf without layers.
+`
+
+	usagePageLabelsList   = "pdfcpu pagelabels list   inFile"
+	usagePageLabelsSet    = "pdfcpu pagelabels set    inFile labels [outFile]"
+	usagePageLabelsRemove = "pdfcpu pagelabels remove inFile [outFile]"
+
+	usagePageLabels = "usage: " + usagePageLabelsList +
+		"\n       " + usagePageLabelsSet +
+		"\n       " + usagePageLabelsRemove + generalFlags
+
+	usageLongPageLabels = `Manage page labels (logical page numbering):
+
+     inFile ... input PDF file
+     labels ... comma separated list of page label ranges: pageFrom:style[:prefix[:start]]
+    outFile ... output PDF file
+
+   pageFrom ... first physical page of the range
+      style ... one of:
+
+          D ... decimal arabic numerals: 1, 2, 3
+          R ... uppercase roman numerals: I, II, III
+          r ... lowercase roman numerals: i, ii, iii
+          A ... uppercase letters: A to Z, then AA to ZZ..
+          a ... lowercase letters: a to z, then aa to zz..
+            ... empty for prefix only labels
+
+     prefix ... label prefix
+      start ... numeric value of the first label of the range, defaults to 1
+
+    A range extends to the page preceding the next range.
+    Page labels may also be used for page selection via api.PageSelectionForLabels, eg. "iii" or "A-1-A-5".
+
+    Eg. label the front matter using roman numerals, the main part starting with 1 and the appendix using A-1, A-2..
+           pdfcpu pagelabels set test.pdf "1:r, 5:D, 20:D:A-"
+
+        list page labels:
+           pdfcpu pagelabels list test.pdf
+
+        remove page labels:
+           pdfcpu pagelabels remove test.pdf
+`
+
+	usageTransitionsList       = "pdfcpu transitions list       [-p(ages) selectedPages] -- inFile"
+	usageTransitionsSet        = "pdfcpu transitions set        [-p(ages) selectedPages] -- inFile description [outFile]"
+	usageTransitionsRemove     = "pdfcpu transitions remove     [-p(ages) selectedPages] -- inFile [outFile]"
+	usageTransitionsFullScreen = "pdfcpu transitions fullscreen                             inFile [outFile]"
+
+	usageTransitions = "usage: " + usageTransitionsList +
+		"\n       " + usageTransitionsSet +
+		"\n       " + usageTransitionsRemove +
+		"\n       " + usageTransitionsFullScreen + generalFlags
+
+	usageLongTransitions = `Manage page transitions and auto advance for presentations:
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     inFile ... input PDF file
+description ... comma separated list of parameter:value pairs
+    outFile ... output PDF file
+
+    parameters:
+
+         style ... Split, Blinds, Box, Wipe, Dissolve, Glitter, Replace,
+                   Fly, Push, Cover, Uncover, Fade (since PDF 1.5)
+      duration ... transition duration in seconds, defaults to 1
+     dimension ... h(orizontal), v(ertical) for Split and Blinds
+        motion ... i(nward), o(utward) for Split, Box and Fly
+     direction ... 0, 90, 180, 270 degrees counterclockwise starting left to right for Wipe, Fly, Push, Cover, Uncover
+                   0, 270, 315 for Glitter
+                   none for Fly
+         scale ... starting or ending scale for Fly, defaults to 1
+        opaque ... on/off true/false t/f for Fly
+       advance ... seconds to display the page before advancing to the next page
+
+    An auto advance without style keeps the page's transition.
+    fullscreen opens the document in full screen mode and keeps the current page mode for leaving full screen mode.
+
+    Eg. dissolve all pages within 0.5 seconds and advance every 5 seconds:
+           pdfcpu transitions set -- test.pdf "style:dissolve, dur:0.5, adv:5"
+
+        vertical outward split for page 2:
+           pdfcpu transitions set -p 2 -- test.pdf "sty:split, dim:v, mot:o"
+
+        list page transitions:
+           pdfcpu transitions list test.pdf
+
+        remove page transitions from page 3-5:
+           pdfcpu transitions remove -p 3-5 -- test.pdf
+
+        open the document in full screen mode:
+           pdfcpu transitions fullscreen test.pdf
 `
 
 	usagePageLayoutList  = "pdfcpu pagelayout list  inFile"
@@ -1545,6 +2226,7 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
      value ... one of:
 
      SinglePage     ... Display one page at a time (default)
+     OneColumn      ... Display the pages in one column
      TwoColumnLeft  ... Display the pages in two columns, with odd- numbered pages on the left
      TwoColumnRight ... Display the pages in two columns, with odd- numbered pages on the right
      TwoPageLeft    ... Display the pages two at a time, with odd-numbered pages on the left
@@ -1640,11 +2322,23 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
                                 the first and last pages in a sub-range of pages to be printed. The first page of the PDF file shall be denoted by 1.
       
       NumCopies             ... The number of copies that shall be printed when the print dialog is opened for this file (since PDF 1.7).
+
+      PrintScaling          ... The page scaling option that shall be selected when a print dialog is displayed for this file (since PDF 1.6):
+                                    None        = No page scaling
+                                    AppDefault  = The PDF processor's default print scaling (=default)
      
       Enforce               ... Array of names of Viewer preference settings that shall be enforced by PDF processors and
                                 that shall not be overridden by subsequent selections in the application user interface (since PDF 2.0).
                                     Possible values: PrintScaling
 
+      PageLayout            ... The page layout which shall be used when the document is opened (catalog entry):
+                                    SinglePage, OneColumn, TwoColumnLeft, TwoColumnRight, TwoPageLeft, TwoPageRight
+                                    Please refer to "pdfcpu help pagelayout"
+
+      PageMode              ... How the document shall be displayed when opened (catalog entry):
+                                    UseNone, UseOutlines, UseThumbs, FullScreen, UseOC, UseAttachments
+                                    Please refer to "pdfcpu help pagemode"
+
     Eg. list viewer preferences:
          pdfcpu viewerpref list test.pdf
          pdfcpu viewerpref list -all test.pdf
@@ -1680,9 +2374,12 @@ description ... scalefactor, dimensions, formsize, enforce, border, bgcolor
                   10, 20
                ],
                "NumCopies": 3,
+               "PrintScaling": "None",
                "Enforce": [
                   "PrintScaling"
-               ]
+               ],
+               "PageLayout": "TwoColumnLeft",
+               "PageMode": "UseOutlines"
             }
          }
    
@@ -1757,4 +2454,413 @@ Examples:
                                         timeoutOCSP,
                                         preferredCertRevocationChecker
 `
+
+	usageImpose     = "usage: pdfcpu impose [-p(ages) selectedPages] -- inFile inFileJSON outFile" + generalFlags
+	usageLongImpose = `Arrange pages onto sheets according to a user defined imposition layout.
+Use this for impositions not covered by nup or booklet eg. calendars, gatefolds or 3-panel brochures.
+
+        pages ... Please refer to "pdfcpu selectedpages"
+       inFile ... input PDF file
+   inFileJSON ... JSON imposition template
+      outFile ... output PDF file
+
+The imposition template defines a sheet, a grid of cells and the cells of each sheet side:
+
+   {
+      "name": "3-panel brochure",
+      "paperSize": "A4L",
+      "unit": "mm",
+      "rows": 1,
+      "cols": 3,
+      "colWidths": [1, 1, 0.97],
+      "margin": 5,
+      "marks": "crop fold",
+      "sides": [
+         {"cells": [{"row":0, "col":0, "page":"6*i+5"}, {"row":0, "col":1, "page":"6*i+6"}, {"row":0, "col":2, "page":"6*i+1"}]},
+         {"cells": [{"row":0, "col":0, "page":"6*i+2"}, {"row":0, "col":1, "page":"6*i+3"}, {"row":0, "col":2, "page":"6*i+4"}]}
+      ]
+   }
+
+   paperSize:      sheet size, eg. A4, Letter, A3L (Please refer to "pdfcpu paper")
+   dimensions:     sheet dimensions [width, height] in given unit, overrides paperSize
+   unit:           points, inches, cm, mm (default: display unit in effect)
+   rows, cols:     grid dimensions
+   colWidths:      optional relative column widths
+   rowHeights:     optional relative row heights
+   margin:         cell content margin
+   border:         draw cell borders (true/false)
+   marks:          printer's marks: crop reg fold slug (or all)
+   pagesPerSheet:  pages consumed per sheet (default: number of cells of all sides)
+   sides:          the sheet sides in print order, each with a list of cells:
+
+      row, col:    grid position, starting with 0 at the top left
+      page:        page number formula, integer expression using + - * / % ( ) and the variables:
+                      i ... sheet number starting with 0
+                      n ... page count rounded up to a multiple of pagesPerSheet
+                      p ... pagesPerSheet
+                   Page numbers outside of the selected pages result in a blank cell.
+      rotate:      counter clockwise content rotation: 0, 90, 180, 270
+      dx, dy:      cell offset in given unit
+
+Examples:
+
+   pdfcpu impose in.pdf brochure.json out.pdf
+      Impose all pages of in.pdf as defined in brochure.json.
+
+   pdfcpu impose -pages 1-12 -- in.pdf calendar.json out.pdf
+      Impose the first 12 pages of in.pdf as defined in calendar.json.
+`
+
+	usageDuplex     = "usage: pdfcpu duplex [-p(ages) selectedPages] [-m(ode) reverse|forward] inFile outFile [outFileBacks]" + generalFlags
+	usageLongDuplex = `Reorder pages for double sided printing on printers without a duplexer.
+The odd pages are followed by the even pages, so you can print the front sides first
+and then reload the printed stack for printing the back sides.
+
+        pages ... Please refer to "pdfcpu selectedpages"
+         mode ... the order of the back sides:
+                     reverse ... even pages in reverse order (=default)
+                     forward ... even pages in order
+       inFile ... input PDF file
+      outFile ... output PDF file
+ outFileBacks ... optional output PDF file for the back sides,
+                  if present outFile only contains the front sides.
+
+For an odd page count a blank back side is added for the last sheet.
+A single page has no back sides and outFileBacks is not written.
+
+Examples:
+
+   pdfcpu duplex in.pdf out.pdf
+      Write the odd pages followed by the even pages in reverse order to out.pdf.
+
+   pdfcpu duplex -m forward in.pdf fronts.pdf backs.pdf
+      Write the odd pages to fronts.pdf and the even pages in order to backs.pdf.
+`
+
+	usageCover     = "usage: pdfcpu cover [-u(nit)] -- description outFile" + generalFlags
+	usageLongCover = `Create a one page cover spread (back cover, spine, front cover) for a perfect bound book.
+The spine width is computed from the page count and the paper thickness.
+
+description ... page count, trim size, paper, bleed, artwork
+    outFile ... output PDF file
+
+  <description> is a comma separated configuration string containing:
+
+  mandatory entries:
+
+      pages:       the number of book pages (the spine holds pages/2 leaves)
+
+  optional entries:
+
+      (defaults: "formsize:A5, grammage:80, bulk:1.25, bleed:3mm, guides:off")
+
+      formsize:    trim size of the book pages eg. A5, Letter...
+                   Please refer to "pdfcpu paper" for a comprehensive list of defined paper sizes.
+                   "papersize" is also accepted.
+      dimensions:  trim size of the book pages (width height) in given display unit eg. '400 600'
+      caliper:     paper thickness per leaf in given display unit, overrides grammage and bulk
+      grammage:    paper weight in g/m²
+      bulk:        paper volume in cm³/g
+      bleed:       bleed in given display unit
+      guides:      on/off true/false t/f, draw the trim box and the spine folds
+      front:       image file for the front cover
+      back:        image file for the back cover
+      bgcolor:     background color as 3 RGB values in 0.0 <= c <= 1.0,
+                   or as 3 or 6 digit hex value, eg. #FF0000
+
+      The caliper in µm equals grammage * bulk, eg. 80 g/m² * 1.25 cm³/g = 100 µm.
+      spine width = ceil(pages / 2) * caliper
+
+      Artwork is scaled to fill its panel including bleed, preserving the aspect ratio.
+      The resulting page has TrimBox and BleedBox set.
+
+Examples:
+
+   pdfcpu cover -- "pages:240, formsize:A5" cover.pdf
+      Create the cover spread for a 240 page A5 book printed on 80 g/m² paper,
+      resulting in a spine width of 12mm.
+
+   pdfcpu cover -u mm -- "pages:320, dim:170 240, caliper:0.09, bleed:5, front:front.jpg, back:back.png, guides:on" cover.pdf
+      Create the cover spread for a 320 page book using a trim size of 170 x 240 mm
+      with a caliper of 90 µm, a bleed of 5 mm and artwork for the front and back cover.
+`
+	usageBates     = "usage: pdfcpu bates [-p(ages) selectedPages] -- config description outDir inFile..." + generalFlags
+	usageLongBates = `Stamp selected pages of all inFiles with consecutive Bates numbers.
+The numbering continues across inFiles in the given order.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     config ... prefix, suffix, start, digits
+description ... stamp description for position, font etc., please refer to "pdfcpu help stamp"
+     outDir ... output directory, must not contain inFiles
+     inFile ... input PDF file
+
+The stamped files are written to outDir using their original names
+together with the manifest bates.csv mapping file and page to Bates number.
+
+  <config> is a comma separated configuration string containing these optional entries:
+
+      (defaults: "start:1, digits:6")
+
+      prefix:      text preceding the counter
+      suffix:      text following the counter
+      start:       first Bates number >= 0
+      digits:      minimal number of digits, the counter is padded with leading zeros
+
+  <description> defaults to: "font:Helvetica, points:10, color:#000000, rot:0, pos:br"
+                 Unless an offset is given, Bates numbers are moved 20 points from the page edges towards the center.
+
+Examples:
+
+   pdfcpu bates -- "prefix:ACME" "" out in1.pdf in2.pdf
+      Stamp ACME000001, ACME000002.. continuing from in1.pdf to in2.pdf.
+
+   pdfcpu bates -- "prefix:CASE-, start:1001, digits:7, suffix:-C" "pos:bl, off:20 20, points:8" out *.pdf
+      Stamp CASE-0001001-C.. onto the lower left corner of all PDF files in the current directory.
+`
+
+	usageHeaderFooter     = "usage: pdfcpu headerfooter [-p(ages) selectedPages] -- config description inFile [outFile]" + generalFlags
+	usageLongHeaderFooter = `Add headers and footers to selected pages.
+
+      pages ... Please refer to "pdfcpu selectedpages"
+     config ... header, footer, oddheader, oddfooter, evenheader, evenfooter, margin, inset, dateformat
+description ... stamp description for font, points, colors etc., please refer to "pdfcpu help stamp"
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  <config> is a comma separated configuration string containing these entries:
+
+      (defaults: "margin:36, inset:36, dateformat:2006-01-02" in points)
+
+      header:      left|center|right slots for all pages
+      footer:      left|center|right slots for all pages
+      oddheader:   slots for odd pages, overrides header
+      oddfooter:   slots for odd pages, overrides footer
+      evenheader:  slots for even pages, overrides header
+      evenfooter:  slots for even pages, overrides footer
+      margin:      distance between headers/footers and the top/bottom page edge in given display unit
+      inset:       distance between left/right slots and the left/right page edge in given display unit
+      dateformat:  Go time layout for %date, eg. 02.01.2006
+
+  Slots may be left empty, eg. "footer:|%page|" and use these variables:
+
+      %page       current page number
+      %pages      total pages
+      %filename   name of the input file
+      %date       current date
+      %bookmark   title of the bookmark covering the current page
+
+  Use \, for a comma within slot text. Slot text may use the inline style tags of text stamps.
+
+  <description> defaults to: "font:Helvetica, points:10, color:#000000".
+                 Position and offset are determined by slot, margin and inset.
+
+Headers and footers are added as stamps and may be removed using "pdfcpu stamp remove".
+
+Examples:
+
+   pdfcpu headerfooter -- "footer:|Page %page of %pages|" "" in.pdf out.pdf
+      Center a page counter within the footer.
+
+   pdfcpu headerfooter -- "header:%filename||%date, oddfooter:||%page, evenfooter:%page||" "points:9" in.pdf
+      Add a header with filename and date and put page numbers onto the outer edges.
+
+   pdfcpu headerfooter -u mm -- "header:|<b>%bookmark</b>|, margin:10, inset:15" "" in.pdf out.pdf
+      Put the current chapter title into the header.
+`
+
+	usageInvoice     = "usage: pdfcpu invoice -- [description] inFile inFileXML [outFile]" + generalFlags
+	usageLongInvoice = `Embed an XML invoice producing a Factur-X / ZUGFeRD hybrid invoice.
+The XML is embedded as associated file and the document metadata is extended accordingly.
+
+description ... profile, file name, relationship, description, version
+     inFile ... input PDF file
+  inFileXML ... Cross Industry Invoice (CII) XML file
+    outFile ... output PDF file
+
+  <description> is a comma separated configuration string containing these optional entries:
+
+      (defaults: "description:Factur-X invoice, version:1.0")
+
+      profile:       minimum, basicwl, basic, en16931, extended, xrechnung (default: detected from inFileXML)
+      name:          name of the embedded file (default: factur-x.xml, xrechnung.xml for profile xrechnung)
+      relationship:  Source, Data, Alternative, Supplement, Unspecified
+                     (default: Data for profiles minimum and basicwl, Alternative otherwise)
+      description:   description of the embedded file
+      version:       Factur-X version
+
+  The document is declared PDF/A-3b unless its metadata declares PDF/A-3 already.
+  Other PDF/A requirements like embedded fonts or an output intent have to be met by inFile.
+
+Examples:
+
+   pdfcpu invoice -- in.pdf factur-x.xml out.pdf
+      Embed factur-x.xml using the profile found in the XML.
+
+   pdfcpu invoice -- "rel:source, desc:Rechnung 4711" in.pdf factur-x.xml
+      Embed factur-x.xml as source of the document and update in.pdf.
+`
+
+	usageTOC     = "usage: pdfcpu toc [-u(nit) -tag] -- [description] inFile [outFile]" + generalFlags
+	usageLongTOC = `Insert table of contents pages generated from the bookmarks.
+Each entry links to its bookmark destination and shows the page number using dot leaders.
+
+        tag ... build a structure tree for the table of contents (tagged PDF)
+description ... title, insertion point, levels, font, layout
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  <description> is a comma separated configuration string containing these optional entries:
+
+      (defaults: "title:Contents, page:1, levels:0, fontname:Helvetica, points:12, indent:20, margin:72, labels:on")
+
+      title:       heading of the first table of contents page, may be empty
+      page:        the page number the table of contents pages are inserted before or "end"
+      levels:      the number of bookmark levels covered, 0 covers all levels
+      fontname:    Please refer to "pdfcpu fonts list"
+      points:      font size in points for the entries
+      indent:      indentation per bookmark level in given display unit
+      margin:      page margin in given display unit
+      labels:      on/off true/false t/f, create page labels for files without any
+
+  The table of contents pages take the size of the page they are inserted before.
+  They are labeled i, ii, iii.. and all other pages keep their page labels.
+  Existing page labels are always preserved.
+
+  Bookmarks are not affected, use "pdfcpu bookmarks" to manage them or to detect bookmarks from headings.
+
+Examples:
+
+   pdfcpu toc -- in.pdf out.pdf
+      Insert a table of contents in front of the first page.
+
+   pdfcpu toc -- "title:Table of Contents, levels:2, page:3" in.pdf
+      Insert a table of contents covering 2 bookmark levels before page 3.
+
+   pdfcpu toc -- "page:end, labels:off" in.pdf out.pdf
+      Append a table of contents.
+
+   pdfcpu toc -tag -- in.pdf out.pdf
+      Insert a tagged table of contents made of TOCI entries each containing a Link.
+`
+
+	usageConvertCMYK = "pdfcpu convert cmyk -- description inFile [outFile]"
+	usageConvertGray = "pdfcpu convert gray inFile [outFile]"
+
+	usageConvert = "usage: " + usageConvertCMYK +
+		"\n       " + usageConvertGray + generalFlags
+
+	usageLongConvert = `Convert the colors of a PDF file for prepress delivery or into gray.
+
+description ... source and destination profiles, rendering intent
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  <description> is a comma separated configuration string containing these entries:
+
+      (defaults: "src:sRGB, intent:relative, outputintent:on")
+
+      src:          RGB ICC profile file used for DeviceRGB colors
+      dest:         CMYK ICC profile file of the output condition (required)
+      intent:       perceptual, relative, saturation
+      outputintent: on/off true/false t/f, embed dest as output intent unless the file has one
+
+  cmyk converts DeviceRGB fill and stroke colors of page content, form XObjects, patterns and
+  Type 3 glyphs as well as RGB images, shadings, indexed color spaces and the alternate color spaces
+  of separation and DeviceN colors into DeviceCMYK.
+
+  gray converts RGB and CMYK colors, images, shadings and indexed color spaces into DeviceGray.
+  Separation and DeviceN colors are replaced by the gray level of their alternate color.
+
+  Inline images, 16 bit images, images using a decode array,
+  mesh shadings without function and PostScript calculator functions are left alone.
+
+Examples:
+
+   pdfcpu convert cmyk -- "dest:coated.icc" in.pdf out.pdf
+      Convert sRGB colors using coated.icc and embed it as output intent.
+
+   pdfcpu convert cmyk -- "src:AdobeRGB1998.icc, dest:uncoated.icc, intent:perceptual" in.pdf
+      Convert Adobe RGB colors perceptually and update in.pdf.
+
+   pdfcpu convert gray in.pdf out.pdf
+      Create a grayscale version of in.pdf.
+`
+
+	usageFlattenTransparency = "pdfcpu flatten transparency inFile [outFile]"
+
+	usageFlatten = "usage: " + usageFlattenTransparency + generalFlags
+
+	usageLongFlatten = `Flatten transparency for print workflows and viewers lacking transparency support.
+
+     inFile ... input PDF file
+    outFile ... output PDF file
+
+  transparency makes all content opaque assuming it gets painted on a white page:
+
+      Colors painted with constant alpha are blended with white.
+      Images painted on white are composited with their soft masks.
+      Blend modes are reset to Normal and transparency groups are removed.
+
+  Flattening other transparency needs rasterization which is not supported.
+  The command fails for transparent content painted over other content or within forms,
+  patterns and glyphs, content using soft masks or blend modes other than Normal and
+  images, shadings and forms painted with constant alpha.
+
+Examples:
+
+   pdfcpu flatten transparency in.pdf out.pdf
+      Create an opaque version of in.pdf.
+`
+
+	usagePipelineRun = "pdfcpu pipeline run jobFile"
+
+	usagePipeline = "usage: " + usagePipelineRun + generalFlags
+
+	usageLongPipeline = `Run a processing pipeline described by a job file.
+
+    jobFile ... JSON or YAML job file, relative paths are resolved against its directory
+
+    Each input file is read once, all steps are applied in order and the result is written once.
+
+    input:   input files, may contain glob patterns
+    output:  output directory or for a single input file the output file,
+             defaults to processing input files in place
+    steps:   operations in order of execution, each with optional pages and options:
+
+      decrypt    upw, opw                      must be the first step
+      remove     (pages required)
+      rotate     rotation
+      stamp      mode (text, image, pdf), string, desc
+      watermark  mode (text, image, pdf), string, desc
+      optimize
+      encrypt    upw, opw, mode (rc4, aes), key (40, 128, 256), perm (none, print, all)
+                                               must be the last step
+
+    Option values are strings, for stamp and watermark please refer to "pdfcpu stamp".
+
+    job.yaml:
+    input:
+      - in/*.pdf
+    output: out
+    steps:
+      - op: decrypt
+        options:
+          upw: secret
+      - op: remove
+        pages: ["1"]
+      - op: stamp
+        options:
+          string: Confidential
+          desc: "scale:.8, rot:45, op:.4"
+      - op: optimize
+      - op: encrypt
+        options:
+          opw: owner
+          perm: print
+
+Examples:
+
+   pdfcpu pipeline run job.yaml
+      Process all files matching in/*.pdf and write the results to out.
+`
 )
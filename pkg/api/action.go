@@ -0,0 +1,356 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForActions(rs io.ReadSeeker, cmd model.CommandMode, selectedPages []string, conf *model.Configuration) (*model.Context, map[int]bool, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ctx, pages, conf, nil
+}
+
+// DocumentActions returns rs's open action and document additional actions mapped by trigger.
+func DocumentActions(rs io.ReadSeeker, conf *model.Configuration) (map[string]model.Action, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: DocumentActions: missing rs")
+	}
+
+	ctx, _, _, err := readContextForActions(rs, model.LISTACTIONS, nil, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.DocumentActions(ctx)
+}
+
+// DocumentActionsFile returns inFile's open action and document additional actions mapped by trigger.
+func DocumentActionsFile(inFile string, conf *model.Configuration) (map[string]model.Action, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DocumentActions(f, conf)
+}
+
+// PageActions returns the additional actions of selected pages of rs.
+func PageActions(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]model.PageAction, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageActions: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForActions(rs, model.LISTACTIONS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.PageActions(ctx, pages)
+}
+
+// PageActionsFile returns the additional actions of selected pages of inFile.
+func PageActionsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]model.PageAction, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageActions(f, selectedPages, conf)
+}
+
+// ListActions lists rs's document actions and the additional actions of selected pages.
+func ListActions(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListActions: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForActions(rs, model.LISTACTIONS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListActions(ctx, pages)
+}
+
+// ListActionsFile lists inFile's document actions and the additional actions of selected pages.
+func ListActionsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListActions(f, selectedPages, conf)
+}
+
+// SetOpenAction sets the action performed when rs is opened and writes the result to w.
+func SetOpenAction(rs io.ReadSeeker, w io.Writer, a model.Action, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetOpenAction: missing rs")
+	}
+
+	ctx, _, conf, err := readContextForActions(rs, model.SETACTIONS, nil, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetOpenAction(ctx, a); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetOpenActionFile sets the action performed when inFile is opened and writes the result to outFile.
+func SetOpenActionFile(inFile, outFile string, a model.Action, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetOpenAction(f1, f2, a, conf)
+}
+
+// RemoveDocumentActions removes rs's open action and document additional actions for triggers and writes the result to w.
+// All document actions are removed if triggers is empty.
+func RemoveDocumentActions(rs io.ReadSeeker, w io.Writer, triggers []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveDocumentActions: missing rs")
+	}
+
+	ctx, _, conf, err := readContextForActions(rs, model.REMOVEACTIONS, nil, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RemoveDocumentActions(ctx, triggers)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("pdfcpu: no document actions available")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveDocumentActionsFile removes inFile's open action and document additional actions for triggers and writes the result to outFile.
+func RemoveDocumentActionsFile(inFile, outFile string, triggers []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveDocumentActions(f1, f2, triggers, conf)
+}
+
+// SetPageActions sets the action performed on trigger for selected pages of rs and writes the result to w.
+func SetPageActions(rs io.ReadSeeker, w io.Writer, selectedPages []string, trigger string, a model.Action, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPageActions: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForActions(rs, model.SETACTIONS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetPageActions(ctx, pages, trigger, a); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetPageActionsFile sets the action performed on trigger for selected pages of inFile and writes the result to outFile.
+func SetPageActionsFile(inFile, outFile string, selectedPages []string, trigger string, a model.Action, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPageActions(f1, f2, selectedPages, trigger, a, conf)
+}
+
+// RemovePageActions removes the additional actions for triggers from selected pages of rs and writes the result to w.
+// All page actions are removed if triggers is empty.
+func RemovePageActions(rs io.ReadSeeker, w io.Writer, selectedPages []string, triggers []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePageActions: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForActions(rs, model.REMOVEACTIONS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RemovePageActions(ctx, pages, triggers)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("pdfcpu: no page actions available")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemovePageActionsFile removes the additional actions for triggers from selected pages of inFile and writes the result to outFile.
+func RemovePageActionsFile(inFile, outFile string, selectedPages []string, triggers []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemovePageActions(f1, f2, selectedPages, triggers, conf)
+}
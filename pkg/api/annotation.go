@@ -17,8 +17,10 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"io"
 	"os"
+	"path/filepath"
 
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -276,6 +278,188 @@ func AddAnnotationsMapFile(inFile, outFile string, m map[int][]model.AnnotationR
 	return AddAnnotationsMap(f1, f2, m, conf)
 }
 
+// AddAnnotationsJSON adds the annotations described by the JSON spec read from rd to rs and writes the result to w.
+// Relative file names of file attachments are resolved against the current directory.
+func AddAnnotationsJSON(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) error {
+	if rd == nil {
+		return errors.New("pdfcpu: AddAnnotationsJSON: missing rd")
+	}
+
+	m, err := pdfcpu.ParseAnnotationsJSON(rd, "")
+	if err != nil {
+		return err
+	}
+
+	return AddAnnotationsMap(rs, w, m, conf)
+}
+
+// AddAnnotationsJSONFile adds the annotations described by jsonFile to inFile and writes the result to outFile.
+// Relative file names of file attachments are resolved against the directory of jsonFile.
+func AddAnnotationsJSONFile(inFile, jsonFile, outFile string, conf *model.Configuration, incr bool) error {
+	f, err := os.Open(jsonFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	m, err := pdfcpu.ParseAnnotationsJSON(f, filepath.Dir(jsonFile))
+	if err != nil {
+		return err
+	}
+
+	return AddAnnotationsMapFile(inFile, outFile, m, conf, incr)
+}
+
+// ExportAnnotations writes the markup annotations of selected pages of rs including replies and review states as JSON to w.
+func ExportAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExportAnnotations: missing rs")
+	}
+
+	if w == nil {
+		return errors.New("pdfcpu: ExportAnnotations: missing w")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	spec, err := pdfcpu.ExportAnnotations(ctx, pages)
+	if err != nil {
+		return err
+	}
+
+	bb, err := json.MarshalIndent(spec, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.Write(append(bb, '\n'))
+	return err
+}
+
+// ExportAnnotationsFile writes the markup annotations of selected pages of inFile including replies and review states to outFileJSON.
+func ExportAnnotationsFile(inFile, outFileJSON string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+	defer f1.Close()
+
+	if f2, err = os.Create(outFileJSON); err != nil {
+		return err
+	}
+	logWritingTo(outFileJSON)
+
+	defer func() {
+		if cerr := f2.Close(); err == nil {
+			err = cerr
+		}
+	}()
+
+	return ExportAnnotations(f1, f2, selectedPages, conf)
+}
+
+// ImportAnnotations adds the annotations read as JSON from rd including replies and review states to rs and writes the result to w.
+// Annotations whose id is already present on their page are merged with new replies instead of being added again.
+// Relative file names of file attachments are resolved against the current directory.
+func ImportAnnotations(rs io.ReadSeeker, rd io.Reader, w io.Writer, conf *model.Configuration) (int, error) {
+	return importAnnotations(rs, rd, "", w, conf)
+}
+
+func importAnnotations(rs io.ReadSeeker, rd io.Reader, dir string, w io.Writer, conf *model.Configuration) (int, error) {
+	if rs == nil {
+		return 0, errors.New("pdfcpu: ImportAnnotations: missing rs")
+	}
+
+	if rd == nil {
+		return 0, errors.New("pdfcpu: ImportAnnotations: missing rd")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPORTANNOTATIONS
+
+	var spec pdfcpu.AnnotationsSpec
+	if err := json.NewDecoder(rd).Decode(&spec); err != nil {
+		return 0, errors.Wrap(err, "pdfcpu: invalid annotations JSON")
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := pdfcpu.ImportAnnotations(ctx, &spec, dir)
+	if err != nil {
+		return 0, err
+	}
+
+	return n, Write(ctx, w, conf)
+}
+
+// ImportAnnotationsFile adds the annotations of inFileJSON including replies and review states to inFile and writes the result to outFile.
+// Relative file names of file attachments are resolved against the directory of inFileJSON.
+func ImportAnnotationsFile(inFile, inFileJSON, outFile string, conf *model.Configuration) (err error) {
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFileJSON); err != nil {
+		return err
+	}
+	defer f0.Close()
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	_, err = importAnnotations(f1, f0, filepath.Dir(inFileJSON), f2, conf)
+	return err
+}
+
 // RemoveAnnotations removes annotations for selected pages by id and object number
 // from a PDF context read from rs and writes the result to w.
 func RemoveAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages, idsAndTypes []string, objNrs []int, conf *model.Configuration) error {
@@ -400,3 +584,80 @@ func RemoveAnnotationsFile(inFile, outFile string, selectedPages, idsAndTypes []
 
 	return RemoveAnnotations(f1, f2, selectedPages, idsAndTypes, objNrs, conf)
 }
+
+// FlattenAnnotations burns the appearances of annotations of selected pages into the page content
+// of a PDF context read from rs, removes the flattened annotations and writes the result to w.
+// Annotations may be filtered by id, type and object number.
+func FlattenAnnotations(rs io.ReadSeeker, w io.Writer, selectedPages, idsAndTypes []string, objNrs []int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FlattenAnnotations: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENANNOTATIONS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.FlattenAnnotations(ctx, pages, idsAndTypes, objNrs)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("pdfcpu: FlattenAnnotations: No annotation flattened")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// FlattenAnnotationsFile burns the appearances of annotations of selected pages of inFile into the page content
+// and writes the result to outFile.
+func FlattenAnnotationsFile(inFile, outFile string, selectedPages, idsAndTypes []string, objNrs []int, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return FlattenAnnotations(f1, f2, selectedPages, idsAndTypes, objNrs, conf)
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForArticles(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, conf, nil
+}
+
+// Articles returns the article threads of rs.
+func Articles(rs io.ReadSeeker, conf *model.Configuration) ([]model.Article, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Articles: missing rs")
+	}
+
+	ctx, _, err := readContextForArticles(rs, model.LISTARTICLES, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Articles(ctx)
+}
+
+// ArticlesFile returns the article threads of inFile.
+func ArticlesFile(inFile string, conf *model.Configuration) ([]model.Article, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Articles(f, conf)
+}
+
+// ListArticles lists the article threads of rs.
+func ListArticles(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListArticles: missing rs")
+	}
+
+	ctx, _, err := readContextForArticles(rs, model.LISTARTICLES, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListArticles(ctx)
+}
+
+// ListArticlesFile lists the article threads of inFile.
+func ListArticlesFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListArticles(f, conf)
+}
+
+// AddArticles adds article threads to rs and writes the result to w.
+func AddArticles(rs io.ReadSeeker, w io.Writer, articles []model.Article, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddArticles: missing rs")
+	}
+
+	if len(articles) == 0 {
+		return errors.New("pdfcpu: AddArticles: missing articles")
+	}
+
+	ctx, conf, err := readContextForArticles(rs, model.ADDARTICLES, conf)
+	if err != nil {
+		return err
+	}
+
+	for i, a := range articles {
+		if err := pdfcpu.AddArticle(ctx, a); err != nil {
+			return errors.Wrapf(err, "article %d", i+1)
+		}
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddArticlesFile adds article threads to inFile and writes the result to outFile.
+func AddArticlesFile(inFile, outFile string, articles []model.Article, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddArticles(f1, f2, articles, conf)
+}
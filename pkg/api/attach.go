@@ -18,6 +18,7 @@ package api
 
 import (
 	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
 	"strings"
@@ -49,6 +50,20 @@ func Attachments(rs io.ReadSeeker, conf *model.Configuration) ([]model.Attachmen
 // AddAttachments embeds files into a PDF context read from rs and writes the result to w.
 // file is either a file name or a file name and a description separated by a comma.
 func AddAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, conf *model.Configuration) error {
+	open := func(name string) (fs.File, error) { return os.Open(name) }
+	return addAttachments(rs, w, files, coll, open, conf)
+}
+
+// AddAttachmentsFS embeds files read from fsys into a PDF context read from rs and writes the result to w.
+// file is either a file name or a file name and a description separated by a comma.
+func AddAttachmentsFS(rs io.ReadSeeker, w io.Writer, fsys fs.FS, files []string, coll bool, conf *model.Configuration) error {
+	if fsys == nil {
+		return errors.New("pdfcpu: AddAttachmentsFS: missing fsys")
+	}
+	return addAttachments(rs, w, files, coll, fsys.Open, conf)
+}
+
+func addAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, open func(string) (fs.File, error), conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: AddAttachments: missing rs")
 	}
@@ -84,7 +99,7 @@ func AddAttachments(rs io.ReadSeeker, w io.Writer, files []string, coll bool, co
 		if log.CLIEnabled() {
 			log.CLI.Printf("adding %s\n", fileName)
 		}
-		f, err := os.Open(fileName)
+		f, err := open(fileName)
 		if err != nil {
 			return err
 		}
@@ -236,28 +251,15 @@ func ExtractAttachmentsRaw(rs io.ReadSeeker, outDir string, fileNames []string,
 	return ctx.ExtractAttachments(fileNames)
 }
 
-// ExtractAttachments extracts embedded files from a PDF context read from rs into outDir.
-func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, conf *model.Configuration) error {
-	aa, err := ExtractAttachmentsRaw(rs, outDir, fileNames, conf)
+// ExtractAttachmentsTo writes embedded files from a PDF context read from rs to out.
+func ExtractAttachmentsTo(rs io.ReadSeeker, out Output, fileNames []string, conf *model.Configuration) error {
+	aa, err := ExtractAttachmentsRaw(rs, "", fileNames, conf)
 	if err != nil {
 		return err
 	}
 
 	for _, a := range aa {
-		fileName := filepath.Join(outDir, a.FileName)
-		f, err := os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-		if err != nil {
-			fileName = filepath.Base(a.FileName)
-			f, err = os.OpenFile(fileName, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, os.ModePerm)
-			if err != nil {
-				return err
-			}
-		}
-		logWritingTo(fileName)
-		if _, err = io.Copy(f, a); err != nil {
-			return err
-		}
-		if err := f.Close(); err != nil {
+		if err := writeOutput(out, a.FileName, a); err != nil {
 			return err
 		}
 	}
@@ -265,6 +267,19 @@ func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, con
 	return nil
 }
 
+// ExtractAttachments extracts embedded files from a PDF context read from rs into outDir.
+func ExtractAttachments(rs io.ReadSeeker, outDir string, fileNames []string, conf *model.Configuration) error {
+	out := DirOutput(outDir)
+	return ExtractAttachmentsTo(rs, func(name string) (io.WriteCloser, error) {
+		w, err := out(name)
+		if err != nil {
+			// Fall back to the base name within the current directory.
+			return DirOutput("")(filepath.Base(name))
+		}
+		return w, nil
+	}, fileNames, conf)
+}
+
 // ExtractAttachmentsFile extracts embedded files from a PDF context read from inFile into outDir.
 func ExtractAttachmentsFile(inFile, outDir string, files []string, conf *model.Configuration) error {
 	f, err := os.Open(inFile)
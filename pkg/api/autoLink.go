@@ -0,0 +1,101 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddAutoLinks creates link annotations for URLs and email addresses found in the text of selected pages of rs
+// and writes the result to w. Returns the number of links created.
+func AddAutoLinks(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) (int, error) {
+	if rs == nil {
+		return 0, errors.New("pdfcpu: AddAutoLinks: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDAUTOLINKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := pdfcpu.AddAutoLinks(ctx, pages)
+	if err != nil {
+		return 0, err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("added %d link(s)\n", n)
+	}
+
+	return n, Write(ctx, w, conf)
+}
+
+// AddAutoLinksFile creates link annotations for URLs and email addresses found in the text of selected pages of inFile
+// and writes the result to outFile.
+func AddAutoLinksFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	_, err = AddAutoLinks(f1, f2, selectedPages, conf)
+	return err
+}
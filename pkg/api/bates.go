@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddBatesNumbers stamps selected pages of rs with consecutive Bates numbers starting with b.Next and writes the result to w.
+// b.Next is advanced accordingly, so subsequent calls continue the numbering.
+// The returned entries map the stamped pages of fileName to their Bates numbers.
+func AddBatesNumbers(rs io.ReadSeeker, w io.Writer, fileName string, selectedPages []string, b *model.Bates, conf *model.Configuration) ([]model.BatesEntry, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: AddBatesNumbers: missing rs")
+	}
+
+	if b == nil {
+		return nil, errors.New("pdfcpu: AddBatesNumbers: missing b")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.BATES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	ee, err := pdfcpu.AddBatesNumbers(ctx, pages, b, fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return ee, nil
+}
+
+func addBatesNumbersFile(inFile, outFile string, selectedPages []string, b *model.Bates, conf *model.Configuration) (ee []model.BatesEntry, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(outFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return AddBatesNumbers(f1, f2, filepath.Base(inFile), selectedPages, b, conf)
+}
+
+// AddBatesNumbersFile stamps selected pages of inFiles with Bates numbers continuing across all files
+// and writes the results to outDir using the original file names.
+// If manifestFile is not empty, a CSV file mapping file and page to the Bates number is written.
+func AddBatesNumbersFile(inFiles []string, outDir, manifestFile string, selectedPages []string, b *model.Bates, conf *model.Configuration) error {
+	if len(inFiles) == 0 {
+		return errors.New("pdfcpu: AddBatesNumbersFile: missing inFiles")
+	}
+
+	ee := []model.BatesEntry{}
+
+	for _, inFile := range inFiles {
+		outFile := filepath.Join(outDir, filepath.Base(inFile))
+		if outFile == filepath.Clean(inFile) {
+			return errors.Errorf("pdfcpu: Bates: %s would overwrite its input file, please choose another outDir", outFile)
+		}
+		ee1, err := addBatesNumbersFile(inFile, outFile, selectedPages, b, conf)
+		if err != nil {
+			return err
+		}
+		ee = append(ee, ee1...)
+	}
+
+	if manifestFile == "" {
+		return nil
+	}
+
+	f, err := os.Create(manifestFile)
+	if err != nil {
+		return err
+	}
+	logWritingTo(manifestFile)
+
+	if err := pdfcpu.WriteBatesManifest(f, ee); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
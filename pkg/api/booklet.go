@@ -20,7 +20,6 @@ import (
 	"io"
 	"os"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
@@ -66,10 +65,6 @@ func Booklet(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nu
 	}
 	conf.Cmd = model.BOOKLET
 
-	if log.InfoEnabled() {
-		log.Info.Printf("%s", nup)
-	}
-
 	var (
 		ctx *model.Context
 		err error
@@ -97,6 +92,8 @@ func Booklet(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nu
 		}
 	}
 
+	ctx.LogInfo("Booklet", "nup", nup)
+
 	return Write(ctx, w, conf)
 }
 
@@ -104,6 +101,10 @@ func Booklet(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nu
 func BookletFile(inFiles []string, outFile string, selectedPages []string, nup *model.NUp, conf *model.Configuration) (err error) {
 	var f1, f2 *os.File
 
+	if nup.Marks != nil && nup.Marks.FileName == "" {
+		nup = nupWithSlugFileName(nup, inFiles[0])
+	}
+
 	// booklet from a PDF
 	if f1, err = os.Open(inFiles[0]); err != nil {
 		return err
@@ -112,7 +112,7 @@ func ExportBookmarksFile(inFilePDF, outFileJSON string, conf *model.Configuratio
 	return ExportBookmarksJSON(f1, f2, inFilePDF, conf)
 }
 
-// ImportBookmarks creates/replaces outlines in rs and writes the result to w.
+// ImportBookmarks creates/replaces outlines in rs as provided by rd in JSON or YAML and writes the result to w.
 func ImportBookmarks(rs io.ReadSeeker, rd io.Reader, w io.Writer, replace bool, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ImportBookmarks: missing rs")
@@ -254,6 +254,109 @@ func AddBookmarksFile(inFile, outFile string, bms []pdfcpu.Bookmark, replace boo
 	return AddBookmarks(f1, f2, bms, replace, conf)
 }
 
+// DetectBookmarks returns an outline for rs built from headings found in the page text.
+func DetectBookmarks(rs io.ReadSeeker, conf *model.Configuration) ([]pdfcpu.Bookmark, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: DetectBookmarks: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.DETECTBOOKMARKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.DetectBookmarks(ctx)
+}
+
+// DetectBookmarksFile returns an outline for inFile built from headings found in the page text.
+func DetectBookmarksFile(inFile string, conf *model.Configuration) ([]pdfcpu.Bookmark, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return DetectBookmarks(f, conf)
+}
+
+// AddDetectedBookmarks adds an outline built from headings found in the page text of rs and writes the result to w.
+func AddDetectedBookmarks(rs io.ReadSeeker, w io.Writer, replace bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddDetectedBookmarks: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.DETECTBOOKMARKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	bms, err := pdfcpu.DetectBookmarks(ctx)
+	if err != nil {
+		return err
+	}
+	if len(bms) == 0 {
+		return errors.New("pdfcpu: AddDetectedBookmarks: no headings detected")
+	}
+
+	if err := pdfcpu.AddBookmarks(ctx, bms, replace); err != nil {
+		return err
+	}
+
+	return WriteContext(ctx, w)
+}
+
+// AddDetectedBookmarksFile adds an outline built from headings found in the page text of inFile and writes the result to outFile.
+func AddDetectedBookmarksFile(inFile, outFile string, replace bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddDetectedBookmarks(f1, f2, replace, conf)
+}
+
 // RemoveBookmarks deletes outlines from rs and writes the result to w.
 func RemoveBookmarks(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 	if rs == nil {
@@ -21,6 +21,7 @@ import (
 	"os"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -290,3 +291,77 @@ func CropFile(inFile, outFile string, selectedPages []string, b *model.Box, conf
 
 	return Crop(f1, f2, selectedPages, b, conf)
 }
+
+// AutoCrop sets the crop boxes of selected pages of rs to the bounding box of their content and writes the result to w.
+func AutoCrop(rs io.ReadSeeker, w io.Writer, selectedPages []string, ac *model.AutoCrop, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AutoCrop: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUTOCROP
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.AutoCrop(ctx, pages, ac); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AutoCropFile sets the crop boxes of selected pages of inFile to the bounding box of their content and writes the result to outFile.
+func AutoCropFile(inFile, outFile string, selectedPages []string, ac *model.AutoCrop, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("auto cropping %s\n", inFile)
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AutoCrop(f1, f2, selectedPages, ac, conf)
+}
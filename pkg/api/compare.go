@@ -0,0 +1,113 @@
+/*
+	Copyright 2025 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Compare compares the PDF streams rs1 and rs2 and returns a report of differing document properties, page counts and pages.
+// visual turns on locating the changed regions of differing pages by matching glyphs, painted paths and images.
+// If w is not nil the second PDF stream with changed regions highlighted is written to w.
+func Compare(rs1, rs2 io.ReadSeeker, w io.Writer, visual bool, conf *model.Configuration) (*model.ComparisonReport, error) {
+	if rs1 == nil {
+		return nil, errors.New("pdfcpu: Compare: missing rs1")
+	}
+
+	if rs2 == nil {
+		return nil, errors.New("pdfcpu: Compare: missing rs2")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.COMPARE
+
+	ctx1, err := ReadValidateAndOptimize(rs1, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx2, err := ReadValidateAndOptimize(rs2, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	cr, err := pdfcpu.Compare(ctx1, ctx2, visual)
+	if err != nil {
+		return nil, err
+	}
+
+	if w == nil {
+		return cr, nil
+	}
+
+	if err := pdfcpu.AnnotateChanges(ctx2, cr); err != nil {
+		return nil, err
+	}
+
+	if err := WriteContext(ctx2, w); err != nil {
+		return nil, err
+	}
+
+	return cr, nil
+}
+
+// CompareFile compares inFile1 and inFile2 and returns a report of differing document properties, page counts and pages.
+// If outFile is provided a copy of inFile2 with changed regions highlighted is written to outFile.
+func CompareFile(inFile1, inFile2, outFile string, visual bool, conf *model.Configuration) (cr *model.ComparisonReport, err error) {
+	f1, err := os.Open(inFile1)
+	if err != nil {
+		return nil, err
+	}
+	defer f1.Close()
+
+	f2, err := os.Open(inFile2)
+	if err != nil {
+		return nil, err
+	}
+	defer f2.Close()
+
+	var w io.Writer
+
+	if outFile != "" {
+		f, err := os.Create(outFile)
+		if err != nil {
+			return nil, err
+		}
+		defer func() {
+			if cerr := f.Close(); err == nil {
+				err = cerr
+			}
+		}()
+		logWritingTo(outFile)
+		w = f
+	}
+
+	if cr, err = Compare(f1, f2, w, visual, conf); err != nil {
+		return nil, err
+	}
+
+	cr.File1, cr.File2 = inFile1, inFile2
+
+	return cr, nil
+}
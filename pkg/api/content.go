@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// PageContent returns the parsed content of page pageNr of rs.
+func PageContent(rs io.ReadSeeker, pageNr int, conf *model.Configuration) (model.Content, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageContent: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTCONTENT
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return nil, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	return pdfcpu.PageContent(ctx, pageNr)
+}
+
+// PageContentFile returns the parsed content of page pageNr of inFile.
+func PageContentFile(inFile string, pageNr int, conf *model.Configuration) (model.Content, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageContent(f, pageNr, conf)
+}
+
+// EditContent replaces the content of selected pages of rs with the content returned by fn and writes the result to w.
+func EditContent(rs io.ReadSeeker, w io.Writer, selectedPages []string, fn model.ContentEditor, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: EditContent: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EDITCONTENT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.EditContent(ctx, pages, fn); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// EditContentFile replaces the content of selected pages of inFile with the content returned by fn and writes the result to outFile.
+func EditContentFile(inFile, outFile string, selectedPages []string, fn model.ContentEditor, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return EditContent(f1, f2, selectedPages, fn, conf)
+}
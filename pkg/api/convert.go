@@ -0,0 +1,172 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func logColorConversion(stats *model.ColorConversionStats) {
+	if log.CLIEnabled() {
+		log.CLI.Printf("converted %d content stream(s), %d image(s), %d shading(s), %d color space(s), skipped %d\n",
+			stats.ContentStreams, stats.Images, stats.Shadings, stats.ColorSpaces, stats.Skipped)
+	}
+}
+
+// ConvertToCMYK converts the RGB colors of rs into CMYK colors as configured by cc and writes the result to w.
+func ConvertToCMYK(rs io.ReadSeeker, w io.Writer, cc *model.CMYKConversion, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ConvertToCMYK: missing rs")
+	}
+
+	if cc == nil {
+		return errors.New("pdfcpu: ConvertToCMYK: missing cc")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTCMYK
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	stats, err := pdfcpu.ConvertToCMYK(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	logColorConversion(stats)
+
+	return Write(ctx, w, conf)
+}
+
+// ConvertToCMYKFile converts the RGB colors of inFile into CMYK colors as configured by cc and writes the result to outFile.
+func ConvertToCMYKFile(inFile, outFile string, cc *model.CMYKConversion, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ConvertToCMYK(f1, f2, cc, conf)
+}
+
+// ConvertToGray converts the colors of rs into gray and writes the result to w.
+func ConvertToGray(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ConvertToGray: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTGRAY
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	stats, err := pdfcpu.ConvertToGray(ctx)
+	if err != nil {
+		return err
+	}
+
+	logColorConversion(stats)
+
+	return Write(ctx, w, conf)
+}
+
+// ConvertToGrayFile converts the colors of inFile into gray and writes the result to outFile.
+func ConvertToGrayFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ConvertToGray(f1, f2, conf)
+}
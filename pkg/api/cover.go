@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// CreateCover writes a single page PDF to w containing the cover spread of a perfect bound book.
+func CreateCover(w io.Writer, cover *model.Cover, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: CreateCover: missing w")
+	}
+
+	if cover == nil {
+		return errors.New("pdfcpu: CreateCover: missing cover")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CREATECOVER
+
+	ctx, err := pdfcpu.CreateCover(cover, conf)
+	if err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// CreateCoverFile writes a single page PDF to outFile containing the cover spread of a perfect bound book.
+func CreateCoverFile(outFile string, cover *model.Cover, conf *model.Configuration) (err error) {
+	var f *os.File
+
+	if f, err = os.Create(outFile); err != nil {
+		return err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f.Close()
+			os.Remove(outFile)
+			return
+		}
+		err = f.Close()
+	}()
+
+	return CreateCover(f, cover, conf)
+}
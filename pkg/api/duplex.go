@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ManualDuplex reorders selected pages of rs for double sided printing on printers without a duplexer.
+// The odd pages are written to w followed by the even pages, which are reversed if reverseBacks is set.
+// If wBacks is not nil, the even pages are written to wBacks instead.
+func ManualDuplex(rs io.ReadSeeker, w, wBacks io.Writer, selectedPages []string, reverseBacks bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ManualDuplex: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.MANUALDUPLEX
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	ctxFronts, ctxBacks, err := pdfcpu.ManualDuplex(ctx, pages, reverseBacks, wBacks != nil)
+	if err != nil {
+		return err
+	}
+
+	if err := Write(ctxFronts, w, conf); err != nil {
+		return err
+	}
+
+	if ctxBacks == nil {
+		return nil
+	}
+
+	return Write(ctxBacks, wBacks, conf)
+}
+
+// ManualDuplexFile reorders selected pages of inFile for double sided printing on printers without a duplexer.
+// If outFileBacks is empty, the odd pages followed by the even pages are written to outFile.
+// Otherwise the odd pages are written to outFile and the even pages to outFileBacks unless there are none.
+func ManualDuplexFile(inFile, outFile, outFileBacks string, selectedPages []string, reverseBacks bool, conf *model.Configuration) (err error) {
+	var f1, f2, f3 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return err
+	}
+	logWritingTo(outFile)
+
+	var wBacks io.Writer
+	if outFileBacks != "" {
+		if f3, err = os.Create(outFileBacks); err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(outFile)
+			return err
+		}
+		logWritingTo(outFileBacks)
+		wBacks = f3
+	}
+
+	defer func() {
+		if err != nil {
+			if f3 != nil {
+				f3.Close()
+				os.Remove(outFileBacks)
+			}
+			f2.Close()
+			f1.Close()
+			os.Remove(outFile)
+			return
+		}
+		if f3 != nil {
+			fi, err1 := f3.Stat()
+			if err = f3.Close(); err != nil {
+				return
+			}
+			if err1 == nil && fi.Size() == 0 {
+				// A single front side has no back side.
+				os.Remove(outFileBacks)
+			}
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return ManualDuplex(f1, f2, wBacks, selectedPages, reverseBacks, conf)
+}
@@ -18,6 +18,7 @@ package api
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
@@ -118,6 +119,27 @@ func ExtractImages(rs io.ReadSeeker, selectedPages []string, digestImage func(mo
 	return nil
 }
 
+// imageOutput returns a digestImage func for ExtractImages writing images to out.
+func imageOutput(out Output, fileName string) func(model.Image, bool, int) error {
+	return func(img model.Image, singleImgPerPage bool, maxPageDigits int) error {
+		if img.Reader == nil {
+			return nil
+		}
+		s := "%s_%" + fmt.Sprintf("0%dd", maxPageDigits)
+		qual := img.Name
+		if img.Thumb {
+			qual = "thumb"
+		}
+		return writeOutput(out, fmt.Sprintf(s+"_%s.%s", fileName, img.PageNr, qual, img.FileType), img)
+	}
+}
+
+// ExtractImagesTo writes embedded image resources from rs to out for selected pages.
+func ExtractImagesTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, conf *model.Configuration) error {
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+	return ExtractImages(rs, selectedPages, imageOutput(out, fileName), conf)
+}
+
 // ExtractImagesFile dumps embedded image resources from inFile into outDir for selected pages.
 func ExtractImagesFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
 	f, err := os.Open(inFile)
@@ -134,27 +156,179 @@ func ExtractImagesFile(inFile, outDir string, selectedPages []string, conf *mode
 	return ExtractImages(f, selectedPages, pdfcpu.WriteImageToDisk(outDir, fileName), conf)
 }
 
-func writeFonts(ff []pdfcpu.Font, outDir, fileName string) error {
-	for _, f := range ff {
-		outFile := filepath.Join(outDir, fmt.Sprintf("%s_%s.%s", fileName, f.Name, f.Type))
-		logWritingTo(outFile)
-		w, err := os.Create(outFile)
+func writeLosslessImage(li model.LosslessImage, out Output, fileName string, maxPageDigits int) error {
+	s := "%s_%" + fmt.Sprintf("0%dd", maxPageDigits) + "_%s"
+	baseName := fmt.Sprintf(s, fileName, li.PageNr, li.Name)
+	li.File = baseName + "." + li.FileType
+
+	if err := writeOutput(out, li.File, li); err != nil {
+		return err
+	}
+
+	bb, err := json.MarshalIndent(li, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(out, baseName+".json", bytes.NewReader(bb))
+}
+
+// ExtractImagesLossless dumps embedded image resources from rs into outDir for selected pages
+// along with a JSON manifest per image describing its origin, placements, colorspace and filters.
+// DCT and JPX encoded images are written byte-for-byte as jpg and jp2 files without recompression,
+// bare JPEG 2000 codestreams get wrapped into a JP2 container.
+func ExtractImagesLossless(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	return ExtractImagesLosslessTo(rs, DirOutput(outDir), fileName, selectedPages, conf)
+}
+
+// ExtractImagesLosslessTo writes embedded image resources from rs to out for selected pages
+// along with a JSON manifest per image.
+func ExtractImagesLosslessTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ExtractImagesLossless: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTIMAGESLOSSLESS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for k, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, k)
+		}
+	}
+	if len(pageNrs) == 0 {
+		return nil
+	}
+
+	sort.Ints(pageNrs)
+	maxPageDigits := len(strconv.Itoa(pageNrs[len(pageNrs)-1]))
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, i := range pageNrs {
+		ii, err := pdfcpu.ExtractPageLosslessImages(ctx, i)
 		if err != nil {
 			return err
 		}
-		if _, err = io.Copy(w, f); err != nil {
-			return err
+		for _, li := range ii {
+			if err := writeLosslessImage(li, out, fileName, maxPageDigits); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ExtractImagesLosslessFile dumps embedded image resources from inFile into outDir for selected pages
+// along with a JSON manifest per image.
+func ExtractImagesLosslessFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting images losslessly from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractImagesLossless(f, outDir, filepath.Base(inFile), selectedPages, conf)
+}
+
+// FontFile describes an extracted font file.
+type FontFile struct {
+	ObjNr int    `json:"objNr"` // font dict objNr
+	Name  string `json:"name"`
+	File  string `json:"file"`
+	Pages []int  `json:"pages,omitempty"` // pages using this font, form fonts are not used by any page.
+}
+
+func fontFileName(f pdfcpu.Font, fileName string, used map[string]bool) string {
+	name := strings.NewReplacer("/", "_", "\\", "_", " ", "_").Replace(f.Name)
+	fn := fmt.Sprintf("%s_%s.%s", fileName, name, f.Type)
+	if used[fn] {
+		// Distinct subsets of the same font.
+		fn = fmt.Sprintf("%s_%s_%d.%s", fileName, name, f.ObjNr, f.Type)
+	}
+	used[fn] = true
+	return fn
+}
+
+func writeFonts(ff []pdfcpu.Font, out Output, fileName string, used map[string]bool, m map[int]*FontFile) error {
+	for _, f := range ff {
+		if _, ok := m[f.ObjNr]; ok {
+			continue
 		}
-		if err := w.Close(); err != nil {
+		fn := fontFileName(f, fileName, used)
+		if err := writeOutput(out, fn, f); err != nil {
 			return err
 		}
+		m[f.ObjNr] = &FontFile{ObjNr: f.ObjNr, Name: f.Name, File: fn}
 	}
 
 	return nil
 }
 
-// ExtractFonts dumps embedded fontfiles from rs into outDir for selected pages.
+func writeFontManifest(ctx *model.Context, pages types.IntSet, m map[int]*FontFile, out Output, fileName string) error {
+	if len(m) == 0 {
+		return nil
+	}
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if !pages[i] {
+			continue
+		}
+		for _, objNr := range pdfcpu.FontObjNrs(ctx, i) {
+			if ff, ok := m[objNr]; ok {
+				ff.Pages = append(ff.Pages, i)
+			}
+		}
+	}
+
+	objNrs := make([]int, 0, len(m))
+	for objNr := range m {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	ff := make([]*FontFile, len(objNrs))
+	for i, objNr := range objNrs {
+		ff[i] = m[objNr]
+	}
+
+	bb, err := json.MarshalIndent(ff, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return writeOutput(out, fileName+"_Fonts.json", bytes.NewReader(bb))
+}
+
+// ExtractFonts dumps embedded fontfiles from rs into outDir for selected pages
+// along with a JSON manifest listing the pages using each font.
+// TrueType fonts are written as ttf, OpenType and bare CFF fonts as otf and Type 1 fonts as pfb files.
+// CFF fonts that cannot be wrapped into OpenType are written as is.
 func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	return ExtractFontsTo(rs, DirOutput(outDir), fileName, selectedPages, conf)
+}
+
+// ExtractFontsTo writes embedded fontfiles from rs to out for selected pages
+// along with a JSON manifest listing the pages using each font.
+func ExtractFontsTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ExtractFonts: missing rs")
 	}
@@ -177,6 +351,7 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
 
 	objNrs, skipped := types.IntSet{}, types.IntSet{}
+	used, m := map[string]bool{}, map[int]*FontFile{}
 
 	for i, v := range pages {
 		if !v {
@@ -186,7 +361,7 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		if err != nil {
 			return err
 		}
-		if err := writeFonts(ff, outDir, fileName); err != nil {
+		if err := writeFonts(ff, out, fileName, used, m); err != nil {
 			return err
 		}
 	}
@@ -196,7 +371,11 @@ func ExtractFonts(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		return err
 	}
 
-	return writeFonts(ff, outDir, fileName)
+	if err := writeFonts(ff, out, fileName, used, m); err != nil {
+		return err
+	}
+
+	return writeFontManifest(ctx, pages, m, out, fileName)
 }
 
 // ExtractFontsFile dumps embedded fontfiles from inFile into outDir for selected pages.
@@ -216,16 +395,11 @@ func ExtractFontsFile(inFile, outDir string, selectedPages []string, conf *model
 
 // WritePage consumes an io.Reader containing some PDF bytes and writes to outDir/fileName.
 func WritePage(r io.Reader, outDir, fileName string, pageNr int) error {
-	outFile := filepath.Join(outDir, fmt.Sprintf("%s_page_%d.pdf", fileName, pageNr))
-	logWritingTo(outFile)
-	w, err := os.Create(outFile)
-	if err != nil {
-		return err
-	}
-	if _, err = io.Copy(w, r); err != nil {
-		return err
-	}
-	return w.Close()
+	return writePage(r, DirOutput(outDir), fileName, pageNr)
+}
+
+func writePage(r io.Reader, out Output, fileName string, pageNr int) error {
+	return writeOutput(out, fmt.Sprintf("%s_page_%d.pdf", fileName, pageNr), r)
 }
 
 // ExtractPage extracts the page with pageNr out of ctx into an io.Reader.
@@ -245,6 +419,11 @@ func ExtractPage(ctx *model.Context, pageNr int) (io.Reader, error) {
 
 // ExtractPages generates single page PDF files from rs in outDir for selected pages.
 func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	return ExtractPagesTo(rs, DirOutput(outDir), fileName, selectedPages, conf)
+}
+
+// ExtractPagesTo writes single page PDF files from rs to out for selected pages.
+func ExtractPagesTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ExtractPages: missing rs")
 	}
@@ -278,7 +457,7 @@ func ExtractPages(rs io.ReadSeeker, outDir, fileName string, selectedPages []str
 		if err != nil {
 			return err
 		}
-		if err := WritePage(r, outDir, fileName, i); err != nil {
+		if err := writePage(r, out, fileName, i); err != nil {
 			return err
 		}
 	}
@@ -303,6 +482,11 @@ func ExtractPagesFile(inFile, outDir string, selectedPages []string, conf *model
 
 // ExtractContent dumps "PDF source" files from rs into outDir for selected pages.
 func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, conf *model.Configuration) error {
+	return ExtractContentTo(rs, DirOutput(outDir), fileName, selectedPages, conf)
+}
+
+// ExtractContentTo writes "PDF source" files from rs to out for selected pages.
+func ExtractContentTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ExtractContent: missing rs")
 	}
@@ -337,18 +521,200 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 			continue
 		}
 
-		outFile := filepath.Join(outDir, fmt.Sprintf("%s_Content_page_%d.txt", fileName, p))
-		logWritingTo(outFile)
-		f, err := os.Create(outFile)
-		if err != nil {
+		if err := writeOutput(out, fmt.Sprintf("%s_Content_page_%d.txt", fileName, p), r); err != nil {
 			return err
 		}
+	}
 
-		if _, err = io.Copy(f, r); err != nil {
+	return nil
+}
+
+// ExtractContentFile dumps "PDF source" files from inFile into outDir for selected pages.
+func ExtractContentFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting content from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractContent(f, outDir, inFile, selectedPages, conf)
+}
+
+// VectorPaths returns the vector paths of selected pages of rs.
+func VectorPaths(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]model.PageVectorPaths, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: VectorPaths: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTVECTORPATHS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var pp []model.PageVectorPaths
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if !pages[i] {
+			continue
+		}
+		p, err := pdfcpu.PageVectorPaths(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		pp = append(pp, *p)
+	}
+
+	return pp, nil
+}
+
+// ExtractVectorPaths writes the vector paths of selected pages of rs into outDir as SVG or JSON files.
+func ExtractVectorPaths(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
+	return ExtractVectorPathsTo(rs, DirOutput(outDir), fileName, selectedPages, jsonOutput, conf)
+}
+
+// ExtractVectorPathsTo writes the vector paths of selected pages of rs to out as SVG or JSON files.
+func ExtractVectorPathsTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
+	pp, err := VectorPaths(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	for _, p := range pp {
+		var bb []byte
+		ext := "svg"
+		if jsonOutput {
+			ext = "json"
+			if bb, err = json.MarshalIndent(p, "", "\t"); err != nil {
+				return err
+			}
+		} else {
+			bb = []byte(p.SVG())
+		}
+
+		if err := writeOutput(out, fmt.Sprintf("%s_Paths_page_%d.%s", fileName, p.PageNr, ext), bytes.NewReader(bb)); err != nil {
 			return err
 		}
+	}
+
+	return nil
+}
 
-		if err := f.Close(); err != nil {
+// ExtractVectorPathsFile writes the vector paths of selected pages of inFile into outDir as SVG or JSON files.
+func ExtractVectorPathsFile(inFile, outDir string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("extracting vector paths from %s into %s/ ...\n", inFile, outDir)
+	}
+
+	return ExtractVectorPaths(f, outDir, inFile, selectedPages, jsonOutput, conf)
+}
+
+// Tables returns the tables detected on selected pages of rs.
+func Tables(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]model.Table, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Tables: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTTABLES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var tt []model.Table
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if !pages[i] {
+			continue
+		}
+		t, err := pdfcpu.PageTables(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		tt = append(tt, t...)
+	}
+
+	return tt, nil
+}
+
+// ExtractTables writes the tables detected on selected pages of rs into outDir
+// as one CSV file per table or one JSON file per page including cell coordinates.
+func ExtractTables(rs io.ReadSeeker, outDir, fileName string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
+	return ExtractTablesTo(rs, DirOutput(outDir), fileName, selectedPages, jsonOutput, conf)
+}
+
+// ExtractTablesTo writes the tables detected on selected pages of rs to out
+// as one CSV file per table or one JSON file per page including cell coordinates.
+func ExtractTablesTo(rs io.ReadSeeker, out Output, fileName string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
+	tt, err := Tables(rs, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
+
+	if jsonOutput {
+		m := map[int][]model.Table{}
+		var pageNrs []int
+		for _, t := range tt {
+			if _, ok := m[t.PageNr]; !ok {
+				pageNrs = append(pageNrs, t.PageNr)
+			}
+			m[t.PageNr] = append(m[t.PageNr], t)
+		}
+		for _, pageNr := range pageNrs {
+			bb, err := json.MarshalIndent(m[pageNr], "", "\t")
+			if err != nil {
+				return err
+			}
+			if err := writeOutput(out, fmt.Sprintf("%s_Tables_page_%d.json", fileName, pageNr), bytes.NewReader(bb)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var pageNr, i int
+	for _, t := range tt {
+		if t.PageNr != pageNr {
+			pageNr, i = t.PageNr, 0
+		}
+		i++
+		var buf bytes.Buffer
+		if err := t.WriteCSV(&buf); err != nil {
+			return err
+		}
+		if err := writeOutput(out, fmt.Sprintf("%s_Table_page_%d_%d.csv", fileName, t.PageNr, i), &buf); err != nil {
 			return err
 		}
 	}
@@ -356,8 +722,8 @@ func ExtractContent(rs io.ReadSeeker, outDir, fileName string, selectedPages []s
 	return nil
 }
 
-// ExtractContentFile dumps "PDF source" files from inFile into outDir for selected pages.
-func ExtractContentFile(inFile, outDir string, selectedPages []string, conf *model.Configuration) error {
+// ExtractTablesFile writes the tables detected on selected pages of inFile into outDir as CSV or JSON files.
+func ExtractTablesFile(inFile, outDir string, selectedPages []string, jsonOutput bool, conf *model.Configuration) error {
 	f, err := os.Open(inFile)
 	if err != nil {
 		return err
@@ -365,14 +731,19 @@ func ExtractContentFile(inFile, outDir string, selectedPages []string, conf *mod
 	defer f.Close()
 
 	if log.CLIEnabled() {
-		log.CLI.Printf("extracting content from %s into %s/ ...\n", inFile, outDir)
+		log.CLI.Printf("extracting tables from %s into %s/ ...\n", inFile, outDir)
 	}
 
-	return ExtractContent(f, outDir, inFile, selectedPages, conf)
+	return ExtractTables(f, outDir, inFile, selectedPages, jsonOutput, conf)
 }
 
 // ExtractMetadata dumps all metadata dict entries for rs into outDir.
 func ExtractMetadata(rs io.ReadSeeker, outDir, fileName string, conf *model.Configuration) error {
+	return ExtractMetadataTo(rs, DirOutput(outDir), fileName, conf)
+}
+
+// ExtractMetadataTo writes all metadata dict entries for rs to out.
+func ExtractMetadataTo(rs io.ReadSeeker, out Output, fileName string, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ExtractMetadata: missing rs")
 	}
@@ -395,16 +766,7 @@ func ExtractMetadata(rs io.ReadSeeker, outDir, fileName string, conf *model.Conf
 	if len(mm) > 0 {
 		fileName = strings.TrimSuffix(filepath.Base(fileName), ".pdf")
 		for _, m := range mm {
-			outFile := filepath.Join(outDir, fmt.Sprintf("%s_Metadata_%s_%d_%d.txt", fileName, m.ParentType, m.ParentObjNr, m.ObjNr))
-			logWritingTo(outFile)
-			f, err := os.Create(outFile)
-			if err != nil {
-				return err
-			}
-			if _, err = io.Copy(f, m); err != nil {
-				return err
-			}
-			if err := f.Close(); err != nil {
+			if err := writeOutput(out, fmt.Sprintf("%s_Metadata_%s_%d_%d.txt", fileName, m.ParentType, m.ParentObjNr, m.ObjNr), m); err != nil {
 				return err
 			}
 		}
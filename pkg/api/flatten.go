@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func logTransparencyFlattening(stats *model.TransparencyStats) {
+	if log.CLIEnabled() {
+		log.CLI.Printf("flattened %d content stream(s), %d image(s), %d graphics state(s), %d group(s), dropped %d unused soft mask(s)\n",
+			stats.ContentStreams, stats.Images, stats.ExtGStates, stats.Groups, stats.SoftMasks)
+	}
+}
+
+// FlattenTransparency makes the content of rs opaque and writes the result to w.
+// Transparency which cannot be flattened without rasterizing results in an error.
+func FlattenTransparency(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FlattenTransparency: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENTRANSPARENCY
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	stats, err := pdfcpu.FlattenTransparency(ctx)
+	if err != nil {
+		return err
+	}
+
+	logTransparencyFlattening(stats)
+
+	return Write(ctx, w, conf)
+}
+
+// FlattenTransparencyFile makes the content of inFile opaque and writes the result to outFile.
+func FlattenTransparencyFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return FlattenTransparency(f1, f2, conf)
+}
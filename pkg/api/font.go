@@ -61,27 +61,34 @@ func ListFonts() ([]string, error) {
 }
 
 // InstallFonts installs true type fonts for embedding.
+// A file name may select a single face of a TrueType collection by index or PostScript name (eg. "fonts.ttc:1")
+// or a named instance of a variable font (eg. "RobotoFlex.ttf:Bold").
 func InstallFonts(fileNames []string) error {
 	if log.CLIEnabled() {
 		log.CLI.Printf("installing to %s...", font.UserFontDir)
 	}
 
-	for _, fn := range fileNames {
+	for _, s := range fileNames {
+		fn, face := font.SplitFontFileName(s)
+		var err error
 		switch filepath.Ext(fn) {
 		case ".ttf":
 			//log.CLI.Println(filepath.Base(fn))
-			if err := font.InstallTrueTypeFont(font.UserFontDir, fn); err != nil {
-				if log.CLIEnabled() {
-					log.CLI.Printf("%v", err)
-				}
+			if face != "" {
+				err = font.InstallVariableFontInstance(font.UserFontDir, fn, face)
+				break
 			}
+			err = font.InstallTrueTypeFont(font.UserFontDir, fn)
 		case ".ttc":
 			//log.CLI.Println(filepath.Base(fn))
-			if err := font.InstallTrueTypeCollection(font.UserFontDir, fn); err != nil {
-				if log.CLIEnabled() {
-					log.CLI.Printf("%v", err)
-				}
+			if face != "" {
+				err = font.InstallTrueTypeCollectionFace(font.UserFontDir, fn, face)
+				break
 			}
+			err = font.InstallTrueTypeCollection(font.UserFontDir, fn)
+		}
+		if err != nil && log.CLIEnabled() {
+			log.CLI.Printf("%v", err)
 		}
 	}
 
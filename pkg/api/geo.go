@@ -0,0 +1,263 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForGeoViewports(rs io.ReadSeeker, cmd model.CommandMode, selectedPages []string, conf *model.Configuration) (*model.Context, map[int]bool, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ctx, pages, conf, nil
+}
+
+// GeoViewports returns the georeferenced viewports of selected pages of rs mapped by page number.
+func GeoViewports(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int][]model.GeoViewport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: GeoViewports: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForGeoViewports(rs, model.LISTGEOVIEWPORTS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.GeoViewports(ctx, pages)
+}
+
+// GeoViewportsFile returns the georeferenced viewports of selected pages of inFile mapped by page number.
+func GeoViewportsFile(inFile string, selectedPages []string, conf *model.Configuration) (map[int][]model.GeoViewport, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return GeoViewports(f, selectedPages, conf)
+}
+
+// ListGeoViewports lists the georeferenced viewports of selected pages of rs.
+func ListGeoViewports(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListGeoViewports: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForGeoViewports(rs, model.LISTGEOVIEWPORTS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListGeoViewports(ctx, pages)
+}
+
+// ListGeoViewportsFile lists the georeferenced viewports of selected pages of inFile.
+func ListGeoViewportsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListGeoViewports(f, selectedPages, conf)
+}
+
+// AddGeoViewport adds a georeferenced viewport to selected pages of rs and writes the result to w.
+func AddGeoViewport(rs io.ReadSeeker, w io.Writer, selectedPages []string, vp model.GeoViewport, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddGeoViewport: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForGeoViewports(rs, model.ADDGEOVIEWPORTS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddGeoViewport(ctx, pages, vp); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddGeoViewportFile adds a georeferenced viewport to selected pages of inFile and writes the result to outFile.
+func AddGeoViewportFile(inFile, outFile string, selectedPages []string, vp model.GeoViewport, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddGeoViewport(f1, f2, selectedPages, vp, conf)
+}
+
+// RemoveGeoViewports removes the georeferenced viewports of selected pages of rs and writes the result to w.
+func RemoveGeoViewports(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveGeoViewports: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForGeoViewports(rs, model.REMOVEGEOVIEWPORTS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RemoveGeoViewports(ctx, pages)
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return errors.New("pdfcpu: no geospatial viewports available")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveGeoViewportsFile removes the georeferenced viewports of selected pages of inFile and writes the result to outFile.
+func RemoveGeoViewportsFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveGeoViewports(f1, f2, selectedPages, conf)
+}
+
+// PageToGeo transforms the point (x,y) of page pageNr of rs into latitude and longitude.
+func PageToGeo(rs io.ReadSeeker, pageNr int, x, y float64, conf *model.Configuration) (float64, float64, error) {
+	if rs == nil {
+		return 0, 0, errors.New("pdfcpu: PageToGeo: missing rs")
+	}
+
+	ctx, _, _, err := readContextForGeoViewports(rs, model.LISTGEOVIEWPORTS, nil, conf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return pdfcpu.PageToGeo(ctx, pageNr, x, y)
+}
+
+// PageToGeoFile transforms the point (x,y) of page pageNr of inFile into latitude and longitude.
+func PageToGeoFile(inFile string, pageNr int, x, y float64, conf *model.Configuration) (float64, float64, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	return PageToGeo(f, pageNr, x, y, conf)
+}
+
+// GeoToPage transforms latitude and longitude into a point of page pageNr of rs.
+func GeoToPage(rs io.ReadSeeker, pageNr int, lat, lon float64, conf *model.Configuration) (float64, float64, error) {
+	if rs == nil {
+		return 0, 0, errors.New("pdfcpu: GeoToPage: missing rs")
+	}
+
+	ctx, _, _, err := readContextForGeoViewports(rs, model.LISTGEOVIEWPORTS, nil, conf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return pdfcpu.GeoToPage(ctx, pageNr, lat, lon)
+}
+
+// GeoToPageFile transforms latitude and longitude into a point of page pageNr of inFile.
+func GeoToPageFile(inFile string, pageNr int, lat, lon float64, conf *model.Configuration) (float64, float64, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return 0, 0, err
+	}
+	defer f.Close()
+
+	return GeoToPage(f, pageNr, lat, lon, conf)
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddHeadersAndFooters adds the headers and footers hf to selected pages of rs and writes the result to w.
+// Set hf.FileName for the variable %filename.
+func AddHeadersAndFooters(rs io.ReadSeeker, w io.Writer, selectedPages []string, hf *model.HeaderFooter, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddHeadersAndFooters: missing rs")
+	}
+
+	if hf == nil {
+		return errors.New("pdfcpu: AddHeadersAndFooters: missing hf")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.HEADERFOOTER
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddHeadersAndFooters(ctx, pages, hf); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddHeadersAndFootersFile adds the headers and footers hf to selected pages of inFile and writes the result to outFile.
+func AddHeadersAndFootersFile(inFile, outFile string, selectedPages []string, hf *model.HeaderFooter, conf *model.Configuration) (err error) {
+	if hf == nil {
+		return errors.New("pdfcpu: AddHeadersAndFootersFile: missing hf")
+	}
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	hf1 := *hf
+	if hf1.FileName == "" {
+		hf1.FileName = filepath.Base(inFile)
+	}
+
+	return AddHeadersAndFooters(f1, f2, selectedPages, &hf1, conf)
+}
@@ -171,3 +171,93 @@ func UpdateImagesFile(inFile, imageFile, outFile string, objNr, pageNr int, id s
 
 	return UpdateImages(f0, f1, f2, objNr, pageNr, id, conf)
 }
+
+// ReplaceImage replaces the image XObject identified by objNr or (pageNr and index) by the image read from rd
+// and keeps its placements in the content streams.
+// index is the 1-based position of the image within the images of pageNr as listed by "pdfcpu images list".
+// Unlike UpdateImages the new image may differ in dimensions, colorspace and filters.
+func ReplaceImage(rs io.ReadSeeker, rd io.Reader, w io.Writer, objNr, pageNr, index int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReplaceImage: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: ReplaceImage: missing rd")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.UPDATEIMAGES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if objNr < 1 {
+		if pageNr < 1 || index < 1 {
+			return errors.New("pdfcpu: ReplaceImage: missing objNr or pageNr and index")
+		}
+		if objNr, err = pdfcpu.ImageObjNr(ctx, pageNr, index); err != nil {
+			return err
+		}
+	}
+
+	if err := pdfcpu.ReplaceImage(ctx, rd, objNr); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ReplaceImageFile replaces the image XObject identified by objNr or (pageNr and index) by imageFile.
+func ReplaceImageFile(inFile, imageFile, outFile string, objNr, pageNr, index int, conf *model.Configuration) (err error) {
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f1, err = os.Open(imageFile); err != nil {
+		f0.Close()
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		f0.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			f0.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if err = f0.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ReplaceImage(f0, f1, f2, objNr, pageNr, index, conf)
+}
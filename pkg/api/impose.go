@@ -0,0 +1,127 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ParseImpositionTemplate reads a JSON imposition template from rd.
+func ParseImpositionTemplate(rd io.Reader, conf *model.Configuration) (*model.ImpositionTemplate, error) {
+	if rd == nil {
+		return nil, errors.New("pdfcpu: ParseImpositionTemplate: missing rd")
+	}
+
+	bb, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ParseImpositionTemplate(bb, conf)
+}
+
+// ParseImpositionTemplateFile reads a JSON imposition template from inFileJSON.
+func ParseImpositionTemplateFile(inFileJSON string, conf *model.Configuration) (*model.ImpositionTemplate, error) {
+	f, err := os.Open(inFileJSON)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ParseImpositionTemplate(f, conf)
+}
+
+// Impose arranges selected pages of rs onto sheets according to the user defined imposition layout t and writes the result to w.
+func Impose(rs io.ReadSeeker, w io.Writer, selectedPages []string, t *model.ImpositionTemplate, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: Impose: missing rs")
+	}
+
+	if t == nil {
+		return errors.New("pdfcpu: Impose: missing imposition template")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPOSE
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	ctx.LogInfo("Impose", "template", t)
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.ImposeFromPDF(ctx, pages, t); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ImposeFile arranges selected pages of inFile onto sheets according to the imposition template inFileJSON and writes the result to outFile.
+func ImposeFile(inFile, inFileJSON, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	t, err := ParseImpositionTemplateFile(inFileJSON, conf)
+	if err != nil {
+		return err
+	}
+
+	t.FileName = filepath.Base(inFile)
+
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		f1.Close()
+		return err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(outFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		err = f1.Close()
+	}()
+
+	return Impose(f1, f2, selectedPages, t, conf)
+}
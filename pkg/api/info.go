@@ -59,3 +59,42 @@ func PDFInfo(rs io.ReadSeeker, fileName string, selectedPages []string, fonts bo
 
 	return pdfcpu.Info(ctx, fileName, pages, fonts)
 }
+
+// PageResources returns a report of the fonts, images, color spaces, transparency features and annotations used by selected pages of rs.
+func PageResources(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]model.PageResourceReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageResources: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = model.LISTINFO
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var rr []model.PageResourceReport
+
+	for i := 1; i <= ctx.PageCount; i++ {
+		if !pages[i] {
+			continue
+		}
+		r, err := pdfcpu.PageResources(ctx, i)
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, *r)
+	}
+
+	return rr, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddInvoice embeds the XML invoice read from rd into rs producing a Factur-X / ZUGFeRD hybrid invoice and writes the result to w.
+func AddInvoice(rs io.ReadSeeker, rd io.Reader, w io.Writer, modTime time.Time, inv *model.Invoice, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddInvoice: missing rs")
+	}
+
+	if rd == nil {
+		return errors.New("pdfcpu: AddInvoice: missing rd")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDINVOICE
+
+	bb, err := io.ReadAll(rd)
+	if err != nil {
+		return err
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddInvoice(ctx, bb, modTime, inv); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddInvoiceFile embeds inFileXML into inFile producing a Factur-X / ZUGFeRD hybrid invoice and writes the result to outFile.
+func AddInvoiceFile(inFile, inFileXML, outFile string, inv *model.Invoice, conf *model.Configuration) (err error) {
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFileXML); err != nil {
+		return err
+	}
+	defer f0.Close()
+
+	fi, err := f0.Stat()
+	if err != nil {
+		return err
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddInvoice(f1, f0, f2, fi.ModTime(), inv, conf)
+}
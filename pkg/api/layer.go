@@ -0,0 +1,274 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForLayers(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, conf, nil
+}
+
+// Layers returns rs's optional content groups.
+func Layers(rs io.ReadSeeker, conf *model.Configuration) ([]model.Layer, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Layers: missing rs")
+	}
+
+	ctx, _, err := readContextForLayers(rs, model.LISTLAYERS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.Layers(ctx)
+}
+
+// LayersFile returns inFile's optional content groups.
+func LayersFile(inFile string, conf *model.Configuration) ([]model.Layer, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return Layers(f, conf)
+}
+
+// ListLayers lists rs's optional content groups.
+func ListLayers(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListLayers: missing rs")
+	}
+
+	ctx, _, err := readContextForLayers(rs, model.LISTLAYERS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListLayers(ctx)
+}
+
+// ListLayersFile lists inFile's optional content groups.
+func ListLayersFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListLayers(f, conf)
+}
+
+// SetLayerVisibility sets the default visibility of rs's optional content groups named by names and writes the result to w.
+func SetLayerVisibility(rs io.ReadSeeker, w io.Writer, names []string, visible bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetLayerVisibility: missing rs")
+	}
+
+	cmd := model.HIDELAYERS
+	if visible {
+		cmd = model.SHOWLAYERS
+	}
+
+	ctx, conf, err := readContextForLayers(rs, cmd, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetLayerVisibility(ctx, names, visible); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetLayerVisibilityFile sets the default visibility of inFile's optional content groups named by names and writes the result to outFile.
+func SetLayerVisibilityFile(inFile, outFile string, names []string, visible bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetLayerVisibility(f1, f2, names, visible, conf)
+}
+
+// RemoveLayers removes rs's optional content groups named by names including their content and writes the result to w.
+func RemoveLayers(rs io.ReadSeeker, w io.Writer, names []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveLayers: missing rs")
+	}
+
+	ctx, conf, err := readContextForLayers(rs, model.REMOVELAYERS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.RemoveLayers(ctx, names); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveLayersFile removes inFile's optional content groups named by names including their content and writes the result to outFile.
+func RemoveLayersFile(inFile, outFile string, names []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveLayers(f1, f2, names, conf)
+}
+
+// FlattenLayers turns rs's optional content into regular content as visible by default and writes the result to w.
+func FlattenLayers(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: FlattenLayers: missing rs")
+	}
+
+	ctx, conf, err := readContextForLayers(rs, model.FLATTENLAYERS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.FlattenLayers(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// FlattenLayersFile turns inFile's optional content into regular content as visible by default and writes the result to outFile.
+func FlattenLayersFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return FlattenLayers(f1, f2, conf)
+}
@@ -43,6 +43,24 @@ func appendTo(rs io.ReadSeeker, fName string, ctxDest *model.Context, dividerPag
 	return pdfcpu.MergeXRefTables(fName, ctxSource, ctxDest, false, dividerPage)
 }
 
+// dedupeResources collapses identical font files, images and ICC profiles taken over from different source files.
+func dedupeResources(ctx *model.Context) error {
+	if !ctx.Configuration.DedupeResources {
+		return nil
+	}
+
+	n, err := pdfcpu.DedupeStreams(ctx)
+	if err != nil {
+		return err
+	}
+
+	if n > 0 && log.CLIEnabled() {
+		log.CLI.Printf("removed %d duplicate resources\n", n)
+	}
+
+	return nil
+}
+
 // MergeRaw merges a sequence of PDF streams and writes the result to w.
 func MergeRaw(rsc []io.ReadSeeker, w io.Writer, dividerPage bool, conf *model.Configuration) error {
 	if rsc == nil {
@@ -73,6 +91,14 @@ func MergeRaw(rsc []io.ReadSeeker, w io.Writer, dividerPage bool, conf *model.Co
 		}
 	}
 
+	if ctxDest, err = cleanupPages(ctxDest); err != nil {
+		return err
+	}
+
+	if err = dedupeResources(ctxDest); err != nil {
+		return err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err = OptimizeContext(ctxDest); err != nil {
 			return err
@@ -162,6 +188,14 @@ func Merge(destFile string, inFiles []string, w io.Writer, conf *model.Configura
 		}
 	}
 
+	if ctxDest, err = cleanupPages(ctxDest); err != nil {
+		return err
+	}
+
+	if err := dedupeResources(ctxDest); err != nil {
+		return err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err := OptimizeContext(ctxDest); err != nil {
 			return err
@@ -280,6 +314,14 @@ func MergeCreateZip(rs1, rs2 io.ReadSeeker, w io.Writer, conf *model.Configurati
 		return err
 	}
 
+	if ctxDest, err = cleanupPages(ctxDest); err != nil {
+		return err
+	}
+
+	if err := dedupeResources(ctxDest); err != nil {
+		return err
+	}
+
 	if conf.OptimizeBeforeWriting {
 		if err := OptimizeContext(ctxDest); err != nil {
 			return err
@@ -0,0 +1,295 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForNamedDests(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, conf, nil
+}
+
+// NamedDestinations returns rs's named destinations.
+func NamedDestinations(rs io.ReadSeeker, conf *model.Configuration) ([]model.NamedDestination, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: NamedDestinations: missing rs")
+	}
+
+	ctx, _, err := readContextForNamedDests(rs, model.LISTNAMEDDESTS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.NamedDestinations(ctx)
+}
+
+// NamedDestinationsFile returns inFile's named destinations.
+func NamedDestinationsFile(inFile string, conf *model.Configuration) ([]model.NamedDestination, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return NamedDestinations(f, conf)
+}
+
+// ListNamedDestinations lists rs's named destinations.
+func ListNamedDestinations(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListNamedDestinations: missing rs")
+	}
+
+	ctx, _, err := readContextForNamedDests(rs, model.LISTNAMEDDESTS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListNamedDestinations(ctx)
+}
+
+// ListNamedDestinationsFile lists inFile's named destinations.
+func ListNamedDestinationsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListNamedDestinations(f, conf)
+}
+
+// ResolveNamedDestination returns the destination of rs's named destination name.
+func ResolveNamedDestination(rs io.ReadSeeker, name string, conf *model.Configuration) (*model.Destination, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ResolveNamedDestination: missing rs")
+	}
+
+	ctx, _, err := readContextForNamedDests(rs, model.LISTNAMEDDESTS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ResolveNamedDestination(ctx, name)
+}
+
+// ResolveNamedDestinationFile returns the destination of inFile's named destination name.
+func ResolveNamedDestinationFile(inFile, name string, conf *model.Configuration) (*model.Destination, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ResolveNamedDestination(f, name, conf)
+}
+
+// AddNamedDestinations adds nds to rs and writes the result to w.
+// Existing named destinations are replaced if replace is true.
+func AddNamedDestinations(rs io.ReadSeeker, w io.Writer, nds []model.NamedDestination, replace bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddNamedDestinations: missing rs")
+	}
+
+	ctx, conf, err := readContextForNamedDests(rs, model.ADDNAMEDDESTS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddNamedDestinations(ctx, nds, replace); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddNamedDestinationsFile adds nds to inFile and writes the result to outFile.
+func AddNamedDestinationsFile(inFile, outFile string, nds []model.NamedDestination, replace bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddNamedDestinations(f1, f2, nds, replace, conf)
+}
+
+// RemoveNamedDestinations removes named destinations from rs and writes the result to w.
+// All named destinations are removed if names is empty.
+func RemoveNamedDestinations(rs io.ReadSeeker, w io.Writer, names []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveNamedDestinations: missing rs")
+	}
+
+	ctx, conf, err := readContextForNamedDests(rs, model.REMOVENAMEDDESTS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.RemoveNamedDestinations(ctx, names); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveNamedDestinationsFile removes named destinations from inFile and writes the result to outFile.
+func RemoveNamedDestinationsFile(inFile, outFile string, names []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveNamedDestinations(f1, f2, names, conf)
+}
+
+// RetargetNamedDestinations moves rs's named destinations according to pageMap and writes the result to w.
+// pageMap maps a page number to the page number named destinations shall point to instead, 0 removes them.
+// Dangling named destinations pointing to no longer existing pages are removed.
+func RetargetNamedDestinations(rs io.ReadSeeker, w io.Writer, pageMap map[int]int, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RetargetNamedDestinations: missing rs")
+	}
+
+	ctx, conf, err := readContextForNamedDests(rs, model.RETARGETNAMEDDESTS, conf)
+	if err != nil {
+		return err
+	}
+
+	n, err := pdfcpu.RetargetNamedDestinations(ctx, pageMap)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("retargeted %d named destination(s)\n", n)
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RetargetNamedDestinationsFile moves inFile's named destinations according to pageMap and writes the result to outFile.
+func RetargetNamedDestinationsFile(inFile, outFile string, pageMap map[int]int, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RetargetNamedDestinations(f1, f2, pageMap, conf)
+}
@@ -19,8 +19,8 @@ package api
 import (
 	"io"
 	"os"
+	"path/filepath"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
@@ -97,10 +97,6 @@ func NUp(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nup *m
 	}
 	conf.Cmd = model.NUP
 
-	if log.InfoEnabled() {
-		log.Info.Printf("%s", nup)
-	}
-
 	var (
 		ctx *model.Context
 		err error
@@ -131,13 +127,29 @@ func NUp(rs io.ReadSeeker, w io.Writer, imgFiles, selectedPages []string, nup *m
 
 	}
 
+	ctx.LogInfo("NUp", "nup", nup)
+
 	return Write(ctx, w, conf)
 }
 
+// nupWithSlugFileName returns a copy of nup whose printer's marks use the base name of inFile for the slug line.
+// The caller's nup is left alone so it may be reused for other files.
+func nupWithSlugFileName(nup *model.NUp, inFile string) *model.NUp {
+	nup1 := *nup
+	marks := *nup.Marks
+	marks.FileName = filepath.Base(inFile)
+	nup1.Marks = &marks
+	return &nup1
+}
+
 // NUpFile rearranges PDF pages or images into page grids and writes the result to outFile.
 func NUpFile(inFiles []string, outFile string, selectedPages []string, nup *model.NUp, conf *model.Configuration) (err error) {
 	var f1, f2 *os.File
 
+	if nup.Marks != nil && nup.Marks.FileName == "" && len(inFiles) > 0 {
+		nup = nupWithSlugFileName(nup, inFiles[0])
+	}
+
 	if !nup.ImgInputFile {
 		// Nup from a PDF page.
 		if f1, err = os.Open(inFiles[0]); err != nil {
@@ -0,0 +1,129 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddOCRText makes the scanned pages of rs searchable by overlaying invisible text
+// at the word positions found in hOCR or ALTO XML OCR results and writes the result to w.
+// The OCR pages read from ocr are assigned to the pages of rs in order starting with page 1.
+func AddOCRText(rs io.ReadSeeker, w io.Writer, ocr []io.Reader, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddOCRText: missing rs")
+	}
+
+	if len(ocr) == 0 {
+		return errors.New("pdfcpu: AddOCRText: missing OCR results")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDOCRTEXT
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages := map[int]pdfcpu.OCRPage{}
+	for _, r := range ocr {
+		pp, err := pdfcpu.ParseOCR(r)
+		if err != nil {
+			return err
+		}
+		for _, p := range pp {
+			pages[len(pages)+1] = p
+		}
+	}
+
+	if len(pages) > ctx.PageCount {
+		return errors.Errorf("pdfcpu: AddOCRText: %d OCR pages for %d pages", len(pages), ctx.PageCount)
+	}
+
+	if err := pdfcpu.AddOCRText(ctx, pages); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddOCRTextFile makes the scanned pages of inFile searchable using the hOCR or ALTO XML results in ocrFiles
+// and writes the result to outFile.
+func AddOCRTextFile(inFile, outFile string, ocrFiles []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	ff := make([]*os.File, 0, len(ocrFiles))
+	defer func() {
+		for _, f := range ff {
+			f.Close()
+		}
+	}()
+
+	rr := make([]io.Reader, 0, len(ocrFiles))
+	for _, fn := range ocrFiles {
+		f, err := os.Open(fn)
+		if err != nil {
+			return err
+		}
+		ff = append(ff, f)
+		rr = append(rr, f)
+	}
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddOCRText(f1, f2, rr, conf)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
 
@@ -41,6 +42,30 @@ func Optimize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 		return err
 	}
 
+	if ctx, err = cleanupPages(ctx); err != nil {
+		return err
+	}
+
+	if conf.ImageOptimization != nil {
+		stats, err := pdfcpu.OptimizeImages(ctx, conf.ImageOptimization)
+		if err != nil {
+			return err
+		}
+		if log.CLIEnabled() {
+			log.CLI.Printf("re-encoded %d images (%d downsampled, %d grayscaled, %d mrc), saved %s\n", stats.Images, stats.Downsized, stats.Grayscaled, stats.MRC, types.ByteSize(stats.BytesSaved))
+		}
+	}
+
+	if conf.SubsetFonts {
+		stats, err := pdfcpu.SubsetFonts(ctx)
+		if err != nil {
+			return err
+		}
+		if log.CLIEnabled() {
+			log.CLI.Printf("subset %d fonts, saved %s\n", stats.Fonts, types.ByteSize(stats.BytesSaved))
+		}
+	}
+
 	if log.StatsEnabled() {
 		log.Stats.Printf("XRefTable:\n%s\n", ctx)
 	}
@@ -0,0 +1,185 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Output creates the writer for an output artifact like a split part or an extracted image.
+// Functions writing to an Output close each writer once the artifact is complete.
+type Output func(name string) (io.WriteCloser, error)
+
+// dirFile is a file created by DirOutput which gets removed on close if writing it failed.
+type dirFile struct {
+	*os.File
+	failed bool
+}
+
+func (f *dirFile) Write(p []byte) (int, error) {
+	n, err := f.File.Write(p)
+	if err != nil {
+		f.failed = true
+	}
+	return n, err
+}
+
+func (f *dirFile) Close() error {
+	err := f.File.Close()
+	if f.failed || err != nil {
+		os.Remove(f.Name())
+	}
+	return err
+}
+
+// DirOutput returns an Output creating files in dir.
+// Partially written files are removed.
+func DirOutput(dir string) Output {
+	return func(name string) (io.WriteCloser, error) {
+		outFile := filepath.Join(dir, name)
+		f, err := os.Create(outFile)
+		if err != nil {
+			return nil, err
+		}
+		logWritingTo(outFile)
+		return &dirFile{File: f}, nil
+	}
+}
+
+type memFile struct {
+	bytes.Buffer
+}
+
+func (f *memFile) Close() error {
+	return nil
+}
+
+// MemOutput returns an Output collecting artifacts in m mapped by name.
+func MemOutput(m map[string]*bytes.Buffer) Output {
+	return func(name string) (io.WriteCloser, error) {
+		f := &memFile{}
+		m[name] = &f.Buffer
+		return f, nil
+	}
+}
+
+func writeOutput(out Output, name string, r io.Reader) error {
+	if out == nil {
+		return errors.New("pdfcpu: missing output")
+	}
+	w, err := out(name)
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(w, r); err != nil {
+		if f, ok := w.(*dirFile); ok {
+			f.failed = true
+		}
+		w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// ReadSeekerFS returns an io.ReadSeeker for the file name of fsys.
+// Files implementing io.ReadSeeker are returned as is, the caller is responsible for closing them.
+// Files not supporting io.Seeker, eg. zip entries, get read into memory.
+func ReadSeekerFS(fsys fs.FS, name string) (io.ReadSeeker, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if rs, ok := f.(io.ReadSeeker); ok {
+		return rs, nil
+	}
+
+	defer f.Close()
+
+	bb, err := io.ReadAll(f)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(bb), nil
+}
+
+func closeReadSeekers(rsc []io.ReadSeeker) {
+	for _, rs := range rsc {
+		if c, ok := rs.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
+
+func readSeekersFS(fsys fs.FS, names []string) ([]io.ReadSeeker, error) {
+	rsc := make([]io.ReadSeeker, 0, len(names))
+	for _, name := range names {
+		rs, err := ReadSeekerFS(fsys, name)
+		if err != nil {
+			closeReadSeekers(rsc)
+			return nil, err
+		}
+		rsc = append(rsc, rs)
+	}
+	return rsc, nil
+}
+
+// ReadContextFS reads in the PDF file name of fsys and returns an unvalidated context.
+func ReadContextFS(fsys fs.FS, name string, conf *model.Configuration) (*model.Context, error) {
+	rs, err := ReadSeekerFS(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	defer closeReadSeekers([]io.ReadSeeker{rs})
+	return ReadContext(rs, conf)
+}
+
+// MergeFS merges a sequence of PDF files of fsys and writes the result to w.
+func MergeFS(fsys fs.FS, inFiles []string, w io.Writer, dividerPage bool, conf *model.Configuration) error {
+	rsc, err := readSeekersFS(fsys, inFiles)
+	if err != nil {
+		return err
+	}
+	defer closeReadSeekers(rsc)
+	return MergeRaw(rsc, w, dividerPage, conf)
+}
+
+// ImportImagesFS appends PDF pages containing images of fsys to rs and writes the result to w.
+// If rs == nil a new PDF file will be written to w.
+func ImportImagesFS(rs io.ReadSeeker, w io.Writer, fsys fs.FS, imgFiles []string, imp *pdfcpu.Import, conf *model.Configuration) error {
+	rsc, err := readSeekersFS(fsys, imgFiles)
+	if err != nil {
+		return err
+	}
+	defer closeReadSeekers(rsc)
+
+	imgs := make([]io.Reader, len(rsc))
+	for i, r := range rsc {
+		imgs[i] = r
+	}
+
+	return ImportImages(rs, w, imgs, imp, conf)
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForOutputIntents(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, conf, nil
+}
+
+// OutputIntents returns rs's output intents.
+func OutputIntents(rs io.ReadSeeker, conf *model.Configuration) ([]model.OutputIntent, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: OutputIntents: missing rs")
+	}
+
+	ctx, _, err := readContextForOutputIntents(rs, model.LISTOUTPUTINTENTS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.OutputIntents(ctx)
+}
+
+// OutputIntentsFile returns inFile's output intents.
+func OutputIntentsFile(inFile string, conf *model.Configuration) ([]model.OutputIntent, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return OutputIntents(f, conf)
+}
+
+// ListOutputIntents lists rs's output intents.
+func ListOutputIntents(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListOutputIntents: missing rs")
+	}
+
+	ctx, _, err := readContextForOutputIntents(rs, model.LISTOUTPUTINTENTS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListOutputIntents(ctx)
+}
+
+// ListOutputIntentsFile lists inFile's output intents.
+func ListOutputIntentsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListOutputIntents(f, conf)
+}
+
+// AddOutputIntent adds oi to rs's output intents and writes the result to w.
+func AddOutputIntent(rs io.ReadSeeker, w io.Writer, oi *model.OutputIntent, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AddOutputIntent: missing rs")
+	}
+
+	ctx, conf, err := readContextForOutputIntents(rs, model.ADDOUTPUTINTENT, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.AddOutputIntent(ctx, oi); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AddOutputIntentFile adds oi to inFile's output intents and writes the result to outFile.
+func AddOutputIntentFile(inFile, outFile string, oi *model.OutputIntent, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AddOutputIntent(f1, f2, oi, conf)
+}
+
+// ReplaceOutputIntent replaces rs's output intent having the subtype of oi by oi and writes the result to w.
+func ReplaceOutputIntent(rs io.ReadSeeker, w io.Writer, oi *model.OutputIntent, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReplaceOutputIntent: missing rs")
+	}
+
+	ctx, conf, err := readContextForOutputIntents(rs, model.REPLACEOUTPUTINTENT, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.ReplaceOutputIntent(ctx, oi); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ReplaceOutputIntentFile replaces inFile's output intent having the subtype of oi by oi and writes the result to outFile.
+func ReplaceOutputIntentFile(inFile, outFile string, oi *model.OutputIntent, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ReplaceOutputIntent(f1, f2, oi, conf)
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestDirOutputRemovesPartialFiles(t *testing.T) {
+	dir := t.TempDir()
+	out := DirOutput(dir)
+
+	r := io.MultiReader(strings.NewReader("partial"), iotest.ErrReader(errors.New("broken")))
+	if err := writeOutput(out, "broken.txt", r); err == nil {
+		t.Fatal("want error for broken reader")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "broken.txt")); !os.IsNotExist(err) {
+		t.Fatalf("want partially written file removed, got %v\n", err)
+	}
+
+	if err := writeOutput(out, "ok.txt", strings.NewReader("complete")); err != nil {
+		t.Fatal(err)
+	}
+	if bb, err := os.ReadFile(filepath.Join(dir, "ok.txt")); err != nil || string(bb) != "complete" {
+		t.Fatalf("want complete file, got %q %v\n", bb, err)
+	}
+}
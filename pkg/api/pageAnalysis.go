@@ -0,0 +1,81 @@
+/*
+	Copyright 2025 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AnalyzePages returns the ink coverage of the pages of rs and identifies blank and duplicate pages.
+func AnalyzePages(rs io.ReadSeeker, conf *model.Configuration) ([]model.PageAnalysis, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: AnalyzePages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ANALYZEPAGES
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := model.DefaultBlankThreshold
+	if conf.PageCleanup != nil && conf.PageCleanup.BlankThreshold > 0 {
+		threshold = conf.PageCleanup.BlankThreshold
+	}
+
+	return pdfcpu.AnalyzePages(ctx, threshold)
+}
+
+// AnalyzePagesFile returns the ink coverage of the pages of inFile and identifies blank and duplicate pages.
+func AnalyzePagesFile(inFile string, conf *model.Configuration) ([]model.PageAnalysis, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return AnalyzePages(f, conf)
+}
+
+// cleanupPages removes blank and duplicate pages from ctx as configured by ctx.Conf.PageCleanup.
+func cleanupPages(ctx *model.Context) (*model.Context, error) {
+	pc := ctx.Conf.PageCleanup
+	if pc == nil {
+		return ctx, nil
+	}
+
+	ctxDest, removed, err := pdfcpu.CleanupPages(ctx, pc)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(removed) > 0 && log.CLIEnabled() {
+		log.CLI.Printf("removed pages: %v\n", removed)
+	}
+
+	return ctxDest, nil
+}
@@ -0,0 +1,241 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForPageLabels(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return ctx, conf, nil
+}
+
+// PageLabels returns rs's page label ranges.
+func PageLabels(rs io.ReadSeeker, conf *model.Configuration) ([]model.PageLabel, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageLabels: missing rs")
+	}
+
+	ctx, _, err := readContextForPageLabels(rs, model.LISTPAGELABELS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.PageLabels(ctx)
+}
+
+// PageLabelsFile returns inFile's page label ranges.
+func PageLabelsFile(inFile string, conf *model.Configuration) ([]model.PageLabel, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageLabels(f, conf)
+}
+
+// ListPageLabels lists rs's page label ranges.
+func ListPageLabels(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListPageLabels: missing rs")
+	}
+
+	ctx, _, err := readContextForPageLabels(rs, model.LISTPAGELABELS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListPageLabels(ctx)
+}
+
+// ListPageLabelsFile lists inFile's page label ranges.
+func ListPageLabelsFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListPageLabels(f, conf)
+}
+
+// SetPageLabels replaces rs's page labels by pls and writes the result to w.
+func SetPageLabels(rs io.ReadSeeker, w io.Writer, pls []model.PageLabel, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPageLabels: missing rs")
+	}
+
+	ctx, conf, err := readContextForPageLabels(rs, model.SETPAGELABELS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetPageLabels(ctx, pls); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetPageLabelsFile replaces inFile's page labels by pls and writes the result to outFile.
+func SetPageLabelsFile(inFile, outFile string, pls []model.PageLabel, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPageLabels(f1, f2, pls, conf)
+}
+
+// RemovePageLabels removes rs's page labels and writes the result to w.
+func RemovePageLabels(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePageLabels: missing rs")
+	}
+
+	ctx, conf, err := readContextForPageLabels(rs, model.REMOVEPAGELABELS, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.RemovePageLabels(ctx); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemovePageLabelsFile removes inFile's page labels and writes the result to outFile.
+func RemovePageLabelsFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemovePageLabels(f1, f2, conf)
+}
+
+// PageSelectionForLabels translates the page labels used in pageSelection into page numbers of rs.
+// The result may be passed on as page selection to any other API function.
+// eg. []string{"iii-v", "A-2"} may result in []string{"3-5", "12"}.
+func PageSelectionForLabels(rs io.ReadSeeker, pageSelection []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageSelectionForLabels: missing rs")
+	}
+
+	ctx, _, err := readContextForPageLabels(rs, model.LISTPAGELABELS, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	ss, err := pdfcpu.PageSelectionForLabels(ctx, pageSelection)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ss) == 0 {
+		return nil, nil
+	}
+
+	return ParsePageSelection(strings.Join(ss, ","))
+}
+
+// PageSelectionForLabelsFile translates the page labels used in pageSelection into page numbers of inFile.
+func PageSelectionForLabelsFile(inFile string, pageSelection []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageSelectionForLabels(f, pageSelection, conf)
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// ProcessPages calls fn for selected pages of ctx using up to workers goroutines, workers <= 0 means one per CPU.
+// Each goroutine works on its own snapshot of ctx (see model.Context.Snapshot),
+// so fn may apply any read-only operation like text, image or font extraction to the context it gets passed.
+// ctx must not be used elsewhere while ProcessPages is running.
+// ProcessPages returns the first error encountered and stops handing out pages once fn failed.
+func ProcessPages(ctx *model.Context, selectedPages []string, workers int, fn func(ctx *model.Context, pageNr int) error) error {
+	if ctx == nil {
+		return errors.New("pdfcpu: ProcessPages: missing ctx")
+	}
+
+	if fn == nil {
+		return errors.New("pdfcpu: ProcessPages: missing fn")
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := []int{}
+	for k, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, k)
+		}
+	}
+	if len(pageNrs) == 0 {
+		return nil
+	}
+	sort.Ints(pageNrs)
+
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, len(pageNrs))
+
+	// Take all snapshots up front, Snapshot must not run concurrently with any other use of ctx.
+	snapshots := make([]*model.Context, workers)
+	for i := range snapshots {
+		if snapshots[i], err = ctx.Snapshot(); err != nil {
+			return err
+		}
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		done     = make(chan struct{})
+		ch       = make(chan int)
+	)
+
+	for _, snapshot := range snapshots {
+		wg.Add(1)
+		go func(ctx *model.Context) {
+			defer wg.Done()
+			for pageNr := range ch {
+				if err := fn(ctx, pageNr); err != nil {
+					once.Do(func() {
+						firstErr = errors.Wrapf(err, "page %d", pageNr)
+						close(done)
+					})
+				}
+			}
+		}(snapshot)
+	}
+
+dispatch:
+	for _, pageNr := range pageNrs {
+		select {
+		case ch <- pageNr:
+		case <-done:
+			break dispatch
+		}
+	}
+	close(ch)
+	wg.Wait()
+
+	return firstErr
+}
@@ -0,0 +1,402 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// pipelineOp describes an operation available to pipeline steps.
+type pipelineOp struct {
+	cmd     model.CommandMode
+	options []string
+	apply   func(ctx *model.Context, step model.PipelineStep) (*model.Context, error)
+}
+
+var pipelineOps = map[string]pipelineOp{
+	"decrypt":   {model.DECRYPT, []string{"upw", "opw"}, nil},
+	"remove":    {model.REMOVEPAGES, nil, pipelineRemovePages},
+	"rotate":    {model.ROTATE, []string{"rotation"}, pipelineRotate},
+	"stamp":     {model.ADDWATERMARKS, []string{"mode", "string", "desc"}, pipelineAddWatermarks},
+	"watermark": {model.ADDWATERMARKS, []string{"mode", "string", "desc"}, pipelineAddWatermarks},
+	"optimize":  {model.OPTIMIZE, nil, pipelineOptimize},
+	"encrypt":   {model.ENCRYPT, []string{"upw", "opw", "mode", "key", "perm"}, pipelineEncrypt},
+}
+
+func pipelineRemovePages(ctx *model.Context, step model.PipelineStep) (*model.Context, error) {
+	pages, err := RemainingPagesForPageRemoval(ctx.PageCount, step.Pages, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var pageNrs []int
+	for k, v := range pages {
+		if v {
+			pageNrs = append(pageNrs, k)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	ctxDest, err := pdfcpu.ExtractPages(ctx, pageNrs, false)
+	if err != nil {
+		return nil, err
+	}
+
+	// Allow for subsequent optimize steps.
+	ctxDest.ResetOptimizationContext()
+
+	return ctxDest, nil
+}
+
+func pipelineRotate(ctx *model.Context, step model.PipelineStep) (*model.Context, error) {
+	rotation, err := strconv.Atoi(step.Options["rotation"])
+	if err != nil || rotation%90 != 0 {
+		return nil, errors.Errorf("pdfcpu: rotation must be a multiple of 90: %s", step.Options["rotation"])
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, step.Pages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, pdfcpu.RotatePages(ctx, pages, rotation)
+}
+
+func pipelineAddWatermarks(ctx *model.Context, step model.PipelineStep) (*model.Context, error) {
+	var (
+		wm    *model.Watermark
+		err   error
+		s     = step.Options["string"]
+		desc  = step.Options["desc"]
+		onTop = step.Op == "stamp"
+	)
+
+	switch step.Options["mode"] {
+	case "", "text":
+		wm, err = TextWatermark(s, desc, onTop, false, ctx.Unit)
+	case "image":
+		wm, err = ImageWatermark(s, desc, onTop, false, ctx.Unit)
+	case "pdf":
+		wm, err = PDFWatermark(s, desc, onTop, false, ctx.Unit)
+	default:
+		err = errors.Errorf("pdfcpu: invalid mode: %s (text, image, pdf)", step.Options["mode"])
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, step.Pages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx, pdfcpu.AddWatermarks(ctx, pages, wm)
+}
+
+func pipelineOptimize(ctx *model.Context, step model.PipelineStep) (*model.Context, error) {
+	return ctx, OptimizeContext(ctx)
+}
+
+func pipelineEncrypt(ctx *model.Context, step model.PipelineStep) (*model.Context, error) {
+	conf := ctx.Configuration
+
+	conf.UserPW = step.Options["upw"]
+	conf.OwnerPW = step.Options["opw"]
+
+	switch step.Options["mode"] {
+	case "", "aes":
+		conf.EncryptUsingAES = true
+	case "rc4":
+		conf.EncryptUsingAES = false
+	default:
+		return nil, errors.Errorf("pdfcpu: invalid mode: %s (rc4, aes)", step.Options["mode"])
+	}
+
+	if s := step.Options["key"]; s != "" {
+		kl, err := strconv.Atoi(s)
+		if err != nil || (kl != 40 && kl != 128 && kl != 256) {
+			return nil, errors.Errorf("pdfcpu: invalid key length: %s (40, 128, 256)", s)
+		}
+		conf.EncryptKeyLength = kl
+	}
+
+	switch step.Options["perm"] {
+	case "", "none":
+		conf.Permissions = model.PermissionsNone
+	case "print":
+		conf.Permissions = model.PermissionsPrint
+	case "all":
+		conf.Permissions = model.PermissionsAll
+	default:
+		return nil, errors.Errorf("pdfcpu: invalid permissions: %s (none, print, all)", step.Options["perm"])
+	}
+
+	return ctx, nil
+}
+
+// ValidatePipeline checks p for unknown operations, options and a misplaced decrypt or encrypt step.
+func ValidatePipeline(p *model.Pipeline) error {
+	if p == nil {
+		return errors.New("pdfcpu: missing pipeline")
+	}
+
+	if len(p.Steps) == 0 {
+		return errors.New("pdfcpu: pipeline: missing steps")
+	}
+
+	for i, step := range p.Steps {
+		op, ok := pipelineOps[step.Op]
+		if !ok {
+			return errors.Errorf("pdfcpu: pipeline step %d: unknown op: %s", i+1, step.Op)
+		}
+		for k := range step.Options {
+			if !slices.Contains(op.options, k) {
+				return errors.Errorf("pdfcpu: pipeline step %d (%s): unknown option: %s", i+1, step.Op, k)
+			}
+		}
+		if step.Op == "decrypt" && i > 0 {
+			return errors.Errorf("pdfcpu: pipeline step %d: decrypt must be the first step", i+1)
+		}
+		if step.Op == "encrypt" {
+			if i < len(p.Steps)-1 {
+				return errors.Errorf("pdfcpu: pipeline step %d: encrypt must be the last step", i+1)
+			}
+			if step.Options["opw"] == "" {
+				return errors.Errorf("pdfcpu: pipeline step %d (encrypt): missing owner password", i+1)
+			}
+		}
+		if step.Op == "remove" && len(step.Pages) == 0 {
+			return errors.Errorf("pdfcpu: pipeline step %d (remove): missing page selection", i+1)
+		}
+	}
+
+	return nil
+}
+
+// RunPipeline applies all steps of p to rs in order and writes the result to w.
+// rs is read and w is written once only, all steps operate on the same in-memory context.
+func RunPipeline(rs io.ReadSeeker, w io.Writer, p *model.Pipeline, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RunPipeline: missing rs")
+	}
+
+	if err := ValidatePipeline(p); err != nil {
+		return err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.PIPELINE
+
+	first, last := p.Steps[0], p.Steps[len(p.Steps)-1]
+
+	writeCmd := model.PIPELINE
+	if first.Op == "decrypt" {
+		conf.Cmd = model.DECRYPT
+		writeCmd = model.DECRYPT
+		if s, ok := first.Options["upw"]; ok {
+			conf.UserPW = s
+		}
+		if s, ok := first.Options["opw"]; ok {
+			conf.OwnerPW = s
+		}
+	}
+	if last.Op == "encrypt" {
+		writeCmd = model.ENCRYPT
+	}
+
+	for _, step := range p.Steps {
+		if step.Op == "stamp" || step.Op == "watermark" {
+			conf.OptimizeDuplicateContentStreams = false
+		}
+	}
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	if writeCmd == model.ENCRYPT && first.Op != "decrypt" && ctx.Encrypt != nil {
+		return errors.New("pdfcpu: this file is already encrypted")
+	}
+
+	for i, step := range p.Steps {
+		op := pipelineOps[step.Op]
+		if op.apply == nil {
+			continue
+		}
+		if log.CLIEnabled() {
+			log.CLI.Printf("step %d: %s\n", i+1, step.Op)
+		}
+		conf.Cmd = op.cmd
+		ctx.LogInfo("pipeline step", "step", i+1)
+		if ctx, err = op.apply(ctx, step); err != nil {
+			return errors.Wrapf(err, "pipeline step %d (%s)", i+1, step.Op)
+		}
+	}
+
+	conf.Cmd = writeCmd
+
+	return Write(ctx, w, conf)
+}
+
+func runPipelineFile(inFile, outFile string, p *model.Pipeline, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RunPipeline(f1, f2, p, conf)
+}
+
+// PipelineInputFiles returns the input files of p in order with all glob patterns expanded.
+func PipelineInputFiles(p *model.Pipeline) ([]string, error) {
+	var inFiles []string
+	seen := map[string]bool{}
+
+	for _, pattern := range p.Input {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "pdfcpu: pipeline: invalid input: %s", pattern)
+		}
+		if len(matches) == 0 {
+			return nil, errors.Errorf("pdfcpu: pipeline: no input files matching: %s", pattern)
+		}
+		for _, fn := range matches {
+			if !seen[fn] {
+				seen[fn] = true
+				inFiles = append(inFiles, fn)
+			}
+		}
+	}
+
+	if len(inFiles) == 0 {
+		return nil, errors.New("pdfcpu: pipeline: missing input")
+	}
+
+	return inFiles, nil
+}
+
+// RunPipelines applies p to each of its input files and returns the files written.
+// An output ending with .pdf names the output file for a single input file,
+// any other output is taken as output directory, no output processes input files in place.
+func RunPipelines(p *model.Pipeline, conf *model.Configuration) ([]string, error) {
+	if err := ValidatePipeline(p); err != nil {
+		return nil, err
+	}
+
+	inFiles, err := PipelineInputFiles(p)
+	if err != nil {
+		return nil, err
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	outFile := func(inFile string) string { return inFile }
+
+	if p.Output != "" {
+		if strings.HasSuffix(strings.ToLower(p.Output), ".pdf") {
+			if len(inFiles) > 1 {
+				return nil, errors.Errorf("pdfcpu: pipeline: output %s requires a single input file", p.Output)
+			}
+			outFile = func(string) string { return p.Output }
+		} else {
+			if err := os.MkdirAll(p.Output, os.ModePerm); err != nil {
+				return nil, err
+			}
+			outFile = func(inFile string) string { return filepath.Join(p.Output, filepath.Base(inFile)) }
+		}
+	}
+
+	var outFiles []string
+
+	for _, inFile := range inFiles {
+		// Steps modify passwords and encryption settings, start from the same configuration for each file.
+		c := *conf
+		f := outFile(inFile)
+		if err := runPipelineFile(inFile, f, p, &c); err != nil {
+			return outFiles, errors.Wrap(err, inFile)
+		}
+		outFiles = append(outFiles, f)
+	}
+
+	return outFiles, nil
+}
+
+// RunPipelineFile runs the pipeline described by jobFile in JSON or YAML and returns the files written.
+// Relative paths within jobFile are resolved against the directory of jobFile.
+func RunPipelineFile(jobFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(jobFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p, err := pdfcpu.ParsePipeline(f)
+	if err != nil {
+		return nil, err
+	}
+	p.ResolvePaths(filepath.Dir(jobFile))
+
+	return RunPipelines(p, conf)
+}
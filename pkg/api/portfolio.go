@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// CreatePortfolio writes a portfolio (aka PDF collection) made up of the files of p to w.
+// rs serves as cover sheet, if rs is nil a cover sheet listing the embedded files is generated.
+func CreatePortfolio(rs io.ReadSeeker, w io.Writer, p *model.Portfolio, conf *model.Configuration) error {
+	if w == nil {
+		return errors.New("pdfcpu: CreatePortfolio: missing w")
+	}
+
+	if p == nil {
+		return errors.New("pdfcpu: CreatePortfolio: missing portfolio")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CREATEPORTFOLIO
+
+	var (
+		ctx *model.Context
+		err error
+	)
+
+	if rs != nil {
+		ctx, err = ReadValidateAndOptimize(rs, conf)
+	} else {
+		ctx, err = pdfcpu.CreatePortfolioCoverSheet(p, conf)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.CreatePortfolio(ctx, p); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// CreatePortfolioFile writes a portfolio described by inFileJSON to outFile.
+// Relative paths within inFileJSON are resolved against the directory of inFileJSON.
+func CreatePortfolioFile(inFileJSON, outFile string, conf *model.Configuration) (err error) {
+	var f0, f1, f2 *os.File
+
+	if f0, err = os.Open(inFileJSON); err != nil {
+		return err
+	}
+	defer f0.Close()
+
+	p, err := pdfcpu.ParsePortfolio(f0)
+	if err != nil {
+		return err
+	}
+	p.ResolvePaths(filepath.Dir(inFileJSON))
+
+	var rs io.ReadSeeker
+	if p.Cover != "" {
+		if f1, err = os.Open(p.Cover); err != nil {
+			return err
+		}
+		defer f1.Close()
+		rs = f1
+	}
+
+	if f2, err = os.Create(outFile); err != nil {
+		return err
+	}
+	logWritingTo(outFile)
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			os.Remove(outFile)
+			return
+		}
+		err = f2.Close()
+	}()
+
+	return CreatePortfolio(rs, f2, p, conf)
+}
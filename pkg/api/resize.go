@@ -23,6 +23,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
 
@@ -106,3 +107,79 @@ func ResizeFile(inFile, outFile string, selectedPages []string, resize *model.Re
 
 	return Resize(f1, f2, selectedPages, resize, conf)
 }
+
+// ResizePages scales selected pages of rs to pageDim including their content and annotations and writes result to w.
+func ResizePages(rs io.ReadSeeker, w io.Writer, selectedPages []string, pageDim types.Dim, fit model.ResizeFit, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ResizePages: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.RESIZE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.ResizePages(ctx, pages, pageDim, fit); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// ResizePagesFile scales selected pages of inFile to pageDim including their content and annotations and writes result to outFile.
+func ResizePagesFile(inFile, outFile string, selectedPages []string, pageDim types.Dim, fit model.ResizeFit, conf *model.Configuration) (err error) {
+	if log.CLIEnabled() {
+		log.CLI.Printf("resizing %s\n", inFile)
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	var (
+		f1, f2 *os.File
+	)
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ResizePages(f1, f2, selectedPages, pageDim, fit, conf)
+}
@@ -93,3 +93,96 @@ func RotateFile(inFile, outFile string, rotation int, selectedPages []string, co
 
 	return Rotate(f1, f2, rotation, selectedPages, conf)
 }
+
+// TextOrientations detects the text orientation of selected pages of rs.
+func TextOrientations(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]pdfcpu.TextOrientation, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: TextOrientations: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUTOROTATE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.TextOrientations(ctx, pages)
+}
+
+// AutoRotate fixes the rotation of selected pages of rs whose text is not displayed upright and writes the result to w.
+func AutoRotate(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: AutoRotate: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUTOROTATE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if _, err = pdfcpu.AutoRotate(ctx, pages); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// AutoRotateFile fixes the rotation of selected pages of inFile whose text is not displayed upright and writes the result to outFile.
+func AutoRotateFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return AutoRotate(f1, f2, selectedPages, conf)
+}
@@ -0,0 +1,100 @@
+/*
+	Copyright 2025 The pdfcpu Authors.
+
+	Licensed under the Apache License, Version 2.0 (the "License");
+	you may not use this file except in compliance with the License.
+	You may obtain a copy of the License at
+
+		http://www.apache.org/licenses/LICENSE-2.0
+
+	Unless required by applicable law or agreed to in writing, software
+	distributed under the License is distributed on an "AS IS" BASIS,
+	WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+	See the License for the specific language governing permissions and
+	limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Sanitize prepares rs for external release and writes the result to w.
+// JavaScript and launch actions, embedded files, hidden layers, metadata, review annotations
+// and any incremental updates get removed. Sanitize returns a report of what got removed.
+func Sanitize(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) (*model.SanitizeReport, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: Sanitize: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SANITIZE
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := pdfcpu.Sanitize(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = Write(ctx, w, conf); err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// SanitizeFile prepares inFile for external release and writes the result to outFile.
+// If outFile is not provided then inFile gets overwritten
+// which leads to the same result as when inFile equals outFile.
+func SanitizeFile(inFile, outFile string, conf *model.Configuration) (r *model.SanitizeReport, err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return nil, err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return nil, err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return Sanitize(f1, f2, conf)
+}
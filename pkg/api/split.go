@@ -60,21 +60,19 @@ func spanFileName(fileName string, from, thru int) string {
 	return fn + "-" + strconv.Itoa(thru) + ".pdf"
 }
 
-func splitOutPath(outDir, fileName string, forBookmark bool, from, thru int) string {
-	p := filepath.Join(outDir, fileName+".pdf")
-	if !forBookmark {
-		p = filepath.Join(outDir, spanFileName(fileName, from, thru))
+func splitOutName(fileName string, forBookmark bool, from, thru int) string {
+	if forBookmark {
+		return fileName + ".pdf"
 	}
-	return p
+	return spanFileName(fileName, from, thru)
 }
 
-func writePageSpan(ctx *model.Context, from, thru int, outPath string) error {
+func writePageSpan(ctx *model.Context, from, thru int, out Output, name string) error {
 	ps, err := pageSpan(ctx, from, thru)
 	if err != nil {
 		return err
 	}
-	logWritingTo(outPath)
-	return pdfcpu.WriteReader(outPath, ps.Reader)
+	return writeOutput(out, name, ps.Reader)
 }
 
 func context(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error) {
@@ -83,7 +81,12 @@ func context(rs io.ReadSeeker, conf *model.Configuration) (*model.Context, error
 	}
 	conf.Cmd = model.SPLIT
 
-	return ReadValidateAndOptimize(rs, conf)
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return cleanupPages(ctx)
 }
 
 func pageSpansSplitAlongBookmarks(ctx *model.Context) ([]*PageSpan, error) {
@@ -141,14 +144,14 @@ func pageSpans(ctx *model.Context, span int) ([]*PageSpan, error) {
 	return pss, nil
 }
 
-func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error {
+func writePageSpans(ctx *model.Context, span int, out Output, fileName string) error {
 	forBookmark := false
 
 	for i := 0; i < ctx.PageCount/span; i++ {
 		start := i * span
 		from, thru := start+1, start+span
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		name := splitOutName(fileName, forBookmark, from, thru)
+		if err := writePageSpan(ctx, from, thru, out, name); err != nil {
 			return err
 		}
 	}
@@ -157,8 +160,8 @@ func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error
 	if ctx.PageCount%span > 0 {
 		start := (ctx.PageCount / span) * span
 		from, thru := start+1, ctx.PageCount
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		name := splitOutName(fileName, forBookmark, from, thru)
+		if err := writePageSpan(ctx, from, thru, out, name); err != nil {
 			return err
 		}
 	}
@@ -166,7 +169,7 @@ func writePageSpans(ctx *model.Context, span int, outDir, fileName string) error
 	return nil
 }
 
-func writePageSpansSplitAlongBookmarks(ctx *model.Context, outDir string) error {
+func writePageSpansSplitAlongBookmarks(ctx *model.Context, out Output) error {
 	forBookmark := true
 
 	bms, err := pdfcpu.Bookmarks(ctx)
@@ -180,8 +183,8 @@ func writePageSpansSplitAlongBookmarks(ctx *model.Context, outDir string) error
 		if thru == 0 {
 			thru = ctx.PageCount
 		}
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		name := splitOutName(fileName, forBookmark, from, thru)
+		if err := writePageSpan(ctx, from, thru, out, name); err != nil {
 			return err
 		}
 	}
@@ -189,7 +192,7 @@ func writePageSpansSplitAlongBookmarks(ctx *model.Context, outDir string) error
 	return nil
 }
 
-func writePageSpansSplitAlongPages(ctx *model.Context, pageNrs []int, outDir, fileName string) error {
+func writePageSpansSplitAlongPages(ctx *model.Context, pageNrs []int, out Output, fileName string) error {
 	// pageNumbers is a a sorted sequence of page numbers.
 	forBookmark := false
 	from, thru := 1, 0
@@ -207,16 +210,16 @@ func writePageSpansSplitAlongPages(ctx *model.Context, pageNrs []int, outDir, fi
 		if thru >= ctx.PageCount {
 			break
 		}
-		path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-		if err := writePageSpan(ctx, from, thru, path); err != nil {
+		name := splitOutName(fileName, forBookmark, from, thru)
+		if err := writePageSpan(ctx, from, thru, out, name); err != nil {
 			return err
 		}
 		from = thru + 1
 	}
 
 	thru = ctx.PageCount
-	path := splitOutPath(outDir, fileName, forBookmark, from, thru)
-	return writePageSpan(ctx, from, thru, path)
+	name := splitOutName(fileName, forBookmark, from, thru)
+	return writePageSpan(ctx, from, thru, out, name)
 }
 
 // SplitRaw returns page spans for the PDF stream read from rs obeying given split span.
@@ -244,6 +247,14 @@ func SplitRaw(rs io.ReadSeeker, span int, conf *model.Configuration) ([]*PageSpa
 // If span == 0 we split along given bookmarks (level 1 only).
 // Default span: 1
 func Split(rs io.ReadSeeker, outDir, fileName string, span int, conf *model.Configuration) error {
+	return SplitTo(rs, DirOutput(outDir), fileName, span, conf)
+}
+
+// SplitTo writes a sequence of PDF files to out for the PDF stream read from rs obeying given split span.
+// If span == 1 splitting results in single page PDFs.
+// If span == 0 we split along given bookmarks (level 1 only).
+// Default span: 1
+func SplitTo(rs io.ReadSeeker, out Output, fileName string, span int, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: Split: missing rs")
 	}
@@ -254,9 +265,9 @@ func Split(rs io.ReadSeeker, outDir, fileName string, span int, conf *model.Conf
 	}
 
 	if span == 0 {
-		return writePageSpansSplitAlongBookmarks(ctx, outDir)
+		return writePageSpansSplitAlongBookmarks(ctx, out)
 	}
-	return writePageSpans(ctx, span, outDir, fileName)
+	return writePageSpans(ctx, span, out, fileName)
 }
 
 // SplitFile generates a sequence of PDF files in outDir for inFile obeying given split span.
@@ -285,6 +296,11 @@ func SplitFile(inFile, outDir string, span int, conf *model.Configuration) error
 
 // SplitFile generates a sequence of PDF files in outDir for rs splitting along pageNrs.
 func SplitByPageNr(rs io.ReadSeeker, outDir, fileName string, pageNrs []int, conf *model.Configuration) error {
+	return SplitByPageNrTo(rs, DirOutput(outDir), fileName, pageNrs, conf)
+}
+
+// SplitByPageNrTo writes a sequence of PDF files to out for rs splitting along pageNrs.
+func SplitByPageNrTo(rs io.ReadSeeker, out Output, fileName string, pageNrs []int, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: SplitByPageNr: missing rs")
 	}
@@ -294,7 +310,7 @@ func SplitByPageNr(rs io.ReadSeeker, outDir, fileName string, pageNrs []int, con
 		return err
 	}
 
-	return writePageSpansSplitAlongPages(ctx, pageNrs, outDir, fileName)
+	return writePageSpansSplitAlongPages(ctx, pageNrs, out, fileName)
 }
 
 // SplitFile generates a sequence of PDF files in outDir for inFile splitting it along pageNrs.
@@ -311,6 +311,75 @@ func RemoveWatermarksFile(inFile, outFile string, selectedPages []string, conf *
 	return RemoveWatermarks(f1, f2, selectedPages, conf)
 }
 
+// RemoveDetectedWatermarks removes watermarks and stamps added by any tool from all pages selected in rs and writes the result to w.
+func RemoveDetectedWatermarks(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemoveDetectedWatermarks: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEWATERMARKS
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return err
+	}
+
+	if err = pdfcpu.RemoveDetectedWatermarks(ctx, pages); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemoveDetectedWatermarksFile removes watermarks and stamps added by any tool from all selected pages of inFile and writes the result to outFile.
+func RemoveDetectedWatermarksFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemoveDetectedWatermarks(f1, f2, selectedPages, conf)
+}
+
 // HasWatermarks checks rs for watermarks.
 func HasWatermarks(rs io.ReadSeeker, conf *model.Configuration) (bool, error) {
 	if rs == nil {
@@ -382,6 +451,34 @@ func ImageWatermarkForReader(r io.Reader, desc string, onTop, update bool, u typ
 	return wm, nil
 }
 
+// SVGWatermark returns an SVG watermark configuration.
+func SVGWatermark(fileName, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := pdfcpu.ParseSVGWatermarkDetails(fileName, desc, onTop, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.Update = update
+
+	return wm, nil
+}
+
+// SVGWatermarkForReader returns an SVG watermark configuration for r.
+func SVGWatermarkForReader(r io.Reader, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := pdfcpu.ParseSVGWatermarkDetails("", desc, onTop, u)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := pdfcpu.SetSVG(r, wm); err != nil {
+		return nil, err
+	}
+
+	wm.Update = update
+
+	return wm, nil
+}
+
 // PDFWatermark returns a PDF watermark configuration.
 func PDFWatermark(fileName, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
 	wm, err := pdfcpu.ParsePDFWatermarkDetails(fileName, desc, onTop, u)
@@ -427,6 +524,32 @@ func PDFMultiWatermarkForReadSeeker(rs io.ReadSeeker, startPageNrSrc, startPageN
 	return wm, nil
 }
 
+// QRCodeWatermark returns a QR code watermark configuration.
+// text may contain the place holders %p and %P resolving to the page number and the page count.
+func QRCodeWatermark(text, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := pdfcpu.ParseQRCodeWatermarkDetails(text, desc, onTop, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.Update = update
+
+	return wm, nil
+}
+
+// BarcodeWatermark returns a barcode watermark configuration.
+// text may contain the place holders %p and %P resolving to the page number and the page count.
+func BarcodeWatermark(text, desc string, onTop, update bool, u types.DisplayUnit) (*model.Watermark, error) {
+	wm, err := pdfcpu.ParseBarcodeWatermarkDetails(text, desc, onTop, u)
+	if err != nil {
+		return nil, err
+	}
+
+	wm.Update = update
+
+	return wm, nil
+}
+
 // AddTextWatermarksFile adds text stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func AddTextWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
 	unit := types.POINTS
@@ -487,6 +610,51 @@ func AddPDFWatermarksFile(inFile, outFile string, selectedPages []string, onTop
 	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
 }
 
+// AddSVGWatermarksFile adds SVG stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func AddSVGWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := SVGWatermark(fileName, desc, onTop, false, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
+// AddQRCodeWatermarksFile adds QR code stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func AddQRCodeWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := QRCodeWatermark(text, desc, onTop, false, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
+// AddBarcodeWatermarksFile adds barcode stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func AddBarcodeWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := BarcodeWatermark(text, desc, onTop, false, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
 // UpdateTextWatermarksFile adds text stamps/watermarks to all selected pages of inFile and writes the result to outFile.
 func UpdateTextWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
 	unit := types.POINTS
@@ -529,3 +697,48 @@ func UpdatePDFWatermarksFile(inFile, outFile string, selectedPages []string, onT
 
 	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
 }
+
+// UpdateSVGWatermarksFile adds SVG stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func UpdateSVGWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, fileName, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := SVGWatermark(fileName, desc, onTop, true, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
+// UpdateQRCodeWatermarksFile adds QR code stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func UpdateQRCodeWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := QRCodeWatermark(text, desc, onTop, true, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
+
+// UpdateBarcodeWatermarksFile adds barcode stamps/watermarks to all selected pages of inFile and writes the result to outFile.
+func UpdateBarcodeWatermarksFile(inFile, outFile string, selectedPages []string, onTop bool, text, desc string, conf *model.Configuration) error {
+	unit := types.POINTS
+	if conf != nil {
+		unit = conf.Unit
+	}
+
+	wm, err := BarcodeWatermark(text, desc, onTop, true, unit)
+	if err != nil {
+		return err
+	}
+
+	return AddWatermarksFile(inFile, outFile, selectedPages, wm, conf)
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestActions(t *testing.T) {
+	msg := "TestActions"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "Actions.pdf")
+
+	// Open at page 5 zoomed to fit width.
+	a := model.Action{Type: "GoTo", Dest: &model.Destination{Typ: model.DestFitH, PageNr: 5, Top: 792}}
+	if err := api.SetOpenActionFile(inFile, outFile, a, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Print when page 2 is opened.
+	a = model.Action{Type: "Named", Name: "Print"}
+	if err := api.SetPageActionsFile(outFile, "", []string{"2"}, model.PageOpen, a, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	a = model.Action{Type: "URI", URI: "https://pdfcpu.io"}
+	if err := api.SetPageActionsFile(outFile, "", []string{"3-4"}, model.PageClose, a, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m, err := api.DocumentActionsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	oa, ok := m["OpenAction"]
+	if !ok || oa.Type != "GoTo" || oa.Dest == nil || oa.Dest.PageNr != 5 || oa.Dest.Typ != model.DestFitH {
+		t.Fatalf("%s: unexpected open action: %v\n", msg, m)
+	}
+
+	pas, err := api.PageActionsFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pas) != 3 || pas[0].PageNr != 2 || pas[0].Action.Name != "Print" || pas[2].Action.URI != "https://pdfcpu.io" {
+		t.Fatalf("%s: unexpected page actions: %v\n", msg, pas)
+	}
+
+	if _, err := api.ListActionsFile(outFile, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Strip auto print.
+	if err := api.RemovePageActionsFile(outFile, "", nil, []string{model.PageOpen}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.RemoveDocumentActionsFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if m, err = api.DocumentActionsFile(outFile, nil); err != nil || len(m) > 0 {
+		t.Fatalf("%s: want no document actions, got %v %v\n", msg, m, err)
+	}
+	if pas, err = api.PageActionsFile(outFile, nil, nil); err != nil || len(pas) != 2 {
+		t.Fatalf("%s: want 2 page actions, got %v %v\n", msg, pas, err)
+	}
+
+	for _, a := range []model.Action{
+		{Type: "GoTo"},
+		{Type: "GoTo", NamedDest: "missing"},
+		{Type: "GoTo", Dest: &model.Destination{Typ: model.DestFit, PageNr: 999}},
+		{Type: "URI"},
+		{Type: "Named", Name: "Quit"},
+		{Type: "JavaScript"},
+	} {
+		if err := api.SetOpenActionFile(outFile, "", a, nil); err == nil {
+			t.Fatalf("%s: %v: expected error\n", msg, a)
+		}
+	}
+}
@@ -17,8 +17,12 @@ limitations under the License.
 package test
 
 import (
+	"encoding/json"
+	"io"
 	"os"
 	"path/filepath"
+	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -1094,3 +1098,634 @@ func TestCaretAnnotation(t *testing.T) {
 		t.Fatalf("%s add: %v\n", msg, err)
 	}
 }
+
+func TestAutoLinks(t *testing.T) {
+	msg := "TestAutoLinks"
+
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	outFile := filepath.Join(outDir, "AutoLinks.pdf")
+
+	// Page 5 contains three email addresses in plain text.
+	if err := api.AddAutoLinksFile(inFile, outFile, []string{"5"}, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	m, err := api.Annotations(f, []string{"5"}, nil)
+	if err != nil {
+		t.Fatalf("%s annotations: %v\n", msg, err)
+	}
+
+	uris := map[string]bool{}
+	for _, ar := range m[5][model.AnnLink].Map {
+		uris[ar.(model.LinkAnnotation).URI] = true
+	}
+	for _, uri := range []string{"mailto:corpsales@pearsoned.com", "mailto:governmentsales@pearsoned.com", "mailto:international@pearsoned.com"} {
+		if !uris[uri] {
+			t.Fatalf("%s: missing link for %s\n", msg, uri)
+		}
+	}
+
+	// Text already covered by links is skipped.
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("%s seek: %v\n", msg, err)
+	}
+
+	n, err := api.AddAutoLinks(f, io.Discard, []string{"5"}, nil)
+	if err != nil {
+		t.Fatalf("%s add again: %v\n", msg, err)
+	}
+	if n != 0 {
+		t.Fatalf("%s: want 0 additional links, got %d\n", msg, n)
+	}
+}
+
+func checkAppearances(t *testing.T, msg, fileName string, pageNr int, want map[string]bool) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+
+	annots, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil {
+		t.Fatalf("%s annots: %v\n", msg, err)
+	}
+
+	got := map[string]bool{}
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s annot: %v\n", msg, err)
+		}
+		subtype := d.NameEntry("Subtype")
+		ap := d.DictEntry("AP")
+		if ap == nil {
+			t.Fatalf("%s: %s annotation without appearance\n", msg, *subtype)
+		}
+		sd, _, err := ctx.DereferenceStreamDict(ap["N"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s: %s annotation without normal appearance: %v\n", msg, *subtype, err)
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s decode: %v\n", msg, err)
+		}
+		if len(sd.Content) == 0 {
+			t.Fatalf("%s: %s annotation with empty appearance\n", msg, *subtype)
+		}
+		got[*subtype] = true
+	}
+
+	for k := range want {
+		if !got[k] {
+			t.Errorf("%s: missing %s annotation\n", msg, k)
+		}
+	}
+}
+
+func TestAddAnnotationsJSON(t *testing.T) {
+	msg := "TestAddAnnotationsJSON"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(samplesDir, "annotations", "AnnotationsJSON.pdf")
+
+	attachment, err := filepath.Abs(filepath.Join(inDir, "json", "viewerPreferences.json"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	json := `{
+	"annotations": {
+		"1": [
+			{"type": "Highlight", "quadPoints": [100, 720, 300, 720, 100, 700, 300, 700], "opacity": 0.5},
+			{"type": "Underline", "quadPoints": [100, 690, 300, 690, 100, 670, 300, 670], "color": "Blue"},
+			{"type": "StrikeOut", "quadPoints": [100, 660, 300, 660, 100, 640, 300, 640], "color": "Red"},
+			{"type": "Squiggly", "quadPoints": [100, 630, 300, 630, 100, 610, 300, 610], "color": "#00AA00"},
+			{"type": "Ink", "ink": [[100, 500, 150, 450, 200, 500], [100, 550, 150, 550]], "color": "Red", "borderWidth": 2},
+			{"type": "Line", "line": [350, 700, 500, 750], "color": "Blue"},
+			{"type": "Polygon", "vertices": [350, 500, 450, 500, 400, 580], "color": "Black", "fillColor": "0.8 0.8 1"},
+			{"type": "PolyLine", "vertices": [350, 400, 400, 450, 450, 400, 500, 450], "dashed": true},
+			{"type": "FreeText", "rect": [100, 300, 300, 380], "text": "A free text annotation wrapped onto several lines.", "fontSize": 14, "fontColor": "Blue", "color": "#FFFFCC", "borderWidth": 1, "align": "center"},
+			{"type": "Square", "rect": [350, 300, 450, 350], "color": "Green", "borderWidth": 3},
+			{"type": "Circle", "rect": [460, 300, 560, 380], "color": "Red", "fillColor": "LightGray"},
+			{"type": "FileAttachment", "rect": [100, 200, 120, 230], "file": "` + filepath.ToSlash(attachment) + `", "desc": "Viewer preferences", "name": "Paperclip"},
+			{"type": "Stamp", "rect": [350, 150, 550, 210], "name": "NotApproved", "contents": "Rejected"}
+		]
+	}
+}`
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+	if err := api.AddAnnotationsJSON(f, strings.NewReader(json), w, nil); err != nil {
+		w.Close()
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s close: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	if i := annotationCount(t, outFile); i != 13 {
+		t.Fatalf("%s count: got %d want 13\n", msg, i)
+	}
+
+	checkAppearances(t, msg, outFile, 1, map[string]bool{
+		"Highlight": true, "Underline": true, "StrikeOut": true, "Squiggly": true, "Ink": true, "Line": true, "Polygon": true,
+		"PolyLine": true, "FreeText": true, "Square": true, "Circle": true, "FileAttachment": true, "Stamp": true})
+
+	// Unsupported types and missing geometry are rejected.
+	for _, s := range []string{
+		`{"annotations": {"1": [{"type": "Sound", "rect": [0, 0, 10, 10]}]}}`,
+		`{"annotations": {"1": [{"type": "Polygon", "vertices": [0, 0, 10, 10]}]}}`,
+		`{"annotations": {"x": [{"type": "Square", "rect": [0, 0, 10, 10]}]}}`,
+	} {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			t.Fatalf("%s seek: %v\n", msg, err)
+		}
+		if err := api.AddAnnotationsJSON(f, strings.NewReader(s), io.Discard, nil); err == nil {
+			t.Errorf("%s: want error for %s\n", msg, s)
+		}
+	}
+}
+
+func TestStampAndFileAttachmentAnnotation(t *testing.T) {
+	msg := "TestStampAndFileAttachmentAnnotation"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(samplesDir, "annotations", "StampAndFileAttachmentAnnotation.pdf")
+
+	stampAnn := model.NewStampAnnotation(
+		*types.NewRectangle(100, 600, 300, 660), // rect
+		0,                                       // apObjNr
+		"Approved by QA",                        // contents
+		"IDStamp",                               // id
+		"",                                      // modDate
+		model.AnnPrint,                          // f
+		&color.Green,                            // col
+		"Title1",                                // title
+		nil,                                     // popupIndRef
+		nil,                                     // ca
+		"",                                      // rc
+		"",                                      // subject
+		"Approved")                              // name
+
+	fileAttachmentAnn := model.NewFileAttachmentAnnotation(
+		*types.NewRectangle(100, 500, 120, 530), // rect
+		0,                                       // apObjNr
+		"Notes",                                 // contents
+		"IDFileAttachment",                      // id
+		"",                                      // modDate
+		model.AnnPrint,                          // f
+		nil,                                     // col
+		"Title1",                                // title
+		nil,                                     // popupIndRef
+		nil,                                     // ca
+		"",                                      // rc
+		"",                                      // subject
+		strings.NewReader("Some notes."),        // r
+		"notes.txt",                             // fileName
+		"Review notes",                          // desc
+		"PushPin")                               // name
+
+	m := map[int][]model.AnnotationRenderer{1: {stampAnn, fileAttachmentAnn}}
+	if err := api.AddAnnotationsMapFile(inFile, outFile, m, nil, false); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	checkAppearances(t, msg, outFile, 1, map[string]bool{"Stamp": true, "FileAttachment": true})
+}
+
+func exportAnnotations(t *testing.T, msg, fileName string) pdfcpu.AnnotationsSpec {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var sb strings.Builder
+	if err := api.ExportAnnotations(f, &sb, nil, nil); err != nil {
+		t.Fatalf("%s export: %v\n", msg, err)
+	}
+
+	var spec pdfcpu.AnnotationsSpec
+	if err := json.Unmarshal([]byte(sb.String()), &spec); err != nil {
+		t.Fatalf("%s unmarshal: %v\n", msg, err)
+	}
+	return spec
+}
+
+func TestExportImportAnnotations(t *testing.T) {
+	msg := "TestExportImportAnnotations"
+
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	reviewedFile := filepath.Join(outDir, "annotationsReviewed.pdf")
+	jsonFile := filepath.Join(outDir, "annotationsReviewed.json")
+	outFile := filepath.Join(outDir, "annotationsImported.pdf")
+
+	review := `{
+	"annotations": {
+		"1": [
+			{"type": "Highlight", "id": "H1", "title": "Alice", "quadPoints": [100, 720, 300, 720, 100, 700, 300, 700], "opacity": 0.5,
+			 "replies": [
+				{"type": "Text", "id": "R1", "title": "Bob", "contents": "Agreed.",
+				 "replies": [{"type": "Text", "id": "R2", "title": "Alice", "contents": "Thanks!"}]},
+				{"type": "Text", "id": "S1", "title": "Bob", "state": "Accepted"}
+			 ]},
+			{"type": "Ink", "id": "I1", "ink": [[100, 500, 150, 450, 200, 500]], "color": "#FF0000", "borderWidth": 2},
+			{"type": "FreeText", "id": "F1", "rect": [100, 300, 300, 380], "text": "Rephrase", "fontSize": 14, "align": "right"},
+			{"type": "FileAttachment", "id": "A1", "rect": [100, 200, 120, 230], "file": "notes.txt", "fileContent": "U29tZSBub3Rlcy4=", "name": "Tag"}
+		],
+		"2": [
+			{"type": "Text", "id": "T1", "rect": [50, 50, 70, 70], "contents": "Typo", "name": "Comment", "open": true,
+			 "replies": [{"type": "Text", "id": "S2", "state": "Marked"}]}
+		]
+	}
+}`
+
+	// Create the reviewed document.
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(reviewedFile)
+	if err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+	n, err := api.ImportAnnotations(f, strings.NewReader(review), w, nil)
+	if err != nil {
+		w.Close()
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s close: %v\n", msg, err)
+	}
+	if n != 9 {
+		t.Fatalf("%s: got %d imported annotations, want 9\n", msg, n)
+	}
+	if err := api.ValidateFile(reviewedFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	spec := exportAnnotations(t, msg, reviewedFile)
+
+	h := spec.Annotations["1"][0]
+	if h.Type != "Highlight" || len(h.QuadPoints) != 8 || h.Opacity == nil || len(h.Replies) != 2 {
+		t.Fatalf("%s: got %+v\n", msg, h)
+	}
+	if r := h.Replies[0]; r.ID != "R1" || r.Contents != "Agreed." || len(r.Replies) != 1 || r.Replies[0].ID != "R2" {
+		t.Fatalf("%s: got reply %+v\n", msg, r)
+	}
+	if s := h.Replies[1]; s.State != "Accepted" || s.StateModel != "Review" {
+		t.Fatalf("%s: got review state %+v\n", msg, s)
+	}
+	if a := spec.Annotations["1"][3]; string(a.FileContent) != "Some notes." || a.File != "notes.txt" {
+		t.Fatalf("%s: got file attachment %+v\n", msg, a)
+	}
+	if s := spec.Annotations["2"][0].Replies[0]; s.State != "Marked" || s.StateModel != "Marked" {
+		t.Fatalf("%s: got state %+v\n", msg, s)
+	}
+
+	// Move the annotations to another document version and export again.
+	bb, err := json.Marshal(spec)
+	if err != nil {
+		t.Fatalf("%s marshal: %v\n", msg, err)
+	}
+	if err := os.WriteFile(jsonFile, bb, 0644); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+	if err := api.ImportAnnotationsFile(inFile, jsonFile, outFile, nil); err != nil {
+		t.Fatalf("%s import file: %v\n", msg, err)
+	}
+
+	if spec2 := exportAnnotations(t, msg, outFile); !reflect.DeepEqual(spec, spec2) {
+		t.Fatalf("%s: round trip mismatch\ngot:  %+v\nwant: %+v\n", msg, spec2, spec)
+	}
+
+	// Merge a second review: known annotations get new replies only.
+	review2 := `{
+	"annotations": {
+		"1": [
+			{"type": "Highlight", "id": "H1", "quadPoints": [100, 720, 300, 720, 100, 700, 300, 700],
+			 "replies": [
+				{"type": "Text", "id": "R1", "title": "Bob", "contents": "Agreed."},
+				{"type": "Text", "id": "R3", "title": "Carol", "contents": "Me too."}
+			 ]}
+		]
+	}
+}`
+	if err := os.WriteFile(jsonFile, []byte(review2), 0644); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+	if err := api.ImportAnnotationsFile(outFile, jsonFile, "", nil); err != nil {
+		t.Fatalf("%s merge: %v\n", msg, err)
+	}
+
+	spec = exportAnnotations(t, msg, outFile)
+	if len(spec.Annotations["1"]) != 4 {
+		t.Fatalf("%s: got %d annotations on page 1 after merge, want 4\n", msg, len(spec.Annotations["1"]))
+	}
+	var ids []string
+	for _, r := range spec.Annotations["1"][0].Replies {
+		ids = append(ids, r.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"R1", "S1", "R3"}) {
+		t.Fatalf("%s: got replies %v after merge\n", msg, ids)
+	}
+}
+
+func TestFlattenAnnotations(t *testing.T) {
+	msg := "TestFlattenAnnotations"
+
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	jsonFile := filepath.Join(outDir, "annotationsFlatten.json")
+	annotFile := filepath.Join(outDir, "annotationsFlatten.pdf")
+	outFile := filepath.Join(outDir, "annotationsFlattened.pdf")
+
+	review := `{
+	"annotations": {
+		"1": [
+			{"type": "Highlight", "id": "H1", "quadPoints": [100, 720, 300, 720, 100, 700, 300, 700],
+			 "replies": [{"type": "Text", "id": "R1", "contents": "Agreed.", "replies": [{"type": "Text", "id": "R2", "contents": "Thanks!"}]}]},
+			{"type": "Stamp", "id": "S1", "rect": [350, 700, 500, 750], "name": "Approved"},
+			{"type": "FreeText", "id": "F1", "rect": [100, 300, 300, 380], "text": "Rephrase"},
+			{"type": "Ink", "id": "I1", "ink": [[100, 500, 150, 450, 200, 500]], "color": "#FF0000"}
+		],
+		"2": [
+			{"type": "Stamp", "id": "S2", "rect": [350, 700, 500, 750], "name": "Draft"}
+		]
+	}
+}`
+	if err := os.WriteFile(jsonFile, []byte(review), 0644); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+	if err := api.ImportAnnotationsFile(inFile, jsonFile, annotFile, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+
+	// Flatten the highlight including its replies on page 1 only.
+	if err := api.FlattenAnnotationsFile(annotFile, outFile, []string{"1"}, []string{"Highlight"}, nil, nil); err != nil {
+		t.Fatalf("%s flatten highlight: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	spec := exportAnnotations(t, msg, outFile)
+	var ids []string
+	for _, spec := range spec.Annotations["1"] {
+		ids = append(ids, spec.ID)
+	}
+	if !reflect.DeepEqual(ids, []string{"S1", "F1", "I1"}) || len(spec.Annotations["2"]) != 1 {
+		t.Fatalf("%s: got %v on page 1 and %d annotations on page 2 after flattening\n", msg, ids, len(spec.Annotations["2"]))
+	}
+
+	// Flatten everything else.
+	if err := api.FlattenAnnotationsFile(outFile, "", nil, nil, nil, nil); err != nil {
+		t.Fatalf("%s flatten: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	for pageNr, want := range map[int]int{1: 4, 2: 1} {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			t.Fatalf("%s page %d: %v\n", msg, pageNr, err)
+		}
+		if annots, err := ctx.DereferenceArray(d["Annots"]); err != nil || len(annots) > 0 {
+			t.Fatalf("%s page %d: annotations left after flattening\n", msg, pageNr)
+		}
+		bb, err := ctx.PageContent(d, pageNr)
+		if err != nil {
+			t.Fatalf("%s page %d content: %v\n", msg, pageNr, err)
+		}
+		if got := strings.Count(string(bb), " Do Q"); got != want {
+			t.Fatalf("%s page %d: got %d flattened appearances, want %d\n", msg, pageNr, got, want)
+		}
+	}
+
+	// Nothing left to flatten.
+	if err := api.FlattenAnnotationsFile(outFile, "", nil, nil, nil, nil); err == nil {
+		t.Fatalf("%s: flattening a document without annotations should fail\n", msg)
+	}
+}
+
+func TestRichMediaAnnotation(t *testing.T) {
+	msg := "TestRichMediaAnnotation"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(samplesDir, "annotations", "RichMediaAnnotation.pdf")
+
+	poster, err := filepath.Abs(filepath.Join(resDir, "mountain.jpg"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The media content is not validated.
+	spec := `{
+	"annotations": {
+		"1": [
+			{"type": "RichMedia", "id": "Video", "rect": [100, 400, 420, 580], "file": "training.mp4", "fileContent": "AAAAGGZ0eXBtcDQy",
+			 "poster": "` + filepath.ToSlash(poster) + `", "activate": "pageVisible", "toolbar": true},
+			{"type": "RichMedia", "id": "Audio", "rect": [100, 300, 150, 350], "file": "intro.mp3", "fileContent": "SUQz", "windowed": true}
+		]
+	}
+}`
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+	if err := api.AddAnnotationsJSON(f, strings.NewReader(spec), w, nil); err != nil {
+		w.Close()
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s close: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	// Poster and default play button.
+	checkAppearances(t, msg, outFile, 1, map[string]bool{"RichMedia": true})
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	d, err := ctx.DereferenceDict(ctx.RootDict["Extensions"])
+	if err != nil || d == nil || d.DictEntry("ADBE").IntEntry("ExtensionLevel") == nil {
+		t.Fatalf("%s: missing ADBE extension level\n", msg)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		t.Fatalf("%s annots: %v\n", msg, err)
+	}
+
+	// activation condition, presentation style, media type
+	want := map[string][3]string{"Video": {"PV", "Embedded", "Video"}, "Audio": {"XA", "Windowed", "Sound"}}
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s annot: %v\n", msg, err)
+		}
+		id := d["NM"].(types.StringLiteral).Value()
+		config, err := ctx.DereferenceDict(d.DictEntry("RichMediaContent").ArrayEntry("Configurations")[0])
+		if err != nil || config == nil {
+			t.Fatalf("%s %s: missing configuration\n", msg, id)
+		}
+		activation := d.DictEntry("RichMediaSettings").DictEntry("Activation")
+		got := [3]string{
+			*activation.NameEntry("Condition"),
+			*activation.DictEntry("Presentation").NameEntry("Style"),
+			*config.NameEntry("Subtype"),
+		}
+		if got != want[id] {
+			t.Errorf("%s %s: got %v, want %v\n", msg, id, got, want[id])
+		}
+	}
+}
+
+func TestAnnotation3D(t *testing.T) {
+	msg := "TestAnnotation3D"
+
+	inFile := filepath.Join(inDir, "test.pdf")
+	outFile := filepath.Join(samplesDir, "annotations", "Annotation3D.pdf")
+
+	poster, err := filepath.Abs(filepath.Join(resDir, "mountain.jpg"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The artwork is not validated beyond its signature.
+	spec := `{
+	"annotations": {
+		"1": [
+			{"type": "3D", "id": "Gear", "rect": [100, 400, 420, 640], "file": "gear.u3d", "fileContent": "VTNEAGdlYXI=",
+			 "activate": "pageOpen", "toolbar": true, "navPane": true,
+			 "views": [
+				{"name": "Front", "camera": [1, 0, 0, 0, 0, -1, 0, 1, 0, 0, -100, 0], "centerOfOrbit": 100,
+				 "background": "#FFFFFF", "lighting": "CAD", "renderMode": "Solid"},
+				{"name": "Top", "orthographic": true, "lighting": "Headlamp", "renderMode": "Wireframe"}
+			 ]},
+			{"type": "3D", "id": "Housing", "rect": [100, 100, 300, 300], "file": "housing.prc", "fileContent": "UFJDaG91c2luZw==",
+			 "poster": "` + filepath.ToSlash(poster) + `"}
+		]
+	}
+}`
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+	if err := api.AddAnnotationsJSON(f, strings.NewReader(spec), w, nil); err != nil {
+		w.Close()
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatalf("%s close: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, conf); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	// Default placeholder and poster.
+	checkAppearances(t, msg, outFile, 1, map[string]bool{"3D": true})
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		t.Fatalf("%s annots: %v\n", msg, err)
+	}
+
+	// format, activation, number of views
+	want := map[string][3]any{"Gear": {"U3D", "PO", 2}, "Housing": {"PRC", "XA", 0}}
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s annot: %v\n", msg, err)
+		}
+		id := d["NM"].(types.StringLiteral).Value()
+		sd, _, err := ctx.DereferenceStreamDict(d["3DD"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s %s: missing 3D stream\n", msg, id)
+		}
+		got := [3]any{*sd.Dict.NameEntry("Subtype"), *d.DictEntry("3DA").NameEntry("A"), len(sd.Dict.ArrayEntry("VA"))}
+		if got != want[id] {
+			t.Errorf("%s %s: got %v, want %v\n", msg, id, got, want[id])
+		}
+	}
+
+	// Unknown artwork.
+	spec = `{"annotations": {"1": [{"type": "3D", "rect": [100, 100, 300, 300], "file": "model.obj", "fileContent": "djEy"}]}}`
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("%s seek: %v\n", msg, err)
+	}
+	if err := api.AddAnnotationsJSON(f, strings.NewReader(spec), io.Discard, nil); err == nil || !strings.Contains(err.Error(), "unsupported 3D format") {
+		t.Fatalf("%s: got %v, want unsupported 3D format\n", msg, err)
+	}
+}
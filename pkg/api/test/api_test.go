@@ -17,8 +17,11 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"path/filepath"
 	"sort"
@@ -198,6 +201,176 @@ func TestValidate(t *testing.T) {
 	}
 }
 
+func TestValidationResult(t *testing.T) {
+	msg := "TestValidationResult"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	conf.ValidationMode = model.ValidationStrict
+
+	// Stop at the first error.
+	vr, err := api.ValidationResultFile(inFile, false, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if vr.Valid || vr.Errors() != 1 {
+		t.Fatalf("%s: want 1 error, got %d\n", msg, vr.Errors())
+	}
+
+	// Report the errors of all pages.
+	vr, err = api.ValidationResultFile(inFile, true, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if vr.Errors() != 6 {
+		t.Fatalf("%s: want 6 errors, got %d\n", msg, vr.Errors())
+	}
+	for _, f := range vr.Findings {
+		if f.PageNr == 0 || f.ObjNr == 0 || f.SpecRef == "" {
+			t.Fatalf("%s: incomplete finding: %+v\n", msg, f)
+		}
+	}
+
+	bb, err := model.ValidationResultsSARIF([]*model.ValidationResult{vr})
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var sarif struct {
+		Version string
+		Runs    []struct {
+			Results []struct {
+				RuleID string
+				Level  string
+			}
+		}
+	}
+	if err := json.Unmarshal(bb, &sarif); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if sarif.Version != "2.1.0" || len(sarif.Runs) != 1 || len(sarif.Runs[0].Results) != 6 {
+		t.Fatalf("%s: unexpected SARIF log\n", msg)
+	}
+
+	// A valid file yields no errors.
+	vr, err = api.ValidationResultFile(filepath.Join(inDir, "Acroforms2.pdf"), true, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !vr.Valid {
+		t.Fatalf("%s: want valid, got %v\n", msg, vr.Findings)
+	}
+}
+
+func TestReadTolerance(t *testing.T) {
+	msg := "TestReadTolerance"
+	inFile := filepath.Join(inDir, "grid_example.pdf")
+
+	bb, err := os.ReadFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		name       string
+		old, new   string
+		kind       string
+		intolerant func(rt *model.ReadTolerance)
+	}{
+		{"missingEOF", "%%EOF", "", model.RepairMissingEOF, func(rt *model.ReadTolerance) { rt.MissingEOF = false }},
+		{"brokenLength", "/Length 11186", "/Length 11100", model.RepairStreamLength, func(rt *model.ReadTolerance) { rt.BrokenLength = false }},
+		{"xrefOffset", "0000000015 00000 n", "0000000010 00000 n", model.RepairXRefOffset, nil},
+	} {
+		damaged := bytes.Replace(bb, []byte(tt.old), []byte(tt.new), 1)
+
+		conf := model.NewDefaultConfiguration()
+		rr, err := api.RepairLog(bytes.NewReader(damaged), conf)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		found := false
+		for _, r := range rr {
+			found = found || r.Kind == tt.kind
+		}
+		if !found {
+			t.Fatalf("%s %s: missing repair %s in %v\n", msg, tt.name, tt.kind, rr)
+		}
+
+		if tt.intolerant == nil {
+			continue
+		}
+		conf = model.NewDefaultConfiguration()
+		tt.intolerant(&conf.ReadTolerance)
+		if _, err := api.RepairLog(bytes.NewReader(damaged), conf); err == nil {
+			t.Fatalf("%s %s: want error\n", msg, tt.name)
+		}
+	}
+}
+
+func TestRepair(t *testing.T) {
+	msg := "TestRepair"
+
+	for _, tt := range []struct {
+		name     string
+		fileName string
+		damage   func(bb []byte) []byte
+	}{
+		{"missingStartXRef", "grid_example.pdf", func(bb []byte) []byte {
+			return bytes.Replace(bb, []byte("startxref"), []byte("startxrex"), 1)
+		}},
+		{"mangledXRef", "grid_example.pdf", func(bb []byte) []byte {
+			return bytes.ReplaceAll(bb, []byte("0000000015 00000 n"), []byte("junk"))
+		}},
+		{"truncated", "grid_example.pdf", func(bb []byte) []byte {
+			return bb[:bytes.LastIndex(bb, []byte("xref"))]
+		}},
+		{"objectStreams", "TheGoProgrammingLanguageCh1.pdf", func(bb []byte) []byte {
+			return bytes.Replace(bb, []byte("startxref"), []byte("startxrex"), 1)
+		}},
+	} {
+		inFile := filepath.Join(inDir, tt.fileName)
+		bb, err := os.ReadFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		want, err := api.PageCountFile(inFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+
+		var buf bytes.Buffer
+		rr, err := api.Repair(bytes.NewReader(tt.damage(bb)), &buf, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+
+		found := false
+		for _, r := range rr {
+			found = found || r.Kind == model.RepairXRefRebuild
+		}
+		if !found {
+			t.Fatalf("%s %s: missing repair %s in %v\n", msg, tt.name, model.RepairXRefRebuild, rr)
+		}
+
+		// The repaired file needs no further repairs.
+		ctx, err := api.ReadContext(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if err := api.ValidateContext(ctx); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.name, err)
+		}
+		if ctx.PageCount != want {
+			t.Fatalf("%s %s: pageCount want %d, got %d\n", msg, tt.name, want, ctx.PageCount)
+		}
+		for _, r := range ctx.Read.Repairs {
+			if r.Kind == model.RepairXRefRebuild {
+				t.Fatalf("%s %s: repaired file needs repair: %v\n", msg, tt.name, ctx.Read.Repairs)
+			}
+		}
+	}
+}
+
 func TestManipulateContext(t *testing.T) {
 	msg := "TestManipulateContext"
 	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
@@ -244,3 +417,40 @@ func TestInfo(t *testing.T) {
 		t.Fatalf("%s: missing Info\n", msg)
 	}
 }
+
+func TestPageResources(t *testing.T) {
+	msg := "TestPageResources"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	pr, err := api.PageResources(f, []string{"1-2"}, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pr) != 2 {
+		t.Fatalf("%s: want 2 page reports, got %d\n", msg, len(pr))
+	}
+
+	r := pr[0]
+	if r.ImageCount != 2 || len(r.Images) != 2 {
+		t.Fatalf("%s: page 1: want 2 images, got %d\n", msg, r.ImageCount)
+	}
+	img := r.Images[0]
+	if img.Width != 1250 || img.Filter != "DCTDecode" || img.ColorSpace != "DeviceRGB" || math.Round(img.HorDPI) != 357 {
+		t.Fatalf("%s: page 1: unexpected image %+v\n", msg, img)
+	}
+
+	if len(pr[1].Fonts) == 0 {
+		t.Fatalf("%s: page 2: missing fonts\n", msg)
+	}
+	for _, f := range pr[1].Fonts {
+		if f.Name == "" || f.Type == "" {
+			t.Fatalf("%s: page 2: unexpected font %+v\n", msg, f)
+		}
+	}
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestArticles(t *testing.T) {
+	msg := "TestArticles"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	outFile := filepath.Join(outDir, "Articles.pdf")
+
+	// An article flowing through two columns of page 1 continued on page 2 and an article on page 3.
+	articles := []model.Article{
+		{
+			Title:  "Go (lecture)",
+			Author: "pdfcpu",
+			Beads: []model.Bead{
+				{PageNr: 1, Rect: types.NewRectangle(20, 20, 180, 400)},
+				{PageNr: 1, Rect: types.NewRectangle(200, 20, 380, 400)},
+				{PageNr: 2, Rect: types.NewRectangle(20, 20, 380, 400)},
+			},
+		},
+		{
+			Beads: []model.Bead{{PageNr: 3, Rect: types.NewRectangle(20, 20, 380, 400)}},
+		},
+	}
+
+	if err := api.AddArticlesFile(inFile, outFile, articles, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	aa, err := api.ArticlesFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s articles: %v\n", msg, err)
+	}
+	if len(aa) != 2 {
+		t.Fatalf("%s: want 2 articles, got %d\n", msg, len(aa))
+	}
+
+	a := aa[0]
+	if a.Title != "Go (lecture)" || a.Author != "pdfcpu" || len(a.Beads) != 3 {
+		t.Fatalf("%s: unexpected article: %s\n", msg, a)
+	}
+	for i, b := range a.Beads {
+		want := articles[0].Beads[i]
+		if b.PageNr != want.PageNr || !b.Rect.Equals(*want.Rect) {
+			t.Fatalf("%s: bead %d: want %s, got %s\n", msg, i+1, want, b)
+		}
+	}
+	if len(aa[1].Beads) != 1 || aa[1].Beads[0].PageNr != 3 {
+		t.Fatalf("%s: unexpected article: %s\n", msg, aa[1])
+	}
+
+	ss, err := api.ListArticlesFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s list: %v\n", msg, err)
+	}
+	if len(ss) != 2 {
+		t.Fatalf("%s: unexpected list: %v\n", msg, ss)
+	}
+
+	// Invalid page numbers are rejected.
+	a = model.Article{Beads: []model.Bead{{PageNr: 999, Rect: types.NewRectangle(0, 0, 10, 10)}}}
+	if err := api.AddArticlesFile(inFile, outFile, []model.Article{a}, nil); err == nil {
+		t.Fatalf("%s: invalid page number accepted\n", msg)
+	}
+}
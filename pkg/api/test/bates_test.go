@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestBates(t *testing.T) {
+	msg := "TestBates"
+
+	inFiles := []string{
+		filepath.Join(inDir, "Walden.pdf"),
+		filepath.Join(inDir, "Wonderwall.pdf"),
+	}
+
+	dir := filepath.Join(outDir, "bates")
+	if err := os.MkdirAll(dir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	b, err := pdfcpu.ParseBatesConfig("prefix:ACME-, start:99, digits:4, suffix:-C", "pos:bl, points:8", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	manifestFile := filepath.Join(dir, "bates.csv")
+	if err := api.AddBatesNumbersFile(inFiles, dir, manifestFile, nil, b, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pageCount := 0
+	for _, inFile := range inFiles {
+		outFile := filepath.Join(dir, filepath.Base(inFile))
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !hasWatermarks(outFile, t) {
+			t.Fatalf("%s: %s has no Bates numbers\n", msg, outFile)
+		}
+		n, err := api.PageCountFile(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		pageCount += n
+	}
+
+	f, err := os.Open(manifestFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	rr, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if len(rr) != pageCount+1 {
+		t.Fatalf("%s: manifest: got %d records, want %d\n", msg, len(rr), pageCount+1)
+	}
+
+	if got, want := rr[1], []string{"Walden.pdf", "1", "ACME-0099-C"}; got[0] != want[0] || got[1] != want[1] || got[2] != want[2] {
+		t.Fatalf("%s: manifest: got %v, want %v\n", msg, got, want)
+	}
+
+	// The numbering continues across files.
+	last := rr[len(rr)-1]
+	if want := b.Number(99 + pageCount - 1); last[0] != "Wonderwall.pdf" || last[2] != want {
+		t.Fatalf("%s: manifest: got %v, want %s\n", msg, last, want)
+	}
+
+	if b.Next != 99+pageCount {
+		t.Fatalf("%s: next Bates number: got %d, want %d\n", msg, b.Next, 99+pageCount)
+	}
+}
+
+func TestBatesInvalidConfig(t *testing.T) {
+	msg := "TestBatesInvalidConfig"
+
+	for _, tt := range []struct {
+		config, desc string
+	}{
+		{"digits:0", ""},
+		{"start:-1", ""},
+		{"prefix", ""},
+		{"", "pos:xx"},
+		{"", "sym:ean13"},
+	} {
+		if _, err := pdfcpu.ParseBatesConfig(tt.config, tt.desc, nil); err == nil {
+			t.Fatalf("%s: %q %q should fail\n", msg, tt.config, tt.desc)
+		}
+	}
+}
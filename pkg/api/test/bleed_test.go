@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func roundRect(r *types.Rectangle) *types.Rectangle {
+	f := func(f float64) float64 { return math.Round(f*100) / 100 }
+	return types.NewRectangle(f(r.LL.X), f(r.LL.Y), f(r.UR.X), f(r.UR.Y))
+}
+
+func TestBleed(t *testing.T) {
+	msg := "TestBleed"
+	inFile := filepath.Join(outDir, "BleedIn.pdf")
+
+	// A borderless page.
+	imgFile := filepath.Join(resDir, "mountain.jpg")
+	if err := api.ImportImagesFile([]string{imgFile}, inFile, pdfcpu.DefaultImportConfig(), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	mb, cb := pageBoxes(t, inFile, 1)
+	if cb == nil {
+		cb = mb
+	}
+
+	for _, tt := range []struct {
+		desc  string
+		u     types.DisplayUnit
+		width float64
+	}{
+		{"width:3", types.MILLIMETRES, types.ToUserSpace(3, types.MILLIMETRES)},
+		{"w:9, mode:smear", types.POINTS, 9},
+		{"w:.25, fillcol:#00A0E0", types.INCHES, 18},
+	} {
+		outFile := filepath.Join(outDir, "Bleed.pdf")
+
+		b, err := pdfcpu.ParseBleedConfig(tt.desc, tt.u)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+
+		if err := api.BleedFile(inFile, outFile, nil, b, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+
+		d, _, inhPAttrs, err := ctx.PageDict(1, false)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+
+		w := tt.width
+		want := roundRect(types.NewRectangle(cb.LL.X-w, cb.LL.Y-w, cb.UR.X+w, cb.UR.Y+w))
+		if got := roundRect(inhPAttrs.MediaBox); !got.Equals(*want) {
+			t.Fatalf("%s %s: media box: want %v, got %v\n", msg, tt.desc, want, got)
+		}
+
+		a, err := ctx.DereferenceArray(d["TrimBox"])
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+		trimBox, err := ctx.RectForArray(a)
+		if err != nil || !trimBox.Equals(*cb) {
+			t.Fatalf("%s %s: trim box: want %v, got %v (%v)\n", msg, tt.desc, cb, trimBox, err)
+		}
+
+		if d["BleedBox"] == nil {
+			t.Fatalf("%s %s: missing bleed box\n", msg, tt.desc)
+		}
+
+		// The bleed gets painted.
+		bb, err := pdfcpu.ContentBox(ctx, 1)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.desc, err)
+		}
+		if bb == nil || (bb.LL.X >= cb.LL.X && bb.LL.Y >= cb.LL.Y && bb.UR.X <= cb.UR.X && bb.UR.Y <= cb.UR.Y) {
+			t.Fatalf("%s %s: content box %v within trim box\n", msg, tt.desc, bb)
+		}
+	}
+
+	for _, desc := range []string{"", "mode:mirror", "w:0", "w:5, mode:blur"} {
+		if _, err := pdfcpu.ParseBleedConfig(desc, types.POINTS); err == nil {
+			t.Fatalf("%s %q: want error\n", msg, desc)
+		}
+	}
+}
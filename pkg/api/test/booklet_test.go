@@ -265,6 +265,28 @@ func TestBooklet(t *testing.T) {
 			false,
 		},
 
+		// 2-up right-to-left booklet from PDF on Letter with the binding on the right
+		{"TestBookletFromPDF2UpLetterRTL",
+			[]string{filepath.Join(inDir, "bookletTest.pdf")},
+			filepath.Join(outDir, "BookletFromPDFLetter_2UpRTL.pdf"),
+			[]string{"1-"},
+			"p:LetterP, g:on, rtl:on",
+			"points",
+			2,
+			false,
+		},
+
+		// 4-up booklet from PDF on A3 including printer's marks
+		{"TestBookletFromPDF4UpWithPrintersMarks",
+			[]string{filepath.Join(inDir, "bookletTest.pdf")},
+			filepath.Join(outDir, "BookletFromPDF4UpWithPrintersMarks.pdf"),
+			[]string{"1-"},
+			"p:A3, ma:20, sheetmarks:all",
+			"points",
+			4,
+			false,
+		},
+
 		// 2-up multi folio booklet from PDF on A4 using 8 sheets per folio
 		// using the default foliosize:8
 		// Here we print 2 complete folios (2 x 8 sheets) + 1 partial folio
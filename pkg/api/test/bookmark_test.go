@@ -17,8 +17,10 @@
 package test
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -194,3 +196,119 @@ func TestImportBookmarks(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestImportBookmarksYAML(t *testing.T) {
+	msg := "TestImportBookmarksYAML"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "bookmarksYAML.pdf")
+
+	yml := `
+bookmarks:
+  - title: Part 1
+    page: 1
+    style: bold italic
+    children:
+      - title: Section 1.1
+        page: 2
+        destination:
+          type: XYZ
+          left: 50
+          top: 700
+      - title: Section 1.2
+        page: 5
+  - title: Part 2
+    page: 10
+    destination:
+      type: FitH
+      top: 400
+`
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	if err := api.ImportBookmarks(f, strings.NewReader(yml), &buf, true, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+	if err := os.WriteFile(outFile, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	bms, err := api.Bookmarks(bytes.NewReader(buf.Bytes()), nil)
+	if err != nil {
+		t.Fatalf("%s bookmarks: %v\n", msg, err)
+	}
+
+	if len(bms) != 2 || len(bms[0].Kids) != 2 {
+		t.Fatalf("%s: unexpected bookmark tree: %v\n", msg, bms)
+	}
+	if !bms[0].Bold || !bms[0].Italic {
+		t.Fatalf("%s: missing style for %s\n", msg, bms[0].Title)
+	}
+	if d := bms[0].Kids[0].Dest; d == nil || d.Type != "XYZ" || d.Left != 50 || d.Top != 700 {
+		t.Fatalf("%s: unexpected destination for %s: %v\n", msg, bms[0].Kids[0].Title, d)
+	}
+	if d := bms[0].Kids[1].Dest; d != nil {
+		t.Fatalf("%s: unexpected destination for %s: %v\n", msg, bms[0].Kids[1].Title, d)
+	}
+	if d := bms[1].Dest; d == nil || d.Type != "FitH" || d.Top != 400 || bms[1].PageFrom != 10 {
+		t.Fatalf("%s: unexpected destination for %s: %v\n", msg, bms[1].Title, d)
+	}
+}
+
+func TestDetectBookmarks(t *testing.T) {
+	msg := "TestDetectBookmarks"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	outFile := filepath.Join(outDir, "bookmarksDetected.pdf")
+	outFileJSON := filepath.Join(outDir, "bookmarksDetected.json")
+	outFile2 := filepath.Join(outDir, "bookmarksDetectedImported.pdf")
+
+	bms, err := api.DetectBookmarksFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s detect: %v\n", msg, err)
+	}
+	if len(bms) == 0 || bms[0].Title != "Some Trucs and Machins about Google Go" || bms[0].PageFrom != 1 {
+		t.Fatalf("%s: unexpected bookmarks: %v\n", msg, bms)
+	}
+
+	if err := api.AddDetectedBookmarksFile(inFile, outFile, true, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	// Round trip via JSON.
+	if err := api.ExportBookmarksFile(outFile, outFileJSON, nil); err != nil {
+		t.Fatalf("%s export: %v\n", msg, err)
+	}
+	if err := api.ImportBookmarksFile(outFile, outFileJSON, outFile2, true, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile2)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	bms2, err := api.Bookmarks(f, nil)
+	if err != nil {
+		t.Fatalf("%s bookmarks: %v\n", msg, err)
+	}
+
+	if len(bms2) != len(bms) {
+		t.Fatalf("%s: want %d bookmarks, got %d\n", msg, len(bms), len(bms2))
+	}
+	for i := range bms {
+		if bms2[i].Title != bms[i].Title || bms2[i].PageFrom != bms[i].PageFrom || *bms2[i].Dest != *bms[i].Dest {
+			t.Fatalf("%s: bookmark %d: want %s (%v), got %s (%v)\n", msg, i, bms[i].Title, *bms[i].Dest, bms2[i].Title, bms2[i].Dest)
+		}
+	}
+}
@@ -150,3 +150,84 @@ func TestAddRemoveBoxes(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func pageBoxes(t *testing.T, fileName string, pageNr int) (mediaBox, cropBox *types.Rectangle) {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("pageBoxes: %v\n", err)
+	}
+
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("pageBoxes: %v\n", err)
+	}
+
+	return inhPAttrs.MediaBox, inhPAttrs.CropBox
+}
+
+func TestAutoCrop(t *testing.T) {
+	msg := "TestAutoCrop"
+	inFile := filepath.Join(inDir, "test.pdf")
+	blankFile := filepath.Join(outDir, "AutoCropBlank.pdf")
+	stampedFile := filepath.Join(outDir, "AutoCropStamped.pdf")
+	outFile := filepath.Join(outDir, "AutoCrop.pdf")
+
+	// Start out with a single blank page.
+	if err := api.InsertPagesFile(inFile, blankFile, []string{"1"}, true, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.RemovePagesFile(blankFile, "", []string{"2-"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Blank pages remain unchanged.
+	ac, err := model.ParseAutoCrop("auto", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AutoCropFile(blankFile, outFile, nil, ac, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if mb, cb := pageBoxes(t, outFile, 1); cb != nil && !cb.Equals(*mb) {
+		t.Fatalf("%s: blank page: want no crop, got %v\n", msg, cb)
+	}
+
+	desc := "pos:bl, off:100 100, scale:1 abs, points:24, fillc:#000000, op:1, rot:0"
+	if err := api.AddTextWatermarksFile(blankFile, stampedFile, nil, true, "Hello", desc, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ac, err = model.ParseAutoCrop("auto 10", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AutoCropFile(stampedFile, outFile, nil, ac, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	mb, cb := pageBoxes(t, outFile, 1)
+	if cb == nil {
+		t.Fatalf("%s: missing crop box\n", msg)
+	}
+	if cb.LL.X < 85 || cb.LL.Y < 85 || cb.UR.X > 250 || cb.UR.Y > 150 {
+		t.Fatalf("%s: unexpected crop box: %v\n", msg, cb)
+	}
+	if mb.Equals(*cb) {
+		t.Fatalf("%s: media box got modified: %v\n", msg, mb)
+	}
+
+	ac, err = model.ParseAutoCrop("auto 10 media", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AutoCropFile(stampedFile, outFile, nil, ac, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	mb1, cb1 := pageBoxes(t, outFile, 1)
+	if !mb1.Equals(*cb) || (cb1 != nil && !cb1.Equals(*mb1)) {
+		t.Fatalf("%s: want media box %v, got %v %v\n", msg, cb, mb1, cb1)
+	}
+}
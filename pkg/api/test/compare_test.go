@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestCompare(t *testing.T) {
+	msg := "TestCompare"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	stampedFile := filepath.Join(outDir, "CompareStamped.pdf")
+	reducedFile := filepath.Join(outDir, "CompareReduced.pdf")
+	outFile := filepath.Join(outDir, "CompareChanges.pdf")
+
+	cr, err := api.CompareFile(inFile, inFile, "", true, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !cr.Identical {
+		t.Fatalf("%s: want identical, got %v\n", msg, cr)
+	}
+
+	if err := api.AddTextWatermarksFile(inFile, stampedFile, []string{"2"}, true, "DRAFT", "pos:c", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	cr, err = api.CompareFile(inFile, stampedFile, outFile, true, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if cr.Identical || len(cr.Pages) != 1 || cr.Pages[0].PageNr != 2 {
+		t.Fatalf("%s: want page 2 changed, got %v\n", msg, cr.Pages)
+	}
+	if pc := cr.Pages[0]; len(pc.Added) != 1 || len(pc.Removed) != 0 {
+		t.Fatalf("%s: want 1 added region, got %v\n", msg, pc)
+	}
+
+	// The changed region gets highlighted.
+	if want, got := annotationCount(t, stampedFile)+1, annotationCount(t, outFile); got != want {
+		t.Fatalf("%s: want %d annotations, got %d\n", msg, want, got)
+	}
+
+	if err := api.RemovePagesFile(inFile, reducedFile, []string{"3-"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	cr, err = api.CompareFile(inFile, reducedFile, "", false, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if cr.PageCount2 != 2 || len(cr.Pages) != cr.PageCount1-2 || cr.Pages[0].Hash2 != "" {
+		t.Fatalf("%s: want %d removed pages, got %v\n", msg, cr.PageCount1-2, cr.Pages)
+	}
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// isImageOp returns true for operators painting images.
+func isImageOp(ctx *model.Context, op model.ContentOperator, resDict types.Dict) bool {
+	if op.Name == "BI" {
+		return true
+	}
+	if op.Name != "Do" || len(op.Operands) != 1 {
+		return false
+	}
+	name, _ := op.Operands[0].(types.Name)
+	xObjs, err := ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || xObjs == nil {
+		return false
+	}
+	sd, _, err := ctx.DereferenceStreamDict(xObjs[name.Value()])
+	if err != nil || sd == nil {
+		return false
+	}
+	st := sd.Subtype()
+	return st != nil && *st == "Image"
+}
+
+func TestEditContent(t *testing.T) {
+	msg := "TestEditContent"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	outFile := filepath.Join(outDir, "editedContent.pdf")
+
+	c, err := api.PageContentFile(inFile, 1, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(c) == 0 {
+		t.Fatalf("%s: missing content\n", msg)
+	}
+
+	removed := 0
+
+	// Remove all images and turn fill colors red.
+	edit := func(ctx *model.Context, pageNr int, c model.Content, resDict types.Dict) (model.Content, error) {
+		c1 := model.Content{}
+		for _, op := range c {
+			if isImageOp(ctx, op, resDict) {
+				removed++
+				continue
+			}
+			switch op.Name {
+			case "g", "rg", "k":
+				op = model.NewContentOperator("rg", types.Integer(1), types.Integer(0), types.Integer(0))
+			}
+			c1 = append(c1, op)
+		}
+		return c1, nil
+	}
+
+	if err := api.EditContentFile(inFile, outFile, nil, edit, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	if removed == 0 {
+		t.Fatalf("%s: no images removed\n", msg)
+	}
+
+	check := func(ctx *model.Context, pageNr int, c model.Content, resDict types.Dict) (model.Content, error) {
+		for _, op := range c {
+			if isImageOp(ctx, op, resDict) {
+				t.Errorf("%s: page %d paints an image\n", msg, pageNr)
+			}
+			if op.Name == "g" || op.Name == "k" || op.Name == "rg" && op.String() != "1 0 0 rg" {
+				t.Errorf("%s: page %d: unexpected %s\n", msg, pageNr, op)
+			}
+		}
+		return c, nil
+	}
+
+	if err := api.EditContentFile(outFile, "", nil, check, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
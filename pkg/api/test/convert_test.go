@@ -0,0 +1,149 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestConvertToCMYK(t *testing.T) {
+	msg := "TestConvertToCMYK"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	outFile := filepath.Join(outDir, "cmyk.pdf")
+
+	cc, err := pdfcpu.ParseCMYKConversion("dest:" + filepath.Join(inDir, "icc", "TestCMYK.icc"))
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	if err := api.ConvertToCMYKFile(inFile, outFile, cc, nil); err != nil {
+		t.Fatalf("%s convert: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	ii, err := api.Images(bytes.NewReader(bb), nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+	count := 0
+	for _, m := range ii {
+		for _, img := range m {
+			if img.Cs != "DeviceCMYK" {
+				t.Fatalf("%s: obj#%d: got %s\n", msg, img.ObjNr, img.Cs)
+			}
+			count++
+		}
+	}
+	if count == 0 {
+		t.Fatalf("%s: missing images\n", msg)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+	bb, err = ctx.PageContent(pageDict, 1)
+	if err != nil {
+		t.Fatalf("%s page content: %v\n", msg, err)
+	}
+	if regexp.MustCompile(`\b(rg|RG)\s`).Match(bb) || !regexp.MustCompile(`\bk\s`).Match(bb) {
+		t.Fatalf("%s: page 1 still uses DeviceRGB colors\n", msg)
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s catalog: %v\n", msg, err)
+	}
+	if _, found := rootDict.Find("OutputIntents"); !found {
+		t.Fatalf("%s: missing output intent\n", msg)
+	}
+}
+
+func TestConvertToGray(t *testing.T) {
+	msg := "TestConvertToGray"
+
+	// go-lecture.pdf uses DeviceRGB colors and images, 5116.DCT_Filter.pdf ICC based and separation colors.
+	for _, fn := range []string{"go-lecture.pdf", "5116.DCT_Filter.pdf"} {
+		inFile := filepath.Join(inDir, fn)
+		outFile := filepath.Join(outDir, "gray_"+fn)
+
+		if err := api.ConvertToGrayFile(inFile, outFile, nil); err != nil {
+			t.Fatalf("%s %s convert: %v\n", msg, fn, err)
+		}
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s validate: %v\n", msg, fn, err)
+		}
+
+		bb, err := os.ReadFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s read: %v\n", msg, fn, err)
+		}
+
+		ii, err := api.Images(bytes.NewReader(bb), nil, nil)
+		if err != nil {
+			t.Fatalf("%s %s images: %v\n", msg, fn, err)
+		}
+		for _, m := range ii {
+			for _, img := range m {
+				if img.Cs != "DeviceGray" {
+					t.Fatalf("%s %s: obj#%d: got %s\n", msg, fn, img.ObjNr, img.Cs)
+				}
+			}
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s read context: %v\n", msg, fn, err)
+		}
+
+		pageDict, _, _, err := ctx.PageDict(1, false)
+		if err != nil {
+			t.Fatalf("%s %s page dict: %v\n", msg, fn, err)
+		}
+		bb, err = ctx.PageContent(pageDict, 1)
+		if err != nil {
+			t.Fatalf("%s %s page content: %v\n", msg, fn, err)
+		}
+		if regexp.MustCompile(`\b(rg|RG|k|K)\s`).Match(bb) {
+			t.Fatalf("%s %s: page 1 still uses DeviceRGB or DeviceCMYK colors\n", msg, fn)
+		}
+		for _, m := range regexp.MustCompile(`/(\S+)\s+(cs|CS)\b`).FindAllSubmatch(bb, -1) {
+			if string(m[1]) != "DeviceGray" {
+				t.Fatalf("%s %s: page 1 still uses color space %s\n", msg, fn, m[1])
+			}
+		}
+	}
+}
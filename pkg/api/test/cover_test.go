@@ -0,0 +1,92 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestCover(t *testing.T) {
+	msg := "TestCover"
+
+	for _, tt := range []struct {
+		msg       string
+		desc      string
+		unit      types.DisplayUnit
+		wantSpine float64 // in mm
+	}{
+		{"TestCoverPlain",
+			"pages:240, formsize:A5",
+			types.POINTS,
+			12},
+		{"TestCoverOddPageCount",
+			"pages:241, formsize:A5, grammage:100, bulk:1",
+			types.POINTS,
+			12.1},
+		{"TestCoverArtwork",
+			fmt.Sprintf("pages:320, dim:170 240, caliper:0.09, bleed:5, guides:on, bgcolor:#E0E0E0, front:%s, back:%s",
+				filepath.Join(resDir, "mountain.jpg"), filepath.Join(resDir, "snow.jpg")),
+			types.MILLIMETRES,
+			14.4},
+	} {
+		conf := model.NewDefaultConfiguration()
+		conf.Unit = tt.unit
+
+		cover, err := pdfcpu.ParseCoverConfig(tt.desc, conf)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		spine := types.Dim{Width: cover.SpineWidth()}.ToMillimetres().Width
+		if math.Abs(spine-tt.wantSpine) > 0.01 {
+			t.Fatalf("%s %s: spine width: got %.2fmm, want %.2fmm\n", msg, tt.msg, spine, tt.wantSpine)
+		}
+
+		outFile := filepath.Join(outDir, tt.msg+".pdf")
+		if err := api.CreateCoverFile(outFile, cover, conf); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		dims, err := api.PageDimsFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		want := cover.Dim()
+		if len(dims) != 1 || math.Abs(dims[0].Width-want.Width) > 0.01 || math.Abs(dims[0].Height-want.Height) > 0.01 {
+			t.Fatalf("%s %s: got %v, want %v\n", msg, tt.msg, dims, *want)
+		}
+	}
+}
+
+func TestCoverMissingPageCount(t *testing.T) {
+	if _, err := pdfcpu.ParseCoverConfig("formsize:A5", nil); err == nil {
+		t.Fatal("TestCoverMissingPageCount: expected error")
+	}
+}
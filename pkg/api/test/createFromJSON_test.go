@@ -18,10 +18,12 @@ package test
 
 import (
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 /**************************************************************
@@ -72,6 +74,7 @@ func TestCreateContentPrimitivesViaJson(t *testing.T) {
 		{"TestTextAnchored", "textAnchored.json", "textAnchored.pdf"},
 		{"TestTextBordersAndPaddings", "textBordersAndPaddings.json", "textBordersAndPaddings.pdf"},
 		{"TestTextAlignment", "textAndAlignment.json", "textAndAlignment.pdf"},
+		{"TestTextVertical", "textVertical.json", "textVertical.pdf"},
 
 		// Image
 		{"TestImages", "images.json", "images.pdf"},
@@ -354,3 +357,87 @@ func TestReadFormAndUpdateFormViaJson(t *testing.T) {
 	outFile = filepath.Join(outDir, "readFormAndUpdateFormCJK.pdf")
 	createPDF(t, "pass1", inFile, inFileJSON, outFile, conf)
 }
+
+func TestCreateAndUpdateTaggedPageViaJson(t *testing.T) {
+	msg := "TestCreateAndUpdateTaggedPageViaJson"
+	jsonDir := filepath.Join(inDir, "json", "create", "flow")
+	outFile := filepath.Join(outDir, "createAndUpdateTaggedPage.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	conf.TagContent = true
+
+	// Create a tagged page and append more tagged content to it.
+	createPDF(t, msg, "", filepath.Join(jsonDir, "createPage.json"), outFile, conf)
+	createPDF(t, msg, outFile, filepath.Join(jsonDir, "updatePage1.json"), "", conf)
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	if d := ctx.RootDict.DictEntry("MarkInfo"); d == nil || d.BooleanEntry("Marked") == nil || !*d.BooleanEntry("Marked") {
+		t.Fatalf("%s: missing MarkInfo\n", msg)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+
+	bb, err := ctx.PageContent(pageDict, 1)
+	if err != nil {
+		t.Fatalf("%s page content: %v\n", msg, err)
+	}
+
+	// Each text box is a P structure element referring to its own marked content sequence.
+	mcids := strings.Count(string(bb), "/MCID")
+	if mcids != 8 {
+		t.Fatalf("%s: want 8 marked content sequences, got %d\n", msg, mcids)
+	}
+
+	mcid, err := ctx.NextMCID(pageDict)
+	if err != nil {
+		t.Fatalf("%s next mcid: %v\n", msg, err)
+	}
+	if mcid != mcids {
+		t.Fatalf("%s: parent tree covers %d MCIDs, want %d\n", msg, mcid, mcids)
+	}
+}
+
+func TestCreateFallbackFontsViaJson(t *testing.T) {
+	msg := "TestCreateFallbackFontsViaJson"
+
+	inFileJSON := filepath.Join(inDir, "json", "create", "textFallback.json")
+	outFile := filepath.Join(outDir, "textFallback.pdf")
+
+	// Roboto-Regular does not cover CJK, UnifontMedium takes over.
+	conf := model.NewDefaultConfiguration()
+	conf.FallbackFonts = []string{"UnifontMedium"}
+
+	createPDF(t, msg, "", inFileJSON, outFile, conf)
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	baseFonts := map[string]bool{}
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		d, ok := entry.Object.(types.Dict)
+		if !ok || d.Type() == nil || *d.Type() != "Font" || d.Subtype() == nil || *d.Subtype() != "Type0" {
+			continue
+		}
+		if bf := d.NameEntry("BaseFont"); bf != nil {
+			baseFonts[(*bf)[strings.Index(*bf, "+")+1:]] = true
+		}
+	}
+
+	for _, fontName := range []string{"Roboto-Regular", "UnifontMedium"} {
+		if !baseFonts[fontName] {
+			t.Fatalf("%s: missing font %s\n", msg, fontName)
+		}
+	}
+}
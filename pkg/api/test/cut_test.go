@@ -207,6 +207,13 @@ func TestPoster(t *testing.T) {
 			"posterDimScaled",
 			types.CENTIMETRES,
 			"dim:15 10, scale:2.0, margin:1, bgcol:#E9967A, border:on"},
+
+		{"TestPosterOverlapLabels", // 5x5 grid of A6 tiles overlapping by 1 cm => A2
+			"test.pdf", // A4
+			"cut",
+			"posterOverlapLabels",
+			types.CENTIMETRES,
+			"f:A6, scale:2.0, overlap:1, labels:on"},
 	} {
 		testPoster(t, tt.msg, tt.inFile, tt.outDir, tt.outFile, tt.unit, tt.cutConf)
 	}
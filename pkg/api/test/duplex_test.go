@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestManualDuplexPageOrder(t *testing.T) {
+	for _, tt := range []struct {
+		pageCount    int
+		reverseBacks bool
+		fronts       []int
+		backs        []int
+		blank        bool
+	}{
+		{6, true, []int{1, 3, 5}, []int{6, 4, 2}, false},
+		{6, false, []int{1, 3, 5}, []int{2, 4, 6}, false},
+		{5, true, []int{1, 3, 5}, []int{4, 2}, true},
+		{1, true, []int{1}, nil, true},
+	} {
+		pages := types.IntSet{}
+		for i := 1; i <= tt.pageCount; i++ {
+			pages[i] = true
+		}
+		fronts, backs, blank := pdfcpu.ManualDuplexPageOrder(pages, tt.reverseBacks)
+		if !reflect.DeepEqual(fronts, tt.fronts) || !reflect.DeepEqual(backs, tt.backs) || blank != tt.blank {
+			t.Fatalf("TestManualDuplexPageOrder(%d, %t): got %v %v %t, want %v %v %t\n",
+				tt.pageCount, tt.reverseBacks, fronts, backs, blank, tt.fronts, tt.backs, tt.blank)
+		}
+	}
+}
+
+func TestManualDuplex(t *testing.T) {
+	msg := "TestManualDuplex"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	for _, tt := range []struct {
+		selectedPages []string
+		separate      bool
+		reverseBacks  bool
+		wantFronts    int
+		wantBacks     int
+	}{
+		{nil, false, true, 4, 0},
+		{[]string{"1-3"}, false, true, 4, 0},
+		{[]string{"1-3"}, true, true, 2, 2},
+		{[]string{"1-3"}, true, false, 2, 2},
+		{[]string{"1"}, true, true, 1, 0},
+	} {
+		outFile := filepath.Join(outDir, "duplex.pdf")
+		outFileBacks := ""
+		if tt.separate {
+			outFileBacks = filepath.Join(outDir, "duplexBacks.pdf")
+			os.Remove(outFileBacks)
+		}
+
+		if err := api.ManualDuplexFile(inFile, outFile, outFileBacks, tt.selectedPages, tt.reverseBacks, nil); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+
+		n, err := api.PageCountFile(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if n != tt.wantFronts {
+			t.Fatalf("%s: got %d pages, want %d\n", msg, n, tt.wantFronts)
+		}
+
+		if !tt.separate {
+			continue
+		}
+
+		if tt.wantBacks == 0 {
+			if _, err := os.Stat(outFileBacks); !os.IsNotExist(err) {
+				t.Fatalf("%s: want no back sides file\n", msg)
+			}
+			continue
+		}
+
+		if n, err = api.PageCountFile(outFileBacks); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if n != tt.wantBacks {
+			t.Fatalf("%s: got %d back side pages, want %d\n", msg, n, tt.wantBacks)
+		}
+	}
+}
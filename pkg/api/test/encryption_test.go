@@ -17,13 +17,23 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func listPermissions(t *testing.T, fileName string) ([]string, error) {
@@ -230,3 +240,273 @@ func TestSetPermissions(t *testing.T) {
 		t.Fatalf("%s: got: %d want: %d", msg, uint16(*p), uint16(permNew))
 	}
 }
+
+// recipient creates a self signed certificate and its private key saved as PEM files.
+func recipient(t *testing.T, name string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageKeyEncipherment,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile = filepath.Join(outDir, name+".pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	keyFile = filepath.Join(outDir, name+".key")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return certFile, keyFile
+}
+
+func recipientConf(t *testing.T, certFile, keyFile string) *model.Configuration {
+	t.Helper()
+
+	certs, err := pdfcpu.LoadCertificates(certFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := pdfcpu.LoadPrivateKey(keyFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return model.NewRecipientConfiguration(certs[0], key)
+}
+
+func TestPublicKeyEncryption(t *testing.T) {
+	msg := "TestPublicKeyEncryption"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "pubsec.pdf")
+
+	certFile1, keyFile1 := recipient(t, "alice")
+	certFile2, keyFile2 := recipient(t, "bob")
+	certFile3, keyFile3 := recipient(t, "eve")
+
+	var recipients []*x509.Certificate
+	for _, certFile := range []string{certFile1, certFile2} {
+		certs, err := pdfcpu.LoadCertificates(certFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		recipients = append(recipients, certs...)
+	}
+
+	for _, keyLength := range []int{128, 256} {
+
+		conf := model.NewPublicKeyConfiguration(recipients, keyLength)
+		conf.Permissions = model.PermissionsAll
+		if err := api.EncryptFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+		}
+
+		// Reading without a private key should fail.
+		if list, err := listPermissions(t, outFile); err == nil {
+			t.Fatalf("%s: list permissions w/o key %s: %v\n", msg, outFile, list)
+		}
+
+		// Reading as someone else should fail.
+		if _, err := api.GetPermissionsFile(outFile, recipientConf(t, certFile3, keyFile3)); err == nil {
+			t.Fatalf("%s: get permissions as non recipient %s\n", msg, outFile)
+		}
+
+		// Each recipient gets the permissions granted.
+		for _, conf := range []*model.Configuration{recipientConf(t, certFile1, keyFile1), recipientConf(t, certFile2, keyFile2)} {
+			p, err := api.GetPermissionsFile(outFile, conf)
+			if err != nil {
+				t.Fatalf("%s: get permissions %s: %v\n", msg, outFile, err)
+			}
+			if p == nil || uint16(*p) != uint16(model.PermissionsAll) {
+				t.Fatalf("%s: want all permissions, got %v\n", msg, p)
+			}
+		}
+
+		// Processing preserves the encryption.
+		if err := api.OptimizeFile(outFile, "", recipientConf(t, certFile2, keyFile2)); err != nil {
+			t.Fatalf("%s: optimize %s: %v\n", msg, outFile, err)
+		}
+
+		if err := api.DecryptFile(outFile, "", recipientConf(t, certFile1, keyFile1)); err != nil {
+			t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+		}
+	}
+}
+
+func TestPasswordProvider(t *testing.T) {
+	msg := "TestPasswordProvider"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	outFile := filepath.Join(outDir, "pwProvider.pdf")
+
+	if err := api.EncryptFile(inFile, outFile, confForAlgorithm(true, 256, "upw", "opw")); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, outFile, err)
+	}
+
+	// Try a password list.
+	conf := model.NewDefaultConfiguration()
+	conf.PasswordProvider = model.PasswordList("secret", "upw")
+	if _, err := api.GetPermissionsFile(outFile, conf); err != nil {
+		t.Fatalf("%s: get permissions %s: %v\n", msg, outFile, err)
+	}
+
+	// Give up after two attempts.
+	var attempts []int
+	conf = model.NewDefaultConfiguration()
+	conf.PasswordProvider = func(fileName string, attempt int) (string, bool) {
+		if fileName != outFile {
+			t.Fatalf("%s: want %s, got %s\n", msg, outFile, fileName)
+		}
+		attempts = append(attempts, attempt)
+		return "wrong", attempt < 2
+	}
+	if _, err := api.GetPermissionsFile(outFile, conf); err != pdfcpu.ErrWrongPassword {
+		t.Fatalf("%s: want wrong password, got %v\n", msg, err)
+	}
+	if len(attempts) != 2 || attempts[1] != 2 {
+		t.Fatalf("%s: want 2 attempts, got %v\n", msg, attempts)
+	}
+
+	// The provider is not consulted for correct passwords.
+	conf = confForAlgorithm(true, 256, "", "opw")
+	conf.PasswordProvider = func(fileName string, attempt int) (string, bool) {
+		t.Fatalf("%s: unexpected password request\n", msg)
+		return "", false
+	}
+	if err := api.DecryptFile(outFile, "", conf); err != nil {
+		t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+	}
+}
+
+func cryptFilterStream(t *testing.T, ctx *model.Context, content, cryptFilter string) types.IndirectRef {
+	t.Helper()
+
+	sd := types.StreamDict{
+		Dict:           types.NewDict(),
+		Content:        []byte(content),
+		FilterPipeline: []types.PDFFilter{{Name: filter.Crypt}},
+	}
+	sd.InsertName("Filter", filter.Crypt)
+	if cryptFilter != "" {
+		d := types.Dict(map[string]types.Object{"Name": types.Name(cryptFilter)})
+		sd.FilterPipeline[0].DecodeParms = d
+		sd.Insert("DecodeParms", d)
+	}
+
+	if err := sd.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	ir, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return *ir
+}
+
+func TestCryptFilter(t *testing.T) {
+	msg := "TestCryptFilter"
+	inFile := filepath.Join(inDir, "5116.DCT_Filter.pdf")
+	encFile := filepath.Join(outDir, "cryptFilterEnc.pdf")
+	outFile := filepath.Join(outDir, "cryptFilter.pdf")
+
+	if err := api.EncryptFile(inFile, encFile, confForAlgorithm(true, 256, "upw", "opw")); err != nil {
+		t.Fatalf("%s: encrypt %s: %v\n", msg, encFile, err)
+	}
+
+	f, err := os.Open(encFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := api.ReadAndValidate(f, confForAlgorithm(true, 256, "upw", "opw"))
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: read %s: %v\n", msg, encFile, err)
+	}
+
+	// Append a content stream using the Identity crypt filter and one using the documents crypt filter.
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity := "/pdfcpuIdentity BMC EMC\n"
+	named := "/pdfcpuStdCF BMC EMC\n"
+	a := types.Array{d["Contents"]}
+	if a1, ok := d["Contents"].(types.Array); ok {
+		a = a1
+	}
+	a = append(a, cryptFilterStream(t, ctx, identity, ""), cryptFilterStream(t, ctx, named, "StdCF"))
+	d.Update("Contents", a)
+
+	ctx.Cmd = model.OPTIMIZE
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("%s: write %s: %v\n", msg, outFile, err)
+	}
+
+	bb, err := os.ReadFile(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(bb, []byte(identity)) {
+		t.Fatalf("%s: Identity crypt filter stream got encrypted\n", msg)
+	}
+	if bytes.Contains(bb, []byte(named)) {
+		t.Fatalf("%s: StdCF crypt filter stream did not get encrypted\n", msg)
+	}
+
+	// Both streams need to decode.
+	f, err = os.Open(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err = api.ReadAndValidate(f, confForAlgorithm(true, 256, "upw", "opw"))
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: read %s: %v\n", msg, outFile, err)
+	}
+	if d, _, _, err = ctx.PageDict(1, false); err != nil {
+		t.Fatal(err)
+	}
+	bb, err = ctx.PageContent(d, 1)
+	if err != nil {
+		t.Fatalf("%s: page content: %v\n", msg, err)
+	}
+	if !bytes.Contains(bb, []byte(identity)) || !bytes.Contains(bb, []byte(named)) {
+		t.Fatalf("%s: missing content stream\n", msg)
+	}
+
+	// Decryption drops the Crypt filters.
+	if err := api.DecryptFile(outFile, "", confForAlgorithm(true, 256, "upw", "opw")); err != nil {
+		t.Fatalf("%s: decrypt %s: %v\n", msg, outFile, err)
+	}
+	if bb, err = os.ReadFile(outFile); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Contains(bb, []byte(named)) || bytes.Contains(bb, []byte("/Crypt")) {
+		t.Fatalf("%s: Crypt filter survived decryption\n", msg)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate %s: %v\n", msg, outFile, err)
+	}
+}
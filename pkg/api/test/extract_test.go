@@ -17,15 +17,20 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -107,8 +112,8 @@ func TestExtractImagesSoftMasks(t *testing.T) {
 	}
 
 	expected := map[int]string{
-		36:  "VectorApple_36.tif",  // IndexedCMYK w/ softmask
-		245: "VectorApple_245.tif", // DeviceCMYK w/ softmask
+		36:  "VectorApple_36.png",  // IndexedCMYK w/ softmask
+		245: "VectorApple_245.png", // DeviceCMYK w/ softmask
 	}
 
 	for objId, filename := range expected {
@@ -121,7 +126,7 @@ func TestExtractImagesSoftMasks(t *testing.T) {
 		tmpFileName := filepath.Join(outDir, filename)
 		fmt.Printf("tmpFileName: %s\n", tmpFileName)
 
-		// Write the image object (as TIFF file) to disk.
+		// Write the image object (as PNG file with alpha channel) to disk.
 		// fn1 is the resulting fileName path including the suffix (aka filetype extension).
 		fn1, err := pdfcpu.WriteImage(ctx.XRefTable, tmpFileName, sd, false, objId)
 		if err != nil {
@@ -168,6 +173,151 @@ func TestExtractImagesLowLevel(t *testing.T) {
 	}
 }
 
+func TestExtractImagesLossless(t *testing.T) {
+	msg := "TestExtractImagesLossless"
+	inFile := filepath.Join(inDir, "testImage.pdf")
+
+	if err := api.ExtractImagesLosslessFile(inFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s %s: %v\n", msg, inFile, err)
+	}
+
+	for _, tt := range []struct {
+		name, filter string
+		magic        []byte
+		dpi          float64
+	}{
+		{"testImage_1_Im1", "JPXDecode", []byte{0x00, 0x00, 0x00, 0x0C, 'j', 'P', ' ', ' '}, 72},
+		{"testImage_2_Im2", "DCTDecode", []byte{0xFF, 0xD8}, 200},
+	} {
+		bb, err := os.ReadFile(filepath.Join(outDir, tt.name+".json"))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		var li model.LosslessImage
+		if err := json.Unmarshal(bb, &li); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !li.Raw || li.ColorSpace != "ICCBased" || len(li.Filters) != 1 || li.Filters[0] != tt.filter {
+			t.Fatalf("%s %s: unexpected manifest: %+v\n", msg, tt.name, li)
+		}
+		if len(li.Placements) != 1 || li.Placements[0].DPI != tt.dpi {
+			t.Fatalf("%s %s: unexpected placements: %+v\n", msg, tt.name, li.Placements)
+		}
+
+		bb, err = os.ReadFile(filepath.Join(outDir, li.File))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.HasPrefix(bb, tt.magic) {
+			t.Fatalf("%s %s: unexpected image file format\n", msg, li.File)
+		}
+
+		// The image data is written as stored.
+		sd, _, err := ctx.DereferenceStreamDict(*types.NewIndirectRef(li.ObjNr, 0))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.Equal(bb, sd.Raw) {
+			t.Fatalf("%s %s: image data has been modified\n", msg, li.File)
+		}
+	}
+}
+
+// reverseFilter is a proprietary filter reversing its input.
+type reverseFilter struct{}
+
+func reversed(r io.Reader) ([]byte, error) {
+	bb, err := io.ReadAll(r)
+	slices.Reverse(bb)
+	return bb, err
+}
+
+func (f reverseFilter) Encode(r io.Reader) (io.Reader, error) {
+	bb, err := reversed(r)
+	return bytes.NewReader(bb), err
+}
+
+func (f reverseFilter) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f reverseFilter) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	bb, err := reversed(r)
+	return io.NopCloser(bytes.NewReader(bb)), err
+}
+
+func TestExtractImagesRegisteredFilter(t *testing.T) {
+	msg := "TestExtractImagesRegisteredFilter"
+	const filterName = "ReverseDecode"
+
+	filter.Register(filterName, func(parms map[string]int) (filter.Filter, error) {
+		return reverseFilter{}, nil
+	})
+	defer filter.Register(filterName, nil)
+
+	var buf bytes.Buffer
+	f, err := os.Open(filepath.Join(resDir, "logoSmall.png"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+	if err := api.ImportImages(nil, &buf, []io.Reader{f}, pdfcpu.DefaultImportConfig(), nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+
+	want := map[string]*bytes.Buffer{}
+	if err := api.ExtractImagesTo(bytes.NewReader(buf.Bytes()), api.MemOutput(want), "logo.pdf", nil, nil); err != nil {
+		t.Fatalf("%s extract images: %v\n", msg, err)
+	}
+	if len(want) == 0 {
+		t.Fatalf("%s: missing images\n", msg)
+	}
+
+	// Re-encode all images using the registered filter.
+	ctx, err := api.ReadAndValidate(bytes.NewReader(buf.Bytes()), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for objNr, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.Subtype() == nil || *sd.Subtype() != "Image" {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s obj#%d: %v\n", msg, objNr, err)
+		}
+		sd.FilterPipeline = []types.PDFFilter{{Name: filterName}}
+		sd.Update("Filter", types.Name(filterName))
+		sd.Delete("DecodeParms")
+		if err := sd.Encode(); err != nil {
+			t.Fatalf("%s obj#%d: %v\n", msg, objNr, err)
+		}
+		entry.Object = sd
+	}
+	buf.Reset()
+	if err := api.WriteContext(ctx, &buf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	got := map[string]*bytes.Buffer{}
+	if err := api.ExtractImagesTo(bytes.NewReader(buf.Bytes()), api.MemOutput(got), "logo.pdf", nil, nil); err != nil {
+		t.Fatalf("%s extract images: %v\n", msg, err)
+	}
+	if len(got) != len(want) {
+		t.Fatalf("%s: want %d images, got %d\n", msg, len(want), len(got))
+	}
+	for name, bb := range want {
+		if got[name] == nil || !bytes.Equal(got[name].Bytes(), bb.Bytes()) {
+			t.Fatalf("%s %s: image mismatch\n", msg, name)
+		}
+	}
+}
+
 func TestExtractFonts(t *testing.T) {
 	msg := "TestExtractFonts"
 	// Extract fonts for all pages into outDir.
@@ -184,6 +334,49 @@ func TestExtractFonts(t *testing.T) {
 	}
 }
 
+func TestExtractFontFiles(t *testing.T) {
+	msg := "TestExtractFontFiles"
+
+	for _, tt := range []struct {
+		fileName, fontFile string
+		magic              []byte
+	}{
+		{"grid_example.pdf", "grid_example_CMR10.pfb", []byte{0x80, 0x01}},
+		{"TheGoProgrammingLanguageCh1.pdf", "TheGoProgrammingLanguageCh1_Lato-Bold.otf", []byte("OTTO")},
+	} {
+		inFile := filepath.Join(inDir, tt.fileName)
+		if err := api.ExtractFontsFile(inFile, outDir, nil, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, inFile, err)
+		}
+
+		bb, err := os.ReadFile(filepath.Join(outDir, tt.fontFile))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if !bytes.HasPrefix(bb, tt.magic) {
+			t.Fatalf("%s %s: unexpected font file format\n", msg, tt.fontFile)
+		}
+
+		bb, err = os.ReadFile(filepath.Join(outDir, strings.TrimSuffix(tt.fileName, ".pdf")+"_Fonts.json"))
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		var ff []api.FontFile
+		if err := json.Unmarshal(bb, &ff); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		var found bool
+		for _, f := range ff {
+			if f.File == tt.fontFile {
+				found = len(f.Pages) > 0
+			}
+		}
+		if !found {
+			t.Fatalf("%s %s: missing manifest entry\n", msg, tt.fontFile)
+		}
+	}
+}
+
 func TestExtractFontsLowLevel(t *testing.T) {
 	msg := "TestExtractFontsLowLevel"
 	inFile := filepath.Join(inDir, "go.pdf")
@@ -224,6 +417,42 @@ func TestExtractPages(t *testing.T) {
 	}
 }
 
+func TestExtractPagesEncrypted(t *testing.T) {
+	msg := "TestExtractPagesEncrypted"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s readContext: %v\n", msg, err)
+	}
+
+	ctxDest, err := pdfcpu.ExtractPages(ctx, []int{1, 3}, false)
+	if err != nil {
+		t.Fatalf("%s extractPages: %v\n", msg, err)
+	}
+	if ctxDest.PageCount != 2 {
+		t.Fatalf("%s: want 2 pages, got %d\n", msg, ctxDest.PageCount)
+	}
+
+	// The extracted context has not been read from a file.
+	ctxDest.Cmd = model.ENCRYPT
+	ctxDest.OwnerPW = "opw"
+	var buf bytes.Buffer
+	if err := api.WriteContext(ctxDest, &buf); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.OwnerPW = "opw"
+	n, err := api.PageCount(bytes.NewReader(buf.Bytes()), conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n != 2 {
+		t.Fatalf("%s: want 2 pages, got %d\n", msg, n)
+	}
+}
+
 func TestExtractPagesLowLevel(t *testing.T) {
 	msg := "TestExtractPagesLowLevel"
 	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
@@ -318,3 +547,69 @@ func TestExtractMetadataLowLevel(t *testing.T) {
 			md.ObjNr, md.ParentObjNr, md.ParentType, string(bb))
 	}
 }
+
+func TestExtractVectorPaths(t *testing.T) {
+	msg := "TestExtractVectorPaths"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+
+	// Extract vector paths of page 1 as SVG and JSON into outDir.
+	for _, jsonOutput := range []bool{false, true} {
+		if err := api.ExtractVectorPathsFile(inFile, outDir, []string{"1"}, jsonOutput, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, inFile, err)
+		}
+	}
+
+	bb, err := os.ReadFile(filepath.Join(outDir, "go-lecture_Paths_page_1.svg"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !strings.HasPrefix(string(bb), "<svg") || !strings.Contains(string(bb), "<path ") {
+		t.Fatalf("%s: missing SVG paths\n", msg)
+	}
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	pp, err := api.VectorPaths(f, []string{"1"}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pp) != 1 || pp[0].PageNr != 1 || len(pp[0].Paths) == 0 {
+		t.Fatalf("%s: got %d pages\n", msg, len(pp))
+	}
+}
+
+func TestExtractTables(t *testing.T) {
+	msg := "TestExtractTables"
+	inFile := filepath.Join(inDir, "T4.pdf")
+
+	// Extract the resolution table on page 10 as CSV and JSON into outDir.
+	for _, jsonOutput := range []bool{false, true} {
+		if err := api.ExtractTablesFile(inFile, outDir, []string{"10"}, jsonOutput, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, inFile, err)
+		}
+	}
+
+	bb, err := os.ReadFile(filepath.Join(outDir, "T4_Table_page_10_1.csv"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !strings.Contains(string(bb), "\nHorizontal 200 Vertical 200,±1%,1728/219.46 mm,2048/260.10 mm,2432/308.86 mm\n") {
+		t.Fatalf("%s: unexpected CSV:\n%s\n", msg, bb)
+	}
+
+	bb, err = os.ReadFile(filepath.Join(outDir, "T4_Tables_page_10.json"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	var tt []model.Table
+	if err := json.Unmarshal(bb, &tt); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(tt) != 1 || tt[0].Method != model.TableRuling || tt[0].Cols != 5 || len(tt[0].Cells) != tt[0].Rows*tt[0].Cols {
+		t.Fatalf("%s: got %+v\n", msg, tt)
+	}
+}
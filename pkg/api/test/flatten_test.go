@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestFlattenTransparency(t *testing.T) {
+	msg := "TestFlattenTransparency"
+	inFile := filepath.Join(outDir, "flattenTransparencyIn.pdf")
+	outFile := filepath.Join(outDir, "flattenedTransparency.pdf")
+
+	// An image with alpha channel painted on a white page.
+	imgFile := filepath.Join(resDir, "logoSmall.png")
+	if err := api.ImportImagesFile([]string{imgFile}, inFile, pdfcpu.DefaultImportConfig(), nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.FlattenTransparencyFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	for objNr, entry := range ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		o, err := ctx.Dereference(*types.NewIndirectRef(objNr, *entry.Generation))
+		if err != nil {
+			t.Fatalf("%s obj#%d: %v\n", msg, objNr, err)
+		}
+
+		var d types.Dict
+		switch o := o.(type) {
+		case types.Dict:
+			d = o
+		case types.StreamDict:
+			d = o.Dict
+		default:
+			continue
+		}
+
+		if st := d.Subtype(); st != nil && *st == "Image" {
+			if _, found := d.Find("SMask"); found {
+				t.Errorf("%s: image obj#%d has a soft mask\n", msg, objNr)
+			}
+		}
+
+		if g, _ := ctx.DereferenceDict(d["Group"]); g != nil {
+			if s := g.NameEntry("S"); s != nil && *s == "Transparency" {
+				t.Errorf("%s: obj#%d has a transparency group\n", msg, objNr)
+			}
+		}
+
+		for _, k := range []string{"ca", "CA"} {
+			if o, found := d.Find(k); found {
+				if f, err := ctx.DereferenceNumber(o); err != nil || f != 1 {
+					t.Errorf("%s: obj#%d has %s %v\n", msg, objNr, k, o)
+				}
+			}
+		}
+	}
+}
+
+func TestFlattenTransparencyOverText(t *testing.T) {
+	msg := "TestFlattenTransparencyOverText"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	stampedFile := filepath.Join(outDir, "flattenTransparencyStamped.pdf")
+	outFile := filepath.Join(outDir, "flattenedTransparencyOverText.pdf")
+
+	// A semi transparent stamp on top of text.
+	if err := api.AddTextWatermarksFile(inFile, stampedFile, []string{"1"}, true, "DRAFT", "pos:c, op:.5", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	os.Remove(outFile)
+
+	if err := api.FlattenTransparencyFile(stampedFile, outFile, nil); err == nil {
+		t.Fatalf("%s: want error for transparent content over text\n", msg)
+	}
+
+	if _, err := os.Stat(outFile); !os.IsNotExist(err) {
+		t.Fatalf("%s: want no output file\n", msg)
+	}
+}
@@ -17,10 +17,14 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"encoding/binary"
 	"fmt"
-
+	"os"
 	"path/filepath"
+	"sort"
 	"testing"
+	"unicode/utf16"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/font"
@@ -128,3 +132,257 @@ func TestUserFontDemoPDF(t *testing.T) {
 		}
 	}
 }
+
+func sfntTables(t *testing.T, fileName string) map[string][]byte {
+	t.Helper()
+	bb, err := os.ReadFile(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+	m := map[string][]byte{}
+	for i := 0; i < int(binary.BigEndian.Uint16(bb[4:])); i++ {
+		rec := bb[12+16*i:]
+		off, size := binary.BigEndian.Uint32(rec[8:]), binary.BigEndian.Uint32(rec[12:])
+		m[string(rec[:4])] = bb[off : off+size]
+	}
+	return m
+}
+
+func tableChecksum(bb []byte) uint32 {
+	var sum uint32
+	for i := 0; i < len(bb); i += 4 {
+		var w [4]byte
+		copy(w[:], bb[i:])
+		sum += binary.BigEndian.Uint32(w[:])
+	}
+	return sum
+}
+
+// sfnt writes the table directory and tables starting at off.
+func sfnt(tables map[string][]byte, off int) []byte {
+	tags := []string{}
+	for tag := range tables {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+
+	var dir, data bytes.Buffer
+	dir.WriteString("\x00\x01\x00\x00")
+	binary.Write(&dir, binary.BigEndian, []uint16{uint16(len(tags)), 0, 0, 0})
+	o := off + 12 + 16*len(tags)
+	for _, tag := range tags {
+		bb := tables[tag]
+		dir.WriteString(tag)
+		chk := tableChecksum(bb)
+		if tag == "head" {
+			chk -= binary.BigEndian.Uint32(bb[8:])
+		}
+		binary.Write(&dir, binary.BigEndian, []uint32{chk, uint32(o + data.Len()), uint32(len(bb))})
+		data.Write(bb)
+		for data.Len()%4 > 0 {
+			data.WriteByte(0)
+		}
+	}
+
+	return append(dir.Bytes(), data.Bytes()...)
+}
+
+func writeTrueTypeCollection(t *testing.T, fileName string, fonts ...map[string][]byte) {
+	t.Helper()
+	var buf bytes.Buffer
+	buf.WriteString("ttcf")
+	binary.Write(&buf, binary.BigEndian, []uint32{0x00010000, uint32(len(fonts))})
+	off := 12 + 4*len(fonts)
+	faces := [][]byte{}
+	for _, tables := range fonts {
+		binary.Write(&buf, binary.BigEndian, uint32(off))
+		bb := sfnt(tables, off)
+		faces = append(faces, bb)
+		off += len(bb)
+	}
+	for _, bb := range faces {
+		buf.Write(bb)
+	}
+	if err := os.WriteFile(fileName, buf.Bytes(), 0644); err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+}
+
+func TestInstallTrueTypeCollectionFace(t *testing.T) {
+	msg := "TestInstallTrueTypeCollectionFace"
+
+	fontDir := filepath.Join(inDir, "fonts")
+	fn := filepath.Join(outDir, "test.ttc")
+	writeTrueTypeCollection(t, fn,
+		sfntTables(t, filepath.Join(fontDir, "Roboto-Regular.ttf")),
+		sfntTables(t, filepath.Join(fontDir, "unifont_jp-13.0.03.ttf")))
+
+	faces, err := font.TrueTypeCollectionFaces(fn)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(faces) != 2 || faces[0] != "Roboto-Regular" || faces[1] != "Unifont-JPMedium" {
+		t.Fatalf("%s: unexpected faces: %v\n", msg, faces)
+	}
+
+	for _, face := range []string{"1", "unifont-jpmedium"} {
+		dir, err := os.MkdirTemp(outDir, "fonts")
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if err := font.InstallTrueTypeCollectionFace(dir, fn, face); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, face, err)
+		}
+		files, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if len(files) != 1 || files[0].Name() != "Unifont-JPMedium.gob" {
+			t.Fatalf("%s %s: expected Unifont-JPMedium.gob only\n", msg, face)
+		}
+	}
+
+	for _, face := range []string{"2", "Roboto-Bold"} {
+		if err := font.InstallTrueTypeCollectionFace(outDir, fn, face); err == nil {
+			t.Fatalf("%s %s: should have failed\n", msg, face)
+		}
+	}
+}
+
+// addNameRecord adds a Windows English name record to table "name".
+func addNameRecord(name []byte, nameID uint16, s string) []byte {
+	count := binary.BigEndian.Uint16(name[2:])
+	storage := binary.BigEndian.Uint16(name[4:])
+	strs := append([]byte(nil), name[storage:]...)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.BigEndian, []uint16{0, count + 1, storage + 12})
+	buf.Write(name[6 : 6+12*int(count)])
+	u := utf16.Encode([]rune(s))
+	binary.Write(&buf, binary.BigEndian, []uint16{3, 1, 0x0409, nameID, uint16(2 * len(u)), uint16(len(strs))})
+	buf.Write(strs)
+	binary.Write(&buf, binary.BigEndian, u)
+
+	return buf.Bytes()
+}
+
+// writeVariableRoboto writes a variable version of Roboto-Regular with a weight axis
+// and the named instance "Heavy" widening the space character by 100 units.
+func writeVariableRoboto(t *testing.T, fileName string, spaceGID uint16) {
+	t.Helper()
+	tables := sfntTables(t, filepath.Join(inDir, "fonts", "Roboto-Regular.ttf"))
+	tables["name"] = addNameRecord(tables["name"], 256, "Heavy")
+
+	// fvar: wght 100..400..900, instance "Heavy" at 900
+	var fvar bytes.Buffer
+	binary.Write(&fvar, binary.BigEndian, []uint16{1, 0, 16, 2, 1, 20, 1, 8})
+	fvar.WriteString("wght")
+	binary.Write(&fvar, binary.BigEndian, []uint32{100 << 16, 400 << 16, 900 << 16})
+	binary.Write(&fvar, binary.BigEndian, []uint16{0, 256})
+	binary.Write(&fvar, binary.BigEndian, []uint16{256, 0})
+	binary.Write(&fvar, binary.BigEndian, uint32(900<<16))
+	tables["fvar"] = fvar.Bytes()
+
+	// gvar: the space glyph at peak wght 1.0 moves the advance width phantom point by 100.
+	glyphCount := int(binary.BigEndian.Uint16(tables["maxp"][4:]))
+	data := []byte{
+		0x80, 0x01, 0x00, 0x0A, // shared point numbers, 1 tuple, data offset
+		0x00, 0x06, 0x80, 0x00, 0x40, 0x00, // tuple size 6, embedded peak 1.0
+		0x00,                         // shared points: all
+		0x03, 0x00, 0x64, 0x00, 0x00, // x deltas 0 100 0 0
+		0x83, // y deltas all zero
+		0x00, // padding
+	}
+	arrayOff := 20 + 4*(glyphCount+1)
+	var gvar bytes.Buffer
+	binary.Write(&gvar, binary.BigEndian, []uint16{1, 0, 1, 0})
+	binary.Write(&gvar, binary.BigEndian, uint32(arrayOff))
+	binary.Write(&gvar, binary.BigEndian, []uint16{uint16(glyphCount), 1})
+	binary.Write(&gvar, binary.BigEndian, uint32(arrayOff))
+	for gid := 0; gid <= glyphCount; gid++ {
+		off := 0
+		if gid > int(spaceGID) {
+			off = len(data)
+		}
+		binary.Write(&gvar, binary.BigEndian, uint32(off))
+	}
+	gvar.Write(data)
+	tables["gvar"] = gvar.Bytes()
+
+	if err := os.WriteFile(fileName, sfnt(tables, 0), 0644); err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+}
+
+func TestInstallVariableFontInstance(t *testing.T) {
+	msg := "TestInstallVariableFontInstance"
+
+	regular, ok := font.UserFontMetrics["Roboto-Regular"]
+	if !ok {
+		t.Fatalf("%s: missing Roboto-Regular\n", msg)
+	}
+	spaceGID := regular.Chars[' ']
+
+	fn := filepath.Join(outDir, "RobotoVF.ttf")
+	writeVariableRoboto(t, fn, spaceGID)
+
+	instances, err := font.VariableFontInstances(fn)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(instances) != 1 || instances[0] != "Heavy" {
+		t.Fatalf("%s: unexpected instances: %v\n", msg, instances)
+	}
+
+	if err := font.InstallVariableFontInstance(outDir, fn, "Black"); err == nil {
+		t.Fatalf("%s: unknown instance should fail\n", msg)
+	}
+
+	if err := api.InstallFonts([]string{fn + ":heavy"}); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	heavy, ok := font.UserFontMetrics["Roboto-Heavy"]
+	if !ok {
+		t.Fatalf("%s: missing Roboto-Heavy\n", msg)
+	}
+
+	// Widths are given in glyph space.
+	w0, w1 := regular.GlyphWidths[spaceGID], heavy.GlyphWidths[spaceGID]
+	if d := w1 - w0 - 100*1000/regular.UnitsPerEm; d < -1 || d > 1 {
+		t.Fatalf("%s: space width %d, got %d\n", msg, w0, w1)
+	}
+
+	gid := regular.Chars['A']
+	if regular.GlyphWidths[gid] != heavy.GlyphWidths[gid] {
+		t.Fatalf("%s: width of A changed\n", msg)
+	}
+
+	if heavy.Chars['A'] != gid || heavy.GlyphCount != regular.GlyphCount {
+		t.Fatalf("%s: glyph mapping changed\n", msg)
+	}
+}
+
+func TestShape(t *testing.T) {
+	msg := "TestShape"
+
+	for _, tt := range []struct {
+		in   string
+		rtl  bool
+		want string
+	}{
+		// seen + lam alef ligature + meem, reversed
+		{"سلام", true, "مﻼﺳ"},
+		// beh initial, beh medial, beh final
+		{"ببب", true, "ﺐﺒﺑ"},
+		// numbers and latin keep their direction
+		{"abc 123", true, "abc 123"},
+		{"שלום 123", false, "123 םולש"},
+		{"(שלום)", true, "(םולש)"},
+		{"Hello", false, "Hello"},
+	} {
+		if got := font.Shape(tt.in, "UnifontMedium", tt.rtl); got != tt.want {
+			t.Errorf("%s %q: want %q, got %q\n", msg, tt.in, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestGeoViewports(t *testing.T) {
+	msg := "TestGeoViewports"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "GeoViewports.pdf")
+
+	// Georeference the viewport corners using WGS 84.
+	vp := model.GeoViewport{
+		Name: "map",
+		BBox: types.NewRectangle(50, 50, 550, 750),
+		GCS:  model.GeoCoordinateSystem{Type: model.GeographicCoordinateSystem, EPSG: 4326},
+		GPTS: []float64{47, 8, 48, 8, 48, 9.5, 47, 9.5},
+		PDU:  []string{"KM", "SQKM", "DEG"},
+	}
+
+	if err := api.AddGeoViewportFile(inFile, outFile, []string{"1"}, vp, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m, err := api.GeoViewportsFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(m) != 1 || len(m[1]) != 1 || m[1][0].GCS.EPSG != 4326 || m[1][0].Name != "map" {
+		t.Fatalf("%s: unexpected geo viewports: %v\n", msg, m)
+	}
+
+	ss, err := api.ListGeoViewportsFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) != 2 {
+		t.Fatalf("%s: unexpected list: %v\n", msg, ss)
+	}
+
+	lat, lon, err := api.PageToGeoFile(outFile, 1, 300, 400, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if math.Abs(lat-47.5) > 1e-9 || math.Abs(lon-8.75) > 1e-9 {
+		t.Fatalf("%s: page to geo: got %f %f, want 47.5 8.75\n", msg, lat, lon)
+	}
+
+	x, y, err := api.GeoToPageFile(outFile, 1, 47.5, 8.75, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if math.Abs(x-300) > 1e-6 || math.Abs(y-400) > 1e-6 {
+		t.Fatalf("%s: geo to page: got %f %f, want 300 400\n", msg, x, y)
+	}
+
+	if _, _, err := api.PageToGeoFile(outFile, 1, 10, 10, nil); err == nil {
+		t.Fatalf("%s: page to geo outside viewport should fail\n", msg)
+	}
+
+	if err := api.RemoveGeoViewportsFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.RemoveGeoViewportsFile(outFile, "", nil, nil); err == nil {
+		t.Fatalf("%s: remove should fail without geo viewports\n", msg)
+	}
+}
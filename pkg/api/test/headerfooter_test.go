@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestHeaderFooter(t *testing.T) {
+	msg := "TestHeaderFooter"
+
+	for _, tt := range []struct {
+		msg             string
+		inFile, outFile string
+		config, desc    string
+	}{
+		{"header and footer",
+			"Walden.pdf",
+			"HeaderFooter.pdf",
+			"header:%filename||%date, footer:|Page %page of %pages|",
+			""},
+
+		{"odd and even",
+			"Walden.pdf",
+			"HeaderFooterOddEven.pdf",
+			"oddfooter:||%page, evenfooter:%page||, margin:24, inset:48",
+			"font:Times-Roman, points:9, fillc:#808080"},
+
+		{"bookmark",
+			"TheGoProgrammingLanguageCh1.pdf",
+			"HeaderFooterBookmark.pdf",
+			"header:|<b>%bookmark</b>|, footer:|%page|, dateformat:Jan 2\\, 2006",
+			""},
+	} {
+		inFile := filepath.Join(inDir, tt.inFile)
+		outFile := filepath.Join(outDir, tt.outFile)
+
+		hf, err := pdfcpu.ParseHeaderFooterConfig(tt.config, tt.desc, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		if err := api.AddHeadersAndFootersFile(inFile, outFile, nil, hf, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.msg, err)
+		}
+
+		if !hasWatermarks(outFile, t) {
+			t.Fatalf("%s %s: %s has no headers or footers\n", msg, tt.msg, outFile)
+		}
+	}
+}
+
+func TestHeaderFooterInvalidConfig(t *testing.T) {
+	msg := "TestHeaderFooterInvalidConfig"
+
+	for _, tt := range []struct {
+		config, desc string
+	}{
+		{"", ""},
+		{"margin:10", ""},
+		{"header:a|b|c|d", ""},
+		{"header:a, margin:-1", ""},
+		{"header", ""},
+		{"header:a, x:1", ""},
+		{"header:a", "pos:xx"},
+	} {
+		if _, err := pdfcpu.ParseHeaderFooterConfig(tt.config, tt.desc, nil); err == nil {
+			t.Fatalf("%s: %q %q should fail\n", msg, tt.config, tt.desc)
+		}
+	}
+}
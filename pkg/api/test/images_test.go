@@ -17,10 +17,19 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"image"
+	_ "image/jpeg"
+	"io"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func testUpdateImages(t *testing.T, msg string, inFile, imgFile, outFile string, objNr, pageNr int, id string) {
@@ -129,3 +138,90 @@ func TestUpdateImages(t *testing.T) {
 			tt.id)
 	}
 }
+
+func pageContent(t *testing.T, ctx *model.Context, pageNr int) []byte {
+	t.Helper()
+
+	r, err := pdfcpu.ExtractPageContent(ctx, pageNr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return bb
+}
+
+func TestReplaceImage(t *testing.T) {
+	msg := "TestReplaceImage"
+	inFile := filepath.Join(inDir, "testImage.pdf")
+	outFile := filepath.Join(outDir, "imageReplaced.pdf")
+	imgFile := filepath.Join(resDir, "mountain.jpg")
+
+	f, err := os.Open(imgFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	c, _, err := image.DecodeConfig(f)
+	f.Close()
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Replace the 1250x1800 DCT image on page 2.
+	if err := api.ReplaceImageFile(inFile, imgFile, outFile, 0, 2, 1, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Replace the JPX image on page 1 by objNr.
+	if err := api.ReplaceImageFile(outFile, filepath.Join(resDir, "mountain.png"), "", 7, 0, 0, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx0, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The placements are untouched.
+	for pageNr := 1; pageNr <= 2; pageNr++ {
+		if !bytes.Equal(pageContent(t, ctx0, pageNr), pageContent(t, ctx, pageNr)) {
+			t.Fatalf("%s: page %d content has changed\n", msg, pageNr)
+		}
+	}
+
+	for _, tt := range []struct {
+		objNr, w, h int
+		filter      string
+	}{
+		{16, c.Width, c.Height, filter.DCT},
+		{7, c.Width, c.Height, filter.Flate},
+	} {
+		sd, _, err := ctx.DereferenceStreamDict(*types.NewIndirectRef(tt.objNr, 0))
+		if err != nil || sd == nil {
+			t.Fatalf("%s obj#%d: %v\n", msg, tt.objNr, err)
+		}
+		w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+		if w == nil || *w != tt.w || h == nil || *h != tt.h {
+			t.Fatalf("%s obj#%d: got %v x %v, want %d x %d\n", msg, tt.objNr, w, h, tt.w, tt.h)
+		}
+		if len(sd.FilterPipeline) != 1 || sd.FilterPipeline[0].Name != tt.filter {
+			t.Fatalf("%s obj#%d: want %s\n", msg, tt.objNr, tt.filter)
+		}
+	}
+
+	if err := api.ReplaceImageFile(inFile, imgFile, outFile, 0, 1, 2, nil); err == nil {
+		t.Fatalf("%s: want invalid index error\n", msg)
+	}
+}
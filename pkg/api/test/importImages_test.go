@@ -19,13 +19,23 @@ package test
 import (
 	"bufio"
 	"bytes"
+	"image"
+	"image/color"
+	"image/png"
 	"io"
+	"math"
+	"math/bits"
+	"math/rand"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/hhrutter/tiff"
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -99,6 +109,286 @@ func TestImportImages(t *testing.T) {
 	}
 }
 
+// bilevelPage returns a black and white image resembling a page of text.
+func bilevelPage() *image.Gray {
+	w, h := 850, 1100
+	img := image.NewGray(image.Rect(0, 0, w, h))
+	r := rand.New(rand.NewSource(7))
+
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+
+	// Lines of ring shaped glyphs.
+	for y := 80; y < h-80; y += 24 {
+		for x := 60; x < w-80; x += 4 + r.Intn(8) {
+			rx, ry := 3+r.Intn(4), 5+r.Intn(3)
+			for dy := -ry; dy <= ry; dy++ {
+				for dx := -rx; dx <= rx; dx++ {
+					d := float64(dx*dx)/float64(rx*rx) + float64(dy*dy)/float64(ry*ry)
+					if d <= 1 && d >= .4 {
+						img.SetGray(x+rx+dx, y+ry+dy, color.Gray{0})
+					}
+				}
+			}
+			x += 2 * rx
+		}
+	}
+
+	// Scanner noise
+	for i := 0; i < w*h/500; i++ {
+		img.Pix[r.Intn(len(img.Pix))] = 0
+	}
+
+	return img
+}
+
+func writeBilevelPage(t *testing.T, fileName string) *image.Gray {
+	t.Helper()
+
+	img := bilevelPage()
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("create: %v\n", err)
+	}
+	defer f.Close()
+
+	if err := png.Encode(f, img); err != nil {
+		t.Fatalf("encode: %v\n", err)
+	}
+
+	return img
+}
+
+func TestImportBilevelImage(t *testing.T) {
+	msg := "TestImportBilevelImage"
+	imgFile := filepath.Join(outDir, "bilevel.png")
+	outFile := filepath.Join(outDir, "bilevel.pdf")
+
+	want := writeBilevelPage(t, imgFile)
+
+	testImportImages(t, msg, []string{imgFile}, outFile, "")
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+
+	for _, m := range mm {
+		for _, img := range m {
+			if img.Filter != filter.CCITTFax || img.Bpc != 1 {
+				t.Fatalf("%s: want 1 bit CCITT image, got %s bpc=%d\n", msg, img.Filter, img.Bpc)
+			}
+		}
+	}
+
+	// Extract the image and compare it with the original.
+	if err := api.ExtractImagesFile(outFile, outDir, nil, nil); err != nil {
+		t.Fatalf("%s extract: %v\n", msg, err)
+	}
+
+	fn, err := filepath.Glob(filepath.Join(outDir, "bilevel_1_*.png"))
+	if err != nil || len(fn) != 1 {
+		t.Fatalf("%s: missing extracted image\n", msg)
+	}
+
+	f1, err := os.Open(fn[0])
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f1.Close()
+
+	got, err := png.Decode(f1)
+	if err != nil {
+		t.Fatalf("%s decode: %v\n", msg, err)
+	}
+
+	b := want.Bounds()
+	if got.Bounds() != b {
+		t.Fatalf("%s: got bounds %v want %v\n", msg, got.Bounds(), b)
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if c := color.GrayModel.Convert(got.At(x, y)).(color.Gray); c != want.GrayAt(x, y) {
+				t.Fatalf("%s: pixel %d,%d: got %v want %v\n", msg, x, y, c, want.GrayAt(x, y))
+			}
+		}
+	}
+}
+
+func TestImportMultipageTIFF(t *testing.T) {
+	msg := "TestImportMultipageTIFF"
+	imgFile := filepath.Join(resDir, "multipageBilevel.tif")
+	outFile := filepath.Join(outDir, "multipageBilevel.pdf")
+	os.Remove(outFile)
+
+	// 2 CCITT Group 4 pages at 300 and 200 dpi followed by a LZW page at 40x20 dots per cm.
+	testImportImages(t, msg, []string{imgFile}, outFile, "")
+
+	dims, err := api.PageDimsFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	want := []types.Dim{{Width: 96, Height: 72}, {Width: 115.2, Height: 86.4}, {Width: 180, Height: 180}}
+	if len(dims) != len(want) {
+		t.Fatalf("%s: got %d pages, want %d\n", msg, len(dims), len(want))
+	}
+	for i, d := range dims {
+		if math.Abs(d.Width-want[i].Width) > .01 || math.Abs(d.Height-want[i].Height) > .01 {
+			t.Fatalf("%s page %d: got %v, want %v\n", msg, i+1, d, want[i])
+		}
+	}
+
+	bb, err := os.ReadFile(imgFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// The CCITT encoded data is passed through.
+	for pageNr := 1; pageNr <= 2; pageNr++ {
+		d, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		res, _ := ctx.DereferenceDict(d["Resources"])
+		xObjs, _ := ctx.DereferenceDict(res["XObject"])
+		sd, _, err := ctx.DereferenceStreamDict(xObjs["Im0"])
+		if err != nil || sd == nil {
+			t.Fatalf("%s page %d: missing image: %v\n", msg, pageNr, err)
+		}
+		if fpl := sd.FilterPipeline; len(fpl) != 1 || fpl[0].Name != filter.CCITTFax {
+			t.Fatalf("%s page %d: want CCITT image\n", msg, pageNr)
+		}
+		raw := sd.Raw
+		if pageNr == 2 {
+			// FillOrder 2
+			raw = make([]byte, len(sd.Raw))
+			for i, b := range sd.Raw {
+				raw[i] = bits.Reverse8(b)
+			}
+		}
+		if !bytes.Contains(bb, raw) {
+			t.Fatalf("%s page %d: CCITT data has been recompressed\n", msg, pageNr)
+		}
+	}
+
+	// Compare the first page with the decoded TIFF image.
+	wantImg, err := tiff.Decode(bytes.NewReader(bb))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ExtractImagesFile(outFile, outDir, []string{"1"}, nil); err != nil {
+		t.Fatalf("%s extract: %v\n", msg, err)
+	}
+
+	fn, err := filepath.Glob(filepath.Join(outDir, "multipageBilevel_1_*.png"))
+	if err != nil || len(fn) != 1 {
+		t.Fatalf("%s: missing extracted image\n", msg)
+	}
+
+	f, err := os.Open(fn[0])
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	got, err := png.Decode(f)
+	if err != nil {
+		t.Fatalf("%s decode: %v\n", msg, err)
+	}
+
+	b := wantImg.Bounds()
+	if got.Bounds() != b {
+		t.Fatalf("%s: got bounds %v want %v\n", msg, got.Bounds(), b)
+	}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			c1 := color.GrayModel.Convert(got.At(x, y)).(color.Gray)
+			c2 := color.GrayModel.Convert(wantImg.At(x, y)).(color.Gray)
+			if c1 != c2 {
+				t.Fatalf("%s: pixel %d,%d: got %v want %v\n", msg, x, y, c1, c2)
+			}
+		}
+	}
+}
+
+// fakeImage represents an image type provided by a custom decoder.
+type fakeImage struct {
+	image.Image
+}
+
+func decodeFake(r io.Reader) (image.Image, error) {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 2))
+	for i := range img.Pix {
+		img.Pix[i] = 0xFF
+	}
+	img.SetNRGBA(1, 1, color.NRGBA{R: 0xFF, A: 0x80})
+	return fakeImage{img}, nil
+}
+
+func decodeFakeConfig(r io.Reader) (image.Config, error) {
+	return image.Config{ColorModel: color.NRGBAModel, Width: 4, Height: 2}, nil
+}
+
+func TestImportRegisteredImageFormat(t *testing.T) {
+	msg := "TestImportRegisteredImageFormat"
+
+	heicFile := filepath.Join(outDir, "photo.heic")
+	if err := os.WriteFile(heicFile, []byte("\x00\x00\x00\x18ftypheic\x00\x00\x00\x00mif1heic"), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	err := api.ImportImagesFile([]string{heicFile}, filepath.Join(outDir, "photo.pdf"), nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "HEIC") {
+		t.Fatalf("%s: want missing HEIC decoder error, got %v\n", msg, err)
+	}
+
+	model.RegisterImageFormat("fake", "FAKE", decodeFake, decodeFakeConfig, "fake")
+
+	imgFile := filepath.Join(outDir, "photo.fake")
+	if !model.ImageFileName(imgFile) {
+		t.Fatalf("%s: unsupported file extension\n", msg)
+	}
+	if err := os.WriteFile(imgFile, []byte("FAKE"), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "fake.pdf")
+	os.Remove(outFile)
+	testImportImages(t, msg, []string{imgFile}, outFile, "")
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+	if len(mm) != 1 || len(mm[0]) != 1 {
+		t.Fatalf("%s: want 1 image\n", msg)
+	}
+	for _, img := range mm[0] {
+		if img.Width != 4 || img.Height != 2 || img.Cs != model.DeviceRGBCS || !img.HasSMask {
+			t.Fatalf("%s: got %dx%d cs=%s smask=%t\n", msg, img.Width, img.Height, img.Cs, img.HasSMask)
+		}
+	}
+}
+
 func TestMemBasedWriterPanic(t *testing.T) {
 
 	imgFiles := []string{filepath.Join(resDir, "logoSmall.png")}
@@ -0,0 +1,119 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+const brochureTemplate = `{
+	"name": "3-panel brochure",
+	"paperSize": "A4L",
+	"unit": "mm",
+	"rows": 1,
+	"cols": 3,
+	"colWidths": [1, 1, 0.97],
+	"margin": 5,
+	"marks": "crop fold",
+	"sides": [
+		{"cells": [{"row":0, "col":0, "page":"6*i+5"}, {"row":0, "col":1, "page":"6*i+6"}, {"row":0, "col":2, "page":"6*i+1"}]},
+		{"cells": [{"row":0, "col":0, "page":"6*i+2"}, {"row":0, "col":1, "page":"6*i+3"}, {"row":0, "col":2, "page":"6*i+4"}]}
+	]
+}`
+
+const bookletTemplate = `{
+	"name": "2-up saddle stitch",
+	"paperSize": "A4L",
+	"rows": 1,
+	"cols": 2,
+	"pagesPerSheet": 4,
+	"sides": [
+		{"cells": [{"row":0, "col":0, "page":"n-2*i"}, {"row":0, "col":1, "page":"2*i+1"}]},
+		{"cells": [{"row":0, "col":0, "page":"2*i+2", "rotate":180}, {"row":0, "col":1, "page":"n-2*i-1", "rotate":180}]}
+	]
+}`
+
+func TestImpose(t *testing.T) {
+	for _, tt := range []struct {
+		msg           string
+		inFile        string
+		template      string
+		selectedPages []string
+		wantPages     int
+	}{
+		{"TestImposeBrochure", "test.pdf", brochureTemplate, []string{"1-6"}, 2},
+		{"TestImposeBooklet", "bookletTest.pdf", bookletTemplate, nil, 0},
+	} {
+		inFile := filepath.Join(inDir, tt.inFile)
+		outFile := filepath.Join(outDir, tt.msg+".pdf")
+
+		tmpl, err := api.ParseImpositionTemplate(strings.NewReader(tt.template), nil)
+		if err != nil {
+			t.Fatalf("%s: %v\n", tt.msg, err)
+		}
+
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", tt.msg, err)
+		}
+		defer f.Close()
+
+		w, err := os.Create(outFile)
+		if err != nil {
+			t.Fatalf("%s: %v\n", tt.msg, err)
+		}
+
+		if err := api.Impose(f, w, tt.selectedPages, tmpl, nil); err != nil {
+			w.Close()
+			t.Fatalf("%s: %v\n", tt.msg, err)
+		}
+		w.Close()
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s: %v\n", tt.msg, err)
+		}
+
+		if tt.wantPages > 0 {
+			n, err := api.PageCountFile(outFile)
+			if err != nil {
+				t.Fatalf("%s: %v\n", tt.msg, err)
+			}
+			if n != tt.wantPages {
+				t.Fatalf("%s: got %d pages, want %d\n", tt.msg, n, tt.wantPages)
+			}
+		}
+	}
+}
+
+func TestImposeInvalidTemplate(t *testing.T) {
+	for _, s := range []string{
+		`{"rows": 0, "cols": 2, "sides": [{"cells": [{"page":"1"}]}]}`,
+		`{"rows": 1, "cols": 2, "sides": [{"cells": [{"col":2, "page":"1"}]}]}`,
+		`{"rows": 1, "cols": 2, "sides": [{"cells": [{"page":"2*(i+1"}]}]}`,
+		`{"rows": 1, "cols": 2, "sides": [{"cells": [{"page":"1", "rotate":45}]}]}`,
+		`{"rows": 1, "cols": 1, "unit": "furlong", "sides": [{"cells": [{"page":"1"}]}]}`,
+	} {
+		if _, err := api.ParseImpositionTemplate(strings.NewReader(s), nil); err == nil {
+			t.Fatalf("TestImposeInvalidTemplate: expected error for %s\n", s)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestAddInvoice(t *testing.T) {
+	msg := "TestAddInvoice"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	inFileXML := filepath.Join(resDir, "factur-x.xml")
+	outFile := filepath.Join(outDir, "invoice.pdf")
+
+	inv, err := pdfcpu.ParseInvoiceConfig("desc:Invoice 4711", nil)
+	if err != nil {
+		t.Fatalf("%s parse config: %v\n", msg, err)
+	}
+
+	if err := api.AddInvoiceFile(inFile, inFileXML, outFile, inv, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s catalog: %v\n", msg, err)
+	}
+
+	// The invoice is an associated file of the document.
+	a, err := ctx.DereferenceArray(rootDict["AF"])
+	if err != nil || len(a) != 1 {
+		t.Fatalf("%s: want 1 associated file, got %v %v\n", msg, a, err)
+	}
+	d, err := ctx.DereferenceDict(a[0])
+	if err != nil {
+		t.Fatalf("%s file spec: %v\n", msg, err)
+	}
+	if rel := d.NameEntry("AFRelationship"); rel == nil || *rel != "Alternative" {
+		t.Fatalf("%s: want AFRelationship Alternative, got %v\n", msg, rel)
+	}
+	if s, _ := types.StringOrHexLiteral(d["UF"]); s == nil || *s != "factur-x.xml" {
+		t.Fatalf("%s: want file name factur-x.xml, got %v\n", msg, s)
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(d.DictEntry("EF")["F"])
+	if err != nil || sd == nil {
+		t.Fatalf("%s embedded file: %v\n", msg, err)
+	}
+	if st := sd.NameEntry("Subtype"); st == nil || *st != "text/xml" {
+		t.Fatalf("%s: want Subtype text/xml, got %v\n", msg, st)
+	}
+
+	ctx.XRefTable.Conf.Cmd = model.LISTATTACHMENTS
+	aa, err := ctx.ListAttachments()
+	if err != nil || len(aa) != 1 || aa[0].Desc != "Invoice 4711" {
+		t.Fatalf("%s: want 1 attachment, got %v %v\n", msg, aa, err)
+	}
+
+	// The metadata declares a Factur-X EN 16931 invoice in a PDF/A-3b document.
+	sd, _, err = ctx.DereferenceStreamDict(rootDict["Metadata"])
+	if err != nil || sd == nil {
+		t.Fatalf("%s metadata: %v\n", msg, err)
+	}
+	if err := sd.Decode(); err != nil {
+		t.Fatalf("%s decode metadata: %v\n", msg, err)
+	}
+	xmp := string(sd.Content)
+	for _, s := range []string{
+		"<pdfaid:part>3</pdfaid:part>",
+		"<pdfaSchema:prefix>fx</pdfaSchema:prefix>",
+		"<fx:DocumentFileName>factur-x.xml</fx:DocumentFileName>",
+		"<fx:ConformanceLevel>EN 16931</fx:ConformanceLevel>",
+	} {
+		if !strings.Contains(xmp, s) {
+			t.Fatalf("%s: metadata missing %s\n", msg, s)
+		}
+	}
+
+	// A document can't take a second invoice.
+	if err := api.AddInvoiceFile(outFile, inFileXML, "", nil, nil); err == nil {
+		t.Fatalf("%s: want error for second invoice\n", msg)
+	}
+}
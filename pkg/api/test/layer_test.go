@@ -0,0 +1,120 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// optionalContentObjs returns the number of objects of inFile controlled by optional content.
+func optionalContentObjs(t *testing.T, msg, inFile string) int {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	c := 0
+	for objNr, entry := range ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		o, err := ctx.Dereference(*types.NewIndirectRef(objNr, *entry.Generation))
+		if err != nil {
+			t.Fatalf("%s obj#%d: %v\n", msg, objNr, err)
+		}
+		switch o := o.(type) {
+		case types.Dict:
+			if _, found := o.Find("OC"); found {
+				c++
+			}
+		case types.StreamDict:
+			if _, found := o.Find("OC"); found {
+				c++
+			}
+		}
+	}
+
+	return c
+}
+
+func TestLayers(t *testing.T) {
+	msg := "TestLayers"
+	inFile := filepath.Join(inDir, "zineTest.pdf")
+	outFile := filepath.Join(outDir, "layers.pdf")
+
+	ll, err := api.LayersFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s layers: %v\n", msg, err)
+	}
+	if len(ll) != 1 || ll[0].Name != "Watermark" || !ll[0].Visible {
+		t.Fatalf("%s: got %v\n", msg, ll)
+	}
+
+	if optionalContentObjs(t, msg, inFile) == 0 {
+		t.Fatalf("%s: missing optional content\n", msg)
+	}
+
+	if err := api.SetLayerVisibilityFile(inFile, outFile, []string{"Watermark"}, false, nil); err != nil {
+		t.Fatalf("%s hide: %v\n", msg, err)
+	}
+	if ll, err = api.LayersFile(outFile, nil); err != nil || len(ll) != 1 || ll[0].Visible {
+		t.Fatalf("%s hide: %v %v\n", msg, ll, err)
+	}
+
+	if err := api.SetLayerVisibilityFile(outFile, "", []string{"Watermark"}, true, nil); err != nil {
+		t.Fatalf("%s show: %v\n", msg, err)
+	}
+	if ll, err = api.LayersFile(outFile, nil); err != nil || len(ll) != 1 || !ll[0].Visible {
+		t.Fatalf("%s show: %v %v\n", msg, ll, err)
+	}
+
+	if err := api.RemoveLayersFile(outFile, "", []string{"Background"}, nil); err == nil {
+		t.Fatalf("%s: removing an unknown layer should fail\n", msg)
+	}
+
+	if err := api.RemoveLayersFile(inFile, outFile, []string{"Watermark"}, nil); err != nil {
+		t.Fatalf("%s remove: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+	if ll, err = api.LayersFile(outFile, nil); err != nil || len(ll) != 0 {
+		t.Fatalf("%s remove: %v %v\n", msg, ll, err)
+	}
+	if c := optionalContentObjs(t, msg, outFile); c != 0 {
+		t.Fatalf("%s remove: %d objects left depending on removed layer\n", msg, c)
+	}
+
+	if err := api.FlattenLayersFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s flatten: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+	if ll, err = api.LayersFile(outFile, nil); err != nil || len(ll) != 0 {
+		t.Fatalf("%s flatten: %v %v\n", msg, ll, err)
+	}
+	if c := optionalContentObjs(t, msg, outFile); c != 0 {
+		t.Fatalf("%s flatten: %d objects left depending on layers\n", msg, c)
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var rr []map[string]any
+	s := bufio.NewScanner(buf)
+	for s.Scan() {
+		var r map[string]any
+		if err := json.Unmarshal(s.Bytes(), &r); err != nil {
+			t.Fatalf("invalid log record %s: %v\n", s.Text(), err)
+		}
+		rr = append(rr, r)
+	}
+
+	return rr
+}
+
+func TestStructuredLogging(t *testing.T) {
+	msg := "TestStructuredLogging"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var buf bytes.Buffer
+	conf := model.NewDefaultConfiguration()
+	conf.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	if err := api.Rotate(f, io.Discard, 90, []string{"1"}, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var read, rotated bool
+	for _, r := range logRecords(t, &buf) {
+		if r[log.KeyOp] != "rotate" || r[log.KeyFile] != inFile {
+			t.Fatalf("%s: record without op or file: %v\n", msg, r)
+		}
+		switch r["msg"] {
+		case "Read: end":
+			read = true
+		case "rotatePage":
+			if r[log.KeyPage] != float64(1) {
+				t.Fatalf("%s: want page 1, got %v\n", msg, r[log.KeyPage])
+			}
+			rotated = true
+		}
+	}
+	if !read || !rotated {
+		t.Fatalf("%s: missing records, read:%t rotated:%t\n", msg, read, rotated)
+	}
+
+	// Verbosity is controlled per call by the handler level.
+	buf.Reset()
+	conf = model.NewDefaultConfiguration()
+	conf.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.Rotate(f, io.Discard, 90, []string{"1"}, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	for _, r := range logRecords(t, &buf) {
+		if r["level"] == "DEBUG" {
+			t.Fatalf("%s: unexpected debug record: %v\n", msg, r)
+		}
+	}
+}
+
+func TestStructuredLoggingEncrypted(t *testing.T) {
+	msg := "TestStructuredLoggingEncrypted"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	var enc bytes.Buffer
+	if err := api.Encrypt(f, &enc, model.NewAESConfiguration("upw", "opw", 256)); err != nil {
+		t.Fatalf("%s encrypt: %v\n", msg, err)
+	}
+
+	// Permissions of files opened with the user password are logged for the command being executed.
+	var buf bytes.Buffer
+	conf := model.NewAESConfiguration("upw", "", 256)
+	conf.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo}))
+
+	if err := api.Validate(bytes.NewReader(enc.Bytes()), conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var perms bool
+	for _, r := range logRecords(t, &buf) {
+		if r[log.KeyOp] != "validate" {
+			t.Fatalf("%s: record without op: %v\n", msg, r)
+		}
+		if s, ok := r["msg"].(string); ok && strings.HasPrefix(s, "permission bits:") {
+			perms = true
+		}
+	}
+	if !perms {
+		t.Fatalf("%s: missing permission records\n", msg)
+	}
+}
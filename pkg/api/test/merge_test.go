@@ -24,6 +24,7 @@ import (
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 func TestMergeCreateNew(t *testing.T) {
@@ -172,3 +173,40 @@ func TestMergeRaw(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestMergeDedupeResources(t *testing.T) {
+	msg := "TestMergeDedupeResources"
+
+	// Merging files generated from the same template duplicates fonts and images per source file.
+	inFile := filepath.Join(inDir, "go.pdf")
+	inFiles := []string{inFile, inFile, inFile}
+
+	outFile1 := filepath.Join(outDir, "mergedWithDuplicates.pdf")
+	conf := model.NewDefaultConfiguration()
+	conf.DedupeResources = false
+	if err := api.MergeCreateFile(inFiles, outFile1, false, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	outFile2 := filepath.Join(outDir, "mergedDeduped.pdf")
+	if err := api.MergeCreateFile(inFiles, outFile2, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile2, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fi1, err := os.Stat(outFile1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi2, err := os.Stat(outFile2)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if fi2.Size() >= fi1.Size() {
+		t.Fatalf("%s: want %d < %d\n", msg, fi2.Size(), fi1.Size())
+	}
+}
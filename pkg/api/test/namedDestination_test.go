@@ -0,0 +1,150 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func namedDestPages(t *testing.T, msg, inFile string) map[string]int {
+	t.Helper()
+	nds, err := api.NamedDestinationsFile(inFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	m := map[string]int{}
+	for _, nd := range nds {
+		m[nd.Name] = nd.Dest.PageNr
+	}
+	return m
+}
+
+func checkNamedDestPages(t *testing.T, msg, inFile string, want map[string]int) {
+	t.Helper()
+	got := namedDestPages(t, msg, inFile)
+	if len(got) != len(want) {
+		t.Fatalf("%s: %s: want %v, got %v\n", msg, inFile, want, got)
+	}
+	for k, v := range want {
+		if got[k] != v {
+			t.Fatalf("%s: %s: want %v, got %v\n", msg, inFile, want, got)
+		}
+	}
+}
+
+func TestNamedDestinations(t *testing.T) {
+	msg := "TestNamedDestinations"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "NamedDests.pdf")
+
+	nds := []model.NamedDestination{
+		{Name: "intro", Dest: model.Destination{Typ: model.DestFit, PageNr: 1}},
+		{Name: "chapter1", Dest: model.Destination{Typ: model.DestXYZ, PageNr: 3, Left: 72, Top: 720, Zoom: 1.5}},
+		{Name: "chapter2", Dest: model.Destination{Typ: model.DestFitH, PageNr: 5, Top: 500}},
+	}
+
+	if err := api.AddNamedDestinationsFile(inFile, outFile, nds, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile, map[string]int{"intro": 1, "chapter1": 3, "chapter2": 5})
+
+	dest, err := api.ResolveNamedDestinationFile(outFile, "chapter1", nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if dest.PageNr != 3 || dest.Typ != model.DestXYZ || dest.Left != 72 || dest.Top != 720 || dest.Zoom != 1.5 {
+		t.Fatalf("%s: unexpected destination: %+v\n", msg, *dest)
+	}
+
+	if _, err := api.ResolveNamedDestinationFile(outFile, "missing", nil); err == nil {
+		t.Fatalf("%s: resolving a missing named destination should fail\n", msg)
+	}
+
+	// Adding an existing named destination requires replace.
+	nds = []model.NamedDestination{{Name: "intro", Dest: model.Destination{Typ: model.DestFit, PageNr: 2}}}
+	if err := api.AddNamedDestinationsFile(outFile, "", nds, false, nil); err == nil {
+		t.Fatalf("%s: adding a duplicate named destination should fail\n", msg)
+	}
+	if err := api.AddNamedDestinationsFile(outFile, "", nds, true, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile, map[string]int{"intro": 2, "chapter1": 3, "chapter2": 5})
+
+	// Removing pages drops destinations for removed pages and keeps the others intact.
+	outFile2 := filepath.Join(outDir, "NamedDestsRemovedPages.pdf")
+	if err := api.RemovePagesFile(outFile, outFile2, []string{"3"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile2, map[string]int{"intro": 2, "chapter2": 4})
+
+	// Each split file gets the named destinations of its own pages.
+	splitDir := filepath.Join(outDir, "NamedDestsSplit")
+	if err := os.MkdirAll(splitDir, os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SplitByPageNrFile(outFile, splitDir, []int{3, 5}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	ff, err := filepath.Glob(filepath.Join(splitDir, "*.pdf"))
+	if err != nil || len(ff) != 3 {
+		t.Fatalf("%s: want 3 split files, got %v %v\n", msg, ff, err)
+	}
+	total := 0
+	for _, f := range ff {
+		for _, pageNr := range namedDestPages(t, msg, f) {
+			if pageNr != 1 && pageNr != 2 {
+				t.Fatalf("%s: %s: unexpected named destination page: %d\n", msg, f, pageNr)
+			}
+			total++
+		}
+	}
+	if total != 3 {
+		t.Fatalf("%s: want 3 named destinations across split files, got %d\n", msg, total)
+	}
+
+	// Retarget.
+	if err := api.RetargetNamedDestinationsFile(outFile, "", map[int]int{2: 1, 5: 0}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile, map[string]int{"intro": 1, "chapter1": 3})
+
+	// Remove.
+	if err := api.RemoveNamedDestinationsFile(outFile, "", []string{"chapter1"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile, map[string]int{"intro": 1})
+
+	if err := api.RemoveNamedDestinationsFile(outFile, "", []string{"chapter1"}, nil); err == nil {
+		t.Fatalf("%s: removing a missing named destination should fail\n", msg)
+	}
+
+	if err := api.RemoveNamedDestinationsFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	checkNamedDestPages(t, msg, outFile, map[string]int{})
+}
@@ -17,10 +17,13 @@ limitations under the License.
 package test
 
 import (
+	"bytes"
+	"io"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
@@ -84,6 +87,26 @@ func TestNUp(t *testing.T) {
 			2,
 			false},
 
+		// 4-Up work-and-tumble: rotate the bottom row by 180 degrees
+		{"TestNUpFromPDFWorkAndTumble",
+			[]string{filepath.Join(inDir, "bookletTest.pdf")},
+			filepath.Join(outDir, "NUpFromPDFWorkAndTumble.pdf"),
+			[]string{"1-8"},
+			"form:A3P, ma:10, rotatecells:tumble",
+			"points",
+			4,
+			false},
+
+		// 4-Up work-and-turn with individually rotated cells
+		{"TestNUpFromPDFRotatedCells",
+			[]string{filepath.Join(inDir, "bookletTest.pdf")},
+			filepath.Join(outDir, "NUpFromPDFRotatedCells.pdf"),
+			[]string{"1-8"},
+			"form:A3P, ma:10, rotatecells:2 4",
+			"points",
+			4,
+			false},
+
 		// 16-Up an image
 		{"TestNUpFromSingleImage",
 			[]string{filepath.Join(resDir, "logoSmall.png")},
@@ -109,3 +132,61 @@ func TestNUp(t *testing.T) {
 		testNUp(t, tt.msg, tt.inFiles, tt.outFile, tt.selectedPages, tt.desc, tt.n, tt.isImg, conf)
 	}
 }
+
+func TestNUpMixedPageSizes(t *testing.T) {
+	msg := "TestNUpMixedPageSizes"
+
+	// Merge pages of differing sizes.
+	inFile := filepath.Join(outDir, "mixedPageSizes.pdf")
+	inFiles := []string{filepath.Join(inDir, "bookletTest.pdf"), filepath.Join(inDir, "bookletTestA6.pdf")}
+	if err := api.MergeCreateFile(inFiles, inFile, false, nil); err != nil {
+		t.Fatalf("%s merge: %v\n", msg, err)
+	}
+
+	for _, fit := range []string{"scale", "center", "clip"} {
+		outFile := filepath.Join(outDir, "NUpFromMixedPageSizes_"+fit+".pdf")
+		desc := "form:A3P, ma:10, trimsize:A5, fit:" + fit
+		testNUp(t, msg, []string{inFile}, outFile, nil, desc, 4, false, model.NewDefaultConfiguration())
+	}
+}
+
+func TestNUpWithPrintersMarks(t *testing.T) {
+	msg := "TestNUpWithPrintersMarks"
+	outDir := filepath.Join(samplesDir, "nup")
+
+	conf := model.NewDefaultConfiguration()
+	nup, err := api.PDFNUpConfig(4, "form:A3P, ma:20, sheetmarks:all", conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Reusing nup for another file must not carry over the slug line of the previous file.
+	for _, fn := range []string{"bookletTest.pdf", "WaldenFull.pdf"} {
+		outFile := filepath.Join(outDir, "NUpWithPrintersMarks_"+fn)
+		if err := api.NUpFile([]string{filepath.Join(inDir, fn)}, outFile, []string{"1-4"}, nup, conf); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		if nup.Marks.FileName != "" {
+			t.Fatalf("%s %s: nup modified, slug file name: %s\n", msg, fn, nup.Marks.FileName)
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		r, err := pdfcpu.ExtractPageContent(ctx, 1)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		bb, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		if !bytes.Contains(bb, []byte("q [] 0 d ")) {
+			t.Fatalf("%s %s: missing printer's marks\n", msg, fn)
+		}
+		if !bytes.Contains(bb, []byte(fn)) {
+			t.Fatalf("%s %s: missing slug line for %s\n", msg, fn, fn)
+		}
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+)
+
+func TestAddOCRText(t *testing.T) {
+	msg := "TestAddOCRText"
+	inFile := filepath.Join(inDir, "mountain.pdf")
+	outFile := filepath.Join(outDir, "mountainSearchable.pdf")
+
+	ocrFile := filepath.Join(outDir, "mountain.hocr")
+	hocr := `<html><body>
+<div class="ocr_page" title="bbox 0 0 1200 800">
+ <span class="ocrx_word" title="bbox 100 100 400 160">Mountain</span>
+ <span class="ocrx_word" title="bbox 450 100 700 160">Lake</span>
+</div>
+</body></html>`
+	if err := os.WriteFile(ocrFile, []byte(hocr), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.AddOCRTextFile(inFile, outFile, []string{ocrFile}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// mountain.pdf has a single page.
+	if err := api.AddOCRTextFile(inFile, outFile, []string{ocrFile, ocrFile}, nil); err == nil {
+		t.Fatalf("%s: more OCR pages than pages should fail\n", msg)
+	}
+}
@@ -17,10 +17,19 @@ limitations under the License.
 package test
 
 import (
+	"image"
+	"image/color"
+	"image/jpeg"
+	"math/rand"
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestOptimize(t *testing.T) {
@@ -41,3 +50,399 @@ func TestOptimize(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func TestOptimizeImages(t *testing.T) {
+	msg := "TestOptimizeImages"
+	fileName := "go-lecture.pdf"
+	inFile := filepath.Join(inDir, fileName)
+	outFile := filepath.Join(outDir, "imagesOptimized.pdf")
+
+	if _, err := pdfcpu.ParseImageOptimization("quality:0"); err == nil {
+		t.Fatalf("%s: want error for quality 0\n", msg)
+	}
+
+	imo, err := pdfcpu.ParseImageOptimization("dpi:72, quality:60, dct:50KB")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ImageOptimization = imo
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	fi1, err := os.Stat(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi2, err := os.Stat(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fi2.Size() >= fi1.Size() {
+		t.Fatalf("%s: want smaller file, got %d >= %d\n", msg, fi2.Size(), fi1.Size())
+	}
+}
+
+func TestOptimizeImagesGrayscale(t *testing.T) {
+	msg := "TestOptimizeImagesGrayscale"
+	inFile := filepath.Join(inDir, "mountain.pdf")
+	outFile := filepath.Join(outDir, "imagesGrayscaled.pdf")
+
+	imo, err := pdfcpu.ParseImageOptimization("dpi:off, gray:on")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ImageOptimization = imo
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+
+	for _, m := range mm {
+		for _, img := range m {
+			if img.Cs != model.DeviceGrayCS {
+				t.Fatalf("%s: want DeviceGray for obj#%d, got %s\n", msg, img.ObjNr, img.Cs)
+			}
+		}
+	}
+}
+
+func TestOptimizeImagesJPX(t *testing.T) {
+	msg := "TestOptimizeImagesJPX"
+	inFile := filepath.Join(inDir, "testImage.pdf")
+	outFile := filepath.Join(outDir, "imagesJPXGrayscaled.pdf")
+
+	imo, err := pdfcpu.ParseImageOptimization("dpi:off, gray:on")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ImageOptimization = imo
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+
+	for _, m := range mm {
+		for _, img := range m {
+			if img.Cs != model.DeviceGrayCS || img.Filter == filter.JPX {
+				t.Fatalf("%s: want re-encoded DeviceGray for obj#%d, got %s %s\n", msg, img.ObjNr, img.Filter, img.Cs)
+			}
+		}
+	}
+}
+
+func TestOptimizeImagesBilevel(t *testing.T) {
+	msg := "TestOptimizeImagesBilevel"
+	imgFile := filepath.Join(outDir, "bilevelScan.png")
+	inFile := filepath.Join(outDir, "bilevelScan.pdf")
+	outFile := filepath.Join(outDir, "bilevelScanOptimized.pdf")
+
+	writeBilevelPage(t, imgFile)
+
+	if err := api.ImportImagesFile([]string{imgFile}, inFile, nil, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+
+	// Replace the imported CCITT image by a flate encoded one.
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+	for _, entry := range ctx.Table {
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.Subtype() == nil || *sd.Subtype() != "Image" {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s decode: %v\n", msg, err)
+		}
+		sd1, err := model.CreateFlateImageStreamDict(ctx.XRefTable, sd.Content, nil, *sd.IntEntry("Width"), *sd.IntEntry("Height"), 1, model.DeviceGrayCS)
+		if err != nil {
+			t.Fatalf("%s flate: %v\n", msg, err)
+		}
+		entry.Object = *sd1
+	}
+	if err := api.WriteContextFile(ctx, inFile); err != nil {
+		t.Fatalf("%s write: %v\n", msg, err)
+	}
+
+	imo, err := pdfcpu.ParseImageOptimization("dpi:72")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ImageOptimization = imo
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	fi1, err := os.Stat(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi2, err := os.Stat(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fi2.Size() >= fi1.Size() {
+		t.Fatalf("%s: want smaller file, got %d >= %d\n", msg, fi2.Size(), fi1.Size())
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+
+	for _, m := range mm {
+		for _, img := range m {
+			if img.Filter != filter.CCITTFax || img.Width != 850 {
+				t.Fatalf("%s: want CCITT image of full width, got %s width=%d\n", msg, img.Filter, img.Width)
+			}
+		}
+	}
+}
+
+func TestOptimizeSubsetFonts(t *testing.T) {
+	msg := "TestOptimizeSubsetFonts"
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile1 := filepath.Join(outDir, "fontsOptimized.pdf")
+	outFile2 := filepath.Join(outDir, "fontsSubset.pdf")
+
+	conf := model.NewDefaultConfiguration()
+	if err := api.OptimizeFile(inFile, outFile1, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	conf = model.NewDefaultConfiguration()
+	conf.SubsetFonts = true
+	if err := api.OptimizeFile(inFile, outFile2, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile2, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	fi1, err := os.Stat(outFile1)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi2, err := os.Stat(outFile2)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if fi2.Size() >= fi1.Size() {
+		t.Fatalf("%s: want %d < %d\n", msg, fi2.Size(), fi1.Size())
+	}
+}
+
+func TestOptimizeObjectStreamModes(t *testing.T) {
+	msg := "TestOptimizeObjectStreamModes"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+
+	for _, tt := range []struct {
+		mode    string
+		packed  bool
+		outFile string
+	}{
+		{"pack", true, "objectStreamsPacked.pdf"},
+		{"expand", false, "objectStreamsExpanded.pdf"},
+	} {
+		m, err := model.ParseObjectStreamMode(tt.mode)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.mode, err)
+		}
+
+		conf := model.NewDefaultConfiguration()
+		conf.ObjectStreamMode = m
+
+		outFile := filepath.Join(outDir, tt.outFile)
+		if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.mode, err)
+		}
+
+		ctx, err := api.ReadContextFile(outFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.mode, err)
+		}
+
+		if ctx.Read.UsingObjectStreams != tt.packed || ctx.Read.UsingXRefStreams != tt.packed {
+			t.Fatalf("%s %s: objectStreams=%t xrefStreams=%t\n", msg, tt.mode, ctx.Read.UsingObjectStreams, ctx.Read.UsingXRefStreams)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: validate: %v\n", msg, tt.mode, err)
+		}
+	}
+
+	if _, err := model.ParseObjectStreamMode("compress"); err == nil {
+		t.Fatalf("%s: want error for invalid mode\n", msg)
+	}
+}
+
+// writeScannedPage writes a JPEG resembling a scanned page of text to fileName.
+func writeScannedPage(t *testing.T, fileName string) {
+	t.Helper()
+
+	w, h := 1275, 1650
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	r := rand.New(rand.NewSource(42))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			v := uint8(225 + (x+y)*20/(w+h) + r.Intn(8))
+			img.Set(x, y, color.RGBA{v, v, v - 12, 0xFF})
+		}
+	}
+
+	// Lines of "words" made up of "glyphs".
+	for y := 120; y < h-120; y += 36 {
+		for x := 100; x < w-100; {
+			n := 2 + r.Intn(8)
+			for i := 0; i < n && x < w-100; i++ {
+				gw, gh := 6+r.Intn(10), 14+r.Intn(10)
+				for y1 := y + 24 - gh; y1 < y+24; y1++ {
+					for x1 := x; x1 < x+gw; x1++ {
+						if (x1-x)%5 != 4 || y1%3 == 0 {
+							v := uint8(20 + r.Intn(30))
+							img.Set(x1, y1, color.RGBA{v, v, v + 30, 0xFF})
+						}
+					}
+				}
+				x += gw + 3
+			}
+			x += 16
+		}
+	}
+
+	f, err := os.Create(fileName)
+	if err != nil {
+		t.Fatalf("create: %v\n", err)
+	}
+	defer f.Close()
+
+	if err := jpeg.Encode(f, img, &jpeg.Options{Quality: 90}); err != nil {
+		t.Fatalf("encode: %v\n", err)
+	}
+}
+
+func TestOptimizeImagesMRC(t *testing.T) {
+	msg := "TestOptimizeImagesMRC"
+	imgFile := filepath.Join(outDir, "scan.jpg")
+	inFile := filepath.Join(outDir, "scan.pdf")
+	outFile := filepath.Join(outDir, "scanMRC.pdf")
+
+	writeScannedPage(t, imgFile)
+
+	if err := api.ImportImagesFile([]string{imgFile}, inFile, nil, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+
+	imo, err := pdfcpu.ParseImageOptimization("dpi:off, mrc:on")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.ImageOptimization = imo
+
+	if err := api.OptimizeFile(inFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	fi1, err := os.Stat(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	fi2, err := os.Stat(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if fi2.Size() >= fi1.Size()/2 {
+		t.Fatalf("%s: want file at least halved, got %d for %d\n", msg, fi2.Size(), fi1.Size())
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatalf("%s open: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	mm, err := api.Images(f, nil, nil)
+	if err != nil {
+		t.Fatalf("%s images: %v\n", msg, err)
+	}
+
+	// Background and soft masked foreground layer.
+	var bg, fg bool
+	for _, m := range mm {
+		for _, img := range m {
+			if img.HasSMask {
+				fg = true
+			} else {
+				bg = true
+			}
+		}
+	}
+	if !bg || !fg {
+		t.Fatalf("%s: want background and foreground layer\n", msg)
+	}
+}
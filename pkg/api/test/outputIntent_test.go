@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestOutputIntents(t *testing.T) {
+	msg := "TestOutputIntents"
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "outputIntents.pdf")
+	profile := filepath.Join(inDir, "icc", "TestCMYK.icc")
+
+	oi, err := pdfcpu.ParseOutputIntent("profile:" + profile + ", info:test")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+	if err := api.AddOutputIntentFile(inFile, outFile, oi, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ois, err := api.OutputIntentsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s output intents: %v\n", msg, err)
+	}
+	if len(ois) != 1 {
+		t.Fatalf("%s: want 1 output intent, got %d\n", msg, len(ois))
+	}
+	if oi := ois[0]; oi.Subtype != "GTS_PDFX" || oi.OutputConditionIdentifier != "pdfcpu test CMYK" || oi.Info != "test" || oi.N != 4 || len(oi.Profile) == 0 {
+		t.Fatalf("%s: got %s\n", msg, oi)
+	}
+
+	// There may be only one output intent per subtype.
+	if err := api.AddOutputIntentFile(outFile, "", oi, nil); err == nil {
+		t.Fatalf("%s: add duplicate subtype should fail\n", msg)
+	}
+
+	// PDF/A requires a profile.
+	oi, err = pdfcpu.ParseOutputIntent("subtype:pdfa, id:sRGB IEC61966-2.1")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+	if err := api.AddOutputIntentFile(outFile, "", oi, nil); err == nil {
+		t.Fatalf("%s: add PDF/A output intent without profile should fail\n", msg)
+	}
+
+	oi, err = pdfcpu.ParseOutputIntent("id:FOGRA39, registry:http://www.color.org")
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+	if err := api.ReplaceOutputIntentFile(outFile, "", oi, nil); err != nil {
+		t.Fatalf("%s replace: %v\n", msg, err)
+	}
+
+	oi, err = pdfcpu.ParseOutputIntent("subtype:pdfa, profile:" + profile)
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+	if err := api.AddOutputIntentFile(outFile, "", oi, nil); err != nil {
+		t.Fatalf("%s add: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ois, err = api.OutputIntentsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s output intents: %v\n", msg, err)
+	}
+	if len(ois) != 2 {
+		t.Fatalf("%s: want 2 output intents, got %d\n", msg, len(ois))
+	}
+	if oi := ois[0]; oi.OutputConditionIdentifier != "FOGRA39" || oi.RegistryName != "http://www.color.org" || oi.Profile != nil {
+		t.Fatalf("%s: got %s\n", msg, oi)
+	}
+	if oi := ois[1]; oi.Subtype != "GTS_PDFA1" || oi.N != 4 {
+		t.Fatalf("%s: got %s\n", msg, oi)
+	}
+
+	ss, err := api.ListOutputIntentsFile(outFile, nil)
+	if err != nil || len(ss) != 2 {
+		t.Fatalf("%s list: %v %v\n", msg, ss, err)
+	}
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestFSInputsMemOutputs(t *testing.T) {
+	msg := "TestFSInputsMemOutputs"
+
+	// Merge two files read from an fs.FS into memory.
+	var merged bytes.Buffer
+	if err := api.MergeFS(os.DirFS(inDir), []string{"Acroforms2.pdf", "adobe_errata.pdf"}, &merged, false, nil); err != nil {
+		t.Fatalf("%s merge: %v\n", msg, err)
+	}
+	rs := bytes.NewReader(merged.Bytes())
+	pageCount, err := api.PageCount(rs, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Split into single page parts kept in memory.
+	m := map[string]*bytes.Buffer{}
+	if err := api.SplitTo(rs, api.MemOutput(m), "merged.pdf", 1, nil); err != nil {
+		t.Fatalf("%s split: %v\n", msg, err)
+	}
+	if len(m) != pageCount {
+		t.Fatalf("%s split: want %d parts, got %d\n", msg, pageCount, len(m))
+	}
+	part := m["merged_1.pdf"]
+	if part == nil {
+		t.Fatalf("%s split: missing merged_1.pdf\n", msg)
+	}
+	if err := api.Validate(bytes.NewReader(part.Bytes()), nil); err != nil {
+		t.Fatalf("%s split: %v\n", msg, err)
+	}
+
+	// Create a PDF from an image read from an fs.FS and extract it again.
+	var buf bytes.Buffer
+	imp := pdfcpu.DefaultImportConfig()
+	if err := api.ImportImagesFS(nil, &buf, os.DirFS(resDir), []string{"mountain.jpg"}, imp, nil); err != nil {
+		t.Fatalf("%s import: %v\n", msg, err)
+	}
+	m = map[string]*bytes.Buffer{}
+	if err := api.ExtractImagesTo(bytes.NewReader(buf.Bytes()), api.MemOutput(m), "mountain.pdf", nil, nil); err != nil {
+		t.Fatalf("%s extract images: %v\n", msg, err)
+	}
+	if len(m) != 1 {
+		t.Fatalf("%s extract images: want 1 image, got %d\n", msg, len(m))
+	}
+
+	// Attach a file read from an fs.FS and extract it into memory.
+	var attached bytes.Buffer
+	if err := api.AddAttachmentsFS(bytes.NewReader(buf.Bytes()), &attached, os.DirFS(resDir), []string{"test.wav"}, false, nil); err != nil {
+		t.Fatalf("%s attach: %v\n", msg, err)
+	}
+	m = map[string]*bytes.Buffer{}
+	if err := api.ExtractAttachmentsTo(bytes.NewReader(attached.Bytes()), api.MemOutput(m), nil, nil); err != nil {
+		t.Fatalf("%s extract attachments: %v\n", msg, err)
+	}
+	want, err := os.ReadFile(filepath.Join(resDir, "test.wav"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if got := m["test.wav"]; got == nil || !bytes.Equal(got.Bytes(), want) {
+		t.Fatalf("%s extract attachments: test.wav mismatch\n", msg)
+	}
+}
+
+func TestReadSeekerFS(t *testing.T) {
+	msg := "TestReadSeekerFS"
+
+	// Seekable files are used as is.
+	rs, err := api.ReadSeekerFS(os.DirFS(inDir), "Acroforms2.pdf")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	f, ok := rs.(*os.File)
+	if !ok {
+		t.Fatalf("%s: want *os.File, got %T\n", msg, rs)
+	}
+	defer f.Close()
+	want, err := io.ReadAll(f)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Zip entries do not support seeking and get read into memory.
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	w, err := zw.Create("Acroforms2.pdf")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, err := w.Write(want); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	rs, err = api.ReadSeekerFS(zr, "Acroforms2.pdf")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if _, ok := rs.(*bytes.Reader); !ok {
+		t.Fatalf("%s: want *bytes.Reader, got %T\n", msg, rs)
+	}
+	if _, err := api.ReadContext(rs, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
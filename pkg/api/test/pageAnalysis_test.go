@@ -0,0 +1,117 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestAnalyzePages(t *testing.T) {
+	msg := "TestAnalyzePages"
+	inFile := filepath.Join(inDir, "Acroforms2.pdf")
+	insertedFile := filepath.Join(outDir, "AnalyzeInserted.pdf")
+	mergedFile := filepath.Join(outDir, "AnalyzeMerged.pdf")
+	outFile := filepath.Join(outDir, "AnalyzeCleaned.pdf")
+
+	pageCount, err := api.PageCountFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// Insert a blank page before page 2 and append a copy of inFile.
+	if err := api.InsertPagesFile(inFile, insertedFile, []string{"2"}, true, nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.MergeCreateFile([]string{insertedFile, inFile}, mergedFile, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	aa, err := api.AnalyzePagesFile(mergedFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(aa) != 2*pageCount+1 {
+		t.Fatalf("%s: want %d pages, got %d\n", msg, 2*pageCount+1, len(aa))
+	}
+
+	var blank, dup int
+	for _, a := range aa {
+		if a.Blank {
+			blank++
+			if a.PageNr != 2 {
+				t.Fatalf("%s: want page 2 blank, got page %d\n", msg, a.PageNr)
+			}
+		}
+		if a.DuplicateOf > 0 {
+			dup++
+		}
+	}
+	if blank != 1 || dup != pageCount {
+		t.Fatalf("%s: want 1 blank and %d duplicates, got %d blank and %d duplicates\n", msg, pageCount, blank, dup)
+	}
+
+	// A blank scan.
+	aa, err = api.AnalyzePagesFile(filepath.Join(inDir, "blank-scan.pdf"), nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !aa[0].Blank {
+		t.Fatalf("%s: want blank scan, got ink coverage %f\n", msg, aa[0].InkCoverage)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	if conf.PageCleanup, err = pdfcpu.ParsePageCleanup("blank,dup"); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.OptimizeFile(mergedFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, err := api.PageCountFile(outFile); err != nil || n != pageCount {
+		t.Fatalf("%s: want %d pages, got %d (%v)\n", msg, pageCount, n, err)
+	}
+
+	// A page with a few specks of ink gets removed using the default blank threshold.
+	speckFile := filepath.Join(outDir, "AnalyzeSpeck.pdf")
+	imp, err := api.Import("form:A4, pos:c, sc:.02 abs", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.ImportImagesFile([]string{filepath.Join(resDir, "qr.png")}, speckFile, imp, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.MergeAppendFile([]string{inFile}, speckFile, false, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	conf = model.NewDefaultConfiguration()
+	conf.PageCleanup = &model.PageCleanup{Blank: true}
+	if err := api.OptimizeFile(speckFile, outFile, conf); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if n, err := api.PageCountFile(outFile); err != nil || n != pageCount {
+		t.Fatalf("%s: want %d pages, got %d (%v)\n", msg, pageCount, n, err)
+	}
+
+	if _, err := pdfcpu.ParsePageCleanup("blank,empty"); err == nil {
+		t.Fatalf("%s: want error for invalid page cleanup\n", msg)
+	}
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+)
+
+func TestPageLabels(t *testing.T) {
+	msg := "TestPageLabels"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "PageLabels.pdf")
+
+	pls, err := pdfcpu.ParsePageLabels("1:r, 5:D, 20:D:A-")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.SetPageLabelsFile(inFile, outFile, pls, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pls, err = api.PageLabelsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(pls) != 3 {
+		t.Fatalf("%s: want 3 page label ranges, got %d\n", msg, len(pls))
+	}
+
+	ss, err := api.ListPageLabelsFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) != 3 || !strings.Contains(ss[0], "i .. iv") || !strings.Contains(ss[2], "A-1 .. A-6") {
+		t.Fatalf("%s: unexpected page labels: %v\n", msg, ss)
+	}
+
+	for _, tt := range []struct {
+		sel, want string
+	}{
+		{"iii", "3"},
+		{"A-2", "21"},
+		{"ii-iv", "2-4"},
+		{"A-1-A-3", "20-22"},
+		{"!iv", "!4"},
+		{"even", "even"},
+	} {
+		got, err := api.PageSelectionForLabelsFile(outFile, []string{tt.sel}, nil)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, tt.sel, err)
+		}
+		if len(got) != 1 || got[0] != tt.want {
+			t.Fatalf("%s %s: want %s, got %v\n", msg, tt.sel, tt.want, got)
+		}
+	}
+
+	if err := api.RemovePageLabelsFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if pls, err = api.PageLabelsFile(outFile, nil); err != nil || len(pls) > 0 {
+		t.Fatalf("%s: page labels should be gone: %v %v\n", msg, pls, err)
+	}
+
+	if err := api.RemovePageLabelsFile(outFile, "", nil); err == nil {
+		t.Fatalf("%s: removing missing page labels should fail\n", msg)
+	}
+}
+
+func TestPageLabelsInvalid(t *testing.T) {
+	msg := "TestPageLabelsInvalid"
+
+	for _, s := range []string{"", "1", "0:D", "x:D", "1:X", "1:D:A-:0"} {
+		if _, err := pdfcpu.ParsePageLabels(s); err == nil {
+			t.Fatalf("%s: %q should fail\n", msg, s)
+		}
+	}
+
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "PageLabelsInvalid.pdf")
+
+	pls, err := pdfcpu.ParsePageLabels("1:D, 99:r")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetPageLabelsFile(inFile, outFile, pls, nil); err == nil {
+		t.Fatalf("%s: page label beyond page count should fail\n", msg)
+	}
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+type pageOutput struct {
+	content []byte
+	images  map[int][]byte
+}
+
+func extractPageOutput(ctx *model.Context, pageNr int) (*pageOutput, error) {
+	po := &pageOutput{images: map[int][]byte{}}
+
+	r, err := pdfcpu.ExtractPageContent(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+	if r != nil {
+		if po.content, err = io.ReadAll(r); err != nil {
+			return nil, err
+		}
+	}
+
+	mm, err := pdfcpu.ExtractPageImages(ctx, pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	for objNr, img := range mm {
+		if img.Reader == nil {
+			continue
+		}
+		if po.images[objNr], err = io.ReadAll(img); err != nil {
+			return nil, err
+		}
+	}
+
+	return po, nil
+}
+
+func TestProcessPages(t *testing.T) {
+	msg := "TestProcessPages"
+
+	for _, fn := range []string{"go.pdf", "testImage.pdf", "TheGoProgrammingLanguageCh1.pdf"} {
+		inFile := filepath.Join(inDir, fn)
+
+		f, err := os.Open(inFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		conf := model.NewDefaultConfiguration()
+		conf.Cmd = model.EXTRACTIMAGES
+		ctx, err := api.ReadValidateAndOptimize(f, conf)
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+
+		// Extract sequentially from a separate snapshot serving as reference.
+		ref, err := ctx.Snapshot()
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		want := map[int]*pageOutput{}
+		for pageNr := 1; pageNr <= ref.PageCount; pageNr++ {
+			if want[pageNr], err = extractPageOutput(ref, pageNr); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, fn, err)
+			}
+		}
+
+		var mu sync.Mutex
+		got := map[int]*pageOutput{}
+		err = api.ProcessPages(ctx, nil, 4, func(ctx *model.Context, pageNr int) error {
+			po, err := extractPageOutput(ctx, pageNr)
+			if err != nil {
+				return err
+			}
+			mu.Lock()
+			got[pageNr] = po
+			mu.Unlock()
+			return nil
+		})
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+
+		if len(got) != ctx.PageCount {
+			t.Fatalf("%s %s: want %d pages, got %d\n", msg, fn, ctx.PageCount, len(got))
+		}
+		for pageNr, po := range want {
+			po1 := got[pageNr]
+			if !bytes.Equal(po.content, po1.content) {
+				t.Fatalf("%s %s: page %d: content mismatch\n", msg, fn, pageNr)
+			}
+			if len(po.images) != len(po1.images) {
+				t.Fatalf("%s %s: page %d: want %d images, got %d\n", msg, fn, pageNr, len(po.images), len(po1.images))
+			}
+			for objNr, bb := range po.images {
+				if !bytes.Equal(bb, po1.images[objNr]) {
+					t.Fatalf("%s %s: page %d: image obj#%d mismatch\n", msg, fn, pageNr, objNr)
+				}
+			}
+		}
+	}
+}
+
+func TestProcessPagesError(t *testing.T) {
+	msg := "TestProcessPagesError"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+
+	ctx, err := api.ReadContextFile(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	errFail := errors.New("fail")
+	err = api.ProcessPages(ctx, nil, 2, func(ctx *model.Context, pageNr int) error {
+		if pageNr == 3 {
+			return errFail
+		}
+		return nil
+	})
+	if errors.Cause(err) != errFail {
+		t.Fatalf("%s: want %v, got %v\n", msg, errFail, err)
+	}
+}
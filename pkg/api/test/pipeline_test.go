@@ -0,0 +1,219 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func readContextWithPW(t *testing.T, inFile, upw string) *model.Context {
+	t.Helper()
+
+	f, err := os.Open(inFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", inFile, err)
+	}
+	defer f.Close()
+
+	conf := model.NewDefaultConfiguration()
+	conf.UserPW = upw
+	ctx, err := api.ReadAndValidate(f, conf)
+	if err != nil {
+		t.Fatalf("%s: %v\n", inFile, err)
+	}
+
+	return ctx
+}
+
+func TestPipeline(t *testing.T) {
+	msg := "TestPipeline"
+
+	dir := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dir, "in"), os.ModePerm); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	fileNames := []string{"TheGoProgrammingLanguageCh1.pdf", "CenterOfWhy.pdf"}
+	pageCounts := map[string]int{}
+	for _, fn := range fileNames {
+		inFile := filepath.Join(inDir, fn)
+		n, err := api.PageCountFile(inFile)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		pageCounts[fn] = n
+		if err := copyFile(t, inFile, filepath.Join(dir, "in", fn)); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+	}
+
+	// Relative paths are resolved against the directory of the job file.
+	jobFile := filepath.Join(dir, "job.yaml")
+	job := `
+input:
+  - in/*.pdf
+output: out
+steps:
+  - op: remove
+    pages: ["1"]
+  - op: stamp
+    options:
+      string: Confidential
+      desc: "scale:.5, rot:45, op:.4"
+  - op: rotate
+    pages: ["1"]
+    options:
+      rotation: 90
+  - op: optimize
+  - op: encrypt
+    options:
+      upw: upw
+      opw: opw
+      perm: print
+`
+	if err := os.WriteFile(jobFile, []byte(job), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	outFiles, err := api.RunPipelineFile(jobFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(outFiles) != len(fileNames) {
+		t.Fatalf("%s: want %d output files, got %d\n", msg, len(fileNames), len(outFiles))
+	}
+
+	for _, fn := range fileNames {
+		outFile := filepath.Join(dir, "out", fn)
+		ctx := readContextWithPW(t, outFile, "upw")
+		if ctx.Encrypt == nil {
+			t.Fatalf("%s %s: want encrypted output\n", msg, fn)
+		}
+		if ctx.PageCount != pageCounts[fn]-1 {
+			t.Fatalf("%s %s: want %d pages, got %d\n", msg, fn, pageCounts[fn]-1, ctx.PageCount)
+		}
+		d, _, _, err := ctx.PageDict(1, false)
+		if err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fn, err)
+		}
+		if rot := d.IntEntry("Rotate"); rot == nil || *rot%360 != 90 {
+			t.Fatalf("%s %s: want page 1 rotated by 90, got %v\n", msg, fn, d["Rotate"])
+		}
+	}
+
+	// Decrypt a single file using a JSON job.
+	inFile := filepath.Join(dir, "out", fileNames[0])
+	outFile := filepath.Join(dir, "plain.pdf")
+	job = `{
+  "input": ["` + filepath.ToSlash(inFile) + `"],
+  "output": "plain.pdf",
+  "steps": [
+    {"op": "decrypt", "options": {"upw": "upw", "opw": "opw"}},
+    {"op": "watermark", "pages": ["2-3"], "options": {"string": "Draft"}}
+  ]
+}`
+	jobFile = filepath.Join(dir, "job.json")
+	if err := os.WriteFile(jobFile, []byte(job), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := api.RunPipelineFile(jobFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx := readContextWithPW(t, outFile, "")
+	if ctx.Encrypt != nil {
+		t.Fatalf("%s: want decrypted output\n", msg)
+	}
+	if ctx.PageCount != pageCounts[fileNames[0]]-1 {
+		t.Fatalf("%s: want %d pages, got %d\n", msg, pageCounts[fileNames[0]]-1, ctx.PageCount)
+	}
+}
+
+func duplicateFonts(t *testing.T, bb []byte) int {
+	t.Helper()
+
+	ctx, err := api.ReadValidateAndOptimize(bytes.NewReader(bb), model.NewDefaultConfiguration())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return len(ctx.Optimize.DuplicateFonts)
+}
+
+func TestPipelineOptimizeAfterRemove(t *testing.T) {
+	msg := "TestPipelineOptimizeAfterRemove"
+
+	// zineTest.pdf contains a duplicate font.
+	bb, err := os.ReadFile(filepath.Join(inDir, "zineTest.pdf"))
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, tt := range []struct {
+		steps          []model.PipelineStep
+		duplicateFonts int
+	}{
+		{[]model.PipelineStep{{Op: "remove", Pages: []string{"1"}}}, 1},
+		{[]model.PipelineStep{{Op: "remove", Pages: []string{"1"}}, {Op: "optimize"}}, 0},
+	} {
+		// Skip optimization while reading, leave it to the optimize step.
+		conf := model.NewDefaultConfiguration()
+		conf.Optimize = false
+
+		var buf bytes.Buffer
+		if err := api.RunPipeline(bytes.NewReader(bb), &buf, &model.Pipeline{Steps: tt.steps}, conf); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		if n := duplicateFonts(t, buf.Bytes()); n != tt.duplicateFonts {
+			t.Fatalf("%s %v: want %d duplicate fonts, got %d\n", msg, tt.steps, tt.duplicateFonts, n)
+		}
+	}
+}
+
+func TestPipelineValidation(t *testing.T) {
+	msg := "TestPipelineValidation"
+
+	for _, p := range []*model.Pipeline{
+		{Steps: nil},
+		{Steps: []model.PipelineStep{{Op: "shuffle"}}},
+		{Steps: []model.PipelineStep{{Op: "rotate", Options: map[string]string{"angle": "90"}}}},
+		{Steps: []model.PipelineStep{{Op: "optimize"}, {Op: "decrypt"}}},
+		{Steps: []model.PipelineStep{{Op: "encrypt", Options: map[string]string{"opw": "opw"}}, {Op: "optimize"}}},
+		{Steps: []model.PipelineStep{{Op: "encrypt"}}},
+		{Steps: []model.PipelineStep{{Op: "remove"}}},
+	} {
+		if err := api.ValidatePipeline(p); err == nil {
+			t.Fatalf("%s: want error for %v\n", msg, p.Steps)
+		}
+	}
+
+	p := &model.Pipeline{Steps: []model.PipelineStep{
+		{Op: "decrypt"},
+		{Op: "remove", Pages: []string{"1"}},
+		{Op: "encrypt", Options: map[string]string{"opw": "opw"}},
+	}}
+	if err := api.ValidatePipeline(p); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
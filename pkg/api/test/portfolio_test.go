@@ -17,10 +17,14 @@ limitations under the License.
 package test
 
 import (
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestPortfolio(t *testing.T) {
@@ -76,3 +80,147 @@ func TestPortfolio(t *testing.T) {
 		t.Fatalf("%s: validate: %v\n", msg, err)
 	}
 }
+
+func TestCreatePortfolio(t *testing.T) {
+	msg := "TestCreatePortfolio"
+
+	// Create a directory tree to be embedded.
+	dir := filepath.Join(outDir, "media")
+	if err := os.MkdirAll(filepath.Join(dir, "audio"), os.ModePerm); err != nil {
+		t.Fatalf("%s mkdir: %v\n", msg, err)
+	}
+	for _, fn := range []string{"mountain.jpg", "audio/test.wav"} {
+		bb, err := os.ReadFile(filepath.Join(inDir, "resources", filepath.Base(fn)))
+		if err != nil {
+			t.Fatalf("%s read %s: %v\n", msg, fn, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, fn), bb, os.ModePerm); err != nil {
+			t.Fatalf("%s write %s: %v\n", msg, fn, err)
+		}
+	}
+
+	// Relative paths are resolved against the directory of the JSON file.
+	absInDir, err := filepath.Abs(inDir)
+	if err != nil {
+		t.Fatalf("%s abs: %v\n", msg, err)
+	}
+
+	json := `{
+		"view": "tile",
+		"initial": "docs/go.pdf",
+		"sort": {"field": "Project", "ascending": true},
+		"fields": [
+			{"key": "FileName", "name": "Name", "type": "filename", "order": 1},
+			{"key": "Project", "name": "Project", "type": "text", "order": 2},
+			{"key": "Due", "name": "Due", "type": "date", "order": 3},
+			{"key": "Size", "name": "Size", "type": "size", "order": 4, "hidden": true}
+		],
+		"files": [
+			{"path": "` + filepath.ToSlash(filepath.Join(absInDir, "go.pdf")) + `", "folder": "docs", "description": "Go", "values": {"Project": "Alpha", "Due": "2025-06-30"}},
+			{"path": "` + filepath.ToSlash(filepath.Join(absInDir, "golang.pdf")) + `", "values": {"Project": "Beta"}},
+			{"path": "media"}
+		]
+	}`
+
+	inFileJSON := filepath.Join(outDir, "portfolio.json")
+	if err := os.WriteFile(inFileJSON, []byte(json), os.ModePerm); err != nil {
+		t.Fatalf("%s write json: %v\n", msg, err)
+	}
+
+	outFile := filepath.Join(outDir, "portfolio.pdf")
+	if err := api.CreatePortfolioFile(inFileJSON, outFile, nil); err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+
+	if ctx.PageCount != 1 {
+		t.Fatalf("%s: cover sheet: want 1 page, got %d\n", msg, ctx.PageCount)
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		t.Fatalf("%s catalog: %v\n", msg, err)
+	}
+
+	d, err := ctx.DereferenceDict(rootDict["Collection"])
+	if err != nil || d == nil {
+		t.Fatalf("%s: missing collection: %v\n", msg, err)
+	}
+
+	if v := d.NameEntry("View"); v == nil || *v != "T" {
+		t.Fatalf("%s: want view T, got %v\n", msg, v)
+	}
+
+	if s, err := types.StringOrHexLiteral(d["D"]); err != nil || *s != "<1>go.pdf" {
+		t.Fatalf("%s: want initial <1>go.pdf, got %v %v\n", msg, s, err)
+	}
+
+	sortDict := d.DictEntry("Sort")
+	if sortDict == nil || *sortDict.NameEntry("S") != "Project" || !*sortDict.BooleanEntry("A") {
+		t.Fatalf("%s: invalid sort: %v\n", msg, sortDict)
+	}
+
+	// Collect the top level folders.
+	rootFolder, err := ctx.DereferenceDict(d["Folders"])
+	if err != nil || rootFolder == nil {
+		t.Fatalf("%s: missing root folder: %v\n", msg, err)
+	}
+	folders := []string{}
+	for ir := rootFolder.IndirectRefEntry("Child"); ir != nil; {
+		fd, err := ctx.DereferenceDict(*ir)
+		if err != nil {
+			t.Fatalf("%s folder: %v\n", msg, err)
+		}
+		s, _ := types.StringOrHexLiteral(fd["Name"])
+		folders = append(folders, *s)
+		ir = fd.IndirectRefEntry("Next")
+	}
+	if got := strings.Join(folders, ","); got != "docs,media" {
+		t.Fatalf("%s: folders: want docs,media, got %s\n", msg, got)
+	}
+
+	ctx.XRefTable.Conf.Cmd = model.LISTATTACHMENTS
+	aa, err := ctx.ListAttachments()
+	if err != nil {
+		t.Fatalf("%s list attachments: %v\n", msg, err)
+	}
+
+	keys := []string{}
+	for _, a := range aa {
+		keys = append(keys, a.ID)
+	}
+	want := "<1>go.pdf,<2>mountain.jpg,<3>test.wav,golang.pdf"
+	if got := strings.Join(keys, ","); got != want {
+		t.Fatalf("%s: attachments: want %s, got %s\n", msg, want, got)
+	}
+
+	// Use an existing PDF as cover sheet.
+	f, err := os.Open(filepath.Join(inDir, "Acroforms2.pdf"))
+	if err != nil {
+		t.Fatalf("%s open cover: %v\n", msg, err)
+	}
+	defer f.Close()
+
+	p := &model.Portfolio{Files: []model.PortfolioFile{{Path: filepath.Join(inDir, "go.pdf")}}}
+	outFile = filepath.Join(outDir, "portfolioCover.pdf")
+	w, err := os.Create(outFile)
+	if err != nil {
+		t.Fatalf("%s create: %v\n", msg, err)
+	}
+	if err := api.CreatePortfolio(f, w, p, nil); err != nil {
+		t.Fatalf("%s create with cover: %v\n", msg, err)
+	}
+	w.Close()
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate with cover: %v\n", msg, err)
+	}
+}
@@ -17,11 +17,13 @@ limitations under the License.
 package test
 
 import (
+	"math"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -138,3 +140,113 @@ func TestResizeToDimensions(t *testing.T) {
 		t.Fatalf("%s resize: %v\n", msg, err)
 	}
 }
+
+func annotationRects(t *testing.T, fileName string, pageNr int) []types.Rectangle {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("annotationRects: %v\n", err)
+	}
+
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		t.Fatalf("annotationRects: %v\n", err)
+	}
+
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil {
+		t.Fatalf("annotationRects: %v\n", err)
+	}
+
+	var rr []types.Rectangle
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("annotationRects: %v\n", err)
+		}
+		a1, err := ctx.DereferenceArray(d1["Rect"])
+		if err != nil {
+			t.Fatalf("annotationRects: %v\n", err)
+		}
+		r, err := ctx.RectForArray(a1)
+		if err != nil {
+			t.Fatalf("annotationRects: %v\n", err)
+		}
+		rr = append(rr, *r)
+	}
+
+	return rr
+}
+
+func TestResizePages(t *testing.T) {
+	msg := "TestResizePages"
+
+	inFile := filepath.Join(outDir, "ResizePagesIn.pdf")
+	outFile := filepath.Join(outDir, "ResizePages.pdf")
+
+	if err := api.AddAnnotationsFile(filepath.Join(inDir, "test.pdf"), inFile, []string{"1"}, squareAnn, nil, false); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	mb, cb := pageBoxes(t, inFile, 1)
+	if cb == nil {
+		cb = mb
+	}
+	ar := annotationRects(t, inFile, 1)[0]
+
+	letter := types.PaperSize["Letter"]
+
+	for _, fit := range []model.ResizeFit{model.ResizeFitContain, model.ResizeFitCover, model.ResizeFitStretch} {
+		if err := api.ResizePagesFile(inFile, outFile, nil, *letter, fit, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fit, err)
+		}
+
+		if err := api.ValidateFile(outFile, nil); err != nil {
+			t.Fatalf("%s %s: %v\n", msg, fit, err)
+		}
+
+		w, h := letter.Width, letter.Height
+		if cb.Landscape() {
+			w, h = h, w
+		}
+
+		mb1, _ := pageBoxes(t, outFile, 1)
+		if want := types.RectForDim(w, h); !roundRect(mb1).Equals(*roundRect(want)) {
+			t.Fatalf("%s %s: media box: want %v, got %v\n", msg, fit, want, mb1)
+		}
+
+		sx, sy := w/cb.Width(), h/cb.Height()
+		switch fit {
+		case model.ResizeFitContain:
+			sx = math.Min(sx, sy)
+			sy = sx
+		case model.ResizeFitCover:
+			sx = math.Max(sx, sy)
+			sy = sx
+		}
+		dx, dy := (w-sx*cb.Width())/2, (h-sy*cb.Height())/2
+
+		want := types.NewRectangle(
+			dx+sx*(ar.LL.X-cb.LL.X), dy+sy*(ar.LL.Y-cb.LL.Y),
+			dx+sx*(ar.UR.X-cb.LL.X), dy+sy*(ar.UR.Y-cb.LL.Y))
+
+		if got := annotationRects(t, outFile, 1)[0]; !roundRect(&got).Equals(*roundRect(want)) {
+			t.Fatalf("%s %s: annotation rect: want %v, got %v\n", msg, fit, want, got)
+		}
+	}
+
+	res, err := pdfcpu.ParseResizeConfig("form:Letter, fit:cover", types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if res.Fit == nil || *res.Fit != model.ResizeFitCover {
+		t.Fatalf("%s: want fit cover, got %v\n", msg, res.Fit)
+	}
+
+	for _, desc := range []string{"fit:contain", "sc:2, fit:cover", "form:A4, enforce:on, fit:contain", "dim:400 0, fit:stretch"} {
+		if _, err := pdfcpu.ParseResizeConfig(desc, types.POINTS); err == nil {
+			t.Fatalf("%s %q: want error\n", msg, desc)
+		}
+	}
+}
@@ -17,10 +17,12 @@ limitations under the License.
 package test
 
 import (
+	"os"
 	"path/filepath"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 )
 
 func TestRotate(t *testing.T) {
@@ -41,3 +43,56 @@ func TestRotate(t *testing.T) {
 		t.Fatalf("%s: %v\n", msg, err)
 	}
 }
+
+func textOrientations(t *testing.T, fileName string) []pdfcpu.TextOrientation {
+	t.Helper()
+
+	f, err := os.Open(fileName)
+	if err != nil {
+		t.Fatalf("textOrientations: %v\n", err)
+	}
+	defer f.Close()
+
+	tt, err := api.TextOrientations(f, nil, nil)
+	if err != nil {
+		t.Fatalf("textOrientations: %v\n", err)
+	}
+
+	return tt
+}
+
+func TestAutoRotate(t *testing.T) {
+	msg := "TestAutoRotate"
+	inFile := filepath.Join(inDir, "TheGoProgrammingLanguageCh1.pdf")
+	outFile := filepath.Join(outDir, "AutoRotate.pdf")
+
+	// Text pages are upright.
+	for _, to := range textOrientations(t, inFile) {
+		if to.Detected && to.NeedsRotation() {
+			t.Fatalf("%s: want upright page, got %s\n", msg, to)
+		}
+	}
+
+	// Turn some pages sideways or upside down.
+	if err := api.RotateFile(inFile, outFile, 90, []string{"2"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.RotateFile(outFile, "", 180, []string{"3"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	tt := textOrientations(t, outFile)
+	if !tt[1].NeedsRotation() || tt[1].Current != 90 || !tt[2].NeedsRotation() || tt[2].Current != 180 {
+		t.Fatalf("%s: want pages 2,3 detected as rotated, got %s, %s\n", msg, tt[1], tt[2])
+	}
+
+	if err := api.AutoRotateFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	for _, to := range textOrientations(t, outFile) {
+		if to.NeedsRotation() {
+			t.Fatalf("%s: want upright page, got %s\n", msg, to)
+		}
+	}
+}
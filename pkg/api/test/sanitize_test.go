@@ -0,0 +1,123 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// riskyFile writes a copy of inFile with a hidden layer, an attachment, a JavaScript and a launch action to outFile.
+func riskyFile(t *testing.T, inFile, outFile string) {
+	t.Helper()
+
+	if err := api.SetLayerVisibilityFile(inFile, outFile, []string{"Watermark"}, false, nil); err != nil {
+		t.Fatalf("hide layer: %v\n", err)
+	}
+
+	if err := api.AddAttachmentsFile(outFile, "", []string{filepath.Join(resDir, "logo.svg")}, false, nil); err != nil {
+		t.Fatalf("add attachment: %v\n", err)
+	}
+
+	f, err := os.Open(outFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, err := api.ReadAndValidate(f, nil)
+	f.Close()
+	if err != nil {
+		t.Fatalf("read %s: %v\n", outFile, err)
+	}
+
+	ctx.RootDict.Insert("OpenAction", types.Dict(map[string]types.Object{
+		"S":  types.Name("JavaScript"),
+		"JS": types.StringLiteral("app.alert('pdfcpu');"),
+	}))
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.Insert("AA", types.Dict(map[string]types.Object{
+		"O": types.Dict(map[string]types.Object{
+			"S": types.Name("Launch"),
+			"F": types.StringLiteral("calc.exe"),
+		}),
+	}))
+
+	if err := api.WriteContextFile(ctx, outFile); err != nil {
+		t.Fatalf("write %s: %v\n", outFile, err)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	msg := "TestSanitize"
+
+	// A file with a review annotation and an incremental update.
+	inFile := filepath.Join(inDir, "testWithText.pdf")
+	outFile := filepath.Join(outDir, "sanitizedText.pdf")
+
+	r, err := api.SanitizeFile(inFile, outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r.Annotations != 1 || r.Revisions != 1 || !r.Info {
+		t.Fatalf("%s: unexpected report %v\n", msg, r.List())
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	// A file with a hidden layer, an attachment, a JavaScript and a launch action.
+	inFile = filepath.Join(outDir, "risky.pdf")
+	riskyFile(t, filepath.Join(inDir, "bookletTestA6.pdf"), inFile)
+	outFile = filepath.Join(outDir, "sanitized.pdf")
+
+	if r, err = api.SanitizeFile(inFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if r.JavaScript != 1 || r.LaunchActions != 1 || r.EmbeddedFiles != 1 || len(r.HiddenLayers) != 1 || r.HiddenLayers[0] != "Watermark" {
+		t.Fatalf("%s: unexpected report %v\n", msg, r.List())
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: read %s: %v\n", msg, outFile, err)
+	}
+	if _, found := ctx.RootDict.Find("OpenAction"); found {
+		t.Fatalf("%s: JavaScript action survived\n", msg)
+	}
+	if _, found := ctx.RootDict.Find("OCProperties"); found {
+		t.Fatalf("%s: layers survived\n", msg)
+	}
+	if _, found := ctx.RootDict.Find("Names"); found {
+		t.Fatalf("%s: attachments survived\n", msg)
+	}
+
+	// Nothing left to remove except for the minimal info dict written by pdfcpu.
+	if r, err = api.SanitizeFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	r.Info = false
+	if !r.Empty() {
+		t.Fatalf("%s: unexpected report %v\n", msg, r.List())
+	}
+}
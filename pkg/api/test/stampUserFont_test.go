@@ -19,9 +19,12 @@ package test
 import (
 	"fmt"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
 func TestStampUserFont(t *testing.T) {
@@ -45,3 +48,64 @@ func TestStampUserFont(t *testing.T) {
 		}
 	}
 }
+
+func TestStampUserFontFallback(t *testing.T) {
+	msg := "TestStampUserFontFallback"
+	inFile := filepath.Join(inDir, "mountain.pdf")
+	outFile := filepath.Join(outDir, "stampUserFontFallback.pdf")
+
+	// Roboto-Regular does not cover CJK, UnifontMedium takes over.
+	conf := model.NewDefaultConfiguration()
+	conf.FallbackFonts = []string{"UnifontMedium"}
+
+	desc := "font:Roboto-Regular, scale:1.0 rel, rot:0, fillc:#000000"
+	if err := api.AddTextWatermarksFile(inFile, outFile, nil, true, "Hello 世界 world", desc, conf); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	var found bool
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok || sd.Subtype() == nil || *sd.Subtype() != "Form" {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		content := string(sd.Content)
+		if !strings.Contains(content, "/UnifontMedium ") {
+			continue
+		}
+		found = true
+		if !strings.Contains(content, "/F1 ") {
+			t.Fatalf("%s: primary font not restored: %s\n", msg, content)
+		}
+		d, err := ctx.DereferenceDict(sd.Dict["Resources"])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		fontDict, err := ctx.DereferenceDict(d["Font"])
+		if err != nil {
+			t.Fatalf("%s: %v\n", msg, err)
+		}
+		for _, id := range []string{"F1", "UnifontMedium"} {
+			if _, ok := fontDict.Find(id); !ok {
+				t.Fatalf("%s: missing font resource %s\n", msg, id)
+			}
+		}
+	}
+	if !found {
+		t.Fatalf("%s: no font switch to fallback font\n", msg)
+	}
+}
@@ -18,7 +18,9 @@ package test
 
 import (
 	"fmt"
+	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/pdfcpu/pdfcpu/pkg/api"
@@ -468,6 +470,42 @@ func TestAddWatermarks(t *testing.T) {
 	}
 }
 
+func TestAddQRCodeWatermarks(t *testing.T) {
+	msg := "TestAddQRCodeWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+
+	for _, tt := range []struct {
+		outFile string
+		text    string
+		wmConf  string
+	}{
+		{"QRCodeDefaults.pdf", "https://pdfcpu.io", ""},
+		{"QRCodePerPage.pdf", "https://pdfcpu.io/doc/4711?page=%p&pages=%P", "pos:br, off:-20 20, size:72, err:H"},
+		{"QRCodeColors.pdf", "pdfcpu %v", "pos:tl, scale:.2, fillc:#00008B, bgcol:#FFFFE0, rot:45, op:.8"},
+	} {
+		for _, onTop := range []bool{false, true} {
+			outFile := filepath.Join(outDir, tt.outFile)
+			if err := api.AddQRCodeWatermarksFile(inFile, outFile, nil, onTop, tt.text, tt.wmConf, nil); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, outFile, err)
+			}
+			if err := api.ValidateFile(outFile, nil); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if !hasWatermarks(outFile, t) {
+				t.Fatalf("%s: %s has no watermarks\n", msg, outFile)
+			}
+		}
+	}
+
+	if _, err := api.QRCodeWatermark("", "", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: missing payload should fail\n", msg)
+	}
+
+	if _, err := api.TextWatermark("text", "err:H", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: error correction for text should fail\n", msg)
+	}
+}
+
 func TestAddStampWithLink(t *testing.T) {
 	for _, tt := range []struct {
 		msg             string
@@ -612,6 +650,52 @@ func TestStampingLifecycle(t *testing.T) {
 	}
 }
 
+func TestRemoveDetectedWatermarks(t *testing.T) {
+	msg := "TestRemoveDetectedWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	jsonFile := filepath.Join(outDir, "draft.json")
+	outFile := filepath.Join(outDir, "draft.pdf")
+
+	// Simulate a third party watermark: a rotated DRAFT overlay on each page.
+	text := `{"value": "DRAFT", "anchor": "center", "rot": 45, "font": {"name": "Helvetica", "size": 48, "col": "#FF0000"}}`
+	json := fmt.Sprintf(`{"pages": {"1": {"content": {"text": [%s]}}, "2": {"content": {"text": [%s]}}}}`, text, text)
+	if err := os.WriteFile(jsonFile, []byte(json), 0644); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.CreateFile(inFile, jsonFile, outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	// A single page does not qualify as repeated overlay.
+	if err := api.RemoveDetectedWatermarksFile(outFile, filepath.Join(outDir, "draft1.pdf"), []string{"1"}, nil); err == nil {
+		t.Fatalf("%s: single page removal should fail\n", msg)
+	}
+
+	if err := api.RemoveDetectedWatermarksFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.RemoveDetectedWatermarksFile(outFile, "", nil, nil); err == nil {
+		t.Fatalf("%s: watermarks found after removal\n", msg)
+	}
+
+	// Stamps added by pdfcpu are detected too.
+	wm, err := api.TextWatermark("Demo", "", true, false, types.POINTS)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.AddWatermarksFile(inFile, outFile, []string{"1"}, wm, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.RemoveDetectedWatermarksFile(outFile, "", nil, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+}
+
 func TestRecycleWM(t *testing.T) {
 	msg := "TestRecycleWM"
 	inFile := filepath.Join(inDir, "test.pdf")
@@ -637,3 +721,180 @@ func TestRecycleWM(t *testing.T) {
 		t.Fatalf("%s %s: %v\n", msg, outFile, err)
 	}
 }
+
+func TestAddBarcodeWatermarks(t *testing.T) {
+	msg := "TestAddBarcodeWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+
+	for _, tt := range []struct {
+		outFile string
+		text    string
+		wmConf  string
+	}{
+		{"BarcodeCode128.pdf", "TRK-4711-%p", "pos:bl, off:20 20, size:200, height:40"},
+		{"BarcodeEAN13.pdf", "400638133393", "sym:ean13, pos:br, scale:.3"},
+		{"BarcodeCode39.pdf", "PDFCPU-%p", "sym:code39, pos:tc, fillc:#00008B, bgcol:#FFFFE0, rot:90"},
+	} {
+		for _, onTop := range []bool{false, true} {
+			outFile := filepath.Join(outDir, tt.outFile)
+			if err := api.AddBarcodeWatermarksFile(inFile, outFile, nil, onTop, tt.text, tt.wmConf, nil); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, outFile, err)
+			}
+			if err := api.ValidateFile(outFile, nil); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if !hasWatermarks(outFile, t) {
+				t.Fatalf("%s: %s has no watermarks\n", msg, outFile)
+			}
+		}
+	}
+
+	if _, err := api.BarcodeWatermark("12345", "sym:ean13", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: invalid EAN-13 payload should fail\n", msg)
+	}
+
+	if _, err := api.TextWatermark("text", "sym:code39", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: symbology for text should fail\n", msg)
+	}
+}
+
+func TestAddRichTextWatermarks(t *testing.T) {
+	msg := "TestAddRichTextWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+
+	for _, tt := range []struct {
+		outFile string
+		text    string
+		wmConf  string
+	}{
+		{"RichTextHeader.pdf",
+			"<b>CONFIDENTIAL</b>\\nCase No. <color=#C00000>2025-CV-4711</color>\\n<i><size=10>Page %p of %P</size></i>",
+			"pos:tc, off:0 -20, scale:1 abs, points:14, aligntext:c, leading:1.2, rot:0, fillc:#000000, bgcol:#F0F0F0, ma:5, bo:1 #000000"},
+		{"RichTextBanner.pdf",
+			"<size=36><b>DRAFT</b></size>\\n<i>not for distribution</i>",
+			"font:Times-Roman, aligntext:r, op:.6"},
+		{"RichTextLeading.pdf",
+			"Line 1\\nLine 2\\nLine 3",
+			"pos:bl, off:20 20, scale:1 abs, points:10, leading:2, rot:0"},
+	} {
+		for _, onTop := range []bool{false, true} {
+			outFile := filepath.Join(outDir, tt.outFile)
+			if err := api.AddTextWatermarksFile(inFile, outFile, nil, onTop, tt.text, tt.wmConf, nil); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, outFile, err)
+			}
+			if err := api.ValidateFile(outFile, nil); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if !hasWatermarks(outFile, t) {
+				t.Fatalf("%s: %s has no watermarks\n", msg, outFile)
+			}
+		}
+	}
+
+	for _, text := range []string{"<b>bold", "bold</b>", "<size=0>x</size>", "<color=#XYZ>x</color>"} {
+		if _, err := api.TextWatermark(text, "", true, false, types.POINTS); err == nil {
+			t.Fatalf("%s: invalid markup %q should fail\n", msg, text)
+		}
+	}
+
+	if _, err := api.TextWatermark("text", "leading:1.5", true, false, types.POINTS); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if _, err := api.QRCodeWatermark("text", "leading:1.5", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: leading for QR codes should fail\n", msg)
+	}
+}
+
+func verticalFontDicts(t *testing.T, fileName string) int {
+	t.Helper()
+
+	ctx, err := api.ReadContextFile(fileName)
+	if err != nil {
+		t.Fatalf("%s: %v\n", fileName, err)
+	}
+
+	var count int
+	for _, entry := range ctx.Table {
+		d, ok := entry.Object.(types.Dict)
+		if !ok || d.NameEntry("Encoding") == nil || *d.NameEntry("Encoding") != "Identity-V" {
+			continue
+		}
+		a, err := ctx.DereferenceArray(d["DescendantFonts"])
+		if err != nil || len(a) != 1 {
+			t.Fatalf("%s: missing descendant font\n", fileName)
+		}
+		cidFont, err := ctx.DereferenceDict(a[0])
+		if err != nil {
+			t.Fatalf("%s: %v\n", fileName, err)
+		}
+		if *cidFont.NameEntry("Subtype") != "CIDFontType2" || cidFont["W2"] == nil || cidFont["DW2"] == nil {
+			t.Fatalf("%s: descendant font without vertical metrics: %s\n", fileName, cidFont)
+		}
+		count++
+	}
+
+	return count
+}
+
+func TestAddVerticalTextWatermarks(t *testing.T) {
+	msg := "TestAddVerticalTextWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	outFile := filepath.Join(outDir, "VerticalTextStamp.pdf")
+
+	text := "縦書きのスタンプ\\n%p / %P"
+	wmConf := "font:Unifont-JPMedium, vertical:on, pos:r, off:-20 0, scale:1 abs, points:24, rot:0, fillc:#C00000, bgcol:#F0F0F0, ma:5, bo:1 #C00000"
+
+	if err := api.AddTextWatermarksFile(inFile, outFile, nil, true, text, wmConf, nil); err != nil {
+		t.Fatalf("%s %s: %v\n", msg, outFile, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if !hasWatermarks(outFile, t) {
+		t.Fatalf("%s: %s has no watermarks\n", msg, outFile)
+	}
+	if verticalFontDicts(t, outFile) == 0 {
+		t.Fatalf("%s: %s has no Identity-V font\n", msg, outFile)
+	}
+
+	if _, err := api.TextWatermark("text", "vertical:on", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: vertical writing using a core font should fail\n", msg)
+	}
+}
+
+func TestAddSVGWatermarks(t *testing.T) {
+	msg := "TestAddSVGWatermarks"
+	inFile := filepath.Join(inDir, "Walden.pdf")
+	svgFile := filepath.Join(resDir, "logo.svg")
+
+	for _, tt := range []struct {
+		outFile string
+		wmConf  string
+	}{
+		{"SVGDefaults.pdf", ""},
+		{"SVGPosTopRight.pdf", "pos:tr, off:-20 -20, scale:.3, rot:0"},
+		{"SVGAbsScaling.pdf", "scale:1 abs, rot:30, op:.5"},
+	} {
+		for _, onTop := range []bool{false, true} {
+			outFile := filepath.Join(outDir, tt.outFile)
+			if err := api.AddSVGWatermarksFile(inFile, outFile, nil, onTop, svgFile, tt.wmConf, nil); err != nil {
+				t.Fatalf("%s %s: %v\n", msg, outFile, err)
+			}
+			if err := api.ValidateFile(outFile, nil); err != nil {
+				t.Fatalf("%s: %v\n", msg, err)
+			}
+			if !hasWatermarks(outFile, t) {
+				t.Fatalf("%s: %s has no watermarks\n", msg, outFile)
+			}
+		}
+	}
+
+	if _, err := api.SVGWatermark(filepath.Join(resDir, "logoSmall.png"), "", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: non SVG file should fail\n", msg)
+	}
+
+	if _, err := api.SVGWatermarkForReader(strings.NewReader("<svg/>"), "", true, false, types.POINTS); err == nil {
+		t.Fatalf("%s: SVG without dimensions should fail\n", msg)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestAddTOC(t *testing.T) {
+	msg := "TestAddTOC"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	bmFile := filepath.Join(outDir, "tocBookmarks.pdf")
+	outFile := filepath.Join(outDir, "toc.pdf")
+
+	if err := api.AddDetectedBookmarksFile(inFile, bmFile, true, nil); err != nil {
+		t.Fatalf("%s add bookmarks: %v\n", msg, err)
+	}
+
+	pageCount, err := api.PageCountFile(bmFile)
+	if err != nil {
+		t.Fatalf("%s page count: %v\n", msg, err)
+	}
+
+	toc, err := pdfcpu.ParseTOCConfig("page:2, margin:36", nil)
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	if err := api.AddTOCFile(bmFile, outFile, toc, nil); err != nil {
+		t.Fatalf("%s add toc: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	n := ctx.PageCount - pageCount
+	if n < 1 {
+		t.Fatalf("%s: missing toc pages\n", msg)
+	}
+
+	// The first entry links to the first page, the second entry to the former page 2.
+	pageDict, _, _, err := ctx.PageDict(2, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || len(annots) < 2 {
+		t.Fatalf("%s: missing toc links: %v\n", msg, err)
+	}
+	for i, want := range []int{1, 2 + n} {
+		d, err := ctx.DereferenceDict(annots[i])
+		if err != nil {
+			t.Fatalf("%s annot: %v\n", msg, err)
+		}
+		arr, err := ctx.DereferenceArray(d["Dest"])
+		if err != nil || len(arr) == 0 {
+			t.Fatalf("%s: missing dest: %v\n", msg, err)
+		}
+		pageNr, err := ctx.PageNumber(arr[0].(types.IndirectRef).ObjectNumber.Value())
+		if err != nil {
+			t.Fatalf("%s page number: %v\n", msg, err)
+		}
+		if pageNr != want {
+			t.Fatalf("%s: link %d: want page %d, got %d\n", msg, i, want, pageNr)
+		}
+	}
+
+	// TOC pages are labeled i, ii.. and all other pages keep their page numbers.
+	labels, err := pdfcpu.PageLabelStrings(ctx)
+	if err != nil {
+		t.Fatalf("%s labels: %v\n", msg, err)
+	}
+	if labels[0] != "1" || labels[1] != "i" || labels[n+1] != "2" || labels[len(labels)-1] != "41" {
+		t.Fatalf("%s: unexpected page labels: %v\n", msg, labels)
+	}
+}
+
+func TestAddTaggedTOC(t *testing.T) {
+	msg := "TestAddTaggedTOC"
+	inFile := filepath.Join(inDir, "go-lecture.pdf")
+	bmFile := filepath.Join(outDir, "tocTaggedBookmarks.pdf")
+	outFile := filepath.Join(outDir, "tocTagged.pdf")
+
+	if err := api.AddDetectedBookmarksFile(inFile, bmFile, true, nil); err != nil {
+		t.Fatalf("%s add bookmarks: %v\n", msg, err)
+	}
+
+	conf := model.NewDefaultConfiguration()
+	conf.TagContent = true
+
+	toc, err := pdfcpu.ParseTOCConfig("", conf)
+	if err != nil {
+		t.Fatalf("%s parse: %v\n", msg, err)
+	}
+
+	if err := api.AddTOCFile(bmFile, outFile, toc, conf); err != nil {
+		t.Fatalf("%s add toc: %v\n", msg, err)
+	}
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s validate: %v\n", msg, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read: %v\n", msg, err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatalf("%s page dict: %v\n", msg, err)
+	}
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || len(annots) == 0 {
+		t.Fatalf("%s: missing toc links: %v\n", msg, err)
+	}
+
+	// Each link annotation belongs to a Link structure element.
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			t.Fatalf("%s annot: %v\n", msg, err)
+		}
+		if d.IntEntry("StructParent") == nil {
+			t.Fatalf("%s: link annotation without StructParent\n", msg)
+		}
+	}
+
+	// The title plus one marked content sequence per entry.
+	mcid, err := ctx.NextMCID(pageDict)
+	if err != nil {
+		t.Fatalf("%s next mcid: %v\n", msg, err)
+	}
+	if mcid != len(annots)+1 {
+		t.Fatalf("%s: want %d MCIDs, got %d\n", msg, len(annots)+1, mcid)
+	}
+}
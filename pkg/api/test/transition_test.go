@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestPageTransitions(t *testing.T) {
+	msg := "TestPageTransitions"
+	inFile := filepath.Join(inDir, "CenterOfWhy.pdf")
+	outFile := filepath.Join(outDir, "PageTransitions.pdf")
+
+	tr, err := pdfcpu.ParsePageTransition("style:dissolve, dur:0.5, adv:5")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetPageTransitionsFile(inFile, outFile, nil, *tr, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	tr, err = pdfcpu.ParsePageTransition("sty:fly, mot:o, dir:none, sc:0.5, op:on")
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if err := api.SetPageTransitionsFile(outFile, "", []string{"2"}, *tr, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	m, err := api.PageTransitionsFile(outFile, nil, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	want := model.PageTransition{Style: "Fly", Motion: "O", Direction: model.TransitionDirectionNone, Scale: 0.5, Opaque: true, AutoAdvance: 5}
+	if m[2] != want {
+		t.Fatalf("%s: page 2: want %v, got %v\n", msg, want, m[2])
+	}
+	if m[1].Style != "Dissolve" || m[1].Duration != 0.5 || m[1].AutoAdvance != 5 {
+		t.Fatalf("%s: page 1: unexpected transition %v\n", msg, m[1])
+	}
+
+	if err := api.RemovePageTransitionsFile(outFile, "", []string{"1"}, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	ss, err := api.ListPageTransitionsFile(outFile, []string{"1-2"}, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if len(ss) != 1 {
+		t.Fatalf("%s: want 1 page transition, got %v\n", msg, ss)
+	}
+
+	// Full screen mode for presentations.
+	if err := api.SetFullScreenFile(outFile, "", nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	pm, err := api.PageModeFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if pm == nil || *pm != model.PageModeFullScreen {
+		t.Fatalf("%s: want page mode FullScreen, got %v\n", msg, pm)
+	}
+
+	vp, err := api.ViewerPreferencesFile(outFile, false, nil)
+	if err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+	if vp == nil || vp.NonFullScreenPageMode == nil {
+		t.Fatalf("%s: missing NonFullScreenPageMode\n", msg)
+	}
+
+	for _, s := range []string{
+		"",
+		"dur:2",
+		"style:wipe, dim:h",
+		"style:glitter, dir:90",
+		"style:box, dir:none",
+		"style:split, scale:2",
+		"style:spin",
+		"di:90",
+	} {
+		if _, err := pdfcpu.ParsePageTransition(s); err == nil {
+			t.Fatalf("%s: %q: expected error\n", msg, s)
+		}
+	}
+}
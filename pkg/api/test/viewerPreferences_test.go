@@ -77,4 +77,24 @@ func TestViewerPreferences(t *testing.T) {
 	if err := api.SetViewerPreferencesFileFromJSONBytes(inFile, "", []byte(stringJSON), nil); err != nil {
 		t.Fatalf("%s %s: set via JSON string: %v\n", msg, inFile, err)
 	}
+
+	// Page layout and page mode are catalog entries.
+	stringJSON = "{\"PrintScaling\": \"None\", \"PageLayout\": \"TwoColumnLeft\", \"PageMode\": \"UseOutlines\"}"
+	if err := api.SetViewerPreferencesFileFromJSONBytes(inFile, "", []byte(stringJSON), nil); err != nil {
+		t.Fatalf("%s %s: set via JSON string: %v\n", msg, inFile, err)
+	}
+
+	vp, err = api.ViewerPreferencesFile(inFile, false, nil)
+	if err != nil {
+		t.Fatalf("%s %s: viewerPref struct: %v\n", msg, inFile, err)
+	}
+	if vp == nil || vp.PrintScaling == nil || *vp.PrintScaling != model.PrintScalingNone {
+		t.Fatalf("%s %s: missing print scaling: %v\n", msg, inFile, vp)
+	}
+	if vp.PageLayout == nil || *vp.PageLayout != model.PageLayoutTwoColumnLeft {
+		t.Fatalf("%s %s: missing page layout: %v\n", msg, inFile, vp)
+	}
+	if vp.PageMode == nil || *vp.PageMode != model.PageModeUseOutlines {
+		t.Fatalf("%s %s: missing page mode: %v\n", msg, inFile, vp)
+	}
 }
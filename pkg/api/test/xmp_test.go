@@ -0,0 +1,160 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package test
+
+import (
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestSetXMP(t *testing.T) {
+	msg := "TestSetXMP"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "xmp.pdf")
+
+	x, err := pdfcpu.ParseXMPProperties(map[string]string{
+		"title":      "Go & <Gophers>",
+		"creator":    "Jane Doe; John Doe",
+		"trapped":    "false",
+		"createdate": "2024-05-01T10:00:00Z",
+	})
+	if err != nil {
+		t.Fatalf("%s parse properties: %v\n", msg, err)
+	}
+
+	// Create a new XMP packet.
+	if err := api.SetXMPFile(inFile, outFile, x, nil); err != nil {
+		t.Fatalf("%s: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(outFile, nil); err != nil {
+		t.Fatalf("%s: validate: %v\n", msg, err)
+	}
+
+	x1, err := api.XMPFile(outFile, nil)
+	if err != nil || x1 == nil {
+		t.Fatalf("%s read XMP: %v\n", msg, err)
+	}
+	if x1.Title != x.Title || !reflect.DeepEqual(x1.Creators, x.Creators) {
+		t.Fatalf("%s: want %s %v, got %s %v\n", msg, x.Title, x.Creators, x1.Title, x1.Creators)
+	}
+	if x1.Trapped == nil || *x1.Trapped || x1.CreateDate == nil || !x1.CreateDate.Equal(*x.CreateDate) || x1.MetadataDate == nil {
+		t.Fatalf("%s: unexpected trapped or dates: %+v\n", msg, x1)
+	}
+
+	// Merge into an existing XMP packet declaring a Factur-X invoice.
+	invFile := filepath.Join(outDir, "xmpInvoice.pdf")
+	if err := api.AddInvoiceFile(outFile, filepath.Join(resDir, "factur-x.xml"), invFile, nil, nil); err != nil {
+		t.Fatalf("%s add invoice: %v\n", msg, err)
+	}
+
+	x = &model.XMP{Title: "Invoice 4711", Subjects: []string{"invoice", "2025"}}
+	if err := api.SetXMPFile(invFile, "", x, nil); err != nil {
+		t.Fatalf("%s merge: %v\n", msg, err)
+	}
+
+	if err := api.ValidateFile(invFile, nil); err != nil {
+		t.Fatalf("%s: validate merged: %v\n", msg, err)
+	}
+
+	x1, err = api.XMPFile(invFile, nil)
+	if err != nil || x1 == nil {
+		t.Fatalf("%s read merged XMP: %v\n", msg, err)
+	}
+	if x1.Title != x.Title || !reflect.DeepEqual(x1.Subjects, x.Subjects) {
+		t.Fatalf("%s: want %s %v, got %s %v\n", msg, x.Title, x.Subjects, x1.Title, x1.Subjects)
+	}
+
+	// Untouched properties and unknown schemas survive.
+	if len(x1.Creators) != 2 || x1.Trapped == nil {
+		t.Fatalf("%s: lost properties: %+v\n", msg, x1)
+	}
+	if !slices.Contains(x1.Schemas, "urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#") {
+		t.Fatalf("%s: lost Factur-X schema: %v\n", msg, x1.Schemas)
+	}
+
+	if _, err := pdfcpu.ParseXMPProperties(map[string]string{"color": "red"}); err == nil {
+		t.Fatalf("%s: want error for unsupported property\n", msg)
+	}
+}
+
+func TestSyncInfoXMP(t *testing.T) {
+	msg := "TestSyncInfoXMP"
+
+	inFile := filepath.Join(inDir, "go.pdf")
+	outFile := filepath.Join(outDir, "xmpSync.pdf")
+
+	// Info dict changes go into the XMP metadata.
+	if err := api.AddPropertiesFile(inFile, outFile, map[string]string{"Title": "Hello", "Author": "Jane Doe; John Doe"}, nil); err != nil {
+		t.Fatalf("%s add properties: %v\n", msg, err)
+	}
+	if err := api.AddKeywordsFile(outFile, "", []string{"go"}, nil); err != nil {
+		t.Fatalf("%s add keywords: %v\n", msg, err)
+	}
+
+	x, err := api.XMPFile(outFile, nil)
+	if err != nil || x == nil {
+		t.Fatalf("%s read XMP: %v\n", msg, err)
+	}
+	if x.Title != "Hello" || len(x.Creators) != 2 || x.Keywords != "go" {
+		t.Fatalf("%s: info dict not synced: %+v\n", msg, x)
+	}
+
+	// go.pdf carries a Creator not yet known to XMP.
+	mm, err := api.MetadataMismatchesFile(outFile, nil)
+	if err != nil {
+		t.Fatalf("%s mismatches: %v\n", msg, err)
+	}
+	if len(mm) != 1 || mm[0].Key != "Creator" {
+		t.Fatalf("%s: want Creator mismatch, got %v\n", msg, mm)
+	}
+
+	// Resolve a conflict in favor of XMP.
+	if err := api.SetXMPFile(outFile, "", &model.XMP{Title: "Other"}, nil); err != nil {
+		t.Fatalf("%s set XMP: %v\n", msg, err)
+	}
+	if err := api.ReconcileMetadataFile(outFile, "", true, nil); err != nil {
+		t.Fatalf("%s reconcile: %v\n", msg, err)
+	}
+
+	if mm, err = api.MetadataMismatchesFile(outFile, nil); err != nil || len(mm) > 0 {
+		t.Fatalf("%s: want no mismatches, got %v %v\n", msg, mm, err)
+	}
+
+	ctx, err := api.ReadContextFile(outFile)
+	if err != nil {
+		t.Fatalf("%s read context: %v\n", msg, err)
+	}
+	if ctx.Title != "Other" {
+		t.Fatalf("%s: want title Other, got %s\n", msg, ctx.Title)
+	}
+
+	// Removing an info dict entry removes its XMP counterpart.
+	if err := api.RemovePropertiesFile(outFile, "", []string{"Title"}, nil); err != nil {
+		t.Fatalf("%s remove properties: %v\n", msg, err)
+	}
+	if x, err = api.XMPFile(outFile, nil); err != nil || x.Title != "" {
+		t.Fatalf("%s: want no XMP title, got %+v %v\n", msg, x, err)
+	}
+}
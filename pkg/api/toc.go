@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// AddTOC inserts table of contents pages generated from the outline of rs and writes the result to w.
+// Returns the number of TOC pages inserted.
+func AddTOC(rs io.ReadSeeker, w io.Writer, toc *model.TOC, conf *model.Configuration) (int, error) {
+	if rs == nil {
+		return 0, errors.New("pdfcpu: AddTOC: missing rs")
+	}
+
+	if toc == nil {
+		toc = model.DefaultTOCConfig()
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDTOC
+
+	ctx, err := ReadValidateAndOptimize(rs, conf)
+	if err != nil {
+		return 0, err
+	}
+
+	n, err := pdfcpu.AddTOC(ctx, toc)
+	if err != nil {
+		return 0, err
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("inserted %d toc page(s)\n", n)
+	}
+
+	return n, Write(ctx, w, conf)
+}
+
+// AddTOCFile inserts table of contents pages generated from the outline of inFile and writes the result to outFile.
+func AddTOCFile(inFile, outFile string, toc *model.TOC, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	_, err = AddTOC(f1, f2, toc, conf)
+	return err
+}
@@ -0,0 +1,267 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForTransitions(rs io.ReadSeeker, cmd model.CommandMode, selectedPages []string, conf *model.Configuration) (*model.Context, map[int]bool, *model.Configuration, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	} else {
+		conf.ValidationMode = model.ValidationRelaxed
+	}
+	conf.Cmd = cmd
+
+	ctx, err := ReadAndValidate(rs, conf)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	pages, err := PagesForPageSelection(ctx.PageCount, selectedPages, true, true)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	return ctx, pages, conf, nil
+}
+
+// PageTransitions returns the page transitions of selected pages of rs mapped by page number.
+func PageTransitions(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) (map[int]model.PageTransition, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: PageTransitions: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForTransitions(rs, model.LISTPAGETRANSITIONS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.PageTransitions(ctx, pages)
+}
+
+// PageTransitionsFile returns the page transitions of selected pages of inFile mapped by page number.
+func PageTransitionsFile(inFile string, selectedPages []string, conf *model.Configuration) (map[int]model.PageTransition, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return PageTransitions(f, selectedPages, conf)
+}
+
+// ListPageTransitions lists the page transitions of selected pages of rs.
+func ListPageTransitions(rs io.ReadSeeker, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListPageTransitions: missing rs")
+	}
+
+	ctx, pages, _, err := readContextForTransitions(rs, model.LISTPAGETRANSITIONS, selectedPages, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListPageTransitions(ctx, pages)
+}
+
+// ListPageTransitionsFile lists the page transitions of selected pages of inFile.
+func ListPageTransitionsFile(inFile string, selectedPages []string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListPageTransitions(f, selectedPages, conf)
+}
+
+// SetPageTransitions applies t to selected pages of rs and writes the result to w.
+func SetPageTransitions(rs io.ReadSeeker, w io.Writer, selectedPages []string, t model.PageTransition, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetPageTransitions: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForTransitions(rs, model.SETPAGETRANSITIONS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetPageTransitions(ctx, pages, t); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// SetPageTransitionsFile applies t to selected pages of inFile and writes the result to outFile.
+func SetPageTransitionsFile(inFile, outFile string, selectedPages []string, t model.PageTransition, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetPageTransitions(f1, f2, selectedPages, t, conf)
+}
+
+// RemovePageTransitions removes transitions and auto advance durations from selected pages of rs and writes the result to w.
+func RemovePageTransitions(rs io.ReadSeeker, w io.Writer, selectedPages []string, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: RemovePageTransitions: missing rs")
+	}
+
+	ctx, pages, conf, err := readContextForTransitions(rs, model.REMOVEPAGETRANSITIONS, selectedPages, conf)
+	if err != nil {
+		return err
+	}
+
+	ok, err := pdfcpu.RemovePageTransitions(ctx, pages)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New("pdfcpu: no page transitions available")
+	}
+
+	return Write(ctx, w, conf)
+}
+
+// RemovePageTransitionsFile removes transitions and auto advance durations from selected pages of inFile and writes the result to outFile.
+func RemovePageTransitionsFile(inFile, outFile string, selectedPages []string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return RemovePageTransitions(f1, f2, selectedPages, conf)
+}
+
+// SetFullScreen configures rs to open in full screen mode and writes the result to w.
+func SetFullScreen(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetFullScreen: missing rs")
+	}
+
+	ctx, _, conf, err := readContextForTransitions(rs, model.SETFULLSCREEN, nil, conf)
+	if err != nil {
+		return err
+	}
+
+	pdfcpu.SetFullScreen(ctx)
+
+	return Write(ctx, w, conf)
+}
+
+// SetFullScreenFile configures inFile to open in full screen mode and writes the result to outFile.
+func SetFullScreenFile(inFile, outFile string, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetFullScreen(f1, f2, conf)
+}
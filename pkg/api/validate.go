@@ -128,6 +128,70 @@ func ValidateFiles(inFiles []string, conf *model.Configuration) error {
 	return nil
 }
 
+// ValidationResult validates a PDF stream read from rs and returns the collected findings.
+// If continueOnError is set validation proceeds with the next page or document level structure after an error.
+func ValidationResult(rs io.ReadSeeker, fileName string, continueOnError bool, conf *model.Configuration) (*model.ValidationResult, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ValidationResult: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+
+	vr := model.NewValidationResult(fileName, conf, continueOnError)
+
+	ctx, err := ReadContext(rs, conf)
+	if err != nil {
+		// The file structure is broken beyond repair.
+		vr.Add(model.SeverityError, err.Error(), 0, 0, "7.5")
+		return vr, nil
+	}
+
+	for _, r := range ctx.Read.Repairs {
+		vr.Add(model.SeverityWarning, "repaired "+r.String(), r.ObjNr, 0, "7.5")
+	}
+
+	ctx.XRefTable.Findings = vr
+
+	if err = ValidateContext(ctx); err != nil && vr.Errors() == 0 {
+		vr.Add(model.SeverityError, err.Error(), ctx.CurObj, 0, "")
+	}
+
+	return vr, nil
+}
+
+// ValidationResultFile validates inFile and returns the collected findings.
+func ValidationResultFile(inFile string, continueOnError bool, conf *model.Configuration) (*model.ValidationResult, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ValidationResult(f, inFile, continueOnError, conf)
+}
+
+// RepairLog reads a PDF stream from rs obeying conf.ReadTolerance and returns the workarounds applied for reading damaged files.
+func RepairLog(rs io.ReadSeeker, conf *model.Configuration) ([]model.Repair, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: RepairLog: missing rs")
+	}
+
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+
+	ctx, err := ReadContext(rs, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.Read.Repairs, nil
+}
+
 // DumpObject writes an object from rs to stdout.
 func DumpObject(rs io.ReadSeeker, mode, objNr int, conf *model.Configuration) error {
 	if rs == nil {
@@ -50,7 +50,7 @@ func ViewerPreferences(rs io.ReadSeeker, conf *model.Configuration) (*model.View
 
 	v := ctx.XRefTable.Version()
 
-	return ctx.ViewerPref, &v, nil
+	return ctx.ViewerPreferences(), &v, nil
 }
 
 // ViewerPreferences returns inFile's viewer preferences.
@@ -91,14 +91,16 @@ func ListViewerPreferences(rs io.ReadSeeker, all bool, conf *model.Configuration
 		return nil, err
 	}
 
+	vp := ctx.ViewerPreferences()
+
 	if !all {
-		if ctx.ViewerPref != nil {
-			return ctx.ViewerPref.List(), nil
+		if vp != nil {
+			return vp.List(), nil
 		}
 		return []string{"No viewer preferences available."}, nil
 	}
 
-	vp1, err := model.ViewerPreferencesWithDefaults(ctx.ViewerPref, ctx.XRefTable.Version())
+	vp1, err := model.ViewerPreferencesWithDefaults(vp, ctx.XRefTable.Version())
 	if err != nil {
 		return nil, err
 	}
@@ -335,7 +337,7 @@ func SetViewerPreferencesFileFromJSONFile(inFilePDF, outFilePDF, inFileJSON stri
 	return SetViewerPreferencesFileFromJSONBytes(inFilePDF, outFilePDF, bb, conf)
 }
 
-// ResetViewerPreferences resets rs's viewer preferences and writes the result to w.
+// ResetViewerPreferences resets rs's viewer preferences including page layout and page mode and writes the result to w.
 func ResetViewerPreferences(rs io.ReadSeeker, w io.Writer, conf *model.Configuration) error {
 	if rs == nil {
 		return errors.New("pdfcpu: ResetViewerPreferences: missing rs")
@@ -357,11 +359,14 @@ func ResetViewerPreferences(rs io.ReadSeeker, w io.Writer, conf *model.Configura
 		return err
 	}
 
-	if ctx.ViewerPref == nil {
+	if ctx.ViewerPreferences() == nil {
 		return ErrNoOp
 	}
 
-	delete(ctx.RootDict, "ViewerPreferences")
+	// Page layout and page mode are part of the viewer preferences.
+	for _, k := range []string{"ViewerPreferences", "PageLayout", "PageMode"} {
+		delete(ctx.RootDict, k)
+	}
 
 	return Write(ctx, w, conf)
 }
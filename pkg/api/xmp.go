@@ -0,0 +1,239 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+func readContextForXMP(rs io.ReadSeeker, cmd model.CommandMode, conf *model.Configuration) (*model.Context, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.ValidationMode = model.ValidationRelaxed
+	conf.Cmd = cmd
+
+	return ReadValidateAndOptimize(rs, conf)
+}
+
+// XMP returns the Dublin Core, PDF and XMP basic properties of rs's XMP metadata or nil.
+func XMP(rs io.ReadSeeker, conf *model.Configuration) (*model.XMP, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: XMP: missing rs")
+	}
+
+	ctx, err := readContextForXMP(rs, model.LISTMETADATA, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.XMP(ctx)
+}
+
+// XMPFile returns the Dublin Core, PDF and XMP basic properties of inFile's XMP metadata or nil.
+func XMPFile(inFile string, conf *model.Configuration) (*model.XMP, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return XMP(f, conf)
+}
+
+// ListXMP returns a formatted list of rs's XMP metadata.
+func ListXMP(rs io.ReadSeeker, conf *model.Configuration) ([]string, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: ListXMP: missing rs")
+	}
+
+	ctx, err := readContextForXMP(rs, model.LISTMETADATA, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.ListXMP(ctx)
+}
+
+// ListXMPFile returns a formatted list of inFile's XMP metadata.
+func ListXMPFile(inFile string, conf *model.Configuration) ([]string, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ListXMP(f, conf)
+}
+
+// SetXMP merges the properties set in x into rs's XMP metadata and writes the result to w.
+// Unknown schemas and properties are preserved.
+func SetXMP(rs io.ReadSeeker, w io.Writer, x *model.XMP, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: SetXMP: missing rs")
+	}
+
+	ctx, err := readContextForXMP(rs, model.SETMETADATA, conf)
+	if err != nil {
+		return err
+	}
+
+	if err := pdfcpu.SetXMP(ctx, x); err != nil {
+		return err
+	}
+
+	return Write(ctx, w, ctx.Configuration)
+}
+
+// SetXMPFile merges the properties set in x into inFile's XMP metadata and writes the result to outFile.
+func SetXMPFile(inFile, outFile string, x *model.XMP, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return SetXMP(f1, f2, x, conf)
+}
+
+// MetadataMismatches returns the entries of rs's info dict disagreeing with their XMP counterparts.
+func MetadataMismatches(rs io.ReadSeeker, conf *model.Configuration) ([]model.MetadataMismatch, error) {
+	if rs == nil {
+		return nil, errors.New("pdfcpu: MetadataMismatches: missing rs")
+	}
+
+	ctx, err := readContextForXMP(rs, model.CHECKMETADATA, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	return pdfcpu.MetadataMismatches(ctx)
+}
+
+// MetadataMismatchesFile returns the entries of inFile's info dict disagreeing with their XMP counterparts.
+func MetadataMismatchesFile(inFile string, conf *model.Configuration) ([]model.MetadataMismatch, error) {
+	f, err := os.Open(inFile)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return MetadataMismatches(f, conf)
+}
+
+// ReconcileMetadata fixes all mismatches between rs's info dict and XMP metadata and writes the result to w.
+// Conflicting values are resolved in favor of the info dict unless preferXMP is set.
+func ReconcileMetadata(rs io.ReadSeeker, w io.Writer, preferXMP bool, conf *model.Configuration) error {
+	if rs == nil {
+		return errors.New("pdfcpu: ReconcileMetadata: missing rs")
+	}
+
+	ctx, err := readContextForXMP(rs, model.SYNCMETADATA, conf)
+	if err != nil {
+		return err
+	}
+
+	mm, err := pdfcpu.ReconcileMetadata(ctx, preferXMP)
+	if err != nil {
+		return err
+	}
+
+	if log.CLIEnabled() {
+		for _, m := range mm {
+			log.CLI.Printf("fixing %s\n", m)
+		}
+	}
+
+	return Write(ctx, w, ctx.Configuration)
+}
+
+// ReconcileMetadataFile fixes all mismatches between inFile's info dict and XMP metadata and writes the result to outFile.
+func ReconcileMetadataFile(inFile, outFile string, preferXMP bool, conf *model.Configuration) (err error) {
+	var f1, f2 *os.File
+
+	if f1, err = os.Open(inFile); err != nil {
+		return err
+	}
+
+	tmpFile := inFile + ".tmp"
+	if outFile != "" && inFile != outFile {
+		tmpFile = outFile
+		logWritingTo(outFile)
+	} else {
+		logWritingTo(inFile)
+	}
+	if f2, err = os.Create(tmpFile); err != nil {
+		f1.Close()
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			f2.Close()
+			f1.Close()
+			os.Remove(tmpFile)
+			return
+		}
+		if err = f2.Close(); err != nil {
+			return
+		}
+		if err = f1.Close(); err != nil {
+			return
+		}
+		if outFile == "" || inFile == outFile {
+			err = os.Rename(tmpFile, inFile)
+		}
+	}()
+
+	return ReconcileMetadata(f1, f2, preferXMP, conf)
+}
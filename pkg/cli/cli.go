@@ -19,11 +19,15 @@ package cli
 
 import (
 	"github.com/pdfcpu/pdfcpu/pkg/api"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 )
 
 // Validate inFile against ISO-32000-1:2008.
 func Validate(cmd *Command) ([]string, error) {
+	if cmd.BoolVal1 || cmd.StringVal != "" {
+		return ListValidationResults(cmd.InFiles, cmd.BoolVal1, cmd.StringVal, cmd.Conf)
+	}
 	return nil, api.ValidateFiles(cmd.InFiles, cmd.Conf)
 }
 
@@ -32,6 +36,44 @@ func Optimize(cmd *Command) ([]string, error) {
 	return nil, api.OptimizeFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
 }
 
+// Repair inFile and write result to outFile.
+func Repair(cmd *Command) ([]string, error) {
+	rr, err := api.RepairFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+	if err != nil {
+		return nil, err
+	}
+	if len(rr) == 0 {
+		return []string{"no repairs necessary"}, nil
+	}
+	ss := make([]string, len(rr))
+	for i, r := range rr {
+		ss[i] = r.String()
+	}
+	return ss, nil
+}
+
+// Sanitize inFile and write result to outFile.
+func Sanitize(cmd *Command) ([]string, error) {
+	r, err := api.SanitizeFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+	if err != nil {
+		return nil, err
+	}
+	if r.Empty() {
+		return []string{"nothing to remove"}, nil
+	}
+	return r.List(), nil
+}
+
+// AddOCRText overlays inFile with invisible text taken from OCR results and writes the result to outFile.
+func AddOCRText(cmd *Command) ([]string, error) {
+	return nil, api.AddOCRTextFile(*cmd.InFile, *cmd.OutFile, cmd.InFiles, cmd.Conf)
+}
+
+// Compare inFiles and optionally write the second file with changes highlighted to outFile.
+func Compare(cmd *Command) ([]string, error) {
+	return ListComparison(cmd.InFiles[0], cmd.InFiles[1], *cmd.OutFile, cmd.BoolVal1, cmd.BoolVal2, cmd.Conf)
+}
+
 // Encrypt inFile and write result to outFile.
 func Encrypt(cmd *Command) ([]string, error) {
 	return nil, api.EncryptFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
@@ -82,13 +124,22 @@ func Rotate(cmd *Command) ([]string, error) {
 	return nil, api.RotateFile(*cmd.InFile, *cmd.OutFile, cmd.IntVal, cmd.PageSelection, cmd.Conf)
 }
 
+// AutoRotate fixes the rotation of selected pages of inFile whose text is not displayed upright.
+func AutoRotate(cmd *Command) ([]string, error) {
+	return nil, api.AutoRotateFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
 // AddWatermarks adds watermarks or stamps to selected pages of inFile and writes the result to outFile.
 func AddWatermarks(cmd *Command) ([]string, error) {
 	return nil, api.AddWatermarksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Watermark, cmd.Conf)
 }
 
 // RemoveWatermarks remove watermarks or stamps from selected pages of inFile and writes the result to outFile.
+// cmd.BoolVal1 selects the removal of watermarks added by any tool.
 func RemoveWatermarks(cmd *Command) ([]string, error) {
+	if cmd.BoolVal1 {
+		return nil, api.RemoveDetectedWatermarksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+	}
 	return nil, api.RemoveWatermarksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
 }
 
@@ -123,6 +174,11 @@ func RemovePages(cmd *Command) ([]string, error) {
 	return nil, api.RemovePagesFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
 }
 
+// AnalyzePages detects blank and duplicate pages of inFile.
+func AnalyzePages(cmd *Command) ([]string, error) {
+	return ListPageAnalysis(*cmd.InFile, cmd.BoolVal1, cmd.Conf)
+}
+
 // MergeCreate merges inFiles in the order specified and writes the result to outFile.
 func MergeCreate(cmd *Command) ([]string, error) {
 	return nil, api.MergeCreateFile(cmd.InFiles, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
@@ -143,6 +199,12 @@ func ExtractImages(cmd *Command) ([]string, error) {
 	return nil, api.ExtractImagesFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
 }
 
+// ExtractImagesLossless dumps embedded image resources from inFile into outDir for selected pages
+// without recompression along with a JSON manifest per image.
+func ExtractImagesLossless(cmd *Command) ([]string, error) {
+	return nil, api.ExtractImagesLosslessFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
+}
+
 // ExtractFonts dumps embedded fontfiles from inFile into outDir for selected pages.
 func ExtractFonts(cmd *Command) ([]string, error) {
 	return nil, api.ExtractFontsFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
@@ -158,6 +220,18 @@ func ExtractContent(cmd *Command) ([]string, error) {
 	return nil, api.ExtractContentFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.Conf)
 }
 
+// ExtractVectorPaths writes the vector paths of selected pages of inFile into outDir.
+// cmd.BoolVal1 selects JSON instead of SVG output.
+func ExtractVectorPaths(cmd *Command) ([]string, error) {
+	return nil, api.ExtractVectorPathsFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.BoolVal1, cmd.Conf)
+}
+
+// ExtractTables writes the tables detected on selected pages of inFile into outDir.
+// cmd.BoolVal1 selects JSON instead of CSV output.
+func ExtractTables(cmd *Command) ([]string, error) {
+	return nil, api.ExtractTablesFile(*cmd.InFile, *cmd.OutDir, cmd.PageSelection, cmd.BoolVal1, cmd.Conf)
+}
+
 // ExtractMetadata dumps all metadata dict entries for inFile into outDir.
 func ExtractMetadata(cmd *Command) ([]string, error) {
 	return nil, api.ExtractMetadataFile(*cmd.InFile, *cmd.OutDir, cmd.Conf)
@@ -185,7 +259,7 @@ func ExtractAttachments(cmd *Command) ([]string, error) {
 
 // ListInfo gathers information about inFile and returns the result as []string.
 func ListInfo(cmd *Command) ([]string, error) {
-	return ListInfoFiles(cmd.InFiles, cmd.PageSelection, cmd.BoolVal1, cmd.BoolVal2, cmd.Conf)
+	return ListInfoFiles(cmd.InFiles, cmd.PageSelection, cmd.BoolVal1, cmd.BoolVal3, cmd.BoolVal2, cmd.Conf)
 }
 
 // CreateCheatSheetsFonts creates single page PDF cheat sheets for user fonts in current dir.
@@ -258,6 +332,11 @@ func Crop(cmd *Command) ([]string, error) {
 	return nil, api.CropFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Box, cmd.Conf)
 }
 
+// AutoCrop crops selected pages of inFile to their content and writes result to outFile.
+func AutoCrop(cmd *Command) ([]string, error) {
+	return nil, api.AutoCropFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.AutoCrop, cmd.Conf)
+}
+
 // ListAnnotations returns inFile's page annotations.
 func ListAnnotations(cmd *Command) ([]string, error) {
 	_, ss, err := ListAnnotationsFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
@@ -270,6 +349,32 @@ func RemoveAnnotations(cmd *Command) ([]string, error) {
 	return nil, api.RemoveAnnotationsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.StringVals, cmd.IntVals, cmd.Conf, incr)
 }
 
+// AddAnnotations adds the annotations described by a JSON file to inFile and writes the result to outFile.
+func AddAnnotations(cmd *Command) ([]string, error) {
+	incr := false // No incremental writing on cli.
+	return nil, api.AddAnnotationsJSONFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf, incr)
+}
+
+// ExportAnnotations writes the annotations of inFile including replies and review states to outFileJSON.
+func ExportAnnotations(cmd *Command) ([]string, error) {
+	return nil, api.ExportAnnotationsFile(*cmd.InFile, *cmd.OutFileJSON, cmd.PageSelection, cmd.Conf)
+}
+
+// ImportAnnotations adds the annotations of a JSON file as written by ExportAnnotations to inFile and writes the result to outFile.
+func ImportAnnotations(cmd *Command) ([]string, error) {
+	return nil, api.ImportAnnotationsFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
+}
+
+// FlattenAnnotations burns annotations of inFile into the page content and writes the result to outFile.
+func FlattenAnnotations(cmd *Command) ([]string, error) {
+	return nil, api.FlattenAnnotationsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.StringVals, cmd.IntVals, cmd.Conf)
+}
+
+// AddAutoLinks adds link annotations for URLs and email addresses found in inFile's page text and writes the result to outFile.
+func AddAutoLinks(cmd *Command) ([]string, error) {
+	return nil, api.AddAutoLinksFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
 // ListImages returns inFiles embedded images.
 func ListImages(cmd *Command) ([]string, error) {
 	return ListImagesFile(cmd.InFiles, cmd.PageSelection, cmd.Conf)
@@ -348,9 +453,17 @@ func MultiFillFormFields(cmd *Command) ([]string, error) {
 
 // Resize selected pages and write result to outFile.
 func Resize(cmd *Command) ([]string, error) {
+	if res := cmd.Resize; res != nil && res.Fit != nil {
+		return nil, api.ResizePagesFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, *res.PageDim, *res.Fit, cmd.Conf)
+	}
 	return nil, api.ResizeFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Resize, cmd.Conf)
 }
 
+// Bleed adds bleed to selected pages and writes result to outFile.
+func Bleed(cmd *Command) ([]string, error) {
+	return nil, api.BleedFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Bleed, cmd.Conf)
+}
+
 // Create poster for selected pages and write result PDFs into outDir.
 func Poster(cmd *Command) ([]string, error) {
 	return nil, api.PosterFile(*cmd.InFile, *cmd.OutDir, *cmd.OutFile, cmd.PageSelection, cmd.Cut, cmd.Conf)
@@ -376,11 +489,16 @@ func ExportBookmarks(cmd *Command) ([]string, error) {
 	return nil, api.ExportBookmarksFile(*cmd.InFile, *cmd.OutFileJSON, cmd.Conf)
 }
 
-// ImportBookmarks creates/replaces outlines of inFile corresponding to declarations found in inJSONFile and writes the result to outFile.
+// ImportBookmarks creates/replaces outlines of inFile corresponding to declarations found in inJSONFile (JSON or YAML) and writes the result to outFile.
 func ImportBookmarks(cmd *Command) ([]string, error) {
 	return nil, api.ImportBookmarksFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
 }
 
+// DetectBookmarks creates/replaces outlines of inFile corresponding to headings found in the page text and writes the result to outFile.
+func DetectBookmarks(cmd *Command) ([]string, error) {
+	return nil, api.AddDetectedBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
+}
+
 // RemoveBookmarks erases outlines of inFile.
 func RemoveBookmarks(cmd *Command) ([]string, error) {
 	return nil, api.RemoveBookmarksFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
@@ -418,6 +536,49 @@ func ResetPageMode(cmd *Command) ([]string, error) {
 	return nil, api.ResetPageModeFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
 }
 
+// ListPageLabels returns inFile's page labels.
+func ListPageLabels(cmd *Command) ([]string, error) {
+	return api.ListPageLabelsFile(*cmd.InFile, cmd.Conf)
+}
+
+// SetPageLabels sets inFile's page labels.
+func SetPageLabels(cmd *Command) ([]string, error) {
+	pls, err := pdfcpu.ParsePageLabels(cmd.StringVal)
+	if err != nil {
+		return nil, err
+	}
+	return nil, api.SetPageLabelsFile(*cmd.InFile, *cmd.OutFile, pls, cmd.Conf)
+}
+
+// RemovePageLabels removes inFile's page labels.
+func RemovePageLabels(cmd *Command) ([]string, error) {
+	return nil, api.RemovePageLabelsFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// ListPageTransitions returns the page transitions of selected pages of inFile.
+func ListPageTransitions(cmd *Command) ([]string, error) {
+	return api.ListPageTransitionsFile(*cmd.InFile, cmd.PageSelection, cmd.Conf)
+}
+
+// SetPageTransitions sets the page transitions of selected pages of inFile.
+func SetPageTransitions(cmd *Command) ([]string, error) {
+	t, err := pdfcpu.ParsePageTransition(cmd.StringVal)
+	if err != nil {
+		return nil, err
+	}
+	return nil, api.SetPageTransitionsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, *t, cmd.Conf)
+}
+
+// RemovePageTransitions removes the page transitions of selected pages of inFile.
+func RemovePageTransitions(cmd *Command) ([]string, error) {
+	return nil, api.RemovePageTransitionsFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
+// SetFullScreen configures inFile to open in full screen mode.
+func SetFullScreen(cmd *Command) ([]string, error) {
+	return nil, api.SetFullScreenFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
 // ListViewerPreferences returns inFile's viewer preferences.
 func ListViewerPreferences(cmd *Command) ([]string, error) {
 	return api.ListViewerPreferencesFile(*cmd.InFile, cmd.BoolVal1, cmd.BoolVal2, cmd.Conf)
@@ -460,3 +621,123 @@ func InspectCertificates(cmd *Command) ([]string, error) {
 func ValidateSignatures(cmd *Command) ([]string, error) {
 	return api.ValidateSignaturesFile(*cmd.InFile, cmd.BoolVal1, cmd.BoolVal2, cmd.Conf)
 }
+
+// Impose arranges selected pages of inFile onto sheets according to a user defined imposition template.
+func Impose(cmd *Command) ([]string, error) {
+	return nil, api.ImposeFile(*cmd.InFile, *cmd.InFileJSON, *cmd.OutFile, cmd.PageSelection, cmd.Conf)
+}
+
+// ManualDuplex reorders inFile's pages for double sided printing on printers without a duplexer.
+func ManualDuplex(cmd *Command) ([]string, error) {
+	return nil, api.ManualDuplexFile(*cmd.InFile, *cmd.OutFile, cmd.StringVal, cmd.PageSelection, cmd.BoolVal1, cmd.Conf)
+}
+
+// CreateCover creates the cover spread of a perfect bound book.
+func CreateCover(cmd *Command) ([]string, error) {
+	return nil, api.CreateCoverFile(*cmd.OutFile, cmd.Cover, cmd.Conf)
+}
+
+// AddBatesNumbers stamps selected pages of inFiles with consecutive Bates numbers.
+func AddBatesNumbers(cmd *Command) ([]string, error) {
+	return nil, api.AddBatesNumbersFile(cmd.InFiles, *cmd.OutDir, cmd.StringVal, cmd.PageSelection, cmd.Bates, cmd.Conf)
+}
+
+// AddHeadersAndFooters adds headers and footers to selected pages of inFile and writes the result to outFile.
+func AddHeadersAndFooters(cmd *Command) ([]string, error) {
+	return nil, api.AddHeadersAndFootersFile(*cmd.InFile, *cmd.OutFile, cmd.PageSelection, cmd.HeaderFooter, cmd.Conf)
+}
+
+// AddTOC inserts table of contents pages generated from inFile's outline and writes the result to outFile.
+func AddTOC(cmd *Command) ([]string, error) {
+	return nil, api.AddTOCFile(*cmd.InFile, *cmd.OutFile, cmd.TOC, cmd.Conf)
+}
+
+// CreatePortfolio creates a portfolio as described by a JSON file.
+func CreatePortfolio(cmd *Command) ([]string, error) {
+	return nil, api.CreatePortfolioFile(*cmd.InFileJSON, *cmd.OutFile, cmd.Conf)
+}
+
+// AddInvoice embeds an XML invoice into inFile and writes the resulting hybrid invoice to outFile.
+func AddInvoice(cmd *Command) ([]string, error) {
+	return nil, api.AddInvoiceFile(*cmd.InFile, cmd.InFiles[0], *cmd.OutFile, cmd.Invoice, cmd.Conf)
+}
+
+// ListMetadata returns inFile's XMP metadata.
+func ListMetadata(cmd *Command) ([]string, error) {
+	return api.ListXMPFile(*cmd.InFile, cmd.Conf)
+}
+
+// SetMetadata sets properties of inFile's XMP metadata and writes the result to outFile.
+func SetMetadata(cmd *Command) ([]string, error) {
+	return nil, api.SetXMPFile(*cmd.InFile, *cmd.OutFile, cmd.XMP, cmd.Conf)
+}
+
+// CheckMetadata returns the mismatches between inFile's info dict and XMP metadata.
+func CheckMetadata(cmd *Command) ([]string, error) {
+	return ListMetadataMismatchesFile(*cmd.InFile, cmd.Conf)
+}
+
+// SyncMetadata fixes mismatches between inFile's info dict and XMP metadata and writes the result to outFile.
+func SyncMetadata(cmd *Command) ([]string, error) {
+	return nil, api.ReconcileMetadataFile(*cmd.InFile, *cmd.OutFile, cmd.BoolVal1, cmd.Conf)
+}
+
+// ConvertToCMYK converts inFile's RGB colors into CMYK colors and writes the result to outFile.
+func ConvertToCMYK(cmd *Command) ([]string, error) {
+	return nil, api.ConvertToCMYKFile(*cmd.InFile, *cmd.OutFile, cmd.CMYKConversion, cmd.Conf)
+}
+
+// ConvertToGray converts inFile's colors into gray and writes the result to outFile.
+func ConvertToGray(cmd *Command) ([]string, error) {
+	return nil, api.ConvertToGrayFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// FlattenTransparency makes inFile's content opaque and writes the result to outFile.
+func FlattenTransparency(cmd *Command) ([]string, error) {
+	return nil, api.FlattenTransparencyFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// ListOutputIntents returns inFile's output intents.
+func ListOutputIntents(cmd *Command) ([]string, error) {
+	return api.ListOutputIntentsFile(*cmd.InFile, cmd.Conf)
+}
+
+// AddOutputIntent adds an output intent to inFile and writes the result to outFile.
+func AddOutputIntent(cmd *Command) ([]string, error) {
+	return nil, api.AddOutputIntentFile(*cmd.InFile, *cmd.OutFile, cmd.OutputIntent, cmd.Conf)
+}
+
+// ReplaceOutputIntent replaces an output intent of inFile and writes the result to outFile.
+func ReplaceOutputIntent(cmd *Command) ([]string, error) {
+	return nil, api.ReplaceOutputIntentFile(*cmd.InFile, *cmd.OutFile, cmd.OutputIntent, cmd.Conf)
+}
+
+// ListLayers returns inFile's layers.
+func ListLayers(cmd *Command) ([]string, error) {
+	return api.ListLayersFile(*cmd.InFile, cmd.Conf)
+}
+
+// ShowLayers makes inFile's layers visible by default and writes the result to outFile.
+func ShowLayers(cmd *Command) ([]string, error) {
+	return nil, api.SetLayerVisibilityFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals, true, cmd.Conf)
+}
+
+// HideLayers makes inFile's layers hidden by default and writes the result to outFile.
+func HideLayers(cmd *Command) ([]string, error) {
+	return nil, api.SetLayerVisibilityFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals, false, cmd.Conf)
+}
+
+// RemoveLayers removes inFile's layers including their content and writes the result to outFile.
+func RemoveLayers(cmd *Command) ([]string, error) {
+	return nil, api.RemoveLayersFile(*cmd.InFile, *cmd.OutFile, cmd.StringVals, cmd.Conf)
+}
+
+// FlattenLayers turns inFile's layers into regular content and writes the result to outFile.
+func FlattenLayers(cmd *Command) ([]string, error) {
+	return nil, api.FlattenLayersFile(*cmd.InFile, *cmd.OutFile, cmd.Conf)
+}
+
+// RunPipeline runs the processing pipeline described by a JSON or YAML job file.
+func RunPipeline(cmd *Command) ([]string, error) {
+	return api.RunPipelineFile(*cmd.InFile, cmd.Conf)
+}
@@ -40,6 +40,7 @@ type Command struct {
 	IntVal            int
 	BoolVal1          bool
 	BoolVal2          bool
+	BoolVal3          bool
 	IntVals           []int
 	StringVals        []string
 	StringMap         map[string]string
@@ -47,11 +48,21 @@ type Command struct {
 	Inputs            []io.ReadSeeker
 	Output            io.Writer
 	Box               *model.Box
+	AutoCrop          *model.AutoCrop
 	Import            *pdfcpu.Import
 	NUp               *model.NUp
 	Cut               *model.Cut
+	Cover             *model.Cover
+	Bates             *model.Bates
+	HeaderFooter      *model.HeaderFooter
+	TOC               *model.TOC
+	Invoice           *model.Invoice
+	XMP               *model.XMP
+	CMYKConversion    *model.CMYKConversion
+	OutputIntent      *model.OutputIntent
 	PageBoundaries    *model.PageBoundaries
 	Resize            *model.Resize
+	Bleed             *model.Bleed
 	Zoom              *model.Zoom
 	Watermark         *model.Watermark
 	ViewerPreferences *model.ViewerPreferences
@@ -62,15 +73,22 @@ type Command struct {
 var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.VALIDATE:                Validate,
 	model.OPTIMIZE:                Optimize,
+	model.REPAIR:                  Repair,
+	model.SANITIZE:                Sanitize,
+	model.ADDOCRTEXT:              AddOCRText,
+	model.COMPARE:                 Compare,
 	model.SPLIT:                   Split,
 	model.SPLITBYPAGENR:           SplitByPageNr,
 	model.MERGECREATE:             MergeCreate,
 	model.MERGECREATEZIP:          MergeCreateZip,
 	model.MERGEAPPEND:             MergeAppend,
 	model.EXTRACTIMAGES:           ExtractImages,
+	model.EXTRACTIMAGESLOSSLESS:   ExtractImagesLossless,
 	model.EXTRACTFONTS:            ExtractFonts,
 	model.EXTRACTPAGES:            ExtractPages,
 	model.EXTRACTCONTENT:          ExtractContent,
+	model.EXTRACTVECTORPATHS:      ExtractVectorPaths,
+	model.EXTRACTTABLES:           ExtractTables,
 	model.EXTRACTMETADATA:         ExtractMetadata,
 	model.TRIM:                    Trim,
 	model.ADDWATERMARKS:           AddWatermarks,
@@ -90,7 +108,10 @@ var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.INSERTPAGESBEFORE:       processPages,
 	model.INSERTPAGESAFTER:        processPages,
 	model.REMOVEPAGES:             processPages,
+	model.ANALYZEPAGES:            processPages,
 	model.ROTATE:                  Rotate,
+	model.AUTOROTATE:              AutoRotate,
+	model.PIPELINE:                RunPipeline,
 	model.NUP:                     NUp,
 	model.BOOKLET:                 Booklet,
 	model.LISTINFO:                ListInfo,
@@ -108,8 +129,14 @@ var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.ADDBOXES:                processPageBoundaries,
 	model.REMOVEBOXES:             processPageBoundaries,
 	model.CROP:                    processPageBoundaries,
+	model.AUTOCROP:                processPageBoundaries,
 	model.LISTANNOTATIONS:         processPageAnnotations,
+	model.ADDANNOTATIONS:          processPageAnnotations,
+	model.EXPORTANNOTATIONS:       processPageAnnotations,
+	model.IMPORTANNOTATIONS:       processPageAnnotations,
+	model.FLATTENANNOTATIONS:      processPageAnnotations,
 	model.REMOVEANNOTATIONS:       processPageAnnotations,
+	model.ADDAUTOLINKS:            processPageAnnotations,
 	model.LISTIMAGES:              processImages,
 	model.UPDATEIMAGES:            processImages,
 	model.DUMP:                    Dump,
@@ -123,6 +150,7 @@ var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.FILLFORMFIELDS:          processForm,
 	model.MULTIFILLFORMFIELDS:     processForm,
 	model.RESIZE:                  Resize,
+	model.BLEED:                   Bleed,
 	model.POSTER:                  Poster,
 	model.NDOWN:                   NDown,
 	model.CUT:                     Cut,
@@ -130,9 +158,17 @@ var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.EXPORTBOOKMARKS:         processBookmarks,
 	model.IMPORTBOOKMARKS:         processBookmarks,
 	model.REMOVEBOOKMARKS:         processBookmarks,
+	model.DETECTBOOKMARKS:         processBookmarks,
 	model.LISTPAGEMODE:            processPageMode,
 	model.SETPAGEMODE:             processPageMode,
 	model.RESETPAGEMODE:           processPageMode,
+	model.LISTPAGELABELS:          processPageLabels,
+	model.SETPAGELABELS:           processPageLabels,
+	model.REMOVEPAGELABELS:        processPageLabels,
+	model.LISTPAGETRANSITIONS:     processPageTransitions,
+	model.SETPAGETRANSITIONS:      processPageTransitions,
+	model.REMOVEPAGETRANSITIONS:   processPageTransitions,
+	model.SETFULLSCREEN:           processPageTransitions,
 	model.LISTPAGELAYOUT:          processPageLayout,
 	model.SETPAGELAYOUT:           processPageLayout,
 	model.RESETPAGELAYOUT:         processPageLayout,
@@ -144,6 +180,29 @@ var cmdMap = map[model.CommandMode]func(cmd *Command) ([]string, error){
 	model.INSPECTCERTIFICATES:     processCertificates,
 	model.IMPORTCERTIFICATES:      processCertificates,
 	model.VALIDATESIGNATURES:      processSignatures,
+	model.IMPOSE:                  Impose,
+	model.MANUALDUPLEX:            ManualDuplex,
+	model.CREATECOVER:             CreateCover,
+	model.BATES:                   AddBatesNumbers,
+	model.HEADERFOOTER:            AddHeadersAndFooters,
+	model.ADDTOC:                  AddTOC,
+	model.CREATEPORTFOLIO:         CreatePortfolio,
+	model.ADDINVOICE:              AddInvoice,
+	model.LISTMETADATA:            processMetadata,
+	model.SETMETADATA:             processMetadata,
+	model.CHECKMETADATA:           processMetadata,
+	model.SYNCMETADATA:            processMetadata,
+	model.CONVERTCMYK:             ConvertToCMYK,
+	model.CONVERTGRAY:             ConvertToGray,
+	model.LISTOUTPUTINTENTS:       processOutputIntents,
+	model.ADDOUTPUTINTENT:         processOutputIntents,
+	model.REPLACEOUTPUTINTENT:     processOutputIntents,
+	model.FLATTENTRANSPARENCY:     FlattenTransparency,
+	model.LISTLAYERS:              processLayers,
+	model.SHOWLAYERS:              processLayers,
+	model.HIDELAYERS:              processLayers,
+	model.REMOVELAYERS:            processLayers,
+	model.FLATTENLAYERS:           processLayers,
 }
 
 // ValidateCommand creates a new command to validate a file.
@@ -158,6 +217,20 @@ func ValidateCommand(inFiles []string, conf *model.Configuration) *Command {
 		Conf:    conf}
 }
 
+// ValidationResultCommand creates a new command to validate files and report all findings as text, json or sarif.
+func ValidationResultCommand(inFiles []string, continueOnError bool, format string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.VALIDATE
+	return &Command{
+		Mode:      model.VALIDATE,
+		InFiles:   inFiles,
+		BoolVal1:  continueOnError,
+		StringVal: format,
+		Conf:      conf}
+}
+
 // OptimizeCommand creates a new command to optimize a file.
 func OptimizeCommand(inFile, outFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -171,6 +244,61 @@ func OptimizeCommand(inFile, outFile string, conf *model.Configuration) *Command
 		Conf:    conf}
 }
 
+// RepairCommand creates a new command to repair a damaged file.
+func RepairCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPAIR
+	return &Command{
+		Mode:    model.REPAIR,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// SanitizeCommand creates a new command to strip risky and private content.
+func SanitizeCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SANITIZE
+	return &Command{
+		Mode:    model.SANITIZE,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// AddOCRTextCommand creates a new command to make scanned pages searchable using hOCR or ALTO OCR results.
+func AddOCRTextCommand(inFile string, ocrFiles []string, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDOCRTEXT
+	return &Command{
+		Mode:    model.ADDOCRTEXT,
+		InFile:  &inFile,
+		InFiles: ocrFiles,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// CompareCommand creates a new command to compare two files.
+func CompareCommand(inFile1, inFile2, outFile string, visual, json bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.COMPARE
+	return &Command{
+		Mode:     model.COMPARE,
+		InFiles:  []string{inFile1, inFile2},
+		OutFile:  &outFile,
+		BoolVal1: visual,
+		BoolVal2: json,
+		Conf:     conf}
+}
+
 // SplitCommand creates a new command to split a file according to span or along bookmarks..
 func SplitCommand(inFile, dirNameOut string, span int, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -258,6 +386,21 @@ func ExtractImagesCommand(inFile string, outDir string, pageSelection []string,
 		Conf:          conf}
 }
 
+// ExtractImagesLosslessCommand creates a new command to extract embedded images without recompression
+// along with a JSON manifest per image.
+func ExtractImagesLosslessCommand(inFile string, outDir string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTIMAGESLOSSLESS
+	return &Command{
+		Mode:          model.EXTRACTIMAGESLOSSLESS,
+		InFile:        &inFile,
+		OutDir:        &outDir,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // ExtractFontsCommand creates a new command to extract embedded fonts.
 // (experimental)
 func ExtractFontsCommand(inFile string, outDir string, pageSelection []string, conf *model.Configuration) *Command {
@@ -301,6 +444,36 @@ func ExtractContentCommand(inFile string, outDir string, pageSelection []string,
 		Conf:          conf}
 }
 
+// ExtractVectorPathsCommand creates a new command to extract page vector paths as SVG or JSON.
+func ExtractVectorPathsCommand(inFile string, outDir string, pageSelection []string, jsonOutput bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTVECTORPATHS
+	return &Command{
+		Mode:          model.EXTRACTVECTORPATHS,
+		InFile:        &inFile,
+		OutDir:        &outDir,
+		PageSelection: pageSelection,
+		BoolVal1:      jsonOutput,
+		Conf:          conf}
+}
+
+// ExtractTablesCommand creates a new command to extract detected tables as CSV or JSON.
+func ExtractTablesCommand(inFile string, outDir string, pageSelection []string, jsonOutput bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXTRACTTABLES
+	return &Command{
+		Mode:          model.EXTRACTTABLES,
+		InFile:        &inFile,
+		OutDir:        &outDir,
+		PageSelection: pageSelection,
+		BoolVal1:      jsonOutput,
+		Conf:          conf}
+}
+
 // ExtractMetadataCommand creates a new command to extract metadata streams.
 func ExtractMetadataCommand(inFile string, outDir string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -506,6 +679,21 @@ func RemoveWatermarksCommand(inFile, outFile string, pageSelection []string, con
 		Conf:          conf}
 }
 
+// RemoveDetectedWatermarksCommand creates a new command to remove watermarks added by any tool from a file.
+func RemoveDetectedWatermarksCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEWATERMARKS
+	return &Command{
+		Mode:          model.REMOVEWATERMARKS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		BoolVal1:      true,
+		Conf:          conf}
+}
+
 // ImportImagesCommand creates a new command to import images.
 func ImportImagesCommand(imageFiles []string, outFile string, imp *pdfcpu.Import, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -553,6 +741,19 @@ func RemovePagesCommand(inFile, outFile string, pageSelection []string, conf *mo
 		Conf:          conf}
 }
 
+// AnalyzePagesCommand creates a new command to detect blank and duplicate pages.
+func AnalyzePagesCommand(inFile string, json bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ANALYZEPAGES
+	return &Command{
+		Mode:     model.ANALYZEPAGES,
+		InFile:   &inFile,
+		BoolVal1: json,
+		Conf:     conf}
+}
+
 // RotateCommand creates a new command to rotate pages.
 func RotateCommand(inFile, outFile string, rotation int, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -568,6 +769,20 @@ func RotateCommand(inFile, outFile string, rotation int, pageSelection []string,
 		Conf:          conf}
 }
 
+// AutoRotateCommand creates a new command to fix the rotation of pages based on their text orientation.
+func AutoRotateCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUTOROTATE
+	return &Command{
+		Mode:          model.AUTOROTATE,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // NUpCommand creates a new command to render PDFs or image files in n-up fashion.
 func NUpCommand(inFiles []string, outFile string, pageSelection []string, nUp *model.NUp, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -599,7 +814,7 @@ func BookletCommand(inFiles []string, outFile string, pageSelection []string, nu
 }
 
 // InfoCommand creates a new command to output information about inFile.
-func InfoCommand(inFiles []string, pageSelection []string, fonts, json bool, conf *model.Configuration) *Command {
+func InfoCommand(inFiles []string, pageSelection []string, fonts, resources, json bool, conf *model.Configuration) *Command {
 	if conf == nil {
 		conf = model.NewDefaultConfiguration()
 	}
@@ -610,6 +825,7 @@ func InfoCommand(inFiles []string, pageSelection []string, fonts, json bool, con
 		PageSelection: pageSelection,
 		BoolVal1:      fonts,
 		BoolVal2:      json,
+		BoolVal3:      resources,
 		Conf:          conf}
 }
 
@@ -801,6 +1017,21 @@ func CropCommand(inFile, outFile string, pageSelection []string, box *model.Box,
 		Conf:          conf}
 }
 
+// AutoCropCommand creates a new command to crop selected pages to their content.
+func AutoCropCommand(inFile, outFile string, pageSelection []string, ac *model.AutoCrop, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.AUTOCROP
+	return &Command{
+		Mode:          model.AUTOCROP,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		AutoCrop:      ac,
+		Conf:          conf}
+}
+
 // ListAnnotationsCommand creates a new command to list annotations for selected pages.
 func ListAnnotationsCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -830,6 +1061,78 @@ func RemoveAnnotationsCommand(inFile, outFile string, pageSelection []string, id
 		Conf:          conf}
 }
 
+// AddAnnotationsCommand creates a new command to add the annotations described by inFileJSON.
+func AddAnnotationsCommand(inFile, inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDANNOTATIONS
+	return &Command{
+		Mode:       model.ADDANNOTATIONS,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
+// ExportAnnotationsCommand creates a new command to export the annotations of selected pages to outFileJSON.
+func ExportAnnotationsCommand(inFile, outFileJSON string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.EXPORTANNOTATIONS
+	return &Command{
+		Mode:          model.EXPORTANNOTATIONS,
+		InFile:        &inFile,
+		OutFileJSON:   &outFileJSON,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ImportAnnotationsCommand creates a new command to import the annotations of inFileJSON.
+func ImportAnnotationsCommand(inFile, inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPORTANNOTATIONS
+	return &Command{
+		Mode:       model.IMPORTANNOTATIONS,
+		InFile:     &inFile,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
+// FlattenAnnotationsCommand creates a new command to flatten annotations by id, type and object number.
+func FlattenAnnotationsCommand(inFile, outFile string, pageSelection []string, idsAndTypes []string, objNrs []int, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENANNOTATIONS
+	return &Command{
+		Mode:          model.FLATTENANNOTATIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		StringVals:    idsAndTypes,
+		IntVals:       objNrs,
+		Conf:          conf}
+}
+
+// AddAutoLinksCommand creates a new command to link URLs and email addresses found in the text of selected pages.
+func AddAutoLinksCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDAUTOLINKS
+	return &Command{
+		Mode:          model.ADDAUTOLINKS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
 // ListImagesCommand creates a new command to list annotations for selected pages.
 func ListImagesCommand(inFiles []string, pageSelection []string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -1012,6 +1315,21 @@ func ResizeCommand(inFile, outFile string, pageSelection []string, resize *model
 		Conf:          conf}
 }
 
+// BleedCommand creates a new command to add bleed to selected pages.
+func BleedCommand(inFile, outFile string, pageSelection []string, b *model.Bleed, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.BLEED
+	return &Command{
+		Mode:          model.BLEED,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Bleed:         b,
+		Conf:          conf}
+}
+
 // PosterCommand creates a new command to cut and slice pages horizontally or vertically.
 func PosterCommand(inFile, outDir, outFile string, pageSelection []string, cut *model.Cut, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -1101,6 +1419,20 @@ func ImportBookmarksCommand(inFile, inFileJSON, outFile string, replace bool, co
 		Conf:       conf}
 }
 
+// DetectBookmarksCommand creates a new command to add bookmarks for headings found in inFile's page text.
+func DetectBookmarksCommand(inFile, outFile string, replace bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.DETECTBOOKMARKS
+	return &Command{
+		Mode:     model.DETECTBOOKMARKS,
+		BoolVal1: replace,
+		InFile:   &inFile,
+		OutFile:  &outFile,
+		Conf:     conf}
+}
+
 // RemoveBookmarksCommand creates a new command to remove all bookmarks from inFile.
 func RemoveBookmarksCommand(inFile, outFile string, conf *model.Configuration) *Command {
 	if conf == nil {
@@ -1192,6 +1524,100 @@ func ResetPageModeCommand(inFile, outFile string, conf *model.Configuration) *Co
 		Conf:    conf}
 }
 
+// ListPageLabelsCommand creates a new command to list the page labels.
+func ListPageLabelsCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTPAGELABELS
+	return &Command{
+		Mode:   model.LISTPAGELABELS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SetPageLabelsCommand creates a new command to set the page labels.
+func SetPageLabelsCommand(inFile, outFile, value string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETPAGELABELS
+	return &Command{
+		Mode:      model.SETPAGELABELS,
+		InFile:    &inFile,
+		OutFile:   &outFile,
+		StringVal: value,
+		Conf:      conf}
+}
+
+// RemovePageLabelsCommand creates a new command to remove the page labels.
+func RemovePageLabelsCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEPAGELABELS
+	return &Command{
+		Mode:    model.REMOVEPAGELABELS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// ListPageTransitionsCommand creates a new command to list the page transitions of selected pages.
+func ListPageTransitionsCommand(inFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTPAGETRANSITIONS
+	return &Command{
+		Mode:          model.LISTPAGETRANSITIONS,
+		InFile:        &inFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// SetPageTransitionsCommand creates a new command to set the page transitions of selected pages.
+func SetPageTransitionsCommand(inFile, outFile string, pageSelection []string, value string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETPAGETRANSITIONS
+	return &Command{
+		Mode:          model.SETPAGETRANSITIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		StringVal:     value,
+		Conf:          conf}
+}
+
+// RemovePageTransitionsCommand creates a new command to remove the page transitions of selected pages.
+func RemovePageTransitionsCommand(inFile, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVEPAGETRANSITIONS
+	return &Command{
+		Mode:          model.REMOVEPAGETRANSITIONS,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// SetFullScreenCommand creates a new command to open a document in full screen mode.
+func SetFullScreenCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETFULLSCREEN
+	return &Command{
+		Mode:    model.SETFULLSCREEN,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
 // ListViewerPreferencesCommand creates a new command to list the viewer preferences.
 func ListViewerPreferencesCommand(inFile string, all, json bool, conf *model.Configuration) *Command {
 
@@ -1300,3 +1726,331 @@ func ValidateSignaturesCommand(inFile string, all, full bool, conf *model.Config
 		BoolVal2: full,
 		Conf:     conf}
 }
+
+// ImposeCommand creates a new command to arrange pages according to a user defined imposition template.
+func ImposeCommand(inFile, inFileJSON, outFile string, pageSelection []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.IMPOSE
+	return &Command{
+		Mode:          model.IMPOSE,
+		InFile:        &inFile,
+		InFileJSON:    &inFileJSON,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		Conf:          conf}
+}
+
+// ManualDuplexCommand creates a new command to reorder pages for manual duplex printing.
+func ManualDuplexCommand(inFile, outFile, outFileBacks string, pageSelection []string, reverseBacks bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.MANUALDUPLEX
+	return &Command{
+		Mode:          model.MANUALDUPLEX,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		StringVal:     outFileBacks,
+		PageSelection: pageSelection,
+		BoolVal1:      reverseBacks,
+		Conf:          conf}
+}
+
+// CreateCoverCommand creates a new command to create the cover spread of a perfect bound book.
+func CreateCoverCommand(outFile string, cover *model.Cover, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CREATECOVER
+	return &Command{
+		Mode:    model.CREATECOVER,
+		OutFile: &outFile,
+		Cover:   cover,
+		Conf:    conf}
+}
+
+// AddBatesNumbersCommand creates a new command to Bates number selected pages of inFiles.
+func AddBatesNumbersCommand(inFiles []string, outDir, manifestFile string, pageSelection []string, b *model.Bates, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.BATES
+	return &Command{
+		Mode:          model.BATES,
+		InFiles:       inFiles,
+		OutDir:        &outDir,
+		StringVal:     manifestFile,
+		PageSelection: pageSelection,
+		Bates:         b,
+		Conf:          conf}
+}
+
+// AddHeadersAndFootersCommand creates a new command to add headers and footers to selected pages of inFile.
+func AddHeadersAndFootersCommand(inFile, outFile string, pageSelection []string, hf *model.HeaderFooter, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.HEADERFOOTER
+	return &Command{
+		Mode:          model.HEADERFOOTER,
+		InFile:        &inFile,
+		OutFile:       &outFile,
+		PageSelection: pageSelection,
+		HeaderFooter:  hf,
+		Conf:          conf}
+}
+
+// AddTOCCommand creates a new command to insert table of contents pages generated from inFile's outline.
+func AddTOCCommand(inFile, outFile string, toc *model.TOC, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDTOC
+	return &Command{
+		Mode:    model.ADDTOC,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		TOC:     toc,
+		Conf:    conf}
+}
+
+// CreatePortfolioCommand creates a new command to create a portfolio as described by inFileJSON.
+func CreatePortfolioCommand(inFileJSON, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CREATEPORTFOLIO
+	return &Command{
+		Mode:       model.CREATEPORTFOLIO,
+		InFileJSON: &inFileJSON,
+		OutFile:    &outFile,
+		Conf:       conf}
+}
+
+// AddInvoiceCommand creates a new command to embed an XML invoice into inFile.
+func AddInvoiceCommand(inFile, inFileXML, outFile string, inv *model.Invoice, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDINVOICE
+	return &Command{
+		Mode:    model.ADDINVOICE,
+		InFile:  &inFile,
+		InFiles: []string{inFileXML},
+		OutFile: &outFile,
+		Invoice: inv,
+		Conf:    conf}
+}
+
+// ListMetadataCommand creates a new command to list inFile's XMP metadata.
+func ListMetadataCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTMETADATA
+	return &Command{
+		Mode:   model.LISTMETADATA,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SetMetadataCommand creates a new command to set properties of inFile's XMP metadata.
+func SetMetadataCommand(inFile, outFile string, x *model.XMP, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SETMETADATA
+	return &Command{
+		Mode:    model.SETMETADATA,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		XMP:     x,
+		Conf:    conf}
+}
+
+// CheckMetadataCommand creates a new command to list mismatches between inFile's info dict and XMP metadata.
+func CheckMetadataCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CHECKMETADATA
+	return &Command{
+		Mode:   model.CHECKMETADATA,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// SyncMetadataCommand creates a new command to fix mismatches between inFile's info dict and XMP metadata.
+func SyncMetadataCommand(inFile, outFile string, preferXMP bool, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SYNCMETADATA
+	return &Command{
+		Mode:     model.SYNCMETADATA,
+		InFile:   &inFile,
+		OutFile:  &outFile,
+		BoolVal1: preferXMP,
+		Conf:     conf}
+}
+
+// ConvertToCMYKCommand creates a new command to convert inFile's RGB colors into CMYK colors.
+func ConvertToCMYKCommand(inFile, outFile string, cc *model.CMYKConversion, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTCMYK
+	return &Command{
+		Mode:           model.CONVERTCMYK,
+		InFile:         &inFile,
+		OutFile:        &outFile,
+		CMYKConversion: cc,
+		Conf:           conf}
+}
+
+// ConvertToGrayCommand creates a new command to convert inFile's colors into gray.
+func ConvertToGrayCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.CONVERTGRAY
+	return &Command{
+		Mode:    model.CONVERTGRAY,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// FlattenTransparencyCommand creates a new command to flatten inFile's transparency.
+func FlattenTransparencyCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENTRANSPARENCY
+	return &Command{
+		Mode:    model.FLATTENTRANSPARENCY,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// ListOutputIntentsCommand creates a new command to list the output intents.
+func ListOutputIntentsCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTOUTPUTINTENTS
+	return &Command{
+		Mode:   model.LISTOUTPUTINTENTS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// AddOutputIntentCommand creates a new command to add an output intent.
+func AddOutputIntentCommand(inFile, outFile string, oi *model.OutputIntent, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.ADDOUTPUTINTENT
+	return &Command{
+		Mode:         model.ADDOUTPUTINTENT,
+		InFile:       &inFile,
+		OutFile:      &outFile,
+		OutputIntent: oi,
+		Conf:         conf}
+}
+
+// ReplaceOutputIntentCommand creates a new command to replace an output intent.
+func ReplaceOutputIntentCommand(inFile, outFile string, oi *model.OutputIntent, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REPLACEOUTPUTINTENT
+	return &Command{
+		Mode:         model.REPLACEOUTPUTINTENT,
+		InFile:       &inFile,
+		OutFile:      &outFile,
+		OutputIntent: oi,
+		Conf:         conf}
+}
+
+// ListLayersCommand creates a new command to list the layers.
+func ListLayersCommand(inFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.LISTLAYERS
+	return &Command{
+		Mode:   model.LISTLAYERS,
+		InFile: &inFile,
+		Conf:   conf}
+}
+
+// ShowLayersCommand creates a new command to make layers visible by default.
+func ShowLayersCommand(inFile, outFile string, names []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.SHOWLAYERS
+	return &Command{
+		Mode:       model.SHOWLAYERS,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		StringVals: names,
+		Conf:       conf}
+}
+
+// HideLayersCommand creates a new command to make layers hidden by default.
+func HideLayersCommand(inFile, outFile string, names []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.HIDELAYERS
+	return &Command{
+		Mode:       model.HIDELAYERS,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		StringVals: names,
+		Conf:       conf}
+}
+
+// RemoveLayersCommand creates a new command to remove layers including their content.
+func RemoveLayersCommand(inFile, outFile string, names []string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.REMOVELAYERS
+	return &Command{
+		Mode:       model.REMOVELAYERS,
+		InFile:     &inFile,
+		OutFile:    &outFile,
+		StringVals: names,
+		Conf:       conf}
+}
+
+// FlattenLayersCommand creates a new command to turn all layers into regular content.
+func FlattenLayersCommand(inFile, outFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.FLATTENLAYERS
+	return &Command{
+		Mode:    model.FLATTENLAYERS,
+		InFile:  &inFile,
+		OutFile: &outFile,
+		Conf:    conf}
+}
+
+// RunPipelineCommand creates a new command to run the processing pipeline described by jobFile.
+func RunPipelineCommand(jobFile string, conf *model.Configuration) *Command {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+	conf.Cmd = model.PIPELINE
+	return &Command{
+		Mode:   model.PIPELINE,
+		InFile: &jobFile,
+		Conf:   conf}
+}
@@ -265,7 +265,7 @@ func ListImagesFile(inFiles []string, selectedPages []string, conf *model.Config
 }
 
 // ListInfoFile returns formatted information about inFile.
-func ListInfoFile(inFile string, selectedPages []string, fonts bool, conf *model.Configuration) ([]string, error) {
+func ListInfoFile(inFile string, selectedPages []string, fonts, resources bool, conf *model.Configuration) ([]string, error) {
 	f, err := os.Open(inFile)
 	if err != nil {
 		return nil, err
@@ -287,6 +287,17 @@ func ListInfoFile(inFile string, selectedPages []string, fonts bool, conf *model
 		return nil, err
 	}
 
+	if resources {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		pr, err := api.PageResources(f, selectedPages, conf)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, pdfcpu.ListPageResources(pr)...)
+	}
+
 	return append([]string{inFile + ":"}, ss...), err
 }
 
@@ -357,7 +368,7 @@ func jsonInfo(info *pdfcpu.PDFInfo, pages types.IntSet) (map[string]model.PageBo
 	return nil, dims
 }
 
-func listInfoFilesJSON(inFiles []string, selectedPages []string, fonts bool, conf *model.Configuration) ([]string, error) {
+func listInfoFilesJSON(inFiles []string, selectedPages []string, fonts, resources bool, conf *model.Configuration) ([]string, error) {
 	var infos []*pdfcpu.PDFInfo
 
 	for _, fn := range inFiles {
@@ -380,6 +391,15 @@ func listInfoFilesJSON(inFiles []string, selectedPages []string, fonts bool, con
 
 		info.Boundaries, info.Dimensions = jsonInfo(info, pages)
 
+		if resources {
+			if _, err := f.Seek(0, io.SeekStart); err != nil {
+				return nil, err
+			}
+			if info.PageResources, err = api.PageResources(f, selectedPages, conf); err != nil {
+				return nil, err
+			}
+		}
+
 		infos = append(infos, info)
 	}
 
@@ -400,10 +420,10 @@ func listInfoFilesJSON(inFiles []string, selectedPages []string, fonts bool, con
 }
 
 // ListInfoFiles returns formatted information about inFiles.
-func ListInfoFiles(inFiles []string, selectedPages []string, fonts, json bool, conf *model.Configuration) ([]string, error) {
+func ListInfoFiles(inFiles []string, selectedPages []string, fonts, resources, json bool, conf *model.Configuration) ([]string, error) {
 
 	if json {
-		return listInfoFilesJSON(inFiles, selectedPages, fonts, conf)
+		return listInfoFilesJSON(inFiles, selectedPages, fonts, resources, conf)
 	}
 
 	var ss []string
@@ -412,7 +432,7 @@ func ListInfoFiles(inFiles []string, selectedPages []string, fonts, json bool, c
 		if i > 0 {
 			ss = append(ss, "")
 		}
-		ssx, err := ListInfoFile(fn, selectedPages, fonts, conf)
+		ssx, err := ListInfoFile(fn, selectedPages, fonts, resources, conf)
 		if err != nil {
 			if len(inFiles) == 1 {
 				return nil, err
@@ -671,3 +691,132 @@ func ListCertificatesAll(json bool, conf *model.Configuration) ([]string, error)
 
 	return nil, err
 }
+
+// ListMetadataMismatchesFile returns a list of mismatches between inFile's info dict and XMP metadata.
+func ListMetadataMismatchesFile(inFile string, conf *model.Configuration) ([]string, error) {
+	mm, err := api.MetadataMismatchesFile(inFile, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(mm) == 0 {
+		return []string{"info dict and XMP metadata are in sync"}, nil
+	}
+
+	ss := []string{}
+	for _, m := range mm {
+		ss = append(ss, m.String())
+	}
+
+	return ss, nil
+}
+
+// ListValidationResults validates inFiles and returns their findings formatted as text, json or sarif.
+func ListValidationResults(inFiles []string, continueOnError bool, format string, conf *model.Configuration) ([]string, error) {
+	var vrs []*model.ValidationResult
+
+	for _, fn := range inFiles {
+		vr, err := api.ValidationResultFile(fn, continueOnError, conf)
+		if err != nil {
+			return nil, err
+		}
+		vrs = append(vrs, vr)
+	}
+
+	switch format {
+
+	case "json":
+		s := struct {
+			Header      pdfcpu.Header             `json:"header"`
+			Validations []*model.ValidationResult `json:"validations"`
+		}{
+			Header:      pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+			Validations: vrs,
+		}
+		bb, err := json.MarshalIndent(s, "", "\t")
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(bb)}, nil
+
+	case "sarif":
+		bb, err := model.ValidationResultsSARIF(vrs)
+		if err != nil {
+			return nil, err
+		}
+		return []string{string(bb)}, nil
+	}
+
+	var ss []string
+
+	for i, vr := range vrs {
+		if i > 0 {
+			ss = append(ss, "")
+		}
+		ss = append(ss, fmt.Sprintf("validating(mode=%s) %s ...", vr.Mode, vr.FileName))
+		for _, f := range vr.Findings {
+			ss = append(ss, f.String())
+		}
+		if vr.Valid {
+			ss = append(ss, "validation ok")
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("validation failed: %d error(s)", vr.Errors()))
+	}
+
+	return ss, nil
+}
+
+// ListComparison returns the differences between inFile1 and inFile2 as text or JSON.
+func ListComparison(inFile1, inFile2, outFile string, visual, jsonOutput bool, conf *model.Configuration) ([]string, error) {
+	cr, err := api.CompareFile(inFile1, inFile2, outFile, visual, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if !jsonOutput {
+		return pdfcpu.ListComparison(cr), nil
+	}
+
+	s := struct {
+		Header     pdfcpu.Header           `json:"header"`
+		Comparison *model.ComparisonReport `json:"comparison"`
+	}{
+		Header:     pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Comparison: cr,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(bb)}, nil
+}
+
+// ListPageAnalysis returns the ink coverage of the pages of inFile and identifies blank and duplicate pages.
+func ListPageAnalysis(inFile string, jsonOutput bool, conf *model.Configuration) ([]string, error) {
+	aa, err := api.AnalyzePagesFile(inFile, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	if !jsonOutput {
+		return pdfcpu.ListPageAnalysis(aa), nil
+	}
+
+	s := struct {
+		Header pdfcpu.Header        `json:"header"`
+		Pages  []model.PageAnalysis `json:"pages"`
+	}{
+		Header: pdfcpu.Header{Version: "pdfcpu " + model.VersionStr, Creation: time.Now().Format("2006-01-02 15:04:05 MST")},
+		Pages:  aa,
+	}
+
+	bb, err := json.MarshalIndent(s, "", "\t")
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{string(bb)}, nil
+}
@@ -71,6 +71,9 @@ func processBookmarks(cmd *Command) (out []string, err error) {
 
 	case model.REMOVEBOOKMARKS:
 		return RemoveBookmarks(cmd)
+
+	case model.DETECTBOOKMARKS:
+		return DetectBookmarks(cmd)
 	}
 
 	return nil, nil
@@ -162,8 +165,23 @@ func processPageAnnotations(cmd *Command) (out []string, err error) {
 	case model.LISTANNOTATIONS:
 		out, err = ListAnnotations(cmd)
 
+	case model.ADDANNOTATIONS:
+		out, err = AddAnnotations(cmd)
+
+	case model.EXPORTANNOTATIONS:
+		out, err = ExportAnnotations(cmd)
+
+	case model.IMPORTANNOTATIONS:
+		out, err = ImportAnnotations(cmd)
+
+	case model.FLATTENANNOTATIONS:
+		out, err = FlattenAnnotations(cmd)
+
 	case model.REMOVEANNOTATIONS:
 		out, err = RemoveAnnotations(cmd)
+
+	case model.ADDAUTOLINKS:
+		out, err = AddAutoLinks(cmd)
 	}
 
 	return out, err
@@ -183,6 +201,9 @@ func processPageBoundaries(cmd *Command) (out []string, err error) {
 
 	case model.CROP:
 		return Crop(cmd)
+
+	case model.AUTOCROP:
+		return AutoCrop(cmd)
 	}
 
 	return nil, nil
@@ -220,6 +241,41 @@ func processPageMode(cmd *Command) (out []string, err error) {
 	return nil, nil
 }
 
+func processPageLabels(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTPAGELABELS:
+		return ListPageLabels(cmd)
+
+	case model.SETPAGELABELS:
+		return SetPageLabels(cmd)
+
+	case model.REMOVEPAGELABELS:
+		return RemovePageLabels(cmd)
+	}
+
+	return nil, nil
+}
+
+func processPageTransitions(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTPAGETRANSITIONS:
+		return ListPageTransitions(cmd)
+
+	case model.SETPAGETRANSITIONS:
+		return SetPageTransitions(cmd)
+
+	case model.REMOVEPAGETRANSITIONS:
+		return RemovePageTransitions(cmd)
+
+	case model.SETFULLSCREEN:
+		return SetFullScreen(cmd)
+	}
+
+	return nil, nil
+}
+
 func processPages(cmd *Command) (out []string, err error) {
 	switch cmd.Mode {
 
@@ -228,6 +284,9 @@ func processPages(cmd *Command) (out []string, err error) {
 
 	case model.REMOVEPAGES:
 		return RemovePages(cmd)
+
+	case model.ANALYZEPAGES:
+		return AnalyzePages(cmd)
 	}
 
 	return nil, nil
@@ -305,3 +364,60 @@ func processSignatures(cmd *Command) (out []string, err error) {
 
 	return nil, nil
 }
+
+func processMetadata(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTMETADATA:
+		return ListMetadata(cmd)
+
+	case model.SETMETADATA:
+		return SetMetadata(cmd)
+
+	case model.CHECKMETADATA:
+		return CheckMetadata(cmd)
+
+	case model.SYNCMETADATA:
+		return SyncMetadata(cmd)
+	}
+
+	return nil, nil
+}
+
+func processOutputIntents(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTOUTPUTINTENTS:
+		return ListOutputIntents(cmd)
+
+	case model.ADDOUTPUTINTENT:
+		return AddOutputIntent(cmd)
+
+	case model.REPLACEOUTPUTINTENT:
+		return ReplaceOutputIntent(cmd)
+	}
+
+	return nil, nil
+}
+
+func processLayers(cmd *Command) (out []string, err error) {
+	switch cmd.Mode {
+
+	case model.LISTLAYERS:
+		return ListLayers(cmd)
+
+	case model.SHOWLAYERS:
+		return ShowLayers(cmd)
+
+	case model.HIDELAYERS:
+		return HideLayers(cmd)
+
+	case model.REMOVELAYERS:
+		return RemoveLayers(cmd)
+
+	case model.FLATTENLAYERS:
+		return FlattenLayers(cmd)
+	}
+
+	return nil, nil
+}
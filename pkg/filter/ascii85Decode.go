@@ -17,6 +17,7 @@ limitations under the License.
 package filter
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/ascii85"
 	"io"
@@ -47,43 +48,52 @@ func (f ascii85Decode) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for an ASCII85Decode filter.
-func (f ascii85Decode) Decode(r io.Reader) (io.Reader, error) {
+func (f ascii85Decode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f ascii85Decode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
-
-	bb, err := getReaderBytes(r)
-	if err != nil {
-		return nil, err
-	}
-
-	// fmt.Printf("dump:\n%s", hex.Dump(bb))
+// DecodeLength returns a reader decoding r on demand up to the eod marker.
+func (f ascii85Decode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	decoder := ascii85.NewDecoder(&ascii85Reader{r: bufio.NewReader(r)})
+	return limitReadCloser(io.NopCloser(decoder), maxLen), nil
+}
 
-	l := len(bb)
-	if bb[l-1] == 0x0A || bb[l-1] == 0x0D {
-		bb = bb[:l-1]
-	}
+// ascii85Reader passes on the encoded bytes up to the eod marker.
+type ascii85Reader struct {
+	r   *bufio.Reader
+	eod bool
+}
 
-	if !bytes.HasSuffix(bb, []byte(eodASCII85)) {
-		return nil, errors.New("pdfcpu: Decode: missing eod marker")
+func (ar *ascii85Reader) Read(p []byte) (int, error) {
+	if ar.eod {
+		return 0, io.EOF
 	}
 
-	// Strip eod sequence: "~>"
-	bb = bb[:len(bb)-2]
-
-	decoder := ascii85.NewDecoder(bytes.NewReader(bb))
-
-	var b2 bytes.Buffer
-	if maxLen < 0 {
-		if _, err := io.Copy(&b2, decoder); err != nil {
-			return nil, err
+	n := 0
+	for n < len(p) {
+		c, err := ar.r.ReadByte()
+		if err == io.EOF {
+			if n > 0 {
+				return n, nil
+			}
+			return 0, errors.New("pdfcpu: Decode: missing eod marker")
 		}
-	} else {
-		if _, err := io.CopyN(&b2, decoder, maxLen); err != nil {
-			return nil, err
+		if err != nil {
+			return n, err
 		}
+
+		if c == eodASCII85[0] {
+			// Strip eod sequence: "~>"
+			if c, err = ar.r.ReadByte(); err != nil || c != eodASCII85[1] {
+				return n, errors.New("pdfcpu: Decode: missing eod marker")
+			}
+			ar.eod = true
+			break
+		}
+
+		p[n] = c
+		n++
 	}
 
-	return &b2, nil
+	return n, nil
 }
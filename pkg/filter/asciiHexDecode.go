@@ -17,6 +17,7 @@ limitations under the License.
 package filter
 
 import (
+	"bufio"
 	"bytes"
 	"encoding/hex"
 	"io"
@@ -46,41 +47,60 @@ func (f asciiHexDecode) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for an ASCIIHexDecode filter.
-func (f asciiHexDecode) Decode(r io.Reader) (io.Reader, error) {
+func (f asciiHexDecode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f asciiHexDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
-	bb, err := getReaderBytes(r)
-	if err != nil {
-		return nil, err
-	}
+// DecodeLength returns a reader decoding r on demand up to the eod marker.
+func (f asciiHexDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	return limitReadCloser(io.NopCloser(&asciiHexReader{r: bufio.NewReader(r)}), maxLen), nil
+}
 
-	var p []byte
+// asciiHexReader decodes hex digit pairs skipping white space and cutting off on eod.
+type asciiHexReader struct {
+	r   *bufio.Reader
+	eod bool
+}
 
-	// Remove any white space and cut off on eod
-	for i := 0; i < len(bb); i++ {
-		if bb[i] == eodHexDecode {
-			break
+func (hr *asciiHexReader) Read(p []byte) (int, error) {
+	n := 0
+
+	for n < len(p) && !hr.eod {
+		var digits [2]byte
+		i := 0
+		for i < 2 {
+			c, err := hr.r.ReadByte()
+			if err == io.EOF || c == eodHexDecode {
+				hr.eod = true
+				break
+			}
+			if err != nil {
+				return n, err
+			}
+			if !bytes.ContainsRune([]byte{0x09, 0x0A, 0x0C, 0x0D, 0x20}, rune(c)) {
+				digits[i] = c
+				i++
+			}
 		}
-		if !bytes.ContainsRune([]byte{0x09, 0x0A, 0x0C, 0x0D, 0x20}, rune(bb[i])) {
-			p = append(p, bb[i])
+
+		if i == 0 {
+			break
 		}
-	}
 
-	// if len == odd add "0"
-	if len(p)%2 == 1 {
-		p = append(p, '0')
-	}
+		// if len == odd add "0"
+		if i == 1 {
+			digits[1] = '0'
+		}
 
-	if maxLen < 0 {
-		maxLen = int64(hex.DecodedLen(len(p)))
+		if _, err := hex.Decode(p[n:n+1], digits[:]); err != nil {
+			return n, err
+		}
+		n++
 	}
-	dst := make([]byte, maxLen)
 
-	if _, err := hex.Decode(dst, p[:maxLen*2]); err != nil {
-		return nil, err
+	if n == 0 && hr.eod {
+		return 0, io.EOF
 	}
 
-	return bytes.NewBuffer(dst), nil
+	return n, nil
 }
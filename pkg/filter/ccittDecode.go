@@ -17,7 +17,6 @@ limitations under the License.
 package filter
 
 import (
-	"bytes"
 	"io"
 
 	"github.com/pdfcpu/pdfcpu/pkg/log"
@@ -31,16 +30,15 @@ type ccittDecode struct {
 
 // Encode implements encoding for a CCITTDecode filter.
 func (f ccittDecode) Encode(r io.Reader) (io.Reader, error) {
-	// TODO
-	return nil, nil
+	return f.encode(r)
 }
 
 // Decode implements decoding for a CCITTDecode filter.
-func (f ccittDecode) Decode(r io.Reader) (io.Reader, error) {
+func (f ccittDecode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f ccittDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
+func (f ccittDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
 	if log.TraceEnabled() {
 		log.Trace.Println("DecodeCCITT begin")
 	}
@@ -87,15 +85,5 @@ func (f ccittDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error)
 	}
 	rd := ccitt.NewReader(r, ccitt.MSB, mode, cols, rows, opts)
 
-	var b bytes.Buffer
-	written, err := io.Copy(&b, rd)
-	if err != nil {
-		return nil, err
-	}
-
-	if log.TraceEnabled() {
-		log.Trace.Printf("DecodeCCITT: decoded %d bytes.\n", written)
-	}
-
-	return &b, nil
+	return limitReadCloser(io.NopCloser(rd), maxLen), nil
 }
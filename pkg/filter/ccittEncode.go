@@ -0,0 +1,248 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// Code tables of ITU-T T.4.
+
+// ccittWhiteTermCodes are the terminating codes for white runs of 0..63 pixels.
+var ccittWhiteTermCodes = [...]string{
+	"00110101", "000111", "0111", "1000", "1011", "1100", "1110", "1111", "10011", "10100", "00111",
+	"01000", "001000", "000011", "110100", "110101", "101010", "101011", "0100111", "0001100",
+	"0001000", "0010111", "0000011", "0000100", "0101000", "0101011", "0010011", "0100100", "0011000",
+	"00000010", "00000011", "00011010", "00011011", "00010010", "00010011", "00010100", "00010101",
+	"00010110", "00010111", "00101000", "00101001", "00101010", "00101011", "00101100", "00101101",
+	"00000100", "00000101", "00001010", "00001011", "01010010", "01010011", "01010100", "01010101",
+	"00100100", "00100101", "01011000", "01011001", "01011010", "01011011", "01001010", "01001011",
+	"00110010", "00110011", "00110100",
+}
+
+// ccittBlackTermCodes are the terminating codes for black runs of 0..63 pixels.
+var ccittBlackTermCodes = [...]string{
+	"0000110111", "010", "11", "10", "011", "0011", "0010", "00011", "000101", "000100", "0000100",
+	"0000101", "0000111", "00000100", "00000111", "000011000", "0000010111", "0000011000",
+	"0000001000", "00001100111", "00001101000", "00001101100", "00000110111", "00000101000",
+	"00000010111", "00000011000", "000011001010", "000011001011", "000011001100", "000011001101",
+	"000001101000", "000001101001", "000001101010", "000001101011", "000011010010", "000011010011",
+	"000011010100", "000011010101", "000011010110", "000011010111", "000001101100", "000001101101",
+	"000011011010", "000011011011", "000001010100", "000001010101", "000001010110", "000001010111",
+	"000001100100", "000001100101", "000001010010", "000001010011", "000000100100", "000000110111",
+	"000000111000", "000000100111", "000000101000", "000001011000", "000001011001", "000000101011",
+	"000000101100", "000001011010", "000001100110", "000001100111",
+}
+
+// ccittWhiteMakeupCodes are the make-up codes for white runs of 64..1728 pixels.
+var ccittWhiteMakeupCodes = [...]string{
+	"11011", "10010", "010111", "0110111", "00110110", "00110111", "01100100", "01100101", "01101000",
+	"01100111", "011001100", "011001101", "011010010", "011010011", "011010100", "011010101",
+	"011010110", "011010111", "011011000", "011011001", "011011010", "011011011", "010011000",
+	"010011001", "010011010", "011000", "010011011",
+}
+
+// ccittBlackMakeupCodes are the make-up codes for black runs of 64..1728 pixels.
+var ccittBlackMakeupCodes = [...]string{
+	"0000001111", "000011001000", "000011001001", "000001011011", "000000110011", "000000110100",
+	"000000110101", "0000001101100", "0000001101101", "0000001001010", "0000001001011",
+	"0000001001100", "0000001001101", "0000001110010", "0000001110011", "0000001110100",
+	"0000001110101", "0000001110110", "0000001110111", "0000001010010", "0000001010011",
+	"0000001010100", "0000001010101", "0000001011010", "0000001011011", "0000001100100",
+	"0000001100101",
+}
+
+// ccittExtMakeupCodes are the make-up codes for runs of 1792..2560 pixels shared by both colors.
+var ccittExtMakeupCodes = [...]string{
+	"00000001000", "00000001100", "00000001101", "000000010010", "000000010011", "000000010100",
+	"000000010101", "000000010110", "000000010111", "000000011100", "000000011101", "000000011110",
+	"000000011111",
+}
+
+// Two-dimensional coding mode codes of ITU-T T.6.
+const (
+	ccittPassCode = "0001"
+	ccittHorCode  = "001"
+	ccittEOFB     = "000000000001000000000001"
+)
+
+// ccittVerticalCodes are the vertical mode codes for a1-b1 = -3..3.
+var ccittVerticalCodes = [...]string{"0000010", "000010", "010", "1", "011", "000011", "0000011"}
+
+type ccittBitWriter struct {
+	buf   bytes.Buffer
+	b     byte
+	nbits int
+}
+
+func (w *ccittBitWriter) writeCode(code string) {
+	for i := 0; i < len(code); i++ {
+		w.b <<= 1
+		if code[i] == '1' {
+			w.b |= 1
+		}
+		if w.nbits++; w.nbits == 8 {
+			w.buf.WriteByte(w.b)
+			w.b, w.nbits = 0, 0
+		}
+	}
+}
+
+func (w *ccittBitWriter) flush() {
+	if w.nbits > 0 {
+		w.buf.WriteByte(w.b << (8 - w.nbits))
+		w.b, w.nbits = 0, 0
+	}
+}
+
+func (w *ccittBitWriter) writeRun(n int, black bool) {
+	term, makeup := ccittWhiteTermCodes[:], ccittWhiteMakeupCodes[:]
+	if black {
+		term, makeup = ccittBlackTermCodes[:], ccittBlackMakeupCodes[:]
+	}
+	for n >= 2624 {
+		w.writeCode(ccittExtMakeupCodes[len(ccittExtMakeupCodes)-1])
+		n -= 2560
+	}
+	if n >= 1792 {
+		w.writeCode(ccittExtMakeupCodes[n/64-28])
+		n %= 64
+	} else if n >= 64 {
+		w.writeCode(makeup[n/64-1])
+		n %= 64
+	}
+	w.writeCode(term[n])
+}
+
+// ccittNextChange returns the position of the first pixel at or after start whose color differs from black.
+func ccittNextChange(line []bool, start int, black bool) int {
+	for i := start; i < len(line); i++ {
+		if line[i] != black {
+			return i
+		}
+	}
+	return len(line)
+}
+
+// encodeG4Line encodes line against the reference line ref using two-dimensional coding.
+func encodeG4Line(w *ccittBitWriter, line, ref []bool) {
+	cols := len(line)
+
+	// a0 starts on an imaginary white pixel left of the line.
+	a0, black := -1, false
+	a1 := ccittNextChange(line, 0, false)
+	b1 := ccittNextChange(ref, 0, false)
+
+	for {
+		b2 := ccittNextChange(ref, b1, !black)
+
+		switch d := a1 - b1; {
+
+		case b2 < a1:
+			w.writeCode(ccittPassCode)
+			a0 = b2
+
+		case d >= -3 && d <= 3:
+			w.writeCode(ccittVerticalCodes[d+3])
+			a0, black = a1, !black
+
+		default:
+			a2 := ccittNextChange(line, a1, !black)
+			w.writeCode(ccittHorCode)
+			w.writeRun(a1-max(a0, 0), black)
+			w.writeRun(a2-a1, !black)
+			a0 = a2
+		}
+
+		if a0 >= cols {
+			return
+		}
+
+		// The pixel at a0 has the color of a0.
+		a1 = ccittNextChange(line, a0, black)
+
+		// b1 is the first changing element on ref right of a0 and of opposite color to a0.
+		b1 = ccittNextChange(ref, ccittNextChange(ref, a0, !black), black)
+	}
+}
+
+// ccittG4Encode encodes rows of packed 1 bit pixels with CCITT Group 4 compression.
+func ccittG4Encode(bb []byte, cols, rows int, blackIs1 bool) ([]byte, error) {
+	rowLen := (cols + 7) / 8
+	if len(bb) < rowLen*rows {
+		return nil, errors.New("pdfcpu: ccitt: insufficient image data")
+	}
+
+	w := &ccittBitWriter{}
+	ref, line := make([]bool, cols), make([]bool, cols)
+
+	for y := 0; y < rows; y++ {
+		row := bb[y*rowLen:]
+		for x := 0; x < cols; x++ {
+			bit := row[x/8]&(0x80>>(x%8)) != 0
+			line[x] = bit == blackIs1
+		}
+		encodeG4Line(w, line, ref)
+		ref, line = line, ref
+	}
+
+	w.writeCode(ccittEOFB)
+	w.flush()
+
+	return w.buf.Bytes(), nil
+}
+
+func (f ccittDecode) encode(r io.Reader) (io.Reader, error) {
+	k, ok := f.parms["K"]
+	if !ok || k >= 0 {
+		return nil, errors.New("pdfcpu: filter CCITTFax encoding supports Group 4 only (K < 0)")
+	}
+
+	cols := 1728
+	if col, ok := f.parms["Columns"]; ok {
+		cols = col
+	}
+
+	rows, ok := f.parms["Rows"]
+	if !ok {
+		return nil, errors.New("pdfcpu: ccitt: missing DecodeParam \"Rows\"")
+	}
+
+	if v, ok := f.parms["EncodedByteAlign"]; ok && v == 1 {
+		return nil, errors.New("pdfcpu: ccitt: encoding with EncodedByteAlign currently unsupported")
+	}
+
+	blackIs1 := false
+	if v, ok := f.parms["BlackIs1"]; ok && v == 1 {
+		blackIs1 = true
+	}
+
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err = ccittG4Encode(bb, cols, rows, blackIs1)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewBuffer(bb), nil
+}
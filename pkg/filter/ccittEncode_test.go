@@ -0,0 +1,103 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter_test
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+)
+
+// bitmap returns rows of packed 1 bit pixels where set reports black pixels.
+func bitmap(cols, rows int, set func(x, y int) bool) []byte {
+	rowLen := (cols + 7) / 8
+	bb := make([]byte, rowLen*rows)
+	for y := 0; y < rows; y++ {
+		for x := 0; x < cols; x++ {
+			if set(x, y) {
+				bb[y*rowLen+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return bb
+}
+
+func TestCCITTG4EncodeDecode(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+
+	for _, tt := range []struct {
+		name       string
+		cols, rows int
+		set        func(x, y int) bool
+	}{
+		{"white", 100, 10, func(x, y int) bool { return false }},
+		{"black", 100, 10, func(x, y int) bool { return true }},
+		{"noise", 203, 50, func(x, y int) bool { return r.Intn(3) == 0 }},
+		{"blocks", 1000, 60, func(x, y int) bool { return (x/7+y/5)%2 == 0 }},
+		{"diagonal", 77, 77, func(x, y int) bool { return x > y }},
+		{"longRuns", 5300, 4, func(x, y int) bool { return x > 2700*y/2 }},
+	} {
+		for _, blackIs1 := range []int{0, 1} {
+			bb := bitmap(tt.cols, tt.rows, tt.set)
+			if blackIs1 == 0 {
+				for i := range bb {
+					bb[i] = ^bb[i]
+				}
+			}
+
+			parms := map[string]int{"K": -1, "Columns": tt.cols, "Rows": tt.rows, "BlackIs1": blackIs1}
+			f, err := filter.NewFilter(filter.CCITTFax, parms)
+			if err != nil {
+				t.Fatalf("%s: %v\n", tt.name, err)
+			}
+
+			enc, err := f.Encode(bytes.NewReader(bb))
+			if err != nil {
+				t.Fatalf("%s: encode: %v\n", tt.name, err)
+			}
+
+			dec, err := f.Decode(enc)
+			if err != nil {
+				t.Fatalf("%s: decode: %v\n", tt.name, err)
+			}
+
+			got, err := io.ReadAll(dec)
+			if err != nil {
+				t.Fatalf("%s: %v\n", tt.name, err)
+			}
+
+			if len(got) != len(bb) {
+				t.Fatalf("%s blackIs1=%d: length mismatch %d != %d\n", tt.name, blackIs1, len(got), len(bb))
+			}
+
+			rowLen := (tt.cols + 7) / 8
+			pad := byte(0xFF << (rowLen*8 - tt.cols))
+			for i := range got {
+				m := byte(0xFF)
+				if i%rowLen == rowLen-1 {
+					m = pad
+				}
+				if got[i]&m != bb[i]&m {
+					t.Fatalf("%s blackIs1=%d: mismatch at row %d byte %d\n", tt.name, blackIs1, i/rowLen, i%rowLen)
+				}
+			}
+		}
+	}
+}
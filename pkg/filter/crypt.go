@@ -0,0 +1,42 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"io"
+)
+
+// cryptFilter represents a Crypt filter (7.4.10).
+// Encryption and decryption are taken care of by the security handler,
+// so this filter just passes the data through.
+type cryptFilter struct {
+	baseFilter
+}
+
+// Encode implements encoding for a Crypt filter.
+func (f cryptFilter) Encode(r io.Reader) (io.Reader, error) {
+	return r, nil
+}
+
+// Decode implements decoding for a Crypt filter.
+func (f cryptFilter) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f cryptFilter) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	return limitReadCloser(io.NopCloser(r), maxLen), nil
+}
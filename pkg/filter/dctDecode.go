@@ -34,11 +34,11 @@ func (f dctDecode) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for a DCTDecode filter.
-func (f dctDecode) Decode(r io.Reader) (io.Reader, error) {
+func (f dctDecode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f dctDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
+func (f dctDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
 	im, err := jpeg.Decode(r)
 	if err != nil {
 		return nil, err
@@ -52,5 +52,5 @@ func (f dctDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
 		return nil, err
 	}
 
-	return &b, nil
+	return newBuffer(b.Bytes()), nil
 }
@@ -21,7 +21,6 @@ import (
 	"bytes"
 	"io"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pkg/errors"
 )
 
@@ -36,23 +35,34 @@ const (
 	JBIG2     = "JBIG2Decode"
 	DCT       = "DCTDecode"
 	JPX       = "JPXDecode"
+	Crypt     = "Crypt"
 )
 
 // ErrUnsupportedFilter signals unsupported filter encountered.
 var ErrUnsupportedFilter = errors.New("pdfcpu: filter not supported")
 
 // Filter defines an interface for encoding/decoding PDF object streams.
+//
+// Decoders are meant to be chained: the reader returned by Decode decodes lazily
+// while being consumed so a stream never needs to be held in memory as a whole.
+// Filters where decoding parts doesn't make sense (e.g. DCT) decode the whole stream up front.
+// The caller is responsible for closing the returned reader.
 type Filter interface {
 	Encode(r io.Reader) (io.Reader, error)
-	Decode(r io.Reader) (io.Reader, error)
+	Decode(r io.Reader) (io.ReadCloser, error)
 	// DecodeLength will decode at least maxLen bytes. For filters where decoding
 	// parts doesn't make sense (e.g. DCT), the whole stream is decoded.
 	// If maxLen < 0 is passed, the whole stream is decoded.
-	DecodeLength(r io.Reader, maxLen int64) (io.Reader, error)
+	DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error)
 }
 
 // NewFilter returns a filter for given filterName and an optional parameter dictionary.
+// Filters registered by the application take precedence over the built in filters.
 func NewFilter(filterName string, parms map[string]int) (filter Filter, err error) {
+	if factory, ok := registered(filterName); ok {
+		return factory(parms)
+	}
+
 	switch filterName {
 
 	case ASCII85:
@@ -76,16 +86,14 @@ func NewFilter(filterName string, parms map[string]int) (filter Filter, err erro
 	case DCT:
 		filter = dctDecode{baseFilter{parms}}
 
+	case Crypt:
+		filter = cryptFilter{baseFilter{}}
+
 	case JBIG2:
-		// Unsupported
-		fallthrough
+		filter = jbig2Decode{baseFilter: baseFilter{parms}}
 
 	case JPX:
-		// Unsupported
-		if log.InfoEnabled() {
-			log.Info.Printf("Filter not supported: <%s>", filterName)
-		}
-		err = ErrUnsupportedFilter
+		filter = jpxDecode{baseFilter{parms}}
 
 	default:
 		err = errors.Errorf("Invalid filter: <%s>", filterName)
@@ -105,13 +113,41 @@ type baseFilter struct {
 }
 
 func SupportsDecodeParms(f string) bool {
-	return f == CCITTFax || f == LZW || f == Flate
+	return f == CCITTFax || f == LZW || f == Flate || f == Crypt || Registered(f)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitReadCloser stops reading rc after maxLen bytes unless maxLen < 0.
+func limitReadCloser(rc io.ReadCloser, maxLen int64) io.ReadCloser {
+	if maxLen < 0 {
+		return rc
+	}
+	return readCloser{io.LimitReader(rc, maxLen), rc}
+}
+
+// buffer is the io.ReadCloser returned by filters decoding the whole stream at once.
+type buffer struct {
+	*bytes.Buffer
+}
+
+func (buffer) Close() error {
+	return nil
+}
+
+func newBuffer(bb []byte) io.ReadCloser {
+	return buffer{bytes.NewBuffer(bb)}
 }
 
 func getReaderBytes(r io.Reader) ([]byte, error) {
 	var bb []byte
 	if buf, ok := r.(*bytes.Buffer); ok {
 		bb = buf.Bytes()
+	} else if buf, ok := r.(buffer); ok {
+		bb = buf.Bytes()
 	} else {
 		var buf bytes.Buffer
 		if _, err := io.Copy(&buf, r); err != nil {
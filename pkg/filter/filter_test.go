@@ -17,8 +17,11 @@ limitations under the License.
 package filter_test
 
 import (
+	"bytes"
+	"compress/zlib"
 	"errors"
 	"io"
+	"math/rand"
 	"os"
 	"strings"
 	"testing"
@@ -38,8 +41,8 @@ func TestFilterSupport(t *testing.T) {
 		{filter.Flate, nil},
 		{filter.CCITTFax, nil},
 		{filter.DCT, nil},
-		{filter.JBIG2, filter.ErrUnsupportedFilter},
-		{filter.JPX, filter.ErrUnsupportedFilter},
+		{filter.JBIG2, nil},
+		{filter.JPX, nil},
 		{"INVALID_FILTER", errors.New("Invalid filter: <INVALID_FILTER>")},
 	}
 	for _, tt := range filtersTests {
@@ -271,3 +274,158 @@ func TestEncodeDecodeFilterPipeline(t *testing.T) {
 		encodeDecodeFilterPipeline(t, filename, []string{filter.ASCII85, filter.Flate})
 	}
 }
+
+type countingReader struct {
+	r io.Reader
+	n int
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += n
+	return n, err
+}
+
+func TestDecodeStreaming(t *testing.T) {
+	// 8 MB of barely compressible data.
+	raw := make([]byte, 8<<20)
+	rand.New(rand.NewSource(1)).Read(raw)
+
+	for _, fpl := range [][]string{{filter.Flate}, {filter.Flate, filter.ASCIIHex}, {filter.ASCII85, filter.LZW, filter.RunLength}} {
+
+		r := io.Reader(bytes.NewReader(raw))
+		for i := len(fpl) - 1; i >= 0; i-- {
+			r = encode(t, r, fpl[i])
+		}
+		enc, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cr := &countingReader{r: bytes.NewReader(enc)}
+		r = cr
+		for _, f := range fpl {
+			r = decode(t, r, f)
+		}
+
+		// Reading the first KB must not consume the whole stream.
+		bb := make([]byte, 1024)
+		if _, err := io.ReadFull(r, bb); err != nil {
+			t.Fatalf("%v: %v", fpl, err)
+		}
+		if !bytes.Equal(bb, raw[:1024]) {
+			t.Fatalf("%v: content mismatch", fpl)
+		}
+		if cr.n > len(enc)/10 {
+			t.Fatalf("%v: consumed %d of %d bytes", fpl, cr.n, len(enc))
+		}
+
+		rest, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("%v: %v", fpl, err)
+		}
+		if !bytes.Equal(rest, raw[1024:]) {
+			t.Fatalf("%v: content mismatch", fpl)
+		}
+	}
+}
+
+func TestFlatePredictorDecodeLength(t *testing.T) {
+	const columns, rows = 100, 1000
+
+	// PNG Up prediction of a gradient.
+	var enc bytes.Buffer
+	w := zlib.NewWriter(&enc)
+	want := make([]byte, 0, columns*rows)
+	for y := 0; y < rows; y++ {
+		row := make([]byte, columns+1)
+		row[0] = filter.PNGUp
+		for x := 0; x < columns; x++ {
+			if y == 0 {
+				row[x+1] = byte(x)
+			} else {
+				row[x+1] = 1
+			}
+			want = append(want, byte(x+y))
+		}
+		w.Write(row)
+	}
+	w.Close()
+
+	f, err := filter.NewFilter(filter.Flate, map[string]int{"Predictor": filter.PredictorUp, "Columns": columns})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, maxLen := range []int64{-1, 0, 1, 150, 5000, columns * rows} {
+		rc, err := f.DecodeLength(bytes.NewReader(enc.Bytes()), maxLen)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(rc)
+		if err != nil {
+			t.Fatalf("maxLen=%d: %v", maxLen, err)
+		}
+		if err := rc.Close(); err != nil {
+			t.Fatalf("maxLen=%d: %v", maxLen, err)
+		}
+		n := maxLen
+		if n < 0 {
+			n = int64(len(want))
+		}
+		if !bytes.Equal(got, want[:n]) {
+			t.Fatalf("maxLen=%d: content mismatch", maxLen)
+		}
+	}
+
+	// A partial pixel row is an error.
+	f, err = filter.NewFilter(filter.Flate, map[string]int{"Predictor": filter.PredictorUp, "Columns": columns + 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rc, err := f.Decode(bytes.NewReader(enc.Bytes()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := io.ReadAll(rc); err == nil {
+		t.Fatal("expected read error")
+	}
+}
+
+func TestASCIIDecode(t *testing.T) {
+	for _, tt := range []struct {
+		filterName, enc, want string
+		ok                    bool
+	}{
+		{filter.ASCIIHex, "48 65\n6c6C 6f>", "Hello", true},
+		{filter.ASCIIHex, "4865 7>ignored", "He\x70", true},
+		{filter.ASCIIHex, "4865", "He", true},
+		{filter.ASCIIHex, "48XY>", "", false},
+		{filter.ASCII85, "87cURDZ~>\n", "Hello", true},
+		{filter.ASCII85, "87cURDZ", "", false},
+		{filter.ASCII85, "", "", false},
+	} {
+		f, err := filter.NewFilter(tt.filterName, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rc, err := f.Decode(strings.NewReader(tt.enc))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(rc)
+		if !tt.ok {
+			if err == nil {
+				t.Errorf("%s %q: expected error", tt.filterName, tt.enc)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s %q: %v", tt.filterName, tt.enc, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("%s %q: got %q want %q", tt.filterName, tt.enc, got, tt.want)
+		}
+	}
+}
@@ -81,11 +81,12 @@ func (f flate) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for a Flate filter.
-func (f flate) Decode(r io.Reader) (io.Reader, error) {
+func (f flate) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f flate) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
+// DecodeLength returns a reader inflating r on demand.
+func (f flate) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
 	if log.TraceEnabled() {
 		log.Trace.Println("DecodeFlate begin")
 	}
@@ -94,25 +95,26 @@ func (f flate) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer rc.Close()
 
 	// Optional decode parameters need postprocessing.
-	return f.decodePostProcess(rc, maxLen)
+	return f.decodePostProcess(flateReader{rc}, maxLen)
 }
 
-func passThru(rin io.Reader, maxLen int64) (*bytes.Buffer, error) {
-	var b bytes.Buffer
-	var err error
-	if maxLen < 0 {
-		_, err = io.Copy(&b, rin)
-	} else {
-		_, err = io.CopyN(&b, rin, maxLen)
+// flateReader tolerates zlib streams with a bad checksum or missing their final block.
+type flateReader struct {
+	io.ReadCloser
+}
+
+func (fr flateReader) Read(p []byte) (int, error) {
+	n, err := fr.ReadCloser.Read(p)
+	if err == nil || err == io.EOF {
+		return n, err
 	}
-	if err != nil && strings.Contains(err.Error(), "invalid checksum") {
+	if isTruncatedZlib(err) {
 		if log.CLIEnabled() {
 			log.CLI.Println("skipped: truncated zlib stream")
 		}
-		err = nil
+		err = io.EOF
 	}
 	if err == io.ErrUnexpectedEOF {
 		// Workaround for missing support for partial flush in compress/flate.
@@ -120,9 +122,22 @@ func passThru(rin io.Reader, maxLen int64) (*bytes.Buffer, error) {
 		if log.ReadEnabled() {
 			log.Read.Println("flateDecode: ignoring unexpected EOF")
 		}
+		err = io.EOF
+	}
+	return n, err
+}
+
+// Close omits the errors already skipped by Read.
+func (fr flateReader) Close() error {
+	err := fr.ReadCloser.Close()
+	if err == io.ErrUnexpectedEOF || err != nil && isTruncatedZlib(err) {
 		err = nil
 	}
-	return &b, err
+	return err
+}
+
+func isTruncatedZlib(err error) bool {
+	return strings.Contains(err.Error(), "invalid checksum")
 }
 
 func intMemberOf(i int, list []int) bool {
@@ -274,26 +289,55 @@ func (f flate) parameters() (colors, bpc, columns int, err error) {
 	return colors, bpc, columns, nil
 }
 
-func checkBufLen(b bytes.Buffer, maxLen int64) bool {
-	return maxLen < 0 || int64(b.Len()) < maxLen
+// predictorReader undoes TIFF or PNG prediction one pixel row at a time.
+type predictorReader struct {
+	r                      io.Reader
+	predictor, colors, bpp int
+	pr, cr                 []byte // the bytes for the previous and current row.
+	row                    []byte // the unread part of the last decoded row.
+	err                    error
 }
 
-func process(w io.Writer, pr, cr []byte, predictor, colors, bytesPerPixel int) error {
-	d, err := processRow(pr, cr, predictor, colors, bytesPerPixel)
+func (pr *predictorReader) Read(p []byte) (int, error) {
+	for len(pr.row) == 0 {
+		if pr.err != nil {
+			return 0, pr.err
+		}
+		pr.nextRow()
+	}
+
+	n := copy(p, pr.row)
+	pr.row = pr.row[n:]
+
+	return n, nil
+}
+
+func (pr *predictorReader) nextRow() {
+	// Read decompressed bytes for one pixel row.
+	n, err := io.ReadFull(pr.r, pr.cr)
 	if err != nil {
-		return err
+		if err == io.ErrUnexpectedEOF {
+			err = errors.Errorf("pdfcpu: filter FlateDecode: read error, expected %d bytes, got: %d", len(pr.cr), n)
+		}
+		pr.err = err
+		return
 	}
 
-	_, err = w.Write(d)
+	d, err := processRow(pr.pr, pr.cr, pr.predictor, pr.colors, pr.bpp)
+	if err != nil {
+		pr.err = err
+		return
+	}
 
-	return err
+	pr.row = d
+	pr.pr, pr.cr = pr.cr, pr.pr
 }
 
 // decodePostProcess
-func (f flate) decodePostProcess(r io.Reader, maxLen int64) (io.Reader, error) {
+func (f flate) decodePostProcess(rc io.ReadCloser, maxLen int64) (io.ReadCloser, error) {
 	predictor, found := f.parms["Predictor"]
 	if !found || predictor == PredictorNo {
-		return passThru(r, maxLen)
+		return limitReadCloser(rc, maxLen), nil
 	}
 
 	if !intMemberOf(
@@ -306,63 +350,30 @@ func (f flate) decodePostProcess(r io.Reader, maxLen int64) (io.Reader, error) {
 			PredictorPaeth,
 			PredictorOptimum,
 		}) {
+		rc.Close()
 		return nil, errors.Errorf("pdfcpu: filter FlateDecode: undefined \"Predictor\" %d", predictor)
 	}
 
 	colors, bpc, columns, err := f.parameters()
 	if err != nil {
+		rc.Close()
 		return nil, err
 	}
 
-	bytesPerPixel := (bpc*colors + 7) / 8
-	rowSize := (bpc*colors*columns + 7) / 8
-
-	m := rowSize
+	m := (bpc*colors*columns + 7) / 8
 	if predictor != PredictorTIFF {
 		// PNG prediction uses a row filter byte prefixing the pixelbytes of a row.
 		m++
 	}
 
-	// cr and pr are the bytes for the current and previous row.
-	cr := make([]byte, m)
-	pr := make([]byte, m)
-
-	// Output buffer
-	var b bytes.Buffer
-
-	for checkBufLen(b, maxLen) {
-
-		// Read decompressed bytes for one pixel row.
-		n, err := io.ReadFull(r, cr)
-		if err != nil {
-			if err != io.EOF {
-				return nil, err
-			}
-			// eof
-			if n == 0 {
-				break
-			}
-		}
-
-		if n != m {
-			return nil, errors.Errorf("pdfcpu: filter FlateDecode: read error, expected %d bytes, got: %d", m, n)
-		}
-
-		if err := process(&b, pr, cr, predictor, colors, bytesPerPixel); err != nil {
-			return nil, err
-		}
-
-		if err == io.EOF {
-			break
-		}
-
-		pr, cr = cr, pr
-	}
-
-	if maxLen < 0 && b.Len()%rowSize > 0 {
-		log.Info.Printf("failed postprocessing: %d %d\n", b.Len(), rowSize)
-		return nil, errors.New("pdfcpu: filter FlateDecode: postprocessing failed")
+	pr := &predictorReader{
+		r:         rc,
+		predictor: predictor,
+		colors:    colors,
+		bpp:       (bpc*colors + 7) / 8,
+		pr:        make([]byte, m),
+		cr:        make([]byte, m),
 	}
 
-	return &b, nil
+	return limitReadCloser(readCloser{pr, rc}, maxLen), nil
 }
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+// qe represents a row of the probability estimation table (ITU T.88 Table E.1).
+type qe struct {
+	qe         uint32
+	nmps, nlps uint8
+	switchFlag bool
+}
+
+var qeTable = []qe{
+	{0x5601, 1, 1, true}, {0x3401, 2, 6, false}, {0x1801, 3, 9, false}, {0x0AC1, 4, 12, false},
+	{0x0521, 5, 29, false}, {0x0221, 38, 33, false}, {0x5601, 7, 6, true}, {0x5401, 8, 14, false},
+	{0x4801, 9, 14, false}, {0x3801, 10, 14, false}, {0x3001, 11, 17, false}, {0x2401, 12, 18, false},
+	{0x1C01, 13, 20, false}, {0x1601, 29, 21, false}, {0x5601, 15, 14, true}, {0x5401, 16, 14, false},
+	{0x5101, 17, 15, false}, {0x4801, 18, 16, false}, {0x3801, 19, 17, false}, {0x3401, 20, 18, false},
+	{0x3001, 21, 19, false}, {0x2801, 22, 19, false}, {0x2401, 23, 20, false}, {0x2201, 24, 21, false},
+	{0x1C01, 25, 22, false}, {0x1801, 26, 23, false}, {0x1601, 27, 24, false}, {0x1401, 28, 25, false},
+	{0x1201, 29, 26, false}, {0x1101, 30, 27, false}, {0x0AC1, 31, 28, false}, {0x09C1, 32, 29, false},
+	{0x08A1, 33, 30, false}, {0x0521, 34, 31, false}, {0x0441, 35, 32, false}, {0x02A1, 36, 33, false},
+	{0x0221, 37, 34, false}, {0x0141, 38, 35, false}, {0x0111, 39, 36, false}, {0x0085, 40, 37, false},
+	{0x0049, 41, 38, false}, {0x0025, 42, 39, false}, {0x0015, 43, 40, false}, {0x0009, 44, 41, false},
+	{0x0005, 45, 42, false}, {0x0001, 45, 43, false}, {0x5601, 46, 46, false},
+}
+
+// arithContexts holds adaptive context states: the index into qeTable shifted left by one or'ed with the MPS.
+type arithContexts []uint8
+
+// arithDecoder implements the MQ arithmetic decoder (ITU T.88 Annex E.3).
+type arithDecoder struct {
+	data     []byte
+	bp       int
+	c        uint32
+	a        uint32
+	ct       int
+	contexts map[string]arithContexts
+}
+
+func newArithDecoder(data []byte) *arithDecoder {
+	d := &arithDecoder{data: data, contexts: map[string]arithContexts{}}
+	d.c = uint32(d.byteAt(0)) << 16
+	d.byteIn()
+	d.c <<= 7
+	d.ct -= 7
+	d.a = 0x8000
+	return d
+}
+
+// byteAt returns the byte at i, 0xFF beyond the end of data.
+func (d *arithDecoder) byteAt(i int) byte {
+	if i >= len(d.data) {
+		return 0xFF
+	}
+	return d.data[i]
+}
+
+func (d *arithDecoder) byteIn() {
+	if d.byteAt(d.bp) == 0xFF {
+		if d.byteAt(d.bp+1) > 0x8F {
+			// Marker code: feed 1 bits.
+			d.c += 0xFF00
+			d.ct = 8
+			return
+		}
+		d.bp++
+		d.c += uint32(d.byteAt(d.bp)) << 9
+		d.ct = 7
+		return
+	}
+	d.bp++
+	d.c += uint32(d.byteAt(d.bp)) << 8
+	d.ct = 8
+}
+
+// context returns the context states for the named decoding procedure.
+func (d *arithDecoder) context(name string, size int) arithContexts {
+	cx, ok := d.contexts[name]
+	if !ok {
+		cx = make(arithContexts, size)
+		d.contexts[name] = cx
+	}
+	return cx
+}
+
+// decodeBit decodes a bit using context cx[i].
+func (d *arithDecoder) decodeBit(cx arithContexts, i int) int {
+	index, mps := cx[i]>>1, int(cx[i]&1)
+	q := qeTable[index]
+	d.a -= q.qe
+
+	var bit int
+
+	if d.c>>16 < q.qe {
+		// LPS exchange
+		if d.a < q.qe {
+			bit = mps
+			index = q.nmps
+		} else {
+			bit = 1 - mps
+			if q.switchFlag {
+				mps = bit
+			}
+			index = q.nlps
+		}
+		d.a = q.qe
+	} else {
+		d.c -= q.qe << 16
+		if d.a&0x8000 != 0 {
+			return mps
+		}
+		// MPS exchange
+		if d.a < q.qe {
+			bit = 1 - mps
+			if q.switchFlag {
+				mps = bit
+			}
+			index = q.nlps
+		} else {
+			bit = mps
+			index = q.nmps
+		}
+	}
+
+	// Renormalize.
+	for {
+		if d.ct == 0 {
+			d.byteIn()
+		}
+		d.a <<= 1
+		d.c <<= 1
+		d.ct--
+		if d.a&0x8000 != 0 {
+			break
+		}
+	}
+
+	cx[i] = index<<1 | uint8(mps)
+	return bit
+}
+
+// decodeInt decodes an integer using the named integer arithmetic decoding procedure (ITU T.88 Annex A.2).
+// ok is false for OOB.
+func (d *arithDecoder) decodeInt(name string) (v int, ok bool) {
+	cx := d.context(name, 512)
+	prev := 1
+
+	bits := func(n int) int {
+		v := 0
+		for i := 0; i < n; i++ {
+			bit := d.decodeBit(cx, prev)
+			if prev < 256 {
+				prev = prev<<1 | bit
+			} else {
+				prev = (prev<<1|bit)&511 | 256
+			}
+			v = v<<1 | bit
+		}
+		return v
+	}
+
+	s := bits(1)
+
+	switch {
+	case bits(1) == 0:
+		v = bits(2)
+	case bits(1) == 0:
+		v = bits(4) + 4
+	case bits(1) == 0:
+		v = bits(6) + 20
+	case bits(1) == 0:
+		v = bits(8) + 84
+	case bits(1) == 0:
+		v = bits(12) + 340
+	default:
+		v = bits(32) + 4436
+	}
+
+	if s == 1 {
+		if v == 0 {
+			return 0, false
+		}
+		v = -v
+	}
+
+	return v, true
+}
+
+// decodeIAID decodes a symbol ID of codeLen bits (ITU T.88 Annex A.3).
+func (d *arithDecoder) decodeIAID(codeLen int) int {
+	cx := d.context("IAID", 1<<(codeLen+1))
+	prev := 1
+	for i := 0; i < codeLen; i++ {
+		prev = prev<<1 | d.decodeBit(cx, prev)
+	}
+	return prev - 1<<codeLen
+}
@@ -0,0 +1,599 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pkg/errors"
+)
+
+// JBIG2 segment types (ITU T.88 7.3).
+const (
+	jbig2SymbolDictionary             = 0
+	jbig2IntermediateTextRegion       = 4
+	jbig2ImmediateTextRegion          = 6
+	jbig2ImmediateLosslessTextRegion  = 7
+	jbig2PatternDictionary            = 16
+	jbig2IntermediateHalftoneRegion   = 20
+	jbig2ImmediateHalftoneRegion      = 22
+	jbig2ImmediateLosslessHalftone    = 23
+	jbig2IntermediateGenericRegion    = 36
+	jbig2ImmediateGenericRegion       = 38
+	jbig2ImmediateLosslessGeneric     = 39
+	jbig2IntermediateRefinementRegion = 40
+	jbig2ImmediateRefinementRegion    = 42
+	jbig2ImmediateLosslessRefinement  = 43
+	jbig2PageInformation              = 48
+	jbig2EndOfPage                    = 49
+	jbig2EndOfStripe                  = 50
+	jbig2EndOfFile                    = 51
+)
+
+// errJBIG2Unsupported signals JBIG2 data using Huffman coding, halftone regions or extended templates.
+var errJBIG2Unsupported = errors.New("pdfcpu: jbig2: unsupported coding feature")
+
+type jbig2Decode struct {
+	baseFilter
+	globals []byte
+}
+
+// NewJBIG2Filter returns a JBIG2Decode filter using the decoded content of a JBIG2Globals stream.
+func NewJBIG2Filter(globals []byte) Filter {
+	return jbig2Decode{globals: globals}
+}
+
+// WithJBIG2Globals returns a copy of f using the decoded content of a JBIG2Globals stream.
+func (f jbig2Decode) WithJBIG2Globals(globals []byte) Filter {
+	f.globals = globals
+	return f
+}
+
+// Encode implements encoding for a JBIG2Decode filter.
+func (f jbig2Decode) Encode(r io.Reader) (io.Reader, error) {
+	return nil, errors.New("pdfcpu: filter JBIG2Decode: encoding unsupported")
+}
+
+// Decode implements decoding for a JBIG2Decode filter.
+func (f jbig2Decode) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f jbig2Decode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	if log.TraceEnabled() {
+		log.Trace.Println("DecodeJBIG2 begin")
+	}
+
+	bb, err := getReaderBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := &jbig2Decoder{
+		symbols:  map[uint32][]*jbig2Bitmap{},
+		contexts: map[uint32]map[string]arithContexts{},
+		regions:  map[uint32]*jbig2Bitmap{},
+	}
+
+	// Embedded streams don't have a file header and are organized sequentially (ITU T.88 Annex D.3).
+	for _, data := range [][]byte{f.globals, bb} {
+		if err := dec.decodeSegments(data); err != nil {
+			if err == errJBIG2Unsupported {
+				if log.InfoEnabled() {
+					log.Info.Printf("Filter not supported: <%s>: %v", JBIG2, err)
+				}
+				err = ErrUnsupportedFilter
+			}
+			return nil, err
+		}
+	}
+
+	if dec.page == nil {
+		return nil, errors.New("pdfcpu: jbig2: missing page information")
+	}
+
+	bb = dec.page.packed()
+
+	if log.TraceEnabled() {
+		log.Trace.Printf("DecodeJBIG2: decoded %d bytes.\n", len(bb))
+	}
+
+	if maxLen >= 0 && int64(len(bb)) > maxLen {
+		bb = bb[:maxLen]
+	}
+
+	return newBuffer(bb), nil
+}
+
+// jbig2Reader reads big endian integers and remembers running out of data.
+type jbig2Reader struct {
+	bb  []byte
+	off int
+	err error
+}
+
+func (r *jbig2Reader) next(n int) []byte {
+	if r.err != nil || n < 0 || r.off+n > len(r.bb) {
+		r.err = errJBIG2Corrupt
+		// Enough for any integer read.
+		return make([]byte, 4)
+	}
+	b := r.bb[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *jbig2Reader) u8() int {
+	return int(r.next(1)[0])
+}
+
+func (r *jbig2Reader) i8() int {
+	return int(int8(r.next(1)[0]))
+}
+
+func (r *jbig2Reader) u16() int {
+	return int(binary.BigEndian.Uint16(r.next(2)))
+}
+
+func (r *jbig2Reader) u32() uint32 {
+	return binary.BigEndian.Uint32(r.next(4))
+}
+
+// at reads n adaptive template pixels.
+func (r *jbig2Reader) at(n int) []jbig2Pixel {
+	at := make([]jbig2Pixel, n)
+	for i := range at {
+		at[i].x = r.i8()
+		at[i].y = r.i8()
+	}
+	return at
+}
+
+// jbig2Segment represents a segment (ITU T.88 7.2).
+type jbig2Segment struct {
+	number   uint32
+	typ      int
+	referred []uint32
+	data     []byte
+}
+
+// jbig2RegionInfo represents a region segment information field (ITU T.88 7.4.1).
+type jbig2RegionInfo struct {
+	w, h, x, y int
+	combOp     int
+}
+
+func (r *jbig2Reader) regionInfo() jbig2RegionInfo {
+	var ri jbig2RegionInfo
+	ri.w = int(r.u32())
+	ri.h = int(r.u32())
+	ri.x = int(r.u32())
+	ri.y = int(r.u32())
+	ri.combOp = r.u8() & 0x07
+	return ri
+}
+
+func (r *jbig2Reader) segment() (*jbig2Segment, error) {
+	s := &jbig2Segment{number: r.u32()}
+
+	flags := r.u8()
+	s.typ = flags & 0x3F
+
+	n := r.u8() >> 5
+	if n == 7 {
+		// Long form
+		r.off--
+		n = int(r.u32() & 0x1FFFFFFF)
+		r.next((n + 8) / 8)
+	} else if n > 4 {
+		return nil, errJBIG2Corrupt
+	}
+
+	for i := 0; i < n && r.err == nil; i++ {
+		switch {
+		case s.number <= 256:
+			s.referred = append(s.referred, uint32(r.u8()))
+		case s.number <= 65536:
+			s.referred = append(s.referred, uint32(r.u16()))
+		default:
+			s.referred = append(s.referred, r.u32())
+		}
+	}
+
+	// Page association
+	if flags&0x40 > 0 {
+		r.u32()
+	} else {
+		r.u8()
+	}
+
+	l := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if l == 0xFFFFFFFF {
+		var err error
+		if l, err = r.unknownSegmentLength(s.typ); err != nil {
+			return nil, err
+		}
+	}
+
+	s.data = r.next(int(l))
+
+	return s, r.err
+}
+
+// unknownSegmentLength returns the length of an immediate generic region segment
+// terminated by an end marker and its row count (ITU T.88 7.2.7).
+func (r *jbig2Reader) unknownSegmentLength(typ int) (uint32, error) {
+	if typ != jbig2ImmediateGenericRegion || r.off+18 > len(r.bb) {
+		return 0, errJBIG2Corrupt
+	}
+
+	marker := []byte{0, 0, 0, 0, 0, 0}
+	if r.bb[r.off+17]&0x01 == 0 {
+		marker[0], marker[1] = 0xFF, 0xAC
+	}
+	copy(marker[2:], r.bb[r.off+4:r.off+8])
+
+	i := bytes.Index(r.bb[r.off:], marker)
+	if i < 0 {
+		return 0, errJBIG2Corrupt
+	}
+
+	return uint32(i + len(marker)), nil
+}
+
+// jbig2Decoder decodes the segments of a page.
+type jbig2Decoder struct {
+	page         *jbig2Bitmap
+	pageDefPixel byte
+	pageStriped  bool // page height unknown
+	symbols      map[uint32][]*jbig2Bitmap
+	contexts     map[uint32]map[string]arithContexts
+	regions      map[uint32]*jbig2Bitmap
+}
+
+func (dec *jbig2Decoder) decodeSegments(bb []byte) error {
+	r := &jbig2Reader{bb: bb}
+
+	for r.off < len(bb) {
+		s, err := r.segment()
+		if err != nil {
+			return err
+		}
+
+		switch s.typ {
+
+		case jbig2EndOfPage, jbig2EndOfFile:
+			return nil
+
+		case jbig2PageInformation:
+			err = dec.pageInformation(s)
+
+		case jbig2EndOfStripe:
+			err = dec.endOfStripe(s)
+
+		case jbig2SymbolDictionary:
+			err = dec.symbolDictionary(s)
+
+		case jbig2IntermediateTextRegion, jbig2ImmediateTextRegion, jbig2ImmediateLosslessTextRegion:
+			err = dec.textRegion(s)
+
+		case jbig2IntermediateGenericRegion, jbig2ImmediateGenericRegion, jbig2ImmediateLosslessGeneric:
+			err = dec.genericRegion(s)
+
+		case jbig2IntermediateRefinementRegion, jbig2ImmediateRefinementRegion, jbig2ImmediateLosslessRefinement:
+			err = dec.refinementRegion(s)
+
+		case jbig2PatternDictionary, jbig2IntermediateHalftoneRegion, jbig2ImmediateHalftoneRegion, jbig2ImmediateLosslessHalftone:
+			err = errJBIG2Unsupported
+
+		default:
+			// Tables, profiles, extensions: not needed for decoding.
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pageInformation processes a page information segment (ITU T.88 7.4.8).
+func (dec *jbig2Decoder) pageInformation(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+	w, h := int(r.u32()), r.u32()
+	r.u32() // x resolution
+	r.u32() // y resolution
+	flags := r.u8()
+	if r.err != nil {
+		return r.err
+	}
+
+	dec.pageDefPixel = byte(flags>>2) & 0x01
+	dec.pageStriped = h == 0xFFFFFFFF
+	if dec.pageStriped {
+		h = 0
+	}
+
+	bm, err := newJBIG2Bitmap(w, int(h))
+	if err != nil {
+		return err
+	}
+	if dec.pageDefPixel == 1 {
+		bm.fill(1)
+	}
+	dec.page = bm
+
+	return nil
+}
+
+// endOfStripe processes an end of stripe segment (ITU T.88 7.4.10).
+func (dec *jbig2Decoder) endOfStripe(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+	y := int(r.u32())
+	if r.err != nil {
+		return r.err
+	}
+	if y < 0 || y+1 < 0 {
+		return errors.Errorf("pdfcpu: jbig2: invalid end of stripe row %d", y)
+	}
+	if dec.page != nil && dec.pageStriped {
+		return dec.page.grow(y+1, dec.pageDefPixel)
+	}
+	return nil
+}
+
+// storeRegion keeps the bitmap of an intermediate region or draws it onto the page.
+func (dec *jbig2Decoder) storeRegion(s *jbig2Segment, ri jbig2RegionInfo, bm *jbig2Bitmap) error {
+	switch s.typ {
+	case jbig2IntermediateTextRegion, jbig2IntermediateGenericRegion, jbig2IntermediateRefinementRegion:
+		dec.regions[s.number] = bm
+		return nil
+	}
+
+	if dec.page == nil {
+		return errors.New("pdfcpu: jbig2: missing page information")
+	}
+
+	if dec.pageStriped {
+		if ri.y < 0 || ri.y+bm.h < ri.y {
+			return errors.Errorf("pdfcpu: jbig2: invalid region position %d", ri.y)
+		}
+		if err := dec.page.grow(ri.y+bm.h, dec.pageDefPixel); err != nil {
+			return err
+		}
+	}
+
+	dec.page.combine(bm, ri.x, ri.y, ri.combOp)
+
+	return nil
+}
+
+// referredSymbols returns the symbols exported by the symbol dictionaries referred to by s.
+func (dec *jbig2Decoder) referredSymbols(s *jbig2Segment) []*jbig2Bitmap {
+	var symbols []*jbig2Bitmap
+	for _, nr := range s.referred {
+		symbols = append(symbols, dec.symbols[nr]...)
+	}
+	return symbols
+}
+
+// genericRegion processes a generic region segment (ITU T.88 7.4.6).
+func (dec *jbig2Decoder) genericRegion(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+	ri := r.regionInfo()
+
+	flags := r.u8()
+	p := jbig2GenericParms{
+		mmr:      flags&0x01 > 0,
+		w:        ri.w,
+		h:        ri.h,
+		template: flags >> 1 & 0x03,
+		tpgdon:   flags&0x08 > 0,
+	}
+
+	if flags&0x10 > 0 {
+		// Extended reference template
+		return errJBIG2Unsupported
+	}
+
+	if !p.mmr {
+		n := 1
+		if p.template == 0 {
+			n = 4
+		}
+		p.at = r.at(n)
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	var d *arithDecoder
+	if !p.mmr {
+		d = newArithDecoder(s.data[r.off:])
+	}
+
+	bm, err := decodeGenericRegion(d, s.data[r.off:], p)
+	if err != nil {
+		return err
+	}
+
+	return dec.storeRegion(s, ri, bm)
+}
+
+// refinementRegion processes a generic refinement region segment (ITU T.88 7.4.7).
+func (dec *jbig2Decoder) refinementRegion(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+	ri := r.regionInfo()
+
+	flags := r.u8()
+	p := jbig2RefinementParms{
+		w:        ri.w,
+		h:        ri.h,
+		template: flags & 0x01,
+		tpgron:   flags&0x02 > 0,
+	}
+
+	if p.template == 0 {
+		p.at = r.at(2)
+	}
+
+	if r.err != nil {
+		return r.err
+	}
+
+	if len(s.referred) > 0 {
+		// Refines an intermediate region.
+		p.ref = dec.regions[s.referred[0]]
+		delete(dec.regions, s.referred[0])
+	} else if dec.page != nil {
+		// Refines the page.
+		ref, err := newJBIG2Bitmap(ri.w, ri.h)
+		if err != nil {
+			return err
+		}
+		ref.combine(dec.page, -ri.x, -ri.y, jbig2REPLACE)
+		p.ref = ref
+	}
+
+	if p.ref == nil {
+		return errJBIG2Corrupt
+	}
+
+	bm, err := decodeRefinementRegion(newArithDecoder(s.data[r.off:]), p)
+	if err != nil {
+		return err
+	}
+
+	return dec.storeRegion(s, ri, bm)
+}
+
+// symbolDictionary processes a symbol dictionary segment (ITU T.88 7.4.2).
+func (dec *jbig2Decoder) symbolDictionary(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+
+	flags := r.u16()
+	if flags&0x01 > 0 {
+		// Huffman coding
+		return errJBIG2Unsupported
+	}
+
+	p := jbig2SymbolParms{
+		refAgg:      flags&0x02 > 0,
+		template:    flags >> 10 & 0x03,
+		refTemplate: flags >> 12 & 0x01,
+	}
+
+	n := 1
+	if p.template == 0 {
+		n = 4
+	}
+	p.at = r.at(n)
+
+	if p.refAgg && p.refTemplate == 0 {
+		p.refAt = r.at(2)
+	}
+
+	p.numExported = int(r.u32())
+	p.numNew = int(r.u32())
+
+	if r.err != nil {
+		return r.err
+	}
+
+	p.in = dec.referredSymbols(s)
+
+	d := newArithDecoder(s.data[r.off:])
+
+	if flags&0x0100 > 0 && len(s.referred) > 0 {
+		// Continue with the coding contexts retained by the last referred to symbol dictionary.
+		for k, cx := range dec.contexts[s.referred[len(s.referred)-1]] {
+			d.contexts[k] = append(arithContexts{}, cx...)
+		}
+	}
+
+	symbols, err := decodeSymbolDictionary(d, p)
+	if err != nil {
+		return err
+	}
+
+	dec.symbols[s.number] = symbols
+
+	if flags&0x0200 > 0 {
+		dec.contexts[s.number] = d.contexts
+	}
+
+	return nil
+}
+
+// textRegion processes a text region segment (ITU T.88 7.4.3).
+func (dec *jbig2Decoder) textRegion(s *jbig2Segment) error {
+	r := &jbig2Reader{bb: s.data}
+	ri := r.regionInfo()
+
+	flags := r.u16()
+	if flags&0x01 > 0 {
+		// Huffman coding
+		return errJBIG2Unsupported
+	}
+
+	dsOffset := flags >> 10 & 0x1F
+	if dsOffset > 0x0F {
+		dsOffset -= 0x20
+	}
+
+	p := jbig2TextParms{
+		w:           ri.w,
+		h:           ri.h,
+		refine:      flags&0x02 > 0,
+		strips:      1 << (flags >> 2 & 0x03),
+		refCorner:   flags >> 4 & 0x03,
+		transposed:  flags&0x40 > 0,
+		combOp:      flags >> 7 & 0x03,
+		defPixel:    byte(flags>>9) & 0x01,
+		dsOffset:    dsOffset,
+		refTemplate: flags >> 15 & 0x01,
+	}
+
+	if p.refine && p.refTemplate == 0 {
+		p.refAt = r.at(2)
+	}
+
+	p.numInstances = int(r.u32())
+
+	if r.err != nil {
+		return r.err
+	}
+
+	p.symbols = dec.referredSymbols(s)
+	p.codeLen = symbolCodeLength(len(p.symbols))
+
+	bm, err := decodeTextRegion(newArithDecoder(s.data[r.off:]), p)
+	if err != nil {
+		return err
+	}
+
+	return dec.storeRegion(s, ri, bm)
+}
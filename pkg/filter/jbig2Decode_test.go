@@ -0,0 +1,506 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+	"math/rand"
+	"testing"
+)
+
+// Test sequence for the arithmetic coder (ITU T.88 Annex H.2) using a single context.
+var (
+	mqPlain   = []byte{0x00, 0x02, 0x00, 0x51, 0x00, 0x00, 0x00, 0xC0, 0x03, 0x52, 0x87, 0x2A, 0xAA, 0xAA, 0xAA, 0xAA, 0x82, 0xC0, 0x20, 0x00, 0xFC, 0xD7, 0x9E, 0xF6, 0xBF, 0x7F, 0xED, 0x90, 0x4F, 0x46, 0xA3, 0xBF}
+	mqEncoded = []byte{0x84, 0xC7, 0x3B, 0xFC, 0xE1, 0xA1, 0x43, 0x04, 0x02, 0x20, 0x00, 0x00, 0x41, 0x0D, 0xBB, 0x86, 0xF4, 0x31, 0x7F, 0xFF, 0x88, 0xFF, 0x37, 0x47, 0x1A, 0xDB, 0x6A, 0xDF, 0xFF, 0xAC}
+)
+
+// mqEncoder implements the MQ arithmetic encoder (ITU T.88 Annex E.2).
+type mqEncoder struct {
+	a, c     uint32
+	ct       int
+	out      []byte // out[0] is the byte preceding the coded data.
+	contexts map[string]arithContexts
+}
+
+func newMQEncoder() *mqEncoder {
+	return &mqEncoder{a: 0x8000, ct: 12, out: []byte{0}, contexts: map[string]arithContexts{}}
+}
+
+func (e *mqEncoder) context(name string, size int) arithContexts {
+	cx, ok := e.contexts[name]
+	if !ok {
+		cx = make(arithContexts, size)
+		e.contexts[name] = cx
+	}
+	return cx
+}
+
+func (e *mqEncoder) byteOut() {
+	b := &e.out[len(e.out)-1]
+	if *b == 0xFF {
+		e.out = append(e.out, byte(e.c>>20))
+		e.c &= 0xFFFFF
+		e.ct = 7
+		return
+	}
+	if e.c < 0x8000000 {
+		e.out = append(e.out, byte(e.c>>19))
+		e.c &= 0x7FFFF
+		e.ct = 8
+		return
+	}
+	*b++
+	if *b == 0xFF {
+		e.c &= 0x7FFFFFF
+		e.out = append(e.out, byte(e.c>>20))
+		e.c &= 0xFFFFF
+		e.ct = 7
+		return
+	}
+	e.out = append(e.out, byte(e.c>>19))
+	e.c &= 0x7FFFF
+	e.ct = 8
+}
+
+func (e *mqEncoder) renorm() {
+	for {
+		e.a <<= 1
+		e.c <<= 1
+		e.ct--
+		if e.ct == 0 {
+			e.byteOut()
+		}
+		if e.a&0x8000 != 0 {
+			return
+		}
+	}
+}
+
+func (e *mqEncoder) encodeBit(cx arithContexts, i, bit int) {
+	index, mps := cx[i]>>1, int(cx[i]&1)
+	q := qeTable[index]
+	e.a -= q.qe
+
+	if bit != mps {
+		if e.a < q.qe {
+			e.c += q.qe
+		} else {
+			e.a = q.qe
+		}
+		if q.switchFlag {
+			mps = 1 - mps
+		}
+		cx[i] = q.nlps<<1 | uint8(mps)
+		e.renorm()
+		return
+	}
+
+	if e.a&0x8000 != 0 {
+		e.c += q.qe
+		return
+	}
+	if e.a < q.qe {
+		e.a = q.qe
+	} else {
+		e.c += q.qe
+	}
+	cx[i] = q.nmps<<1 | uint8(mps)
+	e.renorm()
+}
+
+func (e *mqEncoder) flush() []byte {
+	t := e.c + e.a
+	e.c |= 0xFFFF
+	if e.c >= t {
+		e.c -= 0x8000
+	}
+	e.c <<= e.ct
+	e.byteOut()
+	e.c <<= e.ct
+	e.byteOut()
+	if e.out[len(e.out)-1] != 0xFF {
+		e.out = append(e.out, 0xFF)
+	}
+	return append(e.out[1:], 0xAC)
+}
+
+// encodeInt encodes v or OOB using the named integer arithmetic encoding procedure (ITU T.88 Annex A.2).
+func (e *mqEncoder) encodeInt(name string, v int, oob bool) {
+	cx := e.context(name, 512)
+	prev := 1
+
+	bits := func(v, n int) {
+		for i := n - 1; i >= 0; i-- {
+			bit := v >> i & 1
+			e.encodeBit(cx, prev, bit)
+			if prev < 256 {
+				prev = prev<<1 | bit
+			} else {
+				prev = (prev<<1|bit)&511 | 256
+			}
+		}
+	}
+
+	if oob {
+		bits(0x08, 4)
+		return
+	}
+
+	if v < 0 {
+		bits(1, 1)
+		v = -v
+	} else {
+		bits(0, 1)
+	}
+
+	switch {
+	case v < 4:
+		bits(0, 1)
+		bits(v, 2)
+	case v < 20:
+		bits(2, 2)
+		bits(v-4, 4)
+	case v < 84:
+		bits(6, 3)
+		bits(v-20, 6)
+	case v < 340:
+		bits(14, 4)
+		bits(v-84, 8)
+	case v < 4436:
+		bits(30, 5)
+		bits(v-340, 12)
+	default:
+		bits(31, 5)
+		bits(v-4436, 32)
+	}
+}
+
+func (e *mqEncoder) encodeIAID(id, codeLen int) {
+	cx := e.context("IAID", 1<<(codeLen+1))
+	prev := 1
+	for i := codeLen - 1; i >= 0; i-- {
+		bit := id >> i & 1
+		e.encodeBit(cx, prev, bit)
+		prev = prev<<1 | bit
+	}
+}
+
+func (e *mqEncoder) encodeGeneric(bm *jbig2Bitmap, template int, at []jbig2Pixel, tpgdon bool) {
+	tmpl := genericTemplate(template, at)
+	cx := e.context("GB", 1<<len(tmpl))
+	ltp := 0
+
+	for y := 0; y < bm.h; y++ {
+		if tpgdon {
+			typical := 0
+			if y > 0 && bytes.Equal(bm.pix[y*bm.w:(y+1)*bm.w], bm.pix[(y-1)*bm.w:y*bm.w]) {
+				typical = 1
+			}
+			e.encodeBit(cx, jbig2GenericSLTPContexts[template], typical^ltp)
+			if ltp = typical; ltp == 1 {
+				continue
+			}
+		}
+		for x := 0; x < bm.w; x++ {
+			c := 0
+			for _, px := range tmpl {
+				c = c<<1 | bm.at(x+px.x, y+px.y)
+			}
+			e.encodeBit(cx, c, bm.at(x, y))
+		}
+	}
+}
+
+func (e *mqEncoder) encodeRefinement(bm, ref *jbig2Bitmap, at []jbig2Pixel) {
+	t := jbig2RefinementTemplates[0]
+	coding := append(append([]jbig2Pixel{}, t.coding...), at[0])
+	reference := append(append([]jbig2Pixel{}, t.reference...), at[1])
+	cx := e.context("GR", 1<<(len(coding)+len(reference)))
+
+	for y := 0; y < bm.h; y++ {
+		for x := 0; x < bm.w; x++ {
+			c := 0
+			for _, px := range coding {
+				c = c<<1 | bm.at(x+px.x, y+px.y)
+			}
+			for _, px := range reference {
+				c = c<<1 | ref.at(x+px.x, y+px.y)
+			}
+			e.encodeBit(cx, c, bm.at(x, y))
+		}
+	}
+}
+
+func TestMQDecoder(t *testing.T) {
+	e := newMQEncoder()
+	cx := make(arithContexts, 1)
+	for i := 0; i < len(mqPlain)*8; i++ {
+		e.encodeBit(cx, 0, int(mqPlain[i/8]>>(7-i%8)&1))
+	}
+	if got := e.flush(); !bytes.Equal(got, mqEncoded) {
+		t.Fatalf("encode: got % X\n", got)
+	}
+
+	d := newArithDecoder(mqEncoded)
+	cx = make(arithContexts, 1)
+	got := make([]byte, len(mqPlain))
+	for i := 0; i < len(got)*8; i++ {
+		got[i/8] |= byte(d.decodeBit(cx, 0) << (7 - i%8))
+	}
+	if !bytes.Equal(got, mqPlain) {
+		t.Fatalf("decode: got % X\n", got)
+	}
+}
+
+func jbig2TestBitmap(w, h int, set func(x, y int) bool) *jbig2Bitmap {
+	bm, _ := newJBIG2Bitmap(w, h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if set(x, y) {
+				bm.pix[y*w+x] = 1
+			}
+		}
+	}
+	return bm
+}
+
+func jbig2Glyph(rows ...string) *jbig2Bitmap {
+	return jbig2TestBitmap(len(rows[0]), len(rows), func(x, y int) bool { return rows[y][x] == '#' })
+}
+
+// jbig2TestSegment returns a segment with a one byte page association.
+func jbig2TestSegment(nr uint32, typ byte, referred []byte, data ...[]byte) []byte {
+	bb := binary.BigEndian.AppendUint32(nil, nr)
+	bb = append(bb, typ, byte(len(referred)<<5))
+	bb = append(bb, referred...)
+	bb = append(bb, 1)
+	d := bytes.Join(data, nil)
+	bb = binary.BigEndian.AppendUint32(bb, uint32(len(d)))
+	return append(bb, d...)
+}
+
+func jbig2TestU32(ii ...int) []byte {
+	var bb []byte
+	for _, i := range ii {
+		bb = binary.BigEndian.AppendUint32(bb, uint32(i))
+	}
+	return bb
+}
+
+func jbig2TestPage(w, h int) []byte {
+	return jbig2TestSegment(0, jbig2PageInformation, nil, jbig2TestU32(w, h, 0, 0), []byte{0, 0, 0})
+}
+
+func jbig2TestDecode(t *testing.T, globals, bb []byte, want *jbig2Bitmap) {
+	t.Helper()
+
+	f := NewJBIG2Filter(globals)
+	r, err := f.Decode(bytes.NewReader(bb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(got, want.packed()) {
+		t.Fatalf("decoded bitmap mismatch\n")
+	}
+}
+
+var jbig2TestAT = [][]jbig2Pixel{{{3, -1}, {-3, -1}, {2, -2}, {-2, -2}}, {{3, -1}}, {{2, -1}}, {{2, -1}}}
+
+func TestJBIG2GenericRegion(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	bm := jbig2TestBitmap(203, 50, func(x, y int) bool { return y%10 < 5 && (x/7+y/3)%2 == 0 || r.Intn(20) == 0 })
+
+	for template := 0; template < 4; template++ {
+		for _, tpgdon := range []bool{false, true} {
+			e := newMQEncoder()
+			e.encodeGeneric(bm, template, jbig2TestAT[template], tpgdon)
+
+			flags := byte(template << 1)
+			if tpgdon {
+				flags |= 0x08
+			}
+			var at []byte
+			for _, px := range jbig2TestAT[template] {
+				at = append(at, byte(px.x), byte(px.y))
+			}
+
+			bb := append(jbig2TestPage(bm.w, bm.h),
+				jbig2TestSegment(1, jbig2ImmediateGenericRegion, nil, jbig2TestU32(bm.w, bm.h, 0, 0), []byte{jbig2OR, flags}, at, e.flush())...)
+
+			jbig2TestDecode(t, nil, bb, bm)
+		}
+	}
+}
+
+func TestJBIG2GenericRegionMMR(t *testing.T) {
+	bm := jbig2TestBitmap(77, 40, func(x, y int) bool { return x > y || (x+y)%9 == 0 })
+
+	mmr, err := ccittG4Encode(bm.packed(), bm.w, bm.h, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Placed at 3,5 onto a larger page.
+	bb := append(jbig2TestPage(100, 50), jbig2TestSegment(1, jbig2ImmediateGenericRegion, nil, jbig2TestU32(bm.w, bm.h, 3, 5), []byte{jbig2OR, 0x01}, mmr)...)
+
+	want, _ := newJBIG2Bitmap(100, 50)
+	want.combine(bm, 3, 5, jbig2OR)
+
+	jbig2TestDecode(t, nil, bb, want)
+}
+
+func TestJBIG2SymbolsAndRefinement(t *testing.T) {
+	glyphs := []*jbig2Bitmap{
+		jbig2Glyph(
+			".###.",
+			"#...#",
+			"#####",
+			"#...#",
+			"#...#"),
+		jbig2Glyph(
+			"####.",
+			"#...#",
+			"####.",
+			"#...#",
+			"####."),
+		jbig2Glyph(
+			"#",
+			"#",
+			"#",
+			"#",
+			"#",
+			"#",
+			"#"),
+	}
+
+	// A symbol dictionary segment exporting the glyphs within JBIG2Globals.
+	e := newMQEncoder()
+	h := 0
+	for _, hc := range [][]*jbig2Bitmap{glyphs[:2], glyphs[2:]} {
+		e.encodeInt("IADH", hc[0].h-h, false)
+		h = hc[0].h
+		w := 0
+		for _, g := range hc {
+			e.encodeInt("IADW", g.w-w, false)
+			w = g.w
+			e.encodeGeneric(g, 0, jbig2TestAT[0], false)
+		}
+		e.encodeInt("IADW", 0, true)
+	}
+	e.encodeInt("IAEX", 0, false)
+	e.encodeInt("IAEX", len(glyphs), false)
+
+	globals := jbig2TestSegment(0, jbig2SymbolDictionary, nil, []byte{0, 0, 3, 0xFF, 0xFD, 0xFF, 2, 0xFE, 0xFE, 0xFE}, jbig2TestU32(len(glyphs), len(glyphs)), e.flush())
+
+	// A text region placing the glyphs with the second instance of glyph 0 getting refined.
+	refined := jbig2Glyph(
+		".###.",
+		"#...#",
+		"#####",
+		"#...#",
+		"##.##")
+
+	want, _ := newJBIG2Bitmap(40, 12)
+	instances := []struct {
+		id   int
+		s, t int
+		bm   *jbig2Bitmap
+	}{
+		{0, 2, 1, glyphs[0]},
+		{1, 9, 1, glyphs[1]},
+		{2, 16, 1, glyphs[2]},
+		{0, 3, 9, refined},
+		{1, 10, 9, glyphs[1]},
+	}
+
+	e = newMQEncoder()
+	e.encodeInt("IADT", 0, false)
+	stripT, firstS := 0, 0
+	for i, inst := range instances {
+		if i == 0 || inst.t != instances[i-1].t {
+			if i > 0 {
+				e.encodeInt("IADS", 0, true)
+			}
+			e.encodeInt("IADT", inst.t-stripT, false)
+			e.encodeInt("IAFS", inst.s-firstS, false)
+			stripT, firstS = inst.t, inst.s
+		} else {
+			prev := instances[i-1]
+			e.encodeInt("IADS", inst.s-(prev.s+prev.bm.w-1), false)
+		}
+		e.encodeIAID(inst.id, symbolCodeLength(len(glyphs)))
+		if inst.bm == glyphs[inst.id] {
+			e.encodeInt("IARI", 0, false)
+		} else {
+			e.encodeInt("IARI", 1, false)
+			for _, k := range []string{"IARDW", "IARDH", "IARDX", "IARDY"} {
+				e.encodeInt(k, 0, false)
+			}
+			e.encodeRefinement(inst.bm, glyphs[inst.id], []jbig2Pixel{{-1, -1}, {-1, -1}})
+		}
+		want.combine(inst.bm, inst.s, inst.t, jbig2OR)
+	}
+	e.encodeInt("IADS", 0, true)
+
+	// REFCORNER TOPLEFT, refinement using template 0.
+	flags := []byte{0x00, 0x12, 0xFF, 0xFF, 0xFF, 0xFF}
+
+	bb := append(jbig2TestPage(want.w, want.h),
+		jbig2TestSegment(1, jbig2ImmediateTextRegion, []byte{0}, jbig2TestU32(want.w, want.h, 0, 0), []byte{jbig2OR}, flags, jbig2TestU32(len(instances)), e.flush())...)
+
+	jbig2TestDecode(t, globals, bb, want)
+
+	// Without JBIG2Globals the referred to symbol dictionary is missing.
+	if _, err := NewJBIG2Filter(nil).Decode(bytes.NewReader(bb)); err == nil {
+		t.Fatal("missing symbols: expected error")
+	}
+}
+
+func TestJBIG2StripedPageLimits(t *testing.T) {
+	bm := jbig2TestBitmap(8, 1, func(x, y int) bool { return x%2 == 0 })
+	e := newMQEncoder()
+	e.encodeGeneric(bm, 0, jbig2TestAT[0], false)
+	var at []byte
+	for _, px := range jbig2TestAT[0] {
+		at = append(at, byte(px.x), byte(px.y))
+	}
+
+	for _, tt := range []struct {
+		name string
+		bb   []byte
+	}{
+		{"end of stripe beyond size limit",
+			append(jbig2TestPage(1<<30, -1), jbig2TestSegment(1, jbig2EndOfStripe, nil, jbig2TestU32(0x7FFFFFFF))...)},
+		{"end of stripe at invalid row",
+			append(jbig2TestPage(8, -1), jbig2TestSegment(1, jbig2EndOfStripe, nil, jbig2TestU32(-1))...)},
+		{"region beyond size limit",
+			append(jbig2TestPage(bm.w, -1),
+				jbig2TestSegment(1, jbig2ImmediateGenericRegion, nil, jbig2TestU32(bm.w, bm.h, 0, 1<<29), []byte{jbig2OR, 0x00}, at, e.flush())...)},
+	} {
+		if _, err := NewJBIG2Filter(nil).Decode(bytes.NewReader(tt.bb)); err == nil {
+			t.Fatalf("%s: expected error\n", tt.name)
+		}
+	}
+}
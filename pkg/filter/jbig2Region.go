@@ -0,0 +1,313 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	"golang.org/x/image/ccitt"
+)
+
+// JBIG2 region combination operators.
+const (
+	jbig2OR = iota
+	jbig2AND
+	jbig2XOR
+	jbig2XNOR
+	jbig2REPLACE
+)
+
+// jbig2Bitmap is a bilevel image using one byte per pixel where 1 means black.
+type jbig2Bitmap struct {
+	w, h int
+	pix  []byte
+}
+
+func newJBIG2Bitmap(w, h int) (*jbig2Bitmap, error) {
+	if w < 0 || h < 0 || int64(w)*int64(h) > 1<<30 {
+		return nil, errors.Errorf("pdfcpu: jbig2: invalid bitmap size %dx%d", w, h)
+	}
+	return &jbig2Bitmap{w: w, h: h, pix: make([]byte, w*h)}, nil
+}
+
+func (bm *jbig2Bitmap) at(x, y int) int {
+	if x < 0 || y < 0 || x >= bm.w || y >= bm.h {
+		return 0
+	}
+	return int(bm.pix[y*bm.w+x])
+}
+
+func (bm *jbig2Bitmap) fill(v byte) {
+	for i := range bm.pix {
+		bm.pix[i] = v
+	}
+}
+
+// grow extends bm to h rows filled with v.
+func (bm *jbig2Bitmap) grow(h int, v byte) error {
+	if h < 0 || int64(bm.w)*int64(h) > 1<<30 {
+		return errors.Errorf("pdfcpu: jbig2: invalid bitmap size %dx%d", bm.w, h)
+	}
+	if h <= bm.h {
+		return nil
+	}
+	pix := make([]byte, bm.w*h)
+	copy(pix, bm.pix)
+	for i := len(bm.pix); i < len(pix); i++ {
+		pix[i] = v
+	}
+	bm.h, bm.pix = h, pix
+	return nil
+}
+
+// combine draws src onto bm at x,y using op.
+func (bm *jbig2Bitmap) combine(src *jbig2Bitmap, x, y, op int) {
+	for sy := 0; sy < src.h; sy++ {
+		dy := y + sy
+		if dy < 0 || dy >= bm.h {
+			continue
+		}
+		for sx := 0; sx < src.w; sx++ {
+			dx := x + sx
+			if dx < 0 || dx >= bm.w {
+				continue
+			}
+			s, d := src.pix[sy*src.w+sx], &bm.pix[dy*bm.w+dx]
+			switch op {
+			case jbig2OR:
+				*d |= s
+			case jbig2AND:
+				*d &= s
+			case jbig2XOR:
+				*d ^= s
+			case jbig2XNOR:
+				*d = 1 - (*d ^ s)
+			default:
+				*d = s
+			}
+		}
+	}
+}
+
+// packed returns the rows of bm packed into bytes using PDF's convention of 0 meaning black.
+func (bm *jbig2Bitmap) packed() []byte {
+	rowLen := (bm.w + 7) / 8
+	bb := make([]byte, rowLen*bm.h)
+	for y := 0; y < bm.h; y++ {
+		for x := 0; x < bm.w; x++ {
+			if bm.pix[y*bm.w+x] == 1 {
+				bb[y*rowLen+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	for i := range bb {
+		bb[i] = ^bb[i]
+	}
+	return bb
+}
+
+type jbig2Pixel struct {
+	x, y int
+}
+
+// genericTemplate returns the context template of the generic region decoding procedure (ITU T.88 6.2.5.3)
+// for the adaptive template pixels at, most significant context bit first.
+func genericTemplate(template int, at []jbig2Pixel) []jbig2Pixel {
+	switch template {
+	case 0:
+		return []jbig2Pixel{
+			at[3], {-1, -2}, {0, -2}, {1, -2}, at[2],
+			at[1], {-2, -1}, {-1, -1}, {0, -1}, {1, -1}, {2, -1}, at[0],
+			{-4, 0}, {-3, 0}, {-2, 0}, {-1, 0}}
+	case 1:
+		return []jbig2Pixel{
+			{-1, -2}, {0, -2}, {1, -2}, {2, -2},
+			{-2, -1}, {-1, -1}, {0, -1}, {1, -1}, {2, -1}, at[0],
+			{-3, 0}, {-2, 0}, {-1, 0}}
+	case 2:
+		return []jbig2Pixel{
+			{-1, -2}, {0, -2}, {1, -2},
+			{-2, -1}, {-1, -1}, {0, -1}, {1, -1}, at[0],
+			{-2, 0}, {-1, 0}}
+	}
+	return []jbig2Pixel{
+		{-3, -1}, {-2, -1}, {-1, -1}, {0, -1}, {1, -1}, at[0],
+		{-4, 0}, {-3, 0}, {-2, 0}, {-1, 0}}
+}
+
+// Contexts used for decoding SLTP (ITU T.88 6.2.5.7).
+var jbig2GenericSLTPContexts = []int{0x9B25, 0x0795, 0x00E5, 0x0195}
+
+// jbig2GenericParms represents the parameters of the generic region decoding procedure (ITU T.88 6.2.2).
+type jbig2GenericParms struct {
+	mmr      bool
+	w, h     int
+	template int
+	tpgdon   bool
+	at       []jbig2Pixel
+}
+
+// decodeGenericRegion runs the generic region decoding procedure (ITU T.88 6.2).
+func decodeGenericRegion(d *arithDecoder, data []byte, p jbig2GenericParms) (*jbig2Bitmap, error) {
+	if p.mmr {
+		return decodeMMR(data, p.w, p.h)
+	}
+
+	bm, err := newJBIG2Bitmap(p.w, p.h)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl := genericTemplate(p.template, p.at)
+	cx := d.context("GB", 1<<len(tmpl))
+	ltp := 0
+
+	for y := 0; y < p.h; y++ {
+		if p.tpgdon {
+			ltp ^= d.decodeBit(cx, jbig2GenericSLTPContexts[p.template])
+			if ltp == 1 {
+				// Typical row: a copy of the row above.
+				if y > 0 {
+					copy(bm.pix[y*p.w:(y+1)*p.w], bm.pix[(y-1)*p.w:y*p.w])
+				}
+				continue
+			}
+		}
+		for x := 0; x < p.w; x++ {
+			c := 0
+			for _, px := range tmpl {
+				c = c<<1 | bm.at(x+px.x, y+px.y)
+			}
+			bm.pix[y*p.w+x] = byte(d.decodeBit(cx, c))
+		}
+	}
+
+	return bm, nil
+}
+
+// decodeMMR decodes a generic region encoded using CCITT Group 4 (ITU T.88 6.2.6).
+func decodeMMR(data []byte, w, h int) (*jbig2Bitmap, error) {
+	bm, err := newJBIG2Bitmap(w, h)
+	if err != nil {
+		return nil, err
+	}
+	if w == 0 || h == 0 {
+		return bm, nil
+	}
+
+	rowLen := (w + 7) / 8
+	bb := make([]byte, rowLen*h)
+	rd := ccitt.NewReader(bytes.NewReader(data), ccitt.MSB, ccitt.Group4, w, h, &ccitt.Options{Invert: true})
+	if _, err := io.ReadFull(rd, bb); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: jbig2: mmr")
+	}
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if bb[y*rowLen+x/8]&(0x80>>(x%8)) != 0 {
+				bm.pix[y*w+x] = 1
+			}
+		}
+	}
+
+	return bm, nil
+}
+
+// Context templates of the generic refinement region decoding procedure (ITU T.88 6.3.5.3) without AT pixels.
+var jbig2RefinementTemplates = []struct {
+	coding, reference []jbig2Pixel
+}{
+	{
+		[]jbig2Pixel{{0, -1}, {1, -1}, {-1, 0}},
+		[]jbig2Pixel{{0, -1}, {1, -1}, {-1, 0}, {0, 0}, {1, 0}, {-1, 1}, {0, 1}, {1, 1}},
+	},
+	{
+		[]jbig2Pixel{{-1, -1}, {0, -1}, {1, -1}, {-1, 0}},
+		[]jbig2Pixel{{0, -1}, {-1, 0}, {0, 0}, {1, 0}, {0, 1}, {1, 1}},
+	},
+}
+
+// Contexts used for decoding SLTP (ITU T.88 6.3.5.6).
+var jbig2RefinementSLTPContexts = []int{0x0020, 0x0008}
+
+// jbig2RefinementParms represents the parameters of the generic refinement region decoding procedure (ITU T.88 6.3.2).
+type jbig2RefinementParms struct {
+	w, h     int
+	template int
+	ref      *jbig2Bitmap
+	dx, dy   int
+	tpgron   bool
+	at       []jbig2Pixel
+}
+
+// typicalRefinementPixel returns the value of the 3x3 neighbourhood of the reference pixel at x,y if uniform.
+func typicalRefinementPixel(ref *jbig2Bitmap, x, y int) (int, bool) {
+	v := ref.at(x, y)
+	for j := -1; j <= 1; j++ {
+		for i := -1; i <= 1; i++ {
+			if ref.at(x+i, y+j) != v {
+				return 0, false
+			}
+		}
+	}
+	return v, true
+}
+
+// decodeRefinementRegion runs the generic refinement region decoding procedure (ITU T.88 6.3).
+func decodeRefinementRegion(d *arithDecoder, p jbig2RefinementParms) (*jbig2Bitmap, error) {
+	bm, err := newJBIG2Bitmap(p.w, p.h)
+	if err != nil {
+		return nil, err
+	}
+
+	t := jbig2RefinementTemplates[p.template]
+	coding, reference := t.coding, t.reference
+	if p.template == 0 {
+		coding = append(append([]jbig2Pixel{}, coding...), p.at[0])
+		reference = append(append([]jbig2Pixel{}, reference...), p.at[1])
+	}
+
+	cx := d.context("GR", 1<<(len(coding)+len(reference)))
+	ltp := 0
+
+	for y := 0; y < p.h; y++ {
+		if p.tpgron {
+			ltp ^= d.decodeBit(cx, jbig2RefinementSLTPContexts[p.template])
+		}
+		for x := 0; x < p.w; x++ {
+			rx, ry := x-p.dx, y-p.dy
+			if ltp == 1 {
+				if v, ok := typicalRefinementPixel(p.ref, rx, ry); ok {
+					bm.pix[y*p.w+x] = byte(v)
+					continue
+				}
+			}
+			c := 0
+			for _, px := range coding {
+				c = c<<1 | bm.at(x+px.x, y+px.y)
+			}
+			for _, px := range reference {
+				c = c<<1 | p.ref.at(rx+px.x, ry+px.y)
+			}
+			bm.pix[y*p.w+x] = byte(d.decodeBit(cx, c))
+		}
+	}
+
+	return bm, nil
+}
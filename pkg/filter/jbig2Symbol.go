@@ -0,0 +1,306 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"math/bits"
+
+	"github.com/pkg/errors"
+)
+
+var errJBIG2Corrupt = errors.New("pdfcpu: jbig2: corrupt data")
+
+// symbolCodeLength returns the number of bits needed for symbol IDs of n symbols.
+func symbolCodeLength(n int) int {
+	if n <= 1 {
+		return 0
+	}
+	return bits.Len(uint(n - 1))
+}
+
+// jbig2SymbolParms represents the parameters of the symbol dictionary decoding procedure (ITU T.88 6.5.2).
+type jbig2SymbolParms struct {
+	refAgg                bool
+	in                    []*jbig2Bitmap
+	numNew, numExported   int
+	template, refTemplate int
+	at, refAt             []jbig2Pixel
+}
+
+// decodeSymbolDictionary runs the symbol dictionary decoding procedure (ITU T.88 6.5) using arithmetic coding
+// and returns the exported symbols.
+func decodeSymbolDictionary(d *arithDecoder, p jbig2SymbolParms) ([]*jbig2Bitmap, error) {
+	var (
+		newSymbols []*jbig2Bitmap
+		h          int
+	)
+
+	codeLen := symbolCodeLength(len(p.in) + p.numNew)
+
+	for len(newSymbols) < p.numNew {
+		dh, ok := d.decodeInt("IADH")
+		if !ok {
+			return nil, errJBIG2Corrupt
+		}
+		h += dh
+
+		w := 0
+		for {
+			dw, ok := d.decodeInt("IADW")
+			if !ok {
+				// End of height class.
+				break
+			}
+			if len(newSymbols) == p.numNew {
+				return nil, errJBIG2Corrupt
+			}
+			w += dw
+			if w < 0 || h < 0 {
+				return nil, errJBIG2Corrupt
+			}
+
+			var (
+				bm  *jbig2Bitmap
+				err error
+			)
+
+			if !p.refAgg {
+				bm, err = decodeGenericRegion(d, nil, jbig2GenericParms{w: w, h: h, template: p.template, at: p.at})
+			} else {
+				bm, err = decodeRefinementAggregate(d, p, newSymbols, w, h, codeLen)
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			newSymbols = append(newSymbols, bm)
+		}
+	}
+
+	// Exported symbols are flagged using run lengths alternating between not exported and exported.
+	all := append(append([]*jbig2Bitmap{}, p.in...), newSymbols...)
+	var exported []*jbig2Bitmap
+
+	for i, export := 0, false; i < len(all); export = !export {
+		n, ok := d.decodeInt("IAEX")
+		if !ok || n < 0 || i+n > len(all) {
+			return nil, errJBIG2Corrupt
+		}
+		if export {
+			exported = append(exported, all[i:i+n]...)
+		}
+		i += n
+	}
+
+	if len(exported) != p.numExported {
+		return nil, errJBIG2Corrupt
+	}
+
+	return exported, nil
+}
+
+// decodeRefinementAggregate decodes a symbol bitmap that is a refinement or an aggregation of known symbols (ITU T.88 6.5.8.2).
+func decodeRefinementAggregate(d *arithDecoder, p jbig2SymbolParms, newSymbols []*jbig2Bitmap, w, h, codeLen int) (*jbig2Bitmap, error) {
+	symbols := append(append([]*jbig2Bitmap{}, p.in...), newSymbols...)
+
+	n, ok := d.decodeInt("IAAI")
+	if !ok || n < 1 {
+		return nil, errJBIG2Corrupt
+	}
+
+	if n > 1 {
+		return decodeTextRegion(d, jbig2TextParms{
+			w: w, h: h,
+			numInstances: n,
+			strips:       1,
+			symbols:      symbols,
+			codeLen:      codeLen,
+			refine:       true,
+			refCorner:    jbig2TopLeft,
+			refTemplate:  p.refTemplate,
+			refAt:        p.refAt,
+		})
+	}
+
+	id := d.decodeIAID(codeLen)
+	rdx, ok1 := d.decodeInt("IARDX")
+	rdy, ok2 := d.decodeInt("IARDY")
+	if !ok1 || !ok2 || id >= len(symbols) {
+		return nil, errJBIG2Corrupt
+	}
+
+	return decodeRefinementRegion(d, jbig2RefinementParms{
+		w: w, h: h,
+		template: p.refTemplate,
+		ref:      symbols[id],
+		dx:       rdx, dy: rdy,
+		at: p.refAt,
+	})
+}
+
+// Text region reference corners.
+const (
+	jbig2BottomLeft = iota
+	jbig2TopLeft
+	jbig2BottomRight
+	jbig2TopRight
+)
+
+// jbig2TextParms represents the parameters of the text region decoding procedure (ITU T.88 6.4.2).
+type jbig2TextParms struct {
+	w, h         int
+	numInstances int
+	strips       int
+	symbols      []*jbig2Bitmap
+	codeLen      int
+	defPixel     byte
+	combOp       int
+	transposed   bool
+	refCorner    int
+	dsOffset     int
+	refine       bool
+	refTemplate  int
+	refAt        []jbig2Pixel
+}
+
+// decodeTextRegion runs the text region decoding procedure (ITU T.88 6.4) using arithmetic coding.
+func decodeTextRegion(d *arithDecoder, p jbig2TextParms) (*jbig2Bitmap, error) {
+	bm, err := newJBIG2Bitmap(p.w, p.h)
+	if err != nil {
+		return nil, err
+	}
+	if p.defPixel == 1 {
+		bm.fill(1)
+	}
+
+	stripT, ok := d.decodeInt("IADT")
+	if !ok {
+		return nil, errJBIG2Corrupt
+	}
+	stripT *= -p.strips
+
+	firstS := 0
+
+	for i := 0; i < p.numInstances; {
+		dt, ok1 := d.decodeInt("IADT")
+		dfs, ok2 := d.decodeInt("IAFS")
+		if !ok1 || !ok2 {
+			return nil, errJBIG2Corrupt
+		}
+		stripT += dt * p.strips
+		firstS += dfs
+		s := firstS
+
+		// Each strip gets terminated by OOB.
+		for first := true; ; first = false {
+			if !first {
+				ds, ok := d.decodeInt("IADS")
+				if !ok || i == p.numInstances {
+					break
+				}
+				s += ds + p.dsOffset
+			}
+
+			t := stripT
+			if p.strips > 1 {
+				ct, ok := d.decodeInt("IAIT")
+				if !ok {
+					return nil, errJBIG2Corrupt
+				}
+				t += ct
+			}
+
+			id := d.decodeIAID(p.codeLen)
+			if id >= len(p.symbols) {
+				return nil, errJBIG2Corrupt
+			}
+			sym := p.symbols[id]
+
+			if p.refine {
+				ri, ok := d.decodeInt("IARI")
+				if !ok {
+					return nil, errJBIG2Corrupt
+				}
+				if ri != 0 {
+					if sym, err = decodeRefinedSymbol(d, p, sym); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			s = placeSymbol(bm, sym, s, t, p)
+			i++
+		}
+	}
+
+	return bm, nil
+}
+
+// decodeRefinedSymbol decodes a refinement of sym (ITU T.88 6.4.11).
+func decodeRefinedSymbol(d *arithDecoder, p jbig2TextParms, sym *jbig2Bitmap) (*jbig2Bitmap, error) {
+	rdw, ok1 := d.decodeInt("IARDW")
+	rdh, ok2 := d.decodeInt("IARDH")
+	rdx, ok3 := d.decodeInt("IARDX")
+	rdy, ok4 := d.decodeInt("IARDY")
+	if !ok1 || !ok2 || !ok3 || !ok4 {
+		return nil, errJBIG2Corrupt
+	}
+
+	return decodeRefinementRegion(d, jbig2RefinementParms{
+		w: sym.w + rdw, h: sym.h + rdh,
+		template: p.refTemplate,
+		ref:      sym,
+		dx:       rdw>>1 + rdx, dy: rdh>>1 + rdy,
+		at: p.refAt,
+	})
+}
+
+// placeSymbol draws sym onto bm at s,t according to the reference corner (ITU T.88 6.4.5 3c)
+// and returns the updated current S coordinate.
+func placeSymbol(bm, sym *jbig2Bitmap, s, t int, p jbig2TextParms) int {
+	// Extent of sym along S.
+	w := sym.w
+	if p.transposed {
+		w = sym.h
+	}
+
+	right := p.refCorner == jbig2TopRight || p.refCorner == jbig2BottomRight
+	bottom := p.refCorner == jbig2BottomLeft || p.refCorner == jbig2BottomRight
+
+	if p.transposed && bottom || !p.transposed && right {
+		s += w - 1
+	}
+
+	x, y := s, t
+	if p.transposed {
+		x, y = t, s
+	}
+	if right {
+		x -= sym.w - 1
+	}
+	if bottom {
+		y -= sym.h - 1
+	}
+
+	bm.combine(sym, x, y, p.combOp)
+
+	if p.transposed && !bottom || !p.transposed && !right {
+		s += w - 1
+	}
+
+	return s
+}
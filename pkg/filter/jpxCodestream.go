@@ -0,0 +1,465 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// JPEG 2000 codestream markers (ITU T.800 A.2).
+const (
+	jpxSOC = 0xFF4F
+	jpxSIZ = 0xFF51
+	jpxCOD = 0xFF52
+	jpxCOC = 0xFF53
+	jpxQCD = 0xFF5C
+	jpxQCC = 0xFF5D
+	jpxRGN = 0xFF5E
+	jpxPOC = 0xFF5F
+	jpxPPM = 0xFF60
+	jpxPPT = 0xFF61
+	jpxSOT = 0xFF90
+	jpxSOP = 0xFF91
+	jpxEPH = 0xFF92
+	jpxSOD = 0xFF93
+	jpxEOC = 0xFFD9
+)
+
+// Progression orders (ITU T.800 Table A.16).
+const (
+	jpxLRCP = iota
+	jpxRLCP
+	jpxRPCL
+	jpxPCRL
+	jpxCPRL
+)
+
+// Code-block styles (ITU T.800 Table A.19).
+const (
+	jpxBypass       = 0x01
+	jpxReset        = 0x02
+	jpxTermAll      = 0x04
+	jpxVertCausal   = 0x08
+	jpxPredTerm     = 0x10
+	jpxSegmentation = 0x20
+)
+
+var (
+	errJPXCorrupt     = errors.New("pdfcpu: jpx: corrupt data")
+	errJPXUnsupported = errors.New("pdfcpu: jpx: unsupported coding feature")
+)
+
+// jpxReader reads big endian integers and remembers running out of data.
+type jpxReader struct {
+	bb  []byte
+	off int
+	err error
+}
+
+func (r *jpxReader) next(n int) []byte {
+	if r.err != nil || n < 0 || r.off+n > len(r.bb) {
+		r.err = errJPXCorrupt
+		// Enough for any integer read.
+		return make([]byte, 8)
+	}
+	b := r.bb[r.off : r.off+n]
+	r.off += n
+	return b
+}
+
+func (r *jpxReader) u8() int {
+	return int(r.next(1)[0])
+}
+
+func (r *jpxReader) u16() int {
+	return int(binary.BigEndian.Uint16(r.next(2)))
+}
+
+func (r *jpxReader) u32() int {
+	return int(binary.BigEndian.Uint32(r.next(4)))
+}
+
+// jpxComponent represents the image component parameters of SIZ.
+type jpxComponent struct {
+	prec   int
+	signed bool
+	dx, dy int
+}
+
+// jpxSize represents the image and tile size (ITU T.800 A.5.1).
+type jpxSize struct {
+	x0, y0, x1, y1 int // image area on the reference grid
+	tx0, ty0       int // tile grid offset
+	tw, th         int // tile size
+	comps          []jpxComponent
+}
+
+func (s *jpxSize) tilesWide() int {
+	return ceilDiv(s.x1-s.tx0, s.tw)
+}
+
+func (s *jpxSize) tilesHigh() int {
+	return ceilDiv(s.y1-s.ty0, s.th)
+}
+
+// jpxCodingStyle represents the coding style of a component (ITU T.800 A.6.1, A.6.2).
+type jpxCodingStyle struct {
+	levels     int   // number of decomposition levels
+	cbw, cbh   int   // code-block size exponents
+	cbStyle    int   // code-block style
+	reversible bool  // 5-3 reversible filter, else 9-7 irreversible filter
+	pp         []int // precinct size exponents per resolution: PPx | PPy<<4
+}
+
+// jpxSubbandStep represents the quantization step size of a subband.
+type jpxSubbandStep struct {
+	exp, mant int
+}
+
+// jpxQuantization represents the quantization of a component (ITU T.800 A.6.4, A.6.5).
+type jpxQuantization struct {
+	style int // 0 = none, 1 = scalar derived, 2 = scalar expounded
+	guard int
+	steps []jpxSubbandStep
+}
+
+// step returns the step size of band kind of resolution r.
+func (q jpxQuantization) step(r, kind int) jpxSubbandStep {
+	if q.style == 1 {
+		s := q.steps[0]
+		// Exponents of subbands of resolution r > 0 at decomposition level levels-r+1 (ITU T.800 E-5).
+		if r > 0 {
+			s.exp -= r - 1
+		}
+		return s
+	}
+	i := 0
+	if r > 0 {
+		i = 3*(r-1) + kind
+	}
+	if i >= len(q.steps) {
+		return q.steps[len(q.steps)-1]
+	}
+	return q.steps[i]
+}
+
+// jpxProgression represents a progression order change (ITU T.800 A.6.6).
+type jpxProgression struct {
+	rs, cs     int // first resolution and component
+	le, re, ce int // layer, resolution and component end (exclusive)
+	order      int
+}
+
+// jpxHeader collects the parameters of a main or tile header.
+type jpxHeader struct {
+	cod          bool
+	order        int
+	layers       int
+	mct          bool
+	sop, eph     bool
+	cs           *jpxCodingStyle
+	coc          map[int]jpxCodingStyle
+	qcd          *jpxQuantization
+	qcc          map[int]jpxQuantization
+	rgn          map[int]int
+	poc          []jpxProgression
+	ppm          [][]byte // packed packet headers per PPM marker
+	ppt          [][]byte // packed packet headers per PPT marker
+	pptIndex     []int
+	ppmIndex     []int
+	tilePartData [][]byte
+}
+
+func newJPXHeader() *jpxHeader {
+	return &jpxHeader{coc: map[int]jpxCodingStyle{}, qcc: map[int]jpxQuantization{}, rgn: map[int]int{}}
+}
+
+// jpxCodestream represents a parsed codestream.
+type jpxCodestream struct {
+	siz   jpxSize
+	main  *jpxHeader
+	tiles map[int]*jpxHeader // tile headers and data by tile index
+	parts []int              // tile index of each tile-part in codestream order
+}
+
+func (cs *jpxCodestream) componentIndex(r *jpxReader) int {
+	if len(cs.siz.comps) < 257 {
+		return r.u8()
+	}
+	return r.u16()
+}
+
+// parseJPXCodestream parses the main header and the tile-parts of a codestream (ITU T.800 A.3).
+func parseJPXCodestream(bb []byte) (*jpxCodestream, error) {
+	r := &jpxReader{bb: bb}
+	if r.u16() != jpxSOC || r.u16() != jpxSIZ {
+		return nil, errJPXCorrupt
+	}
+
+	cs := &jpxCodestream{main: newJPXHeader(), tiles: map[int]*jpxHeader{}}
+
+	if err := cs.parseSIZ(r.next(r.u16() - 2)); err != nil {
+		return nil, err
+	}
+
+	// Main header
+	for {
+		m := r.u16()
+		if r.err != nil {
+			return nil, r.err
+		}
+		if m == jpxSOT {
+			break
+		}
+		if m == jpxEOC {
+			return cs, nil
+		}
+		l := r.u16()
+		if l < 2 {
+			return nil, errJPXCorrupt
+		}
+		if err := cs.parseMarker(cs.main, m, r.next(l-2)); err != nil {
+			return nil, err
+		}
+		if r.err != nil {
+			return nil, r.err
+		}
+	}
+
+	// Tile-parts
+	for {
+		start := r.off - 2
+		tr := &jpxReader{bb: r.next(r.u16() - 2)}
+		idx, psot := tr.u16(), tr.u32()
+		if r.err != nil || tr.err != nil {
+			return nil, errJPXCorrupt
+		}
+		if idx >= cs.siz.tilesWide()*cs.siz.tilesHigh() {
+			return nil, errJPXCorrupt
+		}
+
+		th, ok := cs.tiles[idx]
+		if !ok {
+			th = newJPXHeader()
+			cs.tiles[idx] = th
+		}
+		cs.parts = append(cs.parts, idx)
+
+		for {
+			m := r.u16()
+			if r.err != nil {
+				return nil, r.err
+			}
+			if m == jpxSOD {
+				break
+			}
+			l := r.u16()
+			if l < 2 {
+				return nil, errJPXCorrupt
+			}
+			if err := cs.parseMarker(th, m, r.next(l-2)); err != nil {
+				return nil, err
+			}
+		}
+
+		end := start + psot
+		if psot == 0 || end > len(bb) {
+			// The last tile-part may extend up to EOC, truncated data is tolerated.
+			end = len(bb)
+			if end-2 >= r.off && bb[end-2] == 0xFF && bb[end-1] == 0xD9 {
+				end -= 2
+			}
+		}
+		if end < r.off {
+			return nil, errJPXCorrupt
+		}
+
+		th.tilePartData = append(th.tilePartData, bb[r.off:end])
+		r.off = end
+
+		if r.off+2 > len(bb) {
+			break
+		}
+		m := r.u16()
+		if m != jpxSOT {
+			break
+		}
+	}
+
+	return cs, nil
+}
+
+func (cs *jpxCodestream) parseSIZ(bb []byte) error {
+	r := &jpxReader{bb: bb}
+	r.u16() // capabilities
+	s := &cs.siz
+	s.x1, s.y1 = r.u32(), r.u32()
+	s.x0, s.y0 = r.u32(), r.u32()
+	s.tw, s.th = r.u32(), r.u32()
+	s.tx0, s.ty0 = r.u32(), r.u32()
+	n := r.u16()
+	if r.err != nil {
+		return r.err
+	}
+	if n == 0 || n > 16384 || s.x1 <= s.x0 || s.y1 <= s.y0 || s.tw == 0 || s.th == 0 ||
+		s.tx0 > s.x0 || s.ty0 > s.y0 || s.tx0+s.tw <= s.x0 || s.ty0+s.th <= s.y0 {
+		return errJPXCorrupt
+	}
+
+	s.comps = make([]jpxComponent, n)
+	for i := range s.comps {
+		c := &s.comps[i]
+		v := r.u8()
+		c.prec, c.signed = v&0x7F+1, v&0x80 > 0
+		c.dx, c.dy = r.u8(), r.u8()
+		if c.dx == 0 || c.dy == 0 || c.prec > 38 {
+			return errJPXCorrupt
+		}
+	}
+
+	if s.tilesWide()*s.tilesHigh() > 65535 {
+		return errJPXCorrupt
+	}
+
+	return r.err
+}
+
+func (cs *jpxCodestream) parseMarker(h *jpxHeader, m int, bb []byte) error {
+	r := &jpxReader{bb: bb}
+
+	switch m {
+
+	case jpxCOD:
+		scod := r.u8()
+		h.cod = true
+		h.sop, h.eph = scod&0x02 > 0, scod&0x04 > 0
+		h.order = r.u8()
+		h.layers = r.u16()
+		h.mct = r.u8() > 0
+		s := cs.parseCodingStyle(r, scod&0x01 > 0)
+		h.cs = &s
+		if h.order > jpxCPRL || h.layers == 0 {
+			return errJPXCorrupt
+		}
+
+	case jpxCOC:
+		c := cs.componentIndex(r)
+		h.coc[c] = cs.parseCodingStyle(r, r.u8()&0x01 > 0)
+
+	case jpxQCD:
+		q := parseQuantization(r)
+		h.qcd = &q
+
+	case jpxQCC:
+		c := cs.componentIndex(r)
+		h.qcc[c] = parseQuantization(r)
+
+	case jpxRGN:
+		c := cs.componentIndex(r)
+		if r.u8() != 0 {
+			return errJPXUnsupported
+		}
+		h.rgn[c] = r.u8()
+
+	case jpxPOC:
+		for r.off < len(bb) && r.err == nil {
+			var p jpxProgression
+			p.rs = r.u8()
+			p.cs = cs.componentIndex(r)
+			p.le = r.u16()
+			p.re = r.u8()
+			p.ce = cs.componentIndex(r)
+			if p.ce == 0 {
+				p.ce = 256
+			}
+			p.order = r.u8()
+			if p.order > jpxCPRL {
+				return errJPXCorrupt
+			}
+			h.poc = append(h.poc, p)
+		}
+
+	case jpxPPM:
+		h.ppmIndex = append(h.ppmIndex, r.u8())
+		h.ppm = append(h.ppm, r.next(len(bb)-1))
+
+	case jpxPPT:
+		h.pptIndex = append(h.pptIndex, r.u8())
+		h.ppt = append(h.ppt, r.next(len(bb)-1))
+
+	default:
+		// TLM, PLM, PLT, CRG, COM: not needed for decoding.
+	}
+
+	return r.err
+}
+
+func (cs *jpxCodestream) parseCodingStyle(r *jpxReader, precincts bool) jpxCodingStyle {
+	s := jpxCodingStyle{levels: r.u8()}
+	s.cbw, s.cbh = r.u8()+2, r.u8()+2
+	s.cbStyle = r.u8()
+	s.reversible = r.u8() == 1
+
+	if s.levels > 32 || s.cbw > 10 || s.cbh > 10 || s.cbw+s.cbh > 12 {
+		r.err = errJPXCorrupt
+		return s
+	}
+
+	s.pp = make([]int, s.levels+1)
+	for i := range s.pp {
+		s.pp[i] = 0xFF
+		if precincts {
+			s.pp[i] = r.u8()
+		}
+	}
+
+	return s
+}
+
+func parseQuantization(r *jpxReader) jpxQuantization {
+	v := r.u8()
+	q := jpxQuantization{style: v & 0x1F, guard: v >> 5}
+
+	switch q.style {
+
+	case 0:
+		for r.off < len(r.bb) {
+			q.steps = append(q.steps, jpxSubbandStep{exp: r.u8() >> 3})
+		}
+
+	case 1, 2:
+		for r.off+1 < len(r.bb) {
+			v := r.u16()
+			q.steps = append(q.steps, jpxSubbandStep{exp: v >> 11, mant: v & 0x7FF})
+		}
+
+	default:
+		r.err = errJPXCorrupt
+	}
+
+	if len(q.steps) == 0 {
+		r.err = errJPXCorrupt
+	}
+
+	return q
+}
+
+func ceilDiv(a, b int) int {
+	return (a + b - 1) / b
+}
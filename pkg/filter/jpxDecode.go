@@ -0,0 +1,779 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pkg/errors"
+)
+
+type jpxDecode struct {
+	baseFilter
+}
+
+// JPXImage represents a decoded JPEG 2000 image.
+type JPXImage struct {
+	Width, Height int
+	Components    int    // Number of color components.
+	BPC           int    // 8 or 16
+	ColorSpace    string // DeviceGray, DeviceRGB, DeviceCMYK or empty if unknown.
+	Pix           []byte // Interleaved samples, 16 bit samples are big endian.
+}
+
+// Encode implements encoding for a JPXDecode filter.
+func (f jpxDecode) Encode(r io.Reader) (io.Reader, error) {
+	return nil, errors.New("pdfcpu: filter JPXDecode: encoding unsupported")
+}
+
+// Decode implements decoding for a JPXDecode filter.
+func (f jpxDecode) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f jpxDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	if log.TraceEnabled() {
+		log.Trace.Println("DecodeJPX begin")
+	}
+
+	img, err := f.DecodeJPX(r)
+	if err != nil {
+		return nil, err
+	}
+
+	bb := img.Pix
+
+	if log.TraceEnabled() {
+		log.Trace.Printf("DecodeJPX: decoded %d bytes.\n", len(bb))
+	}
+
+	if maxLen >= 0 && int64(len(bb)) > maxLen {
+		bb = bb[:maxLen]
+	}
+
+	return newBuffer(bb), nil
+}
+
+// DecodeJPX decodes a JPEG 2000 image given either as JP2 file or as codestream
+// using a registered JPXDecode filter implementing JPXDecoder if available.
+func DecodeJPX(r io.Reader) (*JPXImage, error) {
+	if factory, ok := registered(JPX); ok {
+		f, err := factory(nil)
+		if err != nil {
+			return nil, err
+		}
+		if d, ok := f.(JPXDecoder); ok {
+			return d.DecodeJPX(r)
+		}
+	}
+
+	return jpxDecode{}.DecodeJPX(r)
+}
+
+// DecodeJPX implements JPXDecoder for the built in JPXDecode filter.
+func (f jpxDecode) DecodeJPX(r io.Reader) (*JPXImage, error) {
+	bb, err := getReaderBytes(r)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := decodeJPX(bb)
+	if err == errJPXUnsupported {
+		if log.InfoEnabled() {
+			log.Info.Printf("Filter not supported: <%s>: %v", JPX, err)
+		}
+		err = ErrUnsupportedFilter
+	}
+
+	return img, err
+}
+
+// JP2 enumerated color spaces (ISO/IEC 15444-1 Table I.10, ISO/IEC 15444-2 Table M.25).
+const (
+	jp2CMYK      = 12
+	jp2SRGB      = 16
+	jp2Greyscale = 17
+	jp2SYCC      = 18
+	jp2ESRGB     = 20
+	jp2ROMMRGB   = 21
+)
+
+// jp2Palette represents a palette box (ISO/IEC 15444-1 I.5.3.4).
+type jp2Palette struct {
+	prec []int
+	cols [][]int // entries by column
+}
+
+// jp2ComponentMapping represents an entry of a component mapping box (ISO/IEC 15444-1 I.5.3.5).
+type jp2ComponentMapping struct {
+	comp    int
+	palette bool
+	col     int
+}
+
+// jp2ChannelDefinition represents an entry of a channel definition box (ISO/IEC 15444-1 I.5.3.6).
+type jp2ChannelDefinition struct {
+	channel, typ, assoc int
+}
+
+// jp2Header represents the relevant contents of a JP2 header box (ISO/IEC 15444-1 I.5.3).
+type jp2Header struct {
+	enumCS  int
+	palette *jp2Palette
+	cmap    []jp2ComponentMapping
+	cdef    []jp2ChannelDefinition
+}
+
+// jp2Boxes calls f for the boxes contained in bb (ISO/IEC 15444-1 I.4).
+func jp2Boxes(bb []byte, f func(typ string, content []byte) error) error {
+	for len(bb) >= 8 {
+		l, typ, hl := uint64(binary.BigEndian.Uint32(bb)), string(bb[4:8]), uint64(8)
+		switch l {
+		case 0:
+			l = uint64(len(bb))
+		case 1:
+			if len(bb) < 16 {
+				return errJPXCorrupt
+			}
+			l, hl = binary.BigEndian.Uint64(bb[8:]), 16
+		}
+		if l < hl {
+			return errJPXCorrupt
+		}
+		if l > uint64(len(bb)) {
+			if typ != "jp2c" {
+				return errJPXCorrupt
+			}
+			// Tolerate a truncated codestream.
+			l = uint64(len(bb))
+		}
+		if err := f(typ, bb[hl:l]); err != nil {
+			return err
+		}
+		bb = bb[l:]
+	}
+	return nil
+}
+
+func (h *jp2Header) parse(bb []byte) error {
+	return jp2Boxes(bb, func(typ string, content []byte) error {
+		r := &jpxReader{bb: content}
+
+		switch typ {
+
+		case "colr":
+			if h.enumCS == 0 && r.u8() == 1 {
+				r.next(2) // precedence, approximation
+				h.enumCS = r.u32()
+			}
+
+		case "pclr":
+			p := &jp2Palette{}
+			n, cols := r.u16(), r.u8()
+			for i := 0; i < cols; i++ {
+				p.prec = append(p.prec, r.u8()&0x7F+1)
+			}
+			p.cols = make([][]int, cols)
+			for i := 0; i < n && r.err == nil; i++ {
+				for j := 0; j < cols; j++ {
+					v := 0
+					for _, b := range r.next((p.prec[j] + 7) / 8) {
+						v = v<<8 | int(b)
+					}
+					p.cols[j] = append(p.cols[j], v)
+				}
+			}
+			h.palette = p
+
+		case "cmap":
+			for r.off+4 <= len(content) {
+				h.cmap = append(h.cmap, jp2ComponentMapping{comp: r.u16(), palette: r.u8() == 1, col: r.u8()})
+			}
+
+		case "cdef":
+			n := r.u16()
+			for i := 0; i < n; i++ {
+				h.cdef = append(h.cdef, jp2ChannelDefinition{channel: r.u16(), typ: r.u16(), assoc: r.u16()})
+			}
+		}
+
+		return r.err
+	})
+}
+
+// jp2Codestream returns the first codestream of a JP2 file and its header.
+func jp2Codestream(bb []byte) ([]byte, *jp2Header, error) {
+	var (
+		cs []byte
+		h  = &jp2Header{}
+	)
+
+	err := jp2Boxes(bb, func(typ string, content []byte) error {
+		switch typ {
+		case "jp2h":
+			return h.parse(content)
+		case "jp2c":
+			if cs == nil {
+				cs = content
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if cs == nil {
+		return nil, nil, errJPXUnsupported
+	}
+
+	return cs, h, nil
+}
+
+func jp2Box(typ string, content ...[]byte) []byte {
+	l := 8
+	for _, c := range content {
+		l += len(c)
+	}
+	bb := binary.BigEndian.AppendUint32(make([]byte, 0, l), uint32(l))
+	bb = append(bb, typ...)
+	for _, c := range content {
+		bb = append(bb, c...)
+	}
+	return bb
+}
+
+// JP2File returns a JPEG 2000 image as JP2 file (ISO/IEC 15444-1 Annex I).
+// A bare codestream is wrapped into a minimal container, colors are specified by iccProfile if present
+// or else derived from the component count.
+func JP2File(bb, iccProfile []byte) ([]byte, error) {
+	if len(bb) >= 12 && string(bb[4:8]) == "jP  " {
+		return bb, nil
+	}
+
+	r := &jpxReader{bb: bb}
+	if r.u16() != jpxSOC || r.u16() != jpxSIZ {
+		return nil, errJPXCorrupt
+	}
+
+	cs := &jpxCodestream{}
+	if err := cs.parseSIZ(r.next(r.u16() - 2)); err != nil {
+		return nil, err
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	s := cs.siz
+	n := len(s.comps)
+
+	bpc := func(c jpxComponent) byte {
+		b := byte(c.prec - 1)
+		if c.signed {
+			b |= 0x80
+		}
+		return b
+	}
+
+	var bpcc []byte
+	ihdrBPC := bpc(s.comps[0])
+	for _, c := range s.comps {
+		bpcc = append(bpcc, bpc(c))
+		if bpc(c) != ihdrBPC {
+			ihdrBPC = 0xFF
+		}
+	}
+
+	ihdr := binary.BigEndian.AppendUint32(nil, uint32(s.y1-s.y0))
+	ihdr = binary.BigEndian.AppendUint32(ihdr, uint32(s.x1-s.x0))
+	ihdr = binary.BigEndian.AppendUint16(ihdr, uint16(n))
+	ihdr = append(ihdr, ihdrBPC, 7, 0, 0) // compression type, colorspace known, no intellectual property
+
+	brand := "jp2 "
+
+	var colr []byte
+	if len(iccProfile) > 0 {
+		colr = append([]byte{2, 0, 0}, iccProfile...)
+	} else {
+		enumCS := jp2SRGB
+		switch {
+		case n < 3:
+			enumCS = jp2Greyscale
+		case n >= 4:
+			// CMYK is defined by JPX.
+			enumCS, brand = jp2CMYK, "jpx "
+		}
+		colr = binary.BigEndian.AppendUint32([]byte{1, 0, 0}, uint32(enumCS))
+	}
+
+	hdr := [][]byte{jp2Box("ihdr", ihdr)}
+	if ihdrBPC == 0xFF {
+		hdr = append(hdr, jp2Box("bpcc", bpcc))
+	}
+	hdr = append(hdr, jp2Box("colr", colr))
+
+	ftyp := append([]byte(brand), 0, 0, 0, 0)
+	ftyp = append(ftyp, "jp2 "...)
+	if brand != "jp2 " {
+		ftyp = append(ftyp, brand...)
+	}
+
+	var out []byte
+	out = append(out, jp2Box("jP  ", []byte{0x0D, 0x0A, 0x87, 0x0A})...)
+	out = append(out, jp2Box("ftyp", ftyp)...)
+	out = append(out, jp2Box("jp2h", hdr...)...)
+	out = append(out, jp2Box("jp2c", bb)...)
+
+	return out, nil
+}
+
+// jpxPlane represents the samples of a component mapped to [0, 2^prec - 1].
+type jpxPlane struct {
+	pix    []int32
+	x0, y0 int // origin on the component grid
+	w, h   int
+	dx, dy int
+	prec   int
+}
+
+func decodeJPX(bb []byte) (*JPXImage, error) {
+	h := &jp2Header{}
+
+	if len(bb) >= 12 && string(bb[4:8]) == "jP  " {
+		var err error
+		if bb, h, err = jp2Codestream(bb); err != nil {
+			return nil, err
+		}
+	}
+
+	cs, err := parseJPXCodestream(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	planes, err := cs.decode()
+	if err != nil {
+		return nil, err
+	}
+
+	return h.image(&cs.siz, planes)
+}
+
+// componentCodingStyle returns the coding style of component c of the tile with header th.
+func (cs *jpxCodestream) componentCodingStyle(c int, th *jpxHeader) (jpxCodingStyle, error) {
+	if s, ok := th.coc[c]; ok {
+		return s, nil
+	}
+	if th.cs != nil {
+		return *th.cs, nil
+	}
+	if s, ok := cs.main.coc[c]; ok {
+		return s, nil
+	}
+	if cs.main.cs != nil {
+		return *cs.main.cs, nil
+	}
+	return jpxCodingStyle{}, errJPXCorrupt
+}
+
+// componentQuantization returns the quantization of component c of the tile with header th.
+func (cs *jpxCodestream) componentQuantization(c int, th *jpxHeader) (jpxQuantization, error) {
+	if q, ok := th.qcc[c]; ok {
+		return q, nil
+	}
+	if th.qcd != nil {
+		return *th.qcd, nil
+	}
+	if q, ok := cs.main.qcc[c]; ok {
+		return q, nil
+	}
+	if cs.main.qcd != nil {
+		return *cs.main.qcd, nil
+	}
+	return jpxQuantization{}, errJPXCorrupt
+}
+
+// packedHeaders returns the packed packet headers of PPM and PPT marker segments by tile.
+func (cs *jpxCodestream) packedHeaders() (map[int][]byte, error) {
+	m := map[int][]byte{}
+
+	concat := func(index []int, segs [][]byte) []byte {
+		ii := make([]int, len(segs))
+		for i := range ii {
+			ii[i] = i
+		}
+		sort.SliceStable(ii, func(i, j int) bool { return index[ii[i]] < index[ii[j]] })
+		var bb []byte
+		for _, i := range ii {
+			bb = append(bb, segs[i]...)
+		}
+		return bb
+	}
+
+	if len(cs.main.ppm) > 0 {
+		// One header chunk per tile-part.
+		r := &jpxReader{bb: concat(cs.main.ppmIndex, cs.main.ppm)}
+		for _, idx := range cs.parts {
+			if r.off >= len(r.bb) {
+				break
+			}
+			n := r.u32()
+			m[idx] = append(m[idx], r.next(n)...)
+			if r.err != nil {
+				return nil, r.err
+			}
+		}
+		return m, nil
+	}
+
+	for idx, th := range cs.tiles {
+		if len(th.ppt) > 0 {
+			m[idx] = concat(th.pptIndex, th.ppt)
+		}
+	}
+
+	return m, nil
+}
+
+// decode decodes all tiles and returns the component planes.
+func (cs *jpxCodestream) decode() ([]*jpxPlane, error) {
+	siz := &cs.siz
+
+	planes := make([]*jpxPlane, len(siz.comps))
+	for i, c := range siz.comps {
+		if c.prec > 16 {
+			return nil, errJPXUnsupported
+		}
+		p := &jpxPlane{x0: ceilDiv(siz.x0, c.dx), y0: ceilDiv(siz.y0, c.dy), dx: c.dx, dy: c.dy, prec: c.prec}
+		p.w, p.h = ceilDiv(siz.x1, c.dx)-p.x0, ceilDiv(siz.y1, c.dy)-p.y0
+		if int64(p.w)*int64(p.h) > 1<<28 {
+			return nil, errors.Errorf("pdfcpu: jpx: invalid image size %dx%d", p.w, p.h)
+		}
+		p.pix = make([]int32, p.w*p.h)
+		planes[i] = p
+	}
+
+	hdrs, err := cs.packedHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	for idx, th := range cs.tiles {
+		if err := cs.decodeTile(idx, th, hdrs[idx], planes); err != nil {
+			return nil, err
+		}
+	}
+
+	return planes, nil
+}
+
+func (cs *jpxCodestream) decodeTile(idx int, th *jpxHeader, hdr []byte, planes []*jpxPlane) error {
+	siz := &cs.siz
+	p, q := idx%siz.tilesWide(), idx/siz.tilesWide()
+
+	t := &jpxTile{
+		x0: max(siz.tx0+p*siz.tw, siz.x0), y0: max(siz.ty0+q*siz.th, siz.y0),
+		x1: min(siz.tx0+(p+1)*siz.tw, siz.x1), y1: min(siz.ty0+(q+1)*siz.th, siz.y1),
+	}
+
+	h := cs.main
+	if th.cod {
+		h = th
+	}
+	if !h.cod {
+		return errJPXCorrupt
+	}
+	t.order, t.layers, t.mct, t.sop, t.eph = h.order, h.layers, h.mct, h.sop, h.eph
+
+	t.poc = cs.main.poc
+	if len(th.poc) > 0 {
+		t.poc = th.poc
+	}
+
+	for c, comp := range siz.comps {
+		s, err := cs.componentCodingStyle(c, th)
+		if err != nil {
+			return err
+		}
+		q, err := cs.componentQuantization(c, th)
+		if err != nil {
+			return err
+		}
+		if q.style == 0 && !s.reversible || len(q.steps) < 3*s.levels+1 && q.style != 1 {
+			return errJPXCorrupt
+		}
+		tc, err := newJPXTileComponent(t, comp, s, q)
+		if err != nil {
+			return err
+		}
+		tc.roi = cs.main.rgn[c]
+		if roi, ok := th.rgn[c]; ok {
+			tc.roi = roi
+		}
+		t.comps = append(t.comps, tc)
+	}
+
+	for _, bb := range th.tilePartData {
+		t.data = append(t.data, bb...)
+	}
+	if hdr != nil {
+		t.hdr = &jpxBitReader{bb: hdr}
+	}
+
+	if err := t.decodePackets(); err != nil {
+		return err
+	}
+
+	samples := make([][]float32, len(t.comps))
+	for c, tc := range t.comps {
+		samples[c] = tc.decode()
+	}
+
+	if t.mct && len(t.comps) >= 3 {
+		if err := t.inverseComponentTransform(samples); err != nil {
+			return err
+		}
+	}
+
+	for c, tc := range t.comps {
+		tc.store(samples[c], planes[c])
+	}
+
+	return nil
+}
+
+// decode decodes the code-blocks of tc and returns its reconstructed samples.
+func (tc *jpxTileComponent) decode() []float32 {
+	gains := []int{0, 1, 1, 2}
+
+	for r, res := range tc.res {
+		for _, b := range res.bands {
+			b.coeffs = make([]float32, (b.x1-b.x0)*(b.y1-b.y0))
+
+			// Quantization step size and number of magnitude bit planes (ITU T.800 E.1).
+			step := tc.q.step(r, b.kind)
+			planes := tc.q.guard + step.exp - 1
+			delta := float32(1)
+			if !tc.cs.reversible {
+				delta = float32(math.Ldexp(1+float64(step.mant)/2048, tc.prec+gains[b.kind]-step.exp))
+			}
+
+			for _, prc := range b.precincts {
+				for _, cb := range prc.blocks {
+					decodeCodeBlock(cb, b, tc.cs.cbStyle, planes+tc.roi, tc.roi, delta)
+				}
+			}
+		}
+	}
+
+	return tc.synthesize()
+}
+
+// inverseComponentTransform applies the inverse multiple component transformation to the first three components (ITU T.800 G.2, G.3).
+func (t *jpxTile) inverseComponentTransform(samples [][]float32) error {
+	c0, c1, c2 := t.comps[0], t.comps[1], t.comps[2]
+	if c0.dx != c1.dx || c0.dx != c2.dx || c0.dy != c1.dy || c0.dy != c2.dy ||
+		c0.cs.reversible != c1.cs.reversible || c0.cs.reversible != c2.cs.reversible {
+		return errJPXCorrupt
+	}
+
+	y0, y1, y2 := samples[0], samples[1], samples[2]
+
+	if c0.cs.reversible {
+		for i := range y0 {
+			g := y0[i] - floor32((y1[i]+y2[i])/4)
+			y0[i], y1[i], y2[i] = y2[i]+g, g, y1[i]+g
+		}
+		return nil
+	}
+
+	for i := range y0 {
+		y, cb, cr := y0[i], y1[i], y2[i]
+		y0[i] = y + 1.402*cr
+		y1[i] = y - 0.34413*cb - 0.71414*cr
+		y2[i] = y + 1.772*cb
+	}
+
+	return nil
+}
+
+// store applies the DC level shift to the samples of tc and copies them into p (ITU T.800 G.1.2).
+func (tc *jpxTileComponent) store(samples []float32, p *jpxPlane) {
+	w := tc.x1 - tc.x0
+	if len(samples) < w*(tc.y1-tc.y0) {
+		return
+	}
+
+	shift, maxV := float32(int32(1)<<(tc.prec-1)), int32(1)<<tc.prec-1
+
+	for y := tc.y0; y < tc.y1; y++ {
+		row := p.pix[(y-p.y0)*p.w+tc.x0-p.x0:]
+		src := samples[(y-tc.y0)*w:]
+		for x := 0; x < w; x++ {
+			v := int32(floor32(src[x] + shift + 0.5))
+			row[x] = max(0, min(maxV, v))
+		}
+	}
+}
+
+// jpxChannel represents an image channel taking its samples from a plane either directly or via a palette.
+type jpxChannel struct {
+	plane *jpxPlane
+	lut   []int
+	prec  int
+}
+
+// channels returns the color channels of an image.
+func (h *jp2Header) channels(planes []*jpxPlane) ([]jpxChannel, error) {
+	var ch []jpxChannel
+
+	if h.palette != nil && len(h.cmap) > 0 {
+		for _, m := range h.cmap {
+			if m.comp >= len(planes) || m.palette && m.col >= len(h.palette.cols) {
+				return nil, errJPXCorrupt
+			}
+			c := jpxChannel{plane: planes[m.comp], prec: planes[m.comp].prec}
+			if m.palette {
+				c.lut, c.prec = h.palette.cols[m.col], h.palette.prec[m.col]
+			}
+			ch = append(ch, c)
+		}
+	} else {
+		for _, p := range planes {
+			ch = append(ch, jpxChannel{plane: p, prec: p.prec})
+		}
+	}
+
+	if len(h.cdef) == 0 {
+		return ch, nil
+	}
+
+	// Skip opacity channels and order color channels by association.
+	color := make([]jpxChannel, 0, len(ch))
+	assoc := make([]int, 0, len(ch))
+	for _, d := range h.cdef {
+		if d.channel >= len(ch) || d.typ != 0 {
+			continue
+		}
+		color = append(color, ch[d.channel])
+		assoc = append(assoc, d.assoc)
+	}
+	if len(color) == 0 {
+		return nil, errJPXCorrupt
+	}
+
+	ordered := append([]jpxChannel{}, color...)
+	for i, a := range assoc {
+		if a < 1 || a > len(color) {
+			return color, nil
+		}
+		ordered[a-1] = color[i]
+	}
+
+	return ordered, nil
+}
+
+func (h *jp2Header) colorSpace(n int) string {
+	switch h.enumCS {
+	case jp2Greyscale:
+		return "DeviceGray"
+	case jp2SRGB, jp2SYCC, jp2ESRGB, jp2ROMMRGB:
+		return "DeviceRGB"
+	case jp2CMYK:
+		return "DeviceCMYK"
+	}
+	switch n {
+	case 1:
+		return "DeviceGray"
+	case 3:
+		return "DeviceRGB"
+	case 4:
+		return "DeviceCMYK"
+	}
+	return ""
+}
+
+// image assembles the interleaved samples of the color channels of an image.
+func (h *jp2Header) image(siz *jpxSize, planes []*jpxPlane) (*JPXImage, error) {
+	ch, err := h.channels(planes)
+	if err != nil {
+		return nil, err
+	}
+
+	img := &JPXImage{
+		Width:      siz.x1 - siz.x0,
+		Height:     siz.y1 - siz.y0,
+		Components: len(ch),
+		BPC:        8,
+		ColorSpace: h.colorSpace(len(ch)),
+	}
+
+	for _, c := range ch {
+		if c.prec > 8 {
+			img.BPC = 16
+		}
+	}
+	maxOut := 1<<img.BPC - 1
+
+	n := len(ch)
+	bytesPerSample := img.BPC / 8
+	img.Pix = make([]byte, img.Width*img.Height*n*bytesPerSample)
+
+	ycc := h.enumCS == jp2SYCC && n == 3
+	v := make([]int, n)
+
+	for y := 0; y < img.Height; y++ {
+		for x := 0; x < img.Width; x++ {
+			for i, c := range ch {
+				p := c.plane
+				px := min(max((siz.x0+x)/p.dx-p.x0, 0), p.w-1)
+				py := min(max((siz.y0+y)/p.dy-p.y0, 0), p.h-1)
+				s := int(p.pix[py*p.w+px])
+				if c.lut != nil {
+					s = c.lut[min(s, len(c.lut)-1)]
+				}
+				maxIn := 1<<c.prec - 1
+				v[i] = (s*maxOut + maxIn/2) / maxIn
+			}
+			if ycc {
+				yccToRGB(v, maxOut)
+			}
+			off := (y*img.Width + x) * n * bytesPerSample
+			for i, s := range v {
+				if bytesPerSample == 1 {
+					img.Pix[off+i] = byte(s)
+				} else {
+					binary.BigEndian.PutUint16(img.Pix[off+2*i:], uint16(s))
+				}
+			}
+		}
+	}
+
+	return img, nil
+}
+
+func yccToRGB(v []int, maxV int) {
+	mid := float64(maxV+1) / 2
+	y, cb, cr := float64(v[0]), float64(v[1])-mid, float64(v[2])-mid
+	for i, f := range []float64{y + 1.402*cr, y - 0.34413*cb - 0.71414*cr, y + 1.772*cb} {
+		v[i] = max(0, min(maxV, int(math.Round(f))))
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"testing"
+)
+
+const jpxTestFile = "../testdata/resources/mountain.jpx"
+
+func checkMountain(t *testing.T, img *JPXImage) {
+	t.Helper()
+
+	if img.Width != 1667 || img.Height != 2646 || img.Components != 3 || img.BPC != 8 || img.ColorSpace != "DeviceRGB" {
+		t.Fatalf("got %dx%d comp=%d bpc=%d cs=%s", img.Width, img.Height, img.Components, img.BPC, img.ColorSpace)
+	}
+
+	if len(img.Pix) != img.Width*img.Height*3 {
+		t.Fatalf("got %d bytes", len(img.Pix))
+	}
+
+	pixel := func(x, y int) []byte {
+		i := (y*img.Width + x) * 3
+		return img.Pix[i : i+3]
+	}
+
+	// Blue sky
+	if p := pixel(100, 100); p[2] < 200 || p[0] > p[2] {
+		t.Errorf("sky: got %v", p)
+	}
+
+	// Green meadow
+	if p := pixel(300, 2200); p[1] < p[0] || p[1] < p[2] {
+		t.Errorf("meadow: got %v", p)
+	}
+
+	// Black caption "JPEG2000" in the upper right corner.
+	dark := 0
+	for y := 30; y < 90; y++ {
+		for x := 1300; x < 1650; x++ {
+			if p := pixel(x, y); p[0] < 64 && p[1] < 64 && p[2] < 64 {
+				dark++
+			}
+		}
+	}
+	if dark < 2000 {
+		t.Errorf("caption: got %d dark pixels", dark)
+	}
+}
+
+func TestJPXDecodeJP2(t *testing.T) {
+	f, err := os.Open(jpxTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	img, err := DecodeJPX(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checkMountain(t, img)
+}
+
+func TestJPXDecodeCodestream(t *testing.T) {
+	bb, err := os.ReadFile(jpxTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs, _, err := jp2Codestream(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := DecodeJPX(bytes.NewReader(cs))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Without the JP2 channel definitions the opacity channel is taken for a color component.
+	if img.Components != 4 || img.ColorSpace != "DeviceCMYK" {
+		t.Fatalf("got comp=%d cs=%s", img.Components, img.ColorSpace)
+	}
+
+	img1, err := DecodeJPX(bytes.NewReader(bb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < img.Width*img.Height; i++ {
+		if !bytes.Equal(img.Pix[4*i:4*i+3], img1.Pix[3*i:3*i+3]) {
+			t.Fatalf("pixel %d: got %v want %v", i, img.Pix[4*i:4*i+3], img1.Pix[3*i:3*i+3])
+		}
+	}
+}
+
+func TestJPXDecodeFilter(t *testing.T) {
+	f, err := NewFilter(JPX, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := f.Encode(bytes.NewReader(nil)); err == nil {
+		t.Fatal("expected encoding error")
+	}
+
+	bb, err := os.ReadFile(jpxTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := f.Decode(bytes.NewReader(bb))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pix, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := 1667 * 2646 * 3; len(pix) != want {
+		t.Fatalf("got %d bytes, want %d", len(pix), want)
+	}
+}
+
+func TestJPXDecodeTruncated(t *testing.T) {
+	bb, err := os.ReadFile(jpxTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Truncated codestreams decode to a lower quality image.
+	img, err := DecodeJPX(bytes.NewReader(bb[:len(bb)/2]))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 1667 || img.Height != 2646 {
+		t.Fatalf("got %dx%d", img.Width, img.Height)
+	}
+
+	for _, l := range []int{0, 8, 40, 200} {
+		if _, err := DecodeJPX(bytes.NewReader(bb[:l])); err == nil {
+			t.Errorf("len=%d: expected error", l)
+		}
+	}
+}
+
+func TestJP2File(t *testing.T) {
+	bb, err := os.ReadFile(jpxTestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// JP2 files are taken as is.
+	bb1, err := JP2File(bb, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bb, bb1) {
+		t.Fatal("JP2 file has been modified")
+	}
+
+	cs, _, err := jp2Codestream(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bb1, err = JP2File(cs, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cs1, h, err := jp2Codestream(bb1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(cs, cs1) {
+		t.Fatal("codestream has been modified")
+	}
+	if h.enumCS != jp2CMYK {
+		t.Fatalf("got enumCS=%d", h.enumCS)
+	}
+
+	img, err := DecodeJPX(bytes.NewReader(bb1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 1667 || img.Height != 2646 || img.Components != 4 {
+		t.Fatalf("got %dx%d comp=%d", img.Width, img.Height, img.Components)
+	}
+
+	if _, err := JP2File([]byte{0xFF, 0xD8, 0xFF}, nil); err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,386 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+// Coefficient state flags.
+const (
+	jpxSig     = 0x01 // significant
+	jpxNeg     = 0x02 // negative
+	jpxVisited = 0x04 // coded in the current bit plane
+	jpxRefined = 0x08 // refined at least once
+)
+
+// Coding pass types.
+const (
+	jpxSignificancePass = iota
+	jpxRefinementPass
+	jpxCleanupPass
+)
+
+// Contexts (ITU T.800 D.3).
+const (
+	jpxRunLengthContext = 17
+	jpxUniformContext   = 18
+)
+
+// jpxZeroCodingContexts holds the significance coding contexts by subband kind
+// and horizontal, vertical and diagonal significant neighbours (ITU T.800 Table D.1).
+var jpxZeroCodingContexts [4][3][3][5]uint8
+
+func init() {
+	llLH := func(h, v, d int) uint8 {
+		switch {
+		case h == 2:
+			return 8
+		case h == 1 && v >= 1:
+			return 7
+		case h == 1 && d >= 1:
+			return 6
+		case h == 1:
+			return 5
+		case v == 2:
+			return 4
+		case v == 1:
+			return 3
+		case d >= 2:
+			return 2
+		case d == 1:
+			return 1
+		}
+		return 0
+	}
+
+	hh := func(h, v, d int) uint8 {
+		hv := h + v
+		switch {
+		case d >= 3:
+			return 8
+		case d == 2 && hv >= 1:
+			return 7
+		case d == 2:
+			return 6
+		case d == 1 && hv >= 2:
+			return 5
+		case d == 1 && hv == 1:
+			return 4
+		case d == 1:
+			return 3
+		case hv >= 2:
+			return 2
+		case hv == 1:
+			return 1
+		}
+		return 0
+	}
+
+	for h := 0; h < 3; h++ {
+		for v := 0; v < 3; v++ {
+			for d := 0; d < 5; d++ {
+				jpxZeroCodingContexts[jpxLL][h][v][d] = llLH(h, v, d)
+				jpxZeroCodingContexts[jpxLH][h][v][d] = llLH(h, v, d)
+				jpxZeroCodingContexts[jpxHL][h][v][d] = llLH(v, h, d)
+				jpxZeroCodingContexts[jpxHH][h][v][d] = hh(h, v, d)
+			}
+		}
+	}
+}
+
+// jpxCodeBlockDecoder implements the coefficient bit modeling of a code-block (ITU T.800 Annex D).
+type jpxCodeBlockDecoder struct {
+	w, h    int
+	kind    int
+	cbStyle int
+	flags   []uint8 // coefficient state including a border of one coefficient
+	mag     []uint32
+	cx      arithContexts
+	ad      *arithDecoder
+	raw     *jpxBitReader // raw coding pass
+}
+
+func (d *jpxCodeBlockDecoder) resetContexts() {
+	for i := range d.cx {
+		d.cx[i] = 0
+	}
+	d.cx[0] = 4 << 1
+	d.cx[jpxRunLengthContext] = 3 << 1
+	d.cx[jpxUniformContext] = 46 << 1
+}
+
+func (d *jpxCodeBlockDecoder) bit(cx int) int {
+	if d.raw != nil {
+		return d.raw.bit()
+	}
+	return d.ad.decodeBit(d.cx, cx)
+}
+
+// neighbours returns the number of significant horizontal, vertical and diagonal neighbours of the coefficient at flag index i.
+func (d *jpxCodeBlockDecoder) neighbours(i, y int) (int, int, int) {
+	s, f := d.w+2, d.flags
+	h := int(f[i-1]&jpxSig + f[i+1]&jpxSig)
+	v := int(f[i-s] & jpxSig)
+	dg := int(f[i-s-1]&jpxSig + f[i-s+1]&jpxSig)
+	// Vertically causal context formation ignores the next stripe.
+	if d.cbStyle&jpxVertCausal == 0 || y%4 != 3 {
+		v += int(f[i+s] & jpxSig)
+		dg += int(f[i+s-1]&jpxSig + f[i+s+1]&jpxSig)
+	}
+	return h, v, dg
+}
+
+func (d *jpxCodeBlockDecoder) zeroCodingContext(i, y int) int {
+	h, v, dg := d.neighbours(i, y)
+	return int(jpxZeroCodingContexts[d.kind][h][v][dg])
+}
+
+func signOf(f uint8) int {
+	if f&jpxSig == 0 {
+		return 0
+	}
+	if f&jpxNeg > 0 {
+		return -1
+	}
+	return 1
+}
+
+// signContribution returns the sign contribution of two neighbours (ITU T.800 Table D.2).
+func signContribution(f1, f2 uint8) int {
+	return max(-1, min(1, signOf(f1)+signOf(f2)))
+}
+
+// decodeSign decodes the sign of the coefficient at flag index i (ITU T.800 Table D.3).
+func (d *jpxCodeBlockDecoder) decodeSign(i, y int) int {
+	if d.raw != nil {
+		return d.raw.bit()
+	}
+
+	s, f := d.w+2, d.flags
+	below := f[i+s]
+	if d.cbStyle&jpxVertCausal > 0 && y%4 == 3 {
+		below = 0
+	}
+	h := signContribution(f[i-1], f[i+1])
+	v := signContribution(f[i-s], below)
+
+	xor := 0
+	if h < 0 || h == 0 && v < 0 {
+		h, v, xor = -h, -v, 1
+	}
+
+	var cx int
+	switch {
+	case h == 1:
+		cx = 12 + v
+	default:
+		cx = 9 + v*v
+	}
+
+	return d.ad.decodeBit(d.cx, cx) ^ xor
+}
+
+func (d *jpxCodeBlockDecoder) setSignificant(x, y, i int, one uint32) {
+	d.flags[i] |= jpxSig
+	if d.decodeSign(i, y) == 1 {
+		d.flags[i] |= jpxNeg
+	}
+	d.mag[y*d.w+x] = one
+}
+
+func (d *jpxCodeBlockDecoder) significancePass(one uint32) {
+	s := d.w + 2
+	for y0 := 0; y0 < d.h; y0 += 4 {
+		for x := 0; x < d.w; x++ {
+			for y := y0; y < y0+4 && y < d.h; y++ {
+				i := (y+1)*s + x + 1
+				if d.flags[i]&jpxSig > 0 {
+					continue
+				}
+				cx := d.zeroCodingContext(i, y)
+				if cx == 0 {
+					continue
+				}
+				d.flags[i] |= jpxVisited
+				if d.bit(cx) == 1 {
+					d.setSignificant(x, y, i, one)
+				}
+			}
+		}
+	}
+}
+
+func (d *jpxCodeBlockDecoder) refinementPass(one uint32) {
+	s := d.w + 2
+	for y0 := 0; y0 < d.h; y0 += 4 {
+		for x := 0; x < d.w; x++ {
+			for y := y0; y < y0+4 && y < d.h; y++ {
+				i := (y+1)*s + x + 1
+				f := d.flags[i]
+				if f&(jpxSig|jpxVisited) != jpxSig {
+					continue
+				}
+				cx := 16
+				if f&jpxRefined == 0 {
+					cx = 14
+					if h, v, dg := d.neighbours(i, y); h+v+dg > 0 {
+						cx = 15
+					}
+				}
+				if d.bit(cx) == 1 {
+					d.mag[y*d.w+x] |= one
+				}
+				d.flags[i] |= jpxRefined
+			}
+		}
+	}
+}
+
+// runLength returns true if the column of four coefficients at x,y0 is coded in run-length mode.
+func (d *jpxCodeBlockDecoder) runLength(x, y0 int) bool {
+	s := d.w + 2
+	for y := y0; y < y0+4; y++ {
+		i := (y+1)*s + x + 1
+		if d.flags[i]&(jpxSig|jpxVisited) > 0 || d.zeroCodingContext(i, y) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func (d *jpxCodeBlockDecoder) cleanupPass(one uint32) {
+	s := d.w + 2
+	for y0 := 0; y0 < d.h; y0 += 4 {
+		for x := 0; x < d.w; x++ {
+			y := y0
+			if y0+4 <= d.h && d.runLength(x, y0) {
+				if d.bit(jpxRunLengthContext) == 0 {
+					continue
+				}
+				y += d.bit(jpxUniformContext)<<1 | d.bit(jpxUniformContext)
+				d.setSignificant(x, y, (y+1)*s+x+1, one)
+				y++
+			}
+			for ; y < y0+4 && y < d.h; y++ {
+				i := (y+1)*s + x + 1
+				if d.flags[i]&(jpxSig|jpxVisited) > 0 {
+					continue
+				}
+				if d.bit(d.zeroCodingContext(i, y)) == 1 {
+					d.setSignificant(x, y, i, one)
+				}
+			}
+		}
+	}
+
+	for i := range d.flags {
+		d.flags[i] &^= jpxVisited
+	}
+
+	if d.cbStyle&jpxSegmentation > 0 {
+		// Segmentation symbol 1010
+		for i := 0; i < 4; i++ {
+			d.bit(jpxUniformContext)
+		}
+	}
+}
+
+// decodeCodeBlock decodes the coding passes of cb into the quantized coefficients of band b
+// scaled by delta where planes is the number of magnitude bit planes including a ROI shift of roi.
+func decodeCodeBlock(cb *jpxCodeBlock, b *jpxBand, cbStyle, planes, roi int, delta float32) {
+	w, h := cb.x1-cb.x0, cb.y1-cb.y0
+	top := planes - 1 - cb.zeroPlanes
+	if w <= 0 || h <= 0 || cb.passes == 0 || top < 0 || top > 31 {
+		return
+	}
+
+	d := &jpxCodeBlockDecoder{
+		w: w, h: h,
+		kind:    b.kind,
+		cbStyle: cbStyle,
+		flags:   make([]uint8, (w+2)*(h+2)),
+		mag:     make([]uint32, w*h),
+		cx:      make(arithContexts, 19),
+	}
+	d.resetContexts()
+
+	k, plane, typ := 0, top, jpxCleanupPass
+
+	for _, seg := range cb.segs {
+		d.ad, d.raw = nil, nil
+		if rawSegment(cbStyle, seg.start) {
+			d.raw = &jpxBitReader{bb: seg.data}
+		} else {
+			d.ad = newArithDecoder(seg.data)
+		}
+
+		for j := 0; j < seg.passes; j, k = j+1, k+1 {
+			if k > 0 {
+				plane, typ = top-(k+2)/3, (k-1)%3
+			}
+			if plane < 0 {
+				break
+			}
+			one := uint32(1) << plane
+			switch typ {
+			case jpxSignificancePass:
+				d.significancePass(one)
+			case jpxRefinementPass:
+				d.refinementPass(one)
+			case jpxCleanupPass:
+				d.cleanupPass(one)
+			}
+			if cbStyle&jpxReset > 0 {
+				d.resetContexts()
+			}
+		}
+	}
+
+	plane = max(plane, 0)
+	bw := b.x1 - b.x0
+	s := w + 2
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			m := d.mag[y*w+x]
+			if m == 0 {
+				continue
+			}
+			f := d.flags[(y+1)*s+x+1]
+
+			// Lowest bit plane decoded for this coefficient.
+			p := plane
+			if typ == jpxSignificancePass && f&jpxVisited == 0 {
+				p++
+			}
+
+			if roi > 0 && m >= 1<<roi {
+				m >>= roi
+				p = max(p-roi, 0)
+			}
+
+			v := float32(m)
+			if p > 0 {
+				// Reconstruct at the midpoint of the remaining uncertainty.
+				v += float32(uint32(1) << (p - 1))
+			}
+			v *= delta
+			if f&jpxNeg > 0 {
+				v = -v
+			}
+
+			b.coeffs[(cb.y0-b.y0+y)*bw+cb.x0-b.x0+x] = v
+		}
+	}
+}
@@ -0,0 +1,641 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/pkg/errors"
+)
+
+// Subband kinds.
+const (
+	jpxLL = iota
+	jpxHL
+	jpxLH
+	jpxHH
+)
+
+// errJPXTruncated signals the end of the packet data of a tile.
+var errJPXTruncated = errors.New("pdfcpu: jpx: truncated tile")
+
+// jpxBitReader reads bits with bit stuffing after 0xFF bytes as used by packet headers and raw coding passes
+// (ITU T.800 B.10.1, D.6).
+type jpxBitReader struct {
+	bb  []byte
+	off int
+	cur int
+	n   int  // bits left in cur
+	ff  bool // cur is 0xFF
+	eof bool
+}
+
+func (br *jpxBitReader) bit() int {
+	if br.n == 0 {
+		b := 0xFF
+		if br.off < len(br.bb) {
+			b = int(br.bb[br.off])
+		} else {
+			br.eof = true
+		}
+		br.off++
+		br.n = 8
+		if br.ff {
+			br.n = 7
+		}
+		br.cur, br.ff = b, b == 0xFF
+	}
+	br.n--
+	return br.cur >> br.n & 1
+}
+
+func (br *jpxBitReader) bits(n int) int {
+	v := 0
+	for i := 0; i < n; i++ {
+		v = v<<1 | br.bit()
+	}
+	return v
+}
+
+// align skips the remaining bits of the current byte including a stuffed byte following 0xFF.
+func (br *jpxBitReader) align() {
+	br.n = 0
+	if br.ff {
+		br.off++
+		br.ff = false
+	}
+}
+
+// jpxTagTree represents a tag tree (ITU T.800 B.10.2).
+type jpxTagTree struct {
+	widths []int
+	levels [][]jpxTagNode
+}
+
+type jpxTagNode struct {
+	value, low int
+}
+
+func newJPXTagTree(w, h int) *jpxTagTree {
+	t := &jpxTagTree{}
+	for {
+		nodes := make([]jpxTagNode, w*h)
+		for i := range nodes {
+			nodes[i].value = math.MaxInt32
+		}
+		t.widths = append(t.widths, w)
+		t.levels = append(t.levels, nodes)
+		if w <= 1 && h <= 1 {
+			return t
+		}
+		w, h = (w+1)/2, (h+1)/2
+	}
+}
+
+// decode returns true if the value of leaf x,y is below threshold.
+func (t *jpxTagTree) decode(br *jpxBitReader, x, y, threshold int) bool {
+	low := 0
+	var n *jpxTagNode
+	for k := len(t.levels) - 1; k >= 0; k-- {
+		n = &t.levels[k][(y>>k)*t.widths[k]+(x>>k)]
+		if low > n.low {
+			n.low = low
+		} else {
+			low = n.low
+		}
+		for low < threshold && low < n.value && !br.eof {
+			if br.bit() == 1 {
+				n.value = low
+			} else {
+				low++
+			}
+		}
+		n.low = low
+	}
+	return n.value < threshold
+}
+
+// jpxSegment represents a codeword segment of a code-block (ITU T.800 D.4.1).
+type jpxSegment struct {
+	start     int // index of the first coding pass
+	passes    int
+	maxPasses int
+	data      []byte
+}
+
+// jpxCodeBlock represents a code-block and its codeword segments collected from packets.
+type jpxCodeBlock struct {
+	x0, y0, x1, y1 int
+	included       bool
+	zeroPlanes     int
+	lblock         int
+	passes         int
+	segs           []*jpxSegment
+}
+
+// segmentPasses returns the maximum number of coding passes of a segment starting with pass i.
+func segmentPasses(cbStyle, i int) int {
+	switch {
+	case cbStyle&jpxTermAll > 0:
+		return 1
+	case cbStyle&jpxBypass > 0:
+		// The first 10 passes are arithmetically coded, followed by alternating raw significance
+		// and refinement passes and arithmetically coded cleanup passes.
+		if i < 10 {
+			return 10 - i
+		}
+		if (i-10)%3 == 2 {
+			return 1
+		}
+		return 2
+	}
+	return math.MaxInt32
+}
+
+// rawSegment returns true if the segment starting with pass i uses raw coding.
+func rawSegment(cbStyle, i int) bool {
+	return cbStyle&jpxBypass > 0 && i >= 10 && (i-10)%3 != 2
+}
+
+// jpxPrecinct represents the code-blocks of a subband belonging to a precinct.
+type jpxPrecinct struct {
+	cw, ch     int // code-blocks wide and high
+	blocks     []*jpxCodeBlock
+	incl, zero *jpxTagTree
+}
+
+// jpxBand represents a subband of a tile-component.
+type jpxBand struct {
+	kind           int
+	x0, y0, x1, y1 int
+	precincts      []*jpxPrecinct
+	coeffs         []float32
+}
+
+// jpxResolution represents a resolution level of a tile-component.
+type jpxResolution struct {
+	x0, y0, x1, y1 int
+	ppx, ppy       int // precinct size exponents
+	pw, ph         int // precincts wide and high
+	bands          []*jpxBand
+	layers         []int // number of decoded layers by precinct
+}
+
+// jpxTileComponent represents a tile-component.
+type jpxTileComponent struct {
+	x0, y0, x1, y1 int
+	dx, dy         int
+	prec           int
+	signed         bool
+	cs             jpxCodingStyle
+	q              jpxQuantization
+	roi            int
+	res            []*jpxResolution
+}
+
+// jpxTile represents a tile and the state of its packet decoding.
+type jpxTile struct {
+	x0, y0, x1, y1 int
+	comps          []*jpxTileComponent
+	order          int
+	layers         int
+	mct            bool
+	sop, eph       bool
+	poc            []jpxProgression
+	data           []byte
+	off            int
+	hdr            *jpxBitReader // packed packet headers
+}
+
+// newJPXTileComponent computes the resolutions, subbands, precincts and code-blocks of a tile-component
+// (ITU T.800 B.5 - B.7).
+func newJPXTileComponent(t *jpxTile, c jpxComponent, cs jpxCodingStyle, q jpxQuantization) (*jpxTileComponent, error) {
+	tc := &jpxTileComponent{
+		x0: ceilDiv(t.x0, c.dx), y0: ceilDiv(t.y0, c.dy),
+		x1: ceilDiv(t.x1, c.dx), y1: ceilDiv(t.y1, c.dy),
+		dx: c.dx, dy: c.dy,
+		prec: c.prec, signed: c.signed,
+		cs: cs, q: q,
+	}
+
+	nl := cs.levels
+
+	for r := 0; r <= nl; r++ {
+		level := nl - r
+		res := &jpxResolution{
+			x0: ceilDiv(tc.x0, 1<<level), y0: ceilDiv(tc.y0, 1<<level),
+			x1: ceilDiv(tc.x1, 1<<level), y1: ceilDiv(tc.y1, 1<<level),
+			ppx: cs.pp[r] & 0x0F, ppy: cs.pp[r] >> 4,
+		}
+		if r > 0 && (res.ppx == 0 || res.ppy == 0) {
+			return nil, errJPXCorrupt
+		}
+		if res.x1 > res.x0 && res.y1 > res.y0 {
+			res.pw = ceilDiv(res.x1, 1<<res.ppx) - res.x0>>res.ppx
+			res.ph = ceilDiv(res.y1, 1<<res.ppy) - res.y0>>res.ppy
+		}
+		res.layers = make([]int, res.pw*res.ph)
+
+		if r == 0 {
+			res.bands = []*jpxBand{{kind: jpxLL, x0: res.x0, y0: res.y0, x1: res.x1, y1: res.y1}}
+		} else {
+			nb := level + 1
+			for kind := jpxHL; kind <= jpxHH; kind++ {
+				xo, yo := kind&1, kind>>1
+				res.bands = append(res.bands, &jpxBand{
+					kind: kind,
+					x0:   ceilDiv(tc.x0-xo<<(nb-1), 1<<nb),
+					y0:   ceilDiv(tc.y0-yo<<(nb-1), 1<<nb),
+					x1:   ceilDiv(tc.x1-xo<<(nb-1), 1<<nb),
+					y1:   ceilDiv(tc.y1-yo<<(nb-1), 1<<nb),
+				})
+			}
+		}
+
+		for _, b := range res.bands {
+			if err := b.partition(res, r, cs); err != nil {
+				return nil, err
+			}
+		}
+
+		tc.res = append(tc.res, res)
+	}
+
+	return tc, nil
+}
+
+// partition divides b into precincts and code-blocks.
+func (b *jpxBand) partition(res *jpxResolution, r int, cs jpxCodingStyle) error {
+	// Precinct size in subband coordinates.
+	ppx, ppy := res.ppx, res.ppy
+	if r > 0 {
+		ppx, ppy = ppx-1, ppy-1
+	}
+	xcb, ycb := min(cs.cbw, ppx), min(cs.cbh, ppy)
+
+	if res.pw*res.ph > 1<<24 {
+		return errJPXCorrupt
+	}
+
+	b.precincts = make([]*jpxPrecinct, res.pw*res.ph)
+
+	for i := range b.precincts {
+		px := res.x0>>res.ppx + i%res.pw
+		py := res.y0>>res.ppy + i/res.pw
+		x0, x1 := max(b.x0, px<<ppx), min(b.x1, (px+1)<<ppx)
+		y0, y1 := max(b.y0, py<<ppy), min(b.y1, (py+1)<<ppy)
+
+		prc := &jpxPrecinct{}
+		b.precincts[i] = prc
+		if x1 <= x0 || y1 <= y0 {
+			continue
+		}
+
+		cbx0, cby0 := x0>>xcb, y0>>ycb
+		prc.cw, prc.ch = ceilDiv(x1, 1<<xcb)-cbx0, ceilDiv(y1, 1<<ycb)-cby0
+		prc.incl, prc.zero = newJPXTagTree(prc.cw, prc.ch), newJPXTagTree(prc.cw, prc.ch)
+
+		for j := 0; j < prc.cw*prc.ch; j++ {
+			cx, cy := cbx0+j%prc.cw, cby0+j/prc.cw
+			prc.blocks = append(prc.blocks, &jpxCodeBlock{
+				x0: max(x0, cx<<xcb), y0: max(y0, cy<<ycb),
+				x1: min(x1, (cx+1)<<xcb), y1: min(y1, (cy+1)<<ycb),
+			})
+		}
+	}
+
+	return nil
+}
+
+// codingPasses decodes the number of coding passes (ITU T.800 Table B.4).
+func codingPasses(br *jpxBitReader) int {
+	if br.bit() == 0 {
+		return 1
+	}
+	if br.bit() == 0 {
+		return 2
+	}
+	if v := br.bits(2); v < 3 {
+		return 3 + v
+	}
+	if v := br.bits(5); v < 31 {
+		return 6 + v
+	}
+	return 37 + br.bits(7)
+}
+
+type jpxContribution struct {
+	seg    *jpxSegment
+	length int
+}
+
+// decodePacket decodes the packet of layer l of precinct p of resolution r of component c (ITU T.800 B.9, B.10).
+func (t *jpxTile) decodePacket(l, r, c, p int) error {
+	tc := t.comps[c]
+	if r >= len(tc.res) {
+		return nil
+	}
+	res := tc.res[r]
+	if p >= len(res.layers) || l < res.layers[p] {
+		return nil
+	}
+	res.layers[p] = l + 1
+
+	if t.sop && t.off+6 <= len(t.data) && t.data[t.off] == 0xFF && t.data[t.off+1] == 0x91 {
+		t.off += 6
+	}
+
+	br := t.hdr
+	if br == nil {
+		br = &jpxBitReader{bb: t.data[t.off:]}
+	}
+
+	var cc []jpxContribution
+
+	if br.bit() == 1 {
+		for _, b := range res.bands {
+			prc := b.precincts[p]
+			for i, cb := range prc.blocks {
+				if br.eof {
+					return errJPXTruncated
+				}
+				x, y := i%prc.cw, i/prc.cw
+
+				var included bool
+				if cb.included {
+					included = br.bit() == 1
+				} else {
+					included = prc.incl.decode(br, x, y, l+1)
+				}
+				if !included {
+					continue
+				}
+
+				if !cb.included {
+					z := 0
+					for !prc.zero.decode(br, x, y, z+1) {
+						if z++; z > 74 || br.eof {
+							return errJPXCorrupt
+						}
+					}
+					cb.included, cb.zeroPlanes, cb.lblock = true, z, 3
+				}
+
+				n := codingPasses(br)
+				for br.bit() == 1 && !br.eof {
+					cb.lblock++
+				}
+
+				for n > 0 {
+					var seg *jpxSegment
+					if len(cb.segs) > 0 {
+						seg = cb.segs[len(cb.segs)-1]
+					}
+					if seg == nil || seg.passes == seg.maxPasses {
+						seg = &jpxSegment{start: cb.passes, maxPasses: segmentPasses(tc.cs.cbStyle, cb.passes)}
+						cb.segs = append(cb.segs, seg)
+					}
+					k := min(n, seg.maxPasses-seg.passes)
+					if cb.lblock+bits.Len(uint(k))-1 > 32 {
+						return errJPXCorrupt
+					}
+					cc = append(cc, jpxContribution{seg: seg, length: br.bits(cb.lblock + bits.Len(uint(k)) - 1)})
+					seg.passes += k
+					cb.passes += k
+					n -= k
+				}
+			}
+		}
+	}
+
+	br.align()
+	if br.eof {
+		return errJPXTruncated
+	}
+
+	if t.eph && br.off+2 <= len(br.bb) && br.bb[br.off] == 0xFF && br.bb[br.off+1] == 0x92 {
+		br.off += 2
+	}
+
+	if t.hdr == nil {
+		t.off += br.off
+	}
+
+	for _, c := range cc {
+		if t.off+c.length > len(t.data) {
+			c.seg.data = append(c.seg.data, t.data[t.off:]...)
+			t.off = len(t.data)
+			return errJPXTruncated
+		}
+		c.seg.data = append(c.seg.data, t.data[t.off:t.off+c.length]...)
+		t.off += c.length
+	}
+
+	return nil
+}
+
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
+
+// precinctAt returns the precinct of resolution r of component c starting at x,y on the reference grid
+// (ITU T.800 B.12.1.3).
+func (t *jpxTile) precinctAt(c, r, x, y int) (int, bool) {
+	tc := t.comps[c]
+	if r >= len(tc.res) {
+		return 0, false
+	}
+	res := tc.res[r]
+	if res.pw == 0 || res.ph == 0 {
+		return 0, false
+	}
+	level := len(tc.res) - 1 - r
+
+	if !(y%(tc.dy<<(res.ppy+level)) == 0 || y == t.y0 && (res.y0<<level)%(1<<(res.ppy+level)) != 0) {
+		return 0, false
+	}
+	if !(x%(tc.dx<<(res.ppx+level)) == 0 || x == t.x0 && (res.x0<<level)%(1<<(res.ppx+level)) != 0) {
+		return 0, false
+	}
+
+	px := ceilDiv(x, tc.dx<<level)>>res.ppx - res.x0>>res.ppx
+	py := ceilDiv(y, tc.dy<<level)>>res.ppy - res.y0>>res.ppy
+	if px < 0 || py < 0 || px >= res.pw || py >= res.ph {
+		return 0, false
+	}
+
+	return px + py*res.pw, true
+}
+
+// positionSteps returns the steps on the reference grid between the precincts of the given components and resolutions.
+func (t *jpxTile) positionSteps(cs, ce, rs, re int) (int, int) {
+	xs, ys := 0, 0
+	for c := cs; c < ce; c++ {
+		tc := t.comps[c]
+		for r := rs; r < re && r < len(tc.res); r++ {
+			level := len(tc.res) - 1 - r
+			xs = gcd(xs, tc.dx<<(tc.res[r].ppx+level))
+			ys = gcd(ys, tc.dy<<(tc.res[r].ppy+level))
+		}
+	}
+	return xs, ys
+}
+
+// positions calls f for all positions on the reference grid where precincts may start.
+func (t *jpxTile) positions(xs, ys int, f func(x, y int) error) error {
+	if xs == 0 || ys == 0 {
+		return nil
+	}
+	for y := t.y0; y < t.y1; y += ys - y%ys {
+		for x := t.x0; x < t.x1; x += xs - x%xs {
+			if err := f(x, y); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// progress decodes the packets of a tile in progression order (ITU T.800 B.12).
+func (t *jpxTile) progress(pr jpxProgression) error {
+	numPrecincts := func(c, r int) int {
+		if tc := t.comps[c]; r < len(tc.res) {
+			return len(tc.res[r].layers)
+		}
+		return 0
+	}
+
+	layers := func(r, c, p int) error {
+		for l := 0; l < pr.le; l++ {
+			if err := t.decodePacket(l, r, c, p); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	switch pr.order {
+
+	case jpxLRCP:
+		for l := 0; l < pr.le; l++ {
+			for r := pr.rs; r < pr.re; r++ {
+				for c := pr.cs; c < pr.ce; c++ {
+					for p := 0; p < numPrecincts(c, r); p++ {
+						if err := t.decodePacket(l, r, c, p); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+
+	case jpxRLCP:
+		for r := pr.rs; r < pr.re; r++ {
+			for l := 0; l < pr.le; l++ {
+				for c := pr.cs; c < pr.ce; c++ {
+					for p := 0; p < numPrecincts(c, r); p++ {
+						if err := t.decodePacket(l, r, c, p); err != nil {
+							return err
+						}
+					}
+				}
+			}
+		}
+
+	case jpxRPCL:
+		for r := pr.rs; r < pr.re; r++ {
+			xs, ys := t.positionSteps(pr.cs, pr.ce, r, r+1)
+			err := t.positions(xs, ys, func(x, y int) error {
+				for c := pr.cs; c < pr.ce; c++ {
+					if p, ok := t.precinctAt(c, r, x, y); ok {
+						if err := layers(r, c, p); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+	case jpxPCRL:
+		xs, ys := t.positionSteps(pr.cs, pr.ce, pr.rs, pr.re)
+		return t.positions(xs, ys, func(x, y int) error {
+			for c := pr.cs; c < pr.ce; c++ {
+				for r := pr.rs; r < pr.re; r++ {
+					if p, ok := t.precinctAt(c, r, x, y); ok {
+						if err := layers(r, c, p); err != nil {
+							return err
+						}
+					}
+				}
+			}
+			return nil
+		})
+
+	case jpxCPRL:
+		for c := pr.cs; c < pr.ce; c++ {
+			xs, ys := t.positionSteps(c, c+1, pr.rs, pr.re)
+			err := t.positions(xs, ys, func(x, y int) error {
+				for r := pr.rs; r < pr.re; r++ {
+					if p, ok := t.precinctAt(c, r, x, y); ok {
+						if err := layers(r, c, p); err != nil {
+							return err
+						}
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// decodePackets decodes all packets of t.
+func (t *jpxTile) decodePackets() error {
+	maxRes := 0
+	for _, tc := range t.comps {
+		maxRes = max(maxRes, len(tc.res))
+	}
+
+	pp := t.poc
+	if len(pp) == 0 {
+		pp = []jpxProgression{{le: t.layers, re: maxRes, ce: len(t.comps), order: t.order}}
+	}
+
+	for _, pr := range pp {
+		pr.le, pr.re, pr.ce = min(pr.le, t.layers), min(pr.re, maxRes), min(pr.ce, len(t.comps))
+		if err := t.progress(pr); err != nil {
+			if err == errJPXTruncated {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import "math"
+
+// Lifting parameters of the 9-7 irreversible filter (ITU T.800 Table F.4).
+const (
+	jpxAlpha = -1.586134342059924
+	jpxBeta  = -0.052980118572961
+	jpxGamma = 0.882911075530934
+	jpxDelta = 0.443506852043971
+	jpxK     = 1.230174104914001
+)
+
+// jpxExtension is the number of samples a signal gets extended by on both sides.
+const jpxExtension = 4
+
+func floor32(v float32) float32 {
+	return float32(math.Floor(float64(v)))
+}
+
+// lift applies a lifting step with parameter c to the samples of buf of the given parity within [lo, len(buf)-lo).
+func lift(buf []float32, lo, parity int, c float32) {
+	j := lo
+	if j&1 != parity {
+		j++
+	}
+	for ; j < len(buf)-lo; j += 2 {
+		buf[j] -= c * (buf[j-1] + buf[j+1])
+	}
+}
+
+// synthesize1D performs the 1D inverse wavelet transform of x whose first sample has index i0 (ITU T.800 F.3.6).
+func synthesize1D(x []float32, i0 int, reversible bool, buf []float32) {
+	n := len(x)
+	if n == 1 {
+		if i0&1 == 1 {
+			x[0] /= 2
+		}
+		return
+	}
+
+	// Periodic symmetric extension (ITU T.800 F.3.7)
+	buf = buf[:n+2*jpxExtension]
+	period := 2 * (n - 1)
+	for j := range buf {
+		k := (j - jpxExtension) % period
+		if k < 0 {
+			k += period
+		}
+		if k >= n {
+			k = period - k
+		}
+		buf[j] = x[k]
+	}
+
+	// Parity of the indices into buf of low pass and high pass samples.
+	even, odd := i0&1, 1-i0&1
+
+	if reversible {
+		j := 1
+		if j&1 != even {
+			j++
+		}
+		for ; j < len(buf)-1; j += 2 {
+			buf[j] -= floor32((buf[j-1] + buf[j+1] + 2) / 4)
+		}
+		j = 2
+		if j&1 != odd {
+			j++
+		}
+		for ; j < len(buf)-2; j += 2 {
+			buf[j] += floor32((buf[j-1] + buf[j+1]) / 2)
+		}
+	} else {
+		for j := range buf {
+			if j&1 == even {
+				buf[j] *= jpxK
+			} else {
+				buf[j] *= 1 / jpxK
+			}
+		}
+		lift(buf, 1, even, jpxDelta)
+		lift(buf, 2, odd, jpxGamma)
+		lift(buf, 3, even, jpxBeta)
+		lift(buf, 4, odd, jpxAlpha)
+	}
+
+	copy(x, buf[jpxExtension:])
+}
+
+// interleave places the coefficients of b into a, the samples of resolution res (ITU T.800 F.3.3).
+func interleave(a []float32, res *jpxResolution, coeffs []float32, bx0, by0, bx1, by1, xo, yo int) {
+	w, bw := res.x1-res.x0, bx1-bx0
+	for y := by0; y < by1; y++ {
+		row := a[(2*y+yo-res.y0)*w:]
+		src := coeffs[(y-by0)*bw:]
+		for x := bx0; x < bx1; x++ {
+			row[2*x+xo-res.x0] = src[x-bx0]
+		}
+	}
+}
+
+// synthesize performs the inverse discrete wavelet transform of tc and returns its samples (ITU T.800 F.3.2).
+func (tc *jpxTileComponent) synthesize() []float32 {
+	ll := tc.res[0].bands[0].coeffs
+
+	for r := 1; r < len(tc.res); r++ {
+		res, prev := tc.res[r], tc.res[r-1]
+		w, h := res.x1-res.x0, res.y1-res.y0
+		if w == 0 || h == 0 {
+			ll = nil
+			continue
+		}
+
+		a := make([]float32, w*h)
+		interleave(a, res, ll, prev.x0, prev.y0, prev.x1, prev.y1, 0, 0)
+		for _, b := range res.bands {
+			interleave(a, res, b.coeffs, b.x0, b.y0, b.x1, b.y1, b.kind&1, b.kind>>1)
+		}
+
+		buf := make([]float32, max(w, h)+2*jpxExtension)
+
+		for y := 0; y < h; y++ {
+			synthesize1D(a[y*w:(y+1)*w], res.x0, tc.cs.reversible, buf)
+		}
+
+		col := make([]float32, h)
+		for x := 0; x < w; x++ {
+			for y := range col {
+				col[y] = a[y*w+x]
+			}
+			synthesize1D(col, res.y0, tc.cs.reversible, buf)
+			for y, v := range col {
+				a[y*w+x] = v
+			}
+		}
+
+		ll = a
+	}
+
+	return ll
+}
@@ -58,11 +58,12 @@ func (f lzwDecode) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for an LZWDecode filter.
-func (f lzwDecode) Decode(r io.Reader) (io.Reader, error) {
+func (f lzwDecode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f lzwDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
+// DecodeLength returns a reader decompressing r on demand.
+func (f lzwDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
 	if log.TraceEnabled() {
 		log.Trace.Println("DecodeLZW begin")
 	}
@@ -77,24 +78,5 @@ func (f lzwDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
 		ec = 1
 	}
 
-	rc := lzw.NewReader(r, ec == 1)
-	defer rc.Close()
-
-	var b bytes.Buffer
-	var written int64
-	var err error
-	if maxLen < 0 {
-		written, err = io.Copy(&b, rc)
-	} else {
-		written, err = io.CopyN(&b, rc, maxLen)
-	}
-	if err != nil {
-		return nil, err
-	}
-
-	if log.TraceEnabled() {
-		log.Trace.Printf("DecodeLZW: decoded %d bytes.\n", written)
-	}
-
-	return &b, nil
+	return limitReadCloser(lzw.NewReader(r, ec == 1), maxLen), nil
 }
@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter
+
+import (
+	"io"
+	"sync"
+)
+
+// Factory returns a filter for an optional parameter dictionary.
+type Factory func(parms map[string]int) (Filter, error)
+
+// JBIG2GlobalsFilter is implemented by JBIG2Decode filters taking the decoded content of a JBIG2Globals stream into account.
+type JBIG2GlobalsFilter interface {
+	WithJBIG2Globals(globals []byte) Filter
+}
+
+// JPXDecoder is implemented by JPXDecode filters returning the decoded image along with its geometry.
+// A registered JPXDecode filter needs to implement JPXDecoder in order to be used for image extraction and optimization.
+type JPXDecoder interface {
+	DecodeJPX(r io.Reader) (*JPXImage, error)
+}
+
+var registry = struct {
+	sync.RWMutex
+	m map[string]Factory
+}{m: map[string]Factory{}}
+
+// Register makes factory provide the filter named filterName taking precedence over any built in implementation.
+// This allows applications to supply implementations for unsupported or proprietary filters
+// which then get used transparently for validation, extraction and optimization.
+// A registered filter may return ErrUnsupportedFilter for data it is unable to decode.
+// Registering a nil factory restores the built in implementation.
+func Register(filterName string, factory Factory) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	if factory == nil {
+		delete(registry.m, filterName)
+		return
+	}
+
+	registry.m[filterName] = factory
+}
+
+// Registered reports whether an application supplied implementation is registered for filterName.
+func Registered(filterName string) bool {
+	_, ok := registered(filterName)
+	return ok
+}
+
+func registered(filterName string) (Factory, bool) {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	factory, ok := registry.m[filterName]
+	return factory, ok
+}
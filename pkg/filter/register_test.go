@@ -0,0 +1,136 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package filter_test
+
+import (
+	"bytes"
+	"io"
+	"slices"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+)
+
+// reverse is a proprietary filter reversing its input.
+type reverse struct {
+	parms map[string]int
+}
+
+func reversed(r io.Reader) ([]byte, error) {
+	bb, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	slices.Reverse(bb)
+	return bb, nil
+}
+
+func (f reverse) Encode(r io.Reader) (io.Reader, error) {
+	bb, err := reversed(r)
+	return bytes.NewReader(bb), err
+}
+
+func (f reverse) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f reverse) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	bb, err := reversed(r)
+	if maxLen >= 0 && int64(len(bb)) > maxLen {
+		bb = bb[:maxLen]
+	}
+	return io.NopCloser(bytes.NewReader(bb)), err
+}
+
+// jpx is a JPXDecode filter returning a fixed image.
+type jpx struct {
+	reverse
+}
+
+func (f jpx) DecodeJPX(r io.Reader) (*filter.JPXImage, error) {
+	return &filter.JPXImage{Width: 1, Height: 1, Components: 1, BPC: 8, ColorSpace: "DeviceGray", Pix: []byte{0x80}}, nil
+}
+
+func TestRegister(t *testing.T) {
+	const name = "ReverseDecode"
+
+	if _, err := filter.NewFilter(name, nil); err == nil {
+		t.Fatalf("%s: want error for unregistered filter\n", name)
+	}
+
+	filter.Register(name, func(parms map[string]int) (filter.Filter, error) {
+		return reverse{parms}, nil
+	})
+	defer filter.Register(name, nil)
+
+	if !filter.Registered(name) || !filter.SupportsDecodeParms(name) {
+		t.Fatalf("%s: want registered filter supporting decode parms\n", name)
+	}
+
+	f, err := filter.NewFilter(name, map[string]int{"Columns": 4})
+	if err != nil {
+		t.Fatalf("%s: %v\n", name, err)
+	}
+	if f.(reverse).parms["Columns"] != 4 {
+		t.Fatalf("%s: missing decode parms\n", name)
+	}
+
+	r, err := f.Encode(bytes.NewReader([]byte("abc")))
+	if err != nil {
+		t.Fatalf("%s: %v\n", name, err)
+	}
+	rc, err := f.Decode(r)
+	if err != nil {
+		t.Fatalf("%s: %v\n", name, err)
+	}
+	if bb, _ := io.ReadAll(rc); string(bb) != "abc" {
+		t.Fatalf("%s: want abc, got %s\n", name, bb)
+	}
+
+	filter.Register(name, nil)
+	if filter.Registered(name) {
+		t.Fatalf("%s: want unregistered filter\n", name)
+	}
+}
+
+func TestRegisterOverridesBuiltin(t *testing.T) {
+	filter.Register(filter.JPX, func(parms map[string]int) (filter.Filter, error) {
+		return jpx{}, nil
+	})
+	defer filter.Register(filter.JPX, nil)
+
+	f, err := filter.NewFilter(filter.JPX, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := f.(jpx); !ok {
+		t.Fatalf("want registered JPX filter, got %T\n", f)
+	}
+
+	img, err := filter.DecodeJPX(bytes.NewReader([]byte("no JPEG 2000 data")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Width != 1 || img.Height != 1 || !bytes.Equal(img.Pix, []byte{0x80}) {
+		t.Fatalf("want image of registered JPX filter, got %v\n", img)
+	}
+
+	filter.Register(filter.JPX, nil)
+	if _, err := filter.DecodeJPX(bytes.NewReader([]byte("no JPEG 2000 data"))); err == nil {
+		t.Fatal("want error from built in JPX filter")
+	}
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package filter
 
 import (
+	"bufio"
 	"bytes"
 	"io"
 )
@@ -25,40 +26,77 @@ type runLengthDecode struct {
 	baseFilter
 }
 
-func (f runLengthDecode) decode(w io.ByteWriter, src []byte, maxLen int64) {
-	var written int64
+// runLengthReader decodes runs on demand.
+type runLengthReader struct {
+	r   *bufio.Reader
+	lit int  // remaining bytes of the current literal run
+	rep int  // remaining repetitions of b
+	b   byte // the byte of the current constant run
+	err error
+}
+
+func (rr *runLengthReader) readByte() (byte, bool) {
+	b, err := rr.r.ReadByte()
+	if err != nil {
+		rr.err = err
+		return 0, false
+	}
+	return b, true
+}
+
+func (rr *runLengthReader) Read(p []byte) (int, error) {
+	n := 0
+
+	for n < len(p) {
+
+		if rr.rep > 0 {
+			p[n] = rr.b
+			rr.rep--
+			n++
+			continue
+		}
+
+		if rr.lit > 0 {
+			b, ok := rr.readByte()
+			if !ok {
+				break
+			}
+			p[n] = b
+			rr.lit--
+			n++
+			continue
+		}
+
+		if rr.err != nil {
+			break
+		}
+
+		b, ok := rr.readByte()
+		if !ok {
+			break
+		}
 
-	for i := 0; i < len(src); {
-		b := src[i]
 		if b == 0x80 {
 			// eod
+			rr.err = io.EOF
 			break
 		}
-		i++
-		if b < 0x80 {
-			c := int(b) + 1
-			for j := 0; j < c; j++ {
-				if maxLen >= 0 && maxLen == written {
-					break
-				}
 
-				w.WriteByte(src[i])
-				written++
-				i++
-			}
+		if b < 0x80 {
+			rr.lit = int(b) + 1
 			continue
 		}
-		c := 257 - int(b)
-		for j := 0; j < c; j++ {
-			if maxLen >= 0 && maxLen == written {
-				break
-			}
 
-			w.WriteByte(src[i])
-			written++
+		if rr.b, ok = rr.readByte(); ok {
+			rr.rep = 257 - int(b)
 		}
-		i++
 	}
+
+	if n > 0 {
+		return n, nil
+	}
+
+	return 0, rr.err
 }
 
 func (f runLengthDecode) encode(w io.ByteWriter, src []byte) {
@@ -135,19 +173,11 @@ func (f runLengthDecode) Encode(r io.Reader) (io.Reader, error) {
 }
 
 // Decode implements decoding for an RunLengthDecode filter.
-func (f runLengthDecode) Decode(r io.Reader) (io.Reader, error) {
+func (f runLengthDecode) Decode(r io.Reader) (io.ReadCloser, error) {
 	return f.DecodeLength(r, -1)
 }
 
-func (f runLengthDecode) DecodeLength(r io.Reader, maxLen int64) (io.Reader, error) {
-
-	b1, err := getReaderBytes(r)
-	if err != nil {
-		return nil, err
-	}
-
-	var b2 bytes.Buffer
-	f.decode(&b2, b1, maxLen)
-
-	return &b2, nil
+// DecodeLength returns a reader decoding r on demand.
+func (f runLengthDecode) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	return limitReadCloser(io.NopCloser(&runLengthReader{r: bufio.NewReader(r)}), maxLen), nil
 }
@@ -19,6 +19,7 @@ package filter
 import (
 	"bytes"
 	"encoding/hex"
+	"io"
 	"testing"
 )
 
@@ -70,9 +71,15 @@ func TestRunLengthEncoding(t *testing.T) {
 		f.encode(&enc, []byte(tt.raw))
 		compare(t, enc.Bytes(), []byte(tt.enc))
 
-		var raw bytes.Buffer
-		f.decode(&raw, enc.Bytes(), -1)
-		compare(t, raw.Bytes(), []byte(tt.raw))
+		r, err := f.Decode(&enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		raw, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		compare(t, raw, []byte(tt.raw))
 	}
 
 }
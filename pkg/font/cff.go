@@ -0,0 +1,732 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// CFF DICT operators, two byte operators are represented as 1200 + second byte.
+const (
+	cffCharset     = 15
+	cffEncoding    = 16
+	cffCharStrings = 17
+	cffPrivate     = 18
+	cffSubrs       = 19
+	cffROS         = 1230
+	cffFDArray     = 1236
+	cffFDSelect    = 1237
+)
+
+// cffEndChar is a Type 2 charstring without outline.
+var cffEndChar = []byte{14}
+
+var errCorruptCFF = errors.New("pdfcpu: corrupt CFF font")
+
+type cffDictEntry struct {
+	op       int
+	operands [][]byte // raw encoded operands
+}
+
+type cffDict []cffDictEntry
+
+// cffPrivateDict represents a Private DICT along with its local subroutines.
+type cffPrivateDict struct {
+	dict  cffDict
+	subrs [][]byte
+}
+
+// cffFont represents the parts of a CFF font program relevant for subsetting.
+type cffFont struct {
+	names       [][]byte
+	top         cffDict
+	strings     [][]byte
+	gsubrs      [][]byte
+	charStrings [][]byte
+	charset     []byte // nil for predefined charsets
+	encoding    []byte // nil for predefined encodings
+	fdSelect    []byte
+	fdArray     []cffDict
+	privates    []*cffPrivateDict // one per FDArray entry or the Private DICT of a name keyed font
+}
+
+func cffIndex(bb []byte, off int) ([][]byte, int, error) {
+	if off+2 > len(bb) {
+		return nil, 0, errCorruptCFF
+	}
+
+	count := int(binary.BigEndian.Uint16(bb[off:]))
+	if count == 0 {
+		return nil, off + 2, nil
+	}
+
+	if off+3 > len(bb) {
+		return nil, 0, errCorruptCFF
+	}
+
+	offSize := int(bb[off+2])
+	if offSize < 1 || offSize > 4 {
+		return nil, 0, errCorruptCFF
+	}
+
+	offsets := off + 3
+	data := offsets + (count+1)*offSize - 1
+	if data >= len(bb) {
+		return nil, 0, errCorruptCFF
+	}
+
+	offset := func(i int) int {
+		v := 0
+		for _, b := range bb[offsets+i*offSize : offsets+(i+1)*offSize] {
+			v = v<<8 | int(b)
+		}
+		return data + v
+	}
+
+	elems := make([][]byte, count)
+	for i := 0; i < count; i++ {
+		from, thru := offset(i), offset(i+1)
+		if from > thru || thru > len(bb) {
+			return nil, 0, errCorruptCFF
+		}
+		elems[i] = bb[from:thru]
+	}
+
+	return elems, offset(count), nil
+}
+
+func writeCFFIndex(buf *bytes.Buffer, elems [][]byte) {
+	buf.Write(uint16ToBigEndianBytes(uint16(len(elems))))
+	if len(elems) == 0 {
+		return
+	}
+
+	buf.WriteByte(4)
+	off := 1
+	buf.Write(uint32ToBigEndianBytes(uint32(off)))
+	for _, e := range elems {
+		off += len(e)
+		buf.Write(uint32ToBigEndianBytes(uint32(off)))
+	}
+	for _, e := range elems {
+		buf.Write(e)
+	}
+}
+
+func cffIndexSize(elems [][]byte) int {
+	if len(elems) == 0 {
+		return 2
+	}
+	n := 3 + (len(elems)+1)*4
+	for _, e := range elems {
+		n += len(e)
+	}
+	return n
+}
+
+func parseCFFDict(bb []byte) (cffDict, error) {
+	var (
+		d        cffDict
+		operands [][]byte
+	)
+
+	for i := 0; i < len(bb); {
+		b0 := bb[i]
+		j := i + 1
+
+		switch {
+
+		case b0 <= 21:
+			op := int(b0)
+			if b0 == 12 {
+				if j >= len(bb) {
+					return nil, errCorruptCFF
+				}
+				op = 1200 + int(bb[j])
+				j++
+			}
+			d = append(d, cffDictEntry{op: op, operands: operands})
+			operands = nil
+			i = j
+			continue
+
+		case b0 == 28:
+			j += 2
+
+		case b0 == 29:
+			j += 4
+
+		case b0 == 30:
+			for ; j < len(bb) && bb[j]&0x0F != 0x0F && bb[j]&0xF0 != 0xF0; j++ {
+			}
+			j++
+
+		case b0 >= 32 && b0 <= 246:
+
+		case b0 >= 247 && b0 <= 254:
+			j++
+
+		default:
+			return nil, errCorruptCFF
+		}
+
+		if j > len(bb) {
+			return nil, errCorruptCFF
+		}
+
+		operands = append(operands, bb[i:j])
+		i = j
+	}
+
+	return d, nil
+}
+
+func cffOperandInt(b []byte) (int, error) {
+	b0 := int(b[0])
+	switch {
+	case b0 >= 32 && b0 <= 246:
+		return b0 - 139, nil
+	case b0 >= 247 && b0 <= 250:
+		return (b0-247)*256 + int(b[1]) + 108, nil
+	case b0 >= 251 && b0 <= 254:
+		return -(b0-251)*256 - int(b[1]) - 108, nil
+	case b0 == 28:
+		return int(int16(binary.BigEndian.Uint16(b[1:]))), nil
+	case b0 == 29:
+		return int(int32(binary.BigEndian.Uint32(b[1:]))), nil
+	}
+	return 0, errCorruptCFF
+}
+
+// cffOperandBytes encodes i using five bytes so that the size of a DICT doesn't depend on offset values.
+func cffOperandBytes(i int) []byte {
+	return append([]byte{29}, uint32ToBigEndianBytes(uint32(int32(i)))...)
+}
+
+func (d cffDict) entry(op int) *cffDictEntry {
+	for i := range d {
+		if d[i].op == op {
+			return &d[i]
+		}
+	}
+	return nil
+}
+
+func (d cffDict) ints(op int) ([]int, bool, error) {
+	e := d.entry(op)
+	if e == nil {
+		return nil, false, nil
+	}
+	ii := make([]int, len(e.operands))
+	for i, b := range e.operands {
+		v, err := cffOperandInt(b)
+		if err != nil {
+			return nil, false, err
+		}
+		ii[i] = v
+	}
+	return ii, true, nil
+}
+
+func (d cffDict) offset(op int) (int, bool, error) {
+	ii, ok, err := d.ints(op)
+	if err != nil || !ok {
+		return 0, ok, err
+	}
+	if len(ii) != 1 {
+		return 0, false, errCorruptCFF
+	}
+	return ii[0], true, nil
+}
+
+func (d cffDict) set(op int, ii ...int) cffDict {
+	operands := make([][]byte, len(ii))
+	for i, v := range ii {
+		operands[i] = cffOperandBytes(v)
+	}
+	if e := d.entry(op); e != nil {
+		e.operands = operands
+		return d
+	}
+	return append(d, cffDictEntry{op: op, operands: operands})
+}
+
+func (d cffDict) bytes() []byte {
+	var buf bytes.Buffer
+	for _, e := range d {
+		// ROS has to be the first operator of a CIDFont's Top DICT.
+		if e.op == cffROS {
+			d.writeEntry(&buf, e)
+		}
+	}
+	for _, e := range d {
+		if e.op != cffROS {
+			d.writeEntry(&buf, e)
+		}
+	}
+	return buf.Bytes()
+}
+
+func (d cffDict) writeEntry(buf *bytes.Buffer, e cffDictEntry) {
+	for _, b := range e.operands {
+		buf.Write(b)
+	}
+	if e.op >= 1200 {
+		buf.WriteByte(12)
+		buf.WriteByte(byte(e.op - 1200))
+		return
+	}
+	buf.WriteByte(byte(e.op))
+}
+
+func (f *cffFont) cidKeyed() bool {
+	return f.top.entry(cffROS) != nil
+}
+
+func cffCharsetSize(bb []byte, off, numGlyphs int) (int, error) {
+	if off >= len(bb) {
+		return 0, errCorruptCFF
+	}
+
+	switch bb[off] {
+	case 0:
+		return 1 + 2*(numGlyphs-1), nil
+	case 1, 2:
+		rangeSize := 3
+		if bb[off] == 2 {
+			rangeSize = 4
+		}
+		n, i := 1, off+1
+		for n < numGlyphs {
+			if i+rangeSize > len(bb) {
+				return 0, errCorruptCFF
+			}
+			nLeft := int(bb[i+2])
+			if rangeSize == 4 {
+				nLeft = int(binary.BigEndian.Uint16(bb[i+2:]))
+			}
+			n += nLeft + 1
+			i += rangeSize
+		}
+		return i - off, nil
+	}
+
+	return 0, errCorruptCFF
+}
+
+func cffEncodingSize(bb []byte, off int) (int, error) {
+	if off+2 > len(bb) {
+		return 0, errCorruptCFF
+	}
+
+	n := 2
+	switch bb[off] & 0x7F {
+	case 0:
+		n += int(bb[off+1])
+	case 1:
+		n += 2 * int(bb[off+1])
+	default:
+		return 0, errCorruptCFF
+	}
+
+	if bb[off]&0x80 > 0 {
+		// Supplements
+		if off+n >= len(bb) {
+			return 0, errCorruptCFF
+		}
+		n += 1 + 3*int(bb[off+n])
+	}
+
+	return n, nil
+}
+
+func cffFDSelectSize(bb []byte, off, numGlyphs int) (int, error) {
+	if off >= len(bb) {
+		return 0, errCorruptCFF
+	}
+
+	switch bb[off] {
+	case 0:
+		return 1 + numGlyphs, nil
+	case 3:
+		if off+3 > len(bb) {
+			return 0, errCorruptCFF
+		}
+		return 3 + 3*int(binary.BigEndian.Uint16(bb[off+1:])) + 2, nil
+	}
+
+	return 0, errCorruptCFF
+}
+
+func subslice(bb []byte, off, size int) ([]byte, error) {
+	if off < 0 || size < 0 || off+size > len(bb) {
+		return nil, errCorruptCFF
+	}
+	return bb[off : off+size], nil
+}
+
+func parseCFFPrivateDict(bb []byte, d cffDict) (*cffPrivateDict, error) {
+	ii, ok, err := d.ints(cffPrivate)
+	if err != nil {
+		return nil, err
+	}
+	if !ok || len(ii) != 2 {
+		return nil, errCorruptCFF
+	}
+
+	size, off := ii[0], ii[1]
+	b, err := subslice(bb, off, size)
+	if err != nil {
+		return nil, err
+	}
+
+	pd, err := parseCFFDict(b)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &cffPrivateDict{dict: pd}
+
+	subrsOff, ok, err := pd.offset(cffSubrs)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if p.subrs, _, err = cffIndex(bb, off+subrsOff); err != nil {
+			return nil, err
+		}
+	}
+
+	return p, nil
+}
+
+func (f *cffFont) parseCIDFont(bb []byte, numGlyphs int) error {
+	off, ok, err := f.top.offset(cffFDSelect)
+	if err != nil || !ok {
+		return errCorruptCFF
+	}
+
+	size, err := cffFDSelectSize(bb, off, numGlyphs)
+	if err != nil {
+		return err
+	}
+
+	if f.fdSelect, err = subslice(bb, off, size); err != nil {
+		return err
+	}
+
+	if off, ok, err = f.top.offset(cffFDArray); err != nil || !ok {
+		return errCorruptCFF
+	}
+
+	fds, _, err := cffIndex(bb, off)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range fds {
+		fd, err := parseCFFDict(b)
+		if err != nil {
+			return err
+		}
+		p, err := parseCFFPrivateDict(bb, fd)
+		if err != nil {
+			return err
+		}
+		f.fdArray = append(f.fdArray, fd)
+		f.privates = append(f.privates, p)
+	}
+
+	return nil
+}
+
+func (f *cffFont) parseNameKeyedFont(bb []byte) error {
+	off, ok, err := f.top.offset(cffEncoding)
+	if err != nil {
+		return err
+	}
+	if ok && off > 1 {
+		size, err := cffEncodingSize(bb, off)
+		if err != nil {
+			return err
+		}
+		if f.encoding, err = subslice(bb, off, size); err != nil {
+			return err
+		}
+	}
+
+	p, err := parseCFFPrivateDict(bb, f.top)
+	if err != nil {
+		return err
+	}
+
+	f.privates = append(f.privates, p)
+
+	return nil
+}
+
+func parseCFF(bb []byte) (*cffFont, error) {
+	if len(bb) < 4 {
+		return nil, errCorruptCFF
+	}
+
+	f := &cffFont{}
+
+	var (
+		tops [][]byte
+		err  error
+	)
+
+	off := int(bb[2])
+
+	if f.names, off, err = cffIndex(bb, off); err != nil {
+		return nil, err
+	}
+	if tops, off, err = cffIndex(bb, off); err != nil {
+		return nil, err
+	}
+	if len(f.names) != 1 || len(tops) != 1 {
+		return nil, errors.New("pdfcpu: unsupported CFF font set")
+	}
+	if f.top, err = parseCFFDict(tops[0]); err != nil {
+		return nil, err
+	}
+	if f.strings, off, err = cffIndex(bb, off); err != nil {
+		return nil, err
+	}
+	if f.gsubrs, _, err = cffIndex(bb, off); err != nil {
+		return nil, err
+	}
+
+	if off, _, err = f.top.offset(cffCharStrings); err != nil {
+		return nil, err
+	}
+	if f.charStrings, _, err = cffIndex(bb, off); err != nil {
+		return nil, err
+	}
+
+	numGlyphs := len(f.charStrings)
+	if numGlyphs == 0 {
+		return nil, errCorruptCFF
+	}
+
+	off, ok, err := f.top.offset(cffCharset)
+	if err != nil {
+		return nil, err
+	}
+	if ok && off > 2 {
+		size, err := cffCharsetSize(bb, off, numGlyphs)
+		if err != nil {
+			return nil, err
+		}
+		if f.charset, err = subslice(bb, off, size); err != nil {
+			return nil, err
+		}
+	}
+
+	if f.cidKeyed() {
+		err = f.parseCIDFont(bb, numGlyphs)
+	} else {
+		err = f.parseNameKeyedFont(bb)
+	}
+
+	return f, err
+}
+
+// cidToGID returns the mapping of CIDs to glyph ids defined by the charset of a CIDFont.
+func (f *cffFont) cidToGID() (map[int]uint16, error) {
+	m := map[int]uint16{0: 0}
+
+	b := f.charset
+	if b == nil {
+		return nil, errors.New("pdfcpu: missing CFF charset")
+	}
+
+	numGlyphs := len(f.charStrings)
+
+	switch b[0] {
+
+	case 0:
+		for gid := 1; gid < numGlyphs; gid++ {
+			m[int(binary.BigEndian.Uint16(b[1+(gid-1)*2:]))] = uint16(gid)
+		}
+
+	case 1, 2:
+		rangeSize := 3
+		if b[0] == 2 {
+			rangeSize = 4
+		}
+		for gid, i := 1, 1; gid < numGlyphs; i += rangeSize {
+			first := int(binary.BigEndian.Uint16(b[i:]))
+			nLeft := int(b[i+2])
+			if rangeSize == 4 {
+				nLeft = int(binary.BigEndian.Uint16(b[i+2:]))
+			}
+			for cid := first; cid <= first+nLeft && gid < numGlyphs; cid, gid = cid+1, gid+1 {
+				m[cid] = uint16(gid)
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func (p *cffPrivateDict) bytes() []byte {
+	if len(p.subrs) == 0 {
+		return p.dict.bytes()
+	}
+	// Local subroutines follow their Private DICT.
+	p.dict = p.dict.set(cffSubrs, 0)
+	p.dict = p.dict.set(cffSubrs, len(p.dict.bytes()))
+	return p.dict.bytes()
+}
+
+// write serializes f. All offsets are encoded using five bytes
+// so DICT sizes are known before the layout is determined.
+func (f *cffFont) write() []byte {
+	top := f.top
+	if f.charset != nil {
+		top = top.set(cffCharset, 0)
+	}
+	if f.encoding != nil {
+		top = top.set(cffEncoding, 0)
+	}
+	top = top.set(cffCharStrings, 0)
+	if f.cidKeyed() {
+		top = top.set(cffFDSelect, 0)
+		top = top.set(cffFDArray, 0)
+	} else {
+		top = top.set(cffPrivate, 0, 0)
+	}
+
+	pp := make([][]byte, len(f.privates))
+	for i, p := range f.privates {
+		pp[i] = p.bytes()
+	}
+
+	topSize := cffIndexSize([][]byte{top.bytes()})
+	off := 4 + cffIndexSize(f.names) + topSize + cffIndexSize(f.strings) + cffIndexSize(f.gsubrs)
+
+	if f.charset != nil {
+		top = top.set(cffCharset, off)
+		off += len(f.charset)
+	}
+
+	if f.encoding != nil {
+		top = top.set(cffEncoding, off)
+		off += len(f.encoding)
+	}
+
+	top = top.set(cffCharStrings, off)
+	off += cffIndexSize(f.charStrings)
+
+	var fds [][]byte
+
+	if f.cidKeyed() {
+		top = top.set(cffFDSelect, off)
+		off += len(f.fdSelect)
+
+		for i, fd := range f.fdArray {
+			fds = append(fds, fd.set(cffPrivate, len(pp[i]), 0).bytes())
+		}
+		top = top.set(cffFDArray, off)
+		off += cffIndexSize(fds)
+
+		for i, fd := range f.fdArray {
+			fds[i] = fd.set(cffPrivate, len(pp[i]), off).bytes()
+			off += len(pp[i]) + cffIndexSize(f.privates[i].subrs)
+		}
+	} else {
+		top = top.set(cffPrivate, len(pp[0]), off)
+	}
+
+	buf := bytes.NewBuffer([]byte{1, 0, 4, 4})
+	writeCFFIndex(buf, f.names)
+	writeCFFIndex(buf, [][]byte{top.bytes()})
+	writeCFFIndex(buf, f.strings)
+	writeCFFIndex(buf, f.gsubrs)
+	buf.Write(f.charset)
+	buf.Write(f.encoding)
+	writeCFFIndex(buf, f.charStrings)
+
+	if f.cidKeyed() {
+		buf.Write(f.fdSelect)
+		writeCFFIndex(buf, fds)
+	}
+
+	for i, p := range f.privates {
+		buf.Write(pp[i])
+		if len(p.subrs) > 0 {
+			writeCFFIndex(buf, p.subrs)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// CFFGlyphIDs returns the glyph ids of the CIDs in cids for the CFF font program bb.
+// CIDs of fonts not being CID-keyed are glyph ids.
+func CFFGlyphIDs(bb []byte, cids []int) (map[uint16]bool, error) {
+	f, err := parseCFF(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	gids := map[uint16]bool{}
+
+	if !f.cidKeyed() {
+		for _, cid := range cids {
+			if cid < len(f.charStrings) {
+				gids[uint16(cid)] = true
+			}
+		}
+		return gids, nil
+	}
+
+	m, err := f.cidToGID()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, cid := range cids {
+		if gid, ok := m[cid]; ok {
+			gids[gid] = true
+		}
+	}
+
+	return gids, nil
+}
+
+// SubsetCFF returns a subset of CFF font program bb containing the glyphs in usedGIDs.
+// Glyph ids are preserved, unused glyphs lose their outlines.
+func SubsetCFF(bb []byte, usedGIDs map[uint16]bool) ([]byte, error) {
+	f, err := parseCFF(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	for gid := 1; gid < len(f.charStrings); gid++ {
+		if !usedGIDs[uint16(gid)] {
+			f.charStrings[gid] = cffEndChar
+		}
+	}
+
+	return f.write(), nil
+}
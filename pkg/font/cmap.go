@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+)
+
+// CMapID identifies a TrueType cmap subtable by platform and encoding id.
+type CMapID struct {
+	Platform, Encoding uint16
+}
+
+// Frequently used cmap subtables.
+var (
+	CMapMacRoman      = CMapID{1, 0}
+	CMapWindowsSymbol = CMapID{3, 0}
+	CMapWindowsBMP    = CMapID{3, 1}
+)
+
+// CMap maps character codes to glyph ids.
+type CMap map[uint32]uint16
+
+func cmapFormat0(b []byte) (CMap, error) {
+	if len(b) < 6+256 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 0")
+	}
+	m := CMap{}
+	for c := 0; c < 256; c++ {
+		if gid := b[6+c]; gid > 0 {
+			m[uint32(c)] = uint16(gid)
+		}
+	}
+	return m, nil
+}
+
+func cmapFormat4(b []byte) (CMap, error) {
+	if len(b) < 14 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 4")
+	}
+	segCount := int(binary.BigEndian.Uint16(b[6:]) / 2)
+	endOff := 14
+	startOff := endOff + 2*segCount + 2
+	deltaOff := startOff + 2*segCount
+	rangeOff := deltaOff + 2*segCount
+	if len(b) < rangeOff+2*segCount {
+		return nil, errors.New("pdfcpu: corrupt cmap format 4")
+	}
+
+	m := CMap{}
+	for i := 0; i < segCount; i++ {
+		startCode := int(binary.BigEndian.Uint16(b[startOff+i*2:]))
+		endCode := int(binary.BigEndian.Uint16(b[endOff+i*2:]))
+		idDelta := binary.BigEndian.Uint16(b[deltaOff+i*2:])
+		idRangeOff := int(binary.BigEndian.Uint16(b[rangeOff+i*2:]))
+		for c := startCode; c <= endCode && c != 0xFFFF; c++ {
+			gid := uint16(c) + idDelta
+			if idRangeOff > 0 {
+				off := rangeOff + i*2 + idRangeOff + (c-startCode)*2
+				if off+2 > len(b) {
+					break
+				}
+				if gid = binary.BigEndian.Uint16(b[off:]); gid > 0 {
+					gid += idDelta
+				}
+			}
+			if gid > 0 {
+				m[uint32(c)] = gid
+			}
+		}
+	}
+	return m, nil
+}
+
+func cmapFormat6(b []byte) (CMap, error) {
+	if len(b) < 10 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 6")
+	}
+	firstCode := uint32(binary.BigEndian.Uint16(b[6:]))
+	count := int(binary.BigEndian.Uint16(b[8:]))
+	if len(b) < 10+count*2 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 6")
+	}
+	m := CMap{}
+	for i := 0; i < count; i++ {
+		if gid := binary.BigEndian.Uint16(b[10+i*2:]); gid > 0 {
+			m[firstCode+uint32(i)] = gid
+		}
+	}
+	return m, nil
+}
+
+func cmapFormat12(b []byte) (CMap, error) {
+	if len(b) < 16 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 12")
+	}
+	numGroups := int(binary.BigEndian.Uint32(b[12:]))
+	if numGroups < 0 || len(b) < 16+numGroups*12 {
+		return nil, errors.New("pdfcpu: corrupt cmap format 12")
+	}
+	m := CMap{}
+	for i := 0; i < numGroups; i++ {
+		g := b[16+i*12:]
+		startCode := binary.BigEndian.Uint32(g)
+		endCode := binary.BigEndian.Uint32(g[4:])
+		gid := binary.BigEndian.Uint32(g[8:])
+		if endCode < startCode || endCode-startCode > 0xFFFF {
+			return nil, errors.New("pdfcpu: corrupt cmap format 12")
+		}
+		for c := startCode; c <= endCode; c++ {
+			m[c] = uint16(gid)
+			gid++
+		}
+	}
+	return m, nil
+}
+
+func cmapSubtable(b []byte) (CMap, bool, error) {
+	if len(b) < 4 {
+		return nil, false, errors.New("pdfcpu: corrupt cmap subtable")
+	}
+
+	var (
+		m   CMap
+		err error
+	)
+
+	switch binary.BigEndian.Uint16(b) {
+	case 0:
+		m, err = cmapFormat0(b)
+	case 4:
+		m, err = cmapFormat4(b)
+	case 6:
+		m, err = cmapFormat6(b)
+	case 12:
+		m, err = cmapFormat12(b)
+	default:
+		return nil, false, nil
+	}
+
+	return m, err == nil, err
+}
+
+// TrueTypeCMaps returns the cmap subtables of TrueType font file bb using format 0, 4, 6 or 12.
+func TrueTypeCMaps(bb []byte) (map[CMapID]CMap, error) {
+	_, tables, err := checkedTTFTables(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	t, ok := tables["cmap"]
+	if !ok {
+		return nil, errors.New("pdfcpu: missing font table: cmap")
+	}
+
+	b := t.data[:t.size]
+	if len(b) < 4 {
+		return nil, errors.New("pdfcpu: corrupt font table: cmap")
+	}
+
+	tableCount := int(binary.BigEndian.Uint16(b[2:]))
+	if len(b) < 4+tableCount*8 {
+		return nil, errors.New("pdfcpu: corrupt font table: cmap")
+	}
+
+	mm := map[CMapID]CMap{}
+
+	for i := 0; i < tableCount; i++ {
+		e := b[4+i*8:]
+		id := CMapID{binary.BigEndian.Uint16(e), binary.BigEndian.Uint16(e[2:])}
+		off := int(binary.BigEndian.Uint32(e[4:]))
+		if off >= len(b) {
+			return nil, errors.New("pdfcpu: corrupt font table: cmap")
+		}
+		m, ok, err := cmapSubtable(b[off:])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			mm[id] = m
+		}
+	}
+
+	return mm, nil
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import "unicode"
+
+// Run represents a part of a text line rendered using a single font.
+type Run struct {
+	Text     string
+	FontName string
+}
+
+// Runs shapes text like Shape and splits the result into runs of chars
+// supported by the user font fontName or else by the first font of fallbacks supporting them.
+// Chars missing in all fonts are left to fontName.
+func Runs(text, fontName string, fallbacks []string, rtl bool) []Run {
+	fontNames := append([]string{fontName}, fallbacks...)
+
+	charMaps := make([]map[uint32]uint16, len(fontNames))
+	for i, fn := range fontNames {
+		charMaps[i] = userFontChars(fn)
+	}
+
+	supports := func(i int, r rune) bool {
+		_, ok := charMaps[i][uint32(r)]
+		return ok
+	}
+
+	runs := []Run{}
+	var cur int
+	var rs []rune
+
+	for _, r := range visualOrder(text, rtl, fontNames...) {
+		i := 0
+		// Whitespace and marks stick to the current font.
+		if (unicode.IsSpace(r) || unicode.Is(unicode.Mn, r)) && supports(cur, r) {
+			i = cur
+		} else {
+			for i < len(fontNames) && !supports(i, r) {
+				i++
+			}
+			if i == len(fontNames) {
+				i = 0
+			}
+		}
+		if i != cur && len(rs) > 0 {
+			runs = append(runs, Run{Text: string(rs), FontName: fontNames[cur]})
+			rs = nil
+		}
+		cur = i
+		rs = append(rs, r)
+	}
+
+	if len(rs) > 0 {
+		runs = append(runs, Run{Text: string(rs), FontName: fontNames[cur]})
+	}
+
+	return runs
+}
+
+// TextWidthWithFallbacks represents the width in user space units for a given text string
+// rendered using the user font fontName and fallbacks for missing chars.
+func TextWidthWithFallbacks(text, fontName string, fallbacks []string, fontSize int) float64 {
+	if len(fallbacks) == 0 || !IsUserFont(fontName) {
+		return TextWidth(text, fontName, fontSize)
+	}
+	var w float64
+	for _, run := range Runs(text, fontName, fallbacks, false) {
+		w += TextWidth(run.Text, run.FontName, fontSize)
+	}
+	return w
+}
+
+// UsedFallbacks returns the fonts of fallbacks needed for rendering text using the user font fontName.
+func UsedFallbacks(text, fontName string, fallbacks []string) []string {
+	if len(fallbacks) == 0 || !IsUserFont(fontName) {
+		return nil
+	}
+	m := map[string]bool{}
+	for _, run := range Runs(text, fontName, fallbacks, false) {
+		m[run.FontName] = true
+	}
+	ss := []string{}
+	for _, fn := range fallbacks {
+		if m[fn] && fn != fontName {
+			ss = append(ss, fn)
+		}
+	}
+	return ss
+}
@@ -27,6 +27,7 @@ import (
 	"path/filepath"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"unicode/utf16"
 
@@ -59,6 +60,8 @@ type ttf struct {
 	HorMetricsCount    int               // hhea: numOfLongHorMetrics
 	GlyphCount         int               // maxp: numGlyphs
 	GlyphWidths        []int             // hmtx: fd.HorMetricsCount.advanceWidth
+	VerMetricsCount    int               // vhea: numOfLongVerMetrics
+	GlyphHeights       []int             // vmtx: fd.VerMetricsCount.advanceHeight
 	Chars              map[uint32]uint16 // cmap: Unicode character to glyph index
 	ToUnicode          map[uint16]uint32 // map glyph index to unicode character
 	Planes             map[int]bool      // used Unicode planes
@@ -385,6 +388,32 @@ func (t table) parseHorizontalMetricsTable(fd *ttf) error {
 	return nil
 }
 
+func (t table) parseVerticalHeaderTable(fd *ttf) error {
+	// table "vhea"
+	numOfLongVerMetrics := t.uint16(34)
+	fd.VerMetricsCount = int(numOfLongVerMetrics)
+	return nil
+}
+
+func (t table) parseVerticalMetricsTable(fd *ttf) error {
+	// table "vmtx"
+	if fd.VerMetricsCount == 0 {
+		return nil
+	}
+
+	fd.GlyphHeights = make([]int, fd.GlyphCount)
+
+	for i := 0; i < fd.VerMetricsCount && i < fd.GlyphCount; i++ {
+		fd.GlyphHeights[i] = fd.toPDFGlyphSpace(int(t.uint16(i * 4)))
+	}
+
+	for i := fd.VerMetricsCount; i < fd.GlyphCount; i++ {
+		fd.GlyphHeights[i] = fd.GlyphHeights[fd.VerMetricsCount-1]
+	}
+
+	return nil
+}
+
 func (t table) parseCMapFormat4(fd *ttf) error {
 	fd.Planes[0] = true
 	segCount := int(t.uint16(6) / 2)
@@ -595,7 +624,8 @@ func parse(tags map[string]*table, tag string, fd *ttf) error {
 	t, found := tags[tag]
 	if !found {
 		// OS/2 is optional for True Type fonts.
-		if tag == "OS/2" {
+		// vhea and vmtx are only present in fonts supporting vertical writing.
+		if tag == "OS/2" || tag == "vhea" || tag == "vmtx" {
 			return nil
 		}
 		return fmt.Errorf("pdfcpu: tag: %s unavailable", tag)
@@ -621,6 +651,10 @@ func parse(tags map[string]*table, tag string, fd *ttf) error {
 		err = t.parseMaximumProfile(fd)
 	case "hmtx":
 		err = t.parseHorizontalMetricsTable(fd)
+	case "vhea":
+		err = t.parseVerticalHeaderTable(fd)
+	case "vmtx":
+		err = t.parseVerticalMetricsTable(fd)
 	case "cmap":
 		err = t.parseCharToGlyphMappingTable(fd)
 	}
@@ -651,7 +685,7 @@ func readGob(fileName string, fd *ttf) error {
 func installTrueTypeRep(fontDir, fontName string, header []byte, tables map[string]*table) error {
 	fd := ttf{}
 	//fmt.Println(fontName)
-	for _, v := range []string{"head", "OS/2", "post", "name", "hhea", "maxp", "hmtx", "cmap"} {
+	for _, v := range []string{"head", "OS/2", "post", "name", "hhea", "maxp", "hmtx", "vhea", "vmtx", "cmap"} {
 		if err := parse(tables, v, &fd); err != nil {
 			return err
 		}
@@ -687,26 +721,18 @@ func installTrueTypeRep(fontDir, fontName string, header []byte, tables map[stri
 	return nil
 }
 
-// InstallTrueTypeCollection saves an internal representation of all fonts
-// contained in a TrueType collection to the pdfcpu config dir.
-func InstallTrueTypeCollection(fontDir, fn string) error {
-	f, err := os.Open(fn)
-	if err != nil {
-		return err
-	}
-	defer f.Close()
-
+func collectionOffsets(fn string, f io.ReaderAt) ([]int64, error) {
 	b := make([]byte, 12)
-	n, err := f.Read(b)
+	n, err := f.ReadAt(b, 0)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if n != 12 {
-		return fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
+		return nil, fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
 	}
 
 	if string(b[:4]) != ttcTag {
-		return fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
+		return nil, fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
 	}
 
 	c := int(binary.BigEndian.Uint32(b[8:]))
@@ -714,15 +740,36 @@ func InstallTrueTypeCollection(fontDir, fn string) error {
 	b = make([]byte, c*4)
 	n, err = f.ReadAt(b, 12)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if n != c*4 {
-		return fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
+		return nil, fmt.Errorf("pdfcpu: corrupt ttc file: %s", fn)
 	}
 
-	// Process contained fonts.
+	offs := make([]int64, c)
 	for i := 0; i < c; i++ {
-		off := int64(binary.BigEndian.Uint32(b[i*4:]))
+		offs[i] = int64(binary.BigEndian.Uint32(b[i*4:]))
+	}
+
+	return offs, nil
+}
+
+// InstallTrueTypeCollection saves an internal representation of all fonts
+// contained in a TrueType collection to the pdfcpu config dir.
+func InstallTrueTypeCollection(fontDir, fn string) error {
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offs, err := collectionOffsets(fn, f)
+	if err != nil {
+		return err
+	}
+
+	// Process contained fonts.
+	for _, off := range offs {
 		header, tables, err := headerAndTables(fn, f, off)
 		if err != nil {
 			return err
@@ -735,6 +782,78 @@ func InstallTrueTypeCollection(fontDir, fn string) error {
 	return nil
 }
 
+// TrueTypeCollectionFaces returns the PostScript names of the fonts contained in a TrueType collection.
+func TrueTypeCollectionFaces(fn string) ([]string, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	offs, err := collectionOffsets(fn, f)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, len(offs))
+	for i, off := range offs {
+		_, tables, err := headerAndTables(fn, f, off)
+		if err != nil {
+			return nil, err
+		}
+		fd := ttf{}
+		if err := parse(tables, "name", &fd); err != nil {
+			return nil, err
+		}
+		ss[i] = fd.PostscriptName
+	}
+
+	return ss, nil
+}
+
+// InstallTrueTypeCollectionFace saves an internal representation of a single font
+// contained in a TrueType collection to the pdfcpu config dir.
+// face is either the zero based index or the PostScript name of the font.
+func InstallTrueTypeCollectionFace(fontDir, fn, face string) error {
+	faces, err := TrueTypeCollectionFaces(fn)
+	if err != nil {
+		return err
+	}
+
+	i, err := strconv.Atoi(face)
+	if err != nil {
+		i = -1
+		for j, s := range faces {
+			if strings.EqualFold(s, face) {
+				i = j
+				break
+			}
+		}
+	}
+
+	if i < 0 || i >= len(faces) {
+		return errors.Errorf("pdfcpu: %s: unknown face %q, available are: %s", fn, face, strings.Join(faces, ", "))
+	}
+
+	f, err := os.Open(fn)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	offs, err := collectionOffsets(fn, f)
+	if err != nil {
+		return err
+	}
+
+	header, tables, err := headerAndTables(fn, f, offs[i])
+	if err != nil {
+		return err
+	}
+
+	return installTrueTypeRep(fontDir, fn, header, tables)
+}
+
 // InstallTrueTypeFont saves an internal representation of TrueType font fontName to the pdfcpu config dir.
 func InstallTrueTypeFont(fontDir, fontName string) error {
 	f, err := os.Open(fontName)
@@ -824,6 +943,9 @@ func resolveCompoundGlyph(fontName string, bb []byte, usedGIDs map[uint16]bool,
 	locaFull, glyfsFull *table, numGlyphs, indexToLocFormat int) error {
 	last := false
 	for off := 10; !last; {
+		if off+4 > len(bb) {
+			return errors.Errorf("pdfcpu: corrupt compound glyph for font: %s", fontName)
+		}
 		flags := binary.BigEndian.Uint16(bb[off:])
 		last = flags&0x20 == 0
 		wordArgs := flags&0x01 > 0
@@ -850,8 +972,12 @@ func resolveCompoundGlyph(fontName string, bb []byte, usedGIDs map[uint16]bool,
 			continue
 		}
 
+		if int(gid) >= numGlyphs {
+			return errors.Errorf("pdfcpu: illegal glyph component for font: %s", fontName)
+		}
+
 		offFrom, offThru := glyphOffsets(int(gid), locaFull, glyfsFull, numGlyphs, indexToLocFormat)
-		if offThru < offFrom {
+		if offThru < offFrom || offThru > len(glyfsFull.data) {
 			return errors.Errorf("pdfcpu: illegal glyfOffset for font: %s", fontName)
 		}
 		if offFrom == offThru {
@@ -881,7 +1007,7 @@ func resolveCompoundGlyphs(fontName string, usedGIDs map[uint16]bool, locaFull,
 	}
 	for _, gid := range gids {
 		offFrom, offThru := glyphOffsets(int(gid), locaFull, glyfsFull, numGlyphs, indexToLocFormat)
-		if offThru < offFrom {
+		if offThru < offFrom || offThru > len(glyfsFull.data) {
 			return errors.Errorf("pdfcpu: illegal glyfOffset for font: %s", fontName)
 		}
 		if offFrom == offThru {
@@ -943,7 +1069,7 @@ func glyfAndLoca(fontName string, tables map[string]*table, usedGIDs map[uint16]
 
 	for _, gid := range gids {
 		offFrom, offThru := glyphOffsets(gid, locaFull, glyfsFull, numGlyphs, indexToLocFormat)
-		if offThru < offFrom {
+		if offThru < offFrom || offThru > len(glyfsFull.data) {
 			return errors.Errorf("pdfcpu: illegal glyfOffset for font: %s", fontName)
 		}
 		if offThru != offFrom {
@@ -1038,3 +1164,77 @@ func Subset(fontName string, usedGIDs map[uint16]bool) ([]byte, error) {
 
 	return createTTF(header, tables)
 }
+
+// checkedTTFTables returns the tables of font file bb making sure all table data is in bounds.
+func checkedTTFTables(bb []byte) ([]byte, map[string]*table, error) {
+	if len(bb) < 12 {
+		return nil, nil, errors.New("pdfcpu: corrupt font file")
+	}
+
+	if st := string(bb[:4]); st != sfntVersionTrueType && st != sfntVersionTrueTypeApple {
+		return nil, nil, errors.New("pdfcpu: unsupported font format")
+	}
+
+	tableCount := int(binary.BigEndian.Uint16(bb[4:]))
+	if len(bb) < 12+tableCount*16 {
+		return nil, nil, errors.New("pdfcpu: corrupt font file")
+	}
+
+	for j := 0; j < tableCount; j++ {
+		b1 := bb[12+j*16:]
+		o := binary.BigEndian.Uint32(b1[8:])
+		l := binary.BigEndian.Uint32(b1[12:])
+		if uint64(o)+uint64(l) > uint64(len(bb)) {
+			return nil, nil, errors.Errorf("pdfcpu: corrupt font table: %s", string(b1[:4]))
+		}
+	}
+
+	// Tables are read including their padding which the last table may lack,
+	// as may tables not starting on a 4 byte boundary.
+	bb = append(append([]byte(nil), bb...), 0, 0, 0)
+
+	tables, err := ttfTables(tableCount, bb)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return bb[:12], tables, nil
+}
+
+// SubsetTrueType returns a subset of TrueType font file bb containing the glyphs in usedGIDs.
+// Glyph ids are preserved, unused glyphs lose their outlines.
+func SubsetTrueType(bb []byte, usedGIDs map[uint16]bool) ([]byte, error) {
+	header, tables, err := checkedTTFTables(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	head, ok1 := tables["head"]
+	maxp, ok2 := tables["maxp"]
+	loca, ok3 := tables["loca"]
+	if !ok1 || !ok2 || !ok3 || head.size < 54 || maxp.size < 6 {
+		return nil, errors.New("pdfcpu: missing font tables")
+	}
+
+	numGlyphs := int(maxp.uint16(4))
+	entrySize := 2
+	if head.uint16(50) != 0 {
+		entrySize = 4
+	}
+	if int(loca.size) < (numGlyphs+1)*entrySize {
+		return nil, errors.New("pdfcpu: corrupt font table: loca")
+	}
+
+	gids := map[uint16]bool{}
+	for gid := range usedGIDs {
+		if int(gid) < numGlyphs {
+			gids[gid] = true
+		}
+	}
+
+	if err := glyfAndLoca("", tables, gids); err != nil {
+		return nil, err
+	}
+
+	return createTTF(header, tables)
+}
@@ -52,6 +52,8 @@ type TTFLight struct {
 	HorMetricsCount    int               // hhea: numOfLongHorMetrics
 	GlyphCount         int               // maxp: numGlyphs
 	GlyphWidths        []int             // hmtx: fd.HorMetricsCount.advanceWidth
+	VerMetricsCount    int               // vhea: numOfLongVerMetrics
+	GlyphHeights       []int             // vmtx: fd.VerMetricsCount.advanceHeight
 	Chars              map[uint32]uint16 // cmap: Unicode character to glyph index
 	ToUnicode          map[uint16]uint32 // map glyph index to unicode character
 	Planes             map[int]bool      // used Unicode planes
@@ -330,7 +332,7 @@ func glyphSpaceWidth(text, fontName string) int {
 		}
 		return w
 	}
-	for _, r := range text {
+	for _, r := range shapedRunes(text, fontName) {
 		w += CharWidth(fontName, r)
 	}
 	return w
@@ -342,6 +344,54 @@ func TextWidth(text, fontName string, fontSize int) float64 {
 	return UserSpaceUnits(float64(w), fontSize)
 }
 
+// CharHeight returns the vertical advance for a char of a user font in glyph space units.
+// Fonts without vertical metrics advance by their line height.
+func CharHeight(fontName string, r rune) int {
+	UserFontMetricsLock.RLock()
+	defer UserFontMetricsLock.RUnlock()
+	ttf, ok := UserFontMetrics[fontName]
+	if !ok {
+		return 1000
+	}
+
+	if len(ttf.GlyphHeights) > 0 {
+		pos, ok := ttf.Chars[uint32(r)]
+		if !ok {
+			pos = 0
+		}
+		if h := ttf.GlyphHeights[pos]; h > 0 {
+			return h
+		}
+	}
+
+	if h := ttf.Ascent - ttf.Descent; h > 0 {
+		return h
+	}
+
+	return 1000
+}
+
+// TextHeight represents the height in user space units for a given text string written vertically using a user font and font size.
+func TextHeight(text, fontName string, fontSize int) float64 {
+	var h int
+	for _, r := range text {
+		h += CharHeight(fontName, r)
+	}
+	return UserSpaceUnits(float64(h), fontSize)
+}
+
+const verticalPrefix = "vert:"
+
+// VerticalFontName returns the font name used to register fontName for vertical writing.
+func VerticalFontName(fontName string) string {
+	return verticalPrefix + fontName
+}
+
+// VerticalBaseFontName returns the user font name for a font name registered for vertical writing.
+func VerticalBaseFontName(fontName string) (string, bool) {
+	return strings.CutPrefix(fontName, verticalPrefix)
+}
+
 // Size returns the needed font size (aka. font scaling factor) in points
 // for rendering a given text string using a given font name with a given user space width.
 func Size(text, fontName string, width float64) int {
@@ -0,0 +1,362 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// CFF DICT operators used for building OpenType tables.
+const (
+	cffFontBBox           = 5
+	cffDefaultWidthX      = 20
+	cffNominalWidthX      = 21
+	cffIsFixedPitch       = 1201
+	cffItalicAngle        = 1202
+	cffUnderlinePosition  = 1203
+	cffUnderlineThickness = 1204
+	cffFontMatrix         = 1207
+)
+
+// cffOperandFloat decodes an integer or real DICT operand.
+func cffOperandFloat(b []byte) (float64, error) {
+	if b[0] != 30 {
+		i, err := cffOperandInt(b)
+		return float64(i), err
+	}
+
+	var sb strings.Builder
+	for _, c := range b[1:] {
+		for _, n := range []byte{c >> 4, c & 0x0F} {
+			switch {
+			case n <= 9:
+				sb.WriteByte('0' + n)
+			case n == 0x0A:
+				sb.WriteByte('.')
+			case n == 0x0B:
+				sb.WriteByte('E')
+			case n == 0x0C:
+				sb.WriteString("E-")
+			case n == 0x0E:
+				sb.WriteByte('-')
+			case n == 0x0F:
+				return strconv.ParseFloat(sb.String(), 64)
+			}
+		}
+	}
+
+	return 0, errCorruptCFF
+}
+
+func (d cffDict) floats(op int, def ...float64) []float64 {
+	e := d.entry(op)
+	if e == nil {
+		return def
+	}
+	ff := make([]float64, len(e.operands))
+	for i, b := range e.operands {
+		f, err := cffOperandFloat(b)
+		if err != nil {
+			return def
+		}
+		ff[i] = f
+	}
+	return ff
+}
+
+// charStringWidth returns the advance width encoded by a Type 2 charstring.
+// Widths set within subroutines are not taken into account.
+func charStringWidth(cs []byte, defaultWidth, nominalWidth float64) float64 {
+	var args []float64
+
+	for i := 0; i < len(cs); {
+		b0 := cs[i]
+
+		switch {
+
+		case b0 == 28:
+			if i+3 > len(cs) {
+				return defaultWidth
+			}
+			args = append(args, float64(int16(binary.BigEndian.Uint16(cs[i+1:]))))
+			i += 3
+			continue
+
+		case b0 >= 32 && b0 <= 246:
+			args = append(args, float64(int(b0)-139))
+			i++
+			continue
+
+		case b0 >= 247 && b0 <= 254:
+			if i+2 > len(cs) {
+				return defaultWidth
+			}
+			if b0 <= 250 {
+				args = append(args, float64((int(b0)-247)*256+int(cs[i+1])+108))
+			} else {
+				args = append(args, float64(-(int(b0)-251)*256-int(cs[i+1])-108))
+			}
+			i += 2
+			continue
+
+		case b0 == 255:
+			if i+5 > len(cs) {
+				return defaultWidth
+			}
+			args = append(args, float64(int32(binary.BigEndian.Uint32(cs[i+1:])))/65536)
+			i += 5
+			continue
+		}
+
+		// The width is an optional extra argument of the first stack clearing operator.
+		var hasWidth bool
+		switch b0 {
+		case 1, 3, 18, 23, 19, 20: // stems and hint masks
+			hasWidth = len(args)%2 == 1
+		case 21: // rmoveto
+			hasWidth = len(args) > 2
+		case 4, 22: // vmoveto, hmoveto
+			hasWidth = len(args) > 1
+		case 14: // endchar
+			hasWidth = len(args) == 1 || len(args) == 5
+		default:
+			return defaultWidth
+		}
+
+		if hasWidth {
+			return nominalWidth + args[0]
+		}
+		return defaultWidth
+	}
+
+	return defaultWidth
+}
+
+// fdIndex returns the FDArray index of glyph gid of a CIDFont.
+func (f *cffFont) fdIndex(gid int) int {
+	b := f.fdSelect
+	if len(b) == 0 {
+		return 0
+	}
+	if b[0] == 0 {
+		if gid+1 < len(b) {
+			return int(b[gid+1])
+		}
+		return 0
+	}
+	// Format 3
+	n := int(binary.BigEndian.Uint16(b[1:]))
+	for i := n - 1; i >= 0; i-- {
+		r := b[3+3*i:]
+		if gid >= int(binary.BigEndian.Uint16(r)) {
+			return int(r[2])
+		}
+	}
+	return 0
+}
+
+func (f *cffFont) widths(scale float64) []uint16 {
+	ww := make([]uint16, len(f.charStrings))
+	for gid, cs := range f.charStrings {
+		var p *cffPrivateDict
+		if i := f.fdIndex(gid); i < len(f.privates) {
+			p = f.privates[i]
+		}
+		def, nom := 0., 0.
+		if p != nil {
+			def = p.dict.floats(cffDefaultWidthX, 0)[0]
+			nom = p.dict.floats(cffNominalWidthX, 0)[0]
+		}
+		w := charStringWidth(cs, def, nom) * scale
+		ww[gid] = uint16(math.Max(0, math.Min(math.MaxUint16, math.Round(w))))
+	}
+	return ww
+}
+
+type otfWriter struct {
+	bytes.Buffer
+}
+
+func (w *otfWriter) u16(ii ...int) {
+	for _, i := range ii {
+		w.Write(uint16ToBigEndianBytes(uint16(i)))
+	}
+}
+
+func (w *otfWriter) u32(ii ...uint32) {
+	for _, i := range ii {
+		w.Write(uint32ToBigEndianBytes(i))
+	}
+}
+
+func nameTable(psName string) []byte {
+	family := psName
+	if i := strings.IndexByte(family, '+'); i == 6 {
+		family = family[i+1:]
+	}
+
+	names := []struct {
+		id int
+		s  string
+	}{{1, family}, {2, "Regular"}, {3, psName}, {4, family}, {6, psName}}
+
+	var w, data otfWriter
+	w.u16(0, len(names), 6+12*len(names))
+	for _, n := range names {
+		s := utf16.Encode([]rune(n.s))
+		w.u16(3, 1, 0x409, n.id, 2*len(s), data.Len())
+		for _, c := range s {
+			data.u16(int(c))
+		}
+	}
+	w.Write(data.Bytes())
+
+	return w.Bytes()
+}
+
+func otfTable(tag string, bb []byte) *table {
+	size := uint32(len(bb))
+	bb = pad(append([]byte(nil), bb...))
+	return &table{chksum: calcTableChecksum(tag, bb), size: size, padded: uint32(len(bb)), data: bb}
+}
+
+// WrapCFF returns an OpenType font containing bare CFF font program bb.
+// The font comes without character mapping, glyphs are addressed by glyph id.
+func WrapCFF(bb []byte) ([]byte, error) {
+	f, err := parseCFF(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	numGlyphs := len(f.charStrings)
+	if numGlyphs > math.MaxUint16 {
+		return nil, errors.New("pdfcpu: WrapCFF: too many glyphs")
+	}
+
+	fm := f.top.floats(cffFontMatrix, 0.001)
+	unitsPerEm := 1000
+	if fm[0] > 0 {
+		unitsPerEm = int(math.Round(1 / fm[0]))
+	}
+	if unitsPerEm < 16 || unitsPerEm > 16384 {
+		unitsPerEm = 1000
+	}
+	scale := fm[0] * float64(unitsPerEm)
+
+	bbox := f.top.floats(cffFontBBox, 0, 0, 0, 0)
+	if len(bbox) != 4 {
+		bbox = []float64{0, 0, 0, 0}
+	}
+	xMin, yMin, xMax, yMax := int(bbox[0]*scale), int(bbox[1]*scale), int(bbox[2]*scale), int(bbox[3]*scale)
+
+	ww := f.widths(scale)
+	maxWidth, sumWidth := 0, 0
+	for _, w := range ww {
+		maxWidth = max(maxWidth, int(w))
+		sumWidth += int(w)
+	}
+
+	tables := map[string]*table{"CFF ": otfTable("CFF ", bb)}
+
+	var w otfWriter
+
+	// head
+	w.u32(0x00010000, 0x00010000, 0, 0x5F0F3CF5)
+	w.u16(0x0003, unitsPerEm)
+	w.u32(0, 0, 0, 0)
+	w.u16(xMin, yMin, xMax, yMax, 0, 8, 2, 0, 0)
+	tables["head"] = otfTable("head", w.Bytes())
+	w.Reset()
+
+	// hhea
+	w.u32(0x00010000)
+	w.u16(yMax, yMin, 0, maxWidth, 0, 0, xMax, 1, 0, 0, 0, 0, 0, 0, 0, numGlyphs)
+	tables["hhea"] = otfTable("hhea", w.Bytes())
+	w.Reset()
+
+	// hmtx
+	for _, wd := range ww {
+		w.u16(int(wd), 0)
+	}
+	tables["hmtx"] = otfTable("hmtx", w.Bytes())
+	w.Reset()
+
+	// maxp
+	w.u32(0x00005000)
+	w.u16(numGlyphs)
+	tables["maxp"] = otfTable("maxp", w.Bytes())
+	w.Reset()
+
+	// OS/2 version 3
+	w.u16(3, sumWidth/numGlyphs, 400, 5, 0)
+	w.u16(unitsPerEm*65/100, unitsPerEm*60/100, 0, unitsPerEm*7/100, unitsPerEm*65/100, unitsPerEm*60/100, 0, unitsPerEm*35/100)
+	w.u16(unitsPerEm*5/100, unitsPerEm*25/100, 0)
+	w.Write(make([]byte, 10)) // panose
+	w.u32(0, 0, 0, 0)
+	w.WriteString("PDFC")
+	w.u16(0x40, 0x20, 0xFFFF, yMax, yMin, 0, yMax, -yMin)
+	w.u32(1, 0)
+	w.u16(0, 0, 0, 0x20, 1)
+	tables["OS/2"] = otfTable("OS/2", w.Bytes())
+	w.Reset()
+
+	// cmap with an empty format 4 subtable
+	w.u16(0, 1, 3, 1)
+	w.u32(12)
+	w.u16(4, 24, 0, 2, 2, 0, 0, 0xFFFF, 0, 0xFFFF, 1, 0)
+	tables["cmap"] = otfTable("cmap", w.Bytes())
+	w.Reset()
+
+	// name
+	tables["name"] = otfTable("name", nameTable(string(f.names[0])))
+
+	// post version 3
+	italicAngle := f.top.floats(cffItalicAngle, 0)[0]
+	uPos := f.top.floats(cffUnderlinePosition, -100)[0] * scale
+	uThick := f.top.floats(cffUnderlineThickness, 50)[0] * scale
+	fixedPitch := uint32(f.top.floats(cffIsFixedPitch, 0)[0])
+	w.u32(0x00030000, uint32(int32(italicAngle*65536)))
+	w.u16(int(uPos), int(uThick))
+	w.u32(fixedPitch, 0, 0, 0, 0)
+	tables["post"] = otfTable("post", w.Bytes())
+	w.Reset()
+
+	n := len(tables)
+	entrySelector := int(math.Floor(math.Log2(float64(n))))
+	searchRange := 16 << entrySelector
+	w.WriteString("OTTO")
+	w.u16(n, searchRange, entrySelector, n*16-searchRange)
+
+	otf, err := createTTF(w.Bytes(), tables)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fix the head table's checkSumAdjustment.
+	sum := calcTableChecksum("", pad(otf))
+	head := tables["head"].off
+	binary.BigEndian.PutUint32(otf[head+8:], 0xB1B0AFBA-sum)
+
+	return otf, nil
+}
@@ -0,0 +1,452 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import "unicode"
+
+// Text shaping for right-to-left scripts:
+// Arabic letters are mapped to their contextual presentation forms (Arabic Presentation Forms-A/B)
+// and bidirectional text is reordered for display following the Unicode Bidirectional Algorithm (UAX #9)
+// for a single line without explicit embeddings.
+
+// arabicForms holds the isolated, final, initial and medial presentation form of a letter, 0 for n/a.
+type arabicForms [4]rune
+
+const (
+	formIsolated = iota
+	formFinal
+	formInitial
+	formMedial
+)
+
+const (
+	arabicTatweel = 0x0640
+	arabicLam     = 0x0644
+	zwj           = 0x200D
+)
+
+var arabicPresentationForms = map[rune]arabicForms{
+	0x0671: {0xFB50, 0xFB51},                 // alef wasla
+	0x0679: {0xFB66, 0xFB67, 0xFB68, 0xFB69}, // tteh
+	0x067E: {0xFB56, 0xFB57, 0xFB58, 0xFB59}, // peh
+	0x0686: {0xFB7A, 0xFB7B, 0xFB7C, 0xFB7D}, // tcheh
+	0x0688: {0xFB88, 0xFB89},                 // ddal
+	0x0691: {0xFB8C, 0xFB8D},                 // rreh
+	0x0698: {0xFB8A, 0xFB8B},                 // jeh
+	0x06A9: {0xFB8E, 0xFB8F, 0xFB90, 0xFB91}, // keheh
+	0x06AF: {0xFB92, 0xFB93, 0xFB94, 0xFB95}, // gaf
+	0x06BA: {0xFB9E, 0xFB9F},                 // noon ghunna
+	0x06BE: {0xFBAA, 0xFBAB, 0xFBAC, 0xFBAD}, // heh doachashmee
+	0x06C0: {0xFBA4, 0xFBA5},                 // heh with yeh above
+	0x06C1: {0xFBA6, 0xFBA7, 0xFBA8, 0xFBA9}, // heh goal
+	0x06CC: {0xFBFC, 0xFBFD, 0xFBFE, 0xFBFF}, // farsi yeh
+	0x06D2: {0xFBAE, 0xFBAF},                 // yeh barree
+}
+
+// lamAlefLigatures maps alef variants to the isolated form of their ligature with a preceding lam.
+// The final form immediately follows.
+var lamAlefLigatures = map[rune]rune{
+	0x0622: 0xFEF5, // alef with madda above
+	0x0623: 0xFEF7, // alef with hamza above
+	0x0625: 0xFEF9, // alef with hamza below
+	0x0627: 0xFEFB, // alef
+}
+
+func init() {
+	// The basic Arabic letters are laid out consecutively in Arabic Presentation Forms-B.
+	next := rune(0xFE80)
+	for _, seg := range []struct {
+		from, thru rune
+		forms      int
+	}{
+		{0x0621, 0x0621, 1}, {0x0622, 0x0625, 2}, {0x0626, 0x0626, 4}, {0x0627, 0x0627, 2},
+		{0x0628, 0x0628, 4}, {0x0629, 0x0629, 2}, {0x062A, 0x062E, 4}, {0x062F, 0x0632, 2},
+		{0x0633, 0x063A, 4}, {0x0641, 0x0647, 4}, {0x0648, 0x0649, 2}, {0x064A, 0x064A, 4},
+	} {
+		for r := seg.from; r <= seg.thru; r++ {
+			var f arabicForms
+			for i := 0; i < seg.forms; i++ {
+				f[i] = next
+				next++
+			}
+			arabicPresentationForms[r] = f
+		}
+	}
+}
+
+func joinCausing(r rune) bool {
+	return r == arabicTatweel || r == zwj
+}
+
+func joinsPrevious(r rune) bool {
+	return arabicPresentationForms[r][formFinal] != 0 || joinCausing(r)
+}
+
+func joinsNext(r rune) bool {
+	return arabicPresentationForms[r][formInitial] != 0 || joinCausing(r)
+}
+
+func transparent(r rune) bool {
+	return unicode.Is(unicode.Mn, r)
+}
+
+func needsShaping(rs []rune) bool {
+	for _, r := range rs {
+		if r >= 0x0590 && r <= 0x08FF || r >= 0xFB1D && r <= 0xFEFC || r == 0x200F {
+			return true
+		}
+	}
+	return false
+}
+
+// joinArabic replaces Arabic letters by their contextual forms if supported as indicated by has.
+func joinArabic(rs []rune, has func(rune) bool) []rune {
+	out := make([]rune, 0, len(rs))
+
+	// neighbour returns the index of the next non transparent char in direction d.
+	neighbour := func(i, d int) int {
+		for i += d; i >= 0 && i < len(rs); i += d {
+			if !transparent(rs[i]) {
+				return i
+			}
+		}
+		return -1
+	}
+
+	for i := 0; i < len(rs); i++ {
+		r := rs[i]
+		forms, ok := arabicPresentationForms[r]
+		if !ok {
+			out = append(out, r)
+			continue
+		}
+
+		prev, next := neighbour(i, -1), neighbour(i, 1)
+		jp := prev >= 0 && joinsNext(rs[prev]) && joinsPrevious(r)
+
+		if r == arabicLam && next >= 0 {
+			if lig, ok := lamAlefLigatures[rs[next]]; ok {
+				if jp {
+					lig++
+				}
+				if has(lig) {
+					out = append(out, lig)
+					out = append(out, rs[i+1:next]...)
+					i = next
+					continue
+				}
+			}
+		}
+
+		jn := next >= 0 && joinsNext(r) && joinsPrevious(rs[next])
+
+		f := rune(0)
+		switch {
+		case jp && jn:
+			f = forms[formMedial]
+		case jp:
+			f = forms[formFinal]
+		case jn:
+			f = forms[formInitial]
+		}
+
+		// The isolated form is the nominal glyph.
+		if f == 0 || !has(f) {
+			f = r
+		}
+		out = append(out, f)
+	}
+
+	return out
+}
+
+type bidiClass int
+
+const (
+	bidiL bidiClass = iota
+	bidiR
+	bidiAL
+	bidiEN
+	bidiES
+	bidiET
+	bidiAN
+	bidiCS
+	bidiNSM
+	bidiWS
+	bidiON
+)
+
+func classify(r rune) bidiClass {
+	switch {
+	case r >= '0' && r <= '9', r >= 0x06F0 && r <= 0x06F9, r >= 0xFF10 && r <= 0xFF19:
+		return bidiEN
+	case r >= 0x0660 && r <= 0x0669, r == 0x066B, r == 0x066C:
+		return bidiAN
+	case r == '+', r == '-':
+		return bidiES
+	case r == '#', r == '$', r == '%', r == 0x00B0, r >= 0x00A2 && r <= 0x00A5, r == 0x066A, r >= 0x20A0 && r <= 0x20CF:
+		return bidiET
+	case r == ',', r == '.', r == ':', r == '/', r == 0x00A0, r == 0x060C:
+		return bidiCS
+	case r == ' ', r == '\t', r >= 0x2000 && r <= 0x200A:
+		return bidiWS
+	case r == 0x200E:
+		return bidiL
+	case r == 0x200F:
+		return bidiR
+	case transparent(r):
+		return bidiNSM
+	case r >= 0x0590 && r <= 0x05FF, r >= 0x07C0 && r <= 0x085F, r >= 0xFB1D && r <= 0xFB4F:
+		return bidiR
+	case r >= 0x0600 && r <= 0x07BF, r >= 0x0860 && r <= 0x08FF, r >= 0xFB50 && r <= 0xFDFF, r >= 0xFE70 && r <= 0xFEFC:
+		return bidiAL
+	case unicode.IsLetter(r), unicode.IsMark(r), unicode.IsDigit(r):
+		return bidiL
+	}
+	return bidiON
+}
+
+// resolveWeakTypes applies rules W1-W7.
+func resolveWeakTypes(cls []bidiClass, sos bidiClass) {
+	// W1
+	prev := sos
+	for i, c := range cls {
+		if c == bidiNSM {
+			cls[i] = prev
+		}
+		prev = cls[i]
+	}
+
+	// W2, W3
+	strong := sos
+	for i, c := range cls {
+		switch c {
+		case bidiL, bidiR:
+			strong = c
+		case bidiAL:
+			strong = c
+			cls[i] = bidiR
+		case bidiEN:
+			if strong == bidiAL {
+				cls[i] = bidiAN
+			}
+		}
+	}
+
+	// W4
+	for i := 1; i < len(cls)-1; i++ {
+		a, c, b := cls[i-1], cls[i], cls[i+1]
+		if a == bidiEN && b == bidiEN && (c == bidiES || c == bidiCS) {
+			cls[i] = bidiEN
+		}
+		if a == bidiAN && b == bidiAN && c == bidiCS {
+			cls[i] = bidiAN
+		}
+	}
+
+	// W5
+	for i := 0; i < len(cls); i++ {
+		if cls[i] != bidiET {
+			continue
+		}
+		j := i
+		for j < len(cls) && cls[j] == bidiET {
+			j++
+		}
+		if (i > 0 && cls[i-1] == bidiEN) || (j < len(cls) && cls[j] == bidiEN) {
+			for k := i; k < j; k++ {
+				cls[k] = bidiEN
+			}
+		}
+		i = j - 1
+	}
+
+	// W6, W7
+	strong = sos
+	for i, c := range cls {
+		switch c {
+		case bidiL, bidiR:
+			strong = c
+		case bidiES, bidiET, bidiCS:
+			cls[i] = bidiON
+		case bidiEN:
+			if strong == bidiL {
+				cls[i] = bidiL
+			}
+		}
+	}
+}
+
+// resolveNeutralTypes applies rules N1 and N2.
+func resolveNeutralTypes(cls []bidiClass, sos bidiClass) {
+	dir := func(c bidiClass) bidiClass {
+		if c == bidiEN || c == bidiAN {
+			return bidiR
+		}
+		return c
+	}
+
+	for i := 0; i < len(cls); i++ {
+		if cls[i] != bidiWS && cls[i] != bidiON {
+			continue
+		}
+		j := i
+		for j < len(cls) && (cls[j] == bidiWS || cls[j] == bidiON) {
+			j++
+		}
+		before, after := sos, sos
+		if i > 0 {
+			before = dir(cls[i-1])
+		}
+		if j < len(cls) {
+			after = dir(cls[j])
+		}
+		c := sos
+		if before == after {
+			c = before
+		}
+		for k := i; k < j; k++ {
+			cls[k] = c
+		}
+		i = j - 1
+	}
+}
+
+func bidiLevels(rs []rune, rtl bool) []int {
+	sos, base := bidiL, 0
+	if rtl {
+		sos, base = bidiR, 1
+	}
+
+	cls := make([]bidiClass, len(rs))
+	for i, r := range rs {
+		cls[i] = classify(r)
+	}
+	ws := make([]bool, len(rs))
+	for i, c := range cls {
+		ws[i] = c == bidiWS
+	}
+
+	resolveWeakTypes(cls, sos)
+	resolveNeutralTypes(cls, sos)
+
+	// I1, I2
+	levels := make([]int, len(rs))
+	for i, c := range cls {
+		l := base
+		switch {
+		case base == 0 && c == bidiR:
+			l = 1
+		case base == 0 && (c == bidiAN || c == bidiEN):
+			l = 2
+		case base == 1 && c != bidiR:
+			l = 2
+		}
+		levels[i] = l
+	}
+
+	// L1: trailing whitespace
+	for i := len(rs) - 1; i >= 0 && ws[i]; i-- {
+		levels[i] = base
+	}
+
+	return levels
+}
+
+var mirroredPairs = map[rune]rune{
+	'(': ')', ')': '(', '[': ']', ']': '[', '{': '}', '}': '{', '<': '>', '>': '<',
+	0x00AB: 0x00BB, 0x00BB: 0x00AB, 0x2039: 0x203A, 0x203A: 0x2039,
+}
+
+// reorder returns rs in visual order according to levels (rules L2 and L4).
+func reorder(rs []rune, levels []int) []rune {
+	out := make([]rune, len(rs))
+	copy(out, rs)
+
+	maxLevel := 0
+	for i, l := range levels {
+		maxLevel = max(maxLevel, l)
+		if l%2 == 1 {
+			if m, ok := mirroredPairs[out[i]]; ok {
+				out[i] = m
+			}
+		}
+	}
+
+	// Reverse from the highest level to the lowest odd level.
+	lv := append([]int(nil), levels...)
+	for l := maxLevel; l >= 1; l-- {
+		for i := 0; i < len(out); i++ {
+			if lv[i] < l {
+				continue
+			}
+			j := i
+			for j < len(out) && lv[j] >= l {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				out[a], out[b] = out[b], out[a]
+				lv[a], lv[b] = lv[b], lv[a]
+			}
+			i = j
+		}
+	}
+
+	return out
+}
+
+func userFontChars(fontName string) map[uint32]uint16 {
+	UserFontMetricsLock.RLock()
+	defer UserFontMetricsLock.RUnlock()
+	return UserFontMetrics[fontName].Chars
+}
+
+// shapedRunes joins Arabic letters of text using presentation forms supported by any of fontNames.
+func shapedRunes(text string, fontNames ...string) []rune {
+	rs := []rune(text)
+	if !needsShaping(rs) {
+		return rs
+	}
+	charMaps := make([]map[uint32]uint16, len(fontNames))
+	for i, fontName := range fontNames {
+		charMaps[i] = userFontChars(fontName)
+	}
+	return joinArabic(rs, func(r rune) bool {
+		for _, chars := range charMaps {
+			if _, ok := chars[uint32(r)]; ok {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// visualOrder returns the shaped runes of text reordered for display.
+func visualOrder(text string, rtl bool, fontNames ...string) []rune {
+	rs := shapedRunes(text, fontNames...)
+	if !rtl && !needsShaping(rs) {
+		return rs
+	}
+	return reorder(rs, bidiLevels(rs, rtl))
+}
+
+// Shape returns text shaped for rendering a single line using the user font fontName:
+// Arabic letters are replaced by the presentation forms for their joining context supported by fontName
+// and the result is reordered for display using a right-to-left paragraph direction if rtl is true.
+func Shape(text, fontName string, rtl bool) string {
+	return string(visualOrder(text, rtl, fontName))
+}
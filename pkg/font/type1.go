@@ -0,0 +1,83 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// type1Trailer terminates the encrypted portion of a Type 1 font program.
+var type1Trailer = []byte(strings.Repeat(strings.Repeat("0", 64)+"\n", 8) + "cleartomark\n")
+
+func pfbSegment(buf *bytes.Buffer, typ byte, bb []byte) {
+	buf.Write([]byte{0x80, typ})
+	b := make([]byte, 4)
+	binary.LittleEndian.PutUint32(b, uint32(len(bb)))
+	buf.Write(b)
+	buf.Write(bb)
+}
+
+// Type1ToPFB returns the embedded Type 1 font program bb in Printer Font Binary format.
+// length1 and length2 are the lengths of the clear text and the encrypted portion of bb (9.9).
+func Type1ToPFB(bb []byte, length1, length2 int) ([]byte, error) {
+	if length1 <= 0 || length1 > len(bb) {
+		i := bytes.Index(bb, []byte("eexec"))
+		if i < 0 {
+			return nil, errors.New("pdfcpu: corrupt Type 1 font")
+		}
+		length1 = i + len("eexec")
+		for length1 < len(bb) && (bb[length1] == '\r' || bb[length1] == '\n' || bb[length1] == ' ' || bb[length1] == '\t') {
+			length1++
+		}
+	}
+
+	if length2 <= 0 || length1+length2 > len(bb) {
+		length2 = len(bb) - length1
+		if i := bytes.LastIndex(bb[length1:], []byte("0000000000000000")); i >= 0 {
+			length2 = i
+			for length2 > 0 && bytes.IndexByte([]byte("0\r\n \t"), bb[length1+length2-1]) >= 0 {
+				length2--
+			}
+		}
+	}
+
+	clear, encrypted, trailer := bb[:length1], bb[length1:length1+length2], bb[length1+length2:]
+
+	// Some producers embed the encrypted portion hex encoded.
+	if s := bytes.Join(bytes.Fields(encrypted), nil); len(s) >= 4 {
+		if b, err := hex.DecodeString(string(s)); err == nil {
+			encrypted = b
+		}
+	}
+
+	if !bytes.Contains(trailer, []byte("cleartomark")) {
+		trailer = type1Trailer
+	}
+
+	var buf bytes.Buffer
+	pfbSegment(&buf, 1, clear)
+	pfbSegment(&buf, 2, encrypted)
+	pfbSegment(&buf, 1, trailer)
+	buf.Write([]byte{0x80, 3})
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,1470 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package font
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+// Support for variable TrueType fonts as defined in the OpenType "Font Variations" overview.
+// A named instance is installed as a static font by applying the glyph variations (gvar)
+// and horizontal metrics variations (HVAR) for the instance coordinates.
+
+// Tables describing the variation space which are dropped for a static instance.
+var variationTables = []string{"avar", "cvar", "fvar", "gvar", "HVAR", "MVAR", "STAT", "VVAR"}
+
+var errCorruptVariableFont = errors.New("pdfcpu: corrupt variable font")
+
+type byteReader struct {
+	bb  []byte
+	pos int
+	err error
+}
+
+func (r *byteReader) ok(n int) bool {
+	if r.err == nil && (r.pos < 0 || r.pos+n > len(r.bb)) {
+		r.err = errCorruptVariableFont
+	}
+	return r.err == nil
+}
+
+func (r *byteReader) u8() int {
+	if !r.ok(1) {
+		return 0
+	}
+	r.pos++
+	return int(r.bb[r.pos-1])
+}
+
+func (r *byteReader) i8() int {
+	return int(int8(r.u8()))
+}
+
+func (r *byteReader) u16() int {
+	if !r.ok(2) {
+		return 0
+	}
+	r.pos += 2
+	return int(binary.BigEndian.Uint16(r.bb[r.pos-2:]))
+}
+
+func (r *byteReader) i16() int {
+	return int(int16(r.u16()))
+}
+
+func (r *byteReader) u32() int {
+	if !r.ok(4) {
+		return 0
+	}
+	r.pos += 4
+	return int(binary.BigEndian.Uint32(r.bb[r.pos-4:]))
+}
+
+func (r *byteReader) i32() int {
+	return int(int32(r.u32()))
+}
+
+func (r *byteReader) fixed() float64 {
+	return float64(r.i32()) / 65536
+}
+
+func (r *byteReader) f2dot14() float64 {
+	return float64(r.i16()) / 16384
+}
+
+func (r *byteReader) bytes(n int) []byte {
+	if !r.ok(n) {
+		return nil
+	}
+	r.pos += n
+	return r.bb[r.pos-n : r.pos]
+}
+
+type variationAxis struct {
+	tag           string
+	min, def, max float64
+}
+
+type namedInstance struct {
+	subfamilyNameID  int
+	postScriptNameID int // 0xFFFF if undefined
+	coords           []float64
+}
+
+func (t table) parseFontVariationsTable() ([]variationAxis, []namedInstance, error) {
+	// table "fvar"
+	r := &byteReader{bb: t.data[:t.size]}
+	r.pos = 4
+	axesOff := r.u16()
+	r.u16()
+	axisCount := r.u16()
+	axisSize := r.u16()
+	instanceCount := r.u16()
+	instanceSize := r.u16()
+	if r.err != nil || axisSize < 20 || instanceSize < 4+4*axisCount {
+		return nil, nil, errCorruptVariableFont
+	}
+
+	axes := make([]variationAxis, axisCount)
+	for i := range axes {
+		r.pos = axesOff + i*axisSize
+		axes[i].tag = string(r.bytes(4))
+		axes[i].min = r.fixed()
+		axes[i].def = r.fixed()
+		axes[i].max = r.fixed()
+	}
+
+	instOff := axesOff + axisCount*axisSize
+	instances := make([]namedInstance, instanceCount)
+	for i := range instances {
+		r.pos = instOff + i*instanceSize
+		inst := namedInstance{subfamilyNameID: r.u16(), postScriptNameID: 0xFFFF}
+		r.u16()
+		inst.coords = make([]float64, axisCount)
+		for j := range inst.coords {
+			inst.coords[j] = r.fixed()
+		}
+		if instanceSize >= 6+4*axisCount {
+			inst.postScriptNameID = r.u16()
+		}
+		instances[i] = inst
+	}
+
+	return axes, instances, r.err
+}
+
+func (t table) parseAxisVariationsTable(axisCount int) ([][][2]float64, error) {
+	// table "avar"
+	r := &byteReader{bb: t.data[:t.size]}
+	r.pos = 6
+	if r.u16() != axisCount {
+		return nil, errCorruptVariableFont
+	}
+	maps := make([][][2]float64, axisCount)
+	for i := range maps {
+		c := r.u16()
+		for j := 0; j < c; j++ {
+			maps[i] = append(maps[i], [2]float64{r.f2dot14(), r.f2dot14()})
+		}
+	}
+	return maps, r.err
+}
+
+// normalizedCoords maps user space coordinates into the normalized range -1..1 (see "Coordinate scales and normalization").
+func normalizedCoords(axes []variationAxis, avar [][][2]float64, coords []float64) []float64 {
+	nc := make([]float64, len(axes))
+	for i, a := range axes {
+		v := math.Max(a.min, math.Min(a.max, coords[i]))
+		switch {
+		case v < a.def && a.def > a.min:
+			v = (v - a.def) / (a.def - a.min)
+		case v > a.def && a.max > a.def:
+			v = (v - a.def) / (a.max - a.def)
+		default:
+			v = 0
+		}
+		if i < len(avar) && len(avar[i]) > 1 {
+			m := avar[i]
+			for j := 1; j < len(m); j++ {
+				if v <= m[j][0] {
+					from0, to0, from1, to1 := m[j-1][0], m[j-1][1], m[j][0], m[j][1]
+					if from1 > from0 {
+						v = to0 + (v-from0)*(to1-to0)/(from1-from0)
+					} else {
+						v = to1
+					}
+					break
+				}
+			}
+		}
+		nc[i] = math.Round(v*16384) / 16384
+	}
+	return nc
+}
+
+// regionScalar returns the scalar for a variation region at normalized coordinates coords.
+// start and end are nil for tuples without an intermediate region.
+func regionScalar(peak, start, end, coords []float64) float64 {
+	s := 1.
+	for i, p := range peak {
+		if p == 0 {
+			continue
+		}
+		var v float64
+		if i < len(coords) {
+			v = coords[i]
+		}
+		if v == p {
+			continue
+		}
+		lo, hi := math.Min(0, p), math.Max(0, p)
+		if start != nil {
+			lo, hi = start[i], end[i]
+			if lo > p || p > hi || (lo < 0 && hi > 0) {
+				continue
+			}
+		}
+		if v <= lo || v >= hi {
+			if v == 0 || v < lo || v > hi || (v == lo && lo != 0 && v != p) || (v == hi && hi != 0 && v != p) {
+				return 0
+			}
+		}
+		if v < p {
+			if p == lo {
+				continue
+			}
+			s *= (v - lo) / (p - lo)
+		} else {
+			if p == hi {
+				continue
+			}
+			s *= (hi - v) / (hi - p)
+		}
+	}
+	return s
+}
+
+type glyphVariations struct {
+	data         []byte
+	axisCount    int
+	sharedTuples [][]float64
+	offsets      []int // glyph variation data offsets into data
+}
+
+func (t table) parseGlyphVariationsTable(glyphCount int) (*glyphVariations, error) {
+	// table "gvar"
+	r := &byteReader{bb: t.data[:t.size]}
+	r.pos = 4
+	gv := &glyphVariations{data: r.bb, axisCount: r.u16()}
+	sharedTupleCount := r.u16()
+	sharedTuplesOff := r.u32()
+	c := r.u16()
+	flags := r.u16()
+	arrayOff := r.u32()
+	if r.err != nil || c < glyphCount {
+		return nil, errCorruptVariableFont
+	}
+
+	gv.offsets = make([]int, c+1)
+	for i := range gv.offsets {
+		if flags&1 == 0 {
+			gv.offsets[i] = arrayOff + 2*r.u16()
+		} else {
+			gv.offsets[i] = arrayOff + r.u32()
+		}
+	}
+
+	r.pos = sharedTuplesOff
+	for i := 0; i < sharedTupleCount; i++ {
+		tuple := make([]float64, gv.axisCount)
+		for j := range tuple {
+			tuple[j] = r.f2dot14()
+		}
+		gv.sharedTuples = append(gv.sharedTuples, tuple)
+	}
+
+	return gv, r.err
+}
+
+// unpackPoints returns packed point numbers or nil for all points.
+func unpackPoints(r *byteReader) []int {
+	n := r.u8()
+	if n == 0 {
+		return nil
+	}
+	if n&0x80 > 0 {
+		n = (n&0x7F)<<8 | r.u8()
+	}
+	pts := make([]int, 0, n)
+	var last int
+	for len(pts) < n && r.err == nil {
+		ctl := r.u8()
+		for j := 0; j < ctl&0x7F+1 && len(pts) < n; j++ {
+			if ctl&0x80 > 0 {
+				last += r.u16()
+			} else {
+				last += r.u8()
+			}
+			pts = append(pts, last)
+		}
+	}
+	return pts
+}
+
+func unpackDeltas(r *byteReader, n int) []int {
+	d := make([]int, 0, n)
+	for len(d) < n && r.err == nil {
+		ctl := r.u8()
+		for j := 0; j < ctl&0x3F+1 && len(d) < n; j++ {
+			switch ctl & 0xC0 {
+			case 0x80:
+				d = append(d, 0)
+			case 0x40:
+				d = append(d, r.i16())
+			case 0xC0:
+				d = append(d, r.i32())
+			default:
+				d = append(d, r.i8())
+			}
+		}
+	}
+	return d
+}
+
+type tupleDeltas struct {
+	scalar float64
+	points []int // nil for all points
+	dx, dy []int
+}
+
+// deltas returns the applicable point deltas for glyph gid having pointCount points including phantom points.
+func (gv *glyphVariations) deltas(gid, pointCount int, coords []float64) ([]tupleDeltas, error) {
+	from, thru := gv.offsets[gid], gv.offsets[gid+1]
+	if thru <= from {
+		return nil, nil
+	}
+	if from < 0 || thru > len(gv.data) {
+		return nil, errCorruptVariableFont
+	}
+
+	bb := gv.data[from:thru]
+	r := &byteReader{bb: bb}
+	count := r.u16()
+	serial := &byteReader{bb: bb, pos: r.u16()}
+
+	var shared []int
+	if count&0x8000 > 0 {
+		shared = unpackPoints(serial)
+	}
+
+	tds := []tupleDeltas{}
+
+	for i := 0; i < count&0x0FFF; i++ {
+		size := r.u16()
+		idx := r.u16()
+
+		var peak, start, end []float64
+		if idx&0x8000 > 0 {
+			peak = make([]float64, gv.axisCount)
+			for j := range peak {
+				peak[j] = r.f2dot14()
+			}
+		} else {
+			if idx&0x0FFF >= len(gv.sharedTuples) {
+				return nil, errCorruptVariableFont
+			}
+			peak = gv.sharedTuples[idx&0x0FFF]
+		}
+		if idx&0x4000 > 0 {
+			start, end = make([]float64, gv.axisCount), make([]float64, gv.axisCount)
+			for j := range start {
+				start[j] = r.f2dot14()
+			}
+			for j := range end {
+				end[j] = r.f2dot14()
+			}
+		}
+
+		if r.err != nil {
+			return nil, r.err
+		}
+
+		tuple := &byteReader{bb: serial.bytes(size)}
+		if serial.err != nil {
+			return nil, serial.err
+		}
+
+		scalar := regionScalar(peak, start, end, coords)
+		if scalar == 0 {
+			continue
+		}
+
+		td := tupleDeltas{scalar: scalar, points: shared}
+		if idx&0x2000 > 0 {
+			td.points = unpackPoints(tuple)
+		}
+
+		n := pointCount
+		if td.points != nil {
+			n = len(td.points)
+		}
+		td.dx = unpackDeltas(tuple, n)
+		td.dy = unpackDeltas(tuple, n)
+		if tuple.err != nil {
+			return nil, tuple.err
+		}
+
+		tds = append(tds, td)
+	}
+
+	return tds, nil
+}
+
+// itemVariationStore represents an item variation store as used by HVAR.
+type itemVariationStore struct {
+	regions [][3][]float64 // start, peak, end
+	data    []itemVariationData
+}
+
+type itemVariationData struct {
+	regionIndexes []int
+	deltaSets     [][]int
+}
+
+func parseItemVariationStore(r *byteReader, off int) (*itemVariationStore, error) {
+	r.pos = off + 2
+	regionListOff := r.u32()
+	dataCount := r.u16()
+	dataOffs := make([]int, dataCount)
+	for i := range dataOffs {
+		dataOffs[i] = r.u32()
+	}
+
+	ivs := &itemVariationStore{}
+
+	r.pos = off + regionListOff
+	axisCount := r.u16()
+	regionCount := r.u16()
+	for i := 0; i < regionCount && r.err == nil; i++ {
+		var reg [3][]float64
+		for j := range reg {
+			reg[j] = make([]float64, axisCount)
+		}
+		for j := 0; j < axisCount; j++ {
+			reg[0][j], reg[1][j], reg[2][j] = r.f2dot14(), r.f2dot14(), r.f2dot14()
+		}
+		ivs.regions = append(ivs.regions, reg)
+	}
+
+	for _, o := range dataOffs {
+		r.pos = off + o
+		itemCount := r.u16()
+		wordDeltaCount := r.u16()
+		longWords := wordDeltaCount&0x8000 > 0
+		wordCount := wordDeltaCount & 0x7FFF
+		ivd := itemVariationData{regionIndexes: make([]int, r.u16())}
+		for i := range ivd.regionIndexes {
+			ivd.regionIndexes[i] = r.u16()
+		}
+		for i := 0; i < itemCount && r.err == nil; i++ {
+			row := make([]int, len(ivd.regionIndexes))
+			for j := range row {
+				switch {
+				case j < wordCount && longWords:
+					row[j] = r.i32()
+				case j < wordCount || longWords:
+					row[j] = r.i16()
+				default:
+					row[j] = r.i8()
+				}
+			}
+			ivd.deltaSets = append(ivd.deltaSets, row)
+		}
+		ivs.data = append(ivs.data, ivd)
+	}
+
+	return ivs, r.err
+}
+
+func (ivs *itemVariationStore) delta(outer, inner int, coords []float64) float64 {
+	if outer >= len(ivs.data) || inner >= len(ivs.data[outer].deltaSets) {
+		return 0
+	}
+	ivd := ivs.data[outer]
+	var d float64
+	for i, ri := range ivd.regionIndexes {
+		if ri >= len(ivs.regions) {
+			continue
+		}
+		reg := ivs.regions[ri]
+		d += regionScalar(reg[1], reg[0], reg[2], coords) * float64(ivd.deltaSets[inner][i])
+	}
+	return d
+}
+
+// horizontalMetricsVariations returns the advance width deltas of all glyphs as defined by table "HVAR".
+func (t table) horizontalMetricsVariations(glyphCount int, coords []float64) ([]float64, error) {
+	r := &byteReader{bb: t.data[:t.size]}
+	r.pos = 4
+	ivsOff := r.u32()
+	mapOff := r.u32()
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	ivs, err := parseItemVariationStore(r, ivsOff)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without a delta set index map glyph ids are used as inner indexes into the first item variation data.
+	outer, inner := make([]int, glyphCount), make([]int, glyphCount)
+	for gid := range inner {
+		inner[gid] = gid
+	}
+
+	if mapOff > 0 {
+		r.pos = mapOff
+		format := r.u8()
+		entryFormat := r.u8()
+		mapCount := r.u16()
+		if format == 1 {
+			mapCount = mapCount<<16 | r.u16()
+		}
+		entrySize := (entryFormat&0x30)>>4 + 1
+		innerBits := entryFormat&0x0F + 1
+		entries := make([]int, mapCount)
+		for i := range entries {
+			var e int
+			for j := 0; j < entrySize; j++ {
+				e = e<<8 | r.u8()
+			}
+			entries[i] = e
+		}
+		if r.err != nil || mapCount == 0 {
+			return nil, errCorruptVariableFont
+		}
+		for gid := range inner {
+			e := entries[len(entries)-1]
+			if gid < len(entries) {
+				e = entries[gid]
+			}
+			outer[gid], inner[gid] = e>>innerBits, e&(1<<innerBits-1)
+		}
+	}
+
+	deltas := make([]float64, glyphCount)
+	for gid := range deltas {
+		deltas[gid] = ivs.delta(outer[gid], inner[gid], coords)
+	}
+
+	return deltas, nil
+}
+
+type simpleGlyph struct {
+	endPts       []int
+	instructions []byte
+	flags        []byte
+	x, y         []float64
+}
+
+func parseSimpleGlyph(bb []byte, contours int) (*simpleGlyph, error) {
+	r := &byteReader{bb: bb, pos: 10}
+	g := &simpleGlyph{endPts: make([]int, contours)}
+	for i := range g.endPts {
+		g.endPts[i] = r.u16()
+	}
+	g.instructions = r.bytes(r.u16())
+
+	var n int
+	if contours > 0 {
+		n = g.endPts[contours-1] + 1
+	}
+
+	for len(g.flags) < n && r.err == nil {
+		f := byte(r.u8())
+		g.flags = append(g.flags, f)
+		if f&0x08 > 0 {
+			for c := r.u8(); c > 0 && len(g.flags) < n; c-- {
+				g.flags = append(g.flags, f)
+			}
+		}
+	}
+
+	g.x, g.y = make([]float64, n), make([]float64, n)
+
+	var v int
+	for i, f := range g.flags {
+		switch {
+		case f&0x02 > 0 && f&0x10 > 0:
+			v += r.u8()
+		case f&0x02 > 0:
+			v -= r.u8()
+		case f&0x10 == 0:
+			v += r.i16()
+		}
+		g.x[i] = float64(v)
+	}
+
+	v = 0
+	for i, f := range g.flags {
+		switch {
+		case f&0x04 > 0 && f&0x20 > 0:
+			v += r.u8()
+		case f&0x04 > 0:
+			v -= r.u8()
+		case f&0x20 == 0:
+			v += r.i16()
+		}
+		g.y[i] = float64(v)
+	}
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	return g, nil
+}
+
+func roundedCoords(f []float64) []int {
+	c := make([]int, len(f))
+	for i, v := range f {
+		c[i] = int(math.Round(v))
+	}
+	return c
+}
+
+func minMax(c []int) (int, int) {
+	if len(c) == 0 {
+		return 0, 0
+	}
+	lo, hi := c[0], c[0]
+	for _, v := range c[1:] {
+		lo, hi = min(lo, v), max(hi, v)
+	}
+	return lo, hi
+}
+
+func (g *simpleGlyph) bbox() [4]int {
+	xMin, xMax := minMax(roundedCoords(g.x))
+	yMin, yMax := minMax(roundedCoords(g.y))
+	return [4]int{xMin, yMin, xMax, yMax}
+}
+
+func writeInt16(buf *bytes.Buffer, i int) {
+	buf.Write(uint16ToBigEndianBytes(uint16(int16(i))))
+}
+
+func (g *simpleGlyph) bytes() []byte {
+	var buf bytes.Buffer
+
+	writeInt16(&buf, len(g.endPts))
+	for _, v := range g.bbox() {
+		writeInt16(&buf, v)
+	}
+	for _, v := range g.endPts {
+		writeInt16(&buf, v)
+	}
+	writeInt16(&buf, len(g.instructions))
+	buf.Write(g.instructions)
+
+	xs, ys := roundedCoords(g.x), roundedCoords(g.y)
+	flags := make([]byte, len(xs))
+	var xb, yb bytes.Buffer
+	var x0, y0 int
+
+	for i := range xs {
+		// Keep ON_CURVE_POINT and OVERLAP_SIMPLE.
+		f := g.flags[i] & 0x41
+		dx, dy := xs[i]-x0, ys[i]-y0
+		x0, y0 = xs[i], ys[i]
+		switch {
+		case dx == 0:
+			f |= 0x10
+		case dx >= -255 && dx <= 255:
+			f |= 0x02
+			if dx > 0 {
+				f |= 0x10
+			}
+			xb.WriteByte(byte(absInt(dx)))
+		default:
+			writeInt16(&xb, dx)
+		}
+		switch {
+		case dy == 0:
+			f |= 0x20
+		case dy >= -255 && dy <= 255:
+			f |= 0x04
+			if dy > 0 {
+				f |= 0x20
+			}
+			yb.WriteByte(byte(absInt(dy)))
+		default:
+			writeInt16(&yb, dy)
+		}
+		flags[i] = f
+	}
+
+	buf.Write(flags)
+	buf.Write(xb.Bytes())
+	buf.Write(yb.Bytes())
+
+	return buf.Bytes()
+}
+
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+// iup interpolates the deltas of untouched points of a simple glyph contour by contour
+// (see "Inferred deltas for un-referenced point numbers").
+func (g *simpleGlyph) iup(dx, dy []float64, touched []bool) {
+	start := 0
+	for _, end := range g.endPts {
+		iupContour(g.x, dx, touched, start, end)
+		iupContour(g.y, dy, touched, start, end)
+		start = end + 1
+	}
+}
+
+func iupContour(c, d []float64, touched []bool, start, end int) {
+	if end >= len(c) || start > end {
+		return
+	}
+
+	refs := []int{}
+	for i := start; i <= end; i++ {
+		if touched[i] {
+			refs = append(refs, i)
+		}
+	}
+
+	if len(refs) == 0 {
+		return
+	}
+
+	if len(refs) == 1 {
+		for i := start; i <= end; i++ {
+			d[i] = d[refs[0]]
+		}
+		return
+	}
+
+	for k, p1 := range refs {
+		p2 := refs[(k+1)%len(refs)]
+		c1, c2, d1, d2 := c[p1], c[p2], d[p1], d[p2]
+		if c1 > c2 {
+			c1, c2, d1, d2 = c2, c1, d2, d1
+		}
+		for i := p1 + 1; ; i++ {
+			if i > end {
+				i = start
+			}
+			if i == p2 {
+				break
+			}
+			switch v := c[i]; {
+			case c1 == c2:
+				if d1 == d2 {
+					d[i] = d1
+				} else {
+					d[i] = 0
+				}
+			case v <= c1:
+				d[i] = d1
+			case v >= c2:
+				d[i] = d2
+			default:
+				d[i] = d1 + (v-c1)*(d2-d1)/(c2-c1)
+			}
+		}
+	}
+}
+
+type glyphComponent struct {
+	flags     int
+	gid       int
+	dx, dy    float64 // offset or point numbers
+	transform []byte
+}
+
+type compositeGlyph struct {
+	components   []glyphComponent
+	instructions []byte
+}
+
+func parseCompositeGlyph(bb []byte) (*compositeGlyph, error) {
+	r := &byteReader{bb: bb, pos: 10}
+	g := &compositeGlyph{}
+	for more := true; more && r.err == nil; {
+		c := glyphComponent{flags: r.u16(), gid: r.u16()}
+		xy := c.flags&0x0002 > 0
+		switch {
+		case c.flags&0x0001 > 0 && xy:
+			c.dx, c.dy = float64(r.i16()), float64(r.i16())
+		case c.flags&0x0001 > 0:
+			c.dx, c.dy = float64(r.u16()), float64(r.u16())
+		case xy:
+			c.dx, c.dy = float64(r.i8()), float64(r.i8())
+		default:
+			c.dx, c.dy = float64(r.u8()), float64(r.u8())
+		}
+		switch {
+		case c.flags&0x0008 > 0:
+			c.transform = r.bytes(2)
+		case c.flags&0x0040 > 0:
+			c.transform = r.bytes(4)
+		case c.flags&0x0080 > 0:
+			c.transform = r.bytes(8)
+		}
+		more = c.flags&0x0020 > 0
+		g.components = append(g.components, c)
+	}
+	if len(g.components) > 0 && g.components[len(g.components)-1].flags&0x0100 > 0 {
+		g.instructions = r.bytes(r.u16())
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	return g, nil
+}
+
+func (g *compositeGlyph) bytes(bbox [4]int) []byte {
+	var buf bytes.Buffer
+
+	writeInt16(&buf, -1)
+	for _, v := range bbox {
+		writeInt16(&buf, v)
+	}
+
+	for i, c := range g.components {
+		// Always use word arguments, arguments may grow beyond a byte.
+		f := c.flags&^(0x0020|0x0100) | 0x0001
+		if i < len(g.components)-1 {
+			f |= 0x0020
+		} else if g.instructions != nil {
+			f |= 0x0100
+		}
+		writeInt16(&buf, f)
+		writeInt16(&buf, c.gid)
+		writeInt16(&buf, int(math.Round(c.dx)))
+		writeInt16(&buf, int(math.Round(c.dy)))
+		buf.Write(c.transform)
+	}
+
+	if g.instructions != nil {
+		writeInt16(&buf, len(g.instructions))
+		buf.Write(g.instructions)
+	}
+
+	return buf.Bytes()
+}
+
+// transformBBox returns the bounding box of bb transformed by the component's scale and offset.
+func (c glyphComponent) transformBBox(bb [4]int) [4]int {
+	a, b, cc, d := 1., 0., 0., 1.
+	tr := &byteReader{bb: c.transform}
+	switch len(c.transform) {
+	case 2:
+		a = tr.f2dot14()
+		d = a
+	case 4:
+		a, d = tr.f2dot14(), tr.f2dot14()
+	case 8:
+		a, b, cc, d = tr.f2dot14(), tr.f2dot14(), tr.f2dot14(), tr.f2dot14()
+	}
+
+	var dx, dy float64
+	if c.flags&0x0002 > 0 {
+		dx, dy = c.dx, c.dy
+	}
+
+	xs, ys := []int{}, []int{}
+	for _, p := range [][2]int{{bb[0], bb[1]}, {bb[0], bb[3]}, {bb[2], bb[1]}, {bb[2], bb[3]}} {
+		x, y := float64(p[0]), float64(p[1])
+		xs = append(xs, int(math.Round(a*x+cc*y+dx)))
+		ys = append(ys, int(math.Round(b*x+d*y+dy)))
+	}
+
+	xMin, xMax := minMax(xs)
+	yMin, yMax := minMax(ys)
+	return [4]int{xMin, yMin, xMax, yMax}
+}
+
+type instanceGlyph struct {
+	simple    *simpleGlyph
+	composite *compositeGlyph
+	adv, lsb  int
+	pp1       int // x of the origin phantom point
+	bbox      *[4]int
+}
+
+type fontInstancer struct {
+	tables           map[string]*table
+	coords           []float64
+	numGlyphs        int
+	indexToLocFormat int
+	glyphs           []instanceGlyph
+}
+
+func (fi *fontInstancer) horizontalMetrics() ([]int, []int, error) {
+	hhea, ok1 := fi.tables["hhea"]
+	hmtx, ok2 := fi.tables["hmtx"]
+	if !ok1 || !ok2 {
+		return nil, nil, errors.New("pdfcpu: missing horizontal metrics")
+	}
+	r := &byteReader{bb: hmtx.data[:hmtx.size]}
+	n := (&byteReader{bb: hhea.data, pos: 34}).u16()
+	advs, lsbs := make([]int, fi.numGlyphs), make([]int, fi.numGlyphs)
+	for gid := 0; gid < fi.numGlyphs; gid++ {
+		if gid < n {
+			advs[gid] = r.u16()
+		} else if gid > 0 {
+			advs[gid] = advs[gid-1]
+		}
+		lsbs[gid] = r.i16()
+	}
+	return advs, lsbs, r.err
+}
+
+func (fi *fontInstancer) glyphData(gid int) []byte {
+	glyf, loca := fi.tables["glyf"], fi.tables["loca"]
+	from, thru := glyphOffsets(gid, loca, glyf, fi.numGlyphs, fi.indexToLocFormat)
+	if thru <= from || thru > len(glyf.data) {
+		return nil
+	}
+	return glyf.data[from:thru]
+}
+
+// applyDeltas applies the glyph variations for glyph gid to its points and phantom points.
+func (fi *fontInstancer) applyDeltas(gv *glyphVariations, gid int, g *instanceGlyph, adv, lsb int) error {
+	var x, y []float64
+	switch {
+	case g.simple != nil:
+		x, y = append([]float64{}, g.simple.x...), append([]float64{}, g.simple.y...)
+	case g.composite != nil:
+		for _, c := range g.composite.components {
+			x, y = append(x, c.dx), append(y, c.dy)
+		}
+	}
+
+	var xMin int
+	if g.simple != nil {
+		xMin = g.simple.bbox()[0]
+	} else if bb := fi.glyphData(gid); len(bb) >= 4 {
+		xMin = (&byteReader{bb: bb, pos: 2}).i16()
+	}
+
+	n := len(x)
+	g.pp1 = xMin - lsb
+	pp1 := float64(g.pp1)
+	x = append(x, pp1, pp1+float64(adv), 0, 0)
+	y = append(y, 0, 0, 0, 0)
+
+	if gv != nil {
+		tds, err := gv.deltas(gid, len(x), fi.coords)
+		if err != nil {
+			return err
+		}
+		for _, td := range tds {
+			dx, dy := make([]float64, len(x)), make([]float64, len(x))
+			if td.points == nil {
+				for i := 0; i < len(x) && i < len(td.dx); i++ {
+					dx[i], dy[i] = float64(td.dx[i]), float64(td.dy[i])
+				}
+			} else {
+				touched := make([]bool, len(x))
+				for i, p := range td.points {
+					if p < len(x) && i < len(td.dx) {
+						dx[p], dy[p] = float64(td.dx[i]), float64(td.dy[i])
+						touched[p] = true
+					}
+				}
+				if g.simple != nil {
+					g.simple.iup(dx, dy, touched)
+				}
+			}
+			for i := range x {
+				x[i] += td.scalar * dx[i]
+				y[i] += td.scalar * dy[i]
+			}
+		}
+	}
+
+	// Keep the glyph origin in place.
+	shift := x[n] - pp1
+
+	switch {
+	case g.simple != nil:
+		for i := 0; i < n; i++ {
+			g.simple.x[i], g.simple.y[i] = x[i]-shift, y[i]
+		}
+	case g.composite != nil:
+		for i := range g.composite.components {
+			c := &g.composite.components[i]
+			if c.flags&0x0002 > 0 {
+				c.dx, c.dy = x[i]-shift, y[i]
+			}
+		}
+	}
+
+	g.adv = int(math.Round(x[n+1] - x[n]))
+	if g.adv < 0 {
+		g.adv = 0
+	}
+
+	return nil
+}
+
+func (fi *fontInstancer) compositeBBox(gid, depth int) [4]int {
+	g := &fi.glyphs[gid]
+	if g.bbox != nil {
+		return *g.bbox
+	}
+	if g.composite == nil || depth > 16 {
+		return [4]int{}
+	}
+	var bb *[4]int
+	for _, c := range g.composite.components {
+		if c.gid >= fi.numGlyphs {
+			continue
+		}
+		cg := fi.glyphs[c.gid]
+		if cg.simple == nil && cg.composite == nil {
+			continue
+		}
+		cbb := c.transformBBox(fi.compositeBBox(c.gid, depth+1))
+		if bb == nil {
+			bb = &cbb
+			continue
+		}
+		bb[0], bb[1] = min(bb[0], cbb[0]), min(bb[1], cbb[1])
+		bb[2], bb[3] = max(bb[2], cbb[2]), max(bb[3], cbb[3])
+	}
+	if bb == nil {
+		bb = &[4]int{}
+	}
+	g.bbox = bb
+	return *bb
+}
+
+func (fi *fontInstancer) instantiateGlyphs() error {
+	advs, lsbs, err := fi.horizontalMetrics()
+	if err != nil {
+		return err
+	}
+
+	var gv *glyphVariations
+	if t, ok := fi.tables["gvar"]; ok {
+		if gv, err = t.parseGlyphVariationsTable(fi.numGlyphs); err != nil {
+			return err
+		}
+	}
+
+	fi.glyphs = make([]instanceGlyph, fi.numGlyphs)
+
+	for gid := range fi.glyphs {
+		g := &fi.glyphs[gid]
+		if bb := fi.glyphData(gid); len(bb) >= 10 {
+			contours := (&byteReader{bb: bb}).i16()
+			switch {
+			case contours >= 0:
+				g.simple, err = parseSimpleGlyph(bb, contours)
+			case contours == -1:
+				g.composite, err = parseCompositeGlyph(bb)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		if err := fi.applyDeltas(gv, gid, g, advs[gid], lsbs[gid]); err != nil {
+			return err
+		}
+		if g.simple != nil {
+			bb := g.simple.bbox()
+			g.bbox = &bb
+		}
+	}
+
+	if t, ok := fi.tables["HVAR"]; ok {
+		deltas, err := t.horizontalMetricsVariations(fi.numGlyphs, fi.coords)
+		if err != nil {
+			return err
+		}
+		for gid := range fi.glyphs {
+			fi.glyphs[gid].adv = max(0, int(math.Round(float64(advs[gid])+deltas[gid])))
+		}
+	}
+
+	for gid := range fi.glyphs {
+		g := &fi.glyphs[gid]
+		if g.composite != nil {
+			fi.compositeBBox(gid, 0)
+		}
+		if g.bbox != nil {
+			g.lsb = g.bbox[0] - g.pp1
+		}
+	}
+
+	return nil
+}
+
+func setTableData(t *table, bb []byte) {
+	t.size = uint32(len(bb))
+	t.data = pad(append([]byte(nil), bb...))
+	t.padded = uint32(len(t.data))
+	t.chksum = calcTableChecksum("", t.data)
+}
+
+func putInt16(bb []byte, off, i int) {
+	binary.BigEndian.PutUint16(bb[off:], uint16(int16(i)))
+}
+
+// writeGlyphTables replaces glyf, loca, hmtx and updates head and hhea accordingly.
+func (fi *fontInstancer) writeGlyphTables() {
+	var glyf, loca, hmtx bytes.Buffer
+
+	fb := [4]int{math.MaxInt16, math.MaxInt16, math.MinInt16, math.MinInt16}
+	var advMax, minLSB, minRSB, xMaxExtent int
+	first := true
+
+	for _, g := range fi.glyphs {
+		loca.Write(uint32ToBigEndianBytes(uint32(glyf.Len())))
+
+		var bb []byte
+		switch {
+		case g.simple != nil:
+			bb = g.simple.bytes()
+		case g.composite != nil:
+			bb = g.composite.bytes(*g.bbox)
+		}
+		glyf.Write(pad(bb))
+
+		writeInt16(&hmtx, g.adv)
+		writeInt16(&hmtx, g.lsb)
+
+		advMax = max(advMax, g.adv)
+
+		if g.bbox == nil || len(bb) == 0 {
+			continue
+		}
+
+		b := *g.bbox
+		fb[0], fb[1], fb[2], fb[3] = min(fb[0], b[0]), min(fb[1], b[1]), max(fb[2], b[2]), max(fb[3], b[3])
+		rsb := g.adv - g.lsb - (b[2] - b[0])
+		if first {
+			minLSB, minRSB, xMaxExtent = g.lsb, rsb, b[2]
+			first = false
+		}
+		minLSB, minRSB, xMaxExtent = min(minLSB, g.lsb), min(minRSB, rsb), max(xMaxExtent, g.lsb+b[2]-b[0])
+	}
+	loca.Write(uint32ToBigEndianBytes(uint32(glyf.Len())))
+
+	setTableData(fi.tables["glyf"], glyf.Bytes())
+	setTableData(fi.tables["loca"], loca.Bytes())
+	setTableData(fi.tables["hmtx"], hmtx.Bytes())
+
+	head := append([]byte(nil), fi.tables["head"].data[:fi.tables["head"].size]...)
+	if !first {
+		for i, v := range fb {
+			putInt16(head, 36+2*i, v)
+		}
+	}
+	// Long loca offsets.
+	putInt16(head, 50, 1)
+	setTableData(fi.tables["head"], head)
+
+	hhea := append([]byte(nil), fi.tables["hhea"].data[:fi.tables["hhea"].size]...)
+	putInt16(hhea, 10, advMax)
+	putInt16(hhea, 12, minLSB)
+	putInt16(hhea, 14, minRSB)
+	putInt16(hhea, 16, xMaxExtent)
+	putInt16(hhea, 34, fi.numGlyphs)
+	setTableData(fi.tables["hhea"], hhea)
+}
+
+func (fi *fontInstancer) setWeightClass(axes []variationAxis, coords []float64) {
+	os2, ok := fi.tables["OS/2"]
+	if !ok || os2.size < 6 {
+		return
+	}
+	for i, a := range axes {
+		if a.tag == "wght" {
+			bb := append([]byte(nil), os2.data[:os2.size]...)
+			putInt16(bb, 4, int(math.Max(1, math.Min(1000, math.Round(coords[i])))))
+			setTableData(os2, bb)
+			return
+		}
+	}
+}
+
+type nameRecord struct {
+	platformID, encodingID, languageID, nameID, length, offset int
+}
+
+// nameString returns the string for nameID preferring the Windows English entry.
+func (t table) nameString(nameID int) string {
+	r := &byteReader{bb: t.data[:t.size], pos: 2}
+	count := r.u16()
+	storage := r.u16()
+	var mac string
+	var win []string
+	for i := 0; i < count && r.err == nil; i++ {
+		rec := nameRecord{r.u16(), r.u16(), r.u16(), r.u16(), r.u16(), r.u16()}
+		if rec.nameID != nameID {
+			continue
+		}
+		sr := &byteReader{bb: r.bb, pos: storage + rec.offset}
+		bb := sr.bytes(rec.length)
+		if sr.err != nil {
+			continue
+		}
+		switch {
+		case rec.platformID == 3 && (rec.encodingID == 1 || rec.encodingID == 10):
+			s := utf16BEToString(bb)
+			if rec.languageID == 0x0409 {
+				return s
+			}
+			win = append(win, s)
+		case rec.platformID == 0:
+			win = append(win, utf16BEToString(bb))
+		case rec.platformID == 1 && rec.encodingID == 0:
+			mac = string(bb)
+		}
+	}
+	if len(win) > 0 {
+		return win[0]
+	}
+	return mac
+}
+
+// setPostScriptName replaces nameID 6 in table "name" keeping all other name records.
+func (t *table) setPostScriptName(ps string) {
+	r := &byteReader{bb: t.data[:t.size]}
+	format := r.u16()
+	count := r.u16()
+	storage := r.u16()
+
+	recs := []nameRecord{}
+	for i := 0; i < count; i++ {
+		rec := nameRecord{r.u16(), r.u16(), r.u16(), r.u16(), r.u16(), r.u16()}
+		if rec.nameID != 6 {
+			recs = append(recs, rec)
+		}
+	}
+
+	var langTags []byte
+	if format == 1 {
+		n := r.u16()
+		langTags = append(uint16ToBigEndianBytes(uint16(n)), r.bytes(4*n)...)
+	}
+
+	if r.err != nil || storage > int(t.size) {
+		return
+	}
+
+	strs := append([]byte(nil), t.data[storage:t.size]...)
+
+	u := utf16.Encode([]rune(ps))
+	win := make([]byte, 0, 2*len(u))
+	for _, v := range u {
+		win = append(win, uint16ToBigEndianBytes(v)...)
+	}
+
+	recs = append(recs,
+		nameRecord{1, 0, 0, 6, len(ps), len(strs)},
+		nameRecord{3, 1, 0x0409, 6, len(win), len(strs) + len(ps)})
+	strs = append(append(strs, ps...), win...)
+
+	sort.SliceStable(recs, func(i, j int) bool {
+		a, b := recs[i], recs[j]
+		if a.platformID != b.platformID {
+			return a.platformID < b.platformID
+		}
+		if a.encodingID != b.encodingID {
+			return a.encodingID < b.encodingID
+		}
+		if a.languageID != b.languageID {
+			return a.languageID < b.languageID
+		}
+		return a.nameID < b.nameID
+	})
+
+	var buf bytes.Buffer
+	writeInt16(&buf, format)
+	writeInt16(&buf, len(recs))
+	writeInt16(&buf, 6+12*len(recs)+len(langTags))
+	for _, rec := range recs {
+		for _, v := range []int{rec.platformID, rec.encodingID, rec.languageID, rec.nameID, rec.length, rec.offset} {
+			writeInt16(&buf, v)
+		}
+	}
+	buf.Write(langTags)
+	buf.Write(strs)
+
+	setTableData(t, buf.Bytes())
+}
+
+func postScriptNameChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r < 33 || r > 126 || strings.ContainsRune("[](){}<>/%", r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// instancePostScriptName returns the PostScript name of a named instance (see Adobe Technical Note #5902).
+func instancePostScriptName(name *table, inst namedInstance) string {
+	if inst.postScriptNameID != 0xFFFF && inst.postScriptNameID > 0 {
+		if s := name.nameString(inst.postScriptNameID); s != "" {
+			return s
+		}
+	}
+
+	prefix := name.nameString(25)
+	if prefix == "" {
+		prefix, _, _ = strings.Cut(name.nameString(6), "-")
+	}
+	if prefix == "" {
+		if prefix = name.nameString(16); prefix == "" {
+			prefix = name.nameString(1)
+		}
+	}
+
+	return postScriptNameChars(prefix) + "-" + postScriptNameChars(name.nameString(inst.subfamilyNameID))
+}
+
+func variableFontTables(fn string) ([]byte, map[string]*table, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+
+	header, tables, err := headerAndTables(fn, f, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, tag := range []string{"fvar", "name", "head", "hhea", "hmtx", "maxp", "glyf", "loca"} {
+		if _, ok := tables[tag]; !ok {
+			if tag == "fvar" {
+				return nil, nil, errors.Errorf("pdfcpu: %s is not a variable font", fn)
+			}
+			return nil, nil, errors.Errorf("pdfcpu: %s: missing table %s", fn, tag)
+		}
+	}
+
+	return header, tables, nil
+}
+
+// VariableFontInstances returns the subfamily names of the named instances of the variable TrueType font fn.
+func VariableFontInstances(fn string) ([]string, error) {
+	_, tables, err := variableFontTables(fn)
+	if err != nil {
+		return nil, err
+	}
+
+	_, instances, err := tables["fvar"].parseFontVariationsTable()
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, len(instances))
+	for i, inst := range instances {
+		ss[i] = tables["name"].nameString(inst.subfamilyNameID)
+	}
+
+	return ss, nil
+}
+
+func instantiate(fn string, tables map[string]*table, instance string) error {
+	axes, instances, err := tables["fvar"].parseFontVariationsTable()
+	if err != nil {
+		return err
+	}
+
+	name := tables["name"]
+
+	var inst *namedInstance
+	for i := range instances {
+		if strings.EqualFold(name.nameString(instances[i].subfamilyNameID), instance) ||
+			strings.EqualFold(instancePostScriptName(name, instances[i]), instance) {
+			inst = &instances[i]
+			break
+		}
+	}
+
+	if inst == nil {
+		ss, _ := VariableFontInstances(fn)
+		return errors.Errorf("pdfcpu: %s: unknown instance %q, available are: %s", fn, instance, strings.Join(ss, ", "))
+	}
+
+	var avar [][][2]float64
+	if t, ok := tables["avar"]; ok {
+		if avar, err = t.parseAxisVariationsTable(len(axes)); err != nil {
+			return err
+		}
+	}
+
+	fi := &fontInstancer{
+		tables:           tables,
+		coords:           normalizedCoords(axes, avar, inst.coords),
+		numGlyphs:        (&byteReader{bb: tables["maxp"].data, pos: 4}).u16(),
+		indexToLocFormat: (&byteReader{bb: tables["head"].data, pos: 50}).u16(),
+	}
+
+	if err := fi.instantiateGlyphs(); err != nil {
+		return errors.Wrapf(err, "%s", fn)
+	}
+
+	fi.writeGlyphTables()
+	fi.setWeightClass(axes, inst.coords)
+
+	ps := instancePostScriptName(name, *inst)
+	name.setPostScriptName(ps)
+
+	for _, tag := range variationTables {
+		delete(tables, tag)
+	}
+
+	return nil
+}
+
+// InstallVariableFontInstance saves an internal representation of a named instance
+// of the variable TrueType font fn to the pdfcpu config dir.
+// instance is either the subfamily name (eg. "Bold") or the PostScript name of the instance.
+func InstallVariableFontInstance(fontDir, fn, instance string) error {
+	header, tables, err := variableFontTables(fn)
+	if err != nil {
+		return err
+	}
+
+	if err := instantiate(fn, tables, instance); err != nil {
+		return err
+	}
+
+	// Adjust the table count of the sfnt header.
+	header = append([]byte(nil), header...)
+	binary.BigEndian.PutUint16(header[4:], uint16(len(tables)))
+
+	return installTrueTypeRep(fontDir, fn, header, tables)
+}
+
+// SplitFontFileName splits a font file name with an optional face selector
+// like "NotoSansCJK.ttc:2", "NotoSansCJK.ttc:NotoSansCJKjp-Regular" or "RobotoFlex.ttf:Bold"
+// into the file name and the selector.
+func SplitFontFileName(s string) (string, string) {
+	for _, ext := range []string{".ttc:", ".ttf:"} {
+		if i := strings.LastIndex(strings.ToLower(s), ext); i > 0 {
+			return s[:i+len(ext)-1], s[i+len(ext):]
+		}
+	}
+	return s, ""
+}
@@ -190,52 +190,63 @@ func DisableLoggers() {
 
 // CLIEnabled returns true if the CLI Logger is enabled.
 func CLIEnabled() bool {
-	return CLI.log != nil
+	return CLI.enabled()
 }
 
 // DebugEnabled returns true if the Debug Logger is enabled.
 func DebugEnabled() bool {
-	return Debug.log != nil
+	return Debug.enabled()
 }
 
 // InfoEnabled returns true if the Info Logger is enabled.
 func InfoEnabled() bool {
-	return Info.log != nil
+	return Info.enabled()
 }
 
 // OptimizeEnabled returns true if the Optimize Logger is enabled.
 func OptimizeEnabled() bool {
-	return Optimize.log != nil
+	return Optimize.enabled()
 }
 
 // ParseEnabled returns true if the Parse Logger is enabled.
 func ParseEnabled() bool {
-	return Parse.log != nil
+	return Parse.enabled()
 }
 
 // ReadEnabled returns true if the Read Logger is enabled.
 func ReadEnabled() bool {
-	return Read.log != nil
+	return Read.enabled()
 }
 
 // StatsEnabled returns true if the Read Logger is enabled.
 func StatsEnabled() bool {
-	return Stats.log != nil
+	return Stats.enabled()
 }
 
 // TraceEnabled returns true if the Trace Logger is enabled.
 func TraceEnabled() bool {
-	return Trace.log != nil
+	return Trace.enabled()
 }
 
 // ValidateEnabled returns true if the Validate Logger is enabled.
 func ValidateEnabled() bool {
-	return Validate.log != nil
+	return Validate.enabled()
 }
 
 // WriteEnabled returns true if the Write Logger is enabled.
 func WriteEnabled() bool {
-	return Write.log != nil
+	return Write.enabled()
+}
+
+// enabled returns true if l is set and, for slog based loggers, accepts records of its level.
+func (l *logger) enabled() bool {
+	if l.log == nil {
+		return false
+	}
+	if sl, ok := l.log.(*slogLogger); ok {
+		return sl.enabled()
+	}
+	return true
 }
 
 // Printf writes a formatted message to the log.
@@ -16,7 +16,12 @@ limitations under the License.
 
 package log
 
-import "testing"
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+)
 
 func TestLog(t *testing.T) {
 
@@ -30,3 +35,21 @@ func TestLog(t *testing.T) {
 	Debug.Println("Testlog")
 	DisableLoggers()
 }
+
+func TestSlogLoggers(t *testing.T) {
+	var buf bytes.Buffer
+	SetSlogLoggers(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	defer DisableLoggers()
+
+	if DebugEnabled() {
+		t.Fatal("debug logger enabled below handler level")
+	}
+	if !InfoEnabled() {
+		t.Fatal("info logger disabled")
+	}
+
+	Info.Printf("Test%s\n", "log")
+	if s := buf.String(); !strings.Contains(s, "msg=Testlog") || !strings.Contains(s, KeyLogger+"=info") {
+		t.Fatalf("unexpected record: %s", s)
+	}
+}
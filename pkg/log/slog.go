@@ -0,0 +1,93 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Attribute keys of structured log records.
+const (
+	KeyLogger = "logger" // the pdfcpu logger a record originates from
+	KeyOp     = "op"     // the command being executed
+	KeyFile   = "file"   // the input file
+	KeyPage   = "page"   // the page number
+	KeyObj    = "obj"    // the object number
+)
+
+// slogLogger adapts a slog.Logger to Logger.
+type slogLogger struct {
+	l     *slog.Logger
+	level slog.Level
+}
+
+// NewSlogLogger returns a Logger emitting records of level to l.
+func NewSlogLogger(l *slog.Logger, level slog.Level) Logger {
+	return &slogLogger{l: l, level: level}
+}
+
+func (sl *slogLogger) enabled() bool {
+	return sl.l.Enabled(context.Background(), sl.level)
+}
+
+func (sl *slogLogger) log(level slog.Level, msg string) {
+	sl.l.Log(context.Background(), level, strings.TrimSpace(msg))
+}
+
+// Printf logs a formatted string.
+func (sl *slogLogger) Printf(format string, args ...interface{}) {
+	sl.log(sl.level, fmt.Sprintf(format, args...))
+}
+
+// Println logs a line.
+func (sl *slogLogger) Println(args ...interface{}) {
+	sl.log(sl.level, fmt.Sprintln(args...))
+}
+
+// Fatalf logs a formatted string with level error followed by a program abort.
+func (sl *slogLogger) Fatalf(format string, args ...interface{}) {
+	sl.log(slog.LevelError, fmt.Sprintf(format, args...))
+	os.Exit(1)
+}
+
+// Fatalln logs a line with level error followed by a program abort.
+func (sl *slogLogger) Fatalln(args ...interface{}) {
+	sl.log(slog.LevelError, fmt.Sprintln(args...))
+	os.Exit(1)
+}
+
+// SetSlogLoggers routes all loggers except the CLI logger to l.
+// Stats and Info records are emitted with level info, all others with level debug.
+// Each record carries the name of the originating logger.
+func SetSlogLoggers(l *slog.Logger) {
+	with := func(name string, level slog.Level) Logger {
+		return NewSlogLogger(l.With(KeyLogger, name), level)
+	}
+	SetDebugLogger(with("debug", slog.LevelDebug))
+	SetInfoLogger(with("info", slog.LevelInfo))
+	SetStatsLogger(with("stats", slog.LevelInfo))
+	SetTraceLogger(with("trace", slog.LevelDebug))
+	SetParseLogger(with("parse", slog.LevelDebug))
+	SetReadLogger(with("read", slog.LevelDebug))
+	SetValidateLogger(with("validate", slog.LevelDebug))
+	SetOptimizeLogger(with("optimize", slog.LevelDebug))
+	SetWriteLogger(with("write", slog.LevelDebug))
+}
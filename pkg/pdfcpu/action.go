@@ -0,0 +1,366 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// actionForObject returns the action for an action dict or an explicit destination array as used for OpenAction.
+func actionForObject(ctx *model.Context, o types.Object) (*model.Action, error) {
+	o, err := ctx.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	if arr, ok := o.(types.Array); ok {
+		dest, err := destination(ctx, arr)
+		if err != nil {
+			return nil, err
+		}
+		return &model.Action{Type: "GoTo", Dest: dest}, nil
+	}
+
+	d, ok := o.(types.Dict)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid action: %s", o)
+	}
+
+	a := &model.Action{Type: annotName(ctx, d, "S")}
+
+	switch a.Type {
+
+	case "GoTo":
+		o, err := ctx.Dereference(d["D"])
+		if err != nil {
+			return nil, err
+		}
+		switch o := o.(type) {
+		case types.Array:
+			if a.Dest, err = destination(ctx, o); err != nil {
+				return nil, err
+			}
+		case types.Name:
+			a.NamedDest = o.Value()
+		case types.StringLiteral, types.HexLiteral:
+			if a.NamedDest, err = ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+				return nil, err
+			}
+		}
+
+	case "URI":
+		if a.URI, err = ctx.DereferenceStringOrHexLiteral(d["URI"], model.V10, nil); err != nil {
+			return nil, err
+		}
+
+	case "Named":
+		a.Name = annotName(ctx, d, "N")
+	}
+
+	return a, nil
+}
+
+// actionDict returns the action dict for a.
+func actionDict(ctx *model.Context, a model.Action) (types.Dict, error) {
+	if err := a.Validate(); err != nil {
+		return nil, err
+	}
+
+	d := types.Dict{"Type": types.Name("Action"), "S": types.Name(a.Type)}
+
+	switch a.Type {
+
+	case "GoTo":
+		if a.NamedDest != "" {
+			if !namedDestExists(ctx, a.NamedDest) {
+				return nil, errors.Errorf("pdfcpu: GoTo action: unknown named destination: %s", a.NamedDest)
+			}
+			d["D"] = types.StringLiteral(a.NamedDest)
+			break
+		}
+		if a.Dest.PageNr < 1 || a.Dest.PageNr > ctx.PageCount {
+			return nil, errors.Errorf("pdfcpu: GoTo action: invalid page number: %d", a.Dest.PageNr)
+		}
+		_, pageIndRef, _, err := ctx.PageDict(a.Dest.PageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		d["D"] = a.Dest.Array(*pageIndRef)
+
+	case "URI":
+		d["URI"] = types.StringLiteral(a.URI)
+
+	case "Named":
+		d["N"] = types.Name(a.Name)
+	}
+
+	return d, nil
+}
+
+// DocumentActions returns the open action and the document additional actions of ctx mapped by trigger.
+func DocumentActions(ctx *model.Context) (map[string]model.Action, error) {
+	m := map[string]model.Action{}
+
+	if o, found := ctx.RootDict.Find("OpenAction"); found {
+		a, err := actionForObject(ctx, o)
+		if err != nil {
+			return nil, errors.Wrap(err, "OpenAction")
+		}
+		if a != nil {
+			m["OpenAction"] = *a
+		}
+	}
+
+	aa, err := ctx.DereferenceDict(ctx.RootDict["AA"])
+	if err != nil {
+		return nil, err
+	}
+
+	for k, o := range aa {
+		a, err := actionForObject(ctx, o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "AA %s", k)
+		}
+		if a != nil {
+			m[k] = *a
+		}
+	}
+
+	return m, nil
+}
+
+// SetOpenAction sets the action performed when ctx is opened.
+func SetOpenAction(ctx *model.Context, a model.Action) error {
+	d, err := actionDict(ctx, a)
+	if err != nil {
+		return err
+	}
+
+	ctx.RootDict["OpenAction"] = d
+
+	return nil
+}
+
+// RemoveDocumentActions removes the open action and document additional actions for triggers.
+// All document actions are removed if triggers is empty.
+// RemoveDocumentActions returns the number of removed actions.
+func RemoveDocumentActions(ctx *model.Context, triggers []string) (int, error) {
+	for _, trigger := range triggers {
+		if !types.MemberOf(trigger, model.DocumentActionTriggers) {
+			return 0, errors.Errorf("pdfcpu: invalid document action trigger: %s", trigger)
+		}
+	}
+
+	remove := func(trigger string) bool {
+		return len(triggers) == 0 || types.MemberOf(trigger, triggers)
+	}
+
+	var count int
+
+	if remove("OpenAction") && ctx.RootDict.Delete("OpenAction") != nil {
+		count++
+	}
+
+	aa, err := ctx.DereferenceDict(ctx.RootDict["AA"])
+	if err != nil {
+		return 0, err
+	}
+
+	for k := range aa {
+		if remove(k) {
+			// Actions may refer to pages, so only detach them.
+			aa.Delete(k)
+			count++
+		}
+	}
+
+	if aa != nil && len(aa) == 0 {
+		ctx.RootDict.Delete("AA")
+	}
+
+	return count, nil
+}
+
+func validatePageActionTrigger(trigger string) error {
+	if trigger != model.PageOpen && trigger != model.PageClose {
+		return errors.Errorf("pdfcpu: invalid page action trigger: %s", trigger)
+	}
+	return nil
+}
+
+// PageActions returns the additional actions of selected pages.
+func PageActions(ctx *model.Context, selectedPages types.IntSet) ([]model.PageAction, error) {
+	pas := []model.PageAction{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		aa, err := ctx.DereferenceDict(pageDict["AA"])
+		if err != nil {
+			return nil, err
+		}
+
+		for _, trigger := range []string{model.PageOpen, model.PageClose} {
+			a, err := actionForObject(ctx, aa[trigger])
+			if err != nil {
+				return nil, errors.Wrapf(err, "page %d", pageNr)
+			}
+			if a != nil {
+				pas = append(pas, model.PageAction{PageNr: pageNr, Trigger: trigger, Action: *a})
+			}
+		}
+	}
+
+	return pas, nil
+}
+
+// SetPageActions sets the action performed on trigger for selected pages.
+func SetPageActions(ctx *model.Context, selectedPages types.IntSet, trigger string, a model.Action) error {
+	if err := validatePageActionTrigger(trigger); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+
+		d, err := actionDict(ctx, a)
+		if err != nil {
+			return err
+		}
+
+		aa, err := ctx.DereferenceDict(pageDict["AA"])
+		if err != nil {
+			return err
+		}
+		if aa == nil {
+			aa = types.Dict{}
+			pageDict["AA"] = aa
+		}
+
+		aa[trigger] = d
+	}
+
+	return nil
+}
+
+// RemovePageActions removes the additional actions for triggers from selected pages.
+// All page actions are removed if triggers is empty.
+// RemovePageActions returns the number of removed actions.
+func RemovePageActions(ctx *model.Context, selectedPages types.IntSet, triggers []string) (int, error) {
+	for _, trigger := range triggers {
+		if err := validatePageActionTrigger(trigger); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(triggers) == 0 {
+		triggers = []string{model.PageOpen, model.PageClose}
+	}
+
+	var count int
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return 0, err
+		}
+
+		aa, err := ctx.DereferenceDict(pageDict["AA"])
+		if err != nil {
+			return 0, err
+		}
+		if aa == nil {
+			continue
+		}
+
+		for _, trigger := range triggers {
+			// Actions may refer to pages, so only detach them.
+			if aa.Delete(trigger) != nil {
+				count++
+			}
+		}
+
+		if len(aa) == 0 {
+			pageDict.Delete("AA")
+		}
+	}
+
+	return count, nil
+}
+
+// ListActions returns a formatted list of the document actions and the additional actions of selected pages.
+func ListActions(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	m, err := DocumentActions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	pas, err := PageActions(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m) == 0 && len(pas) == 0 {
+		return []string{"no actions available"}, nil
+	}
+
+	ss := []string{}
+
+	if len(m) > 0 {
+		ss = append(ss, "Document:")
+		for _, k := range model.DocumentActionTriggers {
+			if a, ok := m[k]; ok {
+				ss = append(ss, fmt.Sprintf("   %-10s %s", k, a))
+			}
+		}
+	}
+
+	if len(pas) > 0 {
+		ss = append(ss, "Pages:")
+		for _, pa := range pas {
+			trigger := "open"
+			if pa.Trigger == model.PageClose {
+				trigger = "close"
+			}
+			ss = append(ss, fmt.Sprintf("   page %3d %-5s %s", pa.PageNr, trigger, pa.Action))
+		}
+	}
+
+	return ss, nil
+}
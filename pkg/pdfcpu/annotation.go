@@ -128,6 +128,15 @@ func createAnnot(ctx *model.Context, ar model.AnnotationRenderer, pageIndRef *ty
 	if err != nil {
 		return nil, nil, err
 	}
+	if ar.APObjNrInt() == 0 {
+		apIndRef, err := generateAppearance(ctx, d)
+		if err != nil {
+			return nil, nil, err
+		}
+		if apIndRef != nil {
+			d["AP"] = types.Dict{"N": *apIndRef}
+		}
+	}
 	indRef, err := ctx.IndRefForNewObject(d)
 	if err != nil {
 		return nil, nil, err
@@ -135,6 +144,24 @@ func createAnnot(ctx *model.Context, ar model.AnnotationRenderer, pageIndRef *ty
 	return indRef, d, nil
 }
 
+// annotStreamObjNrs returns the object numbers of the appearance stream and embedded file stream created along with d.
+func annotStreamObjNrs(d types.Dict) []int {
+	var objNrs []int
+	if ap, ok := d["AP"].(types.Dict); ok {
+		if ir, ok := ap["N"].(types.IndirectRef); ok {
+			objNrs = append(objNrs, ir.ObjectNumber.Value())
+		}
+	}
+	if fs, ok := d["FS"].(types.Dict); ok {
+		if ef, ok := fs["EF"].(types.Dict); ok {
+			if ir, ok := ef["F"].(types.IndirectRef); ok {
+				objNrs = append(objNrs, ir.ObjectNumber.Value())
+			}
+		}
+	}
+	return objNrs
+}
+
 func linkAnnotation(xRefTable *model.XRefTable, d types.Dict, r *types.Rectangle, apObjNr int, contents, nm string, f model.AnnotationFlags) (model.AnnotationRenderer, error) {
 	var uri string
 	o, found := d.Find("A")
@@ -469,6 +496,9 @@ func AddAnnotation(
 	if incr {
 		// Mark new annotaton dict obj for incremental writing.
 		ctx.Write.IncrementWithObjNr(annotIndRef.ObjectNumber.Value())
+		for _, objNr := range annotStreamObjNrs(d) {
+			ctx.Write.IncrementWithObjNr(objNr)
+		}
 	}
 
 	obj, found := pageDict.Find("Annots")
@@ -0,0 +1,589 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// annotAppearance collects the content and resources of a normal appearance stream.
+type annotAppearance struct {
+	ctx   *model.Context
+	d     types.Dict
+	rect  types.Rectangle
+	buf   bytes.Buffer
+	fonts types.Dict
+}
+
+func (ap *annotAppearance) numbers(key string) []float64 {
+	return numberArray(ap.ctx, ap.d[key])
+}
+
+func colorOp(c []float64, stroke bool) string {
+	var ss []string
+	for _, f := range c {
+		ss = append(ss, strconv.FormatFloat(f, 'f', 3, 64))
+	}
+	op := ""
+	switch len(c) {
+	case 1:
+		op = "g"
+	case 3:
+		op = "rg"
+	case 4:
+		op = "k"
+	default:
+		return ""
+	}
+	if stroke {
+		op = strings.ToUpper(op)
+	}
+	return strings.Join(ss, " ") + " " + op + "\n"
+}
+
+// color returns the annotation color C or def.
+func (ap *annotAppearance) color(def ...float64) []float64 {
+	if c := ap.numbers("C"); len(c) == 1 || len(c) == 3 || len(c) == 4 {
+		return c
+	}
+	return def
+}
+
+// borderWidth returns the border width taken from BS or Border, or def.
+func (ap *annotAppearance) borderWidth(def float64) float64 {
+	if bs, err := ap.ctx.DereferenceDict(ap.d["BS"]); err == nil && bs != nil {
+		if o, found := bs.Find("W"); found {
+			if w, err := ap.ctx.DereferenceNumber(o); err == nil {
+				return w
+			}
+		}
+	}
+	if b := ap.numbers("Border"); len(b) >= 3 {
+		return b[2]
+	}
+	return def
+}
+
+func (ap *annotAppearance) dashed() bool {
+	bs, err := ap.ctx.DereferenceDict(ap.d["BS"])
+	if err != nil || bs == nil {
+		return false
+	}
+	s := bs.NameEntry("S")
+	return s != nil && *s == "D"
+}
+
+func (ap *annotAppearance) printf(format string, a ...any) {
+	fmt.Fprintf(&ap.buf, format, a...)
+}
+
+// setStroke sets up line width, dash pattern and stroke color and returns false if nothing shall be stroked.
+func (ap *annotAppearance) setStroke(width float64, c []float64) bool {
+	if width <= 0 || c == nil {
+		return false
+	}
+	ap.printf("%.2f w\n", width)
+	if ap.dashed() {
+		ap.printf("[3] 0 d\n")
+	}
+	ap.buf.WriteString(colorOp(c, true))
+	return true
+}
+
+// paintOp returns the path painting operator for the given fill and stroke settings.
+func paintOp(fill, stroke, closed bool) string {
+	switch {
+	case fill && stroke:
+		if closed {
+			return "b"
+		}
+		return "B"
+	case fill:
+		return "f"
+	case stroke:
+		if closed {
+			return "s"
+		}
+		return "S"
+	}
+	return "n"
+}
+
+func (ap *annotAppearance) polyline(pp []float64, closed, fill, stroke bool) {
+	if len(pp) < 2 {
+		return
+	}
+	ap.printf("%.2f %.2f m\n", pp[0], pp[1])
+	if len(pp) == 2 {
+		// Show a single point as a dot.
+		ap.printf("%.2f %.2f l\n", pp[0], pp[1])
+	}
+	for i := 2; i+1 < len(pp); i += 2 {
+		ap.printf("%.2f %.2f l\n", pp[i], pp[i+1])
+	}
+	ap.printf("%s\n", paintOp(fill, stroke, closed))
+}
+
+// quads returns the quadrilaterals of a text markup annotation as p1 p2 p3 p4 (upper left, upper right, lower left, lower right).
+func (ap *annotAppearance) quads() [][8]float64 {
+	var qq [][8]float64
+	qp := ap.numbers("QuadPoints")
+	for i := 0; i+7 < len(qp); i += 8 {
+		var q [8]float64
+		copy(q[:], qp[i:i+8])
+		qq = append(qq, q)
+	}
+	if len(qq) == 0 {
+		r := ap.rect
+		qq = append(qq, [8]float64{r.LL.X, r.UR.Y, r.UR.X, r.UR.Y, r.LL.X, r.LL.Y, r.UR.X, r.LL.Y})
+	}
+	return qq
+}
+
+func (ap *annotAppearance) highlight() {
+	ap.buf.WriteString(colorOp(ap.color(1, 1, 0), false))
+	for _, q := range ap.quads() {
+		ap.polyline([]float64{q[0], q[1], q[2], q[3], q[6], q[7], q[4], q[5]}, true, true, false)
+	}
+}
+
+// textLine strokes a line parallel to the base line of each quad at offset f times the quad height.
+func (ap *annotAppearance) textLine(f float64, squiggly bool) {
+	c := ap.color(0, 0, 0)
+	ap.buf.WriteString(colorOp(c, true))
+	for _, q := range ap.quads() {
+		ux, uy := q[0]-q[4], q[1]-q[5]
+		h := math.Hypot(ux, uy)
+		vx, vy := q[6]-q[4], q[7]-q[5]
+		l := math.Hypot(vx, vy)
+		if h == 0 || l == 0 {
+			continue
+		}
+		ux, uy, vx, vy = ux/h, uy/h, vx/l, vy/l
+
+		lw := math.Max(.5, h/16)
+		ap.printf("%.2f w\n", lw)
+		x0, y0 := q[4]+ux*h*f, q[5]+uy*h*f
+
+		if !squiggly {
+			ap.polyline([]float64{x0, y0, x0 + vx*l, y0 + vy*l}, false, false, true)
+			continue
+		}
+
+		step, amp := h/6, h/16
+		pp := []float64{x0, y0}
+		for i, s := 1, step; s <= l; i, s = i+1, s+step {
+			a := amp
+			if i%2 == 1 {
+				a = -amp
+			}
+			pp = append(pp, x0+vx*s+ux*a, y0+vy*s+uy*a)
+		}
+		ap.polyline(pp, false, false, true)
+	}
+}
+
+func (ap *annotAppearance) ink() {
+	if !ap.setStroke(ap.borderWidth(1), ap.color(0, 0, 0)) {
+		return
+	}
+	ap.printf("1 J 1 j\n")
+	a, err := ap.ctx.DereferenceArray(ap.d["InkList"])
+	if err != nil {
+		return
+	}
+	for _, o := range a {
+		ap.polyline(numberArray(ap.ctx, o), false, false, true)
+	}
+}
+
+// interior sets the fill color IC and returns true if the interior of a shape shall be painted.
+func (ap *annotAppearance) interior() bool {
+	ic := ap.numbers("IC")
+	if op := colorOp(ic, false); op != "" {
+		ap.buf.WriteString(op)
+		return true
+	}
+	return false
+}
+
+func (ap *annotAppearance) poly(closed bool) {
+	fill := closed && ap.interior()
+	stroke := ap.setStroke(ap.borderWidth(1), ap.color(0, 0, 0))
+	ap.printf("1 j\n")
+	pp := ap.numbers("Vertices")
+	if ap.d.NameEntry("Subtype") != nil && *ap.d.NameEntry("Subtype") == "Line" {
+		pp = ap.numbers("L")
+	}
+	ap.polyline(pp, closed, fill, stroke)
+}
+
+// innerRect returns the annotation rectangle reduced by the margins RD and half of the border width w.
+func (ap *annotAppearance) innerRect(w float64) types.Rectangle {
+	r := ap.rect
+	if rd := ap.numbers("RD"); len(rd) == 4 {
+		r = *types.NewRectangle(r.LL.X+rd[0], r.LL.Y+rd[3], r.UR.X-rd[2], r.UR.Y-rd[1])
+	}
+	return *types.NewRectangle(r.LL.X+w/2, r.LL.Y+w/2, r.UR.X-w/2, r.UR.Y-w/2)
+}
+
+// bezierCircle is the distance of the control points from the end points of a cubic Bézier curve approximating a quarter circle.
+const bezierCircle = 0.5523
+
+func (ap *annotAppearance) shape(ellipse bool) {
+	fill := ap.interior()
+	w := ap.borderWidth(1)
+	stroke := ap.setStroke(w, ap.color(0, 0, 0))
+	if !stroke {
+		w = 0
+	}
+	r := ap.innerRect(w)
+
+	if !ellipse {
+		ap.printf("%.2f %.2f %.2f %.2f re %s\n", r.LL.X, r.LL.Y, r.Width(), r.Height(), paintOp(fill, stroke, true))
+		return
+	}
+
+	cx, cy := (r.LL.X+r.UR.X)/2, (r.LL.Y+r.UR.Y)/2
+	rx, ry := r.Width()/2, r.Height()/2
+	kx, ky := rx*bezierCircle, ry*bezierCircle
+	ap.printf("%.2f %.2f m\n", cx+rx, cy)
+	ap.printf("%.2f %.2f %.2f %.2f %.2f %.2f c\n", cx+rx, cy+ky, cx+kx, cy+ry, cx, cy+ry)
+	ap.printf("%.2f %.2f %.2f %.2f %.2f %.2f c\n", cx-kx, cy+ry, cx-rx, cy+ky, cx-rx, cy)
+	ap.printf("%.2f %.2f %.2f %.2f %.2f %.2f c\n", cx-rx, cy-ky, cx-kx, cy-ry, cx, cy-ry)
+	ap.printf("%.2f %.2f %.2f %.2f %.2f %.2f c\n", cx+kx, cy-ry, cx+rx, cy-ky, cx+rx, cy)
+	ap.printf("%s\n", paintOp(fill, stroke, true))
+}
+
+// fontID returns the resource name of a core font used by the appearance stream.
+func (ap *annotAppearance) fontID(fontName string) string {
+	if !font.IsCoreFont(fontName) {
+		fontName = "Helvetica"
+	}
+	if ap.fonts == nil {
+		ap.fonts = types.Dict{}
+	}
+	ap.fonts[fontName] = types.Dict(map[string]types.Object{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("Type1"),
+		"BaseFont": types.Name(fontName),
+		"Encoding": types.Name("WinAnsiEncoding"),
+	})
+	return fontName
+}
+
+// defaultAppearance parses font name, font size and fill color of a DA string like "/Helvetica 12 Tf 0 0 1 rg".
+func defaultAppearance(da string) (fontName string, fontSize int, col []float64) {
+	fontName, fontSize, col = "Helvetica", 12, []float64{0}
+	var nn []float64
+	for _, s := range strings.Fields(da) {
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			nn = append(nn, f)
+			continue
+		}
+		switch {
+		case s[0] == '/':
+			fontName = s[1:]
+		case s == "Tf" && len(nn) > 0 && nn[len(nn)-1] > 0:
+			fontSize = int(math.Round(nn[len(nn)-1]))
+		case s == "g" && len(nn) >= 1:
+			col = nn[len(nn)-1:]
+		case s == "rg" && len(nn) >= 3:
+			col = nn[len(nn)-3:]
+		case s == "k" && len(nn) >= 4:
+			col = nn[len(nn)-4:]
+		}
+		nn = nil
+	}
+	return fontName, fontSize, col
+}
+
+// wrapText breaks s into lines not wider than w.
+func wrapText(s, fontName string, fontSize int, w float64) []string {
+	var ll []string
+	for _, para := range strings.Split(strings.ReplaceAll(s, "\r", "\n"), "\n") {
+		line := ""
+		for _, word := range strings.Fields(para) {
+			if line == "" {
+				line = word
+				continue
+			}
+			if font.TextWidth(line+" "+word, fontName, fontSize) > w {
+				ll = append(ll, line)
+				line = word
+				continue
+			}
+			line += " " + word
+		}
+		ll = append(ll, line)
+	}
+	return ll
+}
+
+// text shows the lines of s within r using the alignment q (0 = left, 1 = centered, 2 = right).
+func (ap *annotAppearance) text(s string, r types.Rectangle, fontName string, fontSize int, col []float64, q int, vCenter bool) {
+	if !font.IsCoreFont(fontName) {
+		fontName = "Helvetica"
+	}
+	id := ap.fontID(fontName)
+	lh := font.LineHeight(fontName, fontSize)
+	ll := wrapText(s, fontName, fontSize, r.Width())
+
+	y := r.UR.Y - font.Ascent(fontName, fontSize)
+	if vCenter {
+		y = (r.LL.Y+r.UR.Y)/2 + float64(len(ll))*lh/2 - font.Ascent(fontName, fontSize)
+	}
+
+	ap.printf("q %.2f %.2f %.2f %.2f re W n\n", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	ap.printf("BT /%s %d Tf %s", id, fontSize, colorOp(col, false))
+	for _, l := range ll {
+		x := r.LL.X
+		switch q {
+		case 1:
+			x += (r.Width() - font.TextWidth(l, fontName, fontSize)) / 2
+		case 2:
+			x += r.Width() - font.TextWidth(l, fontName, fontSize)
+		}
+		ap.printf("1 0 0 1 %.2f %.2f Tm (%s) Tj\n", x, y, model.PrepBytes(ap.ctx.XRefTable, l, fontName, false, false, false))
+		y -= lh
+	}
+	ap.printf("ET Q\n")
+}
+
+func (ap *annotAppearance) stringEntry(key string) string {
+	return annotString(ap.ctx, ap.d, key)
+}
+
+func (ap *annotAppearance) freeText() {
+	if c := colorOp(ap.color(), false); c != "" {
+		ap.buf.WriteString(c)
+		ap.printf("%.2f %.2f %.2f %.2f re f\n", ap.rect.LL.X, ap.rect.LL.Y, ap.rect.Width(), ap.rect.Height())
+	}
+
+	w := ap.borderWidth(0)
+	if ap.setStroke(w, []float64{0}) {
+		r := ap.innerRect(w)
+		ap.printf("%.2f %.2f %.2f %.2f re S\n", r.LL.X, r.LL.Y, r.Width(), r.Height())
+	} else {
+		w = 0
+	}
+
+	s := ap.stringEntry("RC")
+	if s == "" {
+		s = ap.stringEntry("Contents")
+	}
+
+	da, _ := ap.ctx.DereferenceStringOrHexLiteral(ap.d["DA"], model.V10, nil)
+	fontName, fontSize, col := defaultAppearance(da)
+
+	q := 0
+	if i := ap.d.IntEntry("Q"); i != nil {
+		q = *i
+	}
+
+	r := ap.innerRect(w)
+	pad := w/2 + 2
+	r = *types.NewRectangle(r.LL.X+pad, r.LL.Y+pad, r.UR.X-pad, r.UR.Y-pad)
+	ap.text(s, r, fontName, fontSize, col, q, false)
+}
+
+// stampLabel returns the text shown for a stamp name like "NotApproved".
+func stampLabel(name string) string {
+	var sb strings.Builder
+	for i, r := range name {
+		if i > 0 && unicode.IsUpper(r) {
+			sb.WriteRune(' ')
+		}
+		sb.WriteRune(unicode.ToUpper(r))
+	}
+	return sb.String()
+}
+
+func (ap *annotAppearance) stamp() {
+	name := "Draft"
+	if n := ap.d.NameEntry("Name"); n != nil && *n != "" {
+		name = *n
+	}
+	label := stampLabel(name)
+
+	c := ap.color(.8, .1, .1)
+	r := ap.rect
+	w := math.Max(1, math.Min(r.Width(), r.Height())/12)
+	ap.setStroke(w, c)
+	ap.printf("1 j\n")
+	r = *types.NewRectangle(r.LL.X+w/2, r.LL.Y+w/2, r.UR.X-w/2, r.UR.Y-w/2)
+	ap.printf("%.2f %.2f %.2f %.2f re S\n", r.LL.X, r.LL.Y, r.Width(), r.Height())
+
+	fontName := "Helvetica-Bold"
+	inner := *types.NewRectangle(r.LL.X+2*w, r.LL.Y+w, r.UR.X-2*w, r.UR.Y-w)
+	fontSize := font.Size(label, fontName, inner.Width())
+	if fs := font.SizeForLineHeight(fontName, inner.Height()); fs < fontSize {
+		fontSize = fs
+	}
+	// Keep the label on a single line.
+	for fontSize > 1 && font.TextWidth(label, fontName, fontSize) > inner.Width() {
+		fontSize--
+	}
+	ap.text(label, inner, fontName, max(1, fontSize), c, 1, true)
+}
+
+func (ap *annotAppearance) fileAttachment() {
+	// A sheet of paper with a folded corner.
+	c := ap.color(.2, .4, .8)
+	r := ap.rect
+	s := math.Min(r.Width(), r.Height())
+	x, y := r.LL.X+(r.Width()-s)/2, r.LL.Y+(r.Height()-s)/2
+	ap.printf("1 g\n")
+	ap.setStroke(math.Max(.5, s/20), c)
+	ap.printf("1 j\n")
+	ap.polyline([]float64{x + .2*s, y + .05*s, x + .8*s, y + .05*s, x + .8*s, y + .7*s, x + .55*s, y + .95*s, x + .2*s, y + .95*s}, true, true, true)
+	ap.polyline([]float64{x + .55*s, y + .95*s, x + .55*s, y + .7*s, x + .8*s, y + .7*s}, false, false, true)
+	for _, f := range []float64{.55, .4, .25} {
+		ap.polyline([]float64{x + .3*s, y + f*s, x + .7*s, y + f*s}, false, false, true)
+	}
+}
+
+func (ap *annotAppearance) richMedia() {
+	// A dark panel with a play button as long as there is no poster image.
+	r := ap.rect
+	ap.printf(".2 g\n")
+	ap.polyline([]float64{r.LL.X, r.LL.Y, r.UR.X, r.LL.Y, r.UR.X, r.UR.Y, r.LL.X, r.UR.Y}, true, true, false)
+	s := math.Min(r.Width(), r.Height()) / 3
+	x, y := r.LL.X+r.Width()/2, r.LL.Y+r.Height()/2
+	ap.printf("1 g\n")
+	ap.polyline([]float64{x - .4*s, y - .5*s, x + .5*s, y, x - .4*s, y + .5*s}, true, true, false)
+}
+
+func (ap *annotAppearance) threeD() {
+	// A light panel with a wireframe cube as long as there is no poster image.
+	r := ap.rect
+	ap.printf(".9 g\n")
+	ap.polyline([]float64{r.LL.X, r.LL.Y, r.UR.X, r.LL.Y, r.UR.X, r.UR.Y, r.LL.X, r.UR.Y}, true, true, false)
+	s := math.Min(r.Width(), r.Height()) / 4
+	x, y := r.LL.X+r.Width()/2-.6*s, r.LL.Y+r.Height()/2-.6*s
+	d := .4 * s
+	ap.setStroke(math.Max(.5, s/15), []float64{.3, .3, .3})
+	ap.polyline([]float64{x, y, x + s, y, x + s, y + s, x, y + s}, true, false, true)
+	ap.polyline([]float64{x, y + s, x + d, y + s + d, x + s + d, y + s + d, x + s, y + s}, false, false, true)
+	ap.polyline([]float64{x + s + d, y + s + d, x + s + d, y + d, x + s, y}, false, false, true)
+}
+
+func (ap *annotAppearance) extGState(subtype string) types.Dict {
+	d := types.Dict{}
+	if o, found := ap.d.Find("CA"); found {
+		if ca, err := ap.ctx.DereferenceNumber(o); err == nil && ca < 1 {
+			d["CA"], d["ca"] = types.Float(ca), types.Float(ca)
+		}
+	}
+	if subtype == "Highlight" {
+		d["BM"] = types.Name("Multiply")
+	}
+	if len(d) == 0 {
+		return nil
+	}
+	d["Type"] = types.Name("ExtGState")
+	return d
+}
+
+// generateAppearance returns a normal appearance stream for the markup annotation dict d
+// or nil if d already has an appearance or appearance generation is not supported for its type.
+func generateAppearance(ctx *model.Context, d types.Dict) (*types.IndirectRef, error) {
+	if _, found := d.Find("AP"); found {
+		return nil, nil
+	}
+
+	subtype := d.NameEntry("Subtype")
+	if subtype == nil {
+		return nil, nil
+	}
+
+	a, err := ctx.DereferenceArray(d["Rect"])
+	if err != nil || len(a) != 4 {
+		return nil, err
+	}
+	rect, err := ctx.RectForArray(a)
+	if err != nil {
+		return nil, err
+	}
+
+	ap := &annotAppearance{ctx: ctx, d: d, rect: *rect}
+
+	resDict := types.Dict{}
+	if gs := ap.extGState(*subtype); gs != nil {
+		resDict["ExtGState"] = types.Dict{"GS0": gs}
+		ap.printf("/GS0 gs\n")
+	}
+
+	switch *subtype {
+	case "Highlight":
+		ap.highlight()
+	case "Underline":
+		ap.textLine(.1, false)
+	case "StrikeOut":
+		ap.textLine(.45, false)
+	case "Squiggly":
+		ap.textLine(.1, true)
+	case "Ink":
+		ap.ink()
+	case "Line", "PolyLine":
+		ap.poly(false)
+	case "Polygon":
+		ap.poly(true)
+	case "Square":
+		ap.shape(false)
+	case "Circle":
+		ap.shape(true)
+	case "FreeText":
+		ap.freeText()
+	case "Stamp":
+		ap.stamp()
+	case "FileAttachment":
+		ap.fileAttachment()
+	case "RichMedia":
+		ap.richMedia()
+	case "3D":
+		ap.threeD()
+	default:
+		return nil, nil
+	}
+
+	if ap.fonts != nil {
+		resDict["Font"] = ap.fonts
+	}
+
+	sd, _ := ctx.NewStreamDictForBuf(ap.buf.Bytes())
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", rect.Array())
+	if len(resDict) > 0 {
+		sd.Insert("Resources", resDict)
+	}
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return ctx.IndRefForNewObject(*sd)
+}
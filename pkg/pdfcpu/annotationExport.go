@@ -0,0 +1,445 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// exportableAnnotTypes are the annotation types covered by AnnotationSpec.
+var exportableAnnotTypes = []string{
+	"Text", "Highlight", "Underline", "StrikeOut", "Squiggly", "Ink", "Line", "Polygon", "PolyLine",
+	"FreeText", "Square", "Circle", "FileAttachment", "Stamp",
+}
+
+func annotString(ctx *model.Context, d types.Dict, key string) string {
+	o, found := d.Find(key)
+	if !found {
+		return ""
+	}
+	s, err := ctx.DereferenceStringOrHexLiteral(o, model.V10, nil)
+	if err != nil {
+		return ""
+	}
+	return s
+}
+
+func annotName(ctx *model.Context, d types.Dict, key string) string {
+	o, err := ctx.DereferenceName(d[key], model.V10, nil)
+	if err != nil {
+		return ""
+	}
+	return o.Value()
+}
+
+// colorString returns c as "#RRGGBB".
+func colorString(c []float64) string {
+	var r, g, b float64
+	switch len(c) {
+	case 1:
+		r, g, b = c[0], c[0], c[0]
+	case 3:
+		r, g, b = c[0], c[1], c[2]
+	case 4:
+		k := 1 - c[3]
+		r, g, b = (1-c[0])*k, (1-c[1])*k, (1-c[2])*k
+	default:
+		return ""
+	}
+	i := func(f float64) int { return int(math.Round(math.Max(0, math.Min(1, f)) * 255)) }
+	return fmt.Sprintf("#%02X%02X%02X", i(r), i(g), i(b))
+}
+
+func exportFileAttachment(ctx *model.Context, d types.Dict, spec *AnnotationSpec) error {
+	fs, err := ctx.DereferenceDict(d["FS"])
+	if err != nil || fs == nil {
+		return err
+	}
+
+	if spec.File = annotString(ctx, fs, "UF"); spec.File == "" {
+		spec.File = annotString(ctx, fs, "F")
+	}
+	spec.Desc = annotString(ctx, fs, "Desc")
+
+	ef, err := ctx.DereferenceDict(fs["EF"])
+	if err != nil || ef == nil {
+		return err
+	}
+	o, found := ef.Find("F")
+	if !found {
+		return nil
+	}
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return err
+	}
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+	spec.FileContent = sd.Content
+	return nil
+}
+
+func exportFreeText(ctx *model.Context, d types.Dict, spec *AnnotationSpec) {
+	// Rich text is XHTML, fall back to the plain text of Contents.
+	if s := annotString(ctx, d, "RC"); s != "" && !strings.HasPrefix(strings.TrimSpace(s), "<") {
+		spec.Text = s
+	}
+
+	fontName, fontSize, col := defaultAppearance(annotString(ctx, d, "DA"))
+	spec.Font, spec.FontSize = fontName, fontSize
+	if c := colorString(col); c != "#000000" {
+		spec.FontColor = c
+	}
+
+	if q := d.IntEntry("Q"); q != nil {
+		switch *q {
+		case 1:
+			spec.Align = "center"
+		case 2:
+			spec.Align = "right"
+		}
+	}
+}
+
+// exportAnnotation returns the spec for the annotation dict d.
+func exportAnnotation(ctx *model.Context, d types.Dict, subtype string) (AnnotationSpec, error) {
+	spec := AnnotationSpec{
+		Type:     subtype,
+		Rect:     numberArray(ctx, d["Rect"]),
+		Contents: annotString(ctx, d, "Contents"),
+		ID:       annotString(ctx, d, "NM"),
+		ModDate:  annotString(ctx, d, "M"),
+		Color:    colorString(numberArray(ctx, d["C"])),
+		Title:    annotString(ctx, d, "T"),
+		Subject:  annotString(ctx, d, "Subj"),
+	}
+
+	f := 0
+	if i := d.IntEntry("F"); i != nil {
+		f = *i
+	}
+	spec.Flags = &f
+
+	if subtype != "FreeText" {
+		spec.FillColor = colorString(numberArray(ctx, d["IC"]))
+	}
+
+	if o, found := d.Find("CA"); found {
+		if ca, err := ctx.DereferenceNumber(o); err == nil && ca < 1 {
+			spec.Opacity = &ca
+		}
+	}
+
+	ap := &annotAppearance{ctx: ctx, d: d}
+	if _, found := d.Find("BS"); found || subtype == "FreeText" {
+		spec.BorderWidth = ap.borderWidth(0)
+	} else if b := numberArray(ctx, d["Border"]); len(b) >= 3 {
+		spec.BorderWidth = b[2]
+	}
+	spec.Dashed = ap.dashed()
+
+	switch subtype {
+
+	case "Text":
+		spec.Name = annotName(ctx, d, "Name")
+		if b := d.BooleanEntry("Open"); b != nil {
+			spec.Open = *b
+		}
+		spec.State = annotString(ctx, d, "State")
+		spec.StateModel = annotString(ctx, d, "StateModel")
+
+	case "Highlight", "Underline", "StrikeOut", "Squiggly":
+		spec.QuadPoints = numberArray(ctx, d["QuadPoints"])
+
+	case "Ink":
+		a, err := ctx.DereferenceArray(d["InkList"])
+		if err != nil {
+			return spec, err
+		}
+		for _, o := range a {
+			spec.Ink = append(spec.Ink, numberArray(ctx, o))
+		}
+
+	case "Line":
+		spec.Line = numberArray(ctx, d["L"])
+
+	case "Polygon", "PolyLine":
+		spec.Vertices = numberArray(ctx, d["Vertices"])
+
+	case "FreeText":
+		exportFreeText(ctx, d, &spec)
+
+	case "FileAttachment":
+		spec.Name = annotName(ctx, d, "Name")
+		if err := exportFileAttachment(ctx, d, &spec); err != nil {
+			return spec, err
+		}
+
+	case "Stamp":
+		spec.Name = annotName(ctx, d, "Name")
+	}
+
+	return spec, nil
+}
+
+// pageAnnot is an annotation dict together with its object number.
+type pageAnnot struct {
+	objNr int
+	d     types.Dict
+}
+
+// pageAnnots returns the annotation dicts of page pageNr in the order of its Annots array.
+func pageAnnots(ctx *model.Context, pageNr int) ([]pageAnnot, error) {
+	d, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || a == nil {
+		return nil, err
+	}
+
+	var aa []pageAnnot
+	for _, o := range a {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		d, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil {
+			aa = append(aa, pageAnnot{objNr: ir.ObjectNumber.Value(), d: d})
+		}
+	}
+	return aa, nil
+}
+
+func irtObjNr(d types.Dict) int {
+	if ir, ok := d["IRT"].(types.IndirectRef); ok {
+		return ir.ObjectNumber.Value()
+	}
+	return 0
+}
+
+func exportReplies(ctx *model.Context, objNr int, replies map[int][]pageAnnot) ([]AnnotationSpec, error) {
+	var ss []AnnotationSpec
+	for _, pa := range replies[objNr] {
+		if subtype := annotName(ctx, pa.d, "Subtype"); subtype == "Text" {
+			spec, err := exportAnnotation(ctx, pa.d, subtype)
+			if err != nil {
+				return nil, err
+			}
+			if spec.Replies, err = exportReplies(ctx, pa.objNr, replies); err != nil {
+				return nil, err
+			}
+			ss = append(ss, spec)
+		}
+	}
+	return ss, nil
+}
+
+// ExportAnnotations returns the markup annotations of selected pages together with their replies and review states.
+func ExportAnnotations(ctx *model.Context, selectedPages types.IntSet) (*AnnotationsSpec, error) {
+	spec := &AnnotationsSpec{Annotations: map[string][]AnnotationSpec{}}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		aa, err := pageAnnots(ctx, pageNr)
+		if err != nil {
+			return nil, err
+		}
+
+		objNrs := map[int]bool{}
+		for _, pa := range aa {
+			objNrs[pa.objNr] = true
+		}
+
+		replies := map[int][]pageAnnot{}
+		var ss []AnnotationSpec
+
+		for _, pa := range aa {
+			if objNr := irtObjNr(pa.d); objNrs[objNr] {
+				replies[objNr] = append(replies[objNr], pa)
+			}
+		}
+
+		for _, pa := range aa {
+			if objNrs[irtObjNr(pa.d)] {
+				continue
+			}
+			subtype := annotName(ctx, pa.d, "Subtype")
+			if !types.MemberOf(subtype, exportableAnnotTypes) {
+				continue
+			}
+			as, err := exportAnnotation(ctx, pa.d, subtype)
+			if err != nil {
+				return nil, errors.Wrapf(err, "page %d obj#%d", pageNr, pa.objNr)
+			}
+			if as.Replies, err = exportReplies(ctx, pa.objNr, replies); err != nil {
+				return nil, err
+			}
+			ss = append(ss, as)
+		}
+
+		if len(ss) > 0 {
+			spec.Annotations[strconv.Itoa(pageNr)] = ss
+		}
+	}
+
+	return spec, nil
+}
+
+// annotationImporter adds annotations to a page skipping those whose id is already in use.
+type annotationImporter struct {
+	ctx    *model.Context
+	pageNr int
+	dir    string
+	ids    map[string]types.IndirectRef
+	count  int
+}
+
+func (ai *annotationImporter) existingRect(ir types.IndirectRef) *types.Rectangle {
+	d, err := ai.ctx.DereferenceDict(ir)
+	if err != nil || d == nil {
+		return nil
+	}
+	a, err := ai.ctx.DereferenceArray(d["Rect"])
+	if err != nil {
+		return nil
+	}
+	r, err := ai.ctx.RectForArray(a)
+	if err != nil {
+		return nil
+	}
+	return r
+}
+
+func (ai *annotationImporter) add(spec AnnotationSpec, irt *types.IndirectRef, parentRect *types.Rectangle) error {
+	var (
+		ir   types.IndirectRef
+		rect *types.Rectangle
+	)
+
+	if existing, ok := ai.ids[spec.ID]; ok && spec.ID != "" {
+		ir, rect = existing, ai.existingRect(existing)
+	} else {
+		if irt != nil {
+			if !strings.EqualFold(spec.Type, "Text") {
+				return errors.Errorf("pdfcpu: replies must be of type Text: %s", spec.Type)
+			}
+			if len(spec.Rect) == 0 && parentRect != nil {
+				spec.Rect = []float64{parentRect.LL.X, parentRect.LL.Y, parentRect.UR.X, parentRect.UR.Y}
+			}
+		}
+
+		ar, err := spec.renderer(ai.dir)
+		if err != nil {
+			return err
+		}
+		if irt != nil {
+			ta := ar.(model.TextAnnotation)
+			ta.IRT = irt
+			ar = ta
+		}
+
+		indRef, _, err := AddAnnotationToPage(ai.ctx, ai.pageNr, ar, false)
+		if err != nil {
+			return err
+		}
+		ai.count++
+
+		ir = *indRef
+		if rect, err = spec.rect(); err != nil {
+			return err
+		}
+		if spec.ID != "" {
+			ai.ids[spec.ID] = ir
+		}
+	}
+
+	for _, reply := range spec.Replies {
+		if err := ai.add(reply, &ir, rect); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportAnnotations adds the annotations of spec including their replies and review states to ctx.
+// Annotations whose id is already present on their page are not added again but get merged with new replies.
+// This allows to merge the exported annotations of multiple reviewers.
+// Relative file names of file attachments are resolved against dir.
+func ImportAnnotations(ctx *model.Context, spec *AnnotationsSpec, dir string) (int, error) {
+	if spec == nil || len(spec.Annotations) == 0 {
+		return 0, errors.New("pdfcpu: annotations JSON: missing \"annotations\"")
+	}
+
+	var pageNrs []int
+	keys := map[int]string{}
+	for k := range spec.Annotations {
+		pageNr, err := strconv.Atoi(k)
+		if err != nil || pageNr < 1 {
+			return 0, errors.Errorf("pdfcpu: annotations JSON: invalid page number: %s", k)
+		}
+		if pageNr > ctx.PageCount {
+			return 0, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+		}
+		pageNrs = append(pageNrs, pageNr)
+		keys[pageNr] = k
+	}
+	sort.Ints(pageNrs)
+
+	var count int
+	for _, pageNr := range pageNrs {
+		aa, err := pageAnnots(ctx, pageNr)
+		if err != nil {
+			return 0, err
+		}
+
+		ai := &annotationImporter{ctx: ctx, pageNr: pageNr, dir: dir, ids: map[string]types.IndirectRef{}}
+		for _, pa := range aa {
+			if id := annotString(ctx, pa.d, "NM"); id != "" {
+				ai.ids[id] = *types.NewIndirectRef(pa.objNr, 0)
+			}
+		}
+
+		for i, as := range spec.Annotations[keys[pageNr]] {
+			if err := ai.add(as, nil, nil); err != nil {
+				return 0, errors.Wrapf(err, "page %d annotation %d", pageNr, i+1)
+			}
+		}
+		count += ai.count
+	}
+
+	return count, nil
+}
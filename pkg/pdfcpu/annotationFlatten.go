@@ -0,0 +1,307 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// flattenableAnnotTypes are the markup annotation types that may be burned into the page content.
+var flattenableAnnotTypes = append([]string{"Caret"}, exportableAnnotTypes...)
+
+// normalAppearance returns the normal appearance stream of the annotation dict d taking into account its appearance state.
+func normalAppearance(ctx *model.Context, d types.Dict) (*types.IndirectRef, *types.StreamDict, error) {
+	apDict, err := ctx.DereferenceDict(d["AP"])
+	if err != nil || apDict == nil {
+		return nil, nil, err
+	}
+
+	o := apDict["N"]
+	if d1, err := ctx.DereferenceDict(o); err == nil && d1 != nil {
+		// Appearance subdictionary keyed by appearance state.
+		as := d.NameEntry("AS")
+		if as == nil {
+			return nil, nil, nil
+		}
+		o = d1[*as]
+	}
+
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		return nil, nil, nil
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(ir)
+	if err != nil || sd == nil {
+		return nil, nil, err
+	}
+
+	return &ir, sd, nil
+}
+
+// appearanceMatrix returns the matrix mapping the transformed bounding box of the appearance stream sd onto rect.
+func appearanceMatrix(ctx *model.Context, sd *types.StreamDict, rect *types.Rectangle) (*[6]float64, error) {
+	bb := numberArray(ctx, sd.Dict["BBox"])
+	if len(bb) != 4 {
+		return nil, errors.New("pdfcpu: appearance stream: missing BBox")
+	}
+
+	m := [6]float64{1, 0, 0, 1, 0, 0}
+	if mm := numberArray(ctx, sd.Dict["Matrix"]); len(mm) == 6 {
+		copy(m[:], mm)
+	}
+
+	// Transform the corners of BBox by Matrix.
+	llx, lly, urx, ury := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range [][2]float64{{bb[0], bb[1]}, {bb[2], bb[1]}, {bb[0], bb[3]}, {bb[2], bb[3]}} {
+		x := m[0]*p[0] + m[2]*p[1] + m[4]
+		y := m[1]*p[0] + m[3]*p[1] + m[5]
+		llx, lly, urx, ury = math.Min(llx, x), math.Min(lly, y), math.Max(urx, x), math.Max(ury, y)
+	}
+
+	if urx-llx == 0 || ury-lly == 0 {
+		return nil, nil
+	}
+
+	sx, sy := rect.Width()/(urx-llx), rect.Height()/(ury-lly)
+
+	return &[6]float64{sx, 0, 0, sy, rect.LL.X - llx*sx, rect.LL.Y - lly*sy}, nil
+}
+
+// annotationFlattener collects the content and the annotations to be removed for a page.
+type annotationFlattener struct {
+	ctx        *model.Context
+	pageDict   types.Dict
+	pageIndRef types.IndirectRef
+	inhPAttrs  *model.InheritedPageAttrs
+	buf        bytes.Buffer
+	removed    types.IntSet
+	replies    map[int][]int
+}
+
+// remove marks the annotation objNr together with all replies for removal.
+func (af *annotationFlattener) remove(objNr int) {
+	if af.removed[objNr] {
+		return
+	}
+	af.removed[objNr] = true
+	for _, objNr := range af.replies[objNr] {
+		af.remove(objNr)
+	}
+}
+
+// draw renders the appearance of annotation dict d into af's content and returns false if there is no appearance.
+func (af *annotationFlattener) draw(d types.Dict) (bool, error) {
+	ctx := af.ctx
+
+	if _, found := d.Find("AP"); !found {
+		ir, err := generateAppearance(ctx, d)
+		if err != nil {
+			return false, err
+		}
+		if ir == nil {
+			return false, nil
+		}
+		d["AP"] = types.Dict{"N": *ir}
+	}
+
+	ir, sd, err := normalAppearance(ctx, d)
+	if err != nil || ir == nil {
+		return false, err
+	}
+
+	if f := d.IntEntry("F"); f != nil && model.AnnotationFlags(*f)&(model.AnnHidden|model.AnnNoView) > 0 {
+		// Not visible, remove only.
+		return true, nil
+	}
+
+	a, err := ctx.DereferenceArray(d["Rect"])
+	if err != nil {
+		return false, err
+	}
+	rect, err := ctx.RectForArray(a)
+	if err != nil {
+		return false, err
+	}
+
+	m, err := appearanceMatrix(ctx, sd, rect)
+	if err != nil || m == nil {
+		return m != nil, err
+	}
+
+	id, err := addPageResource(ctx, af.pageDict, af.inhPAttrs, "XObject", "Fm", *ir)
+	if err != nil {
+		return false, err
+	}
+
+	// Keep optional content annotations optional.
+	var emc bool
+	if oc, ok := d["OC"].(types.IndirectRef); ok {
+		ocID, err := addPageResource(ctx, af.pageDict, af.inhPAttrs, "Properties", "OC", oc)
+		if err != nil {
+			return false, err
+		}
+		fmt.Fprintf(&af.buf, "/OC /%s BDC\n", ocID)
+		emc = true
+	}
+
+	// Tag the flattened appearance taking over the annotation's description.
+	var tagged bool
+	if ctx.Conf.TagContent {
+		elem, err := ctx.AddStructElem(nil, model.StructTag{Type: "Annot", Alt: annotString(ctx, d, "Contents")})
+		if err != nil {
+			return false, err
+		}
+		mcid, err := ctx.NewMCID(*elem, af.pageIndRef, af.pageDict)
+		if err != nil {
+			return false, err
+		}
+		model.BeginMarkedContent(&af.buf, "Annot", mcid)
+		tagged = true
+	}
+
+	fmt.Fprintf(&af.buf, "q %.4f %.4f %.4f %.4f %.4f %.4f cm /%s Do Q\n", m[0], m[1], m[2], m[3], m[4], m[5], id)
+
+	if tagged {
+		model.EndMarkedContent(&af.buf)
+	}
+
+	if emc {
+		af.buf.WriteString("EMC\n")
+	}
+
+	// Detach what is referenced by the page content now so removing the annotation does not free it.
+	d.Delete("AP")
+	d.Delete("OC")
+
+	return true, nil
+}
+
+func flattenPageAnnotations(ctx *model.Context, pageNr int, annotTypes []string, ids []string, objNrs types.IntSet) (int, error) {
+	aa, err := pageAnnots(ctx, pageNr)
+	if err != nil || len(aa) == 0 {
+		return 0, err
+	}
+
+	pageDictIndRef, err := ctx.PageDictIndRef(pageNr)
+	if err != nil {
+		return 0, err
+	}
+
+	pageDict, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return 0, err
+	}
+
+	af := &annotationFlattener{ctx: ctx, pageDict: pageDict, pageIndRef: *pageDictIndRef, inhPAttrs: inhPAttrs, removed: types.IntSet{}, replies: map[int][]int{}}
+
+	for _, pa := range aa {
+		if objNr := irtObjNr(pa.d); objNr > 0 {
+			af.replies[objNr] = append(af.replies[objNr], pa.objNr)
+		}
+	}
+
+	filter := len(annotTypes) > 0 || len(ids) > 0 || len(objNrs) > 0
+
+	var count int
+	for _, pa := range aa {
+		subtype := annotName(ctx, pa.d, "Subtype")
+		if !types.MemberOf(subtype, flattenableAnnotTypes) || irtObjNr(pa.d) > 0 {
+			// Replies go along with the annotation they refer to.
+			continue
+		}
+
+		if filter && !types.MemberOf(subtype, annotTypes) && !objNrs[pa.objNr] {
+			if id := annotString(ctx, pa.d, "NM"); id == "" || !types.MemberOf(id, ids) {
+				continue
+			}
+		}
+
+		ok, err := af.draw(pa.d)
+		if err != nil {
+			return 0, errors.Wrapf(err, "page %d obj#%d", pageNr, pa.objNr)
+		}
+		if !ok {
+			continue
+		}
+
+		af.remove(pa.objNr)
+		if ir := pa.d.IndirectRefEntry("Popup"); ir != nil {
+			af.removed[ir.ObjectNumber.Value()] = true
+		}
+		count++
+	}
+
+	if af.buf.Len() > 0 {
+		if err := appendPageContent(ctx, pageDict, af.buf.Bytes()); err != nil {
+			return 0, err
+		}
+	}
+
+	if len(af.removed) > 0 {
+		if _, err := RemoveAnnotationsFromPageDict(ctx, nil, nil, af.removed, pageDict, pageDictIndRef.ObjectNumber.Value(), pageNr, false); err != nil {
+			return 0, err
+		}
+	}
+
+	return count, nil
+}
+
+// FlattenAnnotations burns the appearances of markup annotations of selected pages into the page content
+// and removes the annotations along with their popups and replies.
+// Annotations may be filtered by id, type or object number.
+// Missing appearances are generated where supported, annotations without appearance are left untouched.
+// FlattenAnnotations returns the number of flattened annotations.
+func FlattenAnnotations(ctx *model.Context, selectedPages types.IntSet, idsAndTypes []string, objNrs []int) (int, error) {
+	var annotTypes, ids []string
+	for _, s := range idsAndTypes {
+		if _, ok := model.AnnotTypes[s]; ok {
+			annotTypes = append(annotTypes, s)
+			continue
+		}
+		ids = append(ids, s)
+	}
+
+	objNrSet := types.IntSet{}
+	for _, objNr := range objNrs {
+		objNrSet[objNr] = true
+	}
+
+	var count int
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		n, err := flattenPageAnnotations(ctx, pageNr, annotTypes, ids, objNrSet)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+
+	if count > 0 {
+		ctx.EnsureVersionForWriting()
+	}
+
+	return count, nil
+}
@@ -0,0 +1,463 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// AnnotationSpec describes an annotation to be added.
+// Points are given in user space, colors as "#RRGGBB", "r g b" or a color name.
+type AnnotationSpec struct {
+	Type        string           `json:"type"`                  // Text, Highlight, Underline, StrikeOut, Squiggly, Ink, Line, Polygon, PolyLine, FreeText, Square, Circle, FileAttachment, Stamp, RichMedia, 3D
+	Rect        []float64        `json:"rect,omitempty"`        // llx, lly, urx, ury; defaults to the bounding box of the annotation's points
+	Contents    string           `json:"contents,omitempty"`    // text to display or an alternate description
+	ID          string           `json:"id,omitempty"`          // annotation name
+	ModDate     string           `json:"modDate,omitempty"`     // date of last modification, eg. D:20250102150405+01'00'
+	Flags       *int             `json:"flags,omitempty"`       // annotation flags, defaults to print
+	Color       string           `json:"color,omitempty"`       // stroke color, background color for FreeText
+	FillColor   string           `json:"fillColor,omitempty"`   // interior color for Square, Circle and Polygon
+	Opacity     *float64         `json:"opacity,omitempty"`     // 0.0 <= opacity <= 1.0
+	Title       string           `json:"title,omitempty"`       // author
+	Subject     string           `json:"subject,omitempty"`     // subject
+	BorderWidth float64          `json:"borderWidth,omitempty"` // border or line width
+	Dashed      bool             `json:"dashed,omitempty"`      // dashed border or line
+	QuadPoints  []float64        `json:"quadPoints,omitempty"`  // 8 x n numbers for text markup annotations: ul, ur, ll, lr
+	Ink         [][]float64      `json:"ink,omitempty"`         // paths for Ink: x1 y1 x2 y2 ...
+	Vertices    []float64        `json:"vertices,omitempty"`    // Polygon and PolyLine: x1 y1 x2 y2 ...
+	Line        []float64        `json:"line,omitempty"`        // Line: x1 y1 x2 y2
+	Text        string           `json:"text,omitempty"`        // FreeText, defaults to contents
+	Font        string           `json:"font,omitempty"`        // FreeText core font, defaults to Helvetica
+	FontSize    int              `json:"fontSize,omitempty"`    // FreeText, defaults to 12
+	FontColor   string           `json:"fontColor,omitempty"`   // FreeText, defaults to black
+	Align       string           `json:"align,omitempty"`       // FreeText: left, center, right
+	File        string           `json:"file,omitempty"`        // FileAttachment, RichMedia, 3D: the file to embed, .mp4, .m4v, .mov, .mp3, .m4a or .aac for RichMedia, .u3d or .prc for 3D
+	FileContent []byte           `json:"fileContent,omitempty"` // FileAttachment, RichMedia, 3D: the content of file, base64 encoded, takes precedence over reading file
+	Desc        string           `json:"desc,omitempty"`        // FileAttachment: file description
+	Name        string           `json:"name,omitempty"`        // Stamp name like Approved or Draft, FileAttachment and Text icon
+	Open        bool             `json:"open,omitempty"`        // Text: initially open
+	State       string           `json:"state,omitempty"`       // Text reply: review state of the parent like Accepted, Rejected, Marked
+	StateModel  string           `json:"stateModel,omitempty"`  // Text reply: Review or Marked, derived from state if missing
+	Replies     []AnnotationSpec `json:"replies,omitempty"`     // Text annotations in reply to this annotation
+	Poster      string           `json:"poster,omitempty"`      // RichMedia, 3D: image displayed until activation, defaults to a placeholder
+	Activate    string           `json:"activate,omitempty"`    // RichMedia, 3D: click (default), pageOpen, pageVisible
+	Deactivate  string           `json:"deactivate,omitempty"`  // RichMedia, 3D: click (default), pageClose, pageInvisible
+	Windowed    bool             `json:"windowed,omitempty"`    // RichMedia: play in a floating window
+	Toolbar     bool             `json:"toolbar,omitempty"`     // RichMedia, 3D: show player controls or the 3D toolbar
+	Format      string           `json:"format,omitempty"`      // 3D: U3D or PRC, detected from the file content if missing
+	NavPane     bool             `json:"navPane,omitempty"`     // 3D: show the model tree
+	Views       []View3DSpec     `json:"views,omitempty"`       // 3D: predefined views, the first one is the default view
+}
+
+// View3DSpec describes a predefined view of 3D artwork.
+type View3DSpec struct {
+	Name          string    `json:"name"`                    // view name displayed by the viewer
+	Camera        []float64 `json:"camera,omitempty"`        // 12 numbers: camera to world matrix
+	CenterOfOrbit float64   `json:"centerOfOrbit,omitempty"` // distance from the camera to the center of orbit
+	Orthographic  bool      `json:"orthographic,omitempty"`  // orthographic instead of perspective projection
+	FOV           float64   `json:"fov,omitempty"`           // perspective field of view in degrees, defaults to 30
+	Background    string    `json:"background,omitempty"`    // background color
+	Lighting      string    `json:"lighting,omitempty"`      // Artwork, None, White, Day, Night, Hard, Primary, Blue, Red, Cube, CAD, Headlamp
+	RenderMode    string    `json:"renderMode,omitempty"`    // Solid, Wireframe, Transparent, Illustration, ...
+}
+
+// AnnotationsSpec maps page numbers to the annotations to be added.
+type AnnotationsSpec struct {
+	Annotations map[string][]AnnotationSpec `json:"annotations"`
+}
+
+func optionalColor(s string) (*color.SimpleColor, error) {
+	if s == "" {
+		return nil, nil
+	}
+	c, err := color.ParseColor(s)
+	if err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+// pointsRect returns the bounding box of the points pp enlarged by w.
+func pointsRect(pp []float64, w float64) *types.Rectangle {
+	if len(pp) < 2 {
+		return nil
+	}
+	r := types.NewRectangle(pp[0], pp[1], pp[0], pp[1])
+	for i := 2; i+1 < len(pp); i += 2 {
+		r.LL.X, r.UR.X = math.Min(r.LL.X, pp[i]), math.Max(r.UR.X, pp[i])
+		r.LL.Y, r.UR.Y = math.Min(r.LL.Y, pp[i+1]), math.Max(r.UR.Y, pp[i+1])
+	}
+	return types.NewRectangle(r.LL.X-w, r.LL.Y-w, r.UR.X+w, r.UR.Y+w)
+}
+
+func (spec AnnotationSpec) rect() (*types.Rectangle, error) {
+	if len(spec.Rect) > 0 {
+		if len(spec.Rect) != 4 {
+			return nil, errors.Errorf("pdfcpu: %s annotation: rect needs 4 numbers", spec.Type)
+		}
+		return types.NewRectangle(spec.Rect[0], spec.Rect[1], spec.Rect[2], spec.Rect[3]), nil
+	}
+
+	w := math.Max(1, spec.BorderWidth)
+	var pp []float64
+	switch {
+	case len(spec.QuadPoints) > 0:
+		pp, w = spec.QuadPoints, 0
+	case len(spec.Ink) > 0:
+		for _, p := range spec.Ink {
+			pp = append(pp, p...)
+		}
+	case len(spec.Vertices) > 0:
+		pp = spec.Vertices
+	case len(spec.Line) > 0:
+		pp = spec.Line
+	}
+
+	if r := pointsRect(pp, w); r != nil {
+		return r, nil
+	}
+	return nil, errors.Errorf("pdfcpu: %s annotation: missing rect", spec.Type)
+}
+
+func (spec AnnotationSpec) quadPoints(r types.Rectangle) (types.QuadPoints, error) {
+	var qp types.QuadPoints
+	if len(spec.QuadPoints) == 0 {
+		qp.AddQuadLiteral(*types.NewQuadLiteralForRect(&r))
+		return qp, nil
+	}
+	if len(spec.QuadPoints)%8 != 0 {
+		return nil, errors.Errorf("pdfcpu: %s annotation: quadPoints needs 8 x n numbers", spec.Type)
+	}
+	q := spec.QuadPoints
+	for i := 0; i < len(q); i += 8 {
+		qp.AddQuadLiteral(types.QuadLiteral{
+			P1: types.Point{X: q[i], Y: q[i+1]},
+			P2: types.Point{X: q[i+2], Y: q[i+3]},
+			P3: types.Point{X: q[i+4], Y: q[i+5]},
+			P4: types.Point{X: q[i+6], Y: q[i+7]},
+		})
+	}
+	return qp, nil
+}
+
+func (spec AnnotationSpec) points(pp []float64, min int, key string) (types.Array, error) {
+	if len(pp) < 2*min || len(pp)%2 != 0 {
+		return nil, errors.Errorf("pdfcpu: %s annotation: %s needs at least %d points", spec.Type, key, min)
+	}
+	return types.NewNumberArray(pp...), nil
+}
+
+func (spec AnnotationSpec) align() (types.HAlignment, error) {
+	switch strings.ToLower(spec.Align) {
+	case "", "l", "left":
+		return types.AlignLeft, nil
+	case "c", "center":
+		return types.AlignCenter, nil
+	case "r", "right":
+		return types.AlignRight, nil
+	}
+	return 0, errors.Errorf("pdfcpu: FreeText annotation: invalid align: %s", spec.Align)
+}
+
+// readFile returns the content of fileName resolved against dir.
+func readFile(dir, fileName string) ([]byte, error) {
+	if !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(dir, fileName)
+	}
+	return os.ReadFile(fileName)
+}
+
+// fileContent returns the content of the file to be embedded.
+func (spec AnnotationSpec) fileContent(dir string) ([]byte, error) {
+	if spec.File == "" {
+		return nil, errors.Errorf("pdfcpu: %s annotation: missing file", spec.Type)
+	}
+	if spec.FileContent != nil {
+		return spec.FileContent, nil
+	}
+	return readFile(dir, spec.File)
+}
+
+func (spec AnnotationSpec) conditions() (string, string, error) {
+	activations := map[string]string{"": "XA", "click": "XA", "pageopen": "PO", "pagevisible": "PV"}
+	deactivations := map[string]string{"": "XD", "click": "XD", "pageclose": "PC", "pageinvisible": "PI"}
+
+	a, ok := activations[strings.ToLower(spec.Activate)]
+	if !ok {
+		return "", "", errors.Errorf("pdfcpu: %s annotation: invalid activate: %s", spec.Type, spec.Activate)
+	}
+	d, ok := deactivations[strings.ToLower(spec.Deactivate)]
+	if !ok {
+		return "", "", errors.Errorf("pdfcpu: %s annotation: invalid deactivate: %s", spec.Type, spec.Deactivate)
+	}
+	return a, d, nil
+}
+
+func (spec AnnotationSpec) poster(dir string) (io.Reader, error) {
+	if spec.Poster == "" {
+		return nil, nil
+	}
+	bb, err := readFile(dir, spec.Poster)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(bb), nil
+}
+
+func (spec AnnotationSpec) views() ([]model.View3D, error) {
+	var vv []model.View3D
+	for _, v := range spec.Views {
+		bg, err := optionalColor(v.Background)
+		if err != nil {
+			return nil, err
+		}
+		vv = append(vv, model.View3D{
+			Name:          v.Name,
+			CameraToWorld: v.Camera,
+			CenterOfOrbit: v.CenterOfOrbit,
+			Orthographic:  v.Orthographic,
+			FOV:           v.FOV,
+			Background:    bg,
+			Lighting:      v.Lighting,
+			RenderMode:    v.RenderMode,
+		})
+	}
+	return vv, nil
+}
+
+// renderer returns an annotation renderer for spec.
+// Relative file names of file attachments are resolved against dir.
+func (spec AnnotationSpec) renderer(dir string) (model.AnnotationRenderer, error) {
+	r, err := spec.rect()
+	if err != nil {
+		return nil, err
+	}
+
+	col, err := optionalColor(spec.Color)
+	if err != nil {
+		return nil, err
+	}
+
+	fillCol, err := optionalColor(spec.FillColor)
+	if err != nil {
+		return nil, err
+	}
+
+	if spec.Opacity != nil && (*spec.Opacity < 0 || *spec.Opacity > 1) {
+		return nil, errors.Errorf("pdfcpu: %s annotation: opacity must be between 0.0 and 1.0", spec.Type)
+	}
+
+	f := model.AnnPrint
+	if spec.Flags != nil {
+		f = model.AnnotationFlags(*spec.Flags)
+	}
+
+	bs := model.BSSolid
+	if spec.Dashed {
+		bs = model.BSDashed
+	}
+
+	bw := spec.BorderWidth
+	if bw == 0 {
+		bw = 1
+	}
+
+	c, id, ca, title, subject := spec.Contents, spec.ID, spec.Opacity, spec.Title, spec.Subject
+
+	switch strings.ToLower(spec.Type) {
+
+	case "highlight", "underline", "strikeout", "squiggly":
+		qp, err := spec.quadPoints(*r)
+		if err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(spec.Type) {
+		case "highlight":
+			return model.NewHighlightAnnotation(*r, 0, c, id, spec.ModDate, f, col, 0, 0, 0, title, nil, ca, "", subject, qp), nil
+		case "underline":
+			return model.NewUnderlineAnnotation(*r, 0, c, id, spec.ModDate, f, col, 0, 0, 0, title, nil, ca, "", subject, qp), nil
+		case "strikeout":
+			return model.NewStrikeOutAnnotation(*r, 0, c, id, spec.ModDate, f, col, 0, 0, 0, title, nil, ca, "", subject, qp), nil
+		}
+		return model.NewSquigglyAnnotation(*r, 0, c, id, spec.ModDate, f, col, 0, 0, 0, title, nil, ca, "", subject, qp), nil
+
+	case "ink":
+		if len(spec.Ink) == 0 {
+			return nil, errors.New("pdfcpu: Ink annotation: missing ink")
+		}
+		var ink []model.InkPath
+		for _, p := range spec.Ink {
+			if _, err := spec.points(p, 1, "ink path"); err != nil {
+				return nil, err
+			}
+			ink = append(ink, model.InkPath(p))
+		}
+		return model.NewInkAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject, ink, bw, bs), nil
+
+	case "line":
+		if len(spec.Line) != 4 {
+			return nil, errors.New("pdfcpu: Line annotation: line needs 4 numbers")
+		}
+		p1, p2 := types.Point{X: spec.Line[0], Y: spec.Line[1]}, types.Point{X: spec.Line[2], Y: spec.Line[3]}
+		return model.NewLineAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject,
+			p1, p2, nil, nil, 0, 0, 0, nil, nil, false, false, 0, 0, fillCol, bw, bs), nil
+
+	case "polygon":
+		vv, err := spec.points(spec.Vertices, 3, "vertices")
+		if err != nil {
+			return nil, err
+		}
+		return model.NewPolygonAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject,
+			vv, nil, nil, nil, fillCol, bw, bs, false, 0), nil
+
+	case "polyline":
+		vv, err := spec.points(spec.Vertices, 2, "vertices")
+		if err != nil {
+			return nil, err
+		}
+		return model.NewPolyLineAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject,
+			vv, nil, nil, nil, fillCol, bw, bs, nil, nil), nil
+
+	case "square":
+		return model.NewSquareAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject, fillCol, 0, 0, 0, 0, bw, bs, false, 0), nil
+
+	case "circle":
+		return model.NewCircleAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject, fillCol, 0, 0, 0, 0, bw, bs, false, 0), nil
+
+	case "freetext":
+		fontCol, err := optionalColor(spec.FontColor)
+		if err != nil {
+			return nil, err
+		}
+		hAlign, err := spec.align()
+		if err != nil {
+			return nil, err
+		}
+		if spec.Text == "" && c == "" {
+			return nil, errors.New("pdfcpu: FreeText annotation: missing text")
+		}
+		return model.NewFreeTextAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject,
+			spec.Text, hAlign, spec.Font, spec.FontSize, fontCol, "", nil, nil, nil, 0, 0, 0, 0, spec.BorderWidth, bs, false, 0), nil
+
+	case "fileattachment":
+		bb, err := spec.fileContent(dir)
+		if err != nil {
+			return nil, err
+		}
+		return model.NewFileAttachmentAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject,
+			bytes.NewReader(bb), filepath.Base(spec.File), spec.Desc, spec.Name), nil
+
+	case "text":
+		ta := model.NewTextAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject, 0, 0, 0, spec.Open, spec.Name)
+		ta.State, ta.StateModel = spec.State, spec.StateModel
+		return ta, nil
+
+	case "stamp":
+		return model.NewStampAnnotation(*r, 0, c, id, spec.ModDate, f, col, title, nil, ca, "", subject, spec.Name), nil
+
+	case "richmedia":
+		bb, err := spec.fileContent(dir)
+		if err != nil {
+			return nil, err
+		}
+		activation, deactivation, err := spec.conditions()
+		if err != nil {
+			return nil, err
+		}
+		poster, err := spec.poster(dir)
+		if err != nil {
+			return nil, err
+		}
+		return model.NewRichMediaAnnotation(*r, c, id, spec.ModDate, f,
+			bytes.NewReader(bb), filepath.Base(spec.File), poster, activation, deactivation, spec.Windowed, spec.Toolbar), nil
+
+	case "3d":
+		bb, err := spec.fileContent(dir)
+		if err != nil {
+			return nil, err
+		}
+		activation, deactivation, err := spec.conditions()
+		if err != nil {
+			return nil, err
+		}
+		poster, err := spec.poster(dir)
+		if err != nil {
+			return nil, err
+		}
+		views, err := spec.views()
+		if err != nil {
+			return nil, err
+		}
+		return model.NewAnnotation3D(*r, c, id, spec.ModDate, f,
+			bytes.NewReader(bb), strings.ToUpper(spec.Format), views, poster, activation, deactivation, spec.Toolbar, spec.NavPane), nil
+	}
+
+	return nil, errors.Errorf("pdfcpu: unsupported annotation type: %s", spec.Type)
+}
+
+// ParseAnnotationsJSON parses a JSON annotations spec like:
+//
+//	{"annotations": {"1": [{"type": "Highlight", "quadPoints": [...], "color": "#FFFF00"}]}}
+//
+// and returns the annotation renderers by page number.
+// Relative file names of file attachments are resolved against dir.
+func ParseAnnotationsJSON(r io.Reader, dir string) (map[int][]model.AnnotationRenderer, error) {
+	var spec AnnotationsSpec
+	if err := json.NewDecoder(r).Decode(&spec); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid annotations JSON")
+	}
+
+	if len(spec.Annotations) == 0 {
+		return nil, errors.New("pdfcpu: annotations JSON: missing \"annotations\"")
+	}
+
+	keys := make([]string, 0, len(spec.Annotations))
+	for k := range spec.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	m := map[int][]model.AnnotationRenderer{}
+	for _, k := range keys {
+		pageNr, err := strconv.Atoi(k)
+		if err != nil || pageNr < 1 {
+			return nil, errors.Errorf("pdfcpu: annotations JSON: invalid page number: %s", k)
+		}
+		for i, as := range spec.Annotations[k] {
+			ar, err := as.renderer(dir)
+			if err != nil {
+				return nil, errors.Wrapf(err, "page %d annotation %d", pageNr, i+1)
+			}
+			m[pageNr] = append(m[pageNr], ar)
+		}
+	}
+
+	return m, nil
+}
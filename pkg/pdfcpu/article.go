@@ -0,0 +1,235 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+func bead(ctx *model.Context, ir types.IndirectRef) (*model.Bead, types.Dict, error) {
+	d, err := ctx.DereferenceDict(ir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d == nil {
+		return nil, nil, errors.Errorf("pdfcpu: article: obj#%d missing bead", ir.ObjectNumber.Value())
+	}
+
+	pageIndRef := d.IndirectRefEntry("P")
+	if pageIndRef == nil {
+		return nil, nil, errors.Errorf("pdfcpu: article: obj#%d missing bead page", ir.ObjectNumber.Value())
+	}
+
+	pageNr, err := ctx.PageNumber(pageIndRef.ObjectNumber.Value())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	a, err := ctx.DereferenceArray(d["R"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	r, err := ctx.RectForArray(a)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &model.Bead{PageNr: pageNr, Rect: r}, d, nil
+}
+
+func article(ctx *model.Context, o types.Object) (*model.Article, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, err
+	}
+
+	a := &model.Article{}
+
+	info, err := ctx.DereferenceDict(d["I"])
+	if err != nil {
+		return nil, err
+	}
+	if info != nil {
+		a.Title = annotString(ctx, info, "Title")
+		a.Author = annotString(ctx, info, "Author")
+		a.Subject = annotString(ctx, info, "Subject")
+		a.Keywords = annotString(ctx, info, "Keywords")
+	}
+
+	first := d.IndirectRefEntry("F")
+	if first == nil {
+		return a, nil
+	}
+
+	// Follow the circular chain of beads, guarding against corrupt chains.
+	visited := types.IntSet{}
+	for ir := *first; !visited[ir.ObjectNumber.Value()]; {
+		visited[ir.ObjectNumber.Value()] = true
+		b, bd, err := bead(ctx, ir)
+		if err != nil {
+			return nil, err
+		}
+		a.Beads = append(a.Beads, *b)
+		next := bd.IndirectRefEntry("N")
+		if next == nil {
+			break
+		}
+		ir = *next
+	}
+
+	return a, nil
+}
+
+// Articles returns the article threads of ctx.
+func Articles(ctx *model.Context) ([]model.Article, error) {
+	a, err := ctx.DereferenceArray(ctx.RootDict["Threads"])
+	if err != nil {
+		return nil, err
+	}
+
+	aa := []model.Article{}
+
+	for i, o := range a {
+		art, err := article(ctx, o)
+		if err != nil {
+			return nil, errors.Wrapf(err, "thread %d", i+1)
+		}
+		if art != nil {
+			aa = append(aa, *art)
+		}
+	}
+
+	return aa, nil
+}
+
+// ListArticles returns a formatted list of the article threads of ctx.
+func ListArticles(ctx *model.Context) ([]string, error) {
+	aa, err := Articles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(aa) == 0 {
+		return []string{"no articles available"}, nil
+	}
+
+	ss := []string{}
+	for i, a := range aa {
+		ss = append(ss, fmt.Sprintf("%d: %s", i+1, a))
+	}
+
+	return ss, nil
+}
+
+func appendThread(ctx *model.Context, threadIndRef types.IndirectRef) error {
+	if ir := ctx.RootDict.IndirectRefEntry("Threads"); ir != nil {
+		if entry, ok := ctx.FindTableEntryForIndRef(ir); ok && entry != nil {
+			a, err := ctx.DereferenceArray(*ir)
+			if err != nil {
+				return err
+			}
+			entry.Object = append(a, threadIndRef)
+			return nil
+		}
+	}
+
+	a, err := ctx.DereferenceArray(ctx.RootDict["Threads"])
+	if err != nil {
+		return err
+	}
+
+	// Threads needs to be an indirect reference.
+	ir, err := ctx.IndRefForNewObject(append(a, threadIndRef))
+	if err != nil {
+		return err
+	}
+
+	ctx.RootDict["Threads"] = *ir
+
+	return nil
+}
+
+// AddArticle adds an article thread chaining the beads of a in reading order.
+func AddArticle(ctx *model.Context, a model.Article) error {
+	if err := a.Validate(ctx.PageCount); err != nil {
+		return err
+	}
+
+	info, err := a.InfoDict()
+	if err != nil {
+		return err
+	}
+
+	d := types.Dict{"Type": types.Name("Thread")}
+	if len(info) > 0 {
+		d["I"] = info
+	}
+
+	threadIndRef, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	beads := make([]types.Dict, len(a.Beads))
+	beadIndRefs := make([]types.IndirectRef, len(a.Beads))
+
+	for i, b := range a.Beads {
+		pageDict, pageIndRef, _, err := ctx.PageDict(b.PageNr, false)
+		if err != nil {
+			return err
+		}
+
+		bd := types.Dict{
+			"Type": types.Name("Bead"),
+			"P":    *pageIndRef,
+			"R":    b.Rect.Array(),
+		}
+		if i == 0 {
+			bd["T"] = *threadIndRef
+		}
+
+		ir, err := ctx.IndRefForNewObject(bd)
+		if err != nil {
+			return err
+		}
+
+		// Record the bead in the page's list of beads.
+		arr, err := ctx.DereferenceArray(pageDict["B"])
+		if err != nil {
+			return err
+		}
+		pageDict["B"] = append(arr, *ir)
+
+		beads[i], beadIndRefs[i] = bd, *ir
+	}
+
+	// The beads form a circular doubly linked list.
+	n := len(beads)
+	for i, bd := range beads {
+		bd["N"] = beadIndRefs[(i+1)%n]
+		bd["V"] = beadIndRefs[(i+n-1)%n]
+	}
+
+	d["F"] = beadIndRefs[0]
+
+	return appendThread(ctx, *threadIndRef)
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// imageInkBox returns the region of the dark pixels of an image within the unit square.
+func imageInkBox(ctx *model.Context, sd *types.StreamDict) (*types.Rectangle, bool) {
+	w, h, ink, ok := imageInk(ctx, sd)
+	if !ok {
+		return nil, false
+	}
+
+	x0, y0, x1, y1 := w, h, -1, -1
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !ink(x, y) {
+				continue
+			}
+			x0, x1 = min(x0, x), max(x1, x)
+			y0, y1 = min(y0, y), max(y1, y)
+		}
+	}
+
+	if x1 < 0 {
+		// Blank image.
+		return nil, true
+	}
+
+	// The first sample row is painted at the top of the unit square.
+	fw, fh := float64(w), float64(h)
+	return types.NewRectangle(float64(x0)/fw, 1-float64(y1+1)/fh, float64(x1+1)/fw, 1-float64(y0)/fh), true
+}
+
+// markInkBox returns the region of m covered by ink or nil.
+func markInkBox(ctx *model.Context, m pageMark, inkBoxes map[int]*types.Rectangle) *types.Rectangle {
+	if area(m.rect) == 0 {
+		return nil
+	}
+
+	if m.ink == 0 {
+		// Glyphs shown by forms are not located individually, fall back to the form bbox.
+		if m.text {
+			r := m.rect
+			return &r
+		}
+		return nil
+	}
+
+	if m.image == nil {
+		r := m.rect
+		return &r
+	}
+
+	r, ok := inkBoxes[m.objNr]
+	if !ok {
+		var found bool
+		if r, found = imageInkBox(ctx, m.image); !found {
+			// Assume images with unknown samples are painted completely.
+			r = &unitSquare
+		}
+		inkBoxes[m.objNr] = r
+	}
+	if r == nil {
+		return nil
+	}
+
+	pp := []types.Point{}
+	for _, p := range []types.Point{r.LL, {X: r.UR.X, Y: r.LL.Y}, r.UR, {X: r.LL.X, Y: r.UR.Y}} {
+		pp = append(pp, m.ctm.Transform(p))
+	}
+	r1 := boundingBox(pp)
+	return &r1
+}
+
+// ContentBox returns the bounding box of the visible content of page pageNr in default user space
+// or nil for a blank page. Annotations are not taken into account.
+func ContentBox(ctx *model.Context, pageNr int) (*types.Rectangle, error) {
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	box := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		box = inhPAttrs.CropBox
+	}
+	if box == nil || area(*box) == 0 {
+		return nil, errors.Errorf("pdfcpu: ContentBox: missing page boundaries for page %d", pageNr)
+	}
+
+	marks, err := pageMarks(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	var bb *types.Rectangle
+	inkBoxes := map[int]*types.Rectangle{}
+
+	for _, m := range marks {
+		r := markInkBox(ctx, m, inkBoxes)
+		if r == nil {
+			continue
+		}
+		// Anything outside the crop box is invisible.
+		if r1 := intersection(*r, *box); area(r1) > 0 {
+			bb = model.CalcBoundingBoxForRects(bb, &r1)
+		}
+	}
+
+	return bb, nil
+}
+
+func autoCropPage(ctx *model.Context, pageNr int, ac *model.AutoCrop) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	bb, err := ContentBox(ctx, pageNr)
+	if err != nil {
+		return err
+	}
+	if bb == nil {
+		// Leave blank pages alone.
+		return nil
+	}
+
+	m := ac.Margin
+	r := intersection(*types.NewRectangle(bb.LL.X-m, bb.LL.Y-m, bb.UR.X+m, bb.UR.Y+m), *inhPAttrs.MediaBox)
+
+	if ac.MediaBox {
+		d["MediaBox"] = r.Array()
+		for _, k := range []string{"CropBox", "TrimBox", "BleedBox", "ArtBox"} {
+			d.Delete(k)
+		}
+		return nil
+	}
+
+	d["CropBox"] = r.Array()
+
+	return nil
+}
+
+// AutoCrop sets the crop box of selected pages to the bounding box of their content extended by a margin.
+// Blank pages remain unchanged.
+func AutoCrop(ctx *model.Context, selectedPages types.IntSet, ac *model.AutoCrop) error {
+	if ac == nil {
+		return errors.New("pdfcpu: AutoCrop: missing auto crop definition")
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		if err := autoCropPage(ctx, pageNr, ac); err != nil {
+			return errors.Wrapf(err, "page %d", pageNr)
+		}
+	}
+
+	return nil
+}
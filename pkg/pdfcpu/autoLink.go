@@ -0,0 +1,243 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+var (
+	autoLinkURL   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"'(){}\[\]]+`)
+	autoLinkEmail = regexp.MustCompile(`(?i)\b[a-z0-9._%+-]+@[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}\b`)
+)
+
+// glyphLine represents a line of text assembled from glyphs.
+type glyphLine struct {
+	s      string
+	glyphs []int // glyph index for each byte of s, -1 for inserted separators.
+}
+
+func (l *glyphLine) append(s string, i int) {
+	l.s += s
+	for range len(s) {
+		l.glyphs = append(l.glyphs, i)
+	}
+}
+
+// textLines assembles gg into lines of text inserting spaces for visual gaps.
+func textLines(gg []textGlyph) []glyphLine {
+	var (
+		ll []glyphLine
+		l  glyphLine
+	)
+
+	for i, g := range gg {
+		if i > 0 {
+			prev := gg[i-1]
+			fs := math.Max(1, math.Max(prev.fontSize, g.fontSize))
+			dy := math.Abs((g.rect.LL.Y + g.rect.UR.Y - prev.rect.LL.Y - prev.rect.UR.Y) / 2)
+			dx := g.rect.LL.X - prev.rect.UR.X
+			switch {
+			case dy > fs/2 || dx < -fs:
+				ll = append(ll, l)
+				l = glyphLine{}
+			case dx > fs/5:
+				l.append(" ", -1)
+			}
+		}
+		l.append(g.s, i)
+	}
+
+	if len(l.s) > 0 {
+		ll = append(ll, l)
+	}
+
+	return ll
+}
+
+// autoLink represents a URL or email address detected in page text.
+type autoLink struct {
+	uri  string
+	rect types.Rectangle
+}
+
+func (l glyphLine) rect(gg []textGlyph, beg, end int) *types.Rectangle {
+	var r *types.Rectangle
+	for _, i := range l.glyphs[beg:end] {
+		if i < 0 {
+			continue
+		}
+		gr := gg[i].rect
+		if r == nil {
+			r = types.NewRectangle(gr.LL.X, gr.LL.Y, gr.UR.X, gr.UR.Y)
+			continue
+		}
+		r.LL.X, r.LL.Y = math.Min(r.LL.X, gr.LL.X), math.Min(r.LL.Y, gr.LL.Y)
+		r.UR.X, r.UR.Y = math.Max(r.UR.X, gr.UR.X), math.Max(r.UR.Y, gr.UR.Y)
+	}
+	return r
+}
+
+func overlaps(beg, end int, ii [][]int) bool {
+	for _, i := range ii {
+		if beg < i[1] && i[0] < end {
+			return true
+		}
+	}
+	return false
+}
+
+// detectAutoLinks returns the URLs and email addresses found in gg.
+func detectAutoLinks(gg []textGlyph) []autoLink {
+	var links []autoLink
+
+	for _, l := range textLines(gg) {
+		urls := autoLinkURL.FindAllStringIndex(l.s, -1)
+		for _, ii := range urls {
+			s := strings.TrimRight(l.s[ii[0]:ii[1]], ".,;:!?")
+			ii[1] = ii[0] + len(s)
+			if strings.HasPrefix(strings.ToLower(s), "www.") {
+				s = "http://" + s
+			}
+			if r := l.rect(gg, ii[0], ii[1]); r != nil {
+				links = append(links, autoLink{uri: s, rect: *r})
+			}
+		}
+		for _, ii := range autoLinkEmail.FindAllStringIndex(l.s, -1) {
+			if overlaps(ii[0], ii[1], urls) {
+				continue
+			}
+			if r := l.rect(gg, ii[0], ii[1]); r != nil {
+				links = append(links, autoLink{uri: "mailto:" + l.s[ii[0]:ii[1]], rect: *r})
+			}
+		}
+	}
+
+	return links
+}
+
+// pageLinkRects returns the rectangles of the link annotations of pageDict.
+func pageLinkRects(ctx *model.Context, pageDict types.Dict) ([]types.Rectangle, error) {
+	annots, err := ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil || annots == nil {
+		return nil, err
+	}
+
+	var rr []types.Rectangle
+	for _, o := range annots {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil || d.Subtype() == nil || *d.Subtype() != "Link" {
+			continue
+		}
+		a := numberArray(ctx, d["Rect"])
+		if len(a) != 4 {
+			continue
+		}
+		rr = append(rr, *types.NewRectangle(math.Min(a[0], a[2]), math.Min(a[1], a[3]), math.Max(a[0], a[2]), math.Max(a[1], a[3])))
+	}
+
+	return rr, nil
+}
+
+// coveredByLink returns true if r mostly lies within any of rr.
+func coveredByLink(r types.Rectangle, rr []types.Rectangle) bool {
+	for _, r1 := range rr {
+		w := math.Min(r.UR.X, r1.UR.X) - math.Max(r.LL.X, r1.LL.X)
+		h := math.Min(r.UR.Y, r1.UR.Y) - math.Max(r.LL.Y, r1.LL.Y)
+		if w > 0 && h > 0 && w*h > r.Width()*r.Height()/2 {
+			return true
+		}
+	}
+	return false
+}
+
+func addAutoLinksToPage(ctx *model.Context, pageNr int) (int, error) {
+	gg, err := pageGlyphs(ctx, pageNr)
+	if err != nil || len(gg) == 0 {
+		return 0, err
+	}
+
+	links := detectAutoLinks(gg)
+	if len(links) == 0 {
+		return 0, nil
+	}
+
+	pageDictIndRef, err := ctx.PageDictIndRef(pageNr)
+	if err != nil {
+		return 0, err
+	}
+
+	pageDict, err := ctx.DereferenceDict(*pageDictIndRef)
+	if err != nil {
+		return 0, err
+	}
+
+	rr, err := pageLinkRects(ctx, pageDict)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for _, l := range links {
+		if coveredByLink(l.rect, rr) {
+			continue
+		}
+		ann := model.NewLinkAnnotation(l.rect, 0, "", "", "", 0, nil, nil, l.uri, nil, false, 0, model.BSSolid)
+		if _, _, err := AddAnnotation(ctx, pageDictIndRef, pageDict, pageNr, ann, false); err != nil {
+			return 0, err
+		}
+		rr = append(rr, l.rect)
+		count++
+	}
+
+	return count, nil
+}
+
+// AddAutoLinks creates link annotations for URLs and email addresses found in the text of selected pages.
+// Text already covered by a link annotation is skipped. Returns the number of links created.
+func AddAutoLinks(ctx *model.Context, selectedPages types.IntSet) (int, error) {
+	pageNrs := []int{}
+	for k, v := range selectedPages {
+		if v {
+			pageNrs = append(pageNrs, k)
+		}
+	}
+	sort.Ints(pageNrs)
+
+	count := 0
+	for _, pageNr := range pageNrs {
+		if pageNr > ctx.PageCount {
+			continue
+		}
+		n, err := addAutoLinksToPage(ctx, pageNr)
+		if err != nil {
+			return 0, err
+		}
+		count += n
+	}
+
+	return count, nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	// minOrientationGlyphs is the minimum number of glyphs needed for detecting the text orientation of a page.
+	minOrientationGlyphs = 20
+
+	// minOrientationShare is the minimum share of glyphs running in the dominant direction.
+	minOrientationShare = .75
+)
+
+// TextOrientation represents the detected text orientation of a page.
+type TextOrientation struct {
+	PageNr   int
+	Rotate   int     // The page rotation displaying the dominant text direction upright.
+	Current  int     // The current page rotation.
+	Share    float64 // Share of glyphs running in the dominant direction.
+	Glyphs   int     // Number of glyphs taken into account.
+	Detected bool    // True if there is enough text running in a dominant direction.
+}
+
+// NeedsRotation returns true if the page rotation needs to be fixed.
+func (to TextOrientation) NeedsRotation() bool {
+	return to.Detected && to.Rotate != to.Current
+}
+
+func (to TextOrientation) String() string {
+	if !to.Detected {
+		return fmt.Sprintf("page %d: undetected (%d glyphs)", to.PageNr, to.Glyphs)
+	}
+	return fmt.Sprintf("page %d: rotate %d (current %d, %.0f%% of %d glyphs)", to.PageNr, to.Rotate, to.Current, to.Share*100, to.Glyphs)
+}
+
+// normalizedRotation returns rot as one of 0, 90, 180, 270.
+func normalizedRotation(rot int) int {
+	return ((rot % 360) + 360) % 360
+}
+
+// dominantTextRotation returns the page rotation displaying the dominant baseline direction of gg upright,
+// the share of glyphs running in this direction and the number of glyphs taken into account.
+func dominantTextRotation(gg []textGlyph) (int, float64, int) {
+	var counts [4]int
+	var n int
+
+	for _, g := range gg {
+		if strings.TrimSpace(g.s) == "" {
+			continue
+		}
+		// Baselines running up get displayed upright by rotating the page by 90 degrees clockwise.
+		i := normalizedRotation(int(math.Round(g.angle/90))*90) / 90
+		counts[i]++
+		n++
+	}
+
+	if n == 0 {
+		return 0, 0, 0
+	}
+
+	i := 0
+	for j := 1; j < 4; j++ {
+		if counts[j] > counts[i] {
+			i = j
+		}
+	}
+
+	return i * 90, float64(counts[i]) / float64(n), n
+}
+
+// PageTextOrientation detects the orientation of page pageNr using the baseline direction of its text.
+func PageTextOrientation(ctx *model.Context, pageNr int) (*TextOrientation, error) {
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	to := &TextOrientation{PageNr: pageNr, Current: normalizedRotation(inhPAttrs.Rotate)}
+
+	gg, err := pageGlyphs(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	to.Rotate, to.Share, to.Glyphs = dominantTextRotation(gg)
+	to.Detected = to.Glyphs >= minOrientationGlyphs && to.Share >= minOrientationShare
+
+	return to, nil
+}
+
+// TextOrientations detects the text orientation of selected pages.
+func TextOrientations(ctx *model.Context, selectedPages types.IntSet) ([]TextOrientation, error) {
+	tt := []TextOrientation{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		to, err := PageTextOrientation(ctx, pageNr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "page %d", pageNr)
+		}
+		tt = append(tt, *to)
+	}
+
+	return tt, nil
+}
+
+// AutoRotate fixes the rotation of selected pages whose text is not displayed upright.
+// Pages without a dominant text direction, eg. scans without a text layer, remain unchanged.
+// AutoRotate returns the numbers of the rotated pages.
+func AutoRotate(ctx *model.Context, selectedPages types.IntSet) ([]int, error) {
+	tt, err := TextOrientations(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	pageNrs := []int{}
+
+	for _, to := range tt {
+		if !to.NeedsRotation() {
+			continue
+		}
+		if log.CLIEnabled() {
+			log.CLI.Printf("rotating %s\n", to)
+		}
+		if err := rotatePage(ctx.XRefTable, to.PageNr, to.Rotate-to.Current+360); err != nil {
+			return nil, err
+		}
+		pageNrs = append(pageNrs, to.PageNr)
+	}
+
+	return pageNrs, nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestDominantTextRotation(t *testing.T) {
+	resDict := types.Dict{
+		"Font": types.Dict{
+			"F1": types.Dict{"Type": types.Name("Font"), "Subtype": types.Name("Type1"), "BaseFont": types.Name("Helvetica")},
+		},
+	}
+
+	for _, tt := range []struct {
+		tm   string
+		want int
+	}{
+		{"1 0 0 1 100 700", 0},
+		{"0 1 -1 0 500 100", 90},   // Running up.
+		{"-1 0 0 -1 500 100", 180}, // Upside down.
+		{"0 -1 1 0 100 700", 270},  // Running down.
+		{"0.98 0.17 -0.17 0.98 100 700", 0},
+	} {
+		in := "BT /F1 12 Tf " + tt.tm + " Tm (Sideways) Tj 0 -14 Td (text) Tj ET " +
+			"BT /F1 8 Tf 1 0 0 1 20 20 Tm (1) Tj ET"
+
+		te := textExtractor{
+			ctx:       &model.Context{},
+			resDict:   resDict,
+			fonts:     map[string]*textFont{},
+			textState: textState{ctm: matrix.IdentMatrix, th: 1},
+			tm:        matrix.IdentMatrix,
+			tlm:       matrix.IdentMatrix,
+		}
+		te.process(parseContentOps([]byte(in)))
+
+		rot, share, n := dominantTextRotation(te.glyphs)
+		if n != 13 {
+			t.Fatalf("%s: got %d glyphs, want 13\n", tt.tm, n)
+		}
+		if rot != tt.want || share < .9 {
+			t.Fatalf("%s: got rotation %d (%.2f), want %d\n", tt.tm, rot, share, tt.want)
+		}
+	}
+
+	if rot, share, n := dominantTextRotation(nil); rot != 0 || share != 0 || n != 0 {
+		t.Fatalf("no glyphs: got %d %.2f %d\n", rot, share, n)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package barcode implements encoders for linear barcodes.
+package barcode
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Symbology is a linear barcode symbology.
+type Symbology int
+
+// The supported symbologies.
+const (
+	Code128 Symbology = iota
+	EAN13
+	Code39
+)
+
+// ParseSymbology parses a barcode symbology: code128, ean13 or code39.
+func ParseSymbology(s string) (Symbology, error) {
+	switch strings.ToLower(strings.ReplaceAll(s, "-", "")) {
+	case "code128", "128":
+		return Code128, nil
+	case "ean13", "ean":
+		return EAN13, nil
+	case "code39", "39":
+		return Code39, nil
+	}
+	return Code128, errors.Errorf("pdfcpu: unsupported barcode symbology: %s, please provide one of: code128, ean13, code39", s)
+}
+
+func (s Symbology) String() string {
+	return [...]string{"code128", "ean13", "code39"}[s]
+}
+
+// Code is an encoded linear barcode.
+type Code struct {
+	Symbology Symbology
+	Text      string // Encoded text including computed check digits if part of the human readable text.
+	Modules   []bool // Module sequence including quiet zones, true for bars.
+}
+
+// Encode encodes s using symbology sym.
+func Encode(s string, sym Symbology) (*Code, error) {
+	if s == "" {
+		return nil, errors.New("pdfcpu: missing barcode payload")
+	}
+
+	var (
+		c   *Code
+		err error
+	)
+
+	switch sym {
+	case EAN13:
+		c, err = encodeEAN13(s)
+	case Code39:
+		c, err = encodeCode39(s)
+	default:
+		c, err = encodeCode128(s)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	c.Symbology = sym
+
+	return c, nil
+}
+
+// moduleBuffer is a sequence of modules.
+type moduleBuffer []bool
+
+// appendWidths appends alternating bars and spaces starting with a bar.
+// Each width is given as a digit denoting the number of modules.
+func (mb *moduleBuffer) appendWidths(ww string) {
+	bar := true
+	for _, w := range ww {
+		for i := 0; i < int(w-'0'); i++ {
+			*mb = append(*mb, bar)
+		}
+		bar = !bar
+	}
+}
+
+// appendPattern appends the modules of a pattern like "0101".
+func (mb *moduleBuffer) appendPattern(p string) {
+	for _, c := range p {
+		*mb = append(*mb, c == '1')
+	}
+}
+
+func (mb *moduleBuffer) appendQuietZone(n int) {
+	for i := 0; i < n; i++ {
+		*mb = append(*mb, false)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package barcode
+
+import (
+	"math/bits"
+	"reflect"
+	"testing"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	for i, p := range code128Patterns {
+		want := 11
+		if i == code128Stop {
+			want = 13
+		}
+		sum := 0
+		for _, w := range p {
+			sum += int(w - '0')
+		}
+		if sum != want {
+			t.Errorf("code128 pattern %d: got %d modules, want %d", i, sum, want)
+		}
+	}
+}
+
+func TestCode128Values(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want []int
+	}{
+		{"PJJ123C", []int{code128StartB, 48, 42, 42, 17, 18, 19, 35, 55, code128Stop}},
+		{"1234", []int{code128StartC, 12, 34, 82, code128Stop}},
+		{"12345", []int{code128StartC, 12, 34, code128CodeB, 21, 54, code128Stop}},
+		{"AB123456", []int{code128StartB, 33, 34, code128CodeC, 12, 34, 56, 26, code128Stop}},
+		{"AB12345", []int{code128StartB, 33, 34, 17, code128CodeC, 23, 45, 7, code128Stop}},
+	} {
+		got, err := code128Values(tt.s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.s, got, tt.want)
+		}
+	}
+
+	if _, err := code128Values("tab\t"); err == nil {
+		t.Error("expected error for control character")
+	}
+}
+
+func TestCode39Patterns(t *testing.T) {
+	for i, p := range append(code39Patterns, code39StartStop) {
+		if bits.OnesCount(uint(p)) != 3 {
+			t.Errorf("code39 pattern %d: expected 3 wide elements", i)
+		}
+	}
+
+	c, err := Encode("pdfcpu-39", Code39)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Each character consists of 6 narrow and 3 wide elements plus a gap.
+	want := 20 + (9+2)*(6+3*code39Wide+1) - 1
+	if len(c.Modules) != want || c.Text != "PDFCPU-39" {
+		t.Errorf("code39: got %d modules, want %d", len(c.Modules), want)
+	}
+
+	if _, err := Encode("a_b", Code39); err == nil {
+		t.Error("expected error for unsupported character")
+	}
+}
+
+func TestEAN13(t *testing.T) {
+	if cd := EANCheckDigit("400638133393"); cd != 1 {
+		t.Errorf("check digit: got %d, want 1", cd)
+	}
+
+	for _, s := range []string{"400638133393", "4006381333931"} {
+		c, err := Encode(s, EAN13)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Text != "4006381333931" || len(c.Modules) != 11+95+7 {
+			t.Errorf("%s: got %s with %d modules", s, c.Text, len(c.Modules))
+		}
+	}
+
+	for _, s := range []string{"4006381333932", "40063813339", "40063813339X"} {
+		if _, err := Encode(s, EAN13); err == nil {
+			t.Errorf("%s: expected error", s)
+		}
+	}
+}
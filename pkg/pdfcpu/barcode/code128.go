@@ -0,0 +1,131 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package barcode
+
+import (
+	"github.com/pkg/errors"
+)
+
+const (
+	code128StartA = 103
+	code128StartB = 104
+	code128StartC = 105
+	code128CodeB  = 100
+	code128CodeC  = 99
+	code128Stop   = 106
+)
+
+// Bar and space widths of all Code 128 symbol values.
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+func isDigit(b byte) bool {
+	return b >= '0' && b <= '9'
+}
+
+// digitRun returns the number of consecutive digits in s starting at i.
+func digitRun(s string, i int) int {
+	n := 0
+	for i+n < len(s) && isDigit(s[i+n]) {
+		n++
+	}
+	return n
+}
+
+// code128Values returns the symbol values for s using code set B
+// and switching to code set C for longer digit sequences.
+func code128Values(s string) ([]int, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] < 32 || s[i] > 126 {
+			return nil, errors.Errorf("pdfcpu: code128: unsupported character: %q", s[i])
+		}
+	}
+
+	vv := []int{}
+	codeC := false
+
+	// Start with code set C for strings starting with at least 4 digits or consisting of an even number of digits.
+	if n := digitRun(s, 0); n >= 4 || n == len(s) && n%2 == 0 {
+		vv = append(vv, code128StartC)
+		codeC = true
+	} else {
+		vv = append(vv, code128StartB)
+	}
+
+	for i := 0; i < len(s); {
+		n := digitRun(s, i)
+
+		if codeC {
+			if n >= 2 {
+				vv = append(vv, int(s[i]-'0')*10+int(s[i+1]-'0'))
+				i += 2
+				continue
+			}
+			vv = append(vv, code128CodeB)
+			codeC = false
+		}
+
+		// Switch to code set C for at least 6 digits or at least 4 trailing digits.
+		// An odd number of digits starts with a single digit in code set B.
+		if n >= 6 || n >= 4 && i+n == len(s) {
+			if n%2 == 0 {
+				vv = append(vv, code128CodeC)
+				codeC = true
+				continue
+			}
+		}
+
+		vv = append(vv, int(s[i])-32)
+		i++
+	}
+
+	// Checksum
+	sum := vv[0]
+	for i, v := range vv[1:] {
+		sum += (i + 1) * v
+	}
+	vv = append(vv, sum%103, code128Stop)
+
+	return vv, nil
+}
+
+func encodeCode128(s string) (*Code, error) {
+	vv, err := code128Values(s)
+	if err != nil {
+		return nil, err
+	}
+
+	var mb moduleBuffer
+	mb.appendQuietZone(10)
+	for _, v := range vv {
+		mb.appendWidths(code128Patterns[v])
+	}
+	mb.appendQuietZone(10)
+
+	return &Code{Text: s, Modules: mb}, nil
+}
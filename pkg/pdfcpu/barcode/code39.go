@@ -0,0 +1,74 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package barcode
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const code39Alphabet = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ-. $/+%"
+
+// Code 39 patterns: 9 elements starting with a bar, a set bit marks a wide element.
+var code39Patterns = []int{
+	0x034, 0x121, 0x061, 0x160, 0x031, 0x130, 0x070, 0x025, 0x124, 0x064,
+	0x109, 0x049, 0x148, 0x019, 0x118, 0x058, 0x00D, 0x10C, 0x04C, 0x01C,
+	0x103, 0x043, 0x142, 0x013, 0x112, 0x052, 0x007, 0x106, 0x046, 0x016,
+	0x181, 0x0C1, 0x1C0, 0x091, 0x190, 0x0D0, 0x085, 0x184, 0x0C4, 0x0A8,
+	0x0A2, 0x08A, 0x02A,
+}
+
+const code39StartStop = 0x094
+
+// code39Wide is the width of a wide element in modules.
+const code39Wide = 3
+
+func appendCode39Pattern(mb *moduleBuffer, p int) {
+	bb := make([]byte, 9)
+	for i := range bb {
+		bb[i] = '1'
+		if p&(1<<(8-i)) != 0 {
+			bb[i] = '0' + code39Wide
+		}
+	}
+	mb.appendWidths(string(bb))
+}
+
+func encodeCode39(s string) (*Code, error) {
+	s = strings.ToUpper(s)
+
+	var mb moduleBuffer
+	mb.appendQuietZone(10)
+	appendCode39Pattern(&mb, code39StartStop)
+
+	for _, r := range s {
+		i := strings.IndexRune(code39Alphabet, r)
+		if i < 0 {
+			return nil, errors.Errorf("pdfcpu: code39: unsupported character: %q", r)
+		}
+		// Narrow inter character gap
+		mb = append(mb, false)
+		appendCode39Pattern(&mb, code39Patterns[i])
+	}
+
+	mb = append(mb, false)
+	appendCode39Pattern(&mb, code39StartStop)
+	mb.appendQuietZone(10)
+
+	return &Code{Text: s, Modules: mb}, nil
+}
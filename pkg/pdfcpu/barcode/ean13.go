@@ -0,0 +1,106 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package barcode
+
+import (
+	"github.com/pkg/errors"
+)
+
+// Left hand odd parity digit patterns.
+var eanLPatterns = [10]string{
+	"0001101", "0011001", "0010011", "0111101", "0100011",
+	"0110001", "0101111", "0111011", "0110111", "0001011",
+}
+
+// Parity of the left hand digits encoding the first digit, G marks even parity.
+var eanParities = [10]string{
+	"LLLLLL", "LLGLGG", "LLGGLG", "LLGGGL", "LGLLGG",
+	"LGGLLG", "LGGGLL", "LGLGLG", "LGLGGL", "LGGLGL",
+}
+
+func complement(p string) string {
+	bb := []byte(p)
+	for i, b := range bb {
+		bb[i] = '0' + '1' - b
+	}
+	return string(bb)
+}
+
+func reverse(p string) string {
+	bb := []byte(p)
+	for i, j := 0, len(bb)-1; i < j; i, j = i+1, j-1 {
+		bb[i], bb[j] = bb[j], bb[i]
+	}
+	return string(bb)
+}
+
+// EANCheckDigit returns the check digit for the 12 digits of an EAN-13 code.
+func EANCheckDigit(s string) int {
+	sum := 0
+	for i := 0; i < 12; i++ {
+		d := int(s[i] - '0')
+		if i%2 == 1 {
+			d *= 3
+		}
+		sum += d
+	}
+	return (10 - sum%10) % 10
+}
+
+func encodeEAN13(s string) (*Code, error) {
+	if len(s) != 12 && len(s) != 13 {
+		return nil, errors.Errorf("pdfcpu: ean13: expected 12 or 13 digits: %s", s)
+	}
+	for i := 0; i < len(s); i++ {
+		if !isDigit(s[i]) {
+			return nil, errors.Errorf("pdfcpu: ean13: expected 12 or 13 digits: %s", s)
+		}
+	}
+
+	cd := EANCheckDigit(s)
+	if len(s) == 13 && int(s[12]-'0') != cd {
+		return nil, errors.Errorf("pdfcpu: ean13: invalid check digit: %s", s)
+	}
+	if len(s) == 12 {
+		s += string(rune('0' + cd))
+	}
+
+	parity := eanParities[s[0]-'0']
+
+	var mb moduleBuffer
+	mb.appendQuietZone(11)
+	mb.appendPattern("101")
+
+	for i := 1; i <= 6; i++ {
+		p := eanLPatterns[s[i]-'0']
+		if parity[i-1] == 'G' {
+			p = reverse(complement(p))
+		}
+		mb.appendPattern(p)
+	}
+
+	mb.appendPattern("01010")
+
+	for i := 7; i <= 12; i++ {
+		mb.appendPattern(complement(eanLPatterns[s[i]-'0']))
+	}
+
+	mb.appendPattern("101")
+	mb.appendQuietZone(7)
+
+	return &Code{Text: s, Modules: mb}, nil
+}
@@ -0,0 +1,228 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/csv"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	batesFontSize = 10
+	batesOffset   = 20.
+)
+
+type batesParamMap map[string]func(string, *model.Bates) error
+
+var batParamMap = batesParamMap{
+	"prefix": parseBatesPrefix,
+	"suffix": parseBatesSuffix,
+	"start":  parseBatesStart,
+	"digits": parseBatesDigits,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into b.
+func (m batesParamMap) Handle(paramPrefix, paramValueStr string, b *model.Bates) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, b)
+}
+
+func parseBatesPrefix(s string, b *model.Bates) error {
+	b.Prefix = s
+	return nil
+}
+
+func parseBatesSuffix(s string, b *model.Bates) error {
+	b.Suffix = s
+	return nil
+}
+
+func parseBatesStart(s string, b *model.Bates) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return errors.Errorf("pdfcpu: Bates start number must be an integer >= 0: %s", s)
+	}
+	b.Start, b.Next = i, i
+	return nil
+}
+
+func parseBatesDigits(s string, b *model.Bates) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 1 || i > 20 {
+		return errors.Errorf("pdfcpu: Bates digits must be an integer: 1 <= i <= 20: %s", s)
+	}
+	b.Digits = i
+	return nil
+}
+
+// ParseBatesConfig parses a Bates command string into an internal structure.
+// desc is a stamp description configuring position, font etc. of the Bates numbers.
+func ParseBatesConfig(s, desc string, conf *model.Configuration) (*model.Bates, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	b := model.DefaultBatesConfig()
+	b.InpUnit = conf.Unit
+	b.Desc = desc
+
+	if s != "" {
+		for _, s := range strings.Split(s, ",") {
+			ss := strings.Split(s, ":")
+			if len(ss) != 2 {
+				return nil, errors.New("pdfcpu: Invalid Bates configuration string. Please consult pdfcpu help bates")
+			}
+			paramPrefix := strings.TrimSpace(ss[0])
+			paramValueStr := strings.TrimSpace(ss[1])
+			if err := batParamMap.Handle(paramPrefix, paramValueStr, b); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Validate the stamp description.
+	if _, err := batesWatermark(b.Number(b.Start), b); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// batesWatermark returns a text stamp rendering the Bates number s.
+// Bates numbers default to 10 point black Helvetica at the lower right corner.
+func batesWatermark(s string, b *model.Bates) (*model.Watermark, error) {
+	wm := model.DefaultWatermarkConfig()
+	wm.OnTop = true
+	wm.InpUnit = b.InpUnit
+	wm.FontSize = batesFontSize
+	wm.Scale = 1
+	wm.ScaleAbs = true
+	wm.Diagonal = model.NoDiagonal
+	wm.Pos = types.BottomRight
+	wm.Dx, wm.Dy = math.NaN(), math.NaN()
+	wm.Color = color.Black
+	wm.StrokeColor = color.Black
+	wm.FillColor = color.Black
+
+	if err := applyWatermarkDetails(model.WMText, s, b.Desc, wm); err != nil {
+		return nil, err
+	}
+
+	if math.IsNaN(wm.Dx) {
+		wm.Dx, wm.Dy = batesAnchorOffset(wm.Pos)
+	}
+
+	return wm, nil
+}
+
+// batesAnchorOffset returns the default offset moving a Bates number from anchor a towards the page center.
+func batesAnchorOffset(a types.Anchor) (float64, float64) {
+	var dx, dy float64
+
+	switch a {
+	case types.TopLeft, types.Left, types.BottomLeft:
+		dx = batesOffset
+	case types.TopRight, types.Right, types.BottomRight:
+		dx = -batesOffset
+	}
+
+	switch a {
+	case types.BottomLeft, types.BottomCenter, types.BottomRight:
+		dy = batesOffset
+	case types.TopLeft, types.TopCenter, types.TopRight:
+		dy = -batesOffset
+	}
+
+	return dx, dy
+}
+
+// AddBatesNumbers stamps the selected pages of ctx with consecutive Bates numbers starting with b.Next.
+// On return b.Next holds the Bates number to be assigned to the next page processed.
+// The returned entries map each stamped page of fileName to its Bates number.
+func AddBatesNumbers(ctx *model.Context, selectedPages types.IntSet, b *model.Bates, fileName string) ([]model.BatesEntry, error) {
+	pageNrs := sortSelectedPages(selectedPages)
+	if len(pageNrs) == 0 {
+		return nil, errors.New("pdfcpu: Bates: no pages selected")
+	}
+
+	m := map[int]*model.Watermark{}
+	ee := []model.BatesEntry{}
+
+	next := b.Next
+	for _, pageNr := range pageNrs {
+		s := b.Number(next)
+		wm, err := batesWatermark(s, b)
+		if err != nil {
+			return nil, err
+		}
+		m[pageNr] = wm
+		ee = append(ee, model.BatesEntry{FileName: fileName, PageNr: pageNr, Number: s})
+		next++
+	}
+
+	if err := AddWatermarksMap(ctx, m); err != nil {
+		return nil, err
+	}
+
+	b.Next = next
+
+	return ee, nil
+}
+
+// WriteBatesManifest writes ee as CSV with the columns file, page and bates to w.
+func WriteBatesManifest(w io.Writer, ee []model.BatesEntry) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{"file", "page", "bates"}); err != nil {
+		return err
+	}
+
+	for _, e := range ee {
+		if err := cw.Write([]string{e.FileName, strconv.Itoa(e.PageNr), e.Number}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+
+	return cw.Error()
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// smearStrip is the width of the page edge strip in points being stretched across the bleed.
+const smearStrip = 1.
+
+// ParseBleedConfig parses a Bleed command string into an internal structure.
+// "width:3, mode:mirror"
+func ParseBleedConfig(s string, u types.DisplayUnit) (*model.Bleed, error) {
+
+	if s == "" {
+		return nil, errors.New("pdfcpu: missing bleed configuration string")
+	}
+
+	b := &model.Bleed{Unit: u}
+
+	for _, s := range strings.Split(s, ",") {
+
+		ss1 := strings.Split(s, ":")
+		if len(ss1) != 2 {
+			return nil, errors.New("pdfcpu: Invalid bleed configuration string. Please consult pdfcpu help bleed")
+		}
+
+		paramPrefix := strings.TrimSpace(ss1[0])
+		paramValueStr := strings.TrimSpace(ss1[1])
+
+		if err := model.BleedParamMap.Handle(paramPrefix, paramValueStr, b); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := b.Validate(); err != nil {
+		return nil, err
+	}
+
+	return b, nil
+}
+
+// bleedTile returns the bleed region next to the trim box r in direction (dx,dy) within the media box mb
+// and the transformation filling this region with the page content.
+func bleedTile(r, mb *types.Rectangle, dx, dy int, mode model.BleedMode) (*types.Rectangle, [6]float64) {
+	w := r.LL.X - mb.LL.X
+
+	span := func(d int, lo, hi, mlo, mhi float64) (float64, float64, float64, float64) {
+		switch d {
+		case -1:
+			if mode == model.BleedSmear {
+				sc := w / smearStrip
+				return mlo, lo, sc, mlo - lo*sc
+			}
+			return mlo, lo, -1, 2 * lo
+		case 1:
+			if mode == model.BleedSmear {
+				sc := w / smearStrip
+				return hi, mhi, sc, hi - (hi-smearStrip)*sc
+			}
+			return hi, mhi, -1, 2 * hi
+		}
+		return lo, hi, 1, 0
+	}
+
+	x0, x1, a, e := span(dx, r.LL.X, r.UR.X, mb.LL.X, mb.UR.X)
+	y0, y1, d, f := span(dy, r.LL.Y, r.UR.Y, mb.LL.Y, mb.UR.Y)
+
+	return types.NewRectangle(x0, y0, x1, y1), [6]float64{a, 0, 0, d, e, f}
+}
+
+func bleedContent(w io.Writer, r, mb *types.Rectangle, b *model.Bleed, formResID string) {
+	if b.Mode == model.BleedColor {
+		c := b.Color
+		fmt.Fprintf(w, "q %.3f %.3f %.3f rg %.2f %.2f %.2f %.2f re %.2f %.2f %.2f %.2f re f* Q ",
+			c.R, c.G, c.B,
+			mb.LL.X, mb.LL.Y, mb.Width(), mb.Height(),
+			r.LL.X, r.LL.Y, r.Width(), r.Height())
+	} else {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if dx == 0 && dy == 0 {
+					continue
+				}
+				t, m := bleedTile(r, mb, dx, dy, b.Mode)
+				fmt.Fprintf(w, "q %.2f %.2f %.2f %.2f re W n %.5f %.5f %.5f %.5f %.5f %.5f cm /%s Do Q ",
+					t.LL.X, t.LL.Y, t.Width(), t.Height(), m[0], m[1], m[2], m[3], m[4], m[5], formResID)
+			}
+		}
+	}
+	fmt.Fprintf(w, "q /%s Do Q", formResID)
+}
+
+func createBleedForm(ctx *model.Context, bb []byte, r *types.Rectangle, resDict types.Dict) (*types.IndirectRef, error) {
+	sd := types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":    types.Name("XObject"),
+				"Subtype": types.Name("Form"),
+				"BBox":    r.Array(),
+				"Matrix":  types.NewIntegerArray(1, 0, 0, 1, 0, 0),
+			},
+		),
+		Content:        bb,
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+
+	if resDict != nil {
+		sd.Insert("Resources", resDict)
+	}
+
+	sd.InsertName("Filter", filter.Flate)
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return ctx.IndRefForNewObject(sd)
+}
+
+func bleedPage(ctx *model.Context, pageNr int, b *model.Bleed) error {
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	// The visible region of the page becomes the trim box.
+	r := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		r = inhPAttrs.CropBox
+	}
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil && err != model.ErrNoContent {
+		return err
+	}
+
+	formIndRef, err := createBleedForm(ctx, bb, r, inhPAttrs.Resources)
+	if err != nil {
+		return err
+	}
+
+	mb := types.NewRectangle(r.LL.X-b.Width, r.LL.Y-b.Width, r.UR.X+b.Width, r.UR.Y+b.Width)
+
+	formResID := "Fm0"
+
+	var buf bytes.Buffer
+	bleedContent(&buf, r, mb, b, formResID)
+
+	sd, _ := ctx.NewStreamDictForBuf(buf.Bytes())
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	d["Contents"] = *ir
+	d["Resources"] = types.Dict(
+		map[string]types.Object{
+			"XObject": types.Dict(map[string]types.Object{formResID: *formIndRef}),
+		},
+	)
+
+	d.Update("MediaBox", mb.Array())
+	d.Update("BleedBox", mb.Array())
+	d.Update("TrimBox", r.Array())
+	d.Delete("CropBox")
+
+	return nil
+}
+
+// Bleed enlarges the media box of selected pages by a bleed filled according to b.
+// The former crop box becomes the trim box.
+func Bleed(ctx *model.Context, selectedPages types.IntSet, b *model.Bleed) error {
+	if b == nil {
+		return errors.New("pdfcpu: Bleed: missing bleed configuration")
+	}
+
+	ctx.LogDebug("Bleed", "bleed", b)
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		if err := bleedPage(ctx, pageNr, b); err != nil {
+			return errors.Wrapf(err, "page %d", pageNr)
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
@@ -387,6 +387,17 @@ func nupPerfectBound(positionNumber int, inputPageCount int, pageNumbers []int,
 	return getPageNumber(pageNumbers, p-1), rotate // p is one-indexed and we want zero-indexed
 }
 
+// rtlPageNumbers returns pageNumbers padded with blank pages to pageCount in reverse order.
+// A right-to-left booklet is a left-to-right booklet of the reversed page sequence turned over:
+// the binding edge moves to the right and the trailing blank pages stay at the end.
+func rtlPageNumbers(pageNumbers []int, pageCount int) []int {
+	pp := make([]int, pageCount)
+	for i, p := range pageNumbers {
+		pp[pageCount-1-i] = p
+	}
+	return pp
+}
+
 func GetBookletOrdering(pages types.IntSet, nup *model.NUp) []model.BookletPage {
 	pageNumbers := sortSelectedPages(pages)
 	pageCount := len(pageNumbers)
@@ -400,6 +411,10 @@ func GetBookletOrdering(pages types.IntSet, nup *model.NUp) []model.BookletPage
 		pageCount += sheetPageCount - pageCount%sheetPageCount
 	}
 
+	if nup.BookletRTL {
+		pageNumbers = rtlPageNumbers(pageNumbers, pageCount)
+	}
+
 	if nup.MultiFolio {
 		bookletPages := make([]model.BookletPage, 0)
 		// folioSize is the number of sheets - each "folio" has two sides and two pages per side
@@ -31,6 +31,7 @@ type pageOrderResults struct {
 	binding            string
 	useSignatures      bool
 	nPagesPerSignature int
+	rtl                bool
 }
 
 var bookletTestCases = []pageOrderResults{
@@ -68,6 +69,57 @@ var bookletTestCases = []pageOrderResults{
 		bookletType: "booklet",
 		binding:     "long",
 	},
+	{
+		id:        "2up rtl",
+		nup:       2,
+		pageCount: 16,
+		expectedPageOrder: []int{
+			1, 16,
+			2, 15,
+			3, 14,
+			4, 13,
+			5, 12,
+			6, 11,
+			7, 10,
+			8, 9,
+		},
+		papersize:   "A6",
+		bookletType: "booklet",
+		binding:     "long",
+		rtl:         true,
+	},
+	{
+		id:        "2up rtl with trailing blank pages",
+		nup:       2,
+		pageCount: 10,
+		expectedPageOrder: []int{
+			1, 0,
+			2, 0,
+			3, 10,
+			4, 9,
+			5, 8,
+			6, 7,
+		},
+		papersize:   "A6",
+		bookletType: "booklet",
+		binding:     "long",
+		rtl:         true,
+	},
+	{
+		id:        "booklet portrait long edge rtl",
+		nup:       4,
+		pageCount: 16,
+		expectedPageOrder: []int{
+			1, 16, 14, 3,
+			15, 2, 4, 13,
+			5, 12, 10, 7,
+			11, 6, 8, 9,
+		},
+		papersize:   "A6",
+		bookletType: "booklet",
+		binding:     "long",
+		rtl:         true,
+	},
 	// basic booklet sidefold test cases
 	{
 		id:        "booklet portrait long edge",
@@ -350,6 +402,9 @@ func TestBookletPageOrder(t *testing.T) {
 			if test.useSignatures {
 				desc += fmt.Sprintf(", multifolio:on, foliosize:%d", test.nPagesPerSignature/4)
 			}
+			if test.rtl {
+				desc += ", rtl:on"
+			}
 			nup, err := PDFBookletConfig(test.nup, desc, nil)
 			if err != nil {
 				tt.Fatal(err)
@@ -24,11 +24,11 @@ import (
 	"strings"
 	"time"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
 )
 
 var (
@@ -52,19 +52,107 @@ type Header struct {
 
 // Bookmark represents an outline item tree.
 type Bookmark struct {
-	Title    string             `json:"title"`
-	PageFrom int                `json:"page"`
-	PageThru int                `json:"-"` // for extraction only; >= pageFrom and reaches until before pageFrom of the next bookmark.
-	Bold     bool               `json:"bold,omitempty"`
-	Italic   bool               `json:"italic,omitempty"`
-	Color    *color.SimpleColor `json:"color,omitempty"`
-	Kids     []Bookmark         `json:"kids,omitempty"`
-	Parent   *Bookmark          `json:"-"`
+	Title    string             `json:"title" yaml:"title"`
+	PageFrom int                `json:"page" yaml:"page"`
+	PageThru int                `json:"-" yaml:"-"` // for extraction only; >= pageFrom and reaches until before pageFrom of the next bookmark.
+	Dest     *BookmarkDest      `json:"destination,omitempty" yaml:"destination,omitempty"`
+	Bold     bool               `json:"bold,omitempty" yaml:"bold,omitempty"`
+	Italic   bool               `json:"italic,omitempty" yaml:"italic,omitempty"`
+	Color    *color.SimpleColor `json:"color,omitempty" yaml:"color,omitempty"`
+	Kids     []Bookmark         `json:"kids,omitempty" yaml:"kids,omitempty"`
+	Parent   *Bookmark          `json:"-" yaml:"-"`
+}
+
+// BookmarkDest represents the view of the bookmark page to be displayed.
+// Bookmarks without destination fit the entire page within the window.
+type BookmarkDest struct {
+	Type   string  `json:"type" yaml:"type"` // XYZ, Fit, FitH, FitV, FitR, FitB, FitBH, FitBV
+	Left   int     `json:"left,omitempty" yaml:"left,omitempty"`
+	Bottom int     `json:"bottom,omitempty" yaml:"bottom,omitempty"`
+	Right  int     `json:"right,omitempty" yaml:"right,omitempty"`
+	Top    int     `json:"top,omitempty" yaml:"top,omitempty"`
+	Zoom   float32 `json:"zoom,omitempty" yaml:"zoom,omitempty"`
 }
 
 type BookmarkTree struct {
-	Header    Header     `json:"header"`
-	Bookmarks []Bookmark `json:"bookmarks"`
+	Header    Header     `json:"header" yaml:"header"`
+	Bookmarks []Bookmark `json:"bookmarks" yaml:"bookmarks"`
+}
+
+type bookmarkAlias Bookmark
+
+// applyStyle applies style to bm, eg. "bold", "italic" or "bold italic".
+func (bm *Bookmark) applyStyle(style string) {
+	style = strings.ToLower(style)
+	bm.Bold = bm.Bold || strings.Contains(style, "bold")
+	bm.Italic = bm.Italic || strings.Contains(style, "italic")
+}
+
+// UnmarshalJSON also accepts "children" for "kids" and "style" for "bold" and "italic".
+func (bm *Bookmark) UnmarshalJSON(bb []byte) error {
+	aux := struct {
+		*bookmarkAlias
+		Children []Bookmark `json:"children"`
+		Style    string     `json:"style"`
+	}{bookmarkAlias: (*bookmarkAlias)(bm)}
+
+	if err := json.Unmarshal(bb, &aux); err != nil {
+		return err
+	}
+
+	if len(bm.Kids) == 0 {
+		bm.Kids = aux.Children
+	}
+	bm.applyStyle(aux.Style)
+
+	return nil
+}
+
+// UnmarshalYAML also accepts "children" for "kids" and "style" for "bold" and "italic".
+func (bm *Bookmark) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	aux := struct {
+		bookmarkAlias `yaml:",inline"`
+		Children      []Bookmark `yaml:"children"`
+		Style         string     `yaml:"style"`
+	}{}
+
+	if err := unmarshal(&aux); err != nil {
+		return err
+	}
+
+	*bm = Bookmark(aux.bookmarkAlias)
+	if len(bm.Kids) == 0 {
+		bm.Kids = aux.Children
+	}
+	bm.applyStyle(aux.Style)
+
+	return nil
+}
+
+func newBookmarkDest(dest model.Destination) *BookmarkDest {
+	return &BookmarkDest{
+		Type:   dest.String(),
+		Left:   dest.Left,
+		Bottom: dest.Bottom,
+		Right:  dest.Right,
+		Top:    dest.Top,
+		Zoom:   dest.Zoom,
+	}
+}
+
+func (bmd BookmarkDest) destination() (*model.Destination, error) {
+	typ, ok := destinationType(bmd.Type)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid bookmark destination type: %s", bmd.Type)
+	}
+	return &model.Destination{
+		Typ:    typ,
+		Left:   bmd.Left,
+		Bottom: bmd.Bottom,
+		Right:  bmd.Right,
+		Top:    bmd.Top,
+		Zoom:   bmd.Zoom,
+	}, nil
 }
 
 func header(xRefTable *model.XRefTable, source string) Header {
@@ -252,6 +340,13 @@ func BookmarksForOutlineItem(ctx *model.Context, item *types.IndirectRef, parent
 
 		bm := bookmark(d, title, pageFrom, parent)
 
+		// Preserve any destination other than the default for round tripping.
+		if arr, err := destArray(ctx, obj); err == nil {
+			if dest, err := destination(ctx, arr); err == nil && dest.Typ != model.DestFit {
+				bm.Dest = newBookmarkDest(*dest)
+			}
+		}
+
 		first := d["First"]
 		if first != nil {
 			indRef := first.(types.IndirectRef)
@@ -353,6 +448,14 @@ func bmDict(ctx *model.Context, bm Bookmark, parent types.IndirectRef) (types.Di
 	}
 
 	arr := types.Array{*pageIndRef, types.Name("Fit")}
+	if bm.Dest != nil {
+		dest, err := bm.Dest.destination()
+		if err != nil {
+			return nil, err
+		}
+		arr = dest.Array(*pageIndRef)
+	}
+
 	ir, err := ctx.IndRefForNewObject(arr)
 	if err != nil {
 		return nil, err
@@ -365,14 +468,17 @@ func bmDict(ctx *model.Context, bm Bookmark, parent types.IndirectRef) (types.Di
 		return nil, err
 	}
 
+	// Named destinations containing backslashes do not survive reading them back in.
+	destName := strings.ReplaceAll(bm.Title, "\\", "/")
+
 	d := types.Dict(map[string]types.Object{
-		"Dest":   types.NewHexLiteral([]byte(bm.Title)),
+		"Dest":   types.NewHexLiteral([]byte(destName)),
 		"Title":  types.StringLiteral(*s),
 		"Parent": parent},
 	)
 
-	m := model.NameMap{bm.Title: []types.Dict{d}}
-	if err := ctx.Names["Dests"].Add(ctx.XRefTable, bm.Title, o, m, []string{"D", "Dest"}); err != nil {
+	m := model.NameMap{destName: []types.Dict{d}}
+	if err := ctx.Names["Dests"].Add(ctx.XRefTable, destName, o, m, []string{"D", "Dest"}); err != nil {
 		return nil, err
 	}
 
@@ -536,9 +642,7 @@ func removeNamedDests(ctx *model.Context, item *types.IndirectRef) error {
 			return err
 		}
 		if !ok {
-			if log.DebugEnabled() {
-				log.Debug.Println("removeNamedDests: unable to remove dest name: " + s)
-			}
+			ctx.LogDebug("removeNamedDests: unable to remove dest", "name", s)
 		}
 
 		first := d["First"]
@@ -637,7 +741,28 @@ func parseBookmarksFromJSON(bb []byte) (*BookmarkTree, error) {
 	return bmTree, nil
 }
 
-// ImportBookmarks creates/replaces outlines in ctx as provided by rd.
+func parseBookmarksFromYAML(bb []byte) (*BookmarkTree, error) {
+	bmTree := &BookmarkTree{}
+
+	if err := yaml.Unmarshal(bb, bmTree); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid YAML encoding detected")
+	}
+
+	if len(bmTree.Bookmarks) == 0 {
+		return nil, errors.New("pdfcpu: missing bookmarks")
+	}
+
+	return bmTree, nil
+}
+
+func parseBookmarks(bb []byte) (*BookmarkTree, error) {
+	if bytes.HasPrefix(bytes.TrimSpace(bb), []byte("{")) {
+		return parseBookmarksFromJSON(bb)
+	}
+	return parseBookmarksFromYAML(bb)
+}
+
+// ImportBookmarks creates/replaces outlines in ctx as provided by rd in JSON or YAML.
 func ImportBookmarks(ctx *model.Context, rd io.Reader, replace bool) (bool, error) {
 
 	var buf bytes.Buffer
@@ -645,7 +770,7 @@ func ImportBookmarks(ctx *model.Context, rd io.Reader, replace bool) (bool, erro
 		return false, err
 	}
 
-	bmTree, err := parseBookmarksFromJSON(buf.Bytes())
+	bmTree, err := parseBookmarks(buf.Bytes())
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+const (
+	headingMinRatio  = 1.15 // minimum ratio of heading font size to body font size
+	headingMaxLevels = 3
+	headingMaxLen    = 100
+)
+
+// heading represents a line of text set in a font larger than the body text.
+type heading struct {
+	title     string
+	pageNr    int
+	fontSize  float64
+	left, top float64
+	bottom    float64
+}
+
+// fontSizeKey rounds fs to half points in order to compensate for rounding errors.
+func fontSizeKey(fs float64) float64 {
+	return math.Round(fs*2) / 2
+}
+
+func lineFontSize(gg []textGlyph, l glyphLine) float64 {
+	fs := 0.
+	for _, i := range l.glyphs {
+		if i >= 0 && gg[i].fontSize > fs {
+			fs = gg[i].fontSize
+		}
+	}
+	return fontSizeKey(fs)
+}
+
+func validHeadingTitle(s string) bool {
+	n := utf8.RuneCountInString(s)
+	if n < 2 || n > headingMaxLen {
+		return false
+	}
+	return strings.IndexFunc(s, unicode.IsLetter) >= 0
+}
+
+// pageLines returns the text lines of all pages along with the body font size weighted by glyph count.
+func pageLines(ctx *model.Context) ([][]textGlyph, [][]glyphLine, float64, error) {
+	var (
+		ggs   = make([][]textGlyph, ctx.PageCount)
+		lls   = make([][]glyphLine, ctx.PageCount)
+		hist  = map[float64]int{}
+		body  float64
+		count int
+	)
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		gg, err := pageGlyphs(ctx, pageNr)
+		if err != nil {
+			return nil, nil, 0, err
+		}
+		ggs[pageNr-1] = gg
+		for _, g := range gg {
+			hist[fontSizeKey(g.fontSize)]++
+		}
+		lls[pageNr-1] = textLines(gg)
+	}
+
+	for fs, c := range hist {
+		if c > count || (c == count && fs < body) {
+			body, count = fs, c
+		}
+	}
+
+	return ggs, lls, body, nil
+}
+
+// detectHeadings returns all lines of ctx set in a font larger than the body text in reading order.
+func detectHeadings(ctx *model.Context) ([]heading, error) {
+	ggs, lls, body, err := pageLines(ctx)
+	if err != nil || body == 0 {
+		return nil, err
+	}
+
+	hh := []heading{}
+	for i, ll := range lls {
+		pageNr := i + 1
+		for _, l := range ll {
+			fs := lineFontSize(ggs[i], l)
+			if fs < body*headingMinRatio {
+				continue
+			}
+			title := strings.Join(strings.Fields(outlineItemTitle(l.s)), " ")
+			if title == "" {
+				continue
+			}
+			r := l.rect(ggs[i], 0, len(l.s))
+			if r == nil {
+				continue
+			}
+			if n := len(hh); n > 0 {
+				// Merge headings spanning multiple lines.
+				h := &hh[n-1]
+				if h.pageNr == pageNr && h.fontSize == fs && h.bottom-r.UR.Y < fs {
+					h.title += " " + title
+					h.bottom = r.LL.Y
+					continue
+				}
+			}
+			hh = append(hh, heading{title: title, pageNr: pageNr, fontSize: fs, left: r.LL.X, top: r.UR.Y, bottom: r.LL.Y})
+		}
+	}
+
+	return filterHeadings(hh, ctx.PageCount), nil
+}
+
+// filterHeadings drops invalid titles and running headers repeated on many pages.
+func filterHeadings(hh []heading, pageCount int) []heading {
+	pages := map[string]map[int]bool{}
+	for _, h := range hh {
+		if pages[h.title] == nil {
+			pages[h.title] = map[int]bool{}
+		}
+		pages[h.title][h.pageNr] = true
+	}
+
+	hh1 := []heading{}
+	for _, h := range hh {
+		if !validHeadingTitle(h.title) {
+			continue
+		}
+		if pageCount > 3 && len(pages[h.title]) > pageCount/2 {
+			continue
+		}
+		hh1 = append(hh1, h)
+	}
+
+	return hh1
+}
+
+// headingLevels maps heading font sizes to outline levels starting with 0 for the largest font size.
+func headingLevels(hh []heading) map[float64]int {
+	sizes := []float64{}
+	m := map[float64]int{}
+	for _, h := range hh {
+		if _, ok := m[h.fontSize]; !ok {
+			m[h.fontSize] = 0
+			sizes = append(sizes, h.fontSize)
+		}
+	}
+
+	sort.Sort(sort.Reverse(sort.Float64Slice(sizes)))
+
+	for i, fs := range sizes {
+		m[fs] = min(i, headingMaxLevels-1)
+	}
+
+	return m
+}
+
+// DetectBookmarks returns an outline for ctx built from headings found in the page text.
+// Headings are lines set in a font larger than the body text and the font size determines the outline level.
+func DetectBookmarks(ctx *model.Context) ([]Bookmark, error) {
+	hh, err := detectHeadings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hh) == 0 {
+		return nil, nil
+	}
+
+	levels := headingLevels(hh)
+
+	var (
+		bms   []Bookmark
+		stack []*[]Bookmark // open bookmark lists by level
+	)
+
+	stack = append(stack, &bms)
+
+	for _, h := range hh {
+		bm := Bookmark{
+			Title:    h.title,
+			PageFrom: h.pageNr,
+			Dest:     &BookmarkDest{Type: "XYZ", Left: int(h.left), Top: int(math.Ceil(h.top))},
+		}
+
+		level := levels[h.fontSize]
+		if level > len(stack)-1 {
+			// Skipped levels are attached to the deepest open level.
+			level = len(stack) - 1
+		}
+		stack = stack[:level+1]
+
+		bmsp := stack[level]
+		*bmsp = append(*bmsp, bm)
+		last := &(*bmsp)[len(*bmsp)-1]
+		stack = append(stack, &last.Kids)
+	}
+
+	return bms, nil
+}
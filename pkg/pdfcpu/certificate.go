@@ -18,6 +18,7 @@ package pdfcpu
 
 import (
 	"bytes"
+	"crypto"
 	"crypto/x509"
 	"encoding/base64"
 	"encoding/pem"
@@ -137,6 +138,41 @@ func LoadCertificates(filename string) ([]*x509.Certificate, error) {
 	}
 }
 
+func parsePrivateKey(der []byte) (crypto.PrivateKey, error) {
+	if key, err := x509.ParsePKCS1PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParsePKCS8PrivateKey(der); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(der); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("pdfcpu: unsupported private key")
+}
+
+// LoadPrivateKey loads an unencrypted PKCS#1, PKCS#8 or EC private key from a PEM or DER encoded file.
+func LoadPrivateKey(filename string) (crypto.PrivateKey, error) {
+	bb, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	for rest := bb; len(rest) > 0; {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if strings.HasSuffix(block.Type, "PRIVATE KEY") {
+			return parsePrivateKey(block.Bytes)
+		}
+	}
+
+	// DER
+	return parsePrivateKey(bb)
+}
+
 func loadCertificatesToCertPool(path string, certPool *x509.CertPool, n *int) error {
 	certs, err := LoadCertificates(path)
 	if err != nil {
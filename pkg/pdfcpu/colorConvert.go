@@ -0,0 +1,1136 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// colorFamily classifies color spaces by their process colorants.
+type colorFamily int
+
+const (
+	colorFamilyNone colorFamily = iota
+	colorFamilyGray
+	colorFamilyRGB
+	colorFamilyCMYK
+)
+
+func (f colorFamily) components() int {
+	switch f {
+	case colorFamilyGray:
+		return 1
+	case colorFamilyRGB:
+		return 3
+	case colorFamilyCMYK:
+		return 4
+	}
+	return 0
+}
+
+func (f colorFamily) colorSpace() types.Name {
+	switch f {
+	case colorFamilyGray:
+		return model.DeviceGrayCS
+	case colorFamilyRGB:
+		return model.DeviceRGBCS
+	}
+	return model.DeviceCMYKCS
+}
+
+// colorOperator returns the operator setting a color of f.
+func (f colorFamily) colorOperator(stroke bool) string {
+	op := "k"
+	switch f {
+	case colorFamilyGray:
+		op = "g"
+	case colorFamilyRGB:
+		op = "rg"
+	}
+	if stroke {
+		return strings.ToUpper(op)
+	}
+	return op
+}
+
+var colorOperatorFamilies = map[string]colorFamily{
+	"g":  colorFamilyGray,
+	"rg": colorFamilyRGB,
+	"k":  colorFamilyCMYK,
+}
+
+// Grid sizes used for resampling functions by number of inputs.
+var resampleSizes = []int{0, 256, 33, 17, 9}
+
+// colorConverter rewrites colors of page content, images, shadings and color spaces into a target family.
+type colorConverter struct {
+	ctx     *model.Context
+	target  colorFamily
+	sources map[colorFamily]bool                   // Families to be converted.
+	convert func(colorFamily, []float64) []float64 // Converts a color of a source family.
+	pixels  func(colorFamily, []byte, int) []byte  // Converts 8 bit samples of a source family.
+	flatten bool                                   // Replace separation and DeviceN color spaces by the target.
+	done    types.IntSet                           // Converted content streams.
+	stats   model.ColorConversionStats
+}
+
+// colorSpaceConversion converts the colors of a source color space into the target family.
+type colorSpaceConversion struct {
+	n       int                       // Number of source color components.
+	convert func([]float64) []float64 // Converts a color.
+	pixels  func([]byte) []byte       // Converts 8 bit samples.
+	initial []float64                 // Initial color of a flattened color space.
+}
+
+func (c *colorConverter) family(o types.Object) colorFamily {
+	o, err := c.ctx.Dereference(o)
+	if err != nil {
+		return colorFamilyNone
+	}
+
+	switch cs := o.(type) {
+
+	case types.Name:
+		switch cs {
+		case model.DeviceGrayCS:
+			return colorFamilyGray
+		case model.DeviceRGBCS:
+			return colorFamilyRGB
+		case model.DeviceCMYKCS:
+			return colorFamilyCMYK
+		}
+
+	case types.Array:
+		if len(cs) < 2 {
+			return colorFamilyNone
+		}
+		n, _ := cs[0].(types.Name)
+		switch n {
+		case model.CalGrayCS:
+			return colorFamilyGray
+		case model.CalRGBCS:
+			return colorFamilyRGB
+		case model.ICCBasedCS:
+			sd, _, err := c.ctx.DereferenceStreamDict(cs[1])
+			if err != nil || sd == nil {
+				return colorFamilyNone
+			}
+			if n := sd.IntEntry("N"); n != nil {
+				switch *n {
+				case 1:
+					return colorFamilyGray
+				case 3:
+					return colorFamilyRGB
+				case 4:
+					return colorFamilyCMYK
+				}
+			}
+		}
+	}
+
+	return colorFamilyNone
+}
+
+func (c *colorConverter) converts(f colorFamily) bool {
+	return c.sources[f]
+}
+
+func colorBytes(y []float64) []byte {
+	bb := make([]byte, len(y))
+	for i, v := range y {
+		bb[i] = byte(math.Round(clamp01(v) * 255))
+	}
+	return bb
+}
+
+// samplePixels converts 8 bit samples with n components one distinct color at a time.
+func samplePixels(convert func([]float64) []float64, pix []byte, n int) []byte {
+	cache := map[string][]byte{}
+	var out []byte
+	x := make([]float64, n)
+	for i := 0; i+n <= len(pix); i += n {
+		k := string(pix[i : i+n])
+		y, ok := cache[k]
+		if !ok {
+			for j := range x {
+				x[j] = float64(pix[i+j]) / 255
+			}
+			y = colorBytes(convert(x))
+			cache[k] = y
+		}
+		out = append(out, y...)
+	}
+	return out
+}
+
+func (c *colorConverter) processConversion(f colorFamily) *colorSpaceConversion {
+	if !c.converts(f) {
+		return nil
+	}
+	return &colorSpaceConversion{
+		n:       f.components(),
+		convert: func(x []float64) []float64 { return c.convert(f, x) },
+		pixels:  func(pix []byte) []byte { return c.pixels(f, pix, f.components()) },
+	}
+}
+
+// tintConversion returns the conversion of a separation or DeviceN color space via its tint transform.
+func (c *colorConverter) tintConversion(a types.Array) (*colorSpaceConversion, error) {
+	n, _ := a[0].(types.Name)
+	m := 1
+
+	switch {
+	case n == model.SeparationCS && len(a) == 4:
+		if colorant, _ := a[1].(types.Name); colorant == "None" {
+			// Nothing gets painted.
+			return nil, nil
+		}
+	case n == model.DeviceNCS && len(a) >= 4:
+		names, err := c.ctx.DereferenceArray(a[1])
+		if err != nil {
+			return nil, err
+		}
+		m = len(names)
+	default:
+		return nil, nil
+	}
+
+	alt := c.family(a[2])
+	if alt != c.target && !c.converts(alt) {
+		return nil, nil
+	}
+
+	fn, err := parseFunction(c.ctx, a[3])
+	if err == errUnsupportedFunction {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	k := alt.components()
+
+	convert := func(x []float64) []float64 {
+		y := fn.eval(x)
+		if len(y) < k {
+			y = append(y, make([]float64, k-len(y))...)
+		}
+		if alt == c.target {
+			return y[:k]
+		}
+		return c.convert(alt, y[:k])
+	}
+
+	initial := make([]float64, m)
+	for i := range initial {
+		initial[i] = 1
+	}
+
+	return &colorSpaceConversion{
+		n:       m,
+		convert: convert,
+		pixels:  func(pix []byte) []byte { return samplePixels(convert, pix, m) },
+		initial: initial,
+	}, nil
+}
+
+// conversion returns the conversion of the colors of color space o into the target or nil.
+func (c *colorConverter) conversion(o types.Object) (*colorSpaceConversion, error) {
+	if conv := c.processConversion(c.family(o)); conv != nil || !c.flatten {
+		return conv, nil
+	}
+
+	a, err := c.ctx.DereferenceArray(o)
+	if err != nil || len(a) == 0 {
+		return nil, nil
+	}
+
+	return c.tintConversion(a)
+}
+
+func colorValuesString(y []float64) string {
+	ss := make([]string, len(y))
+	for i, v := range y {
+		ss[i] = strconv.FormatFloat(math.Round(clamp01(v)*10000)/10000, 'f', -1, 64)
+	}
+	return strings.Join(ss, " ")
+}
+
+func numericOperands(operands []string, n int) ([]float64, bool) {
+	if len(operands) != n {
+		return nil, false
+	}
+	x := make([]float64, n)
+	for i, s := range operands {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, false
+		}
+		x[i] = f
+	}
+	return x, true
+}
+
+// contentColorSpace returns the color space denoted by the operand of cs or CS.
+func (c *colorConverter) contentColorSpace(operand string, resDict types.Dict) types.Object {
+	if len(operand) < 2 || operand[0] != '/' {
+		return nil
+	}
+
+	name, err := types.DecodeName(operand[1:])
+	if err != nil {
+		return nil
+	}
+
+	if c.family(types.Name(name)) != colorFamilyNone {
+		return types.Name(name)
+	}
+
+	if resDict == nil {
+		return nil
+	}
+
+	d, err := c.ctx.DereferenceDict(resDict["ColorSpace"])
+	if err != nil || d == nil {
+		return nil
+	}
+
+	o, _ := d.Find(name)
+
+	return o
+}
+
+type contentColorState struct {
+	fill, stroke *colorSpaceConversion
+}
+
+// convertContent rewrites the color operators of content stream bb.
+func (c *colorConverter) convertContent(bb []byte, resDict types.Dict) ([]byte, bool) {
+	var (
+		out     []byte
+		last    int
+		changed bool
+		st      contentColorState
+		stack   []contentColorState
+	)
+
+	replace := func(op contentOp, s string) {
+		out = append(out, bb[last:op.beg]...)
+		out = append(out, s...)
+		last, changed = op.end, true
+	}
+
+	for _, op := range parseContentOps(bb) {
+		stroke := op.name == strings.ToUpper(op.name)
+
+		switch op.name {
+
+		case "q":
+			stack = append(stack, st)
+
+		case "Q":
+			if len(stack) > 0 {
+				st, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+
+		case "g", "G", "rg", "RG", "k", "K":
+			conv := c.processConversion(colorOperatorFamilies[strings.ToLower(op.name)])
+			if stroke {
+				st.stroke = conv
+			} else {
+				st.fill = conv
+			}
+			if conv == nil {
+				continue
+			}
+			if x, ok := numericOperands(op.operands, conv.n); ok {
+				replace(op, colorValuesString(conv.convert(x))+" "+c.target.colorOperator(stroke))
+			}
+
+		case "cs", "CS":
+			if len(op.operands) != 1 {
+				continue
+			}
+			conv, err := c.conversion(c.contentColorSpace(op.operands[0], resDict))
+			if err != nil {
+				conv = nil
+			}
+			if stroke {
+				st.stroke = conv
+			} else {
+				st.fill = conv
+			}
+			if conv == nil {
+				continue
+			}
+			s := "/" + c.target.colorSpace().Value() + " " + op.name
+			if conv.initial != nil {
+				setColor := "sc"
+				if stroke {
+					setColor = "SC"
+				}
+				s += " " + colorValuesString(conv.convert(conv.initial)) + " " + setColor
+			}
+			replace(op, s)
+
+		case "sc", "scn", "SC", "SCN":
+			conv := st.fill
+			if stroke {
+				conv = st.stroke
+			}
+			if conv == nil {
+				continue
+			}
+			if x, ok := numericOperands(op.operands, conv.n); ok {
+				replace(op, colorValuesString(conv.convert(x))+" "+op.name)
+			}
+		}
+	}
+
+	if !changed {
+		return bb, false
+	}
+
+	return append(out, bb[last:]...), true
+}
+
+func (c *colorConverter) convertPages() error {
+	if err := c.ctx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= c.ctx.PageCount; pageNr++ {
+		pageDict, bb, resDict, err := pageContentAndResources(c.ctx, pageNr)
+		if err != nil {
+			return err
+		}
+		if bb == nil {
+			continue
+		}
+		bb, ok := c.convertContent(bb, resDict)
+		if !ok {
+			continue
+		}
+		if err := replacePageContent(c.ctx, pageDict, bb); err != nil {
+			return err
+		}
+		c.stats.ContentStreams++
+	}
+
+	return nil
+}
+
+// convertContentStream converts the content stream obj#objNr using the resources in o.
+func (c *colorConverter) convertContentStream(objNr int, o types.Object) error {
+	if c.done[objNr] {
+		return nil
+	}
+	c.done[objNr] = true
+
+	entry, ok := c.ctx.FindTableEntryLight(objNr)
+	if !ok {
+		return nil
+	}
+
+	sd, ok := entry.Object.(types.StreamDict)
+	if !ok {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	resDict, err := c.ctx.DereferenceDict(o)
+	if err != nil {
+		return err
+	}
+
+	bb, ok := c.convertContent(sd.Content, resDict)
+	if !ok {
+		return nil
+	}
+
+	sd.Content = bb
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = sd
+	c.stats.ContentStreams++
+
+	return nil
+}
+
+// isContentStream returns true for form XObjects including appearance streams and tiling patterns.
+func isContentStream(sd types.StreamDict) bool {
+	if st := sd.Subtype(); st != nil {
+		return *st == "Form"
+	}
+	if pt := sd.IntEntry("PatternType"); pt != nil {
+		return *pt == 1
+	}
+	_, found := sd.Find("BBox")
+	return found
+}
+
+func (c *colorConverter) convertType3Glyphs(d types.Dict) error {
+	cp, err := c.ctx.DereferenceDict(d["CharProcs"])
+	if err != nil || cp == nil {
+		return err
+	}
+	for _, o := range cp {
+		if indRef, ok := o.(types.IndirectRef); ok {
+			if err := c.convertContentStream(indRef.ObjectNumber.Value(), d["Resources"]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// convertImage converts the samples of image sd.
+func (c *colorConverter) convertImage(objNr int, sd types.StreamDict) error {
+	conv, err := c.conversion(sd.Dict["ColorSpace"])
+	if err != nil || conv == nil {
+		return err
+	}
+
+	if _, found := sd.Find("Alternates"); found {
+		c.stats.Skipped++
+		return nil
+	}
+
+	is, err := decodeImageSamplesN(c.ctx, &sd, conv.n)
+	if err != nil {
+		return err
+	}
+	if is == nil {
+		c.ctx.LogDebug("convertImage: skipping", log.KeyObj, objNr)
+		c.stats.Skipped++
+		return nil
+	}
+
+	if o, found := sd.Find("SMask"); found {
+		sm, _, err := c.ctx.DereferenceStreamDict(o)
+		if err != nil {
+			return err
+		}
+		if sm != nil {
+			if err := c.convertMatte(sd.IndirectRefEntry("SMask"), sm, conv); err != nil {
+				return err
+			}
+		}
+	}
+
+	is1 := &imageSamples{w: is.w, h: is.h, n: c.target.components(), pix: conv.pixels(is.pix)}
+
+	// CMYK can't be JPEG encoded.
+	dct := is.dct && c.target != colorFamilyCMYK
+
+	sd1, err := imageStreamDict(&sd, is1, dct, colorConversionJPEGQuality)
+	if err != nil {
+		return err
+	}
+	sd1.Dict.Update("ColorSpace", c.target.colorSpace())
+
+	if entry, ok := c.ctx.FindTableEntryLight(objNr); ok {
+		entry.Object = *sd1
+	}
+
+	c.stats.Images++
+
+	return nil
+}
+
+const colorConversionJPEGQuality = 90
+
+// convertMatte converts the Matte entry of a soft mask which is specified in the color space of its parent image.
+func (c *colorConverter) convertMatte(indRef *types.IndirectRef, sm *types.StreamDict, conv *colorSpaceConversion) error {
+	m, err := numberArrayEntry(c.ctx, sm.Dict, "Matte")
+	if err != nil || len(m) != conv.n {
+		return err
+	}
+	sm.Dict.Update("Matte", types.NewNumberArray(conv.convert(m)...))
+	if indRef != nil {
+		if entry, ok := c.ctx.FindTableEntryLight(indRef.ObjectNumber.Value()); ok {
+			entry.Object = *sm
+		}
+	}
+	return nil
+}
+
+// resampledFunction returns a sampled function evaluating fn followed by converting its output into the target.
+func (c *colorConverter) resampledFunction(fn pdfFunction, domain []float64, conv *colorSpaceConversion) (*types.IndirectRef, error) {
+	m := len(domain) / 2
+	if m < 1 || m >= len(resampleSizes) {
+		return nil, errUnsupportedFunction
+	}
+
+	size := resampleSizes[m]
+	count := 1
+	for i := 0; i < m; i++ {
+		count *= size
+	}
+
+	n := c.target.components()
+	bb := make([]byte, 0, count*n)
+	x := make([]float64, m)
+
+	for i := 0; i < count; i++ {
+		// The first input varies fastest.
+		for j, k := 0, i; j < m; j, k = j+1, k/size {
+			x[j] = interpolate(float64(k%size), 0, float64(size-1), domain[2*j], domain[2*j+1])
+		}
+		y := fn.eval(x)
+		if len(y) < conv.n {
+			return nil, errUnsupportedFunction
+		}
+		bb = append(bb, colorBytes(conv.convert(y[:conv.n]))...)
+	}
+
+	sd, err := c.ctx.NewStreamDictForBuf(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := make([]int, m)
+	for i := range sizes {
+		sizes[i] = size
+	}
+
+	rng := types.Array{}
+	for i := 0; i < n; i++ {
+		rng = append(rng, types.Integer(0), types.Integer(1))
+	}
+
+	sd.InsertInt("FunctionType", 0)
+	sd.Insert("Domain", types.NewNumberArray(domain...))
+	sd.Insert("Range", rng)
+	sd.Insert("Size", types.NewIntegerArray(sizes...))
+	sd.InsertInt("BitsPerSample", 8)
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return c.ctx.IndRefForNewObject(*sd)
+}
+
+// convertShading converts shading dict d whose colors are defined by a function.
+// Shadings with colors defined by mesh vertices or PostScript calculator functions are left alone.
+func (c *colorConverter) convertShading(d types.Dict) error {
+	conv, err := c.conversion(d["ColorSpace"])
+	if err != nil || conv == nil {
+		return err
+	}
+
+	o, found := d.Find("Function")
+	if !found {
+		c.stats.Skipped++
+		return nil
+	}
+
+	fn, err := parseFunction(c.ctx, o)
+	if err == errUnsupportedFunction {
+		c.stats.Skipped++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	domain, err := numberArrayEntry(c.ctx, d, "Domain")
+	if err != nil {
+		return err
+	}
+
+	st := d.IntEntry("ShadingType")
+	switch {
+	case st != nil && *st == 1:
+		if domain == nil {
+			domain = []float64{0, 1, 0, 1}
+		}
+	case st != nil && *st <= 3:
+		if domain == nil {
+			domain = []float64{0, 1}
+		}
+	default:
+		// Mesh shadings use the parametric variable t.
+		if domain = functionDomain(c.ctx, o); domain == nil {
+			c.stats.Skipped++
+			return nil
+		}
+	}
+
+	indRef, err := c.resampledFunction(fn, domain, conv)
+	if err == errUnsupportedFunction {
+		c.stats.Skipped++
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if bg, err := numberArrayEntry(c.ctx, d, "Background"); err != nil {
+		return err
+	} else if len(bg) == conv.n {
+		d.Update("Background", types.NewNumberArray(conv.convert(bg)...))
+	}
+
+	d.Update("ColorSpace", c.target.colorSpace())
+	d.Update("Function", *indRef)
+
+	c.stats.Shadings++
+
+	return nil
+}
+
+func functionDomain(ctx *model.Context, o types.Object) []float64 {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil
+	}
+	if a, ok := o.(types.Array); ok && len(a) > 0 {
+		return functionDomain(ctx, a[0])
+	}
+	var d types.Dict
+	switch o := o.(type) {
+	case types.Dict:
+		d = o
+	case types.StreamDict:
+		d = o.Dict
+	default:
+		return nil
+	}
+	domain, err := numberArrayEntry(ctx, d, "Domain")
+	if err != nil || len(domain) != 2 {
+		return nil
+	}
+	return domain
+}
+
+// convertIndexed returns a copy of indexed color space a with its lookup table converted.
+func (c *colorConverter) convertIndexed(a types.Array) (types.Array, bool, error) {
+	conv, err := c.conversion(a[1])
+	if err != nil || conv == nil {
+		return nil, false, err
+	}
+
+	o, err := c.ctx.Dereference(a[3])
+	if err != nil {
+		return nil, false, err
+	}
+
+	var bb []byte
+
+	switch o := o.(type) {
+	case types.StringLiteral:
+		if bb, err = types.Unescape(o.Value()); err != nil {
+			return nil, false, err
+		}
+	case types.HexLiteral:
+		if bb, err = o.Bytes(); err != nil {
+			return nil, false, err
+		}
+	case types.StreamDict:
+		if err := o.Decode(); err != nil {
+			return nil, false, err
+		}
+		bb = o.Content
+	default:
+		return nil, false, nil
+	}
+
+	bb1 := samplePixels(conv.convert, bb, conv.n)
+
+	c.stats.ColorSpaces++
+
+	return types.Array{a[0], c.target.colorSpace(), a[2], types.NewHexLiteral(bb1)}, true, nil
+}
+
+// convertSpecial returns a copy of Separation or DeviceN color space a with its alternate color space converted.
+func (c *colorConverter) convertSpecial(a types.Array, deviceN bool) (types.Array, bool, error) {
+	const alt = 2
+
+	conv := c.processConversion(c.family(a[alt]))
+	if conv == nil {
+		return nil, false, nil
+	}
+
+	fn, err := parseFunction(c.ctx, a[alt+1])
+	if err == errUnsupportedFunction {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	m := 1
+	if deviceN {
+		names, err := c.ctx.DereferenceArray(a[1])
+		if err != nil {
+			return nil, false, err
+		}
+		m = len(names)
+	}
+
+	domain := make([]float64, 0, 2*m)
+	for i := 0; i < m; i++ {
+		domain = append(domain, 0, 1)
+	}
+
+	indRef, err := c.resampledFunction(fn, domain, conv)
+	if err == errUnsupportedFunction {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	a1 := append(types.Array{}, a...)
+	a1[alt], a1[alt+1] = c.target.colorSpace(), *indRef
+
+	c.stats.ColorSpaces++
+
+	return a1, true, nil
+}
+
+func (c *colorConverter) convertColorSpace(a types.Array) (types.Array, bool, error) {
+	n, _ := a[0].(types.Name)
+	switch {
+	case n == model.IndexedCS && len(a) == 4:
+		return c.convertIndexed(a)
+	case n == model.SeparationCS && len(a) == 4:
+		return c.convertSpecial(a, false)
+	case n == model.DeviceNCS && len(a) >= 4:
+		return c.convertSpecial(a, true)
+	}
+	return nil, false, nil
+}
+
+// convertObject converts color spaces and shadings within o recursively.
+func (c *colorConverter) convertObject(o types.Object) (types.Object, error) {
+	switch o := o.(type) {
+
+	case types.Dict:
+		if _, found := o.Find("ShadingType"); found {
+			if err := c.convertShading(o); err != nil {
+				return nil, err
+			}
+		}
+		for k, v := range o {
+			v1, err := c.convertObject(v)
+			if err != nil {
+				return nil, err
+			}
+			o[k] = v1
+		}
+
+	case types.StreamDict:
+		if _, err := c.convertObject(o.Dict); err != nil {
+			return nil, err
+		}
+
+	case types.Array:
+		if len(o) > 0 {
+			a, ok, err := c.convertColorSpace(o)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				return a, nil
+			}
+		}
+		for i, v := range o {
+			v1, err := c.convertObject(v)
+			if err != nil {
+				return nil, err
+			}
+			o[i] = v1
+		}
+	}
+
+	return o, nil
+}
+
+func (c *colorConverter) convertObjects() error {
+	for _, objNr := range tableObjNrs(c.ctx) {
+		// Also loads objects from object streams.
+		o, err := c.ctx.Dereference(*types.NewIndirectRef(objNr, *c.ctx.Table[objNr].Generation))
+		if err != nil {
+			return err
+		}
+
+		switch o := o.(type) {
+
+		case types.StreamDict:
+			if st := o.Subtype(); st != nil && *st == "Image" {
+				if err := c.convertImage(objNr, o); err != nil {
+					return err
+				}
+				break
+			}
+			if isContentStream(o) {
+				if err := c.convertContentStream(objNr, o.Dict["Resources"]); err != nil {
+					return err
+				}
+			}
+
+		case types.Dict:
+			if st := o.Subtype(); st != nil && *st == "Type3" {
+				if err := c.convertType3Glyphs(o); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	for _, objNr := range tableObjNrs(c.ctx) {
+		entry := c.ctx.Table[objNr]
+		o, err := c.convertObject(entry.Object)
+		if err != nil {
+			return err
+		}
+		entry.Object = o
+	}
+
+	return nil
+}
+
+func (c *colorConverter) run() (*model.ColorConversionStats, error) {
+	if err := c.convertPages(); err != nil {
+		return nil, err
+	}
+
+	if err := c.convertObjects(); err != nil {
+		return nil, err
+	}
+
+	return &c.stats, nil
+}
+
+type cmykConversionParamMap map[string]func(string, *model.CMYKConversion) error
+
+var cmykConversionParamsMap = cmykConversionParamMap{
+	"src":          parseCMYKConversionSourceProfile,
+	"dest":         parseCMYKConversionDestProfile,
+	"intent":       parseCMYKConversionIntent,
+	"outputintent": parseCMYKConversionOutputIntent,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into cc.
+func (m cmykConversionParamMap) Handle(paramPrefix, paramValueStr string, cc *model.CMYKConversion) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, cc)
+}
+
+func readICCProfile(fileName string, dataColorSpace string) ([]byte, error) {
+	bb, err := os.ReadFile(fileName)
+	if err != nil {
+		return nil, err
+	}
+	p, err := newICCProfile(bb)
+	if err != nil {
+		return nil, errors.Errorf("pdfcpu: invalid ICC profile: %s", fileName)
+	}
+	if p.dataColorSpace() != dataColorSpace {
+		return nil, errors.Errorf("pdfcpu: %s: want ICC profile for %s, got: %s", fileName, strings.TrimSpace(dataColorSpace), strings.TrimSpace(p.dataColorSpace()))
+	}
+	return bb, nil
+}
+
+func parseCMYKConversionSourceProfile(s string, cc *model.CMYKConversion) error {
+	if strings.ToLower(s) == "srgb" {
+		cc.SourceProfile, cc.SourceProfileName = nil, ""
+		return nil
+	}
+	bb, err := readICCProfile(s, "RGB ")
+	if err != nil {
+		return err
+	}
+	cc.SourceProfile, cc.SourceProfileName = bb, s
+	return nil
+}
+
+func parseCMYKConversionDestProfile(s string, cc *model.CMYKConversion) error {
+	bb, err := readICCProfile(s, "CMYK")
+	if err != nil {
+		return err
+	}
+	cc.DestProfile, cc.DestProfileName = bb, s
+	return nil
+}
+
+func parseCMYKConversionIntent(s string, cc *model.CMYKConversion) error {
+	switch strings.ToLower(s) {
+	case "perceptual", "p":
+		cc.Intent = model.Perceptual
+	case "relative", "rel", "r":
+		cc.Intent = model.RelativeColorimetric
+	case "saturation", "sat", "s":
+		cc.Intent = model.Saturation
+	default:
+		return errors.New("pdfcpu: invalid rendering intent, please provide one of: perceptual, relative, saturation")
+	}
+	return nil
+}
+
+func parseCMYKConversionOutputIntent(s string, cc *model.CMYKConversion) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		cc.OutputIntent = true
+	case "off", "false", "f":
+		cc.OutputIntent = false
+	default:
+		return errors.New("pdfcpu: embed output intent, please provide one of: on/off true/false")
+	}
+	return nil
+}
+
+// ParseCMYKConversion parses a CMYK conversion string into an internal structure.
+func ParseCMYKConversion(s string) (*model.CMYKConversion, error) {
+	cc := model.DefaultCMYKConversion()
+
+	for _, s := range strings.Split(s, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		i := strings.Index(s, ":")
+		if i < 0 {
+			return nil, errors.New("pdfcpu: Invalid CMYK conversion string. Please consult pdfcpu help convert")
+		}
+
+		paramPrefix := strings.TrimSpace(s[:i])
+		paramValueStr := strings.TrimSpace(s[i+1:])
+
+		if err := cmykConversionParamsMap.Handle(paramPrefix, paramValueStr, cc); err != nil {
+			return nil, err
+		}
+	}
+
+	if cc.DestProfile == nil {
+		return nil, errors.New("pdfcpu: missing CMYK destination profile, eg. \"dest:coated.icc\"")
+	}
+
+	return cc, nil
+}
+
+// ConvertToCMYK converts RGB colors of page content, form XObjects, patterns, images, shadings and
+// indexed, separation and DeviceN color spaces into DeviceCMYK using the ICC profiles of cc.
+// The destination profile is embedded as output intent if requested and the document has none.
+// Inline images, 16 bit images, images using a decode array,
+// mesh shadings without function and PostScript calculator functions are left alone.
+func ConvertToCMYK(ctx *model.Context, cc *model.CMYKConversion) (*model.ColorConversionStats, error) {
+	if cc == nil || cc.DestProfile == nil {
+		return nil, errors.New("pdfcpu: ConvertToCMYK: missing destination profile")
+	}
+
+	t, err := newICCTransform(cc.SourceProfile, cc.DestProfile, cc.Intent)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.n != 3 {
+		return nil, errors.New("pdfcpu: ConvertToCMYK: source profile must be an RGB profile")
+	}
+
+	c := &colorConverter{
+		ctx:     ctx,
+		target:  colorFamilyCMYK,
+		sources: map[colorFamily]bool{colorFamilyRGB: true},
+		convert: func(_ colorFamily, x []float64) []float64 {
+			return t.convert(x)
+		},
+		pixels: func(_ colorFamily, pix []byte, n int) []byte {
+			return t.convertPixels(pix, n)
+		},
+		done: types.IntSet{},
+	}
+
+	stats, err := c.run()
+	if err != nil {
+		return nil, err
+	}
+
+	if cc.OutputIntent {
+		if err := addMissingOutputIntent(ctx, cc.DestProfile); err != nil {
+			return nil, err
+		}
+	}
+
+	return stats, nil
+}
+
+// grayValue converts an RGB or CMYK color into a gray level the same way images get grayscaled.
+func grayValue(f colorFamily, x []float64) float64 {
+	if f == colorFamilyRGB {
+		return 0.299*x[0] + 0.587*x[1] + 0.114*x[2]
+	}
+	return 1 - math.Min(1, 0.3*x[0]+0.59*x[1]+0.11*x[2]+x[3])
+}
+
+// ConvertToGray converts RGB and CMYK colors of page content, form XObjects, patterns, images, shadings and
+// indexed color spaces into DeviceGray. Separation and DeviceN colors are replaced by the gray level of their
+// alternate color and left over separation and DeviceN color spaces get a DeviceGray alternate color space.
+// Inline images, 16 bit images, images using a decode array,
+// mesh shadings without function and PostScript calculator functions are left alone.
+func ConvertToGray(ctx *model.Context) (*model.ColorConversionStats, error) {
+	c := &colorConverter{
+		ctx:     ctx,
+		target:  colorFamilyGray,
+		sources: map[colorFamily]bool{colorFamilyRGB: true, colorFamilyCMYK: true},
+		convert: func(f colorFamily, x []float64) []float64 {
+			return []float64{grayValue(f, x)}
+		},
+		pixels: func(_ colorFamily, pix []byte, n int) []byte {
+			is := &imageSamples{w: len(pix) / n, h: 1, n: n, pix: pix}
+			return is.grayscale().pix
+		},
+		flatten: true,
+		done:    types.IntSet{},
+	}
+
+	return c.run()
+}
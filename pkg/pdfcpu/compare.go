@@ -0,0 +1,629 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// regionGap is the distance in points up to which changed regions get merged.
+const regionGap = 4
+
+// pageMark represents something painted on a page.
+type pageMark struct {
+	key   string // Identifies what and where something got painted.
+	rect  types.Rectangle
+	ink   float64           // Estimated area covered by ink.
+	text  bool              // Something shows text.
+	image *types.StreamDict // Image XObject whose ink depends on its samples.
+	objNr int               // Object number of image.
+	ctm   matrix.Matrix     // Maps the unit square onto the image.
+}
+
+func area(r types.Rectangle) float64 {
+	return r.Width() * r.Height()
+}
+
+func round2(f float64) float64 {
+	return math.Round(f*100) / 100
+}
+
+func boundingBox(pp []types.Point) types.Rectangle {
+	if len(pp) == 0 {
+		return types.Rectangle{}
+	}
+	r := types.Rectangle{LL: pp[0], UR: pp[0]}
+	for _, p := range pp[1:] {
+		r.LL.X, r.LL.Y = math.Min(r.LL.X, p.X), math.Min(r.LL.Y, p.Y)
+		r.UR.X, r.UR.Y = math.Max(r.UR.X, p.X), math.Max(r.UR.Y, p.Y)
+	}
+	return r
+}
+
+func rectKey(r types.Rectangle) string {
+	return fmt.Sprintf("%.2f %.2f %.2f %.2f", round2(r.LL.X), round2(r.LL.Y), round2(r.UR.X), round2(r.UR.Y))
+}
+
+// hashObject writes a canonical representation of o to h following indirect references.
+// Parent references are skipped in order to hash page relevant objects only.
+// Indirect objects are represented by the hash of their canonical representation
+// in order to be independent of how objects are shared.
+func hashObject(ctx *model.Context, h hash.Hash, o types.Object, sums map[int][]byte) {
+	switch o := o.(type) {
+
+	case nil:
+		h.Write([]byte("null "))
+
+	case types.IndirectRef:
+		objNr := o.ObjectNumber.Value()
+		sum, ok := sums[objNr]
+		if ok && sum == nil {
+			// Cycle
+			h.Write([]byte("R "))
+			return
+		}
+		if !ok {
+			sums[objNr] = nil
+			o1, err := ctx.Dereference(o)
+			if err != nil {
+				delete(sums, objNr)
+				return
+			}
+			h1 := sha256.New()
+			hashObject(ctx, h1, o1, sums)
+			sum = h1.Sum(nil)
+			sums[objNr] = sum
+		}
+		h.Write(sum)
+
+	case types.Dict:
+		keys := make([]string, 0, len(o))
+		for k := range o {
+			if k != "Parent" {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		h.Write([]byte("<<"))
+		for _, k := range keys {
+			fmt.Fprintf(h, "/%s ", k)
+			hashObject(ctx, h, o[k], sums)
+		}
+		h.Write([]byte(">>"))
+
+	case types.StreamDict:
+		hashObject(ctx, h, o.Dict, sums)
+		h.Write(o.Raw)
+
+	case types.Array:
+		h.Write([]byte("["))
+		for _, o1 := range o {
+			hashObject(ctx, h, o1, sums)
+		}
+		h.Write([]byte("]"))
+
+	default:
+		h.Write([]byte(o.PDFString() + " "))
+	}
+}
+
+// PageHash returns a hash of page pageNr covering its content, resources and page boundaries
+// independent of object numbering.
+func PageHash(ctx *model.Context, pageNr int) (string, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return "", err
+	}
+
+	_, bb, _, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	h.Write(bb)
+
+	if inhPAttrs.MediaBox != nil {
+		fmt.Fprintf(h, "MediaBox %s ", rectKey(*inhPAttrs.MediaBox))
+	}
+	if inhPAttrs.CropBox != nil {
+		fmt.Fprintf(h, "CropBox %s ", rectKey(*inhPAttrs.CropBox))
+	}
+	fmt.Fprintf(h, "Rotate %d ", inhPAttrs.Rotate)
+
+	sums := map[int][]byte{}
+	hashObject(ctx, h, inhPAttrs.Resources, sums)
+	hashObject(ctx, h, d["Group"], sums)
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+var unitSquare = *types.NewRectangle(0, 0, 1, 1)
+
+type xObjectMarker struct {
+	ctx   *model.Context
+	marks []pageMark
+	forms types.IntSet // Form XObjects being processed.
+}
+
+func (xm *xObjectMarker) mark(id string, bbox types.Rectangle, ctm matrix.Matrix) pageMark {
+	pp := []types.Point{}
+	for _, p := range []types.Point{bbox.LL, {X: bbox.UR.X, Y: bbox.LL.Y}, bbox.UR, {X: bbox.LL.X, Y: bbox.UR.Y}} {
+		pp = append(pp, ctm.Transform(p))
+	}
+	r := boundingBox(pp)
+	return pageMark{key: id + " " + rectKey(r), rect: r, ink: area(r)}
+}
+
+// showsText returns true if content contains text showing operators.
+func showsText(content []byte) bool {
+	for _, op := range parseContentOps(content) {
+		switch op.name {
+		case "Tj", "TJ", "'", "\"":
+			return true
+		}
+	}
+	return false
+}
+
+func streamID(prefix string, bb []byte) string {
+	sum := sha256.Sum256(bb)
+	return prefix + hex.EncodeToString(sum[:8])
+}
+
+func (xm *xObjectMarker) xObject(operand string, resDict types.Dict, ctm matrix.Matrix) {
+	if resDict == nil {
+		return
+	}
+	d, err := xm.ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || d == nil {
+		return
+	}
+	name, err := types.DecodeName(strings.TrimPrefix(operand, "/"))
+	if err != nil {
+		return
+	}
+	indRef := d.IndirectRefEntry(name)
+	if indRef == nil {
+		return
+	}
+	objNr := indRef.ObjectNumber.Value()
+	sd, _, err := xm.ctx.DereferenceStreamDict(*indRef)
+	if err != nil || sd == nil || sd.Subtype() == nil {
+		return
+	}
+
+	switch *sd.Subtype() {
+
+	case "Image":
+		m := xm.mark(streamID("i", sd.Raw), unitSquare, ctm)
+		m.image, m.objNr, m.ctm = sd, objNr, ctm
+		xm.marks = append(xm.marks, m)
+
+	case "Form":
+		if xm.forms[objNr] {
+			return
+		}
+		if err := sd.Decode(); err != nil {
+			return
+		}
+		res, err := xm.ctx.DereferenceDict(sd.Dict["Resources"])
+		if err != nil {
+			return
+		}
+		if res == nil {
+			res = resDict
+		}
+		if m := numberArray(xm.ctx, sd.Dict["Matrix"]); len(m) == 6 {
+			ctm = matrixFromOperands(m).Multiply(ctm)
+		}
+		if bb := numberArray(xm.ctx, sd.Dict["BBox"]); len(bb) == 4 {
+			// Also covers text shown by forms.
+			m := xm.mark(streamID("f", sd.Content), *types.NewRectangle(bb[0], bb[1], bb[2], bb[3]), ctm)
+			m.ink, m.text = 0, showsText(sd.Content)
+			xm.marks = append(xm.marks, m)
+		}
+		xm.forms[objNr] = true
+		xm.process(sd.Content, res, ctm)
+		delete(xm.forms, objNr)
+	}
+}
+
+func (xm *xObjectMarker) process(bb []byte, resDict types.Dict, ctm matrix.Matrix) {
+	var stack []matrix.Matrix
+
+	for _, op := range parseContentOps(bb) {
+		switch op.name {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if n := len(stack); n > 0 {
+				ctm, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			if len(op.operands) == 6 {
+				ctm = matrixFromOperands(operandNumbers(op.operands)).Multiply(ctm)
+			}
+		case "Do":
+			if len(op.operands) == 1 {
+				xm.xObject(op.operands[0], resDict, ctm)
+			}
+		case "BI":
+			xm.marks = append(xm.marks, xm.mark(streamID("i", bb[op.beg:op.end]), unitSquare, ctm))
+		}
+	}
+}
+
+func pathKey(p model.VectorPath) string {
+	var sb strings.Builder
+	for _, seg := range p.Segments {
+		sb.WriteString(seg.Op)
+		for _, pt := range seg.Points {
+			fmt.Fprintf(&sb, " %.2f %.2f", round2(pt.X), round2(pt.Y))
+		}
+		sb.WriteString(" ")
+	}
+	fmt.Fprintf(&sb, "%t %t %t %.2f %v", p.Fill, p.Stroke, p.EvenOdd, round2(p.LineWidth), p.Dash)
+	if p.Fill && p.FillColor != nil {
+		fmt.Fprintf(&sb, " f%s%v", p.FillColor.Space, p.FillColor.Values)
+	}
+	if p.Stroke && p.StrokeColor != nil {
+		fmt.Fprintf(&sb, " s%s%v", p.StrokeColor.Space, p.StrokeColor.Values)
+	}
+	return sb.String()
+}
+
+func white(c *model.PathColor) bool {
+	if c == nil {
+		return false
+	}
+	r, g, b := c.RGB()
+	return r == 1 && g == 1 && b == 1
+}
+
+// pathInk returns the area of r unless path p gets painted white.
+func pathInk(p model.VectorPath, r types.Rectangle) float64 {
+	if (!p.Fill || white(p.FillColor)) && (!p.Stroke || white(p.StrokeColor)) {
+		return 0
+	}
+	return area(r)
+}
+
+// pageMarks returns the glyphs, painted paths and images of page pageNr.
+func pageMarks(ctx *model.Context, pageNr int) ([]pageMark, error) {
+	var marks []pageMark
+
+	gg, err := pageGlyphs(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+	for _, g := range gg {
+		if strings.TrimSpace(g.s) == "" {
+			continue
+		}
+		marks = append(marks, pageMark{key: "t" + g.s + " " + rectKey(g.rect), rect: g.rect, ink: area(g.rect), text: true})
+	}
+
+	vp, err := PageVectorPaths(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range vp.Paths {
+		if !p.Fill && !p.Stroke {
+			continue
+		}
+		pp := []types.Point{}
+		for _, seg := range p.Segments {
+			pp = append(pp, seg.Points...)
+		}
+		if len(pp) == 0 {
+			continue
+		}
+		r := boundingBox(pp)
+		if p.Stroke {
+			w := p.LineWidth / 2
+			r = *types.NewRectangle(r.LL.X-w, r.LL.Y-w, r.UR.X+w, r.UR.Y+w)
+		}
+		marks = append(marks, pageMark{key: "p" + pathKey(p), rect: r, ink: pathInk(p, r)})
+	}
+
+	_, bb, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+	xm := xObjectMarker{ctx: ctx, forms: types.IntSet{}}
+	xm.process(bb, resDict, matrix.IdentMatrix)
+
+	return append(marks, xm.marks...), nil
+}
+
+// unmatchedMarks returns the rectangles of marks1 missing in marks2.
+func unmatchedMarks(marks1, marks2 []pageMark) []types.Rectangle {
+	m := map[string]int{}
+	for _, mk := range marks2 {
+		m[mk.key]++
+	}
+	var rr []types.Rectangle
+	for _, mk := range marks1 {
+		if m[mk.key] > 0 {
+			m[mk.key]--
+			continue
+		}
+		rr = append(rr, mk.rect)
+	}
+	return rr
+}
+
+func near(r1, r2 types.Rectangle, gap float64) bool {
+	return r1.LL.X-gap <= r2.UR.X && r2.LL.X-gap <= r1.UR.X && r1.LL.Y-gap <= r2.UR.Y && r2.LL.Y-gap <= r1.UR.Y
+}
+
+// mergeRegions combines overlapping or adjacent rectangles.
+func mergeRegions(rr []types.Rectangle, gap float64) []types.Rectangle {
+	for merged := true; merged; {
+		merged = false
+		for i := 0; i < len(rr); i++ {
+			for j := i + 1; j < len(rr); j++ {
+				if !near(rr[i], rr[j], gap) {
+					continue
+				}
+				rr[i] = boundingBox([]types.Point{rr[i].LL, rr[i].UR, rr[j].LL, rr[j].UR})
+				rr = append(rr[:j], rr[j+1:]...)
+				merged = true
+				j--
+			}
+		}
+	}
+
+	for i, r := range rr {
+		rr[i] = *types.NewRectangle(round2(r.LL.X), round2(r.LL.Y), round2(r.UR.X), round2(r.UR.Y))
+	}
+
+	return rr
+}
+
+func comparePageMarks(ctx1, ctx2 *model.Context, pageNr int, pc *model.PageComparison) error {
+	marks1, err := pageMarks(ctx1, pageNr)
+	if err != nil {
+		return err
+	}
+	marks2, err := pageMarks(ctx2, pageNr)
+	if err != nil {
+		return err
+	}
+	pc.Removed = mergeRegions(unmatchedMarks(marks1, marks2), regionGap)
+	pc.Added = mergeRegions(unmatchedMarks(marks2, marks1), regionGap)
+	return nil
+}
+
+func compareProperties(ctx1, ctx2 *model.Context) []model.PropertyDiff {
+	var diffs []model.PropertyDiff
+
+	add := func(name, v1, v2 string) {
+		if v1 != v2 {
+			diffs = append(diffs, model.PropertyDiff{Name: name, Value1: v1, Value2: v2})
+		}
+	}
+
+	add("Version", ctx1.VersionString(), ctx2.VersionString())
+	add("Title", ctx1.Title, ctx2.Title)
+	add("Subject", ctx1.Subject, ctx2.Subject)
+	add("Author", ctx1.Author, ctx2.Author)
+	add("Creator", ctx1.Creator, ctx2.Creator)
+	add("Producer", ctx1.Producer, ctx2.Producer)
+	add("CreationDate", ctx1.XRefTable.CreationDate, ctx2.XRefTable.CreationDate)
+	add("ModDate", ctx1.ModDate, ctx2.ModDate)
+	add("Keywords", ctx1.Keywords, ctx2.Keywords)
+	add("Encrypted", fmt.Sprintf("%t", ctx1.Encrypt != nil), fmt.Sprintf("%t", ctx2.Encrypt != nil))
+
+	keys := []string{}
+	for k := range ctx1.Properties {
+		keys = append(keys, k)
+	}
+	for k := range ctx2.Properties {
+		if _, ok := ctx1.Properties[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		add(k, ctx1.Properties[k], ctx2.Properties[k])
+	}
+
+	return diffs
+}
+
+func comparePage(ctx1, ctx2 *model.Context, pageNr int, visual bool) (*model.PageComparison, error) {
+	pc := &model.PageComparison{PageNr: pageNr}
+
+	var err error
+
+	if pageNr <= ctx1.PageCount {
+		if pc.Hash1, err = PageHash(ctx1, pageNr); err != nil {
+			return nil, err
+		}
+	}
+	if pageNr <= ctx2.PageCount {
+		if pc.Hash2, err = PageHash(ctx2, pageNr); err != nil {
+			return nil, err
+		}
+	}
+
+	if pc.Equal = pc.Hash1 == pc.Hash2; pc.Equal {
+		return pc, nil
+	}
+
+	if pc.Hash1 == "" || pc.Hash2 == "" {
+		// Page only present in one of the files.
+		ctx, rr := ctx1, &pc.Removed
+		if pc.Hash1 == "" {
+			ctx, rr = ctx2, &pc.Added
+		}
+		_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+		if inhPAttrs.MediaBox != nil {
+			*rr = []types.Rectangle{*inhPAttrs.MediaBox}
+		}
+		return pc, nil
+	}
+
+	_, _, inhPAttrs1, err := ctx1.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	_, _, inhPAttrs2, err := ctx2.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if inhPAttrs1.MediaBox != nil && inhPAttrs2.MediaBox != nil {
+		pc.Resized = !inhPAttrs1.MediaBox.Equals(*inhPAttrs2.MediaBox)
+	}
+
+	if visual {
+		if err := comparePageMarks(ctx1, ctx2, pageNr, pc); err != nil {
+			return nil, err
+		}
+	}
+
+	return pc, nil
+}
+
+// Compare compares the document properties and pages of ctx1 and ctx2.
+// For visual comparison the glyphs, painted paths and images of differing pages get matched
+// by position in order to locate the changed regions.
+func Compare(ctx1, ctx2 *model.Context, visual bool) (*model.ComparisonReport, error) {
+	cr := &model.ComparisonReport{
+		PageCount1: ctx1.PageCount,
+		PageCount2: ctx2.PageCount,
+		Properties: compareProperties(ctx1, ctx2),
+	}
+
+	for pageNr := 1; pageNr <= max(ctx1.PageCount, ctx2.PageCount); pageNr++ {
+		pc, err := comparePage(ctx1, ctx2, pageNr, visual)
+		if err != nil {
+			return nil, err
+		}
+		if !pc.Equal {
+			cr.Pages = append(cr.Pages, *pc)
+		}
+	}
+
+	cr.Identical = len(cr.Properties) == 0 && len(cr.Pages) == 0
+
+	return cr, nil
+}
+
+func changeAnnotation(r types.Rectangle, contents string, col *color.SimpleColor) model.AnnotationRenderer {
+	return model.NewSquareAnnotation(r, 0, contents, "", "", model.AnnPrint, col, "pdfcpu compare", nil, nil, "", "",
+		nil, 0, 0, 0, 0, 1, model.BSSolid, false, 0)
+}
+
+// AnnotateChanges highlights the changed regions of cr on the pages of ctx being the second file compared.
+// Regions removed are framed red, regions added or changed pages without regions are framed green.
+func AnnotateChanges(ctx *model.Context, cr *model.ComparisonReport) error {
+	m := map[int][]model.AnnotationRenderer{}
+
+	for _, pc := range cr.Pages {
+		if pc.PageNr > ctx.PageCount {
+			continue
+		}
+
+		anns := []model.AnnotationRenderer{}
+		for _, r := range pc.Removed {
+			anns = append(anns, changeAnnotation(r, "removed", &color.Red))
+		}
+		for _, r := range pc.Added {
+			anns = append(anns, changeAnnotation(r, "added", &color.Green))
+		}
+
+		if len(anns) == 0 {
+			_, _, inhPAttrs, err := ctx.PageDict(pc.PageNr, false)
+			if err != nil {
+				return err
+			}
+			if inhPAttrs.MediaBox != nil {
+				anns = append(anns, changeAnnotation(*inhPAttrs.MediaBox, "changed", &color.Green))
+			}
+		}
+
+		m[pc.PageNr] = anns
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	_, err := AddAnnotationsMap(ctx, m, false)
+	return err
+}
+
+// ListComparison returns a human readable version of cr.
+func ListComparison(cr *model.ComparisonReport) []string {
+	ss := []string{}
+
+	if cr.File1 != "" {
+		ss = append(ss, fmt.Sprintf("%s <-> %s", cr.File1, cr.File2))
+	}
+
+	if cr.Identical {
+		return append(ss, "identical")
+	}
+
+	if cr.PageCount1 != cr.PageCount2 {
+		ss = append(ss, fmt.Sprintf("page count: %d <-> %d", cr.PageCount1, cr.PageCount2))
+	}
+
+	for _, pd := range cr.Properties {
+		ss = append(ss, fmt.Sprintf("%s: %q <-> %q", pd.Name, pd.Value1, pd.Value2))
+	}
+
+	for _, pc := range cr.Pages {
+		switch {
+		case pc.Hash1 == "":
+			ss = append(ss, fmt.Sprintf("page %d: added", pc.PageNr))
+		case pc.Hash2 == "":
+			ss = append(ss, fmt.Sprintf("page %d: removed", pc.PageNr))
+		default:
+			s := fmt.Sprintf("page %d: changed", pc.PageNr)
+			if pc.Resized {
+				s += ", resized"
+			}
+			ss = append(ss, s)
+			for _, r := range pc.Removed {
+				ss = append(ss, fmt.Sprintf("   removed %s", r.ShortString()))
+			}
+			for _, r := range pc.Added {
+				ss = append(ss, fmt.Sprintf("   added   %s", r.ShortString()))
+			}
+		}
+	}
+
+	return ss
+}
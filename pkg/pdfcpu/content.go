@@ -0,0 +1,177 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ParseContent parses content stream bb into a sequence of operators.
+func ParseContent(bb []byte) (model.Content, error) {
+	ops := parseContentOps(bb)
+
+	c := make(model.Content, 0, len(ops))
+
+	for _, op := range ops {
+		if op.name == "BI" {
+			c = append(c, model.ContentOperator{Name: op.name, Raw: string(bytes.TrimSpace(bb[op.beg:op.end]))})
+			continue
+		}
+
+		op1 := model.ContentOperator{Name: op.name, Operands: make([]types.Object, 0, len(op.operands))}
+		for _, s := range op.operands {
+			s1 := s
+			o, err := model.ParseObject(&s1)
+			if err != nil {
+				return nil, errors.Errorf("pdfcpu: corrupt content stream: invalid operand %q of operator %s", s, op.name)
+			}
+			op1.Operands = append(op1.Operands, o)
+		}
+
+		c = append(c, op1)
+	}
+
+	return c, nil
+}
+
+// PageContent returns the parsed content of page pageNr.
+func PageContent(ctx *model.Context, pageNr int) (model.Content, error) {
+	_, bb, _, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseContent(bb)
+}
+
+// EditContent replaces the content of selected pages with the content returned by fn.
+func EditContent(ctx *model.Context, selectedPages types.IntSet, fn model.ContentEditor) error {
+	if fn == nil {
+		return errors.New("pdfcpu: EditContent: missing content editor")
+	}
+
+	if err := ctx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, bb, resDict, err := pageContentAndResources(ctx, pageNr)
+		if err != nil {
+			return err
+		}
+
+		c, err := ParseContent(bb)
+		if err != nil {
+			return errors.Wrapf(err, "page %d", pageNr)
+		}
+
+		if c, err = fn(ctx, pageNr, c, resDict); err != nil {
+			return err
+		}
+
+		if err := replacePageContent(ctx, pageDict, c.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func newContentStream(ctx *model.Context, bb []byte) (*types.IndirectRef, error) {
+	sd, _ := ctx.NewStreamDictForBuf(bb)
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	return ctx.IndRefForNewObject(*sd)
+}
+
+// addPageResource adds ir to the resources of category (eg. Font, XObject) of page dict d
+// and returns its resource name starting with prefix.
+func addPageResource(ctx *model.Context, d types.Dict, inhPAttrs *model.InheritedPageAttrs, category, prefix string, ir types.IndirectRef) (string, error) {
+	resDict, err := ctx.DereferenceDict(d["Resources"])
+	if err != nil {
+		return "", err
+	}
+	if resDict == nil {
+		resDict = types.NewDict()
+		if inhPAttrs.Resources != nil {
+			resDict = inhPAttrs.Resources.Clone().(types.Dict)
+		}
+		d["Resources"] = resDict
+	}
+
+	catDict, err := ctx.DereferenceDict(resDict[category])
+	if err != nil {
+		return "", err
+	}
+	if catDict == nil {
+		catDict = types.NewDict()
+		resDict[category] = catDict
+	}
+
+	id := prefix
+	for i := 1; ; i++ {
+		if _, found := catDict.Find(id); !found {
+			break
+		}
+		id = prefix + strconv.Itoa(i)
+	}
+	catDict[id] = ir
+
+	return id, nil
+}
+
+// appendPageContent appends bb to the content of page dict d.
+// The existing content gets wrapped into q/Q so bb is not affected by any graphics state left behind.
+func appendPageContent(ctx *model.Context, d types.Dict, bb []byte) error {
+	var contents types.Array
+	o, err := ctx.Dereference(d["Contents"])
+	if err != nil {
+		return err
+	}
+	switch o := o.(type) {
+	case types.StreamDict:
+		contents = types.Array{d["Contents"]}
+	case types.Array:
+		contents = o
+	}
+
+	q, err := newContentStream(ctx, []byte("q\n"))
+	if err != nil {
+		return err
+	}
+
+	overlay, err := newContentStream(ctx, append([]byte("Q\n"), bb...))
+	if err != nil {
+		return err
+	}
+
+	a := types.Array{*q}
+	a = append(a, contents...)
+	d["Contents"] = append(a, *overlay)
+
+	return nil
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestParseContent(t *testing.T) {
+	in := "q 1 0 0 1 0.5 -2 cm /GS0 gs\n" +
+		"BT /F1 12 Tf [(Hello) -250 (World)] TJ ET\n" +
+		"/Span <</MCID 3>> BDC 0 g EMC\n" +
+		"BI /W 1 /H 1 /BPC 8 /CS /G ID \x00 EI\n" +
+		"<48656c6c6f> Tj Q"
+
+	c, err := ParseContent([]byte(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, op := range c {
+		names = append(names, op.Name)
+	}
+	want := []string{"q", "cm", "gs", "BT", "Tf", "TJ", "ET", "BDC", "g", "EMC", "BI", "Tj", "Q"}
+	if len(names) != len(want) {
+		t.Fatalf("got %v, want %v\n", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Fatalf("got %v, want %v\n", names, want)
+		}
+	}
+
+	if f, ok := c[1].Operands[4].(types.Float); !ok || f != 0.5 {
+		t.Fatalf("cm: got %v\n", c[1].Operands)
+	}
+	if n, ok := c[2].Operands[0].(types.Name); !ok || n != "GS0" {
+		t.Fatalf("gs: got %v\n", c[2].Operands)
+	}
+	if a, ok := c[5].Operands[0].(types.Array); !ok || len(a) != 3 {
+		t.Fatalf("TJ: got %v\n", c[5].Operands)
+	}
+	if d, ok := c[7].Operands[1].(types.Dict); !ok || d.IntEntry("MCID") == nil {
+		t.Fatalf("BDC: got %v\n", c[7].Operands)
+	}
+
+	// Recolor and serialize.
+	c[8] = model.NewContentOperator("rg", types.Float(1), types.Integer(0), types.Integer(0))
+
+	c1, err := ParseContent(c.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(c1) != len(c) {
+		t.Fatalf("round trip: got %d operators, want %d\n%s", len(c1), len(c), c)
+	}
+	for i := range c {
+		if c1[i].String() != c[i].String() {
+			t.Fatalf("round trip: got %q, want %q\n", c1[i], c[i])
+		}
+	}
+	if s := c1[8].String(); s != "1 0 0 rg" {
+		t.Fatalf("got %q\n", s)
+	}
+	if s := c1[1].String(); s != "1 0 0 1 0.5 -2 cm" {
+		t.Fatalf("got %q\n", s)
+	}
+}
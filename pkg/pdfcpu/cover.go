@@ -0,0 +1,296 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+type coverParamMap map[string]func(string, *model.Cover) error
+
+var covParamMap = coverParamMap{
+	"pages":      parseCoverPageCount,
+	"formsize":   parseCoverTrimSize,
+	"papersize":  parseCoverTrimSize,
+	"dimensions": parseCoverTrimDim,
+	"caliper":    parseCoverCaliper,
+	"grammage":   parseCoverGrammage,
+	"bulk":       parseCoverBulk,
+	"bleed":      parseCoverBleed,
+	"guides":     parseCoverGuides,
+	"front":      parseCoverFront,
+	"back":       parseCoverBack,
+	"bgcolor":    parseCoverBackgroundColor,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into cover.
+func (m coverParamMap) Handle(paramPrefix, paramValueStr string, cover *model.Cover) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, cover)
+}
+
+func parseCoverPageCount(s string, cover *model.Cover) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return errors.Errorf("pdfcpu: cover page count must be a positive integer: %s", s)
+	}
+	cover.PageCount = i
+	return nil
+}
+
+func parseCoverTrimSize(s string, cover *model.Cover) (err error) {
+	cover.TrimDim, _, err = types.ParsePageFormat(s)
+	return err
+}
+
+func parseCoverTrimDim(s string, cover *model.Cover) (err error) {
+	cover.TrimDim, _, err = ParsePageDim(s, cover.InpUnit)
+	return err
+}
+
+func parseCoverPositiveFloat(s, name string) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return 0, errors.Errorf("pdfcpu: cover %s must be a positive numeric value: %s", name, s)
+	}
+	return f, nil
+}
+
+func parseCoverCaliper(s string, cover *model.Cover) error {
+	f, err := parseCoverPositiveFloat(s, "caliper")
+	if err != nil {
+		return err
+	}
+	cover.Caliper = types.ToUserSpace(f, cover.InpUnit)
+	return nil
+}
+
+func parseCoverGrammage(s string, cover *model.Cover) (err error) {
+	cover.Grammage, err = parseCoverPositiveFloat(s, "grammage")
+	return err
+}
+
+func parseCoverBulk(s string, cover *model.Cover) (err error) {
+	cover.Bulk, err = parseCoverPositiveFloat(s, "bulk")
+	return err
+}
+
+func parseCoverBleed(s string, cover *model.Cover) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return errors.Errorf("pdfcpu: cover bleed must be a numeric value >= 0: %s", s)
+	}
+	cover.Bleed = types.ToUserSpace(f, cover.InpUnit)
+	return nil
+}
+
+func parseCoverGuides(s string, cover *model.Cover) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		cover.Guides = true
+	case "off", "false", "f":
+		cover.Guides = false
+	default:
+		return errors.New("pdfcpu: cover guides, please provide one of: on/off true/false t/f")
+	}
+	return nil
+}
+
+func parseCoverFront(s string, cover *model.Cover) error {
+	if !model.ImageFileName(s) {
+		return errors.Errorf("pdfcpu: cover front: unsupported image file: %s", s)
+	}
+	cover.Front = s
+	return nil
+}
+
+func parseCoverBack(s string, cover *model.Cover) error {
+	if !model.ImageFileName(s) {
+		return errors.Errorf("pdfcpu: cover back: unsupported image file: %s", s)
+	}
+	cover.Back = s
+	return nil
+}
+
+func parseCoverBackgroundColor(s string, cover *model.Cover) error {
+	c, err := color.ParseColor(s)
+	if err != nil {
+		return err
+	}
+	cover.BgColor = &c
+	return nil
+}
+
+// ParseCoverConfig parses a cover command string into an internal structure.
+func ParseCoverConfig(s string, conf *model.Configuration) (*model.Cover, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	cover := model.DefaultCoverConfig()
+	cover.InpUnit = conf.Unit
+
+	if s != "" {
+		for _, s := range strings.Split(s, ",") {
+			ss := strings.Split(s, ":")
+			if len(ss) != 2 {
+				return nil, errors.New("pdfcpu: Invalid cover configuration string. Please consult pdfcpu help cover")
+			}
+			paramPrefix := strings.TrimSpace(ss[0])
+			paramValueStr := strings.TrimSpace(ss[1])
+			if err := covParamMap.Handle(paramPrefix, paramValueStr, cover); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if cover.PageCount == 0 {
+		return nil, errors.New("pdfcpu: cover: missing page count")
+	}
+
+	return cover, nil
+}
+
+// drawCoverImage renders the image resource imgResID with dimensions w, h
+// scaled to fill region r preserving its aspect ratio and clipped to r.
+func drawCoverImage(wr io.Writer, r *types.Rectangle, imgResID string, w, h int) {
+	sc := math.Max(r.Width()/float64(w), r.Height()/float64(h))
+	sw, sh := float64(w)*sc, float64(h)*sc
+	x := r.LL.X + (r.Width()-sw)/2
+	y := r.LL.Y + (r.Height()-sh)/2
+	fmt.Fprintf(wr, "q %.2f %.2f %.2f %.2f re W n %.5f 0 0 %.5f %.5f %.5f cm /%s Do Q ",
+		r.LL.X, r.LL.Y, r.Width(), r.Height(), sw, sh, x, y, imgResID)
+}
+
+func addCoverImage(xRefTable *model.XRefTable, d types.Dict, wr io.Writer, fileName, imgResID string, r *types.Rectangle) error {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	imgIndRef, w, h, err := model.CreateImageResource(xRefTable, f)
+	if err != nil {
+		return err
+	}
+
+	d.Insert(imgResID, *imgIndRef)
+	drawCoverImage(wr, r, imgResID, w, h)
+
+	return nil
+}
+
+func drawCoverGuides(w io.Writer, cover *model.Cover) {
+	tb := cover.TrimBox()
+	sr := cover.SpineRect()
+
+	draw.DrawRect(w, tb, 0.5, &color.Gray, nil)
+
+	fmt.Fprint(w, "q [3] 0 d ")
+	draw.SetLineWidth(w, 0.5)
+	draw.SetStrokeColor(w, color.Gray)
+	draw.DrawLineSimple(w, sr.LL.X, tb.LL.Y, sr.LL.X, tb.UR.Y)
+	draw.DrawLineSimple(w, sr.UR.X, tb.LL.Y, sr.UR.X, tb.UR.Y)
+	fmt.Fprint(w, "Q ")
+}
+
+// CreateCover creates a single page PDF containing the cover spread of a perfect bound book.
+func CreateCover(cover *model.Cover, conf *model.Configuration) (*model.Context, error) {
+	dim := cover.Dim()
+	mb := types.RectForDim(dim.Width, dim.Height)
+
+	ctx, err := CreateContextWithXRefTable(conf, dim)
+	if err != nil {
+		return nil, err
+	}
+
+	pagesIndRef, err := ctx.Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	pagesDict, err := ctx.DereferenceDict(*pagesIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	d := types.NewDict()
+
+	if cover.BgColor != nil {
+		draw.FillRectNoBorder(&buf, mb, *cover.BgColor)
+	}
+
+	if cover.Back != "" {
+		if err := addCoverImage(ctx.XRefTable, d, &buf, cover.Back, "Im0", cover.BackRect()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cover.Front != "" {
+		if err := addCoverImage(ctx.XRefTable, d, &buf, cover.Front, "Im1", cover.FrontRect()); err != nil {
+			return nil, err
+		}
+	}
+
+	if cover.Guides {
+		drawCoverGuides(&buf, cover)
+	}
+
+	if err := addSheetPage(ctx, dim, d, model.FontMap{}, buf, pagesDict, pagesIndRef); err != nil {
+		return nil, err
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	pageDict.Insert("BleedBox", mb.Array())
+	pageDict.Insert("TrimBox", cover.TrimBox().Array())
+
+	return ctx, nil
+}
@@ -318,6 +318,12 @@ func CreatePage(
 		return nil, nil, err
 	}
 
+	if len(p.Tags) > 0 {
+		if err := xRefTable.TagMarkedContent(*pageDictIndRef, pageDict, p.MCIDBase, p.Tags); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	if len(p.AnnotTabs) == 0 && len(p.Annots) == 0 && len(p.LinkAnnots) == 0 {
 		return pageDictIndRef, pageDict, nil
 	}
@@ -340,6 +346,9 @@ func CreatePage(
 		if err != nil {
 			return nil, nil, err
 		}
+		if err := tagLinkAnnotation(xRefTable, p, *pageDictIndRef, *ir); err != nil {
+			return nil, nil, err
+		}
 		arr = append(arr, *ir)
 	}
 
@@ -348,6 +357,17 @@ func CreatePage(
 	return pageDictIndRef, pageDict, err
 }
 
+func tagLinkAnnotation(xRefTable *model.XRefTable, p *model.Page, pageIndRef, annotIndRef types.IndirectRef) error {
+	if !p.Tagged {
+		return nil
+	}
+	elem, err := xRefTable.AddStructElem(nil, model.StructTag{Type: "Link"})
+	if err != nil {
+		return err
+	}
+	return xRefTable.AddObjRef(*elem, pageIndRef, annotIndRef)
+}
+
 // UpdatePage updates the existing page dict d with content provided by p.
 func UpdatePage(xRefTable *model.XRefTable, dIndRef types.IndirectRef, d, res types.Dict, p *model.Page, fonts model.FontMap) error {
 
@@ -363,6 +383,12 @@ func UpdatePage(xRefTable *model.XRefTable, dIndRef types.IndirectRef, d, res ty
 		return err
 	}
 
+	if len(p.Tags) > 0 {
+		if err := xRefTable.TagMarkedContent(dIndRef, d, p.MCIDBase, p.Tags); err != nil {
+			return err
+		}
+	}
+
 	if len(p.AnnotTabs) == 0 && len(p.Annots) == 0 && len(p.LinkAnnots) == 0 {
 		return nil
 	}
@@ -390,6 +416,9 @@ func UpdatePage(xRefTable *model.XRefTable, dIndRef types.IndirectRef, d, res ty
 		if err != nil {
 			return err
 		}
+		if err := tagLinkAnnotation(xRefTable, p, dIndRef, *ir); err != nil {
+			return err
+		}
 		arr = append(arr, *ir)
 	}
 
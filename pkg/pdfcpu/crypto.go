@@ -31,11 +31,12 @@ import (
 	"encoding/hex"
 	"fmt"
 	"io"
+	"log/slog"
 	"math/big"
 	"strconv"
 	"time"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -44,6 +45,9 @@ import (
 	"golang.org/x/text/unicode/norm"
 )
 
+// The crypt filter passing data through unchanged.
+const identityCryptFilter = "Identity"
+
 var (
 	pad = []byte{
 		0x28, 0xBF, 0x4E, 0x5E, 0x4E, 0x75, 0x8A, 0x41, 0x64, 0x00, 0x4E, 0x56, 0xFF, 0xFA, 0x01, 0x08,
@@ -124,6 +128,66 @@ var (
 		model.SETVIEWERPREFERENCES:    {0, 1},
 		model.RESETVIEWERPREFERENCES:  {0, 1},
 		model.ZOOM:                    {0, 1},
+		model.IMPOSE:                  {0, 1},
+		model.MANUALDUPLEX:            {1, 0},
+		model.CREATECOVER:             {0, 0},
+		model.BATES:                   {0, 1},
+		model.HEADERFOOTER:            {0, 1},
+		model.LISTPAGELABELS:          {0, 1},
+		model.SETPAGELABELS:           {0, 1},
+		model.REMOVEPAGELABELS:        {0, 1},
+		model.LISTNAMEDDESTS:          {0, 1},
+		model.ADDNAMEDDESTS:           {0, 1},
+		model.REMOVENAMEDDESTS:        {0, 1},
+		model.RETARGETNAMEDDESTS:      {0, 1},
+		model.ADDAUTOLINKS:            {0, 1},
+		model.DETECTBOOKMARKS:         {0, 1},
+		model.ADDTOC:                  {0, 1},
+		model.CREATEPORTFOLIO:         {0, 1},
+		model.ADDINVOICE:              {0, 1},
+		model.LISTMETADATA:            {0, 0},
+		model.SETMETADATA:             {0, 1},
+		model.CHECKMETADATA:           {0, 0},
+		model.SYNCMETADATA:            {0, 1},
+		model.CONVERTCMYK:             {0, 1},
+		model.CONVERTGRAY:             {0, 1},
+		model.LISTOUTPUTINTENTS:       {0, 1},
+		model.ADDOUTPUTINTENT:         {0, 1},
+		model.REPLACEOUTPUTINTENT:     {0, 1},
+		model.FLATTENTRANSPARENCY:     {0, 1},
+		model.LISTLAYERS:              {0, 0},
+		model.SHOWLAYERS:              {0, 1},
+		model.HIDELAYERS:              {0, 1},
+		model.REMOVELAYERS:            {0, 1},
+		model.FLATTENLAYERS:           {0, 1},
+		model.EDITCONTENT:             {0, 1},
+		model.EXTRACTVECTORPATHS:      {1, 0},
+		model.REPAIR:                  {0, 1},
+		model.COMPARE:                 {1, 0},
+		model.ANALYZEPAGES:            {1, 0},
+		model.SANITIZE:                {0, 1},
+		model.EXTRACTIMAGESLOSSLESS:   {1, 0},
+		model.ADDOCRTEXT:              {0, 1},
+		model.EXTRACTTABLES:           {1, 0},
+		model.EXPORTANNOTATIONS:       {1, 0},
+		model.IMPORTANNOTATIONS:       {0, 1},
+		model.FLATTENANNOTATIONS:      {0, 1},
+		model.LISTPAGETRANSITIONS:     {1, 0},
+		model.SETPAGETRANSITIONS:      {0, 1},
+		model.REMOVEPAGETRANSITIONS:   {0, 1},
+		model.SETFULLSCREEN:           {0, 1},
+		model.LISTACTIONS:             {1, 0},
+		model.SETACTIONS:              {0, 1},
+		model.REMOVEACTIONS:           {0, 1},
+		model.LISTGEOVIEWPORTS:        {1, 0},
+		model.ADDGEOVIEWPORTS:         {0, 1},
+		model.REMOVEGEOVIEWPORTS:      {0, 1},
+		model.LISTARTICLES:            {1, 0},
+		model.ADDARTICLES:             {0, 1},
+		model.AUTOCROP:                {0, 1},
+		model.BLEED:                   {0, 1},
+		model.AUTOROTATE:              {0, 1},
+		model.PIPELINE:                {0, 1},
 	}
 
 	ErrUnknownEncryption = errors.New("pdfcpu: unknown encryption")
@@ -887,14 +951,13 @@ func writePermissions(ctx *model.Context, d types.Dict) error {
 	return nil
 }
 
-func logP(enc *model.Enc) {
-	if !log.InfoEnabled() {
+func logP(ctx *model.Context) {
+	if !ctx.LogEnabled(slog.LevelInfo) {
 		return
 	}
-	for _, s := range perms(enc.P) {
-		log.Info.Println(s)
+	for _, s := range perms(ctx.E.P) {
+		ctx.LogInfo(s)
 	}
-
 }
 
 func maskExtract(mode model.CommandMode, secHandlerRev int) int {
@@ -932,10 +995,12 @@ func maskModify(mode model.CommandMode, secHandlerRev int) int {
 }
 
 // HasNeededPermissions returns true if permissions for pdfcpu processing are present.
-func hasNeededPermissions(mode model.CommandMode, enc *model.Enc) bool {
+func hasNeededPermissions(ctx *model.Context) bool {
 	// see 7.6.3.2
 
-	logP(enc)
+	mode, enc := ctx.Cmd, ctx.E
+
+	logP(ctx)
 
 	m := maskExtract(mode, enc.R)
 	if m > 0 {
@@ -973,7 +1038,7 @@ func getV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 	return v, nil
 }
 func checkStmf(ctx *model.Context, stmf *string, cfDict types.Dict) error {
-	if stmf != nil && *stmf != "Identity" {
+	if stmf != nil && *stmf != identityCryptFilter {
 
 		d := cfDict.DictEntry(*stmf)
 		if d == nil {
@@ -996,6 +1061,9 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 		return nil, err
 	}
 
+	ctx.CryptFilters = nil
+	ctx.StmF, ctx.StrF, ctx.EFF = "", "", ""
+
 	// v == 2 implies RC4
 	if *v != 4 && *v != 5 {
 		return v, nil
@@ -1007,16 +1075,22 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 		return nil, errors.Errorf("pdfcpu: checkV: required entry \"CF\" missing.")
 	}
 
+	ctx.CryptFilters = cryptFilters(cfDict)
+	ctx.StmF, ctx.StrF = identityCryptFilter, identityCryptFilter
+
 	// StmF
 	stmf := d.NameEntry("StmF")
 	err = checkStmf(ctx, stmf, cfDict)
 	if err != nil {
 		return nil, err
 	}
+	if stmf != nil {
+		ctx.StmF = *stmf
+	}
 
 	// StrF
 	strf := d.NameEntry("StrF")
-	if strf != nil && *strf != "Identity" {
+	if strf != nil && *strf != identityCryptFilter {
 		d1 := cfDict.DictEntry(*strf)
 		if d1 == nil {
 			return nil, errors.Errorf("pdfcpu: checkV: entry \"%s\" missing in \"CF\"", *strf)
@@ -1027,10 +1101,13 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 		}
 		ctx.AES4Strings = aes
 	}
+	if strf != nil {
+		ctx.StrF = *strf
+	}
 
 	// EFF
 	eff := d.NameEntry("EFF")
-	if eff != nil && *eff != "Identity" {
+	if eff != nil && *eff != identityCryptFilter {
 		d := cfDict.DictEntry(*eff)
 		if d == nil {
 			return nil, errors.Errorf("pdfcpu: checkV: entry \"%s\" missing in \"CF\"", *eff)
@@ -1041,10 +1118,122 @@ func checkV(ctx *model.Context, d types.Dict, l int) (*int, error) {
 		}
 		ctx.AES4EmbeddedStreams = aes
 	}
+	ctx.EFF = ctx.StmF
+	if eff != nil {
+		ctx.EFF = *eff
+	} else {
+		ctx.AES4EmbeddedStreams = ctx.AES4Streams
+	}
 
 	return v, nil
 }
 
+// cryptFilters returns the supported crypt filters of cfDict mapped to their use of AES.
+func cryptFilters(cfDict types.Dict) map[string]bool {
+	m := map[string]bool{}
+	for k, v := range cfDict {
+		d, ok := v.(types.Dict)
+		if !ok {
+			continue
+		}
+		if aes, err := supportedCFEntry(d); err == nil {
+			m[k] = aes
+		}
+	}
+	return m
+}
+
+// cryptFilter returns true if the crypt filter name encrypts and whether it uses AES.
+func cryptFilter(ctx *model.Context, name string) (bool, bool, error) {
+	if name == identityCryptFilter {
+		return false, false, nil
+	}
+	aes, ok := ctx.CryptFilters[name]
+	if !ok {
+		return false, false, errors.Errorf("pdfcpu: unsupported crypt filter: %s", name)
+	}
+	return true, aes, nil
+}
+
+// streamCryptFilterName returns the name of the crypt filter set in the filter pipeline of sd.
+func streamCryptFilterName(sd *types.StreamDict) (string, bool) {
+	if len(sd.FilterPipeline) == 0 || sd.FilterPipeline[0].Name != filter.Crypt {
+		return "", false
+	}
+	if d := sd.FilterPipeline[0].DecodeParms; d != nil {
+		if n := d.NameEntry("Name"); n != nil {
+			return *n, true
+		}
+	}
+	return identityCryptFilter, true
+}
+
+// streamEncryption returns true if sd needs to be en/decrypted and whether to use AES.
+func streamEncryption(ctx *model.Context, sd *types.StreamDict) (bool, bool, error) {
+	if ctx.EncKey == nil {
+		return false, false, nil
+	}
+
+	if t := sd.Type(); t != nil {
+		if *t == "XRef" {
+			// XRefStreams are not encrypted.
+			return false, false, nil
+		}
+		if *t == "Metadata" && !ctx.E.Emd {
+			return false, false, nil
+		}
+	}
+
+	if name, ok := streamCryptFilterName(sd); ok {
+		return cryptFilter(ctx, name)
+	}
+
+	name := ctx.StmF
+	if t := sd.Type(); t != nil && *t == "EmbeddedFile" {
+		name = ctx.EFF
+	}
+
+	if name == "" {
+		// No crypt filters for V < 4.
+		return true, ctx.AES4Streams, nil
+	}
+
+	return cryptFilter(ctx, name)
+}
+
+// removeCryptFilter removes a leading Crypt filter from the filter pipeline of sd.
+func removeCryptFilter(sd *types.StreamDict) {
+	if _, ok := streamCryptFilterName(sd); !ok {
+		return
+	}
+
+	sd.FilterPipeline = sd.FilterPipeline[1:]
+
+	if len(sd.FilterPipeline) == 0 {
+		sd.FilterPipeline = nil
+		sd.Delete("Filter")
+		sd.Delete("DecodeParms")
+		return
+	}
+
+	if a, ok := sd.Find("Filter"); ok {
+		if a, ok := a.(types.Array); ok && len(a) > 1 {
+			sd.Update("Filter", a[1:])
+		}
+	}
+
+	if a, ok := sd.Find("DecodeParms"); ok {
+		if a, ok := a.(types.Array); ok && len(a) > 1 {
+			sd.Update("DecodeParms", a[1:])
+		}
+	}
+}
+
+// stringEncryption returns true if strings need to be en/decrypted.
+func stringEncryption(ctx *model.Context) bool {
+	return ctx.EncKey != nil && ctx.StrF != identityCryptFilter
+}
+
 func length(d types.Dict) (int, error) {
 	l := d.IntEntry("Length")
 	if l == nil {
@@ -1169,8 +1358,11 @@ func validateOAndU(ctx *model.Context, d types.Dict, r int) (o, u []byte, err er
 func supportedEncryption(ctx *model.Context, d types.Dict) (*model.Enc, error) {
 	// Filter
 	filter := d.NameEntry("Filter")
+	if filter != nil && *filter == pubSecFilter {
+		return supportedPubSecEncryption(ctx, d)
+	}
 	if filter == nil || *filter != "Standard" {
-		return nil, errors.New("pdfcpu: unsupported encryption: filter must be \"Standard\"")
+		return nil, errors.New("pdfcpu: unsupported encryption: filter must be \"Standard\" or \"Adobe.PubSec\"")
 	}
 
 	// SubFilter
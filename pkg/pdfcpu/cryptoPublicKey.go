@@ -0,0 +1,315 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"io"
+	"sync"
+
+	"github.com/hhrutter/pkcs7"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// See 7.6.5 Public-key security handlers.
+
+const (
+	pubSecFilter      = "Adobe.PubSec"
+	pubSecSubFilterS4 = "adbe.pkcs7.s4"
+	pubSecSubFilterS5 = "adbe.pkcs7.s5"
+	pubSecCryptFilter = "DefaultCryptFilter"
+	pubSecSeedLength  = 20
+)
+
+var ErrNoRecipient = errors.New("pdfcpu: this file is encrypted for other recipients")
+
+// pkcs7Mu serializes the use of pkcs7.ContentEncryptionAlgorithm.
+var pkcs7Mu sync.Mutex
+
+// pkcs7Encrypt creates an AES-256 enveloped data for recipients.
+// pkcs7.Encrypt takes the content encryption algorithm from a package variable which is set
+// for the duration of the call only and restored afterwards.
+// This does not guard against applications accessing this variable concurrently.
+func pkcs7Encrypt(content []byte, recipients []*x509.Certificate) ([]byte, error) {
+	pkcs7Mu.Lock()
+	defer pkcs7Mu.Unlock()
+
+	alg := pkcs7.ContentEncryptionAlgorithm
+	pkcs7.ContentEncryptionAlgorithm = pkcs7.EncryptionAlgorithmAES256CBC
+	defer func() { pkcs7.ContentEncryptionAlgorithm = alg }()
+
+	return pkcs7.Encrypt(content, recipients)
+}
+
+// newPubSecEncryptDict creates a new EncryptDict using the public key security handler.
+func newPubSecEncryptDict(needAES bool, keyLength int, encryptMetadata bool, recipients [][]byte) types.Dict {
+	d := types.NewDict()
+
+	d.Insert("Filter", types.Name(pubSecFilter))
+	d.Insert("SubFilter", types.Name(pubSecSubFilterS5))
+	d.Insert("Length", types.Integer(keyLength))
+
+	v := 4
+	if keyLength == 256 {
+		v = 5
+	}
+	d.Insert("V", types.Integer(v))
+
+	d.Insert("StmF", types.Name(pubSecCryptFilter))
+	d.Insert("StrF", types.Name(pubSecCryptFilter))
+
+	d1 := types.NewDict()
+	d1.Insert("AuthEvent", types.Name("DocOpen"))
+
+	cfm := "V2"
+	if needAES {
+		cfm = "AESV2"
+		if keyLength == 256 {
+			cfm = "AESV3"
+		}
+	}
+	d1.Insert("CFM", types.Name(cfm))
+	d1.Insert("Length", types.Integer(keyLength/8))
+
+	a := types.Array{}
+	for _, bb := range recipients {
+		a = append(a, types.NewHexLiteral(bb))
+	}
+	d1.Insert("Recipients", a)
+
+	if !encryptMetadata {
+		d1.Insert("EncryptMetadata", types.Boolean(false))
+	}
+
+	d2 := types.NewDict()
+	d2.Insert(pubSecCryptFilter, d1)
+
+	d.Insert("CF", d2)
+
+	return d
+}
+
+func recipientsEntry(o types.Object) ([][]byte, error) {
+	a, ok := o.(types.Array)
+	if !ok || len(a) == 0 {
+		return nil, errors.New("pdfcpu: unsupported encryption: missing or invalid entry \"Recipients\"")
+	}
+
+	var bbb [][]byte
+	for _, o := range a {
+		var (
+			bb  []byte
+			err error
+		)
+		switch o := o.(type) {
+		case types.StringLiteral:
+			bb, err = types.Unescape(o.Value())
+		case types.HexLiteral:
+			bb, err = o.Bytes()
+		default:
+			err = errors.New("pdfcpu: unsupported encryption: invalid entry \"Recipients\"")
+		}
+		if err != nil {
+			return nil, err
+		}
+		bbb = append(bbb, bb)
+	}
+
+	return bbb, nil
+}
+
+// supportedPubSecEncryption returns a pointer to a struct encapsulating used public key encryption.
+func supportedPubSecEncryption(ctx *model.Context, d types.Dict) (*model.Enc, error) {
+	subFilter := d.NameEntry("SubFilter")
+	if subFilter == nil || (*subFilter != pubSecSubFilterS4 && *subFilter != pubSecSubFilterS5) {
+		return nil, errors.New("pdfcpu: unsupported encryption: \"SubFilter\" must be adbe.pkcs7.s4 or adbe.pkcs7.s5")
+	}
+
+	l, err := length(d)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := checkV(ctx, d, l)
+	if err != nil {
+		return nil, err
+	}
+
+	encMeta := true
+	if emd := d.BooleanEntry("EncryptMetadata"); emd != nil {
+		encMeta = *emd
+	}
+
+	var recipients [][]byte
+
+	if *v < 4 {
+		if *subFilter != pubSecSubFilterS4 {
+			return nil, errors.New("pdfcpu: unsupported encryption: adbe.pkcs7.s5 needs crypt filters")
+		}
+		if recipients, err = recipientsEntry(d["Recipients"]); err != nil {
+			return nil, err
+		}
+	} else {
+		// The recipients belong to the crypt filter used for streams.
+		stmf := d.NameEntry("StmF")
+		cfDict := d.DictEntry("CF")
+		if stmf == nil || cfDict == nil || cfDict.DictEntry(*stmf) == nil {
+			return nil, errors.New("pdfcpu: unsupported encryption: missing crypt filter")
+		}
+		d1 := cfDict.DictEntry(*stmf)
+		if recipients, err = recipientsEntry(d1["Recipients"]); err != nil {
+			return nil, err
+		}
+		if emd := d1.BooleanEntry("EncryptMetadata"); emd != nil {
+			encMeta = *emd
+		}
+		if l1 := d1.IntEntry("Length"); l1 != nil && *l1 <= 32 {
+			l = *l1 * 8
+		}
+	}
+
+	// Public key encryption follows the standard security handler except for the way the file key is calculated.
+	r := 4
+	if *v < 4 {
+		r = 3
+	}
+	if *v == 5 {
+		r = 6
+	}
+
+	return &model.Enc{L: l, R: r, V: *v, Emd: encMeta, Recipients: recipients}, nil
+}
+
+func pubSecSeedAndPermissions(seed []byte, p int) []byte {
+	bb := make([]byte, pubSecSeedLength+4)
+	copy(bb, seed)
+	binary.BigEndian.PutUint32(bb[pubSecSeedLength:], uint32(int32(p)))
+	return bb
+}
+
+// pubSecFileKey calculates the file encryption key using algorithm 7.6.5.3.
+func pubSecFileKey(enc *model.Enc, seed []byte) []byte {
+	h := sha1.New()
+	if enc.V == 5 {
+		h = sha256.New()
+	}
+
+	h.Write(seed)
+	for _, bb := range enc.Recipients {
+		h.Write(bb)
+	}
+	if !enc.Emd {
+		h.Write([]byte{0xFF, 0xFF, 0xFF, 0xFF})
+	}
+
+	key := h.Sum(nil)
+	if n := enc.L / 8; enc.V > 1 && n < len(key) {
+		key = key[:n]
+	}
+	if enc.V == 1 {
+		key = key[:5]
+	}
+
+	return key
+}
+
+// decryptPubSecKey sets up the file encryption key for the recipient configured.
+func decryptPubSecKey(ctx *model.Context) error {
+	if ctx.RecipientCert == nil || ctx.RecipientKey == nil {
+		return errors.New("pdfcpu: this file is encrypted for recipients, please provide a certificate and its private key")
+	}
+
+	for _, bb := range ctx.E.Recipients {
+		p7, err := pkcs7.Parse(bb)
+		if err != nil {
+			return errors.Wrap(err, "pdfcpu: invalid recipient")
+		}
+		content, err := p7.Decrypt(ctx.RecipientCert, ctx.RecipientKey)
+		if err != nil {
+			// Encrypted for other recipients.
+			continue
+		}
+		if len(content) < pubSecSeedLength+4 {
+			return errors.New("pdfcpu: invalid recipient: corrupt seed")
+		}
+
+		ctx.E.P = int(int32(binary.BigEndian.Uint32(content[pubSecSeedLength:])))
+		ctx.EncKey = pubSecFileKey(ctx.E, content[:pubSecSeedLength])
+
+		// Double check minimum permissions for pdfcpu processing.
+		if !hasNeededPermissions(ctx) {
+			return errors.New("pdfcpu: operation restricted via pdfcpu's permission bits setting")
+		}
+
+		return nil
+	}
+
+	return ErrNoRecipient
+}
+
+// setupPubSecEncryption prepares writing ctx encrypted for the configured recipients.
+func setupPubSecEncryption(ctx *model.Context) error {
+	if ctx.EncryptKeyLength < 128 {
+		return errors.New("pdfcpu: public key encryption needs a key length of 128 or 256 bits")
+	}
+
+	seed := make([]byte, pubSecSeedLength)
+	if _, err := io.ReadFull(rand.Reader, seed); err != nil {
+		return err
+	}
+
+	// Like the standard security handler the unused high order permission bits are set.
+	p := int(int16(ctx.Permissions))
+
+	// All recipients share the same permissions and therefore one envelope.
+	bb, err := pkcs7Encrypt(pubSecSeedAndPermissions(seed, p), ctx.Recipients)
+	if err != nil {
+		return errors.Wrap(err, "pdfcpu: encrypt for recipients")
+	}
+
+	d := newPubSecEncryptDict(ctx.EncryptUsingAES, ctx.EncryptKeyLength, true, [][]byte{bb})
+
+	if ctx.E, err = supportedPubSecEncryption(ctx, d); err != nil {
+		return err
+	}
+
+	ctx.E.P = p
+	ctx.EncKey = pubSecFileKey(ctx.E, seed)
+
+	if ctx.ID != nil {
+		if ctx.E.ID, err = ctx.IDFirstElement(); err != nil {
+			return err
+		}
+	}
+
+	xRefTableEntry := model.NewXRefTableEntryGen0(d)
+
+	objNumber, err := ctx.InsertAndUseRecycled(*xRefTableEntry)
+	if err != nil {
+		return err
+	}
+
+	ctx.Encrypt = types.NewIndirectRef(objNumber, 0)
+
+	return nil
+}
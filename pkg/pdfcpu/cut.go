@@ -380,6 +380,77 @@ func handleCutMargin(ctxSrc *model.Context, d, d1 types.Dict, pageNr int, cropBo
 	return nil
 }
 
+// tileLabel returns the assembly label for the tile in row i and column j eg. "B3".
+func tileLabel(i, j int) string {
+	s := ""
+	for j++; j > 0; j = (j - 1) / 26 {
+		s = string(rune('A'+(j-1)%26)) + s
+	}
+	return fmt.Sprintf("%s%d", s, i+1)
+}
+
+func drawAlignmentMark(w io.Writer, x, y float64) {
+	draw.DrawCircle(w, x, y, 3, color.Gray, nil)
+	draw.DrawLineSimple(w, x-6, y, x+6, y)
+	draw.DrawLineSimple(w, x, y-6, x, y+6)
+}
+
+// drawAlignmentMarks draws alignment marks into the centers of the overlap areas of a tile cb.
+// Adjacent tiles share these marks, which makes it easy to line them up during assembly.
+func drawAlignmentMarks(w io.Writer, cb *types.Rectangle, left, top, right, bottom bool, overlap float64) {
+	fmt.Fprint(w, "q 0.5 w ")
+	draw.SetStrokeColor(w, color.Gray)
+
+	d := overlap / 2
+	for _, f := range []float64{.25, .75} {
+		x := cb.LL.X + f*cb.Width()
+		y := cb.LL.Y + f*cb.Height()
+		if left {
+			drawAlignmentMark(w, cb.LL.X+d, y)
+		}
+		if right {
+			drawAlignmentMark(w, cb.UR.X-d, y)
+		}
+		if top {
+			drawAlignmentMark(w, x, cb.UR.Y-d)
+		}
+		if bottom {
+			drawAlignmentMark(w, x, cb.LL.Y+d)
+		}
+	}
+
+	fmt.Fprint(w, "Q ")
+}
+
+func addAlignmentMarks(ctxSrc *model.Context, d, d1 types.Dict, pageNr int, cb *types.Rectangle, left, top, right, bottom bool, cut *model.Cut) error {
+	bb, err := ctxSrc.PageContent(d, pageNr)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	drawAlignmentMarks(&buf, cb, left, top, right, bottom, cut.Overlap)
+
+	bb = append([]byte("q "), bb...)
+	bb = append(bb, []byte(" Q ")...)
+	bb = append(bb, buf.Bytes()...)
+
+	sd, _ := ctxSrc.NewStreamDictForBuf(bb)
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	indRef, err := ctxSrc.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	d1["Contents"] = *indRef
+
+	return nil
+}
+
+// createTiles creates a page for each tile and returns the tile labels in page order.
 func createTiles(
 	ctxSrc, ctxDest *model.Context,
 	pagesIndRef types.IndirectRef,
@@ -388,9 +459,10 @@ func createTiles(
 	cropBox *types.Rectangle,
 	inhPAttrs *model.InheritedPageAttrs,
 	migrated map[int]int,
-	cut *model.Cut) error {
+	cut *model.Cut) ([]string, error) {
 
 	var sc float64
+	labels := []string{}
 
 	for i := 0; i < len(cut.Hor); i++ {
 		ury := cropBox.UR.Y - cut.Hor[i]*cropBox.Height()
@@ -402,6 +474,12 @@ func createTiles(
 			lly = cropBox.UR.Y - cut.Hor[i+1]*cropBox.Height()
 		}
 
+		// Extend this tile by the overlap into the tile below.
+		bottom := cut.Overlap > 0 && lly > cropBox.LL.Y
+		if bottom {
+			lly = math.Max(lly-cut.Overlap, cropBox.LL.Y)
+		}
+
 		h := ury - lly
 
 		for j := 0; j < len(cut.Vert); j++ {
@@ -413,6 +491,13 @@ func createTiles(
 			if j+1 < len(cut.Vert) {
 				urx = cropBox.LL.X + cut.Vert[j+1]*cropBox.Width()
 			}
+
+			// Extend this tile by the overlap into the tile to the right.
+			right := cut.Overlap > 0 && urx < cropBox.UR.X
+			if right {
+				urx = math.Min(urx+cut.Overlap, cropBox.UR.X)
+			}
+
 			w := urx - llx
 
 			cb := types.NewRectangle(llx, lly, urx, ury)
@@ -425,30 +510,52 @@ func createTiles(
 
 			if cut.Margin > 0 {
 				if err := handleCutMargin(ctxSrc, d, d1, pageNr, cropBox, cb, i, j, w, h, &sc, cut); err != nil {
-					return err
+					return nil, err
+				}
+			} else if cut.Labels && cut.Overlap > 0 {
+				if err := addAlignmentMarks(ctxSrc, d, d1, pageNr, cb, j > 0, i > 0, right, bottom, cut); err != nil {
+					return nil, err
 				}
 			}
 
 			pageIndRef, err := ctxDest.IndRefForNewObject(d1)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			if err := ctxDest.SetValid(*pageIndRef); err != nil {
-				return err
+				return nil, err
 			}
 
 			if err := migratePageDict(d1, *pageIndRef, ctxSrc, ctxDest, migrated); err != nil {
-				return err
+				return nil, err
 			}
 
 			if err := model.AppendPageTree(pageIndRef, 1, pagesDict); err != nil {
-				return err
+				return nil, err
 			}
+
+			labels = append(labels, tileLabel(i, j))
 		}
 	}
 
-	return nil
+	return labels, nil
+}
+
+// addTileLabels stamps the tile labels onto the tile pages of ctx following the outline page.
+func addTileLabels(ctx *model.Context, labels []string) error {
+	// The outline page is followed by the tiles.
+	ctx.PageCount = len(labels) + 1
+
+	m := map[int]*model.Watermark{}
+	for i, s := range labels {
+		wm, err := ParseTextWatermarkDetails(s, "font:Helvetica, points:12, pos:tl, off:10 -10, fillc:#808080, op:.6, rot:0, scale:1 abs", true, types.POINTS)
+		if err != nil {
+			return err
+		}
+		m[i+2] = wm
+	}
+	return AddWatermarksMap(ctx, m)
 }
 
 func CutPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Context, error) {
@@ -482,10 +589,17 @@ func CutPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Context,
 		return nil, err
 	}
 
-	if err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
+	labels, err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut)
+	if err != nil {
 		return nil, err
 	}
 
+	if cut.Labels {
+		if err := addTileLabels(ctxDest, labels); err != nil {
+			return nil, err
+		}
+	}
+
 	return ctxDest, nil
 }
 
@@ -558,15 +672,23 @@ func NDownPage(ctxSrc *model.Context, pageNr, n int, cut *model.Cut) (*model.Con
 		return nil, err
 	}
 
-	if err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
+	labels, err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut)
+	if err != nil {
 		return nil, err
 	}
 
+	if cut.Labels {
+		if err := addTileLabels(ctxDest, labels); err != nil {
+			return nil, err
+		}
+	}
+
 	return ctxDest, nil
 }
 
 func createPosterCuts(cropBox *types.Rectangle, cut *model.Cut) {
-	dim := cut.PageDim
+	// Adjacent tiles overlap, so each tile covers the tile dimensions.
+	dim := &types.Dim{Width: cut.PageDim.Width - cut.Overlap, Height: cut.PageDim.Height - cut.Overlap}
 
 	cut.Vert = []float64{0.}
 	for x := 0.; ; x += dim.Width {
@@ -612,6 +734,15 @@ func PosterPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Conte
 		return nil, errors.New("pdfcpu: selected poster tile dimensions too big")
 	}
 
+	if cut.Overlap > 0 {
+		if cut.Margin > 0 {
+			return nil, errors.New("pdfcpu: poster: please use either overlap or margin")
+		}
+		if cut.Overlap >= dim.Width/2 || cut.Overlap >= dim.Height/2 {
+			return nil, errors.New("pdfcpu: poster: overlap must be less than half of the tile dimensions")
+		}
+	}
+
 	rotate := inhPAttrs.Rotate
 
 	if types.IntMemberOf(rotate, []int{+90, -90, +270, -270}) {
@@ -644,9 +775,16 @@ func PosterPage(ctxSrc *model.Context, pageNr int, cut *model.Cut) (*model.Conte
 		return nil, err
 	}
 
-	if err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut); err != nil {
+	labels, err := createTiles(ctxSrc, ctxDest, *pagesIndRef, pagesDict, d, pageNr, cropBox, inhPAttrs, migrated, cut)
+	if err != nil {
 		return nil, err
 	}
 
+	if cut.Labels {
+		if err := addTileLabels(ctxDest, labels); err != nil {
+			return nil, err
+		}
+	}
+
 	return ctxDest, nil
 }
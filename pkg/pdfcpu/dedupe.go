@@ -0,0 +1,249 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// maxDedupePasses limits the number of dedupe passes.
+// Each pass may turn images referring to collapsed ICC profiles or soft masks into duplicates themselves.
+const maxDedupePasses = 3
+
+type dedupeStream struct {
+	objNr int
+	gen   int
+	dict  string
+	raw   []byte
+}
+
+func tableObjNrs(ctx *model.Context) []int {
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr, entry := range ctx.Table {
+		if objNr == 0 || entry == nil || entry.Free || entry.Object == nil || entry.Generation == nil {
+			continue
+		}
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+	return objNrs
+}
+
+func addDedupeCandidate(o types.Object, c types.IntSet) {
+	if indRef, ok := o.(types.IndirectRef); ok {
+		c[indRef.ObjectNumber.Value()] = true
+	}
+}
+
+// collectDedupeCandidates records font files, ICC profiles and images referenced by o.
+func collectDedupeCandidates(o types.Object, c types.IntSet) {
+	switch o := o.(type) {
+
+	case types.Dict:
+		if o.Type() != nil && *o.Type() == "FontDescriptor" {
+			for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+				addDedupeCandidate(o[k], c)
+			}
+		}
+		if o.Type() != nil && *o.Type() == "OutputIntent" {
+			addDedupeCandidate(o["DestOutputProfile"], c)
+		}
+		for _, v := range o {
+			collectDedupeCandidates(v, c)
+		}
+
+	case types.StreamDict:
+		collectDedupeCandidates(o.Dict, c)
+
+	case types.Array:
+		if len(o) == 2 {
+			if n, ok := o[0].(types.Name); ok && n == "ICCBased" {
+				addDedupeCandidate(o[1], c)
+			}
+		}
+		for _, v := range o {
+			collectDedupeCandidates(v, c)
+		}
+	}
+}
+
+func dedupeCandidates(ctx *model.Context) (types.IntSet, error) {
+	c := types.IntSet{}
+
+	for _, objNr := range tableObjNrs(ctx) {
+		// Also loads objects from object streams.
+		o, err := ctx.Dereference(*types.NewIndirectRef(objNr, *ctx.Table[objNr].Generation))
+		if err != nil {
+			return nil, err
+		}
+
+		if sd, ok := o.(types.StreamDict); ok {
+			if st := sd.Subtype(); st != nil && *st == "Image" {
+				c[objNr] = true
+			}
+		}
+
+		collectDedupeCandidates(o, c)
+	}
+
+	return c, nil
+}
+
+// streamForDedupe returns the raw stream data of obj#objNr along with its dict minus the Length entry.
+func streamForDedupe(ctx *model.Context, objNr int) (*dedupeStream, error) {
+	entry, ok := ctx.FindTableEntryLight(objNr)
+	if !ok || entry.Free || entry.Generation == nil {
+		return nil, nil
+	}
+
+	sd, ok := entry.Object.(types.StreamDict)
+	if !ok {
+		return nil, nil
+	}
+
+	if sd.Raw == nil {
+		if err := sd.Encode(); err != nil {
+			return nil, err
+		}
+	}
+
+	d := sd.Dict.Clone().(types.Dict)
+	d.Delete("Length")
+
+	return &dedupeStream{objNr: objNr, gen: *entry.Generation, dict: d.PDFString(), raw: sd.Raw}, nil
+}
+
+// duplicateStreams maps the object numbers of duplicate streams to the first stream with identical content.
+func duplicateStreams(ctx *model.Context, c types.IntSet) (map[int]types.IndirectRef, error) {
+	objNrs := make([]int, 0, len(c))
+	for objNr := range c {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	seen := map[[sha256.Size]byte][]*dedupeStream{}
+	m := map[int]types.IndirectRef{}
+
+	for _, objNr := range objNrs {
+		s, err := streamForDedupe(ctx, objNr)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			continue
+		}
+
+		h := sha256.New()
+		h.Write([]byte(s.dict))
+		h.Write(s.raw)
+		var key [sha256.Size]byte
+		copy(key[:], h.Sum(nil))
+
+		var orig *dedupeStream
+		for _, s1 := range seen[key] {
+			if s1.dict == s.dict && bytes.Equal(s1.raw, s.raw) {
+				orig = s1
+				break
+			}
+		}
+
+		if orig == nil {
+			seen[key] = append(seen[key], s)
+			continue
+		}
+
+		m[objNr] = *types.NewIndirectRef(orig.objNr, orig.gen)
+	}
+
+	return m, nil
+}
+
+func replaceIndRefs(o types.Object, m map[int]types.IndirectRef) types.Object {
+	switch o := o.(type) {
+
+	case types.IndirectRef:
+		if indRef, ok := m[o.ObjectNumber.Value()]; ok {
+			return indRef
+		}
+
+	case types.Dict:
+		for k, v := range o {
+			o[k] = replaceIndRefs(v, m)
+		}
+
+	case types.StreamDict:
+		replaceIndRefs(o.Dict, m)
+
+	case types.Array:
+		for i, v := range o {
+			o[i] = replaceIndRefs(v, m)
+		}
+	}
+
+	return o
+}
+
+// DedupeStreams collapses identical embedded font files, images and ICC profiles into single objects.
+// This is useful after merging files generated from the same template.
+// It returns the number of freed duplicates.
+func DedupeStreams(ctx *model.Context) (int, error) {
+	var count int
+
+	for i := 0; i < maxDedupePasses; i++ {
+
+		c, err := dedupeCandidates(ctx)
+		if err != nil {
+			return 0, err
+		}
+
+		m, err := duplicateStreams(ctx, c)
+		if err != nil {
+			return 0, err
+		}
+
+		if len(m) == 0 {
+			break
+		}
+
+		for _, objNr := range tableObjNrs(ctx) {
+			if _, ok := m[objNr]; ok {
+				continue
+			}
+			entry := ctx.Table[objNr]
+			entry.Object = replaceIndRefs(entry.Object, m)
+		}
+
+		for objNr := range m {
+			if log.OptimizeEnabled() {
+				log.Optimize.Printf("DedupeStreams: obj#%d duplicates obj#%d\n", objNr, m[objNr].ObjectNumber)
+			}
+			if err := ctx.FreeObject(objNr); err != nil {
+				return 0, err
+			}
+		}
+
+		count += len(m)
+	}
+
+	return count, nil
+}
@@ -0,0 +1,629 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Watermarks and stamps added by other tools are detected by splitting page content
+// into top level segments (q..Q, BT..ET, BDC..EMC and single operators) and looking for
+// segments which
+//
+//	are marked as watermark artifact, watermark optional content or Acrobat watermark form, or
+//	repeat on at least half of the selected pages (min. 2) and look like an overlay:
+//	they are not tagged content and use transparency or are rotated.
+
+// contentOp represents a content stream operator including its operands.
+type contentOp struct {
+	beg, end int // byte range within the page content.
+	name     string
+	operands []string
+}
+
+// contentSegment represents a top level sequence of content stream operators.
+type contentSegment struct {
+	beg, end int
+	ops      []contentOp
+}
+
+func isContentWhitespace(c byte) bool {
+	return c == 0x00 || c == 0x09 || c == 0x0A || c == 0x0C || c == 0x0D || c == 0x20
+}
+
+func isContentDelimiter(c byte) bool {
+	return strings.IndexByte("()<>[]{}/%", c) >= 0
+}
+
+// skipContentString returns the position following the string literal starting at i.
+func skipContentString(s []byte, i int) int {
+	depth := 0
+	for ; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '(':
+			depth++
+		case ')':
+			if depth--; depth == 0 {
+				return i + 1
+			}
+		}
+	}
+	return len(s)
+}
+
+// skipContentBalanced returns the position following the array or dict starting at i.
+func skipContentBalanced(s []byte, i int) int {
+	depth := 0
+	for i < len(s) {
+		switch {
+		case s[i] == '(':
+			i = skipContentString(s, i)
+			continue
+		case s[i] == '[' || bytes.HasPrefix(s[i:], []byte("<<")):
+			depth++
+			if s[i] == '<' {
+				i++
+			}
+		case s[i] == ']' || bytes.HasPrefix(s[i:], []byte(">>")):
+			depth--
+			if s[i] == '>' {
+				i++
+			}
+			if depth == 0 {
+				return i + 1
+			}
+		case s[i] == '<':
+			// Hex string.
+			j := bytes.IndexByte(s[i:], '>')
+			if j < 0 {
+				return len(s)
+			}
+			i += j
+		}
+		i++
+	}
+	return len(s)
+}
+
+// skipInlineImage returns the position following the EI operator of the inline image starting at i.
+func skipInlineImage(s []byte, i int) int {
+	j := bytes.Index(s[i:], []byte("ID"))
+	if j < 0 {
+		return len(s)
+	}
+	for i += j + 2; i < len(s); i++ {
+		if !bytes.HasPrefix(s[i:], []byte("EI")) || !isContentWhitespace(s[i-1]) {
+			continue
+		}
+		if i+2 == len(s) || isContentWhitespace(s[i+2]) {
+			return i + 2
+		}
+	}
+	return len(s)
+}
+
+func isContentOperand(t string) bool {
+	c := t[0]
+	return c == '+' || c == '-' || c == '.' || (c >= '0' && c <= '9') || t == "true" || t == "false" || t == "null"
+}
+
+// parseContentOps splits s into operators.
+func parseContentOps(s []byte) []contentOp {
+	var (
+		ops      []contentOp
+		operands []string
+	)
+	beg := -1
+
+	for i := 0; i < len(s); {
+		c := s[i]
+
+		if isContentWhitespace(c) {
+			i++
+			continue
+		}
+
+		if c == '%' {
+			j := bytes.IndexAny(s[i:], "\n\r")
+			if j < 0 {
+				break
+			}
+			i += j
+			continue
+		}
+
+		j := i
+		switch {
+		case c == '(':
+			j = skipContentString(s, i)
+		case c == '[' || bytes.HasPrefix(s[i:], []byte("<<")):
+			j = skipContentBalanced(s, i)
+		case c == '<':
+			if k := bytes.IndexByte(s[i:], '>'); k >= 0 {
+				j = i + k + 1
+			} else {
+				j = len(s)
+			}
+		default:
+			j++
+			for j < len(s) && !isContentWhitespace(s[j]) && !isContentDelimiter(s[j]) {
+				j++
+			}
+		}
+
+		t := string(s[i:j])
+		if beg < 0 {
+			beg = i
+		}
+
+		if c == '(' || c == '[' || c == '<' || c == '/' || c == ']' || c == '>' || isContentOperand(t) {
+			operands = append(operands, t)
+			i = j
+			continue
+		}
+
+		if t == "BI" {
+			j = skipInlineImage(s, j)
+		}
+
+		ops = append(ops, contentOp{beg: beg, end: j, name: t, operands: operands})
+		operands, beg = nil, -1
+		i = j
+	}
+
+	return ops
+}
+
+func isSegmentOpener(op string) bool {
+	return op == "q" || op == "BT" || op == "BDC" || op == "BMC"
+}
+
+func isSegmentCloser(op string) bool {
+	return op == "Q" || op == "ET" || op == "EMC"
+}
+
+// contentSegments groups ops into top level segments.
+// Trailing unbalanced operators do not make a segment.
+func contentSegments(ops []contentOp) []contentSegment {
+	var (
+		ss    []contentSegment
+		seg   *contentSegment
+		depth int
+	)
+
+	for _, op := range ops {
+		if depth == 0 {
+			seg = &contentSegment{beg: op.beg}
+		}
+		seg.ops = append(seg.ops, op)
+
+		if isSegmentOpener(op.name) {
+			depth++
+		} else if isSegmentCloser(op.name) && depth > 0 {
+			depth--
+		}
+
+		if depth == 0 {
+			seg.end = op.end
+			ss = append(ss, *seg)
+		}
+	}
+
+	return ss
+}
+
+// watermarkSegmentDetector analyzes the content segments of a single page.
+type watermarkSegmentDetector struct {
+	ctx     *model.Context
+	resDict types.Dict
+}
+
+func (d watermarkSegmentDetector) resource(subDict, name string) (types.Object, error) {
+	if d.resDict == nil || !strings.HasPrefix(name, "/") {
+		return nil, nil
+	}
+	sd, err := d.ctx.DereferenceDict(d.resDict[subDict])
+	if err != nil || sd == nil {
+		return nil, err
+	}
+	o, ok := sd.Find(name[1:])
+	if !ok {
+		return nil, nil
+	}
+	return o, nil
+}
+
+// resourceKey identifies the resource name within subDict independent of the page it is used on.
+func (d watermarkSegmentDetector) resourceKey(subDict, name string) (string, error) {
+	o, err := d.resource(subDict, name)
+	if err != nil || o == nil {
+		return name, err
+	}
+
+	if subDict == "XObject" {
+		// Copies of the same form or image count as one.
+		sd, _, err := d.ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			return name, err
+		}
+		bb := sd.Raw
+		if len(bb) == 0 {
+			bb = sd.Content
+		}
+		h := fnv.New64a()
+		h.Write(bb)
+		return fmt.Sprintf("@%x", h.Sum64()), nil
+	}
+
+	if ir, ok := o.(types.IndirectRef); ok {
+		return fmt.Sprintf("@%d", ir.ObjectNumber.Value()), nil
+	}
+
+	return name, nil
+}
+
+var resourceOperators = map[string]string{
+	"Do":  "XObject",
+	"gs":  "ExtGState",
+	"Tf":  "Font",
+	"sh":  "Shading",
+	"BDC": "Properties",
+	"cs":  "ColorSpace",
+	"CS":  "ColorSpace",
+}
+
+// key returns a page independent representation of seg.
+func (d watermarkSegmentDetector) key(seg contentSegment) (string, error) {
+	var sb strings.Builder
+	for _, op := range seg.ops {
+		for i, t := range op.operands {
+			if subDict, ok := resourceOperators[op.name]; ok && strings.HasPrefix(t, "/") && (i == len(op.operands)-1 || op.name == "Tf") {
+				k, err := d.resourceKey(subDict, t)
+				if err != nil {
+					return "", err
+				}
+				t = k
+			}
+			sb.WriteString(t)
+			sb.WriteByte(' ')
+		}
+		sb.WriteString(op.name)
+		sb.WriteByte(' ')
+	}
+	return sb.String(), nil
+}
+
+func isRotation(operands []string) bool {
+	if len(operands) != 6 {
+		return false
+	}
+	b, err1 := strconv.ParseFloat(operands[1], 64)
+	c, err2 := strconv.ParseFloat(operands[2], 64)
+	return err1 == nil && err2 == nil && (math.Abs(b) > 0.001 || math.Abs(c) > 0.001)
+}
+
+func (d watermarkSegmentDetector) resourceDict(subDict, name string) (types.Dict, error) {
+	o, err := d.resource(subDict, name)
+	if err != nil || o == nil {
+		return nil, err
+	}
+	if subDict == "XObject" {
+		sd, _, err := d.ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			return nil, err
+		}
+		return sd.Dict, nil
+	}
+	return d.ctx.DereferenceDict(o)
+}
+
+func (d watermarkSegmentDetector) isTransparent(name string) (bool, error) {
+	gs, err := d.resourceDict("ExtGState", name)
+	if err != nil || gs == nil {
+		return false, err
+	}
+	for _, k := range []string{"CA", "ca"} {
+		o, found := gs.Find(k)
+		if !found {
+			continue
+		}
+		f, err := d.ctx.DereferenceNumber(o)
+		if err == nil && f < 1 {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isWatermarkForm returns true for forms carrying the Acrobat watermark piece info.
+func (d watermarkSegmentDetector) isWatermarkForm(name string) (bool, error) {
+	sd, err := d.resourceDict("XObject", name)
+	if err != nil || sd == nil {
+		return false, err
+	}
+	o, found := sd.Find("PieceInfo")
+	if !found {
+		return false, nil
+	}
+	pi, err := d.ctx.DereferenceDict(o)
+	if err != nil || pi == nil {
+		return false, err
+	}
+	ct, err := d.ctx.DereferenceDict(pi["ADBE_CompoundType"])
+	if err != nil || ct == nil {
+		return false, err
+	}
+	n := ct.NameEntry("Private")
+	return n != nil && *n == "Watermark", nil
+}
+
+// isWatermarkOCG returns true for optional content groups named like watermarks.
+func (d watermarkSegmentDetector) isWatermarkOCG(name string) (bool, error) {
+	ocg, err := d.resourceDict("Properties", name)
+	if err != nil || ocg == nil {
+		return false, err
+	}
+	n, err := ocg.StringOrHexLiteralEntry("Name")
+	if err != nil || n == nil {
+		return false, nil
+	}
+	return strings.Contains(strings.ToLower(*n), "watermark"), nil
+}
+
+// isWatermark returns true if seg is marked as watermark.
+func (d watermarkSegmentDetector) isWatermark(seg contentSegment) (bool, error) {
+	op := seg.ops[0]
+	if op.name == "BDC" && len(op.operands) == 2 {
+		switch op.operands[0] {
+		case "/Artifact":
+			if strings.Contains(op.operands[1], "/Watermark") {
+				return true, nil
+			}
+		case "/OC":
+			return d.isWatermarkOCG(op.operands[1])
+		}
+	}
+	for _, op := range seg.ops {
+		if op.name == "Do" && len(op.operands) == 1 {
+			if ok, err := d.isWatermarkForm(op.operands[0]); err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+	return false, nil
+}
+
+// isOverlay returns true if seg is not part of the logical structure and uses transparency or is rotated.
+func (d watermarkSegmentDetector) isOverlay(seg contentSegment) (bool, error) {
+	if op := seg.ops[0]; op.name == "BDC" && len(op.operands) == 2 && strings.Contains(op.operands[1], "/MCID") {
+		return false, nil
+	}
+	for _, op := range seg.ops {
+		switch op.name {
+		case "cm", "Tm":
+			if isRotation(op.operands) {
+				return true, nil
+			}
+		case "gs":
+			if len(op.operands) == 1 {
+				if ok, err := d.isTransparent(op.operands[0]); err != nil || ok {
+					return ok, err
+				}
+			}
+		}
+	}
+	return false, nil
+}
+
+type pageSegments struct {
+	content []byte
+	segs    []contentSegment
+	keys    []string
+	remove  []bool
+}
+
+func pageContentAndResources(ctx *model.Context, pageNr int) (types.Dict, []byte, types.Dict, error) {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	o, err := ctx.Dereference(d["Contents"])
+	if err != nil || o == nil {
+		return d, nil, nil, err
+	}
+
+	var bb []byte
+
+	switch o := o.(type) {
+	case types.StreamDict:
+		if err := o.Decode(); err != nil {
+			return nil, nil, nil, err
+		}
+		bb = o.Content
+
+	case types.Array:
+		for _, o := range o {
+			sd, _, err := ctx.DereferenceStreamDict(o)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+			if sd == nil {
+				continue
+			}
+			if err := sd.Decode(); err != nil {
+				return nil, nil, nil, err
+			}
+			// Content streams may split operators only at token boundaries.
+			bb = append(bb, sd.Content...)
+			bb = append(bb, '\n')
+		}
+	}
+
+	return d, bb, inhPAttrs.Resources, nil
+}
+
+func analyzePageSegments(ctx *model.Context, content []byte, resDict types.Dict) (*pageSegments, error) {
+	d := watermarkSegmentDetector{ctx: ctx, resDict: resDict}
+
+	ps := &pageSegments{content: content}
+
+	for _, seg := range contentSegments(parseContentOps(content)) {
+		ok, err := d.isWatermark(seg)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			ps.segs = append(ps.segs, seg)
+			ps.keys = append(ps.keys, "")
+			ps.remove = append(ps.remove, true)
+			continue
+		}
+		if ok, err = d.isOverlay(seg); err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		k, err := d.key(seg)
+		if err != nil {
+			return nil, err
+		}
+		ps.segs = append(ps.segs, seg)
+		ps.keys = append(ps.keys, k)
+		ps.remove = append(ps.remove, false)
+	}
+
+	return ps, nil
+}
+
+// minWatermarkRepeats returns the minimum number of pages an overlay has to show up on to be considered a watermark.
+func minWatermarkRepeats(pageCount int) int {
+	return max(2, (pageCount+1)/2)
+}
+
+func (ps pageSegments) strippedContent() []byte {
+	bb := []byte{}
+	i := 0
+	for j, seg := range ps.segs {
+		if !ps.remove[j] {
+			continue
+		}
+		bb = append(bb, ps.content[i:seg.beg]...)
+		i = seg.end
+	}
+	return append(bb, ps.content[i:]...)
+}
+
+func replacePageContent(ctx *model.Context, pageDict types.Dict, bb []byte) error {
+	sd, _ := ctx.NewStreamDictForBuf(bb)
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	pageDict.Update("Contents", *ir)
+	return nil
+}
+
+// RemoveDetectedWatermarks removes watermarks and stamps from selected pages
+// regardless of the tool used for adding them.
+func RemoveDetectedWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
+	ctx.LogDebug("RemoveDetectedWatermarks")
+
+	pageNrs := sortSelectedPages(selectedPages)
+	if len(pageNrs) == 0 {
+		pageNrs = make([]int, ctx.PageCount)
+		for i := range pageNrs {
+			pageNrs[i] = i + 1
+		}
+	}
+
+	pageDicts := map[int]types.Dict{}
+	pss := map[int]*pageSegments{}
+	repeats := map[string]int{}
+
+	for _, pageNr := range pageNrs {
+		d, bb, resDict, err := pageContentAndResources(ctx, pageNr)
+		if err != nil {
+			return err
+		}
+		if len(bb) == 0 {
+			continue
+		}
+		ps, err := analyzePageSegments(ctx, bb, resDict)
+		if err != nil {
+			return err
+		}
+		pageDicts[pageNr], pss[pageNr] = d, ps
+
+		seen := map[string]bool{}
+		for _, k := range ps.keys {
+			if k != "" && !seen[k] {
+				seen[k] = true
+				repeats[k]++
+			}
+		}
+	}
+
+	minRepeats := minWatermarkRepeats(len(pageNrs))
+
+	removed := 0
+
+	for pageNr, ps := range pss {
+		found := false
+		for i, k := range ps.keys {
+			if k != "" && repeats[k] >= minRepeats {
+				ps.remove[i] = true
+			}
+			found = found || ps.remove[i]
+		}
+		if !found {
+			continue
+		}
+		if err := replacePageContent(ctx, pageDicts[pageNr], ps.strippedContent()); err != nil {
+			return errors.Wrapf(err, "pdfcpu: page %d", pageNr)
+		}
+		removed++
+	}
+
+	if removed == 0 {
+		return errNoWatermark
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("removed watermarks from %d page(s)\n", removed)
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// ManualDuplexPageOrder returns the page sequences needed for printing selectedPages double sided on a printer without a duplexer.
+// fronts holds the odd pages of the selection in order, backs holds the even pages, reversed if reverseBacks is set,
+// so the printed front side stack can be reloaded as is.
+// blank is true if the last sheet needs a blank back side, which has to go first into a reversed back side stack.
+func ManualDuplexPageOrder(selectedPages types.IntSet, reverseBacks bool) (fronts, backs []int, blank bool) {
+	pageNumbers := sortSelectedPages(selectedPages)
+
+	for i, p := range pageNumbers {
+		if i%2 == 0 {
+			fronts = append(fronts, p)
+		} else {
+			backs = append(backs, p)
+		}
+	}
+
+	if reverseBacks {
+		for i, j := 0, len(backs)-1; i < j; i, j = i+1, j-1 {
+			backs[i], backs[j] = backs[j], backs[i]
+		}
+	}
+
+	return fronts, backs, len(fronts) > len(backs)
+}
+
+// ManualDuplex creates the page sequence for printing selectedPages of ctx double sided on a printer without a duplexer.
+// If separate is set, the front sides and the back sides are returned as separate contexts,
+// otherwise the back sides follow the front sides in ctxFronts.
+func ManualDuplex(ctx *model.Context, selectedPages types.IntSet, reverseBacks, separate bool) (ctxFronts, ctxBacks *model.Context, err error) {
+	fronts, backs, blank := ManualDuplexPageOrder(selectedPages, reverseBacks)
+	if len(fronts) == 0 {
+		return nil, nil, errors.New("pdfcpu: manual duplex: no pages selected")
+	}
+
+	if !separate {
+		if ctxFronts, err = ExtractPages(ctx, append(fronts, backs...), false); err != nil {
+			return nil, nil, err
+		}
+		if blank {
+			// The last front side gets a blank back side.
+			pageNr := len(fronts) + len(backs)
+			if reverseBacks {
+				pageNr = len(fronts)
+			}
+			if err := ctxFronts.InsertBlankPages(types.IntSet{pageNr: true}, nil, false); err != nil {
+				return nil, nil, err
+			}
+		}
+		return ctxFronts, nil, nil
+	}
+
+	if ctxFronts, err = ExtractPages(ctx, fronts, false); err != nil {
+		return nil, nil, err
+	}
+
+	if len(backs) == 0 {
+		// A single front side needs no back side stack.
+		return ctxFronts, nil, nil
+	}
+
+	if ctxBacks, err = ExtractPages(ctx, backs, false); err != nil {
+		return nil, nil, err
+	}
+
+	if blank {
+		pageNr, before := len(backs), false
+		if reverseBacks {
+			pageNr, before = 1, true
+		}
+		if err := ctxBacks.InsertBlankPages(types.IntSet{pageNr: true}, nil, before); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return ctxFronts, ctxBacks, nil
+}
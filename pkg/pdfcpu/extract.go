@@ -20,11 +20,13 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"sort"
 	"strings"
 
 	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
-	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -268,7 +270,7 @@ func imageStub(
 	if err != nil {
 		return nil, err
 	}
-	if lastFilter == filter.CCITTFax {
+	if lastFilter == filter.CCITTFax || lastFilter == filter.JBIG2 {
 		comp = 1
 	}
 
@@ -355,8 +357,8 @@ func prepareExtractImage(sd *types.StreamDict) (string, string, types.Dict, bool
 	return filters, lastFilter, d, imgMask
 }
 func decodeImage(ctx *model.Context, sd *types.StreamDict, filters, lastFilter string, objNr int) error {
-	// CCITTDecoded images / (bit) masks don't have a ColorSpace attribute, but we render image files.
-	if lastFilter == filter.CCITTFax {
+	// CCITT/JBIG2 decoded images / (bit) masks don't have a ColorSpace attribute, but we render image files.
+	if lastFilter == filter.CCITTFax || lastFilter == filter.JBIG2 {
 		if _, err := ctx.DereferenceDictEntry(sd.Dict, "ColorSpace"); err != nil {
 			sd.InsertName("ColorSpace", model.DeviceGrayCS)
 		}
@@ -372,16 +374,20 @@ func decodeImage(ctx *model.Context, sd *types.StreamDict, filters, lastFilter s
 
 	switch lastFilter {
 
-	case filter.DCT, filter.JPX, filter.Flate, filter.LZW, filter.CCITTFax, filter.RunLength:
+	case filter.DCT, filter.JPX, filter.Flate, filter.LZW, filter.CCITTFax, filter.JBIG2, filter.RunLength:
 		if err := sd.Decode(); err != nil {
 			return err
 		}
 
 	default:
-		msg := fmt.Sprintf("pdfcpu: ExtractImage(obj#%d): skipping img, filter %s unsupported", objNr, filters)
-		if log.DebugEnabled() {
-			log.Debug.Println(msg)
+		if filter.Registered(lastFilter) {
+			// Application supplied filters decode into image samples.
+			if err := sd.Decode(); err != filter.ErrUnsupportedFilter {
+				return err
+			}
 		}
+		msg := fmt.Sprintf("pdfcpu: ExtractImage(obj#%d): skipping img, filter %s unsupported", objNr, filters)
+		ctx.LogDebug("decodeImage: skipping img, filter unsupported", log.KeyObj, objNr, "filters", filters)
 		if log.CLIEnabled() {
 			log.CLI.Println(msg)
 		}
@@ -471,11 +477,148 @@ func ExtractPageImages(ctx *model.Context, pageNr int, stub bool) (map[int]model
 	return m, nil
 }
 
+// iccProfileBytes returns the ICC profile of sd's ICCBased colorspace.
+func iccProfileBytes(ctx *model.Context, sd *types.StreamDict) ([]byte, error) {
+	o, found := sd.Find("ColorSpace")
+	if !found {
+		return nil, nil
+	}
+
+	a, err := ctx.DereferenceArray(o)
+	if err != nil || len(a) != 2 {
+		return nil, nil
+	}
+	if n, ok := a[0].(types.Name); !ok || n != model.ICCBasedCS {
+		return nil, nil
+	}
+
+	sd1, _, err := ctx.DereferenceStreamDict(a[1])
+	if err != nil || sd1 == nil {
+		return nil, err
+	}
+	if err := sd1.Decode(); err != nil {
+		return nil, err
+	}
+
+	return sd1.Content, nil
+}
+
+func losslessImageData(ctx *model.Context, sd *types.StreamDict, lastFilter string) ([]byte, string, error) {
+	// Decode up to the image compression filter.
+	sd1 := *sd
+	sd1.Content, sd1.CSComponents = nil, 0
+	if err := sd1.Decode(); err != nil {
+		return nil, "", err
+	}
+
+	if lastFilter == filter.DCT {
+		return sd1.Content, "jpg", nil
+	}
+
+	icc, err := iccProfileBytes(ctx, sd)
+	if err != nil {
+		return nil, "", err
+	}
+
+	bb, err := filter.JP2File(sd1.Content, icc)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return bb, "jp2", nil
+}
+
+// ExtractLosslessImage extracts an image from sd along with its manifest.
+// DCT and JPX encoded image data is returned byte-for-byte as JPEG or JP2 file,
+// all other images are rendered losslessly.
+func ExtractLosslessImage(ctx *model.Context, sd *types.StreamDict, resourceID string, objNr int) (*model.LosslessImage, error) {
+	if sd == nil {
+		return nil, nil
+	}
+
+	li := &model.LosslessImage{Name: resourceID, ObjNr: objNr, Filters: []string{}}
+
+	for _, f := range sd.FilterPipeline {
+		li.Filters = append(li.Filters, f.Name)
+	}
+
+	if w := sd.IntEntry("Width"); w != nil {
+		li.Width = *w
+	}
+	if h := sd.IntEntry("Height"); h != nil {
+		li.Height = *h
+	}
+	if bpc := sd.IntEntry("BitsPerComponent"); bpc != nil {
+		li.Bpc = *bpc
+	}
+
+	cs, err := ColorSpaceString(ctx, sd)
+	if err != nil {
+		return nil, err
+	}
+	li.ColorSpace = cs
+
+	if comp, err := ColorSpaceComponents(ctx.XRefTable, sd); err == nil {
+		li.Comp = comp
+	}
+
+	_, lastFilter, _, _ := prepareExtractImage(sd)
+
+	if lastFilter == filter.DCT || lastFilter == filter.JPX {
+		bb, t, err := losslessImageData(ctx, sd, lastFilter)
+		if err != nil {
+			return nil, err
+		}
+		li.Reader, li.FileType, li.Raw = bytes.NewReader(bb), t, true
+		return li, nil
+	}
+
+	img, err := ExtractImage(ctx, sd, false, resourceID, objNr, false)
+	if err != nil || img == nil || img.Reader == nil {
+		return nil, err
+	}
+	li.Reader, li.FileType = img.Reader, img.FileType
+
+	return li, nil
+}
+
+// ExtractPageLosslessImages extracts all images used by pageNr along with their manifests.
+func ExtractPageLosslessImages(ctx *model.Context, pageNr int) ([]model.LosslessImage, error) {
+	placements, err := PageImagePlacements(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	objNrs := ImageObjNrs(ctx, pageNr)
+	sort.Ints(objNrs)
+
+	ii := []model.LosslessImage{}
+	for _, objNr := range objNrs {
+		imageObj := ctx.Optimize.ImageObjects[objNr]
+		li, err := ExtractLosslessImage(ctx, imageObj.ImageDict, imageObj.ResourceNames[pageNr-1], objNr)
+		if err != nil {
+			return nil, err
+		}
+		if li == nil {
+			continue
+		}
+		li.PageNr = pageNr
+		li.Placements = placements[objNr]
+		if li.Placements == nil {
+			li.Placements = []model.ImagePlacement{}
+		}
+		ii = append(ii, *li)
+	}
+
+	return ii, nil
+}
+
 // Font is a Reader representing an embedded font.
 type Font struct {
 	io.Reader
-	Name string
-	Type string
+	Name  string
+	Type  string // File extension: ttf, otf, cff or pfb
+	ObjNr int    // font dict objNr
 }
 
 // FontObjNrs returns all font dict objNrs for pageNr.
@@ -505,68 +648,101 @@ func FontObjNrs(ctx *model.Context, pageNr int) []int {
 	return objNrs
 }
 
+func fontFileLength(ctx *model.Context, sd *types.StreamDict, key string) int {
+	i, err := ctx.DereferenceInteger(sd.Dict[key])
+	if err != nil || i == nil {
+		return 0
+	}
+	return i.Value()
+}
+
+// fontFileData returns an embedded font program along with its file extension.
+// Type 1 fonts are converted into PFB files, bare CFF fonts are wrapped into OpenType files.
+func fontFileData(ctx *model.Context, key string, sd *types.StreamDict) ([]byte, string, error) {
+	bb := sd.Content
+
+	switch key {
+
+	case "FontFile":
+		bb, err := font.Type1ToPFB(bb, fontFileLength(ctx, sd, "Length1"), fontFileLength(ctx, sd, "Length2"))
+		return bb, "pfb", err
+
+	case "FontFile2":
+		// ttf ... true type file
+		// ttc ... true type collection
+		return bb, "ttf", nil
+	}
+
+	// FontFile3
+	if bytes.HasPrefix(bb, []byte("OTTO")) || (sd.Subtype() != nil && *sd.Subtype() == "OpenType") {
+		return bb, "otf", nil
+	}
+
+	otf, err := font.WrapCFF(bb)
+	if err != nil {
+		ctx.LogDebug("fontFileData: unable to wrap CFF font", "err", err)
+		return bb, "cff", nil
+	}
+
+	return otf, "otf", nil
+}
+
 // ExtractFont extracts a font from fontObject.
 func ExtractFont(ctx *model.Context, fontObject model.FontObject, objNr int) (*Font, error) {
-	d, err := font.FontDescriptor(ctx.XRefTable, fontObject.FontDict, objNr)
+	d, err := pdffont.FontDescriptor(ctx.XRefTable, fontObject.FontDict, objNr)
 	if err != nil {
 		return nil, err
 	}
 
 	if d == nil {
-		if log.DebugEnabled() {
-			log.Debug.Printf("ExtractFont: ignoring obj#%d - no fontDescriptor available for font: %s\n", objNr, fontObject.FontName)
-		}
+		ctx.LogDebug("ExtractFont: ignoring font without fontDescriptor", log.KeyObj, objNr, "font", fontObject.FontName)
 		return nil, nil
 	}
 
-	ir := fontDescriptorFontFileIndirectObjectRef(d)
-	if ir == nil {
-		if log.DebugEnabled() {
-			log.Debug.Printf("ExtractFont: ignoring obj#%d - no font file available for font: %s\n", objNr, fontObject.FontName)
+	var (
+		key string
+		ir  *types.IndirectRef
+	)
+
+	for _, key = range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if ir = d.IndirectRefEntry(key); ir != nil {
+			break
 		}
-		return nil, nil
 	}
 
-	var f *Font
-
-	fontType := fontObject.SubType()
-
-	switch fontType {
-
-	case "TrueType":
-		// ttf ... true type file
-		// ttc ... true type collection
-		sd, _, err := ctx.DereferenceStreamDict(*ir)
-		if err != nil {
-			return nil, err
-		}
-		if sd == nil {
-			return nil, errors.Errorf("extractFontData: corrupt font obj#%d for font: %s\n", objNr, fontObject.FontName)
-		}
+	if ir == nil {
+		ctx.LogDebug("ExtractFont: ignoring font without font file", log.KeyObj, objNr, "font", fontObject.FontName)
+		return nil, nil
+	}
 
-		// Decode streamDict if used filter is supported only.
-		err = sd.Decode()
-		if err == filter.ErrUnsupportedFilter {
-			return nil, nil
-		}
-		if err != nil {
-			return nil, err
-		}
+	sd, _, err := ctx.DereferenceStreamDict(*ir)
+	if err != nil {
+		return nil, err
+	}
+	if sd == nil {
+		return nil, errors.Errorf("extractFontData: corrupt font obj#%d for font: %s\n", objNr, fontObject.FontName)
+	}
 
-		f = &Font{bytes.NewReader(sd.Content), fontObject.FontName, "ttf"}
+	// Decode streamDict if used filter is supported only.
+	err = sd.Decode()
+	if err == filter.ErrUnsupportedFilter {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
 
-	default:
-		s := fmt.Sprintf("extractFontData: obj#%d - unsupported fonttype %s -  font: %s\n", objNr, fontType, fontObject.FontName)
-		if log.InfoEnabled() {
-			log.Info.Println(s)
-		}
+	bb, fontType, err := fontFileData(ctx, key, sd)
+	if err != nil {
+		s := fmt.Sprintf("extractFontData: obj#%d - %v - font: %s\n", objNr, err, fontObject.FontName)
+		ctx.LogInfo("extractFontData: failed", log.KeyObj, objNr, "font", fontObject.FontName, "err", err)
 		if log.CLIEnabled() {
 			log.CLI.Printf(s)
 		}
 		return nil, nil
 	}
 
-	return f, nil
+	return &Font{Reader: bytes.NewReader(bb), Name: fontObject.FontName, Type: fontType, ObjNr: objNr}, nil
 }
 
 // ExtractPageFonts extracts all fonts used by pageNr.
@@ -616,6 +792,7 @@ func ExtractPages(ctx *model.Context, pageNrs []int, usePgCache bool) (*model.Co
 	if err := AddPages(ctx, ctxDest, pageNrs, usePgCache); err != nil {
 		return nil, err
 	}
+	ctxDest.PageCount = len(pageNrs)
 
 	return ctxDest, nil
 }
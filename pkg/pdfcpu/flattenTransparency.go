@@ -0,0 +1,751 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Errors for transparency which can only be flattened by rasterizing.
+var (
+	errFlattenBackdrop  = errors.New("transparent content over a backdrop not known to be white")
+	errFlattenSoftMask  = errors.New("content painted through a soft mask")
+	errFlattenBlendMode = errors.New("content painted using a blend mode other than Normal")
+	errFlattenShape     = errors.New("content painted using alpha as shape")
+	errFlattenColor     = errors.New("transparent content painted using an unsupported color space")
+	errFlattenAlpha     = errors.New("images, shadings or forms painted with constant alpha")
+	errFlattenImage     = errors.New("image using an unsupported soft mask")
+)
+
+// transparencyFlattener makes page content opaque by compositing it with a white backdrop.
+type transparencyFlattener struct {
+	ctx    *model.Context
+	cc     *colorConverter // Classifies color spaces.
+	done   types.IntSet    // Flattened content streams.
+	images types.IntSet    // Soft masked images painted on a white backdrop only.
+	stats  model.TransparencyStats
+}
+
+// flatColor is the current color of a content stream.
+type flatColor struct {
+	f  colorFamily
+	x  []float64
+	op string // Operator setting this color.
+}
+
+type flatState struct {
+	fillAlpha, strokeAlpha float64
+	fillCS, strokeCS       colorFamily
+	fill, stroke           *flatColor
+	softMask               bool // A soft mask is in effect.
+	blend                  bool // A blend mode other than Normal is in effect.
+	shape                  bool // Alpha is interpreted as shape.
+	textMode               int
+}
+
+func initialFlatState() flatState {
+	return flatState{
+		fillAlpha:   1,
+		strokeAlpha: 1,
+		fillCS:      colorFamilyGray,
+		strokeCS:    colorFamilyGray,
+		fill:        &flatColor{f: colorFamilyGray, x: initialColor(colorFamilyGray), op: "g"},
+		stroke:      &flatColor{f: colorFamilyGray, x: initialColor(colorFamilyGray), op: "G"},
+	}
+}
+
+// blendWithWhite composites color x with constant alpha a over a white backdrop.
+func blendWithWhite(f colorFamily, x []float64, a float64) []float64 {
+	y := make([]float64, len(x))
+	for i, v := range x {
+		if f == colorFamilyCMYK {
+			y[i] = a * v
+			continue
+		}
+		y[i] = a*v + 1 - a
+	}
+	return y
+}
+
+// blended returns the content stream operation setting c blended with constant alpha a.
+func (c flatColor) blended(a float64) string {
+	x := c.x
+	if a < 1 {
+		x = blendWithWhite(c.f, x, a)
+	}
+	return colorValuesString(x) + " " + c.op
+}
+
+// initialColor returns the initial color of a color space of family f (8.6.5).
+func initialColor(f colorFamily) []float64 {
+	x := make([]float64, f.components())
+	if f == colorFamilyCMYK {
+		x[3] = 1
+	}
+	return x
+}
+
+// resource returns the resource named by operand of category cat.
+func (tf *transparencyFlattener) resource(operand, cat string, resDict types.Dict) types.Object {
+	if len(operand) < 2 || operand[0] != '/' || resDict == nil {
+		return nil
+	}
+
+	name, err := types.DecodeName(operand[1:])
+	if err != nil {
+		return nil
+	}
+
+	d, err := tf.ctx.DereferenceDict(resDict[cat])
+	if err != nil || d == nil {
+		return nil
+	}
+
+	return d[name]
+}
+
+// extGState returns st modified by the graphics state parameter dict named by operand.
+func (tf *transparencyFlattener) extGState(operand string, resDict types.Dict, st flatState) flatState {
+	gs, err := tf.ctx.DereferenceDict(tf.resource(operand, "ExtGState", resDict))
+	if err != nil || gs == nil {
+		return st
+	}
+
+	if o, found := gs.Find("ca"); found {
+		if f, err := tf.ctx.DereferenceNumber(o); err == nil {
+			st.fillAlpha = clamp01(f)
+		}
+	}
+
+	if o, found := gs.Find("CA"); found {
+		if f, err := tf.ctx.DereferenceNumber(o); err == nil {
+			st.strokeAlpha = clamp01(f)
+		}
+	}
+
+	if o, found := gs.Find("SMask"); found {
+		n, ok := o.(types.Name)
+		st.softMask = !ok || n != "None"
+	}
+
+	if o, found := gs.Find("BM"); found {
+		st.blend = !normalBlendMode(tf.ctx, o)
+	}
+
+	if ais := gs.BooleanEntry("AIS"); ais != nil {
+		st.shape = *ais
+	}
+
+	return st
+}
+
+// normalBlendMode returns true for the blend modes Normal and Compatible.
+func normalBlendMode(ctx *model.Context, o types.Object) bool {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return false
+	}
+	if a, ok := o.(types.Array); ok {
+		// The first supported blend mode of the array gets used.
+		if len(a) == 0 {
+			return true
+		}
+		o = a[0]
+	}
+	n, ok := o.(types.Name)
+	return ok && (n == "Normal" || n == "Compatible")
+}
+
+// textPainting returns whether text gets filled and stroked using text rendering mode m.
+func textPainting(m int) (bool, bool) {
+	switch m {
+	case 0, 4:
+		return true, false
+	case 1, 5:
+		return false, true
+	case 2, 6:
+		return true, true
+	}
+	return false, false
+}
+
+// checkPainting returns whether op paints and an error if op cannot be flattened.
+// Soft masked images painted on a white backdrop get recorded.
+func (tf *transparencyFlattener) checkPainting(op contentOp, resDict types.Dict, st flatState, painted bool) (bool, error) {
+	var fill, stroke bool
+
+	switch op.name {
+	case "f", "F", "f*", "sh", "Do", "BI":
+		fill = true
+	case "S", "s":
+		stroke = true
+	case "B", "B*", "b", "b*":
+		fill, stroke = true, true
+	case "Tj", "TJ", "'", "\"":
+		fill, stroke = textPainting(st.textMode)
+	}
+
+	if !fill && !stroke {
+		return false, nil
+	}
+
+	if st.softMask {
+		return true, errFlattenSoftMask
+	}
+
+	if st.blend {
+		return true, errFlattenBlendMode
+	}
+
+	fillTransparent, strokeTransparent := fill && st.fillAlpha < 1, stroke && st.strokeAlpha < 1
+
+	if fillTransparent || strokeTransparent {
+		if painted {
+			return true, errFlattenBackdrop
+		}
+		if st.shape {
+			return true, errFlattenShape
+		}
+		if fillTransparent && st.fill == nil || strokeTransparent && st.stroke == nil {
+			return true, errFlattenColor
+		}
+	}
+
+	switch op.name {
+	case "sh", "BI":
+		if fillTransparent {
+			return true, errFlattenAlpha
+		}
+	case "Do":
+		return true, tf.checkXObject(op, resDict, fillTransparent, painted)
+	}
+
+	return true, nil
+}
+
+// checkXObject returns an error if the XObject painted by op cannot be flattened.
+func (tf *transparencyFlattener) checkXObject(op contentOp, resDict types.Dict, transparent, painted bool) error {
+	if transparent {
+		return errFlattenAlpha
+	}
+
+	if len(op.operands) != 1 {
+		return nil
+	}
+
+	indRef, ok := tf.resource(op.operands[0], "XObject", resDict).(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+
+	sd, _, err := tf.ctx.DereferenceStreamDict(indRef)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	if st := sd.Subtype(); st == nil || *st != "Image" {
+		return nil
+	}
+
+	if smid := sd.IntEntry("SMaskInData"); smid != nil && *smid != 0 {
+		return errFlattenImage
+	}
+
+	if _, found := sd.Find("SMask"); found {
+		if painted {
+			return errFlattenBackdrop
+		}
+		tf.images[indRef.ObjectNumber.Value()] = true
+	}
+
+	return nil
+}
+
+// flattenContent blends the colors of content stream bb with the constant alpha they are painted with.
+// painted indicates whether the backdrop of bb is not known to be white.
+// Transparent content which cannot be flattened without rasterizing it results in an error.
+func (tf *transparencyFlattener) flattenContent(bb []byte, resDict types.Dict, painted bool) ([]byte, bool, error) {
+	var (
+		out     []byte
+		last    int
+		changed bool
+		stack   []flatState
+	)
+
+	st := initialFlatState()
+
+	replace := func(op contentOp, s string) {
+		out = append(out, bb[last:op.beg]...)
+		out = append(out, s...)
+		last, changed = op.end, true
+	}
+
+	for _, op := range parseContentOps(bb) {
+		paints, err := tf.checkPainting(op, resDict, st, painted)
+		if err != nil {
+			return nil, false, err
+		}
+		if paints {
+			painted = true
+		}
+
+		stroke := op.name == strings.ToUpper(op.name)
+
+		alpha, cs, col := &st.fillAlpha, &st.fillCS, &st.fill
+		if stroke {
+			alpha, cs, col = &st.strokeAlpha, &st.strokeCS, &st.stroke
+		}
+
+		switch op.name {
+
+		case "q":
+			stack = append(stack, st)
+
+		case "Q":
+			if len(stack) > 0 {
+				st, stack = stack[len(stack)-1], stack[:len(stack)-1]
+			}
+
+		case "Tr":
+			if x, ok := numericOperands(op.operands, 1); ok {
+				st.textMode = int(x[0])
+			}
+
+		case "g", "G", "rg", "RG", "k", "K":
+			*cs, *col = colorOperatorFamilies[strings.ToLower(op.name)], nil
+			x, ok := numericOperands(op.operands, cs.components())
+			if !ok {
+				continue
+			}
+			*col = &flatColor{f: *cs, x: x, op: op.name}
+			if *alpha < 1 {
+				replace(op, (*col).blended(*alpha))
+			}
+
+		case "cs", "CS":
+			*cs, *col = colorFamilyNone, nil
+			if len(op.operands) != 1 {
+				continue
+			}
+			*cs = tf.cc.family(tf.cc.contentColorSpace(op.operands[0], resDict))
+			if *cs == colorFamilyNone {
+				continue
+			}
+			setColor := "sc"
+			if stroke {
+				setColor = "SC"
+			}
+			*col = &flatColor{f: *cs, x: initialColor(*cs), op: setColor}
+			if *alpha < 1 {
+				replace(op, string(bb[op.beg:op.end])+" "+(*col).blended(*alpha))
+			}
+
+		case "sc", "scn", "SC", "SCN":
+			*col = nil
+			if *cs == colorFamilyNone {
+				continue
+			}
+			x, ok := numericOperands(op.operands, cs.components())
+			if !ok {
+				continue
+			}
+			*col = &flatColor{f: *cs, x: x, op: op.name}
+			if *alpha < 1 {
+				replace(op, (*col).blended(*alpha))
+			}
+
+		case "gs":
+			if len(op.operands) != 1 {
+				continue
+			}
+			st1 := tf.extGState(op.operands[0], resDict, st)
+			s := string(bb[op.beg:op.end])
+			if st1.fillAlpha != st.fillAlpha && st.fill != nil {
+				s += " " + st.fill.blended(st1.fillAlpha)
+			}
+			if st1.strokeAlpha != st.strokeAlpha && st.stroke != nil {
+				s += " " + st.stroke.blended(st1.strokeAlpha)
+			}
+			st = st1
+			if len(s) > op.end-op.beg {
+				replace(op, s)
+			}
+		}
+	}
+
+	if !changed {
+		return bb, false, nil
+	}
+
+	return append(out, bb[last:]...), true, nil
+}
+
+func (tf *transparencyFlattener) flattenPages() error {
+	if err := tf.ctx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= tf.ctx.PageCount; pageNr++ {
+		pageDict, bb, resDict, err := pageContentAndResources(tf.ctx, pageNr)
+		if err != nil {
+			return err
+		}
+		if bb == nil {
+			continue
+		}
+		bb, ok, err := tf.flattenContent(bb, resDict, false)
+		if err != nil {
+			return errors.Errorf("pdfcpu: flatten transparency: page %d: %v", pageNr, err)
+		}
+		if !ok {
+			continue
+		}
+		if err := replacePageContent(tf.ctx, pageDict, bb); err != nil {
+			return err
+		}
+		tf.stats.ContentStreams++
+	}
+
+	return nil
+}
+
+// flattenContentStream flattens the content stream obj#objNr using the resources in o.
+// Forms, patterns and glyphs may get painted on any backdrop.
+func (tf *transparencyFlattener) flattenContentStream(objNr int, o types.Object) error {
+	if tf.done[objNr] {
+		return nil
+	}
+	tf.done[objNr] = true
+
+	entry, ok := tf.ctx.FindTableEntryLight(objNr)
+	if !ok {
+		return nil
+	}
+
+	sd, ok := entry.Object.(types.StreamDict)
+	if !ok {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	resDict, err := tf.ctx.DereferenceDict(o)
+	if err != nil {
+		return err
+	}
+
+	bb, ok, err := tf.flattenContent(sd.Content, resDict, true)
+	if err != nil {
+		return errors.Errorf("pdfcpu: flatten transparency: obj#%d: %v", objNr, err)
+	}
+	if !ok {
+		return nil
+	}
+
+	sd.Content = bb
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = sd
+	tf.stats.ContentStreams++
+
+	return nil
+}
+
+func (tf *transparencyFlattener) flattenType3Glyphs(d types.Dict) error {
+	cp, err := tf.ctx.DereferenceDict(d["CharProcs"])
+	if err != nil || cp == nil {
+		return err
+	}
+	for _, o := range cp {
+		if indRef, ok := o.(types.IndirectRef); ok {
+			if err := tf.flattenContentStream(indRef.ObjectNumber.Value(), d["Resources"]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// compositeSoftMask composites the samples of is with the alpha values of soft mask ms over a white backdrop.
+func compositeSoftMask(f colorFamily, is, ms *imageSamples, matte []float64) []byte {
+	n := is.n
+	bg := 1.
+	if f == colorFamilyCMYK {
+		bg = 0
+	}
+
+	pix := make([]byte, len(is.pix))
+	x := make([]float64, n)
+
+	for y := 0; y < is.h; y++ {
+		my := y * ms.h / is.h
+		for i := 0; i < is.w; i++ {
+			a := float64(ms.pix[my*ms.w+i*ms.w/is.w]) / 255
+			off := (y*is.w + i) * n
+			for j := range x {
+				c := float64(is.pix[off+j]) / 255
+				if matte != nil && a > 0 {
+					// Undo the preblending with the matte color (11.6.5.3).
+					c = clamp01(matte[j] + (c-matte[j])/a)
+				}
+				x[j] = a*c + (1-a)*bg
+			}
+			copy(pix[off:], colorBytes(x))
+		}
+	}
+
+	return pix
+}
+
+// flattenImage composites image sd with its soft mask.
+func (tf *transparencyFlattener) flattenImage(objNr int, sd types.StreamDict) error {
+	o, found := sd.Find("SMask")
+	if !found {
+		return nil
+	}
+
+	sm, _, err := tf.ctx.DereferenceStreamDict(o)
+	if err != nil || sm == nil {
+		return err
+	}
+
+	f := tf.cc.family(sd.Dict["ColorSpace"])
+
+	is, err := decodeImageSamplesN(tf.ctx, &sd, f.components())
+	if err != nil {
+		return err
+	}
+
+	var ms *imageSamples
+	if is != nil {
+		if ms, err = decodeImageSamplesN(tf.ctx, sm, 1); err != nil {
+			return err
+		}
+	}
+
+	if ms == nil {
+		return errors.Errorf("pdfcpu: flatten transparency: obj#%d: %v", objNr, errFlattenImage)
+	}
+
+	matte, err := numberArrayEntry(tf.ctx, sm.Dict, "Matte")
+	if err != nil {
+		return err
+	}
+	if len(matte) != is.n {
+		matte = nil
+	}
+
+	is1 := &imageSamples{w: is.w, h: is.h, n: is.n, pix: compositeSoftMask(f, is, ms, matte)}
+
+	sd1, err := imageStreamDict(&sd, is1, is.dct, colorConversionJPEGQuality)
+	if err != nil {
+		return err
+	}
+	sd1.Dict.Delete("SMask")
+
+	if entry, ok := tf.ctx.FindTableEntryLight(objNr); ok {
+		entry.Object = *sd1
+	}
+
+	tf.stats.Images++
+
+	return nil
+}
+
+// flattenExtGState makes graphics state parameter dict d opaque.
+func (tf *transparencyFlattener) flattenExtGState(d types.Dict) {
+	changed := false
+
+	for _, k := range []string{"ca", "CA"} {
+		if o, found := d.Find(k); found {
+			if f, err := tf.ctx.DereferenceNumber(o); err != nil || f != 1 {
+				d.Update(k, types.Float(1))
+				changed = true
+			}
+		}
+	}
+
+	if o, found := d.Find("BM"); found {
+		if n, ok := o.(types.Name); !ok || n != "Normal" {
+			d.Update("BM", types.Name("Normal"))
+			changed = true
+		}
+	}
+
+	if o, found := d.Find("SMask"); found {
+		if n, ok := o.(types.Name); !ok || n != "None" {
+			d.Update("SMask", types.Name("None"))
+			tf.stats.SoftMasks++
+			changed = true
+		}
+	}
+
+	if ais := d.BooleanEntry("AIS"); ais != nil && *ais {
+		d.Delete("AIS")
+		changed = true
+	}
+
+	if changed {
+		tf.stats.ExtGStates++
+	}
+}
+
+// removeTransparencyGroup removes the transparency group attributes of a page or form XObject.
+func (tf *transparencyFlattener) removeTransparencyGroup(d types.Dict) error {
+	o, found := d.Find("Group")
+	if !found {
+		return nil
+	}
+	g, err := tf.ctx.DereferenceDict(o)
+	if err != nil || g == nil {
+		return err
+	}
+	if s := g.NameEntry("S"); s != nil && *s == "Transparency" {
+		d.Delete("Group")
+		tf.stats.Groups++
+	}
+	return nil
+}
+
+func (tf *transparencyFlattener) flattenObject(o types.Object) error {
+	switch o := o.(type) {
+
+	case types.Dict:
+		if o1, found := o.Find("ExtGState"); found {
+			d, err := tf.ctx.DereferenceDict(o1)
+			if err != nil {
+				return err
+			}
+			for _, v := range d {
+				gs, err := tf.ctx.DereferenceDict(v)
+				if err != nil {
+					return err
+				}
+				if gs != nil {
+					tf.flattenExtGState(gs)
+				}
+			}
+		}
+		if t := o.Type(); t != nil && *t == "Page" {
+			if err := tf.removeTransparencyGroup(o); err != nil {
+				return err
+			}
+		}
+		for _, v := range o {
+			if err := tf.flattenObject(v); err != nil {
+				return err
+			}
+		}
+
+	case types.StreamDict:
+		if err := tf.removeTransparencyGroup(o.Dict); err != nil {
+			return err
+		}
+		return tf.flattenObject(o.Dict)
+
+	case types.Array:
+		for _, v := range o {
+			if err := tf.flattenObject(v); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (tf *transparencyFlattener) flattenObjects() error {
+	for _, objNr := range tableObjNrs(tf.ctx) {
+		// Also loads objects from object streams.
+		o, err := tf.ctx.Dereference(*types.NewIndirectRef(objNr, *tf.ctx.Table[objNr].Generation))
+		if err != nil {
+			return err
+		}
+
+		switch o := o.(type) {
+
+		case types.StreamDict:
+			if isContentStream(o) {
+				if err := tf.flattenContentStream(objNr, o.Dict["Resources"]); err != nil {
+					return err
+				}
+			}
+
+		case types.Dict:
+			if st := o.Subtype(); st != nil && *st == "Type3" {
+				if err := tf.flattenType3Glyphs(o); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Only images painted on a white backdrop are known once all content got processed.
+	for _, objNr := range tableObjNrs(tf.ctx) {
+		if !tf.images[objNr] {
+			continue
+		}
+		if sd, ok := tf.ctx.Table[objNr].Object.(types.StreamDict); ok {
+			if err := tf.flattenImage(objNr, sd); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Graphics states are made opaque only after all content got blended with their alpha values.
+	for _, objNr := range tableObjNrs(tf.ctx) {
+		if err := tf.flattenObject(tf.ctx.Table[objNr].Object); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FlattenTransparency makes all content of ctx opaque assuming it gets painted on an opaque white backdrop.
+// Colors painted with constant alpha are blended with white, images painted on white get composited with their soft mask,
+// blend modes are reset to Normal and transparency groups are removed.
+// Flattening any other transparency involves rasterizing which is not supported and results in an error:
+// transparent content painted over other content or within forms, patterns and glyphs,
+// content painted through soft masks or using other blend modes and
+// images, shadings and forms painted with constant alpha.
+func FlattenTransparency(ctx *model.Context) (*model.TransparencyStats, error) {
+	tf := &transparencyFlattener{
+		ctx:    ctx,
+		cc:     &colorConverter{ctx: ctx},
+		done:   types.IntSet{},
+		images: types.IntSet{},
+	}
+
+	if err := tf.flattenPages(); err != nil {
+		return nil, err
+	}
+
+	if err := tf.flattenObjects(); err != nil {
+		return nil, err
+	}
+
+	return &tf.stats, nil
+}
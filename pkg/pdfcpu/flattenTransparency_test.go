@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestFlattenContent(t *testing.T) {
+	ctx, err := CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resDict := types.Dict{
+		"ExtGState": types.Dict{
+			"GS0": types.Dict{"ca": types.Float(.5), "CA": types.Float(.25)},
+			"GS1": types.Dict{"ca": types.Float(1)},
+			"GS2": types.Dict{"BM": types.Name("Multiply")},
+			"GS3": types.Dict{"SMask": types.Dict{"S": types.Name("Luminosity")}},
+			"GS4": types.Dict{"SMask": types.Name("None"), "BM": types.Array{types.Name("Normal")}},
+		},
+	}
+
+	tf := &transparencyFlattener{ctx: ctx, cc: &colorConverter{ctx: ctx}, done: types.IntSet{}, images: types.IntSet{}}
+
+	for _, tt := range []struct {
+		in, want string
+	}{
+		{"1 0 0 rg 0 0 9 9 re f", "1 0 0 rg 0 0 9 9 re f"},
+		{"/GS0 gs 1 0 0 rg 0 0 9 9 re f", "/GS0 gs 0.5 g 0.75 G 1 0.5 0.5 rg 0 0 9 9 re f"},
+		{"0 0 0 1 K /GS0 gs 0 0 9 9 re S", "0 0 0 1 K /GS0 gs 0.5 g 0 0 0 0.25 K 0 0 9 9 re S"},
+		{"q /GS0 gs 0 g Q 0 g", "q /GS0 gs 0.5 g 0.75 G 0.5 g Q 0 g"},
+		{"/GS0 gs /DeviceRGB cs 0 0 1 sc", "/GS0 gs 0.5 g 0.75 G /DeviceRGB cs 0.5 0.5 0.5 sc 0.5 0.5 1 sc"},
+		{"/GS0 gs 0 g /GS1 gs", "/GS0 gs 0.5 g 0.75 G 0.5 g /GS1 gs 0 g"},
+		{"/GS0 gs /P0 scn", "/GS0 gs 0.5 g 0.75 G /P0 scn"},
+		{"/GS0 gs 0 0 9 9 re f /GS1 gs 0 0 9 9 re f", "/GS0 gs 0.5 g 0.75 G 0 0 9 9 re f /GS1 gs 0 g 0 0 9 9 re f"},
+		{"BT /F0 12 Tf (a) Tj ET /GS0 gs 7 Tr BT (a) Tj ET", "BT /F0 12 Tf (a) Tj ET /GS0 gs 0.5 g 0.75 G 7 Tr BT (a) Tj ET"},
+		{"/GS2 gs /GS3 gs /GS4 gs 0 0 9 9 re f", "/GS2 gs /GS3 gs /GS4 gs 0 0 9 9 re f"},
+	} {
+		got, _, err := tf.flattenContent([]byte(tt.in), resDict, false)
+		if err != nil {
+			t.Errorf("flattenContent(%q): %v\n", tt.in, err)
+			continue
+		}
+		if string(got) != tt.want {
+			t.Errorf("flattenContent(%q):\ngot:  %q\nwant: %q\n", tt.in, got, tt.want)
+		}
+	}
+
+	for _, tt := range []struct {
+		in      string
+		painted bool
+		want    error
+	}{
+		// A highlight over text.
+		{"BT /F0 12 Tf (a) Tj ET /GS0 gs 1 1 0 rg 0 0 9 9 re f", false, errFlattenBackdrop},
+		{"0 0 9 9 re f /GS0 gs 0 0 9 9 re S", false, errFlattenBackdrop},
+		{"/GS0 gs 0 0 9 9 re f 0 0 9 9 re f", false, errFlattenBackdrop},
+		{"/GS0 gs 0 0 9 9 re f", true, errFlattenBackdrop},
+		{"/GS2 gs 0 0 9 9 re f", false, errFlattenBlendMode},
+		{"/GS3 gs 0 0 9 9 re f", false, errFlattenSoftMask},
+		{"/GS0 gs /Pattern cs /P0 scn 0 0 9 9 re f", false, errFlattenColor},
+		{"/GS0 gs /Sh0 sh", false, errFlattenAlpha},
+		{"/GS0 gs /Im0 Do", false, errFlattenAlpha},
+	} {
+		if _, _, err := tf.flattenContent([]byte(tt.in), resDict, tt.painted); err != tt.want {
+			t.Errorf("flattenContent(%q): got error %v, want %v\n", tt.in, err, tt.want)
+		}
+	}
+}
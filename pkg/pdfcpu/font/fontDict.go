@@ -548,6 +548,51 @@ func CIDWidths(xRefTable *model.XRefTable, ttf font.TTFLight, fontName string, s
 	return indRef, nil
 }
 
+func glyphHeight(ttf font.TTFLight, gid int) int {
+	if gid < len(ttf.GlyphHeights) && ttf.GlyphHeights[gid] > 0 {
+		return ttf.GlyphHeights[gid]
+	}
+	if h := ttf.Ascent - ttf.Descent; h > 0 {
+		return h
+	}
+	return 1000
+}
+
+func defaultVerticalMetrics(ttf font.TTFLight) types.Array {
+	return types.Array{types.Integer(ttf.Ascent), types.Integer(-glyphHeight(ttf, 0))}
+}
+
+// CIDVerticalMetrics returns the value for W2 in a CIDFontDict used for vertical writing (see 9.7.4.3).
+// Each used glyph is positioned horizontally centered with its vertical origin at the font's ascent.
+func CIDVerticalMetrics(xRefTable *model.XRefTable, ttf font.TTFLight, fontName string) (*types.IndirectRef, error) {
+	usedGIDs := xRefTable.UsedGIDs[fontName]
+	if len(usedGIDs) == 0 {
+		return nil, nil
+	}
+
+	gids := make([]int, 0, len(usedGIDs))
+	for gid := range usedGIDs {
+		gids = append(gids, int(gid))
+	}
+	sort.Ints(gids)
+
+	// c [w1y v1x v1y]
+	a := types.Array{}
+	for _, gid := range gids {
+		var w int
+		if gid < len(ttf.GlyphWidths) {
+			w = ttf.GlyphWidths[gid]
+		}
+		a = append(a, types.Integer(gid), types.Array{
+			types.Integer(-glyphHeight(ttf, gid)),
+			types.Integer(w / 2),
+			types.Integer(ttf.Ascent),
+		})
+	}
+
+	return xRefTable.IndRefForNewObject(a)
+}
+
 // Widths returns the value for Widths in a TrueType FontDict.
 func Widths(xRefTable *model.XRefTable, ttf font.TTFLight, first, last int) (*types.IndirectRef, error) {
 	a := types.Array{}
@@ -873,7 +918,7 @@ func CIDFontDict(xRefTable *model.XRefTable, ttf font.TTFLight, fontName, baseFo
 	return xRefTable.IndRefForNewObject(d)
 }
 
-func type0FontDict(xRefTable *model.XRefTable, fontName, lang, script string, indRef *types.IndirectRef) (*types.IndirectRef, error) {
+func type0FontDict(xRefTable *model.XRefTable, fontName, lang, script string, vertical bool, indRef *types.IndirectRef) (*types.IndirectRef, error) {
 	font.UserFontMetricsLock.RLock()
 	ttf, ok := font.UserFontMetrics[fontName]
 	font.UserFontMetricsLock.RUnlock()
@@ -901,7 +946,9 @@ func type0FontDict(xRefTable *model.XRefTable, fontName, lang, script string, in
 	}
 
 	encoding := "Identity-H"
-	if parms != nil {
+	if vertical {
+		encoding = "Identity-V"
+	} else if parms != nil {
 		encoding = parms.encoding
 	}
 
@@ -910,6 +957,12 @@ func type0FontDict(xRefTable *model.XRefTable, fontName, lang, script string, in
 		return nil, err
 	}
 
+	if vertical {
+		if err := addVerticalMetrics(xRefTable, ttf, fontName, *descendentFontIndRef); err != nil {
+			return nil, err
+		}
+	}
+
 	d := types.NewDict()
 	d.InsertName("Type", "Font")
 	d.InsertName("Subtype", "Type0")
@@ -941,6 +994,48 @@ func type0FontDict(xRefTable *model.XRefTable, fontName, lang, script string, in
 	return indRef, nil
 }
 
+func addVerticalMetrics(xRefTable *model.XRefTable, ttf font.TTFLight, fontName string, indRef types.IndirectRef) error {
+	d, err := xRefTable.DereferenceDict(indRef)
+	if err != nil {
+		return err
+	}
+
+	d["DW2"] = defaultVerticalMetrics(ttf)
+
+	w2IndRef, err := CIDVerticalMetrics(xRefTable, ttf, fontName)
+	if err != nil {
+		return err
+	}
+	if w2IndRef != nil {
+		d["W2"] = *w2IndRef
+	}
+
+	return nil
+}
+
+// verticalType0FontDict returns a Type0 font dict using Identity-V for the user font registered as fontName via font.VerticalFontName.
+func verticalType0FontDict(xRefTable *model.XRefTable, fontName, baseFontName, lang string, indRef *types.IndirectRef) (*types.IndirectRef, error) {
+	// Glyphs used for vertical writing are collected separately and go into their own font subset.
+	// Temporarily hand them over to the subsetting machinery which operates on the user font name.
+	gids, ok := xRefTable.UsedGIDs[baseFontName]
+	if m := xRefTable.UsedGIDs[fontName]; m != nil {
+		xRefTable.UsedGIDs[baseFontName] = m
+	} else {
+		delete(xRefTable.UsedGIDs, baseFontName)
+	}
+
+	defer func() {
+		delete(xRefTable.UsedGIDs, fontName)
+		if ok {
+			xRefTable.UsedGIDs[baseFontName] = gids
+			return
+		}
+		delete(xRefTable.UsedGIDs, baseFontName)
+	}()
+
+	return type0FontDict(xRefTable, baseFontName, lang, "", true, indRef)
+}
+
 func trueTypeFontDict(xRefTable *model.XRefTable, fontName, fontLang string) (*types.IndirectRef, error) {
 	font.UserFontMetricsLock.RLock()
 	ttf, ok := font.UserFontMetrics[fontName]
@@ -996,10 +1091,13 @@ func EnsureFontDict(xRefTable *model.XRefTable, fontName, lang, script string, f
 		}
 		return coreFontDict(xRefTable, fontName)
 	}
+	if baseFontName, ok := font.VerticalBaseFontName(fontName); ok {
+		return verticalType0FontDict(xRefTable, fontName, baseFontName, lang, indRef)
+	}
 	if field && (script == "" || !CJK(script, lang)) {
 		return trueTypeFontDict(xRefTable, fontName, lang)
 	}
-	return type0FontDict(xRefTable, fontName, lang, script, indRef)
+	return type0FontDict(xRefTable, fontName, lang, script, false, indRef)
 }
 
 // FontResources returns a font resource dict for a font map.
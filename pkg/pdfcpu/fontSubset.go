@@ -0,0 +1,1144 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"crypto/md5"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// fontUsage collects the character codes shown per font dict.
+type fontUsage struct {
+	ctx     *model.Context
+	codes   map[int]types.IntSet // character codes by font dict object number
+	twoByte map[int]bool         // fonts using 2 byte codes by font dict object number
+	blocked types.IntSet         // font programs which must not be subset
+	active  types.IntSet         // content streams being processed
+	done    types.IntSet         // content streams processed using their own resources
+}
+
+// blockFont prevents the font program of font dict d from being subset.
+func (fu *fontUsage) blockFont(d types.Dict) {
+	if objNr, _, _ := fontProgram(fu.ctx, d); objNr > 0 {
+		fu.blocked[objNr] = true
+	}
+}
+
+// font returns the object number of the font dict fontRef which is 0 for direct font dicts.
+func (fu *fontUsage) font(fontRef types.Object) (int, types.Dict, error) {
+	d, err := fu.ctx.DereferenceDict(fontRef)
+	if err != nil || d == nil {
+		return 0, nil, err
+	}
+
+	indRef, ok := fontRef.(types.IndirectRef)
+	if !ok {
+		fu.blockFont(d)
+		return 0, d, nil
+	}
+
+	objNr := indRef.ObjectNumber.Value()
+	if _, ok := fu.twoByte[objNr]; !ok {
+		st := d.Subtype()
+		fu.twoByte[objNr] = st != nil && *st == "Type0"
+	}
+
+	return objNr, d, nil
+}
+
+func (fu *fontUsage) type3Font(objNr int, d types.Dict, resDict types.Dict, depth int) error {
+	if fu.active[objNr] || fu.done[objNr] || depth > 10 {
+		return nil
+	}
+
+	res := resDict
+	if d1, err := fu.ctx.DereferenceDict(d["Resources"]); err == nil && d1 != nil {
+		res = d1
+	}
+
+	procs, err := fu.ctx.DereferenceDict(d["CharProcs"])
+	if err != nil || procs == nil {
+		return err
+	}
+
+	fu.active[objNr] = true
+	defer delete(fu.active, objNr)
+
+	for _, o := range procs {
+		sd, _, err := fu.ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			continue
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		if err := fu.process(sd.Content, res, depth+1); err != nil {
+			return err
+		}
+	}
+
+	fu.done[objNr] = true
+
+	return nil
+}
+
+func (fu *fontUsage) selectFont(resDict types.Dict, name string, depth int) (int, error) {
+	fonts, err := fu.ctx.DereferenceDict(resDict["Font"])
+	if err != nil || fonts == nil {
+		return 0, err
+	}
+
+	objNr, d, err := fu.font(fonts[name])
+	if err != nil || d == nil {
+		return 0, err
+	}
+
+	if st := d.Subtype(); st != nil && *st == "Type3" {
+		if err := fu.type3Font(objNr, d, resDict, depth); err != nil {
+			return 0, err
+		}
+	}
+
+	return objNr, nil
+}
+
+func (fu *fontUsage) extGStateFont(resDict types.Dict, name string) (int, bool, error) {
+	gStates, err := fu.ctx.DereferenceDict(resDict["ExtGState"])
+	if err != nil || gStates == nil {
+		return 0, false, err
+	}
+
+	d, err := fu.ctx.DereferenceDict(gStates[name])
+	if err != nil || d == nil {
+		return 0, false, err
+	}
+
+	a, err := fu.ctx.DereferenceArray(d["Font"])
+	if err != nil || len(a) != 2 {
+		return 0, false, err
+	}
+
+	objNr, _, err := fu.font(a[0])
+
+	return objNr, true, err
+}
+
+func (fu *fontUsage) recordString(objNr int, t string) {
+	if objNr == 0 {
+		return
+	}
+
+	codes, ok := fu.codes[objNr]
+	if !ok {
+		codes = types.IntSet{}
+		fu.codes[objNr] = codes
+	}
+
+	bb := contentStringBytes(t)
+
+	if fu.twoByte[objNr] {
+		for i := 0; i+1 < len(bb); i += 2 {
+			codes[int(bb[i])<<8|int(bb[i+1])] = true
+		}
+		return
+	}
+
+	for _, b := range bb {
+		codes[int(b)] = true
+	}
+}
+
+// contentStream processes form XObjects, tiling patterns and appearance streams.
+func (fu *fontUsage) contentStream(o types.Object, resDict types.Dict, depth int) error {
+	indRef, ok := o.(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+
+	objNr := indRef.ObjectNumber.Value()
+	if fu.active[objNr] || fu.done[objNr] || depth > 10 {
+		return nil
+	}
+
+	sd, _, err := fu.ctx.DereferenceStreamDict(indRef)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	if st := sd.Subtype(); st != nil && *st != "Form" {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	res := resDict
+	d, err := fu.ctx.DereferenceDict(sd.Dict["Resources"])
+	if err != nil {
+		return err
+	}
+	if d != nil {
+		res = d
+	}
+
+	fu.active[objNr] = true
+	err = fu.process(sd.Content, res, depth+1)
+	delete(fu.active, objNr)
+
+	if d != nil {
+		fu.done[objNr] = true
+	}
+
+	return err
+}
+
+func (fu *fontUsage) patterns(resDict types.Dict, depth int) error {
+	patterns, err := fu.ctx.DereferenceDict(resDict["Pattern"])
+	if err != nil || patterns == nil {
+		return err
+	}
+
+	for _, o := range patterns {
+		sd, _, err := fu.ctx.DereferenceStreamDict(o)
+		if err != nil || sd == nil {
+			continue
+		}
+		if pt := sd.IntEntry("PatternType"); pt != nil && *pt == 1 {
+			if err := fu.contentStream(o, nil, depth); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fu *fontUsage) process(content []byte, resDict types.Dict, depth int) error {
+	if resDict == nil {
+		return nil
+	}
+
+	if err := fu.patterns(resDict, depth); err != nil {
+		return err
+	}
+
+	var (
+		objNr int
+		stack []int
+		err   error
+	)
+
+	for _, op := range parseContentOps(content) {
+		switch op.name {
+
+		case "q":
+			stack = append(stack, objNr)
+
+		case "Q":
+			if n := len(stack); n > 0 {
+				objNr, stack = stack[n-1], stack[:n-1]
+			}
+
+		case "Tf":
+			if len(op.operands) == 2 {
+				if objNr, err = fu.selectFont(resDict, strings.TrimPrefix(op.operands[0], "/"), depth); err != nil {
+					return err
+				}
+			}
+
+		case "gs":
+			if len(op.operands) == 1 {
+				i, ok, err := fu.extGStateFont(resDict, strings.TrimPrefix(op.operands[0], "/"))
+				if err != nil {
+					return err
+				}
+				if ok {
+					objNr = i
+				}
+			}
+
+		case "Tj", "'", "\"":
+			if n := len(op.operands); n > 0 {
+				fu.recordString(objNr, op.operands[n-1])
+			}
+
+		case "TJ":
+			if len(op.operands) > 0 {
+				for _, e := range textArrayElements(op.operands[0]) {
+					if e[0] == '(' || e[0] == '<' {
+						fu.recordString(objNr, e)
+					}
+				}
+			}
+
+		case "Do":
+			if len(op.operands) == 1 {
+				xObjs, err := fu.ctx.DereferenceDict(resDict["XObject"])
+				if err != nil {
+					return err
+				}
+				if xObjs != nil {
+					if err := fu.contentStream(xObjs[strings.TrimPrefix(op.operands[0], "/")], resDict, depth); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (fu *fontUsage) appearances(pageDict types.Dict) error {
+	annots, err := fu.ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		return err
+	}
+
+	for _, o := range annots {
+		d, err := fu.ctx.DereferenceDict(o)
+		if err != nil || d == nil {
+			continue
+		}
+		ap, err := fu.ctx.DereferenceDict(d["AP"])
+		if err != nil || ap == nil {
+			continue
+		}
+		for _, k := range []string{"N", "R", "D"} {
+			o, found := ap.Find(k)
+			if !found {
+				continue
+			}
+			if states, err := fu.ctx.DereferenceDict(o); err == nil && states != nil {
+				for _, o := range states {
+					if err := fu.contentStream(o, nil, 0); err != nil {
+						return err
+					}
+				}
+				continue
+			}
+			if err := fu.contentStream(o, nil, 0); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// blockFormFonts prevents fonts used for filling in form fields from being subset.
+func (fu *fontUsage) blockFormFonts() error {
+	rootDict, err := fu.ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d, err := fu.ctx.DereferenceDict(rootDict["AcroForm"])
+	if err != nil || d == nil {
+		return err
+	}
+
+	dr, err := fu.ctx.DereferenceDict(d["DR"])
+	if err != nil || dr == nil {
+		return err
+	}
+
+	fonts, err := fu.ctx.DereferenceDict(dr["Font"])
+	if err != nil || fonts == nil {
+		return err
+	}
+
+	for _, o := range fonts {
+		if d, err := fu.ctx.DereferenceDict(o); err == nil && d != nil {
+			fu.blockFont(d)
+		}
+	}
+
+	return nil
+}
+
+// collectFontUsage returns the character codes shown per font dict
+// on pages, in form XObjects, tiling patterns, Type3 glyphs and annotation appearances.
+func collectFontUsage(ctx *model.Context) (*fontUsage, error) {
+	fu := &fontUsage{
+		ctx:     ctx,
+		codes:   map[int]types.IntSet{},
+		twoByte: map[int]bool{},
+		blocked: types.IntSet{},
+		active:  types.IntSet{},
+		done:    types.IntSet{},
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		pageDict, content, resDict, err := pageContentAndResources(ctx, pageNr)
+		if err != nil {
+			return nil, err
+		}
+		if err := fu.process(content, resDict, 0); err != nil {
+			return nil, err
+		}
+		if err := fu.appearances(pageDict); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := fu.blockFormFonts(); err != nil {
+		return nil, err
+	}
+
+	return fu, nil
+}
+
+func fontDescriptorDict(ctx *model.Context, d types.Dict) (types.Dict, error) {
+	if st := d.Subtype(); st != nil && *st == "Type0" {
+		a, err := ctx.DereferenceArray(d["DescendantFonts"])
+		if err != nil || len(a) != 1 {
+			return nil, err
+		}
+		if d, err = ctx.DereferenceDict(a[0]); err != nil || d == nil {
+			return nil, err
+		}
+	}
+	return ctx.DereferenceDict(d["FontDescriptor"])
+}
+
+// fontProgram returns the object number and key of the embedded font program of font dict d.
+func fontProgram(ctx *model.Context, d types.Dict) (int, string, error) {
+	fd, err := fontDescriptorDict(ctx, d)
+	if err != nil || fd == nil {
+		return 0, "", err
+	}
+	for _, k := range []string{"FontFile", "FontFile2", "FontFile3"} {
+		if indRef := fd.IndirectRefEntry(k); indRef != nil {
+			return indRef.ObjectNumber.Value(), k, nil
+		}
+	}
+	return 0, "", nil
+}
+
+// subsetFont represents a font dict whose font program is going to be subset.
+type subsetFont struct {
+	objNr          int
+	d, cidFont, fd types.Dict
+	codes          types.IntSet
+	cidToGIDMap    []byte // nil for Identity
+	fontFile       string // FontFile2 or FontFile3
+}
+
+// subsetProgram represents a font program along with the font dicts using it.
+type subsetProgram struct {
+	objNr     int
+	sd        *types.StreamDict
+	fonts     []*subsetFont
+	gids      map[uint16]bool
+	cids      types.IntSet
+	supported bool
+}
+
+type fontSubsetter struct {
+	ctx      *model.Context
+	fu       *fontUsage
+	programs map[int]*subsetProgram
+	users    map[int]types.IntSet // font dicts referencing a font program
+	covered  map[int]types.IntSet // font dicts whose usage is known per font program
+	refs     map[int]int          // number of font dicts referencing a ToUnicode stream
+	stats    model.FontSubsetStats
+}
+
+func isSubsetFontName(s string) bool {
+	if len(s) < 7 || s[6] != '+' {
+		return false
+	}
+	for i := 0; i < 6; i++ {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// newSubsetFont returns the font dict objNr if its font program is suitable for subsetting.
+func (fs *fontSubsetter) newSubsetFont(objNr int, d types.Dict) (*subsetFont, int, error) {
+	progObjNr, key, err := fontProgram(fs.ctx, d)
+	if err != nil || progObjNr == 0 {
+		return nil, 0, err
+	}
+
+	f := &subsetFont{objNr: objNr, d: d, codes: fs.fu.codes[objNr], fontFile: key}
+	if f.codes == nil {
+		f.codes = types.IntSet{}
+	}
+
+	if bf := d.NameEntry("BaseFont"); bf == nil || isSubsetFontName(*bf) {
+		return nil, progObjNr, nil
+	}
+
+	st := d.Subtype()
+	if st == nil {
+		return nil, progObjNr, nil
+	}
+
+	switch *st {
+
+	case "TrueType":
+		if key != "FontFile2" {
+			return nil, progObjNr, nil
+		}
+
+	case "Type0":
+		enc := d.NameEntry("Encoding")
+		if enc == nil || (*enc != "Identity-H" && *enc != "Identity-V") {
+			return nil, progObjNr, nil
+		}
+		a, err := fs.ctx.DereferenceArray(d["DescendantFonts"])
+		if err != nil || len(a) != 1 {
+			return nil, progObjNr, err
+		}
+		if f.cidFont, err = fs.ctx.DereferenceDict(a[0]); err != nil || f.cidFont == nil {
+			return nil, progObjNr, err
+		}
+		if ok, err := f.cidToGID(fs.ctx); err != nil || !ok {
+			return nil, progObjNr, err
+		}
+
+	default:
+		return nil, progObjNr, nil
+	}
+
+	if f.fd, err = fontDescriptorDict(fs.ctx, d); err != nil {
+		return nil, progObjNr, err
+	}
+
+	return f, progObjNr, nil
+}
+
+// cidToGID checks the font program type of a CIDFont and reads its CIDToGIDMap.
+func (f *subsetFont) cidToGID(ctx *model.Context) (bool, error) {
+	st := f.cidFont.Subtype()
+	if st == nil {
+		return false, nil
+	}
+
+	switch *st {
+
+	case "CIDFontType0":
+		return f.fontFile == "FontFile3", nil
+
+	case "CIDFontType2":
+		if f.fontFile != "FontFile2" {
+			return false, nil
+		}
+		o, found := f.cidFont.Find("CIDToGIDMap")
+		if !found {
+			return true, nil
+		}
+		o, err := ctx.Dereference(o)
+		if err != nil {
+			return false, err
+		}
+		switch o := o.(type) {
+		case types.Name:
+			return o == "Identity", nil
+		case types.StreamDict:
+			if err := o.Decode(); err != nil {
+				return false, err
+			}
+			f.cidToGIDMap = o.Content
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (f *subsetFont) sortedCodes() []int {
+	cids := make([]int, 0, len(f.codes))
+	for c := range f.codes {
+		cids = append(cids, c)
+	}
+	sort.Ints(cids)
+	return cids
+}
+
+func (f *subsetFont) trueTypeCIDFontGIDs(gids map[uint16]bool) {
+	for c := range f.codes {
+		if f.cidToGIDMap == nil {
+			gids[uint16(c)] = true
+			continue
+		}
+		if 2*c+1 < len(f.cidToGIDMap) {
+			gids[uint16(f.cidToGIDMap[2*c])<<8|uint16(f.cidToGIDMap[2*c+1])] = true
+		}
+	}
+}
+
+func (f *subsetFont) unicodes(c int, toUnicode map[int]string) []rune {
+	rr := []rune{rune(c)}
+
+	if s, ok := toUnicode[c]; ok {
+		for _, r := range s {
+			rr = append(rr, r)
+			break
+		}
+	}
+
+	var cm *charmap.Charmap
+
+	if n := f.d.NameEntry("Encoding"); n != nil {
+		switch *n {
+		case "WinAnsiEncoding":
+			cm = charmap.Windows1252
+		case "MacRomanEncoding":
+			cm = charmap.Macintosh
+		}
+	}
+
+	if cm != nil {
+		rr = append(rr, cm.DecodeByte(byte(c)))
+	}
+
+	return rr
+}
+
+// simpleTrueTypeGIDs adds the glyph ids for the character codes shown using a simple TrueType font
+// taking into account all cmap subtables a viewer might use.
+func (f *subsetFont) simpleTrueTypeGIDs(ctx *model.Context, bb []byte, gids map[uint16]bool) (bool, error) {
+	if enc, err := ctx.DereferenceDict(f.d["Encoding"]); err == nil && enc != nil {
+		if _, found := enc.Find("Differences"); found {
+			// Glyph names would have to be resolved.
+			return false, nil
+		}
+	}
+
+	cmaps, err := font.TrueTypeCMaps(bb)
+	if err != nil {
+		ctx.LogDebug("simpleTrueTypeGIDs: failed", "err", err)
+		return false, nil
+	}
+
+	var toUnicode map[int]string
+	if sd, _, err := ctx.DereferenceStreamDict(f.d["ToUnicode"]); err == nil && sd != nil {
+		if err := sd.Decode(); err == nil {
+			toUnicode = parseToUnicodeCMap(sd.Content)
+		}
+	}
+
+	for c := range f.codes {
+		found := false
+		add := func(m font.CMap, code uint32) {
+			if gid, ok := m[code]; ok {
+				gids[gid] = true
+				found = true
+			}
+		}
+
+		if m, ok := cmaps[font.CMapWindowsSymbol]; ok {
+			for _, off := range []uint32{0, 0xF000, 0xF100, 0xF200} {
+				add(m, off+uint32(c))
+			}
+		}
+
+		mac, hasMac := cmaps[font.CMapMacRoman]
+		if hasMac {
+			add(mac, uint32(c))
+		}
+
+		for _, r := range f.unicodes(c, toUnicode) {
+			for id, m := range cmaps {
+				if id == font.CMapWindowsBMP || id.Platform == 0 {
+					add(m, uint32(r))
+				}
+			}
+			if hasMac {
+				if b, ok := charmap.Macintosh.EncodeRune(r); ok {
+					add(mac, uint32(b))
+				}
+			}
+		}
+
+		if !found {
+			// A viewer might resolve this code via glyph names.
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+func (fs *fontSubsetter) addFont(objNr int, d types.Dict) error {
+	f, progObjNr, err := fs.newSubsetFont(objNr, d)
+	if err != nil || progObjNr == 0 {
+		return err
+	}
+
+	p, ok := fs.programs[progObjNr]
+	if !ok {
+		p = &subsetProgram{objNr: progObjNr, gids: map[uint16]bool{}, cids: types.IntSet{}, supported: true}
+		fs.programs[progObjNr] = p
+	}
+
+	if f == nil {
+		p.supported = false
+		return nil
+	}
+
+	if p.sd == nil {
+		sd, _, err := fs.ctx.DereferenceStreamDict(*types.NewIndirectRef(progObjNr, 0))
+		if err != nil || sd == nil {
+			p.supported = false
+			return err
+		}
+		if err := sd.Decode(); err != nil {
+			p.supported = false
+			return nil
+		}
+		p.sd = sd
+	}
+
+	if f.fontFile == "FontFile3" {
+		if st := p.sd.Subtype(); st == nil || *st != "CIDFontType0C" {
+			// OpenType and Type1C font programs are left alone.
+			p.supported = false
+			return nil
+		}
+	}
+
+	p.fonts = append(p.fonts, f)
+
+	fs.cover(progObjNr, objNr)
+	if f.cidFont != nil {
+		a, err := fs.ctx.DereferenceArray(d["DescendantFonts"])
+		if err != nil {
+			return err
+		}
+		if indRef, ok := a[0].(types.IndirectRef); ok {
+			fs.cover(progObjNr, indRef.ObjectNumber.Value())
+		}
+	}
+
+	return nil
+}
+
+func (fs *fontSubsetter) cover(progObjNr, objNr int) {
+	if fs.covered[progObjNr] == nil {
+		fs.covered[progObjNr] = types.IntSet{}
+	}
+	fs.covered[progObjNr][objNr] = true
+}
+
+// scanFonts registers all font dicts along with the font programs they use.
+func (fs *fontSubsetter) scanFonts() error {
+	objNrs := make([]int, 0, len(fs.ctx.Table))
+	for objNr := range fs.ctx.Table {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objNr := range objNrs {
+		entry := fs.ctx.Table[objNr]
+		if entry == nil || entry.Free || entry.Object == nil || entry.Generation == nil {
+			continue
+		}
+
+		// Also loads objects from object streams.
+		o, err := fs.ctx.Dereference(*types.NewIndirectRef(objNr, *entry.Generation))
+		if err != nil {
+			return err
+		}
+
+		d, ok := o.(types.Dict)
+		if !ok {
+			continue
+		}
+
+		_, hasFD := d.Find("FontDescriptor")
+		_, hasDF := d.Find("DescendantFonts")
+		if !hasFD && !hasDF {
+			continue
+		}
+
+		if indRef, ok := d["ToUnicode"].(types.IndirectRef); ok {
+			fs.refs[indRef.ObjectNumber.Value()]++
+		}
+
+		if hasFD {
+			progObjNr, _, err := fontProgram(fs.ctx, d)
+			if err != nil {
+				return err
+			}
+			if progObjNr > 0 {
+				if fs.users[progObjNr] == nil {
+					fs.users[progObjNr] = types.IntSet{}
+				}
+				fs.users[progObjNr][objNr] = true
+			}
+		}
+
+		if st := d.Subtype(); st != nil && (*st == "Type0" || *st == "TrueType") {
+			if err := fs.addFont(objNr, d); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// subsettable returns true if the usage of p is known for all fonts using it.
+func (fs *fontSubsetter) subsettable(p *subsetProgram) bool {
+	if !p.supported || p.sd == nil || fs.fu.blocked[p.objNr] {
+		return false
+	}
+	for objNr := range fs.users[p.objNr] {
+		if !fs.covered[p.objNr][objNr] {
+			return false
+		}
+	}
+	return true
+}
+
+func (fs *fontSubsetter) glyphIDs(p *subsetProgram) (bool, error) {
+	for _, f := range p.fonts {
+
+		if f.cidFont == nil {
+			ok, err := f.simpleTrueTypeGIDs(fs.ctx, p.sd.Content, p.gids)
+			if err != nil || !ok {
+				return false, err
+			}
+			continue
+		}
+
+		for c := range f.codes {
+			p.cids[c] = true
+		}
+
+		if f.fontFile == "FontFile2" {
+			f.trueTypeCIDFontGIDs(p.gids)
+			continue
+		}
+
+		gids, err := font.CFFGlyphIDs(p.sd.Content, f.sortedCodes())
+		if err != nil {
+			fs.ctx.LogDebug("glyphIDs: failed", log.KeyObj, p.objNr, "err", err)
+			return false, nil
+		}
+		for gid := range gids {
+			p.gids[gid] = true
+		}
+	}
+
+	return true, nil
+}
+
+func subsetTag(bb []byte) string {
+	h := md5.Sum(bb)
+	tag := make([]byte, 6)
+	for i := range tag {
+		tag[i] = 'A' + h[i]%26
+	}
+	return string(tag)
+}
+
+func renameFont(d types.Dict, k, tag string) {
+	if n := d.NameEntry(k); n != nil && !isSubsetFontName(*n) {
+		d[k] = types.Name(tag + "+" + *n)
+	}
+}
+
+func widthObject(w float64) types.Object {
+	if w == math.Trunc(w) {
+		return types.Integer(int(w))
+	}
+	return types.Float(w)
+}
+
+// subsetCIDWidths returns the CIDFont glyph widths for cids.
+func subsetCIDWidths(ctx *model.Context, o types.Object, cids []int) types.Array {
+	m := cidWidths(ctx, o)
+
+	a := types.Array{}
+	var ww types.Array
+
+	for i, cid := range cids {
+		w, ok := m[cid]
+		if ok {
+			if ww == nil {
+				a = append(a, types.Integer(cid))
+			}
+			ww = append(ww, widthObject(w))
+		}
+		if ww != nil && (!ok || i == len(cids)-1 || cids[i+1] != cid+1) {
+			a = append(a, ww)
+			ww = nil
+		}
+	}
+
+	return a
+}
+
+func cidSet(cids types.IntSet) []byte {
+	maxCID := 0
+	for cid := range cids {
+		maxCID = max(maxCID, cid)
+	}
+	bb := make([]byte, maxCID/8+1)
+	bb[0] |= 0x80 // CID 0 is always present.
+	for cid := range cids {
+		bb[cid/8] |= 1 << (7 - cid%8)
+	}
+	return bb
+}
+
+// toUnicodeCMap returns a ToUnicode CMap for the codes in m.
+func toUnicodeCMap(m map[int]string, codes []int, twoByte bool) []byte {
+	var b bytes.Buffer
+
+	b.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	b.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	b.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+
+	format := "<%02X>"
+	b.WriteString("1 begincodespacerange\n")
+	if twoByte {
+		format = "<%04X>"
+		b.WriteString("<0000> <FFFF>\n")
+	} else {
+		b.WriteString("<00> <FF>\n")
+	}
+	b.WriteString("endcodespacerange\n")
+
+	for i := 0; i < len(codes); i += 100 {
+		cc := codes[i:min(i+100, len(codes))]
+		fmt.Fprintf(&b, "%d beginbfchar\n", len(cc))
+		for _, c := range cc {
+			fmt.Fprintf(&b, format+" <", c)
+			for _, v := range utf16.Encode([]rune(m[c])) {
+				fmt.Fprintf(&b, "%04X", v)
+			}
+			b.WriteString(">\n")
+		}
+		b.WriteString("endbfchar\n")
+	}
+
+	b.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	return b.Bytes()
+}
+
+// subsetToUnicode limits the ToUnicode CMap of f to the codes f uses unless it's shared.
+func (fs *fontSubsetter) subsetToUnicode(f *subsetFont) error {
+	indRef, ok := f.d["ToUnicode"].(types.IndirectRef)
+	if !ok || fs.refs[indRef.ObjectNumber.Value()] != 1 {
+		return nil
+	}
+
+	sd, _, err := fs.ctx.DereferenceStreamDict(indRef)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	if err := sd.Decode(); err != nil {
+		return nil
+	}
+
+	m := parseToUnicodeCMap(sd.Content)
+
+	codes := []int{}
+	for _, c := range f.sortedCodes() {
+		if _, ok := m[c]; ok {
+			codes = append(codes, c)
+		}
+	}
+
+	if len(codes) == 0 || len(codes) == len(m) {
+		return nil
+	}
+
+	sd1, err := fs.ctx.NewStreamDictForBuf(toUnicodeCMap(m, codes, f.cidFont != nil))
+	if err != nil {
+		return err
+	}
+	if err := sd1.Encode(); err != nil {
+		return err
+	}
+
+	if len(sd1.Raw) >= len(sd.Raw) {
+		return nil
+	}
+
+	fs.stats.BytesSaved += int64(len(sd.Raw) - len(sd1.Raw))
+	fs.updateEntry(indRef.ObjectNumber.Value(), sd1)
+
+	return nil
+}
+
+func (fs *fontSubsetter) updateEntry(objNr int, sd *types.StreamDict) {
+	if entry, ok := fs.ctx.FindTableEntry(objNr, 0); ok {
+		entry.Object = *sd
+	}
+}
+
+func (fs *fontSubsetter) updateCIDSet(fd types.Dict, cids types.IntSet) error {
+	indRef := fd.IndirectRefEntry("CIDSet")
+	if indRef == nil {
+		return nil
+	}
+	sd, err := fs.ctx.NewStreamDictForBuf(cidSet(cids))
+	if err != nil {
+		return err
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+	fs.updateEntry(indRef.ObjectNumber.Value(), sd)
+	return nil
+}
+
+// updateFonts renames the fonts using p and limits their widths and cmaps to the glyphs used.
+func (fs *fontSubsetter) updateFonts(p *subsetProgram, tag string) error {
+	cids := make([]int, 0, len(p.cids))
+	for cid := range p.cids {
+		cids = append(cids, cid)
+	}
+	sort.Ints(cids)
+
+	for _, f := range p.fonts {
+		renameFont(f.d, "BaseFont", tag)
+		renameFont(f.fd, "FontName", tag)
+
+		if f.cidFont != nil {
+			renameFont(f.cidFont, "BaseFont", tag)
+			if _, found := f.cidFont.Find("W"); found {
+				f.cidFont["W"] = subsetCIDWidths(fs.ctx, f.cidFont["W"], cids)
+			}
+			if err := fs.updateCIDSet(f.fd, p.cids); err != nil {
+				return err
+			}
+		}
+
+		if err := fs.subsetToUnicode(f); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (fs *fontSubsetter) subset(p *subsetProgram) error {
+	ok, err := fs.glyphIDs(p)
+	if err != nil || !ok {
+		return err
+	}
+
+	var bb []byte
+	if p.fonts[0].fontFile == "FontFile2" {
+		bb, err = font.SubsetTrueType(p.sd.Content, p.gids)
+	} else {
+		bb, err = font.SubsetCFF(p.sd.Content, p.gids)
+	}
+	if err != nil {
+		fs.ctx.LogDebug("subset: failed", log.KeyObj, p.objNr, "err", err)
+		return nil
+	}
+
+	d := p.sd.Dict.Clone().(types.Dict)
+	d.Delete("DecodeParms")
+	d.Update("Filter", types.Name(filter.Flate))
+	if p.fonts[0].fontFile == "FontFile2" {
+		d.Update("Length1", types.Integer(len(bb)))
+	}
+
+	sd := &types.StreamDict{
+		Dict:           d,
+		Content:        bb,
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	if len(sd.Raw) >= len(p.sd.Raw) {
+		return nil
+	}
+
+	fs.stats.Fonts++
+	fs.stats.BytesSaved += int64(len(p.sd.Raw) - len(sd.Raw))
+	fs.updateEntry(p.objNr, sd)
+
+	return fs.updateFonts(p, subsetTag(bb))
+}
+
+// SubsetFonts replaces fully embedded TrueType and CID-keyed CFF font programs by subsets
+// containing the glyphs shown on pages, in form XObjects, tiling patterns and annotation appearances.
+// Glyph ids are preserved so that character codes remain valid.
+// Fonts used for filling in form fields, already subset fonts and fonts sharing their program
+// with fonts pdfcpu can't analyze are left alone.
+func SubsetFonts(ctx *model.Context) (*model.FontSubsetStats, error) {
+	if ctx == nil {
+		return nil, errors.New("pdfcpu: SubsetFonts: missing ctx")
+	}
+
+	fu, err := collectFontUsage(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fs := fontSubsetter{
+		ctx:      ctx,
+		fu:       fu,
+		programs: map[int]*subsetProgram{},
+		users:    map[int]types.IntSet{},
+		covered:  map[int]types.IntSet{},
+		refs:     map[int]int{},
+	}
+
+	if err := fs.scanFonts(); err != nil {
+		return nil, err
+	}
+
+	objNrs := make([]int, 0, len(fs.programs))
+	for objNr := range fs.programs {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objNr := range objNrs {
+		p := fs.programs[objNr]
+		if !fs.subsettable(p) {
+			continue
+		}
+		if err := fs.subset(p); err != nil {
+			return nil, err
+		}
+	}
+
+	return &fs.stats, nil
+}
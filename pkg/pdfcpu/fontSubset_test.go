@@ -0,0 +1,557 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"golang.org/x/image/font/sfnt"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/text/encoding/charmap"
+)
+
+// createFontContext returns a single page context showing content using font dict d as /F1.
+// d gets the font program bb embedded as fontFile with stream dict entries in sdEntries.
+func createFontContext(t *testing.T, content string, d types.Dict, fontFile string, bb []byte, sdEntries types.Dict) (*model.Context, types.Dict) {
+	t.Helper()
+
+	xRefTable, err := CreateXRefTableWithRootDict()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := model.Page{MediaBox: types.RectForFormat("A4"), Fm: model.FontMap{}, Buf: bytes.NewBufferString(content)}
+	if err := AddPageTreeWithSamplePage(xRefTable, rootDict, p); err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := CreateContext(xRefTable, nil)
+	if err := ctx.EnsurePageCount(); err != nil {
+		t.Fatal(err)
+	}
+
+	sd, err := ctx.NewStreamDictForBuf(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range sdEntries {
+		sd.Insert(k, v)
+	}
+	if err := sd.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	progIndRef, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fd := types.Dict{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name(*d.NameEntry("BaseFont")),
+		"Flags":       types.Integer(32),
+		"FontBBox":    types.NewNumberArray(0, -200, 1000, 800),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(800),
+		"Descent":     types.Integer(-200),
+		"CapHeight":   types.Integer(700),
+		"StemV":       types.Integer(80),
+		fontFile:      *progIndRef,
+	}
+	fdIndRef, err := ctx.IndRefForNewObject(fd)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fontDict := d
+	if a, ok := d["DescendantFonts"].(types.Array); ok {
+		fontDict = a[0].(types.Dict)
+		cidFontIndRef, err := ctx.IndRefForNewObject(fontDict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d["DescendantFonts"] = types.Array{*cidFontIndRef}
+	}
+	fontDict["FontDescriptor"] = *fdIndRef
+
+	fontIndRef, err := ctx.IndRefForNewObject(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pageDict["Resources"] = types.Dict{"Font": types.Dict{"F1": *fontIndRef}}
+
+	return ctx, d
+}
+
+func fontProgramBytes(t *testing.T, ctx *model.Context, d types.Dict) []byte {
+	t.Helper()
+
+	objNr, _, err := fontProgram(ctx, d)
+	if err != nil || objNr == 0 {
+		t.Fatalf("missing font program: %v\n", err)
+	}
+	sd, _, err := ctx.DereferenceStreamDict(*types.NewIndirectRef(objNr, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := sd.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	return sd.Content
+}
+
+func glyphSegments(t *testing.T, f *sfnt.Font, gid sfnt.GlyphIndex) sfnt.Segments {
+	t.Helper()
+
+	var b sfnt.Buffer
+	segs, err := f.LoadGlyph(&b, gid, fixed.I(1000), nil)
+	if err != nil {
+		t.Fatalf("glyph %d: %v\n", gid, err)
+	}
+	return append(sfnt.Segments(nil), segs...)
+}
+
+// checkSubsetTrueTypeFont checks the subset of TrueType font program bb embedded for font dict d
+// keeps the cmaps and the glyphs for used and drops the glyphs for unused.
+func checkSubsetTrueTypeFont(t *testing.T, ctx *model.Context, d types.Dict, bb []byte, used, unused []rune) {
+	t.Helper()
+
+	if bf := d.NameEntry("BaseFont"); bf == nil || !isSubsetFontName(*bf) {
+		t.Fatalf("want subset font name, got %v\n", d["BaseFont"])
+	}
+
+	bb1 := fontProgramBytes(t, ctx, d)
+	if len(bb1) >= len(bb) {
+		t.Fatalf("want smaller font program, got %d >= %d\n", len(bb1), len(bb))
+	}
+
+	cmaps, err := font.TrueTypeCMaps(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cmaps1, err := font.TrueTypeCMaps(bb1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cmaps) == 0 || !reflect.DeepEqual(cmaps, cmaps1) {
+		t.Fatal("want cmap subtables preserved")
+	}
+
+	// sfnt rejects tables not starting on a 4 byte boundary as found in some embedded fonts.
+	f, err := sfnt.Parse(bb)
+	if err != nil {
+		f = nil
+	}
+	f1, err := sfnt.Parse(bb1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b sfnt.Buffer
+	usedGIDs := map[sfnt.GlyphIndex]bool{}
+
+	for _, r := range used {
+		gid, err := f1.GlyphIndex(&b, r)
+		if err != nil || gid == 0 {
+			t.Fatalf("missing glyph for %q: %v\n", r, err)
+		}
+		segs1 := glyphSegments(t, f1, gid)
+		if len(segs1) == 0 && !unicode.IsSpace(r) || f != nil && !reflect.DeepEqual(glyphSegments(t, f, gid), segs1) {
+			t.Fatalf("glyph %d for %q modified\n", gid, r)
+		}
+		usedGIDs[gid] = true
+	}
+
+	for _, r := range unused {
+		gid, err := f1.GlyphIndex(&b, r)
+		if err != nil || gid == 0 || usedGIDs[gid] {
+			t.Fatalf("missing glyph for %q: %v\n", r, err)
+		}
+		if f != nil && len(glyphSegments(t, f, gid)) == 0 || len(glyphSegments(t, f1, gid)) > 0 {
+			t.Fatalf("unused glyph %d for %q still present\n", gid, r)
+		}
+	}
+}
+
+func TestSubsetSimpleTrueTypeFont(t *testing.T) {
+	const text = "Hello, World!"
+
+	for _, tt := range []struct {
+		fontFile string
+		cmaps    string
+	}{
+		{"Roboto-Regular.ttf", "cmap formats 4 and 12"},
+		{"unifont-13.0.03.ttf", "cmap formats 4, 6 and 12"},
+	} {
+		t.Run(tt.cmaps, func(t *testing.T) {
+			bb, err := os.ReadFile(filepath.Join("..", "testdata", "fonts", tt.fontFile))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ww := types.Array{}
+			for c := 32; c <= 126; c++ {
+				ww = append(ww, types.Integer(500))
+			}
+			d := types.Dict{
+				"Type":      types.Name("Font"),
+				"Subtype":   types.Name("TrueType"),
+				"BaseFont":  types.Name(strings.TrimSuffix(tt.fontFile, ".ttf")),
+				"FirstChar": types.Integer(32),
+				"LastChar":  types.Integer(126),
+				"Widths":    ww,
+				"Encoding":  types.Name("WinAnsiEncoding"),
+			}
+
+			ctx, d := createFontContext(t, "BT /F1 12 Tf 72 720 Td ("+text+") Tj ET", d, "FontFile2", bb,
+				types.Dict{"Length1": types.Integer(len(bb))})
+
+			stats, err := SubsetFonts(ctx)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stats.Fonts != 1 || stats.BytesSaved <= 0 {
+				t.Fatalf("want 1 subset font, got %+v\n", stats)
+			}
+
+			checkSubsetTrueTypeFont(t, ctx, d, bb, []rune(text), []rune("AaZz019"))
+		})
+	}
+
+	t.Run("cmap formats 0 and 4", func(t *testing.T) {
+		ctx, err := ReadFile(filepath.Join("..", "testdata", "WaldenFull.pdf"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.EnsurePageCount(); err != nil {
+			t.Fatal(err)
+		}
+
+		// Arial, a fully embedded simple TrueType font using WinAnsiEncoding.
+		const fontObjNr = 1477
+
+		d, err := ctx.DereferenceDict(*types.NewIndirectRef(fontObjNr, 0))
+		if err != nil {
+			t.Fatal(err)
+		}
+		bb := fontProgramBytes(t, ctx, d)
+
+		fu, err := collectFontUsage(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var used, unused []rune
+		for c := range fu.codes[fontObjNr] {
+			if c > ' ' {
+				used = append(used, charmap.Windows1252.DecodeByte(byte(c)))
+			}
+		}
+		for _, r := range "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789" {
+			if !fu.codes[fontObjNr][int(r)] {
+				unused = append(unused, r)
+			}
+		}
+		if len(used) == 0 || len(unused) == 0 {
+			t.Fatalf("want used and unused glyphs, got %q %q\n", used, unused)
+		}
+
+		stats, err := SubsetFonts(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stats.Fonts == 0 || stats.BytesSaved <= 0 {
+			t.Fatalf("want subset fonts, got %+v\n", stats)
+		}
+
+		checkSubsetTrueTypeFont(t, ctx, d, bb, used, unused)
+	})
+}
+
+func TestSubsetTrueTypeFontWithDifferences(t *testing.T) {
+	ctx, err := ReadFile(filepath.Join("..", "testdata", "CenterOfWhy.pdf"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.EnsurePageCount(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Arial, a fully embedded simple TrueType font whose encoding has a Differences array.
+	d, err := ctx.DereferenceDict(*types.NewIndirectRef(836, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bb := fontProgramBytes(t, ctx, d)
+
+	if _, err := SubsetFonts(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Glyph names would have to be resolved, so the font is left alone.
+	if bf := d.NameEntry("BaseFont"); bf == nil || *bf != "Arial" {
+		t.Fatalf("want font left alone, got %v\n", d["BaseFont"])
+	}
+	if !bytes.Equal(bb, fontProgramBytes(t, ctx, d)) {
+		t.Fatal("want font program left alone")
+	}
+}
+
+func cffTestIndex(elems ...[]byte) []byte {
+	if len(elems) == 0 {
+		return []byte{0, 0}
+	}
+	bb := binary.BigEndian.AppendUint16(nil, uint16(len(elems)))
+	bb = append(bb, 4)
+	off := uint32(1)
+	bb = binary.BigEndian.AppendUint32(bb, off)
+	for _, e := range elems {
+		off += uint32(len(e))
+		bb = binary.BigEndian.AppendUint32(bb, off)
+	}
+	for _, e := range elems {
+		bb = append(bb, e...)
+	}
+	return bb
+}
+
+// CFF DICT operators used by testCFF.
+const (
+	cffTestCharset     = 15
+	cffTestCharStrings = 17
+	cffTestPrivate     = 18
+	cffTestROS         = 1230
+	cffTestFDArray     = 1236
+	cffTestFDSelect    = 1237
+)
+
+func cffTestDictEntry(op int, ii ...int) []byte {
+	var bb []byte
+	for _, i := range ii {
+		bb = append(bb, 29)
+		bb = binary.BigEndian.AppendUint32(bb, uint32(i))
+	}
+	if op >= 1200 {
+		return append(bb, 12, byte(op-1200))
+	}
+	return append(bb, byte(op))
+}
+
+// cffTestCharString returns a Type 2 charstring drawing a polygon with n pseudo random vertices.
+func cffTestCharString(seed, n int) []byte {
+	bb := []byte{139, 139, 21} // 0 0 rmoveto
+	for i := 0; i < n; i++ {
+		seed = (seed*1103515245 + 12345) & 0x7FFFFFFF
+		bb = append(bb, byte(32+seed%215), byte(32+(seed>>8)%215))
+	}
+	return append(bb, 5, 14) // rlineto endchar
+}
+
+// testCFF returns a CFF font program along with its charstrings.
+// The charset maps glyph id i > 0 to CID or SID ids[i-1].
+func testCFF(ids []int, cidKeyed bool) ([]byte, [][]byte) {
+	charStrings := [][]byte{{14}}
+	for i := range ids {
+		charStrings = append(charStrings, cffTestCharString(i+1, 40))
+	}
+
+	name := cffTestIndex([]byte("CFFSubsetTest"))
+	strings := cffTestIndex([]byte("Adobe"), []byte("Identity"))
+	gsubrs := cffTestIndex()
+
+	charset := []byte{0}
+	for _, id := range ids {
+		charset = binary.BigEndian.AppendUint16(charset, uint16(id))
+	}
+	charStringsIndex := cffTestIndex(charStrings...)
+	private := []byte{139, 20} // 0 defaultWidthX
+
+	var fdSelect []byte
+	if cidKeyed {
+		fdSelect = make([]byte, 1+len(charStrings))
+	}
+
+	top := func(charsetOff, charStringsOff, fdSelectOff, privateOff int) []byte {
+		var bb []byte
+		if cidKeyed {
+			bb = append(bb, cffTestDictEntry(cffTestROS, 391, 392, 0)...)
+		}
+		bb = append(bb, cffTestDictEntry(cffTestCharset, charsetOff)...)
+		bb = append(bb, cffTestDictEntry(cffTestCharStrings, charStringsOff)...)
+		if cidKeyed {
+			bb = append(bb, cffTestDictEntry(cffTestFDSelect, fdSelectOff)...)
+			bb = append(bb, cffTestDictEntry(cffTestFDArray, fdSelectOff+len(fdSelect))...)
+		} else {
+			bb = append(bb, cffTestDictEntry(cffTestPrivate, len(private), privateOff)...)
+		}
+		return cffTestIndex(bb)
+	}
+
+	fdArray := func(privateOff int) []byte {
+		if !cidKeyed {
+			return nil
+		}
+		return cffTestIndex(cffTestDictEntry(cffTestPrivate, len(private), privateOff))
+	}
+
+	charsetOff := 4 + len(name) + len(top(0, 0, 0, 0)) + len(strings) + len(gsubrs)
+	charStringsOff := charsetOff + len(charset)
+	fdSelectOff := charStringsOff + len(charStringsIndex)
+	privateOff := fdSelectOff + len(fdSelect) + len(fdArray(0))
+
+	bb := []byte{1, 0, 4, 4}
+	for _, b := range [][]byte{
+		name,
+		top(charsetOff, charStringsOff, fdSelectOff, privateOff),
+		strings,
+		gsubrs,
+		charset,
+		charStringsIndex,
+		fdSelect,
+		fdArray(privateOff),
+		private,
+	} {
+		bb = append(bb, b...)
+	}
+
+	return bb, charStrings
+}
+
+func TestSubsetCFFFont(t *testing.T) {
+	for _, tt := range []struct {
+		msg      string
+		cidKeyed bool
+		cids     []int // CIDs of glyph ids 1..6, for name keyed fonts CIDs are glyph ids.
+		shown    string
+		used     []int
+	}{
+		{"CID-keyed", true, []int{10, 20, 30, 40, 50, 60}, "00140032", []int{20, 50}},
+		{"name-keyed", false, []int{1, 2, 3, 4, 5, 6}, "00020005", []int{2, 5}},
+	} {
+		t.Run(tt.msg, func(t *testing.T) {
+			testSubsetCFFFont(t, tt.cidKeyed, tt.cids, tt.shown, tt.used)
+		})
+	}
+}
+
+func testSubsetCFFFont(t *testing.T, cidKeyed bool, cids []int, shown string, usedCIDs []int) {
+	t.Helper()
+
+	// The charset of a name keyed font maps to SIDs.
+	bb, charStrings := testCFF(cids, cidKeyed)
+
+	w := types.Array{}
+	for _, cid := range cids {
+		w = append(w, types.Integer(cid), types.Array{types.Integer(500)})
+	}
+	d := types.Dict{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("Type0"),
+		"BaseFont": types.Name("CFFSubsetTest"),
+		"Encoding": types.Name("Identity-H"),
+		"DescendantFonts": types.Array{types.Dict{
+			"Type":     types.Name("Font"),
+			"Subtype":  types.Name("CIDFontType0"),
+			"BaseFont": types.Name("CFFSubsetTest"),
+			"CIDSystemInfo": types.Dict{
+				"Registry":   types.StringLiteral("Adobe"),
+				"Ordering":   types.StringLiteral("Identity"),
+				"Supplement": types.Integer(0),
+			},
+			"W": w,
+		}},
+	}
+
+	ctx, d := createFontContext(t, "BT /F1 12 Tf 72 720 Td <"+shown+"> Tj ET", d, "FontFile3", bb,
+		types.Dict{"Subtype": types.Name("CIDFontType0C")})
+
+	gids, err := font.CFFGlyphIDs(bb, cids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := map[uint16]bool{1: true, 2: true, 3: true, 4: true, 5: true, 6: true}; !reflect.DeepEqual(gids, want) {
+		t.Fatalf("want glyph ids %v, got %v\n", want, gids)
+	}
+
+	stats, err := SubsetFonts(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Fonts != 1 || stats.BytesSaved <= 0 {
+		t.Fatalf("want 1 subset font, got %+v\n", stats)
+	}
+
+	if bf := d.NameEntry("BaseFont"); bf == nil || !isSubsetFontName(*bf) {
+		t.Fatalf("want subset font name, got %v\n", d["BaseFont"])
+	}
+
+	bb1 := fontProgramBytes(t, ctx, d)
+	if len(bb1) >= len(bb) {
+		t.Fatalf("want smaller font program, got %d >= %d\n", len(bb1), len(bb))
+	}
+
+	// Glyph ids are preserved.
+	gids1, err := font.CFFGlyphIDs(bb1, cids)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gids, gids1) {
+		t.Fatalf("want glyph ids %v, got %v\n", gids, gids1)
+	}
+
+	// The glyphs shown keep their outlines, all others are gone.
+	for i, cid := range cids {
+		used := slices.Contains(usedCIDs, cid)
+		if bytes.Contains(bb1, charStrings[i+1]) != used {
+			t.Fatalf("CID %d: want glyph present=%t\n", cid, used)
+		}
+	}
+
+	a, err := ctx.DereferenceArray(d["DescendantFonts"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	cidFont, err := ctx.DereferenceDict(a[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	w = types.Array{}
+	for _, cid := range usedCIDs {
+		w = append(w, types.Integer(cid), types.Array{types.Integer(500)})
+	}
+	if !reflect.DeepEqual(cidFont["W"], w) {
+		t.Fatalf("want widths %v, got %v\n", w, cidFont["W"])
+	}
+}
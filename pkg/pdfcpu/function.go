@@ -0,0 +1,373 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// pdfFunction is an evaluable PDF function (7.10).
+// PostScript calculator functions are not supported.
+type pdfFunction interface {
+	eval(x []float64) []float64
+}
+
+var errUnsupportedFunction = errors.New("pdfcpu: unsupported function")
+
+// sampledFunction is a type 0 function.
+type sampledFunction struct {
+	domain, rng, encode, decode []float64
+	size                        []int
+	samples                     []float64 // normalized to [0,1]
+	m, n                        int
+}
+
+// exponentialFunction is a type 2 function.
+type exponentialFunction struct {
+	domain, rng []float64
+	c0, c1      []float64
+	exp         float64
+}
+
+// stitchingFunction is a type 3 function.
+type stitchingFunction struct {
+	domain, rng, bounds, encode []float64
+	functions                   []pdfFunction
+}
+
+// functionArray combines n 1-output functions into one n-output function.
+type functionArray []pdfFunction
+
+func interpolate(x, xmin, xmax, ymin, ymax float64) float64 {
+	if xmax == xmin {
+		return ymin
+	}
+	return ymin + (x-xmin)*(ymax-ymin)/(xmax-xmin)
+}
+
+func clip(x, min, max float64) float64 {
+	return math.Max(min, math.Min(max, x))
+}
+
+func clipToRange(y, rng []float64) []float64 {
+	for i := 0; i < len(y) && 2*i+1 < len(rng); i++ {
+		y[i] = clip(y[i], rng[2*i], rng[2*i+1])
+	}
+	return y
+}
+
+func (f sampledFunction) eval(x []float64) []float64 {
+	i0 := make([]int, f.m)
+	fr := make([]float64, f.m)
+	stride := make([]int, f.m)
+
+	// The first input varies fastest.
+	s := f.n
+	for i := 0; i < f.m; i++ {
+		stride[i] = s
+		s *= f.size[i]
+	}
+
+	for i := 0; i < f.m; i++ {
+		xi := clip(x[i], f.domain[2*i], f.domain[2*i+1])
+		e := interpolate(xi, f.domain[2*i], f.domain[2*i+1], f.encode[2*i], f.encode[2*i+1])
+		e = clip(e, 0, float64(f.size[i]-1))
+		if f.size[i] == 1 {
+			continue
+		}
+		i0[i] = min(int(e), f.size[i]-2)
+		fr[i] = e - float64(i0[i])
+	}
+
+	y := make([]float64, f.n)
+
+	for corner := 0; corner < 1<<f.m; corner++ {
+		w, off := 1., 0
+		for i := 0; i < f.m && w > 0; i++ {
+			if corner&(1<<i) != 0 {
+				w *= fr[i]
+				off += (i0[i] + 1) * stride[i]
+			} else {
+				w *= 1 - fr[i]
+				off += i0[i] * stride[i]
+			}
+		}
+		if w == 0 {
+			continue
+		}
+		for j := range y {
+			y[j] += w * f.samples[off+j]
+		}
+	}
+
+	for j := range y {
+		y[j] = interpolate(y[j], 0, 1, f.decode[2*j], f.decode[2*j+1])
+	}
+
+	return clipToRange(y, f.rng)
+}
+
+func (f exponentialFunction) eval(x []float64) []float64 {
+	xi := clip(x[0], f.domain[0], f.domain[1])
+	y := make([]float64, len(f.c0))
+	for i := range y {
+		y[i] = f.c0[i] + math.Pow(xi, f.exp)*(f.c1[i]-f.c0[i])
+	}
+	return clipToRange(y, f.rng)
+}
+
+func (f stitchingFunction) eval(x []float64) []float64 {
+	xi := clip(x[0], f.domain[0], f.domain[1])
+
+	k := 0
+	for k < len(f.bounds) && xi >= f.bounds[k] {
+		k++
+	}
+
+	lo, hi := f.domain[0], f.domain[1]
+	if k > 0 {
+		lo = f.bounds[k-1]
+	}
+	if k < len(f.bounds) {
+		hi = f.bounds[k]
+	}
+
+	e := interpolate(xi, lo, hi, f.encode[2*k], f.encode[2*k+1])
+
+	return clipToRange(f.functions[k].eval([]float64{e}), f.rng)
+}
+
+func (fa functionArray) eval(x []float64) []float64 {
+	y := make([]float64, 0, len(fa))
+	for _, f := range fa {
+		y = append(y, f.eval(x)[0])
+	}
+	return y
+}
+
+func numberArrayEntry(ctx *model.Context, d types.Dict, key string) ([]float64, error) {
+	o, found := d.Find(key)
+	if !found {
+		return nil, nil
+	}
+	a, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return nil, err
+	}
+	ff := make([]float64, len(a))
+	for i, o := range a {
+		if ff[i], err = ctx.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+	return ff, nil
+}
+
+func parseSampledFunction(ctx *model.Context, sd *types.StreamDict, domain, rng []float64) (pdfFunction, error) {
+	m, n := len(domain)/2, len(rng)/2
+	if n == 0 {
+		return nil, errUnsupportedFunction
+	}
+
+	f := sampledFunction{domain: domain, rng: rng, m: m, n: n}
+
+	sizes, err := numberArrayEntry(ctx, sd.Dict, "Size")
+	if err != nil {
+		return nil, err
+	}
+	if len(sizes) != m {
+		return nil, errUnsupportedFunction
+	}
+
+	count := n
+	for _, s := range sizes {
+		if s < 1 {
+			return nil, errUnsupportedFunction
+		}
+		f.size = append(f.size, int(s))
+		count *= int(s)
+	}
+
+	bps := sd.IntEntry("BitsPerSample")
+	if bps == nil || !types.IntMemberOf(*bps, []int{1, 2, 4, 8, 12, 16, 24, 32}) {
+		return nil, errUnsupportedFunction
+	}
+
+	if f.encode, err = numberArrayEntry(ctx, sd.Dict, "Encode"); err != nil {
+		return nil, err
+	}
+	if f.encode == nil {
+		for _, s := range f.size {
+			f.encode = append(f.encode, 0, float64(s-1))
+		}
+	}
+
+	if f.decode, err = numberArrayEntry(ctx, sd.Dict, "Decode"); err != nil {
+		return nil, err
+	}
+	if f.decode == nil {
+		f.decode = rng
+	}
+
+	if len(f.encode) != 2*m || len(f.decode) != 2*n {
+		return nil, errUnsupportedFunction
+	}
+
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+
+	bb := sd.Content
+	if len(bb)*8 < count**bps {
+		return nil, errUnsupportedFunction
+	}
+
+	maxVal := math.Pow(2, float64(*bps)) - 1
+	f.samples = make([]float64, count)
+
+	for i := range f.samples {
+		var v uint64
+		for j := 0; j < *bps; j++ {
+			bit := i**bps + j
+			v = v<<1 | uint64(bb[bit/8]>>(7-bit%8)&1)
+		}
+		f.samples[i] = float64(v) / maxVal
+	}
+
+	return f, nil
+}
+
+func parseStitchingFunction(ctx *model.Context, d types.Dict, domain, rng []float64) (pdfFunction, error) {
+	a, err := ctx.DereferenceArray(d["Functions"])
+	if err != nil || len(a) == 0 {
+		return nil, errUnsupportedFunction
+	}
+
+	f := stitchingFunction{domain: domain, rng: rng}
+
+	for _, o := range a {
+		f1, err := parseFunction(ctx, o)
+		if err != nil {
+			return nil, err
+		}
+		f.functions = append(f.functions, f1)
+	}
+
+	if f.bounds, err = numberArrayEntry(ctx, d, "Bounds"); err != nil {
+		return nil, err
+	}
+
+	if f.encode, err = numberArrayEntry(ctx, d, "Encode"); err != nil {
+		return nil, err
+	}
+
+	if len(domain) != 2 || len(f.bounds) != len(a)-1 || len(f.encode) != 2*len(a) {
+		return nil, errUnsupportedFunction
+	}
+
+	return f, nil
+}
+
+// parseFunction parses a function dict, a function stream or an array of 1-output functions.
+func parseFunction(ctx *model.Context, o types.Object) (pdfFunction, error) {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return nil, err
+	}
+
+	var d types.Dict
+
+	switch o := o.(type) {
+
+	case types.Array:
+		fa := functionArray{}
+		for _, o1 := range o {
+			f, err := parseFunction(ctx, o1)
+			if err != nil {
+				return nil, err
+			}
+			fa = append(fa, f)
+		}
+		return fa, nil
+
+	case types.Dict:
+		d = o
+
+	case types.StreamDict:
+		d = o.Dict
+
+	default:
+		return nil, errUnsupportedFunction
+	}
+
+	domain, err := numberArrayEntry(ctx, d, "Domain")
+	if err != nil {
+		return nil, err
+	}
+	if len(domain) < 2 || len(domain)%2 != 0 {
+		return nil, errUnsupportedFunction
+	}
+
+	rng, err := numberArrayEntry(ctx, d, "Range")
+	if err != nil {
+		return nil, err
+	}
+
+	ft := d.IntEntry("FunctionType")
+	if ft == nil {
+		return nil, errUnsupportedFunction
+	}
+
+	switch *ft {
+
+	case 0:
+		sd, ok := o.(types.StreamDict)
+		if !ok {
+			return nil, errUnsupportedFunction
+		}
+		return parseSampledFunction(ctx, &sd, domain, rng)
+
+	case 2:
+		f := exponentialFunction{domain: domain, rng: rng, c0: []float64{0}, c1: []float64{1}}
+		if c, err := numberArrayEntry(ctx, d, "C0"); err != nil {
+			return nil, err
+		} else if c != nil {
+			f.c0 = c
+		}
+		if c, err := numberArrayEntry(ctx, d, "C1"); err != nil {
+			return nil, err
+		} else if c != nil {
+			f.c1 = c
+		}
+		if f.exp, err = ctx.DereferenceNumber(d["N"]); err != nil {
+			return nil, err
+		}
+		if len(f.c0) != len(f.c1) {
+			return nil, errUnsupportedFunction
+		}
+		return f, nil
+
+	case 3:
+		return parseStitchingFunction(ctx, d, domain, rng)
+	}
+
+	return nil, errUnsupportedFunction
+}
@@ -0,0 +1,339 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+func geoCoordinateSystem(ctx *model.Context, o types.Object) (*model.GeoCoordinateSystem, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		return nil, errors.New("pdfcpu: geo measure: missing GCS")
+	}
+
+	gcs := &model.GeoCoordinateSystem{Type: annotName(ctx, d, "Type")}
+
+	if o, found := d.Find("EPSG"); found {
+		i, err := ctx.DereferenceInteger(o)
+		if err != nil {
+			return nil, err
+		}
+		if i != nil {
+			gcs.EPSG = i.Value()
+		}
+	}
+
+	if o, found := d.Find("WKT"); found {
+		if gcs.WKT, err = ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return gcs, nil
+}
+
+// geoViewport returns the geo viewport for a viewport dict or nil if d is not georeferenced.
+func geoViewport(ctx *model.Context, d types.Dict) (*model.GeoViewport, error) {
+	measure, err := ctx.DereferenceDict(d["Measure"])
+	if err != nil || measure == nil {
+		return nil, err
+	}
+
+	if annotName(ctx, measure, "Subtype") != "GEO" {
+		return nil, nil
+	}
+
+	vp := &model.GeoViewport{}
+
+	a, err := ctx.DereferenceArray(d["BBox"])
+	if err != nil {
+		return nil, err
+	}
+	if len(a) != 4 {
+		return nil, errors.New("pdfcpu: viewport: invalid BBox")
+	}
+	if vp.BBox, err = ctx.RectForArray(a); err != nil {
+		return nil, err
+	}
+
+	if o, found := d.Find("Name"); found {
+		if vp.Name, err = ctx.DereferenceStringOrHexLiteral(o, model.V10, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	gcs, err := geoCoordinateSystem(ctx, measure["GCS"])
+	if err != nil {
+		return nil, err
+	}
+	vp.GCS = *gcs
+
+	if vp.Bounds, err = numberArrayEntry(ctx, measure, "Bounds"); err != nil {
+		return nil, err
+	}
+
+	if vp.GPTS, err = numberArrayEntry(ctx, measure, "GPTS"); err != nil {
+		return nil, err
+	}
+
+	if vp.LPTS, err = numberArrayEntry(ctx, measure, "LPTS"); err != nil {
+		return nil, err
+	}
+
+	if a, err = ctx.DereferenceArray(measure["PDU"]); err != nil {
+		return nil, err
+	}
+	for _, o := range a {
+		if n, ok := o.(types.Name); ok {
+			vp.PDU = append(vp.PDU, n.Value())
+		}
+	}
+
+	return vp, nil
+}
+
+func pageGeoViewports(ctx *model.Context, pageDict types.Dict) ([]model.GeoViewport, error) {
+	a, err := ctx.DereferenceArray(pageDict["VP"])
+	if err != nil {
+		return nil, err
+	}
+
+	vps := []model.GeoViewport{}
+
+	for _, o := range a {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		vp, err := geoViewport(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		if vp != nil {
+			vps = append(vps, *vp)
+		}
+	}
+
+	return vps, nil
+}
+
+// GeoViewports returns the georeferenced viewports of selected pages mapped by page number.
+func GeoViewports(ctx *model.Context, selectedPages types.IntSet) (map[int][]model.GeoViewport, error) {
+	m := map[int][]model.GeoViewport{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		vps, err := pageGeoViewports(ctx, pageDict)
+		if err != nil {
+			return nil, errors.Wrapf(err, "page %d", pageNr)
+		}
+		if len(vps) > 0 {
+			m[pageNr] = vps
+		}
+	}
+
+	return m, nil
+}
+
+// ListGeoViewports returns a formatted list of the georeferenced viewports of selected pages.
+func ListGeoViewports(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	m, err := GeoViewports(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m) == 0 {
+		return []string{"no geospatial viewports available"}, nil
+	}
+
+	ss := []string{}
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		vps, ok := m[pageNr]
+		if !ok {
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("page %d:", pageNr))
+		for _, vp := range vps {
+			ss = append(ss, "   "+vp.String())
+		}
+	}
+
+	return ss, nil
+}
+
+// AddGeoViewport adds a georeferenced viewport to selected pages.
+func AddGeoViewport(ctx *model.Context, selectedPages types.IntSet, vp model.GeoViewport) error {
+	if err := vp.Validate(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+
+		if mb := inhPAttrs.MediaBox; mb != nil && (!mb.Contains(vp.BBox.LL) || !mb.Contains(vp.BBox.UR)) {
+			return errors.Errorf("pdfcpu: page %d: geo viewport exceeds media box", pageNr)
+		}
+
+		a, err := ctx.DereferenceArray(pageDict["VP"])
+		if err != nil {
+			return err
+		}
+
+		// Viewports are processed in array order, the last one containing a point wins.
+		pageDict["VP"] = append(a, vp.Dict())
+	}
+
+	// Geospatial measure dicts need PDF 1.7 extension level 3.
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
+
+// RemoveGeoViewports removes all georeferenced viewports from selected pages.
+// RemoveGeoViewports returns the number of removed viewports.
+func RemoveGeoViewports(ctx *model.Context, selectedPages types.IntSet) (int, error) {
+	var count int
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return 0, err
+		}
+
+		a, err := ctx.DereferenceArray(pageDict["VP"])
+		if err != nil {
+			return 0, err
+		}
+		if a == nil {
+			continue
+		}
+
+		a1 := types.Array{}
+		for _, o := range a {
+			d, err := ctx.DereferenceDict(o)
+			if err != nil {
+				return 0, err
+			}
+			if d != nil {
+				vp, err := geoViewport(ctx, d)
+				if err != nil {
+					return 0, err
+				}
+				if vp != nil {
+					count++
+					continue
+				}
+			}
+			a1 = append(a1, o)
+		}
+
+		if len(a1) == 0 {
+			pageDict.Delete("VP")
+			continue
+		}
+		pageDict["VP"] = a1
+	}
+
+	return count, nil
+}
+
+// PageToGeo transforms the point (x,y) of page pageNr into latitude and longitude
+// using the last georeferenced viewport containing the point.
+func PageToGeo(ctx *model.Context, pageNr int, x, y float64) (float64, float64, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return 0, 0, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	vps, err := pageGeoViewports(ctx, pageDict)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	p := types.Point{X: x, Y: y}
+	for i := len(vps) - 1; i >= 0; i-- {
+		if vps[i].BBox.Contains(p) {
+			return vps[i].PageToGeo(x, y)
+		}
+	}
+
+	return 0, 0, errors.Errorf("pdfcpu: page %d: no geospatial viewport for (%.2f, %.2f)", pageNr, x, y)
+}
+
+// GeoToPage transforms latitude and longitude into a point of page pageNr
+// using the last georeferenced viewport containing the result.
+func GeoToPage(ctx *model.Context, pageNr int, lat, lon float64) (float64, float64, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return 0, 0, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	pageDict, _, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	vps, err := pageGeoViewports(ctx, pageDict)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	for i := len(vps) - 1; i >= 0; i-- {
+		x, y, err := vps[i].GeoToPage(lat, lon)
+		if err != nil {
+			return 0, 0, err
+		}
+		if vps[i].BBox.Contains(types.Point{X: x, Y: y}) {
+			return x, y, nil
+		}
+	}
+
+	return 0, 0, errors.Errorf("pdfcpu: page %d: no geospatial viewport for %.6f %.6f", pageNr, lat, lon)
+}
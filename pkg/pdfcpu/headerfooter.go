@@ -0,0 +1,339 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const headerFooterFontSize = 10
+
+type headerFooterParamMap map[string]func(string, *model.HeaderFooter) error
+
+var hfParamMap = headerFooterParamMap{
+	"header":     parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.Header }),
+	"footer":     parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.Footer }),
+	"oddheader":  parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.OddHeader }),
+	"oddfooter":  parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.OddFooter }),
+	"evenheader": parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.EvenHeader }),
+	"evenfooter": parseHeaderFooterSlots(func(hf *model.HeaderFooter) **model.HeaderFooterSlots { return &hf.EvenFooter }),
+	"margin":     parseHeaderFooterMargin,
+	"inset":      parseHeaderFooterInset,
+	"dateformat": parseHeaderFooterDateFormat,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into hf.
+func (m headerFooterParamMap) Handle(paramPrefix, paramValueStr string, hf *model.HeaderFooter) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, hf)
+}
+
+// parseHeaderFooterSlots returns a parser for "left|center|right" into the slots selected by f.
+func parseHeaderFooterSlots(f func(*model.HeaderFooter) **model.HeaderFooterSlots) func(string, *model.HeaderFooter) error {
+	return func(s string, hf *model.HeaderFooter) error {
+		ss := strings.Split(s, "|")
+		if len(ss) > 3 {
+			return errors.Errorf("pdfcpu: header/footer: too many slots, please provide: left|center|right: %s", s)
+		}
+		for len(ss) < 3 {
+			ss = append(ss, "")
+		}
+		*f(hf) = &model.HeaderFooterSlots{
+			Left:   strings.TrimSpace(ss[0]),
+			Center: strings.TrimSpace(ss[1]),
+			Right:  strings.TrimSpace(ss[2]),
+		}
+		return nil
+	}
+}
+
+func parseHeaderFooterDistance(s string, u types.DisplayUnit) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0, errors.Errorf("pdfcpu: header/footer: distance must be a float value >= 0: %s", s)
+	}
+	return types.ToUserSpace(f, u), nil
+}
+
+func parseHeaderFooterMargin(s string, hf *model.HeaderFooter) (err error) {
+	hf.Margin, err = parseHeaderFooterDistance(s, hf.InpUnit)
+	return err
+}
+
+func parseHeaderFooterInset(s string, hf *model.HeaderFooter) (err error) {
+	hf.Inset, err = parseHeaderFooterDistance(s, hf.InpUnit)
+	return err
+}
+
+func parseHeaderFooterDateFormat(s string, hf *model.HeaderFooter) error {
+	if s == "" {
+		return errors.New("pdfcpu: header/footer: missing date format")
+	}
+	hf.DateFormat = s
+	return nil
+}
+
+// splitHeaderFooterConfig splits s at commas not escaped by a backslash.
+func splitHeaderFooterConfig(s string) []string {
+	ss := []string{}
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\\' && i+1 < len(s) && s[i+1] == ',' {
+			sb.WriteByte(',')
+			i++
+			continue
+		}
+		if s[i] == ',' {
+			ss = append(ss, sb.String())
+			sb.Reset()
+			continue
+		}
+		sb.WriteByte(s[i])
+	}
+	return append(ss, sb.String())
+}
+
+// ParseHeaderFooterConfig parses a header/footer command string into an internal structure.
+// desc is a stamp description configuring font, size, color etc. of all slots.
+func ParseHeaderFooterConfig(s, desc string, conf *model.Configuration) (*model.HeaderFooter, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	hf := model.DefaultHeaderFooterConfig()
+	hf.InpUnit = conf.Unit
+	hf.Desc = desc
+
+	for _, s := range splitHeaderFooterConfig(s) {
+		if strings.TrimSpace(s) == "" {
+			continue
+		}
+		paramPrefix, paramValueStr, ok := strings.Cut(s, ":")
+		if !ok {
+			return nil, errors.New("pdfcpu: Invalid header/footer configuration string. Please consult pdfcpu help headerfooter")
+		}
+		if err := hfParamMap.Handle(strings.TrimSpace(paramPrefix), strings.TrimSpace(paramValueStr), hf); err != nil {
+			return nil, err
+		}
+	}
+
+	if !hasHeaderFooterSlots(hf) {
+		return nil, errors.New("pdfcpu: header/footer: please provide at least one of header, footer, oddheader, oddfooter, evenheader, evenfooter")
+	}
+
+	// Validate the stamp description.
+	if _, err := headerFooterWatermark("x", types.TopLeft, hf); err != nil {
+		return nil, err
+	}
+
+	return hf, nil
+}
+
+func hasHeaderFooterSlots(hf *model.HeaderFooter) bool {
+	for _, slots := range []*model.HeaderFooterSlots{hf.Header, hf.Footer, hf.OddHeader, hf.OddFooter, hf.EvenHeader, hf.EvenFooter} {
+		if slots != nil && !slots.Empty() {
+			return true
+		}
+	}
+	return false
+}
+
+// headerFooterWatermark returns a text stamp rendering s at anchor a moved inwards by hf.Inset and hf.Margin.
+// Slots default to 10 point black Helvetica. Any position or offset of hf.Desc is overridden.
+func headerFooterWatermark(s string, a types.Anchor, hf *model.HeaderFooter) (*model.Watermark, error) {
+	wm := model.DefaultWatermarkConfig()
+	wm.OnTop = true
+	wm.InpUnit = hf.InpUnit
+	wm.FontSize = headerFooterFontSize
+	wm.Scale = 1
+	wm.ScaleAbs = true
+	wm.Diagonal = model.NoDiagonal
+	wm.Color = color.Black
+	wm.StrokeColor = color.Black
+	wm.FillColor = color.Black
+
+	if err := applyWatermarkDetails(model.WMText, s, hf.Desc, wm); err != nil {
+		return nil, err
+	}
+
+	wm.Pos = a
+	wm.Dx, wm.Dy = 0, 0
+
+	switch a {
+	case types.TopLeft, types.BottomLeft:
+		wm.Dx = hf.Inset
+	case types.TopRight, types.BottomRight:
+		wm.Dx = -hf.Inset
+	}
+
+	switch a {
+	case types.TopLeft, types.TopCenter, types.TopRight:
+		wm.Dy = -hf.Margin
+	default:
+		wm.Dy = hf.Margin
+	}
+
+	return wm, nil
+}
+
+// bookmarkTitles returns the title of the innermost bookmark covering each page.
+func bookmarkTitles(ctx *model.Context) (map[int]string, error) {
+	bms, err := Bookmarks(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	type entry struct {
+		pageFrom int
+		title    string
+	}
+
+	// Flatten in document order, kids follow their parent.
+	ee := []entry{}
+	var flatten func([]Bookmark)
+	flatten = func(bms []Bookmark) {
+		for _, bm := range bms {
+			ee = append(ee, entry{bm.PageFrom, bm.Title})
+			flatten(bm.Kids)
+		}
+	}
+	flatten(bms)
+
+	m := map[int]string{}
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		from := 0
+		for _, e := range ee {
+			if e.pageFrom > 0 && e.pageFrom <= pageNr && e.pageFrom >= from {
+				from = e.pageFrom
+				m[pageNr] = e.title
+			}
+		}
+	}
+
+	return m, nil
+}
+
+func usesBookmarkVar(hf *model.HeaderFooter) bool {
+	for _, slots := range []*model.HeaderFooterSlots{hf.Header, hf.Footer, hf.OddHeader, hf.OddFooter, hf.EvenHeader, hf.EvenFooter} {
+		if slots != nil && strings.Contains(slots.String(), "%bookmark") {
+			return true
+		}
+	}
+	return false
+}
+
+func headerFooterSlotWatermarks(slots *model.HeaderFooterSlots, header bool, r *strings.Replacer, hf *model.HeaderFooter) ([]*model.Watermark, error) {
+	if slots == nil {
+		return nil, nil
+	}
+
+	aa := []types.Anchor{types.BottomLeft, types.BottomCenter, types.BottomRight}
+	if header {
+		aa = []types.Anchor{types.TopLeft, types.TopCenter, types.TopRight}
+	}
+
+	wms := []*model.Watermark{}
+	for i, s := range []string{slots.Left, slots.Center, slots.Right} {
+		s = r.Replace(s)
+		if strings.TrimSpace(s) == "" {
+			// eg. %bookmark for a page not covered by any bookmark.
+			continue
+		}
+		wm, err := headerFooterWatermark(s, aa[i], hf)
+		if err != nil {
+			return nil, err
+		}
+		wms = append(wms, wm)
+	}
+
+	return wms, nil
+}
+
+// AddHeadersAndFooters adds the headers and footers hf to the selected pages of ctx.
+func AddHeadersAndFooters(ctx *model.Context, selectedPages types.IntSet, hf *model.HeaderFooter) error {
+	pageNrs := sortSelectedPages(selectedPages)
+	if len(pageNrs) == 0 {
+		return errors.New("pdfcpu: header/footer: no pages selected")
+	}
+
+	var titles map[int]string
+	if usesBookmarkVar(hf) {
+		var err error
+		if titles, err = bookmarkTitles(ctx); err != nil {
+			return err
+		}
+	}
+
+	date := time.Now().Format(hf.DateFormat)
+
+	m := map[int][]*model.Watermark{}
+
+	for _, pageNr := range pageNrs {
+		// %pages has to be replaced before %page.
+		r := strings.NewReplacer(
+			"%pages", strconv.Itoa(ctx.PageCount),
+			"%page", strconv.Itoa(pageNr),
+			"%filename", hf.FileName,
+			"%date", date,
+			"%bookmark", titles[pageNr],
+		)
+
+		wms, err := headerFooterSlotWatermarks(hf.HeaderForPage(pageNr), true, r, hf)
+		if err != nil {
+			return err
+		}
+
+		wms1, err := headerFooterSlotWatermarks(hf.FooterForPage(pageNr), false, r, hf)
+		if err != nil {
+			return err
+		}
+
+		if wms = append(wms, wms1...); len(wms) > 0 {
+			m[pageNr] = wms
+		}
+	}
+
+	if len(m) == 0 {
+		return nil
+	}
+
+	return AddWatermarksSliceMap(ctx, m)
+}
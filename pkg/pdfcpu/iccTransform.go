@@ -0,0 +1,824 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+)
+
+// Color transforms between ICC profiles.
+//
+// Supported are matrix/TRC based RGB profiles and LUT based profiles using
+// lut8Type, lut16Type, lutAtoBType or lutBtoAType for the chosen rendering intent.
+// Colors are transformed via the profile connection space (PCS) using D50 XYZ as hub.
+
+// D50 white point of the PCS.
+const (
+	iccD50X = 0.9642
+	iccD50Y = 1.0
+	iccD50Z = 0.8249
+)
+
+var errCorruptICCProfile = errors.New("pdfcpu: corrupt ICC profile")
+
+// The PCS encodings used by lookup tables.
+type iccPCSEncoding int
+
+const (
+	iccPCSEncodingV4     iccPCSEncoding = iota // lut8Type, lutAtoBType, lutBtoAType
+	iccPCSEncodingLegacy                       // lut16Type
+)
+
+type iccCurve func(float64) float64
+
+type iccStage func([]float64) []float64
+
+type iccPipeline []iccStage
+
+func (pl iccPipeline) eval(x []float64) []float64 {
+	for _, st := range pl {
+		x = st(x)
+	}
+	return x
+}
+
+func clamp01(x float64) float64 {
+	return math.Max(0, math.Min(1, x))
+}
+
+func s15Fixed16(b []byte, i int) float64 {
+	return float64(int32(binary.BigEndian.Uint32(b[i:]))) / 65536
+}
+
+func newICCProfile(bb []byte) (*iccProfile, error) {
+	if len(bb) < 132 || string(bb[36:40]) != "acsp" {
+		return nil, errCorruptICCProfile
+	}
+	p := &iccProfile{b: bb}
+	if p.tagCount() < 0 || 132+12*p.tagCount() > len(bb) {
+		return nil, errCorruptICCProfile
+	}
+	return p, nil
+}
+
+// tagData returns the data of tag sig including type signature and reserved bytes.
+func (p iccProfile) tagData(sig string) ([]byte, bool) {
+	off, size, err := p.tag(sig)
+	if err != nil || off < 0 || size < 12 || off+size > len(p.b) {
+		return nil, false
+	}
+	return p.b[off : off+size], true
+}
+
+// lutTag returns the lookup table prefix (A2B or B2A) for intent falling back to the perceptual table.
+func (p iccProfile) lutTag(prefix string, intent model.RenderingIntent) ([]byte, bool) {
+	if b, ok := p.tagData(prefix + string(rune('0'+intent))); ok {
+		return b, true
+	}
+	return p.tagData(prefix + "0")
+}
+
+func (p iccProfile) pcsIsLab() bool {
+	return p.pcs() == "Lab "
+}
+
+// description returns the profile description.
+func (p iccProfile) description() string {
+	b, ok := p.tagData("desc")
+	if !ok {
+		return ""
+	}
+
+	switch string(b[:4]) {
+
+	case "desc":
+		n := int(binary.BigEndian.Uint32(b[8:]))
+		if n <= 0 || 12+n > len(b) {
+			return ""
+		}
+		return strings.TrimRight(string(b[12:12+n]), "\x00")
+
+	case "mluc":
+		if len(b) < 28 || binary.BigEndian.Uint32(b[8:]) == 0 {
+			return ""
+		}
+		n := int(binary.BigEndian.Uint32(b[20:]))
+		off := int(binary.BigEndian.Uint32(b[24:]))
+		if off+n > len(b) {
+			return ""
+		}
+		u := make([]uint16, n/2)
+		for i := range u {
+			u[i] = binary.BigEndian.Uint16(b[off+2*i:])
+		}
+		return strings.TrimRight(string(utf16.Decode(u)), "\x00")
+	}
+
+	return ""
+}
+
+func interpolateTable(t []float64, x float64) float64 {
+	p := clamp01(x) * float64(len(t)-1)
+	i := int(p)
+	if i >= len(t)-1 {
+		return t[len(t)-1]
+	}
+	f := p - float64(i)
+	return t[i] + f*(t[i+1]-t[i])
+}
+
+func parametricCurve(funcType int, p [7]float64) iccCurve {
+	g, a, b, c, d, e, f := p[0], p[1], p[2], p[3], p[4], p[5], p[6]
+
+	pow := func(x float64) float64 {
+		if x <= 0 {
+			return 0
+		}
+		return math.Pow(x, g)
+	}
+
+	switch funcType {
+	case 1:
+		return func(x float64) float64 {
+			if a*x+b >= 0 {
+				return pow(a*x + b)
+			}
+			return 0
+		}
+	case 2:
+		return func(x float64) float64 {
+			if a*x+b >= 0 {
+				return pow(a*x+b) + c
+			}
+			return c
+		}
+	case 3:
+		return func(x float64) float64 {
+			if x >= d {
+				return pow(a*x + b)
+			}
+			return c * x
+		}
+	case 4:
+		return func(x float64) float64 {
+			if x >= d {
+				return pow(a*x+b) + e
+			}
+			return c*x + f
+		}
+	}
+
+	return pow
+}
+
+// parseICCCurve parses the curveType or parametricCurveType at the start of b and returns the curve and its size.
+func parseICCCurve(b []byte) (iccCurve, int, error) {
+	if len(b) < 12 {
+		return nil, 0, errCorruptICCProfile
+	}
+
+	switch string(b[:4]) {
+
+	case "curv":
+		n := int(binary.BigEndian.Uint32(b[8:]))
+		if n < 0 || len(b) < 12+2*n {
+			return nil, 0, errCorruptICCProfile
+		}
+		switch n {
+		case 0:
+			return func(x float64) float64 { return x }, 12, nil
+		case 1:
+			g := float64(binary.BigEndian.Uint16(b[12:])) / 256
+			return func(x float64) float64 { return math.Pow(clamp01(x), g) }, 14, nil
+		}
+		t := make([]float64, n)
+		for i := range t {
+			t[i] = float64(binary.BigEndian.Uint16(b[12+2*i:])) / 65535
+		}
+		return func(x float64) float64 { return interpolateTable(t, x) }, 12 + 2*n, nil
+
+	case "para":
+		funcType := int(binary.BigEndian.Uint16(b[8:]))
+		paramCounts := []int{1, 3, 4, 5, 7}
+		if funcType >= len(paramCounts) || len(b) < 12+4*paramCounts[funcType] {
+			return nil, 0, errCorruptICCProfile
+		}
+		var p [7]float64
+		for i := 0; i < paramCounts[funcType]; i++ {
+			p[i] = s15Fixed16(b, 12+4*i)
+		}
+		return parametricCurve(funcType, p), 12 + 4*paramCounts[funcType], nil
+	}
+
+	return nil, 0, errors.Errorf("pdfcpu: unsupported ICC curve type: %s", b[:4])
+}
+
+// parseICCCurves parses n consecutive curves starting at offset off of b.
+func parseICCCurves(b []byte, off, n int) ([]iccCurve, error) {
+	cc := make([]iccCurve, n)
+	for i := range cc {
+		if off >= len(b) {
+			return nil, errCorruptICCProfile
+		}
+		c, size, err := parseICCCurve(b[off:])
+		if err != nil {
+			return nil, err
+		}
+		cc[i] = c
+		off += (size + 3) &^ 3
+	}
+	return cc, nil
+}
+
+func curvesStage(cc []iccCurve) iccStage {
+	return func(x []float64) []float64 {
+		y := make([]float64, len(x))
+		for i, v := range x {
+			y[i] = cc[i](clamp01(v))
+		}
+		return y
+	}
+}
+
+// matrixStage applies a 3x3 matrix in row major order followed by 3 offsets.
+func matrixStage(m [12]float64) iccStage {
+	return func(x []float64) []float64 {
+		y := make([]float64, 3)
+		for i := range y {
+			y[i] = m[3*i]*x[0] + m[3*i+1]*x[1] + m[3*i+2]*x[2] + m[9+i]
+		}
+		return y
+	}
+}
+
+func isIdentityMatrix(m [12]float64) bool {
+	return m == [12]float64{1, 0, 0, 0, 1, 0, 0, 0, 1}
+}
+
+// iccCLUT is a multidimensional color lookup table with samples normalized to [0,1].
+// The first input channel varies least rapidly.
+type iccCLUT struct {
+	in, out int
+	grid    []int
+	data    []float64
+}
+
+func newICCCLUT(in, out int, grid []int) (*iccCLUT, int, error) {
+	n := out
+	for _, g := range grid {
+		if g < 1 {
+			return nil, 0, errCorruptICCProfile
+		}
+		n *= g
+	}
+	return &iccCLUT{in: in, out: out, grid: grid}, n, nil
+}
+
+// eval interpolates multilinearly.
+func (c *iccCLUT) eval(x []float64) []float64 {
+	i0 := make([]int, c.in)
+	f := make([]float64, c.in)
+	stride := make([]int, c.in)
+
+	s := c.out
+	for i := c.in - 1; i >= 0; i-- {
+		stride[i] = s
+		s *= c.grid[i]
+	}
+
+	for i := 0; i < c.in; i++ {
+		if c.grid[i] == 1 {
+			continue
+		}
+		p := clamp01(x[i]) * float64(c.grid[i]-1)
+		i0[i] = min(int(p), c.grid[i]-2)
+		f[i] = p - float64(i0[i])
+	}
+
+	y := make([]float64, c.out)
+
+	for corner := 0; corner < 1<<c.in; corner++ {
+		w, off := 1., 0
+		for i := 0; i < c.in && w > 0; i++ {
+			if corner&(1<<i) != 0 {
+				w *= f[i]
+				off += (i0[i] + 1) * stride[i]
+			} else {
+				w *= 1 - f[i]
+				off += i0[i] * stride[i]
+			}
+		}
+		if w == 0 {
+			continue
+		}
+		for j := range y {
+			y[j] += w * c.data[off+j]
+		}
+	}
+
+	return y
+}
+
+func clutStage(c *iccCLUT) iccStage {
+	return c.eval
+}
+
+// parseLUT16 parses a lut8Type or a lut16Type.
+// The matrix only applies to XYZ input which is the case for PCS to device tables of XYZ based profiles.
+func parseLUT16(b []byte, pcsXYZInput bool) (iccPipeline, error) {
+	if len(b) < 52 {
+		return nil, errCorruptICCProfile
+	}
+
+	lut8 := string(b[:4]) == "mft1"
+	in, out, g := int(b[8]), int(b[9]), int(b[10])
+	if in < 1 || in > 8 || out < 1 || out > 8 || g < 2 {
+		return nil, errCorruptICCProfile
+	}
+
+	var m [12]float64
+	for i := 0; i < 9; i++ {
+		m[i] = s15Fixed16(b, 12+4*i)
+	}
+
+	inEntries, outEntries, off, size := 256, 256, 48, 1
+	if !lut8 {
+		inEntries = int(binary.BigEndian.Uint16(b[48:]))
+		outEntries = int(binary.BigEndian.Uint16(b[50:]))
+		off, size = 52, 2
+	}
+	if inEntries < 2 || outEntries < 2 {
+		return nil, errCorruptICCProfile
+	}
+
+	grid := make([]int, in)
+	for i := range grid {
+		grid[i] = g
+	}
+	clut, n, err := newICCCLUT(in, out, grid)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) < off+size*(in*inEntries+n+out*outEntries) {
+		return nil, errCorruptICCProfile
+	}
+
+	next := func() float64 {
+		var v float64
+		if lut8 {
+			v = float64(b[off]) / 255
+		} else {
+			v = float64(binary.BigEndian.Uint16(b[off:])) / 65535
+		}
+		off += size
+		return v
+	}
+
+	tables := func(count, entries int) []iccCurve {
+		cc := make([]iccCurve, count)
+		for i := range cc {
+			t := make([]float64, entries)
+			for j := range t {
+				t[j] = next()
+			}
+			cc[i] = func(x float64) float64 { return interpolateTable(t, x) }
+		}
+		return cc
+	}
+
+	var pl iccPipeline
+
+	if pcsXYZInput && in == 3 && !isIdentityMatrix(m) {
+		pl = append(pl, matrixStage(m))
+	}
+
+	pl = append(pl, curvesStage(tables(in, inEntries)))
+
+	clut.data = make([]float64, n)
+	for i := range clut.data {
+		clut.data[i] = next()
+	}
+	pl = append(pl, clutStage(clut))
+
+	pl = append(pl, curvesStage(tables(out, outEntries)))
+
+	return pl, nil
+}
+
+func parseLUTABCLUT(b []byte, off, in, out int) (*iccCLUT, error) {
+	if off+20 > len(b) || in > 16 {
+		return nil, errCorruptICCProfile
+	}
+
+	grid := make([]int, in)
+	for i := range grid {
+		grid[i] = int(b[off+i])
+	}
+
+	clut, n, err := newICCCLUT(in, out, grid)
+	if err != nil {
+		return nil, err
+	}
+
+	precision := int(b[off+16])
+	if precision != 1 && precision != 2 {
+		return nil, errCorruptICCProfile
+	}
+
+	off += 20
+	if off+n*precision > len(b) {
+		return nil, errCorruptICCProfile
+	}
+
+	clut.data = make([]float64, n)
+	for i := range clut.data {
+		if precision == 1 {
+			clut.data[i] = float64(b[off+i]) / 255
+		} else {
+			clut.data[i] = float64(binary.BigEndian.Uint16(b[off+2*i:])) / 65535
+		}
+	}
+
+	return clut, nil
+}
+
+// parseLUTAB parses a lutAtoBType or a lutBtoAType.
+func parseLUTAB(b []byte) (iccPipeline, error) {
+	if len(b) < 32 {
+		return nil, errCorruptICCProfile
+	}
+
+	aToB := string(b[:4]) == "mAB "
+	in, out := int(b[8]), int(b[9])
+	if in < 1 || out < 1 {
+		return nil, errCorruptICCProfile
+	}
+
+	offB := int(binary.BigEndian.Uint32(b[12:]))
+	offMatrix := int(binary.BigEndian.Uint32(b[16:]))
+	offM := int(binary.BigEndian.Uint32(b[20:]))
+	offCLUT := int(binary.BigEndian.Uint32(b[24:]))
+	offA := int(binary.BigEndian.Uint32(b[28:]))
+
+	// A curves are on the device side, B curves on the PCS side.
+	nA, nB := in, out
+	if !aToB {
+		nA, nB = out, in
+	}
+
+	curves := func(off, n int) (iccStage, error) {
+		if off == 0 {
+			return nil, nil
+		}
+		cc, err := parseICCCurves(b, off, n)
+		if err != nil {
+			return nil, err
+		}
+		return curvesStage(cc), nil
+	}
+
+	stA, err := curves(offA, nA)
+	if err != nil {
+		return nil, err
+	}
+
+	var stCLUT iccStage
+	if offCLUT > 0 {
+		clut, err := parseLUTABCLUT(b, offCLUT, in, out)
+		if err != nil {
+			return nil, err
+		}
+		stCLUT = clutStage(clut)
+	}
+
+	// M curves and matrix are on the PCS side of the CLUT.
+	nPCS := nB
+	stM, err := curves(offM, nPCS)
+	if err != nil {
+		return nil, err
+	}
+
+	var stMatrix iccStage
+	if offMatrix > 0 && nPCS == 3 {
+		if offMatrix+48 > len(b) {
+			return nil, errCorruptICCProfile
+		}
+		var m [12]float64
+		for i := range m {
+			m[i] = s15Fixed16(b, offMatrix+4*i)
+		}
+		stMatrix = matrixStage(m)
+	}
+
+	stB, err := curves(offB, nB)
+	if err != nil {
+		return nil, err
+	}
+	if stB == nil {
+		return nil, errCorruptICCProfile
+	}
+
+	stages := []iccStage{stA, stCLUT, stM, stMatrix, stB}
+	if !aToB {
+		stages = []iccStage{stB, stMatrix, stM, stCLUT, stA}
+	}
+
+	var pl iccPipeline
+	for _, st := range stages {
+		if st != nil {
+			pl = append(pl, st)
+		}
+	}
+
+	return pl, nil
+}
+
+// parseICCLUT parses a lookup table tag and returns its pipeline along with its PCS encoding.
+func parseICCLUT(b []byte, pcsXYZInput bool) (iccPipeline, iccPCSEncoding, error) {
+	switch string(b[:4]) {
+	case "mft1":
+		pl, err := parseLUT16(b, pcsXYZInput)
+		return pl, iccPCSEncodingV4, err
+	case "mft2":
+		pl, err := parseLUT16(b, pcsXYZInput)
+		return pl, iccPCSEncodingLegacy, err
+	case "mAB ", "mBA ":
+		pl, err := parseLUTAB(b)
+		return pl, iccPCSEncodingV4, err
+	}
+	return nil, 0, errors.Errorf("pdfcpu: unsupported ICC lookup table type: %s", b[:4])
+}
+
+func labF(t float64) float64 {
+	if t > 216./24389 {
+		return math.Cbrt(t)
+	}
+	return (24389./27*t + 16) / 116
+}
+
+func labFInv(t float64) float64 {
+	if t3 := t * t * t; t3 > 216./24389 {
+		return t3
+	}
+	return (116*t - 16) * 27 / 24389
+}
+
+func xyzToLab(xyz [3]float64) [3]float64 {
+	fx, fy, fz := labF(xyz[0]/iccD50X), labF(xyz[1]/iccD50Y), labF(xyz[2]/iccD50Z)
+	return [3]float64{116*fy - 16, 500 * (fx - fy), 200 * (fy - fz)}
+}
+
+func labToXYZ(lab [3]float64) [3]float64 {
+	fy := (lab[0] + 16) / 116
+	fx, fz := fy+lab[1]/500, fy-lab[2]/200
+	return [3]float64{labFInv(fx) * iccD50X, labFInv(fy) * iccD50Y, labFInv(fz) * iccD50Z}
+}
+
+// pcsDecode returns PCS XYZ for lookup table output v.
+func pcsDecode(v []float64, lab bool, enc iccPCSEncoding) [3]float64 {
+	if !lab {
+		f := 65535. / 32768
+		return [3]float64{v[0] * f, v[1] * f, v[2] * f}
+	}
+	var l [3]float64
+	if enc == iccPCSEncodingLegacy {
+		l = [3]float64{v[0] * 65535 / 652.8, v[1]*65535/256 - 128, v[2]*65535/256 - 128}
+	} else {
+		l = [3]float64{v[0] * 100, v[1]*255 - 128, v[2]*255 - 128}
+	}
+	return labToXYZ(l)
+}
+
+// pcsEncode returns lookup table input for PCS XYZ xyz.
+func pcsEncode(xyz [3]float64, lab bool, enc iccPCSEncoding) []float64 {
+	if !lab {
+		f := 32768. / 65535
+		return []float64{xyz[0] * f, xyz[1] * f, xyz[2] * f}
+	}
+	l := xyzToLab(xyz)
+	if enc == iccPCSEncodingLegacy {
+		return []float64{l[0] * 652.8 / 65535, (l[1] + 128) * 256 / 65535, (l[2] + 128) * 256 / 65535}
+	}
+	return []float64{l[0] / 100, (l[1] + 128) / 255, (l[2] + 128) / 255}
+}
+
+func (p iccProfile) xyzTag(sig string) ([3]float64, error) {
+	b, ok := p.tagData(sig)
+	if !ok || len(b) < 20 || string(b[:4]) != "XYZ " {
+		return [3]float64{}, errors.Errorf("pdfcpu: missing ICC tag: %s", sig)
+	}
+	return [3]float64{s15Fixed16(b, 8), s15Fixed16(b, 12), s15Fixed16(b, 16)}, nil
+}
+
+// matrixTRC returns the transform of a matrix/TRC based RGB profile.
+func (p iccProfile) matrixTRC() (func([]float64) [3]float64, error) {
+	var (
+		m  [12]float64
+		cc []iccCurve
+	)
+
+	for i, c := range []string{"r", "g", "b"} {
+		col, err := p.xyzTag(c + "XYZ")
+		if err != nil {
+			return nil, err
+		}
+		m[i], m[3+i], m[6+i] = col[0], col[1], col[2]
+
+		b, ok := p.tagData(c + "TRC")
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: missing ICC tag: %sTRC", c)
+		}
+		curve, _, err := parseICCCurve(b)
+		if err != nil {
+			return nil, err
+		}
+		cc = append(cc, curve)
+	}
+
+	trc, mat := curvesStage(cc), matrixStage(m)
+
+	return func(x []float64) [3]float64 {
+		v := mat(trc(x))
+		return [3]float64{v[0], v[1], v[2]}
+	}, nil
+}
+
+// deviceToPCS returns the transform from device colors into PCS XYZ.
+func (p iccProfile) deviceToPCS(intent model.RenderingIntent) (func([]float64) [3]float64, error) {
+	b, ok := p.lutTag("A2B", intent)
+	if !ok {
+		if p.dataColorSpace() != "RGB " {
+			return nil, errors.New("pdfcpu: ICC profile lacks a device to PCS transform")
+		}
+		return p.matrixTRC()
+	}
+
+	pl, enc, err := parseICCLUT(b, false)
+	if err != nil {
+		return nil, err
+	}
+
+	lab := p.pcsIsLab()
+
+	return func(x []float64) [3]float64 {
+		return pcsDecode(pl.eval(x), lab, enc)
+	}, nil
+}
+
+// pcsToDevice returns the transform from PCS XYZ into device colors.
+func (p iccProfile) pcsToDevice(intent model.RenderingIntent) (func([3]float64) []float64, error) {
+	b, ok := p.lutTag("B2A", intent)
+	if !ok {
+		return nil, errors.New("pdfcpu: ICC profile lacks a PCS to device transform")
+	}
+
+	lab := p.pcsIsLab()
+
+	pl, enc, err := parseICCLUT(b, !lab)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(xyz [3]float64) []float64 {
+		y := pl.eval(pcsEncode(xyz, lab, enc))
+		for i, v := range y {
+			y[i] = clamp01(v)
+		}
+		return y
+	}, nil
+}
+
+// sRGB transform chromatically adapted to D50.
+func srgbToPCS(x []float64) [3]float64 {
+	lin := func(v float64) float64 {
+		if v = clamp01(v); v <= 0.04045 {
+			return v / 12.92
+		}
+		return math.Pow((v+0.055)/1.055, 2.4)
+	}
+	r, g, b := lin(x[0]), lin(x[1]), lin(x[2])
+	return [3]float64{
+		0.4360747*r + 0.3850649*g + 0.1430804*b,
+		0.2225045*r + 0.7168786*g + 0.0606169*b,
+		0.0139322*r + 0.0971045*g + 0.7141733*b,
+	}
+}
+
+// iccTransform converts device colors of a source profile into device colors of a destination profile.
+type iccTransform struct {
+	toPCS   func([]float64) [3]float64
+	fromPCS func([3]float64) []float64
+	n       int       // Number of source color components.
+	grid    []float64 // Cached destination colors for interpolating 3 component source colors.
+}
+
+const iccTransformGridSize = 33
+
+// newICCTransform returns a transform from src to dest for intent, where a missing src means sRGB.
+func newICCTransform(src, dest []byte, intent model.RenderingIntent) (*iccTransform, error) {
+	t := &iccTransform{toPCS: srgbToPCS, n: 3}
+
+	if src != nil {
+		p, err := newICCProfile(src)
+		if err != nil {
+			return nil, err
+		}
+		if t.toPCS, err = p.deviceToPCS(intent); err != nil {
+			return nil, err
+		}
+		switch p.dataColorSpace() {
+		case "GRAY":
+			t.n = 1
+		case "RGB ":
+			t.n = 3
+		case "CMYK":
+			t.n = 4
+		default:
+			return nil, errors.Errorf("pdfcpu: unsupported ICC source color space: %s", p.dataColorSpace())
+		}
+	}
+
+	p, err := newICCProfile(dest)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.fromPCS, err = p.pcsToDevice(intent); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// convert transforms a single color.
+func (t *iccTransform) convert(x []float64) []float64 {
+	return t.fromPCS(t.toPCS(x))
+}
+
+func (t *iccTransform) ensureGrid() {
+	if t.grid != nil {
+		return
+	}
+	g := iccTransformGridSize
+	x := make([]float64, 3)
+	for r := 0; r < g; r++ {
+		for gr := 0; gr < g; gr++ {
+			for b := 0; b < g; b++ {
+				x[0], x[1], x[2] = float64(r)/float64(g-1), float64(gr)/float64(g-1), float64(b)/float64(g-1)
+				t.grid = append(t.grid, t.convert(x)...)
+			}
+		}
+	}
+}
+
+// convertPixels transforms 8 bit samples of 3 component colors using a cached grid of destination colors.
+func (t *iccTransform) convertPixels(pix []byte, n int) []byte {
+	t.ensureGrid()
+
+	m := len(t.grid) / (iccTransformGridSize * iccTransformGridSize * iccTransformGridSize)
+	clut := iccCLUT{in: 3, out: m, grid: []int{iccTransformGridSize, iccTransformGridSize, iccTransformGridSize}, data: t.grid}
+
+	out := make([]byte, 0, len(pix)/n*m)
+	cache := map[[3]byte][]byte{}
+	x := make([]float64, 3)
+
+	for i := 0; i+n <= len(pix); i += n {
+		k := [3]byte{pix[i], pix[i+1], pix[i+2]}
+		if c, ok := cache[k]; ok {
+			out = append(out, c...)
+			continue
+		}
+		x[0], x[1], x[2] = float64(k[0])/255, float64(k[1])/255, float64(k[2])/255
+		c := make([]byte, m)
+		for j, v := range clut.eval(x) {
+			c[j] = byte(math.Round(clamp01(v) * 255))
+		}
+		if len(cache) < 1<<16 {
+			cache[k] = c
+		}
+		out = append(out, c...)
+	}
+
+	return out
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+)
+
+func TestICCTransformRGBToCMYK(t *testing.T) {
+	bb, err := os.ReadFile(filepath.Join("..", "testdata", "icc", "TestCMYK.icc"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p, err := newICCProfile(bb)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := p.description(); got != "pdfcpu test CMYK" {
+		t.Fatalf("description: got %q\n", got)
+	}
+
+	tf, err := newICCTransform(nil, bb, model.RelativeColorimetric)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		rgb, cmyk []float64
+	}{
+		{[]float64{1, 1, 1}, []float64{0, 0, 0, 0}},
+		{[]float64{0, 0, 0}, []float64{0, 0, 0, 1}},
+		{[]float64{1, 0, 0}, []float64{0, 1, 1, 0}},
+		{[]float64{0, 1, 0}, []float64{1, 0, 1, 0}},
+		{[]float64{0, 0, 1}, []float64{1, 1, 0, 0}},
+		{[]float64{.5, .5, .5}, []float64{0, 0, 0, .5}},
+	} {
+		got := tf.convert(tt.rgb)
+		for i := range got {
+			if math.Abs(got[i]-tt.cmyk[i]) > 0.1 {
+				t.Fatalf("convert %v: got %.3f, want %v\n", tt.rgb, got, tt.cmyk)
+			}
+		}
+
+		pix := tf.convertPixels([]byte{byte(tt.rgb[0] * 255), byte(tt.rgb[1] * 255), byte(tt.rgb[2] * 255)}, 3)
+		for i := range pix {
+			if math.Abs(float64(pix[i])/255-tt.cmyk[i]) > 0.1 {
+				t.Fatalf("convertPixels %v: got %v, want %v\n", tt.rgb, pix, tt.cmyk)
+			}
+		}
+	}
+}
+
+func TestLabRoundTrip(t *testing.T) {
+	for _, xyz := range [][3]float64{{iccD50X, iccD50Y, iccD50Z}, {0.2, 0.3, 0.1}, {0.001, 0.002, 0.001}} {
+		got := labToXYZ(xyzToLab(xyz))
+		for i := range got {
+			if math.Abs(got[i]-xyz[i]) > 1e-9 {
+				t.Fatalf("Lab round trip of %v: got %v\n", xyz, got)
+			}
+		}
+	}
+}
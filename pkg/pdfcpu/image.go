@@ -419,3 +419,59 @@ func UpdateImagesByPageNrAndId(ctx *model.Context, rd io.Reader, pageNr int, id
 
 	return errors.Errorf("pdfcpu: page %d: unknown resource %s\n", pageNr, id)
 }
+
+// ImageObjNr returns the objNr of the image at 1-based index within the images of pageNr ordered by objNr.
+// Requires an optimized context.
+func ImageObjNr(ctx *model.Context, pageNr, index int) (int, error) {
+	if pageNr < 1 || pageNr > ctx.PageCount {
+		return 0, errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
+	}
+
+	objNrs := ImageObjNrs(ctx, pageNr)
+	sort.Ints(objNrs)
+
+	if index < 1 || index > len(objNrs) {
+		return 0, errors.Errorf("pdfcpu: page %d: invalid image index: %d (%d images)", pageNr, index, len(objNrs))
+	}
+
+	return objNrs[index-1], nil
+}
+
+// ReplaceImage replaces the stream and dict of the image XObject objNr by the image read from rd.
+// The new image may differ in dimensions, colorspace and filters and is rendered using the original placements.
+func ReplaceImage(ctx *model.Context, rd io.Reader, objNr int) error {
+	entry, ok := ctx.FindTableEntry(objNr, 0)
+	if !ok || entry.Free {
+		return errors.Errorf("pdfcpu: invalid objNr=%d", objNr)
+	}
+
+	sd0, ok := entry.Object.(types.StreamDict)
+	if !ok {
+		return errors.Errorf("pdfcpu: obj#%d is not an image", objNr)
+	}
+	if st := sd0.Subtype(); st == nil || *st != "Image" {
+		return errors.Errorf("pdfcpu: obj#%d is not an image", objNr)
+	}
+
+	sd, _, _, err := model.CreateImageStreamDict(ctx.XRefTable, rd)
+	if err != nil {
+		return err
+	}
+
+	// Retain entries relating to the document.
+	for _, k := range []string{"Name", "OC", "StructParent"} {
+		if o, found := sd0.Find(k); found {
+			sd.Insert(k, o)
+		}
+	}
+
+	entry.Object = *sd
+
+	if ctx.Optimize != nil {
+		if imgObj, ok := ctx.Optimize.ImageObjects[objNr]; ok {
+			imgObj.ImageDict = sd
+		}
+	}
+
+	return nil
+}
@@ -353,7 +353,14 @@ func NewPagesForImage(xRefTable *model.XRefTable, r io.Reader, parentIndRef *typ
 			return nil, err
 		}
 
-		dim := &types.Dim{Width: float64(imgRes.Width), Height: float64(imgRes.Height)}
+		w, h := float64(imgRes.Width), float64(imgRes.Height)
+		if imp.DPI == 0 && imgRes.DPIX > 0 && imgRes.DPIY > 0 {
+			// Honour the image resolution.
+			w *= 72 / imgRes.DPIX
+			h *= 72 / imgRes.DPIY
+		}
+
+		dim := &types.Dim{Width: w, Height: h}
 		if imp.Pos != types.Full {
 			dim = imp.PageDim
 		}
@@ -361,7 +368,7 @@ func NewPagesForImage(xRefTable *model.XRefTable, r io.Reader, parentIndRef *typ
 		mediaBox := types.RectForDim(dim.Width, dim.Height)
 
 		var buf bytes.Buffer
-		importImagePDFBytes(&buf, dim, float64(imgRes.Width), float64(imgRes.Height), imp)
+		importImagePDFBytes(&buf, dim, w, h, imp)
 		sd, err := xRefTable.NewStreamDictForBuf(buf.Bytes())
 		if err != nil {
 			return nil, err
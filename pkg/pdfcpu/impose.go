@@ -0,0 +1,396 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var errInvalidPageFormula = errors.New("pdfcpu: invalid page number formula")
+
+// pageFormula is a parsed page number formula of an imposition cell.
+type pageFormula func(vars map[string]int) (int, error)
+
+// formulaParser is a recursive descent parser for integer expressions:
+//
+//	expr   = term {("+"|"-") term}
+//	term   = factor {("*"|"/"|"%") factor}
+//	factor = ["-"] (number | variable | "(" expr ")")
+type formulaParser struct {
+	s   string
+	pos int
+}
+
+func (p *formulaParser) skipWhitespace() {
+	for p.pos < len(p.s) && p.s[p.pos] == ' ' {
+		p.pos++
+	}
+}
+
+func (p *formulaParser) peek() byte {
+	p.skipWhitespace()
+	if p.pos >= len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *formulaParser) expr() (pageFormula, error) {
+	f, err := p.term()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '+' && op != '-' {
+			return f, nil
+		}
+		p.pos++
+		g, err := p.term()
+		if err != nil {
+			return nil, err
+		}
+		f = binaryFormula(op, f, g)
+	}
+}
+
+func (p *formulaParser) term() (pageFormula, error) {
+	f, err := p.factor()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		op := p.peek()
+		if op != '*' && op != '/' && op != '%' {
+			return f, nil
+		}
+		p.pos++
+		g, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		f = binaryFormula(op, f, g)
+	}
+}
+
+func (p *formulaParser) factor() (pageFormula, error) {
+	c := p.peek()
+
+	switch {
+
+	case c == '-':
+		p.pos++
+		f, err := p.factor()
+		if err != nil {
+			return nil, err
+		}
+		return func(vars map[string]int) (int, error) {
+			i, err := f(vars)
+			return -i, err
+		}, nil
+
+	case c == '(':
+		p.pos++
+		f, err := p.expr()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() != ')' {
+			return nil, errInvalidPageFormula
+		}
+		p.pos++
+		return f, nil
+
+	case c >= '0' && c <= '9':
+		start := p.pos
+		for p.pos < len(p.s) && unicode.IsDigit(rune(p.s[p.pos])) {
+			p.pos++
+		}
+		i, err := strconv.Atoi(p.s[start:p.pos])
+		if err != nil {
+			return nil, errInvalidPageFormula
+		}
+		return func(map[string]int) (int, error) { return i, nil }, nil
+
+	case c == 'i' || c == 'n' || c == 'p':
+		p.pos++
+		v := string(c)
+		return func(vars map[string]int) (int, error) { return vars[v], nil }, nil
+	}
+
+	return nil, errInvalidPageFormula
+}
+
+func binaryFormula(op byte, f, g pageFormula) pageFormula {
+	return func(vars map[string]int) (int, error) {
+		a, err := f(vars)
+		if err != nil {
+			return 0, err
+		}
+		b, err := g(vars)
+		if err != nil {
+			return 0, err
+		}
+		switch op {
+		case '+':
+			return a + b, nil
+		case '-':
+			return a - b, nil
+		case '*':
+			return a * b, nil
+		}
+		if b == 0 {
+			return 0, errors.New("pdfcpu: page number formula: division by zero")
+		}
+		if op == '/' {
+			return a / b, nil
+		}
+		return a % b, nil
+	}
+}
+
+func parsePageFormula(s string) (pageFormula, error) {
+	p := &formulaParser{s: strings.ToLower(strings.TrimSpace(s))}
+	if p.s == "" {
+		return nil, errInvalidPageFormula
+	}
+	f, err := p.expr()
+	if err != nil {
+		return nil, errors.Wrapf(err, "%q", s)
+	}
+	if p.peek() != 0 {
+		return nil, errors.Wrapf(errInvalidPageFormula, "%q", s)
+	}
+	return f, nil
+}
+
+func validateImpositionCell(t *model.ImpositionTemplate, c model.ImpositionCell) error {
+	if c.Row < 0 || c.Row >= t.Rows || c.Col < 0 || c.Col >= t.Cols {
+		return errors.Errorf("pdfcpu: imposition cell (row:%d, col:%d) outside of %dx%d grid", c.Row, c.Col, t.Rows, t.Cols)
+	}
+	if !types.IntMemberOf(c.Rotate, []int{0, 90, 180, 270, -90, -180, -270}) {
+		return errors.Errorf("pdfcpu: imposition cell (row:%d, col:%d) rotation must be a multiple of 90", c.Row, c.Col)
+	}
+	_, err := parsePageFormula(c.Page)
+	return err
+}
+
+func validateImpositionTemplate(t *model.ImpositionTemplate) error {
+	if t.Rows <= 0 || t.Cols <= 0 {
+		return errors.New("pdfcpu: imposition template: rows and cols must be > 0")
+	}
+	if len(t.ColWidths) > 0 && len(t.ColWidths) != t.Cols {
+		return errors.New("pdfcpu: imposition template: colWidths must have one entry per column")
+	}
+	if len(t.RowHeights) > 0 && len(t.RowHeights) != t.Rows {
+		return errors.New("pdfcpu: imposition template: rowHeights must have one entry per row")
+	}
+	for _, f := range append(append([]float64{}, t.ColWidths...), t.RowHeights...) {
+		if f <= 0 {
+			return errors.New("pdfcpu: imposition template: colWidths and rowHeights must be > 0")
+		}
+	}
+	if len(t.Sides) == 0 || t.CellCount() == 0 {
+		return errors.New("pdfcpu: imposition template: missing sides or cells")
+	}
+	if t.Margin < 0 {
+		return errors.New("pdfcpu: imposition template: margin must be >= 0")
+	}
+	for _, side := range t.Sides {
+		for _, c := range side.Cells {
+			if err := validateImpositionCell(t, c); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func resolveImpositionTemplateDim(t *model.ImpositionTemplate) (err error) {
+	if len(t.Dimensions) > 0 {
+		if len(t.Dimensions) != 2 || t.Dimensions[0] <= 0 || t.Dimensions[1] <= 0 {
+			return errors.New("pdfcpu: imposition template: dimensions must be: width > 0, height > 0")
+		}
+		w := types.ToUserSpace(t.Dimensions[0], t.InpUnit)
+		h := types.ToUserSpace(t.Dimensions[1], t.InpUnit)
+		t.PageDim = &types.Dim{Width: w, Height: h}
+		return nil
+	}
+
+	paperSize := t.PaperSize
+	if paperSize == "" {
+		paperSize = "A4"
+	}
+
+	t.PageDim, _, err = types.ParsePageFormat(paperSize)
+	return err
+}
+
+// ParseImpositionTemplate parses a JSON imposition template.
+func ParseImpositionTemplate(bb []byte, conf *model.Configuration) (*model.ImpositionTemplate, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	t := &model.ImpositionTemplate{}
+	if err := json.Unmarshal(bb, t); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid imposition template")
+	}
+
+	t.InpUnit = conf.Unit
+	if t.Unit != "" {
+		c := *conf
+		c.SetUnit(t.Unit)
+		if c.UnitString() != t.Unit {
+			return nil, errors.Errorf("pdfcpu: imposition template: unknown unit %q, please provide one of: points, inches, cm, mm", t.Unit)
+		}
+		t.InpUnit = c.Unit
+	}
+
+	if err := resolveImpositionTemplateDim(t); err != nil {
+		return nil, err
+	}
+
+	t.Margin = types.ToUserSpace(t.Margin, t.InpUnit)
+
+	if err := validateImpositionTemplate(t); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func impositionNUp(t *model.ImpositionTemplate) (*model.NUp, error) {
+	nup := model.DefaultNUpConfig()
+	nup.PageDim = t.PageDim
+	nup.Grid = &types.Dim{Width: float64(t.Cols), Height: float64(t.Rows)}
+	nup.Margin = t.Margin
+	nup.Border = t.Border
+	nup.Enforce = false
+
+	if t.Marks != "" {
+		pm, err := model.ParsePrintersMarks(t.Marks)
+		if err != nil {
+			return nil, err
+		}
+		if pm != nil {
+			pm.FileName = t.FileName
+		}
+		nup.Marks = pm
+	}
+
+	return nup, nil
+}
+
+func imposeSide(ctx *model.Context, t *model.ImpositionTemplate, nup *model.NUp, side model.ImpositionSide, vars map[string]int, pageNumbers []int, pagesDict types.Dict, pagesIndRef *types.IndirectRef) error {
+	var buf bytes.Buffer
+	formsResDict := types.NewDict()
+	cells := []*types.Rectangle{}
+
+	for _, c := range side.Cells {
+
+		rDest := t.CellRect(c)
+		cells = append(cells, rDest)
+
+		f, err := parsePageFormula(c.Page)
+		if err != nil {
+			return err
+		}
+
+		p, err := f(vars)
+		if err != nil {
+			return err
+		}
+
+		pageNr := 0
+		if p >= 1 && p <= len(pageNumbers) {
+			pageNr = pageNumbers[p-1]
+		}
+
+		if pageNr == 0 {
+			// This is a blank cell.
+			continue
+		}
+
+		if err := ctx.NUpTilePDFBytesForPDFRotated(pageNr, formsResDict, &buf, rDest, nup, c.Rotate); err != nil {
+			return err
+		}
+	}
+
+	fm := model.FontMap{}
+	model.DrawPrintersMarksForCells(&buf, nup.Marks, types.RectForDim(t.PageDim.Width, t.PageDim.Height), cells, nup.Margin, fm)
+
+	return addSheetPage(ctx, t.PageDim, formsResDict, fm, buf, pagesDict, pagesIndRef)
+}
+
+// ImposeFromPDF arranges selectedPages of ctx according to the user defined imposition layout t.
+func ImposeFromPDF(ctx *model.Context, selectedPages types.IntSet, t *model.ImpositionTemplate) error {
+	nup, err := impositionNUp(t)
+	if err != nil {
+		return err
+	}
+
+	pageNumbers := sortSelectedPages(selectedPages)
+	if len(pageNumbers) == 0 {
+		return errors.New("pdfcpu: impose: no pages selected")
+	}
+
+	p := t.PageCountPerSheet()
+	sheets := (len(pageNumbers) + p - 1) / p
+
+	mb := types.RectForDim(t.PageDim.Width, t.PageDim.Height)
+
+	pagesDict := types.Dict(
+		map[string]types.Object{
+			"Type":     types.Name("Pages"),
+			"Count":    types.Integer(0),
+			"MediaBox": mb.Array(),
+		},
+	)
+
+	pagesIndRef, err := ctx.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i < sheets; i++ {
+		vars := map[string]int{"i": i, "n": sheets * p, "p": p}
+		for _, side := range t.Sides {
+			if err := imposeSide(ctx, t, nup, side, vars, pageNumbers, pagesDict, pagesIndRef); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Replace original pagesDict.
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	rootDict.Update("Pages", *pagesIndRef)
+
+	return nil
+}
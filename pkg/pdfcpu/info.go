@@ -347,6 +347,7 @@ type PDFInfo struct {
 	Unit               types.DisplayUnit               `json:"-"`
 	UnitString         string                          `json:"unit"`
 	Fonts              []model.FontInfo                `json:"fonts,omitempty"`
+	PageResources      []model.PageResourceReport      `json:"pageResources,omitempty"`
 }
 
 func (info PDFInfo) renderKeywords(ss *[]string) error {
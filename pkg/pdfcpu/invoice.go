@@ -0,0 +1,457 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const facturXNamespace = "urn:factur-x:pdfa:CrossIndustryDocument:invoice:1p0#"
+
+var pdfaPartRE = regexp.MustCompile(`pdfaid:part(?:>|=["'])\s*(\d)`)
+
+type invoiceParamMap map[string]func(string, *model.Invoice) error
+
+var invoiceParamsMap = invoiceParamMap{
+	"profile":      parseInvoiceProfile,
+	"name":         parseInvoiceFileName,
+	"relationship": parseInvoiceRelationship,
+	"description":  parseInvoiceDesc,
+	"version":      parseInvoiceVersion,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into inv.
+func (m invoiceParamMap) Handle(paramPrefix, paramValueStr string, inv *model.Invoice) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, inv)
+}
+
+func parseInvoiceProfile(s string, inv *model.Invoice) error {
+	s = strings.ToLower(strings.ReplaceAll(s, " ", ""))
+	if _, ok := model.InvoiceProfiles[s]; !ok {
+		return errors.Errorf("pdfcpu: invalid invoice profile: %s, please use one of: minimum, basicwl, basic, en16931, extended, xrechnung", s)
+	}
+	inv.Profile = s
+	return nil
+}
+
+func parseInvoiceFileName(s string, inv *model.Invoice) error {
+	if s == "" || strings.ContainsAny(s, "/\\") {
+		return errors.Errorf("pdfcpu: invalid invoice file name: %s", s)
+	}
+	inv.FileName = s
+	return nil
+}
+
+func parseInvoiceRelationship(s string, inv *model.Invoice) error {
+	for _, rel := range model.AFRelationships {
+		if strings.EqualFold(s, rel) {
+			inv.Relationship = rel
+			return nil
+		}
+	}
+	return errors.Errorf("pdfcpu: invalid invoice relationship: %s, please use one of: %s", s, strings.Join(model.AFRelationships, ", "))
+}
+
+func parseInvoiceDesc(s string, inv *model.Invoice) error {
+	inv.Desc = s
+	return nil
+}
+
+func parseInvoiceVersion(s string, inv *model.Invoice) error {
+	if s == "" {
+		return errors.New("pdfcpu: missing invoice version")
+	}
+	inv.Version = s
+	return nil
+}
+
+// ParseInvoiceConfig parses an invoice command string into an internal structure.
+func ParseInvoiceConfig(s string, conf *model.Configuration) (*model.Invoice, error) {
+	inv := model.DefaultInvoiceConfig()
+
+	if s == "" {
+		return inv, nil
+	}
+
+	ss := strings.Split(s, ",")
+
+	for _, s := range ss {
+
+		ss1 := strings.Split(s, ":")
+		if len(ss1) != 2 {
+			return nil, errors.New("pdfcpu: Invalid invoice configuration string. Please consult pdfcpu help invoice")
+		}
+
+		paramPrefix := strings.TrimSpace(ss1[0])
+		paramValueStr := strings.TrimSpace(ss1[1])
+
+		if err := invoiceParamsMap.Handle(paramPrefix, paramValueStr, inv); err != nil {
+			return nil, err
+		}
+	}
+
+	return inv, nil
+}
+
+// invoiceProfile returns the profile for a guideline specified document context parameter ID.
+func invoiceProfile(id string) string {
+	id = strings.ToLower(id)
+	switch {
+	case strings.Contains(id, "xrechnung"):
+		return "xrechnung"
+	case strings.HasSuffix(id, ":minimum"):
+		return "minimum"
+	case strings.HasSuffix(id, ":basicwl"):
+		return "basicwl"
+	case strings.HasSuffix(id, ":basic"):
+		return "basic"
+	case strings.HasSuffix(id, ":extended"):
+		return "extended"
+	case strings.HasPrefix(id, "urn:cen.eu:en16931:2017"):
+		return "en16931"
+	}
+	return ""
+}
+
+// DetectInvoiceProfile returns the Factur-X profile of a Cross Industry Invoice.
+func DetectInvoiceProfile(bb []byte) (string, error) {
+	dec := xml.NewDecoder(bytes.NewReader(bb))
+
+	var (
+		root, inGuideline, inID bool
+		id                      string
+	)
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return "", errors.Wrap(err, "pdfcpu: invalid invoice XML")
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			if !root {
+				if t.Name.Local != "CrossIndustryInvoice" {
+					return "", errors.Errorf("pdfcpu: invoice XML: unexpected root element: %s", t.Name.Local)
+				}
+				root = true
+			}
+			if t.Name.Local == "GuidelineSpecifiedDocumentContextParameter" {
+				inGuideline = true
+			}
+			if inGuideline && t.Name.Local == "ID" {
+				inID = true
+			}
+		case xml.EndElement:
+			if t.Name.Local == "GuidelineSpecifiedDocumentContextParameter" {
+				inGuideline = false
+			}
+			inID = false
+		case xml.CharData:
+			if inID && id == "" {
+				id = strings.TrimSpace(string(t))
+			}
+		}
+	}
+
+	if !root {
+		return "", errors.New("pdfcpu: invoice XML: missing root element")
+	}
+
+	profile := invoiceProfile(id)
+	if profile == "" {
+		return "", errors.Errorf("pdfcpu: invoice XML: unknown guideline: %q", id)
+	}
+
+	return profile, nil
+}
+
+func xmlEscaped(s string) string {
+	var buf bytes.Buffer
+	xml.EscapeText(&buf, []byte(s))
+	return buf.String()
+}
+
+func facturXExtensionProperty(name, desc string) string {
+	return `        <rdf:li rdf:parseType="Resource">
+         <pdfaProperty:name>` + name + `</pdfaProperty:name>
+         <pdfaProperty:valueType>Text</pdfaProperty:valueType>
+         <pdfaProperty:category>external</pdfaProperty:category>
+         <pdfaProperty:description>` + desc + `</pdfaProperty:description>
+        </rdf:li>
+`
+}
+
+// invoiceXMP returns the rdf:Description elements declaring a Factur-X hybrid invoice.
+func invoiceXMP(inv *model.Invoice, pdfaID bool) string {
+	var sb strings.Builder
+
+	if pdfaID {
+		sb.WriteString(`  <rdf:Description rdf:about="" xmlns:pdfaid="http://www.aiim.org/pdfa/ns/id/">
+   <pdfaid:part>3</pdfaid:part>
+   <pdfaid:conformance>B</pdfaid:conformance>
+  </rdf:Description>
+`)
+	}
+
+	sb.WriteString(`  <rdf:Description rdf:about="" xmlns:pdfaExtension="http://www.aiim.org/pdfa/ns/extension/" xmlns:pdfaSchema="http://www.aiim.org/pdfa/ns/schema#" xmlns:pdfaProperty="http://www.aiim.org/pdfa/ns/property#">
+   <pdfaExtension:schemas>
+    <rdf:Bag>
+     <rdf:li rdf:parseType="Resource">
+      <pdfaSchema:schema>Factur-X PDFA Extension Schema</pdfaSchema:schema>
+      <pdfaSchema:namespaceURI>` + facturXNamespace + `</pdfaSchema:namespaceURI>
+      <pdfaSchema:prefix>fx</pdfaSchema:prefix>
+      <pdfaSchema:property>
+       <rdf:Seq>
+`)
+	sb.WriteString(facturXExtensionProperty("DocumentFileName", "The name of the embedded XML document"))
+	sb.WriteString(facturXExtensionProperty("DocumentType", "The type of the hybrid document in capital letters, e.g. INVOICE or ORDER"))
+	sb.WriteString(facturXExtensionProperty("Version", "The actual version of the standard applying to the embedded XML document"))
+	sb.WriteString(facturXExtensionProperty("ConformanceLevel", "The conformance level of the embedded XML document"))
+	sb.WriteString(`       </rdf:Seq>
+      </pdfaSchema:property>
+     </rdf:li>
+    </rdf:Bag>
+   </pdfaExtension:schemas>
+  </rdf:Description>
+`)
+
+	fmt.Fprintf(&sb, `  <rdf:Description rdf:about="" xmlns:fx="%s">
+   <fx:DocumentType>INVOICE</fx:DocumentType>
+   <fx:DocumentFileName>%s</fx:DocumentFileName>
+   <fx:Version>%s</fx:Version>
+   <fx:ConformanceLevel>%s</fx:ConformanceLevel>
+  </rdf:Description>
+`, facturXNamespace, xmlEscaped(inv.FileName), xmlEscaped(inv.Version), model.InvoiceProfiles[inv.Profile])
+
+	return sb.String()
+}
+
+// documentXMP returns the rdf:Description element reflecting the document information of ctx.
+func documentXMP(ctx *model.Context) string {
+	now := time.Now().Format(time.RFC3339)
+
+	var sb strings.Builder
+
+	sb.WriteString(`  <rdf:Description rdf:about="" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmlns:pdf="http://ns.adobe.com/pdf/1.3/">
+`)
+	if ctx.Title != "" {
+		fmt.Fprintf(&sb, "   <dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:title>\n", xmlEscaped(ctx.Title))
+	}
+	if ctx.Author != "" {
+		fmt.Fprintf(&sb, "   <dc:creator><rdf:Seq><rdf:li>%s</rdf:li></rdf:Seq></dc:creator>\n", xmlEscaped(ctx.Author))
+	}
+	if ctx.Subject != "" {
+		fmt.Fprintf(&sb, "   <dc:description><rdf:Alt><rdf:li xml:lang=\"x-default\">%s</rdf:li></rdf:Alt></dc:description>\n", xmlEscaped(ctx.Subject))
+	}
+	if ctx.Creator != "" {
+		fmt.Fprintf(&sb, "   <xmp:CreatorTool>%s</xmp:CreatorTool>\n", xmlEscaped(ctx.Creator))
+	}
+	// Producer and dates are set when writing, see ensureInfoDict.
+	fmt.Fprintf(&sb, "   <xmp:CreateDate>%s</xmp:CreateDate>\n", now)
+	fmt.Fprintf(&sb, "   <xmp:ModifyDate>%s</xmp:ModifyDate>\n", now)
+	fmt.Fprintf(&sb, "   <pdf:Producer>%s</pdf:Producer>\n", xmlEscaped("pdfcpu "+model.VersionStr))
+	sb.WriteString("  </rdf:Description>\n")
+
+	return sb.String()
+}
+
+func newInvoiceMetadata(ctx *model.Context, inv *model.Invoice) []byte {
+	var sb strings.Builder
+	sb.WriteString(xmpPacketHeader + "\n")
+	sb.WriteString("<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n <rdf:RDF xmlns:rdf=\"http://www.w3.org/1999/02/22-rdf-syntax-ns#\">\n")
+	sb.WriteString(documentXMP(ctx))
+	sb.WriteString(invoiceXMP(inv, true))
+	sb.WriteString(" </rdf:RDF>\n</x:xmpmeta>\n" + xmpPacketTrailer)
+	return []byte(sb.String())
+}
+
+// mergeInvoiceMetadata adds the invoice declarations to an existing XMP packet.
+func mergeInvoiceMetadata(bb []byte, inv *model.Invoice) ([]byte, error) {
+	s := string(bb)
+
+	if strings.Contains(s, facturXNamespace) {
+		return nil, errors.New("pdfcpu: document metadata already declares a Factur-X invoice")
+	}
+
+	pdfaID := true
+	if m := pdfaPartRE.FindStringSubmatch(s); m != nil {
+		if m[1] != "3" {
+			return nil, errors.Errorf("pdfcpu: PDF/A-%s documents may not embed invoices, PDF/A-3 is required", m[1])
+		}
+		pdfaID = false
+	}
+
+	i := strings.LastIndex(s, "</rdf:RDF>")
+	if i < 0 {
+		return nil, errors.New("pdfcpu: corrupt document metadata: missing rdf:RDF")
+	}
+
+	return []byte(s[:i] + invoiceXMP(inv, pdfaID) + s[i:]), nil
+}
+
+func updateInvoiceMetadata(ctx *model.Context, inv *model.Invoice) error {
+	bb, err := xmpMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	if bb == nil {
+		return setXMPMetadata(ctx, newInvoiceMetadata(ctx, inv))
+	}
+
+	if bb, err = mergeInvoiceMetadata(bb, inv); err != nil {
+		return err
+	}
+
+	return setXMPMetadata(ctx, bb)
+}
+
+func addAssociatedFile(ctx *model.Context, rootDict types.Dict, ir types.IndirectRef) error {
+	o, found := rootDict.Find("AF")
+	if !found {
+		rootDict.Insert("AF", types.Array{ir})
+		return nil
+	}
+
+	a, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+	a = append(a, ir)
+
+	if indRef, ok := o.(types.IndirectRef); ok {
+		entry, _ := ctx.FindTableEntryForIndRef(&indRef)
+		entry.Object = a
+		return nil
+	}
+
+	rootDict.Update("AF", a)
+	return nil
+}
+
+// AddInvoice embeds the XML invoice bb as associated file of ctx and declares ctx a Factur-X / ZUGFeRD hybrid invoice.
+// The document is declared PDF/A-3b unless its metadata states otherwise,
+// any other PDF/A-3 requirements need to be met by the input document.
+func AddInvoice(ctx *model.Context, bb []byte, modTime time.Time, inv *model.Invoice) error {
+	if inv == nil {
+		inv = model.DefaultInvoiceConfig()
+	}
+
+	profile, err := DetectInvoiceProfile(bb)
+	if err != nil {
+		return err
+	}
+	if inv.Profile == "" {
+		inv.Profile = profile
+	}
+
+	if inv.FileName == "" {
+		inv.FileName = inv.DefaultFileName()
+	}
+	if inv.Relationship == "" {
+		inv.Relationship = inv.DefaultRelationship()
+	}
+	if inv.Version == "" {
+		inv.Version = "1.0"
+	}
+
+	if log.CLIEnabled() {
+		log.CLI.Printf("embedding %s (profile: %s)\n", inv.FileName, model.InvoiceProfiles[inv.Profile])
+	}
+
+	xRefTable := ctx.XRefTable
+
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", true); err != nil {
+		return err
+	}
+
+	if _, found := xRefTable.Names["EmbeddedFiles"].Value(inv.FileName); found {
+		return errors.Errorf("pdfcpu: attachment %s already exists", inv.FileName)
+	}
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if err := updateInvoiceMetadata(ctx, inv); err != nil {
+		return err
+	}
+
+	sdIndRef, err := xRefTable.NewEmbeddedStreamDict(bytes.NewReader(bb), modTime)
+	if err != nil {
+		return err
+	}
+
+	entry, _ := xRefTable.FindTableEntryForIndRef(sdIndRef)
+	sd := entry.Object.(types.StreamDict)
+	sd.InsertName("Subtype", "text/xml")
+	entry.Object = sd
+
+	d, err := xRefTable.NewFileSpecDict(inv.FileName, inv.FileName, inv.Desc, *sdIndRef)
+	if err != nil {
+		return err
+	}
+	d.Delete("CI")
+	d.InsertName("AFRelationship", inv.Relationship)
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	m := model.NameMap{inv.FileName: []types.Dict{d}}
+
+	if err := xRefTable.Names["EmbeddedFiles"].Add(xRefTable, inv.FileName, *ir, m, []string{"F", "UF"}); err != nil {
+		return err
+	}
+
+	return addAssociatedFile(ctx, rootDict, *ir)
+}
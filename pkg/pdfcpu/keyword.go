@@ -34,35 +34,6 @@ func KeywordsList(ctx *model.Context) ([]string, error) {
 	return ss, nil
 }
 
-func removeKeywordsFromMetadata(ctx *model.Context) error {
-	rootDict, err := ctx.Catalog()
-	if err != nil {
-		return err
-	}
-
-	indRef, _ := rootDict["Metadata"].(types.IndirectRef)
-	entry, _ := ctx.FindTableEntryForIndRef(&indRef)
-	sd, _ := entry.Object.(types.StreamDict)
-
-	if err = sd.Decode(); err != nil {
-		return err
-	}
-
-	if err = model.RemoveKeywords(&sd.Content); err != nil {
-		return err
-	}
-
-	//fmt.Println(hex.Dump(sd.Content))
-
-	if err := sd.Encode(); err != nil {
-		return err
-	}
-
-	entry.Object = sd
-
-	return nil
-}
-
 func finalizeKeywords(ctx *model.Context) error {
 	d, err := ctx.DereferenceDict(*ctx.Info)
 	if err != nil || d == nil {
@@ -83,14 +54,10 @@ func finalizeKeywords(ctx *model.Context) error {
 
 	d["Keywords"] = types.StringLiteral(*s)
 
-	if ctx.CatalogXMPMeta != nil {
-		removeKeywordsFromMetadata(ctx)
-	}
-
-	return nil
+	return syncInfoXMP(ctx, map[string]string{"Keywords": s0})
 }
 
-// KeywordsAdd adds keywords to the document info dict.
+// KeywordsAdd adds keywords to the document info dict and the XMP metadata.
 // Returns true if at least one keyword was added.
 func KeywordsAdd(ctx *model.Context, keywords []string) error {
 	if err := ensureInfoDictAndFileID(ctx); err != nil {
@@ -104,7 +71,7 @@ func KeywordsAdd(ctx *model.Context, keywords []string) error {
 	return finalizeKeywords(ctx)
 }
 
-// KeywordsRemove deletes keywords from the document info dict.
+// KeywordsRemove deletes keywords from the document info dict and the XMP metadata.
 // Returns true if at least one keyword was removed.
 func KeywordsRemove(ctx *model.Context, keywords []string) (bool, error) {
 	if ctx.Info == nil {
@@ -120,11 +87,7 @@ func KeywordsRemove(ctx *model.Context, keywords []string) (bool, error) {
 		// Remove all keywords.
 		delete(d, "Keywords")
 
-		if ctx.CatalogXMPMeta != nil {
-			removeKeywordsFromMetadata(ctx)
-		}
-
-		return true, nil
+		return true, syncInfoXMP(ctx, map[string]string{"Keywords": ""})
 	}
 
 	var removed bool
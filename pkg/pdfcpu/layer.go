@@ -0,0 +1,779 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var errNoLayers = errors.New("pdfcpu: no layers available")
+
+func ocProperties(ctx *model.Context) (types.Dict, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+	return ctx.DereferenceDict(rootDict["OCProperties"])
+}
+
+// defaultConfig returns the default viewing configuration of ocp, which gets created if missing.
+func defaultConfig(ctx *model.Context, ocp types.Dict) (types.Dict, error) {
+	d, err := ctx.DereferenceDict(ocp["D"])
+	if err != nil {
+		return nil, err
+	}
+	if d == nil {
+		d = types.Dict{}
+		ocp["D"] = d
+	}
+	return d, nil
+}
+
+func refObjNrs(ctx *model.Context, o types.Object) (types.IntSet, error) {
+	a, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return nil, err
+	}
+	m := types.IntSet{}
+	for _, o := range a {
+		if ir, ok := o.(types.IndirectRef); ok {
+			m[ir.ObjectNumber.Value()] = true
+		}
+	}
+	return m, nil
+}
+
+// Layers returns the optional content groups of ctx in document order.
+func Layers(ctx *model.Context) ([]model.Layer, error) {
+	ocp, err := ocProperties(ctx)
+	if err != nil || ocp == nil {
+		return nil, err
+	}
+
+	a, err := ctx.DereferenceArray(ocp["OCGs"])
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ctx.DereferenceDict(ocp["D"])
+	if err != nil {
+		return nil, err
+	}
+
+	base := true
+	var on, off, locked types.IntSet
+	if d != nil {
+		if bs := d.NameEntry("BaseState"); bs != nil && *bs == "OFF" {
+			base = false
+		}
+		if on, err = refObjNrs(ctx, d["ON"]); err != nil {
+			return nil, err
+		}
+		if off, err = refObjNrs(ctx, d["OFF"]); err != nil {
+			return nil, err
+		}
+		if locked, err = refObjNrs(ctx, d["Locked"]); err != nil {
+			return nil, err
+		}
+	}
+
+	ll := []model.Layer{}
+
+	for _, o := range a {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+
+		ocg, err := ctx.DereferenceDict(ir)
+		if err != nil {
+			return nil, err
+		}
+		if ocg == nil {
+			continue
+		}
+
+		objNr := ir.ObjectNumber.Value()
+
+		l := model.Layer{ObjNr: objNr, Visible: base, Locked: locked[objNr]}
+		if on[objNr] {
+			l.Visible = true
+		}
+		if off[objNr] {
+			l.Visible = false
+		}
+
+		if o, found := ocg.Find("Name"); found {
+			if l.Name, err = ctx.DereferenceText(o); err != nil {
+				return nil, err
+			}
+		}
+
+		switch o := ocg["Intent"].(type) {
+		case types.Name:
+			l.Intent = []string{o.Value()}
+		case types.Array:
+			for _, o := range o {
+				if n, ok := o.(types.Name); ok {
+					l.Intent = append(l.Intent, n.Value())
+				}
+			}
+		}
+
+		ll = append(ll, l)
+	}
+
+	return ll, nil
+}
+
+// ListLayers returns a list of the optional content groups of ctx.
+func ListLayers(ctx *model.Context) ([]string, error) {
+	ll, err := Layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ll) == 0 {
+		return []string{"no layers available"}, nil
+	}
+
+	ss := []string{}
+	for i, l := range ll {
+		ss = append(ss, fmt.Sprintf("%d: %s", i+1, l))
+	}
+
+	return ss, nil
+}
+
+// layersByName returns the object numbers of the optional content groups named by names.
+func layersByName(ctx *model.Context, names []string) (types.IntSet, error) {
+	ll, err := Layers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ll) == 0 {
+		return nil, errNoLayers
+	}
+
+	m := types.IntSet{}
+
+	for _, name := range names {
+		found := false
+		for _, l := range ll {
+			if l.Name == name {
+				m[l.ObjNr] = true
+				found = true
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("pdfcpu: layer %q not found", name)
+		}
+	}
+
+	return m, nil
+}
+
+// withoutRefs returns a reduced by all references to objects in m.
+func withoutRefs(a types.Array, m types.IntSet) types.Array {
+	a1 := types.Array{}
+	for _, o := range a {
+		if ir, ok := o.(types.IndirectRef); ok && m[ir.ObjectNumber.Value()] {
+			continue
+		}
+		a1 = append(a1, o)
+	}
+	return a1
+}
+
+// SetLayerVisibility sets the default visibility of the optional content groups named by names.
+func SetLayerVisibility(ctx *model.Context, names []string, visible bool) error {
+	m, err := layersByName(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	ocp, err := ocProperties(ctx)
+	if err != nil {
+		return err
+	}
+
+	d, err := defaultConfig(ctx, ocp)
+	if err != nil {
+		return err
+	}
+
+	for _, k := range []string{"ON", "OFF"} {
+		a, err := ctx.DereferenceArray(d[k])
+		if err != nil {
+			return err
+		}
+		d[k] = withoutRefs(a, m)
+	}
+
+	k := "OFF"
+	if visible {
+		k = "ON"
+	}
+
+	a := d.ArrayEntry(k)
+	for _, objNr := range sortedKeys(m) {
+		a = append(a, *types.NewIndirectRef(objNr, *ctx.Table[objNr].Generation))
+	}
+	d[k] = a
+
+	return nil
+}
+
+func sortedKeys(m types.IntSet) []int {
+	objNrs := make([]int, 0, len(m))
+	for objNr := range m {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+	return objNrs
+}
+
+// layerFilter removes content depending on hidden optional content groups.
+type layerFilter struct {
+	ctx     *model.Context
+	off     types.IntSet // Hidden optional content groups.
+	flatten bool         // Also remove all optional content markers.
+	done    types.IntSet // Filtered content streams.
+}
+
+// hidden returns true if content controlled by the optional content group or membership dict o is invisible.
+// Visibility expressions of membership dicts are not supported, their visibility policy applies.
+func (lf *layerFilter) hidden(o types.Object) bool {
+	ir, ok := o.(types.IndirectRef)
+	if ok && lf.off[ir.ObjectNumber.Value()] {
+		return true
+	}
+
+	d, err := lf.ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return false
+	}
+
+	if t := d.Type(); t == nil || *t != "OCMD" {
+		return false
+	}
+
+	var refs types.Array
+	switch o := d["OCGs"].(type) {
+	case types.IndirectRef:
+		if o1, err := lf.ctx.Dereference(o); err == nil {
+			if a, ok := o1.(types.Array); ok {
+				refs = a
+				break
+			}
+		}
+		refs = types.Array{o}
+	case types.Array:
+		refs = o
+	}
+
+	if len(refs) == 0 {
+		return false
+	}
+
+	on := 0
+	for _, o := range refs {
+		if ir, ok := o.(types.IndirectRef); !ok || !lf.off[ir.ObjectNumber.Value()] {
+			on++
+		}
+	}
+
+	p := "AnyOn"
+	if n := d.NameEntry("P"); n != nil {
+		p = *n
+	}
+
+	switch p {
+	case "AllOn":
+		return on < len(refs)
+	case "AnyOff":
+		return on == len(refs)
+	case "AllOff":
+		return on > 0
+	}
+
+	return on == 0
+}
+
+// optionalContent returns the optional content group or membership dict named by the operands of BDC.
+func (lf *layerFilter) optionalContent(op contentOp, resDict types.Dict) types.Object {
+	if len(op.operands) != 2 || op.operands[0] != "/OC" || resDict == nil {
+		return nil
+	}
+
+	s := op.operands[1]
+	if len(s) < 2 || s[0] != '/' {
+		return nil
+	}
+
+	name, err := types.DecodeName(s[1:])
+	if err != nil {
+		return nil
+	}
+
+	d, err := lf.ctx.DereferenceDict(resDict["Properties"])
+	if err != nil || d == nil {
+		return nil
+	}
+
+	o, _ := d.Find(name)
+
+	return o
+}
+
+// hiddenXObject returns true if the XObject painted by Do is invisible.
+func (lf *layerFilter) hiddenXObject(op contentOp, resDict types.Dict) bool {
+	if len(op.operands) != 1 || resDict == nil {
+		return false
+	}
+
+	s := op.operands[0]
+	if len(s) < 2 || s[0] != '/' {
+		return false
+	}
+
+	name, err := types.DecodeName(s[1:])
+	if err != nil {
+		return false
+	}
+
+	d, err := lf.ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || d == nil {
+		return false
+	}
+
+	sd, _, err := lf.ctx.DereferenceStreamDict(d[name])
+	if err != nil || sd == nil {
+		return false
+	}
+
+	o, found := sd.Find("OC")
+
+	return found && lf.hidden(o)
+}
+
+// filterContent removes invisible marked content sequences and XObjects from content stream bb.
+func (lf *layerFilter) filterContent(bb []byte, resDict types.Dict) ([]byte, bool) {
+	var (
+		out     []byte
+		last    int
+		changed bool
+		stack   []bool // Remove the EMC of each open marked content sequence.
+		skip    int    // Nesting level within a removed marked content sequence.
+		skipBeg int
+	)
+
+	cut := func(beg, end int) {
+		out = append(out, bb[last:beg]...)
+		last, changed = end, true
+	}
+
+	for _, op := range parseContentOps(bb) {
+		if skip > 0 {
+			switch op.name {
+			case "BDC", "BMC":
+				skip++
+			case "EMC":
+				if skip--; skip == 0 {
+					cut(skipBeg, op.end)
+				}
+			}
+			continue
+		}
+
+		switch op.name {
+
+		case "BMC":
+			stack = append(stack, false)
+
+		case "BDC":
+			o := lf.optionalContent(op, resDict)
+			if o == nil {
+				stack = append(stack, false)
+				continue
+			}
+			if lf.hidden(o) {
+				skip, skipBeg = 1, op.beg
+				continue
+			}
+			if lf.flatten {
+				cut(op.beg, op.end)
+			}
+			stack = append(stack, lf.flatten)
+
+		case "EMC":
+			if len(stack) == 0 {
+				continue
+			}
+			if stack[len(stack)-1] {
+				cut(op.beg, op.end)
+			}
+			stack = stack[:len(stack)-1]
+
+		case "Do":
+			if lf.hiddenXObject(op, resDict) {
+				cut(op.beg, op.end)
+			}
+		}
+	}
+
+	if skip > 0 {
+		cut(skipBeg, len(bb))
+	}
+
+	if !changed {
+		return bb, false
+	}
+
+	return append(out, bb[last:]...), true
+}
+
+// filterAnnots removes invisible annotations of a page.
+func (lf *layerFilter) filterAnnots(pageDict types.Dict) error {
+	o, found := pageDict.Find("Annots")
+	if !found {
+		return nil
+	}
+
+	a, err := lf.ctx.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+
+	a1 := types.Array{}
+	for _, o := range a {
+		d, err := lf.ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d != nil {
+			if oc, found := d.Find("OC"); found && lf.hidden(oc) {
+				continue
+			}
+		}
+		a1 = append(a1, o)
+	}
+
+	if len(a1) == len(a) {
+		return nil
+	}
+
+	if len(a1) == 0 {
+		pageDict.Delete("Annots")
+		return nil
+	}
+
+	pageDict.Update("Annots", a1)
+
+	return nil
+}
+
+func (lf *layerFilter) filterPages() error {
+	if err := lf.ctx.EnsurePageCount(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= lf.ctx.PageCount; pageNr++ {
+		pageDict, bb, resDict, err := pageContentAndResources(lf.ctx, pageNr)
+		if err != nil {
+			return err
+		}
+		if err := lf.filterAnnots(pageDict); err != nil {
+			return err
+		}
+		if bb == nil {
+			continue
+		}
+		bb, ok := lf.filterContent(bb, resDict)
+		if !ok {
+			continue
+		}
+		if err := replacePageContent(lf.ctx, pageDict, bb); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// filterContentStream filters the content stream obj#objNr using the resources in o.
+func (lf *layerFilter) filterContentStream(objNr int, o types.Object) error {
+	if lf.done[objNr] {
+		return nil
+	}
+	lf.done[objNr] = true
+
+	entry, ok := lf.ctx.FindTableEntryLight(objNr)
+	if !ok {
+		return nil
+	}
+
+	sd, ok := entry.Object.(types.StreamDict)
+	if !ok {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	resDict, err := lf.ctx.DereferenceDict(o)
+	if err != nil {
+		return err
+	}
+
+	bb, ok := lf.filterContent(sd.Content, resDict)
+	if !ok {
+		return nil
+	}
+
+	sd.Content = bb
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	entry.Object = sd
+
+	return nil
+}
+
+func (lf *layerFilter) filterType3Glyphs(d types.Dict) error {
+	cp, err := lf.ctx.DereferenceDict(d["CharProcs"])
+	if err != nil || cp == nil {
+		return err
+	}
+	for _, o := range cp {
+		if indRef, ok := o.(types.IndirectRef); ok {
+			if err := lf.filterContentStream(indRef.ObjectNumber.Value(), d["Resources"]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// isOptionalContent returns true for optional content groups and membership dicts.
+func isOptionalContent(ctx *model.Context, o types.Object) bool {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return false
+	}
+	t := d.Type()
+	return t != nil && (*t == "OCG" || *t == "OCMD")
+}
+
+// cleanupObject removes references to removed optional content groups from o.
+// When flattening all references to optional content get removed.
+func (lf *layerFilter) cleanupObject(o types.Object) {
+	var d types.Dict
+
+	switch o := o.(type) {
+	case types.Dict:
+		d = o
+	case types.StreamDict:
+		d = o.Dict
+	default:
+		return
+	}
+
+	if oc, found := d.Find("OC"); found {
+		if ir, ok := oc.(types.IndirectRef); ok && lf.off[ir.ObjectNumber.Value()] || lf.flatten {
+			d.Delete("OC")
+		}
+	}
+
+	if props, err := lf.ctx.DereferenceDict(d["Properties"]); err == nil {
+		for k, v := range props {
+			if ir, ok := v.(types.IndirectRef); ok && lf.off[ir.ObjectNumber.Value()] || lf.flatten && isOptionalContent(lf.ctx, v) {
+				props.Delete(k)
+			}
+		}
+	}
+
+	if t := d.Type(); t != nil && *t == "OCMD" {
+		if a, ok := d["OCGs"].(types.Array); ok {
+			d["OCGs"] = withoutRefs(a, lf.off)
+		}
+	}
+
+	for _, v := range d {
+		if _, ok := v.(types.Dict); ok {
+			lf.cleanupObject(v)
+		}
+	}
+}
+
+func (lf *layerFilter) filterObjects() error {
+	for _, objNr := range tableObjNrs(lf.ctx) {
+		// Also loads objects from object streams.
+		o, err := lf.ctx.Dereference(*types.NewIndirectRef(objNr, *lf.ctx.Table[objNr].Generation))
+		if err != nil {
+			return err
+		}
+
+		switch o := o.(type) {
+
+		case types.StreamDict:
+			if isContentStream(o) {
+				if err := lf.filterContentStream(objNr, o.Dict["Resources"]); err != nil {
+					return err
+				}
+			}
+
+		case types.Dict:
+			if st := o.Subtype(); st != nil && *st == "Type3" {
+				if err := lf.filterType3Glyphs(o); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	// Optional content references are removed only after all content got filtered.
+	for _, objNr := range tableObjNrs(lf.ctx) {
+		lf.cleanupObject(lf.ctx.Table[objNr].Object)
+	}
+
+	return nil
+}
+
+// pruneConfig removes references to the objects in m from the optional content configuration o.
+func pruneConfig(ctx *model.Context, o types.Object, m types.IntSet) types.Object {
+	switch o1 := o.(type) {
+
+	case types.IndirectRef:
+		o2, err := ctx.Dereference(o1)
+		if err != nil {
+			break
+		}
+		if _, ok := o2.(types.Array); ok {
+			return pruneConfig(ctx, o2, m)
+		}
+		if d, ok := o2.(types.Dict); ok && !isOptionalContent(ctx, d) {
+			pruneConfig(ctx, d, m)
+		}
+
+	case types.Dict:
+		for k, v := range o1 {
+			o1[k] = pruneConfig(ctx, v, m)
+		}
+
+	case types.Array:
+		a := types.Array{}
+		for _, v := range withoutRefs(o1, m) {
+			if _, ok := v.(types.Array); ok {
+				a1 := pruneConfig(ctx, v, m).(types.Array)
+				// Drop emptied nested arrays like rbgroups or order sub trees left with a label only.
+				if len(a1) == 0 || len(a1) == 1 && isLabel(a1[0]) {
+					continue
+				}
+				v = a1
+			}
+			if d, ok := v.(types.Dict); ok {
+				v = pruneConfig(ctx, d, m)
+			}
+			a = append(a, v)
+		}
+		return a
+	}
+
+	return o
+}
+
+func isLabel(o types.Object) bool {
+	switch o.(type) {
+	case types.StringLiteral, types.HexLiteral:
+		return true
+	}
+	return false
+}
+
+func filterLayers(ctx *model.Context, off types.IntSet, flatten bool) error {
+	lf := &layerFilter{ctx: ctx, off: off, flatten: flatten, done: types.IntSet{}}
+
+	if err := lf.filterPages(); err != nil {
+		return err
+	}
+
+	if err := lf.filterObjects(); err != nil {
+		return err
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	ocp, err := ocProperties(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !flatten {
+		pruneConfig(ctx, ocp, off)
+		if a, _ := ctx.DereferenceArray(ocp["OCGs"]); len(a) > 0 {
+			return nil
+		}
+	}
+
+	rootDict.Delete("OCProperties")
+
+	return nil
+}
+
+// RemoveLayers removes the optional content groups named by names including all content depending on them.
+func RemoveLayers(ctx *model.Context, names []string) error {
+	m, err := layersByName(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	return filterLayers(ctx, m, false)
+}
+
+// FlattenLayers turns optional content into regular content as it is visible by default.
+// Hidden content gets removed along with all optional content groups.
+func FlattenLayers(ctx *model.Context) error {
+	ll, err := Layers(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(ll) == 0 {
+		return errNoLayers
+	}
+
+	off := types.IntSet{}
+	for _, l := range ll {
+		if !l.Visible {
+			off[l.ObjNr] = true
+		}
+	}
+
+	return filterLayers(ctx, off, true)
+}
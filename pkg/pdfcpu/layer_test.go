@@ -0,0 +1,79 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestFilterLayerContent(t *testing.T) {
+	ctx, err := CreateContextWithXRefTable(nil, types.PaperSize["A4"])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var refs []types.IndirectRef
+	for _, name := range []string{"A", "B"} {
+		ir, err := ctx.IndRefForNewObject(types.Dict{"Type": types.Name("OCG"), "Name": types.StringLiteral(name)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		refs = append(refs, *ir)
+	}
+
+	ocmd := func(p string) types.Dict {
+		return types.Dict{"Type": types.Name("OCMD"), "OCGs": types.Array{refs[0], refs[1]}, "P": types.Name(p)}
+	}
+
+	resDict := types.Dict{
+		"Properties": types.Dict{
+			"OC0": refs[0],
+			"OC1": refs[1],
+			"MC0": ocmd("AnyOn"),
+			"MC1": ocmd("AllOn"),
+		},
+	}
+
+	// Layer A gets removed.
+	off := types.IntSet{refs[0].ObjectNumber.Value(): true}
+
+	for _, tt := range []struct {
+		in, want, flat string
+	}{
+		{"/OC /OC0 BDC 0 g EMC 1 g", " 1 g", " 1 g"},
+		{"/OC /OC1 BDC 0 g EMC 1 g", "/OC /OC1 BDC 0 g EMC 1 g", " 0 g  1 g"},
+		{"/OC /OC0 BDC /Span <</MCID 0>> BDC 0 g EMC /P BMC EMC EMC 1 g", " 1 g", " 1 g"},
+		{"/OC /OC1 BDC /OC /OC0 BDC 0 g EMC EMC", "/OC /OC1 BDC  EMC", "  "},
+		{"/OC /MC0 BDC 0 g EMC", "/OC /MC0 BDC 0 g EMC", " 0 g "},
+		{"/OC /MC1 BDC 0 g EMC", "", ""},
+		{"/OC /OC0 BDC 0 g", "", ""},
+	} {
+		for _, flatten := range []bool{false, true} {
+			want := tt.want
+			if flatten {
+				want = tt.flat
+			}
+			lf := &layerFilter{ctx: ctx, off: off, flatten: flatten, done: types.IntSet{}}
+			got, _ := lf.filterContent([]byte(tt.in), resDict)
+			if string(got) != want {
+				t.Errorf("filterContent(%q, flatten=%t):\ngot:  %q\nwant: %q\n", tt.in, flatten, got, want)
+			}
+		}
+	}
+}
@@ -645,10 +645,9 @@ func patchNameTree(n *model.Node, lookup map[int]int) error {
 }
 
 func patchSourceObjectNumbers(ctxSrc, ctxDest *model.Context) {
-	if log.DebugEnabled() {
-		log.Debug.Printf("patchSourceObjectNumbers:  ctxSrc: xRefTableSize:%d trailer.Size:%d - %s\n", len(ctxSrc.Table), *ctxSrc.Size, ctxSrc.Read.FileName)
-		log.Debug.Printf("patchSourceObjectNumbers: ctxDest: xRefTableSize:%d trailer.Size:%d - %s\n", len(ctxDest.Table), *ctxDest.Size, ctxDest.Read.FileName)
-	}
+	ctxDest.LogDebug("patchSourceObjectNumbers: begin",
+		"src", ctxSrc.Read.FileName, "srcTableSize", len(ctxSrc.Table), "srcSize", *ctxSrc.Size,
+		"destTableSize", len(ctxDest.Table), "destSize", *ctxDest.Size)
 
 	// Patch source xref tables obj numbers which are essentially the keys.
 	//logInfoMerge.Printf("Source XRefTable before:\n%s\n", ctxSource)
@@ -683,18 +682,14 @@ func patchSourceObjectNumbers(ctxSrc, ctxDest *model.Context) {
 		entry := ctxSrc.Table[k]
 
 		if entry.Free {
-			if log.DebugEnabled() {
-				log.Debug.Printf("patch free entry: old offset:%d\n", *entry.Offset)
-			}
+			ctxDest.LogDebug("patch free entry", "oldOffset", *entry.Offset)
 			off := int(*entry.Offset)
 			if off == 0 {
 				continue
 			}
 			i := int64(lookup[off])
 			entry.Offset = &i
-			if log.DebugEnabled() {
-				log.Debug.Printf("patch free entry: new offset:%d\n", *entry.Offset)
-			}
+			ctxDest.LogDebug("patch free entry", "newOffset", *entry.Offset)
 			continue
 		}
 
@@ -726,9 +721,7 @@ func patchSourceObjectNumbers(ctxSrc, ctxDest *model.Context) {
 		patchNameTree(v, lookup)
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("patchSourceObjectNumbers end")
-	}
+	ctxDest.LogDebug("patchSourceObjectNumbers: end")
 }
 
 func createDividerPagesDict(ctx *model.Context, parentIndRef types.IndirectRef) (*types.IndirectRef, error) {
@@ -765,9 +758,7 @@ func createDividerPagesDict(ctx *model.Context, parentIndRef types.IndirectRef)
 }
 
 func appendSourcePageTreeToDestPageTree(ctxSrc, ctxDest *model.Context, dividerPage bool) error {
-	if log.DebugEnabled() {
-		log.Debug.Println("appendSourcePageTreeToDestPageTree begin")
-	}
+	ctxDest.LogDebug("appendSourcePageTreeToDestPageTree: begin")
 
 	indRefPageTreeRootDictDest, err := ctxDest.Pages()
 	if err != nil {
@@ -836,17 +827,13 @@ func appendSourcePageTreeToDestPageTree(ctxSrc, ctxDest *model.Context, dividerP
 
 	rootDict["Pages"] = *indRef
 
-	if log.DebugEnabled() {
-		log.Debug.Println("appendSourcePageTreeToDestPageTree end")
-	}
+	ctxDest.LogDebug("appendSourcePageTreeToDestPageTree: end")
 
 	return nil
 }
 
 func zipSourcePageTreeIntoDestPageTree(ctxSrc, ctxDest *model.Context) error {
-	if log.DebugEnabled() {
-		log.Debug.Println("zipSourcePageTreeIntoDestPageTree begin")
-	}
+	ctxDest.LogDebug("zipSourcePageTreeIntoDestPageTree: begin")
 
 	appendFromPageNr := 0
 	if ctxSrc.PageCount > ctxDest.PageCount {
@@ -871,17 +858,13 @@ func zipSourcePageTreeIntoDestPageTree(ctxSrc, ctxDest *model.Context) error {
 		}
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Println("zipSourcePageTreeIntoDestPageTree end")
-	}
+	ctxDest.LogDebug("zipSourcePageTreeIntoDestPageTree: end")
 
 	return nil
 }
 
 func appendSourceObjectsToDest(ctxSrc, ctxDest *model.Context) {
-	if log.DebugEnabled() {
-		log.Debug.Println("appendSourceObjectsToDest begin")
-	}
+	ctxDest.LogDebug("appendSourceObjectsToDest: begin")
 
 	for objNr, entry := range ctxSrc.Table {
 
@@ -890,9 +873,7 @@ func appendSourceObjectsToDest(ctxSrc, ctxDest *model.Context) {
 			continue
 		}
 
-		if log.DebugEnabled() {
-			log.Debug.Printf("adding obj %d from src to dest\n", objNr)
-		}
+		ctxDest.LogDebug("appendSourceObjectsToDest: adding", log.KeyObj, objNr)
 
 		ctxDest.Table[objNr] = entry
 
@@ -900,9 +881,7 @@ func appendSourceObjectsToDest(ctxSrc, ctxDest *model.Context) {
 
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Println("appendSourceObjectsToDest end")
-	}
+	ctxDest.LogDebug("appendSourceObjectsToDest: end")
 }
 
 // merge two disjunct IntSets
@@ -913,18 +892,14 @@ func mergeIntSets(src, dest types.IntSet) {
 }
 
 func mergeDuplicateObjNumberIntSets(ctxSrc, ctxDest *model.Context) {
-	if log.DebugEnabled() {
-		log.Debug.Println("mergeDuplicateObjNumberIntSets begin")
-	}
+	ctxDest.LogDebug("mergeDuplicateObjNumberIntSets: begin")
 
 	mergeIntSets(ctxSrc.Optimize.DuplicateInfoObjects, ctxDest.Optimize.DuplicateInfoObjects)
 	mergeIntSets(ctxSrc.LinearizationObjs, ctxDest.LinearizationObjs)
 	mergeIntSets(ctxSrc.Read.XRefStreams, ctxDest.Read.XRefStreams)
 	mergeIntSets(ctxSrc.Read.ObjectStreams, ctxDest.Read.ObjectStreams)
 
-	if log.DebugEnabled() {
-		log.Debug.Println("mergeDuplicateObjNumberIntSets end")
-	}
+	ctxDest.LogDebug("mergeDuplicateObjNumberIntSets: end")
 }
 
 // MergeXRefTables merges Context ctxSrc into ctxDest by appending its page tree.
@@ -985,8 +960,9 @@ func MergeXRefTables(fName string, ctxSrc, ctxDest *model.Context, zip, dividerP
 	// Merge all IntSets containing redundant object numbers.
 	mergeDuplicateObjNumberIntSets(ctxSrc, ctxDest)
 
-	if log.InfoEnabled() {
-		log.Info.Printf("Dest XRefTable after merge:\n%s\n", ctxDest)
+	ctxDest.LogInfo("MergeXRefTables: merged", "src", fName, "pages", ctxDest.PageCount)
+	if log.StatsEnabled() {
+		log.Stats.Printf("Dest XRefTable after merge:\n%s\n", ctxDest)
 	}
 
 	return nil
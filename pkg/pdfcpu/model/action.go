@@ -0,0 +1,102 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// The page additional action triggers as defined in 12.6.3 Trigger Events.
+const (
+	PageOpen  = "O" // Page opened.
+	PageClose = "C" // Page closed.
+)
+
+// DocumentActionTriggers are the document additional action triggers as defined in 12.6.3 Trigger Events
+// preceded by OpenAction representing the catalog's open action.
+var DocumentActionTriggers = []string{"OpenAction", "WC", "WS", "DS", "WP", "DP"}
+
+// NamedActions are the standard named actions as defined in 12.6.4.11 Named Actions
+// followed by some well known non standard ones.
+var NamedActions = []string{"NextPage", "PrevPage", "FirstPage", "LastPage", "GoBack", "GoForward", "Find", "Print", "SaveAs", "FullScreen"}
+
+// Action represents a GoTo, URI or Named action.
+// Other action types found in a document are reported by type only.
+type Action struct {
+	Type      string       // GoTo, URI, Named or any other action type.
+	Dest      *Destination // GoTo: explicit destination.
+	NamedDest string       // GoTo: named destination.
+	URI       string       // URI: the uniform resource identifier to resolve.
+	Name      string       // Named: the name of the action, see NamedActions.
+}
+
+// Validate checks a for consistency.
+func (a Action) Validate() error {
+	switch a.Type {
+
+	case "GoTo":
+		if (a.Dest == nil) == (a.NamedDest == "") {
+			return errors.New("pdfcpu: GoTo action: need either destination or named destination")
+		}
+
+	case "URI":
+		if a.URI == "" {
+			return errors.New("pdfcpu: URI action: missing URI")
+		}
+
+	case "Named":
+		if !types.MemberOf(a.Name, NamedActions) {
+			return errors.Errorf("pdfcpu: Named action: unsupported name: %s", a.Name)
+		}
+
+	default:
+		return errors.Errorf("pdfcpu: unsupported action type: %s", a.Type)
+	}
+
+	return nil
+}
+
+func (a Action) String() string {
+	switch a.Type {
+
+	case "GoTo":
+		if a.NamedDest != "" {
+			return fmt.Sprintf("GoTo %s", a.NamedDest)
+		}
+		if a.Dest != nil {
+			return fmt.Sprintf("GoTo page %d /%s", a.Dest.PageNr, a.Dest)
+		}
+
+	case "URI":
+		return fmt.Sprintf("URI %s", a.URI)
+
+	case "Named":
+		return fmt.Sprintf("Named %s", a.Name)
+	}
+
+	return a.Type
+}
+
+// PageAction represents an additional action triggered by opening or closing a page.
+type PageAction struct {
+	PageNr  int
+	Trigger string // PageOpen or PageClose.
+	Action  Action
+}
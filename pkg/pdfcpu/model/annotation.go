@@ -17,9 +17,14 @@ limitations under the License.
 package model
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
@@ -71,6 +76,7 @@ const (
 	AnnWatermark
 	Ann3D
 	AnnRedact
+	AnnRichMedia
 	AnnCustom
 )
 
@@ -101,6 +107,7 @@ var AnnotTypes = map[string]AnnotationType{
 	"Watermark":      AnnWatermark,
 	"3D":             Ann3D,
 	"Redact":         AnnRedact,
+	"RichMedia":      AnnRichMedia,
 	"Custom":         AnnCustom,
 }
 
@@ -132,6 +139,7 @@ var AnnotTypeStrings = map[AnnotationType]string{
 	AnnWatermark:      "Watermark",
 	Ann3D:             "3D",
 	AnnRedact:         "Redact",
+	AnnRichMedia:      "RichMedia",
 	AnnCustom:         "Custom",
 }
 
@@ -571,6 +579,8 @@ type MarkupAnnotation struct {
 	RC           string             // A rich text string that shall be displayed in the pop-up window when the annotation is opened.
 	CreationDate string             // The date and time when the annotation was created.
 	Subj         string             // Text representing a short description of the subject being addressed by the annotation.
+	IRT          *types.IndirectRef // An indirect reference to the annotation this annotation is in reply to.
+	RT           string             // (Default: R) The relationship to IRT: R (reply) or Group.
 }
 
 // NewMarkupAnnotation returns a new markup annotation.
@@ -652,14 +662,23 @@ func (ann MarkupAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.I
 		d.InsertString("Subj", *s)
 	}
 
+	if ann.IRT != nil {
+		d.Insert("IRT", *ann.IRT)
+		if ann.RT != "" && ann.RT != "R" {
+			d.InsertName("RT", ann.RT)
+		}
+	}
+
 	return d, nil
 }
 
 // TextAnnotation represents a PDF text annotation aka "Sticky Note".
 type TextAnnotation struct {
 	MarkupAnnotation
-	Open bool   // A flag specifying whether the annotation shall initially be displayed open.
-	Name string // The name of an icon that shall be used in displaying the annotation. Comment, Key, (Note), Help, NewParagraph, Paragraph, Insert
+	Open       bool   // A flag specifying whether the annotation shall initially be displayed open.
+	Name       string // The name of an icon that shall be used in displaying the annotation. Comment, Key, (Note), Help, NewParagraph, Paragraph, Insert
+	State      string // The review state of the annotation IRT refers to: Marked, Unmarked, Accepted, Rejected, Cancelled, Completed, None
+	StateModel string // The state model corresponding to State: Marked or Review
 }
 
 // NewTextAnnotation returns a new text annotation.
@@ -703,6 +722,21 @@ func (ann TextAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Ind
 		d.InsertName("Name", ann.Name)
 	}
 
+	if ann.State != "" {
+		if ann.IRT == nil {
+			return nil, errors.New("pdfcpu: TextAnnotation: state requires IRT")
+		}
+		stateModel := ann.StateModel
+		if stateModel == "" {
+			stateModel = "Review"
+			if ann.State == "Marked" || ann.State == "Unmarked" {
+				stateModel = "Marked"
+			}
+		}
+		d.InsertString("State", ann.State)
+		d.InsertString("StateModel", stateModel)
+	}
+
 	return d, nil
 }
 
@@ -831,23 +865,26 @@ func (ann FreeTextAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types
 		return nil, err
 	}
 
-	da := ""
-
-	// TODO Implement Tf operator
-
-	// fontID, err := xRefTable.EnsureFont(ann.FontName) // in root page Resources?
-	// if err != nil {
-	// 	return nil, err
-	// }
-
-	// da := fmt.Sprintf("/%s %d Tf", fontID, ann.FontSize)
+	// The font is referenced by its core font name, see the generated appearance stream for its resources.
+	fontName, fontSize := ann.FontName, ann.FontSize
+	if !font.IsCoreFont(fontName) {
+		fontName = "Helvetica"
+	}
+	if fontSize <= 0 {
+		fontSize = 12
+	}
+	da := fmt.Sprintf("/%s %d Tf", fontName, fontSize)
 
 	if ann.FontCol != nil {
 		da += fmt.Sprintf(" %.2f %.2f %.2f rg", ann.FontCol.R, ann.FontCol.G, ann.FontCol.B)
 	}
 	d["DA"] = types.StringLiteral(da)
 
-	d.InsertInt("Q", int(ann.HAlign))
+	q := ann.HAlign
+	if q == types.AlignJustify {
+		q = types.AlignLeft
+	}
+	d.InsertInt("Q", int(q))
 
 	if ann.Text == "" {
 		if ann.Contents == "" {
@@ -1724,3 +1761,521 @@ func (ann InkAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.Indi
 
 	return d, nil
 }
+
+// StampAnnotation displays text or graphics intended to look as if they were stamped on the page with a rubber stamp.
+type StampAnnotation struct {
+	MarkupAnnotation
+	Name string // The name of a predefined stamp like Approved, Confidential, Draft, Final or NotApproved.
+}
+
+// NewStampAnnotation returns a new stamp annotation.
+func NewStampAnnotation(
+	rect types.Rectangle,
+	apObjNr int,
+	contents, id string,
+	modDate string,
+	f AnnotationFlags,
+	col *color.SimpleColor,
+	title string,
+	popupIndRef *types.IndirectRef,
+	ca *float64,
+	rc, subject string,
+
+	name string) StampAnnotation {
+
+	ma := NewMarkupAnnotation(AnnStamp, rect, apObjNr, contents, id, modDate, f, col, 0, 0, 0, title, popupIndRef, ca, rc, subject)
+
+	return StampAnnotation{
+		MarkupAnnotation: ma,
+		Name:             name,
+	}
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann StampAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	d, err := ann.MarkupAnnotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if ann.Name != "" {
+		d.InsertName("Name", ann.Name)
+	}
+
+	return d, nil
+}
+
+// FileAttachmentAnnotation represents a file embedded into the PDF and displayed as an icon on the page.
+type FileAttachmentAnnotation struct {
+	MarkupAnnotation
+	io.Reader        // The content of the attached file.
+	FileName  string // The name of the attached file.
+	Desc      string // The description of the attached file.
+	Name      string // The name of the icon: Graph, PushPin, Paperclip or Tag.
+}
+
+// NewFileAttachmentAnnotation returns a new file attachment annotation.
+func NewFileAttachmentAnnotation(
+	rect types.Rectangle,
+	apObjNr int,
+	contents, id string,
+	modDate string,
+	f AnnotationFlags,
+	col *color.SimpleColor,
+	title string,
+	popupIndRef *types.IndirectRef,
+	ca *float64,
+	rc, subject string,
+
+	r io.Reader,
+	fileName, desc, name string) FileAttachmentAnnotation {
+
+	ma := NewMarkupAnnotation(AnnFileAttachment, rect, apObjNr, contents, id, modDate, f, col, 0, 0, 0, title, popupIndRef, ca, rc, subject)
+
+	return FileAttachmentAnnotation{
+		MarkupAnnotation: ma,
+		Reader:           r,
+		FileName:         fileName,
+		Desc:             desc,
+		Name:             name,
+	}
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann FileAttachmentAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	if ann.Reader == nil || ann.FileName == "" {
+		return nil, errors.New("pdfcpu: FileAttachmentAnnotation missing file")
+	}
+
+	d, err := ann.MarkupAnnotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	ir, err := xRefTable.NewEmbeddedStreamDict(ann.Reader, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	fs, err := xRefTable.NewFileSpecDict(ann.FileName, ann.FileName, ann.Desc, *ir)
+	if err != nil {
+		return nil, err
+	}
+	d["FS"] = fs
+
+	if ann.Name != "" {
+		d.InsertName("Name", ann.Name)
+	}
+
+	return d, nil
+}
+
+// RichMediaTypes maps supported media file extensions to the corresponding rich media content type.
+var RichMediaTypes = map[string]string{
+	".mp4": "Video",
+	".m4v": "Video",
+	".mov": "Video",
+	".mp3": "Sound",
+	".m4a": "Sound",
+	".aac": "Sound",
+}
+
+// RichMediaAnnotation represents a video or audio clip embedded into the PDF and played in place by the viewer.
+// See Adobe Supplement to ISO 32000, BaseVersion 1.7, ExtensionLevel 3.
+type RichMediaAnnotation struct {
+	Annotation
+	io.Reader              // The content of the media file.
+	FileName     string    // The name of the media file, its extension determines the content type.
+	Poster       io.Reader // Optional image displayed until the media gets activated.
+	Activation   string    // XA: on click (default), PO: on page open, PV: when the page becomes visible.
+	Deactivation string    // XD: on click (default), PC: on page close, PI: when the page becomes invisible.
+	Windowed     bool      // Play in a floating window instead of within the annotation rectangle.
+	Toolbar      bool      // Display the player controls.
+}
+
+// NewRichMediaAnnotation returns a new rich media annotation.
+func NewRichMediaAnnotation(
+	rect types.Rectangle,
+	contents, id string,
+	modDate string,
+	f AnnotationFlags,
+
+	r io.Reader,
+	fileName string,
+	poster io.Reader,
+	activation, deactivation string,
+	windowed, toolbar bool) RichMediaAnnotation {
+
+	ann := NewAnnotation(AnnRichMedia, "", rect, 0, contents, id, modDate, f, nil, 0, 0, 0)
+
+	return RichMediaAnnotation{
+		Annotation:   ann,
+		Reader:       r,
+		FileName:     fileName,
+		Poster:       poster,
+		Activation:   activation,
+		Deactivation: deactivation,
+		Windowed:     windowed,
+		Toolbar:      toolbar,
+	}
+}
+
+// mediaConditions returns the activation and deactivation conditions for rich media and 3D annotations.
+func mediaConditions(annotType, activation, deactivation string) (string, string, error) {
+	if activation == "" {
+		activation = "XA"
+	}
+	if !types.MemberOf(activation, []string{"XA", "PO", "PV"}) {
+		return "", "", errors.Errorf("pdfcpu: %s invalid activation: %s", annotType, activation)
+	}
+	if deactivation == "" {
+		deactivation = "XD"
+	}
+	if !types.MemberOf(deactivation, []string{"XD", "PC", "PI"}) {
+		return "", "", errors.Errorf("pdfcpu: %s invalid deactivation: %s", annotType, deactivation)
+	}
+	return activation, deactivation, nil
+}
+
+// posterAppearance returns a normal appearance showing the image read from r fitted into rect.
+func posterAppearance(xRefTable *XRefTable, r io.Reader, rect types.Rectangle) (*types.IndirectRef, error) {
+	ir, w, h, err := CreateImageResource(xRefTable, r)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fit the poster into the annotation rectangle preserving its aspect ratio.
+	rw, rh := rect.Width(), rect.Height()
+	s := min(rw/float64(w), rh/float64(h))
+	iw, ih := s*float64(w), s*float64(h)
+	bb := fmt.Sprintf("0 g 0 0 %.2f %.2f re f q %.2f 0 0 %.2f %.2f %.2f cm /Im0 Do Q\n", rw, rh, iw, ih, (rw-iw)/2, (rh-ih)/2)
+
+	sd, _ := xRefTable.NewStreamDictForBuf([]byte(bb))
+	sd.InsertName("Type", "XObject")
+	sd.InsertName("Subtype", "Form")
+	sd.Insert("BBox", types.RectForDim(rw, rh).Array())
+	sd.Insert("Resources", types.Dict{"XObject": types.Dict{"Im0": *ir}})
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return xRefTable.IndRefForNewObject(*sd)
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann RichMediaAnnotation) RenderDict(xRefTable *XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	if ann.Reader == nil || ann.FileName == "" {
+		return nil, errors.New("pdfcpu: RichMediaAnnotation missing media file")
+	}
+
+	subtype, ok := RichMediaTypes[strings.ToLower(filepath.Ext(ann.FileName))]
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: RichMediaAnnotation unsupported media file: %s", ann.FileName)
+	}
+
+	activation, deactivation, err := mediaConditions("RichMediaAnnotation", ann.Activation, ann.Deactivation)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ann.Annotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xRefTable.EnsureADBEExtensionLevel(3); err != nil {
+		return nil, err
+	}
+
+	ir, err := xRefTable.NewEmbeddedStreamDict(ann.Reader, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := filepath.Base(ann.FileName)
+	fs, err := xRefTable.NewFileSpecDict(fileName, fileName, "", *ir)
+	if err != nil {
+		return nil, err
+	}
+	fsIndRef, err := xRefTable.IndRefForNewObject(fs)
+	if err != nil {
+		return nil, err
+	}
+
+	assetName, err := types.EscapedUTF16String(fileName)
+	if err != nil {
+		return nil, err
+	}
+
+	instance := types.Dict{
+		"Type":    types.Name("RichMediaInstance"),
+		"Subtype": types.Name(subtype),
+		"Asset":   *fsIndRef,
+	}
+
+	config := types.Dict{
+		"Type":      types.Name("RichMediaConfiguration"),
+		"Subtype":   types.Name(subtype),
+		"Instances": types.Array{instance},
+	}
+	configIndRef, err := xRefTable.IndRefForNewObject(config)
+	if err != nil {
+		return nil, err
+	}
+
+	d["RichMediaContent"] = types.Dict{
+		"Type":           types.Name("RichMediaContent"),
+		"Assets":         types.Dict{"Names": types.Array{types.StringLiteral(*assetName), *fsIndRef}},
+		"Configurations": types.Array{*configIndRef},
+	}
+
+	presentation := types.Dict{
+		"Type":    types.Name("RichMediaPresentation"),
+		"Style":   types.Name("Embedded"),
+		"Toolbar": types.Boolean(ann.Toolbar),
+	}
+	if ann.Windowed {
+		presentation["Style"] = types.Name("Windowed")
+		presentation["Window"] = types.Dict{
+			"Type":   types.Name("RichMediaWindow"),
+			"Width":  types.Dict{"Default": types.Float(ann.Rect.Width())},
+			"Height": types.Dict{"Default": types.Float(ann.Rect.Height())},
+		}
+	}
+
+	d["RichMediaSettings"] = types.Dict{
+		"Type": types.Name("RichMediaSettings"),
+		"Activation": types.Dict{
+			"Type":          types.Name("RichMediaActivation"),
+			"Condition":     types.Name(activation),
+			"Configuration": *configIndRef,
+			"Presentation":  presentation,
+		},
+		"Deactivation": types.Dict{
+			"Type":      types.Name("RichMediaDeactivation"),
+			"Condition": types.Name(deactivation),
+		},
+	}
+
+	if ann.Poster != nil {
+		apIndRef, err := posterAppearance(xRefTable, ann.Poster, ann.Rect)
+		if err != nil {
+			return nil, err
+		}
+		d["AP"] = types.Dict{"N": *apIndRef}
+	}
+
+	return d, nil
+}
+
+// Lighting schemes of 3D views (see table 318).
+var LightingSchemes = []string{"Artwork", "None", "White", "Day", "Night", "Hard", "Primary", "Blue", "Red", "Cube", "CAD", "Headlamp"}
+
+// RenderModes of 3D views (see table 316).
+var RenderModes = []string{
+	"Solid", "SolidWireframe", "Transparent", "TransparentWireframe", "BoundingBox", "TransparentBoundingBox",
+	"TransparentBoundingBoxOutline", "Wireframe", "ShadedWireframe", "HiddenWireframe", "Vertices", "ShadedVertices",
+	"Illustration", "SolidOutline", "ShadedIllustration",
+}
+
+// View3D represents a predefined view of a 3D artwork (see 13.6.4).
+type View3D struct {
+	Name          string             // The external name of the view displayed by the viewer.
+	CameraToWorld []float64          // Optional 12 numbers: the matrix transforming camera coordinates to world coordinates.
+	CenterOfOrbit float64            // The distance from the camera to the center of orbit.
+	Orthographic  bool               // Use orthographic instead of perspective projection.
+	FOV           float64            // The field of view of perspective projections in degrees, defaults to 30.
+	Background    *color.SimpleColor // Optional background color.
+	Lighting      string             // Optional lighting scheme, one of LightingSchemes.
+	RenderMode    string             // Optional render mode, one of RenderModes.
+}
+
+func (v View3D) renderDict() (types.Dict, error) {
+	d := types.Dict{
+		"Type": types.Name("3DView"),
+		"XN":   types.StringLiteral(v.Name),
+	}
+
+	if v.CameraToWorld != nil {
+		if len(v.CameraToWorld) != 12 {
+			return nil, errors.Errorf("pdfcpu: 3D view %s: camera to world matrix needs 12 numbers", v.Name)
+		}
+		d["MS"] = types.Name("M")
+		d["C2W"] = types.NewNumberArray(v.CameraToWorld...)
+		d["CO"] = types.Float(v.CenterOfOrbit)
+	}
+
+	p := types.Dict{"Subtype": types.Name("P")}
+	if v.Orthographic {
+		p["Subtype"] = types.Name("O")
+		// Scale to fit the artwork.
+		p["OB"] = types.Name("Min")
+	} else {
+		fov := v.FOV
+		if fov <= 0 {
+			fov = 30
+		}
+		if fov > 180 {
+			return nil, errors.Errorf("pdfcpu: 3D view %s: invalid field of view: %.2f", v.Name, fov)
+		}
+		p["FOV"] = types.Float(fov)
+	}
+	d["P"] = p
+
+	if v.Background != nil {
+		d["BG"] = types.Dict{
+			"Type":    types.Name("3DBG"),
+			"Subtype": types.Name("SC"),
+			"C":       v.Background.Array(),
+		}
+	}
+
+	if v.Lighting != "" {
+		if !types.MemberOf(v.Lighting, LightingSchemes) {
+			return nil, errors.Errorf("pdfcpu: 3D view %s: invalid lighting scheme: %s", v.Name, v.Lighting)
+		}
+		d["LS"] = types.Dict{"Type": types.Name("3DLightingScheme"), "Subtype": types.Name(v.Lighting)}
+	}
+
+	if v.RenderMode != "" {
+		if !types.MemberOf(v.RenderMode, RenderModes) {
+			return nil, errors.Errorf("pdfcpu: 3D view %s: invalid render mode: %s", v.Name, v.RenderMode)
+		}
+		d["RM"] = types.Dict{"Type": types.Name("3DRenderMode"), "Subtype": types.Name(v.RenderMode)}
+	}
+
+	return d, nil
+}
+
+// Annotation3D represents U3D or PRC artwork embedded into the PDF and rendered interactively by the viewer (see 13.6).
+type Annotation3D struct {
+	Annotation
+	io.Reader              // The 3D artwork.
+	Format       string    // U3D or PRC, detected from the artwork if missing.
+	Views        []View3D  // Predefined views, the first one is the default view.
+	Poster       io.Reader // Optional image displayed until the artwork gets activated.
+	Activation   string    // XA: on click (default), PO: on page open, PV: when the page becomes visible.
+	Deactivation string    // XD: on click (default), PC: on page close, PI: when the page becomes invisible.
+	Toolbar      bool      // Display the 3D toolbar.
+	NavPane      bool      // Display the model tree.
+}
+
+// NewAnnotation3D returns a new 3D annotation.
+func NewAnnotation3D(
+	rect types.Rectangle,
+	contents, id string,
+	modDate string,
+	f AnnotationFlags,
+
+	r io.Reader,
+	format string,
+	views []View3D,
+	poster io.Reader,
+	activation, deactivation string,
+	toolbar, navPane bool) Annotation3D {
+
+	ann := NewAnnotation(Ann3D, "", rect, 0, contents, id, modDate, f, nil, 0, 0, 0)
+
+	return Annotation3D{
+		Annotation:   ann,
+		Reader:       r,
+		Format:       format,
+		Views:        views,
+		Poster:       poster,
+		Activation:   activation,
+		Deactivation: deactivation,
+		Toolbar:      toolbar,
+		NavPane:      navPane,
+	}
+}
+
+// format3D returns the format of the 3D artwork bb.
+func format3D(bb []byte, format string) (string, error) {
+	if format == "" {
+		switch {
+		case bytes.HasPrefix(bb, []byte("U3D")):
+			format = "U3D"
+		case bytes.HasPrefix(bb, []byte("PRC")):
+			format = "PRC"
+		}
+	}
+	if format != "U3D" && format != "PRC" {
+		return "", errors.New("pdfcpu: Annotation3D: unsupported 3D format, expected U3D or PRC")
+	}
+	return format, nil
+}
+
+// RenderDict renders ann into a PDF annotation dict.
+func (ann Annotation3D) RenderDict(xRefTable *XRefTable, pageIndRef *types.IndirectRef) (types.Dict, error) {
+	if ann.Reader == nil {
+		return nil, errors.New("pdfcpu: Annotation3D missing 3D artwork")
+	}
+
+	activation, deactivation, err := mediaConditions("Annotation3D", ann.Activation, ann.Deactivation)
+	if err != nil {
+		return nil, err
+	}
+
+	bb, err := io.ReadAll(ann.Reader)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := format3D(bb, ann.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	d, err := ann.Annotation.RenderDict(xRefTable, pageIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	sd, err := xRefTable.NewStreamDictForBuf(bb)
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertName("Type", "3D")
+	sd.InsertName("Subtype", format)
+
+	if len(ann.Views) > 0 {
+		var va types.Array
+		for _, v := range ann.Views {
+			d1, err := v.renderDict()
+			if err != nil {
+				return nil, err
+			}
+			va = append(va, d1)
+		}
+		sd.Insert("VA", va)
+		sd.Insert("DV", types.Integer(0))
+		d["3DV"] = types.Name("D")
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+	d["3DD"] = *ir
+
+	d["3DA"] = types.Dict{
+		"A":  types.Name(activation),
+		"D":  types.Name(deactivation),
+		"TB": types.Boolean(ann.Toolbar),
+		"NP": types.Boolean(ann.NavPane),
+	}
+
+	if ann.Poster != nil {
+		apIndRef, err := posterAppearance(xRefTable, ann.Poster, ann.Rect)
+		if err != nil {
+			return nil, err
+		}
+		d["AP"] = types.Dict{"N": *apIndRef}
+	}
+
+	return d, nil
+}
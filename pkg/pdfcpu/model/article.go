@@ -0,0 +1,96 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Bead represents a region of an article thread on a page as defined in 12.4.3 Articles.
+type Bead struct {
+	PageNr int
+	Rect   *types.Rectangle
+}
+
+func (b Bead) String() string {
+	return fmt.Sprintf("page %d %s", b.PageNr, b.Rect.ShortString())
+}
+
+// Article represents an article thread, a chain of beads defining the reading order of an article across pages.
+type Article struct {
+	Title    string
+	Author   string
+	Subject  string
+	Keywords string
+	Beads    []Bead // In reading order.
+}
+
+// Validate checks a for consistency against a document with pageCount pages.
+func (a Article) Validate(pageCount int) error {
+	if len(a.Beads) == 0 {
+		return errors.New("pdfcpu: article: missing beads")
+	}
+	for i, b := range a.Beads {
+		if b.PageNr < 1 || b.PageNr > pageCount {
+			return errors.Errorf("pdfcpu: article: bead %d: invalid page number: %d", i+1, b.PageNr)
+		}
+		if b.Rect == nil || b.Rect.Width() <= 0 || b.Rect.Height() <= 0 {
+			return errors.Errorf("pdfcpu: article: bead %d: missing or empty rectangle", i+1)
+		}
+	}
+	return nil
+}
+
+// InfoDict returns the thread information dict for a.
+func (a Article) InfoDict() (types.Dict, error) {
+	d := types.Dict{}
+	for k, v := range map[string]string{"Title": a.Title, "Author": a.Author, "Subject": a.Subject, "Keywords": a.Keywords} {
+		if v == "" {
+			continue
+		}
+		s, err := types.EscapedUTF16String(v)
+		if err != nil {
+			return nil, err
+		}
+		d[k] = types.StringLiteral(*s)
+	}
+	return d, nil
+}
+
+func (a Article) String() string {
+	var sb strings.Builder
+
+	title := a.Title
+	if title == "" {
+		title = "(untitled)"
+	}
+	sb.WriteString(title)
+	if a.Author != "" {
+		sb.WriteString(" by " + a.Author)
+	}
+	sb.WriteString(fmt.Sprintf(" (%d beads)", len(a.Beads)))
+
+	for _, b := range a.Beads {
+		sb.WriteString("\n   " + b.String())
+	}
+
+	return sb.String()
+}
@@ -17,7 +17,6 @@ limitations under the License.
 package model
 
 import (
-	"bytes"
 	"fmt"
 	"io"
 	"sort"
@@ -41,17 +40,6 @@ func (a Attachment) String() string {
 	return fmt.Sprintf("Attachment: id:%s desc:%s modTime:%s", a.ID, a.Desc, a.ModTime)
 }
 
-func decodeFileSpecStreamDict(sd *types.StreamDict) error {
-	fpl := sd.FilterPipeline
-
-	if fpl == nil {
-		sd.Content = sd.Raw
-		return nil
-	}
-
-	return sd.Decode()
-}
-
 func fileSpecStreamFileName(xRefTable *XRefTable, d types.Dict) (string, error) {
 	o, found := d.Find("UF")
 	if found {
@@ -125,7 +113,7 @@ func getModDate(xRefTable *XRefTable, obj types.Object) (*time.Time, error) {
 	return &md, nil
 }
 
-func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object, decode bool) (*types.StreamDict, string, string, *time.Time, error) {
+func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object) (*types.StreamDict, string, string, *time.Time, error) {
 	d, err := xRefTable.DereferenceDict(o)
 	if err != nil {
 		return nil, "", "", nil, err
@@ -161,9 +149,7 @@ func fileSpecStreamDictInfo(xRefTable *XRefTable, id string, o types.Object, dec
 		}
 	}
 
-	err = decodeFileSpecStreamDict(sd)
-
-	return sd, desc, fileName, modDate, err
+	return sd, desc, fileName, modDate, nil
 }
 
 // ListAttachments returns a slice of attachment stubs (attachment w/o data).
@@ -181,8 +167,7 @@ func (ctx *Context) ListAttachments() ([]Attachment, error) {
 	aa := []Attachment{}
 
 	createAttachmentStub := func(xRefTable *XRefTable, id string, o *types.Object) error {
-		decode := false
-		_, desc, fileName, modTime, err := fileSpecStreamDictInfo(xRefTable, id, *o, decode)
+		_, desc, fileName, modTime, err := fileSpecStreamDictInfo(xRefTable, id, *o)
 		if err != nil {
 			return err
 		}
@@ -238,8 +223,7 @@ func (ctx *Context) SearchEmbeddedFilesNameTreeNodeByContent(s string) (*string,
 	)
 
 	identifyAttachmentStub := func(xRefTable *XRefTable, id string, o *types.Object) error {
-		decode := false
-		_, desc, fileName, _, err := fileSpecStreamDictInfo(xRefTable, id, *o, decode)
+		_, desc, fileName, _, err := fileSpecStreamDictInfo(xRefTable, id, *o)
 		if err != nil {
 			return err
 		}
@@ -361,12 +345,16 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 	aa := []Attachment{}
 
 	createAttachment := func(xRefTable *XRefTable, id string, o *types.Object) error {
-		decode := true
-		sd, desc, fileName, modTime, err := fileSpecStreamDictInfo(xRefTable, id, *o, decode)
+		sd, desc, fileName, modTime, err := fileSpecStreamDictInfo(xRefTable, id, *o)
 		if err != nil {
 			return err
 		}
-		a := Attachment{Reader: bytes.NewReader(sd.Content), ID: id, FileName: fileName, Desc: desc, ModTime: modTime}
+		// Attachment data gets decoded while being read.
+		r, err := sd.DecodeReader()
+		if err != nil {
+			return err
+		}
+		a := Attachment{Reader: r, ID: id, FileName: fileName, Desc: desc, ModTime: modTime}
 		aa = append(aa, a)
 		return nil
 	}
@@ -385,9 +373,7 @@ func (ctx *Context) ExtractAttachments(ids []string) ([]Attachment, error) {
 					if log.CLIEnabled() {
 						log.CLI.Printf("attachment %s not found", id)
 					}
-					if log.InfoEnabled() {
-						log.Info.Printf("pdfcpu: extractAttachments: %s not found", id)
-					}
+					ctx.LogInfo("ExtractAttachments: not found", "id", id)
 					continue
 				}
 				v = o
@@ -0,0 +1,62 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const defaultBatesDigits = 6
+
+// Bates represents the command details for Bates numbering a set of documents.
+// Each selected page receives a unique, sequential number: Prefix + zero-padded counter + Suffix.
+type Bates struct {
+	Prefix  string            // Text preceding the counter eg. "ACME".
+	Suffix  string            // Text following the counter.
+	Start   int               // First Bates number.
+	Digits  int               // Minimal number of digits, the counter is padded with leading zeros.
+	Desc    string            // Stamp description for position, font etc., please refer to "pdfcpu stamp".
+	Next    int               // Next Bates number to be assigned, continues across files.
+	InpUnit types.DisplayUnit // Input display unit.
+}
+
+// BatesEntry maps a page of a file to its Bates number.
+type BatesEntry struct {
+	FileName string
+	PageNr   int
+	Number   string
+}
+
+// DefaultBatesConfig returns the default Bates configuration.
+func DefaultBatesConfig() *Bates {
+	return &Bates{
+		Start:  1,
+		Digits: defaultBatesDigits,
+		Next:   1,
+	}
+}
+
+// Number returns the Bates number for counter i.
+func (b Bates) Number(i int) string {
+	return fmt.Sprintf("%s%0*d%s", b.Prefix, b.Digits, i, b.Suffix)
+}
+
+func (b Bates) String() string {
+	return fmt.Sprintf("Bates numbering: %s .. (next: %s)\n", b.Number(b.Start), b.Number(b.Next))
+}
@@ -0,0 +1,139 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// BleedMode defines how the bleed gets filled.
+type BleedMode int
+
+// The supported bleed modes.
+const (
+	BleedMirror BleedMode = iota // Reflect the page content at the page edges.
+	BleedSmear                   // Stretch the outermost strip of the page content.
+	BleedColor                   // Fill with a solid color.
+)
+
+func (m BleedMode) String() string {
+	switch m {
+	case BleedSmear:
+		return "smear"
+	case BleedColor:
+		return "color"
+	}
+	return "mirror"
+}
+
+// Bleed represents the configuration for adding bleed to pages.
+type Bleed struct {
+	Width float64            // bleed width in points
+	Unit  types.DisplayUnit  // display unit
+	Mode  BleedMode          // fill mode
+	Color *color.SimpleColor // fill color for BleedColor
+}
+
+func (b Bleed) String() string {
+	s := fmt.Sprintf("width: %.2f pt, mode: %s", b.Width, b.Mode)
+	if b.Mode == BleedColor && b.Color != nil {
+		s += ", color: " + b.Color.String()
+	}
+	return s
+}
+
+// Validate checks b for consistency.
+func (b *Bleed) Validate() error {
+	if b.Width <= 0 {
+		return errors.New("pdfcpu: bleed - please supply a bleed width > 0")
+	}
+	if b.Mode == BleedColor && b.Color == nil {
+		c := color.White
+		b.Color = &c
+	}
+	return nil
+}
+
+func parseWidthBleed(s string, b *Bleed) error {
+	w, err := strconv.ParseFloat(s, 64)
+	if err != nil || w <= 0 {
+		return errors.Errorf("pdfcpu: bleed width must be a float value > 0: %s\n", s)
+	}
+	b.Width = types.ToUserSpace(w, b.Unit)
+	return nil
+}
+
+func parseModeBleed(s string, b *Bleed) error {
+	switch strings.ToLower(s) {
+	case "mirror", "m":
+		b.Mode = BleedMirror
+	case "smear", "s":
+		b.Mode = BleedSmear
+	case "color", "c":
+		b.Mode = BleedColor
+	default:
+		return errors.New("pdfcpu: bleed mode, please provide one of: mirror, smear, color")
+	}
+	return nil
+}
+
+func parseColorBleed(s string, b *Bleed) error {
+	c, err := color.ParseColor(s)
+	if err != nil {
+		return err
+	}
+	b.Color = &c
+	b.Mode = BleedColor
+	return nil
+}
+
+type bleedParameterMap map[string]func(string, *Bleed) error
+
+var BleedParamMap = bleedParameterMap{
+	"width":   parseWidthBleed,
+	"mode":    parseModeBleed,
+	"fillcol": parseColorBleed,
+}
+
+// Handle applies parameter completion and on success parse parameter values into bleed.
+func (m bleedParameterMap) Handle(paramPrefix, paramValueStr string, b *Bleed) error {
+
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, b)
+}
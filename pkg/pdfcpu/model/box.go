@@ -1255,3 +1255,43 @@ func (ctx *Context) Crop(selectedPages types.IntSet, b *Box) error {
 	}
 	return nil
 }
+
+// AutoCrop represents trimming pages to the bounding box of their content.
+type AutoCrop struct {
+	Margin   float64 // Margin in points applied to the content bounding box.
+	MediaBox bool    // Also set the media box and drop page boundaries exceeding it.
+}
+
+// ParseAutoCrop parses an auto crop definition.
+func ParseAutoCrop(s string, u types.DisplayUnit) (*AutoCrop, error) {
+	// auto			... crop to the content bounding box
+	// auto 10		... apply a margin of 10 display units
+	// auto 10 media	... also set the media box
+
+	ss := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	if len(ss) == 0 || strings.ToLower(ss[0]) != "auto" {
+		return nil, errors.Errorf("pdfcpu: invalid auto crop definition: %s", s)
+	}
+
+	ac := &AutoCrop{}
+
+	for _, s1 := range ss[1:] {
+		if strings.HasPrefix("media", strings.ToLower(s1)) {
+			ac.MediaBox = true
+			continue
+		}
+		f, err := strconv.ParseFloat(s1, 64)
+		if err != nil || f < 0 {
+			return nil, errors.Errorf("pdfcpu: invalid auto crop margin: %s", s1)
+		}
+		ac.Margin = types.ToUserSpace(f, u)
+	}
+
+	return ac, nil
+}
+
+// IsAutoCrop returns true if s is an auto crop definition.
+func IsAutoCrop(s string) bool {
+	ss := strings.Fields(strings.ReplaceAll(s, ",", " "))
+	return len(ss) > 0 && strings.ToLower(ss[0]) == "auto"
+}
@@ -0,0 +1,73 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+)
+
+// RenderingIntent represents an ICC rendering intent.
+type RenderingIntent int
+
+// The rendering intents numbered like their ICC lookup table tags.
+const (
+	Perceptual RenderingIntent = iota
+	RelativeColorimetric
+	Saturation
+)
+
+func (ri RenderingIntent) String() string {
+	switch ri {
+	case Perceptual:
+		return "perceptual"
+	case Saturation:
+		return "saturation"
+	}
+	return "relative"
+}
+
+// CMYKConversion represents the settings for converting RGB colors into CMYK colors.
+type CMYKConversion struct {
+	SourceProfile     []byte          // RGB ICC profile for DeviceRGB colors, nil = sRGB.
+	SourceProfileName string          // File name of SourceProfile.
+	DestProfile       []byte          // CMYK output ICC profile.
+	DestProfileName   string          // File name of DestProfile.
+	Intent            RenderingIntent // Rendering intent used for choosing the profile lookup tables.
+	OutputIntent      bool            // Embed DestProfile as output intent unless the document has one.
+}
+
+// DefaultCMYKConversion returns the default settings for converting RGB colors into CMYK colors.
+func DefaultCMYKConversion() *CMYKConversion {
+	return &CMYKConversion{Intent: RelativeColorimetric, OutputIntent: true}
+}
+
+func (cc CMYKConversion) String() string {
+	src := cc.SourceProfileName
+	if src == "" {
+		src = "sRGB"
+	}
+	return fmt.Sprintf("src:%s dest:%s intent:%s outputIntent:%t", src, cc.DestProfileName, cc.Intent, cc.OutputIntent)
+}
+
+// ColorConversionStats represents the outcome of a color conversion.
+type ColorConversionStats struct {
+	ContentStreams int // Number of rewritten page, form, pattern and glyph content streams.
+	Images         int // Number of converted images.
+	Shadings       int // Number of converted shadings.
+	ColorSpaces    int // Number of converted indexed color spaces.
+	Skipped        int // Number of images and shadings left alone.
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+
+// PropertyDiff represents a document property differing between two files.
+type PropertyDiff struct {
+	Name   string `json:"name"`
+	Value1 string `json:"value1"`
+	Value2 string `json:"value2"`
+}
+
+// PageComparison represents the comparison of a page of two files.
+type PageComparison struct {
+	PageNr  int               `json:"page"`
+	Hash1   string            `json:"hash1,omitempty"` // Hash of the page of the first file covering content, resources and page boundaries.
+	Hash2   string            `json:"hash2,omitempty"` // Hash of the page of the second file.
+	Equal   bool              `json:"equal"`
+	Resized bool              `json:"resized,omitempty"` // The media boxes differ.
+	Removed []types.Rectangle `json:"removed,omitempty"` // Regions painted in the first file only.
+	Added   []types.Rectangle `json:"added,omitempty"`   // Regions painted in the second file only.
+}
+
+// ComparisonReport represents the differences between two files.
+type ComparisonReport struct {
+	File1      string           `json:"file1,omitempty"`
+	File2      string           `json:"file2,omitempty"`
+	PageCount1 int              `json:"pageCount1"`
+	PageCount2 int              `json:"pageCount2"`
+	Properties []PropertyDiff   `json:"properties,omitempty"`
+	Pages      []PageComparison `json:"pages,omitempty"` // Differing pages only.
+	Identical  bool             `json:"identical"`
+}
@@ -17,9 +17,12 @@ limitations under the License.
 package model
 
 import (
+	"crypto"
+	"crypto/x509"
 	"embed"
 	_ "embed"
 	"fmt"
+	"log/slog"
 	"os"
 	"path/filepath"
 	"strings"
@@ -28,6 +31,7 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
 )
 
 const (
@@ -38,6 +42,34 @@ const (
 	ValidationRelaxed
 )
 
+// ObjectStreamMode controls the emission of object streams and cross-reference streams.
+type ObjectStreamMode int
+
+const (
+	// ObjectStreamsAuto packs the page tree and the structure tree into object streams if WriteObjectStream is set
+	// and writes a cross-reference stream if WriteXRefStream is set.
+	ObjectStreamsAuto ObjectStreamMode = iota
+
+	// ObjectStreamsPack packs all eligible objects into object streams and always writes a cross-reference stream.
+	ObjectStreamsPack
+
+	// ObjectStreamsExpand writes neither object streams nor cross-reference streams for maximum compatibility.
+	ObjectStreamsExpand
+)
+
+// ParseObjectStreamMode parses an object stream mode: auto, pack or expand.
+func ParseObjectStreamMode(s string) (ObjectStreamMode, error) {
+	switch strings.ToLower(s) {
+	case "auto":
+		return ObjectStreamsAuto, nil
+	case "pack":
+		return ObjectStreamsPack, nil
+	case "expand":
+		return ObjectStreamsExpand, nil
+	}
+	return ObjectStreamsAuto, errors.Errorf("pdfcpu: invalid object stream mode: %s (expecting auto, pack or expand)", s)
+}
+
 // See table 22 - User access permissions
 type PermissionFlags int
 
@@ -161,6 +193,66 @@ const (
 	INSPECTCERTIFICATES
 	IMPORTCERTIFICATES
 	VALIDATESIGNATURES
+	IMPOSE
+	MANUALDUPLEX
+	CREATECOVER
+	BATES
+	HEADERFOOTER
+	LISTPAGELABELS
+	SETPAGELABELS
+	REMOVEPAGELABELS
+	LISTNAMEDDESTS
+	ADDNAMEDDESTS
+	REMOVENAMEDDESTS
+	RETARGETNAMEDDESTS
+	ADDAUTOLINKS
+	DETECTBOOKMARKS
+	ADDTOC
+	CREATEPORTFOLIO
+	ADDINVOICE
+	LISTMETADATA
+	SETMETADATA
+	CHECKMETADATA
+	SYNCMETADATA
+	CONVERTCMYK
+	CONVERTGRAY
+	LISTOUTPUTINTENTS
+	ADDOUTPUTINTENT
+	REPLACEOUTPUTINTENT
+	FLATTENTRANSPARENCY
+	LISTLAYERS
+	SHOWLAYERS
+	HIDELAYERS
+	REMOVELAYERS
+	FLATTENLAYERS
+	EDITCONTENT
+	EXTRACTVECTORPATHS
+	REPAIR
+	COMPARE
+	ANALYZEPAGES
+	SANITIZE
+	EXTRACTIMAGESLOSSLESS
+	ADDOCRTEXT
+	EXTRACTTABLES
+	EXPORTANNOTATIONS
+	IMPORTANNOTATIONS
+	FLATTENANNOTATIONS
+	LISTPAGETRANSITIONS
+	SETPAGETRANSITIONS
+	REMOVEPAGETRANSITIONS
+	SETFULLSCREEN
+	LISTACTIONS
+	SETACTIONS
+	REMOVEACTIONS
+	LISTGEOVIEWPORTS
+	ADDGEOVIEWPORTS
+	REMOVEGEOVIEWPORTS
+	LISTARTICLES
+	ADDARTICLES
+	AUTOCROP
+	BLEED
+	AUTOROTATE
+	PIPELINE
 )
 
 // Configuration of a Context.
@@ -190,6 +282,9 @@ type Configuration struct {
 	// Check for broken links in LinkedAnnotations/URIActions.
 	ValidateLinks bool
 
+	// Damage accepted while reading.
+	ReadTolerance ReadTolerance
+
 	// End of line char sequence for writing.
 	Eol string
 
@@ -202,6 +297,9 @@ type Configuration struct {
 	// Switches between xRefSection (<=V1.4) and objectStream/xRefStream (>=V1.5) writing.
 	WriteXRefStream bool
 
+	// Overrides WriteObjectStream and WriteXRefStream unless ObjectStreamsAuto.
+	ObjectStreamMode ObjectStreamMode
+
 	// Turns on stats collection.
 	// TODO Decision - unused.
 	CollectStats bool
@@ -217,6 +315,10 @@ type Configuration struct {
 	OwnerPW    string
 	OwnerPWNew *string
 
+	// PasswordProvider supplies passwords for opening encrypted files
+	// if the user and owner passwords supplied are wrong, nil = off.
+	PasswordProvider PasswordProvider
+
 	// EncryptUsingAES ensures AES encryption.
 	// true: AES encryption
 	// false: RC4 encryption.
@@ -228,6 +330,13 @@ type Configuration struct {
 	// Supplied user access permissions, see Table 22.
 	Permissions PermissionFlags // int16
 
+	// Recipients for public key encryption, nil = password based encryption.
+	Recipients []*x509.Certificate
+
+	// Certificate and private key of a recipient for opening public key encrypted files.
+	RecipientCert *x509.Certificate
+	RecipientKey  crypto.PrivateKey
+
 	// Command being executed.
 	Cmd CommandMode
 
@@ -253,15 +362,37 @@ type Configuration struct {
 	// Optimize duplicate content streams across pages. (assuming Optimize == true || OptimizeBeforeWriting == true)
 	OptimizeDuplicateContentStreams bool
 
+	// Re-encode images when running optimize, nil = off.
+	ImageOptimization *ImageOptimization
+
+	// Subset fully embedded fonts when running optimize.
+	SubsetFonts bool
+
+	// Remove blank and duplicate pages when running split, merge or optimize, nil = off.
+	PageCleanup *PageCleanup
+
 	// Merge creates bookmarks.
 	CreateBookmarks bool
 
+	// Merge collapses identical font files, images and ICC profiles.
+	DedupeResources bool
+
+	// Create, toc and flatten build a structure tree for generated content (tagged PDF).
+	TagContent bool
+
+	// Ordered list of user fonts taking over chars missing in the font of stamp, create and form field text.
+	FallbackFonts []string
+
 	// PDF Viewer is expected to supply appearance streams for form fields.
 	NeedAppearances bool
 
 	// Internet availability.
 	Offline bool
 
+	// Structured logger for this configuration taking over the global Debug and Info loggers, nil = off.
+	// Records carry the attributes op and file plus page and obj where applicable.
+	Logger *slog.Logger
+
 	// HTTP timeout in seconds.
 	Timeout int
 
@@ -456,6 +587,7 @@ func newDefaultConfiguration() *Configuration {
 		DecodeAllStreams:                false,
 		ValidationMode:                  ValidationRelaxed,
 		ValidateLinks:                   false,
+		ReadTolerance:                   DefaultReadTolerance(),
 		Eol:                             types.EolLF,
 		WriteObjectStream:               true,
 		WriteXRefStream:                 true,
@@ -469,6 +601,7 @@ func newDefaultConfiguration() *Configuration {
 		OptimizeResourceDicts:           true,
 		OptimizeDuplicateContentStreams: false,
 		CreateBookmarks:                 true,
+		DedupeResources:                 true,
 		NeedAppearances:                 false,
 		Offline:                         false,
 		Timeout:                         5,
@@ -506,6 +639,21 @@ func NewDefaultConfiguration() *Configuration {
 	return newDefaultConfiguration()
 }
 
+// PasswordProvider returns the password for attempt number attempt (starting with 1) to open fileName.
+// fileName is empty unless reading from a file.
+// Returning false stops trying.
+type PasswordProvider func(fileName string, attempt int) (string, bool)
+
+// PasswordList returns a PasswordProvider trying pws in order.
+func PasswordList(pws ...string) PasswordProvider {
+	return func(fileName string, attempt int) (string, bool) {
+		if attempt > len(pws) {
+			return "", false
+		}
+		return pws[attempt-1], true
+	}
+}
+
 // NewAESConfiguration returns a default configuration for AES encryption.
 func NewAESConfiguration(userPW, ownerPW string, keyLength int) *Configuration {
 	c := NewDefaultConfiguration()
@@ -516,6 +664,23 @@ func NewAESConfiguration(userPW, ownerPW string, keyLength int) *Configuration {
 	return c
 }
 
+// NewPublicKeyConfiguration returns a default configuration for AES encryption for recipients.
+func NewPublicKeyConfiguration(recipients []*x509.Certificate, keyLength int) *Configuration {
+	c := NewDefaultConfiguration()
+	c.Recipients = recipients
+	c.EncryptUsingAES = true
+	c.EncryptKeyLength = keyLength
+	return c
+}
+
+// NewRecipientConfiguration returns a default configuration for opening files encrypted for the recipient cert.
+func NewRecipientConfiguration(cert *x509.Certificate, key crypto.PrivateKey) *Configuration {
+	c := NewDefaultConfiguration()
+	c.RecipientCert = cert
+	c.RecipientKey = key
+	return c
+}
+
 // NewRC4Configuration returns a default configuration for RC4 encryption.
 func NewRC4Configuration(userPW, ownerPW string, keyLength int) *Configuration {
 	c := NewDefaultConfiguration()
@@ -548,6 +713,17 @@ func (c *Configuration) ValidationModeString() string {
 	return "relaxed"
 }
 
+// ObjectStreamModeString returns a string rep for the object stream mode in effect.
+func (c *Configuration) ObjectStreamModeString() string {
+	switch c.ObjectStreamMode {
+	case ObjectStreamsPack:
+		return "pack"
+	case ObjectStreamsExpand:
+		return "expand"
+	}
+	return "auto"
+}
+
 // PreferredCertRevocationCheckerString returns a string rep for the preferred certificate revocation checker in effect.
 func (c *Configuration) PreferredCertRevocationCheckerString() string {
 	if c.PreferredCertRevocationChecker == CRL {
@@ -594,3 +770,12 @@ func (c *Configuration) ApplyReducedFeatureSet() bool {
 	}
 	return false
 }
+
+// PreserveNamedDests returns true if named destinations of extracted pages shall be written despite a reduced feature set.
+func (c *Configuration) PreserveNamedDests() bool {
+	switch c.Cmd {
+	case SPLIT, TRIM, EXTRACTPAGES:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ContentOperator represents a content stream operator along with its operands.
+type ContentOperator struct {
+	Name     string
+	Operands []types.Object
+	Raw      string // Inline images are kept as is starting with BI up to and including EI.
+}
+
+// NewContentOperator returns a new content operator.
+func NewContentOperator(name string, operands ...types.Object) ContentOperator {
+	return ContentOperator{Name: name, Operands: operands}
+}
+
+// Content represents a parsed content stream.
+type Content []ContentOperator
+
+// ContentEditor edits the content of page pageNr of ctx using resDict, the resources of the page.
+type ContentEditor func(ctx *Context, pageNr int, c Content, resDict types.Dict) (Content, error)
+
+func contentOperandString(o types.Object) string {
+	switch o := o.(type) {
+
+	case types.Float:
+		return strconv.FormatFloat(o.Value(), 'f', -1, 64)
+
+	case types.Array:
+		ss := make([]string, len(o))
+		for i, o := range o {
+			ss[i] = contentOperandString(o)
+		}
+		return "[" + strings.Join(ss, " ") + "]"
+
+	case nil:
+		return "null"
+	}
+
+	return o.PDFString()
+}
+
+func (op ContentOperator) String() string {
+	if op.Raw != "" {
+		return op.Raw
+	}
+	var sb strings.Builder
+	for _, o := range op.Operands {
+		sb.WriteString(contentOperandString(o))
+		sb.WriteByte(' ')
+	}
+	sb.WriteString(op.Name)
+	return sb.String()
+}
+
+// Bytes serializes c into a content stream with one operator per line.
+func (c Content) Bytes() []byte {
+	var sb strings.Builder
+	for _, op := range c {
+		sb.WriteString(op.String())
+		sb.WriteByte('\n')
+	}
+	return []byte(sb.String())
+}
+
+func (c Content) String() string {
+	return string(c.Bytes())
+}
@@ -60,6 +60,7 @@ func NewContext(rs io.ReadSeeker, conf *Configuration) (*Context, error) {
 		false,
 		false,
 	}
+	ctx.XRefTable.fileName = rdCtx.FileName
 
 	return ctx, nil
 }
@@ -69,6 +70,15 @@ func (ctx *Context) ResetWriteContext() {
 	ctx.Write = NewWriteContext(ctx.Write.Eol)
 }
 
+// ResetOptimizationContext prepares a context for optimization.
+// Contexts not read from a file like the result of ExtractPages also get an empty ReadContext.
+func (ctx *Context) ResetOptimizationContext() {
+	if ctx.Read == nil {
+		ctx.Read = &ReadContext{ObjectStreams: types.IntSet{}, XRefStreams: types.IntSet{}}
+	}
+	ctx.Optimize = newOptimizationContext()
+}
+
 func (rc *ReadContext) logReadContext(logStr *[]string) {
 	if rc.UsingObjectStreams {
 		*logStr = append(*logStr, "using object streams\n")
@@ -195,6 +205,9 @@ type ReadContext struct {
 	ObjectStreams       types.IntSet // All object numbers of any object streams found which need to be decoded.
 	UsingXRefStreams    bool         // File is using xref streams.
 	XRefStreams         types.IntSet // All object numbers of any xref streams found.
+	Repairs             []Repair     // Workarounds applied for reading a damaged file.
+	XRefRebuilt         bool         // The xref table got rebuilt by scanning the file for objects.
+	Revisions           int          // Number of xref sections read, more than 1 for incrementally updated files.
 }
 
 func newReadContext(rs io.ReadSeeker) (*ReadContext, error) {
@@ -211,6 +224,10 @@ func newReadContext(rs io.ReadSeeker) (*ReadContext, error) {
 	}
 	rdCtx.FileSize = fileSize
 
+	if f, ok := rs.(interface{ Name() string }); ok {
+		rdCtx.FileName = f.Name()
+	}
+
 	return rdCtx, nil
 }
 
@@ -0,0 +1,115 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	defaultCoverGrammage = 80.   // Paper weight in g/m².
+	defaultCoverBulk     = 1.25  // Paper volume in cm³/g.
+	defaultCoverBleed    = 8.504 // 3 mm
+)
+
+// Cover represents the command details for the cover spread of a perfect bound book.
+// The spread consists of back cover, spine and front cover, surrounded by bleed.
+type Cover struct {
+	PageCount int                // Number of book pages, used to compute the spine width.
+	TrimDim   *types.Dim         // Trimmed book page dimensions in points.
+	Caliper   float64            // Paper thickness per leaf in points, overrides grammage and bulk.
+	Grammage  float64            // Paper weight in g/m².
+	Bulk      float64            // Paper volume in cm³/g, thickness(µm) = grammage * bulk.
+	Bleed     float64            // Bleed in points.
+	Guides    bool               // Draw trim and spine fold lines.
+	Front     string             // Optional image file for the front cover.
+	Back      string             // Optional image file for the back cover.
+	BgColor   *color.SimpleColor // Background color.
+	InpUnit   types.DisplayUnit  // Input display unit.
+}
+
+// DefaultCoverConfig returns the default cover configuration.
+func DefaultCoverConfig() *Cover {
+	return &Cover{
+		TrimDim:  types.PaperSize["A5"],
+		Grammage: defaultCoverGrammage,
+		Bulk:     defaultCoverBulk,
+		Bleed:    defaultCoverBleed,
+	}
+}
+
+// SpineWidth returns the spine width for pageCount pages printed on paper with thickness caliper.
+func SpineWidth(pageCount int, caliper float64) float64 {
+	leaves := math.Ceil(float64(pageCount) / 2)
+	return leaves * caliper
+}
+
+// LeafCaliper returns the paper thickness per leaf in points.
+func (c Cover) LeafCaliper() float64 {
+	if c.Caliper > 0 {
+		return c.Caliper
+	}
+	// grammage * bulk yields µm.
+	return types.ToUserSpace(c.Grammage*c.Bulk/1000, types.MILLIMETRES)
+}
+
+// SpineWidth returns the spine width in points.
+func (c Cover) SpineWidth() float64 {
+	return SpineWidth(c.PageCount, c.LeafCaliper())
+}
+
+// Dim returns the dimensions of the cover spread including bleed.
+func (c Cover) Dim() *types.Dim {
+	return &types.Dim{
+		Width:  2*c.TrimDim.Width + c.SpineWidth() + 2*c.Bleed,
+		Height: c.TrimDim.Height + 2*c.Bleed,
+	}
+}
+
+// TrimBox returns the trimmed cover spread.
+func (c Cover) TrimBox() *types.Rectangle {
+	d := c.Dim()
+	return types.NewRectangle(c.Bleed, c.Bleed, d.Width-c.Bleed, d.Height-c.Bleed)
+}
+
+// BackRect returns the back cover region including bleed.
+func (c Cover) BackRect() *types.Rectangle {
+	d := c.Dim()
+	return types.NewRectangle(0, 0, c.Bleed+c.TrimDim.Width, d.Height)
+}
+
+// SpineRect returns the spine region including bleed.
+func (c Cover) SpineRect() *types.Rectangle {
+	d := c.Dim()
+	llx := c.Bleed + c.TrimDim.Width
+	return types.NewRectangle(llx, 0, llx+c.SpineWidth(), d.Height)
+}
+
+// FrontRect returns the front cover region including bleed.
+func (c Cover) FrontRect() *types.Rectangle {
+	d := c.Dim()
+	return types.NewRectangle(c.Bleed+c.TrimDim.Width+c.SpineWidth(), 0, d.Width, d.Height)
+}
+
+func (c Cover) String() string {
+	return fmt.Sprintf("Cover conf: pages=%d, trim=%s, spine=%.2f, bleed=%.2f, dim=%s\n",
+		c.PageCount, *c.TrimDim, c.SpineWidth(), c.Bleed, *c.Dim())
+}
@@ -37,6 +37,8 @@ type Cut struct {
 	Margin   float64            // glue area in display unit
 	BgColor  *color.SimpleColor // background color
 	Origin   types.Corner       // one of 4 page corners, default = UpperLeft
+	Overlap  float64            // overlap of adjacent tiles in display unit (poster)
+	Labels   bool               // true to stamp tile coordinates and alignment marks onto tiles
 }
 
 type cutParameterMap map[string]func(string, *Cut) error
@@ -174,6 +176,34 @@ func parseBorderCut(s string, cut *Cut) error {
 	return nil
 }
 
+func parseOverlapCut(s string, cut *Cut) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return err
+	}
+
+	if f < 0 {
+		return errors.New("pdfcpu: cut overlap, Please provide a positive value")
+	}
+
+	cut.Overlap = types.ToUserSpace(f, cut.Unit)
+
+	return nil
+}
+
+func parseLabelsCut(s string, cut *Cut) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		cut.Labels = true
+	case "off", "false", "f":
+		cut.Labels = false
+	default:
+		return errors.New("pdfcpu: cut labels, please provide one of: on/off true/false t/f")
+	}
+
+	return nil
+}
+
 func parseMarginCut(s string, cut *Cut) error {
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -198,6 +228,8 @@ var CutParamMap = cutParameterMap{
 	"scalefactor":   parseScaleFactorCut,
 	"border":        parseBorderCut,
 	"margin":        parseMarginCut,
+	"overlap":       parseOverlapCut,
+	"labels":        parseLabelsCut,
 	"bgcolor":       parseBackgroundColorCut,
 }
 
@@ -79,3 +79,9 @@ func (dest Destination) Array(indRef types.IndirectRef) types.Array {
 	}
 	return arr
 }
+
+// NamedDestination represents a destination referred to by name.
+type NamedDestination struct {
+	Name string
+	Dest Destination
+}
@@ -90,6 +90,7 @@ const (
 	PageLayoutTwoColumnRight
 	PageLayoutTwoPageLeft
 	PageLayoutTwoPageRight
+	PageLayoutOneColumn
 )
 
 func PageLayoutFor(s string) *PageLayout {
@@ -108,6 +109,8 @@ func PageLayoutFor(s string) *PageLayout {
 		pl = PageLayoutTwoPageLeft
 	case "twopageright":
 		pl = PageLayoutTwoPageRight
+	case "onecolumn":
+		pl = PageLayoutOneColumn
 	default:
 		return nil
 	}
@@ -129,6 +132,8 @@ func (pl *PageLayout) String() string {
 		return "TwoPageLeft"
 	case PageLayoutTwoPageRight:
 		return "TwoPageRight"
+	case PageLayoutOneColumn:
+		return "OneColumn"
 	default:
 		return "?"
 	}
@@ -331,6 +336,8 @@ type ViewerPreferences struct {
 	PrintPageRange        types.Array    // since 1.7
 	NumCopies             *types.Integer // since 1.7
 	Enforce               types.Array    // since 2.0
+	PageLayout            *PageLayout    // catalog entry
+	PageMode              *PageMode      // catalog entry
 }
 
 func (vp *ViewerPreferences) validatePrinterPreferences(version Version) error {
@@ -356,6 +363,20 @@ func (vp *ViewerPreferences) validatePrinterPreferences(version Version) error {
 	return nil
 }
 
+func (vp *ViewerPreferences) validateCatalogEntries(version Version) error {
+	if vp.PageLayout != nil && (*vp.PageLayout == PageLayoutTwoPageLeft || *vp.PageLayout == PageLayoutTwoPageRight) && version < V15 {
+		return errors.Errorf("pdfcpu: invalid page layout \"%s\" - since PDF 1.5, got: %v\n", vp.PageLayout, version)
+	}
+	if vp.PageMode != nil && *vp.PageMode == PageModeUseOC && version < V15 {
+		return errors.Errorf("pdfcpu: invalid page mode \"UseOC\" - since PDF 1.5, got: %v\n", version)
+	}
+	if vp.PageMode != nil && *vp.PageMode == PageModeUseAttachments && version < V16 {
+		return errors.Errorf("pdfcpu: invalid page mode \"UseAttachments\" - since PDF 1.6, got: %v\n", version)
+	}
+
+	return nil
+}
+
 func (vp *ViewerPreferences) Validate(version Version) error {
 	if vp.Direction != nil && version < V13 {
 		return errors.Errorf("pdfcpu: invalid viewer preference \"Direction\" - since PDF 1.3, got: %v\n", version)
@@ -373,6 +394,10 @@ func (vp *ViewerPreferences) Validate(version Version) error {
 		return errors.Errorf("pdfcpu: invalid viewer preference \"PrintClip\" - since PDF 1.4 until PDF 1.7, got: %v\n", version)
 	}
 
+	if err := vp.validateCatalogEntries(version); err != nil {
+		return err
+	}
+
 	return vp.validatePrinterPreferences(version)
 }
 
@@ -466,6 +491,12 @@ func (vp *ViewerPreferences) Populate(vp1 *ViewerPreferences) {
 	if vp1.ViewClip != nil {
 		vp.ViewClip = vp1.ViewClip
 	}
+	if vp1.PageLayout != nil {
+		vp.PageLayout = vp1.PageLayout
+	}
+	if vp1.PageMode != nil {
+		vp.PageMode = vp1.PageMode
+	}
 
 	vp.populatePrinterPreferences(vp1)
 }
@@ -496,6 +527,8 @@ func DefaultViewerPreferences(version Version) *ViewerPreferences {
 	if version >= V17 {
 		vp.SetNumCopies(1)
 	}
+	vp.PageLayout = PageLayoutFor("SinglePage")
+	vp.PageMode = PageModeFor("UseNone")
 
 	return &vp
 }
@@ -531,6 +564,8 @@ type ViewerPrefJSON struct {
 	PrintPageRange        []int    `json:"printPageRange,omitempty"`
 	NumCopies             *int     `json:"numCopies,omitempty"`
 	Enforce               []string `json:"enforce,omitempty"`
+	PageLayout            string   `json:"pageLayout,omitempty"`
+	PageMode              string   `json:"pageMode,omitempty"`
 }
 
 func (vp *ViewerPreferences) MarshalJSON() ([]byte, error) {
@@ -551,6 +586,8 @@ func (vp *ViewerPreferences) MarshalJSON() ([]byte, error) {
 		Duplex:                vp.Duplex.String(),
 		PickTrayByPDFSize:     vp.PickTrayByPDFSize,
 		NumCopies:             (*int)(vp.NumCopies),
+		PageLayout:            vp.PageLayout.String(),
+		PageMode:              vp.PageMode.String(),
 	}
 
 	if len(vp.PrintPageRange) > 0 {
@@ -678,6 +715,16 @@ func (vp *ViewerPreferences) UnmarshalJSON(data []byte) error {
 		return errors.Errorf("pdfcpu: unknown \"ViewClip\", got: %s want one of: MediaBox, CropBox, TrimBox, BleedBox, ArtBox\n", vpJSON.ViewClip)
 	}
 
+	vp.PageLayout = PageLayoutFor(vpJSON.PageLayout)
+	if vpJSON.PageLayout != "" && vp.PageLayout == nil {
+		return errors.Errorf("pdfcpu: unknown \"PageLayout\", got: %s want one of: SinglePage, OneColumn, TwoColumnLeft, TwoColumnRight, TwoPageLeft, TwoPageRight\n", vpJSON.PageLayout)
+	}
+
+	vp.PageMode = PageModeFor(vpJSON.PageMode)
+	if vpJSON.PageMode != "" && vp.PageMode == nil {
+		return errors.Errorf("pdfcpu: unknown \"PageMode\", got: %s want one of: UseNone, UseOutlines, UseThumbs, FullScreen, UseOC, UseAttachments\n", vpJSON.PageMode)
+	}
+
 	return vp.unmarshalPrinterPreferences(vpJSON)
 }
 
@@ -809,6 +856,14 @@ func (vp ViewerPreferences) List() []string {
 
 	ss = append(ss, vp.listPrinterPreferences()...)
 
+	if vp.PageLayout != nil {
+		ss = append(ss, fmt.Sprintf("%s = %s", "PageLayout", vp.PageLayout))
+	}
+
+	if vp.PageMode != nil {
+		ss = append(ss, fmt.Sprintf("%s = %s", "PageMode", vp.PageMode))
+	}
+
 	if len(ss) > 0 {
 		ss1 := []string{"Viewer preferences:"}
 		for _, s := range ss {
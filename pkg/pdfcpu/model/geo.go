@@ -0,0 +1,283 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// The coordinate system types of a geospatial measure dict as defined in 12.10 Geospatial features.
+const (
+	GeographicCoordinateSystem = "GEOGCS"
+	ProjectedCoordinateSystem  = "PROJCS"
+)
+
+var (
+	geoLinearUnits  = []string{"M", "KM", "FT", "USFT", "MI", "NM"}
+	geoAreaUnits    = []string{"SQM", "HA", "SQKM", "SQFT", "A", "SQMI"}
+	geoAngularUnits = []string{"DEG", "GRD"}
+)
+
+// defaultGeoBounds covers the complete unit square.
+var defaultGeoBounds = []float64{0, 0, 0, 1, 1, 1, 1, 0}
+
+// GeoCoordinateSystem represents a geographic or projected coordinate system
+// identified by an EPSG code and/or a well known text description.
+type GeoCoordinateSystem struct {
+	Type string // GEOGCS or PROJCS
+	EPSG int    // EPSG code, 0 for none.
+	WKT  string // OGC well known text.
+}
+
+func (gcs GeoCoordinateSystem) String() string {
+	if gcs.EPSG > 0 {
+		return fmt.Sprintf("%s EPSG:%d", gcs.Type, gcs.EPSG)
+	}
+	return fmt.Sprintf("%s %s", gcs.Type, gcs.WKT)
+}
+
+// GeoViewport represents a page viewport carrying a geospatial (GEO) measure dict.
+type GeoViewport struct {
+	Name   string              // Optional viewport name.
+	BBox   *types.Rectangle    // The viewport rectangle in default user space.
+	GCS    GeoCoordinateSystem // Coordinate system of GPTS.
+	Bounds []float64           // Optional polygon in the unit square of BBox enclosing the georeferenced region.
+	GPTS   []float64           // Control points as latitude, longitude pairs.
+	LPTS   []float64           // Control points as x, y pairs in the unit square of BBox corresponding to GPTS, defaults to Bounds.
+	PDU    []string            // Optional preferred linear, area and angular display units.
+}
+
+func validUnitSquarePairs(a []float64) bool {
+	if len(a) < 6 || len(a)%2 > 0 {
+		return false
+	}
+	for _, f := range a {
+		if f < 0 || f > 1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (vp GeoViewport) bounds() []float64 {
+	if len(vp.Bounds) > 0 {
+		return vp.Bounds
+	}
+	return defaultGeoBounds
+}
+
+func (vp GeoViewport) lpts() []float64 {
+	if len(vp.LPTS) > 0 {
+		return vp.LPTS
+	}
+	return vp.bounds()
+}
+
+func (vp GeoViewport) validateGCS() error {
+	gcs := vp.GCS
+	if gcs.Type != GeographicCoordinateSystem && gcs.Type != ProjectedCoordinateSystem {
+		return errors.Errorf("pdfcpu: geo viewport: invalid coordinate system type: %s", gcs.Type)
+	}
+	if gcs.EPSG <= 0 && gcs.WKT == "" {
+		return errors.New("pdfcpu: geo viewport: coordinate system needs EPSG code or WKT")
+	}
+	return nil
+}
+
+func (vp GeoViewport) validatePDU() error {
+	if len(vp.PDU) == 0 {
+		return nil
+	}
+	if len(vp.PDU) != 3 ||
+		!types.MemberOf(vp.PDU[0], geoLinearUnits) ||
+		!types.MemberOf(vp.PDU[1], geoAreaUnits) ||
+		!types.MemberOf(vp.PDU[2], geoAngularUnits) {
+		return errors.Errorf("pdfcpu: geo viewport: invalid preferred display units: %v", vp.PDU)
+	}
+	return nil
+}
+
+// Validate checks vp for consistency.
+func (vp GeoViewport) Validate() error {
+	if vp.BBox == nil || vp.BBox.Width() <= 0 || vp.BBox.Height() <= 0 {
+		return errors.New("pdfcpu: geo viewport: missing or empty bbox")
+	}
+
+	if err := vp.validateGCS(); err != nil {
+		return err
+	}
+
+	if len(vp.Bounds) > 0 && !validUnitSquarePairs(vp.Bounds) {
+		return errors.Errorf("pdfcpu: geo viewport: invalid bounds: %v", vp.Bounds)
+	}
+
+	if len(vp.LPTS) > 0 && !validUnitSquarePairs(vp.LPTS) {
+		return errors.Errorf("pdfcpu: geo viewport: invalid LPTS: %v", vp.LPTS)
+	}
+
+	if len(vp.GPTS) != len(vp.lpts()) {
+		return errors.Errorf("pdfcpu: geo viewport: need %d GPTS values, got %d", len(vp.lpts()), len(vp.GPTS))
+	}
+
+	for i := 0; i < len(vp.GPTS); i += 2 {
+		lat, lon := vp.GPTS[i], vp.GPTS[i+1]
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return errors.Errorf("pdfcpu: geo viewport: invalid GPTS point: %.6f %.6f", lat, lon)
+		}
+	}
+
+	return vp.validatePDU()
+}
+
+// Dict returns the viewport dict for vp.
+func (vp GeoViewport) Dict() types.Dict {
+	gcs := types.Dict{"Type": types.Name(vp.GCS.Type)}
+	if vp.GCS.EPSG > 0 {
+		gcs["EPSG"] = types.Integer(vp.GCS.EPSG)
+	}
+	if vp.GCS.WKT != "" {
+		gcs["WKT"] = types.StringLiteral(vp.GCS.WKT)
+	}
+
+	measure := types.Dict{
+		"Type":    types.Name("Measure"),
+		"Subtype": types.Name("GEO"),
+		"GCS":     gcs,
+		"GPTS":    types.NewNumberArray(vp.GPTS...),
+		"LPTS":    types.NewNumberArray(vp.lpts()...),
+	}
+	if len(vp.Bounds) > 0 {
+		measure["Bounds"] = types.NewNumberArray(vp.Bounds...)
+	}
+	if len(vp.PDU) > 0 {
+		measure["PDU"] = types.NewNameArray(vp.PDU...)
+	}
+
+	d := types.Dict{
+		"Type":    types.Name("Viewport"),
+		"BBox":    vp.BBox.Array(),
+		"Measure": measure,
+	}
+	if vp.Name != "" {
+		d["Name"] = types.StringLiteral(vp.Name)
+	}
+
+	return d
+}
+
+// affine returns the coefficients of the least squares affine transformation
+// mapping the unit square control points onto latitude and longitude.
+func (vp GeoViewport) affine() (lat, lon [3]float64, err error) {
+	lpts := vp.lpts()
+
+	var m [3][3]float64
+	var bLat, bLon [3]float64
+
+	for i := 0; i+1 < len(lpts) && i+1 < len(vp.GPTS); i += 2 {
+		p := [3]float64{lpts[i], lpts[i+1], 1}
+		for r := 0; r < 3; r++ {
+			for c := 0; c < 3; c++ {
+				m[r][c] += p[r] * p[c]
+			}
+			bLat[r] += p[r] * vp.GPTS[i]
+			bLon[r] += p[r] * vp.GPTS[i+1]
+		}
+	}
+
+	det := func(m [3][3]float64) float64 {
+		return m[0][0]*(m[1][1]*m[2][2]-m[1][2]*m[2][1]) -
+			m[0][1]*(m[1][0]*m[2][2]-m[1][2]*m[2][0]) +
+			m[0][2]*(m[1][0]*m[2][1]-m[1][1]*m[2][0])
+	}
+
+	d := det(m)
+	if math.Abs(d) < 1e-12 {
+		return lat, lon, errors.New("pdfcpu: geo viewport: degenerate control points")
+	}
+
+	// Cramer's rule
+	for c := 0; c < 3; c++ {
+		m1, m2 := m, m
+		for r := 0; r < 3; r++ {
+			m1[r][c] = bLat[r]
+			m2[r][c] = bLon[r]
+		}
+		lat[c] = det(m1) / d
+		lon[c] = det(m2) / d
+	}
+
+	return lat, lon, nil
+}
+
+// PageToGeo transforms the point (x,y) given in default user space into latitude and longitude.
+// The mapping is the affine transformation best fitting the control points of vp.
+func (vp GeoViewport) PageToGeo(x, y float64) (float64, float64, error) {
+	a, b, err := vp.affine()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	u := (x - vp.BBox.LL.X) / vp.BBox.Width()
+	v := (y - vp.BBox.LL.Y) / vp.BBox.Height()
+
+	return a[0]*u + a[1]*v + a[2], b[0]*u + b[1]*v + b[2], nil
+}
+
+// GeoToPage transforms latitude and longitude into a point given in default user space.
+func (vp GeoViewport) GeoToPage(lat, lon float64) (float64, float64, error) {
+	a, b, err := vp.affine()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	d := a[0]*b[1] - a[1]*b[0]
+	if math.Abs(d) < 1e-12 {
+		return 0, 0, errors.New("pdfcpu: geo viewport: degenerate control points")
+	}
+
+	lat, lon = lat-a[2], lon-b[2]
+	u := (lat*b[1] - a[1]*lon) / d
+	v := (a[0]*lon - lat*b[0]) / d
+
+	return vp.BBox.LL.X + u*vp.BBox.Width(), vp.BBox.LL.Y + v*vp.BBox.Height(), nil
+}
+
+func (vp GeoViewport) String() string {
+	var sb strings.Builder
+
+	if vp.Name != "" {
+		sb.WriteString(vp.Name + " ")
+	}
+	sb.WriteString(fmt.Sprintf("%s %s", vp.BBox.ShortString(), vp.GCS))
+
+	ss := []string{}
+	for i := 0; i+1 < len(vp.GPTS); i += 2 {
+		ss = append(ss, fmt.Sprintf("(%.6f %.6f)", vp.GPTS[i], vp.GPTS[i+1]))
+	}
+	sb.WriteString(" " + strings.Join(ss, " "))
+
+	if len(vp.PDU) > 0 {
+		sb.WriteString(" units: " + strings.Join(vp.PDU, " "))
+	}
+
+	return sb.String()
+}
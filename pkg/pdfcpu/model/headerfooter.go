@@ -0,0 +1,113 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	defaultHeaderFooterMargin = 36. // 0.5 inch
+	defaultHeaderFooterDate   = "2006-01-02"
+)
+
+// HeaderFooterSlots represents the left, center and right aligned text of a header or footer.
+//
+// Slot text may contain the variables:
+//
+//	%page     ... current page number
+//	%pages    ... total pages
+//	%filename ... name of the input file
+//	%date     ... current date
+//	%bookmark ... title of the bookmark covering the current page
+type HeaderFooterSlots struct {
+	Left, Center, Right string
+}
+
+// Empty returns true if no slot has any text.
+func (s HeaderFooterSlots) Empty() bool {
+	return s.Left == "" && s.Center == "" && s.Right == ""
+}
+
+func (s HeaderFooterSlots) String() string {
+	return strings.Join([]string{s.Left, s.Center, s.Right}, "|")
+}
+
+// HeaderFooter represents the command details for adding headers and footers.
+type HeaderFooter struct {
+	Header, Footer         *HeaderFooterSlots // Headers and footers for all pages.
+	OddHeader, OddFooter   *HeaderFooterSlots // Headers and footers for odd pages, override Header and Footer.
+	EvenHeader, EvenFooter *HeaderFooterSlots // Headers and footers for even pages, override Header and Footer.
+	Margin                 float64            // Distance between headers/footers and the top/bottom page edge.
+	Inset                  float64            // Distance between left/right slots and the left/right page edge.
+	DateFormat             string             // Go time layout for %date.
+	Desc                   string             // Stamp description for font, points, color etc., please refer to "pdfcpu stamp".
+	FileName               string             // Input filename for %filename.
+	InpUnit                types.DisplayUnit  // Input display unit.
+}
+
+// DefaultHeaderFooterConfig returns the default header/footer configuration.
+func DefaultHeaderFooterConfig() *HeaderFooter {
+	return &HeaderFooter{
+		Margin:     defaultHeaderFooterMargin,
+		Inset:      defaultHeaderFooterMargin,
+		DateFormat: defaultHeaderFooterDate,
+	}
+}
+
+// HeaderForPage returns the header slots for pageNr or nil.
+func (hf HeaderFooter) HeaderForPage(pageNr int) *HeaderFooterSlots {
+	if pageNr%2 == 1 && hf.OddHeader != nil {
+		return hf.OddHeader
+	}
+	if pageNr%2 == 0 && hf.EvenHeader != nil {
+		return hf.EvenHeader
+	}
+	return hf.Header
+}
+
+// FooterForPage returns the footer slots for pageNr or nil.
+func (hf HeaderFooter) FooterForPage(pageNr int) *HeaderFooterSlots {
+	if pageNr%2 == 1 && hf.OddFooter != nil {
+		return hf.OddFooter
+	}
+	if pageNr%2 == 0 && hf.EvenFooter != nil {
+		return hf.EvenFooter
+	}
+	return hf.Footer
+}
+
+func (hf HeaderFooter) String() string {
+	var sb strings.Builder
+	for _, e := range []struct {
+		name  string
+		slots *HeaderFooterSlots
+	}{
+		{"header", hf.Header}, {"footer", hf.Footer},
+		{"odd header", hf.OddHeader}, {"odd footer", hf.OddFooter},
+		{"even header", hf.EvenHeader}, {"even footer", hf.EvenFooter},
+	} {
+		if e.slots != nil {
+			fmt.Fprintf(&sb, "%s: %s\n", e.name, *e.slots)
+		}
+	}
+	fmt.Fprintf(&sb, "margin: %.2f, inset: %.2f\n", hf.Margin, hf.Inset)
+	return sb.String()
+}
@@ -62,10 +62,83 @@ type Image struct {
 	DecodeParms string
 }
 
+// ImagePlacement describes where an image is drawn on a page.
+type ImagePlacement struct {
+	Rect [4]float64 `json:"rect"` // llx, lly, urx, ury in user space
+	DPI  float64    `json:"dpi"`  // effective resolution
+}
+
+// LosslessImage is a Reader representing an image extracted without recompression.
+// Its JSON encoding serves as the image manifest.
+type LosslessImage struct {
+	io.Reader  `json:"-"`
+	FileType   string           `json:"-"`
+	File       string           `json:"file,omitempty"`
+	PageNr     int              `json:"page"`
+	ObjNr      int              `json:"objNr"`
+	Name       string           `json:"name"` // Resource name
+	Width      int              `json:"width"`
+	Height     int              `json:"height"`
+	Bpc        int              `json:"bpc"`
+	ColorSpace string           `json:"colorSpace"`
+	Comp       int              `json:"components"`
+	Filters    []string         `json:"filters"`
+	Raw        bool             `json:"raw"` // image data has been written as stored in the PDF
+	Placements []ImagePlacement `json:"placements"`
+}
+
+var imageFileExtensions = []string{".png", ".webp", ".tif", ".tiff", ".jpg", ".jpeg"}
+
+// heifBrands maps the major brands of HEIF based files to their format names.
+var heifBrands = map[string]string{
+	"heic": "HEIC",
+	"heix": "HEIC",
+	"heim": "HEIC",
+	"heis": "HEIC",
+	"mif1": "HEIF",
+	"msf1": "HEIF",
+	"avif": "AVIF",
+	"avis": "AVIF",
+}
+
+// RegisterImageFormat registers a decoder for an image format without built-in support like HEIC or AVIF
+// and adds exts to the supported image file extensions.
+// name, magic, decode and decodeConfig are passed on to image.RegisterFormat.
+// Call this during initialization, eg:
+//
+//	model.RegisterImageFormat("avif", "????ftypavif", avif.Decode, avif.DecodeConfig, ".avif")
+func RegisterImageFormat(name, magic string, decode func(io.Reader) (image.Image, error), decodeConfig func(io.Reader) (image.Config, error), exts ...string) {
+	image.RegisterFormat(name, magic, decode, decodeConfig)
+	for _, ext := range exts {
+		ext = strings.ToLower(ext)
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		if !types.MemberOf(ext, imageFileExtensions) {
+			imageFileExtensions = append(imageFileExtensions, ext)
+		}
+	}
+}
+
 // ImageFileName returns true for supported image file types.
 func ImageFileName(fileName string) bool {
 	ext := strings.ToLower(filepath.Ext(fileName))
-	return types.MemberOf(ext, []string{".png", ".webp", ".tif", ".tiff", ".jpg", ".jpeg"})
+	return types.MemberOf(ext, imageFileExtensions)
+}
+
+// decodeImageConfig decodes the image config for the image data in sniff
+// and points out unregistered HEIF based formats.
+func decodeImageConfig(sniff *bytes.Buffer) (image.Config, string, error) {
+	bb := sniff.Bytes()
+
+	c, format, err := image.DecodeConfig(sniff)
+	if err == image.ErrFormat && len(bb) >= 12 && string(bb[4:8]) == "ftyp" {
+		if f, ok := heifBrands[string(bb[8:12])]; ok {
+			err = errors.Errorf("pdfcpu: %s images need a decoder, please use model.RegisterImageFormat", f)
+		}
+	}
+
+	return c, format, err
 }
 
 // ImageFileNames returns a slice of image file names contained in dir constrained by maxFileSize.
@@ -159,6 +232,41 @@ func CreateFlateImageStreamDict(xRefTable *XRefTable, buf, sm []byte, w, h, bpc
 	return sd, nil
 }
 
+// CreateCCITTImageStreamDict returns a CCITT Group 4 encoded stream dict for rows of packed 1 bit DeviceGray samples.
+func CreateCCITTImageStreamDict(xRefTable *XRefTable, buf []byte, w, h int) (*types.StreamDict, error) {
+	parms := types.Dict(
+		map[string]types.Object{
+			"K":       types.Integer(-1),
+			"Columns": types.Integer(w),
+			"Rows":    types.Integer(h),
+		},
+	)
+
+	sd := &types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":             types.Name("XObject"),
+				"Subtype":          types.Name("Image"),
+				"Width":            types.Integer(w),
+				"Height":           types.Integer(h),
+				"BitsPerComponent": types.Integer(1),
+				"ColorSpace":       types.Name(DeviceGrayCS),
+				"DecodeParms":      parms,
+			},
+		),
+		Content:        buf,
+		FilterPipeline: []types.PDFFilter{{Name: filter.CCITTFax, DecodeParms: parms}},
+	}
+
+	sd.InsertName("Filter", filter.CCITTFax)
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return sd, nil
+}
+
 // CreateDCTImageStreamDict returns a DCT encoded stream dict.
 func CreateDCTImageStreamDict(xRefTable *XRefTable, buf []byte, w, h, bpc int, cs string) (*types.StreamDict, error) {
 	sd := &types.StreamDict{
@@ -344,6 +452,43 @@ func writeGrayImageBuf(img image.Image) []byte {
 	return buf
 }
 
+// writeBilevelImageBuf returns the samples of img packed into 1 bit per pixel
+// if img consists of opaque black and white pixels only.
+func writeBilevelImageBuf(img image.Image) ([]byte, bool) {
+	switch img := img.(type) {
+	case *image.Gray:
+	case *image.Paletted:
+		for _, c := range img.Palette {
+			r, g, b, a := c.RGBA()
+			if a != 0xFFFF || r != g || g != b || r != 0 && r != 0xFFFF {
+				return nil, false
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	w := img.Bounds().Dx()
+	h := img.Bounds().Dy()
+	rowLen := (w + 7) / 8
+	buf := make([]byte, rowLen*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			c := color.GrayModel.Convert(img.At(img.Bounds().Min.X+x, img.Bounds().Min.Y+y)).(color.Gray)
+			switch c.Y {
+			case 0:
+			case 0xFF:
+				buf[y*rowLen+x/8] |= 0x80 >> (x % 8)
+			default:
+				return nil, false
+			}
+		}
+	}
+
+	return buf, true
+}
+
 func writeGray16ImageBuf(img image.Image) []byte {
 	w := img.Bounds().Dx()
 	h := img.Bounds().Dy()
@@ -443,9 +588,12 @@ func createImageStreamDict(xRefTable *XRefTable, buf, softMask []byte, w, h, bpc
 		sd  *types.StreamDict
 		err error
 	)
-	switch format {
-	case "jpeg":
+	switch {
+	case format == "jpeg":
 		sd, err = CreateDCTImageStreamDict(xRefTable, buf, w, h, bpc, cs)
+	case bpc == 1 && softMask == nil:
+		// Bilevel images compress best using CCITT Group 4.
+		sd, err = CreateCCITTImageStreamDict(xRefTable, buf, w, h)
 	default:
 		sd, err = CreateFlateImageStreamDict(xRefTable, buf, softMask, w, h, bpc, cs)
 	}
@@ -518,6 +666,9 @@ func createImageBuf(xRefTable *XRefTable, img image.Image, format string) ([]byt
 	case *image.Gray:
 		// 8-bit grayscale color.
 		cs = DeviceGrayCS
+		if bb, ok := writeBilevelImageBuf(img); ok {
+			return bb, sm, 1, cs, nil
+		}
 		bpc = 8
 		buf = writeGrayImageBuf(img)
 
@@ -545,12 +696,18 @@ func createImageBuf(xRefTable *XRefTable, img image.Image, format string) ([]byt
 
 	case *image.Paletted:
 		// In-memory image of uint8 indices into a given palette.
+		if bb, ok := writeBilevelImageBuf(img); ok {
+			return bb, sm, 1, DeviceGrayCS, nil
+		}
 		cs = DeviceRGBCS
 		bpc = 8
 		buf, sm = writeRGBAImageBuf(convertToRGBA(img))
 
 	default:
-		return buf, sm, bpc, cs, errors.Errorf("pdfcpu: unsupported image type: %T", img)
+		// Images provided by registered decoders.
+		cs = DeviceRGBCS
+		bpc = 8
+		buf, sm = writeRGBAImageBuf(convertToRGBA(img))
 	}
 
 	return buf, sm, bpc, cs, nil
@@ -593,10 +750,18 @@ func createImageResourcesForJPEG(xRefTable *XRefTable, c image.Config, bb bytes.
 	return []ImageResource{ir}, err
 }
 
-func decodeImage(xRefTable *XRefTable, buf *bytes.Reader, currentOffset int64, gray, sepia bool, byteOrder binary.ByteOrder, imgResources *[]ImageResource) (int64, error) {
-	img, err := tiff.DecodeAt(buf, currentOffset)
+func tiffImageStreamDict(xRefTable *XRefTable, bb []byte, ifd *tiffIFD, off int64, gray, sepia bool) (*types.StreamDict, int, int, error) {
+	w, h := int(ifd.val(tiffImageWidth, 0)), int(ifd.val(tiffImageLength, 0))
+
+	// Pass through CCITT Group 4 encoded bilevel images.
+	if data, ok := ifd.ccittG4Data(bb); ok && w > 0 && h > 0 {
+		sd, err := createCCITTG4ImageStreamDict(data, w, h)
+		return sd, w, h, err
+	}
+
+	img, err := tiff.DecodeAt(bytes.NewReader(bb), off)
 	if err != nil {
-		return 0, err
+		return nil, 0, 0, err
 	}
 
 	if gray {
@@ -617,84 +782,64 @@ func decodeImage(xRefTable *XRefTable, buf *bytes.Reader, currentOffset int64, g
 
 	imgBuf, softMask, bpc, cs, err := createImageBuf(xRefTable, img, "tiff")
 	if err != nil {
-		return 0, err
+		return nil, 0, 0, err
 	}
 
-	w, h := img.Bounds().Dx(), img.Bounds().Dy()
+	w, h = img.Bounds().Dx(), img.Bounds().Dy()
 
 	sd, err := createImageStreamDict(xRefTable, imgBuf, softMask, w, h, bpc, "tiff", cs)
-	if err != nil {
-		return 0, err
-	}
-
-	indRef, err := xRefTable.IndRefForNewObject(*sd)
-	if err != nil {
-		return 0, err
-	}
-
-	res := Resource{ID: "Im0", IndRef: indRef}
-	ir := ImageResource{Res: res, Width: w, Height: h}
-	*imgResources = append(*imgResources, ir)
-
-	if _, err := buf.Seek(currentOffset, io.SeekStart); err != nil {
-		return 0, err
-	}
-
-	var numEntries uint16
-	if err := binary.Read(buf, byteOrder, &numEntries); err != nil {
-		return 0, err
-	}
-
-	if _, err := buf.Seek(int64(numEntries)*12, io.SeekCurrent); err != nil {
-		return 0, err
-	}
 
-	var nextIFDOffset uint32
-	if err := binary.Read(buf, byteOrder, &nextIFDOffset); err != nil {
-		return 0, err
-	}
-
-	// if nextIFDOffset >= uint32(bb.Len()) {
-	// 	fmt.Println("Invalid next IFD offset, stopping.")
-	// 	break
-	// }
-
-	return int64(nextIFDOffset), nil
+	return sd, w, h, err
 }
 
+// createImageResourcesForTIFF creates an image resource for each image file directory.
 func createImageResourcesForTIFF(xRefTable *XRefTable, bb bytes.Buffer, gray, sepia bool) ([]ImageResource, error) {
-	imgResources := []ImageResource{}
-
-	buf := bytes.NewReader(bb.Bytes())
-
-	var header [8]byte
-	if _, err := io.ReadFull(buf, header[:]); err != nil {
-		return nil, err
+	buf := bb.Bytes()
+	if len(buf) < 8 {
+		return nil, errTIFFCorrupt
 	}
 
 	var byteOrder binary.ByteOrder
-	if string(header[:2]) == "II" {
+	switch string(buf[:2]) {
+	case "II":
 		byteOrder = binary.LittleEndian
-	} else if string(header[:2]) == "MM" {
+	case "MM":
 		byteOrder = binary.BigEndian
-	} else {
+	default:
 		return nil, fmt.Errorf("invalid TIFF byte order")
 	}
 
-	firstIFDOffset := byteOrder.Uint32(header[4:])
-	if firstIFDOffset < 8 || firstIFDOffset >= uint32(bb.Len()) {
+	off := int64(byteOrder.Uint32(buf[4:]))
+	if off < 8 || off >= int64(len(buf)) {
 		return nil, fmt.Errorf("invalid TIFF file: no valid IFD")
 	}
 
-	var err error
+	imgResources := []ImageResource{}
+	visited := map[int64]bool{}
+
+	for off != 0 && off < int64(len(buf)) && !visited[off] {
+		visited[off] = true
 
-	off := int64(firstIFDOffset)
+		ifd, err := parseTIFFIFD(buf, off, byteOrder)
+		if err != nil {
+			return nil, err
+		}
 
-	for off != 0 && off < int64(bb.Len()) {
-		off, err = decodeImage(xRefTable, buf, off, gray, sepia, byteOrder, &imgResources)
+		sd, w, h, err := tiffImageStreamDict(xRefTable, buf, ifd, off, gray, sepia)
 		if err != nil {
 			return nil, err
 		}
+
+		indRef, err := xRefTable.IndRefForNewObject(*sd)
+		if err != nil {
+			return nil, err
+		}
+
+		ir := ImageResource{Res: Resource{ID: "Im0", IndRef: indRef}, Width: w, Height: h}
+		ir.DPIX, ir.DPIY = ifd.resolution()
+		imgResources = append(imgResources, ir)
+
+		off = ifd.next
 	}
 
 	return imgResources, nil
@@ -758,7 +903,7 @@ func CreateImageResources(xRefTable *XRefTable, r io.Reader, gray, sepia bool) (
 		return nil, err
 	}
 
-	c, format, err := image.DecodeConfig(&sniff)
+	c, format, err := decodeImageConfig(&sniff)
 	if err != nil {
 		return nil, err
 	}
@@ -785,7 +930,7 @@ func CreateImageStreamDict(xRefTable *XRefTable, r io.Reader) (*types.StreamDict
 		return nil, 0, 0, err
 	}
 
-	c, format, err := image.DecodeConfig(&sniff)
+	c, format, err := decodeImageConfig(&sniff)
 	if err != nil {
 		return nil, 0, 0, err
 	}
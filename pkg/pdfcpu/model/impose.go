@@ -0,0 +1,132 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ImpositionCell represents a single cell of a user defined imposition layout.
+//
+// Page is a formula yielding the 1-based index into the selected pages rendered into this cell.
+// A formula is an integer expression using +, -, *, /, %, parentheses and the variables:
+//
+//	i ... sheet number starting with 0
+//	n ... number of selected pages rounded up to a multiple of the pages per sheet
+//	p ... pages per sheet
+//
+// Page numbers outside of the selected range result in a blank cell.
+type ImpositionCell struct {
+	Row    int     `json:"row"`    // Grid row starting with 0 at the top.
+	Col    int     `json:"col"`    // Grid column starting with 0 at the left.
+	Page   string  `json:"page"`   // Page number formula, eg. "n-2*i".
+	Rotate int     `json:"rotate"` // Counter clockwise rotation of the cell content: 0, 90, 180, 270
+	DX     float64 `json:"dx"`     // Horizontal cell offset in display unit.
+	DY     float64 `json:"dy"`     // Vertical cell offset in display unit.
+}
+
+// ImpositionSide represents one sheet side of a user defined imposition layout.
+type ImpositionSide struct {
+	Cells []ImpositionCell `json:"cells"`
+}
+
+// ImpositionTemplate represents a user defined imposition layout
+// for impositions not covered by nup or booklet eg. calendars, gatefolds or 3-panel brochures.
+type ImpositionTemplate struct {
+	Name          string           `json:"name"`
+	PaperSize     string           `json:"paperSize"`     // Sheet size eg. A4L, Letter.
+	Dimensions    []float64        `json:"dimensions"`    // Sheet dimensions (width, height) in display unit, overrides paperSize.
+	Unit          string           `json:"unit"`          // Display unit: points, inches, cm, mm
+	Rows          int              `json:"rows"`          // Grid rows.
+	Cols          int              `json:"cols"`          // Grid columns.
+	ColWidths     []float64        `json:"colWidths"`     // Optional relative column widths eg. [1, 1, 0.97]
+	RowHeights    []float64        `json:"rowHeights"`    // Optional relative row heights.
+	Margin        float64          `json:"margin"`        // Cell content margin in display unit.
+	Border        bool             `json:"border"`        // Draw cell borders.
+	Marks         string           `json:"marks"`         // Printer's marks, see ParsePrintersMarks.
+	PagesPerSheet int              `json:"pagesPerSheet"` // Defaults to the number of cells of all sides.
+	Sides         []ImpositionSide `json:"sides"`         // Sheet sides, eg. front and back.
+
+	PageDim  *types.Dim        `json:"-"` // Resolved sheet dimensions in points.
+	InpUnit  types.DisplayUnit `json:"-"` // Resolved display unit.
+	FileName string            `json:"-"` // Input filename used for the slug line.
+}
+
+// CellCount returns the number of cells over all sides.
+func (t ImpositionTemplate) CellCount() int {
+	c := 0
+	for _, side := range t.Sides {
+		c += len(side.Cells)
+	}
+	return c
+}
+
+// PageCountPerSheet returns the number of pages imposed onto one sheet.
+func (t ImpositionTemplate) PageCountPerSheet() int {
+	if t.PagesPerSheet > 0 {
+		return t.PagesPerSheet
+	}
+	return t.CellCount()
+}
+
+func (t ImpositionTemplate) String() string {
+	return fmt.Sprintf("Imposition template %q: %s %dx%d, sides=%d, pagesPerSheet=%d\n",
+		t.Name, *t.PageDim, t.Cols, t.Rows, len(t.Sides), t.PageCountPerSheet())
+}
+
+func relativeSizes(weights []float64, n int, total float64) []float64 {
+	ww := weights
+	if len(ww) != n {
+		ww = make([]float64, n)
+		for i := range ww {
+			ww[i] = 1
+		}
+	}
+	sum := 0.
+	for _, w := range ww {
+		sum += w
+	}
+	ss := make([]float64, n)
+	for i, w := range ww {
+		ss[i] = w / sum * total
+	}
+	return ss
+}
+
+// CellRect returns the sheet region for cell c.
+func (t ImpositionTemplate) CellRect(c ImpositionCell) *types.Rectangle {
+	ww := relativeSizes(t.ColWidths, t.Cols, t.PageDim.Width)
+	hh := relativeSizes(t.RowHeights, t.Rows, t.PageDim.Height)
+
+	llx := 0.
+	for i := 0; i < c.Col; i++ {
+		llx += ww[i]
+	}
+
+	// Row 0 is at the top of the sheet.
+	lly := 0.
+	for i := t.Rows - 1; i > c.Row; i-- {
+		lly += hh[i]
+	}
+
+	dx := types.ToUserSpace(c.DX, t.InpUnit)
+	dy := types.ToUserSpace(c.DY, t.InpUnit)
+
+	return types.NewRectangle(llx+dx, lly+dy, llx+dx+ww[c.Col], lly+dy+hh[c.Row])
+}
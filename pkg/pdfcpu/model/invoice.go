@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "fmt"
+
+// InvoiceProfiles maps Factur-X / ZUGFeRD profiles to XMP conformance levels.
+var InvoiceProfiles = map[string]string{
+	"minimum":   "MINIMUM",
+	"basicwl":   "BASIC WL",
+	"basic":     "BASIC",
+	"en16931":   "EN 16931",
+	"extended":  "EXTENDED",
+	"xrechnung": "XRECHNUNG",
+}
+
+// AFRelationships lists the valid relationships of an associated file to the document (14.13 Associated Files).
+var AFRelationships = []string{"Source", "Data", "Alternative", "Supplement", "Unspecified"}
+
+// Invoice represents the command details for embedding an XML invoice into a PDF (Factur-X / ZUGFeRD hybrid invoice).
+type Invoice struct {
+	Profile      string // Factur-X profile, detected from the XML if empty.
+	FileName     string // Name of the embedded file, defaults to factur-x.xml or xrechnung.xml.
+	Relationship string // AFRelationship, defaults to Data for profiles minimum and basicwl, Alternative otherwise.
+	Desc         string // Description of the embedded file.
+	Version      string // Factur-X version.
+}
+
+// DefaultInvoiceConfig returns the default configuration for embedding an XML invoice.
+func DefaultInvoiceConfig() *Invoice {
+	return &Invoice{Desc: "Factur-X invoice", Version: "1.0"}
+}
+
+// DefaultFileName returns the prescribed name of the embedded XML for inv's profile.
+func (inv Invoice) DefaultFileName() string {
+	if inv.Profile == "xrechnung" {
+		return "xrechnung.xml"
+	}
+	return "factur-x.xml"
+}
+
+// DefaultRelationship returns the prescribed AFRelationship for inv's profile.
+func (inv Invoice) DefaultRelationship() string {
+	if inv.Profile == "minimum" || inv.Profile == "basicwl" {
+		return "Data"
+	}
+	return "Alternative"
+}
+
+func (inv Invoice) String() string {
+	return fmt.Sprintf("Invoice conf: profile=%s, file=%s, relationship=%s, version=%s\n",
+		inv.Profile, inv.FileName, inv.Relationship, inv.Version)
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Layer represents an optional content group.
+type Layer struct {
+	ObjNr   int
+	Name    string
+	Visible bool     // Default visibility as configured by the default viewing configuration.
+	Locked  bool     // The user interface should not allow changing the visibility.
+	Intent  []string // View and/or Design.
+}
+
+func (l Layer) String() string {
+	state := "visible"
+	if !l.Visible {
+		state = "hidden"
+	}
+	if l.Locked {
+		state += ", locked"
+	}
+	s := fmt.Sprintf("%s (%s)", l.Name, state)
+	if len(l.Intent) > 0 && !(len(l.Intent) == 1 && l.Intent[0] == "View") {
+		s += " intent: " + strings.Join(l.Intent, ",")
+	}
+	return s
+}
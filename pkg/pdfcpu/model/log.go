@@ -0,0 +1,247 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+)
+
+var commandModeNames = map[CommandMode]string{
+	VALIDATE:                "validate",
+	LISTINFO:                "listinfo",
+	OPTIMIZE:                "optimize",
+	SPLIT:                   "split",
+	SPLITBYPAGENR:           "splitbypagenr",
+	MERGECREATE:             "mergecreate",
+	MERGECREATEZIP:          "mergecreatezip",
+	MERGEAPPEND:             "mergeappend",
+	EXTRACTIMAGES:           "extractimages",
+	EXTRACTFONTS:            "extractfonts",
+	EXTRACTPAGES:            "extractpages",
+	EXTRACTCONTENT:          "extractcontent",
+	EXTRACTMETADATA:         "extractmetadata",
+	TRIM:                    "trim",
+	LISTATTACHMENTS:         "listattachments",
+	EXTRACTATTACHMENTS:      "extractattachments",
+	ADDATTACHMENTS:          "addattachments",
+	ADDATTACHMENTSPORTFOLIO: "addattachmentsportfolio",
+	REMOVEATTACHMENTS:       "removeattachments",
+	LISTPERMISSIONS:         "listpermissions",
+	SETPERMISSIONS:          "setpermissions",
+	ADDWATERMARKS:           "addwatermarks",
+	REMOVEWATERMARKS:        "removewatermarks",
+	IMPORTIMAGES:            "importimages",
+	INSERTPAGESBEFORE:       "insertpagesbefore",
+	INSERTPAGESAFTER:        "insertpagesafter",
+	REMOVEPAGES:             "removepages",
+	LISTKEYWORDS:            "listkeywords",
+	ADDKEYWORDS:             "addkeywords",
+	REMOVEKEYWORDS:          "removekeywords",
+	LISTPROPERTIES:          "listproperties",
+	ADDPROPERTIES:           "addproperties",
+	REMOVEPROPERTIES:        "removeproperties",
+	COLLECT:                 "collect",
+	CROP:                    "crop",
+	LISTBOXES:               "listboxes",
+	ADDBOXES:                "addboxes",
+	REMOVEBOXES:             "removeboxes",
+	LISTANNOTATIONS:         "listannotations",
+	ADDANNOTATIONS:          "addannotations",
+	REMOVEANNOTATIONS:       "removeannotations",
+	ROTATE:                  "rotate",
+	NUP:                     "nup",
+	BOOKLET:                 "booklet",
+	LISTBOOKMARKS:           "listbookmarks",
+	ADDBOOKMARKS:            "addbookmarks",
+	REMOVEBOOKMARKS:         "removebookmarks",
+	IMPORTBOOKMARKS:         "importbookmarks",
+	EXPORTBOOKMARKS:         "exportbookmarks",
+	LISTIMAGES:              "listimages",
+	UPDATEIMAGES:            "updateimages",
+	CREATE:                  "create",
+	DUMP:                    "dump",
+	LISTFORMFIELDS:          "listformfields",
+	REMOVEFORMFIELDS:        "removeformfields",
+	LOCKFORMFIELDS:          "lockformfields",
+	UNLOCKFORMFIELDS:        "unlockformfields",
+	RESETFORMFIELDS:         "resetformfields",
+	EXPORTFORMFIELDS:        "exportformfields",
+	FILLFORMFIELDS:          "fillformfields",
+	MULTIFILLFORMFIELDS:     "multifillformfields",
+	ENCRYPT:                 "encrypt",
+	DECRYPT:                 "decrypt",
+	CHANGEUPW:               "changeupw",
+	CHANGEOPW:               "changeopw",
+	CHEATSHEETSFONTS:        "cheatsheetsfonts",
+	INSTALLFONTS:            "installfonts",
+	LISTFONTS:               "listfonts",
+	RESIZE:                  "resize",
+	POSTER:                  "poster",
+	NDOWN:                   "ndown",
+	CUT:                     "cut",
+	LISTPAGELAYOUT:          "listpagelayout",
+	SETPAGELAYOUT:           "setpagelayout",
+	RESETPAGELAYOUT:         "resetpagelayout",
+	LISTPAGEMODE:            "listpagemode",
+	SETPAGEMODE:             "setpagemode",
+	RESETPAGEMODE:           "resetpagemode",
+	LISTVIEWERPREFERENCES:   "listviewerpreferences",
+	SETVIEWERPREFERENCES:    "setviewerpreferences",
+	RESETVIEWERPREFERENCES:  "resetviewerpreferences",
+	ZOOM:                    "zoom",
+	ADDSIGNATURE:            "addsignature",
+	VALIDATESIGNATURE:       "validatesignature",
+	LISTCERTIFICATES:        "listcertificates",
+	INSPECTCERTIFICATES:     "inspectcertificates",
+	IMPORTCERTIFICATES:      "importcertificates",
+	VALIDATESIGNATURES:      "validatesignatures",
+	IMPOSE:                  "impose",
+	MANUALDUPLEX:            "manualduplex",
+	CREATECOVER:             "createcover",
+	BATES:                   "bates",
+	HEADERFOOTER:            "headerfooter",
+	LISTPAGELABELS:          "listpagelabels",
+	SETPAGELABELS:           "setpagelabels",
+	REMOVEPAGELABELS:        "removepagelabels",
+	LISTNAMEDDESTS:          "listnameddests",
+	ADDNAMEDDESTS:           "addnameddests",
+	REMOVENAMEDDESTS:        "removenameddests",
+	RETARGETNAMEDDESTS:      "retargetnameddests",
+	ADDAUTOLINKS:            "addautolinks",
+	DETECTBOOKMARKS:         "detectbookmarks",
+	ADDTOC:                  "addtoc",
+	CREATEPORTFOLIO:         "createportfolio",
+	ADDINVOICE:              "addinvoice",
+	LISTMETADATA:            "listmetadata",
+	SETMETADATA:             "setmetadata",
+	CHECKMETADATA:           "checkmetadata",
+	SYNCMETADATA:            "syncmetadata",
+	CONVERTCMYK:             "convertcmyk",
+	CONVERTGRAY:             "convertgray",
+	LISTOUTPUTINTENTS:       "listoutputintents",
+	ADDOUTPUTINTENT:         "addoutputintent",
+	REPLACEOUTPUTINTENT:     "replaceoutputintent",
+	FLATTENTRANSPARENCY:     "flattentransparency",
+	LISTLAYERS:              "listlayers",
+	SHOWLAYERS:              "showlayers",
+	HIDELAYERS:              "hidelayers",
+	REMOVELAYERS:            "removelayers",
+	FLATTENLAYERS:           "flattenlayers",
+	EDITCONTENT:             "editcontent",
+	EXTRACTVECTORPATHS:      "extractvectorpaths",
+	REPAIR:                  "repair",
+	COMPARE:                 "compare",
+	ANALYZEPAGES:            "analyzepages",
+	SANITIZE:                "sanitize",
+	EXTRACTIMAGESLOSSLESS:   "extractimageslossless",
+	ADDOCRTEXT:              "addocrtext",
+	EXTRACTTABLES:           "extracttables",
+	EXPORTANNOTATIONS:       "exportannotations",
+	IMPORTANNOTATIONS:       "importannotations",
+	FLATTENANNOTATIONS:      "flattenannotations",
+	LISTPAGETRANSITIONS:     "listpagetransitions",
+	SETPAGETRANSITIONS:      "setpagetransitions",
+	REMOVEPAGETRANSITIONS:   "removepagetransitions",
+	SETFULLSCREEN:           "setfullscreen",
+	LISTACTIONS:             "listactions",
+	SETACTIONS:              "setactions",
+	REMOVEACTIONS:           "removeactions",
+	LISTGEOVIEWPORTS:        "listgeoviewports",
+	ADDGEOVIEWPORTS:         "addgeoviewports",
+	REMOVEGEOVIEWPORTS:      "removegeoviewports",
+	LISTARTICLES:            "listarticles",
+	ADDARTICLES:             "addarticles",
+	AUTOCROP:                "autocrop",
+	BLEED:                   "bleed",
+	AUTOROTATE:              "autorotate",
+	PIPELINE:                "pipeline",
+}
+
+func (c CommandMode) String() string {
+	if s, ok := commandModeNames[c]; ok {
+		return s
+	}
+	return fmt.Sprintf("cmd%d", int(c))
+}
+
+// slogger returns the structured logger configured for the command being executed, nil if unset.
+// A nil xRefTable logs to the global loggers.
+func (xRefTable *XRefTable) slogger() *slog.Logger {
+	if xRefTable == nil || xRefTable.Conf == nil {
+		return nil
+	}
+	return xRefTable.Conf.Logger
+}
+
+// LogEnabled returns true if records of level get logged for xRefTable.
+func (xRefTable *XRefTable) LogEnabled(level slog.Level) bool {
+	if l := xRefTable.slogger(); l != nil {
+		return l.Enabled(context.Background(), level)
+	}
+	if level < slog.LevelInfo {
+		return log.DebugEnabled()
+	}
+	return log.InfoEnabled()
+}
+
+// Log emits a record of level carrying the current operation, the input file name and the key value pairs args
+// to the structured logger of the configuration in effect.
+// Without a configured structured logger the record is written to the global Debug or Info logger.
+func (xRefTable *XRefTable) Log(level slog.Level, msg string, args ...any) {
+	if l := xRefTable.slogger(); l != nil {
+		if !l.Enabled(context.Background(), level) {
+			return
+		}
+		attrs := []any{log.KeyOp, xRefTable.Conf.Cmd.String()}
+		if xRefTable.fileName != "" {
+			attrs = append(attrs, log.KeyFile, xRefTable.fileName)
+		}
+		l.Log(context.Background(), level, msg, append(attrs, args...)...)
+		return
+	}
+
+	if !xRefTable.LogEnabled(level) {
+		return
+	}
+
+	var sb strings.Builder
+	sb.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&sb, " %v=%v", args[i], args[i+1])
+	}
+
+	if level < slog.LevelInfo {
+		log.Debug.Println(sb.String())
+		return
+	}
+	log.Info.Println(sb.String())
+}
+
+// LogDebug emits a debug record for xRefTable, see Log.
+func (xRefTable *XRefTable) LogDebug(msg string, args ...any) {
+	xRefTable.Log(slog.LevelDebug, msg, args...)
+}
+
+// LogInfo emits an info record for xRefTable, see Log.
+func (xRefTable *XRefTable) LogInfo(msg string, args ...any) {
+	xRefTable.Log(slog.LevelInfo, msg, args...)
+}
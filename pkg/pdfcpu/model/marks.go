@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	markLength = 12. // Length of crop and fold marks.
+	markOffset = 3.  // Distance between a trim box corner and its crop marks.
+	regRadius  = 5.  // Radius of a registration target.
+)
+
+// PrintersMarks represents the printer's marks rendered outside the cell areas of an n-up or booklet sheet.
+type PrintersMarks struct {
+	Crop         bool   // Draw corner crop marks at the trim box of each cell.
+	Registration bool   // Draw registration targets centered along the sheet edges.
+	Fold         bool   // Draw fold/score marks at the sheet edges between cells.
+	Slug         bool   // Draw a slug line with filename and date.
+	FileName     string // Filename used for the slug line.
+}
+
+// Any returns true if at least one kind of printer's mark is enabled.
+func (pm PrintersMarks) Any() bool {
+	return pm.Crop || pm.Registration || pm.Fold || pm.Slug
+}
+
+func (pm PrintersMarks) String() string {
+	ss := []string{}
+	if pm.Crop {
+		ss = append(ss, "crop")
+	}
+	if pm.Registration {
+		ss = append(ss, "reg")
+	}
+	if pm.Fold {
+		ss = append(ss, "fold")
+	}
+	if pm.Slug {
+		ss = append(ss, "slug")
+	}
+	return strings.Join(ss, " ")
+}
+
+// ParsePrintersMarks parses a space separated list of marks: crop, reg, fold, slug, all.
+func ParsePrintersMarks(s string) (*PrintersMarks, error) {
+	pm := &PrintersMarks{}
+
+	for _, v := range strings.Fields(strings.ToLower(s)) {
+		switch v {
+		case "off", "false", "f":
+			return nil, nil
+		case "all", "on", "true", "t":
+			pm.Crop, pm.Registration, pm.Fold, pm.Slug = true, true, true, true
+		case "crop":
+			pm.Crop = true
+		case "reg", "registration":
+			pm.Registration = true
+		case "fold":
+			pm.Fold = true
+		case "slug":
+			pm.Slug = true
+		default:
+			return nil, errors.Errorf("pdfcpu: unknown printer's mark: %s, please provide any of: crop reg fold slug (or all)", v)
+		}
+	}
+
+	if !pm.Any() {
+		return nil, nil
+	}
+
+	return pm, nil
+}
+
+func drawCropMarks(w io.Writer, r *types.Rectangle) {
+	// Horizontal marks extend left and right, vertical marks extend up and down from each corner.
+	for _, x := range []float64{r.LL.X, r.UR.X} {
+		dx := -markOffset
+		if x == r.UR.X {
+			dx = markOffset
+		}
+		for _, y := range []float64{r.LL.Y, r.UR.Y} {
+			dy := -markOffset
+			if y == r.UR.Y {
+				dy = markOffset
+			}
+			draw.DrawLineSimple(w, x+dx, y, x+dx+sign(dx)*markLength, y)
+			draw.DrawLineSimple(w, x, y+dy, x, y+dy+sign(dy)*markLength)
+		}
+	}
+}
+
+func sign(f float64) float64 {
+	if f < 0 {
+		return -1
+	}
+	return 1
+}
+
+func drawRegistrationTarget(w io.Writer, x, y float64) {
+	draw.DrawCircle(w, x, y, regRadius, color.Black, nil)
+	draw.DrawCircle(w, x, y, regRadius/2, color.Black, nil)
+	draw.DrawLineSimple(w, x-regRadius-2, y, x+regRadius+2, y)
+	draw.DrawLineSimple(w, x, y-regRadius-2, x, y+regRadius+2)
+}
+
+func drawRegistrationMarks(w io.Writer, mb *types.Rectangle) {
+	d := regRadius + 2
+	cx, cy := mb.Width()/2, mb.Height()/2
+	drawRegistrationTarget(w, cx, d)
+	drawRegistrationTarget(w, cx, mb.Height()-d)
+	drawRegistrationTarget(w, d, cy)
+	drawRegistrationTarget(w, mb.Width()-d, cy)
+}
+
+func cellEdges(cells []*types.Rectangle, mb *types.Rectangle) ([]float64, []float64) {
+	// Collect the inner vertical and horizontal cell boundaries.
+	xx, yy := map[float64]bool{}, map[float64]bool{}
+	for _, r := range cells {
+		for _, x := range []float64{r.LL.X, r.UR.X} {
+			if x > 1 && x < mb.Width()-1 {
+				xx[math.Round(x*100)/100] = true
+			}
+		}
+		for _, y := range []float64{r.LL.Y, r.UR.Y} {
+			if y > 1 && y < mb.Height()-1 {
+				yy[math.Round(y*100)/100] = true
+			}
+		}
+	}
+	keys := func(m map[float64]bool) []float64 {
+		ff := []float64{}
+		for f := range m {
+			ff = append(ff, f)
+		}
+		sort.Float64s(ff)
+		return ff
+	}
+	return keys(xx), keys(yy)
+}
+
+func drawFoldMarks(w io.Writer, cells []*types.Rectangle, mb *types.Rectangle) {
+	fmt.Fprint(w, "[2 2] 0 d ")
+
+	xx, yy := cellEdges(cells, mb)
+
+	for _, x := range xx {
+		draw.DrawLineSimple(w, x, 0, x, markLength)
+		draw.DrawLineSimple(w, x, mb.Height()-markLength, x, mb.Height())
+	}
+
+	for _, y := range yy {
+		draw.DrawLineSimple(w, 0, y, markLength, y)
+		draw.DrawLineSimple(w, mb.Width()-markLength, y, mb.Width(), y)
+	}
+
+	fmt.Fprint(w, "[] 0 d ")
+}
+
+func drawSlug(w io.Writer, pm *PrintersMarks, mb *types.Rectangle, fm FontMap) {
+	s := time.Now().Format("2006-01-02 15:04")
+	if pm.FileName != "" {
+		s = pm.FileName + "  " + s
+	}
+	fontName := "Helvetica"
+	td := TextDescriptor{
+		FontName:  fontName,
+		FontKey:   fm.EnsureKey(fontName),
+		FontSize:  6,
+		Scale:     1.0,
+		ScaleAbs:  true,
+		StrokeCol: color.Black,
+		FillCol:   color.Black,
+		X:         markLength + 2*markOffset,
+		Y:         2,
+		Text:      s,
+	}
+	WriteMultiLine(nil, w, mb, nil, td)
+}
+
+// DrawPrintersMarks draws the printer's marks enabled for nup.
+// Marks are drawn into the cell margins and along the sheet edges, so they are best combined with a margin > 0.
+func DrawPrintersMarks(nup *NUp, w io.Writer, fm FontMap) {
+	if nup.Marks == nil {
+		return
+	}
+	mb := types.RectForDim(nup.PageDim.Width, nup.PageDim.Height)
+	DrawPrintersMarksForCells(w, nup.Marks, mb, nup.RectsForGrid(), nup.Margin, fm)
+}
+
+// DrawPrintersMarksForCells draws the printer's marks pm for a sheet mb made up of cells.
+// The trim box of a cell is the cell cropped by margin.
+func DrawPrintersMarksForCells(w io.Writer, pm *PrintersMarks, mb *types.Rectangle, cells []*types.Rectangle, margin float64, fm FontMap) {
+	if pm == nil || !pm.Any() {
+		return
+	}
+
+	fmt.Fprint(w, "q [] 0 d ")
+	draw.SetLineWidth(w, 0.25)
+	draw.SetStrokeColor(w, color.Black)
+
+	if pm.Crop {
+		for _, r := range cells {
+			drawCropMarks(w, r.CroppedCopy(margin))
+		}
+	}
+
+	if pm.Registration {
+		drawRegistrationMarks(w, mb)
+	}
+
+	if pm.Fold {
+		drawFoldMarks(w, cells, mb)
+	}
+
+	fmt.Fprint(w, "Q ")
+
+	if pm.Slug {
+		drawSlug(w, pm, mb, fm)
+	}
+}
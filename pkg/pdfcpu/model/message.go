@@ -58,4 +58,5 @@ func ShowDigestedSpecViolation(msg string) {
 func ShowDigestedSpecViolationError(xRefTable *XRefTable, err error) {
 	msg := fmt.Sprintf("spec violation around obj#(%d): %v\n", xRefTable.CurObj, err)
 	ShowMsgTopic("digested", msg)
+	xRefTable.ValidationWarning(err.Error())
 }
@@ -20,7 +20,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
@@ -134,10 +133,8 @@ func (m NameMap) Add(k string, d types.Dict) {
 	m[k] = append(dd, d)
 }
 
-func (n *Node) insertIntoLeaf(k string, v types.Object, m NameMap) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("Insert k:%s in the middle\n", k)
-	}
+func (n *Node) insertIntoLeaf(xRefTable *XRefTable, k string, v types.Object, m NameMap) error {
+	xRefTable.LogDebug("insertIntoLeaf: insert in the middle", "key", k)
 	for i, e := range n.Names {
 		if keyLess(e.k, k) {
 			continue
@@ -151,9 +148,7 @@ func (n *Node) insertIntoLeaf(k string, v types.Object, m NameMap) error {
 		n.Names[i] = entry{k, v}
 		return nil
 	}
-	if log.DebugEnabled() {
-		log.Debug.Printf("Insert k:%s at end\n", k)
-	}
+	xRefTable.LogDebug("insertIntoLeaf: insert at end", "key", k)
 	n.Kmax = k
 	n.Names = append(n.Names, entry{k, v})
 	return nil
@@ -189,11 +184,11 @@ func updateNameRefDicts(dd []types.Dict, nameRefDictKeys []string, nameOld, name
 	return nil
 }
 
-func (n *Node) insertUniqueIntoLeaf(k string, v types.Object, m NameMap, nameRefDictKeys []string) (bool, error) {
+func (n *Node) insertUniqueIntoLeaf(xRefTable *XRefTable, k string, v types.Object, m NameMap, nameRefDictKeys []string) (bool, error) {
 	var err error
 	kOrig := k
 	for first := true; first || err == errNameTreeDuplicateKey; first = false {
-		err = n.insertIntoLeaf(k, v, m)
+		err = n.insertIntoLeaf(xRefTable, k, v, m)
 		if err == nil {
 			break
 		}
@@ -227,35 +222,27 @@ func (n *Node) HandleLeaf(xRefTable *XRefTable, k string, v types.Object, m Name
 	if len(n.Names) == 0 {
 		n.Names = append(n.Names, entry{k, v})
 		n.Kmin, n.Kmax = k, k
-		if log.DebugEnabled() {
-			log.Debug.Printf("first key=%s\n", k)
-		}
+		xRefTable.LogDebug("HandleLeaf: first key", "key", k)
 		return nil
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("kmin=%s kmax=%s\n", n.Kmin, n.Kmax)
-	}
+	xRefTable.LogDebug("HandleLeaf", "kmin", n.Kmin, "kmax", n.Kmax)
 
 	if keyLess(k, n.Kmin) {
 		// Prepend (k,v).
-		if log.DebugEnabled() {
-			log.Debug.Printf("Insert k:%s at beginning\n", k)
-		}
+		xRefTable.LogDebug("HandleLeaf: insert at beginning", "key", k)
 		n.Kmin = k
 		n.Names = append(n.Names, entry{})
 		copy(n.Names[1:], n.Names[0:])
 		n.Names[0] = entry{k, v}
 	} else if keyLess(n.Kmax, k) {
 		// Append (k,v).
-		if log.DebugEnabled() {
-			log.Debug.Printf("Insert k:%s at end\n", k)
-		}
+		xRefTable.LogDebug("HandleLeaf: insert at end", "key", k)
 		n.Kmax = k
 		n.Names = append(n.Names, entry{k, v})
 	} else {
 		// Insert (k,v) while ensuring unique k.
-		ok, err := n.insertUniqueIntoLeaf(k, v, m, nameRefDictKeys)
+		ok, err := n.insertUniqueIntoLeaf(xRefTable, k, v, m, nameRefDictKeys)
 		if err != nil {
 			return err
 		}
@@ -355,9 +342,7 @@ func (n *Node) removeFromNames(xRefTable *XRefTable, k string) (ok bool, err err
 
 			if xRefTable != nil {
 				// Remove object graph of value.
-				if log.DebugEnabled() {
-					log.Debug.Println("removeFromNames: deleting object graph of v")
-				}
+				xRefTable.LogDebug("removeFromNames: deleting object graph of v")
 				if err := xRefTable.DeleteObjectGraph(v.v); err != nil {
 					return false, err
 				}
@@ -375,9 +360,7 @@ func (n *Node) removeFromNames(xRefTable *XRefTable, k string) (ok bool, err err
 func (n *Node) removeSingleFromParent(xRefTable *XRefTable) error {
 	if xRefTable != nil {
 		// Remove object graph of value.
-		if log.DebugEnabled() {
-			log.Debug.Println("removeFromLeaf: deleting object graph of v")
-		}
+		xRefTable.LogDebug("removeFromLeaf: deleting object graph of v")
 		if err := xRefTable.DeleteObjectGraph(n.Names[0].v); err != nil {
 			return err
 		}
@@ -406,9 +389,7 @@ func (n *Node) removeFromLeaf(xRefTable *XRefTable, k string) (empty, ok bool, e
 
 		if xRefTable != nil {
 			// Remove object graph of value.
-			if log.DebugEnabled() {
-				log.Debug.Println("removeFromLeaf: deleting object graph of v")
-			}
+			xRefTable.LogDebug("removeFromLeaf: deleting object graph of v")
 			if err := xRefTable.DeleteObjectGraph(n.Names[0].v); err != nil {
 				return false, false, err
 			}
@@ -423,9 +404,7 @@ func (n *Node) removeFromLeaf(xRefTable *XRefTable, k string) (empty, ok bool, e
 
 		if xRefTable != nil {
 			// Remove object graph of value.
-			if log.DebugEnabled() {
-				log.Debug.Println("removeFromLeaf: deleting object graph of v")
-			}
+			xRefTable.LogDebug("removeFromLeaf: deleting object graph of v")
 			if err := xRefTable.DeleteObjectGraph(n.Names[len(n.Names)-1].v); err != nil {
 				return false, false, err
 			}
@@ -452,21 +431,15 @@ func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 
 	if i == 0 {
 		// Remove first kid.
-		if log.DebugEnabled() {
-			log.Debug.Println("removeFromKids: remove first kid.")
-		}
+		xRefTable.LogDebug("removeFromKids: remove first kid")
 		n.Kids = n.Kids[1:]
 	} else if i == len(n.Kids)-1 {
-		if log.DebugEnabled() {
-			log.Debug.Println("removeFromKids: remove last kid.")
-		}
+		xRefTable.LogDebug("removeFromKids: remove last kid")
 		// Remove last kid.
 		n.Kids = n.Kids[:len(n.Kids)-1]
 	} else {
 		// Remove kid from the middle.
-		if log.DebugEnabled() {
-			log.Debug.Println("removeFromKids: remove kid form the middle.")
-		}
+		xRefTable.LogDebug("removeFromKids: remove kid from the middle")
 		n.Kids = append(n.Kids[:i], n.Kids[i+1:]...)
 	}
 
@@ -474,9 +447,7 @@ func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 
 		// If a single kid remains we can merge it with its parent.
 		// By doing this we get rid of a redundant intermediary node.
-		if log.DebugEnabled() {
-			log.Debug.Println("removeFromKids: only 1 kid")
-		}
+		xRefTable.LogDebug("removeFromKids: only 1 kid")
 
 		if xRefTable != nil {
 			if err := xRefTable.DeleteObject(n.D); err != nil {
@@ -486,9 +457,7 @@ func (n *Node) removeKid(xRefTable *XRefTable, kid *Node, i int) (bool, error) {
 
 		*n = *n.Kids[0]
 
-		if log.DebugEnabled() {
-			log.Debug.Printf("removeFromKids: new n = %s\n", n)
-		}
+		xRefTable.LogDebug("removeFromKids", "node", n)
 
 		return true, nil
 	}
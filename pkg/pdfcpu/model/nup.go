@@ -61,6 +61,28 @@ const (
 	DownLeft
 )
 
+// TrimFit represents the way input pages get normalized to a common trim size.
+type TrimFit int
+
+// These are the supported fit modes for normalizing mixed page sizes.
+const (
+	TrimFitScale  TrimFit = iota // Scale page to fit the trim size, centered.
+	TrimFitCenter                // Center page on the trim size without scaling, clip oversized pages.
+	TrimFitClip                  // Align page with the upper left corner of the trim size without scaling, clip oversized pages.
+)
+
+func (f TrimFit) String() string {
+	switch f {
+	case TrimFitScale:
+		return "scale"
+	case TrimFitCenter:
+		return "center"
+	case TrimFitClip:
+		return "clip"
+	}
+	return ""
+}
+
 type BorderStyling struct {
 	Color     *color.SimpleColor
 	LineStyle *types.LineJoinStyle
@@ -81,10 +103,16 @@ type NUp struct {
 	Border          bool               // Draw bounding box.
 	BorderOnCropbox *BorderStyling     // Draw bounding box around crop box.
 	BookletGuides   bool               // Draw folding and cutting lines.
+	Marks           *PrintersMarks     // Draw printer's marks (crop, registration, fold marks and slug line).
+	RotatedCells    types.IntSet       // 1-based grid cells whose content is rotated by 180 degrees.
+	Tumble          bool               // Rotate the content of the bottom half of the sheet by 180 degrees (work-and-tumble).
+	TrimDim         *types.Dim         // Common trim size input pages get normalized to before imposition.
+	TrimFit         TrimFit            // Page normalization mode: scale, center or clip.
 	MultiFolio      bool               // Render booklet as sequence of folios.
 	FolioSize       int                // Booklet multifolio folio size: default: 8
 	BookletType     BookletType        // Is this a booklet or booklet cover layout
 	BookletBinding  BookletBinding     // Does the booklet have short or long-edge binding
+	BookletRTL      bool               // Right-to-left reading direction: mirrored page ordering and binding edge.
 	InpUnit         types.DisplayUnit  // input display unit.
 	BgColor         *color.SimpleColor // background color
 }
@@ -118,6 +146,45 @@ func (nup NUp) IsBooklet() bool {
 	return nup.BookletType == Booklet || nup.BookletType == BookletAdvanced
 }
 
+// RotateCell returns true if the content of grid cell i (0-based, in grid order) gets rotated by 180 degrees.
+func (nup NUp) RotateCell(i int, r *types.Rectangle) bool {
+	if nup.RotatedCells[i%nup.N()+1] {
+		return true
+	}
+	return nup.Tumble && r.Center().Y < nup.PageDim.Height/2
+}
+
+// TrimBox returns the region of a page with cropBox r to be imposed.
+// If a trim size is set, all pages share its aspect ratio and get imposed using the same scale factor.
+func (nup NUp) TrimBox(r *types.Rectangle) *types.Rectangle {
+	if nup.TrimDim == nil {
+		return r
+	}
+
+	w, h := nup.TrimDim.Width, nup.TrimDim.Height
+
+	switch nup.TrimFit {
+
+	case TrimFitScale:
+		// Expand r to the aspect ratio of the trim size.
+		ar := w / h
+		if r.AspectRatio() > ar {
+			w, h = r.Width(), r.Width()/ar
+		} else {
+			w, h = r.Height()*ar, r.Height()
+		}
+		c := r.Center()
+		return types.NewRectangle(c.X-w/2, c.Y-h/2, c.X+w/2, c.Y+h/2)
+
+	case TrimFitCenter:
+		c := r.Center()
+		return types.NewRectangle(c.X-w/2, c.Y-h/2, c.X+w/2, c.Y+h/2)
+	}
+
+	// TrimFitClip
+	return types.NewRectangle(r.LL.X, r.UR.Y-h, r.LL.X+w, r.UR.Y)
+}
+
 // RectsForGrid calculates dest rectangles for given grid.
 func (nup NUp) RectsForGrid() []*types.Rectangle {
 	cols := int(nup.Grid.Width)
@@ -198,8 +265,20 @@ func createNUpFormForPDF(xRefTable *XRefTable, resDict *types.IndirectRef, conte
 	return xRefTable.IndRefForNewObject(sd)
 }
 
-// NUpTilePDFBytesForPDF applies nup tiles to content bytes.
+// NUpTilePDFBytes applies nup tiles to content bytes.
 func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID string, nup *NUp, rotate bool) {
+	// rotate:
+	//			indicates if we need to apply a post rotation of 180 degrees eg for booklets.
+	rot := 0
+	if rotate {
+		rot = 180
+	}
+	NUpTilePDFBytesRotated(wr, rSrc, rDest, formResID, nup, rot)
+}
+
+// NUpTilePDFBytesRotated applies nup tiles to content bytes rotating the content by rot degrees counter clockwise.
+// rot must be a multiple of 90.
+func NUpTilePDFBytesRotated(wr io.Writer, rSrc, rDest *types.Rectangle, formResID string, nup *NUp, rot int) {
 
 	// rScr is a rectangular region represented by form formResID in form space.
 
@@ -208,8 +287,8 @@ func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID strin
 	// Accounting for the aspect ratios of rSrc and rDest "best fit" tries to fit the largest version of rScr into rDest.
 	// This may result in a 90 degree rotation.
 	//
-	// rotate:
-	//			indicates if we need to apply a post rotation of 180 degrees eg for booklets.
+	// rot:
+	//			indicates if we need to apply a post rotation eg. by 180 degrees for booklets.
 	//
 	// enforceOrient:
 	//			indicates if we need to enforce dest's orientation.
@@ -224,12 +303,26 @@ func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID strin
 	// Apply margin to rDest which potentially makes it smaller.
 	rDestCr := rDest.CroppedCopy(nup.Margin)
 
+	rot = (rot%360 + 360) % 360
+	quarterTurn := rot == 90 || rot == 270
+
+	// For a quarter turn we need to fit the rotated source.
+	rFit := rSrc
+	if quarterTurn {
+		rFit = types.RectForDim(rSrc.Height(), rSrc.Width())
+	}
+
 	// Calculate transform matrix.
 
 	// Best fit translation of a source rectangle into a destination rectangle.
 	// For nup we enforce the dest orientation,
 	// whereas in cases where the original orientation needs to be preserved eg. for booklets, we don't.
-	w, h, dx, dy, r := types.BestFitRectIntoRect(rSrc, rDestCr, nup.Enforce, false)
+	w, h, dx, dy, r := types.BestFitRectIntoRect(rFit, rDestCr, nup.Enforce, false)
+
+	if quarterTurn {
+		// w and h are the dimensions of the rotated content.
+		w, h = h, w
+	}
 
 	if nup.BgColor != nil {
 		if nup.ImgInputFile {
@@ -243,9 +336,7 @@ func NUpTilePDFBytes(wr io.Writer, rSrc, rDest *types.Rectangle, formResID strin
 	}
 
 	// Apply additional rotation.
-	if rotate {
-		r += 180
-	}
+	r = math.Mod(r+float64(rot), 360)
 
 	sx := w
 	sy := h
@@ -311,6 +402,23 @@ func (ctx *Context) NUpTilePDFBytesForPDF(
 	nup *NUp,
 	rotate bool) error {
 
+	rot := 0
+	if rotate {
+		rot = 180
+	}
+
+	return ctx.NUpTilePDFBytesForPDFRotated(pageNr, formsResDict, buf, rDest, nup, rot)
+}
+
+// NUpTilePDFBytesForPDFRotated applies nup tiles from PDF rotating the content by rot degrees counter clockwise.
+func (ctx *Context) NUpTilePDFBytesForPDFRotated(
+	pageNr int,
+	formsResDict types.Dict,
+	buf *bytes.Buffer,
+	rDest *types.Rectangle,
+	nup *NUp,
+	rot int) error {
+
 	consolidateRes := true
 	d, _, inhPAttrs, err := ctx.PageDict(pageNr, consolidateRes)
 	if err != nil {
@@ -351,6 +459,9 @@ func (ctx *Context) NUpTilePDFBytesForPDF(
 		bb = append(ContentBytesForPageRotation(inhPAttrs.Rotate, cropBox.Width(), cropBox.Height()), bb...)
 	}
 
+	// Normalize mixed page sizes.
+	cropBox = nup.TrimBox(cropBox)
+
 	formIndRef, err := createNUpFormForPDF(ctx.XRefTable, ir, bb, cropBox)
 	if err != nil {
 		return err
@@ -360,7 +471,7 @@ func (ctx *Context) NUpTilePDFBytesForPDF(
 	formsResDict.Insert(formResID, *formIndRef)
 
 	// Append to content stream buf of destination page.
-	NUpTilePDFBytes(buf, cropBox, rDest, formResID, nup, rotate)
+	NUpTilePDFBytesRotated(buf, cropBox, rDest, formResID, nup, rot)
 
 	return nil
 }
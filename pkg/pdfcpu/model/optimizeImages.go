@@ -0,0 +1,54 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// ImageOptimization represents the settings for re-encoding embedded images during optimization.
+type ImageOptimization struct {
+	DPI          int   // Downsample images exceeding this resolution based on their placement size, 0 = no downsampling.
+	Quality      int   // JPEG quality (1..100) for DCT encoded images.
+	DCTThreshold int64 // Convert lossless images whose stream exceeds this size in bytes to DCT, 0 = no conversion.
+	Grayscale    bool  // Convert color images to DeviceGray.
+	MRC          bool  // Split scanned page images into a CCITT text mask and low resolution background/foreground layers.
+}
+
+// DefaultImageOptimization returns the default settings for re-encoding images.
+func DefaultImageOptimization() *ImageOptimization {
+	return &ImageOptimization{DPI: 150, Quality: 75}
+}
+
+func (imo ImageOptimization) String() string {
+	dct := "off"
+	if imo.DCTThreshold > 0 {
+		dct = types.ByteSize(imo.DCTThreshold).String()
+	}
+	return fmt.Sprintf("dpi:%d quality:%d dct:%s gray:%t mrc:%t", imo.DPI, imo.Quality, dct, imo.Grayscale, imo.MRC)
+}
+
+// ImageOptimizationStats represents the outcome of re-encoding images.
+type ImageOptimizationStats struct {
+	Images     int   // Number of re-encoded images.
+	Downsized  int   // Number of downsampled images.
+	Grayscaled int   // Number of images converted to DeviceGray.
+	MRC        int   // Number of scanned page images split into mixed raster content layers.
+	BytesSaved int64 // Reduction of image stream sizes in bytes.
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// OutputIntentSubtypes maps abbreviations to the output intent subtypes defined by ISO 32000.
+var OutputIntentSubtypes = map[string]string{
+	"pdfx": "GTS_PDFX",
+	"pdfa": "GTS_PDFA1",
+	"pdfe": "ISO_PDFE1",
+}
+
+// OutputIntent represents an output intent describing the color characteristics of an output device (14.11.5).
+type OutputIntent struct {
+	Subtype                   string // GTS_PDFX, GTS_PDFA1, ISO_PDFE1
+	OutputConditionIdentifier string // Name of the output condition, eg. FOGRA39 or sRGB IEC61966-2.1
+	OutputCondition           string // Human readable description of the output condition.
+	RegistryName              string // Registry OutputConditionIdentifier is defined in, eg. http://www.color.org
+	Info                      string // Additional information about the output condition.
+	Profile                   []byte // ICC profile embedded as DestOutputProfile.
+	ProfileName               string // File name of Profile.
+	ProfileDesc               string // Description of Profile.
+	N                         int    // Number of color components of Profile.
+}
+
+// DefaultOutputIntent returns the default output intent configuration.
+func DefaultOutputIntent() *OutputIntent {
+	return &OutputIntent{Subtype: "GTS_PDFX"}
+}
+
+func (oi OutputIntent) String() string {
+	ss := []string{fmt.Sprintf("%s: %s", oi.Subtype, oi.OutputConditionIdentifier)}
+	if oi.OutputCondition != "" {
+		ss = append(ss, "condition: "+oi.OutputCondition)
+	}
+	if oi.RegistryName != "" {
+		ss = append(ss, "registry: "+oi.RegistryName)
+	}
+	if oi.Info != "" {
+		ss = append(ss, "info: "+oi.Info)
+	}
+	if oi.Profile != nil {
+		ss = append(ss, fmt.Sprintf("profile: %q (%d components, %d bytes)", oi.ProfileDesc, oi.N, len(oi.Profile)))
+	}
+	return strings.Join(ss, ", ")
+}
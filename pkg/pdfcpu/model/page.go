@@ -58,9 +58,10 @@ func (fm FontMap) EnsureKey(fontName string) string {
 
 // ImageResource represents an existing PDF image resource.
 type ImageResource struct {
-	Res    Resource
-	Width  int
-	Height int
+	Res        Resource
+	Width      int
+	Height     int
+	DPIX, DPIY float64 // resolution if known
 }
 
 // ImageMap maps image filenames to image resources.
@@ -85,6 +86,32 @@ type Page struct {
 	LinkAnnots []LinkAnnotation
 	Buf        *bytes.Buffer
 	Fields     types.Array
+	Tagged     bool        // Tag content rendered into Buf for the structure tree.
+	MCIDBase   int         // First marked content identifier used for Tags.
+	Tags       []StructTag // Structure elements for the marked content sequences of Buf in MCID order.
+}
+
+// BeginTag starts a marked content sequence of tagged page content.
+func (p *Page) BeginTag(tag StructTag) {
+	if !p.Tagged {
+		return
+	}
+	BeginMarkedContent(p.Buf, tag.Type, p.MCIDBase+len(p.Tags))
+	p.Tags = append(p.Tags, tag)
+}
+
+// BeginArtifact starts a marked content sequence of tagged page content not being part of the logical structure.
+func (p *Page) BeginArtifact() {
+	if p.Tagged {
+		BeginArtifact(p.Buf)
+	}
+}
+
+// EndTag ends a marked content sequence started by BeginTag or BeginArtifact.
+func (p *Page) EndTag() {
+	if p.Tagged {
+		EndMarkedContent(p.Buf)
+	}
 }
 
 // NewPage creates a page for given mediaBox and cropBox.
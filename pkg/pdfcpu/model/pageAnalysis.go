@@ -0,0 +1,37 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// DefaultBlankThreshold is the maximum ink coverage of a blank page.
+const DefaultBlankThreshold = 0.001
+
+// PageAnalysis represents the outcome of analyzing a page for blankness and duplication.
+type PageAnalysis struct {
+	PageNr      int     `json:"page"`
+	Hash        string  `json:"hash"`        // Hash of the page covering content, resources and page boundaries.
+	InkCoverage float64 `json:"inkCoverage"` // Estimated ratio of the page area covered by ink.
+	Text        bool    `json:"text"`        // The page shows text.
+	Blank       bool    `json:"blank"`
+	DuplicateOf int     `json:"duplicateOf,omitempty"` // Page number of the first identical page.
+}
+
+// PageCleanup represents the removal of blank and duplicate pages.
+type PageCleanup struct {
+	Blank          bool    // Remove blank pages.
+	Duplicates     bool    // Remove pages identical to a preceding page.
+	BlankThreshold float64 // Maximum ink coverage of a blank page.
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// PageLabelStyle represents the numbering style of a page label range.
+type PageLabelStyle int
+
+// The page label numbering styles as defined in 12.4.2 Page Labels.
+const (
+	PageLabelNoNumber     PageLabelStyle = iota // Prefix only.
+	PageLabelDecimal                            // 1, 2, 3
+	PageLabelRomanUpper                         // I, II, III
+	PageLabelRomanLower                         // i, ii, iii
+	PageLabelLettersUpper                       // A to Z, then AA to ZZ..
+	PageLabelLettersLower                       // a to z, then aa to zz..
+)
+
+var pageLabelStyleNames = map[PageLabelStyle]string{
+	PageLabelDecimal:      "D",
+	PageLabelRomanUpper:   "R",
+	PageLabelRomanLower:   "r",
+	PageLabelLettersUpper: "A",
+	PageLabelLettersLower: "a",
+}
+
+// PageLabelStyleFor returns the page label style for the PDF name s.
+func PageLabelStyleFor(s string) (PageLabelStyle, bool) {
+	if s == "" {
+		return PageLabelNoNumber, true
+	}
+	for k, v := range pageLabelStyleNames {
+		if v == s {
+			return k, true
+		}
+	}
+	return PageLabelNoNumber, false
+}
+
+// String returns the PDF name of the numbering style or "" for none.
+func (st PageLabelStyle) String() string {
+	return pageLabelStyleNames[st]
+}
+
+// PageLabel represents a range of pages sharing a labeling scheme.
+// The range extends to the page preceding the next range.
+type PageLabel struct {
+	PageFrom int            // First physical page of the range, starting with 1.
+	Style    PageLabelStyle // Numbering style.
+	Prefix   string         // Label prefix.
+	Start    int            // Numeric value of the first page label of the range, defaults to 1.
+}
+
+func (pl PageLabel) start() int {
+	if pl.Start < 1 {
+		return 1
+	}
+	return pl.Start
+}
+
+func romanNumeral(n int) string {
+	vv := []int{1000, 900, 500, 400, 100, 90, 50, 40, 10, 9, 5, 4, 1}
+	ss := []string{"M", "CM", "D", "CD", "C", "XC", "L", "XL", "X", "IX", "V", "IV", "I"}
+	var sb strings.Builder
+	for i, v := range vv {
+		for n >= v {
+			sb.WriteString(ss[i])
+			n -= v
+		}
+	}
+	return sb.String()
+}
+
+// letterNumeral returns A..Z for 1..26, AA..ZZ for 27..52 and so on.
+func letterNumeral(n int) string {
+	c := string(rune('A' + (n-1)%26))
+	return strings.Repeat(c, (n-1)/26+1)
+}
+
+// Label returns the page label for pageNr which is expected to be covered by pl.
+func (pl PageLabel) Label(pageNr int) string {
+	n := pl.start() + pageNr - pl.PageFrom
+
+	var s string
+	switch pl.Style {
+	case PageLabelDecimal:
+		s = strconv.Itoa(n)
+	case PageLabelRomanUpper:
+		s = romanNumeral(n)
+	case PageLabelRomanLower:
+		s = strings.ToLower(romanNumeral(n))
+	case PageLabelLettersUpper:
+		s = letterNumeral(n)
+	case PageLabelLettersLower:
+		s = strings.ToLower(letterNumeral(n))
+	}
+
+	return pl.Prefix + s
+}
+
+func (pl PageLabel) String() string {
+	st := pl.Style.String()
+	if st == "" {
+		st = "-"
+	}
+	return fmt.Sprintf("page %d: style=%s prefix=%q start=%d", pl.PageFrom, st, pl.Prefix, pl.start())
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// PageFont represents a font used by a page.
+type PageFont struct {
+	ObjNr    int    `json:"objNr,omitempty"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Embedded bool   `json:"embedded"`
+	Subset   bool   `json:"subset"`
+}
+
+// PageImage represents an image placed on a page.
+type PageImage struct {
+	ObjNr      int     `json:"objNr,omitempty"` // 0 for inline images.
+	Width      int     `json:"width"`
+	Height     int     `json:"height"`
+	Bpc        int     `json:"bpc,omitempty"`
+	ColorSpace string  `json:"colorSpace,omitempty"`
+	Filter     string  `json:"filter,omitempty"`
+	HorDPI     float64 `json:"horDPI"` // Effective resolution of this placement.
+	VerDPI     float64 `json:"verDPI"`
+	SMask      bool    `json:"sMask,omitempty"`
+}
+
+// PageResourceReport represents the resources used by a page including the resources of its form XObjects.
+type PageResourceReport struct {
+	PageNr       int            `json:"page"`
+	Fonts        []PageFont     `json:"fonts"`
+	ImageCount   int            `json:"imageCount"`
+	Images       []PageImage    `json:"images"`
+	ColorSpaces  []string       `json:"colorSpaces"`
+	Transparency []string       `json:"transparency,omitempty"` // Transparency features in use.
+	Annotations  map[string]int `json:"annotations,omitempty"`  // Annotation count by subtype.
+}
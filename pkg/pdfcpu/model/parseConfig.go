@@ -30,33 +30,41 @@ import (
 )
 
 type configuration struct {
-	CreationDate                    string `yaml:"created"`
-	Version                         string `yaml:"version"`
-	CheckFileNameExt                bool   `yaml:"checkFileNameExt"`
-	Reader15                        bool   `yaml:"reader15"`
-	DecodeAllStreams                bool   `yaml:"decodeAllStreams"`
-	ValidationMode                  string `yaml:"validationMode"`
-	PostProcessValidate             bool   `yaml:"postProcessValidate"`
-	Eol                             string `yaml:"eol"`
-	WriteObjectStream               bool   `yaml:"writeObjectStream"`
-	WriteXRefStream                 bool   `yaml:"writeXRefStream"`
-	EncryptUsingAES                 bool   `yaml:"encryptUsingAES"`
-	EncryptKeyLength                int    `yaml:"encryptKeyLength"`
-	Permissions                     int    `yaml:"permissions"`
-	Unit                            string `yaml:"unit"`
-	TimestampFormat                 string `yaml:"timestampFormat"`
-	DateFormat                      string `yaml:"dateFormat"`
-	Optimize                        bool   `yaml:"optimize"`
-	OptimizeBeforeWriting           bool   `yaml:"optimizeBeforeWriting"`
-	OptimizeResourceDicts           bool   `yaml:"optimizeResourceDicts"`
-	OptimizeDuplicateContentStreams bool   `yaml:"optimizeDuplicateContentStreams"`
-	CreateBookmarks                 bool   `yaml:"createBookmarks"`
-	NeedAppearances                 bool   `yaml:"needAppearances"`
-	Offline                         bool   `yaml:"offline"`
-	Timeout                         int    `yaml:"timeout"`
-	TimeoutCRL                      int    `yaml:"timeoutCRL"`
-	TimeoutOCSP                     int    `yaml:"timeoutOCSP"`
-	PreferredCertRevocationChecker  string `yaml:"preferredCertRevocationChecker"`
+	CreationDate                    string   `yaml:"created"`
+	Version                         string   `yaml:"version"`
+	CheckFileNameExt                bool     `yaml:"checkFileNameExt"`
+	Reader15                        bool     `yaml:"reader15"`
+	DecodeAllStreams                bool     `yaml:"decodeAllStreams"`
+	ValidationMode                  string   `yaml:"validationMode"`
+	PostProcessValidate             bool     `yaml:"postProcessValidate"`
+	Eol                             string   `yaml:"eol"`
+	WriteObjectStream               bool     `yaml:"writeObjectStream"`
+	WriteXRefStream                 bool     `yaml:"writeXRefStream"`
+	EncryptUsingAES                 bool     `yaml:"encryptUsingAES"`
+	EncryptKeyLength                int      `yaml:"encryptKeyLength"`
+	Permissions                     int      `yaml:"permissions"`
+	Unit                            string   `yaml:"unit"`
+	TimestampFormat                 string   `yaml:"timestampFormat"`
+	DateFormat                      string   `yaml:"dateFormat"`
+	Optimize                        bool     `yaml:"optimize"`
+	OptimizeBeforeWriting           bool     `yaml:"optimizeBeforeWriting"`
+	OptimizeResourceDicts           bool     `yaml:"optimizeResourceDicts"`
+	OptimizeDuplicateContentStreams bool     `yaml:"optimizeDuplicateContentStreams"`
+	CreateBookmarks                 bool     `yaml:"createBookmarks"`
+	NeedAppearances                 bool     `yaml:"needAppearances"`
+	Offline                         bool     `yaml:"offline"`
+	Timeout                         int      `yaml:"timeout"`
+	TimeoutCRL                      int      `yaml:"timeoutCRL"`
+	TimeoutOCSP                     int      `yaml:"timeoutOCSP"`
+	PreferredCertRevocationChecker  string   `yaml:"preferredCertRevocationChecker"`
+	FallbackFonts                   []string `yaml:"fallbackFonts"`
+	DedupeResources                 bool     `yaml:"dedupeResources"`
+	TagContent                      bool     `yaml:"tagContent"`
+	ToleranceMissingEOF             bool     `yaml:"toleranceMissingEOF"`
+	ToleranceBrokenLength           bool     `yaml:"toleranceBrokenLength"`
+	ToleranceXRefOffsets            bool     `yaml:"toleranceXRefOffsets"`
+	ToleranceXRefOffsetRange        int      `yaml:"toleranceXRefOffsetRange"`
+	ToleranceRebuildXRef            bool     `yaml:"toleranceRebuildXRef"`
 }
 
 func loadedConfig(c configuration, configPath string) *Configuration {
@@ -110,6 +118,16 @@ func loadedConfig(c configuration, configPath string) *Configuration {
 	// TODO add to config.yml
 	conf.OptimizeBeforeWriting = true
 
+	conf.DedupeResources = c.DedupeResources
+	conf.TagContent = c.TagContent
+	conf.ReadTolerance = ReadTolerance{
+		MissingEOF:      c.ToleranceMissingEOF,
+		BrokenLength:    c.ToleranceBrokenLength,
+		XRefOffsets:     c.ToleranceXRefOffsets,
+		XRefOffsetRange: c.ToleranceXRefOffsetRange,
+		RebuildXRef:     c.ToleranceRebuildXRef,
+	}
+
 	conf.OptimizeResourceDicts = c.OptimizeResourceDicts
 	conf.OptimizeDuplicateContentStreams = c.OptimizeDuplicateContentStreams
 	conf.CreateBookmarks = c.CreateBookmarks
@@ -118,6 +136,7 @@ func loadedConfig(c configuration, configPath string) *Configuration {
 	conf.Timeout = c.Timeout
 	conf.TimeoutCRL = c.TimeoutCRL
 	conf.TimeoutOCSP = c.TimeoutOCSP
+	conf.FallbackFonts = c.FallbackFonts
 
 	switch strings.ToLower(c.PreferredCertRevocationChecker) {
 	case "crl":
@@ -134,6 +153,13 @@ func parseConfigFile(r io.Reader, configPath string) error {
 
 	// Enforce default for old config files.
 	c.CheckFileNameExt = true
+	c.DedupeResources = true
+	rt := DefaultReadTolerance()
+	c.ToleranceMissingEOF = rt.MissingEOF
+	c.ToleranceBrokenLength = rt.BrokenLength
+	c.ToleranceXRefOffsets = rt.XRefOffsets
+	c.ToleranceXRefOffsetRange = rt.XRefOffsetRange
+	c.ToleranceRebuildXRef = rt.RebuildXRef
 
 	var buf bytes.Buffer
 	if _, err := io.Copy(&buf, r); err != nil {
@@ -160,6 +186,10 @@ func parseConfigFile(r io.Reader, configPath string) error {
 		return errors.Errorf("encryptKeyLength possible values: 40, 128, 256, got: %s", c.Unit)
 	}
 
+	if c.ToleranceXRefOffsetRange < 0 {
+		return errors.Errorf("invalid toleranceXRefOffsetRange: %d", c.ToleranceXRefOffsetRange)
+	}
+
 	if !types.MemberOf(c.PreferredCertRevocationChecker, []string{"crl", "ocsp"}) {
 		if c.PreferredCertRevocationChecker != "" {
 			return errors.Errorf("invalid preferred certificate revocation checker: %s", c.PreferredCertRevocationChecker)
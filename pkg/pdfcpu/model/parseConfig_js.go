@@ -209,6 +209,29 @@ func handlePreferredCertRevocationChecker(v string, c *Configuration) error {
 	return nil
 }
 
+func handleFallbackFonts(v string, c *Configuration) error {
+	v = strings.TrimSpace(v)
+	if !strings.HasPrefix(v, "[") || !strings.HasSuffix(v, "]") {
+		return errors.Errorf("invalid fallbackFonts: %s", v)
+	}
+	c.FallbackFonts = nil
+	for _, s := range strings.Split(v[1:len(v)-1], ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			c.FallbackFonts = append(c.FallbackFonts, s)
+		}
+	}
+	return nil
+}
+
+func handleToleranceXRefOffsetRange(v string, c *Configuration) error {
+	i, err := strconv.Atoi(v)
+	if err != nil || i < 0 {
+		return errors.Errorf("toleranceXRefOffsetRange is numeric >= 0, got: %s", v)
+	}
+	c.ReadTolerance.XRefOffsetRange = i
+	return nil
+}
+
 func handleTimestampFormat(v string, c *Configuration) error {
 	c.TimestampFormat = v
 	return nil
@@ -296,6 +319,9 @@ func parseKeysPart2(k, v string, c *Configuration) (bool, error) {
 
 	case "preferredCertRevocationChecker":
 		return true, handlePreferredCertRevocationChecker(v, c)
+
+	case "fallbackFonts":
+		return true, handleFallbackFonts(v, c)
 	}
 
 	return false, nil
@@ -321,6 +347,27 @@ func parseKeysPart3(k, v string, c *Configuration) (err error) {
 
 	case "offline":
 		c.Offline, err = boolean(k, v)
+
+	case "dedupeResources":
+		c.DedupeResources, err = boolean(k, v)
+
+	case "tagContent":
+		c.TagContent, err = boolean(k, v)
+
+	case "toleranceMissingEOF":
+		c.ReadTolerance.MissingEOF, err = boolean(k, v)
+
+	case "toleranceBrokenLength":
+		c.ReadTolerance.BrokenLength, err = boolean(k, v)
+
+	case "toleranceXRefOffsets":
+		c.ReadTolerance.XRefOffsets, err = boolean(k, v)
+
+	case "toleranceXRefOffsetRange":
+		err = handleToleranceXRefOffsetRange(v, c)
+
+	case "toleranceRebuildXRef":
+		c.ReadTolerance.RebuildXRef, err = boolean(k, v)
 	}
 
 	return err
@@ -354,6 +401,10 @@ func parseConfigFile(r io.Reader, configPath string) error {
 	// TODO add to config.yml
 	conf.OptimizeBeforeWriting = true
 
+	// Enforce defaults for old config files.
+	conf.DedupeResources = true
+	conf.ReadTolerance = DefaultReadTolerance()
+
 	s := bufio.NewScanner(r)
 	for s.Scan() {
 		t := s.Text()
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"bytes"
+	"regexp"
+	"testing"
+)
+
+func TestParseConfigFile(t *testing.T) {
+	defer func(c *Configuration) { loadedDefaultConfig = c }(loadedDefaultConfig)
+
+	want := NewDefaultConfiguration()
+
+	// Old config files lacking the keys for dedupeResources, tagContent and read tolerance get the defaults.
+	old := regexp.MustCompile(`(?m)^(dedupeResources|tagContent|tolerance\w+):.*$`).ReplaceAll(configFileBytes, nil)
+
+	for _, bb := range [][]byte{configFileBytes, old} {
+		if err := parseConfigFile(bytes.NewReader(bb), "config.yml"); err != nil {
+			t.Fatal(err)
+		}
+		c := loadedDefaultConfig
+		if c.DedupeResources != want.DedupeResources || c.TagContent != want.TagContent || c.ReadTolerance != want.ReadTolerance {
+			t.Fatalf("got dedupeResources:%t tagContent:%t readTolerance:%v, want %t %t %v\n",
+				c.DedupeResources, c.TagContent, c.ReadTolerance, want.DedupeResources, want.TagContent, want.ReadTolerance)
+		}
+	}
+
+	bb := []byte("validationMode: ValidationRelaxed\neol: EolLF\nunit: points\nencryptKeyLength: 256\n" +
+		"dedupeResources: false\ntagContent: true\ntoleranceRebuildXRef: false\ntoleranceXRefOffsetRange: 64\n")
+	if err := parseConfigFile(bytes.NewReader(bb), "config.yml"); err != nil {
+		t.Fatal(err)
+	}
+	c := loadedDefaultConfig
+	if c.DedupeResources || !c.TagContent || c.ReadTolerance.RebuildXRef || c.ReadTolerance.XRefOffsetRange != 64 || !c.ReadTolerance.MissingEOF {
+		t.Fatalf("got dedupeResources:%t tagContent:%t readTolerance:%v\n", c.DedupeResources, c.TagContent, c.ReadTolerance)
+	}
+}
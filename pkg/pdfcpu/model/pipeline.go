@@ -0,0 +1,48 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "path/filepath"
+
+// PipelineStep represents a single operation of a processing pipeline.
+type PipelineStep struct {
+	Op      string            `json:"op" yaml:"op"`                               // decrypt, remove, rotate, stamp, watermark, optimize or encrypt.
+	Pages   []string          `json:"pages,omitempty" yaml:"pages,omitempty"`     // Page selection, defaults to all pages.
+	Options map[string]string `json:"options,omitempty" yaml:"options,omitempty"` // Operation specific options.
+}
+
+// Pipeline represents a job ticket describing an ordered sequence of operations
+// applied to each input file which is read and written once only.
+type Pipeline struct {
+	Input  []string       `json:"input" yaml:"input"`   // Input files, may contain glob patterns.
+	Output string         `json:"output" yaml:"output"` // Output directory or for a single input file a PDF output file, defaults to the input file.
+	Steps  []PipelineStep `json:"steps" yaml:"steps"`   // Operations in order of execution.
+}
+
+// ResolvePaths makes all relative input and output paths of p relative to dir.
+func (p *Pipeline) ResolvePaths(dir string) {
+	resolve := func(s string) string {
+		if s == "" || filepath.IsAbs(s) {
+			return s
+		}
+		return filepath.Join(dir, s)
+	}
+	for i := range p.Input {
+		p.Input[i] = resolve(p.Input[i])
+	}
+	p.Output = resolve(p.Output)
+}
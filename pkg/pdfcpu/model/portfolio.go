@@ -0,0 +1,105 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"path/filepath"
+)
+
+// PortfolioFieldTypes maps portfolio field types to collection field subtypes (12.3.5 Collections).
+var PortfolioFieldTypes = map[string]string{
+	"text":         "S",
+	"date":         "D",
+	"number":       "N",
+	"filename":     "F",
+	"description":  "Desc",
+	"size":         "Size",
+	"moddate":      "ModDate",
+	"creationdate": "CreationDate",
+}
+
+// PortfolioViews maps the initial portfolio views to collection view names.
+var PortfolioViews = map[string]string{
+	"details": "D",
+	"tile":    "T",
+	"hidden":  "H",
+}
+
+// PortfolioField represents a column of the file listing of a portfolio (collection field).
+// Fields of type text, date and number take their values from the portfolio files,
+// all other field types are derived from the embedded files.
+type PortfolioField struct {
+	Key      string `json:"key"`                // Field key referenced by sort and file values.
+	Name     string `json:"name"`               // Column header.
+	Type     string `json:"type"`               // text, date, number, filename, description, size, moddate, creationdate
+	Order    int    `json:"order,omitempty"`    // Relative column order.
+	Hidden   bool   `json:"hidden,omitempty"`   // Hide the column initially.
+	Editable bool   `json:"editable,omitempty"` // Allow viewers to edit the field values.
+}
+
+// Custom returns true if the values of f are supplied by the portfolio files.
+func (f PortfolioField) Custom() bool {
+	return f.Type == "text" || f.Type == "date" || f.Type == "number"
+}
+
+// PortfolioFile represents a file or a directory tree to be embedded into a portfolio.
+type PortfolioFile struct {
+	Path   string            `json:"path"`                  // File or directory, directories are embedded recursively.
+	Folder string            `json:"folder,omitempty"`      // Slash separated portfolio folder, eg. "docs/2025".
+	Desc   string            `json:"description,omitempty"` // Description, not applicable for directories.
+	Values map[string]string `json:"values,omitempty"`      // Values for custom fields by field key.
+}
+
+// PortfolioSort represents the initial sort order of a portfolio.
+type PortfolioSort struct {
+	Field     string `json:"field"`               // Key of the field to sort by.
+	Ascending bool   `json:"ascending,omitempty"` // Sort in ascending order.
+}
+
+// Portfolio represents the command details for creating a portfolio (aka PDF collection).
+type Portfolio struct {
+	Cover   string           `json:"cover,omitempty"`   // PDF file serving as cover sheet, defaults to a generated cover sheet.
+	View    string           `json:"view,omitempty"`    // Initial view: details, tile or hidden, defaults to details.
+	Initial string           `json:"initial,omitempty"` // Path of the file within the portfolio to be displayed initially, eg. "docs/readme.pdf".
+	Sort    *PortfolioSort   `json:"sort,omitempty"`    // Initial sort order.
+	Fields  []PortfolioField `json:"fields,omitempty"`  // Portfolio schema, defaults to filename, description, size and modification date.
+	Files   []PortfolioFile  `json:"files"`             // Embedded files.
+}
+
+// DefaultPortfolioFields returns the default portfolio schema.
+func DefaultPortfolioFields() []PortfolioField {
+	return []PortfolioField{
+		{Key: "FileName", Name: "Filename", Type: "filename", Order: 1},
+		{Key: "Description", Name: "Description", Type: "description", Order: 2},
+		{Key: "Size", Name: "Size", Type: "size", Order: 3},
+		{Key: "ModDate", Name: "Last Modification", Type: "moddate", Order: 4},
+	}
+}
+
+// ResolvePaths makes all relative file paths of p relative to dir.
+func (p *Portfolio) ResolvePaths(dir string) {
+	resolve := func(s string) string {
+		if s == "" || filepath.IsAbs(s) {
+			return s
+		}
+		return filepath.Join(dir, s)
+	}
+	p.Cover = resolve(p.Cover)
+	for i := range p.Files {
+		p.Files[i].Path = resolve(p.Files[i].Path)
+	}
+}
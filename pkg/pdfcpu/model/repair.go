@@ -0,0 +1,87 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import "fmt"
+
+// ReadTolerance represents the damage accepted while reading a file.
+// Each applied workaround is recorded in the repair log of the read context.
+type ReadTolerance struct {
+	MissingEOF      bool // Accept startxref not being followed by %%EOF.
+	BrokenLength    bool // Read stream data up to endstream if Length is missing or wrong.
+	XRefOffsets     bool // Search for objects not located at their xref table offset.
+	XRefOffsetRange int  // Maximum distance in bytes searched for a misplaced object.
+	RebuildXRef     bool // Rebuild a corrupt xref table by scanning the file for objects.
+}
+
+// DefaultReadTolerance returns the read tolerance in effect unless configured otherwise.
+func DefaultReadTolerance() ReadTolerance {
+	return ReadTolerance{
+		MissingEOF:      true,
+		BrokenLength:    true,
+		XRefOffsets:     true,
+		XRefOffsetRange: 1024,
+		RebuildXRef:     true,
+	}
+}
+
+// The kinds of repairs applied while reading.
+const (
+	RepairMissingEOF    = "missingEOF"
+	RepairStreamLength  = "streamLength"
+	RepairXRefOffset    = "xrefOffset"
+	RepairXRefRebuild   = "xrefRebuild"
+	RepairTrailer       = "trailer"
+	RepairFreeList      = "freeList"
+	RepairCatalog       = "catalog"
+	RepairMissingObject = "missingObject"
+	RepairPageTree      = "pageTree"
+)
+
+// Repair represents a workaround applied while reading a damaged file.
+type Repair struct {
+	Kind    string `json:"kind"`
+	ObjNr   int    `json:"objNr,omitempty"`
+	Offset  int64  `json:"offset,omitempty"`
+	Message string `json:"message"`
+}
+
+func (r Repair) String() string {
+	s := fmt.Sprintf("%s: %s", r.Kind, r.Message)
+	if r.ObjNr > 0 {
+		s += fmt.Sprintf(" (obj#:%d)", r.ObjNr)
+	}
+	return s
+}
+
+// Repaired records a repair and reports it like any other repair.
+func (rc *ReadContext) Repaired(kind string, objNr int, offset int64, msg string) {
+	rc.Repairs = append(rc.Repairs, Repair{Kind: kind, ObjNr: objNr, Offset: offset, Message: msg})
+	if kind == RepairMissingObject {
+		ShowSkipped(msg)
+		return
+	}
+	ShowRepaired(msg)
+}
+
+// Tolerance returns the read tolerance in effect.
+func (ctx *Context) Tolerance() ReadTolerance {
+	if ctx.Configuration == nil {
+		return DefaultReadTolerance()
+	}
+	return ctx.ReadTolerance
+}
@@ -25,6 +25,28 @@ import (
 	"github.com/pkg/errors"
 )
 
+// ResizeFit represents the way page content gets fitted into a target page size.
+type ResizeFit int
+
+// These are the supported fit modes for resizing pages including their content.
+const (
+	ResizeFitContain ResizeFit = iota // Scale uniformly to fit the target size, centered.
+	ResizeFitCover                    // Scale uniformly to cover the target size, centered, clip overflowing content.
+	ResizeFitStretch                  // Scale width and height independently to match the target size.
+)
+
+func (f ResizeFit) String() string {
+	switch f {
+	case ResizeFitContain:
+		return "contain"
+	case ResizeFitCover:
+		return "cover"
+	case ResizeFitStretch:
+		return "stretch"
+	}
+	return ""
+}
+
 type Resize struct {
 	Scale         float64            // scale factor x > 0, x > 1 enlarges, x < 1 shrinks down
 	Unit          types.DisplayUnit  // display unit
@@ -34,6 +56,7 @@ type Resize struct {
 	UserDim       bool               // true if dimensions set by dim rather than formsize
 	Border        bool               // true to render original crop box
 	BgColor       *color.SimpleColor // background color
+	Fit           *ResizeFit         // transform content and annotations to fit PageDim
 }
 
 func (r Resize) EnforceOrientation() bool {
@@ -99,11 +122,14 @@ func parsePageFormatRes(s string, res *Resize) error {
 		portrait = true
 	}
 
-	d, ok := types.PaperSize[v]
+	dim, ok := types.PaperSize[v]
 	if !ok {
 		return errors.Errorf("pdfcpu: page format %s is unsupported.\n", v)
 	}
 
+	// Don't modify the paper size table.
+	d := &types.Dim{Width: dim.Width, Height: dim.Height}
+
 	if (d.Portrait() && landscape) || (d.Landscape() && portrait) {
 		d.Width, d.Height = d.Height, d.Width
 		res.EnforceOrient = true
@@ -156,6 +182,22 @@ func parseBorderRes(s string, res *Resize) error {
 	return nil
 }
 
+func parseFitRes(s string, res *Resize) error {
+	var f ResizeFit
+	switch strings.ToLower(s) {
+	case "contain":
+		f = ResizeFitContain
+	case "cover":
+		f = ResizeFitCover
+	case "stretch":
+		f = ResizeFitStretch
+	default:
+		return errors.New("pdfcpu: resize fit, please provide one of: contain, cover, stretch")
+	}
+	res.Fit = &f
+	return nil
+}
+
 type resizeParameterMap map[string]func(string, *Resize) error
 
 var ResizeParamMap = resizeParameterMap{
@@ -166,6 +208,7 @@ var ResizeParamMap = resizeParameterMap{
 	"scalefactor": parseScaleFactorRes,
 	"bgcolor":     parseBackgroundColorRes,
 	"border":      parseBorderRes,
+	"fit":         parseFitRes,
 }
 
 // Handle applies parameter completion and on success parse parameter values into resize.
@@ -0,0 +1,71 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SanitizeReport represents the content removed by a sanitization.
+type SanitizeReport struct {
+	JavaScript    int      // Number of removed JavaScript actions and document level scripts.
+	LaunchActions int      // Number of removed launch actions.
+	EmbeddedFiles int      // Number of removed embedded and associated files.
+	HiddenLayers  []string // Names of removed hidden layers.
+	Annotations   int      // Number of removed review annotations.
+	Info          bool     // The document information dictionary got replaced by a minimal one.
+	XMP           int      // Number of removed XMP metadata streams.
+	Revisions     int      // Number of dropped incremental updates.
+}
+
+// Empty returns true if nothing got removed.
+func (sr SanitizeReport) Empty() bool {
+	return sr.JavaScript == 0 && sr.LaunchActions == 0 && sr.EmbeddedFiles == 0 && len(sr.HiddenLayers) == 0 &&
+		sr.Annotations == 0 && !sr.Info && sr.XMP == 0 && sr.Revisions == 0
+}
+
+// List generates output for the sanitize command.
+func (sr SanitizeReport) List() []string {
+	var ss []string
+
+	for _, e := range []struct {
+		n    int
+		what string
+	}{
+		{sr.JavaScript, "JavaScript action(s)"},
+		{sr.LaunchActions, "launch action(s)"},
+		{sr.EmbeddedFiles, "embedded file(s)"},
+		{sr.Annotations, "review annotation(s)"},
+		{sr.XMP, "XMP metadata stream(s)"},
+		{sr.Revisions, "incremental update(s)"},
+	} {
+		if e.n > 0 {
+			ss = append(ss, fmt.Sprintf("removed %d %s", e.n, e.what))
+		}
+	}
+
+	if len(sr.HiddenLayers) > 0 {
+		ss = append(ss, fmt.Sprintf("removed hidden layer(s): %s", strings.Join(sr.HiddenLayers, ", ")))
+	}
+
+	if sr.Info {
+		ss = append(ss, "removed document properties")
+	}
+
+	return ss
+}
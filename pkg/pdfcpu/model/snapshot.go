@@ -0,0 +1,291 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"maps"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func cloneObject(o types.Object) types.Object {
+	switch o := o.(type) {
+
+	case nil:
+		return nil
+
+	case types.ObjectStreamDict:
+		o1 := o
+		o1.StreamDict = o.StreamDict.Clone().(types.StreamDict)
+		if o.ObjArray != nil {
+			o1.ObjArray = o.ObjArray.Clone().(types.Array)
+		}
+		return o1
+
+	case types.XRefStreamDict:
+		o1 := o
+		o1.StreamDict = o.StreamDict.Clone().(types.StreamDict)
+		o1.Objects = append([]int(nil), o.Objects...)
+		return o1
+	}
+
+	return o.Clone()
+}
+
+func cloneDict(d types.Dict) types.Dict {
+	if d == nil {
+		return nil
+	}
+	return d.Clone().(types.Dict)
+}
+
+func cloneStreamDict(sd *types.StreamDict) *types.StreamDict {
+	if sd == nil {
+		return nil
+	}
+	sd1 := sd.Clone().(types.StreamDict)
+	return &sd1
+}
+
+func cloneIntSets(ss []types.IntSet) []types.IntSet {
+	if ss == nil {
+		return nil
+	}
+	ss1 := make([]types.IntSet, len(ss))
+	for i, s := range ss {
+		ss1[i] = maps.Clone(s)
+	}
+	return ss1
+}
+
+func cloneNode(n *Node) *Node {
+	if n == nil {
+		return nil
+	}
+	n1 := &Node{Kmin: n.Kmin, Kmax: n.Kmax, D: cloneDict(n.D)}
+	for _, kid := range n.Kids {
+		n1.Kids = append(n1.Kids, cloneNode(kid))
+	}
+	for _, e := range n.Names {
+		n1.Names = append(n1.Names, entry{e.k, cloneObject(e.v)})
+	}
+	return n1
+}
+
+// rootEntryDict returns the dict for the catalog entry key of xRefTable if the cached dict d is set.
+func (xRefTable *XRefTable) rootEntryDict(d types.Dict, key string) types.Dict {
+	if d == nil || xRefTable.RootDict == nil {
+		return nil
+	}
+	d1, err := xRefTable.DereferenceDict(xRefTable.RootDict[key])
+	if err != nil || d1 == nil {
+		return cloneDict(d)
+	}
+	return d1
+}
+
+// resolveLazyObjects replaces all objects pending decoding from an object stream by their decoded object.
+func (xRefTable *XRefTable) resolveLazyObjects() error {
+	for objNr, entry := range xRefTable.Table {
+		if _, ok := entry.Object.(types.LazyObjectStreamObject); !ok {
+			continue
+		}
+		ir := types.NewIndirectRef(objNr, 0)
+		if entry.Generation != nil {
+			ir.GenerationNumber = types.Integer(*entry.Generation)
+		}
+		if _, _, err := xRefTable.indRefToObject(ir, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (xRefTable *XRefTable) snapshot(conf *Configuration) *XRefTable {
+	xt := *xRefTable
+	xt.Conf = conf
+
+	xt.Table = make(map[int]*XRefTableEntry, len(xRefTable.Table))
+	for objNr, entry := range xRefTable.Table {
+		e := *entry
+		e.Object = cloneObject(entry.Object)
+		xt.Table[objNr] = &e
+	}
+
+	if xRefTable.Size != nil {
+		size := *xRefTable.Size
+		xt.Size = &size
+	}
+
+	xt.RootDict = nil
+	if xt.Root != nil {
+		if d, err := xt.DereferenceDict(*xt.Root); err == nil {
+			xt.RootDict = d
+		}
+	}
+	xt.Form = xt.rootEntryDict(xRefTable.Form, "AcroForm")
+	xt.Outlines = xt.rootEntryDict(xRefTable.Outlines, "Outlines")
+	xt.Dests = xt.rootEntryDict(xRefTable.Dests, "Dests")
+
+	xt.Names = make(map[string]*Node, len(xRefTable.Names))
+	for k, n := range xRefTable.Names {
+		xt.Names[k] = cloneNode(n)
+	}
+
+	xt.NameRefs = make(map[string]NameMap, len(xRefTable.NameRefs))
+	for k, m := range xRefTable.NameRefs {
+		m1 := NameMap{}
+		for k1, dd := range m {
+			m1[k1] = append([]types.Dict(nil), dd...)
+		}
+		xt.NameRefs[k] = m1
+	}
+
+	xt.PageAnnots = make(map[int]PgAnnots, len(xRefTable.PageAnnots))
+	for pageNr, pgAnnots := range xRefTable.PageAnnots {
+		pa := PgAnnots{}
+		for annType, annot := range pgAnnots {
+			a := Annot{Map: maps.Clone(annot.Map)}
+			if annot.IndRefs != nil {
+				indRefs := append([]types.IndirectRef(nil), *annot.IndRefs...)
+				a.IndRefs = &indRefs
+			}
+			pa[annType] = a
+		}
+		xt.PageAnnots[pageNr] = pa
+	}
+
+	xt.Signatures = make(map[int]map[int]Signature, len(xRefTable.Signatures))
+	for k, m := range xRefTable.Signatures {
+		xt.Signatures[k] = maps.Clone(m)
+	}
+
+	xt.URIs = make(map[int]map[string]string, len(xRefTable.URIs))
+	for k, m := range xRefTable.URIs {
+		xt.URIs[k] = maps.Clone(m)
+	}
+
+	xt.UsedGIDs = make(map[string]map[uint16]bool, len(xRefTable.UsedGIDs))
+	for k, m := range xRefTable.UsedGIDs {
+		xt.UsedGIDs[k] = maps.Clone(m)
+	}
+
+	xt.KeywordList = maps.Clone(xRefTable.KeywordList)
+	xt.Properties = maps.Clone(xRefTable.Properties)
+	xt.LinearizationObjs = maps.Clone(xRefTable.LinearizationObjs)
+	xt.PageThumbs = maps.Clone(xRefTable.PageThumbs)
+	xt.FillFonts = maps.Clone(xRefTable.FillFonts)
+	xt.CryptFilters = maps.Clone(xRefTable.CryptFilters)
+	xt.URSignature = cloneDict(xRefTable.URSignature)
+	xt.DSS = cloneDict(xRefTable.DSS)
+	xt.Stats = PDFStats{rootAttrs: maps.Clone(xRefTable.Stats.rootAttrs), pageAttrs: maps.Clone(xRefTable.Stats.pageAttrs)}
+	xt.Findings = nil
+
+	return &xt
+}
+
+func (oc *OptimizationContext) snapshot() *OptimizationContext {
+	oc1 := *oc
+
+	oc1.PageFonts = cloneIntSets(oc.PageFonts)
+	oc1.PageImages = cloneIntSets(oc.PageImages)
+
+	cloneFontObjects := func(m map[int]*FontObject) map[int]*FontObject {
+		m1 := make(map[int]*FontObject, len(m))
+		for objNr, fo := range m {
+			fo1 := *fo
+			fo1.ResourceNames = append([]string(nil), fo.ResourceNames...)
+			fo1.FontDict = cloneDict(fo.FontDict)
+			m1[objNr] = &fo1
+		}
+		return m1
+	}
+	oc1.FontObjects = cloneFontObjects(oc.FontObjects)
+	oc1.FormFontObjects = cloneFontObjects(oc.FormFontObjects)
+
+	oc1.Fonts = make(map[string][]int, len(oc.Fonts))
+	for k, objNrs := range oc.Fonts {
+		oc1.Fonts[k] = append([]int(nil), objNrs...)
+	}
+
+	oc1.DuplicateFonts = make(map[int]types.Dict, len(oc.DuplicateFonts))
+	for objNr, d := range oc.DuplicateFonts {
+		oc1.DuplicateFonts[objNr] = cloneDict(d)
+	}
+
+	oc1.ImageObjects = make(map[int]*ImageObject, len(oc.ImageObjects))
+	for objNr, io := range oc.ImageObjects {
+		oc1.ImageObjects[objNr] = &ImageObject{ResourceNames: maps.Clone(io.ResourceNames), ImageDict: cloneStreamDict(io.ImageDict)}
+	}
+
+	oc1.DuplicateImages = make(map[int]*DuplicateImageObject, len(oc.DuplicateImages))
+	for objNr, dio := range oc.DuplicateImages {
+		oc1.DuplicateImages[objNr] = &DuplicateImageObject{ImageDict: cloneStreamDict(dio.ImageDict), NewObjNr: dio.NewObjNr}
+	}
+
+	cloneStreamCache := func(m map[int]*types.StreamDict) map[int]*types.StreamDict {
+		m1 := make(map[int]*types.StreamDict, len(m))
+		for objNr, sd := range m {
+			m1[objNr] = cloneStreamDict(sd)
+		}
+		return m1
+	}
+	oc1.ContentStreamCache = cloneStreamCache(oc.ContentStreamCache)
+	oc1.FormStreamCache = cloneStreamCache(oc.FormStreamCache)
+
+	oc1.DuplicateFontObjs = maps.Clone(oc.DuplicateFontObjs)
+	oc1.DuplicateImageObjs = maps.Clone(oc.DuplicateImageObjs)
+	oc1.DuplicateInfoObjects = maps.Clone(oc.DuplicateInfoObjects)
+	oc1.NonReferencedObjs = append([]int(nil), oc.NonReferencedObjs...)
+	oc1.Cache = maps.Clone(oc.Cache)
+
+	return &oc1
+}
+
+// Snapshot returns an independent copy of ctx for read-only processing like text, image or font extraction.
+// Snapshots of one context may be used concurrently, each by a single goroutine,
+// which allows processing pages of a parsed document in parallel without reading it again.
+// Objects, caches and the configuration get copied, stream data is shared since it is never modified in place.
+// Snapshot resolves any objects still pending decoding from object streams
+// and must not be called while ctx is in use by another goroutine.
+func (ctx *Context) Snapshot() (*Context, error) {
+	if err := ctx.resolveLazyObjects(); err != nil {
+		return nil, err
+	}
+
+	conf := *ctx.Configuration
+
+	ctx1 := &Context{
+		Configuration: &conf,
+		XRefTable:     ctx.XRefTable.snapshot(&conf),
+		Write:         NewWriteContext(conf.Eol),
+	}
+
+	if ctx.Read != nil {
+		rc := *ctx.Read
+		rc.ObjectStreams = maps.Clone(ctx.Read.ObjectStreams)
+		rc.XRefStreams = maps.Clone(ctx.Read.XRefStreams)
+		rc.Repairs = append([]Repair(nil), ctx.Read.Repairs...)
+		ctx1.Read = &rc
+	}
+
+	if ctx.Optimize != nil {
+		ctx1.Optimize = ctx.Optimize.snapshot()
+	}
+
+	return ctx1, nil
+}
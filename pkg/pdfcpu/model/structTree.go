@@ -0,0 +1,484 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// StandardStructTypes are the standard structure types as defined in 14.8.4 Standard structure types.
+var StandardStructTypes = []string{
+	"Document", "Part", "Art", "Sect", "Div", "BlockQuote", "Caption", "TOC", "TOCI", "Index", "NonStruct", "Private",
+	"P", "H", "H1", "H2", "H3", "H4", "H5", "H6", "L", "LI", "Lbl", "LBody",
+	"Table", "TR", "TH", "TD", "THead", "TBody", "TFoot",
+	"Span", "Quote", "Note", "Reference", "BibEntry", "Code", "Link", "Annot",
+	"Ruby", "RB", "RT", "RP", "Warichu", "WT", "WP",
+	"Figure", "Formula", "Form",
+}
+
+// StructTag represents a structure element to be created for a marked content sequence of generated content.
+type StructTag struct {
+	Type string // Structure type, a non standard type is mapped to Role via the role map.
+	Role string // Standard structure type a non standard Type is mapped to, defaults to P.
+	Alt  string // Alternate description, eg. for figures.
+}
+
+// BeginMarkedContent starts the marked content sequence mcid tagged as structType.
+func BeginMarkedContent(w io.Writer, structType string, mcid int) {
+	fmt.Fprintf(w, "/%s <</MCID %d>> BDC\n", structType, mcid)
+}
+
+// BeginArtifact starts a marked content sequence representing an artifact like a background or page header.
+func BeginArtifact(w io.Writer) {
+	fmt.Fprint(w, "/Artifact BMC\n")
+}
+
+// EndMarkedContent ends a marked content sequence.
+func EndMarkedContent(w io.Writer) {
+	fmt.Fprint(w, "EMC\n")
+}
+
+// EnsureStructTreeRoot returns the structure tree root of xRefTable and creates it if missing.
+// The document is marked as tagged.
+func (xRefTable *XRefTable) EnsureStructTreeRoot() (types.Dict, *types.IndirectRef, error) {
+	o, found := xRefTable.RootDict.Find("StructTreeRoot")
+	if !found {
+		o = types.Dict{"Type": types.Name("StructTreeRoot")}
+	}
+
+	ir, ok := o.(types.IndirectRef)
+	if !ok {
+		d, ok := o.(types.Dict)
+		if !ok {
+			return nil, nil, errors.New("pdfcpu: corrupt struct tree root")
+		}
+		// Struct elements need to refer to the struct tree root.
+		ir1, err := xRefTable.IndRefForNewObject(d)
+		if err != nil {
+			return nil, nil, err
+		}
+		ir = *ir1
+		xRefTable.RootDict["StructTreeRoot"] = ir
+	}
+
+	d, err := xRefTable.DereferenceDict(ir)
+	if err != nil {
+		return nil, nil, err
+	}
+	if d == nil {
+		return nil, nil, errors.New("pdfcpu: missing struct tree root")
+	}
+
+	markInfo, err := xRefTable.DereferenceDict(xRefTable.RootDict["MarkInfo"])
+	if err != nil {
+		return nil, nil, err
+	}
+	if markInfo == nil {
+		markInfo = types.Dict{}
+		xRefTable.RootDict["MarkInfo"] = markInfo
+	}
+	markInfo["Marked"] = types.Boolean(true)
+
+	return d, &ir, nil
+}
+
+func (xRefTable *XRefTable) appendStructElemKid(d types.Dict, o types.Object) error {
+	k, found := d.Find("K")
+	if !found {
+		d["K"] = o
+		return nil
+	}
+
+	k, err := xRefTable.Dereference(k)
+	if err != nil {
+		return err
+	}
+
+	if a, ok := k.(types.Array); ok {
+		d["K"] = append(a, o)
+		return nil
+	}
+
+	d["K"] = types.Array{d["K"], o}
+
+	return nil
+}
+
+// DocumentStructElem returns the top level Document structure element and creates it if missing.
+func (xRefTable *XRefTable) DocumentStructElem() (*types.IndirectRef, error) {
+	root, rootIndRef, err := xRefTable.EnsureStructTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+
+	k, err := xRefTable.Dereference(root["K"])
+	if err != nil {
+		return nil, err
+	}
+
+	kids := types.Array{root["K"]}
+	if a, ok := k.(types.Array); ok {
+		kids = a
+	}
+
+	for _, o := range kids {
+		ir, ok := o.(types.IndirectRef)
+		if !ok {
+			continue
+		}
+		d, err := xRefTable.DereferenceDict(ir)
+		if err != nil {
+			return nil, err
+		}
+		if d != nil && d.NameEntry("S") != nil && *d.NameEntry("S") == "Document" {
+			return &ir, nil
+		}
+	}
+
+	d := types.Dict{"Type": types.Name("StructElem"), "S": types.Name("Document"), "P": *rootIndRef}
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xRefTable.appendStructElemKid(root, *ir); err != nil {
+		return nil, err
+	}
+
+	return ir, nil
+}
+
+func (xRefTable *XRefTable) mapRole(structType, role string) error {
+	if structType == "" {
+		return errors.New("pdfcpu: missing structure type")
+	}
+
+	if types.MemberOf(structType, StandardStructTypes) {
+		return nil
+	}
+
+	if role == "" {
+		role = "P"
+	}
+	if !types.MemberOf(role, StandardStructTypes) {
+		return errors.Errorf("pdfcpu: structure type %s: invalid role: %s", structType, role)
+	}
+
+	root, _, err := xRefTable.EnsureStructTreeRoot()
+	if err != nil {
+		return err
+	}
+
+	roleMap, err := xRefTable.DereferenceDict(root["RoleMap"])
+	if err != nil {
+		return err
+	}
+	if roleMap == nil {
+		roleMap = types.Dict{}
+		root["RoleMap"] = roleMap
+	}
+
+	if _, found := roleMap.Find(structType); !found {
+		roleMap[structType] = types.Name(role)
+	}
+
+	return nil
+}
+
+// AddStructElem adds a new structure element for tag to parent, nil for the Document structure element.
+func (xRefTable *XRefTable) AddStructElem(parent *types.IndirectRef, tag StructTag) (*types.IndirectRef, error) {
+	if err := xRefTable.mapRole(tag.Type, tag.Role); err != nil {
+		return nil, err
+	}
+
+	if parent == nil {
+		var err error
+		if parent, err = xRefTable.DocumentStructElem(); err != nil {
+			return nil, err
+		}
+	}
+
+	pd, err := xRefTable.DereferenceDict(*parent)
+	if err != nil {
+		return nil, err
+	}
+	if pd == nil {
+		return nil, errors.New("pdfcpu: missing parent structure element")
+	}
+
+	d := types.Dict{"Type": types.Name("StructElem"), "S": types.Name(tag.Type), "P": *parent}
+	if tag.Alt != "" {
+		s, err := types.EscapedUTF16String(tag.Alt)
+		if err != nil {
+			return nil, err
+		}
+		d["Alt"] = types.StringLiteral(*s)
+	}
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := xRefTable.appendStructElemKid(pd, *ir); err != nil {
+		return nil, err
+	}
+
+	return ir, nil
+}
+
+func (xRefTable *XRefTable) parentTree() (types.Dict, types.Dict, error) {
+	root, _, err := xRefTable.EnsureStructTreeRoot()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pt, err := xRefTable.DereferenceDict(root["ParentTree"])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if pt == nil {
+		pt = types.Dict{"Nums": types.Array{}}
+		ir, err := xRefTable.IndRefForNewObject(pt)
+		if err != nil {
+			return nil, nil, err
+		}
+		root["ParentTree"] = *ir
+	}
+
+	return root, pt, nil
+}
+
+// numberTreeEntry returns the Nums array of the number tree node d holding key and the index of key.
+func (xRefTable *XRefTable) numberTreeEntry(d types.Dict, key int) (types.Array, int, error) {
+	kids, err := xRefTable.DereferenceArray(d["Kids"])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for _, o := range kids {
+		kid, err := xRefTable.DereferenceDict(o)
+		if err != nil || kid == nil {
+			return nil, 0, err
+		}
+		if lim := kid.ArrayEntry("Limits"); len(lim) == 2 {
+			lo, ok1 := lim[0].(types.Integer)
+			hi, ok2 := lim[1].(types.Integer)
+			if ok1 && ok2 && (key < lo.Value() || key > hi.Value()) {
+				continue
+			}
+		}
+		nums, i, err := xRefTable.numberTreeEntry(kid, key)
+		if err != nil || nums != nil {
+			return nums, i, err
+		}
+	}
+
+	nums, err := xRefTable.DereferenceArray(d["Nums"])
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for i := 0; i+1 < len(nums); i += 2 {
+		if k, ok := nums[i].(types.Integer); ok && k.Value() == key {
+			return nums, i, nil
+		}
+	}
+
+	return nil, 0, nil
+}
+
+// addParentTreeEntry adds the value for a new parent tree key and returns the key.
+func (xRefTable *XRefTable) addParentTreeEntry(o types.Object) (int, error) {
+	root, pt, err := xRefTable.parentTree()
+	if err != nil {
+		return 0, err
+	}
+
+	key := 0
+	if i := root.IntEntry("ParentTreeNextKey"); i != nil {
+		key = *i
+	}
+	for {
+		nums, _, err := xRefTable.numberTreeEntry(pt, key)
+		if err != nil {
+			return 0, err
+		}
+		if nums == nil {
+			break
+		}
+		key++
+	}
+	root["ParentTreeNextKey"] = types.Integer(key + 1)
+
+	if kids := pt.ArrayEntry("Kids"); kids != nil {
+		// Keys are ascending, so append a new leaf.
+		leaf := types.Dict{"Limits": types.NewIntegerArray(key, key), "Nums": types.Array{types.Integer(key), o}}
+		ir, err := xRefTable.IndRefForNewObject(leaf)
+		if err != nil {
+			return 0, err
+		}
+		pt["Kids"] = append(kids, *ir)
+		return key, nil
+	}
+
+	nums, err := xRefTable.DereferenceArray(pt["Nums"])
+	if err != nil {
+		return 0, err
+	}
+	pt["Nums"] = append(nums, types.Integer(key), o)
+
+	return key, nil
+}
+
+// NextMCID returns the next free marked content identifier of a page.
+func (xRefTable *XRefTable) NextMCID(pageDict types.Dict) (int, error) {
+	key := pageDict.IntEntry("StructParents")
+	if key == nil {
+		return 0, nil
+	}
+
+	pt, err := xRefTable.DereferenceDict(xRefTable.structTreeRootEntry("ParentTree"))
+	if err != nil || pt == nil {
+		return 0, err
+	}
+
+	nums, i, err := xRefTable.numberTreeEntry(pt, *key)
+	if err != nil || nums == nil {
+		return 0, err
+	}
+
+	a, err := xRefTable.DereferenceArray(nums[i+1])
+	if err != nil {
+		return 0, err
+	}
+
+	return len(a), nil
+}
+
+func (xRefTable *XRefTable) structTreeRootEntry(key string) types.Object {
+	d, err := xRefTable.DereferenceDict(xRefTable.RootDict["StructTreeRoot"])
+	if err != nil || d == nil {
+		return nil
+	}
+	return d[key]
+}
+
+// NewMCID allocates the next marked content identifier of a page as content item of the structure element elem.
+func (xRefTable *XRefTable) NewMCID(elem, pageIndRef types.IndirectRef, pageDict types.Dict) (int, error) {
+	d, err := xRefTable.DereferenceDict(elem)
+	if err != nil {
+		return 0, err
+	}
+	if d == nil {
+		return 0, errors.New("pdfcpu: missing structure element")
+	}
+
+	var mcid int
+
+	if key := pageDict.IntEntry("StructParents"); key != nil {
+		_, pt, err := xRefTable.parentTree()
+		if err != nil {
+			return 0, err
+		}
+		nums, i, err := xRefTable.numberTreeEntry(pt, *key)
+		if err != nil {
+			return 0, err
+		}
+		if nums == nil {
+			return 0, errors.Errorf("pdfcpu: missing parent tree entry for StructParents %d", *key)
+		}
+		a, err := xRefTable.DereferenceArray(nums[i+1])
+		if err != nil {
+			return 0, err
+		}
+		mcid = len(a)
+		nums[i+1] = append(a, elem)
+	} else {
+		key, err := xRefTable.addParentTreeEntry(types.Array{elem})
+		if err != nil {
+			return 0, err
+		}
+		pageDict["StructParents"] = types.Integer(key)
+	}
+
+	if pg := d.IndirectRefEntry("Pg"); pg == nil {
+		d["Pg"] = pageIndRef
+	}
+
+	var k types.Object = types.Integer(mcid)
+	if pg := d.IndirectRefEntry("Pg"); *pg != pageIndRef {
+		k = types.Dict{"Type": types.Name("MCR"), "Pg": pageIndRef, "MCID": types.Integer(mcid)}
+	}
+
+	if err := xRefTable.appendStructElemKid(d, k); err != nil {
+		return 0, err
+	}
+
+	return mcid, nil
+}
+
+// AddObjRef adds the annotation annotIndRef as content item of the structure element elem.
+func (xRefTable *XRefTable) AddObjRef(elem, pageIndRef, annotIndRef types.IndirectRef) error {
+	d, err := xRefTable.DereferenceDict(elem)
+	if err != nil {
+		return err
+	}
+
+	annotDict, err := xRefTable.DereferenceDict(annotIndRef)
+	if err != nil {
+		return err
+	}
+
+	if d == nil || annotDict == nil {
+		return errors.New("pdfcpu: missing structure element or annotation")
+	}
+
+	key, err := xRefTable.addParentTreeEntry(elem)
+	if err != nil {
+		return err
+	}
+	annotDict["StructParent"] = types.Integer(key)
+
+	objr := types.Dict{"Type": types.Name("OBJR"), "Pg": pageIndRef, "Obj": annotIndRef}
+
+	return xRefTable.appendStructElemKid(d, objr)
+}
+
+// TagMarkedContent creates structure elements below the Document structure element
+// for the marked content sequences of a page tagged with MCIDs starting at mcidBase.
+func (xRefTable *XRefTable) TagMarkedContent(pageIndRef types.IndirectRef, pageDict types.Dict, mcidBase int, tags []StructTag) error {
+	for i, tag := range tags {
+		elem, err := xRefTable.AddStructElem(nil, tag)
+		if err != nil {
+			return err
+		}
+		mcid, err := xRefTable.NewMCID(*elem, pageIndRef, pageDict)
+		if err != nil {
+			return err
+		}
+		if mcid != mcidBase+i {
+			return errors.Errorf("pdfcpu: unexpected MCID %d, want %d", mcid, mcidBase+i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// Table detection methods.
+const (
+	TableRuling     = "ruling"     // Cells are delimited by ruling lines.
+	TableWhitespace = "whitespace" // Columns are delimited by vertical white space.
+)
+
+// TableCell represents a cell of a detected table.
+type TableCell struct {
+	Row  int        `json:"row"`  // 0-based, top to bottom
+	Col  int        `json:"col"`  // 0-based, left to right
+	Rect [4]float64 `json:"rect"` // llx, lly, urx, ury in user space
+	Text string     `json:"text"`
+}
+
+// Table represents a table detected on a page.
+// Cells holds Rows x Cols cells in row major order.
+type Table struct {
+	PageNr int         `json:"page"`
+	Method string      `json:"method"`
+	Rect   [4]float64  `json:"rect"` // llx, lly, urx, ury in user space
+	Rows   int         `json:"rows"`
+	Cols   int         `json:"cols"`
+	Cells  []TableCell `json:"cells"`
+}
+
+// Records returns the cell texts of t by row.
+func (t Table) Records() [][]string {
+	rr := make([][]string, t.Rows)
+	for i := range rr {
+		rr[i] = make([]string, t.Cols)
+	}
+	for _, c := range t.Cells {
+		if c.Row < t.Rows && c.Col < t.Cols {
+			rr[c.Row][c.Col] = c.Text
+		}
+	}
+	return rr
+}
+
+// WriteCSV writes the cell texts of t to w as CSV.
+func (t Table) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(t.Records()); err != nil {
+		return err
+	}
+	return cw.Error()
+}
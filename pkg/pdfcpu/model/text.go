@@ -37,8 +37,10 @@ type TextDescriptor struct {
 	Text           string              // A multi line string using \n for line breaks.
 	FontName       string              // Name of the core or user font to be used.
 	RTL            bool                // Right to left user font.
+	Vertical       bool                // Top to bottom user font, lines progress from right to left.
 	Embed          bool                // Embed font.
 	FontKey        string              // Resource id registered for FontName.
+	FallbackFonts  []FallbackFont      // User fonts used for chars missing in user font FontName.
 	FontSize       int                 // Fontsize in points.
 	X, Y           float64             // Position of first char's baseline.
 	Dx, Dy         float64             // Horizontal and vertical offsets for X,Y.
@@ -67,6 +69,72 @@ type TextDescriptor struct {
 	HairCross      bool                // Draw haircross at X,Y
 }
 
+// FallbackFont represents a user font taking over chars missing in the primary font of a TextDescriptor.
+type FallbackFont struct {
+	Name string // Name of the user font.
+	Key  string // Resource id registered for Name.
+}
+
+// fallbackFontNames returns the names of td's fallback fonts applicable for horizontal writing using a user font.
+func (td TextDescriptor) fallbackFontNames() []string {
+	if len(td.FallbackFonts) == 0 || td.Vertical || !font.IsUserFont(td.FontName) {
+		return nil
+	}
+	ss := make([]string, len(td.FallbackFonts))
+	for i, ff := range td.FallbackFonts {
+		ss[i] = ff.Name
+	}
+	return ss
+}
+
+func (td TextDescriptor) fontKey(fontName string) string {
+	for _, ff := range td.FallbackFonts {
+		if ff.Name == fontName {
+			return ff.Key
+		}
+	}
+	return td.FontKey
+}
+
+// textSegment is a part of a text line ready for rendering using the font registered under fontKey.
+type textSegment struct {
+	fontKey string
+	bytes   string
+}
+
+// segments prepares s for rendering using td's font switching to fallback fonts for missing chars.
+func (td TextDescriptor) segments(xRefTable *XRefTable, s string) []textSegment {
+	fallbacks := td.fallbackFontNames()
+	if fallbacks == nil {
+		return []textSegment{{fontKey: td.FontKey, bytes: PrepBytes(xRefTable, s, td.FontName, td.Embed, td.RTL, false)}}
+	}
+	segs := []textSegment{}
+	for _, run := range font.Runs(s, td.FontName, fallbacks, td.RTL) {
+		segs = append(segs, textSegment{
+			fontKey: td.fontKey(run.FontName),
+			bytes:   encodeBytes(xRefTable, run.Text, run.FontName, run.FontName, td.Embed),
+		})
+	}
+	return segs
+}
+
+// showText returns the text showing operators for segs restoring td's primary font if necessary.
+func (td TextDescriptor) showText(segs []textSegment, fontSize int) string {
+	ss := []string{}
+	fontKey := td.FontKey
+	for _, seg := range segs {
+		if seg.fontKey != fontKey {
+			ss = append(ss, fmt.Sprintf("/%s %d Tf", seg.fontKey, fontSize))
+			fontKey = seg.fontKey
+		}
+		ss = append(ss, fmt.Sprintf("(%s) Tj", seg.bytes))
+	}
+	if fontKey != td.FontKey {
+		ss = append(ss, fmt.Sprintf("/%s %d Tf", td.FontKey, fontSize))
+	}
+	return strings.Join(ss, " ")
+}
+
 func deltaAlignMiddle(fontName string, fontSize, lines int, mTop, mBot float64) float64 {
 	return -font.Ascent(fontName, fontSize) + (float64(lines)*font.LineHeight(fontName, fontSize)+mTop+mBot)/2 - mTop
 }
@@ -155,7 +223,7 @@ func CalcBoundingBoxForRects(r1, r2 *types.Rectangle) *types.Rectangle {
 	return calcBoundingBoxForRectAndPoint(bbox, r2.UR)
 }
 
-func calcBoundingBoxForLines(lines []string, x, y float64, fontName string, fontSize int) (*types.Rectangle, string) {
+func calcBoundingBoxForLines(lines []string, x, y float64, fontName string, fallbacks []string, fontSize int) (*types.Rectangle, string) {
 	var (
 		box      *types.Rectangle
 		maxLine  string
@@ -163,7 +231,7 @@ func calcBoundingBoxForLines(lines []string, x, y float64, fontName string, font
 	)
 	// TODO Return error if lines == nil or empty.
 	for _, s := range lines {
-		bbox := CalcBoundingBox(s, x, y, fontName, fontSize)
+		bbox := calcBoundingBox(s, x, y, fontName, fallbacks, fontSize)
 		if bbox.Width() > maxWidth {
 			maxWidth = bbox.Width()
 			maxLine = s
@@ -175,46 +243,60 @@ func calcBoundingBoxForLines(lines []string, x, y float64, fontName string, font
 }
 
 func PrepBytes(xRefTable *XRefTable, s, fontName string, embed, rtl, fillFont bool) string {
+	return prepBytes(xRefTable, s, fontName, fontName, embed, rtl, fillFont)
+}
+
+// prepBytes records used glyphs under gidKey which is fontName unless fontName is used for vertical writing.
+func prepBytes(xRefTable *XRefTable, s, fontName, gidKey string, embed, rtl, fillFont bool) string {
 	if font.IsUserFont(fontName) && (!fillFont || !embed) {
-		if rtl {
-			s = types.Reverse(s)
+		return encodeBytes(xRefTable, font.Shape(s, fontName, rtl), fontName, gidKey, embed)
+	}
+	s1, _ := types.Escape(s)
+	return *s1
+}
+
+// encodeBytes encodes the already shaped string s for rendering using the user font fontName.
+func encodeBytes(xRefTable *XRefTable, s, fontName, gidKey string, embed bool) string {
+	bb := []byte{}
+	if !embed {
+		for _, r := range s {
+			b := make([]byte, 2)
+			binary.BigEndian.PutUint16(b, uint16(r))
+			bb = append(bb, b...)
 		}
-		bb := []byte{}
-		if !embed {
-			for _, r := range s {
+	} else {
+		usedGIDs, ok := xRefTable.UsedGIDs[gidKey]
+		if !ok {
+			xRefTable.UsedGIDs[gidKey] = map[uint16]bool{}
+			usedGIDs = xRefTable.UsedGIDs[gidKey]
+		}
+
+		font.UserFontMetricsLock.RLock()
+		ttf := font.UserFontMetrics[fontName]
+		font.UserFontMetricsLock.RUnlock()
+
+		for _, r := range s {
+			gid, ok := ttf.Chars[uint32(r)]
+			if ok {
 				b := make([]byte, 2)
-				binary.BigEndian.PutUint16(b, uint16(r))
+				binary.BigEndian.PutUint16(b, gid)
 				bb = append(bb, b...)
-			}
-		} else {
-			usedGIDs, ok := xRefTable.UsedGIDs[fontName]
-			if !ok {
-				xRefTable.UsedGIDs[fontName] = map[uint16]bool{}
-				usedGIDs = xRefTable.UsedGIDs[fontName]
-			}
-
-			font.UserFontMetricsLock.RLock()
-			ttf := font.UserFontMetrics[fontName]
-			font.UserFontMetricsLock.RUnlock()
-
-			for _, r := range s {
-				gid, ok := ttf.Chars[uint32(r)]
-				if ok {
-					b := make([]byte, 2)
-					binary.BigEndian.PutUint16(b, gid)
-					bb = append(bb, b...)
-					usedGIDs[gid] = true
-				} // else "invalid char"
-			}
+				usedGIDs[gid] = true
+			} // else "invalid char"
 		}
-		s = string(bb)
 	}
-	s1, _ := types.Escape(s)
+	s1, _ := types.Escape(string(bb))
 	return *s1
 }
 
-func writeStringToBuf(xRefTable *XRefTable, w io.Writer, s string, x, y float64, td TextDescriptor) {
-	s = PrepBytes(xRefTable, s, td.FontName, td.Embed, td.RTL, false)
+func writeStringToBuf(xRefTable *XRefTable, w io.Writer, s string, x, y float64, td TextDescriptor, fontSize int) {
+	s = td.showText(td.segments(xRefTable, s), fontSize)
+	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %.2f %.2f %.2f rg %.2f %.2f Td %d Tr %s ET ",
+		td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, td.FillCol.R, td.FillCol.G, td.FillCol.B, x, y, td.RMode, s)
+}
+
+func writeVerticalStringToBuf(xRefTable *XRefTable, w io.Writer, s string, x, y float64, td TextDescriptor) {
+	s = prepBytes(xRefTable, s, td.FontName, font.VerticalFontName(td.FontName), true, false, false)
 	fmt.Fprintf(w, "BT 0 Tw %.2f %.2f %.2f RG %.2f %.2f %.2f rg %.2f %.2f Td %d Tr (%s) Tj ET ",
 		td.StrokeCol.R, td.StrokeCol.G, td.StrokeCol.B, td.FillCol.R, td.FillCol.G, td.FillCol.B, x, y, td.RMode, s)
 }
@@ -224,7 +306,11 @@ func setFont(w io.Writer, fontID string, fontSize float32) {
 }
 
 func CalcBoundingBox(s string, x, y float64, fontName string, fontSize int) *types.Rectangle {
-	w := font.TextWidth(s, fontName, fontSize)
+	return calcBoundingBox(s, x, y, fontName, nil, fontSize)
+}
+
+func calcBoundingBox(s string, x, y float64, fontName string, fallbacks []string, fontSize int) *types.Rectangle {
+	w := font.TextWidthWithFallbacks(s, fontName, fallbacks, fontSize)
 	h := font.LineHeight(fontName, fontSize)
 	y -= math.Ceil(font.Descent(fontName, fontSize))
 	return types.NewRectangle(x, y, x+w, y+h)
@@ -251,31 +337,62 @@ func horAdjustBoundingBoxForLines(r, box *types.Rectangle, dx, dy float64, x, y
 	}
 }
 
-func prepJustifiedLine(xRefTable *XRefTable, lines *[]string, strbuf []string, strWidth, w float64, fontSize int, fontName string, embed, rtl bool) {
-	blank := PrepBytes(xRefTable, " ", fontName, embed, true, false)
+// ShowText returns the text showing operators for s switching to td's fallback fonts for chars missing in td.FontName.
+func (td TextDescriptor) ShowText(xRefTable *XRefTable, s string, fontSize int) string {
+	return td.showText(td.segments(xRefTable, s), fontSize)
+}
+
+// TextWidth returns the width of s in user space taking td's fallback fonts into account.
+func (td TextDescriptor) TextWidth(s string, fontSize int) float64 {
+	return font.TextWidthWithFallbacks(s, td.FontName, td.fallbackFontNames(), fontSize)
+}
+
+// blank returns the prepared bytes for a blank using the font registered under fontKey.
+func (td TextDescriptor) blank(xRefTable *XRefTable, fontKey string) string {
+	if fontKey != td.FontKey {
+		for _, ff := range td.FallbackFonts {
+			if ff.Key == fontKey {
+				return encodeBytes(xRefTable, " ", ff.Name, ff.Name, td.Embed)
+			}
+		}
+	}
+	return PrepBytes(xRefTable, " ", td.FontName, td.Embed, true, false)
+}
+
+func prepJustifiedLine(xRefTable *XRefTable, lines *[]string, strbuf []string, strWidth, w float64, fontSize int, td TextDescriptor) {
+	fontKey := td.FontKey
 	var sb strings.Builder
 	sb.WriteString("[")
 	wc := len(strbuf)
 	dx := font.GlyphSpaceUnits(float64((w-strWidth))/float64(wc-1), fontSize)
 	for i := 0; i < wc; i++ {
 		j := i
-		if rtl {
+		if td.RTL {
 			j = wc - 1 - i
 		}
-		s := PrepBytes(xRefTable, strbuf[j], fontName, embed, rtl, false)
-		sb.WriteString(fmt.Sprintf(" (%s)", s))
+		for _, seg := range td.segments(xRefTable, strbuf[j]) {
+			if seg.fontKey != fontKey {
+				// Switch to fallback font.
+				sb.WriteString(fmt.Sprintf(" ] TJ /%s %d Tf [", seg.fontKey, fontSize))
+				fontKey = seg.fontKey
+			}
+			sb.WriteString(fmt.Sprintf(" (%s)", seg.bytes))
+		}
 		if i < wc-1 {
-			sb.WriteString(fmt.Sprintf(" %d (%s)", -int(dx), blank))
+			sb.WriteString(fmt.Sprintf(" %d (%s)", -int(dx), td.blank(xRefTable, fontKey)))
 		}
 	}
 	sb.WriteString(" ] TJ")
+	if fontKey != td.FontKey {
+		sb.WriteString(fmt.Sprintf(" /%s %d Tf", td.FontKey, fontSize))
+	}
 	*lines = append(*lines, sb.String())
 }
 
 func newPrepJustifiedString(
 	xRefTable *XRefTable,
-	fontName string,
-	fontSize int) func(lines *[]string, s string, w float64, fontName string, fontSize *int, lastline, parIndent, cjk, rtl bool) int {
+	td TextDescriptor,
+	fontSize int) func(lines *[]string, s string, w float64, fontSize *int, lastline, parIndent bool) int {
 
 	// Not yet rendered content.
 	strbuf := []string{}
@@ -289,18 +406,20 @@ func newPrepJustifiedString(
 	// Indentation string for first line of paragraphs.
 	identPrefix := "    "
 
+	fontName, fallbacks := td.FontName, td.fallbackFontNames()
+
 	blankWidth := font.TextWidth(" ", fontName, fontSize)
 
-	return func(lines *[]string, s string, w float64, fontName string, fontSize *int, lastline, parIndent, embed, rtl bool) int {
+	return func(lines *[]string, s string, w float64, fontSize *int, lastline, parIndent bool) int {
 
 		if len(s) == 0 {
 			if len(strbuf) > 0 {
-				s1 := PrepBytes(xRefTable, strings.Join(strbuf, " "), fontName, embed, rtl, false)
-				if rtl {
+				s1 := td.showText(td.segments(xRefTable, strings.Join(strbuf, " ")), *fontSize)
+				if td.RTL {
 					dx := font.GlyphSpaceUnits(w-strWidth, *fontSize)
-					s = fmt.Sprintf("[ %d (%s) ] TJ ", -int(dx), s1)
+					s = fmt.Sprintf("[ %d ] TJ %s ", -int(dx), s1)
 				} else {
-					s = fmt.Sprintf("(%s) Tj", s1)
+					s = s1
 				}
 				*lines = append(*lines, s)
 				strbuf = []string{}
@@ -323,7 +442,7 @@ func newPrepJustifiedString(
 		}
 
 		for _, s1 := range ss {
-			s1Width := font.TextWidth(s1, fontName, *fontSize)
+			s1Width := font.TextWidthWithFallbacks(s1, fontName, fallbacks, *fontSize)
 			bw := 0.
 			if len(strbuf) > 0 {
 				bw = blankWidth
@@ -339,10 +458,10 @@ func newPrepJustifiedString(
 				*fontSize = fs
 			}
 			if len(strbuf) == 0 {
-				prepJustifiedLine(xRefTable, lines, []string{s1}, s1Width, w, *fontSize, fontName, embed, rtl)
+				prepJustifiedLine(xRefTable, lines, []string{s1}, s1Width, w, *fontSize, td)
 			} else {
 				// Note: Previous lines have whitespace based on bigger font size.
-				prepJustifiedLine(xRefTable, lines, strbuf, strWidth, w, *fontSize, fontName, embed, rtl)
+				prepJustifiedLine(xRefTable, lines, strbuf, strWidth, w, *fontSize, td)
 				strbuf = []string{s1}
 				strWidth = s1Width
 			}
@@ -370,21 +489,21 @@ func preRenderJustifiedText(
 		if width > 0 {
 			ww = width * td.Scale
 		} else {
-			box, _ := calcBoundingBoxForLines(*lines, x, y, td.FontName, *fontSize)
+			box, _ := calcBoundingBoxForLines(*lines, x, y, td.FontName, td.fallbackFontNames(), *fontSize)
 			ww = box.Width() * td.Scale
 		}
 	}
 	ww -= mLeft + mRight + 2*borderWidth
-	prepJustifiedString := newPrepJustifiedString(xRefTable, td.FontName, *fontSize)
+	prepJustifiedString := newPrepJustifiedString(xRefTable, td, *fontSize)
 	l := []string{}
 	for i, s := range *lines {
-		linefeeds := prepJustifiedString(&l, s, ww, td.FontName, fontSize, false, td.ParIndent, td.Embed, td.RTL)
+		linefeeds := prepJustifiedString(&l, s, ww, fontSize, false, td.ParIndent)
 		for j := 0; j < linefeeds; j++ {
 			l = append(l, "")
 		}
 		isLastLine := i == len(*lines)-1
 		if isLastLine {
-			prepJustifiedString(&l, "", ww, td.FontName, fontSize, true, td.ParIndent, td.Embed, td.RTL)
+			prepJustifiedString(&l, "", ww, fontSize, true, td.ParIndent)
 		}
 	}
 	*lines = l
@@ -393,13 +512,13 @@ func preRenderJustifiedText(
 
 func scaleFontSize(r *types.Rectangle, lines []string, scaleAbs bool,
 	scale, width, x, y, mLeft, mRight, borderWidth float64,
-	fontName string, fontSize *int) {
+	fontName string, fallbacks []string, fontSize *int) {
 	if scaleAbs {
 		*fontSize = int(float64(*fontSize) * scale)
 	} else {
 		www := width
 		if width == 0 {
-			box, _ := calcBoundingBoxForLines(lines, x, y, fontName, *fontSize)
+			box, _ := calcBoundingBoxForLines(lines, x, y, fontName, fallbacks, *fontSize)
 			www = box.Width() + mLeft + mRight + 2*borderWidth
 		}
 		*fontSize = int(r.Width() * scale * float64(*fontSize) / www)
@@ -462,7 +581,7 @@ func createBoundingBoxForColumn(xRefTable *XRefTable, r *types.Rectangle, x, y *
 	}
 
 	if td.HAlign != types.AlignJustify {
-		scaleFontSize(r, *lines, td.ScaleAbs, td.Scale, width, *x, *y, mLeft, mRight, borderWidth, td.FontName, fontSize)
+		scaleFontSize(r, *lines, td.ScaleAbs, td.Scale, width, *x, *y, mLeft, mRight, borderWidth, td.FontName, td.fallbackFontNames(), fontSize)
 	}
 
 	// Apply vertical alignment.
@@ -477,7 +596,7 @@ func createBoundingBoxForColumn(xRefTable *XRefTable, r *types.Rectangle, x, y *
 	}
 	*y += math.Ceil(dy1)
 
-	box, maxLine := calcBoundingBoxForLines(*lines, *x, *y, td.FontName, *fontSize)
+	box, maxLine := calcBoundingBoxForLines(*lines, *x, *y, td.FontName, td.fallbackFontNames(), *fontSize)
 	// maxLine for hAlign != AlignJustify only!
 	horizontalWrapUp(box, maxLine, td.HAlign, x, width, ww, mLeft, mRight, borderWidth, td.FontName, fontSize)
 
@@ -552,7 +671,7 @@ func renderText(xRefTable *XRefTable, w io.Writer, lines []string, td TextDescri
 	lh := font.LineHeight(td.FontName, fontSize)
 	for _, s := range lines {
 		if td.HAlign != types.AlignJustify {
-			lineBB := CalcBoundingBox(s, x, y, td.FontName, fontSize)
+			lineBB := calcBoundingBox(s, x, y, td.FontName, td.fallbackFontNames(), fontSize)
 			// Apply horizontal alignment.
 			var dx float64
 			switch td.HAlign {
@@ -567,7 +686,7 @@ func renderText(xRefTable *XRefTable, w io.Writer, lines []string, td TextDescri
 				draw.SetStrokeColor(w, color.Black)
 				draw.DrawRectSimple(w, lineBB)
 			}
-			writeStringToBuf(xRefTable, w, s, x-dx, y, td)
+			writeStringToBuf(xRefTable, w, s, x-dx, y, td, fontSize)
 			y -= lh
 			continue
 		}
@@ -725,6 +844,104 @@ func WordWrap(wrap string, fontName string, fontSize int, width float64) []strin
 	return lines
 }
 
+// createBoundingBoxForVerticalColumns returns the bounding box for lines written top to bottom
+// and sets x/y to the vertical origin of the first glyph of the rightmost column.
+func createBoundingBoxForVerticalColumns(x, y *float64, td TextDescriptor, fontSize int, lines []string,
+	mTop, mBot, mLeft, mRight, borderWidth float64) *types.Rectangle {
+
+	colWidth := font.LineHeight(td.FontName, fontSize)
+
+	var h float64
+	for _, s := range lines {
+		h = math.Max(h, font.TextHeight(s, td.FontName, fontSize))
+	}
+	w := float64(len(lines)) * colWidth
+
+	llx := *x
+	switch td.HAlign {
+	case types.AlignCenter:
+		llx -= w / 2
+	case types.AlignRight:
+		llx -= w
+	}
+
+	lly := *y
+	switch td.VAlign {
+	case types.AlignMiddle:
+		lly -= h / 2
+	case types.AlignTop:
+		lly -= h
+	}
+
+	if td.MinHeight > 0 && h < td.MinHeight {
+		lly -= td.MinHeight - h
+		h = td.MinHeight
+	}
+
+	*x = llx + w - colWidth/2
+	*y = lly + h
+
+	return types.RectForWidthAndHeight(
+		llx-mLeft-borderWidth,
+		lly-mBot-borderWidth,
+		w+mLeft+mRight+2*borderWidth,
+		h+mTop+mBot+2*borderWidth)
+}
+
+func renderVerticalText(xRefTable *XRefTable, w io.Writer, lines []string, td TextDescriptor, x, y float64, fontSize int) {
+	colWidth := font.LineHeight(td.FontName, fontSize)
+	for _, s := range lines {
+		if td.ShowLineBB {
+			h := font.TextHeight(s, td.FontName, fontSize)
+			draw.SetStrokeColor(w, color.Black)
+			draw.DrawRectSimple(w, types.RectForWidthAndHeight(x-colWidth/2, y-h, colWidth, h))
+		}
+		writeVerticalStringToBuf(xRefTable, w, s, x, y, td)
+		x -= colWidth
+	}
+}
+
+// writeVerticalColumns writes lines top to bottom as columns progressing from right to left using a user font.
+func writeVerticalColumns(xRefTable *XRefTable, w io.Writer, r *types.Rectangle, td TextDescriptor, lines []string,
+	x, y float64, fontSize int, mTop, mBot, mLeft, mRight, borderWidth float64) *types.Rectangle {
+
+	x0, y0 := x, y
+
+	colBB := createBoundingBoxForVerticalColumns(&x, &y, td, fontSize, lines, mTop, mBot, mLeft, mRight, borderWidth)
+
+	fmt.Fprint(w, "q ")
+
+	setFont(w, td.FontKey, float32(fontSize))
+	m := matrix.CalcRotateTransformMatrix(td.Rotation, colBB)
+	fmt.Fprintf(w, "%.5f %.5f %.5f %.5f %.5f %.5f cm ", m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1])
+
+	x -= colBB.LL.X
+	y -= colBB.LL.Y
+	colBB.Translate(-colBB.LL.X, -colBB.LL.Y)
+
+	if td.ShowTextBB {
+		renderBackgroundAndBorder(w, td, borderWidth, colBB)
+	}
+
+	if td.ShowMargins {
+		DrawMargins(w, color.LightGray, colBB, borderWidth, mLeft, mRight, mTop, mBot)
+	}
+
+	renderVerticalText(xRefTable, w, lines, td, x, y, fontSize)
+
+	fmt.Fprintf(w, "Q ")
+
+	if td.HairCross {
+		draw.DrawHairCross(w, x0, y0, r)
+	}
+
+	if td.ShowPosition {
+		draw.DrawCircle(w, x0, y0, 5, color.Black, &color.Red)
+	}
+
+	return colBB
+}
+
 // WriteColumn writes a text column using s at position x/y using a certain font, fontsize and a desired horizontal and vertical alignment.
 // Enforce a desired column width by supplying a width > 0 (especially useful for justified text).
 // It returns the bounding box of this column.
@@ -777,6 +994,10 @@ func WriteColumn(xRefTable *XRefTable, w io.Writer, mediaBox, region *types.Rect
 
 	lines := SplitMultilineStr(s)
 
+	if td.Vertical && font.IsUserFont(td.FontName) {
+		return writeVerticalColumns(xRefTable, w, r, td, lines, x, y, fontSize, mTop, mBot, mLeft, mRight, borderWidth)
+	}
+
 	if !td.ScaleAbs {
 		if td.Scale > 1 {
 			td.Scale = 1
@@ -0,0 +1,215 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TIFF tags (TIFF 6.0 Section 8).
+const (
+	tiffImageWidth      = 256
+	tiffImageLength     = 257
+	tiffBitsPerSample   = 258
+	tiffCompression     = 259
+	tiffPhotometric     = 262
+	tiffFillOrder       = 266
+	tiffStripOffsets    = 273
+	tiffSamplesPerPixel = 277
+	tiffStripByteCounts = 279
+	tiffXResolution     = 282
+	tiffYResolution     = 283
+	tiffT6Options       = 293
+	tiffResolutionUnit  = 296
+	tiffTileWidth       = 322
+)
+
+const tiffCompressionG4 = 4
+
+var errTIFFCorrupt = errors.New("pdfcpu: invalid TIFF file")
+
+// tiffIFD represents the image file directory entries relevant for import.
+type tiffIFD struct {
+	tags map[int][]uint64 // rationals are represented by numerator and denominator
+	next int64            // offset of the next IFD
+}
+
+func (ifd tiffIFD) val(tag int, def uint64) uint64 {
+	if v := ifd.tags[tag]; len(v) > 0 {
+		return v[0]
+	}
+	return def
+}
+
+// parseTIFFIFD parses the image file directory at off.
+func parseTIFFIFD(bb []byte, off int64, byteOrder binary.ByteOrder) (*tiffIFD, error) {
+	if off < 8 || off+2 > int64(len(bb)) {
+		return nil, errTIFFCorrupt
+	}
+
+	n := int64(byteOrder.Uint16(bb[off:]))
+	end := off + 2 + n*12
+	if end+4 > int64(len(bb)) {
+		return nil, errTIFFCorrupt
+	}
+
+	ifd := &tiffIFD{tags: map[int][]uint64{}, next: int64(byteOrder.Uint32(bb[end:]))}
+
+	for e := bb[off+2 : end]; len(e) >= 12; e = e[12:] {
+		tag, typ, count := int(byteOrder.Uint16(e)), byteOrder.Uint16(e[2:]), int64(byteOrder.Uint32(e[4:]))
+
+		var size int64
+		switch typ {
+		case 1: // BYTE
+			size = 1
+		case 3: // SHORT
+			size = 2
+		case 4: // LONG
+			size = 4
+		case 5: // RATIONAL
+			size, count = 4, 2*count
+		default:
+			continue
+		}
+
+		data := e[8:12]
+		if size*count > 4 {
+			o := int64(byteOrder.Uint32(e[8:]))
+			if o < 0 || o+size*count > int64(len(bb)) {
+				return nil, errTIFFCorrupt
+			}
+			data = bb[o : o+size*count]
+		}
+
+		vv := make([]uint64, count)
+		for i := range vv {
+			switch size {
+			case 1:
+				vv[i] = uint64(data[i])
+			case 2:
+				vv[i] = uint64(byteOrder.Uint16(data[2*i:]))
+			case 4:
+				vv[i] = uint64(byteOrder.Uint32(data[4*i:]))
+			}
+		}
+		ifd.tags[tag] = vv
+	}
+
+	return ifd, nil
+}
+
+// resolution returns the horizontal and vertical resolution in dots per inch or 0 if unknown.
+func (ifd tiffIFD) resolution() (float64, float64) {
+	var f float64
+	switch ifd.val(tiffResolutionUnit, 2) {
+	case 2: // inch
+		f = 1
+	case 3: // centimeter
+		f = 2.54
+	default:
+		return 0, 0
+	}
+
+	res := func(tag int) float64 {
+		v := ifd.tags[tag]
+		if len(v) < 2 || v[1] == 0 {
+			return 0
+		}
+		return float64(v[0]) / float64(v[1]) * f
+	}
+
+	x, y := res(tiffXResolution), res(tiffYResolution)
+	if x == 0 || y == 0 {
+		return 0, 0
+	}
+
+	return x, y
+}
+
+// ccittG4Data returns the CCITT Group 4 encoded data of a WhiteIsZero bilevel image stored in a single strip.
+func (ifd tiffIFD) ccittG4Data(bb []byte) ([]byte, bool) {
+	if ifd.val(tiffCompression, 1) != tiffCompressionG4 ||
+		ifd.val(tiffBitsPerSample, 1) != 1 ||
+		ifd.val(tiffSamplesPerPixel, 1) != 1 ||
+		ifd.val(tiffT6Options, 0)&2 > 0 || // uncompressed mode
+		ifd.tags[tiffTileWidth] != nil ||
+		ifd.val(tiffPhotometric, 0) != 0 {
+		return nil, false
+	}
+
+	offs, counts := ifd.tags[tiffStripOffsets], ifd.tags[tiffStripByteCounts]
+	if len(offs) != 1 || len(counts) != 1 || offs[0]+counts[0] > uint64(len(bb)) {
+		return nil, false
+	}
+
+	data := bb[offs[0] : offs[0]+counts[0]]
+
+	if ifd.val(tiffFillOrder, 1) == 2 {
+		// Lower column values are stored in the lower-order bits.
+		rev := make([]byte, len(data))
+		for i, b := range data {
+			rev[i] = bits.Reverse8(b)
+		}
+		data = rev
+	}
+
+	return data, true
+}
+
+// createCCITTG4ImageStreamDict returns a stream dict for CCITT Group 4 encoded data.
+func createCCITTG4ImageStreamDict(buf []byte, w, h int) (*types.StreamDict, error) {
+	parms := types.Dict(
+		map[string]types.Object{
+			"K":       types.Integer(-1),
+			"Columns": types.Integer(w),
+			"Rows":    types.Integer(h),
+		},
+	)
+
+	sd := &types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":             types.Name("XObject"),
+				"Subtype":          types.Name("Image"),
+				"Width":            types.Integer(w),
+				"Height":           types.Integer(h),
+				"BitsPerComponent": types.Integer(1),
+				"ColorSpace":       types.Name(DeviceGrayCS),
+				"DecodeParms":      parms,
+			},
+		),
+		Content: buf,
+	}
+
+	sd.InsertName("Filter", filter.CCITTFax)
+
+	// Calling Encode without FilterPipeline ensures the encoded data in sd.Raw.
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	sd.Content = nil
+
+	sd.FilterPipeline = []types.PDFFilter{{Name: filter.CCITTFax, DecodeParms: parms}}
+
+	return sd, nil
+}
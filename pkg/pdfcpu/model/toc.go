@@ -0,0 +1,72 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	defaultTOCTitle    = "Contents"
+	defaultTOCFontName = "Helvetica"
+	defaultTOCFontSize = 12
+	defaultTOCIndent   = 20. // Indentation per outline level.
+	defaultTOCMargin   = 72. // 1 inch
+)
+
+// TOC represents the command details for generating table of contents pages from the outline of a document.
+type TOC struct {
+	Title      string            // Heading of the first TOC page, may be empty.
+	InsertAt   int               // Page number the TOC pages are inserted before, 0 appends the TOC.
+	MaxLevel   int               // Deepest outline level covered starting with 1, 0 covers all levels.
+	FontName   string            // Name of the core or user font to be used.
+	FontSize   int               // Font size in points for the entries.
+	Indent     float64           // Indentation per outline level.
+	Margin     float64           // Page margin.
+	PageLabels bool              // Create page labels for documents without any, TOC pages are labeled with roman numerals.
+	InpUnit    types.DisplayUnit // Input display unit.
+}
+
+// DefaultTOCConfig returns the default table of contents configuration.
+func DefaultTOCConfig() *TOC {
+	return &TOC{
+		Title:      defaultTOCTitle,
+		InsertAt:   1,
+		FontName:   defaultTOCFontName,
+		FontSize:   defaultTOCFontSize,
+		Indent:     defaultTOCIndent,
+		Margin:     defaultTOCMargin,
+		PageLabels: true,
+	}
+}
+
+// TitleFontSize returns the font size of the TOC heading.
+func (toc TOC) TitleFontSize() int {
+	return toc.FontSize * 3 / 2
+}
+
+// LineHeight returns the distance between two consecutive TOC entries.
+func (toc TOC) LineHeight() float64 {
+	return float64(toc.FontSize) * 1.5
+}
+
+func (toc TOC) String() string {
+	return fmt.Sprintf("TOC conf: title=%q, insertAt=%d, maxLevel=%d, font=%s %d, indent=%.2f, margin=%.2f, labels=%t\n",
+		toc.Title, toc.InsertAt, toc.MaxLevel, toc.FontName, toc.FontSize, toc.Indent, toc.Margin, toc.PageLabels)
+}
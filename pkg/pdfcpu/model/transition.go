@@ -0,0 +1,182 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// TransitionStyles are the page transition styles as defined in 12.4.4 Transitions.
+// Fly, Push, Cover, Uncover and Fade are supported since PDF 1.5.
+var TransitionStyles = []string{"Split", "Blinds", "Box", "Wipe", "Dissolve", "Glitter", "R", "Fly", "Push", "Cover", "Uncover", "Fade"}
+
+// TransitionDirectionNone represents the direction /None which is only allowed for Fly.
+const TransitionDirectionNone = -1
+
+var transitionDirections = map[string][]int{
+	"Wipe":    {0, 90, 180, 270},
+	"Glitter": {0, 270, 315},
+	"Fly":     {0, 90, 180, 270, TransitionDirectionNone},
+	"Push":    {0, 90, 180, 270},
+	"Cover":   {0, 90, 180, 270},
+	"Uncover": {0, 90, 180, 270},
+}
+
+// PageTransition represents the transition effect used when moving to a page during a presentation
+// together with the page's auto-advance duration.
+type PageTransition struct {
+	Style       string  // Transition style, see TransitionStyles. "" leaves the transition dict untouched.
+	Duration    float64 // Transition duration in seconds, 0 for the default of 1 second.
+	Dimension   string  // H(orizontal) or V(ertical) for Split and Blinds.
+	Motion      string  // I(nward) or O(utward) for Split, Box and Fly.
+	Direction   int     // Direction of motion in degrees counterclockwise starting from left-to-right for Wipe, Glitter, Fly, Push, Cover and Uncover.
+	Scale       float64 // Starting or ending scale for Fly, 0 for the default of 1.
+	Opaque      bool    // Fly: the flying area is rectangular and opaque.
+	AutoAdvance float64 // Maximum display duration of the page in seconds, 0 for none.
+}
+
+// Validate checks t for consistency.
+func (t PageTransition) Validate() error {
+	if t.Style == "" && t.AutoAdvance == 0 {
+		return errors.New("pdfcpu: page transition: missing style or auto advance")
+	}
+
+	if t.AutoAdvance < 0 {
+		return errors.Errorf("pdfcpu: page transition: invalid auto advance: %.2f", t.AutoAdvance)
+	}
+
+	if t.Style == "" {
+		return nil
+	}
+
+	if !types.MemberOf(t.Style, TransitionStyles) {
+		return errors.Errorf("pdfcpu: page transition: invalid style: %s", t.Style)
+	}
+
+	if t.Duration < 0 {
+		return errors.Errorf("pdfcpu: page transition: invalid duration: %.2f", t.Duration)
+	}
+
+	if t.Dimension != "" {
+		if t.Style != "Split" && t.Style != "Blinds" {
+			return errors.Errorf("pdfcpu: page transition: dimension not supported for %s", t.Style)
+		}
+		if t.Dimension != "H" && t.Dimension != "V" {
+			return errors.Errorf("pdfcpu: page transition: invalid dimension: %s", t.Dimension)
+		}
+	}
+
+	if t.Motion != "" {
+		if !types.MemberOf(t.Style, []string{"Split", "Box", "Fly"}) {
+			return errors.Errorf("pdfcpu: page transition: motion not supported for %s", t.Style)
+		}
+		if t.Motion != "I" && t.Motion != "O" {
+			return errors.Errorf("pdfcpu: page transition: invalid motion: %s", t.Motion)
+		}
+	}
+
+	if t.Direction != 0 {
+		dirs, ok := transitionDirections[t.Style]
+		if !ok {
+			return errors.Errorf("pdfcpu: page transition: direction not supported for %s", t.Style)
+		}
+		if !types.IntMemberOf(t.Direction, dirs) {
+			return errors.Errorf("pdfcpu: page transition: invalid direction for %s: %d", t.Style, t.Direction)
+		}
+	}
+
+	if (t.Scale != 0 || t.Opaque) && t.Style != "Fly" {
+		return errors.Errorf("pdfcpu: page transition: scale and opaque only supported for Fly")
+	}
+
+	if t.Scale < 0 {
+		return errors.Errorf("pdfcpu: page transition: invalid scale: %.2f", t.Scale)
+	}
+
+	return nil
+}
+
+// Dict returns the transition dict for t.
+func (t PageTransition) Dict() types.Dict {
+	d := types.Dict{"Type": types.Name("Trans"), "S": types.Name(t.Style)}
+
+	if t.Duration > 0 {
+		d["D"] = types.Float(t.Duration)
+	}
+	if t.Dimension != "" {
+		d["Dm"] = types.Name(t.Dimension)
+	}
+	if t.Motion != "" {
+		d["M"] = types.Name(t.Motion)
+	}
+	if _, ok := transitionDirections[t.Style]; ok {
+		if t.Direction == TransitionDirectionNone {
+			d["Di"] = types.Name("None")
+		} else {
+			d["Di"] = types.Integer(t.Direction)
+		}
+	}
+	if t.Scale > 0 {
+		d["SS"] = types.Float(t.Scale)
+	}
+	if t.Opaque {
+		d["B"] = types.Boolean(true)
+	}
+
+	return d
+}
+
+func (t PageTransition) String() string {
+	var ss []string
+
+	if t.Style != "" {
+		ss = append(ss, t.Style)
+		if t.Duration > 0 {
+			ss = append(ss, "duration:"+strconv.FormatFloat(t.Duration, 'f', -1, 64)+"s")
+		}
+		if t.Dimension != "" {
+			ss = append(ss, "dimension:"+t.Dimension)
+		}
+		if t.Motion != "" {
+			ss = append(ss, "motion:"+t.Motion)
+		}
+		if _, ok := transitionDirections[t.Style]; ok {
+			dir := "none"
+			if t.Direction != TransitionDirectionNone {
+				dir = strconv.Itoa(t.Direction)
+			}
+			ss = append(ss, "direction:"+dir)
+		}
+		if t.Scale > 0 {
+			ss = append(ss, "scale:"+strconv.FormatFloat(t.Scale, 'f', -1, 64))
+		}
+		if t.Opaque {
+			ss = append(ss, "opaque")
+		}
+	}
+
+	if t.AutoAdvance > 0 {
+		ss = append(ss, fmt.Sprintf("advance:%ss", strconv.FormatFloat(t.AutoAdvance, 'f', -1, 64)))
+	}
+
+	return strings.Join(ss, " ")
+}
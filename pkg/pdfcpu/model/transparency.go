@@ -0,0 +1,26 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+// TransparencyStats represents the outcome of flattening transparency.
+type TransparencyStats struct {
+	ContentStreams int // Number of rewritten page, form, pattern and glyph content streams.
+	Images         int // Number of images composited with their soft mask.
+	ExtGStates     int // Number of graphics state parameter dicts made opaque.
+	Groups         int // Number of removed transparency groups.
+	SoftMasks      int // Number of dropped soft masks of graphics states.
+}
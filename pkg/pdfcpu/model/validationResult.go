@@ -0,0 +1,255 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// The severities of a validation finding.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+)
+
+// ValidationFinding represents a single validation error or warning.
+type ValidationFinding struct {
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+	ObjNr    int    `json:"objNr,omitempty"`   // Object being validated, 0 if unknown.
+	PageNr   int    `json:"page,omitempty"`    // Page being validated, 0 if unknown.
+	SpecRef  string `json:"specRef,omitempty"` // ISO 32000 section, eg. "7.7.3.3".
+}
+
+func (vf ValidationFinding) String() string {
+	var ss []string
+	if vf.ObjNr > 0 {
+		ss = append(ss, fmt.Sprintf("obj#:%d", vf.ObjNr))
+	}
+	if vf.PageNr > 0 {
+		ss = append(ss, fmt.Sprintf("page:%d", vf.PageNr))
+	}
+	if vf.SpecRef != "" {
+		ss = append(ss, "ISO 32000 "+vf.SpecRef)
+	}
+	s := fmt.Sprintf("%s: %s", vf.Severity, vf.Message)
+	if len(ss) > 0 {
+		s += " (" + strings.Join(ss, ", ") + ")"
+	}
+	return s
+}
+
+// ValidationResult collects the findings of validating a file.
+type ValidationResult struct {
+	FileName        string              `json:"source,omitempty"`
+	Mode            string              `json:"mode"`
+	Valid           bool                `json:"valid"`
+	ContinueOnError bool                `json:"-"` // Keep validating after an error.
+	Findings        []ValidationFinding `json:"findings"`
+	invalid         map[int]bool        // Objects reported invalid.
+}
+
+// NewValidationResult returns a new validation result for fileName.
+func NewValidationResult(fileName string, conf *Configuration, continueOnError bool) *ValidationResult {
+	return &ValidationResult{
+		FileName:        fileName,
+		Mode:            conf.ValidationModeString(),
+		Valid:           true,
+		ContinueOnError: continueOnError,
+		Findings:        []ValidationFinding{},
+	}
+}
+
+// Add appends a finding.
+func (vr *ValidationResult) Add(severity, msg string, objNr, pageNr int, specRef string) {
+	if severity == SeverityError {
+		vr.Valid = false
+	}
+	vr.Findings = append(vr.Findings, ValidationFinding{
+		Severity: severity,
+		Message:  strings.TrimSpace(msg),
+		ObjNr:    objNr,
+		PageNr:   pageNr,
+		SpecRef:  specRef,
+	})
+}
+
+// Errors returns the number of error findings.
+func (vr ValidationResult) Errors() int {
+	i := 0
+	for _, f := range vr.Findings {
+		if f.Severity == SeverityError {
+			i++
+		}
+	}
+	return i
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+}
+
+type sarifLogicalLocation struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties ValidationFinding `json:"properties"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+func sarifRuleID(f ValidationFinding) string {
+	if f.SpecRef == "" {
+		return "pdfcpu"
+	}
+	return "ISO32000-" + f.SpecRef
+}
+
+// ValidationError records err as a finding of validating page pageNr (0 for document level structures) against spec section specRef.
+// It returns nil if validation continues after errors and err otherwise.
+func (xRefTable *XRefTable) ValidationError(err error, pageNr int, specRef string) error {
+	vr := xRefTable.Findings
+	if err == nil || vr == nil {
+		return err
+	}
+	if !vr.ContinueOnError && !vr.Valid {
+		// Already recorded further down.
+		return err
+	}
+	objNr := xRefTable.CurObj
+	if objNr > 0 {
+		if vr.invalid[objNr] {
+			// Each invalid object is reported once only.
+			return nil
+		}
+		if vr.invalid == nil {
+			vr.invalid = map[int]bool{}
+		}
+		vr.invalid[objNr] = true
+	}
+	vr.Add(SeverityError, err.Error(), objNr, pageNr, specRef)
+	if !vr.ContinueOnError {
+		return err
+	}
+	return nil
+}
+
+// ValidationWarning records a digested spec violation.
+func (xRefTable *XRefTable) ValidationWarning(msg string) {
+	if vr := xRefTable.Findings; vr != nil {
+		vr.Add(SeverityWarning, msg, xRefTable.CurObj, 0, "")
+	}
+}
+
+// ValidationResultsSARIF returns vrs as a SARIF 2.1.0 log.
+func ValidationResultsSARIF(vrs []*ValidationResult) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "pdfcpu",
+			Version:        VersionStr,
+			InformationURI: "https://pdfcpu.io",
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	rules := map[string]bool{}
+
+	for _, vr := range vrs {
+		for _, f := range vr.Findings {
+			id := sarifRuleID(f)
+			if !rules[id] {
+				rules[id] = true
+				desc := "pdfcpu validation"
+				if f.SpecRef != "" {
+					desc = "ISO 32000 section " + f.SpecRef
+				}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: desc}})
+			}
+
+			loc := sarifLocation{PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: vr.FileName}}}
+			if f.PageNr > 0 {
+				loc.LogicalLocations = append(loc.LogicalLocations, sarifLogicalLocation{Name: fmt.Sprintf("page %d", f.PageNr), Kind: "page"})
+			}
+			if f.ObjNr > 0 {
+				loc.LogicalLocations = append(loc.LogicalLocations, sarifLogicalLocation{Name: fmt.Sprintf("obj#%d", f.ObjNr), Kind: "object"})
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:     id,
+				Level:      f.Severity,
+				Message:    sarifMessage{Text: f.Message},
+				Locations:  []sarifLocation{loc},
+				Properties: f,
+			})
+		}
+	}
+
+	l := sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	}
+
+	return json.MarshalIndent(l, "", "\t")
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationErrorReportsObjectsOnce(t *testing.T) {
+	vr := NewValidationResult("test.pdf", NewDefaultConfiguration(), true)
+	xRefTable := &XRefTable{Findings: vr}
+
+	for _, objNr := range []int{5, 5, 6, 0, 0, 5} {
+		xRefTable.CurObj = objNr
+		if err := xRefTable.ValidationError(errors.New("invalid"), 1, "7.7.3.3"); err != nil {
+			t.Fatalf("obj#%d: want nil, got %v\n", objNr, err)
+		}
+	}
+
+	// Errors not related to an object are always reported.
+	if vr.Valid || vr.Errors() != 4 {
+		t.Fatalf("want 4 errors, got %d\n", vr.Errors())
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// PathSegment represents a path construction operator in page space.
+type PathSegment struct {
+	Op     string        `json:"op"` // m(oveto), l(ineto), c(urveto) or h (closepath).
+	Points []types.Point `json:"points,omitempty"`
+}
+
+// PathColor represents a fill or stroke color.
+type PathColor struct {
+	Space  string    `json:"space"` // DeviceGray, DeviceRGB, DeviceCMYK or the family of any other color space.
+	Values []float64 `json:"values,omitempty"`
+}
+
+// VectorPath represents a painted or clipping path of a page.
+// Coordinates, line width and dash pattern are given in default user space of the page.
+type VectorPath struct {
+	Segments    []PathSegment `json:"segments"`
+	Fill        bool          `json:"fill"`
+	Stroke      bool          `json:"stroke"`
+	EvenOdd     bool          `json:"evenOdd,omitempty"` // Use the even-odd rule for filling and clipping.
+	Clip        bool          `json:"clip,omitempty"`    // The path modifies the clipping path.
+	FillColor   *PathColor    `json:"fillColor,omitempty"`
+	StrokeColor *PathColor    `json:"strokeColor,omitempty"`
+	LineWidth   float64       `json:"lineWidth,omitempty"`
+	LineCap     int           `json:"lineCap,omitempty"`
+	LineJoin    int           `json:"lineJoin,omitempty"`
+	MiterLimit  float64       `json:"miterLimit,omitempty"`
+	Dash        []float64     `json:"dash,omitempty"`
+	DashPhase   float64       `json:"dashPhase,omitempty"`
+}
+
+// PageVectorPaths represents the vector paths of a page in content order.
+type PageVectorPaths struct {
+	PageNr   int              `json:"page"`
+	MediaBox *types.Rectangle `json:"mediaBox"`
+	Paths    []VectorPath     `json:"paths"`
+}
+
+func svgNumber(f float64) string {
+	return strconv.FormatFloat(math.Round(f*1000)/1000, 'f', -1, 64)
+}
+
+// RGB returns an approximation of c in sRGB.
+// Colors of color spaces other than the device color spaces, separation and DeviceN are rendered black.
+func (c PathColor) RGB() (r, g, b float64) {
+	v := c.Values
+	switch c.Space {
+	case DeviceGrayCS:
+		if len(v) == 1 {
+			return v[0], v[0], v[0]
+		}
+	case DeviceRGBCS:
+		if len(v) == 3 {
+			return v[0], v[1], v[2]
+		}
+	case DeviceCMYKCS:
+		if len(v) == 4 {
+			return (1 - v[0]) * (1 - v[3]), (1 - v[1]) * (1 - v[3]), (1 - v[2]) * (1 - v[3])
+		}
+	case "Separation", "DeviceN":
+		if len(v) > 0 {
+			return 1 - v[0], 1 - v[0], 1 - v[0]
+		}
+	}
+	return 0, 0, 0
+}
+
+func (c *PathColor) svg() string {
+	if c == nil {
+		return "#000000"
+	}
+	r, g, b := c.RGB()
+	f := func(x float64) int {
+		return int(math.Round(math.Max(0, math.Min(1, x)) * 255))
+	}
+	return fmt.Sprintf("#%02x%02x%02x", f(r), f(g), f(b))
+}
+
+func (vp VectorPath) svgPathData() string {
+	ss := make([]string, 0, len(vp.Segments))
+	for _, seg := range vp.Segments {
+		s := strings.ToUpper(seg.Op)
+		if s == "H" {
+			s = "Z"
+		}
+		for _, p := range seg.Points {
+			s += " " + svgNumber(p.X) + " " + svgNumber(p.Y)
+		}
+		ss = append(ss, s)
+	}
+	return strings.Join(ss, " ")
+}
+
+// SVG returns an SVG path element for vp or "" for paths that are not painted.
+func (vp VectorPath) SVG() string {
+	if !vp.Fill && !vp.Stroke {
+		return ""
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, `<path d="%s"`, vp.svgPathData())
+
+	if vp.Fill {
+		fmt.Fprintf(&sb, ` fill="%s"`, vp.FillColor.svg())
+		if vp.EvenOdd {
+			sb.WriteString(` fill-rule="evenodd"`)
+		}
+	} else {
+		sb.WriteString(` fill="none"`)
+	}
+
+	if vp.Stroke {
+		fmt.Fprintf(&sb, ` stroke="%s" stroke-width="%s"`, vp.StrokeColor.svg(), svgNumber(math.Max(vp.LineWidth, 0.1)))
+		switch vp.LineCap {
+		case 1:
+			sb.WriteString(` stroke-linecap="round"`)
+		case 2:
+			sb.WriteString(` stroke-linecap="square"`)
+		}
+		switch vp.LineJoin {
+		case 1:
+			sb.WriteString(` stroke-linejoin="round"`)
+		case 2:
+			sb.WriteString(` stroke-linejoin="bevel"`)
+		}
+		if vp.MiterLimit > 0 && vp.MiterLimit != 4 {
+			fmt.Fprintf(&sb, ` stroke-miterlimit="%s"`, svgNumber(vp.MiterLimit))
+		}
+		if len(vp.Dash) > 0 {
+			ss := make([]string, len(vp.Dash))
+			for i, f := range vp.Dash {
+				ss[i] = svgNumber(f)
+			}
+			fmt.Fprintf(&sb, ` stroke-dasharray="%s"`, strings.Join(ss, " "))
+			if vp.DashPhase != 0 {
+				fmt.Fprintf(&sb, ` stroke-dashoffset="%s"`, svgNumber(vp.DashPhase))
+			}
+		}
+	}
+
+	sb.WriteString("/>")
+
+	return sb.String()
+}
+
+// SVG returns an SVG document rendering the painted paths of pvp.
+// Clipping, shadings, patterns, images and text are not rendered.
+func (pvp PageVectorPaths) SVG() string {
+	r := pvp.MediaBox
+	if r == nil {
+		r = types.RectForFormat("A4")
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "<svg xmlns=\"http://www.w3.org/2000/svg\" viewBox=\"%s %s %s %s\" width=\"%s\" height=\"%s\">\n",
+		svgNumber(r.LL.X), svgNumber(r.LL.Y), svgNumber(r.Width()), svgNumber(r.Height()), svgNumber(r.Width()), svgNumber(r.Height()))
+
+	// Flip the y axis of the PDF coordinate system.
+	fmt.Fprintf(&sb, "<g transform=\"matrix(1 0 0 -1 0 %s)\">\n", svgNumber(r.LL.Y+r.UR.Y))
+
+	for _, vp := range pvp.Paths {
+		if s := vp.SVG(); s != "" {
+			sb.WriteString(s + "\n")
+		}
+	}
+
+	sb.WriteString("</g>\n</svg>\n")
+
+	return sb.String()
+}
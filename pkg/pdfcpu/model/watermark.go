@@ -21,9 +21,12 @@ import (
 	"io"
 	"math"
 
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/barcode"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/qrcode"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/svg"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 )
 
@@ -44,6 +47,9 @@ const (
 	WMText = iota
 	WMImage
 	WMPDF
+	WMQRCode
+	WMBarcode
+	WMSVG
 )
 
 type formCache map[types.Rectangle]*types.IndirectRef
@@ -57,8 +63,8 @@ type PdfResources struct {
 // Watermark represents the basic structure and command details for the commands "Stamp" and "Watermark".
 type Watermark struct {
 	OnTop                     bool                // if true STAMP else WATERMARK.
-	Mode                      int                 // WMText, WMImage or WMPDF
-	FileName                  string              // image or PDF file name
+	Mode                      int                 // WMText, WMImage, WMPDF, WMQRCode, WMBarcode or WMSVG
+	FileName                  string              // image, PDF or SVG file name
 	Image                     io.Reader           // image reader
 	PDF                       io.ReadSeeker       // PDF read seeker
 	TextString                string              // raw display text.
@@ -73,6 +79,7 @@ type Watermark struct {
 	ScaledFontSize            int                 // font scaling factor for a specific page
 	ScriptName                string              // ISO 15924: Hans, Hant, Hira, Kana, Jpan, Hang, Kore: if set, font will not be embedded.
 	RTL                       bool                // if true, render text from right to left
+	Vertical                  bool                // if true, render text top to bottom using a user font, lines progress from right to left
 	Color                     color.SimpleColor   // text fill color(=non stroking color) for backwards compatibility.
 	FillColor                 color.SimpleColor   // text fill color(=non stroking color).
 	StrokeColor               color.SimpleColor   // text stroking color
@@ -93,6 +100,20 @@ type Watermark struct {
 	Update                    bool                // true for updating instead of adding a page watermark.
 	Ocg, ExtGState, Font, Img *types.IndirectRef  // resources
 	Width, Height             int                 // image or page dimensions
+	Size                      float64             // fixed edge length for QR codes, fixed width for barcodes, overrides scaling.
+	QRLevel                   qrcode.Level        // QR code error correction level.
+	QRCode                    *qrcode.Code        // QR code for a specific page
+	Symbology                 barcode.Symbology   // barcode symbology.
+	BarHeight                 float64             // fixed barcode height.
+	Barcode                   *barcode.Code       // barcode for a specific page
+	SVG                       *svg.SVG            // SVG converted to PDF content
+
+	// rich text
+	RichText  bool                          // true for text using inline style markup or leading.
+	RichFonts map[string]*types.IndirectRef // font resources of style runs other than FontName.
+	Leading   float64                       // line spacing factor, 1.0 = font line height.
+
+	FallbackFonts []string // user fonts taking over chars missing in FontName.
 
 	// PDF stamp
 	bbPDF                   *types.Rectangle     // bounding box
@@ -159,6 +180,21 @@ func (wm Watermark) IsImage() bool {
 	return wm.Mode == WMImage
 }
 
+// IsQRCode returns true if the watermark content is a QR code.
+func (wm Watermark) IsQRCode() bool {
+	return wm.Mode == WMQRCode
+}
+
+// IsBarcode returns true if the watermark content is a linear barcode.
+func (wm Watermark) IsBarcode() bool {
+	return wm.Mode == WMBarcode
+}
+
+// IsSVG returns true if the watermark content is SVG.
+func (wm Watermark) IsSVG() bool {
+	return wm.Mode == WMSVG
+}
+
 // Typ returns the nature of wm.
 func (wm Watermark) Typ() string {
 	if wm.IsImage() {
@@ -167,6 +203,15 @@ func (wm Watermark) Typ() string {
 	if wm.IsPDF() {
 		return "pdf"
 	}
+	if wm.IsQRCode() {
+		return "qrcode"
+	}
+	if wm.IsBarcode() {
+		return "barcode"
+	}
+	if wm.IsSVG() {
+		return "svg"
+	}
 	return "text"
 }
 
@@ -254,6 +299,14 @@ func (wm *Watermark) CalcBoundingBox(pageNr int) {
 
 	ar := bb.AspectRatio()
 
+	if (wm.IsQRCode() || wm.IsBarcode()) && wm.Size > 0 {
+		bb.UR.X = bb.LL.X + wm.Size
+		bb.UR.Y = bb.LL.Y + wm.Size/ar
+		wm.Bb = bb
+		wm.ScaleEff = wm.Size / float64(wm.Width)
+		return
+	}
+
 	if wm.ScaleAbs {
 		w1 := wm.Scale * bb.Width()
 		bb.UR.X = bb.LL.X + w1
@@ -430,7 +483,7 @@ func (wm *Watermark) CalcTransformMatrix() matrix.Matrix {
 	cos = math.Cos(float64(r) * float64(DegToRad))
 
 	var dx, dy float64
-	if !wm.IsImage() && !wm.IsPDF() {
+	if wm.IsText() {
 		dy = wm.Bb.LL.Y
 	}
 
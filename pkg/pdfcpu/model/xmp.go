@@ -0,0 +1,67 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// XMP namespaces.
+const (
+	NSRDF = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	NSDC  = "http://purl.org/dc/elements/1.1/"
+	NSPDF = "http://ns.adobe.com/pdf/1.3/"
+	NSXMP = "http://ns.adobe.com/xap/1.0/"
+)
+
+// XMPPrefixes maps the namespaces of the properties covered by XMP to their preferred prefixes.
+var XMPPrefixes = map[string]string{
+	NSDC:  "dc",
+	NSPDF: "pdf",
+	NSXMP: "xmp",
+}
+
+// XMP represents the Dublin Core, Adobe PDF and XMP basic properties of a document's XMP metadata packet.
+// When used for updating metadata, zero values leave the corresponding properties untouched.
+type XMP struct {
+	Title        string     // dc:title
+	Creators     []string   // dc:creator
+	Description  string     // dc:description
+	Subjects     []string   // dc:subject
+	Publishers   []string   // dc:publisher
+	Rights       string     // dc:rights
+	Producer     string     // pdf:Producer
+	Keywords     string     // pdf:Keywords
+	Trapped      *bool      // pdf:Trapped
+	CreatorTool  string     // xmp:CreatorTool
+	CreateDate   *time.Time // xmp:CreateDate
+	ModifyDate   *time.Time // xmp:ModifyDate
+	MetadataDate *time.Time // xmp:MetadataDate
+	Schemas      []string   // Namespaces of all properties present including unknown schemas, read only.
+}
+
+// MetadataMismatch represents a document info dict entry disagreeing with its XMP counterpart.
+type MetadataMismatch struct {
+	Key  string // Title, Author, Subject, Keywords or Creator
+	Info string // value of the info dict entry
+	XMP  string // value of the corresponding XMP property
+}
+
+func (m MetadataMismatch) String() string {
+	return fmt.Sprintf("%s: info=%q xmp=%q", m.Key, m.Info, m.XMP)
+}
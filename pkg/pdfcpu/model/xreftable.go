@@ -86,6 +86,7 @@ type Enc struct {
 	L, P, R, V int
 	Emd        bool // encrypt meta data
 	ID         []byte
+	Recipients [][]byte // PKCS#7 enveloped data of the public key security handler
 }
 
 // AnnotMap represents annotations by object number of the corresponding annotation dict.
@@ -116,6 +117,8 @@ type XRefTable struct {
 	AES4Strings         bool
 	AES4Streams         bool
 	AES4EmbeddedStreams bool
+	CryptFilters        map[string]bool // Crypt filters defined in the Encrypt dict's CF mapped to their use of AES.
+	StmF, StrF, EFF     string          // Default crypt filters for streams, strings and embedded files, "" unless V >= 4.
 
 	// PDF Version
 	HeaderVersion *Version // The PDF version the source is claiming to us as per its header.
@@ -174,6 +177,7 @@ type XRefTable struct {
 	ValidateLinks  bool                      // check for broken links in LinkAnnotations/URIDicts.
 	Valid          bool                      // true means successful validated against ISO 32000.
 	URIs           map[int]map[string]string // URIs for link checking
+	Findings       *ValidationResult         // collects validation findings if not nil
 
 	Optimized      bool
 	Watermarked    bool
@@ -185,6 +189,8 @@ type XRefTable struct {
 	// Fonts
 	UsedGIDs  map[string]map[uint16]bool
 	FillFonts map[string]types.IndirectRef
+
+	fileName string // input file name for structured logging
 }
 
 // NewXRefTable creates a new XRefTable.
@@ -519,9 +525,7 @@ func (xRefTable *XRefTable) locateObjForIndRef(ir types.IndirectRef) (types.Obje
 func (xRefTable *XRefTable) FreeObject(objNr int) error {
 	// see 7.5.4 Cross-Reference Table
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("FreeObject: begin %d\n", objNr)
-	}
+	xRefTable.LogDebug("FreeObject: begin", log.KeyObj, objNr)
 
 	freeListHeadEntry, err := xRefTable.Free(0)
 	if err != nil {
@@ -534,9 +538,7 @@ func (xRefTable *XRefTable) FreeObject(objNr int) error {
 	}
 
 	if entry.Free {
-		if log.DebugEnabled() {
-			log.Debug.Printf("FreeObject: end %d already free\n", objNr)
-		}
+		xRefTable.LogDebug("FreeObject: end already free", log.KeyObj, objNr)
 		return nil
 	}
 
@@ -550,9 +552,7 @@ func (xRefTable *XRefTable) FreeObject(objNr int) error {
 	next := int64(objNr)
 	freeListHeadEntry.Offset = &next
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("FreeObject: end %d\n", objNr)
-	}
+	xRefTable.LogDebug("FreeObject: end", log.KeyObj, objNr)
 
 	return nil
 }
@@ -605,9 +605,7 @@ func (xRefTable *XRefTable) DeleteObject(o types.Object) error {
 
 // DeleteObjectGraph deletes all objects reachable by indRef.
 func (xRefTable *XRefTable) DeleteObjectGraph(o types.Object) error {
-	if log.DebugEnabled() {
-		log.Debug.Println("DeleteObjectGraph: begin")
-	}
+	xRefTable.LogDebug("DeleteObjectGraph: begin")
 
 	indRef, ok := o.(types.IndirectRef)
 	if !ok {
@@ -619,9 +617,7 @@ func (xRefTable *XRefTable) DeleteObjectGraph(o types.Object) error {
 		return err
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Println("DeleteObjectGraph: end")
-	}
+	xRefTable.LogDebug("DeleteObjectGraph: end")
 
 	return nil
 }
@@ -867,9 +863,7 @@ func (xRefTable *XRefTable) DeleteDictEntry(d types.Dict, key string) error {
 // UndeleteObject ensures an object is not recorded in the free list.
 // e.g. sometimes caused by indirect references to free objects in the original PDF file.
 func (xRefTable *XRefTable) UndeleteObject(objectNumber int) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("UndeleteObject: begin %d\n", objectNumber)
-	}
+	xRefTable.LogDebug("UndeleteObject: begin", log.KeyObj, objectNumber)
 
 	f, err := xRefTable.Free(0)
 	if err != nil {
@@ -886,9 +880,7 @@ func (xRefTable *XRefTable) UndeleteObject(objectNumber int) error {
 		}
 
 		if objNr == objectNumber {
-			if log.DebugEnabled() {
-				log.Debug.Printf("UndeleteObject end: undeleting obj#%d\n", objectNumber)
-			}
+			xRefTable.LogDebug("UndeleteObject: end: undeleting", log.KeyObj, objectNumber)
 			*f.Offset = *entry.Offset
 			entry.Offset = nil
 			if *entry.Generation > 0 {
@@ -901,9 +893,7 @@ func (xRefTable *XRefTable) UndeleteObject(objectNumber int) error {
 		f = entry
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("UndeleteObject: end: obj#%d not in free list.\n", objectNumber)
-	}
+	xRefTable.LogDebug("UndeleteObject: end: not in free list", log.KeyObj, objectNumber)
 
 	return nil
 }
@@ -1050,6 +1040,40 @@ func (xRefTable *XRefTable) Catalog() (types.Dict, error) {
 	return xRefTable.RootDict, nil
 }
 
+// EnsureADBEExtensionLevel declares the Adobe extension level to PDF 1.7 required by the features in use.
+func (xRefTable *XRefTable) EnsureADBEExtensionLevel(level int) error {
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d, err := xRefTable.DereferenceDict(rootDict["Extensions"])
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		d = types.NewDict()
+		rootDict["Extensions"] = d
+	}
+
+	d1, err := xRefTable.DereferenceDict(d["ADBE"])
+	if err != nil {
+		return err
+	}
+	if d1 != nil {
+		if l := d1.IntEntry("ExtensionLevel"); l != nil && *l >= level {
+			return nil
+		}
+	}
+
+	d["ADBE"] = types.Dict{
+		"BaseVersion":    types.Name("1.7"),
+		"ExtensionLevel": types.Integer(level),
+	}
+
+	return nil
+}
+
 // EncryptDict returns a pointer to the root object / catalog.
 func (xRefTable *XRefTable) EncryptDict() (types.Dict, error) {
 	o, _, err := xRefTable.indRefToObject(xRefTable.Encrypt, true)
@@ -1333,9 +1357,7 @@ func (xRefTable *XRefTable) bindNameTreeNode(name string, n *Node, root bool) er
 			}
 			namesDict.Update(name, n.D)
 		}
-		if log.DebugEnabled() {
-			log.Debug.Printf("bind dict = %v\n", n.D)
-		}
+		xRefTable.LogDebug("bindNameTreeNode: bind", "dict", n.D)
 		dict = n.D
 	}
 
@@ -1352,9 +1374,7 @@ func (xRefTable *XRefTable) bindNameTreeNode(name string, n *Node, root bool) er
 			a = append(a, e.v)
 		}
 		dict.Update("Names", a)
-		if log.DebugEnabled() {
-			log.Debug.Printf("bound nametree node(leaf): %s/n", dict)
-		}
+		xRefTable.LogDebug("bindNameTreeNode: bound leaf", "dict", dict)
 		return nil
 	}
 
@@ -1373,9 +1393,7 @@ func (xRefTable *XRefTable) bindNameTreeNode(name string, n *Node, root bool) er
 	dict.Update("Kids", kids)
 	dict.Delete("Names")
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("bound nametree node(intermediary): %s/n", dict)
-	}
+	xRefTable.LogDebug("bindNameTreeNode: bound intermediary", "dict", dict)
 
 	return nil
 }
@@ -1513,9 +1531,7 @@ func (xRefTable *XRefTable) RemoveNameTree(nameTreeName string) error {
 		return err
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("Deleted Names from root: %s\n", rootDict)
-	}
+	xRefTable.LogDebug("RemoveNameTree: deleted Names from root", "name", nameTreeName)
 
 	return nil
 }
@@ -2751,12 +2767,10 @@ func (xRefTable *XRefTable) insertContent(pageDict types.Dict, bb []byte) error
 	return nil
 }
 
-func appendToContentStream(sd *types.StreamDict, bb []byte) error {
+func appendToContentStream(xRefTable *XRefTable, sd *types.StreamDict, bb []byte) error {
 	err := sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
-		if log.InfoEnabled() {
-			log.Info.Println("unsupported filter: unable to patch content with watermark.")
-		}
+		xRefTable.LogInfo("unsupported filter: unable to append to content stream")
 		return nil
 	}
 	if err != nil {
@@ -2789,7 +2803,7 @@ func (xRefTable *XRefTable) AppendContent(pageDict types.Dict, bb []byte) error
 	switch o := obj.(type) {
 
 	case types.StreamDict:
-		if err := appendToContentStream(&o, bb); err != nil {
+		if err := appendToContentStream(xRefTable, &o, bb); err != nil {
 			return err
 		}
 		entry.Object = o
@@ -2802,7 +2816,7 @@ func (xRefTable *XRefTable) AppendContent(pageDict types.Dict, bb []byte) error
 		genNr := indRef.GenerationNumber.Value()
 		entry, _ = xRefTable.FindTableEntry(objNr, genNr)
 		sd, _ := (entry.Object).(types.StreamDict)
-		if err := appendToContentStream(&sd, bb); err != nil {
+		if err := appendToContentStream(xRefTable, &sd, bb); err != nil {
 			return err
 		}
 		entry.Object = sd
@@ -2915,9 +2929,40 @@ func (xRefTable *XRefTable) BindViewerPreferences() {
 
 	xRefTable.BindPrinterPreferences(vp, d)
 
+	if vp.PageLayout != nil {
+		xRefTable.RootDict["PageLayout"] = types.Name(vp.PageLayout.String())
+		xRefTable.PageLayout = vp.PageLayout
+	}
+	if vp.PageMode != nil {
+		xRefTable.RootDict["PageMode"] = types.Name(vp.PageMode.String())
+		xRefTable.PageMode = vp.PageMode
+	}
+
+	if len(d) == 0 {
+		delete(xRefTable.RootDict, "ViewerPreferences")
+		return
+	}
+
 	xRefTable.RootDict["ViewerPreferences"] = d
 }
 
+// ViewerPreferences returns the viewer preferences including the page layout and page mode of the catalog
+// or nil if there are none.
+func (xRefTable *XRefTable) ViewerPreferences() *ViewerPreferences {
+	if xRefTable.ViewerPref == nil && xRefTable.PageLayout == nil && xRefTable.PageMode == nil {
+		return nil
+	}
+
+	vp := ViewerPreferences{}
+	if xRefTable.ViewerPref != nil {
+		vp = *xRefTable.ViewerPref
+	}
+	vp.PageLayout = xRefTable.PageLayout
+	vp.PageMode = xRefTable.PageMode
+
+	return &vp
+}
+
 // RectForArray returns a new rectangle for given Array.
 func (xRefTable *XRefTable) RectForArray(a types.Array) (*types.Rectangle, error) {
 	llx, err := xRefTable.DereferenceNumber(a[0])
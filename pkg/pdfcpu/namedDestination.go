@@ -0,0 +1,390 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var errNoNamedDests = errors.New("pdfcpu: no named destinations available")
+
+// namedDestArray returns the destination array for the value of a named destination.
+func namedDestArray(xRefTable *model.XRefTable, o types.Object) (types.Array, error) {
+	o, err := xRefTable.Dereference(o)
+	if err != nil || o == nil {
+		return nil, err
+	}
+
+	switch o := o.(type) {
+	case types.Array:
+		return o, nil
+	case types.Dict:
+		return xRefTable.DereferenceArray(o["D"])
+	}
+
+	return nil, errors.Errorf("pdfcpu: invalid dest array: %s", o)
+}
+
+// migratedDest returns a copy of the named destination value o with its page reference patched.
+// ok is false if the destination page has not been migrated.
+func migratedDest(ctxSrc *model.Context, o types.Object, migrated map[int]int) (types.Object, bool, error) {
+	arr, err := namedDestArray(ctxSrc.XRefTable, o)
+	if err != nil {
+		return nil, false, err
+	}
+	if len(arr) == 0 {
+		return nil, false, nil
+	}
+
+	ir, ok := arr[0].(types.IndirectRef)
+	if !ok {
+		return nil, false, nil
+	}
+	objNr, ok := migrated[ir.ObjectNumber.Value()]
+	if !ok {
+		return nil, false, nil
+	}
+
+	arr1 := make(types.Array, len(arr))
+	copy(arr1, arr)
+	arr1[0] = *types.NewIndirectRef(objNr, ir.GenerationNumber.Value())
+
+	d, err := ctxSrc.DereferenceDict(o)
+	if err != nil || d == nil {
+		return arr1, true, nil
+	}
+
+	d1 := d.Clone().(types.Dict)
+	d1["D"] = arr1
+	return d1, true, nil
+}
+
+func destinationType(s string) (model.DestinationType, bool) {
+	for k, v := range model.DestinationTypeStrings {
+		if v == s {
+			return k, true
+		}
+	}
+	return 0, false
+}
+
+// destination returns the destination for a destination array.
+// PageNr is 0 for destinations pointing to pages not found in ctx.
+func destination(ctx *model.Context, arr types.Array) (*model.Destination, error) {
+	if len(arr) < 2 {
+		return nil, errors.Errorf("pdfcpu: invalid dest array: %s", arr)
+	}
+
+	dest := model.Destination{}
+
+	switch o := arr[0].(type) {
+	case types.IndirectRef:
+		pageNr, err := ctx.PageNumber(o.ObjectNumber.Value())
+		if err != nil {
+			return nil, err
+		}
+		dest.PageNr = pageNr
+	case types.Integer:
+		dest.PageNr = o.Value() + 1
+	}
+
+	n, ok := arr[1].(types.Name)
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid dest array: %s", arr)
+	}
+	typ, ok := destinationType(n.Value())
+	if !ok {
+		return nil, errors.Errorf("pdfcpu: invalid dest type: %s", n)
+	}
+	dest.Typ = typ
+
+	f := func(i int) float64 {
+		if i >= len(arr) || arr[i] == nil {
+			return 0
+		}
+		f, err := ctx.DereferenceNumber(arr[i])
+		if err != nil {
+			return 0
+		}
+		return f
+	}
+
+	switch typ {
+	case model.DestXYZ:
+		dest.Left, dest.Top, dest.Zoom = int(f(2)), int(f(3)), float32(f(4))
+	case model.DestFitH, model.DestFitBH:
+		dest.Top = int(f(2))
+	case model.DestFitV, model.DestFitBV:
+		dest.Left = int(f(2))
+	case model.DestFitR:
+		dest.Left, dest.Bottom, dest.Right, dest.Top = int(f(2)), int(f(3)), int(f(4)), int(f(5))
+	}
+
+	return &dest, nil
+}
+
+// processNamedDests applies fn to the destination array of each named destination of ctx.
+func processNamedDests(ctx *model.Context, fn func(name string, arr types.Array) error) error {
+	if err := ctx.LocateNameTree("Dests", false); err != nil {
+		return err
+	}
+
+	if n := ctx.Names["Dests"]; n != nil {
+		process := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
+			if *v == nil {
+				// Skip corrupt node.
+				return nil
+			}
+			arr, err := namedDestArray(xRefTable, *v)
+			if err != nil {
+				return err
+			}
+			return fn(k, arr)
+		}
+		if err := n.Process(ctx.XRefTable, process); err != nil {
+			return err
+		}
+	}
+
+	for k, v := range ctx.Dests {
+		arr, err := namedDestArray(ctx.XRefTable, v)
+		if err != nil {
+			return err
+		}
+		if err := fn(k, arr); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NamedDestinations returns all named destinations of ctx sorted by name.
+func NamedDestinations(ctx *model.Context) ([]model.NamedDestination, error) {
+	nds := []model.NamedDestination{}
+
+	if err := processNamedDests(ctx, func(name string, arr types.Array) error {
+		dest, err := destination(ctx, arr)
+		if err != nil {
+			return err
+		}
+		nds = append(nds, model.NamedDestination{Name: name, Dest: *dest})
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(nds, func(i, j int) bool { return nds[i].Name < nds[j].Name })
+
+	return nds, nil
+}
+
+// ListNamedDestinations returns a list of the named destinations of ctx.
+func ListNamedDestinations(ctx *model.Context) ([]string, error) {
+	nds, err := NamedDestinations(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(nds) == 0 {
+		return []string{"no named destinations available"}, nil
+	}
+
+	maxLen := 0
+	for _, nd := range nds {
+		if len(nd.Name) > maxLen {
+			maxLen = len(nd.Name)
+		}
+	}
+
+	ss := []string{}
+	for _, nd := range nds {
+		page := "dangling"
+		if nd.Dest.PageNr > 0 {
+			page = fmt.Sprintf("page %d", nd.Dest.PageNr)
+		}
+		ss = append(ss, fmt.Sprintf("%-*s  %s /%s", maxLen, nd.Name, page, nd.Dest))
+	}
+
+	return ss, nil
+}
+
+// ResolveNamedDestination returns the destination for name.
+func ResolveNamedDestination(ctx *model.Context, name string) (*model.Destination, error) {
+	if err := ctx.LocateNameTree("Dests", false); err != nil {
+		return nil, err
+	}
+
+	arr, err := ctx.DereferenceDestArray(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return destination(ctx, arr)
+}
+
+func namedDestExists(ctx *model.Context, name string) bool {
+	if n := ctx.Names["Dests"]; n != nil {
+		if _, ok := n.Value(name); ok {
+			return true
+		}
+	}
+	_, ok := ctx.Dests[name]
+	return ok
+}
+
+// AddNamedDestinations adds nds to ctx.
+// Existing named destinations are replaced if replace is true.
+func AddNamedDestinations(ctx *model.Context, nds []model.NamedDestination, replace bool) error {
+	if len(nds) == 0 {
+		return errors.New("pdfcpu: missing named destinations")
+	}
+
+	if err := ctx.LocateNameTree("Dests", true); err != nil {
+		return err
+	}
+
+	for _, nd := range nds {
+		if nd.Name == "" {
+			return errors.New("pdfcpu: named destination: missing name")
+		}
+
+		if nd.Dest.PageNr < 1 || nd.Dest.PageNr > ctx.PageCount {
+			return errors.Errorf("pdfcpu: named destination %s: invalid page number: %d", nd.Name, nd.Dest.PageNr)
+		}
+
+		if namedDestExists(ctx, nd.Name) {
+			if !replace {
+				return errors.Errorf("pdfcpu: named destination %s already exists", nd.Name)
+			}
+			if _, _, err := removeDest(ctx, nd.Name); err != nil {
+				return err
+			}
+		}
+
+		_, pageIndRef, _, err := ctx.PageDict(nd.Dest.PageNr, false)
+		if err != nil {
+			return err
+		}
+
+		ir, err := ctx.IndRefForNewObject(nd.Dest.Array(*pageIndRef))
+		if err != nil {
+			return err
+		}
+
+		if err := ctx.Names["Dests"].Add(ctx.XRefTable, nd.Name, *ir, nil, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RemoveNamedDestinations removes named destinations from ctx.
+// All named destinations are removed if names is empty.
+func RemoveNamedDestinations(ctx *model.Context, names []string) error {
+	if len(names) == 0 {
+		if err := processNamedDests(ctx, func(name string, arr types.Array) error {
+			names = append(names, name)
+			return nil
+		}); err != nil {
+			return err
+		}
+		if len(names) == 0 {
+			return errNoNamedDests
+		}
+	}
+
+	var empty bool
+
+	for _, name := range names {
+		dNamesEmpty, ok, err := removeDest(ctx, name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return errors.Errorf("pdfcpu: named destination %s not found", name)
+		}
+		empty = empty || dNamesEmpty
+	}
+
+	return cleanupDestinations(ctx, empty)
+}
+
+// RetargetNamedDestinations moves named destinations pointing to a page of pageMap to the mapped page.
+// Named destinations mapped to page 0 and named destinations pointing to pages no longer found in ctx (dangling)
+// are removed. Returns the number of affected named destinations.
+func RetargetNamedDestinations(ctx *model.Context, pageMap map[int]int) (int, error) {
+	var (
+		count       int
+		obsolete    []string
+		pageIndRefs = map[int]types.IndirectRef{}
+	)
+
+	for from, thru := range pageMap {
+		if from < 1 || from > ctx.PageCount || thru < 0 || thru > ctx.PageCount {
+			return 0, errors.Errorf("pdfcpu: invalid page mapping: %d -> %d", from, thru)
+		}
+		if thru == 0 {
+			continue
+		}
+		_, pageIndRef, _, err := ctx.PageDict(thru, false)
+		if err != nil {
+			return 0, err
+		}
+		pageIndRefs[thru] = *pageIndRef
+	}
+
+	if err := processNamedDests(ctx, func(name string, arr types.Array) error {
+		dest, err := destination(ctx, arr)
+		if err != nil {
+			return err
+		}
+		if dest.PageNr == 0 {
+			obsolete = append(obsolete, name)
+			return nil
+		}
+		thru, ok := pageMap[dest.PageNr]
+		if !ok {
+			return nil
+		}
+		if thru == 0 {
+			obsolete = append(obsolete, name)
+			return nil
+		}
+		arr[0] = pageIndRefs[thru]
+		count++
+		return nil
+	}); err != nil {
+		return 0, err
+	}
+
+	if len(obsolete) == 0 {
+		return count, nil
+	}
+
+	if err := RemoveNamedDestinations(ctx, obsolete); err != nil {
+		return 0, err
+	}
+
+	return count + len(obsolete), nil
+}
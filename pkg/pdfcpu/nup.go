@@ -70,7 +70,12 @@ var nupParamMap = nUpParamMap{
 	"foliosize":       parseBookletFolioSize,
 	"btype":           parseBookletType,
 	"binding":         parseBookletBinding,
+	"rtl":             parseBookletRTL,
 	"enforce":         parseEnforce,
+	"sheetmarks":      parsePrintersMarks,
+	"rotatecells":     parseRotatedCells,
+	"trimsize":        parseTrimSize,
+	"fit":             parseTrimFit,
 }
 
 // Handle applies parameter completion and if successful
@@ -115,6 +120,29 @@ func parseDimensionsNUp(s string, nup *model.NUp) (err error) {
 	return err
 }
 
+func parseTrimSize(s string, nup *model.NUp) (err error) {
+	if len(strings.Fields(s)) == 2 {
+		nup.TrimDim, _, err = ParsePageDim(strings.Join(strings.Fields(s), " "), nup.InpUnit)
+		return err
+	}
+	nup.TrimDim, _, err = types.ParsePageFormat(s)
+	return err
+}
+
+func parseTrimFit(s string, nup *model.NUp) error {
+	switch strings.ToLower(s) {
+	case "scale":
+		nup.TrimFit = model.TrimFitScale
+	case "center":
+		nup.TrimFit = model.TrimFitCenter
+	case "clip":
+		nup.TrimFit = model.TrimFitClip
+	default:
+		return errors.New("pdfcpu: fit, please provide one of: scale center clip")
+	}
+	return nil
+}
+
 func parseOrientation(s string, nup *model.NUp) error {
 	switch s {
 	case "rd":
@@ -223,6 +251,28 @@ func parseBookletGuides(s string, nup *model.NUp) error {
 	return nil
 }
 
+func parsePrintersMarks(s string, nup *model.NUp) (err error) {
+	nup.Marks, err = model.ParsePrintersMarks(s)
+	return err
+}
+
+func parseRotatedCells(s string, nup *model.NUp) error {
+	nup.RotatedCells = types.IntSet{}
+	nup.Tumble = false
+	for _, v := range strings.Fields(strings.ToLower(s)) {
+		if v == "tumble" {
+			nup.Tumble = true
+			continue
+		}
+		i, err := strconv.Atoi(v)
+		if err != nil || i < 1 {
+			return errors.Errorf("pdfcpu: rotatecells, please provide a list of cell numbers >= 1 or tumble, got: %s", v)
+		}
+		nup.RotatedCells[i] = true
+	}
+	return nil
+}
+
 func parseBookletMultifolio(s string, nup *model.NUp) error {
 	switch strings.ToLower(s) {
 	case "on", "true", "t":
@@ -272,6 +322,18 @@ func parseBookletBinding(s string, nup *model.NUp) error {
 	return nil
 }
 
+func parseBookletRTL(s string, nup *model.NUp) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		nup.BookletRTL = true
+	case "off", "false", "f":
+		nup.BookletRTL = false
+	default:
+		return errors.New("pdfcpu: booklet rtl, please provide one of: on/off true/false t/f")
+	}
+	return nil
+}
+
 func parseElementMargin(s string, nup *model.NUp) error {
 	f, err := strconv.ParseFloat(s, 64)
 	if err != nil {
@@ -420,9 +482,9 @@ func ParseNUpGridDefinition(rows, cols int, nUp *model.NUp) error {
 }
 
 func nUpImagePDFBytes(w io.Writer, imgWidth, imgHeight int, nup *model.NUp, formResID string) {
-	for _, r := range nup.RectsForGrid() {
+	for i, r := range nup.RectsForGrid() {
 		// Append to content stream.
-		model.NUpTilePDFBytes(w, types.RectForDim(float64(imgWidth), float64(imgHeight)), r, formResID, nup, false)
+		model.NUpTilePDFBytes(w, types.RectForDim(float64(imgWidth), float64(imgHeight)), r, formResID, nup, nup.RotateCell(i, r))
 	}
 }
 
@@ -551,14 +613,21 @@ func NUpFromOneImage(ctx *model.Context, fileName string, nup *model.NUp, pagesD
 }
 
 func wrapUpPage(ctx *model.Context, nup *model.NUp, d types.Dict, buf bytes.Buffer, pagesDict types.Dict, pagesIndRef *types.IndirectRef) error {
-	xRefTable := ctx.XRefTable
-
-	var fm model.FontMap
+	fm := model.FontMap{}
 	if nup.BookletGuides {
 		// For booklets only.
 		fm = model.DrawBookletGuides(nup, &buf)
 	}
 
+	model.DrawPrintersMarks(nup, &buf, fm)
+
+	return addSheetPage(ctx, nup.PageDim, d, fm, buf, pagesDict, pagesIndRef)
+}
+
+// addSheetPage appends a new page of dimensions dim rendering the forms in d via the content in buf.
+func addSheetPage(ctx *model.Context, dim *types.Dim, d types.Dict, fm model.FontMap, buf bytes.Buffer, pagesDict types.Dict, pagesIndRef *types.IndirectRef) error {
+	xRefTable := ctx.XRefTable
+
 	resourceDict := types.Dict(
 		map[string]types.Object{
 			"XObject": d,
@@ -589,7 +658,6 @@ func wrapUpPage(ctx *model.Context, nup *model.NUp, d types.Dict, buf bytes.Buff
 		return err
 	}
 
-	dim := nup.PageDim
 	mediaBox := types.RectForDim(dim.Width, dim.Height)
 
 	pageDict := types.Dict(
@@ -680,7 +748,7 @@ func nupPages(
 			continue
 		}
 
-		if err := ctx.NUpTilePDFBytesForPDF(pageNr, formsResDict, &buf, rDest, nup, false); err != nil {
+		if err := ctx.NUpTilePDFBytesForPDF(pageNr, formsResDict, &buf, rDest, nup, nup.RotateCell(i, rDest)); err != nil {
 			return err
 		}
 	}
@@ -757,7 +825,7 @@ func NUpFromMultipleImages(ctx *model.Context, fileNames []string, nup *model.NU
 		formsResDict.Insert(formResID, *formIndRef)
 
 		// Append to content stream of page i.
-		model.NUpTilePDFBytes(&buf, types.RectForDim(float64(w), float64(h)), rr[i%len(rr)], formResID, nup, false)
+		model.NUpTilePDFBytes(&buf, types.RectForDim(float64(w), float64(h)), rr[i%len(rr)], formResID, nup, nup.RotateCell(i, rr[i%len(rr)]))
 	}
 
 	// Wrap incomplete nUp page.
@@ -0,0 +1,424 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// OCRWord is a word recognized by an OCR engine.
+// The bounding box is given in the coordinate space of the OCR page using a top left origin.
+type OCRWord struct {
+	Text           string
+	X0, Y0, X1, Y1 float64
+}
+
+// OCRPage holds the words recognized on a scanned page of Width x Height OCR units (usually pixels).
+type OCRPage struct {
+	Width, Height float64
+	Words         []OCRWord
+}
+
+// ParseOCR parses hOCR or ALTO XML OCR results and returns the pages contained.
+func ParseOCR(r io.Reader) ([]OCRPage, error) {
+	br := bufio.NewReader(r)
+	bb, err := br.Peek(4096)
+	if err != nil && err != io.EOF && err != bufio.ErrBufferFull {
+		return nil, err
+	}
+
+	if bytes.Contains(bytes.ToLower(bb), []byte("<alto")) {
+		return parseALTO(br)
+	}
+
+	return parseHOCR(br)
+}
+
+func attr(se xml.StartElement, name string) string {
+	for _, a := range se.Attr {
+		if strings.EqualFold(a.Name.Local, name) {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// hocrBBox returns the bbox property of an hOCR title attribute like "bbox 10 20 110 50; x_wconf 95".
+func hocrBBox(title string) ([4]float64, bool) {
+	var bb [4]float64
+	for _, prop := range strings.Split(title, ";") {
+		ss := strings.Fields(prop)
+		if len(ss) != 5 || ss[0] != "bbox" {
+			continue
+		}
+		for i := 0; i < 4; i++ {
+			f, err := strconv.ParseFloat(ss[i+1], 64)
+			if err != nil {
+				return bb, false
+			}
+			bb[i] = f
+		}
+		return bb, true
+	}
+	return bb, false
+}
+
+func hasClass(se xml.StartElement, class string) bool {
+	for _, c := range strings.Fields(attr(se, "class")) {
+		if c == class {
+			return true
+		}
+	}
+	return false
+}
+
+func parseHOCR(r io.Reader) ([]OCRPage, error) {
+	dec := xml.NewDecoder(r)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		pages []OCRPage
+		page  *OCRPage
+		word  *OCRWord
+		sb    strings.Builder
+		depth int // Element depth within the current word.
+	)
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: invalid hOCR")
+		}
+
+		switch t := t.(type) {
+
+		case xml.StartElement:
+			if word != nil {
+				depth++
+				continue
+			}
+			if hasClass(t, "ocr_page") {
+				bb, ok := hocrBBox(attr(t, "title"))
+				if !ok {
+					return nil, errors.New("pdfcpu: invalid hOCR: ocr_page without bbox")
+				}
+				pages = append(pages, OCRPage{Width: bb[2] - bb[0], Height: bb[3] - bb[1]})
+				page = &pages[len(pages)-1]
+				continue
+			}
+			if page != nil && hasClass(t, "ocrx_word") {
+				if bb, ok := hocrBBox(attr(t, "title")); ok {
+					word = &OCRWord{X0: bb[0], Y0: bb[1], X1: bb[2], Y1: bb[3]}
+					sb.Reset()
+					depth = 0
+				}
+			}
+
+		case xml.CharData:
+			if word != nil {
+				sb.Write(t)
+			}
+
+		case xml.EndElement:
+			if word == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if word.Text = strings.TrimSpace(sb.String()); word.Text != "" {
+				page.Words = append(page.Words, *word)
+			}
+			word = nil
+		}
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("pdfcpu: invalid hOCR: no ocr_page found")
+	}
+
+	return pages, nil
+}
+
+func floatAttr(se xml.StartElement, name string) (float64, error) {
+	s := attr(se, name)
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, errors.Errorf("pdfcpu: invalid ALTO: %s %s=%q", se.Name.Local, name, s)
+	}
+	return f, nil
+}
+
+func floatAttrs(se xml.StartElement, names ...string) ([]float64, error) {
+	ff := make([]float64, len(names))
+	for i, name := range names {
+		f, err := floatAttr(se, name)
+		if err != nil {
+			return nil, err
+		}
+		ff[i] = f
+	}
+	return ff, nil
+}
+
+func parseALTO(r io.Reader) ([]OCRPage, error) {
+	dec := xml.NewDecoder(r)
+
+	var (
+		pages []OCRPage
+		page  *OCRPage
+	)
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: invalid ALTO")
+		}
+
+		se, ok := t.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch se.Name.Local {
+
+		case "Page":
+			ff, err := floatAttrs(se, "WIDTH", "HEIGHT")
+			if err != nil {
+				return nil, err
+			}
+			pages = append(pages, OCRPage{Width: ff[0], Height: ff[1]})
+			page = &pages[len(pages)-1]
+
+		case "String":
+			if page == nil {
+				return nil, errors.New("pdfcpu: invalid ALTO: String outside Page")
+			}
+			ff, err := floatAttrs(se, "HPOS", "VPOS", "WIDTH", "HEIGHT")
+			if err != nil {
+				return nil, err
+			}
+			if s := strings.TrimSpace(attr(se, "CONTENT")); s != "" {
+				page.Words = append(page.Words, OCRWord{Text: s, X0: ff[0], Y0: ff[1], X1: ff[0] + ff[2], Y1: ff[1] + ff[3]})
+			}
+		}
+	}
+
+	if len(pages) == 0 {
+		return nil, errors.New("pdfcpu: invalid ALTO: no Page found")
+	}
+
+	return pages, nil
+}
+
+// glyphLessFont returns an indirect reference to a Type0 font without glyphs
+// where each Unicode code unit is shown as a CID of width 500 identical to the code unit.
+func glyphLessFont(ctx *model.Context) (*types.IndirectRef, error) {
+	var buf bytes.Buffer
+	buf.WriteString("/CIDInit /ProcSet findresource begin\n12 dict begin\nbegincmap\n")
+	buf.WriteString("/CIDSystemInfo << /Registry (Adobe) /Ordering (UCS) /Supplement 0 >> def\n")
+	buf.WriteString("/CMapName /Adobe-Identity-UCS def\n/CMapType 2 def\n")
+	buf.WriteString("1 begincodespacerange\n<0000> <FFFF>\nendcodespacerange\n")
+	for hi := 0; hi < 256; hi += 100 {
+		n := min(100, 256-hi)
+		fmt.Fprintf(&buf, "%d beginbfrange\n", n)
+		for i := hi; i < hi+n; i++ {
+			fmt.Fprintf(&buf, "<%02X00> <%02XFF> <%02X00>\n", i, i, i)
+		}
+		buf.WriteString("endbfrange\n")
+	}
+	buf.WriteString("endcmap\nCMapName currentdict /CMap defineresource pop\nend\nend\n")
+
+	sd, _ := ctx.NewStreamDictForBuf(buf.Bytes())
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+	toUnicode, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+
+	fd, err := ctx.IndRefForNewObject(types.Dict(map[string]types.Object{
+		"Type":        types.Name("FontDescriptor"),
+		"FontName":    types.Name("GlyphLessFont"),
+		"FontFamily":  types.StringLiteral("GlyphLessFont"),
+		"FontStretch": types.Name("Normal"),
+		"FontWeight":  types.Integer(400),
+		"Flags":       types.Integer(5),
+		"FontBBox":    types.NewNumberArray(0, -200, 500, 800),
+		"ItalicAngle": types.Integer(0),
+		"Ascent":      types.Integer(800),
+		"Descent":     types.Integer(-200),
+		"CapHeight":   types.Integer(800),
+		"StemV":       types.Integer(80),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	cidFont, err := ctx.IndRefForNewObject(types.Dict(map[string]types.Object{
+		"Type":     types.Name("Font"),
+		"Subtype":  types.Name("CIDFontType2"),
+		"BaseFont": types.Name("GlyphLessFont"),
+		"CIDSystemInfo": types.Dict(map[string]types.Object{
+			"Registry":   types.StringLiteral("Adobe"),
+			"Ordering":   types.StringLiteral("Identity"),
+			"Supplement": types.Integer(0),
+		}),
+		"FontDescriptor": *fd,
+		"DW":             types.Integer(500),
+		"CIDToGIDMap":    types.Name("Identity"),
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	return ctx.IndRefForNewObject(types.Dict(map[string]types.Object{
+		"Type":            types.Name("Font"),
+		"Subtype":         types.Name("Type0"),
+		"BaseFont":        types.Name("GlyphLessFont"),
+		"Encoding":        types.Name("Identity-H"),
+		"DescendantFonts": types.Array{*cidFont},
+		"ToUnicode":       *toUnicode,
+	}))
+}
+
+// ocrTextMatrix maps a point on the displayed page given as offset dx from the left and dd from the top edge
+// to user space and returns the corresponding text matrix for the rotation r of the page.
+func ocrTextMatrix(cropBox *types.Rectangle, r int, dx, dd float64) [6]float64 {
+	llx, lly, w, h := cropBox.LL.X, cropBox.LL.Y, cropBox.Width(), cropBox.Height()
+	switch r {
+	case 90:
+		return [6]float64{0, 1, -1, 0, llx + dd, lly + dx}
+	case 180:
+		return [6]float64{-1, 0, 0, -1, llx + w - dx, lly + dd}
+	case 270:
+		return [6]float64{0, -1, 1, 0, llx + w - dd, lly + h - dx}
+	}
+	return [6]float64{1, 0, 0, 1, llx + dx, lly + h - dd}
+}
+
+func ocrHex(s string) (string, int) {
+	uu := utf16.Encode([]rune(s))
+	var sb strings.Builder
+	for _, u := range uu {
+		fmt.Fprintf(&sb, "%04X", u)
+	}
+	return sb.String(), len(uu)
+}
+
+func ocrContent(p OCRPage, cropBox *types.Rectangle, rotate int, fontID string) []byte {
+	// Dimensions of the page as displayed.
+	w, h := cropBox.Width(), cropBox.Height()
+	if rotate == 90 || rotate == 270 {
+		w, h = h, w
+	}
+	sx, sy := w/p.Width, h/p.Height
+
+	var buf bytes.Buffer
+	buf.WriteString("BT 3 Tr\n")
+	for _, word := range p.Words {
+		fs := (word.Y1 - word.Y0) * sy
+		ww := (word.X1 - word.X0) * sx
+		if fs <= 0 || ww <= 0 {
+			continue
+		}
+		s, n := ocrHex(word.Text)
+		// The baseline is set so the font's descent touches the bottom of the word box.
+		m := ocrTextMatrix(cropBox, rotate, word.X0*sx, word.Y1*sy-.2*fs)
+		fmt.Fprintf(&buf, "/%s %.2f Tf %.2f Tz %.4f %.4f %.4f %.4f %.2f %.2f Tm <%s> Tj\n",
+			fontID, fs, 100*ww/(float64(n)*.5*fs), m[0], m[1], m[2], m[3], m[4], m[5], s)
+	}
+	buf.WriteString("ET\n")
+
+	return buf.Bytes()
+}
+
+func addOCRTextToPage(ctx *model.Context, pageNr int, p OCRPage, fontIndRef types.IndirectRef) error {
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+	if d == nil {
+		return errors.Errorf("pdfcpu: unknown page number: %d", pageNr)
+	}
+
+	fontID, err := addPageResource(ctx, d, inhPAttrs, "Font", "OCR", fontIndRef)
+	if err != nil {
+		return err
+	}
+
+	cropBox := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		cropBox = inhPAttrs.CropBox
+	}
+
+	rotate := inhPAttrs.Rotate % 360
+	if rotate < 0 {
+		rotate += 360
+	}
+
+	return appendPageContent(ctx, d, ocrContent(p, cropBox, rotate, fontID))
+}
+
+// AddOCRText makes scanned pages searchable by overlaying invisible text at the word positions
+// reported by an OCR engine. pages maps page numbers to OCR results.
+func AddOCRText(ctx *model.Context, pages map[int]OCRPage) error {
+	fontIndRef, err := glyphLessFont(ctx)
+	if err != nil {
+		return err
+	}
+
+	pageNrs := make([]int, 0, len(pages))
+	for pageNr := range pages {
+		pageNrs = append(pageNrs, pageNr)
+	}
+	sort.Ints(pageNrs)
+
+	for _, pageNr := range pageNrs {
+		p := pages[pageNr]
+		if p.Width <= 0 || p.Height <= 0 {
+			return errors.Errorf("pdfcpu: page %d: invalid OCR page dimensions %.2f x %.2f", pageNr, p.Width, p.Height)
+		}
+		if err := addOCRTextToPage(ctx, pageNr, p, *fontIndRef); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const testHOCR = `<?xml version="1.0" encoding="UTF-8"?>
+<!DOCTYPE html PUBLIC "-//W3C//DTD XHTML 1.0 Transitional//EN" "http://www.w3.org/TR/xhtml1/DTD/xhtml1-transitional.dtd">
+<html xmlns="http://www.w3.org/1999/xhtml">
+ <head><meta name="ocr-system" content="tesseract"/></head>
+ <body>
+  <div class="ocr_page" id="page_1" title="image &quot;scan.png&quot;; bbox 0 0 1000 500; ppageno 0">
+   <span class="ocr_line" title="bbox 100 50 600 100">
+    <span class="ocrx_word" title="bbox 100 50 300 100; x_wconf 96"><strong>Hello</strong></span>
+    <span class="ocrx_word" title="bbox 350 50 600 100; x_wconf 91">W&ouml;rld&nbsp;</span>
+   </span>
+  </div>
+ </body>
+</html>`
+
+const testALTO = `<?xml version="1.0" encoding="UTF-8"?>
+<alto xmlns="http://www.loc.gov/standards/alto/ns-v3#">
+ <Layout>
+  <Page WIDTH="1000" HEIGHT="500" PHYSICAL_IMG_NR="1">
+   <PrintSpace>
+    <TextBlock><TextLine>
+     <String CONTENT="Hello" HPOS="100" VPOS="50" WIDTH="200" HEIGHT="50"/><SP/>
+     <String CONTENT="Wörld" HPOS="350" VPOS="50" WIDTH="250" HEIGHT="50"/>
+    </TextLine></TextBlock>
+   </PrintSpace>
+  </Page>
+ </Layout>
+</alto>`
+
+func TestParseOCR(t *testing.T) {
+	want := []OCRPage{{
+		Width:  1000,
+		Height: 500,
+		Words: []OCRWord{
+			{Text: "Hello", X0: 100, Y0: 50, X1: 300, Y1: 100},
+			{Text: "Wörld", X0: 350, Y0: 50, X1: 600, Y1: 100},
+		},
+	}}
+
+	for name, s := range map[string]string{"hOCR": testHOCR, "ALTO": testALTO} {
+		pp, err := ParseOCR(strings.NewReader(s))
+		if err != nil {
+			t.Fatalf("%s: %v\n", name, err)
+		}
+		if !reflect.DeepEqual(pp, want) {
+			t.Errorf("%s: got %+v, want %+v\n", name, pp, want)
+		}
+	}
+
+	if _, err := ParseOCR(strings.NewReader("<html><body>no OCR</body></html>")); err == nil {
+		t.Error("missing ocr_page should fail")
+	}
+}
+
+func TestAddOCRText(t *testing.T) {
+	for _, rotate := range []int{0, 90, 180, 270} {
+		ctx, err := ReadFile(filepath.Join("..", "testdata", "mountain.pdf"), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := ctx.EnsurePageCount(); err != nil {
+			t.Fatal(err)
+		}
+
+		d, _, inhPAttrs, err := ctx.PageDict(1, false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		d["Rotate"] = types.Integer(rotate)
+
+		r := inhPAttrs.MediaBox
+		if inhPAttrs.CropBox != nil {
+			r = inhPAttrs.CropBox
+		}
+		w, h := r.Width(), r.Height()
+		if rotate == 90 || rotate == 270 {
+			w, h = h, w
+		}
+
+		p := OCRPage{Width: 1000, Height: 500, Words: []OCRWord{{Text: "Hello", X0: 100, Y0: 50, X1: 300, Y1: 100}}}
+		if err := AddOCRText(ctx, map[int]OCRPage{1: p}); err != nil {
+			t.Fatalf("rotate %d: %v\n", rotate, err)
+		}
+
+		gg, err := pageGlyphs(ctx, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var sb strings.Builder
+		var got *types.Rectangle
+		for _, g := range gg {
+			sb.WriteString(g.s)
+			if got == nil {
+				rect := g.rect
+				got = &rect
+				continue
+			}
+			got.LL.X, got.LL.Y = math.Min(got.LL.X, g.rect.LL.X), math.Min(got.LL.Y, g.rect.LL.Y)
+			got.UR.X, got.UR.Y = math.Max(got.UR.X, g.rect.UR.X), math.Max(got.UR.Y, g.rect.UR.Y)
+		}
+
+		if sb.String() != "Hello" {
+			t.Fatalf("rotate %d: got text %q\n", rotate, sb.String())
+		}
+
+		// The word box relative to the left and top edge of the displayed page.
+		x0, x1 := .1*w, .3*w
+		y0, y1 := .1*h, .2*h
+
+		var want *types.Rectangle
+		switch rotate {
+		case 0:
+			want = types.NewRectangle(r.LL.X+x0, r.UR.Y-y1, r.LL.X+x1, r.UR.Y-y0)
+		case 90:
+			want = types.NewRectangle(r.LL.X+y0, r.LL.Y+x0, r.LL.X+y1, r.LL.Y+x1)
+		case 180:
+			want = types.NewRectangle(r.UR.X-x1, r.LL.Y+y0, r.UR.X-x0, r.LL.Y+y1)
+		case 270:
+			want = types.NewRectangle(r.UR.X-y1, r.UR.Y-x1, r.UR.X-y0, r.UR.Y-x0)
+		}
+
+		for _, f := range [][2]float64{
+			{got.LL.X, want.LL.X}, {got.LL.Y, want.LL.Y}, {got.UR.X, want.UR.X}, {got.UR.Y, want.UR.Y}} {
+			if math.Abs(f[0]-f[1]) > .1 {
+				t.Errorf("rotate %d: got word box %v, want %v\n", rotate, got, want)
+				break
+			}
+		}
+	}
+}
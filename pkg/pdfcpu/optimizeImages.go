@@ -0,0 +1,957 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"image"
+	"image/draw"
+	"image/jpeg"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Images get downsampled only if their resolution exceeds the target resolution by more than this factor.
+const downsampleTolerance = 1.1
+
+type imageOptimizationParamMap map[string]func(string, *model.ImageOptimization) error
+
+var imageOptimizationParamsMap = imageOptimizationParamMap{
+	"dpi":     parseImageOptimizationDPI,
+	"quality": parseImageOptimizationQuality,
+	"dct":     parseImageOptimizationDCTThreshold,
+	"gray":    parseImageOptimizationGrayscale,
+	"mrc":     parseImageOptimizationMRC,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into imo.
+func (m imageOptimizationParamMap) Handle(paramPrefix, paramValueStr string, imo *model.ImageOptimization) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, imo)
+}
+
+func parseImageOptimizationDPI(s string, imo *model.ImageOptimization) error {
+	if strings.EqualFold(s, "off") {
+		imo.DPI = 0
+		return nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return errors.Errorf("pdfcpu: invalid dpi: %s, please use a positive integer or off", s)
+	}
+	imo.DPI = i
+	return nil
+}
+
+func parseImageOptimizationQuality(s string, imo *model.ImageOptimization) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 1 || i > 100 {
+		return errors.Errorf("pdfcpu: invalid quality: %s, please use an integer between 1 and 100", s)
+	}
+	imo.Quality = i
+	return nil
+}
+
+func parseImageOptimizationDCTThreshold(s string, imo *model.ImageOptimization) error {
+	if strings.EqualFold(s, "off") {
+		imo.DCTThreshold = 0
+		return nil
+	}
+
+	f := types.ByteSize(1)
+	s1 := strings.ToUpper(strings.TrimSpace(s))
+	switch {
+	case strings.HasSuffix(s1, "KB"):
+		f, s1 = types.KB, strings.TrimSuffix(s1, "KB")
+	case strings.HasSuffix(s1, "MB"):
+		f, s1 = types.MB, strings.TrimSuffix(s1, "MB")
+	}
+
+	v, err := strconv.ParseFloat(strings.TrimSpace(s1), 64)
+	if err != nil || v < 0 {
+		return errors.Errorf("pdfcpu: invalid dct threshold: %s, please use eg. 500000, 500KB, 1MB or off", s)
+	}
+	imo.DCTThreshold = int64(v * float64(f))
+	return nil
+}
+
+func parseImageOptimizationGrayscale(s string, imo *model.ImageOptimization) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		imo.Grayscale = true
+	case "off", "false", "f":
+		imo.Grayscale = false
+	default:
+		return errors.New("pdfcpu: convert color images to grayscale, please provide one of: on/off true/false")
+	}
+	return nil
+}
+
+func parseImageOptimizationMRC(s string, imo *model.ImageOptimization) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		imo.MRC = true
+	case "off", "false", "f":
+		imo.MRC = false
+	default:
+		return errors.New("pdfcpu: mixed raster content compression of scanned pages, please provide one of: on/off true/false")
+	}
+	return nil
+}
+
+// ParseImageOptimization parses an image optimization string into an internal structure.
+func ParseImageOptimization(s string) (*model.ImageOptimization, error) {
+	imo := model.DefaultImageOptimization()
+
+	if s == "" {
+		return imo, nil
+	}
+
+	ss := strings.Split(s, ",")
+
+	for _, s := range ss {
+
+		ss1 := strings.Split(s, ":")
+		if len(ss1) != 2 {
+			return nil, errors.New("pdfcpu: Invalid image optimization string. Please consult pdfcpu help optimize")
+		}
+
+		paramPrefix := strings.TrimSpace(ss1[0])
+		paramValueStr := strings.TrimSpace(ss1[1])
+
+		if err := imageOptimizationParamsMap.Handle(paramPrefix, paramValueStr, imo); err != nil {
+			return nil, err
+		}
+	}
+
+	return imo, nil
+}
+
+// imagePlacements records for image XObjects the lowest effective resolution they are rendered with.
+type imagePlacements struct {
+	ctx        *model.Context
+	dpi        map[int]float64                // by object number
+	placements map[int][]model.ImagePlacement // by object number, optional
+	forms      map[int]bool                   // form XObjects being processed
+}
+
+func (ip *imagePlacements) recordImage(objNr int, sd *types.StreamDict, ctm matrix.Matrix) {
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if w == nil || h == nil {
+		return
+	}
+
+	// The image is mapped from the unit square.
+	wPt := math.Hypot(ctm[0][0], ctm[0][1])
+	hPt := math.Hypot(ctm[1][0], ctm[1][1])
+	if wPt == 0 || hPt == 0 {
+		return
+	}
+
+	dpi := math.Min(float64(*w)*72/wPt, float64(*h)*72/hPt)
+	if v, ok := ip.dpi[objNr]; !ok || dpi < v {
+		ip.dpi[objNr] = dpi
+	}
+
+	if ip.placements != nil {
+		r := unitSquareBBox(ctm)
+		for i := range r {
+			r[i] = math.Round(r[i]*1e4) / 1e4
+		}
+		ip.placements[objNr] = append(ip.placements[objNr], model.ImagePlacement{Rect: r, DPI: math.Round(dpi*100) / 100})
+	}
+}
+
+// unitSquareBBox returns the bounding box of the unit square mapped by m.
+func unitSquareBBox(m matrix.Matrix) [4]float64 {
+	llx, lly, urx, ury := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range [][2]float64{{0, 0}, {1, 0}, {0, 1}, {1, 1}} {
+		x := p[0]*m[0][0] + p[1]*m[1][0] + m[2][0]
+		y := p[0]*m[0][1] + p[1]*m[1][1] + m[2][1]
+		llx, lly = math.Min(llx, x), math.Min(lly, y)
+		urx, ury = math.Max(urx, x), math.Max(ury, y)
+	}
+	return [4]float64{llx, lly, urx, ury}
+}
+
+func (ip *imagePlacements) xObject(resDict types.Dict, name string, ctm matrix.Matrix, depth int) error {
+	xObjs, err := ip.ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || xObjs == nil {
+		return err
+	}
+
+	indRef, ok := xObjs[name].(types.IndirectRef)
+	if !ok {
+		return nil
+	}
+	objNr := indRef.ObjectNumber.Value()
+
+	sd, _, err := ip.ctx.DereferenceStreamDict(indRef)
+	if err != nil || sd == nil {
+		return err
+	}
+
+	switch st := sd.Subtype(); {
+
+	case st != nil && *st == "Image":
+		ip.recordImage(objNr, sd, ctm)
+
+	case st != nil && *st == "Form":
+		if ip.forms[objNr] || depth > 10 {
+			return nil
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		m := matrix.IdentMatrix
+		if a := sd.ArrayEntry("Matrix"); len(a) == 6 {
+			ff := make([]float64, 6)
+			for i, o := range a {
+				f, err := ip.ctx.DereferenceNumber(o)
+				if err != nil {
+					return err
+				}
+				ff[i] = f
+			}
+			m = matrixFromOperands(ff)
+		}
+		res := resDict
+		if d, err := ip.ctx.DereferenceDict(sd.Dict["Resources"]); err == nil && d != nil {
+			res = d
+		}
+		ip.forms[objNr] = true
+		err = ip.process(sd.Content, res, m.Multiply(ctm), depth+1)
+		delete(ip.forms, objNr)
+		return err
+	}
+
+	return nil
+}
+
+func (ip *imagePlacements) process(content []byte, resDict types.Dict, ctm matrix.Matrix, depth int) error {
+	stack := []matrix.Matrix{}
+
+	for _, op := range parseContentOps(content) {
+		switch op.name {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if n := len(stack); n > 0 {
+				ctm, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			if len(op.operands) >= 6 {
+				ctm = matrixFromOperands(operandNumbers(op.operands[len(op.operands)-6:])).Multiply(ctm)
+			}
+		case "Do":
+			if len(op.operands) == 1 && resDict != nil {
+				if err := ip.xObject(resDict, strings.TrimPrefix(op.operands[0], "/"), ctm, depth); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// imageResolutions returns the lowest effective resolution for images placed on pages directly or via form XObjects.
+func imageResolutions(ctx *model.Context) (map[int]float64, error) {
+	ip := imagePlacements{ctx: ctx, dpi: map[int]float64{}, forms: map[int]bool{}}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		_, content, resDict, err := pageContentAndResources(ctx, pageNr)
+		if err != nil {
+			return nil, err
+		}
+		if err := ip.process(content, resDict, matrix.IdentMatrix, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	return ip.dpi, nil
+}
+
+// PageImagePlacements returns by object number where images are drawn on pageNr directly or via form XObjects.
+func PageImagePlacements(ctx *model.Context, pageNr int) (map[int][]model.ImagePlacement, error) {
+	ip := imagePlacements{ctx: ctx, dpi: map[int]float64{}, placements: map[int][]model.ImagePlacement{}, forms: map[int]bool{}}
+
+	_, content, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+	if err := ip.process(content, resDict, matrix.IdentMatrix, 0); err != nil {
+		return nil, err
+	}
+
+	return ip.placements, nil
+}
+
+// imageSamples represents the decoded 8 bit samples of an image.
+type imageSamples struct {
+	w, h, n int // width, height, number of color components
+	pix     []byte
+	dct     bool // image has been DCT encoded
+}
+
+func imageColorComponents(ctx *model.Context, o types.Object) int {
+	o, err := ctx.Dereference(o)
+	if err != nil {
+		return 0
+	}
+
+	switch cs := o.(type) {
+
+	case types.Name:
+		switch cs {
+		case model.DeviceGrayCS:
+			return 1
+		case model.DeviceRGBCS:
+			return 3
+		case model.DeviceCMYKCS:
+			return 4
+		}
+
+	case types.Array:
+		if len(cs) != 2 {
+			return 0
+		}
+		if n, ok := cs[0].(types.Name); !ok || n != model.ICCBasedCS {
+			return 0
+		}
+		sd, _, err := ctx.DereferenceStreamDict(cs[1])
+		if err != nil || sd == nil {
+			return 0
+		}
+		if n := sd.IntEntry("N"); n != nil && (*n == 1 || *n == 3 || *n == 4) {
+			return *n
+		}
+	}
+
+	return 0
+}
+
+func losslessFilterPipeline(fpl []types.PDFFilter) bool {
+	for _, f := range fpl {
+		switch f.Name {
+		case filter.Flate, filter.LZW, filter.RunLength, filter.ASCII85, filter.ASCIIHex:
+		default:
+			if !filter.Registered(f.Name) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func dctSamples(bb []byte, w, h, n int) ([]byte, error) {
+	img, err := jpeg.Decode(bytes.NewReader(bb))
+	if err != nil {
+		return nil, err
+	}
+
+	r := img.Bounds()
+	if r.Dx() != w || r.Dy() != h {
+		return nil, errors.New("pdfcpu: image dimensions mismatch")
+	}
+
+	if n == 1 {
+		if g, ok := img.(*image.Gray); ok {
+			return g.Pix, nil
+		}
+		g := image.NewGray(r)
+		draw.Draw(g, r, img, r.Min, draw.Src)
+		return g.Pix, nil
+	}
+
+	rgba := image.NewRGBA(r)
+	draw.Draw(rgba, r, img, r.Min, draw.Src)
+
+	pix := make([]byte, 0, w*h*3)
+	for i := 0; i < len(rgba.Pix); i += 4 {
+		pix = append(pix, rgba.Pix[i], rgba.Pix[i+1], rgba.Pix[i+2])
+	}
+
+	return pix, nil
+}
+
+// jpxImageSamples decodes 8 bit JPEG 2000 images unless they carry their own soft mask.
+func jpxImageSamples(ctx *model.Context, sd *types.StreamDict, n int) *imageSamples {
+	if i := sd.IntEntry("SMaskInData"); i != nil && *i != 0 {
+		return nil
+	}
+
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if n == 0 || w == nil || h == nil {
+		return nil
+	}
+
+	img, err := filter.DecodeJPX(bytes.NewReader(sd.Raw))
+	if err != nil {
+		ctx.LogDebug("decodeImageSamples: unable to decode JPX", "err", err)
+		return nil
+	}
+
+	if img.Width != *w || img.Height != *h || img.Components != n || img.BPC != 8 {
+		return nil
+	}
+
+	// JPEG 2000 images are treated like JPEG images except for CMYK which can't be DCT encoded.
+	return &imageSamples{w: *w, h: *h, n: n, pix: img.Pix, dct: n != 4}
+}
+
+// decodeImageSamples returns the 8 bit samples of images pdfcpu is able to re-encode or nil.
+func decodeImageSamples(ctx *model.Context, sd *types.StreamDict) (*imageSamples, error) {
+	return decodeImageSamplesN(ctx, sd, imageColorComponents(ctx, sd.Dict["ColorSpace"]))
+}
+
+// decodeImageSamplesN decodes the samples of an image whose color space has n components.
+func decodeImageSamplesN(ctx *model.Context, sd *types.StreamDict, n int) (*imageSamples, error) {
+	if im := sd.BooleanEntry("ImageMask"); im != nil && *im {
+		return nil, nil
+	}
+
+	if _, found := sd.Find("Decode"); found {
+		return nil, nil
+	}
+
+	if fpl := sd.FilterPipeline; len(fpl) == 1 && fpl[0].Name == filter.JPX {
+		return jpxImageSamples(ctx, sd, n), nil
+	}
+
+	if bpc := sd.IntEntry("BitsPerComponent"); bpc == nil || *bpc != 8 {
+		return nil, nil
+	}
+
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if w == nil || h == nil || *w <= 0 || *h <= 0 {
+		return nil, nil
+	}
+
+	if n == 0 {
+		return nil, nil
+	}
+
+	is := &imageSamples{w: *w, h: *h, n: n}
+
+	fpl := sd.FilterPipeline
+
+	if len(fpl) == 1 && fpl[0].Name == filter.DCT {
+		if n == 4 {
+			// CMYK JPEGs can't be re-encoded without changing their color space.
+			return nil, nil
+		}
+		pix, err := dctSamples(sd.Raw, *w, *h, n)
+		if err != nil {
+			ctx.LogDebug("decodeImageSamples: failed", "err", err)
+			return nil, nil
+		}
+		is.pix, is.dct = pix, true
+		return is, nil
+	}
+
+	if !losslessFilterPipeline(fpl) {
+		return nil, nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		if err == filter.ErrUnsupportedFilter {
+			// A registered filter unable to decode this image.
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if len(sd.Content) < is.w*is.h*n {
+		return nil, nil
+	}
+
+	is.pix = sd.Content[:is.w*is.h*n]
+
+	return is, nil
+}
+
+// downsample reduces is to w x h pixels using area averaging.
+func (is *imageSamples) downsample(w, h int) *imageSamples {
+	pix := make([]byte, w*h*is.n)
+	sum := make([]int, is.n)
+
+	for y := 0; y < h; y++ {
+		y0, y1 := y*is.h/h, (y+1)*is.h/h
+		if y1 == y0 {
+			y1++
+		}
+		for x := 0; x < w; x++ {
+			x0, x1 := x*is.w/w, (x+1)*is.w/w
+			if x1 == x0 {
+				x1++
+			}
+			for i := range sum {
+				sum[i] = 0
+			}
+			for sy := y0; sy < y1; sy++ {
+				row := is.pix[(sy*is.w+x0)*is.n : (sy*is.w+x1)*is.n]
+				for i, v := range row {
+					sum[i%is.n] += int(v)
+				}
+			}
+			count := (y1 - y0) * (x1 - x0)
+			for i, v := range sum {
+				pix[(y*w+x)*is.n+i] = byte((v + count/2) / count)
+			}
+		}
+	}
+
+	return &imageSamples{w: w, h: h, n: is.n, pix: pix, dct: is.dct}
+}
+
+// grayscale converts the RGB or CMYK samples of is to gray.
+func (is *imageSamples) grayscale() *imageSamples {
+	pix := make([]byte, is.w*is.h)
+
+	for i := range pix {
+		p := is.pix[i*is.n : (i+1)*is.n]
+		if is.n == 3 {
+			pix[i] = byte((299*int(p[0]) + 587*int(p[1]) + 114*int(p[2]) + 500) / 1000)
+			continue
+		}
+		// CMYK
+		k := (300*int(p[0])+590*int(p[1])+110*int(p[2])+500)/1000 + int(p[3])
+		pix[i] = byte(255 - min(k, 255))
+	}
+
+	return &imageSamples{w: is.w, h: is.h, n: 1, pix: pix, dct: is.dct}
+}
+
+func (is *imageSamples) encodeDCT(quality int) ([]byte, error) {
+	var img image.Image
+
+	r := image.Rect(0, 0, is.w, is.h)
+	if is.n == 1 {
+		img = &image.Gray{Pix: is.pix, Stride: is.w, Rect: r}
+	} else {
+		rgba := image.NewRGBA(r)
+		for i, j := 0, 0; i < len(is.pix); i, j = i+3, j+4 {
+			rgba.Pix[j], rgba.Pix[j+1], rgba.Pix[j+2], rgba.Pix[j+3] = is.pix[i], is.pix[i+1], is.pix[i+2], 0xFF
+		}
+		img = rgba
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// imageStreamDict returns a copy of sd taking the samples of is, DCT encoded if requested, or flate encoded.
+func imageStreamDict(sd *types.StreamDict, is *imageSamples, dct bool, quality int) (*types.StreamDict, error) {
+	d := sd.Dict.Clone().(types.Dict)
+	d.Update("Width", types.Integer(is.w))
+	d.Update("Height", types.Integer(is.h))
+	d.Update("BitsPerComponent", types.Integer(8))
+	d.Delete("DecodeParms")
+
+	sd1 := &types.StreamDict{Dict: d}
+
+	if !dct {
+		d.Update("Filter", types.Name(filter.Flate))
+		sd1.Content = is.pix
+		sd1.FilterPipeline = []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}}
+		if err := sd1.Encode(); err != nil {
+			return nil, err
+		}
+		return sd1, nil
+	}
+
+	bb, err := is.encodeDCT(quality)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Update("Filter", types.Name(filter.DCT))
+	sd1.Content = bb
+
+	// Calling Encode without FilterPipeline ensures an encoded stream in sd.Raw.
+	if err := sd1.Encode(); err != nil {
+		return nil, err
+	}
+
+	sd1.Content = nil
+	sd1.FilterPipeline = []types.PDFFilter{{Name: filter.DCT, DecodeParms: nil}}
+
+	return sd1, nil
+}
+
+// softMaskForDownsampling returns the soft mask of sd and its samples or nil if it can't be downsampled along with sd.
+func softMaskForDownsampling(ctx *model.Context, sd *types.StreamDict) (*types.IndirectRef, *types.StreamDict, *imageSamples, bool, error) {
+	indRef := sd.IndirectRefEntry("SMask")
+	if indRef == nil {
+		_, found := sd.Find("SMask")
+		return nil, nil, nil, !found, nil
+	}
+
+	sm, _, err := ctx.DereferenceStreamDict(*indRef)
+	if err != nil || sm == nil {
+		return nil, nil, nil, false, err
+	}
+
+	if _, found := sm.Find("Matte"); found {
+		return nil, nil, nil, false, nil
+	}
+
+	is, err := decodeImageSamples(ctx, sm)
+	if err != nil || is == nil || is.n != 1 {
+		return nil, nil, nil, false, err
+	}
+
+	return indRef, sm, is, true, nil
+}
+
+type imageOptimizer struct {
+	ctx   *model.Context
+	imo   *model.ImageOptimization
+	dpi   map[int]float64
+	masks types.IntSet // downsampled soft masks
+	mrc   types.IntSet // layers of mixed raster content
+	stats model.ImageOptimizationStats
+}
+
+// targetSize returns the size is should be downsampled to.
+func (o *imageOptimizer) targetSize(objNr int, is *imageSamples) (int, int, bool) {
+	dpi, ok := o.dpi[objNr]
+	if !ok || o.imo.DPI <= 0 || dpi <= float64(o.imo.DPI)*downsampleTolerance {
+		return is.w, is.h, false
+	}
+
+	f := float64(o.imo.DPI) / dpi
+	w := int(math.Max(1, math.Round(float64(is.w)*f)))
+	h := int(math.Max(1, math.Round(float64(is.h)*f)))
+
+	return w, h, w < is.w || h < is.h
+}
+
+func (o *imageOptimizer) updateEntry(objNr int, sd *types.StreamDict) {
+	if entry, ok := o.ctx.FindTableEntry(objNr, 0); ok {
+		entry.Object = *sd
+	}
+}
+
+// grayscaleCompatible returns true if the color space of sd may be changed to DeviceGray.
+// A soft mask's Matte entry is specified in the color space of its parent image.
+func (o *imageOptimizer) grayscaleCompatible(sd *types.StreamDict) (bool, error) {
+	o1, found := sd.Find("SMask")
+	if !found {
+		return true, nil
+	}
+	sm, _, err := o.ctx.DereferenceStreamDict(o1)
+	if err != nil || sm == nil {
+		return false, err
+	}
+	_, found = sm.Find("Matte")
+	return !found, nil
+}
+
+func (o *imageOptimizer) optimizeImage(objNr int) error {
+	sd, _, err := o.ctx.DereferenceStreamDict(*types.NewIndirectRef(objNr, 0))
+	if err != nil || sd == nil {
+		return err
+	}
+
+	if bilevelImage(o.ctx, sd) {
+		return o.optimizeBilevelImage(objNr, sd)
+	}
+
+	is, err := decodeImageSamples(o.ctx, sd)
+	if err != nil || is == nil {
+		return err
+	}
+
+	if !is.dct && is.n == 1 {
+		if bb, ok := is.packBilevel(); ok {
+			// Thresholded scans keep their resolution.
+			return o.replaceByCCITT(objNr, sd, bb, is.w, is.h)
+		}
+	}
+
+	oldSize := int64(len(sd.Raw))
+
+	dct := is.dct || (o.imo.DCTThreshold > 0 && oldSize > o.imo.DCTThreshold && is.n != 4)
+
+	w, h, downsample := o.targetSize(objNr, is)
+
+	gray := o.imo.Grayscale && is.n > 1
+	if gray {
+		if ok, err := o.grayscaleCompatible(sd); err != nil || !ok {
+			return err
+		}
+	}
+
+	if !downsample && !dct && !gray {
+		return nil
+	}
+
+	var (
+		smIndRef *types.IndirectRef
+		sm, sm1  *types.StreamDict
+	)
+
+	if downsample {
+		var (
+			smIs *imageSamples
+			ok   bool
+		)
+		if smIndRef, sm, smIs, ok, err = softMaskForDownsampling(o.ctx, sd); err != nil {
+			return err
+		}
+		if ok && smIndRef != nil && o.masks[smIndRef.ObjectNumber.Value()] {
+			// The soft mask is shared with an image already downsampled.
+			ok = false
+		}
+		if !ok {
+			if !dct && !gray {
+				return nil
+			}
+			// Keep the size matching an unsupported soft mask.
+			w, h, downsample = is.w, is.h, false
+		}
+		if downsample {
+			is = is.downsample(w, h)
+			if smIs != nil {
+				if sm1, err = imageStreamDict(sm, smIs.downsample(w, h), false, 0); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	if gray {
+		is = is.grayscale()
+	}
+
+	sd1, err := imageStreamDict(sd, is, dct, o.imo.Quality)
+	if err != nil {
+		return err
+	}
+
+	if gray {
+		sd1.Dict.Update("ColorSpace", types.Name(model.DeviceGrayCS))
+	}
+
+	newSize := int64(len(sd1.Raw))
+	if sm1 != nil {
+		oldSize += int64(len(sm.Raw))
+		newSize += int64(len(sm1.Raw))
+	}
+
+	if newSize >= oldSize && !gray {
+		return nil
+	}
+
+	o.updateEntry(objNr, sd1)
+	if sm1 != nil {
+		o.updateEntry(smIndRef.ObjectNumber.Value(), sm1)
+		o.masks[smIndRef.ObjectNumber.Value()] = true
+	}
+
+	o.stats.Images++
+	if downsample {
+		o.stats.Downsized++
+	}
+	if gray {
+		o.stats.Grayscaled++
+	}
+	o.stats.BytesSaved += oldSize - newSize
+
+	return nil
+}
+
+// bilevelImage returns true for 1 bit gray images and image masks using lossless filters.
+func bilevelImage(ctx *model.Context, sd *types.StreamDict) bool {
+	if im := sd.BooleanEntry("ImageMask"); im == nil || !*im {
+		if bpc := sd.IntEntry("BitsPerComponent"); bpc == nil || *bpc != 1 {
+			return false
+		}
+		if n, err := ColorSpaceComponents(ctx.XRefTable, sd); err != nil || n != 1 {
+			return false
+		}
+	}
+	return losslessFilterPipeline(sd.FilterPipeline)
+}
+
+// packBilevel returns the samples of a gray image consisting of black and white pixels only as rows of 1 bit pixels.
+func (is *imageSamples) packBilevel() ([]byte, bool) {
+	rowLen := (is.w + 7) / 8
+	bb := make([]byte, rowLen*is.h)
+	for y := 0; y < is.h; y++ {
+		for x := 0; x < is.w; x++ {
+			switch is.pix[y*is.w+x] {
+			case 0:
+			case 0xFF:
+				bb[y*rowLen+x/8] |= 0x80 >> (x % 8)
+			default:
+				return nil, false
+			}
+		}
+	}
+	return bb, true
+}
+
+// ccittImageStreamDict returns a copy of sd taking rows of 1 bit samples CCITT Group 4 encoded.
+func ccittImageStreamDict(sd *types.StreamDict, bb []byte, w, h int) (*types.StreamDict, error) {
+	parms := types.Dict(map[string]types.Object{
+		"K":       types.Integer(-1),
+		"Columns": types.Integer(w),
+		"Rows":    types.Integer(h),
+	})
+
+	d := sd.Dict.Clone().(types.Dict)
+	d.Update("BitsPerComponent", types.Integer(1))
+	d.Update("Filter", types.Name(filter.CCITTFax))
+	d.Update("DecodeParms", parms)
+
+	sd1 := &types.StreamDict{
+		Dict:           d,
+		Content:        bb,
+		FilterPipeline: []types.PDFFilter{{Name: filter.CCITTFax, DecodeParms: parms}},
+	}
+
+	if err := sd1.Encode(); err != nil {
+		return nil, err
+	}
+
+	return sd1, nil
+}
+
+// replaceByCCITT replaces image objNr by its CCITT Group 4 encoded 1 bit samples if this saves space.
+func (o *imageOptimizer) replaceByCCITT(objNr int, sd *types.StreamDict, bb []byte, w, h int) error {
+	sd1, err := ccittImageStreamDict(sd, bb, w, h)
+	if err != nil {
+		return err
+	}
+
+	oldSize, newSize := int64(len(sd.Raw)), int64(len(sd1.Raw))
+	if newSize >= oldSize {
+		return nil
+	}
+
+	o.updateEntry(objNr, sd1)
+	o.stats.Images++
+	o.stats.BytesSaved += oldSize - newSize
+
+	return nil
+}
+
+// optimizeBilevelImage CCITT Group 4 encodes a 1 bit image.
+func (o *imageOptimizer) optimizeBilevelImage(objNr int, sd *types.StreamDict) error {
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if w == nil || h == nil || *w <= 0 || *h <= 0 {
+		return nil
+	}
+
+	if err := sd.Decode(); err != nil {
+		return err
+	}
+
+	rowLen := (*w + 7) / 8
+	if len(sd.Content) < rowLen**h {
+		return nil
+	}
+
+	return o.replaceByCCITT(objNr, sd, sd.Content[:rowLen**h], *w, *h)
+}
+
+// OptimizeImages re-encodes images placed on pages either directly or via form XObjects.
+// Images are downsampled to the target resolution based on their largest placement size,
+// existing JPEG and JPEG 2000 images are recompressed as JPEG using the given quality and
+// lossless images exceeding the given size get converted to JPEG
+// and color images get converted to DeviceGray if requested.
+// Bilevel images get CCITT Group 4 encoded without being downsampled.
+// Scanned pages may also be split into mixed raster content layers.
+// Apart from grayscale conversion images which would not shrink in size are left alone.
+func OptimizeImages(ctx *model.Context, imo *model.ImageOptimization) (*model.ImageOptimizationStats, error) {
+	if imo == nil {
+		return nil, errors.New("pdfcpu: OptimizeImages: missing imo")
+	}
+
+	if imo.Quality < 1 || imo.Quality > 100 {
+		return nil, errors.Errorf("pdfcpu: invalid JPEG quality: %d", imo.Quality)
+	}
+
+	o := imageOptimizer{ctx: ctx, imo: imo, masks: types.IntSet{}, mrc: types.IntSet{}}
+
+	if imo.MRC {
+		objNrs, err := scannedPageImages(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, objNr := range objNrs {
+			if err := o.mrcCompress(objNr); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	dpi, err := imageResolutions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	o.dpi = dpi
+
+	objNrs := make([]int, 0, len(dpi))
+	for objNr := range dpi {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objNr := range objNrs {
+		if o.mrc[objNr] {
+			continue
+		}
+		if err := o.optimizeImage(objNr); err != nil {
+			return nil, err
+		}
+	}
+
+	return &o.stats, nil
+}
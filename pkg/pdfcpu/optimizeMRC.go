@@ -0,0 +1,487 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"sort"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	// mrcMinPixels is the minimum size of scanned page images subject to MRC compression.
+	mrcMinPixels = 1000 * 1000
+
+	// Resolution divisors for the background and foreground layers.
+	mrcBackgroundFactor = 3
+	mrcForegroundFactor = 6
+
+	// Radius of the window used to estimate the local background brightness.
+	mrcWindowRadius = 15
+
+	// Minimum darkness of foreground pixels compared to their local background.
+	mrcMinContrast = 24
+
+	// Pages whose foreground covers less or more of the page than these ratios are no text scans.
+	mrcMinForegroundRatio = 0.001
+	mrcMaxForegroundRatio = 0.35
+)
+
+// scannedPageImage returns the object number of the image making up the content of page pageNr or 0.
+// A scanned page has nothing but one image placed by q, cm, Do and Q.
+func scannedPageImage(ctx *model.Context, pageNr int) (int, error) {
+	_, content, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil || content == nil || resDict == nil {
+		return 0, err
+	}
+
+	var name string
+
+	for _, op := range parseContentOps(content) {
+		switch op.name {
+		case "q", "Q", "cm":
+		case "Do":
+			if name != "" || len(op.operands) != 1 {
+				return 0, nil
+			}
+			name = op.operands[0]
+		default:
+			return 0, nil
+		}
+	}
+
+	if len(name) < 2 || name[0] != '/' {
+		return 0, nil
+	}
+
+	d, err := ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || d == nil {
+		return 0, err
+	}
+
+	n, err := types.DecodeName(name[1:])
+	if err != nil {
+		return 0, nil
+	}
+
+	indRef, ok := d[n].(types.IndirectRef)
+	if !ok {
+		return 0, nil
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(indRef)
+	if err != nil || sd == nil {
+		return 0, err
+	}
+
+	if st := sd.Subtype(); st == nil || *st != "Image" {
+		return 0, nil
+	}
+
+	for _, k := range []string{"SMask", "Mask", "ImageMask", "Alternates", "OC"} {
+		if _, found := sd.Find(k); found {
+			return 0, nil
+		}
+	}
+
+	return indRef.ObjectNumber.Value(), nil
+}
+
+func scannedPageImages(ctx *model.Context) ([]int, error) {
+	if err := ctx.EnsurePageCount(); err != nil {
+		return nil, err
+	}
+
+	m := types.IntSet{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		objNr, err := scannedPageImage(ctx, pageNr)
+		if err != nil {
+			return nil, err
+		}
+		if objNr > 0 {
+			m[objNr] = true
+		}
+	}
+
+	objNrs := make([]int, 0, len(m))
+	for objNr := range m {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	return objNrs, nil
+}
+
+// luminance returns the brightness of each pixel of is.
+func (is *imageSamples) luminance() []byte {
+	if is.n == 1 {
+		return is.pix
+	}
+	return is.grayscale().pix
+}
+
+// otsuThreshold returns the brightness separating dark from light pixels best.
+func otsuThreshold(lum []byte) int {
+	var hist [256]int
+	for _, v := range lum {
+		hist[v]++
+	}
+
+	var sum float64
+	for i, c := range hist {
+		sum += float64(i * c)
+	}
+
+	var (
+		sumB, maxVar float64
+		wB           int
+		t            int
+	)
+
+	for i, c := range hist {
+		if wB += c; wB == 0 {
+			continue
+		}
+		wF := len(lum) - wB
+		if wF == 0 {
+			break
+		}
+		sumB += float64(i * c)
+		mB, mF := sumB/float64(wB), (sum-sumB)/float64(wF)
+		if v := float64(wB) * float64(wF) * (mB - mF) * (mB - mF); v > maxVar {
+			maxVar, t = v, i
+		}
+	}
+
+	return t
+}
+
+// mrcMask separates text and line art from the background of a scanned page.
+// A pixel belongs to the foreground if it is dark both globally and compared to its surroundings.
+func mrcMask(is *imageSamples) ([]bool, bool) {
+	w, h := is.w, is.h
+	lum := is.luminance()
+	t := otsuThreshold(lum)
+
+	// Integral image for local mean brightness.
+	integral := make([]int64, (w+1)*(h+1))
+	for y := 0; y < h; y++ {
+		var rowSum int64
+		for x := 0; x < w; x++ {
+			rowSum += int64(lum[y*w+x])
+			integral[(y+1)*(w+1)+x+1] = integral[y*(w+1)+x+1] + rowSum
+		}
+	}
+
+	mask := make([]bool, w*h)
+	r := mrcWindowRadius
+
+	for y := 0; y < h; y++ {
+		y0, y1 := max(0, y-r), min(h, y+r+1)
+		for x := 0; x < w; x++ {
+			v := int(lum[y*w+x])
+			if v > t {
+				continue
+			}
+			x0, x1 := max(0, x-r), min(w, x+r+1)
+			s := integral[y1*(w+1)+x1] - integral[y0*(w+1)+x1] - integral[y1*(w+1)+x0] + integral[y0*(w+1)+x0]
+			mean := int(s / int64((y1-y0)*(x1-x0)))
+			mask[y*w+x] = v+mrcMinContrast <= mean
+		}
+	}
+
+	// Drop isolated pixels which are most likely noise.
+	var count int
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !mask[y*w+x] {
+				continue
+			}
+			if !hasMaskNeighbour(mask, w, h, x, y) {
+				mask[y*w+x] = false
+				continue
+			}
+			count++
+		}
+	}
+
+	ratio := float64(count) / float64(w*h)
+
+	return mask, ratio >= mrcMinForegroundRatio && ratio <= mrcMaxForegroundRatio
+}
+
+func hasMaskNeighbour(mask []bool, w, h, x, y int) bool {
+	for dy := -1; dy <= 1; dy++ {
+		for dx := -1; dx <= 1; dx++ {
+			x1, y1 := x+dx, y+dy
+			if (dx != 0 || dy != 0) && x1 >= 0 && x1 < w && y1 >= 0 && y1 < h && mask[y1*w+x1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dilateMask grows the foreground of mask by one pixel in order to keep anti-aliased edges out of the background.
+func dilateMask(mask []bool, w, h int) []bool {
+	m := make([]bool, len(mask))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			m[y*w+x] = mask[y*w+x] || hasMaskNeighbour(mask, w, h, x, y)
+		}
+	}
+	return m
+}
+
+// mrcLayer returns is reduced by factor f averaging either the pixels selected by mask or all others.
+// Cells without any such pixels take the color of their nearest neighbour in order to compress well.
+func mrcLayer(is *imageSamples, mask []bool, selected bool, f int) *imageSamples {
+	w, h, n := (is.w+f-1)/f, (is.h+f-1)/f, is.n
+
+	pix := make([]byte, w*h*n)
+	filled := make([]bool, w*h)
+	sum := make([]int, n)
+	queue := make([]int, 0, w*h)
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			for i := range sum {
+				sum[i] = 0
+			}
+			var count int
+			for sy := y * f; sy < min(is.h, (y+1)*f); sy++ {
+				for sx := x * f; sx < min(is.w, (x+1)*f); sx++ {
+					if mask[sy*is.w+sx] != selected {
+						continue
+					}
+					for i := range sum {
+						sum[i] += int(is.pix[(sy*is.w+sx)*n+i])
+					}
+					count++
+				}
+			}
+			if count == 0 {
+				continue
+			}
+			for i, v := range sum {
+				pix[(y*w+x)*n+i] = byte((v + count/2) / count)
+			}
+			filled[y*w+x] = true
+			queue = append(queue, y*w+x)
+		}
+	}
+
+	if len(queue) == 0 {
+		// Nothing to average, use white for the background and black for the foreground.
+		if !selected {
+			for i := range pix {
+				pix[i] = 0xFF
+			}
+		}
+		return &imageSamples{w: w, h: h, n: n, pix: pix}
+	}
+
+	// Breadth first propagation of filled cells into empty cells.
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		x, y := c%w, c/w
+		for _, d := range [][2]int{{-1, 0}, {1, 0}, {0, -1}, {0, 1}} {
+			x1, y1 := x+d[0], y+d[1]
+			if x1 < 0 || x1 >= w || y1 < 0 || y1 >= h || filled[y1*w+x1] {
+				continue
+			}
+			c1 := y1*w + x1
+			copy(pix[c1*n:(c1+1)*n], pix[c*n:(c+1)*n])
+			filled[c1] = true
+			queue = append(queue, c1)
+		}
+	}
+
+	return &imageSamples{w: w, h: h, n: n, pix: pix}
+}
+
+// packedMask returns mask as rows of 1 bit pixels with foreground pixels set.
+func packedMask(mask []bool, w, h int) []byte {
+	rowLen := (w + 7) / 8
+	bb := make([]byte, rowLen*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if mask[y*w+x] {
+				bb[y*rowLen+x/8] |= 0x80 >> (x % 8)
+			}
+		}
+	}
+	return bb
+}
+
+func mrcMaskStreamDict(mask []bool, w, h int) (*types.StreamDict, error) {
+	parms := types.Dict(map[string]types.Object{
+		"K":        types.Integer(-1),
+		"Columns":  types.Integer(w),
+		"Rows":     types.Integer(h),
+		"BlackIs1": types.Boolean(true),
+	})
+
+	sd := &types.StreamDict{
+		Dict: types.Dict(map[string]types.Object{
+			"Type":             types.Name("XObject"),
+			"Subtype":          types.Name("Image"),
+			"Width":            types.Integer(w),
+			"Height":           types.Integer(h),
+			"ColorSpace":       types.Name(model.DeviceGrayCS),
+			"BitsPerComponent": types.Integer(1),
+			"Filter":           types.Name(filter.CCITTFax),
+			"DecodeParms":      parms,
+		}),
+		Content:        packedMask(mask, w, h),
+		FilterPipeline: []types.PDFFilter{{Name: filter.CCITTFax, DecodeParms: parms}},
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return sd, nil
+}
+
+func mrcLayerStreamDict(is *imageSamples, cs types.Object, quality int) (*types.StreamDict, error) {
+	d := types.Dict(map[string]types.Object{
+		"Type":       types.Name("XObject"),
+		"Subtype":    types.Name("Image"),
+		"ColorSpace": cs,
+	})
+	return imageStreamDict(&types.StreamDict{Dict: d}, is, true, quality)
+}
+
+// mrcFormStreamDict returns a form XObject painting the background layer followed by the masked foreground layer.
+// Its bounding box is the unit square which makes it a drop-in replacement for the image it has been derived from.
+func mrcFormStreamDict(bg, fg types.IndirectRef) (*types.StreamDict, error) {
+	d := types.Dict(map[string]types.Object{
+		"Type":    types.Name("XObject"),
+		"Subtype": types.Name("Form"),
+		"BBox":    types.NewNumberArray(0, 0, 1, 1),
+		"Matrix":  types.NewNumberArray(1, 0, 0, 1, 0, 0),
+		"Filter":  types.Name(filter.Flate),
+		"Resources": types.Dict(map[string]types.Object{
+			"XObject": types.Dict(map[string]types.Object{"Im0": bg, "Im1": fg}),
+		}),
+	})
+
+	sd := &types.StreamDict{
+		Dict:           d,
+		Content:        []byte("/Im0 Do /Im1 Do"),
+		FilterPipeline: []types.PDFFilter{{Name: filter.Flate, DecodeParms: nil}},
+	}
+
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	return sd, nil
+}
+
+// mrcCompress replaces the scanned page image objNr by a form XObject combining
+// a full resolution CCITT text mask with low resolution background and foreground layers.
+func (o *imageOptimizer) mrcCompress(objNr int) error {
+	entry, ok := o.ctx.FindTableEntryLight(objNr)
+	if !ok || entry.Free || entry.Generation == nil {
+		return nil
+	}
+
+	sd, _, err := o.ctx.DereferenceStreamDict(*types.NewIndirectRef(objNr, *entry.Generation))
+	if err != nil || sd == nil {
+		return err
+	}
+
+	is, err := decodeImageSamples(o.ctx, sd)
+	if err != nil || is == nil || is.n == 4 || is.w*is.h < mrcMinPixels {
+		return err
+	}
+
+	oldSize := int64(len(sd.Raw))
+
+	mask, ok := mrcMask(is)
+	if !ok {
+		return nil
+	}
+
+	cs := sd.Dict["ColorSpace"]
+	if o.imo.Grayscale && is.n > 1 {
+		is = is.grayscale()
+		cs = types.Name(model.DeviceGrayCS)
+	}
+
+	msd, err := mrcMaskStreamDict(mask, is.w, is.h)
+	if err != nil {
+		return err
+	}
+
+	bgsd, err := mrcLayerStreamDict(mrcLayer(is, dilateMask(mask, is.w, is.h), false, mrcBackgroundFactor), cs, o.imo.Quality)
+	if err != nil {
+		return err
+	}
+
+	fgsd, err := mrcLayerStreamDict(mrcLayer(is, mask, true, mrcForegroundFactor), cs, o.imo.Quality)
+	if err != nil {
+		return err
+	}
+
+	newSize := int64(len(msd.Raw) + len(bgsd.Raw) + len(fgsd.Raw))
+	if newSize >= oldSize {
+		if log.OptimizeEnabled() {
+			log.Optimize.Printf("mrcCompress: obj#%d: %d bytes would not shrink (%d bytes)\n", objNr, oldSize, newSize)
+		}
+		return nil
+	}
+
+	maskIndRef, err := o.ctx.IndRefForNewObject(*msd)
+	if err != nil {
+		return err
+	}
+	fgsd.Dict.Insert("SMask", *maskIndRef)
+
+	bgIndRef, err := o.ctx.IndRefForNewObject(*bgsd)
+	if err != nil {
+		return err
+	}
+
+	fgIndRef, err := o.ctx.IndRefForNewObject(*fgsd)
+	if err != nil {
+		return err
+	}
+
+	fsd, err := mrcFormStreamDict(*bgIndRef, *fgIndRef)
+	if err != nil {
+		return err
+	}
+
+	entry.Object = *fsd
+
+	for _, indRef := range []*types.IndirectRef{maskIndRef, bgIndRef, fgIndRef} {
+		o.mrc[indRef.ObjectNumber.Value()] = true
+	}
+
+	o.stats.MRC++
+	o.stats.BytesSaved += oldSize - newSize - int64(len(fsd.Raw))
+
+	return nil
+}
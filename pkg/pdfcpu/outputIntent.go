@@ -0,0 +1,422 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// components returns the number of color components of profiles for gray, RGB or CMYK devices.
+func (p iccProfile) components() int {
+	switch p.dataColorSpace() {
+	case "GRAY":
+		return 1
+	case "RGB ":
+		return 3
+	case "CMYK":
+		return 4
+	}
+	return 0
+}
+
+func outputIntentText(ctx *model.Context, d types.Dict, key string) (string, error) {
+	o, found := d.Find(key)
+	if !found {
+		return "", nil
+	}
+	return ctx.DereferenceText(o)
+}
+
+func outputIntent(ctx *model.Context, d types.Dict) (*model.OutputIntent, error) {
+	oi := &model.OutputIntent{}
+
+	if s := d.NameEntry("S"); s != nil {
+		oi.Subtype = *s
+	}
+
+	for _, e := range []struct {
+		key string
+		s   *string
+	}{
+		{"OutputConditionIdentifier", &oi.OutputConditionIdentifier},
+		{"OutputCondition", &oi.OutputCondition},
+		{"RegistryName", &oi.RegistryName},
+		{"Info", &oi.Info},
+	} {
+		s, err := outputIntentText(ctx, d, e.key)
+		if err != nil {
+			return nil, err
+		}
+		*e.s = s
+	}
+
+	o, found := d.Find("DestOutputProfile")
+	if !found {
+		return oi, nil
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return oi, err
+	}
+
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+
+	oi.Profile = sd.Content
+	if n := sd.IntEntry("N"); n != nil {
+		oi.N = *n
+	}
+	if p, err := newICCProfile(sd.Content); err == nil {
+		oi.ProfileDesc = p.description()
+	}
+
+	return oi, nil
+}
+
+// OutputIntents returns the output intents of ctx.
+func OutputIntents(ctx *model.Context) ([]model.OutputIntent, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	a, err := ctx.DereferenceArray(rootDict["OutputIntents"])
+	if err != nil {
+		return nil, err
+	}
+
+	ois := []model.OutputIntent{}
+
+	for _, o := range a {
+		d, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		oi, err := outputIntent(ctx, d)
+		if err != nil {
+			return nil, err
+		}
+		ois = append(ois, *oi)
+	}
+
+	return ois, nil
+}
+
+// ListOutputIntents returns a list of the output intents of ctx.
+func ListOutputIntents(ctx *model.Context) ([]string, error) {
+	ois, err := OutputIntents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ois) == 0 {
+		return []string{"no output intents available"}, nil
+	}
+
+	ss := []string{}
+	for i, oi := range ois {
+		ss = append(ss, fmt.Sprintf("%d: %s", i+1, oi))
+	}
+
+	return ss, nil
+}
+
+// prepareOutputIntent checks the profile of oi and fills in defaults derived from it.
+func prepareOutputIntent(oi *model.OutputIntent) error {
+	if oi.Subtype == "" {
+		return errors.New("pdfcpu: missing output intent subtype")
+	}
+
+	if oi.Profile == nil {
+		if oi.Subtype == "GTS_PDFA1" {
+			return errors.New("pdfcpu: PDF/A output intents require an ICC profile")
+		}
+		if oi.OutputConditionIdentifier == "" {
+			return errors.New("pdfcpu: output intent requires an ICC profile or an output condition identifier")
+		}
+		return nil
+	}
+
+	p, err := newICCProfile(oi.Profile)
+	if err != nil {
+		return err
+	}
+
+	if oi.N = p.components(); oi.N == 0 {
+		return errors.Errorf("pdfcpu: unsupported ICC profile color space: %s", strings.TrimSpace(p.dataColorSpace()))
+	}
+
+	oi.ProfileDesc = p.description()
+
+	if oi.OutputConditionIdentifier == "" {
+		oi.OutputConditionIdentifier = oi.ProfileDesc
+	}
+	if oi.OutputConditionIdentifier == "" {
+		oi.OutputConditionIdentifier = "Custom"
+	}
+
+	return nil
+}
+
+func outputIntentDict(ctx *model.Context, oi *model.OutputIntent) (types.Dict, error) {
+	d := types.Dict(map[string]types.Object{
+		"Type":                      types.Name("OutputIntent"),
+		"S":                         types.Name(oi.Subtype),
+		"OutputConditionIdentifier": types.StringLiteral(types.EncodeUTF16String(oi.OutputConditionIdentifier)),
+	})
+
+	for k, v := range map[string]string{
+		"OutputCondition": oi.OutputCondition,
+		"RegistryName":    oi.RegistryName,
+		"Info":            oi.Info,
+	} {
+		if v != "" {
+			d.Insert(k, types.StringLiteral(types.EncodeUTF16String(v)))
+		}
+	}
+
+	if oi.Profile == nil {
+		return d, nil
+	}
+
+	sd, err := ctx.NewStreamDictForBuf(oi.Profile)
+	if err != nil {
+		return nil, err
+	}
+	sd.InsertInt("N", oi.N)
+	if err := sd.Encode(); err != nil {
+		return nil, err
+	}
+
+	indRef, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return nil, err
+	}
+
+	d.Insert("DestOutputProfile", *indRef)
+
+	return d, nil
+}
+
+// updateOutputIntents adds oi to the output intents of ctx or replaces those of the same subtype.
+func updateOutputIntents(ctx *model.Context, oi *model.OutputIntent, replace bool) error {
+	if oi == nil {
+		return errors.New("pdfcpu: missing output intent")
+	}
+
+	if err := prepareOutputIntent(oi); err != nil {
+		return err
+	}
+
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	a, err := ctx.DereferenceArray(rootDict["OutputIntents"])
+	if err != nil {
+		return err
+	}
+
+	d, err := outputIntentDict(ctx, oi)
+	if err != nil {
+		return err
+	}
+
+	a1 := types.Array{}
+	replaced := false
+
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if s := d1.NameEntry("S"); s == nil || *s != oi.Subtype {
+			a1 = append(a1, o)
+			continue
+		}
+		if !replace {
+			return errors.Errorf("pdfcpu: output intent %s already exists, please use replace", oi.Subtype)
+		}
+		if !replaced {
+			a1 = append(a1, d)
+			replaced = true
+		}
+	}
+
+	if !replaced {
+		a1 = append(a1, d)
+	}
+
+	rootDict.Update("OutputIntents", a1)
+
+	// Output intents were introduced with PDF 1.4.
+	if ctx.XRefTable.Version() < model.V14 {
+		v := model.V14
+		ctx.RootVersion = &v
+	}
+
+	return nil
+}
+
+// AddOutputIntent adds oi to the output intents of ctx.
+// Each output intent subtype may occur only once.
+func AddOutputIntent(ctx *model.Context, oi *model.OutputIntent) error {
+	return updateOutputIntents(ctx, oi, false)
+}
+
+// ReplaceOutputIntent replaces the output intent of ctx having the subtype of oi by oi.
+// oi is added if there is no such output intent.
+func ReplaceOutputIntent(ctx *model.Context, oi *model.OutputIntent) error {
+	return updateOutputIntents(ctx, oi, true)
+}
+
+// addMissingOutputIntent embeds profile as PDF/X output intent unless ctx has an output intent.
+func addMissingOutputIntent(ctx *model.Context, profile []byte) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if _, found := rootDict.Find("OutputIntents"); found {
+		return nil
+	}
+
+	return AddOutputIntent(ctx, &model.OutputIntent{Subtype: "GTS_PDFX", Profile: profile})
+}
+
+type outputIntentParamMap map[string]func(string, *model.OutputIntent) error
+
+var outputIntentParamsMap = outputIntentParamMap{
+	"subtype":   parseOutputIntentSubtype,
+	"id":        parseOutputIntentIdentifier,
+	"condition": parseOutputIntentCondition,
+	"registry":  parseOutputIntentRegistry,
+	"info":      parseOutputIntentInfo,
+	"profile":   parseOutputIntentProfile,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into oi.
+func (m outputIntentParamMap) Handle(paramPrefix, paramValueStr string, oi *model.OutputIntent) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, oi)
+}
+
+func parseOutputIntentSubtype(s string, oi *model.OutputIntent) error {
+	if st, ok := model.OutputIntentSubtypes[strings.ToLower(s)]; ok {
+		oi.Subtype = st
+		return nil
+	}
+	if s == "" || strings.ContainsAny(s, " /") {
+		return errors.Errorf("pdfcpu: invalid output intent subtype: %s", s)
+	}
+	oi.Subtype = s
+	return nil
+}
+
+func parseOutputIntentIdentifier(s string, oi *model.OutputIntent) error {
+	oi.OutputConditionIdentifier = s
+	return nil
+}
+
+func parseOutputIntentCondition(s string, oi *model.OutputIntent) error {
+	oi.OutputCondition = s
+	return nil
+}
+
+func parseOutputIntentRegistry(s string, oi *model.OutputIntent) error {
+	oi.RegistryName = s
+	return nil
+}
+
+func parseOutputIntentInfo(s string, oi *model.OutputIntent) error {
+	oi.Info = s
+	return nil
+}
+
+func parseOutputIntentProfile(s string, oi *model.OutputIntent) error {
+	bb, err := os.ReadFile(s)
+	if err != nil {
+		return err
+	}
+	if _, err := newICCProfile(bb); err != nil {
+		return errors.Errorf("pdfcpu: invalid ICC profile: %s", s)
+	}
+	oi.Profile, oi.ProfileName = bb, s
+	return nil
+}
+
+// ParseOutputIntent parses an output intent string into an internal structure.
+func ParseOutputIntent(s string) (*model.OutputIntent, error) {
+	oi := model.DefaultOutputIntent()
+
+	for _, s := range strings.Split(s, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		i := strings.Index(s, ":")
+		if i < 0 {
+			return nil, errors.New("pdfcpu: Invalid output intent string. Please consult pdfcpu help outputintents")
+		}
+
+		paramPrefix := strings.TrimSpace(s[:i])
+		paramValueStr := strings.TrimSpace(s[i+1:])
+
+		if err := outputIntentParamsMap.Handle(paramPrefix, paramValueStr, oi); err != nil {
+			return nil, err
+		}
+	}
+
+	if oi.Profile == nil && oi.OutputConditionIdentifier == "" {
+		return nil, errors.New("pdfcpu: missing output intent profile, eg. \"profile:coated.icc\"")
+	}
+
+	return oi, nil
+}
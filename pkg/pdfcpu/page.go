@@ -193,29 +193,52 @@ func addPages(
 	return nil
 }
 
-func migrateNamedDests(ctxSrc *model.Context, n *model.Node, migrated map[int]int) error {
-	patchValues := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
+// migrateNamedDests returns a copy of ctxSrc's named destinations limited to migrated pages.
+func migrateNamedDests(ctxSrc *model.Context, n *model.Node, migrated map[int]int) (*model.Node, error) {
+	n1 := &model.Node{D: types.NewDict()}
+	count := 0
+
+	copyValues := func(xRefTable *model.XRefTable, k string, v *types.Object) error {
 		if *v == nil {
 			// Skip corrupt node.
 			return nil
 		}
-		arr, err := xRefTable.DereferenceArray(*v)
-		if err == nil {
-			arr[0] = patchObject(arr[0], migrated)
-			*v = arr
-			return nil
-		}
-		d, err := xRefTable.DereferenceDict(*v)
+		o, ok, err := migratedDest(ctxSrc, *v, migrated)
 		if err != nil {
 			return err
 		}
-		arr = d.ArrayEntry("D")
-		arr[0] = patchObject(arr[0], migrated)
-		*v = d
-		return nil
+		if !ok {
+			// Skip destinations for pages not migrated.
+			return nil
+		}
+		count++
+		return n1.Add(nil, k, o, nil, nil)
+	}
+
+	if err := n.Process(ctxSrc.XRefTable, copyValues); err != nil {
+		return nil, err
 	}
 
-	return n.Process(ctxSrc.XRefTable, patchValues)
+	if count == 0 {
+		return nil, nil
+	}
+
+	return n1, nil
+}
+
+// migrateDests returns a copy of ctxSrc's legacy named destinations dict limited to migrated pages.
+func migrateDests(ctxSrc *model.Context, migrated map[int]int) (types.Dict, error) {
+	d := types.NewDict()
+	for k, v := range ctxSrc.Dests {
+		o, ok, err := migratedDest(ctxSrc, v, migrated)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			d[k] = o
+		}
+	}
+	return d, nil
 }
 
 // AddPages adds pages and corresponding resources from ctxSrc to ctxDest.
@@ -257,10 +280,28 @@ func AddPages(ctxSrc, ctxDest *model.Context, pageNrs []int, usePgCache bool) er
 
 	if n, ok := ctxSrc.Names["Dests"]; ok {
 		// Carry over used named destinations.
-		if err := migrateNamedDests(ctxSrc, n, migrated); err != nil {
+		n1, err := migrateNamedDests(ctxSrc, n, migrated)
+		if err != nil {
 			return err
 		}
-		ctxDest.Names = map[string]*model.Node{"Dests": n}
+		if n1 != nil {
+			ctxDest.Names = map[string]*model.Node{"Dests": n1}
+		}
+	}
+
+	if len(ctxSrc.Dests) > 0 {
+		d, err := migrateDests(ctxSrc, migrated)
+		if err != nil {
+			return err
+		}
+		if len(d) > 0 {
+			ir, err := ctxDest.IndRefForNewObject(d)
+			if err != nil {
+				return err
+			}
+			ctxDest.RootDict["Dests"] = *ir
+			ctxDest.Dests = d
+		}
 	}
 
 	return nil
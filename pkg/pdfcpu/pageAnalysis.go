@@ -0,0 +1,328 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Pixels darker than this luminance count as ink.
+const inkLuminance = 128
+
+// ParsePageCleanup parses a comma separated list of "blank" and "duplicates".
+func ParsePageCleanup(s string) (*model.PageCleanup, error) {
+	pc := &model.PageCleanup{BlankThreshold: model.DefaultBlankThreshold}
+
+	for _, s := range strings.Split(s, ",") {
+		s = strings.ToLower(strings.TrimSpace(s))
+		switch {
+		case s != "" && strings.HasPrefix("blank", s):
+			pc.Blank = true
+		case s != "" && strings.HasPrefix("duplicates", s):
+			pc.Duplicates = true
+		default:
+			return nil, errors.Errorf("pdfcpu: invalid page cleanup: %s, use blank, duplicates", s)
+		}
+	}
+
+	return pc, nil
+}
+
+// bilevelInk returns the dimensions of a 1 bit gray image or image mask and a func reporting its painted samples.
+func bilevelInk(sd *types.StreamDict) (int, int, func(x, y int) bool, bool) {
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if w == nil || h == nil || *w <= 0 || *h <= 0 {
+		return 0, 0, nil, false
+	}
+
+	for _, f := range sd.FilterPipeline {
+		if f.Name != filter.CCITTFax && f.Name != filter.JBIG2 && !losslessFilterPipeline([]types.PDFFilter{f}) {
+			return 0, 0, nil, false
+		}
+	}
+
+	if err := sd.Decode(); err != nil {
+		return 0, 0, nil, false
+	}
+
+	rowLen := (*w + 7) / 8
+	if len(sd.Content) < rowLen**h {
+		return 0, 0, nil, false
+	}
+
+	// Sample value 0 means black for DeviceGray and paint for image masks unless decoded inverted.
+	ink := byte(0)
+	if a := sd.ArrayEntry("Decode"); len(a) == 2 {
+		if i, ok := a[0].(types.Integer); ok && i.Value() == 1 {
+			ink = 1
+		}
+	}
+
+	bb := sd.Content
+	return *w, *h, func(x, y int) bool {
+		return bb[y*rowLen+x/8]>>(7-x%8)&1 == ink
+	}, true
+}
+
+// dctImageSamples decodes JPEG images wrapped into lossless filters like scanners tend to produce them.
+func dctImageSamples(ctx *model.Context, sd *types.StreamDict) *imageSamples {
+	fpl := sd.FilterPipeline
+	if len(fpl) < 2 || fpl[len(fpl)-1].Name != filter.DCT || !losslessFilterPipeline(fpl[:len(fpl)-1]) {
+		return nil
+	}
+
+	n := imageColorComponents(ctx, sd.Dict["ColorSpace"])
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if (n != 1 && n != 3) || w == nil || h == nil {
+		return nil
+	}
+
+	// Decoding stops at the DCT filter.
+	if err := sd.Decode(); err != nil {
+		return nil
+	}
+
+	pix, err := dctSamples(sd.Content, *w, *h, n)
+	if err != nil {
+		return nil
+	}
+
+	return &imageSamples{w: *w, h: *h, n: n, pix: pix, dct: true}
+}
+
+// imageInk returns the dimensions of an image and a func reporting its dark pixels.
+func imageInk(ctx *model.Context, sd *types.StreamDict) (int, int, func(x, y int) bool, bool) {
+	bpc := sd.IntEntry("BitsPerComponent")
+	if im := sd.BooleanEntry("ImageMask"); im != nil && *im {
+		return bilevelInk(sd)
+	}
+	if bpc != nil && *bpc == 1 && imageColorComponents(ctx, sd.Dict["ColorSpace"]) == 1 {
+		return bilevelInk(sd)
+	}
+
+	is, err := decodeImageSamples(ctx, sd)
+	if err != nil {
+		return 0, 0, nil, false
+	}
+	if is == nil {
+		if is = dctImageSamples(ctx, sd); is == nil {
+			return 0, 0, nil, false
+		}
+	}
+
+	lum := is.luminance()
+	if len(lum) < is.w*is.h || is.w*is.h == 0 {
+		return 0, 0, nil, false
+	}
+
+	return is.w, is.h, func(x, y int) bool {
+		return lum[y*is.w+x] < inkLuminance
+	}, true
+}
+
+// imageInkRatio returns the ratio of dark pixels of an image.
+func imageInkRatio(ctx *model.Context, sd *types.StreamDict) (float64, bool) {
+	w, h, ink, ok := imageInk(ctx, sd)
+	if !ok {
+		return 0, false
+	}
+
+	n := 0
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if ink(x, y) {
+				n++
+			}
+		}
+	}
+
+	return float64(n) / float64(w*h), true
+}
+
+func intersection(r1, r2 types.Rectangle) types.Rectangle {
+	r := *types.NewRectangle(math.Max(r1.LL.X, r2.LL.X), math.Max(r1.LL.Y, r2.LL.Y), math.Min(r1.UR.X, r2.UR.X), math.Min(r1.UR.Y, r2.UR.Y))
+	if r.UR.X < r.LL.X || r.UR.Y < r.LL.Y {
+		return types.Rectangle{}
+	}
+	return r
+}
+
+type pageAnalyzer struct {
+	ctx       *model.Context
+	threshold float64
+	inkRatios map[int]float64 // ink ratios of images by object number.
+	hashes    map[string]int  // page numbers by page hash.
+}
+
+func (pa *pageAnalyzer) inkRatio(m pageMark) float64 {
+	if m.image == nil {
+		return 1
+	}
+	if r, ok := pa.inkRatios[m.objNr]; ok {
+		return r
+	}
+	r, ok := imageInkRatio(pa.ctx, m.image)
+	if !ok {
+		// Assume unknown images are no blank scans.
+		r = 1
+	}
+	pa.inkRatios[m.objNr] = r
+	return r
+}
+
+func (pa *pageAnalyzer) analyzePage(pageNr int) (*model.PageAnalysis, error) {
+	h, err := PageHash(pa.ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &model.PageAnalysis{PageNr: pageNr, Hash: h}
+
+	if pageNr1, ok := pa.hashes[h]; ok {
+		a.DuplicateOf = pageNr1
+	} else {
+		pa.hashes[h] = pageNr
+	}
+
+	_, _, inhPAttrs, err := pa.ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	box := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		box = inhPAttrs.CropBox
+	}
+	if box == nil || area(*box) == 0 {
+		return nil, errors.Errorf("pdfcpu: analyzePage: missing page boundaries for page %d", pageNr)
+	}
+
+	marks, err := pageMarks(pa.ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	ink := 0.
+	for _, m := range marks {
+		a.Text = a.Text || m.text
+		if m.ink == 0 || area(m.rect) == 0 {
+			continue
+		}
+		ink += area(intersection(m.rect, *box)) * m.ink / area(m.rect) * pa.inkRatio(m)
+	}
+
+	a.InkCoverage = math.Round(math.Min(1, ink/area(*box))*1e6) / 1e6
+	a.Blank = !a.Text && a.InkCoverage <= pa.threshold
+
+	return a, nil
+}
+
+// AnalyzePages returns the page hashes and ink coverage of all pages of ctx
+// and identifies duplicate pages and blank pages whose ink coverage does not exceed threshold.
+func AnalyzePages(ctx *model.Context, threshold float64) ([]model.PageAnalysis, error) {
+	if err := ctx.EnsurePageCount(); err != nil {
+		return nil, err
+	}
+
+	pa := pageAnalyzer{ctx: ctx, threshold: threshold, inkRatios: map[int]float64{}, hashes: map[string]int{}}
+
+	aa := make([]model.PageAnalysis, 0, ctx.PageCount)
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		a, err := pa.analyzePage(pageNr)
+		if err != nil {
+			return nil, err
+		}
+		aa = append(aa, *a)
+	}
+
+	return aa, nil
+}
+
+// CleanupPages returns a context without the blank and duplicate pages of ctx as configured by pc
+// and the page numbers removed.
+func CleanupPages(ctx *model.Context, pc *model.PageCleanup) (*model.Context, []int, error) {
+	if pc == nil || (!pc.Blank && !pc.Duplicates) {
+		return ctx, nil, nil
+	}
+
+	threshold := model.DefaultBlankThreshold
+	if pc.BlankThreshold > 0 {
+		threshold = pc.BlankThreshold
+	}
+
+	aa, err := AnalyzePages(ctx, threshold)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var keep, removed []int
+	for _, a := range aa {
+		if (pc.Blank && a.Blank) || (pc.Duplicates && a.DuplicateOf > 0) {
+			removed = append(removed, a.PageNr)
+			continue
+		}
+		keep = append(keep, a.PageNr)
+	}
+
+	if len(removed) == 0 {
+		return ctx, nil, nil
+	}
+
+	if len(keep) == 0 {
+		return nil, nil, errors.New("pdfcpu: CleanupPages: all pages are blank or duplicates")
+	}
+
+	ctxDest, err := ExtractPages(ctx, keep, false)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := ctxDest.EnsurePageCount(); err != nil {
+		return nil, nil, err
+	}
+
+	return ctxDest, removed, nil
+}
+
+// ListPageAnalysis returns a human readable version of aa.
+func ListPageAnalysis(aa []model.PageAnalysis) []string {
+	var blank, dup int
+	ss := []string{}
+
+	for _, a := range aa {
+		s := fmt.Sprintf("page %3d: ink %7.3f%%", a.PageNr, a.InkCoverage*100)
+		if a.Blank {
+			s += " blank"
+			blank++
+		}
+		if a.DuplicateOf > 0 {
+			s += fmt.Sprintf(" duplicate of page %d", a.DuplicateOf)
+			dup++
+		}
+		ss = append(ss, s)
+	}
+
+	return append(ss, fmt.Sprintf("%d pages, %d blank, %d duplicates", len(aa), blank, dup))
+}
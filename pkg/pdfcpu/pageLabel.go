@@ -0,0 +1,372 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var errNoPageLabels = errors.New("pdfcpu: no page labels available")
+
+func pageLabelForDict(ctx *model.Context, pageIndex int, o types.Object) (*model.PageLabel, error) {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return nil, errors.Errorf("pdfcpu: corrupt page label dict for page %d", pageIndex+1)
+	}
+
+	pl := model.PageLabel{PageFrom: pageIndex + 1, Start: 1}
+
+	if n := d.NameEntry("S"); n != nil {
+		st, ok := model.PageLabelStyleFor(*n)
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: invalid page label style for page %d: %s", pageIndex+1, *n)
+		}
+		pl.Style = st
+	}
+
+	if o, found := d.Find("P"); found {
+		o, err := ctx.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		if s, err := types.StringOrHexLiteral(o); err == nil {
+			pl.Prefix = *s
+		}
+	}
+
+	if o, found := d.Find("St"); found {
+		o, err := ctx.Dereference(o)
+		if err != nil {
+			return nil, err
+		}
+		if i, ok := o.(types.Integer); ok && i.Value() > 0 {
+			pl.Start = i.Value()
+		}
+	}
+
+	return &pl, nil
+}
+
+func pageLabelsForNumberTree(ctx *model.Context, o types.Object, pls *[]model.PageLabel) error {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return errors.New("pdfcpu: corrupt page labels number tree")
+	}
+
+	if kids := d.ArrayEntry("Kids"); kids != nil {
+		for _, o := range kids {
+			if err := pageLabelsForNumberTree(ctx, o, pls); err != nil {
+				return err
+			}
+		}
+	}
+
+	o, found := d.Find("Nums")
+	if !found {
+		return nil
+	}
+
+	a, err := ctx.DereferenceArray(o)
+	if err != nil {
+		return err
+	}
+
+	for i := 0; i+1 < len(a); i += 2 {
+		o, err := ctx.Dereference(a[i])
+		if err != nil {
+			return err
+		}
+		k, ok := o.(types.Integer)
+		if !ok || k.Value() < 0 {
+			return errors.Errorf("pdfcpu: corrupt page labels number tree key: %v", o)
+		}
+		if k.Value() >= ctx.PageCount {
+			continue
+		}
+		pl, err := pageLabelForDict(ctx, k.Value(), a[i+1])
+		if err != nil {
+			return err
+		}
+		*pls = append(*pls, *pl)
+	}
+
+	return nil
+}
+
+// PageLabels returns the page label ranges of ctx in ascending order.
+func PageLabels(ctx *model.Context) ([]model.PageLabel, error) {
+	o, found := ctx.RootDict.Find("PageLabels")
+	if !found {
+		return nil, nil
+	}
+
+	pls := []model.PageLabel{}
+	if err := pageLabelsForNumberTree(ctx, o, &pls); err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(pls, func(i, j int) bool { return pls[i].PageFrom < pls[j].PageFrom })
+
+	return pls, nil
+}
+
+// PageLabelStrings returns the page label of each page of ctx.
+// Pages not covered by any page label range are labeled with their page number.
+func PageLabelStrings(ctx *model.Context) ([]string, error) {
+	pls, err := PageLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := make([]string, ctx.PageCount)
+	j := -1
+	for i := range ss {
+		pageNr := i + 1
+		for j+1 < len(pls) && pls[j+1].PageFrom <= pageNr {
+			j++
+		}
+		if j < 0 {
+			ss[i] = strconv.Itoa(pageNr)
+			continue
+		}
+		ss[i] = pls[j].Label(pageNr)
+	}
+
+	return ss, nil
+}
+
+// ListPageLabels returns a list of the page label ranges of ctx.
+func ListPageLabels(ctx *model.Context) ([]string, error) {
+	pls, err := PageLabels(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pls) == 0 {
+		return []string{"no page labels available"}, nil
+	}
+
+	ss := []string{}
+	for i, pl := range pls {
+		thru := ctx.PageCount
+		if i+1 < len(pls) {
+			thru = pls[i+1].PageFrom - 1
+		}
+		if thru < pl.PageFrom {
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("pages %3d-%-3d: %s .. %s (%s)", pl.PageFrom, thru, pl.Label(pl.PageFrom), pl.Label(thru), pl))
+	}
+
+	return ss, nil
+}
+
+func validatePageLabels(pls []model.PageLabel, pageCount int) ([]model.PageLabel, error) {
+	if len(pls) == 0 {
+		return nil, errors.New("pdfcpu: missing page labels")
+	}
+
+	pls1 := make([]model.PageLabel, len(pls))
+	copy(pls1, pls)
+	sort.SliceStable(pls1, func(i, j int) bool { return pls1[i].PageFrom < pls1[j].PageFrom })
+
+	for i, pl := range pls1 {
+		if pl.PageFrom < 1 || pl.PageFrom > pageCount {
+			return nil, errors.Errorf("pdfcpu: page label: invalid page number: %d", pl.PageFrom)
+		}
+		if i > 0 && pl.PageFrom == pls1[i-1].PageFrom {
+			return nil, errors.Errorf("pdfcpu: page label: duplicate range for page %d", pl.PageFrom)
+		}
+		if pl.Start < 0 {
+			return nil, errors.Errorf("pdfcpu: page label: start must be >= 1: %d", pl.Start)
+		}
+	}
+
+	// The number tree needs to cover page 1.
+	if pls1[0].PageFrom > 1 {
+		pls1 = append([]model.PageLabel{{PageFrom: 1, Style: model.PageLabelDecimal, Start: 1}}, pls1...)
+	}
+
+	return pls1, nil
+}
+
+// SetPageLabels replaces the page labels of ctx by pls.
+func SetPageLabels(ctx *model.Context, pls []model.PageLabel) error {
+	pls, err := validatePageLabels(pls, ctx.PageCount)
+	if err != nil {
+		return err
+	}
+
+	nums := types.Array{}
+	for _, pl := range pls {
+		d := types.Dict(map[string]types.Object{})
+		if st := pl.Style.String(); st != "" {
+			d.InsertName("S", st)
+		}
+		if pl.Prefix != "" {
+			s, err := types.EscapedUTF16String(pl.Prefix)
+			if err != nil {
+				return err
+			}
+			d.Insert("P", types.StringLiteral(*s))
+		}
+		if pl.Start > 1 {
+			d.InsertInt("St", pl.Start)
+		}
+		nums = append(nums, types.Integer(pl.PageFrom-1), d)
+	}
+
+	ir, err := ctx.IndRefForNewObject(types.Dict(map[string]types.Object{"Nums": nums}))
+	if err != nil {
+		return err
+	}
+
+	ctx.RootDict.Update("PageLabels", *ir)
+
+	return nil
+}
+
+// RemovePageLabels removes all page labels from ctx.
+func RemovePageLabels(ctx *model.Context) error {
+	if _, found := ctx.RootDict.Find("PageLabels"); !found {
+		return errNoPageLabels
+	}
+	ctx.RootDict.Delete("PageLabels")
+	return nil
+}
+
+// ParsePageLabels parses a comma separated list of page label ranges:
+//
+//	pageFrom:style[:prefix[:start]]
+//
+// where style is one of D, R, r, A, a or empty for prefix only labels.
+// eg. "1:r, 5:D, 20:D:A-" labels a document i, ii, iii, iv, 1, 2 .. 15, A-1, A-2 ..
+func ParsePageLabels(s string) ([]model.PageLabel, error) {
+	pls := []model.PageLabel{}
+
+	for _, s := range strings.Split(s, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+
+		ss := strings.SplitN(s, ":", 4)
+		if len(ss) < 2 {
+			return nil, errors.Errorf("pdfcpu: page label: please provide pageFrom:style[:prefix[:start]]: %s", s)
+		}
+
+		pageFrom, err := strconv.Atoi(strings.TrimSpace(ss[0]))
+		if err != nil || pageFrom < 1 {
+			return nil, errors.Errorf("pdfcpu: page label: invalid page number: %s", ss[0])
+		}
+
+		st, ok := model.PageLabelStyleFor(strings.TrimSpace(ss[1]))
+		if !ok {
+			return nil, errors.Errorf("pdfcpu: page label: invalid style: %s, please use one of D, R, r, A, a", ss[1])
+		}
+
+		pl := model.PageLabel{PageFrom: pageFrom, Style: st, Start: 1}
+
+		if len(ss) > 2 {
+			pl.Prefix = ss[2]
+		}
+
+		if len(ss) > 3 {
+			i, err := strconv.Atoi(strings.TrimSpace(ss[3]))
+			if err != nil || i < 1 {
+				return nil, errors.Errorf("pdfcpu: page label: start must be an integer >= 1: %s", ss[3])
+			}
+			pl.Start = i
+		}
+
+		pls = append(pls, pl)
+	}
+
+	if len(pls) == 0 {
+		return nil, errors.New("pdfcpu: missing page labels")
+	}
+
+	return pls, nil
+}
+
+// pageNrForLabel returns the first page labeled s or 0.
+func pageNrForLabel(labels []string, s string) int {
+	for i, l := range labels {
+		if l == s {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// pageRangeForLabels returns the page range for an expression like "iii-v" or "A-1-A-5".
+func pageRangeForLabels(labels []string, s string) (int, int) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '-' {
+			continue
+		}
+		from, thru := pageNrForLabel(labels, s[:i]), pageNrForLabel(labels, s[i+1:])
+		if from > 0 && thru > 0 {
+			return from, thru
+		}
+	}
+	return 0, 0
+}
+
+// PageSelectionForLabels translates page labels used in pageSelection into page numbers.
+// Expressions may be a page label eg. "iii", a negated page label eg. "!iii" or a range of page labels eg. "i-iv" or "A-1-A-5".
+// Page labels take precedence, any other expression is taken as is.
+func PageSelectionForLabels(ctx *model.Context, pageSelection []string) ([]string, error) {
+	labels, err := PageLabelStrings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ss := []string{}
+	for _, s := range pageSelection {
+		var neg string
+		if pageNr := pageNrForLabel(labels, s); pageNr > 0 {
+			ss = append(ss, strconv.Itoa(pageNr))
+			continue
+		}
+		s1 := s
+		if len(s1) > 1 && (s1[0] == '!' || s1[0] == 'n') {
+			neg, s1 = s1[:1], s1[1:]
+		}
+		if pageNr := pageNrForLabel(labels, s1); pageNr > 0 {
+			ss = append(ss, neg+strconv.Itoa(pageNr))
+			continue
+		}
+		if from, thru := pageRangeForLabels(labels, s1); from > 0 {
+			if thru < from {
+				return nil, errors.Errorf("pdfcpu: page label range out of order: %s", s)
+			}
+			ss = append(ss, fmt.Sprintf("%s%d-%d", neg, from, thru))
+			continue
+		}
+		ss = append(ss, s)
+	}
+
+	return ss, nil
+}
@@ -0,0 +1,494 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+type resourceReporter struct {
+	ctx          *model.Context
+	r            *model.PageResourceReport
+	fonts        types.IntSet
+	colorSpaces  map[string]bool
+	transparency map[string]bool
+	forms        types.IntSet // Form XObjects being processed.
+}
+
+// colorSpaceDescription returns a short description of color space o.
+func (rr *resourceReporter) colorSpaceDescription(o types.Object) string {
+	o, err := rr.ctx.Dereference(o)
+	if err != nil || o == nil {
+		return ""
+	}
+
+	switch cs := o.(type) {
+
+	case types.Name:
+		return cs.Value()
+
+	case types.Array:
+		if len(cs) == 0 {
+			return ""
+		}
+		n, ok := cs[0].(types.Name)
+		if !ok {
+			return ""
+		}
+		switch n {
+		case model.ICCBasedCS:
+			if sd, _, err := rr.ctx.DereferenceStreamDict(cs[1]); err == nil && sd != nil {
+				if i := sd.IntEntry("N"); i != nil {
+					return fmt.Sprintf("ICCBased(%d)", *i)
+				}
+			}
+		case model.SeparationCS:
+			if len(cs) > 1 {
+				if s, err := rr.ctx.DereferenceName(cs[1], model.V10, nil); err == nil {
+					return fmt.Sprintf("Separation(%s)", s.Value())
+				}
+			}
+		case model.DeviceNCS:
+			if len(cs) > 1 {
+				if a, err := rr.ctx.DereferenceArray(cs[1]); err == nil {
+					ss := []string{}
+					for _, o := range a {
+						if s, ok := o.(types.Name); ok {
+							ss = append(ss, s.Value())
+						}
+					}
+					return fmt.Sprintf("DeviceN(%s)", strings.Join(ss, ","))
+				}
+			}
+		case model.IndexedCS:
+			if len(cs) > 1 {
+				return fmt.Sprintf("Indexed(%s)", rr.colorSpaceDescription(cs[1]))
+			}
+		case "Pattern":
+			if len(cs) > 1 {
+				return fmt.Sprintf("Pattern(%s)", rr.colorSpaceDescription(cs[1]))
+			}
+		}
+		return n.Value()
+	}
+
+	return ""
+}
+
+func (rr *resourceReporter) addColorSpace(o types.Object) {
+	if s := rr.colorSpaceDescription(o); s != "" {
+		rr.colorSpaces[s] = true
+	}
+}
+
+func (rr *resourceReporter) addFont(indRef *types.IndirectRef) {
+	objNr := indRef.ObjectNumber.Value()
+	if rr.fonts[objNr] {
+		return
+	}
+	rr.fonts[objNr] = true
+
+	d, err := rr.ctx.DereferenceDict(*indRef)
+	if err != nil || d == nil {
+		return
+	}
+
+	fo := model.FontObject{FontDict: d}
+	pf := model.PageFont{ObjNr: objNr, Type: fo.SubType(), Encoding: fo.Encoding()}
+
+	prefix, name, err := pdffont.Name(rr.ctx.XRefTable, d, objNr)
+	if err == nil {
+		pf.Name, pf.Subset = name, prefix != ""
+	}
+
+	if pf.Type != "Type3" {
+		pf.Embedded, _ = pdffont.Embedded(rr.ctx.XRefTable, d, objNr)
+	}
+
+	rr.r.Fonts = append(rr.r.Fonts, pf)
+}
+
+func (rr *resourceReporter) processExtGState(d types.Dict) {
+	for _, k := range []string{"ca", "CA"} {
+		if f, err := rr.ctx.DereferenceNumber(d[k]); err == nil && d[k] != nil && f < 1 {
+			rr.transparency["constant alpha"] = true
+		}
+	}
+	if o, _ := rr.ctx.Dereference(d["SMask"]); o != nil {
+		if n, ok := o.(types.Name); !ok || n != "None" {
+			rr.transparency["soft mask"] = true
+		}
+	}
+	if o, _ := rr.ctx.Dereference(d["BM"]); o != nil {
+		bm := ""
+		switch o := o.(type) {
+		case types.Name:
+			bm = o.Value()
+		case types.Array:
+			if len(o) > 0 {
+				if n, ok := o[0].(types.Name); ok {
+					bm = n.Value()
+				}
+			}
+		}
+		if bm != "" && bm != "Normal" && bm != "Compatible" {
+			rr.transparency["blend mode "+bm] = true
+		}
+	}
+}
+
+func (rr *resourceReporter) processGroup(d types.Dict) {
+	g, err := rr.ctx.DereferenceDict(d["Group"])
+	if err != nil || g == nil {
+		return
+	}
+	if s := g.NameEntry("S"); s != nil && *s == "Transparency" {
+		rr.transparency["transparency group"] = true
+	}
+}
+
+// processResources reports fonts, color spaces and graphics states of resDict.
+func (rr *resourceReporter) processResources(resDict types.Dict) {
+	if resDict == nil {
+		return
+	}
+
+	if d, err := rr.ctx.DereferenceDict(resDict["Font"]); err == nil {
+		for _, o := range d {
+			if indRef, ok := o.(types.IndirectRef); ok {
+				rr.addFont(&indRef)
+			}
+		}
+	}
+
+	if d, err := rr.ctx.DereferenceDict(resDict["ColorSpace"]); err == nil {
+		for _, o := range d {
+			rr.addColorSpace(o)
+		}
+	}
+
+	if d, err := rr.ctx.DereferenceDict(resDict["ExtGState"]); err == nil {
+		for _, o := range d {
+			if gs, err := rr.ctx.DereferenceDict(o); err == nil && gs != nil {
+				rr.processExtGState(gs)
+			}
+		}
+	}
+
+	if d, err := rr.ctx.DereferenceDict(resDict["Shading"]); err == nil {
+		for _, o := range d {
+			o, _ = rr.ctx.Dereference(o)
+			switch sh := o.(type) {
+			case types.Dict:
+				rr.addColorSpace(sh["ColorSpace"])
+			case types.StreamDict:
+				rr.addColorSpace(sh.Dict["ColorSpace"])
+			}
+		}
+	}
+}
+
+func filterString(ctx *model.Context, o types.Object) string {
+	o, _ = ctx.Dereference(o)
+	switch f := o.(type) {
+	case types.Name:
+		return f.Value()
+	case types.Array:
+		ss := []string{}
+		for _, o := range f {
+			if n, ok := o.(types.Name); ok {
+				ss = append(ss, n.Value())
+			}
+		}
+		return strings.Join(ss, ",")
+	}
+	return ""
+}
+
+func placementDPI(pixels int, p1, p2 float64) float64 {
+	size := math.Hypot(p1, p2)
+	if size == 0 {
+		return 0
+	}
+	return math.Round(float64(pixels)*72/size*10) / 10
+}
+
+func (rr *resourceReporter) addImage(objNr int, d types.Dict, ctm matrix.Matrix, abbreviated bool) {
+	key := func(full, abbr string) string {
+		if abbreviated {
+			if _, ok := d[full]; !ok {
+				return abbr
+			}
+		}
+		return full
+	}
+
+	img := model.PageImage{ObjNr: objNr}
+
+	if i, err := rr.ctx.DereferenceInteger(d[key("Width", "W")]); err == nil && i != nil {
+		img.Width = i.Value()
+	}
+	if i, err := rr.ctx.DereferenceInteger(d[key("Height", "H")]); err == nil && i != nil {
+		img.Height = i.Value()
+	}
+	if i, err := rr.ctx.DereferenceInteger(d[key("BitsPerComponent", "BPC")]); err == nil && i != nil {
+		img.Bpc = i.Value()
+	}
+
+	if b := d.BooleanEntry(key("ImageMask", "IM")); b != nil && *b {
+		img.ColorSpace = "ImageMask"
+	} else if cs := rr.colorSpaceDescription(d[key("ColorSpace", "CS")]); cs != "" {
+		img.ColorSpace = cs
+		rr.colorSpaces[cs] = true
+	}
+
+	img.Filter = filterString(rr.ctx, d[key("Filter", "F")])
+
+	if o, found := d.Find("SMask"); found && o != nil {
+		img.SMask = true
+		rr.transparency["soft mask image"] = true
+	}
+	if i := d.IntEntry("SMaskInData"); i != nil && *i > 0 {
+		img.SMask = true
+		rr.transparency["soft mask image"] = true
+	}
+
+	img.HorDPI = placementDPI(img.Width, ctm[0][0], ctm[0][1])
+	img.VerDPI = placementDPI(img.Height, ctm[1][0], ctm[1][1])
+
+	rr.r.Images = append(rr.r.Images, img)
+}
+
+func (rr *resourceReporter) inlineImage(bb []byte, ctm matrix.Matrix) {
+	i := bytes.Index(bb, []byte("BI"))
+	j := bytes.Index(bb, []byte("ID"))
+	if i < 0 || j < i {
+		return
+	}
+	s := "<<" + string(bb[i+2:j]) + ">>"
+	o, err := model.ParseObject(&s)
+	if err != nil {
+		return
+	}
+	if d, ok := o.(types.Dict); ok {
+		rr.addImage(0, d, ctm, true)
+	}
+}
+
+func (rr *resourceReporter) xObject(operand string, resDict types.Dict, ctm matrix.Matrix) {
+	if resDict == nil {
+		return
+	}
+	d, err := rr.ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || d == nil {
+		return
+	}
+	name, err := types.DecodeName(strings.TrimPrefix(operand, "/"))
+	if err != nil {
+		return
+	}
+	indRef := d.IndirectRefEntry(name)
+	if indRef == nil {
+		return
+	}
+	objNr := indRef.ObjectNumber.Value()
+	sd, _, err := rr.ctx.DereferenceStreamDict(*indRef)
+	if err != nil || sd == nil || sd.Subtype() == nil {
+		return
+	}
+
+	switch *sd.Subtype() {
+
+	case "Image":
+		rr.addImage(objNr, sd.Dict, ctm, false)
+
+	case "Form":
+		if rr.forms[objNr] {
+			return
+		}
+		if err := sd.Decode(); err != nil {
+			return
+		}
+		res, err := rr.ctx.DereferenceDict(sd.Dict["Resources"])
+		if err != nil {
+			return
+		}
+		if res == nil {
+			res = resDict
+		}
+		if m := numberArray(rr.ctx, sd.Dict["Matrix"]); len(m) == 6 {
+			ctm = matrixFromOperands(m).Multiply(ctm)
+		}
+		rr.processGroup(sd.Dict)
+		rr.forms[objNr] = true
+		rr.processContent(sd.Content, res, ctm)
+		delete(rr.forms, objNr)
+	}
+}
+
+func (rr *resourceReporter) processContent(bb []byte, resDict types.Dict, ctm matrix.Matrix) {
+	rr.processResources(resDict)
+
+	var stack []matrix.Matrix
+
+	for _, op := range parseContentOps(bb) {
+		switch op.name {
+		case "q":
+			stack = append(stack, ctm)
+		case "Q":
+			if n := len(stack); n > 0 {
+				ctm, stack = stack[n-1], stack[:n-1]
+			}
+		case "cm":
+			if len(op.operands) == 6 {
+				ctm = matrixFromOperands(operandNumbers(op.operands)).Multiply(ctm)
+			}
+		case "g", "G", "rg", "RG", "k", "K":
+			rr.colorSpaces[colorOperatorFamilies[strings.ToLower(op.name)].colorSpace().Value()] = true
+		case "cs", "CS":
+			if len(op.operands) == 1 {
+				if name, err := types.DecodeName(strings.TrimPrefix(op.operands[0], "/")); err == nil {
+					switch name {
+					case model.DeviceGrayCS, model.DeviceRGBCS, model.DeviceCMYKCS, "Pattern":
+						rr.colorSpaces[name] = true
+					}
+				}
+			}
+		case "Do":
+			if len(op.operands) == 1 {
+				rr.xObject(op.operands[0], resDict, ctm)
+			}
+		case "BI":
+			rr.inlineImage(bb[op.beg:op.end], ctm)
+		}
+	}
+}
+
+func (rr *resourceReporter) processAnnots(pageDict types.Dict) {
+	a, err := rr.ctx.DereferenceArray(pageDict["Annots"])
+	if err != nil {
+		return
+	}
+	for _, o := range a {
+		d, err := rr.ctx.DereferenceDict(o)
+		if err != nil || d == nil {
+			continue
+		}
+		st := "Unknown"
+		if s := d.Subtype(); s != nil {
+			st = *s
+		}
+		if rr.r.Annotations == nil {
+			rr.r.Annotations = map[string]int{}
+		}
+		rr.r.Annotations[st]++
+	}
+}
+
+func sortedStrings(m map[string]bool) []string {
+	ss := make([]string, 0, len(m))
+	for s := range m {
+		ss = append(ss, s)
+	}
+	sort.Strings(ss)
+	return ss
+}
+
+// PageResources returns a report of the fonts, images, color spaces, transparency features and annotations used by page pageNr.
+// Images are reported once per placement along with their effective resolution.
+func PageResources(ctx *model.Context, pageNr int) (*model.PageResourceReport, error) {
+	pageDict, bb, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := resourceReporter{
+		ctx:          ctx,
+		r:            &model.PageResourceReport{PageNr: pageNr, Fonts: []model.PageFont{}, Images: []model.PageImage{}},
+		fonts:        types.IntSet{},
+		colorSpaces:  map[string]bool{},
+		transparency: map[string]bool{},
+		forms:        types.IntSet{},
+	}
+
+	rr.processGroup(pageDict)
+	rr.processContent(bb, resDict, matrix.IdentMatrix)
+	rr.processAnnots(pageDict)
+
+	sort.Slice(rr.r.Fonts, func(i, j int) bool {
+		return rr.r.Fonts[i].Name < rr.r.Fonts[j].Name
+	})
+	rr.r.ImageCount = len(rr.r.Images)
+	rr.r.ColorSpaces = sortedStrings(rr.colorSpaces)
+	if len(rr.transparency) > 0 {
+		rr.r.Transparency = sortedStrings(rr.transparency)
+	}
+
+	return rr.r, nil
+}
+
+// ListPageResources returns a formatted resource report of pr.
+func ListPageResources(pr []model.PageResourceReport) []string {
+	var ss []string
+
+	for _, r := range pr {
+		ss = append(ss, fmt.Sprintf("Page %d:", r.PageNr))
+
+		ss = append(ss, fmt.Sprintf("%20s: %d", "Fonts", len(r.Fonts)))
+		for _, f := range r.Fonts {
+			ss = append(ss, fmt.Sprintf("%20s  %s (%s, %s, embedded:%t, subset:%t)", "", f.Name, f.Type, f.Encoding, f.Embedded, f.Subset))
+		}
+
+		ss = append(ss, fmt.Sprintf("%20s: %d", "Images", r.ImageCount))
+		for _, img := range r.Images {
+			ss = append(ss, fmt.Sprintf("%20s  %dx%d %s bpc:%d %s %.0fx%.0f dpi", "", img.Width, img.Height, img.ColorSpace, img.Bpc, img.Filter, img.HorDPI, img.VerDPI))
+		}
+
+		ss = append(ss, fmt.Sprintf("%20s: %s", "Color spaces", strings.Join(r.ColorSpaces, ", ")))
+
+		if len(r.Transparency) > 0 {
+			ss = append(ss, fmt.Sprintf("%20s: %s", "Transparency", strings.Join(r.Transparency, ", ")))
+		}
+
+		if len(r.Annotations) > 0 {
+			kk := make([]string, 0, len(r.Annotations))
+			for k := range r.Annotations {
+				kk = append(kk, k)
+			}
+			sort.Strings(kk)
+			ss1 := make([]string, len(kk))
+			for i, k := range kk {
+				ss1[i] = fmt.Sprintf("%s:%d", k, r.Annotations[k])
+			}
+			ss = append(ss, fmt.Sprintf("%20s: %s", "Annotations", strings.Join(ss1, ", ")))
+		}
+
+		ss = append(ss, draw.HorSepLine([]int{44}))
+	}
+
+	return ss
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v2"
+)
+
+// ParsePipeline returns the pipeline described by rd in JSON or YAML.
+func ParsePipeline(rd io.Reader) (*model.Pipeline, error) {
+	bb, err := io.ReadAll(rd)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &model.Pipeline{}
+
+	if bytes.HasPrefix(bytes.TrimSpace(bb), []byte("{")) {
+		if err := json.Unmarshal(bb, p); err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: invalid pipeline JSON")
+		}
+	} else if err := yaml.Unmarshal(bb, p); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid pipeline YAML")
+	}
+
+	if len(p.Steps) == 0 {
+		return nil, errors.New("pdfcpu: pipeline: missing steps")
+	}
+
+	return p, nil
+}
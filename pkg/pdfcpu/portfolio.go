@@ -0,0 +1,587 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var portfolioDateLayouts = []string{time.RFC3339, "2006-01-02 15:04", "2006-01-02"}
+
+// portfolioEntry represents a file to be embedded into a portfolio.
+type portfolioEntry struct {
+	path    string
+	folder  string
+	desc    string
+	values  map[string]string
+	modTime time.Time
+}
+
+func (e portfolioEntry) name() string {
+	return path.Join(e.folder, filepath.Base(e.path))
+}
+
+// portfolioFolder represents a node of the portfolio folder tree (12.3.5.4 Folders).
+type portfolioFolder struct {
+	id     int
+	name   string
+	parent *portfolioFolder
+	kids   []*portfolioFolder
+	indRef *types.IndirectRef
+}
+
+// ParsePortfolio parses a JSON portfolio description.
+func ParsePortfolio(rd io.Reader) (*model.Portfolio, error) {
+	p := &model.Portfolio{}
+	if err := json.NewDecoder(rd).Decode(p); err != nil {
+		return nil, errors.Wrap(err, "pdfcpu: invalid portfolio JSON")
+	}
+	return p, nil
+}
+
+func cleanPortfolioFolder(s string) (string, error) {
+	s = strings.Trim(strings.ReplaceAll(s, "\\", "/"), "/")
+	if s == "" {
+		return "", nil
+	}
+	for _, f := range strings.Split(s, "/") {
+		if f == "" || f == "." || f == ".." {
+			return "", errors.Errorf("pdfcpu: portfolio: invalid folder: %s", s)
+		}
+	}
+	return s, nil
+}
+
+func portfolioDate(s string) (string, error) {
+	if strings.HasPrefix(s, "D:") {
+		if _, ok := types.DateTime(s, true); ok {
+			return s, nil
+		}
+	}
+	for _, layout := range portfolioDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, time.Local); err == nil {
+			return types.DateString(t), nil
+		}
+	}
+	return "", errors.Errorf("pdfcpu: portfolio: invalid date: %s", s)
+}
+
+// validatePortfolio checks p and applies defaults.
+func validatePortfolio(p *model.Portfolio) error {
+	if len(p.Files) == 0 {
+		return errors.New("pdfcpu: portfolio: missing files")
+	}
+
+	if p.View == "" {
+		p.View = "details"
+	}
+	if _, ok := model.PortfolioViews[p.View]; !ok {
+		return errors.Errorf("pdfcpu: portfolio: invalid view: %s, please use one of: details, tile, hidden", p.View)
+	}
+
+	if len(p.Fields) == 0 {
+		p.Fields = model.DefaultPortfolioFields()
+	}
+
+	fields := map[string]model.PortfolioField{}
+	for _, f := range p.Fields {
+		if f.Key == "" || strings.ContainsAny(f.Key, " /()<>[]{}%#") {
+			return errors.Errorf("pdfcpu: portfolio: invalid field key: %q", f.Key)
+		}
+		if _, ok := fields[f.Key]; ok {
+			return errors.Errorf("pdfcpu: portfolio: duplicate field: %s", f.Key)
+		}
+		if _, ok := model.PortfolioFieldTypes[f.Type]; !ok {
+			return errors.Errorf("pdfcpu: portfolio: field %s: invalid type: %s", f.Key, f.Type)
+		}
+		fields[f.Key] = f
+	}
+
+	if p.Sort != nil {
+		if _, ok := fields[p.Sort.Field]; !ok {
+			return errors.Errorf("pdfcpu: portfolio: unknown sort field: %s", p.Sort.Field)
+		}
+	}
+
+	for _, pf := range p.Files {
+		for k, v := range pf.Values {
+			f, ok := fields[k]
+			if !ok || !f.Custom() {
+				return errors.Errorf("pdfcpu: portfolio: %s: unknown or non custom field: %s", pf.Path, k)
+			}
+			if f.Type == "number" {
+				if _, err := strconv.ParseFloat(v, 64); err != nil {
+					return errors.Errorf("pdfcpu: portfolio: %s: field %s: invalid number: %s", pf.Path, k, v)
+				}
+			}
+			if f.Type == "date" {
+				if _, err := portfolioDate(v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// portfolioEntries returns the files of p expanding directories into their file trees.
+func portfolioEntries(p *model.Portfolio) ([]portfolioEntry, error) {
+	ee := []portfolioEntry{}
+
+	for _, pf := range p.Files {
+		folder, err := cleanPortfolioFolder(pf.Folder)
+		if err != nil {
+			return nil, err
+		}
+
+		fi, err := os.Stat(pf.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !fi.IsDir() {
+			ee = append(ee, portfolioEntry{path: pf.Path, folder: folder, desc: pf.Desc, values: pf.Values, modTime: fi.ModTime()})
+			continue
+		}
+
+		root := filepath.Clean(pf.Path)
+		base := path.Join(folder, filepath.Base(root))
+
+		err = filepath.WalkDir(root, func(fp string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			fi, err := d.Info()
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(root, filepath.Dir(fp))
+			if err != nil {
+				return err
+			}
+			f := base
+			if rel != "." {
+				f = path.Join(base, filepath.ToSlash(rel))
+			}
+			ee = append(ee, portfolioEntry{path: fp, folder: f, values: pf.Values, modTime: fi.ModTime()})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	names := map[string]bool{}
+	for _, e := range ee {
+		if names[e.name()] {
+			return nil, errors.Errorf("pdfcpu: portfolio: duplicate file: %s", e.name())
+		}
+		names[e.name()] = true
+	}
+
+	return ee, nil
+}
+
+// portfolioFolders returns the folder tree for ee along with the folders by path.
+func portfolioFolders(ee []portfolioEntry) (*portfolioFolder, map[string]*portfolioFolder) {
+	root := &portfolioFolder{}
+	m := map[string]*portfolioFolder{"": root}
+	id := 0
+
+	var ensure func(s string) *portfolioFolder
+	ensure = func(s string) *portfolioFolder {
+		if f, ok := m[s]; ok {
+			return f
+		}
+		parent := root
+		if dir := path.Dir(s); dir != "." {
+			parent = ensure(dir)
+		}
+		id++
+		f := &portfolioFolder{id: id, name: path.Base(s), parent: parent}
+		parent.kids = append(parent.kids, f)
+		m[s] = f
+		return f
+	}
+
+	for _, e := range ee {
+		ensure(e.folder)
+	}
+
+	return root, m
+}
+
+func newPortfolioFolderDicts(xRefTable *model.XRefTable, f *portfolioFolder, maxID int) error {
+	d := types.NewDict()
+	d.InsertName("Type", "Folder")
+	d.InsertInt("ID", f.id)
+	s, err := types.EscapedUTF16String(f.name)
+	if err != nil {
+		return err
+	}
+	d.InsertString("Name", *s)
+	d.Insert("ModDate", types.StringLiteral(types.DateString(time.Now())))
+
+	if f.parent == nil {
+		// The root folder keeps track of unused folder IDs.
+		d.Insert("Free", types.Array{types.Integer(maxID + 1), types.Integer(math.MaxInt32)})
+	}
+
+	if f.indRef, err = xRefTable.IndRefForNewObject(d); err != nil {
+		return err
+	}
+
+	for _, kid := range f.kids {
+		if err := newPortfolioFolderDicts(xRefTable, kid, maxID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// linkPortfolioFolders sets the Parent, Child and Next entries of the folder dicts of the tree f.
+func linkPortfolioFolders(xRefTable *model.XRefTable, f *portfolioFolder) error {
+	d, err := xRefTable.DereferenceDict(*f.indRef)
+	if err != nil {
+		return err
+	}
+	if f.parent != nil {
+		d.Insert("Parent", *f.parent.indRef)
+	}
+	if len(f.kids) > 0 {
+		d.Insert("Child", *f.kids[0].indRef)
+	}
+	for i, kid := range f.kids {
+		if i+1 < len(f.kids) {
+			d1, err := xRefTable.DereferenceDict(*kid.indRef)
+			if err != nil {
+				return err
+			}
+			d1.Insert("Next", *f.kids[i+1].indRef)
+		}
+		if err := linkPortfolioFolders(xRefTable, kid); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func portfolioSchemaDict(p *model.Portfolio) types.Dict {
+	d := types.NewDict()
+	d.InsertName("Type", "CollectionSchema")
+
+	for _, f := range p.Fields {
+		cf := types.NewDict()
+		cf.InsertName("Type", "CollectionField")
+		cf.InsertName("Subtype", model.PortfolioFieldTypes[f.Type])
+		s, err := types.EscapedUTF16String(f.Name)
+		if err != nil {
+			s = &f.Name
+		}
+		cf.InsertString("N", *s)
+		if f.Order > 0 {
+			cf.InsertInt("O", f.Order)
+		}
+		if f.Hidden {
+			cf.Insert("V", types.Boolean(false))
+		}
+		if f.Editable {
+			cf.Insert("E", types.Boolean(true))
+		}
+		d.Insert(f.Key, cf)
+	}
+
+	return d
+}
+
+func portfolioItemDict(p *model.Portfolio, e portfolioEntry) (types.Dict, error) {
+	d := types.NewDict()
+	d.InsertName("Type", "CollectionItem")
+
+	for _, f := range p.Fields {
+		v, ok := e.values[f.Key]
+		if !ok {
+			continue
+		}
+		switch f.Type {
+		case "text":
+			s, err := types.EscapedUTF16String(v)
+			if err != nil {
+				return nil, err
+			}
+			d.InsertString(f.Key, *s)
+		case "date":
+			s, err := portfolioDate(v)
+			if err != nil {
+				return nil, err
+			}
+			d.Insert(f.Key, types.StringLiteral(s))
+		case "number":
+			fl, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				return nil, err
+			}
+			if fl == math.Trunc(fl) {
+				d.InsertInt(f.Key, int(fl))
+				continue
+			}
+			d.InsertFloat(f.Key, float32(fl))
+		}
+	}
+
+	return d, nil
+}
+
+func addPortfolioEntry(ctx *model.Context, p *model.Portfolio, e portfolioEntry, key string) error {
+	if log.CLIEnabled() {
+		log.CLI.Printf("adding %s\n", e.name())
+	}
+
+	xRefTable := ctx.XRefTable
+
+	f, err := os.Open(e.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	sdIndRef, err := xRefTable.NewEmbeddedStreamDict(f, e.modTime)
+	if err != nil {
+		return err
+	}
+
+	fileName := filepath.Base(e.path)
+	d, err := xRefTable.NewFileSpecDict(fileName, fileName, e.desc, *sdIndRef)
+	if err != nil {
+		return err
+	}
+
+	ciDict, err := portfolioItemDict(p, e)
+	if err != nil {
+		return err
+	}
+	d.Update("CI", ciDict)
+
+	ir, err := xRefTable.IndRefForNewObject(d)
+	if err != nil {
+		return err
+	}
+
+	m := model.NameMap{key: []types.Dict{d}}
+
+	return xRefTable.Names["EmbeddedFiles"].Add(xRefTable, key, *ir, m, []string{"F", "UF"})
+}
+
+// CreatePortfolio turns ctx into the cover sheet of a portfolio made up of the files of p.
+// Any existing embedded files and collection settings of ctx are replaced.
+func CreatePortfolio(ctx *model.Context, p *model.Portfolio) error {
+	if err := validatePortfolio(p); err != nil {
+		return err
+	}
+
+	ee, err := portfolioEntries(p)
+	if err != nil {
+		return err
+	}
+
+	xRefTable := ctx.XRefTable
+
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", false); err != nil {
+		return err
+	}
+	if xRefTable.Names["EmbeddedFiles"] != nil {
+		if err := xRefTable.RemoveEmbeddedFilesNameTree(); err != nil {
+			return err
+		}
+	} else if err := xRefTable.RemoveCollection(); err != nil {
+		return err
+	}
+
+	if err := xRefTable.LocateNameTree("EmbeddedFiles", true); err != nil {
+		return err
+	}
+
+	root, folders := portfolioFolders(ee)
+
+	// Files within folders use the folder ID as key prefix.
+	keys := map[string]string{}
+	for _, e := range ee {
+		key := filepath.Base(e.path)
+		if f := folders[e.folder]; f != root {
+			key = fmt.Sprintf("<%d>%s", f.id, key)
+		}
+		keys[e.name()] = key
+		if err := addPortfolioEntry(ctx, p, e, key); err != nil {
+			return err
+		}
+	}
+
+	rootDict, err := xRefTable.Catalog()
+	if err != nil {
+		return err
+	}
+
+	d := types.NewDict()
+	d.InsertName("Type", "Collection")
+	d.InsertName("View", model.PortfolioViews[p.View])
+
+	ir, err := xRefTable.IndRefForNewObject(portfolioSchemaDict(p))
+	if err != nil {
+		return err
+	}
+	d.Insert("Schema", *ir)
+
+	if p.Sort != nil {
+		sortDict := types.NewDict()
+		sortDict.InsertName("S", p.Sort.Field)
+		sortDict.Insert("A", types.Boolean(p.Sort.Ascending))
+		d.Insert("Sort", sortDict)
+	}
+
+	if p.Initial != "" {
+		key, ok := keys[strings.Trim(strings.ReplaceAll(p.Initial, "\\", "/"), "/")]
+		if !ok {
+			return errors.Errorf("pdfcpu: portfolio: initial file not found: %s", p.Initial)
+		}
+		s, err := types.EscapedUTF16String(key)
+		if err != nil {
+			return err
+		}
+		d.InsertString("D", *s)
+	}
+
+	if len(folders) > 1 {
+		if err := newPortfolioFolderDicts(xRefTable, root, len(folders)-1); err != nil {
+			return err
+		}
+		if err := linkPortfolioFolders(xRefTable, root); err != nil {
+			return err
+		}
+		d.Insert("Folders", *root.indRef)
+	}
+
+	if ir, err = xRefTable.IndRefForNewObject(d); err != nil {
+		return err
+	}
+	rootDict.Update("Collection", *ir)
+
+	return nil
+}
+
+// CreatePortfolioCoverSheet returns a single page document serving as cover sheet for the portfolio p.
+// The cover sheet is displayed by viewers without portfolio support and lists the embedded files.
+func CreatePortfolioCoverSheet(p *model.Portfolio, conf *model.Configuration) (*model.Context, error) {
+	if err := validatePortfolio(p); err != nil {
+		return nil, err
+	}
+
+	ee, err := portfolioEntries(p)
+	if err != nil {
+		return nil, err
+	}
+
+	dim := types.PaperSize["A4"]
+	mediaBox := types.RectForDim(dim.Width, dim.Height)
+
+	ctx, err := CreateContextWithXRefTable(conf, dim)
+	if err != nil {
+		return nil, err
+	}
+
+	pagesIndRef, err := ctx.Pages()
+	if err != nil {
+		return nil, err
+	}
+
+	pagesDict, err := ctx.DereferenceDict(*pagesIndRef)
+	if err != nil {
+		return nil, err
+	}
+
+	fm := model.FontMap{}
+	fontName := "Helvetica"
+	fontKey := fm.EnsureKey(fontName)
+
+	margin, fontSize := 72., 12
+	lh := float64(fontSize) * 1.5
+
+	var buf bytes.Buffer
+
+	write := func(s string, fontSize int, y float64) {
+		td := model.TextDescriptor{
+			Text:     s,
+			FontName: fontName,
+			FontKey:  fontKey,
+			FontSize: fontSize,
+			Scale:    1.,
+			ScaleAbs: true,
+			X:        margin,
+			Y:        y,
+		}
+		model.WriteMultiLine(ctx.XRefTable, &buf, mediaBox, nil, td)
+	}
+
+	y := dim.Height - margin - 36
+	write("PDF Portfolio", 24, y)
+
+	y -= 2 * lh
+	write(fmt.Sprintf("This document is a portfolio containing %d embedded file(s).", len(ee)), fontSize, y)
+	y -= lh
+	write("Please use a PDF viewer supporting portfolios to browse its contents.", fontSize, y)
+	y -= lh
+
+	names := make([]string, len(ee))
+	for i, e := range ee {
+		names[i] = e.name()
+	}
+	sort.Strings(names)
+
+	w := dim.Width - 2*margin
+	for i, s := range names {
+		y -= lh
+		if y < margin+lh && i+1 < len(names) {
+			write(fmt.Sprintf("... and %d more", len(names)-i), fontSize, y)
+			break
+		}
+		write(truncateTextWidth(s, fontName, fontSize, w), fontSize, y)
+	}
+
+	if err := addSheetPage(ctx, dim, types.NewDict(), fm, buf, pagesDict, pagesIndRef); err != nil {
+		return nil, err
+	}
+
+	return ctx, nil
+}
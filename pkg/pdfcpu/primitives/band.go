@@ -202,6 +202,10 @@ func (hb *HorizontalBand) render(p *model.Page, pageNr int, fonts model.FontMap,
 	h := hb.Height
 	r := types.RectForWidthAndHeight(llx, lly, w, h)
 
+	// Page headers and footers are pagination artifacts.
+	p.BeginArtifact()
+	defer p.EndTag()
+
 	if hb.Left != "" {
 		if err := hb.renderComponent(hb.Left, left, r, p, pageNr, fonts, images); err != nil {
 			return err
@@ -1038,7 +1038,10 @@ func (c *Content) renderBars(p *model.Page) error {
 		if b.Hide {
 			continue
 		}
-		if err := b.render(p); err != nil {
+		p.BeginArtifact()
+		err := b.render(p)
+		p.EndTag()
+		if err != nil {
 			return err
 		}
 	}
@@ -1059,7 +1062,10 @@ func (c *Content) renderSimpleBoxes(p *model.Page) error {
 			}
 			sb.mergeIn(sb0)
 		}
-		if err := sb.render(p); err != nil {
+		p.BeginArtifact()
+		err := sb.render(p)
+		p.EndTag()
+		if err != nil {
 			return err
 		}
 	}
@@ -1080,7 +1086,14 @@ func (c *Content) renderTextBoxes(p *model.Page, pageNr int, fonts model.FontMap
 			}
 			tb.mergeIn(tb0)
 		}
-		if err := tb.render(p, pageNr, fonts); err != nil {
+		tag := model.StructTag{Type: "P"}
+		if tb.Tag != "" {
+			tag = model.StructTag{Type: tb.Tag, Role: tb.Role}
+		}
+		p.BeginTag(tag)
+		err := tb.render(p, pageNr, fonts)
+		p.EndTag()
+		if err != nil {
 			return err
 		}
 	}
@@ -1101,7 +1114,10 @@ func (c *Content) renderImageBoxes(p *model.Page, pageNr int, images model.Image
 			}
 			ib.mergeIn(ib0)
 		}
-		if err := ib.render(p, pageNr, images); err != nil {
+		p.BeginTag(model.StructTag{Type: "Figure", Alt: ib.Alt})
+		err := ib.render(p, pageNr, images)
+		p.EndTag()
+		if err != nil {
 			return err
 		}
 	}
@@ -1122,7 +1138,10 @@ func (c *Content) renderTables(p *model.Page, pageNr int, fonts model.FontMap) e
 			}
 			t.mergeIn(t0)
 		}
-		if err := t.render(p, pageNr, fonts); err != nil {
+		p.BeginTag(model.StructTag{Type: "Table"})
+		err := t.render(p, pageNr, fonts)
+		p.EndTag()
+		if err != nil {
 			return err
 		}
 	}
@@ -1295,6 +1314,8 @@ func (c *Content) render(p *model.Page, pageNr int, fonts model.FontMap, images
 		return c.Regions.render(p, pageNr, fonts, images)
 	}
 
+	p.BeginArtifact()
+
 	// Render background
 	if c.bgCol != nil {
 		draw.FillRectNoBorder(p.Buf, c.BorderRect(), *c.bgCol)
@@ -1306,6 +1327,8 @@ func (c *Content) render(p *model.Page, pageNr int, fonts model.FontMap, images
 		draw.DrawRect(p.Buf, c.BorderRect(), float64(b.Width), b.col, &b.style)
 	}
 
+	p.EndTag()
+
 	if err := c.renderPrimitives(p, pageNr, fonts, images); err != nil {
 		return err
 	}
@@ -1314,7 +1337,9 @@ func (c *Content) render(p *model.Page, pageNr int, fonts model.FontMap, images
 		return err
 	}
 
+	p.BeginArtifact()
 	c.renderBoxesAndGuides(p)
+	p.EndTag()
 
 	return nil
 }
@@ -66,6 +66,7 @@ type ImageBox struct {
 	bgCol           *color.SimpleColor
 	Rotation        float64 `json:"rot"`
 	Url             string
+	Alt             string // alternate description for tagged output
 	Hide            bool
 	PageNr          string `json:"-"`
 }
@@ -214,6 +215,10 @@ func (ib *ImageBox) mergeIn(ib0 *ImageBox) {
 		ib.Rotation = ib0.Rotation
 	}
 
+	if ib.Alt == "" {
+		ib.Alt = ib0.Alt
+	}
+
 	if !ib.Hide {
 		ib.Hide = ib0.Hide
 	}
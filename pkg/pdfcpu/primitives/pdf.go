@@ -620,6 +620,23 @@ func (pdf *PDF) idForFontName(fontName, fontLang string, pageFonts, globalFonts
 	return id, nil
 }
 
+// fallbackFonts returns the configured fallback fonts needed for rendering text using fontName
+// and registers them as page fonts.
+func (pdf *PDF) fallbackFonts(text, fontName string, pageFonts, globalFonts model.FontMap, pageNr int) ([]model.FallbackFont, error) {
+	if pdf.Conf == nil {
+		return nil, nil
+	}
+	ff := []model.FallbackFont{}
+	for _, name := range font.UsedFallbacks(text, fontName, pdf.Conf.FallbackFonts) {
+		id, err := pdf.idForFontName(name, "", pageFonts, globalFonts, pageNr)
+		if err != nil {
+			return nil, err
+		}
+		ff = append(ff, model.FallbackFont{Name: name, Key: id})
+	}
+	return ff, nil
+}
+
 func fontIndRef(xRefTable *model.XRefTable, fontName, fontLang string) (*types.IndirectRef, error) {
 	fName := fontName
 	if strings.HasPrefix(fontName, "cjk:") {
@@ -1048,7 +1065,10 @@ func (pdf *PDF) newModelPageforPDFPage(page *PDFPage) model.Page {
 		cropBox = page.cropBox
 	}
 
-	return model.NewPage(mediaBox, cropBox)
+	p := model.NewPage(mediaBox, cropBox)
+	p.Tagged = pdf.Conf != nil && pdf.Conf.TagContent
+
+	return p
 }
 
 // RenderPages renders page content into model.Pages
@@ -1074,7 +1094,9 @@ func (pdf *PDF) RenderPages() ([]*model.Page, model.FontMap, error) {
 
 			// Create blank page with optional background color.
 			if pdf.bgCol != nil {
+				p.BeginArtifact()
 				draw.FillRectNoBorder(p.Buf, p.CropBox, *pdf.bgCol)
+				p.EndTag()
 			}
 
 			// Render page header.
@@ -1096,7 +1118,20 @@ func (pdf *PDF) RenderPages() ([]*model.Page, model.FontMap, error) {
 			continue
 		}
 
+		if p.Tagged && pageNr <= pdf.XRefTable.PageCount {
+			// Continue the marked content numbering of the existing page.
+			pageDict, _, _, err := pdf.XRefTable.PageDict(pageNr, false)
+			if err != nil {
+				return nil, nil, err
+			}
+			if p.MCIDBase, err = pdf.XRefTable.NextMCID(pageDict); err != nil {
+				return nil, nil, err
+			}
+		}
+
+		p.BeginArtifact()
 		pdf.renderPageBackground(page, p.Buf)
+		p.EndTag()
 
 		var headerHeight, headerDy float64
 		var footerHeight, footerDy float64
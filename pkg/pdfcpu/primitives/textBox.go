@@ -19,6 +19,7 @@ package primitives
 import (
 	"strings"
 
+	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/format"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
@@ -50,7 +51,10 @@ type TextBox struct {
 	Alignment       string `json:"align"` // "Left", "Center", "Right"
 	horAlign        types.HAlignment
 	RTL             bool
+	Vertical        bool    // top to bottom writing for user fonts, lines progress from right to left
 	Rotation        float64 `json:"rot"`
+	Tag             string  // structure type for tagged output, defaults to P
+	Role            string  // standard structure type a custom Tag is mapped to
 	Hide            bool
 }
 
@@ -237,6 +241,14 @@ func (tb *TextBox) mergeIn(tb0 *TextBox) {
 		tb.Rotation = tb0.Rotation
 	}
 
+	if !tb.Vertical {
+		tb.Vertical = tb0.Vertical
+	}
+
+	if tb.Tag == "" {
+		tb.Tag, tb.Role = tb0.Tag, tb0.Role
+	}
+
 	if !tb.Hide {
 		tb.Hide = tb0.Hide
 	}
@@ -268,6 +280,9 @@ func (tb *TextBox) calcFont() error {
 	if f.col == nil {
 		f.col = &color.Black
 	}
+	if tb.Vertical && !font.IsUserFont(f.Name) {
+		return errors.Errorf("pdfcpu: vertical writing needs a user font: %s", f.Name)
+	}
 	return nil
 }
 
@@ -297,7 +312,13 @@ func (tb *TextBox) prepareTextDescriptor(p *model.Page, pageNr int, fonts model.
 
 	t, _ := format.Text(tb.Value, pdf.TimestampFormat, pageNr, pdf.pageCount())
 
-	id, err := tb.pdf.idForFontName(fontName, fontLang, p.Fm, fonts, pageNr)
+	fontKey := fontName
+	if tb.Vertical {
+		// Vertical writing needs its own Type0 font using Identity-V.
+		fontKey = font.VerticalFontName(fontName)
+	}
+
+	id, err := tb.pdf.idForFontName(fontKey, fontLang, p.Fm, fonts, pageNr)
 	if err != nil {
 		return nil, err
 	}
@@ -317,7 +338,14 @@ func (tb *TextBox) prepareTextDescriptor(p *model.Page, pageNr int, fonts model.
 		Scale:    1.,
 		ScaleAbs: true,
 		Rotation: tb.Rotation,
-		RTL:      tb.RTL, // for user fonts only!
+		RTL:      tb.RTL,      // for user fonts only!
+		Vertical: tb.Vertical, // for user fonts only!
+	}
+
+	if !tb.Vertical {
+		if td.FallbackFonts, err = pdf.fallbackFonts(t, fontName, p.Fm, fonts, pageNr); err != nil {
+			return nil, err
+		}
 	}
 
 	if col != nil {
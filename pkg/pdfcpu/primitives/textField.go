@@ -50,6 +50,7 @@ type TextField struct {
 	Multiline       bool
 	Font            *FormFont
 	fontID          string
+	fallbackFonts   []model.FallbackFont
 	Margin          *Margin // applied to content box
 	Border          *Border
 	BackgroundColor string             `json:"bgCol"`
@@ -372,10 +373,20 @@ func (tf *TextField) renderBackground(w io.Writer, bgCol, boCol *color.SimpleCol
 func (tf *TextField) renderLines(xRefTable *model.XRefTable, boWidth, lh, w, y float64, lines []string, buf io.Writer) {
 	f := tf.Font
 	cjk := pdffont.CJK(f.Script, f.Lang)
+	comb := tf.Comb && tf.MaxLen > 0 && tf.HorAlign == types.AlignLeft
+	td := model.TextDescriptor{FontName: f.Name, FontKey: tf.fontID, FallbackFonts: tf.fallbackFonts, Embed: true, RTL: f.RTL()}
 	for i := 0; i < len(lines); i++ {
 		s := lines[i]
+		var tj string
 		lineBB := model.CalcBoundingBox(s, 0, 0, f.Name, f.Size)
-		s = model.PrepBytes(xRefTable, s, f.Name, !cjk, f.RTL(), f.FillFont)
+		if len(td.FallbackFonts) > 0 && !comb {
+			// Switch to fallback fonts for chars missing in f.
+			lineBB.UR.X = lineBB.LL.X + td.TextWidth(s, f.Size)
+			tj = td.ShowText(xRefTable, s, f.Size)
+		} else {
+			s = model.PrepBytes(xRefTable, s, f.Name, !cjk, f.RTL(), f.FillFont)
+			tj = fmt.Sprintf("(%s) Tj", s)
+		}
 		x := 2 * boWidth
 		if x == 0 {
 			x = 2
@@ -394,7 +405,7 @@ func (tf *TextField) renderLines(xRefTable *model.XRefTable, boWidth, lh, w, y f
 				f.col.R, f.col.G, f.col.B)
 		}
 
-		if tf.Comb && tf.MaxLen > 0 && tf.HorAlign == types.AlignLeft {
+		if comb {
 			x = 0.5
 			dx := w / float64(tf.MaxLen)
 			y0 := y
@@ -405,7 +416,7 @@ func (tf *TextField) renderLines(xRefTable *model.XRefTable, boWidth, lh, w, y f
 			}
 			fmt.Fprint(buf, "ET ")
 		} else {
-			fmt.Fprintf(buf, "%.2f %.2f Td (%s) Tj ET ", x, y, s)
+			fmt.Fprintf(buf, "%.2f %.2f Td %s ET ", x, y, tj)
 		}
 
 		y -= lh
@@ -491,7 +502,25 @@ func (tf *TextField) RefreshN(xRefTable *model.XRefTable, indRef *types.Indirect
 	return nil
 }
 
+// setupFallbackFonts selects the configured fallback fonts needed for rendering the appearance of tf.
+func (tf *TextField) setupFallbackFonts() {
+	f := tf.Font
+	tf.fallbackFonts = nil
+	if tf.pdf.Conf == nil || f.FillFont || pdffont.CJK(f.Script, f.Lang) {
+		return
+	}
+	s := tf.Value
+	if s == "" {
+		s = tf.Default
+	}
+	for _, fontName := range font.UsedFallbacks(s, f.Name, tf.pdf.Conf.FallbackFonts) {
+		tf.fallbackFonts = append(tf.fallbackFonts, model.FallbackFont{Name: fontName, Key: fontName})
+	}
+}
+
 func (tf *TextField) irN(fonts model.FontMap) (*types.IndirectRef, error) {
+	tf.setupFallbackFonts()
+
 	bb, err := tf.renderN(tf.pdf.XRefTable)
 	if err != nil {
 		return nil, err
@@ -520,16 +549,22 @@ func (tf *TextField) irN(fonts model.FontMap) (*types.IndirectRef, error) {
 		return nil, err
 	}
 
-	d := types.Dict(
+	fontDict := types.Dict(
 		map[string]types.Object{
-			"Font": types.Dict(
-				map[string]types.Object{
-					tf.fontID: *ir,
-				},
-			),
+			tf.fontID: *ir,
 		},
 	)
 
+	for _, ff := range tf.fallbackFonts {
+		ir, err := tf.pdf.ensureFont(ff.Key, ff.Name, "", fonts)
+		if err != nil {
+			return nil, err
+		}
+		fontDict.Insert(ff.Key, *ir)
+	}
+
+	d := types.Dict(map[string]types.Object{"Font": fontDict})
+
 	sd.Insert("Resources", d)
 
 	if err := sd.Encode(); err != nil {
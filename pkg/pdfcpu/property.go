@@ -42,6 +42,7 @@ func PropertiesList(ctx *model.Context) ([]string, error) {
 }
 
 // PropertiesAdd adds properties into the document info dict.
+// Title, Author, Subject and Creator are also set in the XMP metadata.
 // Returns true if at least one property was added.
 func PropertiesAdd(ctx *model.Context, properties map[string]string) error {
 	if err := ensureInfoDictAndFileID(ctx); err != nil {
@@ -50,6 +51,8 @@ func PropertiesAdd(ctx *model.Context, properties map[string]string) error {
 
 	d, _ := ctx.DereferenceDict(*ctx.Info)
 
+	m := map[string]string{}
+
 	for k, v := range properties {
 		s, err := types.EscapedUTF16String(v)
 		if err != nil {
@@ -57,12 +60,15 @@ func PropertiesAdd(ctx *model.Context, properties map[string]string) error {
 		}
 		d[k] = types.StringLiteral(*s)
 		ctx.Properties[k] = *s
+		if isInfoXMPKey(k) {
+			m[k] = v
+		}
 	}
 
-	return nil
+	return syncInfoXMP(ctx, m)
 }
 
-// PropertiesRemove deletes specified properties.
+// PropertiesRemove deletes specified properties including their XMP counterparts.
 // Returns true if at least one property was removed.
 func PropertiesRemove(ctx *model.Context, properties []string) (bool, error) {
 	if ctx.Info == nil {
@@ -74,13 +80,18 @@ func PropertiesRemove(ctx *model.Context, properties []string) (bool, error) {
 		return false, err
 	}
 
+	m := map[string]string{}
+
 	if len(properties) == 0 {
 		// Remove all properties.
 		for k := range ctx.Properties {
 			delete(d, types.EncodeName(k))
+			if isInfoXMPKey(k) {
+				m[k] = ""
+			}
 		}
 		ctx.Properties = map[string]string{}
-		return true, nil
+		return true, syncInfoXMP(ctx, m)
 	}
 
 	var removed bool
@@ -90,8 +101,11 @@ func PropertiesRemove(ctx *model.Context, properties []string) (bool, error) {
 			delete(d, k)
 			delete(ctx.Properties, k)
 			removed = true
+			if isInfoXMPKey(k) {
+				m[k] = ""
+			}
 		}
 	}
 
-	return removed, nil
+	return removed, syncInfoXMP(ctx, m)
 }
@@ -0,0 +1,80 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qrcode
+
+// bitBuffer is a sequence of bits.
+type bitBuffer []bool
+
+// append appends the n low order bits of v, most significant bit first.
+func (bb *bitBuffer) append(v, n int) {
+	for i := n - 1; i >= 0; i-- {
+		*bb = append(*bb, (v>>i)&1 != 0)
+	}
+}
+
+// bytes packs bb into bytes, the length of bb is expected to be a multiple of 8.
+func (bb bitBuffer) bytes() []byte {
+	b := make([]byte, len(bb)/8)
+	for i, bit := range bb {
+		if bit {
+			b[i>>3] |= 1 << (7 - i&7)
+		}
+	}
+	return b
+}
+
+// gfMultiply returns the product of x and y in GF(2^8/0x11D).
+func gfMultiply(x, y byte) byte {
+	z := 0
+	for i := 7; i >= 0; i-- {
+		z = (z << 1) ^ ((z >> 7) * 0x11D)
+		z ^= int((y>>i)&1) * int(x)
+	}
+	return byte(z)
+}
+
+// rsDivisor returns the Reed-Solomon generator polynomial of degree n
+// without the leading term, coefficients are stored highest to lowest power.
+func rsDivisor(n int) []byte {
+	d := make([]byte, n)
+	d[n-1] = 1
+	root := byte(1)
+	for i := 0; i < n; i++ {
+		for j := range d {
+			d[j] = gfMultiply(d[j], root)
+			if j+1 < len(d) {
+				d[j] ^= d[j+1]
+			}
+		}
+		root = gfMultiply(root, 0x02)
+	}
+	return d
+}
+
+// rsRemainder returns the Reed-Solomon error correction codewords for data.
+func rsRemainder(data, divisor []byte) []byte {
+	r := make([]byte, len(divisor))
+	for _, b := range data {
+		factor := b ^ r[0]
+		copy(r, r[1:])
+		r[len(r)-1] = 0
+		for i := range r {
+			r[i] ^= gfMultiply(divisor[i], factor)
+		}
+	}
+	return r
+}
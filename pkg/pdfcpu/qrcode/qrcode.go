@@ -0,0 +1,504 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package qrcode implements a QR code (ISO/IEC 18004) encoder for byte mode payloads.
+package qrcode
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Level is the error correction level of a QR code.
+type Level int
+
+// The supported error correction levels.
+const (
+	Low      Level = iota // recovers 7% of data
+	Medium                // recovers 15% of data
+	Quartile              // recovers 25% of data
+	High                  // recovers 30% of data
+)
+
+// QuietZone is the width of the mandatory light border around a symbol in modules.
+const QuietZone = 4
+
+// ParseLevel parses an error correction level: L, M, Q or H.
+func ParseLevel(s string) (Level, error) {
+	switch strings.ToUpper(s) {
+	case "L", "LOW":
+		return Low, nil
+	case "M", "MEDIUM":
+		return Medium, nil
+	case "Q", "QUARTILE":
+		return Quartile, nil
+	case "H", "HIGH":
+		return High, nil
+	}
+	return Low, errors.Errorf("pdfcpu: invalid qrcode error correction level: %s, please provide one of: L, M, Q, H", s)
+}
+
+func (l Level) String() string {
+	return [...]string{"L", "M", "Q", "H"}[l]
+}
+
+// formatBits returns the 2 bit indicator of l used in the format information.
+func (l Level) formatBits() int {
+	return [...]int{1, 0, 3, 2}[l]
+}
+
+// Error correction codewords per block indexed by level and version.
+var eccCodewordsPerBlock = [4][41]int{
+	{-1, 7, 10, 15, 20, 26, 18, 20, 24, 30, 18, 20, 24, 26, 30, 22, 24, 28, 30, 28, 28, 28, 28, 30, 30, 26, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 10, 16, 26, 18, 24, 16, 18, 22, 22, 26, 30, 22, 22, 24, 24, 28, 28, 26, 26, 26, 26, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28, 28},
+	{-1, 13, 22, 18, 26, 18, 24, 18, 22, 20, 24, 28, 26, 24, 20, 30, 24, 28, 28, 26, 30, 28, 30, 30, 30, 30, 28, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+	{-1, 17, 28, 22, 16, 22, 28, 26, 26, 24, 28, 24, 28, 22, 24, 24, 30, 28, 28, 26, 28, 30, 24, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30, 30},
+}
+
+// Error correction blocks indexed by level and version.
+var eccBlocks = [4][41]int{
+	{-1, 1, 1, 1, 1, 1, 2, 2, 2, 2, 4, 4, 4, 4, 4, 6, 6, 6, 6, 7, 8, 8, 9, 9, 10, 12, 12, 12, 13, 14, 15, 16, 17, 18, 19, 19, 20, 21, 22, 24, 25},
+	{-1, 1, 1, 1, 2, 2, 4, 4, 4, 5, 5, 5, 8, 9, 9, 10, 10, 11, 13, 14, 16, 17, 17, 18, 20, 21, 23, 25, 26, 28, 29, 31, 33, 35, 37, 38, 40, 43, 45, 47, 49},
+	{-1, 1, 1, 2, 2, 4, 4, 6, 6, 8, 8, 8, 10, 12, 16, 12, 17, 16, 18, 21, 20, 23, 23, 25, 27, 29, 34, 34, 35, 38, 40, 43, 45, 48, 51, 53, 56, 59, 62, 65, 68},
+	{-1, 1, 1, 2, 4, 4, 4, 5, 6, 8, 8, 11, 11, 16, 16, 18, 16, 19, 21, 25, 25, 25, 34, 30, 32, 35, 37, 40, 42, 45, 48, 51, 54, 57, 60, 63, 66, 70, 74, 77, 81},
+}
+
+// Code is an encoded QR code symbol.
+type Code struct {
+	Version  int    // 1..40
+	Level    Level  // error correction level
+	Mask     int    // data mask pattern 0..7
+	Size     int    // width and height in modules excluding the quiet zone
+	modules  []bool // dark modules in row major order, row 0 is the top row
+	function []bool // function pattern modules
+}
+
+// Black returns true if the module at column x and row y is dark.
+// Row 0 is the top row of the symbol.
+func (c *Code) Black(x, y int) bool {
+	if x < 0 || y < 0 || x >= c.Size || y >= c.Size {
+		return false
+	}
+	return c.modules[y*c.Size+x]
+}
+
+// rawDataModules returns the number of modules available for data and error correction codewords.
+func rawDataModules(ver int) int {
+	n := (16*ver+128)*ver + 64
+	if ver >= 2 {
+		numAlign := ver/7 + 2
+		n -= (25*numAlign-10)*numAlign - 55
+		if ver >= 7 {
+			n -= 36
+		}
+	}
+	return n
+}
+
+// dataCodewords returns the number of data codewords for ver and l.
+func dataCodewords(ver int, l Level) int {
+	return rawDataModules(ver)/8 - eccCodewordsPerBlock[l][ver]*eccBlocks[l][ver]
+}
+
+func charCountBits(ver int) int {
+	if ver <= 9 {
+		return 8
+	}
+	return 16
+}
+
+// Encode returns the smallest QR code encoding text in byte mode using error correction level l.
+func Encode(text string, l Level) (*Code, error) {
+	if l < Low || l > High {
+		return nil, errors.Errorf("pdfcpu: invalid qrcode error correction level: %d", l)
+	}
+
+	data := []byte(text)
+
+	ver := 1
+	for ; ver <= 40; ver++ {
+		if 4+charCountBits(ver)+8*len(data) <= 8*dataCodewords(ver, l) {
+			break
+		}
+	}
+	if ver > 40 {
+		return nil, errors.Errorf("pdfcpu: qrcode payload too long: %d bytes", len(data))
+	}
+
+	c := &Code{Version: ver, Level: l, Size: ver*4 + 17}
+	c.modules = make([]bool, c.Size*c.Size)
+	c.function = make([]bool, c.Size*c.Size)
+
+	c.drawFunctionPatterns()
+	c.drawCodewords(addErrorCorrection(encodeData(data, ver, l), ver, l))
+	c.applyBestMask()
+
+	return c, nil
+}
+
+// encodeData returns the data codewords for a single byte mode segment.
+func encodeData(data []byte, ver int, l Level) []byte {
+	var bb bitBuffer
+	bb.append(4, 4) // byte mode
+	bb.append(len(data), charCountBits(ver))
+	for _, b := range data {
+		bb.append(int(b), 8)
+	}
+
+	capBits := 8 * dataCodewords(ver, l)
+
+	// Terminator
+	t := capBits - len(bb)
+	if t > 4 {
+		t = 4
+	}
+	bb.append(0, t)
+
+	if r := len(bb) % 8; r > 0 {
+		bb.append(0, 8-r)
+	}
+
+	for pad := 0xEC; len(bb) < capBits; pad ^= 0xEC ^ 0x11 {
+		bb.append(pad, 8)
+	}
+
+	return bb.bytes()
+}
+
+// addErrorCorrection splits data into blocks, appends the error correction codewords to each block
+// and returns the interleaved codewords.
+func addErrorCorrection(data []byte, ver int, l Level) []byte {
+	numBlocks := eccBlocks[l][ver]
+	eccLen := eccCodewordsPerBlock[l][ver]
+	rawCodewords := rawDataModules(ver) / 8
+	numShortBlocks := numBlocks - rawCodewords%numBlocks
+	shortBlockLen := rawCodewords / numBlocks
+
+	divisor := rsDivisor(eccLen)
+
+	blocks := make([][]byte, numBlocks)
+	for i, k := 0, 0; i < numBlocks; i++ {
+		n := shortBlockLen - eccLen
+		if i >= numShortBlocks {
+			n++
+		}
+		dat := append([]byte{}, data[k:k+n]...)
+		k += n
+		ecc := rsRemainder(dat, divisor)
+		if i < numShortBlocks {
+			// Placeholder for aligning short and long blocks.
+			dat = append(dat, 0)
+		}
+		blocks[i] = append(dat, ecc...)
+	}
+
+	bb := make([]byte, 0, rawCodewords)
+	for i := range blocks[0] {
+		for j, block := range blocks {
+			// Skip the placeholders of short blocks.
+			if i != shortBlockLen-eccLen || j >= numShortBlocks {
+				bb = append(bb, block[i])
+			}
+		}
+	}
+
+	return bb
+}
+
+func (c *Code) set(x, y int, dark bool) {
+	c.modules[y*c.Size+x] = dark
+	c.function[y*c.Size+x] = true
+}
+
+func (c *Code) isFunction(x, y int) bool {
+	return c.function[y*c.Size+x]
+}
+
+func (c *Code) drawFunctionPatterns() {
+	// Timing patterns
+	for i := 0; i < c.Size; i++ {
+		c.set(6, i, i%2 == 0)
+		c.set(i, 6, i%2 == 0)
+	}
+
+	// Finder patterns
+	c.drawFinderPattern(3, 3)
+	c.drawFinderPattern(c.Size-4, 3)
+	c.drawFinderPattern(3, c.Size-4)
+
+	// Alignment patterns
+	pp := alignmentPatternPositions(c.Version)
+	n := len(pp)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			// Skip the corners occupied by finder patterns.
+			if i == 0 && j == 0 || i == 0 && j == n-1 || i == n-1 && j == 0 {
+				continue
+			}
+			c.drawAlignmentPattern(pp[i], pp[j])
+		}
+	}
+
+	// Reserve format information, real values are drawn after masking.
+	c.drawFormatBits(0)
+	c.drawVersion()
+}
+
+func abs(i int) int {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
+func (c *Code) drawFinderPattern(x, y int) {
+	for dy := -4; dy <= 4; dy++ {
+		for dx := -4; dx <= 4; dx++ {
+			xx, yy := x+dx, y+dy
+			if xx < 0 || yy < 0 || xx >= c.Size || yy >= c.Size {
+				continue
+			}
+			d := max(abs(dx), abs(dy))
+			c.set(xx, yy, d != 2 && d != 4)
+		}
+	}
+}
+
+func (c *Code) drawAlignmentPattern(x, y int) {
+	for dy := -2; dy <= 2; dy++ {
+		for dx := -2; dx <= 2; dx++ {
+			c.set(x+dx, y+dy, max(abs(dx), abs(dy)) != 1)
+		}
+	}
+}
+
+func alignmentPatternPositions(ver int) []int {
+	if ver == 1 {
+		return nil
+	}
+	n := ver/7 + 2
+	step := (ver*4 + n*2 + 1) / (n*2 - 2) * 2
+	if ver == 32 {
+		step = 26
+	}
+	pp := make([]int, n)
+	pp[0] = 6
+	for i, pos := n-1, ver*4+10; i >= 1; i, pos = i-1, pos-step {
+		pp[i] = pos
+	}
+	return pp
+}
+
+func (c *Code) drawFormatBits(mask int) {
+	data := c.Level.formatBits()<<3 | mask
+	rem := data
+	for i := 0; i < 10; i++ {
+		rem = (rem << 1) ^ ((rem >> 9) * 0x537)
+	}
+	bits := (data<<10 | rem) ^ 0x5412
+
+	bit := func(i int) bool { return (bits>>i)&1 != 0 }
+
+	// First copy around the top left finder pattern.
+	for i := 0; i <= 5; i++ {
+		c.set(8, i, bit(i))
+	}
+	c.set(8, 7, bit(6))
+	c.set(8, 8, bit(7))
+	c.set(7, 8, bit(8))
+	for i := 9; i < 15; i++ {
+		c.set(14-i, 8, bit(i))
+	}
+
+	// Second copy split between the top right and bottom left finder patterns.
+	for i := 0; i < 8; i++ {
+		c.set(c.Size-1-i, 8, bit(i))
+	}
+	for i := 8; i < 15; i++ {
+		c.set(8, c.Size-15+i, bit(i))
+	}
+
+	// Dark module
+	c.set(8, c.Size-8, true)
+}
+
+func (c *Code) drawVersion() {
+	if c.Version < 7 {
+		return
+	}
+	rem := c.Version
+	for i := 0; i < 12; i++ {
+		rem = (rem << 1) ^ ((rem >> 11) * 0x1F25)
+	}
+	bits := c.Version<<12 | rem
+
+	for i := 0; i < 18; i++ {
+		dark := (bits>>i)&1 != 0
+		a, b := c.Size-11+i%3, i/3
+		c.set(a, b, dark)
+		c.set(b, a, dark)
+	}
+}
+
+// drawCodewords places bb in the zigzag order defined by the spec skipping function modules.
+func (c *Code) drawCodewords(bb []byte) {
+	i := 0
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			// Skip the vertical timing pattern.
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x := right - j
+				y := vert
+				if (right+1)&2 == 0 {
+					// Upwards
+					y = c.Size - 1 - vert
+				}
+				if c.isFunction(x, y) || i >= len(bb)*8 {
+					continue
+				}
+				c.modules[y*c.Size+x] = (bb[i>>3]>>(7-i&7))&1 != 0
+				i++
+			}
+		}
+	}
+}
+
+func masked(mask, x, y int) bool {
+	switch mask {
+	case 0:
+		return (x+y)%2 == 0
+	case 1:
+		return y%2 == 0
+	case 2:
+		return x%3 == 0
+	case 3:
+		return (x+y)%3 == 0
+	case 4:
+		return (x/3+y/2)%2 == 0
+	case 5:
+		return x*y%2+x*y%3 == 0
+	case 6:
+		return (x*y%2+x*y%3)%2 == 0
+	}
+	return ((x+y)%2+x*y%3)%2 == 0
+}
+
+func (c *Code) applyMask(mask int) {
+	for y := 0; y < c.Size; y++ {
+		for x := 0; x < c.Size; x++ {
+			if !c.isFunction(x, y) && masked(mask, x, y) {
+				c.modules[y*c.Size+x] = !c.modules[y*c.Size+x]
+			}
+		}
+	}
+}
+
+// applyBestMask applies the mask pattern resulting in the lowest penalty score.
+func (c *Code) applyBestMask() {
+	best, minPenalty := 0, -1
+	for mask := 0; mask < 8; mask++ {
+		c.applyMask(mask)
+		c.drawFormatBits(mask)
+		if p := c.penalty(); minPenalty < 0 || p < minPenalty {
+			best, minPenalty = mask, p
+		}
+		// Masking is an involution.
+		c.applyMask(mask)
+	}
+	c.Mask = best
+	c.applyMask(best)
+	c.drawFormatBits(best)
+}
+
+var finderLike = [][]bool{
+	{true, false, true, true, true, false, true, false, false, false, false},
+	{false, false, false, false, true, false, true, true, true, false, true},
+}
+
+// penalty returns the penalty score of the current symbol as defined in section 7.8.3 of the spec.
+func (c *Code) penalty() int {
+	p := 0
+	n := c.Size
+
+	line := func(i int, row bool) func(int) bool {
+		if row {
+			return func(j int) bool { return c.Black(j, i) }
+		}
+		return func(j int) bool { return c.Black(i, j) }
+	}
+
+	for i := 0; i < n; i++ {
+		for _, row := range []bool{true, false} {
+			m := line(i, row)
+
+			// Adjacent modules of the same color.
+			run := 1
+			for j := 1; j < n; j++ {
+				if m(j) == m(j-1) {
+					run++
+					continue
+				}
+				if run >= 5 {
+					p += run - 2
+				}
+				run = 1
+			}
+			if run >= 5 {
+				p += run - 2
+			}
+
+			// Finder like patterns.
+			for j := 0; j+11 <= n; j++ {
+				for _, pat := range finderLike {
+					k := 0
+					for k < 11 && m(j+k) == pat[k] {
+						k++
+					}
+					if k == 11 {
+						p += 40
+					}
+				}
+			}
+		}
+	}
+
+	// 2x2 blocks of the same color.
+	for y := 0; y < n-1; y++ {
+		for x := 0; x < n-1; x++ {
+			b := c.Black(x, y)
+			if b == c.Black(x+1, y) && b == c.Black(x, y+1) && b == c.Black(x+1, y+1) {
+				p += 3
+			}
+		}
+	}
+
+	// Balance of dark and light modules.
+	dark := 0
+	for _, b := range c.modules {
+		if b {
+			dark++
+		}
+	}
+	total := n * n
+	k := (abs(dark*20-total*10)+total-1)/total - 1
+	p += k * 10
+
+	return p
+}
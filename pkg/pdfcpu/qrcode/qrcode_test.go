@@ -0,0 +1,246 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package qrcode
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestByteCapacity(t *testing.T) {
+	for _, tt := range []struct {
+		ver  int
+		l    Level
+		want int
+	}{
+		{1, Low, 17},
+		{1, Medium, 14},
+		{1, Quartile, 11},
+		{1, High, 7},
+		{10, Medium, 213},
+		{40, Low, 2953},
+		{40, Medium, 2331},
+		{40, Quartile, 1663},
+		{40, High, 1273},
+	} {
+		got := (8*dataCodewords(tt.ver, tt.l) - 4 - charCountBits(tt.ver)) / 8
+		if got != tt.want {
+			t.Errorf("capacity %d-%s: got %d, want %d", tt.ver, tt.l, got, tt.want)
+		}
+	}
+}
+
+func TestAlignmentPatternPositions(t *testing.T) {
+	for _, tt := range []struct {
+		ver  int
+		want []int
+	}{
+		{1, nil},
+		{2, []int{6, 18}},
+		{7, []int{6, 22, 38}},
+		{15, []int{6, 26, 48, 70}},
+		{32, []int{6, 34, 60, 86, 112, 138}},
+		{36, []int{6, 24, 50, 76, 102, 128, 154}},
+		{40, []int{6, 30, 58, 86, 114, 142, 170}},
+	} {
+		if got := alignmentPatternPositions(tt.ver); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("version %d: got %v, want %v", tt.ver, got, tt.want)
+		}
+	}
+}
+
+func TestReedSolomon(t *testing.T) {
+	// "HELLO WORLD" 1-M
+	data := []byte{32, 91, 11, 120, 209, 114, 220, 77, 67, 64, 236, 17, 236, 17, 236, 17}
+	want := []byte{196, 35, 39, 119, 235, 215, 231, 226, 93, 23}
+	if got := rsRemainder(data, rsDivisor(10)); !bytes.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+// readFormatBits reads the format information next to the top left finder pattern.
+func readFormatBits(c *Code) int {
+	bits := 0
+	set := func(i, x, y int) {
+		if c.Black(x, y) {
+			bits |= 1 << i
+		}
+	}
+	for i := 0; i <= 5; i++ {
+		set(i, 8, i)
+	}
+	set(6, 8, 7)
+	set(7, 8, 8)
+	set(8, 7, 8)
+	for i := 9; i < 15; i++ {
+		set(i, 14-i, 8)
+	}
+	return bits
+}
+
+func TestFormatBits(t *testing.T) {
+	for _, tt := range []struct {
+		l    Level
+		mask int
+		want string
+	}{
+		{Low, 0, "111011111000100"},
+		{Low, 4, "110011000101111"},
+		{Medium, 0, "101010000010010"},
+		{High, 7, "000100000111011"},
+	} {
+		c := &Code{Level: tt.l, Size: 21, modules: make([]bool, 21*21), function: make([]bool, 21*21)}
+		c.drawFormatBits(tt.mask)
+		sb := strings.Builder{}
+		bits := readFormatBits(c)
+		for i := 14; i >= 0; i-- {
+			sb.WriteByte('0' + byte(bits>>i&1))
+		}
+		if sb.String() != tt.want {
+			t.Errorf("format %s%d: got %s, want %s", tt.l, tt.mask, sb.String(), tt.want)
+		}
+	}
+}
+
+// decode extracts the byte mode payload of c, checking the error correction codewords of all blocks.
+func decode(t *testing.T, c *Code) string {
+	t.Helper()
+
+	c.applyMask(c.Mask)
+	defer c.applyMask(c.Mask)
+
+	// Read the codewords in zigzag order.
+	var bb bitBuffer
+	for right := c.Size - 1; right >= 1; right -= 2 {
+		if right == 6 {
+			right = 5
+		}
+		for vert := 0; vert < c.Size; vert++ {
+			for j := 0; j < 2; j++ {
+				x, y := right-j, vert
+				if (right+1)&2 == 0 {
+					y = c.Size - 1 - vert
+				}
+				if !c.isFunction(x, y) {
+					bb = append(bb, c.Black(x, y))
+				}
+			}
+		}
+	}
+	raw := bb[:len(bb)/8*8].bytes()
+
+	// De-interleave the blocks.
+	numBlocks := eccBlocks[c.Level][c.Version]
+	eccLen := eccCodewordsPerBlock[c.Level][c.Version]
+	numShortBlocks := numBlocks - len(raw)%numBlocks
+	shortBlockLen := len(raw) / numBlocks
+
+	blocks := make([][]byte, numBlocks)
+	k := 0
+	for i := 0; i < shortBlockLen+1; i++ {
+		for j := range blocks {
+			if i == shortBlockLen-eccLen && j < numShortBlocks {
+				continue
+			}
+			blocks[j] = append(blocks[j], raw[k])
+			k++
+		}
+	}
+
+	var data []byte
+	for i, block := range blocks {
+		// The syndromes of a valid codeword are all zero.
+		for e, alpha := 0, byte(1); e < eccLen; e, alpha = e+1, gfMultiply(alpha, 2) {
+			s := byte(0)
+			for _, b := range block {
+				s = gfMultiply(s, alpha) ^ b
+			}
+			if s != 0 {
+				t.Fatalf("block %d: syndrome %d = %d", i, e, s)
+			}
+		}
+		data = append(data, block[:len(block)-eccLen]...)
+	}
+
+	var db bitBuffer
+	for _, b := range data {
+		db.append(int(b), 8)
+	}
+	val := func(from, n int) int {
+		v := 0
+		for _, b := range db[from : from+n] {
+			v <<= 1
+			if b {
+				v |= 1
+			}
+		}
+		return v
+	}
+
+	if mode := val(0, 4); mode != 4 {
+		t.Fatalf("unexpected mode: %d", mode)
+	}
+	ccBits := charCountBits(c.Version)
+	n := val(4, ccBits)
+	out := make([]byte, n)
+	for i := range out {
+		out[i] = byte(val(4+ccBits+8*i, 8))
+	}
+	return string(out)
+}
+
+func TestEncode(t *testing.T) {
+	for _, tt := range []struct {
+		text    string
+		l       Level
+		wantVer int
+	}{
+		{"HELLO WORLD", Medium, 1},
+		{"https://pdfcpu.io", Low, 1},
+		{"https://pdfcpu.io/core/stamp?page=1&id=0123456789", High, 6},
+		{strings.Repeat("pdfcpu ", 40), Quartile, 15},
+		{strings.Repeat("0123456789", 120), Medium, 29},
+	} {
+		c, err := Encode(tt.text, tt.l)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Version != tt.wantVer || c.Size != 4*tt.wantVer+17 {
+			t.Errorf("%q: got version %d, want %d", tt.text, c.Version, tt.wantVer)
+		}
+		// Top left finder pattern
+		for i := 0; i < 7; i++ {
+			if !c.Black(i, 0) || !c.Black(0, i) || c.Black(7, i) || c.Black(i, 7) {
+				t.Fatalf("%q: corrupt finder pattern", tt.text)
+			}
+		}
+		if got := decode(t, c); got != tt.text {
+			t.Errorf("%q: decoded %q", tt.text, got)
+		}
+		if mask := (readFormatBits(c) ^ 0x5412) >> 10 & 7; mask != c.Mask {
+			t.Errorf("%q: format bits: got mask %d, want %d", tt.text, mask, c.Mask)
+		}
+	}
+}
+
+func TestEncodeTooLong(t *testing.T) {
+	if _, err := Encode(strings.Repeat("x", 2954), Low); err == nil {
+		t.Fatal("expected error")
+	}
+}
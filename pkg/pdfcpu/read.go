@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
@@ -57,10 +58,6 @@ func ReadFile(inFile string, conf *model.Configuration) (*model.Context, error)
 // ReadFileContext reads in a PDF file and builds an internal structure holding its cross reference table aka the PDF model context.
 // If the passed Go context is cancelled, reading will be interrupted.
 func ReadFileWithContext(c context.Context, inFile string, conf *model.Configuration) (*model.Context, error) {
-	if log.InfoEnabled() {
-		log.Info.Printf("reading %s..\n", inFile)
-	}
-
 	f, err := os.Open(inFile)
 	if err != nil {
 		return nil, errors.Wrapf(err, "can't open %q", inFile)
@@ -96,13 +93,7 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		return nil, errors.New("The file could not be opened because it is empty.")
 	}
 
-	if log.InfoEnabled() {
-		if ctx.Reader15 {
-			log.Info.Println("PDF Version 1.5 conforming reader")
-		} else {
-			log.Info.Println("PDF Version 1.4 conforming reader - no object streams or xrefstreams allowed")
-		}
-	}
+	ctx.LogInfo("Read: begin", "size", ctx.Read.FileSize, "reader15", ctx.Reader15)
 
 	// Populate xRefTable.
 	if err = readXRefTable(c, ctx); err != nil {
@@ -115,17 +106,26 @@ func ReadWithContext(c context.Context, rs io.ReadSeeker, conf *model.Configurat
 		return nil, err
 	}
 
+	if ctx.Read.XRefRebuilt {
+		removeDanglingReferences(ctx)
+		if err = repairPageTree(ctx); err != nil {
+			return nil, err
+		}
+	}
+
 	// Some PDFWriters write an incorrect Size into trailer.
 	if ctx.XRefTable.Size == nil || *ctx.XRefTable.Size != ctx.MaxObjNr+1 {
 		maxObjNr := ctx.MaxObjNr + 1
 		ctx.XRefTable.Size = &maxObjNr
-		model.ShowRepaired("trailer size")
+		ctx.Read.Repaired(model.RepairTrailer, 0, 0, "trailer size")
 	}
 
 	if log.ReadEnabled() {
 		log.Read.Println("Read: end")
 	}
 
+	ctx.LogInfo("Read: end", "version", ctx.VersionString(), "objects", *ctx.Size)
+
 	return ctx, nil
 }
 
@@ -208,7 +208,11 @@ func offsetLastXRefSection(ctx *model.Context, skip int64) (*int64, error) {
 		p := workBuf[j+len("startxref")+1:]
 		posEOF := strings.Index(string(p), "%%EOF")
 		if posEOF == -1 {
-			return nil, errors.New("pdfcpu: no matching %%EOF for startxref")
+			if !ctx.Tolerance().MissingEOF {
+				return nil, errors.New("pdfcpu: no matching %%EOF for startxref")
+			}
+			ctx.Read.Repaired(model.RepairMissingEOF, 0, off+int64(j), "missing %%EOF")
+			posEOF = len(p)
 		}
 
 		p = p[:posEOF]
@@ -228,7 +232,7 @@ func offsetLastXRefSection(ctx *model.Context, skip int64) (*int64, error) {
 	return &offset, nil
 }
 
-func createXRefTableEntry(entryType string, objNr int, offset, offExtra int64, generation, incr int) (model.XRefTableEntry, bool) {
+func createXRefTableEntry(xRefTable *model.XRefTable, entryType string, objNr int, offset, offExtra int64, generation, incr int) (model.XRefTableEntry, bool) {
 	entry := model.XRefTableEntry{Offset: &offset, Generation: &generation, Incr: incr}
 
 	if entryType == "n" {
@@ -245,9 +249,7 @@ func createXRefTableEntry(entryType string, objNr int, offset, offExtra int64, g
 				model.ShowRepaired("obj#0")
 				return entry, true
 			}
-			if log.InfoEnabled() {
-				log.Info.Printf("createXRefTableEntry: Skip entry for in use object #%d with offset 0\n", objNr)
-			}
+			xRefTable.LogInfo("createXRefTableEntry: skip entry for in use object with offset 0", log.KeyObj, objNr)
 			return entry, false
 		}
 
@@ -324,7 +326,7 @@ func parseXRefTableEntry(xRefTable *model.XRefTable, s *bufio.Scanner, objNr int
 		return err
 	}
 
-	entry, ok := createXRefTableEntry(entryType, objNr, offset, offExtra, generation, incr)
+	entry, ok := createXRefTableEntry(xRefTable, entryType, objNr, offset, offExtra, generation, incr)
 	if !ok {
 		return nil
 	}
@@ -409,18 +411,15 @@ func compressedObject(c context.Context, s string) (types.Object, error) {
 }
 
 // Parse all objects of an object stream and save them into objectStreamDict.ObjArray.
-func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
-	if log.ReadEnabled() {
-		log.Read.Printf("parseObjectStream begin: decoding %d objects.\n", osd.ObjCount)
-	}
-
+// objectStreamProlog returns the pairs of object numbers and offsets of osd.
+func objectStreamProlog(osd *types.ObjectStreamDict) ([]string, error) {
 	decodedContent := osd.Content
 	if decodedContent == nil {
 		// The actual content will be decoded lazily, only decode the prolog here.
 		var err error
 		decodedContent, err = osd.DecodeLength(int64(osd.FirstObjOffset))
 		if err != nil {
-			return err
+			return nil, err
 		}
 	}
 	prolog := decodedContent[:osd.FirstObjOffset]
@@ -436,7 +435,20 @@ func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
 
 	objs := strings.Fields(string(prolog))
 	if len(objs)%2 > 0 {
-		return errors.New("pdfcpu: parseObjectStream: corrupt object stream dict")
+		return nil, errors.New("pdfcpu: parseObjectStream: corrupt object stream dict")
+	}
+
+	return objs, nil
+}
+
+func parseObjectStream(c context.Context, osd *types.ObjectStreamDict) error {
+	if log.ReadEnabled() {
+		log.Read.Printf("parseObjectStream begin: decoding %d objects.\n", osd.ObjCount)
+	}
+
+	objs, err := objectStreamProlog(osd)
+	if err != nil {
+		return err
 	}
 
 	// e.g., 10 0 11 25 = 2 Objects: #10 @ offset 0, #11 @ offset 25
@@ -1337,186 +1349,6 @@ func headerVersion(rs io.ReadSeeker) (v *model.Version, eolCount int, offset int
 	return &pdfVersion, eolCount, int64(off), nil
 }
 
-func parseAndLoad(c context.Context, ctx *model.Context, line string, offset *int64, incr int) error {
-	l := line
-	objNr, generation, err := model.ParseObjectAttributes(&l)
-	if err != nil {
-		return err
-	}
-
-	entry := model.XRefTableEntry{
-		Free:       false,
-		Offset:     offset,
-		Generation: generation,
-		Incr:       incr}
-
-	off := *entry.Offset
-
-	e, found := ctx.Table[*objNr]
-	if found {
-		off = *e.Offset
-	} else {
-		ctx.Table[*objNr] = &entry
-	}
-
-	o, err := ParseObjectWithContext(c, ctx, off, *objNr, *entry.Generation)
-	if err != nil {
-		return err
-	}
-
-	entry.Object = o
-
-	sd, ok := o.(types.StreamDict)
-	if ok {
-		if err = loadStreamDict(c, ctx, &sd, *objNr, *generation, true); err != nil {
-			return err
-		}
-		entry.Object = sd
-		*offset = sd.StreamOffset + *sd.StreamLength
-		return nil
-	}
-
-	if ctx.RootDict == nil {
-		if d, ok := o.(types.Dict); ok {
-			if typ := d.Type(); typ != nil {
-				if *typ == "Catalog" {
-					ctx.RootDict = d
-					ctx.Root = types.NewIndirectRef(*objNr, *generation)
-					model.ShowRepaired("catalog")
-				}
-			}
-		}
-	}
-
-	*offset += int64(len(line))
-
-	return nil
-}
-
-func processObject(c context.Context, ctx *model.Context, line string, offset *int64, incr int) (*bufio.Scanner, error) {
-	if err := parseAndLoad(c, ctx, line, offset, incr); err != nil {
-		return nil, err
-	}
-	rd, err := newPositionedReader(ctx.Read.RS, offset)
-	if err != nil {
-		return nil, err
-	}
-	s := bufio.NewScanner(rd)
-	s.Split(scan.LinesSingleEOL)
-	return s, nil
-}
-
-func objCandidate(withinObj bool, line string) bool {
-	if withinObj {
-		return false
-	}
-	i := strings.Index(line, "obj")
-	return i > 2 && strings.Index(line, "endobj") != i-3
-}
-
-func checkEndObj(withinObj *bool, line *string) {
-	if *withinObj {
-		i := strings.Index(*line, "endobj")
-		if i >= 0 {
-			*line = (*line)[i:]
-			*withinObj = false
-		}
-	}
-}
-
-// bypassXrefSection is a fix for digesting corrupt xref sections.
-// It populates the xRefTable by reading in all indirect objects line by line
-// and works on the assumption of a single xref section - meaning no incremental updates.
-func bypassXrefSection(c context.Context, ctx *model.Context, offExtra int64, wasErr error, incr int) error {
-	if log.ReadEnabled() {
-		log.Read.Printf("bypassXRefSection after %v\n", wasErr)
-	}
-
-	var z int64
-	g := types.FreeHeadGeneration
-	ctx.Table[0] = &model.XRefTableEntry{
-		Free:       true,
-		Offset:     &z,
-		Generation: &g}
-
-	rs := ctx.Read.RS
-	var offset int64
-
-	rd, err := newPositionedReader(rs, &offset)
-	if err != nil {
-		return err
-	}
-
-	s := bufio.NewScanner(rd)
-	s.Split(scan.LinesSingleEOL)
-	eolCount := 1
-
-	bb := []byte{}
-	var (
-		withinObj     bool
-		withinXref    bool
-		withinTrailer bool
-		prevLine      string
-	)
-
-	for {
-		line, length, err := scanLineRaw(s)
-		if err != nil {
-			break
-		}
-		if len(prevLine) > 0 {
-			line = prevLine + line
-			prevLine = ""
-		}
-		if withinXref {
-			offset += int64(length + eolCount)
-			if withinTrailer {
-				bb = append(bb, '\n')
-				bb = append(bb, line...)
-				_, err = processTrailer(c, ctx, s, string(bb), nil, offExtra, incr)
-				if err == nil {
-					model.ShowRepaired("xreftable")
-				}
-				if err != nil {
-					return err
-				}
-				withinXref = false
-				withinTrailer = false
-				continue
-			}
-			i := strings.Index(line, "trailer")
-			if i >= 0 {
-				bb = append(bb, line...)
-				withinTrailer = true
-			}
-			continue
-		}
-		i := strings.Index(line, "xref")
-		if i >= 0 {
-			offset += int64(length + eolCount)
-			withinXref = true
-			continue
-		}
-		checkEndObj(&withinObj, &line)
-		if objCandidate(withinObj, line) {
-			if !strings.HasSuffix(line, "obj") {
-				withinObj = true
-				s, err = processObject(c, ctx, line, &offset, incr)
-				if err != nil {
-					return err
-				}
-				continue
-			}
-			prevLine = line
-			continue
-		}
-
-		offset += int64(length + eolCount)
-		continue
-	}
-	return nil
-}
-
 func postProcess(ctx *model.Context, xrefSectionCount int) {
 	// Ensure free object #0 if exactly one xref subsection
 	// and in one of the following weird situations:
@@ -1533,7 +1365,7 @@ func postProcess(ctx *model.Context, xrefSectionCount int) {
 			}
 			delete(ctx.Table, *ctx.Size)
 		}
-		model.ShowRepaired("obj#0")
+		ctx.Read.Repaired(model.RepairFreeList, 0, 0, "obj#0")
 	}
 }
 
@@ -1655,12 +1487,14 @@ func buildXRefTableStartingAt(c context.Context, ctx *model.Context, offset *int
 		}
 
 		if offset, err = parseXRefStream(c, ctx, rd, offset, offExtra, incr); err != nil {
-			// Try fix for corrupt single xref section.
-			return bypassXrefSection(c, ctx, offExtra, err, incr)
+			// readXRefTable rebuilds the xref table if tolerated.
+			return err
 		}
 
 	}
 
+	ctx.Read.Revisions = incr
+
 	postProcess(ctx, xrefSectionCount)
 
 	if log.ReadEnabled() {
@@ -1693,7 +1527,13 @@ func readXRefTable(c context.Context, ctx *model.Context) (err error) {
 
 	err = buildXRefTableStartingAt(c, ctx, offset)
 	if err == io.EOF {
-		return errors.Wrap(err, "readXRefTable: unexpected eof")
+		err = errors.Wrap(err, "readXRefTable: unexpected eof")
+	}
+	if (err != nil || ctx.Root == nil) && ctx.Tolerance().RebuildXRef {
+		if err == nil {
+			err = errors.New("pdfcpu: readXRefTable: missing root object")
+		}
+		err = scanXRefTable(c, ctx, err)
 	}
 	if err != nil {
 		return
@@ -2031,7 +1871,7 @@ func streamDictForObject(c context.Context, ctx *model.Context, d types.Dict, ob
 }
 
 func dict(ctx *model.Context, d1 types.Dict, objNr, genNr, endInd, streamInd int) (d2 types.Dict, err error) {
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		if _, err := decryptDeepObject(d1, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return nil, err
 		}
@@ -2150,7 +1990,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return streamDictForObject(c, ctx, o, objNr, streamInd, streamOffset, offset)
 
 	case types.Array:
-		if ctx.EncKey != nil {
+		if stringEncryption(ctx) {
 			if _, err := decryptDeepObject(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 				return nil, err
 			}
@@ -2158,7 +1998,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.StringLiteral:
-		if ctx.EncKey != nil {
+		if stringEncryption(ctx) {
 			sl, err := decryptStringLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2168,7 +2008,7 @@ func resolveObject(c context.Context, ctx *model.Context, obj types.Object, offs
 		return o, nil
 
 	case types.HexLiteral:
-		if ctx.EncKey != nil {
+		if stringEncryption(ctx) {
 			hl, err := decryptHexLiteral(o, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 			if err != nil {
 				return nil, err
@@ -2371,6 +2211,12 @@ func readStreamContent(rd io.Reader, streamLength int) ([]byte, error) {
 	return buf, nil
 }
 
+// endstreamFollows returns true if the remaining data of rd starts with endstream, optionally preceded by white space.
+func endstreamFollows(rd *bufio.Reader) bool {
+	bb, _ := rd.Peek(64)
+	return bytes.HasPrefix(bytes.TrimLeft(bb, " \t\r\n\f\x00"), []byte("endstream"))
+}
+
 func ensureStreamLength(sd *types.StreamDict, fixLength bool) {
 	l := int64(len(sd.Raw))
 	if fixLength || sd.StreamLength == nil || l != *sd.StreamLength {
@@ -2396,10 +2242,10 @@ func loadEncodedStreamContent(c context.Context, ctx *model.Context, sd *types.S
 	// Dereference stream length if stream length is an indirect object.
 	if !fixLength && sd.StreamLength == nil {
 		if sd.StreamLengthObjNr == nil {
-			if ctx.XRefTable.ValidationMode == model.ValidationStrict {
+			if ctx.XRefTable.ValidationMode == model.ValidationStrict || !ctx.Tolerance().BrokenLength {
 				return errors.New("pdfcpu: loadEncodedStreamContent: missing streamLength")
 			}
-			model.ShowSkipped("missing stream length")
+			ctx.Read.Repaired(model.RepairStreamLength, 0, sd.StreamOffset, "missing stream length")
 		}
 		if sd.StreamLengthObjNr != nil {
 			if sd.StreamLength, err = int64Object(c, ctx, *sd.StreamLengthObjNr); err != nil {
@@ -2424,6 +2270,20 @@ func loadEncodedStreamContent(c context.Context, ctx *model.Context, sd *types.S
 		return err
 	}
 
+	if l1 > 0 && (len(sd.Raw) < l1 || !endstreamFollows(rd)) {
+		// Length points beyond the file or not at endstream.
+		if !ctx.Tolerance().BrokenLength {
+			return errors.Errorf("pdfcpu: loadEncodedStreamContent: invalid stream length %d", l1)
+		}
+		if rd, err = newPositionedReader(ctx.Read.RS, &sd.StreamOffset); err != nil {
+			return err
+		}
+		if sd.Raw, err = readStreamContentBlindly(rd); err != nil {
+			return err
+		}
+		ctx.Read.Repaired(model.RepairStreamLength, 0, sd.StreamOffset, fmt.Sprintf("stream length %d corrected to %d", l1, len(sd.Raw)))
+	}
+
 	ensureStreamLength(sd, fixLength)
 
 	if log.ReadEnabled() {
@@ -2439,14 +2299,6 @@ func saveDecodedStreamContent(ctx *model.Context, sd *types.StreamDict, objNr, g
 		log.Read.Printf("saveDecodedStreamContent: begin decode=%t\n", decode)
 	}
 
-	// If the "Identity" crypt filter is used we do not need to decrypt.
-	if ctx != nil && ctx.EncKey != nil {
-		if len(sd.FilterPipeline) == 1 && sd.FilterPipeline[0].Name == "Crypt" {
-			sd.Content = sd.Raw
-			return nil
-		}
-	}
-
 	// Special case: If the length of the encoded data is 0, we do not need to decode anything.
 	if len(sd.Raw) == 0 {
 		sd.Content = sd.Raw
@@ -2455,11 +2307,18 @@ func saveDecodedStreamContent(ctx *model.Context, sd *types.StreamDict, objNr, g
 
 	// ctx gets created after XRefStream parsing.
 	// XRefStreams are not encrypted.
-	if ctx != nil && ctx.EncKey != nil {
-		if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
+	if ctx != nil {
+		// The crypt filter in charge may be the "Identity" crypt filter.
+		encrypted, aes, err := streamEncryption(ctx, sd)
+		if err != nil {
 			return err
 		}
-		ensureStreamLength(sd, true)
+		if encrypted {
+			if sd.Raw, err = decryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
+				return err
+			}
+			ensureStreamLength(sd, true)
+		}
 	}
 
 	if !decode {
@@ -2669,7 +2528,13 @@ func decodeObjectStreams(c context.Context, ctx *model.Context) error {
 			return err
 		}
 		if err := decodeObjectStream(c, ctx, objNr); err != nil {
-			return err
+			if !ctx.Read.XRefRebuilt {
+				return err
+			}
+			// Salvage the remaining objects.
+			delete(ctx.Read.ObjectStreams, objNr)
+			ctx.Table[objNr].Free = true
+			ctx.Read.Repaired(model.RepairMissingObject, objNr, 0, fmt.Sprintf("broken object stream #%d", objNr))
 		}
 	}
 
@@ -2755,6 +2620,45 @@ func updateBinaryTotalSize(ctx *model.Context, o types.Object) {
 	}
 }
 
+// searchObject returns the offset of "objNr genNr obj" closest to offset within the configured xref offset range.
+func searchObject(ctx *model.Context, objNr, genNr int, offset int64) (int64, bool) {
+	r := int64(ctx.Tolerance().XRefOffsetRange)
+	if r <= 0 {
+		return 0, false
+	}
+
+	from := max(offset-r, 0)
+	bb := make([]byte, 2*r+1)
+	if _, err := ctx.Read.RS.Seek(from, io.SeekStart); err != nil {
+		return 0, false
+	}
+	n, err := fillBuffer(ctx.Read.RS, bb)
+	if err != nil {
+		return 0, false
+	}
+	bb = bb[:n]
+
+	re := regexp.MustCompile(fmt.Sprintf(`(^|[^0-9])%d\s+%d\s+obj`, objNr, genNr))
+
+	best, found := int64(0), false
+	for _, m := range re.FindAllSubmatchIndex(bb, -1) {
+		// m[3] is the end of the leading delimiter group.
+		off := from + int64(m[3])
+		if !found || abs64(off-offset) < abs64(best-offset) {
+			best, found = off, true
+		}
+	}
+
+	return best, found
+}
+
+func abs64(i int64) int64 {
+	if i < 0 {
+		return -i
+	}
+	return i
+}
+
 func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry *model.XRefTableEntry) error {
 	if log.ReadEnabled() {
 		log.Read.Printf("dereferenceAndLoad: dereferencing object %d\n", objNr)
@@ -2768,9 +2672,20 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 		}
 		if ctx.Read.RepairOffset > 0 {
 			o, err = ParseObjectWithContext(c, ctx, *entry.Offset+ctx.Read.RepairOffset, objNr, *entry.Generation)
+			if err == nil {
+				ctx.Read.Repaired(model.RepairXRefOffset, objNr, *entry.Offset, fmt.Sprintf("obj #%d offset shifted by %d", objNr, ctx.Read.RepairOffset))
+			}
+		}
+		if err != nil && ctx.Tolerance().XRefOffsets {
+			if off, ok := searchObject(ctx, objNr, *entry.Generation, *entry.Offset); ok {
+				if o, err = ParseObjectWithContext(c, ctx, off, objNr, *entry.Generation); err == nil {
+					ctx.Read.Repaired(model.RepairXRefOffset, objNr, *entry.Offset, fmt.Sprintf("obj #%d found %d bytes off its xref offset", objNr, off-*entry.Offset))
+					*entry.Offset = off
+				}
+			}
 		}
 		if err != nil {
-			model.ShowSkipped(fmt.Sprintf("missing obj #%d", objNr))
+			ctx.Read.Repaired(model.RepairMissingObject, objNr, *entry.Offset, fmt.Sprintf("missing obj #%d", objNr))
 		}
 		err = nil
 	}
@@ -2797,7 +2712,13 @@ func dereferenceAndLoad(c context.Context, ctx *model.Context, objNr int, entry
 
 	if sd, ok := o.(types.StreamDict); ok {
 		if err = loadStreamDict(c, ctx, &sd, objNr, *entry.Generation, false); err != nil {
-			return err
+			if !ctx.Read.XRefRebuilt {
+				return err
+			}
+			// Drop broken streams of damaged files.
+			entry.Object = nil
+			ctx.Read.Repaired(model.RepairMissingObject, objNr, *entry.Offset, fmt.Sprintf("broken stream obj #%d", objNr))
+			return nil
 		}
 		entry.Object = sd
 	}
@@ -2976,9 +2897,7 @@ func identifyRootVersion(xRefTable *model.XRefTable) error {
 
 	// since V1.4 the header version may be overridden by a Version entry in the catalog.
 	if *xRefTable.HeaderVersion < model.V14 {
-		if log.InfoEnabled() {
-			log.Info.Printf("identifyRootVersion: PDF version is %s - will ignore root version: %s\n", xRefTable.HeaderVersion, *rootVersionStr)
-		}
+		xRefTable.LogInfo("identifyRootVersion: ignoring root version", "header", xRefTable.HeaderVersion.String(), "root", *rootVersionStr)
 	}
 
 	if log.ReadEnabled() {
@@ -3011,11 +2930,19 @@ func dereferenceXRefTable(c context.Context, ctx *model.Context) error {
 		return err
 	}
 
+	if ctx.Read.XRefRebuilt {
+		if err := registerObjectStreamObjects(ctx); err != nil {
+			return err
+		}
+	}
+
 	// For each xRefTableEntry assign a Object either by parsing from file or pointing to a decompressed object.
 	if err := dereferenceObjects(c, ctx); err != nil {
 		return err
 	}
 
+	resolveJBIG2Globals(ctx)
+
 	// Identify an optional Version entry in the root object/catalog.
 	if err := identifyRootVersion(xRefTable); err != nil {
 		return err
@@ -3028,6 +2955,48 @@ func dereferenceXRefTable(c context.Context, ctx *model.Context) error {
 	return nil
 }
 
+// resolveJBIG2Globals hands the decoded JBIG2Globals streams over to the JBIG2Decode filters using them.
+// Images referring to corrupt globals fail to decode later on.
+func resolveJBIG2Globals(ctx *model.Context) {
+	for _, entry := range ctx.Table {
+		if entry == nil || entry.Free {
+			continue
+		}
+
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			continue
+		}
+
+		for i, f := range sd.FilterPipeline {
+			if f.Name != filter.JBIG2 || f.DecodeParms == nil {
+				continue
+			}
+
+			indRef := f.DecodeParms.IndirectRefEntry("JBIG2Globals")
+			if indRef == nil {
+				continue
+			}
+
+			e, found := ctx.Find(indRef.ObjectNumber.Value())
+			if !found {
+				continue
+			}
+
+			globals, ok := e.Object.(types.StreamDict)
+			if !ok || globals.Decode() != nil {
+				if log.ReadEnabled() {
+					log.Read.Printf("resolveJBIG2Globals: corrupt JBIG2Globals stream obj#%d\n", indRef.ObjectNumber.Value())
+				}
+				continue
+			}
+
+			// The filter pipeline is shared with the xref table entry.
+			sd.FilterPipeline[i].JBIG2Globals = globals.Content
+		}
+	}
+}
+
 func handleUnencryptedFile(ctx *model.Context) error {
 	if ctx.Cmd == model.DECRYPT || ctx.Cmd == model.SETPERMISSIONS {
 		return errors.New("pdfcpu: this file is not encrypted")
@@ -3039,8 +3008,8 @@ func handleUnencryptedFile(ctx *model.Context) error {
 
 	// Encrypt subcommand found.
 
-	if ctx.OwnerPW == "" {
-		return errors.New("pdfcpu: please provide owner password and optional user password")
+	if ctx.OwnerPW == "" && len(ctx.Recipients) == 0 {
+		return errors.New("pdfcpu: please provide owner password and optional user password or recipients")
 	}
 
 	return nil
@@ -3066,18 +3035,39 @@ func handlePermissions(ctx *model.Context) error {
 	}
 
 	// Double check minimum permissions for pdfcpu processing.
-	if !hasNeededPermissions(ctx.Cmd, ctx.E) {
+	if !hasNeededPermissions(ctx) {
 		return errors.New("pdfcpu: operation restricted via pdfcpu's permission bits setting")
 	}
 
 	return nil
 }
 
-func setupEncryptionKey(ctx *model.Context, d types.Dict) (err error) {
+func setupEncryptionKey(ctx *model.Context, d types.Dict) error {
+	err := validatePasswords(ctx, d)
+
+	// Ask for passwords until one fits.
+	for attempt := 1; err == ErrWrongPassword && ctx.PasswordProvider != nil; attempt++ {
+		pw, ok := ctx.PasswordProvider(ctx.Read.FileName, attempt)
+		if !ok {
+			break
+		}
+		// pw may be either the user or the owner password.
+		ctx.UserPW, ctx.OwnerPW = pw, pw
+		err = validatePasswords(ctx, d)
+	}
+
+	return err
+}
+
+func validatePasswords(ctx *model.Context, d types.Dict) (err error) {
 	if ctx.E, err = supportedEncryption(ctx, d); err != nil {
 		return err
 	}
 
+	if len(ctx.E.Recipients) > 0 {
+		return decryptPubSecKey(ctx)
+	}
+
 	if ctx.E.ID, err = ctx.IDFirstElement(); err != nil {
 		return err
 	}
@@ -0,0 +1,375 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+var (
+	reObjHeader = regexp.MustCompile(`(?:^|[\s>\]}])(\d+)\s+(\d+)\s+obj\b`)
+	reTrailer   = regexp.MustCompile(`trailer\s*<<`)
+)
+
+func applyTrailerEntries(ctx *model.Context, d types.Dict) {
+	if indRef := d.IndirectRefEntry("Root"); indRef != nil {
+		ctx.Root = indRef
+	}
+	if indRef := d.IndirectRefEntry("Info"); indRef != nil {
+		ctx.Info = indRef
+	}
+	if indRef := d.IndirectRefEntry("Encrypt"); indRef != nil {
+		ctx.Encrypt = indRef
+	}
+	if a := d.ArrayEntry("ID"); len(a) == 2 {
+		ctx.ID = a
+	}
+}
+
+func scanForTrailers(ctx *model.Context, bb []byte) {
+	for _, m := range reTrailer.FindAllIndex(bb, -1) {
+		s := string(bb[m[1]-2 : min(m[1]-2+4096, len(bb))])
+		o, err := model.ParseObject(&s)
+		if err != nil {
+			continue
+		}
+		if d, ok := o.(types.Dict); ok {
+			applyTrailerEntries(ctx, d)
+		}
+	}
+}
+
+// scanForObjects populates the xref table with the last definition of every indirect object found in bb.
+func scanForObjects(ctx *model.Context, bb []byte) int {
+	var z int64
+	g := types.FreeHeadGeneration
+	ctx.Table = map[int]*model.XRefTableEntry{0: {Free: true, Offset: &z, Generation: &g}}
+
+	maxObjNr := 0
+
+	for _, m := range reObjHeader.FindAllSubmatchIndex(bb, -1) {
+		objNr, err := strconv.Atoi(string(bb[m[2]:m[3]]))
+		if err != nil || objNr == 0 {
+			continue
+		}
+		genNr, err := strconv.Atoi(string(bb[m[4]:m[5]]))
+		if err != nil {
+			continue
+		}
+		off := int64(m[2])
+		ctx.Table[objNr] = &model.XRefTableEntry{Offset: &off, Generation: &genNr, Incr: 1}
+		maxObjNr = max(maxObjNr, objNr)
+	}
+
+	return maxObjNr
+}
+
+// classifyObjects identifies the catalog, object streams and xref streams among the objects found.
+func classifyObjects(c context.Context, ctx *model.Context, objNrs []int) (catalog *types.IndirectRef) {
+	for _, objNr := range objNrs {
+		entry := ctx.Table[objNr]
+
+		o, err := ParseObjectWithContext(c, ctx, *entry.Offset, objNr, *entry.Generation)
+		if err != nil || o == nil {
+			continue
+		}
+
+		var d types.Dict
+		switch o := o.(type) {
+		case types.Dict:
+			d = o
+		case types.StreamDict:
+			d = o.Dict
+		default:
+			continue
+		}
+
+		typ := d.Type()
+		if typ == nil {
+			continue
+		}
+
+		switch *typ {
+
+		case "Catalog":
+			catalog = types.NewIndirectRef(objNr, *entry.Generation)
+
+		case "XRef":
+			// Use xref streams as trailers and get rid of them.
+			if ctx.Root == nil {
+				applyTrailerEntries(ctx, d)
+			}
+			entry.Free, entry.Offset = true, nil
+
+		case "ObjStm":
+			ctx.Read.ObjectStreams[objNr] = true
+		}
+	}
+
+	return catalog
+}
+
+// scanXRefTable rebuilds the xref table by scanning the whole file for indirect objects.
+// This is the last resort for files whose xref table is unusable like truncated or mangled files.
+func scanXRefTable(c context.Context, ctx *model.Context, wasErr error) error {
+	if log.ReadEnabled() {
+		log.Read.Printf("scanXRefTable after %v\n", wasErr)
+	}
+
+	rs := ctx.Read.RS
+	if _, err := rs.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	bb, err := io.ReadAll(rs)
+	if err != nil {
+		return err
+	}
+
+	maxObjNr := scanForObjects(ctx, bb)
+	if maxObjNr == 0 {
+		return wasErr
+	}
+
+	ctx.Read.Repaired(model.RepairXRefRebuild, 0, 0, fmt.Sprintf("scanned file for objects after: %v", wasErr))
+	ctx.Read.XRefRebuilt = true
+	ctx.Read.ObjectStreams = types.IntSet{}
+	ctx.Root, ctx.RootDict, ctx.Info, ctx.Encrypt, ctx.ID = nil, nil, nil, nil, nil
+
+	if ctx.HeaderVersion == nil {
+		v := model.V17
+		ctx.HeaderVersion = &v
+	}
+
+	size := maxObjNr + 1
+	ctx.Size = &size
+
+	scanForTrailers(ctx, bb)
+
+	objNrs := make([]int, 0, len(ctx.Table))
+	for objNr := range ctx.Table {
+		if objNr > 0 {
+			objNrs = append(objNrs, objNr)
+		}
+	}
+	sort.Ints(objNrs)
+
+	catalog := classifyObjects(c, ctx, objNrs)
+
+	if ctx.Root != nil {
+		if entry, ok := ctx.Table[ctx.Root.ObjectNumber.Value()]; !ok || entry.Free {
+			ctx.Root = nil
+		}
+	}
+
+	if ctx.Root == nil {
+		if catalog == nil {
+			// Create a catalog, the page tree gets rebuilt after loading all objects.
+			catalog, err = ctx.IndRefForNewObject(types.Dict(map[string]types.Object{"Type": types.Name("Catalog")}))
+			if err != nil {
+				return err
+			}
+		}
+		ctx.Root = catalog
+		ctx.Read.Repaired(model.RepairCatalog, catalog.ObjectNumber.Value(), 0, "catalog")
+	}
+
+	return nil
+}
+
+// registerObjectStreamObjects adds xref table entries for objects of object streams found while scanning.
+func registerObjectStreamObjects(ctx *model.Context) error {
+	objNrs := make([]int, 0, len(ctx.Read.ObjectStreams))
+	for objNr := range ctx.Read.ObjectStreams {
+		objNrs = append(objNrs, objNr)
+	}
+	sort.Ints(objNrs)
+
+	for _, objStmNr := range objNrs {
+		osd, ok := ctx.Table[objStmNr].Object.(types.ObjectStreamDict)
+		if !ok {
+			continue
+		}
+
+		objs, err := objectStreamProlog(&osd)
+		if err != nil {
+			continue
+		}
+
+		for i := 0; i < len(objs)/2; i++ {
+			objNr, err := strconv.Atoi(objs[2*i])
+			if err != nil {
+				break
+			}
+			if entry, ok := ctx.Table[objNr]; ok && !entry.Free {
+				// Objects defined outside of object streams take precedence.
+				continue
+			}
+			objStm, ind, gen := objStmNr, i, 0
+			ctx.Table[objNr] = &model.XRefTableEntry{Compressed: true, ObjectStream: &objStm, ObjectStreamInd: &ind, Generation: &gen}
+			if objNr >= *ctx.Size {
+				*ctx.Size = objNr + 1
+			}
+		}
+	}
+
+	ctx.Read.UsingObjectStreams = len(objNrs) > 0
+
+	return nil
+}
+
+func dangling(ctx *model.Context, o types.Object) bool {
+	indRef, ok := o.(types.IndirectRef)
+	if !ok {
+		return false
+	}
+	entry, found := ctx.Find(indRef.ObjectNumber.Value())
+	return !found || entry.Free || entry.Object == nil
+}
+
+func dropDanglingRefs(ctx *model.Context, o types.Object) int {
+	n := 0
+
+	switch o := o.(type) {
+
+	case types.Dict:
+		for k, v := range o {
+			if dangling(ctx, v) {
+				delete(o, k)
+				n++
+				continue
+			}
+			n += dropDanglingRefs(ctx, v)
+		}
+
+	case types.StreamDict:
+		n = dropDanglingRefs(ctx, o.Dict)
+
+	case types.Array:
+		for i, v := range o {
+			if dangling(ctx, v) {
+				o[i] = nil
+				n++
+				continue
+			}
+			n += dropDanglingRefs(ctx, v)
+		}
+	}
+
+	return n
+}
+
+// removeDanglingReferences removes references to objects lost due to damage.
+func removeDanglingReferences(ctx *model.Context) {
+	n := 0
+	for _, entry := range ctx.Table {
+		if !entry.Free && entry.Object != nil {
+			n += dropDanglingRefs(ctx, entry.Object)
+		}
+	}
+	if n > 0 {
+		ctx.Read.Repaired(model.RepairMissingObject, 0, 0, fmt.Sprintf("removed %d references to missing objects", n))
+	}
+}
+
+func inheritPageAttrs(ctx *model.Context, d types.Dict) {
+	for p, i := d["Parent"], 0; p != nil && i < 32; i++ {
+		pd, err := ctx.DereferenceDict(p)
+		if err != nil || pd == nil {
+			return
+		}
+		for _, k := range []string{"Resources", "MediaBox", "CropBox", "Rotate"} {
+			if _, found := d[k]; !found && pd[k] != nil {
+				d[k] = pd[k]
+			}
+		}
+		p = pd["Parent"]
+	}
+}
+
+// repairPageTree rebuilds an unusable page tree from all page objects.
+func repairPageTree(ctx *model.Context) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if d, err := ctx.DereferenceDict(rootDict["Pages"]); err == nil && d != nil {
+		if typ := d.Type(); typ != nil && *typ == "Pages" && len(pagesDictKidsLenient(ctx, d)) > 0 {
+			return nil
+		}
+	}
+
+	objNrs := []int{}
+	for objNr, entry := range ctx.Table {
+		if d, ok := entry.Object.(types.Dict); ok && !entry.Free {
+			if typ := d.Type(); typ != nil && *typ == "Page" {
+				objNrs = append(objNrs, objNr)
+			}
+		}
+	}
+	if len(objNrs) == 0 {
+		return errors.New("pdfcpu: repairPageTree: no pages found")
+	}
+	sort.Ints(objNrs)
+
+	kids := types.Array{}
+	for _, objNr := range objNrs {
+		kids = append(kids, *types.NewIndirectRef(objNr, *ctx.Table[objNr].Generation))
+	}
+
+	pagesDict := types.Dict(map[string]types.Object{
+		"Type":  types.Name("Pages"),
+		"Count": types.Integer(len(kids)),
+		"Kids":  kids,
+	})
+
+	indRef, err := ctx.IndRefForNewObject(pagesDict)
+	if err != nil {
+		return err
+	}
+	ctx.MaxObjNr = max(ctx.MaxObjNr, indRef.ObjectNumber.Value())
+
+	for _, objNr := range objNrs {
+		d := ctx.Table[objNr].Object.(types.Dict)
+		inheritPageAttrs(ctx, d)
+		d["Parent"] = *indRef
+	}
+
+	rootDict["Pages"] = *indRef
+
+	ctx.Read.Repaired(model.RepairPageTree, indRef.ObjectNumber.Value(), 0, fmt.Sprintf("page tree of %d pages", len(kids)))
+
+	return nil
+}
+
+func pagesDictKidsLenient(ctx *model.Context, d types.Dict) types.Array {
+	a, err := ctx.DereferenceArray(d["Kids"])
+	if err != nil {
+		return nil
+	}
+	return a
+}
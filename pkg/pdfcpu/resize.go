@@ -22,7 +22,6 @@ import (
 	"math"
 	"strings"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
@@ -66,6 +65,15 @@ func ParseResizeConfig(s string, u types.DisplayUnit) (*model.Resize, error) {
 		return nil, errors.New("pdfcpu: resize - please supply either dimensions or form size ")
 	}
 
+	if res.Fit != nil {
+		if res.PageDim == nil || res.PageDim.Width == 0 || res.PageDim.Height == 0 {
+			return nil, errors.New("pdfcpu: resize - fit needs form size or dimensions")
+		}
+		if res.EnforceOrientation() {
+			return nil, errors.New("pdfcpu: resize - fit adapts to the page orientation, please omit enforce")
+		}
+	}
+
 	return res, nil
 }
 
@@ -228,9 +236,7 @@ func resizePage(ctx *model.Context, pageNr int, res *model.Resize) error {
 }
 
 func Resize(ctx *model.Context, selectedPages types.IntSet, res *model.Resize) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("Resize:\n%s\n", res)
-	}
+	ctx.LogDebug("Resize", "resize", res)
 
 	if len(selectedPages) == 0 {
 		selectedPages = types.IntSet{}
@@ -251,3 +257,191 @@ func Resize(ctx *model.Context, selectedPages types.IntSet, res *model.Resize) e
 
 	return nil
 }
+
+// resizeMatrix returns the transformation fitting r into a page of dimensions w x h located at the origin.
+func resizeMatrix(r *types.Rectangle, w, h float64, fit model.ResizeFit) matrix.Matrix {
+	sx, sy := w/r.Width(), h/r.Height()
+
+	switch fit {
+	case model.ResizeFitContain:
+		sx = math.Min(sx, sy)
+		sy = sx
+	case model.ResizeFitCover:
+		sx = math.Max(sx, sy)
+		sy = sx
+	}
+
+	dx := (w-sx*r.Width())/2 - sx*r.LL.X
+	dy := (h-sy*r.Height())/2 - sy*r.LL.Y
+
+	return matrix.CalcTransformMatrix(sx, sy, 0, 1, dx, dy)
+}
+
+func transformRect(r *types.Rectangle, m matrix.Matrix) *types.Rectangle {
+	p1, p2 := m.Transform(r.LL), m.Transform(r.UR)
+	return types.NewRectangle(math.Min(p1.X, p2.X), math.Min(p1.Y, p2.Y), math.Max(p1.X, p2.X), math.Max(p1.Y, p2.Y))
+}
+
+// transformPoints transforms a number array of x,y pairs.
+func transformPoints(ff []float64, m matrix.Matrix) types.Array {
+	for i := 0; i+1 < len(ff); i += 2 {
+		p := m.Transform(types.Point{X: ff[i], Y: ff[i+1]})
+		ff[i], ff[i+1] = p.X, p.Y
+	}
+	return types.NewNumberArray(ff...)
+}
+
+func transformAnnotation(ctx *model.Context, d types.Dict, m matrix.Matrix) error {
+	a, err := ctx.DereferenceArray(d["Rect"])
+	if err != nil {
+		return err
+	}
+	if len(a) == 4 {
+		r, err := ctx.RectForArray(a)
+		if err != nil {
+			return err
+		}
+		// The appearance stream gets mapped onto the transformed rect.
+		d["Rect"] = transformRect(r, m).Array()
+	}
+
+	for _, k := range []string{"QuadPoints", "Vertices", "L", "CL"} {
+		if _, found := d.Find(k); found {
+			d[k] = transformPoints(numberArray(ctx, d[k]), m)
+		}
+	}
+
+	if o, found := d.Find("InkList"); found {
+		a, err := ctx.DereferenceArray(o)
+		if err != nil {
+			return err
+		}
+		a1 := types.Array{}
+		for _, o := range a {
+			a1 = append(a1, transformPoints(numberArray(ctx, o), m))
+		}
+		d["InkList"] = a1
+	}
+
+	return nil
+}
+
+func transformAnnotations(ctx *model.Context, d types.Dict, m matrix.Matrix) error {
+	a, err := ctx.DereferenceArray(d["Annots"])
+	if err != nil || a == nil {
+		return err
+	}
+
+	for _, o := range a {
+		d1, err := ctx.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d1 == nil {
+			continue
+		}
+		if err := transformAnnotation(ctx, d1, m); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func resizePageFit(ctx *model.Context, pageNr int, dim types.Dim, fit model.ResizeFit) error {
+
+	d, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	cropBox := inhPAttrs.MediaBox
+	if inhPAttrs.CropBox != nil {
+		cropBox = inhPAttrs.CropBox
+	}
+
+	// Keep the page orientation.
+	w, h := dim.Width, dim.Height
+	if (cropBox.Landscape() && h > w) || (cropBox.Portrait() && w > h) {
+		w, h = h, w
+	}
+
+	m := resizeMatrix(cropBox, w, h, fit)
+
+	bb, err := ctx.PageContent(d, pageNr)
+	if err != nil && err != model.ErrNoContent {
+		return err
+	}
+
+	if bb != nil {
+		// Clip content outside the former crop box.
+		var buf bytes.Buffer
+		fmt.Fprintf(&buf, "q %.5f %.5f %.5f %.5f %.5f %.5f cm %.2f %.2f %.2f %.2f re W n ",
+			m[0][0], m[0][1], m[1][0], m[1][1], m[2][0], m[2][1],
+			cropBox.LL.X, cropBox.LL.Y, cropBox.Width(), cropBox.Height())
+		buf.Write(bb)
+		buf.WriteString(" Q")
+
+		sd, _ := ctx.NewStreamDictForBuf(buf.Bytes())
+		if err := sd.Encode(); err != nil {
+			return err
+		}
+
+		ir, err := ctx.IndRefForNewObject(*sd)
+		if err != nil {
+			return err
+		}
+
+		d["Contents"] = *ir
+	}
+
+	mediaBox := types.RectForDim(w, h)
+
+	for _, k := range []string{"TrimBox", "BleedBox", "ArtBox"} {
+		a, err := ctx.DereferenceArray(d[k])
+		if err != nil {
+			return err
+		}
+		if len(a) != 4 {
+			continue
+		}
+		r, err := ctx.RectForArray(a)
+		if err != nil {
+			return err
+		}
+		r = transformRect(r, m)
+		r1 := intersection(*r, *mediaBox)
+		if area(r1) == 0 {
+			d.Delete(k)
+			continue
+		}
+		d[k] = r1.Array()
+	}
+
+	d.Update("MediaBox", mediaBox.Array())
+	d.Delete("CropBox")
+
+	return transformAnnotations(ctx, d, m)
+}
+
+// ResizePages scales selected pages to dim including page content, page boundaries and annotations.
+// The fit mode decides how page content gets fitted into dim.
+// The orientation of dim adapts to the orientation of each page.
+func ResizePages(ctx *model.Context, selectedPages types.IntSet, dim types.Dim, fit model.ResizeFit) error {
+	if dim.Width <= 0 || dim.Height <= 0 {
+		return errors.Errorf("pdfcpu: ResizePages: invalid page dimensions: %v", dim)
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+		if err := resizePageFit(ctx, pageNr, dim, fit); err != nil {
+			return errors.Wrapf(err, "page %d", pageNr)
+		}
+	}
+
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
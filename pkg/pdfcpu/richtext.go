@@ -0,0 +1,392 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+// Rich text stamps support inline style markup:
+//
+//	<b>bold</b>
+//	<i>italic</i>
+//	<color=#FF0000>red</color>     any color accepted by the color parameter
+//	<size=12>12 points</size>      font size in points, subject to scaling
+//
+// Tags may be nested. Any other text enclosed in angle brackets is rendered literally.
+
+// coreFontFamilies lists the regular, bold, italic and bold italic styles of the core font families.
+var coreFontFamilies = [][4]string{
+	{"Helvetica", "Helvetica-Bold", "Helvetica-Oblique", "Helvetica-BoldOblique"},
+	{"Times-Roman", "Times-Bold", "Times-Italic", "Times-BoldItalic"},
+	{"Courier", "Courier-Bold", "Courier-Oblique", "Courier-BoldOblique"},
+}
+
+// styledFontName returns the member of fontName's family matching fontName's style combined with bold and italic.
+func styledFontName(fontName string, bold, italic bool) string {
+	for _, fam := range coreFontFamilies {
+		for style, fn := range fam {
+			if fn != fontName {
+				continue
+			}
+			if bold {
+				style |= 1
+			}
+			if italic {
+				style |= 2
+			}
+			return fam[style]
+		}
+	}
+	// Symbol and ZapfDingbats come without styles.
+	return fontName
+}
+
+type textRun struct {
+	s        string  // text in single byte encoding.
+	fontName string  // core font.
+	fontSize float64 // font size before scaling.
+	col      color.SimpleColor
+}
+
+// width returns the width of r rendered using fontSize.
+func (r textRun) width(fontSize float64) float64 {
+	return font.TextWidth(r.s, r.fontName, 1000) * fontSize / 1000
+}
+
+type textLine []textRun
+
+type richTextState struct {
+	bold, italic int
+	cols         []color.SimpleColor
+	sizes        []float64
+}
+
+func (st *richTextState) run(s string, wm model.Watermark) textRun {
+	r := textRun{
+		s:        s,
+		fontName: styledFontName(wm.FontName, st.bold > 0, st.italic > 0),
+		fontSize: float64(wm.FontSize),
+		col:      wm.FillColor,
+	}
+	if len(st.cols) > 0 {
+		r.col = st.cols[len(st.cols)-1]
+	}
+	if len(st.sizes) > 0 {
+		r.fontSize = st.sizes[len(st.sizes)-1]
+	}
+	return r
+}
+
+// applyTag updates st for tag and returns false if tag is not a style tag leaving st untouched.
+func (st *richTextState) applyTag(tag string) (bool, error) {
+	name, val, hasVal := strings.Cut(tag, "=")
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if strings.HasPrefix(name, "/") {
+		if hasVal {
+			return false, nil
+		}
+		var n int
+		switch name[1:] {
+		case "b":
+			n = st.bold
+			st.bold--
+		case "i":
+			n = st.italic
+			st.italic--
+		case "color":
+			n = len(st.cols)
+			if n > 0 {
+				st.cols = st.cols[:n-1]
+			}
+		case "size":
+			n = len(st.sizes)
+			if n > 0 {
+				st.sizes = st.sizes[:n-1]
+			}
+		default:
+			return false, nil
+		}
+		if n == 0 {
+			return false, errors.Errorf("pdfcpu: rich text: unbalanced <%s>", tag)
+		}
+		return true, nil
+	}
+
+	switch name {
+	case "b", "i":
+		if hasVal {
+			return false, nil
+		}
+		if name == "b" {
+			st.bold++
+		} else {
+			st.italic++
+		}
+	case "color":
+		c, err := color.ParseColor(strings.TrimSpace(val))
+		if err != nil {
+			return false, errors.Wrapf(err, "pdfcpu: rich text: <%s>", tag)
+		}
+		st.cols = append(st.cols, c)
+	case "size":
+		fs, err := strconv.ParseFloat(strings.TrimSpace(val), 64)
+		if err != nil || fs <= 0 {
+			return false, errors.Errorf("pdfcpu: rich text: <%s>: font size must be a number > 0", tag)
+		}
+		st.sizes = append(st.sizes, fs)
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+// encodeCoreFontText maps s to the single byte encoding used for core fonts.
+func encodeCoreFontText(s string) string {
+	bb := []byte{}
+	for _, r := range s {
+		b := byte(0x20)
+		if r <= 0xff {
+			b = byte(r)
+		}
+		bb = append(bb, b)
+	}
+	return string(bb)
+}
+
+// parseRichText splits s into lines of style runs.
+func parseRichText(s string, wm model.Watermark) ([]textLine, error) {
+	s = strings.ReplaceAll(s, "\\n", "\n")
+
+	var (
+		st    richTextState
+		lines []textLine
+		line  textLine
+		sb    strings.Builder
+	)
+
+	flush := func() {
+		if sb.Len() > 0 {
+			line = append(line, st.run(encodeCoreFontText(sb.String()), wm))
+			sb.Reset()
+		}
+	}
+
+	for len(s) > 0 {
+		i := strings.IndexAny(s, "<\n")
+		if i < 0 {
+			sb.WriteString(s)
+			break
+		}
+		sb.WriteString(s[:i])
+
+		if s[i] == '\n' {
+			flush()
+			lines = append(lines, line)
+			line = nil
+			s = s[i+1:]
+			continue
+		}
+
+		j := strings.IndexByte(s[i:], '>')
+		if j < 0 {
+			sb.WriteString(s[i:])
+			break
+		}
+
+		flush()
+		ok, err := st.applyTag(s[i+1 : i+j])
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// Not a style tag, render literally.
+			sb.WriteString(s[i : i+j+1])
+		}
+		s = s[i+j+1:]
+	}
+
+	flush()
+	lines = append(lines, line)
+
+	if st.bold > 0 || st.italic > 0 || len(st.cols) > 0 || len(st.sizes) > 0 {
+		return nil, errors.New("pdfcpu: rich text: missing closing tag")
+	}
+
+	return lines, nil
+}
+
+// hasRichTextMarkup returns true if s contains inline style markup.
+func hasRichTextMarkup(s string) bool {
+	s = strings.ToLower(s)
+	for _, tag := range []string{"<b>", "</b>", "<i>", "</i>", "<color=", "</color>", "<size=", "</size>"} {
+		if strings.Contains(s, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// richTextFonts returns the fonts used by the style runs of lines.
+func richTextFonts(lines []textLine) []string {
+	ss := []string{}
+	for _, l := range lines {
+		for _, r := range l {
+			if !types.MemberOf(r.fontName, ss) {
+				ss = append(ss, r.fontName)
+			}
+		}
+	}
+	return ss
+}
+
+func richTextFontID(fontName string, wm model.Watermark) string {
+	if fontName == wm.FontName {
+		return "F1"
+	}
+	return fontName
+}
+
+// fontMetric scales a font metric for fontName to a fractional font size.
+func fontMetric(f func(string, int) float64, fontName string, fontSize float64) float64 {
+	return f(fontName, 1000) * fontSize / 1000
+}
+
+type richLineMetrics struct {
+	width, ascent, descent, height float64
+}
+
+func measureRichTextLine(l textLine, wm model.Watermark, scale float64) richLineMetrics {
+	if len(l) == 0 {
+		// Empty lines take the height of the base font.
+		l = textLine{{fontName: wm.FontName, fontSize: float64(wm.FontSize)}}
+	}
+	var m richLineMetrics
+	for _, r := range l {
+		fs := r.fontSize * scale
+		m.width += r.width(fs)
+		m.ascent = math.Max(m.ascent, fontMetric(font.Ascent, r.fontName, fs))
+		m.descent = math.Max(m.descent, fontMetric(font.Descent, r.fontName, fs))
+		m.height = math.Max(m.height, fontMetric(font.LineHeight, r.fontName, fs))
+	}
+	return m
+}
+
+func richTextWidth(lines []textLine, wm model.Watermark, scale float64) float64 {
+	w := 0.
+	for _, l := range lines {
+		w = math.Max(w, measureRichTextLine(l, wm, scale).width)
+	}
+	return w
+}
+
+func richTextScale(lines []textLine, wm model.Watermark) float64 {
+	if wm.ScaleAbs {
+		return wm.Scale
+	}
+	w := richTextWidth(lines, wm, 1) + wm.MLeft + wm.MRight + 2*wm.BorderWidth
+	if w == 0 {
+		return 1
+	}
+	return wm.Vp.Width() * wm.Scale / w
+}
+
+// richTextFormContent renders lines into w and returns the form bounding box.
+func richTextFormContent(w io.Writer, lines []textLine, wm model.Watermark) *types.Rectangle {
+	leading := wm.Leading
+	if leading <= 0 {
+		leading = 1
+	}
+
+	hAlign := types.AlignLeft
+	if wm.HAlign != nil {
+		hAlign = *wm.HAlign
+	} else {
+		_, _, hAlign, _ = model.AnchorPosAndAlign(wm.Pos, types.RectForDim(0, 0))
+	}
+
+	sc := richTextScale(lines, wm)
+	bw := wm.BorderWidth
+
+	mm := make([]richLineMetrics, len(lines))
+	maxWidth := 0.
+	for i, l := range lines {
+		mm[i] = measureRichTextLine(l, wm, sc)
+		maxWidth = math.Max(maxWidth, mm[i].width)
+	}
+
+	// Baselines relative to the top of the text area.
+	bl := make([]float64, len(lines))
+	for i := range lines {
+		if i == 0 {
+			bl[i] = -mm[i].ascent
+			continue
+		}
+		bl[i] = bl[i-1] - leading*mm[i].height
+	}
+	textHeight := -bl[len(lines)-1] + mm[len(lines)-1].descent
+
+	bb := types.RectForDim(maxWidth+wm.MLeft+wm.MRight+2*bw, textHeight+wm.MTop+wm.MBot+2*bw)
+
+	fmt.Fprint(w, "q ")
+
+	if wm.BgColor != nil {
+		r := types.RectForWidthAndHeight(bw/2, bw/2, bb.Width()-bw, bb.Height()-bw)
+		c := *wm.BgColor
+		if wm.BorderColor != nil {
+			c = *wm.BorderColor
+		}
+		draw.FillRect(w, r, bw, &c, *wm.BgColor, &wm.BorderStyle)
+	}
+
+	top := bb.Height() - wm.MTop - bw
+	for i, l := range lines {
+		x := wm.MLeft + bw
+		switch hAlign {
+		case types.AlignCenter:
+			x += (maxWidth - mm[i].width) / 2
+		case types.AlignRight:
+			x += maxWidth - mm[i].width
+		}
+		y := top + bl[i]
+		for _, r := range l {
+			fs := r.fontSize * sc
+			s, _ := types.Escape(r.s)
+			fmt.Fprintf(w, "BT /%s %.2f Tf %.2f %.2f %.2f RG %.2f %.2f %.2f rg %.2f %.2f Td %d Tr (%s) Tj ET ",
+				richTextFontID(r.fontName, wm), fs,
+				wm.StrokeColor.R, wm.StrokeColor.G, wm.StrokeColor.B, r.col.R, r.col.G, r.col.B,
+				x, y, wm.RenderMode, *s)
+			x += r.width(fs)
+		}
+	}
+
+	fmt.Fprint(w, "Q ")
+
+	return bb
+}
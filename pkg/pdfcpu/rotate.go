@@ -23,9 +23,7 @@ import (
 )
 
 func rotatePage(xRefTable *model.XRefTable, i, j int) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("rotate page:%d\n", i)
-	}
+	xRefTable.LogDebug("rotatePage", log.KeyPage, i, "rotation", j)
 
 	consolidateRes := false
 	d, _, inhPAttrs, err := xRefTable.PageDict(i, consolidateRes)
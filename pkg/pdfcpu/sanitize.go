@@ -0,0 +1,304 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// reviewAnnotations are the annotation types used for reviewing documents (12.5.6.2).
+var reviewAnnotations = []string{
+	"Text", "FreeText", "Line", "Square", "Circle", "Polygon", "PolyLine",
+	"Highlight", "Underline", "Squiggly", "StrikeOut", "Stamp", "Caret", "Ink",
+	"Popup", "FileAttachment", "Sound",
+}
+
+type sanitizer struct {
+	ctx *model.Context
+	r   *model.SanitizeReport
+}
+
+func actionType(ctx *model.Context, o types.Object) string {
+	d, err := ctx.DereferenceDict(o)
+	if err != nil || d == nil {
+		return ""
+	}
+	if s := d.NameEntry("S"); s != nil {
+		return *s
+	}
+	return ""
+}
+
+// risky returns true for JavaScript and launch actions and records them.
+func (s *sanitizer) risky(o types.Object) bool {
+	switch actionType(s.ctx, o) {
+	case "JavaScript":
+		s.r.JavaScript++
+	case "Launch":
+		s.r.LaunchActions++
+	default:
+		return false
+	}
+	return true
+}
+
+func (s *sanitizer) sanitizeNext(d types.Dict) {
+	o, found := d.Find("Next")
+	if !found {
+		return
+	}
+
+	o1, err := s.ctx.Dereference(o)
+	if err != nil {
+		return
+	}
+
+	a, ok := o1.(types.Array)
+	if !ok {
+		if s.risky(o) {
+			d.Delete("Next")
+		}
+		return
+	}
+
+	a1 := types.Array{}
+	for _, o := range a {
+		if !s.risky(o) {
+			a1 = append(a1, o)
+		}
+	}
+
+	if len(a1) == len(a) {
+		return
+	}
+
+	if len(a1) == 0 {
+		d.Delete("Next")
+		return
+	}
+
+	d.Update("Next", a1)
+}
+
+func (s *sanitizer) sanitizeActions(d types.Dict) {
+	for _, k := range []string{"A", "OpenAction"} {
+		if o, found := d.Find(k); found && s.risky(o) {
+			d.Delete(k)
+		}
+	}
+
+	if o, found := d.Find("AA"); found {
+		if aa, err := s.ctx.DereferenceDict(o); err == nil && aa != nil {
+			for k, v := range aa {
+				if s.risky(v) {
+					aa.Delete(k)
+				}
+			}
+			if aa.Len() == 0 {
+				d.Delete("AA")
+			}
+		}
+	}
+
+	s.sanitizeNext(d)
+
+	// Rendition actions may come with JavaScript.
+	if _, found := d.Find("JS"); found && actionType(s.ctx, d) == "Rendition" {
+		d.Delete("JS")
+		s.r.JavaScript++
+	}
+}
+
+func (s *sanitizer) sanitizeDict(d types.Dict) {
+	if t := actionType(s.ctx, d); t == "JavaScript" || t == "Launch" {
+		// Gets dropped along with the reference to it.
+		return
+	}
+
+	s.sanitizeActions(d)
+
+	// Associated files
+	if o, found := d.Find("AF"); found {
+		if a, err := s.ctx.DereferenceArray(o); err == nil {
+			s.r.EmbeddedFiles += len(a)
+		}
+		d.Delete("AF")
+	}
+
+	if o, found := d.Find("Metadata"); found {
+		if sd, _, err := s.ctx.DereferenceStreamDict(o); err == nil && sd != nil {
+			d.Delete("Metadata")
+			s.r.XMP++
+		}
+	}
+
+	for _, o := range d {
+		s.sanitizeObject(o)
+	}
+}
+
+func (s *sanitizer) sanitizeObject(o types.Object) {
+	switch o := o.(type) {
+
+	case types.Dict:
+		s.sanitizeDict(o)
+
+	case types.StreamDict:
+		s.sanitizeDict(o.Dict)
+
+	case types.Array:
+		for _, o1 := range o {
+			s.sanitizeObject(o1)
+		}
+	}
+}
+
+func (s *sanitizer) sanitizeObjects() {
+	for _, entry := range s.ctx.Table {
+		if entry == nil || entry.Free || entry.Object == nil {
+			continue
+		}
+		s.sanitizeObject(entry.Object)
+	}
+}
+
+func (s *sanitizer) removeAnnotations() error {
+	for _, pgAnnots := range s.ctx.PageAnnots {
+		for _, k := range reviewAnnotations {
+			if annots, ok := pgAnnots[model.AnnotTypes[k]]; ok {
+				s.r.Annotations += len(annots.Map)
+			}
+		}
+	}
+
+	if s.r.Annotations == 0 {
+		return nil
+	}
+
+	_, err := RemoveAnnotations(s.ctx, nil, reviewAnnotations, nil, false)
+	return err
+}
+
+func (s *sanitizer) removeHiddenLayers() error {
+	ll, err := Layers(s.ctx)
+	if err != nil {
+		return err
+	}
+
+	off := types.IntSet{}
+	for _, l := range ll {
+		if !l.Visible {
+			off[l.ObjNr] = true
+			s.r.HiddenLayers = append(s.r.HiddenLayers, l.Name)
+		}
+	}
+
+	if len(off) == 0 {
+		return nil
+	}
+
+	return filterLayers(s.ctx, off, false)
+}
+
+// nameTreeSize returns the number of entries of the name tree nameTreeName.
+func (s *sanitizer) nameTreeSize(nameTreeName string) (int, error) {
+	if err := s.ctx.LocateNameTree(nameTreeName, false); err != nil {
+		return 0, err
+	}
+
+	n := s.ctx.Names[nameTreeName]
+	if n == nil {
+		return 0, nil
+	}
+
+	kk, err := n.KeyList()
+	if err != nil {
+		return 0, err
+	}
+
+	return len(kk), nil
+}
+
+func (s *sanitizer) removeNameTrees() error {
+	i, err := s.nameTreeSize("JavaScript")
+	if err != nil {
+		return err
+	}
+
+	if i > 0 {
+		s.r.JavaScript += i
+		delete(s.ctx.Names, "JavaScript")
+		if err := s.ctx.RemoveNameTree("JavaScript"); err != nil {
+			return err
+		}
+	}
+
+	if i, err = s.nameTreeSize("EmbeddedFiles"); err != nil {
+		return err
+	}
+
+	if i > 0 {
+		s.r.EmbeddedFiles += i
+		if _, err := s.ctx.RemoveAttachments(nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sanitizer) removeRevisions() {
+	i := s.ctx.Read.Revisions - 1
+	if s.ctx.Read.Linearized {
+		// The first page xref section.
+		i--
+	}
+	if i > 0 {
+		s.r.Revisions = i
+	}
+}
+
+// Sanitize removes JavaScript and launch actions, embedded files, hidden layers,
+// metadata and review annotations from ctx and returns a report of what got removed.
+// Writing ctx drops any incremental updates.
+func Sanitize(ctx *model.Context) (*model.SanitizeReport, error) {
+	s := &sanitizer{ctx: ctx, r: &model.SanitizeReport{}}
+
+	if err := s.removeAnnotations(); err != nil {
+		return nil, err
+	}
+
+	if err := s.removeHiddenLayers(); err != nil {
+		return nil, err
+	}
+
+	if err := s.removeNameTrees(); err != nil {
+		return nil, err
+	}
+
+	s.sanitizeObjects()
+
+	if ctx.Info != nil {
+		ctx.Info = nil
+		s.r.Info = true
+	}
+
+	s.removeRevisions()
+
+	return s.r, nil
+}
@@ -20,6 +20,7 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -30,17 +31,23 @@ import (
 	"github.com/pdfcpu/pdfcpu/pkg/filter"
 	"github.com/pdfcpu/pdfcpu/pkg/font"
 	"github.com/pdfcpu/pdfcpu/pkg/log"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/barcode"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/format"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/qrcode"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/svg"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
 	"github.com/pkg/errors"
 )
 
-const stampWithBBox = false
+const (
+	stampWithBBox  = false
+	barHeightRatio = 0.3 // Default barcode height relative to its width.
+)
 
 var (
 	errNoWatermark        = errors.New("pdfcpu: no watermarks found")
@@ -100,8 +107,11 @@ var wmParamMap = watermarkParamMap{
 	"border":          parseBorder,
 	"color":           parseFillColor,
 	"diagonal":        parseDiagonal,
+	"errorcorrection": parseQRCodeLevel,
 	"fillcolor":       parseFillColor,
 	"fontname":        parseFontName,
+	"height":          parseBarHeight,
+	"leading":         parseLeading,
 	"scriptname":      parseScriptName,
 	"margins":         parseMargins,
 	"mode":            parseRenderMode,
@@ -113,8 +123,11 @@ var wmParamMap = watermarkParamMap{
 	"rtl":             parseRightToLeft,
 	"rotation":        parseRotation,
 	"scalefactor":     parseScaleFactorWM,
+	"size":            parseSize,
 	"strokecolor":     parseStrokeColor,
+	"symbology":       parseSymbology,
 	"url":             parseURL,
+	"vertical":        parseVertical,
 }
 
 func parseTextHorAlignment(s string, wm *model.Watermark) error {
@@ -175,6 +188,46 @@ func parseScaleFactorWM(s string, wm *model.Watermark) (err error) {
 	return err
 }
 
+func parseSize(s string, wm *model.Watermark) error {
+	if !wm.IsQRCode() && !wm.IsBarcode() {
+		return errors.New("pdfcpu: \"size\" supported for qrcodes and barcodes only")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return errors.Errorf("pdfcpu: invalid size: %s, must be > 0", s)
+	}
+	wm.Size = types.ToUserSpace(f, wm.InpUnit)
+	return nil
+}
+
+func parseBarHeight(s string, wm *model.Watermark) error {
+	if !wm.IsBarcode() {
+		return errors.New("pdfcpu: \"height\" supported for barcodes only")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return errors.Errorf("pdfcpu: invalid height: %s, must be > 0", s)
+	}
+	wm.BarHeight = types.ToUserSpace(f, wm.InpUnit)
+	return nil
+}
+
+func parseSymbology(s string, wm *model.Watermark) (err error) {
+	if !wm.IsBarcode() {
+		return errors.New("pdfcpu: \"symbology\" supported for barcodes only")
+	}
+	wm.Symbology, err = barcode.ParseSymbology(s)
+	return err
+}
+
+func parseQRCodeLevel(s string, wm *model.Watermark) (err error) {
+	if !wm.IsQRCode() {
+		return errors.New("pdfcpu: \"errorcorrection\" supported for qrcodes only")
+	}
+	wm.QRLevel, err = qrcode.ParseLevel(s)
+	return err
+}
+
 func parseFontName(s string, wm *model.Watermark) error {
 	if !font.SupportedFont(s) {
 		return errors.Errorf("pdfcpu: %s is unsupported, please refer to \"pdfcpu fonts list\".\n", s)
@@ -212,6 +265,20 @@ func parseURL(s string, wm *model.Watermark) error {
 	return nil
 }
 
+func parseLeading(s string, wm *model.Watermark) error {
+	if !wm.IsText() {
+		return errors.New("pdfcpu: \"leading\" supported for text only")
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return errors.Errorf("pdfcpu: leading must be a float value > 0: %s\n", s)
+	}
+
+	wm.Leading = f
+
+	return nil
+}
+
 func parseFontSize(s string, wm *model.Watermark) error {
 	fs, err := strconv.Atoi(s)
 	if err != nil {
@@ -264,6 +331,27 @@ func parseScaleFactor(s string) (float64, bool, error) {
 	return sc, scaleAbs, nil
 }
 
+func parseVertical(s string, wm *model.Watermark) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		wm.Vertical = true
+	case "off", "false", "f":
+		wm.Vertical = false
+	default:
+		return errors.New("pdfcpu: vertical (top-to-bottom), please provide one of: on/off true/false t/f")
+	}
+
+	return nil
+}
+
+// fontNameForWM returns the name of the font resource wm's text is rendered with.
+func fontNameForWM(wm model.Watermark) string {
+	if wm.Vertical {
+		return font.VerticalFontName(wm.FontName)
+	}
+	return wm.FontName
+}
+
 func parseRightToLeft(s string, wm *model.Watermark) error {
 	switch strings.ToLower(s) {
 	case "on", "true", "t":
@@ -474,26 +562,45 @@ func parseWatermarkDetails(mode int, modeParm, s string, onTop bool, u types.Dis
 	wm.OnTop = onTop
 	wm.InpUnit = u
 
+	if mode == model.WMQRCode || mode == model.WMBarcode {
+		wm.Mode = mode
+		wm.FillColor = color.Black
+		wm.Diagonal = model.NoDiagonal
+	}
+
+	if mode == model.WMQRCode {
+		wm.QRLevel = qrcode.Medium
+	}
+
+	if err := applyWatermarkDetails(mode, modeParm, s, wm); err != nil {
+		return nil, err
+	}
+
+	return wm, nil
+}
+
+// applyWatermarkDetails parses the description s into wm, overriding the defaults already set.
+func applyWatermarkDetails(mode int, modeParm, s string, wm *model.Watermark) error {
 	ss := strings.Split(s, ",")
 	if len(ss) > 0 && len(ss[0]) == 0 {
-		return wm, setWatermarkType(mode, modeParm, wm)
+		return setWatermarkType(mode, modeParm, wm)
 	}
 
 	for _, s := range ss {
 		ss1 := strings.Split(s, ":")
 		if len(ss1) != 2 {
-			return nil, parseWatermarkError(onTop)
+			return parseWatermarkError(wm.OnTop)
 		}
 
 		paramPrefix := strings.TrimSpace(ss1[0])
 		paramValueStr := strings.TrimSpace(ss1[1])
 
 		if err := wmParamMap.Handle(paramPrefix, paramValueStr, wm); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return wm, setWatermarkType(mode, modeParm, wm)
+	return setWatermarkType(mode, modeParm, wm)
 }
 
 // ParseTextWatermarkDetails parses a text Watermark/Stamp command string into an internal structure.
@@ -511,6 +618,21 @@ func ParsePDFWatermarkDetails(fileName, desc string, onTop bool, u types.Display
 	return parseWatermarkDetails(model.WMPDF, fileName, desc, onTop, u)
 }
 
+// ParseSVGWatermarkDetails parses an SVG Watermark/Stamp command string into an internal structure.
+func ParseSVGWatermarkDetails(fileName, desc string, onTop bool, u types.DisplayUnit) (*model.Watermark, error) {
+	return parseWatermarkDetails(model.WMSVG, fileName, desc, onTop, u)
+}
+
+// ParseQRCodeWatermarkDetails parses a QR code Watermark/Stamp command string into an internal structure.
+func ParseQRCodeWatermarkDetails(text, desc string, onTop bool, u types.DisplayUnit) (*model.Watermark, error) {
+	return parseWatermarkDetails(model.WMQRCode, text, desc, onTop, u)
+}
+
+// ParseBarcodeWatermarkDetails parses a barcode Watermark/Stamp command string into an internal structure.
+func ParseBarcodeWatermarkDetails(text, desc string, onTop bool, u types.DisplayUnit) (*model.Watermark, error) {
+	return parseWatermarkDetails(model.WMBarcode, text, desc, onTop, u)
+}
+
 func onTopString(onTop bool) string {
 	e := "watermark"
 	if onTop {
@@ -524,8 +646,35 @@ func parseWatermarkError(onTop bool) error {
 	return errors.Errorf("Invalid %s configuration string. Please consult pdfcpu help %s.\n", s, s)
 }
 
-func setTextWatermark(s string, wm *model.Watermark) {
+func setRichTextWatermark(s string, wm *model.Watermark) error {
+	if !font.IsCoreFont(wm.FontName) {
+		return errors.Errorf("pdfcpu: rich text and leading need a core font: %s", wm.FontName)
+	}
+	// Style tags do not depend on the page, so the fonts in use are known upfront.
+	lines, err := parseRichText(s, *wm)
+	if err != nil {
+		return err
+	}
+	wm.RichText = true
+	wm.RichFonts = map[string]*types.IndirectRef{}
+	for _, fontName := range richTextFonts(lines) {
+		if fontName != wm.FontName {
+			wm.RichFonts[fontName] = nil
+		}
+	}
+	return nil
+}
+
+func setTextWatermark(s string, wm *model.Watermark) error {
 	wm.TextString = s
+	if wm.Vertical && !font.IsUserFont(wm.FontName) {
+		return errors.Errorf("pdfcpu: vertical writing needs a user font: %s", wm.FontName)
+	}
+	if hasRichTextMarkup(s) || wm.Leading > 0 {
+		if err := setRichTextWatermark(s, wm); err != nil {
+			return err
+		}
+	}
 	if font.IsCoreFont(wm.FontName) {
 		bb := []byte{}
 		for _, r := range s {
@@ -548,6 +697,7 @@ func setTextWatermark(s string, wm *model.Watermark) {
 	}
 	s = strings.ReplaceAll(s, "\\n", "\n")
 	wm.TextLines = append(wm.TextLines, strings.FieldsFunc(s, func(c rune) bool { return c == 0x0a })...)
+	return nil
 }
 
 func setImageWatermark(s string, wm *model.Watermark) error {
@@ -645,17 +795,82 @@ func setPDFWatermark(s string, wm *model.Watermark) error {
 	return nil
 }
 
+func setQRCodeWatermark(s string, wm *model.Watermark) error {
+	if len(s) == 0 {
+		return errors.New("pdfcpu: missing qrcode payload")
+	}
+	// Verify the payload fits into a QR code.
+	if _, err := qrcode.Encode(s, wm.QRLevel); err != nil {
+		return err
+	}
+	wm.TextString = s
+	return nil
+}
+
+func setBarcodeWatermark(s string, wm *model.Watermark) error {
+	if len(s) == 0 {
+		return errors.New("pdfcpu: missing barcode payload")
+	}
+	// Verify the payload unless it depends on the page.
+	if t, unique := format.Text(s, "", 1, 1); !unique {
+		if _, err := barcode.Encode(t, wm.Symbology); err != nil {
+			return err
+		}
+	}
+	wm.TextString = s
+	return nil
+}
+
+func setSVGWatermark(s string, wm *model.Watermark) error {
+	if len(s) == 0 {
+		// The caller is expected to provide: wm.SVG (see api.SVGWatermarkForReader)
+		return nil
+	}
+	if strings.ToLower(filepath.Ext(s)) != ".svg" {
+		return errors.New("pdfcpu: svgFileName has to have the extension .svg")
+	}
+	wm.FileName = s
+	f, err := os.Open(wm.FileName)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	return SetSVG(f, wm)
+}
+
+// SetSVG converts the SVG read from r into the content of wm.
+func SetSVG(r io.Reader, wm *model.Watermark) error {
+	s, err := svg.Parse(r)
+	if err != nil {
+		return err
+	}
+	wm.SVG = s
+	wm.Width = int(math.Round(s.Width))
+	wm.Height = int(math.Round(s.Height))
+	return nil
+}
+
 func setWatermarkType(mode int, s string, wm *model.Watermark) (err error) {
 	wm.Mode = mode
 	switch wm.Mode {
 	case model.WMText:
-		setTextWatermark(s, wm)
+		err = setTextWatermark(s, wm)
 
 	case model.WMImage:
 		err = setImageWatermark(s, wm)
 
 	case model.WMPDF:
 		err = setPDFWatermark(s, wm)
+
+	case model.WMQRCode:
+		err = setQRCodeWatermark(s, wm)
+
+	case model.WMBarcode:
+		err = setBarcodeWatermark(s, wm)
+
+	case model.WMSVG:
+		err = setSVGWatermark(s, wm)
 	}
 	return err
 }
@@ -750,14 +965,39 @@ func createImageResForWM(ctx *model.Context, wm *model.Watermark) (err error) {
 	return err
 }
 
+// setupFallbackFontsForWM registers the configured fallback fonts applicable for wm as additional font resources.
+func setupFallbackFontsForWM(ctx *model.Context, wm *model.Watermark) {
+	if ctx.Conf == nil || wm.Vertical || !font.IsUserFont(wm.FontName) {
+		return
+	}
+	wm.FallbackFonts = font.UsedFallbacks(wm.TextString, wm.FontName, ctx.Conf.FallbackFonts)
+	for _, fontName := range wm.FallbackFonts {
+		if wm.RichFonts == nil {
+			wm.RichFonts = map[string]*types.IndirectRef{}
+		}
+		if _, ok := wm.RichFonts[fontName]; !ok {
+			wm.RichFonts[fontName] = nil
+		}
+	}
+}
+
 func createFontResForWM(ctx *model.Context, wm *model.Watermark) (err error) {
 	// TODO Reuse font dict.
+	setupFallbackFontsForWM(ctx, wm)
 	if font.IsUserFont(wm.FontName) {
 		td, _ := setupTextDescriptor(*wm, "", 123456789, 0)
 		model.WriteMultiLine(ctx.XRefTable, new(bytes.Buffer), types.RectForFormat("A4"), nil, td)
 	}
-	wm.Font, err = pdffont.EnsureFontDict(ctx.XRefTable, wm.FontName, "", wm.ScriptName, false, nil)
-	return err
+	wm.Font, err = pdffont.EnsureFontDict(ctx.XRefTable, fontNameForWM(*wm), "", wm.ScriptName, false, nil)
+	if err != nil {
+		return err
+	}
+	for fontName := range wm.RichFonts {
+		if wm.RichFonts[fontName], err = pdffont.EnsureFontDict(ctx.XRefTable, fontName, "", "", false, nil); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func createResourcesForWM(ctx *model.Context, wm *model.Watermark) error {
@@ -767,6 +1007,10 @@ func createResourcesForWM(ctx *model.Context, wm *model.Watermark) error {
 	if wm.IsImage() {
 		return createImageResForWM(ctx, wm)
 	}
+	if wm.IsQRCode() || wm.IsBarcode() || wm.IsSVG() {
+		// QR codes, barcodes and SVG are rendered as vector content without resources.
+		return nil
+	}
 	return createFontResForWM(ctx, wm)
 }
 
@@ -888,9 +1132,18 @@ func createFormResDict(ctx *model.Context, pageNr int, wm *model.Watermark) (*ty
 		return ctx.IndRefForNewObject(d)
 	}
 
+	if wm.IsQRCode() || wm.IsBarcode() || wm.IsSVG() {
+		return nil, nil
+	}
+
+	fontDict := types.Dict(map[string]types.Object{"F1": *wm.Font})
+	for fontName, ir := range wm.RichFonts {
+		fontDict.Insert(richTextFontID(fontName, *wm), *ir)
+	}
+
 	d := types.Dict(
 		map[string]types.Object{
-			"Font":    types.Dict(map[string]types.Object{"F1": *wm.Font}),
+			"Font":    fontDict,
 			"ProcSet": types.NewNameArray("PDF", "Text", "ImageB", "ImageC", "ImageI"),
 		},
 	)
@@ -933,12 +1186,88 @@ func imageFormContent(w io.Writer, wm model.Watermark) {
 	fmt.Fprintf(w, "q %f 0 0 %f 0 0 cm /Im0 Do Q", wm.Bb.Width(), wm.Bb.Height()) // TODO dont need Q
 }
 
+// qrCodeFormContent renders the modules of wm.QRCode including the quiet zone into wm.Bb.
+func qrCodeFormContent(w io.Writer, wm model.Watermark) {
+	qr := wm.QRCode
+	n := qr.Size + 2*qrcode.QuietZone
+	m := wm.Bb.Width() / float64(n)
+
+	bgCol := color.White
+	if wm.BgColor != nil {
+		bgCol = *wm.BgColor
+	}
+	draw.FillRectNoBorder(w, types.RectForDim(wm.Bb.Width(), wm.Bb.Height()), bgCol)
+
+	draw.SetFillColor(w, wm.FillColor)
+
+	for y := 0; y < qr.Size; y++ {
+		// Row 0 is the top row.
+		yPos := float64(n-qrcode.QuietZone-1-y) * m
+		for x := 0; x < qr.Size; x++ {
+			if !qr.Black(x, y) {
+				continue
+			}
+			// Merge horizontal runs of dark modules.
+			x0 := x
+			for x+1 < qr.Size && qr.Black(x+1, y) {
+				x++
+			}
+			fmt.Fprintf(w, "%.3f %.3f %.3f %.3f re ", float64(x0+qrcode.QuietZone)*m, yPos, float64(x-x0+1)*m, m)
+		}
+	}
+
+	fmt.Fprint(w, "f ")
+}
+
+// barcodeFormContent renders the bars of wm.Barcode into wm.Bb.
+func barcodeFormContent(w io.Writer, wm model.Watermark) {
+	mm := wm.Barcode.Modules
+	m := wm.Bb.Width() / float64(len(mm))
+	h := wm.Bb.Height()
+
+	bgCol := color.White
+	if wm.BgColor != nil {
+		bgCol = *wm.BgColor
+	}
+	draw.FillRectNoBorder(w, types.RectForDim(wm.Bb.Width(), h), bgCol)
+
+	draw.SetFillColor(w, wm.FillColor)
+
+	for i := 0; i < len(mm); i++ {
+		if !mm[i] {
+			continue
+		}
+		i0 := i
+		for i+1 < len(mm) && mm[i+1] {
+			i++
+		}
+		fmt.Fprintf(w, "%.3f 0 %.3f %.3f re ", float64(i0)*m, float64(i-i0+1)*m, h)
+	}
+
+	fmt.Fprint(w, "f ")
+}
+
+// svgFormContent scales the converted SVG content into wm.Bb.
+func svgFormContent(w io.Writer, wm model.Watermark) {
+	sx := wm.Bb.Width() / wm.SVG.Width
+	sy := wm.Bb.Height() / wm.SVG.Height
+	fmt.Fprintf(w, "q %.5f 0 0 %.5f 0 0 cm ", sx, sy)
+	w.Write(wm.SVG.Content())
+	fmt.Fprint(w, "Q ")
+}
+
 func formContent(w io.Writer, pageNr int, wm model.Watermark) error {
 	switch true {
 	case wm.IsPDF():
 		return pdfFormContent(w, pageNr, wm)
 	case wm.IsImage():
 		imageFormContent(w, wm)
+	case wm.IsQRCode():
+		qrCodeFormContent(w, wm)
+	case wm.IsBarcode():
+		barcodeFormContent(w, wm)
+	case wm.IsSVG():
+		svgFormContent(w, wm)
 	}
 	return nil
 }
@@ -962,7 +1291,15 @@ func setupTextDescriptor(wm model.Watermark, timestampFormat string, pageNr, pag
 	// Set right to left rendering.
 	td.RTL = wm.RTL
 
-	td.Embed = wm.ScriptName == ""
+	// Switch to fallback fonts for missing chars.
+	for _, fontName := range wm.FallbackFonts {
+		td.FallbackFonts = append(td.FallbackFonts, model.FallbackFont{Name: fontName, Key: richTextFontID(fontName, wm)})
+	}
+
+	// Vertical writing always embeds a font subset.
+	td.Vertical = wm.Vertical
+
+	td.Embed = wm.ScriptName == "" || wm.Vertical
 
 	// Set margins.
 	td.MLeft = wm.MLeft
@@ -999,22 +1336,58 @@ func drawBoundingBox(b *bytes.Buffer, wm model.Watermark, bb *types.Rectangle) {
 	)
 }
 
-func calcFormBoundingBox(xRefTable *model.XRefTable, w io.Writer, timestampFormat string, pageNr, pageCount int, wm *model.Watermark) bool {
+func calcFormBoundingBox(xRefTable *model.XRefTable, w io.Writer, timestampFormat string, pageNr, pageCount int, wm *model.Watermark) (bool, error) {
 	var unique bool
-	if wm.IsImage() || wm.IsPDF() {
+	if wm.IsImage() || wm.IsPDF() || wm.IsSVG() {
 		wm.CalcBoundingBox(pageNr)
+	} else if wm.IsQRCode() {
+		var s string
+		s, unique = format.Text(wm.TextString, timestampFormat, pageNr, pageCount)
+		qr, err := qrcode.Encode(s, wm.QRLevel)
+		if err != nil {
+			return false, err
+		}
+		wm.QRCode = qr
+		wm.Width = qr.Size + 2*qrcode.QuietZone
+		wm.Height = wm.Width
+		wm.CalcBoundingBox(pageNr)
+	} else if wm.IsBarcode() {
+		var s string
+		s, unique = format.Text(wm.TextString, timestampFormat, pageNr, pageCount)
+		bc, err := barcode.Encode(s, wm.Symbology)
+		if err != nil {
+			return false, err
+		}
+		wm.Barcode = bc
+		wm.Width = len(bc.Modules)
+		wm.Height = int(math.Round(float64(wm.Width) * barHeightRatio))
+		wm.CalcBoundingBox(pageNr)
+		if wm.BarHeight > 0 {
+			wm.Bb.UR.Y = wm.Bb.LL.Y + wm.BarHeight
+		}
+	} else if wm.RichText {
+		var s string
+		s, unique = format.Text(wm.TextString, timestampFormat, pageNr, pageCount)
+		lines, err := parseRichText(s, *wm)
+		if err != nil {
+			return false, err
+		}
+		wm.Bb = richTextFormContent(w, lines, *wm)
 	} else {
 		var td model.TextDescriptor
 		td, unique = setupTextDescriptor(*wm, timestampFormat, pageNr, pageCount)
 		// Render td into b and return the bounding box.
 		wm.Bb = model.WriteMultiLine(xRefTable, w, types.RectForDim(wm.Vp.Width(), wm.Vp.Height()), nil, td)
 	}
-	return unique
+	return unique, nil
 }
 
 func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark, withBB bool) error {
 	var b bytes.Buffer
-	unique := calcFormBoundingBox(ctx.XRefTable, &b, ctx.Configuration.TimestampFormat, pageNr, pageCount, wm)
+	unique, err := calcFormBoundingBox(ctx.XRefTable, &b, ctx.Configuration.TimestampFormat, pageNr, pageCount, wm)
+	if err != nil {
+		return err
+	}
 
 	// The forms bounding box is dependent on the page dimensions.
 	bb := wm.Bb
@@ -1030,7 +1403,7 @@ func createForm(ctx *model.Context, pageNr, pageCount int, wm *model.Watermark,
 		}
 	}
 
-	if wm.IsImage() || wm.IsPDF() {
+	if wm.IsImage() || wm.IsPDF() || wm.IsQRCode() || wm.IsBarcode() || wm.IsSVG() {
 		if err := formContent(&b, pageNr, *wm); err != nil {
 			return err
 		}
@@ -1174,12 +1547,10 @@ func insertPageContentsForWM(ctx *model.Context, pageDict types.Dict, wm *model.
 	return nil
 }
 
-func patchFirstContentStreamForWatermark(sd *types.StreamDict, gsID, xoID string, wm *model.Watermark, isLast bool) error {
+func patchFirstContentStreamForWatermark(ctx *model.Context, sd *types.StreamDict, gsID, xoID string, wm *model.Watermark, isLast bool) error {
 	err := sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
-		if log.InfoEnabled() {
-			log.Info.Println("unsupported filter: unable to patch content with watermark.")
-		}
+		ctx.LogInfo("unsupported filter: unable to patch content with watermark")
 		return nil
 	}
 	if err != nil {
@@ -1217,12 +1588,10 @@ func patchFirstContentStreamForWatermark(sd *types.StreamDict, gsID, xoID string
 	return sd.Encode()
 }
 
-func patchLastContentStreamForWatermark(sd *types.StreamDict, gsID, xoID string, wm *model.Watermark) error {
+func patchLastContentStreamForWatermark(ctx *model.Context, sd *types.StreamDict, gsID, xoID string, wm *model.Watermark) error {
 	err := sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
-		if log.InfoEnabled() {
-			log.Info.Println("unsupported filter: unable to patch content with watermark.")
-		}
+		ctx.LogInfo("unsupported filter: unable to patch content with watermark")
 		return nil
 	}
 	if err != nil {
@@ -1265,7 +1634,7 @@ func updatePageContentsForWM(ctx *model.Context, obj types.Object, wm *model.Wat
 
 	case types.StreamDict:
 
-		err := patchFirstContentStreamForWatermark(&o, gsID, xoID, wm, true)
+		err := patchFirstContentStreamForWatermark(ctx, &o, gsID, xoID, wm, true)
 		if err != nil {
 			return err
 		}
@@ -1290,7 +1659,7 @@ func updatePageContentsForWM(ctx *model.Context, obj types.Object, wm *model.Wat
 			return nil
 		}
 
-		err := patchFirstContentStreamForWatermark(&sd, gsID, xoID, wm, len(o) == 1)
+		err := patchFirstContentStreamForWatermark(ctx, &sd, gsID, xoID, wm, len(o) == 1)
 		if err != nil {
 			return err
 		}
@@ -1315,7 +1684,7 @@ func updatePageContentsForWM(ctx *model.Context, obj types.Object, wm *model.Wat
 		entry, _ = ctx.FindTableEntry(objNr, genNr)
 		sd, _ = (entry.Object).(types.StreamDict)
 
-		err = patchLastContentStreamForWatermark(&sd, gsID, xoID, wm)
+		err = patchLastContentStreamForWatermark(ctx, &sd, gsID, xoID, wm)
 		if err != nil {
 			return err
 		}
@@ -1366,14 +1735,10 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		return errors.Errorf("pdfcpu: invalid page number: %d", pageNr)
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("addPageWatermark page:%d\n", pageNr)
-	}
+	ctx.LogDebug("addPageWatermark", log.KeyPage, pageNr)
 
 	if wm.Update {
-		if log.DebugEnabled() {
-			log.Debug.Println("Updating")
-		}
+		ctx.LogDebug("addPageWatermark: updating", log.KeyPage, pageNr)
 		if _, err := removePageWatermark(ctx, pageNr); err != nil {
 			return err
 		}
@@ -1406,9 +1771,7 @@ func addPageWatermark(ctx *model.Context, pageNr int, wm model.Watermark) error
 		return err
 	}
 
-	if log.DebugEnabled() {
-		log.Debug.Printf("\n%s\n", wm)
-	}
+	ctx.LogDebug("addPageWatermark", log.KeyPage, pageNr, "watermark", wm)
 
 	gsID := "GS0"
 	xoID := "Fm0"
@@ -1457,18 +1820,30 @@ func createResourcesForPageNr(
 		return createPDFResForWM(ctx, wm)
 	}
 
+	if wm.IsQRCode() || wm.IsBarcode() || wm.IsSVG() {
+		return nil
+	}
+
 	// Text watermark
 
+	setupFallbackFontsForWM(ctx, wm)
 	if font.IsUserFont(wm.FontName) {
 		td, _ := setupTextDescriptor(*wm, "", 123456789, 0)
 		model.WriteMultiLine(ctx.XRefTable, new(bytes.Buffer), types.RectForFormat("A4"), nil, td)
 	}
 
-	pageSet, found := fm[wm.FontName]
-	if !found {
-		fm[wm.FontName] = types.IntSet{pageNr: true}
-	} else {
-		pageSet[pageNr] = true
+	fontNames := []string{fontNameForWM(*wm)}
+	for fontName := range wm.RichFonts {
+		fontNames = append(fontNames, fontName)
+	}
+
+	for _, fontName := range fontNames {
+		pageSet, found := fm[fontName]
+		if !found {
+			fm[fontName] = types.IntSet{pageNr: true}
+		} else {
+			pageSet[pageNr] = true
+		}
 	}
 
 	return nil
@@ -1547,10 +1922,7 @@ func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
 			if !v {
 				continue
 			}
-			wm := m[pageNr]
-			if wm.IsText() && wm.FontName == fontName {
-				m[pageNr].Font = ir
-			}
+			setFontResForWM(m[pageNr], fontName, ir)
 		}
 	}
 
@@ -1564,6 +1936,18 @@ func AddWatermarksMap(ctx *model.Context, m map[int]*model.Watermark) error {
 	return nil
 }
 
+func setFontResForWM(wm *model.Watermark, fontName string, ir *types.IndirectRef) {
+	if !wm.IsText() {
+		return
+	}
+	if fontNameForWM(*wm) == fontName {
+		wm.Font = ir
+	}
+	if _, ok := wm.RichFonts[fontName]; ok {
+		wm.RichFonts[fontName] = ir
+	}
+}
+
 func resolveFonts(fm map[string]types.IntSet, xRefTable *model.XRefTable, m1 map[int][]*model.Watermark) error {
 	// TODO Take existing font dicts in xref into account.
 	for fontName, pageSet := range fm {
@@ -1576,9 +1960,7 @@ func resolveFonts(fm map[string]types.IntSet, xRefTable *model.XRefTable, m1 map
 				continue
 			}
 			for _, wm := range m1[pageNr] {
-				if wm.IsText() && wm.FontName == fontName {
-					wm.Font = ir
-				}
+				setFontResForWM(wm, fontName, ir)
 			}
 		}
 	}
@@ -1642,9 +2024,7 @@ func AddWatermarksSliceMap(ctx *model.Context, m map[int][]*model.Watermark) err
 
 // AddWatermarks adds watermarks to all pages selected.
 func AddWatermarks(ctx *model.Context, selectedPages types.IntSet, wm *model.Watermark) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("AddWatermarks wm:\n%s\n", wm)
-	}
+	ctx.LogDebug("AddWatermarks", "watermark", wm)
 	var err error
 	if wm.Ocg, err = prepareOCPropertiesInRoot(ctx, wm.OnTop); err != nil {
 		return err
@@ -1707,12 +2087,10 @@ func removeForms(ctx *model.Context, d types.Dict, ids []string, i int) error {
 	return removeResDictEntry(ctx, d, "XObject", ids, i)
 }
 
-func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string, forms []string, err error) {
+func removeArtifacts(ctx *model.Context, sd *types.StreamDict, i int) (ok bool, extGStates []string, forms []string, err error) {
 	err = sd.Decode()
 	if err == filter.ErrUnsupportedFilter {
-		if log.InfoEnabled() {
-			log.Info.Printf("unsupported filter: unable to patch content with watermark for page %d\n", i)
-		}
+		ctx.LogInfo("unsupported filter: unable to remove watermark", log.KeyPage, i)
 		return false, nil, nil, nil
 	}
 	if err != nil {
@@ -1771,7 +2149,7 @@ func removeArtifacts(sd *types.StreamDict, i int) (ok bool, extGStates []string,
 func removeArtifactsFromPage(ctx *model.Context, sd *types.StreamDict, resDict types.Dict, i int) (bool, error) {
 	// Remove watermark artifacts and locate id's
 	// of used extGStates and forms.
-	ok, extGStates, forms, err := removeArtifacts(sd, i)
+	ok, extGStates, forms, err := removeArtifacts(ctx, sd, i)
 	if err != nil {
 		return false, err
 	}
@@ -2004,9 +2382,7 @@ func removePageWatermarks(ctx *model.Context, selectedPages types.IntSet) error
 
 // RemoveWatermarks removes watermarks for all pages selected.
 func RemoveWatermarks(ctx *model.Context, selectedPages types.IntSet) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("RemoveWatermarks\n")
-	}
+	ctx.LogDebug("RemoveWatermarks")
 
 	arr, err := locateOCGs(ctx)
 	if err != nil {
@@ -0,0 +1,397 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package svg
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var errPathData = errors.New("pdfcpu: svg: invalid path data")
+
+// scanner tokenizes the number lists used by path data, points and transforms.
+type scanner struct {
+	s string
+	i int
+}
+
+func (sc *scanner) skipSeparators() {
+	for sc.i < len(sc.s) && strings.IndexByte(" \t\r\n,", sc.s[sc.i]) >= 0 {
+		sc.i++
+	}
+}
+
+func (sc *scanner) done() bool {
+	sc.skipSeparators()
+	return sc.i >= len(sc.s)
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func (sc *scanner) hasNumber() bool {
+	if sc.done() {
+		return false
+	}
+	c := sc.s[sc.i]
+	return isDigit(c) || c == '.' || c == '-' || c == '+'
+}
+
+func (sc *scanner) digits() int {
+	start := sc.i
+	for sc.i < len(sc.s) && isDigit(sc.s[sc.i]) {
+		sc.i++
+	}
+	return sc.i - start
+}
+
+func (sc *scanner) number() (float64, error) {
+	sc.skipSeparators()
+	start := sc.i
+
+	if sc.i < len(sc.s) && (sc.s[sc.i] == '-' || sc.s[sc.i] == '+') {
+		sc.i++
+	}
+
+	n := sc.digits()
+	if sc.i < len(sc.s) && sc.s[sc.i] == '.' {
+		sc.i++
+		n += sc.digits()
+	}
+	if n == 0 {
+		return 0, errPathData
+	}
+
+	if sc.i < len(sc.s) && (sc.s[sc.i] == 'e' || sc.s[sc.i] == 'E') {
+		j := sc.i + 1
+		if j < len(sc.s) && (sc.s[j] == '-' || sc.s[j] == '+') {
+			j++
+		}
+		if j < len(sc.s) && isDigit(sc.s[j]) {
+			sc.i = j
+			sc.digits()
+		}
+	}
+
+	return strconv.ParseFloat(sc.s[start:sc.i], 64)
+}
+
+func (sc *scanner) numbers(n int) ([]float64, error) {
+	ff := make([]float64, n)
+	for i := range ff {
+		f, err := sc.number()
+		if err != nil {
+			return nil, err
+		}
+		ff[i] = f
+	}
+	return ff, nil
+}
+
+// flag scans an arc flag which may not be followed by a separator.
+func (sc *scanner) flag() (bool, error) {
+	sc.skipSeparators()
+	if sc.i >= len(sc.s) {
+		return false, errPathData
+	}
+	c := sc.s[sc.i]
+	if c != '0' && c != '1' {
+		return false, errPathData
+	}
+	sc.i++
+	return c == '1', nil
+}
+
+func fmtNum(f float64) string {
+	f = math.Round(f*1000) / 1000
+	if f == 0 {
+		// Avoid negative zero.
+		return "0"
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// pathBuilder accumulates PDF path construction operators.
+type pathBuilder struct {
+	b          strings.Builder
+	x, y       float64 // current point
+	sx, sy     float64 // start of the current subpath
+	cx, cy     float64 // last control point
+	lastCmd    byte    // last command in upper case
+	hasSegment bool
+}
+
+func (p *pathBuilder) write(op string, ff ...float64) {
+	for _, f := range ff {
+		p.b.WriteString(fmtNum(f))
+		p.b.WriteByte(' ')
+	}
+	p.b.WriteString(op)
+	p.b.WriteByte(' ')
+}
+
+func (p *pathBuilder) moveTo(x, y float64) {
+	p.write("m", x, y)
+	p.x, p.y, p.sx, p.sy = x, y, x, y
+	p.hasSegment = true
+}
+
+func (p *pathBuilder) lineTo(x, y float64) {
+	p.write("l", x, y)
+	p.x, p.y = x, y
+}
+
+func (p *pathBuilder) curveTo(x1, y1, x2, y2, x, y float64) {
+	p.write("c", x1, y1, x2, y2, x, y)
+	p.cx, p.cy = x2, y2
+	p.x, p.y = x, y
+}
+
+// quadTo converts a quadratic Bézier curve into a cubic.
+func (p *pathBuilder) quadTo(qx, qy, x, y float64) {
+	x1, y1 := p.x+2./3.*(qx-p.x), p.y+2./3.*(qy-p.y)
+	x2, y2 := x+2./3.*(qx-x), y+2./3.*(qy-y)
+	p.curveTo(x1, y1, x2, y2, x, y)
+	p.cx, p.cy = qx, qy
+}
+
+func (p *pathBuilder) closePath() {
+	p.write("h")
+	p.x, p.y = p.sx, p.sy
+}
+
+func vectorAngle(ux, uy, vx, vy float64) float64 {
+	return math.Atan2(ux*vy-uy*vx, ux*vx+uy*vy)
+}
+
+// arcTo approximates an elliptical arc with cubic Bézier curves of at most 90 degrees each.
+// See SVG 1.1, Appendix F.6 Elliptical arc implementation notes.
+func (p *pathBuilder) arcTo(rx, ry, phiDeg float64, large, sweep bool, x2, y2 float64) {
+	x1, y1 := p.x, p.y
+	if x1 == x2 && y1 == y2 {
+		return
+	}
+
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if rx == 0 || ry == 0 {
+		p.lineTo(x2, y2)
+		return
+	}
+
+	phi := phiDeg * math.Pi / 180
+	cos, sin := math.Cos(phi), math.Sin(phi)
+
+	dx, dy := (x1-x2)/2, (y1-y2)/2
+	x1p := cos*dx + sin*dy
+	y1p := -sin*dx + cos*dy
+
+	// Scale up radii too small to span both end points.
+	if l := x1p*x1p/(rx*rx) + y1p*y1p/(ry*ry); l > 1 {
+		s := math.Sqrt(l)
+		rx, ry = rx*s, ry*s
+	}
+
+	num := rx*rx*ry*ry - rx*rx*y1p*y1p - ry*ry*x1p*x1p
+	den := rx*rx*y1p*y1p + ry*ry*x1p*x1p
+	co := 0.
+	if num > 0 && den > 0 {
+		co = math.Sqrt(num / den)
+	}
+	if large == sweep {
+		co = -co
+	}
+
+	cxp := co * rx * y1p / ry
+	cyp := -co * ry * x1p / rx
+	cx := cos*cxp - sin*cyp + (x1+x2)/2
+	cy := sin*cxp + cos*cyp + (y1+y2)/2
+
+	ux, uy := (x1p-cxp)/rx, (y1p-cyp)/ry
+	vx, vy := (-x1p-cxp)/rx, (-y1p-cyp)/ry
+
+	theta := vectorAngle(1, 0, ux, uy)
+	delta := vectorAngle(ux, uy, vx, vy)
+	if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	} else if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	}
+
+	n := int(math.Ceil(math.Abs(delta)/(math.Pi/2) - 1e-9))
+	if n < 1 {
+		n = 1
+	}
+	d := delta / float64(n)
+	k := 4. / 3. * math.Tan(d/4)
+
+	pt := func(ux, uy float64) (float64, float64) {
+		ux, uy = rx*ux, ry*uy
+		return cos*ux - sin*uy + cx, sin*ux + cos*uy + cy
+	}
+
+	for i := 0; i < n; i++ {
+		c1, s1 := math.Cos(theta), math.Sin(theta)
+		c2, s2 := math.Cos(theta+d), math.Sin(theta+d)
+		ax, ay := pt(c1-k*s1, s1+k*c1)
+		bx, by := pt(c2+k*s2, s2-k*c2)
+		ex, ey := pt(c2, s2)
+		if i == n-1 {
+			ex, ey = x2, y2
+		}
+		p.curveTo(ax, ay, bx, by, ex, ey)
+		theta += d
+	}
+}
+
+func isCommand(c byte) bool {
+	return strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0
+}
+
+// convertPath converts SVG path data into PDF path construction operators.
+func convertPath(d string) (string, error) {
+	p := &pathBuilder{}
+	sc := &scanner{s: d}
+
+	var cmd byte
+
+	for !sc.done() {
+		if c := sc.s[sc.i]; isCommand(c) {
+			cmd = c
+			sc.i++
+		} else if cmd == 0 || !sc.hasNumber() {
+			return "", errors.Wrapf(errPathData, "at offset %d", sc.i)
+		}
+
+		if err := p.segment(sc, cmd); err != nil {
+			return "", errors.Wrapf(err, "at offset %d", sc.i)
+		}
+
+		upper := cmd &^ 0x20
+		p.lastCmd = upper
+
+		switch upper {
+		case 'M':
+			// Subsequent coordinate pairs are implicit lineto commands: M -> L, m -> l
+			cmd--
+		case 'Z':
+			cmd = 0
+		}
+	}
+
+	return p.b.String(), nil
+}
+
+func (p *pathBuilder) segment(sc *scanner, cmd byte) error {
+	rel := cmd >= 'a'
+	ox, oy := 0., 0.
+	if rel {
+		ox, oy = p.x, p.y
+	}
+
+	if !p.hasSegment && cmd&^0x20 != 'M' {
+		return errPathData
+	}
+
+	switch cmd &^ 0x20 {
+
+	case 'Z':
+		p.closePath()
+
+	case 'M', 'L', 'T':
+		ff, err := sc.numbers(2)
+		if err != nil {
+			return err
+		}
+		x, y := ff[0]+ox, ff[1]+oy
+		switch cmd &^ 0x20 {
+		case 'M':
+			p.moveTo(x, y)
+		case 'L':
+			p.lineTo(x, y)
+		case 'T':
+			qx, qy := p.x, p.y
+			if p.lastCmd == 'Q' || p.lastCmd == 'T' {
+				qx, qy = 2*p.x-p.cx, 2*p.y-p.cy
+			}
+			p.quadTo(qx, qy, x, y)
+		}
+
+	case 'H':
+		f, err := sc.number()
+		if err != nil {
+			return err
+		}
+		p.lineTo(f+ox, p.y)
+
+	case 'V':
+		f, err := sc.number()
+		if err != nil {
+			return err
+		}
+		p.lineTo(p.x, f+oy)
+
+	case 'C':
+		ff, err := sc.numbers(6)
+		if err != nil {
+			return err
+		}
+		p.curveTo(ff[0]+ox, ff[1]+oy, ff[2]+ox, ff[3]+oy, ff[4]+ox, ff[5]+oy)
+
+	case 'S':
+		ff, err := sc.numbers(4)
+		if err != nil {
+			return err
+		}
+		x1, y1 := p.x, p.y
+		if p.lastCmd == 'C' || p.lastCmd == 'S' {
+			x1, y1 = 2*p.x-p.cx, 2*p.y-p.cy
+		}
+		p.curveTo(x1, y1, ff[0]+ox, ff[1]+oy, ff[2]+ox, ff[3]+oy)
+
+	case 'Q':
+		ff, err := sc.numbers(4)
+		if err != nil {
+			return err
+		}
+		p.quadTo(ff[0]+ox, ff[1]+oy, ff[2]+ox, ff[3]+oy)
+
+	case 'A':
+		ff, err := sc.numbers(3)
+		if err != nil {
+			return err
+		}
+		large, err := sc.flag()
+		if err != nil {
+			return err
+		}
+		sweep, err := sc.flag()
+		if err != nil {
+			return err
+		}
+		xy, err := sc.numbers(2)
+		if err != nil {
+			return err
+		}
+		p.arcTo(ff[0], ff[1], ff[2], large, sweep, xy[0]+ox, xy[1]+oy)
+	}
+
+	return nil
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package svg
+
+import (
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+	"github.com/pkg/errors"
+)
+
+var namedColors = map[string]uint32{
+	"black":     0x000000,
+	"silver":    0xC0C0C0,
+	"gray":      0x808080,
+	"grey":      0x808080,
+	"white":     0xFFFFFF,
+	"maroon":    0x800000,
+	"red":       0xFF0000,
+	"purple":    0x800080,
+	"fuchsia":   0xFF00FF,
+	"magenta":   0xFF00FF,
+	"green":     0x008000,
+	"lime":      0x00FF00,
+	"olive":     0x808000,
+	"yellow":    0xFFFF00,
+	"navy":      0x000080,
+	"blue":      0x0000FF,
+	"teal":      0x008080,
+	"aqua":      0x00FFFF,
+	"cyan":      0x00FFFF,
+	"orange":    0xFFA500,
+	"gold":      0xFFD700,
+	"brown":     0xA52A2A,
+	"pink":      0xFFC0CB,
+	"darkgray":  0xA9A9A9,
+	"darkgrey":  0xA9A9A9,
+	"lightgray": 0xD3D3D3,
+	"lightgrey": 0xD3D3D3,
+	"darkblue":  0x00008B,
+	"darkred":   0x8B0000,
+	"darkgreen": 0x006400,
+}
+
+func parseHexColor(s string) (color.SimpleColor, error) {
+	if len(s) == 3 {
+		// #rgb is short for #rrggbb
+		s = string([]byte{s[0], s[0], s[1], s[1], s[2], s[2]})
+	}
+	if len(s) != 6 {
+		return color.Black, errors.Errorf("pdfcpu: svg: invalid color: #%s", s)
+	}
+	u, err := strconv.ParseUint(s, 16, 32)
+	if err != nil {
+		return color.Black, errors.Errorf("pdfcpu: svg: invalid color: #%s", s)
+	}
+	return color.NewSimpleColor(uint32(u)), nil
+}
+
+func parseRGBColor(s string) (color.SimpleColor, error) {
+	ss := strings.Split(s, ",")
+	if len(ss) != 3 {
+		return color.Black, errors.Errorf("pdfcpu: svg: invalid color: rgb(%s)", s)
+	}
+	var ii [3]float32
+	for i, s := range ss {
+		s = strings.TrimSpace(s)
+		max := 255.
+		if strings.HasSuffix(s, "%") {
+			s, max = s[:len(s)-1], 100
+		}
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return color.Black, errors.Errorf("pdfcpu: svg: invalid color: rgb(%s)", s)
+		}
+		ii[i] = float32(math.Max(0, math.Min(f/max, 1)))
+	}
+	return color.SimpleColor{R: ii[0], G: ii[1], B: ii[2]}, nil
+}
+
+func parseColor(s string) (color.SimpleColor, error) {
+	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "#") {
+		return parseHexColor(s[1:])
+	}
+	if strings.HasPrefix(s, "rgb(") && strings.HasSuffix(s, ")") {
+		return parseRGBColor(s[4 : len(s)-1])
+	}
+	if u, ok := namedColors[strings.ToLower(s)]; ok {
+		return color.NewSimpleColor(u), nil
+	}
+	return color.Black, errors.Errorf("pdfcpu: svg: unsupported color: %s", s)
+}
+
+// paint represents the value of a fill or stroke property.
+type paint struct {
+	none         bool
+	currentColor bool
+	col          color.SimpleColor
+}
+
+func parsePaint(s string) (paint, error) {
+	s = strings.TrimSpace(s)
+
+	if strings.HasPrefix(s, "url(") {
+		// Gradients and patterns are not supported, use the fallback color if any.
+		i := strings.IndexByte(s, ')')
+		if i < 0 {
+			return paint{}, errors.Errorf("pdfcpu: svg: invalid paint: %s", s)
+		}
+		s = strings.TrimSpace(s[i+1:])
+		if s == "" {
+			return paint{none: true}, nil
+		}
+	}
+
+	switch s {
+	case "none":
+		return paint{none: true}, nil
+	case "currentColor":
+		return paint{currentColor: true}, nil
+	}
+
+	c, err := parseColor(s)
+	return paint{col: c}, err
+}
+
+// style holds the inheritable presentation properties relevant for rendering.
+type style struct {
+	fill, stroke paint
+	color        color.SimpleColor // Used by currentColor.
+	strokeWidth  float64
+	fillEvenOdd  bool
+	lineCap      int
+	lineJoin     int
+	miterLimit   float64
+	dash         []float64
+	dashOffset   float64
+	hidden       bool // display:none, not inherited.
+}
+
+func defaultStyle() style {
+	return style{
+		fill:        paint{col: color.Black},
+		stroke:      paint{none: true},
+		color:       color.Black,
+		strokeWidth: 1,
+		miterLimit:  4,
+	}
+}
+
+func (st style) paintColor(p paint) color.SimpleColor {
+	if p.currentColor {
+		return st.color
+	}
+	return p.col
+}
+
+func parseDashArray(s string) ([]float64, error) {
+	if s == "none" {
+		return nil, nil
+	}
+	ff := []float64{}
+	for _, v := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' }) {
+		f, err := parseLength(v)
+		if err != nil {
+			return nil, errors.Errorf("pdfcpu: svg: invalid stroke-dasharray: %s", s)
+		}
+		ff = append(ff, f)
+	}
+	if len(ff)%2 == 1 {
+		// An odd number of values is repeated to yield an even number.
+		ff = append(ff, ff...)
+	}
+	return ff, nil
+}
+
+func (st *style) setProperty(name, value string) (err error) {
+	value = strings.TrimSpace(value)
+	if value == "" || value == "inherit" {
+		return nil
+	}
+
+	switch name {
+	case "fill":
+		st.fill, err = parsePaint(value)
+	case "stroke":
+		st.stroke, err = parsePaint(value)
+	case "color":
+		st.color, err = parseColor(value)
+	case "stroke-width":
+		st.strokeWidth, err = parseLength(value)
+	case "fill-rule":
+		st.fillEvenOdd = value == "evenodd"
+	case "stroke-linecap":
+		st.lineCap = map[string]int{"butt": 0, "round": 1, "square": 2}[value]
+	case "stroke-linejoin":
+		st.lineJoin = map[string]int{"miter": 0, "round": 1, "bevel": 2}[value]
+	case "stroke-miterlimit":
+		st.miterLimit, err = strconv.ParseFloat(value, 64)
+	case "stroke-dasharray":
+		st.dash, err = parseDashArray(value)
+	case "stroke-dashoffset":
+		st.dashOffset, err = parseLength(value)
+	case "display":
+		st.hidden = value == "none"
+	}
+
+	return err
+}
+
+// apply sets the presentation attributes and the style attribute of an element, the latter taking precedence.
+func (st *style) apply(attrs map[string]string) error {
+	st.hidden = false
+
+	for k, v := range attrs {
+		if k == "style" {
+			continue
+		}
+		if err := st.setProperty(k, v); err != nil {
+			return err
+		}
+	}
+
+	for _, decl := range strings.Split(attrs["style"], ";") {
+		ss := strings.SplitN(decl, ":", 2)
+		if len(ss) != 2 {
+			continue
+		}
+		if err := st.setProperty(strings.TrimSpace(ss[0]), ss[1]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
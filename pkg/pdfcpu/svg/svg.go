@@ -0,0 +1,538 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package svg converts a subset of SVG into PDF content streams.
+//
+// Supported are the elements svg, g, path, rect, circle, ellipse, line, polyline and polygon,
+// the transform attribute, solid fills and strokes set via presentation attributes or the style attribute.
+// Text, images, gradients, patterns, clipping, masking, opacity and CSS style sheets are ignored.
+package svg
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
+	"github.com/pkg/errors"
+)
+
+const (
+	svgNamespace = "http://www.w3.org/2000/svg"
+	pxToPt       = 0.75 // SVG user units are CSS pixels: 96 per inch.
+)
+
+// skippedElements are not rendered including their content.
+var skippedElements = map[string]bool{
+	"clipPath":       true,
+	"defs":           true,
+	"desc":           true,
+	"filter":         true,
+	"foreignObject":  true,
+	"image":          true,
+	"linearGradient": true,
+	"marker":         true,
+	"mask":           true,
+	"metadata":       true,
+	"pattern":        true,
+	"radialGradient": true,
+	"script":         true,
+	"style":          true,
+	"switch":         true,
+	"symbol":         true,
+	"text":           true,
+	"title":          true,
+	"use":            true,
+}
+
+// SVG represents an SVG document converted into PDF content.
+type SVG struct {
+	Width, Height float64 // Dimensions in points.
+	content       []byte
+}
+
+// Content returns the PDF content stream rendering s into the rectangle (0, 0, Width, Height).
+func (s SVG) Content() []byte {
+	return s.content
+}
+
+// parseLength returns l in user units (px).
+func parseLength(l string) (float64, error) {
+	l = strings.TrimSpace(l)
+
+	f := 1.
+	for _, u := range []struct {
+		suffix string
+		px     float64
+	}{
+		{"px", 1}, {"pt", 4. / 3}, {"pc", 16}, {"mm", 96 / 25.4}, {"cm", 96 / 2.54}, {"in", 96}, {"em", 16}, {"%", 0},
+	} {
+		if strings.HasSuffix(l, u.suffix) {
+			l, f = strings.TrimSpace(l[:len(l)-len(u.suffix)]), u.px
+			break
+		}
+	}
+
+	v, err := strconv.ParseFloat(l, 64)
+	if err != nil {
+		return 0, errors.Errorf("pdfcpu: svg: invalid length: %s", l)
+	}
+
+	// Percentages are not supported and yield 0.
+	return v * f, nil
+}
+
+func parseLengthAttr(attrs map[string]string, name string) (float64, error) {
+	s, ok := attrs[name]
+	if !ok {
+		return 0, nil
+	}
+	return parseLength(s)
+}
+
+// transformMatrices parses a transform list into a sequence of matrices to be concatenated in order.
+func transformMatrices(s string) ([][6]float64, error) {
+	mm := [][6]float64{}
+
+	for s = strings.TrimSpace(s); s != ""; s = strings.TrimLeft(s, " \t\r\n,") {
+		i := strings.IndexByte(s, '(')
+		j := strings.IndexByte(s, ')')
+		if i < 0 || j < i {
+			return nil, errors.Errorf("pdfcpu: svg: invalid transform: %s", s)
+		}
+
+		name := strings.TrimSpace(s[:i])
+		sc := &scanner{s: s[i+1 : j]}
+		s = s[j+1:]
+
+		ff := []float64{}
+		for !sc.done() {
+			f, err := sc.number()
+			if err != nil {
+				return nil, errors.Errorf("pdfcpu: svg: invalid transform arguments: %s", sc.s)
+			}
+			ff = append(ff, f)
+		}
+
+		n := len(ff)
+		arg := func(i int, def float64) float64 {
+			if i < n {
+				return ff[i]
+			}
+			return def
+		}
+
+		switch {
+		case name == "matrix" && n == 6:
+			mm = append(mm, [6]float64{ff[0], ff[1], ff[2], ff[3], ff[4], ff[5]})
+		case name == "translate" && (n == 1 || n == 2):
+			mm = append(mm, [6]float64{1, 0, 0, 1, ff[0], arg(1, 0)})
+		case name == "scale" && (n == 1 || n == 2):
+			mm = append(mm, [6]float64{ff[0], 0, 0, arg(1, ff[0]), 0, 0})
+		case name == "rotate" && (n == 1 || n == 3):
+			a := ff[0] * math.Pi / 180
+			cx, cy := arg(1, 0), arg(2, 0)
+			mm = append(mm,
+				[6]float64{1, 0, 0, 1, cx, cy},
+				[6]float64{math.Cos(a), math.Sin(a), -math.Sin(a), math.Cos(a), 0, 0},
+				[6]float64{1, 0, 0, 1, -cx, -cy})
+		case name == "skewX" && n == 1:
+			mm = append(mm, [6]float64{1, 0, math.Tan(ff[0] * math.Pi / 180), 1, 0, 0})
+		case name == "skewY" && n == 1:
+			mm = append(mm, [6]float64{1, math.Tan(ff[0] * math.Pi / 180), 0, 1, 0, 0})
+		default:
+			return nil, errors.Errorf("pdfcpu: svg: invalid transform: %s with %d arguments", name, n)
+		}
+	}
+
+	return mm, nil
+}
+
+func writeMatrix(w io.Writer, m [6]float64) {
+	fmt.Fprintf(w, "%s %s %s %s %s %s cm ", fmtNum(m[0]), fmtNum(m[1]), fmtNum(m[2]), fmtNum(m[3]), fmtNum(m[4]), fmtNum(m[5]))
+}
+
+type frame struct {
+	style   style
+	skip    bool // Skip this element including its content.
+	restore bool // Restore the graphics state at the end of this element.
+}
+
+type renderer struct {
+	buf    bytes.Buffer
+	frames []frame
+}
+
+func (r *renderer) top() frame {
+	if len(r.frames) == 0 {
+		return frame{style: defaultStyle()}
+	}
+	return r.frames[len(r.frames)-1]
+}
+
+func attrMap(e xml.StartElement) map[string]string {
+	m := map[string]string{}
+	for _, a := range e.Attr {
+		if a.Name.Space == "" {
+			m[a.Name.Local] = a.Value
+		}
+	}
+	return m
+}
+
+func (r *renderer) start(e xml.StartElement) error {
+	parent := r.top()
+
+	if parent.skip || skippedElements[e.Name.Local] || (e.Name.Space != "" && e.Name.Space != svgNamespace) {
+		r.frames = append(r.frames, frame{skip: true})
+		return nil
+	}
+
+	attrs := attrMap(e)
+
+	f := frame{style: parent.style}
+	if err := f.style.apply(attrs); err != nil {
+		return err
+	}
+
+	if f.style.hidden {
+		r.frames = append(r.frames, frame{skip: true})
+		return nil
+	}
+
+	if s, ok := attrs["transform"]; ok {
+		mm, err := transformMatrices(s)
+		if err != nil {
+			return err
+		}
+		r.buf.WriteString("q ")
+		for _, m := range mm {
+			writeMatrix(&r.buf, m)
+		}
+		f.restore = true
+	}
+
+	r.frames = append(r.frames, f)
+
+	return r.render(e.Name.Local, attrs, f.style)
+}
+
+func (r *renderer) end() {
+	if len(r.frames) == 0 {
+		return
+	}
+	f := r.frames[len(r.frames)-1]
+	r.frames = r.frames[:len(r.frames)-1]
+	if f.restore {
+		r.buf.WriteString("Q ")
+	}
+}
+
+func (r *renderer) render(name string, attrs map[string]string, st style) error {
+	var (
+		path     string
+		err      error
+		fillable = true
+	)
+
+	switch name {
+	case "path":
+		path, err = convertPath(attrs["d"])
+	case "rect":
+		path, err = rectPath(attrs)
+	case "circle":
+		path, err = ellipsePath(attrs, true)
+	case "ellipse":
+		path, err = ellipsePath(attrs, false)
+	case "line":
+		path, err = linePath(attrs)
+		fillable = false
+	case "polyline":
+		path, err = polyPath(attrs["points"], false)
+	case "polygon":
+		path, err = polyPath(attrs["points"], true)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "<%s>", name)
+	}
+
+	r.paint(path, st, fillable)
+
+	return nil
+}
+
+func (r *renderer) paint(path string, st style, fillable bool) {
+	fill := fillable && !st.fill.none
+	stroke := !st.stroke.none && st.strokeWidth > 0
+	if path == "" || (!fill && !stroke) {
+		return
+	}
+
+	w := &r.buf
+	w.WriteString("q ")
+
+	if fill {
+		draw.SetFillColor(w, st.paintColor(st.fill))
+	}
+
+	if stroke {
+		draw.SetStrokeColor(w, st.paintColor(st.stroke))
+		fmt.Fprintf(w, "%s w %d J %d j %s M ", fmtNum(st.strokeWidth), st.lineCap, st.lineJoin, fmtNum(st.miterLimit))
+		if len(st.dash) > 0 {
+			ss := make([]string, len(st.dash))
+			for i, f := range st.dash {
+				ss[i] = fmtNum(f)
+			}
+			fmt.Fprintf(w, "[%s] %s d ", strings.Join(ss, " "), fmtNum(st.dashOffset))
+		}
+	}
+
+	w.WriteString(path)
+
+	op := "S"
+	if fill {
+		op = "f"
+		if stroke {
+			op = "B"
+		}
+		if st.fillEvenOdd {
+			op += "*"
+		}
+	}
+
+	w.WriteString(op + " Q ")
+}
+
+func rectPath(attrs map[string]string) (string, error) {
+	var v [6]float64
+	for i, k := range []string{"x", "y", "width", "height", "rx", "ry"} {
+		f, err := parseLengthAttr(attrs, k)
+		if err != nil {
+			return "", err
+		}
+		v[i] = f
+	}
+
+	x, y, w, h, rx, ry := v[0], v[1], v[2], v[3], v[4], v[5]
+	if w <= 0 || h <= 0 {
+		return "", nil
+	}
+
+	if _, ok := attrs["rx"]; !ok {
+		rx = ry
+	}
+	if _, ok := attrs["ry"]; !ok {
+		ry = rx
+	}
+	rx, ry = math.Min(rx, w/2), math.Min(ry, h/2)
+
+	p := &pathBuilder{}
+
+	if rx <= 0 || ry <= 0 {
+		p.write("re", x, y, w, h)
+		return p.b.String(), nil
+	}
+
+	p.moveTo(x+rx, y)
+	p.lineTo(x+w-rx, y)
+	p.arcTo(rx, ry, 0, false, true, x+w, y+ry)
+	p.lineTo(x+w, y+h-ry)
+	p.arcTo(rx, ry, 0, false, true, x+w-rx, y+h)
+	p.lineTo(x+rx, y+h)
+	p.arcTo(rx, ry, 0, false, true, x, y+h-ry)
+	p.lineTo(x, y+ry)
+	p.arcTo(rx, ry, 0, false, true, x+rx, y)
+	p.closePath()
+
+	return p.b.String(), nil
+}
+
+func ellipsePath(attrs map[string]string, circle bool) (string, error) {
+	keys := []string{"cx", "cy", "rx", "ry"}
+	if circle {
+		keys = []string{"cx", "cy", "r"}
+	}
+
+	v := make([]float64, len(keys))
+	for i, k := range keys {
+		f, err := parseLengthAttr(attrs, k)
+		if err != nil {
+			return "", err
+		}
+		v[i] = f
+	}
+
+	cx, cy, rx := v[0], v[1], v[2]
+	ry := rx
+	if !circle {
+		ry = v[3]
+	}
+	if rx <= 0 || ry <= 0 {
+		return "", nil
+	}
+
+	p := &pathBuilder{}
+	p.moveTo(cx+rx, cy)
+	p.arcTo(rx, ry, 0, false, true, cx-rx, cy)
+	p.arcTo(rx, ry, 0, false, true, cx+rx, cy)
+	p.closePath()
+
+	return p.b.String(), nil
+}
+
+func linePath(attrs map[string]string) (string, error) {
+	var v [4]float64
+	for i, k := range []string{"x1", "y1", "x2", "y2"} {
+		f, err := parseLengthAttr(attrs, k)
+		if err != nil {
+			return "", err
+		}
+		v[i] = f
+	}
+
+	p := &pathBuilder{}
+	p.moveTo(v[0], v[1])
+	p.lineTo(v[2], v[3])
+
+	return p.b.String(), nil
+}
+
+func polyPath(points string, closed bool) (string, error) {
+	sc := &scanner{s: points}
+	p := &pathBuilder{}
+
+	for i := 0; sc.hasNumber(); i++ {
+		ff, err := sc.numbers(2)
+		if err != nil {
+			return "", errors.Errorf("pdfcpu: svg: invalid points: %s", points)
+		}
+		if i == 0 {
+			p.moveTo(ff[0], ff[1])
+			continue
+		}
+		p.lineTo(ff[0], ff[1])
+	}
+
+	if closed && p.hasSegment {
+		p.closePath()
+	}
+
+	return p.b.String(), nil
+}
+
+// viewport returns the dimensions in points and the viewBox of the root element.
+func viewport(attrs map[string]string) (w, h float64, vb [4]float64, err error) {
+	if s, ok := attrs["viewBox"]; ok {
+		sc := &scanner{s: s}
+		ff, err := sc.numbers(4)
+		if err != nil || ff[2] <= 0 || ff[3] <= 0 {
+			return 0, 0, vb, errors.Errorf("pdfcpu: svg: invalid viewBox: %s", s)
+		}
+		copy(vb[:], ff)
+	}
+
+	if w, err = parseLengthAttr(attrs, "width"); err != nil {
+		return 0, 0, vb, err
+	}
+	if h, err = parseLengthAttr(attrs, "height"); err != nil {
+		return 0, 0, vb, err
+	}
+
+	if vb[2] > 0 {
+		switch {
+		case w <= 0 && h <= 0:
+			w, h = vb[2], vb[3]
+		case w <= 0:
+			w = h * vb[2] / vb[3]
+		case h <= 0:
+			h = w * vb[3] / vb[2]
+		}
+	}
+
+	if w <= 0 || h <= 0 {
+		return 0, 0, vb, errors.New("pdfcpu: svg: missing width, height or viewBox")
+	}
+
+	if vb[2] <= 0 {
+		vb = [4]float64{0, 0, w, h}
+	}
+
+	return w * pxToPt, h * pxToPt, vb, nil
+}
+
+// Parse reads an SVG document from rd and converts it into PDF content.
+func Parse(rd io.Reader) (*SVG, error) {
+	dec := xml.NewDecoder(rd)
+	dec.Entity = xml.HTMLEntity
+
+	var (
+		s *SVG
+		r renderer
+	)
+
+	for {
+		t, err := dec.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: svg")
+		}
+
+		switch t := t.(type) {
+
+		case xml.StartElement:
+			if s == nil {
+				if t.Name.Local != "svg" {
+					return nil, errors.New("pdfcpu: svg: missing root element <svg>")
+				}
+				w, h, vb, err := viewport(attrMap(t))
+				if err != nil {
+					return nil, err
+				}
+				s = &SVG{Width: w, Height: h}
+
+				// Map the viewBox onto (0, 0, w, h) preserving the aspect ratio (xMidYMid meet)
+				// and flip the y axis.
+				sc := math.Min(w/vb[2], h/vb[3])
+				dx := (w-vb[2]*sc)/2 - vb[0]*sc
+				dy := h - (h-vb[3]*sc)/2 + vb[1]*sc
+				writeMatrix(&r.buf, [6]float64{sc, 0, 0, -sc, dx, dy})
+			}
+			if err := r.start(t); err != nil {
+				return nil, err
+			}
+
+		case xml.EndElement:
+			r.end()
+		}
+	}
+
+	if s == nil {
+		return nil, errors.New("pdfcpu: svg: missing root element <svg>")
+	}
+
+	s.content = r.buf.Bytes()
+
+	return s, nil
+}
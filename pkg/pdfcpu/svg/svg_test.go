@@ -0,0 +1,201 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package svg
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
+)
+
+func TestConvertPath(t *testing.T) {
+	for _, tt := range []struct {
+		d, want string
+	}{
+		{"M10 20 L30 40 Z", "10 20 m 30 40 l h "},
+		{"m10,20 l5,5 5-5z", "10 20 m 15 25 l 20 20 l h "},
+		{"M0 0 10 10 20 0", "0 0 m 10 10 l 20 0 l "},
+		{"M1 1H5V7h-2v-1", "1 1 m 5 1 l 5 7 l 3 7 l 3 6 l "},
+		{"M0,0C1,2 3,4 5,6S9,10 11,12", "0 0 m 1 2 3 4 5 6 c 7 8 9 10 11 12 c "},
+		{"M0 0Q3 3 6 0", "0 0 m 2 2 4 2 6 0 c "},
+		{"M.5.5l.5-.5", "0.5 0.5 m 1 0 l "},
+		{"M1e1 2E-1 L 0 0", "10 0.2 m 0 0 l "},
+		{"M0 0A5 5 0 0 0 0 0", "0 0 m "},
+		{"M0 0A0 5 0 0 1 10 10", "0 0 m 10 10 l "},
+	} {
+		got, err := convertPath(tt.d)
+		if err != nil {
+			t.Fatalf("%q: %v\n", tt.d, err)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %q, want %q\n", tt.d, got, tt.want)
+		}
+	}
+}
+
+func TestConvertPathArc(t *testing.T) {
+	// A quarter circle approximated by a single cubic Bézier curve.
+	got, err := convertPath("M10 0 A10 10 0 0 1 0 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "10 0 m 10 5.523 5.523 10 0 10 c "; got != want {
+		t.Errorf("got %q, want %q\n", got, want)
+	}
+
+	// The large arc flags yield 3 quarter segments.
+	got, err = convertPath("M10 0 A10 10 0 1 0 0 10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(got, " c "); n != 3 {
+		t.Errorf("large arc: got %d curves, want 3: %s\n", n, got)
+	}
+	if !strings.HasSuffix(got, " 0 10 c ") {
+		t.Errorf("large arc: must end at 0 10: %s\n", got)
+	}
+}
+
+func TestConvertPathInvalid(t *testing.T) {
+	for _, d := range []string{"L1 1", "M1", "M0 0 X", "M0 0 A1 1 0 2 0 1 1", "M0 0 Z 1 1"} {
+		if _, err := convertPath(d); err == nil {
+			t.Errorf("%q: want error\n", d)
+		}
+	}
+}
+
+func TestTransformMatrices(t *testing.T) {
+	mm, err := transformMatrices("translate(10) scale(2,3), rotate(90 5 5) skewX(0) matrix(1 0 0 1 4 5)")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(mm) != 7 {
+		t.Fatalf("got %d matrices, want 7\n", len(mm))
+	}
+	if mm[0] != [6]float64{1, 0, 0, 1, 10, 0} || mm[1] != [6]float64{2, 0, 0, 3, 0, 0} || mm[6] != [6]float64{1, 0, 0, 1, 4, 5} {
+		t.Errorf("unexpected matrices: %v\n", mm)
+	}
+
+	for _, s := range []string{"translate(1,2,3)", "foo(1)", "scale(1"} {
+		if _, err := transformMatrices(s); err == nil {
+			t.Errorf("%q: want error\n", s)
+		}
+	}
+}
+
+func TestParsePaint(t *testing.T) {
+	for _, tt := range []struct {
+		s    string
+		want paint
+	}{
+		{"none", paint{none: true}},
+		{"#f00", paint{col: color.Red}},
+		{"#0000FF", paint{col: color.Blue}},
+		{"rgb(0, 100%, 0)", paint{col: color.SimpleColor{G: 1}}},
+		{"white", paint{col: color.White}},
+		{"currentColor", paint{currentColor: true}},
+		{"url(#gradient)", paint{none: true}},
+		{"url(#gradient) #fff", paint{col: color.White}},
+	} {
+		got, err := parsePaint(tt.s)
+		if err != nil {
+			t.Fatalf("%q: %v\n", tt.s, err)
+		}
+		if got != tt.want {
+			t.Errorf("%q: got %v, want %v\n", tt.s, got, tt.want)
+		}
+	}
+
+	if _, err := parsePaint("#12"); err == nil {
+		t.Error("#12: want error")
+	}
+}
+
+func TestParse(t *testing.T) {
+	doc := `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" xmlns:inkscape="http://www.inkscape.org/namespaces/inkscape" width="200" height="100" viewBox="0 0 100 50">
+  <title>Logo</title>
+  <defs><linearGradient id="g"/></defs>
+  <inkscape:namedview/>
+  <g fill="#ff0000" transform="translate(10 10)">
+    <rect width="20" height="10"/>
+    <circle cx="40" cy="5" r="5" style="fill:none;stroke:blue;stroke-width:2"/>
+    <path d="M0 20 h10" fill="none" stroke="black" stroke-dasharray="2 1"/>
+    <polygon points="0,30 10,30 5,40" fill-rule="evenodd"/>
+    <rect width="5" height="5" display="none"/>
+  </g>
+</svg>`
+
+	s, err := Parse(strings.NewReader(doc))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if s.Width != 150 || s.Height != 75 {
+		t.Errorf("dimensions: got %.2f x %.2f, want 150 x 75\n", s.Width, s.Height)
+	}
+
+	got := string(s.Content())
+
+	for _, want := range []string{
+		"1.5 0 0 -1.5 0 75 cm ",
+		"q 1 0 0 1 10 10 cm ",
+		"1.00 0.00 0.00 rg 0 0 20 10 re f Q ",
+		"0.00 0.00 1.00 RG 2 w 0 J 0 j 4 M ",
+		"[2 1] 0 d 0 20 m 10 20 l S Q ",
+		"0 30 m 10 30 l 5 40 l h f* Q ",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("content misses %q:\n%s\n", want, got)
+		}
+	}
+
+	if n := strings.Count(got, " re "); n != 1 {
+		t.Errorf("hidden rect must not be rendered:\n%s\n", got)
+	}
+
+	if strings.Count(got, "q ") != strings.Count(got, "Q ") {
+		t.Errorf("unbalanced graphics state:\n%s\n", got)
+	}
+}
+
+func TestParseViewport(t *testing.T) {
+	for _, tt := range []struct {
+		attrs string
+		w, h  float64
+	}{
+		{`viewBox="0 0 400 200"`, 300, 150},
+		{`width="100mm" height="50mm"`, 283.465, 141.732},
+		{`width="80" viewBox="0 0 40 10"`, 60, 15},
+		{`width="72pt" height="144pt"`, 72, 144},
+	} {
+		s, err := Parse(strings.NewReader(`<svg xmlns="http://www.w3.org/2000/svg" ` + tt.attrs + `/>`))
+		if err != nil {
+			t.Fatalf("%s: %v\n", tt.attrs, err)
+		}
+		if fmtNum(s.Width) != fmtNum(tt.w) || fmtNum(s.Height) != fmtNum(tt.h) {
+			t.Errorf("%s: got %s x %s, want %s x %s\n", tt.attrs, fmtNum(s.Width), fmtNum(s.Height), fmtNum(tt.w), fmtNum(tt.h))
+		}
+	}
+
+	for _, doc := range []string{`<svg/>`, `<html/>`, `<svg viewBox="0 0 0 10"/>`, `<svg width="10" height="10"><path d="M"/></svg>`, `not xml`} {
+		if _, err := Parse(strings.NewReader(doc)); err == nil {
+			t.Errorf("%s: want error\n", doc)
+		}
+	}
+}
@@ -0,0 +1,630 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+const (
+	rulingTolerance    = 2.0 // Max distance in user space units for ruling lines to be considered connected.
+	minWhitespaceRows  = 3   // Min number of rows of a table delimited by white space.
+	maxWordsPerCell    = 4.0 // Max average number of words per cell of a table delimited by white space.
+	columnGapFontSizes = 1.0 // Min gap between columns in multiples of the font size.
+)
+
+// tableWord is a run of glyphs without visual gaps.
+type tableWord struct {
+	s    string
+	rect types.Rectangle
+	fs   float64
+}
+
+func (w tableWord) center() types.Point {
+	return types.Point{X: (w.rect.LL.X + w.rect.UR.X) / 2, Y: (w.rect.LL.Y + w.rect.UR.Y) / 2}
+}
+
+func unionRect(r1, r2 types.Rectangle) types.Rectangle {
+	return types.Rectangle{
+		LL: types.Point{X: math.Min(r1.LL.X, r2.LL.X), Y: math.Min(r1.LL.Y, r2.LL.Y)},
+		UR: types.Point{X: math.Max(r1.UR.X, r2.UR.X), Y: math.Max(r1.UR.Y, r2.UR.Y)},
+	}
+}
+
+// tableWords assembles gg into words using the same gap heuristics as textLines.
+func tableWords(gg []textGlyph) []tableWord {
+	var (
+		ww []tableWord
+		w  *tableWord
+	)
+
+	flush := func() {
+		if w != nil {
+			ww = append(ww, *w)
+		}
+		w = nil
+	}
+
+	for i, g := range gg {
+		if strings.TrimSpace(g.s) == "" {
+			flush()
+			continue
+		}
+		if w != nil {
+			prev := gg[i-1]
+			fs := math.Max(1, math.Max(prev.fontSize, g.fontSize))
+			dy := math.Abs((g.rect.LL.Y + g.rect.UR.Y - prev.rect.LL.Y - prev.rect.UR.Y) / 2)
+			dx := g.rect.LL.X - prev.rect.UR.X
+			if dy > fs/2 || dx < -fs || dx > fs/5 {
+				flush()
+			}
+		}
+		if w == nil {
+			w = &tableWord{rect: g.rect, fs: g.fontSize}
+		} else {
+			w.rect = unionRect(w.rect, g.rect)
+			w.fs = math.Max(w.fs, g.fontSize)
+		}
+		w.s += g.s
+	}
+	flush()
+
+	return ww
+}
+
+// textRow is a line of words sorted from left to right.
+type textRow struct {
+	ww          []tableWord
+	top, bottom float64
+	cy, fs      float64
+}
+
+// textRows groups ww into lines sorted from top to bottom.
+func textRows(ww []tableWord) []textRow {
+	ww = append([]tableWord(nil), ww...)
+	sort.SliceStable(ww, func(i, j int) bool { return ww[i].center().Y > ww[j].center().Y })
+
+	var rr []textRow
+	for _, w := range ww {
+		c := w.center()
+		if n := len(rr); n > 0 && math.Abs(rr[n-1].cy-c.Y) <= math.Max(rr[n-1].fs, w.fs)/2 {
+			r := &rr[n-1]
+			r.ww = append(r.ww, w)
+			r.top, r.bottom = math.Max(r.top, w.rect.UR.Y), math.Min(r.bottom, w.rect.LL.Y)
+			r.fs = math.Max(r.fs, w.fs)
+			continue
+		}
+		rr = append(rr, textRow{ww: []tableWord{w}, top: w.rect.UR.Y, bottom: w.rect.LL.Y, cy: c.Y, fs: w.fs})
+	}
+
+	for _, r := range rr {
+		sort.SliceStable(r.ww, func(i, j int) bool { return r.ww[i].rect.LL.X < r.ww[j].rect.LL.X })
+	}
+
+	return rr
+}
+
+// cellText returns the text of the words of a cell in reading order.
+func cellText(ww []tableWord) string {
+	var ss []string
+	for _, r := range textRows(ww) {
+		for _, w := range r.ww {
+			ss = append(ss, w.s)
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+// ruling is a horizontal or vertical line segment delimiting table cells.
+type ruling struct {
+	horizontal bool
+	pos        float64 // y of a horizontal, x of a vertical ruling.
+	from, to   float64
+}
+
+func newRuling(p1, p2 types.Point) (ruling, bool) {
+	dx, dy := math.Abs(p2.X-p1.X), math.Abs(p2.Y-p1.Y)
+	switch {
+	case dy <= rulingTolerance && dx > rulingTolerance:
+		return ruling{horizontal: true, pos: (p1.Y + p2.Y) / 2, from: math.Min(p1.X, p2.X), to: math.Max(p1.X, p2.X)}, true
+	case dx <= rulingTolerance && dy > rulingTolerance:
+		return ruling{pos: (p1.X + p2.X) / 2, from: math.Min(p1.Y, p2.Y), to: math.Max(p1.Y, p2.Y)}, true
+	}
+	return ruling{}, false
+}
+
+// pathRulings returns the straight lines of stroked paths and the thin rectangles of filled paths.
+func pathRulings(p model.VectorPath) []ruling {
+	var rr []ruling
+
+	if p.Stroke && !white(p.StrokeColor) {
+		var cur, start types.Point
+		for _, seg := range p.Segments {
+			switch seg.Op {
+			case "m":
+				cur, start = seg.Points[0], seg.Points[0]
+			case "l":
+				if r, ok := newRuling(cur, seg.Points[0]); ok {
+					rr = append(rr, r)
+				}
+				cur = seg.Points[0]
+			case "c":
+				cur = seg.Points[len(seg.Points)-1]
+			case "h":
+				if r, ok := newRuling(cur, start); ok {
+					rr = append(rr, r)
+				}
+				cur = start
+			}
+		}
+		return rr
+	}
+
+	if !p.Fill || white(p.FillColor) {
+		return nil
+	}
+
+	var pp []types.Point
+	for _, seg := range p.Segments {
+		if seg.Op == "c" {
+			return nil
+		}
+		pp = append(pp, seg.Points...)
+	}
+	if len(pp) == 0 {
+		return nil
+	}
+
+	bb := boundingBox(pp)
+	w, h := bb.Width(), bb.Height()
+	switch {
+	case h <= rulingTolerance && w > rulingTolerance:
+		rr = append(rr, ruling{horizontal: true, pos: (bb.LL.Y + bb.UR.Y) / 2, from: bb.LL.X, to: bb.UR.X})
+	case w <= rulingTolerance && h > rulingTolerance:
+		rr = append(rr, ruling{pos: (bb.LL.X + bb.UR.X) / 2, from: bb.LL.Y, to: bb.UR.Y})
+	}
+
+	return rr
+}
+
+// mergeRulings joins collinear rulings that overlap or touch.
+func mergeRulings(rr []ruling) []ruling {
+	sort.Slice(rr, func(i, j int) bool {
+		if rr[i].horizontal != rr[j].horizontal {
+			return rr[i].horizontal
+		}
+		if rr[i].pos != rr[j].pos {
+			return rr[i].pos < rr[j].pos
+		}
+		return rr[i].from < rr[j].from
+	})
+
+	var merged []ruling
+	for _, r := range rr {
+		if n := len(merged); n > 0 {
+			m := &merged[n-1]
+			if m.horizontal == r.horizontal && math.Abs(m.pos-r.pos) <= rulingTolerance && r.from <= m.to+rulingTolerance {
+				m.to = math.Max(m.to, r.to)
+				continue
+			}
+		}
+		merged = append(merged, r)
+	}
+
+	return merged
+}
+
+func intersect(h, v ruling) bool {
+	return v.pos >= h.from-rulingTolerance && v.pos <= h.to+rulingTolerance &&
+		h.pos >= v.from-rulingTolerance && h.pos <= v.to+rulingTolerance
+}
+
+// rulingGroups returns the sets of connected rulings.
+func rulingGroups(rr []ruling) [][]ruling {
+	parent := make([]int, len(rr))
+	for i := range parent {
+		parent[i] = i
+	}
+	var find func(int) int
+	find = func(i int) int {
+		if parent[i] != i {
+			parent[i] = find(parent[i])
+		}
+		return parent[i]
+	}
+
+	for i, r1 := range rr {
+		for j := i + 1; j < len(rr); j++ {
+			r2 := rr[j]
+			if r1.horizontal == r2.horizontal {
+				continue
+			}
+			h, v := r1, r2
+			if !h.horizontal {
+				h, v = v, h
+			}
+			if intersect(h, v) {
+				parent[find(i)] = find(j)
+			}
+		}
+	}
+
+	m := map[int][]ruling{}
+	var roots []int
+	for i, r := range rr {
+		root := find(i)
+		if _, ok := m[root]; !ok {
+			roots = append(roots, root)
+		}
+		m[root] = append(m[root], r)
+	}
+
+	gg := make([][]ruling, len(roots))
+	for i, root := range roots {
+		gg[i] = m[root]
+	}
+	return gg
+}
+
+// distinct returns the sorted values of ff merging values closer than rulingTolerance.
+func distinct(ff []float64) []float64 {
+	sort.Float64s(ff)
+	var dd []float64
+	for _, f := range ff {
+		if n := len(dd); n > 0 && f-dd[n-1] <= rulingTolerance {
+			continue
+		}
+		dd = append(dd, f)
+	}
+	return dd
+}
+
+// gridBounds returns the column boundaries from left to right and the row boundaries from top to bottom of a ruling group.
+// Missing outer borders are assumed to be at the ends of the rulings.
+func gridBounds(rr []ruling) (xs, ys []float64) {
+	for _, r := range rr {
+		if r.horizontal {
+			ys = append(ys, r.pos)
+			xs = append(xs, r.from, r.to)
+			continue
+		}
+		xs = append(xs, r.pos)
+		ys = append(ys, r.from, r.to)
+	}
+
+	var vx, hy []float64
+	for _, r := range rr {
+		if r.horizontal {
+			hy = append(hy, r.pos)
+		} else {
+			vx = append(vx, r.pos)
+		}
+	}
+	xs, ys = distinct(xs), distinct(ys)
+	vx, hy = distinct(vx), distinct(hy)
+
+	// Only keep the ends of the rulings if they extend beyond the outer rulings.
+	keep := func(ff, inner []float64) []float64 {
+		var kk []float64
+		for i, f := range ff {
+			isInner := false
+			for _, g := range inner {
+				if math.Abs(f-g) <= rulingTolerance {
+					isInner = true
+					break
+				}
+			}
+			if isInner || i == 0 && f < inner[0]-rulingTolerance || i == len(ff)-1 && f > inner[len(inner)-1]+rulingTolerance {
+				kk = append(kk, f)
+			}
+		}
+		return distinct(kk)
+	}
+
+	xs, ys = keep(xs, vx), keep(ys, hy)
+	for i, j := 0, len(ys)-1; i < j; i, j = i+1, j-1 {
+		ys[i], ys[j] = ys[j], ys[i]
+	}
+
+	return xs, ys
+}
+
+func rect4(llx, lly, urx, ury float64) [4]float64 {
+	return [4]float64{round2(llx), round2(lly), round2(urx), round2(ury)}
+}
+
+func contains(r [4]float64, p types.Point) bool {
+	return p.X >= r[0] && p.X <= r[2] && p.Y >= r[1] && p.Y <= r[3]
+}
+
+// newTable returns a table for the given boundaries and assigns the words located within its cells.
+func newTable(pageNr int, method string, xs, ys []float64, ww []tableWord) model.Table {
+	t := model.Table{
+		PageNr: pageNr,
+		Method: method,
+		Rect:   rect4(xs[0], ys[len(ys)-1], xs[len(xs)-1], ys[0]),
+		Rows:   len(ys) - 1,
+		Cols:   len(xs) - 1,
+	}
+
+	cellWords := make([][]tableWord, t.Rows*t.Cols)
+	for _, w := range ww {
+		c := w.center()
+		col := sort.SearchFloat64s(xs, c.X) - 1
+		row := sort.Search(len(ys), func(i int) bool { return ys[i] < c.Y }) - 1
+		if col < 0 || col >= t.Cols || row < 0 || row >= t.Rows {
+			continue
+		}
+		cellWords[row*t.Cols+col] = append(cellWords[row*t.Cols+col], w)
+	}
+
+	for row := 0; row < t.Rows; row++ {
+		for col := 0; col < t.Cols; col++ {
+			t.Cells = append(t.Cells, model.TableCell{
+				Row:  row,
+				Col:  col,
+				Rect: rect4(xs[col], ys[row+1], xs[col+1], ys[row]),
+				Text: cellText(cellWords[row*t.Cols+col]),
+			})
+		}
+	}
+
+	return t
+}
+
+// splitRows subdivides rows delimited by ruling lines into the lines of text they contain
+// if the lines of all non-empty cells of a row line up, as for tables with ruled columns only.
+func splitRows(xs, ys []float64, ww []tableWord) []float64 {
+	split := []float64{ys[0]}
+
+	for row := 0; row+1 < len(ys); row++ {
+		var rowWords []tableWord
+		colWords := make([][]tableWord, len(xs)-1)
+		for _, w := range ww {
+			c := w.center()
+			if c.Y > ys[row] || c.Y <= ys[row+1] {
+				continue
+			}
+			col := sort.SearchFloat64s(xs, c.X) - 1
+			if col < 0 || col >= len(colWords) {
+				continue
+			}
+			rowWords = append(rowWords, w)
+			colWords[col] = append(colWords[col], w)
+		}
+
+		rr := textRows(rowWords)
+		lined := len(rr) > 1
+		var cells int
+		for _, cw := range colWords {
+			if len(cw) == 0 {
+				continue
+			}
+			cells++
+			if len(textRows(cw)) != len(rr) {
+				lined = false
+			}
+		}
+
+		if lined && cells > 1 {
+			for i := 1; i < len(rr); i++ {
+				split = append(split, (rr[i-1].bottom+rr[i].top)/2)
+			}
+		}
+		split = append(split, ys[row+1])
+	}
+
+	return split
+}
+
+func emptyCells(t model.Table) int {
+	var n int
+	for _, c := range t.Cells {
+		if c.Text == "" {
+			n++
+		}
+	}
+	return n
+}
+
+// rulingTables returns the tables delimited by ruling lines.
+func rulingTables(pageNr int, vp *model.PageVectorPaths, ww []tableWord) []model.Table {
+	var rr []ruling
+	for _, p := range vp.Paths {
+		rr = append(rr, pathRulings(p)...)
+	}
+
+	var tt []model.Table
+	for _, g := range rulingGroups(mergeRulings(rr)) {
+		var h, v int
+		for _, r := range g {
+			if r.horizontal {
+				h++
+			} else {
+				v++
+			}
+		}
+		if h < 2 || v < 1 {
+			continue
+		}
+		xs, ys := gridBounds(g)
+		if len(xs) < 2 || len(ys) < 2 || (len(xs)-1)*(len(ys)-1) < 2 {
+			continue
+		}
+		t := newTable(pageNr, model.TableRuling, xs, splitRows(xs, ys, ww), ww)
+		if len(t.Cells)-emptyCells(t) < 2 {
+			continue
+		}
+		tt = append(tt, t)
+	}
+
+	return tt
+}
+
+// textChunk is a run of words of a row separated by gaps less than a column gap.
+type textChunk struct {
+	ww       []tableWord
+	from, to float64
+}
+
+func rowChunks(r textRow) []textChunk {
+	var cc []textChunk
+	for i, w := range r.ww {
+		if i > 0 && w.rect.LL.X-r.ww[i-1].rect.UR.X <= columnGapFontSizes*math.Max(w.fs, r.ww[i-1].fs) {
+			c := &cc[len(cc)-1]
+			c.ww = append(c.ww, w)
+			c.to = math.Max(c.to, w.rect.UR.X)
+			continue
+		}
+		cc = append(cc, textChunk{ww: []tableWord{w}, from: w.rect.LL.X, to: w.rect.UR.X})
+	}
+	return cc
+}
+
+// whitespaceTable returns a table for rows whose chunks line up in at least two columns.
+func whitespaceTable(pageNr int, rows []textRow, chunks [][]textChunk) (model.Table, bool) {
+	if len(rows) < minWhitespaceRows {
+		return model.Table{}, false
+	}
+
+	var (
+		cols  []textChunk
+		words int
+		n     int
+	)
+	for _, cc := range chunks {
+		for _, c := range cc {
+			cols = append(cols, textChunk{from: c.from, to: c.to})
+			words += len(c.ww)
+			n++
+		}
+	}
+
+	// Prose set in multiple columns is not a table.
+	if float64(words)/float64(n) > maxWordsPerCell {
+		return model.Table{}, false
+	}
+
+	sort.Slice(cols, func(i, j int) bool { return cols[i].from < cols[j].from })
+	merged := cols[:1]
+	for _, c := range cols[1:] {
+		m := &merged[len(merged)-1]
+		if c.from <= m.to {
+			m.to = math.Max(m.to, c.to)
+			continue
+		}
+		merged = append(merged, c)
+	}
+	if len(merged) < 2 {
+		return model.Table{}, false
+	}
+
+	xs := []float64{merged[0].from}
+	for i := 1; i < len(merged); i++ {
+		xs = append(xs, (merged[i-1].to+merged[i].from)/2)
+	}
+	xs = append(xs, merged[len(merged)-1].to)
+
+	ys := []float64{rows[0].top}
+	for i := 1; i < len(rows); i++ {
+		ys = append(ys, (rows[i-1].bottom+rows[i].top)/2)
+	}
+	ys = append(ys, rows[len(rows)-1].bottom)
+
+	var ww []tableWord
+	for _, r := range rows {
+		ww = append(ww, r.ww...)
+	}
+
+	return newTable(pageNr, model.TableWhitespace, xs, ys, ww), true
+}
+
+// whitespaceTables returns the tables made up of consecutive rows with columns delimited by white space.
+func whitespaceTables(pageNr int, ww []tableWord) []model.Table {
+	var (
+		tt     []model.Table
+		rows   []textRow
+		chunks [][]textChunk
+	)
+
+	flush := func() {
+		if t, ok := whitespaceTable(pageNr, rows, chunks); ok {
+			tt = append(tt, t)
+		}
+		rows, chunks = nil, nil
+	}
+
+	for _, r := range textRows(ww) {
+		cc := rowChunks(r)
+		if len(cc) < 2 {
+			flush()
+			continue
+		}
+		if n := len(rows); n > 0 && rows[n-1].cy-r.cy > 2.5*math.Max(rows[n-1].fs, r.fs) {
+			flush()
+		}
+		rows, chunks = append(rows, r), append(chunks, cc)
+	}
+	flush()
+
+	return tt
+}
+
+// PageTables returns the tables detected on page pageNr sorted from top to bottom.
+// Tables are either delimited by ruling lines or by vertical white space between columns of text.
+// Coordinates are given in default user space, page rotation is not applied.
+func PageTables(ctx *model.Context, pageNr int) ([]model.Table, error) {
+	gg, err := pageGlyphs(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	vp, err := PageVectorPaths(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	ww := tableWords(gg)
+
+	tt := rulingTables(pageNr, vp, ww)
+
+	// Words of tables delimited by ruling lines are not considered for white space tables.
+	var free []tableWord
+	for _, w := range ww {
+		inside := false
+		for _, t := range tt {
+			if contains(t.Rect, w.center()) {
+				inside = true
+				break
+			}
+		}
+		if !inside {
+			free = append(free, w)
+		}
+	}
+
+	tt = append(tt, whitespaceTables(pageNr, free)...)
+
+	sort.SliceStable(tt, func(i, j int) bool { return tt[i].Rect[3] > tt[j].Rect[3] })
+
+	return tt, nil
+}
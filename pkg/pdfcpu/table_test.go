@@ -0,0 +1,135 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// tableTestContent returns a page content stream drawing a table with ruling lines,
+// a table using white space to delimit its columns and a paragraph.
+func tableTestContent() string {
+	var sb strings.Builder
+
+	text := func(x, y float64, s string) {
+		fmt.Fprintf(&sb, "BT /F1 10 Tf %.2f %.2f Td (%s) Tj ET\n", x, y, s)
+	}
+
+	// Ruling lines with the outer left and right borders missing.
+	sb.WriteString("0.5 w\n")
+	for _, y := range []float64{700, 680, 660, 640} {
+		fmt.Fprintf(&sb, "100 %.2f m 400 %.2f l S\n", y, y)
+	}
+	for _, x := range []float64{200, 300} {
+		fmt.Fprintf(&sb, "%.2f 640 m %.2f 700 l S\n", x, x)
+	}
+	for i, row := range [][]string{{"Name", "Qty", "Price"}, {"Apples", "3", "1.20"}, {"Pears", "", "0.80"}} {
+		for j, s := range row {
+			if s != "" {
+				text(105+float64(j)*100, 686-float64(i)*20, s)
+			}
+		}
+	}
+
+	for i, row := range [][]string{{"Year", "Revenue", "Net income"}, {"2023", "1,200", "150"}, {"2024", "1,450", "210"}} {
+		for j, s := range row {
+			text(100+float64(j)*150, 500-float64(i)*14, s)
+		}
+	}
+
+	text(100, 400, "Just a paragraph of prose.")
+
+	return sb.String()
+}
+
+func tableTestContext(t *testing.T) *model.Context {
+	t.Helper()
+
+	ctx, err := ReadFile(filepath.Join("..", "testdata", "mountain.pdf"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ctx.EnsurePageCount(); err != nil {
+		t.Fatal(err)
+	}
+
+	d, _, _, err := ctx.PageDict(1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ir, err := newContentStream(ctx, []byte(tableTestContent()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	d["Contents"] = *ir
+	d["Resources"] = types.Dict{
+		"Font": types.Dict{
+			"F1": types.Dict{"Type": types.Name("Font"), "Subtype": types.Name("Type1"), "BaseFont": types.Name("Helvetica")},
+		},
+	}
+
+	return ctx
+}
+
+func TestPageTables(t *testing.T) {
+	ctx := tableTestContext(t)
+
+	tt, err := PageTables(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tt) != 2 {
+		t.Fatalf("got %d tables, want 2: %+v\n", len(tt), tt)
+	}
+
+	for i, want := range []struct {
+		method string
+		rect   [4]float64
+		rr     [][]string
+	}{
+		{model.TableRuling, [4]float64{100, 640, 400, 700}, [][]string{{"Name", "Qty", "Price"}, {"Apples", "3", "1.20"}, {"Pears", "", "0.80"}}},
+		{model.TableWhitespace, [4]float64{}, [][]string{{"Year", "Revenue", "Net income"}, {"2023", "1,200", "150"}, {"2024", "1,450", "210"}}},
+	} {
+		tb := tt[i]
+		if tb.Method != want.method {
+			t.Errorf("table %d: got method %s, want %s\n", i, tb.Method, want.method)
+		}
+		if want.rect != [4]float64{} && tb.Rect != want.rect {
+			t.Errorf("table %d: got rect %v, want %v\n", i, tb.Rect, want.rect)
+		}
+		if got := tb.Records(); !reflect.DeepEqual(got, want.rr) {
+			t.Errorf("table %d: got %q, want %q\n", i, got, want.rr)
+		}
+		if len(tb.Cells) != tb.Rows*tb.Cols {
+			t.Errorf("table %d: got %d cells for %d x %d\n", i, len(tb.Cells), tb.Rows, tb.Cols)
+		}
+	}
+
+	// The second cell of the ruling table.
+	if c := tt[0].Cells[1]; c.Rect != [4]float64{200, 680, 300, 700} {
+		t.Errorf("got cell rect %v\n", c.Rect)
+	}
+}
@@ -0,0 +1,563 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/hex"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// textGlyph represents a decoded character code shown on a page.
+type textGlyph struct {
+	s        string          // Unicode text.
+	rect     types.Rectangle // Bounding box in user space.
+	fontSize float64         // Font size in user space.
+	angle    float64         // Baseline direction in degrees counterclockwise in user space.
+}
+
+// textFont holds what is needed to decode and measure the strings shown using a font.
+type textFont struct {
+	twoByte         bool
+	coreFont        string
+	firstChar       int
+	widths          []float64
+	cidWidths       map[int]float64
+	dw              float64
+	ascent, descent float64
+	toUnicode       map[int]string
+}
+
+func (f *textFont) width(code int) float64 {
+	if f.twoByte {
+		if w, ok := f.cidWidths[code]; ok {
+			return w
+		}
+		return f.dw
+	}
+	if i := code - f.firstChar; f.widths != nil && i >= 0 && i < len(f.widths) {
+		return f.widths[i]
+	}
+	if f.coreFont != "" {
+		return float64(font.CharWidth(f.coreFont, rune(code)))
+	}
+	return f.dw
+}
+
+func (f *textFont) text(code int) string {
+	if s, ok := f.toUnicode[code]; ok {
+		return s
+	}
+	// Good enough for the ASCII range of WinAnsi, MacRoman and StandardEncoding.
+	return string(rune(code))
+}
+
+func (f *textFont) codes(bb []byte) []int {
+	var cc []int
+	if f.twoByte {
+		for i := 0; i+1 < len(bb); i += 2 {
+			cc = append(cc, int(bb[i])<<8|int(bb[i+1]))
+		}
+		return cc
+	}
+	for _, b := range bb {
+		cc = append(cc, int(b))
+	}
+	return cc
+}
+
+func numberArray(ctx *model.Context, o types.Object) []float64 {
+	a, err := ctx.DereferenceArray(o)
+	if err != nil || a == nil {
+		return nil
+	}
+	ff := make([]float64, len(a))
+	for i, o := range a {
+		if f, err := ctx.DereferenceNumber(o); err == nil {
+			ff[i] = f
+		}
+	}
+	return ff
+}
+
+func cidWidths(ctx *model.Context, o types.Object) map[int]float64 {
+	m := map[int]float64{}
+	a, err := ctx.DereferenceArray(o)
+	if err != nil || a == nil {
+		return m
+	}
+	for i := 0; i+1 < len(a); {
+		c, err := ctx.DereferenceNumber(a[i])
+		if err != nil {
+			return m
+		}
+		o, _ := ctx.Dereference(a[i+1])
+		if arr, ok := o.(types.Array); ok {
+			// c [w1 w2 .. wn]
+			for j, w := range numberArray(ctx, arr) {
+				m[int(c)+j] = w
+			}
+			i += 2
+			continue
+		}
+		// cFirst cLast w
+		if i+2 >= len(a) {
+			return m
+		}
+		cLast, err1 := ctx.DereferenceNumber(a[i+1])
+		w, err2 := ctx.DereferenceNumber(a[i+2])
+		if err1 != nil || err2 != nil {
+			return m
+		}
+		for j := int(c); j <= int(cLast); j++ {
+			m[j] = w
+		}
+		i += 3
+	}
+	return m
+}
+
+func utf16BEString(bb []byte) string {
+	if len(bb) == 1 {
+		return string(rune(bb[0]))
+	}
+	u := make([]uint16, len(bb)/2)
+	for i := range u {
+		u[i] = uint16(bb[2*i])<<8 | uint16(bb[2*i+1])
+	}
+	return string(utf16.Decode(u))
+}
+
+func cMapHex(t string) ([]byte, bool) {
+	if len(t) < 2 || t[0] != '<' || t[len(t)-1] != '>' {
+		return nil, false
+	}
+	s := strings.Join(strings.Fields(t[1:len(t)-1]), "")
+	if len(s)%2 > 0 {
+		s += "0"
+	}
+	bb, err := hex.DecodeString(s)
+	return bb, err == nil
+}
+
+func cMapCode(bb []byte) int {
+	c := 0
+	for _, b := range bb {
+		c = c<<8 | int(b)
+	}
+	return c
+}
+
+// parseToUnicodeCMap returns the code to Unicode mapping defined by the bfchar and bfrange sections of a ToUnicode CMap.
+func parseToUnicodeCMap(bb []byte) map[int]string {
+	m := map[int]string{}
+
+	var tt []string
+	for i := 0; i < len(bb); {
+		c := bb[i]
+		switch {
+		case isContentWhitespace(c):
+			i++
+		case c == '<':
+			k := bytes.IndexByte(bb[i:], '>')
+			if k < 0 {
+				return m
+			}
+			tt = append(tt, string(bb[i:i+k+1]))
+			i += k + 1
+		case c == '[' || c == ']':
+			tt = append(tt, string(c))
+			i++
+		default:
+			j := i + 1
+			for j < len(bb) && !isContentWhitespace(bb[j]) && !isContentDelimiter(bb[j]) {
+				j++
+			}
+			tt = append(tt, string(bb[i:j]))
+			i = j
+		}
+	}
+
+	for i := 0; i < len(tt); i++ {
+		switch tt[i] {
+
+		case "beginbfchar":
+			for i++; i+1 < len(tt) && tt[i] != "endbfchar"; i += 2 {
+				src, ok1 := cMapHex(tt[i])
+				dst, ok2 := cMapHex(tt[i+1])
+				if ok1 && ok2 {
+					m[cMapCode(src)] = utf16BEString(dst)
+				}
+			}
+
+		case "beginbfrange":
+			for i++; i+2 < len(tt) && tt[i] != "endbfrange"; {
+				lo, ok1 := cMapHex(tt[i])
+				hi, ok2 := cMapHex(tt[i+1])
+				if !ok1 || !ok2 {
+					i++
+					continue
+				}
+				from, thru := cMapCode(lo), cMapCode(hi)
+				if tt[i+2] == "[" {
+					j := i + 3
+					for c := from; j < len(tt) && tt[j] != "]"; c, j = c+1, j+1 {
+						if dst, ok := cMapHex(tt[j]); ok {
+							m[c] = utf16BEString(dst)
+						}
+					}
+					i = j + 1
+					continue
+				}
+				dst, ok := cMapHex(tt[i+2])
+				if ok && len(dst) > 0 && thru-from < 0x10000 {
+					for c := from; c <= thru; c++ {
+						dst1 := append([]byte{}, dst...)
+						dst1[len(dst1)-1] += byte(c - from)
+						m[c] = utf16BEString(dst1)
+					}
+				}
+				i += 3
+			}
+		}
+	}
+
+	return m
+}
+
+func fontDescriptorMetrics(ctx *model.Context, d types.Dict, f *textFont) {
+	fd, err := ctx.DereferenceDict(d["FontDescriptor"])
+	if err != nil || fd == nil {
+		return
+	}
+	if o, found := fd.Find("Ascent"); found {
+		if a, err := ctx.DereferenceNumber(o); err == nil && a > 0 {
+			f.ascent = a
+		}
+	}
+	if o, found := fd.Find("Descent"); found {
+		if d, err := ctx.DereferenceNumber(o); err == nil && d < 0 {
+			f.descent = d
+		}
+	}
+}
+
+func newTextFont(ctx *model.Context, d types.Dict) *textFont {
+	f := &textFont{dw: 500, ascent: 800, descent: -200}
+
+	if bf := d.NameEntry("BaseFont"); bf != nil && font.IsCoreFont(*bf) {
+		f.coreFont = *bf
+		bb := font.BoundingBox(*bf)
+		f.ascent, f.descent = bb.UR.Y, bb.LL.Y
+	}
+
+	if st := d.NameEntry("Subtype"); st != nil && *st == "Type0" {
+		f.twoByte, f.dw = true, 1000
+		if a, err := ctx.DereferenceArray(d["DescendantFonts"]); err == nil && len(a) > 0 {
+			if df, err := ctx.DereferenceDict(a[0]); err == nil && df != nil {
+				if o, found := df.Find("DW"); found {
+					if w, err := ctx.DereferenceNumber(o); err == nil {
+						f.dw = w
+					}
+				}
+				f.cidWidths = cidWidths(ctx, df["W"])
+				fontDescriptorMetrics(ctx, df, f)
+			}
+		}
+	} else {
+		if i := d.IntEntry("FirstChar"); i != nil {
+			f.firstChar = *i
+		}
+		f.widths = numberArray(ctx, d["Widths"])
+		fontDescriptorMetrics(ctx, d, f)
+	}
+
+	if sd, _, err := ctx.DereferenceStreamDict(d["ToUnicode"]); err == nil && sd != nil {
+		if err := sd.Decode(); err == nil {
+			f.toUnicode = parseToUnicodeCMap(sd.Content)
+		}
+	}
+
+	return f
+}
+
+type textState struct {
+	ctm                matrix.Matrix
+	font               *textFont
+	fs, tc, tw, th, tl float64
+	rise               float64
+}
+
+type textExtractor struct {
+	ctx     *model.Context
+	resDict types.Dict
+	fonts   map[string]*textFont
+	stack   []textState
+	textState
+	tm, tlm matrix.Matrix
+	glyphs  []textGlyph
+}
+
+func (te *textExtractor) fontForName(name string) *textFont {
+	if f, ok := te.fonts[name]; ok {
+		return f
+	}
+	var f *textFont
+	if fonts, err := te.ctx.DereferenceDict(te.resDict["Font"]); err == nil && fonts != nil {
+		if d, err := te.ctx.DereferenceDict(fonts[name]); err == nil && d != nil {
+			f = newTextFont(te.ctx, d)
+		}
+	}
+	te.fonts[name] = f
+	return f
+}
+
+func operandNumbers(operands []string) []float64 {
+	ff := make([]float64, len(operands))
+	for i, s := range operands {
+		ff[i], _ = strconv.ParseFloat(s, 64)
+	}
+	return ff
+}
+
+func matrixFromOperands(ff []float64) matrix.Matrix {
+	return matrix.Matrix{{ff[0], ff[1], 0}, {ff[2], ff[3], 0}, {ff[4], ff[5], 1}}
+}
+
+func translation(tx, ty float64) matrix.Matrix {
+	return matrix.Matrix{{1, 0, 0}, {0, 1, 0}, {tx, ty, 1}}
+}
+
+// contentStringBytes decodes a literal or hex string operand.
+func contentStringBytes(t string) []byte {
+	if bb, ok := cMapHex(t); ok {
+		return bb
+	}
+	if len(t) >= 2 && t[0] == '(' && t[len(t)-1] == ')' {
+		bb, err := types.Unescape(t[1 : len(t)-1])
+		if err == nil {
+			return bb
+		}
+	}
+	return nil
+}
+
+// textArrayElements splits a TJ array operand into string and number elements.
+func textArrayElements(t string) []string {
+	var ss []string
+	s := []byte(strings.TrimSuffix(strings.TrimPrefix(t, "["), "]"))
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case isContentWhitespace(c):
+			i++
+		case c == '(':
+			j := skipContentString(s, i)
+			ss = append(ss, string(s[i:j]))
+			i = j
+		case c == '<':
+			j := len(s)
+			if k := bytes.IndexByte(s[i:], '>'); k >= 0 {
+				j = i + k + 1
+			}
+			ss = append(ss, string(s[i:j]))
+			i = j
+		default:
+			j := i + 1
+			for j < len(s) && !isContentWhitespace(s[j]) && !isContentDelimiter(s[j]) {
+				j++
+			}
+			ss = append(ss, string(s[i:j]))
+			i = j
+		}
+	}
+	return ss
+}
+
+func (te *textExtractor) nextLine(tx, ty float64) {
+	te.tlm = translation(tx, ty).Multiply(te.tlm)
+	te.tm = te.tlm
+}
+
+func (te *textExtractor) showString(bb []byte) {
+	f := te.font
+	if f == nil {
+		return
+	}
+
+	for _, code := range f.codes(bb) {
+		w0 := f.width(code) / 1000
+
+		trm := matrix.Matrix{{te.fs * te.th, 0, 0}, {0, te.fs, 0}, {0, te.rise, 1}}.Multiply(te.tm).Multiply(te.ctm)
+
+		var llx, lly, urx, ury float64
+		for i, p := range []types.Point{
+			{X: 0, Y: f.descent / 1000}, {X: w0, Y: f.descent / 1000},
+			{X: w0, Y: f.ascent / 1000}, {X: 0, Y: f.ascent / 1000}} {
+			q := trm.Transform(p)
+			if i == 0 {
+				llx, lly, urx, ury = q.X, q.Y, q.X, q.Y
+				continue
+			}
+			llx, lly = math.Min(llx, q.X), math.Min(lly, q.Y)
+			urx, ury = math.Max(urx, q.X), math.Max(ury, q.Y)
+		}
+
+		te.glyphs = append(te.glyphs, textGlyph{
+			s:        f.text(code),
+			rect:     *types.NewRectangle(llx, lly, urx, ury),
+			fontSize: math.Hypot(trm[1][0], trm[1][1]),
+			angle:    math.Atan2(trm[0][1], trm[0][0]) * matrix.RadToDeg,
+		})
+
+		tx := w0*te.fs + te.tc
+		if !f.twoByte && code == 32 {
+			tx += te.tw
+		}
+		te.tm = translation(tx*te.th, 0).Multiply(te.tm)
+	}
+}
+
+func (te *textExtractor) showTextArray(t string) {
+	for _, e := range textArrayElements(t) {
+		if e[0] == '(' || e[0] == '<' {
+			te.showString(contentStringBytes(e))
+			continue
+		}
+		n, err := strconv.ParseFloat(e, 64)
+		if err != nil {
+			continue
+		}
+		te.tm = translation(-n/1000*te.fs*te.th, 0).Multiply(te.tm)
+	}
+}
+
+func (te *textExtractor) process(ops []contentOp) {
+	for _, op := range ops {
+		ff := func(n int) ([]float64, bool) {
+			if len(op.operands) < n {
+				return nil, false
+			}
+			return operandNumbers(op.operands[len(op.operands)-n:]), true
+		}
+
+		switch op.name {
+		case "q":
+			te.stack = append(te.stack, te.textState)
+		case "Q":
+			if n := len(te.stack); n > 0 {
+				te.textState, te.stack = te.stack[n-1], te.stack[:n-1]
+			}
+		case "cm":
+			if f, ok := ff(6); ok {
+				te.ctm = matrixFromOperands(f).Multiply(te.ctm)
+			}
+		case "BT":
+			te.tm, te.tlm = matrix.IdentMatrix, matrix.IdentMatrix
+		case "Tf":
+			if len(op.operands) == 2 {
+				te.font = te.fontForName(strings.TrimPrefix(op.operands[0], "/"))
+				te.fs, _ = strconv.ParseFloat(op.operands[1], 64)
+			}
+		case "Tc":
+			if f, ok := ff(1); ok {
+				te.tc = f[0]
+			}
+		case "Tw":
+			if f, ok := ff(1); ok {
+				te.tw = f[0]
+			}
+		case "Tz":
+			if f, ok := ff(1); ok {
+				te.th = f[0] / 100
+			}
+		case "TL":
+			if f, ok := ff(1); ok {
+				te.tl = f[0]
+			}
+		case "Ts":
+			if f, ok := ff(1); ok {
+				te.rise = f[0]
+			}
+		case "Td":
+			if f, ok := ff(2); ok {
+				te.nextLine(f[0], f[1])
+			}
+		case "TD":
+			if f, ok := ff(2); ok {
+				te.tl = -f[1]
+				te.nextLine(f[0], f[1])
+			}
+		case "Tm":
+			if f, ok := ff(6); ok {
+				te.tm = matrixFromOperands(f)
+				te.tlm = te.tm
+			}
+		case "T*":
+			te.nextLine(0, -te.tl)
+		case "Tj":
+			if len(op.operands) > 0 {
+				te.showString(contentStringBytes(op.operands[0]))
+			}
+		case "'":
+			te.nextLine(0, -te.tl)
+			if len(op.operands) > 0 {
+				te.showString(contentStringBytes(op.operands[0]))
+			}
+		case "\"":
+			if f, ok := ff(3); ok && len(op.operands) == 3 {
+				te.tw, te.tc = f[0], f[1]
+				te.nextLine(0, -te.tl)
+				te.showString(contentStringBytes(op.operands[2]))
+			}
+		case "TJ":
+			if len(op.operands) > 0 {
+				te.showTextArray(op.operands[0])
+			}
+		}
+	}
+}
+
+// pageGlyphs returns the glyphs shown by the content stream of page pageNr in content order.
+// Text shown within form XObjects is not taken into account.
+func pageGlyphs(ctx *model.Context, pageNr int) ([]textGlyph, error) {
+	_, content, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	te := textExtractor{
+		ctx:       ctx,
+		resDict:   resDict,
+		fonts:     map[string]*textFont{},
+		textState: textState{ctm: matrix.IdentMatrix, th: 1},
+		tm:        matrix.IdentMatrix,
+		tlm:       matrix.IdentMatrix,
+	}
+
+	te.process(parseContentOps(content))
+
+	return te.glyphs, nil
+}
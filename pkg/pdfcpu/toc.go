@@ -0,0 +1,556 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/font"
+	pdffont "github.com/pdfcpu/pdfcpu/pkg/pdfcpu/font"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+type tocParamMap map[string]func(string, *model.TOC) error
+
+var tocParamsMap = tocParamMap{
+	"title":    parseTOCTitle,
+	"page":     parseTOCInsertAt,
+	"levels":   parseTOCMaxLevel,
+	"fontname": parseTOCFontName,
+	"points":   parseTOCFontSize,
+	"indent":   parseTOCIndent,
+	"margin":   parseTOCMargin,
+	"labels":   parseTOCPageLabels,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into toc.
+func (m tocParamMap) Handle(paramPrefix, paramValueStr string, toc *model.TOC) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, toc)
+}
+
+func parseTOCTitle(s string, toc *model.TOC) error {
+	toc.Title = s
+	return nil
+}
+
+func parseTOCInsertAt(s string, toc *model.TOC) error {
+	if strings.ToLower(s) == "end" {
+		toc.InsertAt = 0
+		return nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return errors.Errorf("pdfcpu: toc page must be a positive integer or \"end\": %s", s)
+	}
+	toc.InsertAt = i
+	return nil
+}
+
+func parseTOCMaxLevel(s string, toc *model.TOC) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return errors.Errorf("pdfcpu: toc levels must be an integer >= 0: %s", s)
+	}
+	toc.MaxLevel = i
+	return nil
+}
+
+func parseTOCFontName(s string, toc *model.TOC) error {
+	if !font.SupportedFont(s) {
+		return errors.Errorf("pdfcpu: %s is unsupported, please refer to \"pdfcpu fonts list\".\n", s)
+	}
+	toc.FontName = s
+	return nil
+}
+
+func parseTOCFontSize(s string, toc *model.TOC) error {
+	i, err := strconv.Atoi(s)
+	if err != nil || i <= 0 {
+		return errors.Errorf("pdfcpu: toc font size must be a positive integer: %s", s)
+	}
+	toc.FontSize = i
+	return nil
+}
+
+func parseTOCDistance(s, name string, u types.DisplayUnit) (float64, error) {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 {
+		return 0, errors.Errorf("pdfcpu: toc %s must be a numeric value >= 0: %s", name, s)
+	}
+	return types.ToUserSpace(f, u), nil
+}
+
+func parseTOCIndent(s string, toc *model.TOC) (err error) {
+	toc.Indent, err = parseTOCDistance(s, "indent", toc.InpUnit)
+	return err
+}
+
+func parseTOCMargin(s string, toc *model.TOC) (err error) {
+	toc.Margin, err = parseTOCDistance(s, "margin", toc.InpUnit)
+	return err
+}
+
+func parseTOCPageLabels(s string, toc *model.TOC) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		toc.PageLabels = true
+	case "off", "false", "f":
+		toc.PageLabels = false
+	default:
+		return errors.New("pdfcpu: toc labels, please provide one of: on/off true/false t/f")
+	}
+	return nil
+}
+
+// ParseTOCConfig parses a table of contents configuration string into a TOC.
+func ParseTOCConfig(s string, conf *model.Configuration) (*model.TOC, error) {
+	if conf == nil {
+		conf = model.NewDefaultConfiguration()
+	}
+
+	toc := model.DefaultTOCConfig()
+	toc.InpUnit = conf.Unit
+
+	if s == "" {
+		return toc, nil
+	}
+
+	for _, s := range strings.Split(s, ",") {
+		ss := strings.Split(s, ":")
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid toc configuration string. Please consult pdfcpu help toc")
+		}
+		paramPrefix := strings.TrimSpace(ss[0])
+		paramValueStr := strings.TrimSpace(ss[1])
+		if err := tocParamsMap.Handle(paramPrefix, paramValueStr, toc); err != nil {
+			return nil, err
+		}
+	}
+
+	return toc, nil
+}
+
+// tocEntry represents a line of the table of contents.
+type tocEntry struct {
+	title  string
+	level  int
+	pageNr int
+	dest   *BookmarkDest
+	label  string
+}
+
+func tocEntries(bms []Bookmark, level, maxLevel int, ee *[]tocEntry) {
+	if maxLevel > 0 && level >= maxLevel {
+		return
+	}
+	for _, bm := range bms {
+		title := strings.TrimSpace(bm.Title)
+		if bm.PageFrom > 0 && title != "" {
+			*ee = append(*ee, tocEntry{title: title, level: level, pageNr: bm.PageFrom, dest: bm.Dest})
+		}
+		tocEntries(bm.Kids, level+1, maxLevel, ee)
+	}
+}
+
+// tocLayout returns the number of entries fitting on the first and all subsequent TOC pages.
+func tocLayout(toc *model.TOC, dim *types.Dim) (int, int, error) {
+	h := dim.Height - 2*toc.Margin
+	lh := toc.LineHeight()
+	n := int(h / lh)
+	n1 := n
+	if toc.Title != "" {
+		n1 = int((h - 2*float64(toc.TitleFontSize())) / lh)
+	}
+	if n1 < 1 || dim.Width-2*toc.Margin < 4*float64(toc.FontSize) {
+		return 0, 0, errors.New("pdfcpu: toc: font size or margin too large for page size")
+	}
+	return n1, n, nil
+}
+
+// tocPageLabels returns the page labels of ctx after inserting n TOC pages at insertAt.
+// pls are the original page labels of ctx with its original page count pageCount.
+func tocPageLabels(pls []model.PageLabel, insertAt, n, pageCount int) []model.PageLabel {
+	if len(pls) == 0 {
+		pls = []model.PageLabel{{PageFrom: 1, Style: model.PageLabelDecimal, Start: 1}}
+	}
+
+	pls1 := []model.PageLabel{}
+	var cont *model.PageLabel
+
+	for _, pl := range pls {
+		if pl.PageFrom < insertAt {
+			pls1 = append(pls1, pl)
+			pl := pl
+			cont = &pl
+			continue
+		}
+		if pl.PageFrom == insertAt {
+			pl := pl
+			cont = &pl
+			continue
+		}
+		pl.PageFrom += n
+		pls1 = append(pls1, pl)
+	}
+
+	pls1 = append(pls1, model.PageLabel{PageFrom: insertAt, Style: model.PageLabelRomanLower, Start: 1})
+
+	if cont != nil && insertAt <= pageCount {
+		// Continue the labeling of the page the TOC has been inserted before.
+		pl := *cont
+		pl.Start = max(pl.Start, 1) + insertAt - pl.PageFrom
+		pl.PageFrom = insertAt + n
+		pls1 = append(pls1, pl)
+	}
+
+	return pls1
+}
+
+// truncateTextWidth shortens s to fit into width w.
+func truncateTextWidth(s, fontName string, fontSize int, w float64) string {
+	if font.TextWidth(s, fontName, fontSize) <= w {
+		return s
+	}
+	rr := []rune(s)
+	for len(rr) > 0 {
+		rr = rr[:len(rr)-1]
+		s1 := strings.TrimSpace(string(rr)) + "..."
+		if font.TextWidth(s1, fontName, fontSize) <= w {
+			return s1
+		}
+	}
+	return ""
+}
+
+func writeTOCText(ctx *model.Context, w *bytes.Buffer, mediaBox *types.Rectangle, toc *model.TOC, fontKey, s string, fontSize int, x, y float64) {
+	td := model.TextDescriptor{
+		Text:     s,
+		FontName: toc.FontName,
+		FontKey:  fontKey,
+		FontSize: fontSize,
+		Scale:    1.,
+		ScaleAbs: true,
+		X:        x,
+		Y:        y,
+	}
+	model.WriteMultiLine(ctx.XRefTable, w, mediaBox, nil, td)
+}
+
+// tocTags holds the structure elements of a tagged TOC.
+type tocTags struct {
+	title *types.IndirectRef // H1 for the TOC title.
+	toc   *types.IndirectRef // TOC containing one TOCI per entry.
+}
+
+func newTOCTags(ctx *model.Context, title bool) (*tocTags, error) {
+	if !ctx.Conf.TagContent {
+		return nil, nil
+	}
+
+	tt := &tocTags{}
+
+	var err error
+	if title {
+		if tt.title, err = ctx.AddStructElem(nil, model.StructTag{Type: "H1"}); err != nil {
+			return nil, err
+		}
+	}
+
+	if tt.toc, err = ctx.AddStructElem(nil, model.StructTag{Type: "TOC"}); err != nil {
+		return nil, err
+	}
+
+	return tt, nil
+}
+
+// writeTOCEntry renders e onto the line starting at y using dot leaders and returns the clickable area.
+// For mcid >= 0 title and label are written as marked content sequence mcid and the dot leaders as artifact.
+func writeTOCEntry(ctx *model.Context, w *bytes.Buffer, mediaBox *types.Rectangle, toc *model.TOC, fontKey string, e tocEntry, y float64, mcid int) *types.Rectangle {
+	fontName, fontSize := toc.FontName, toc.FontSize
+	fs := float64(fontSize)
+
+	x := toc.Margin + float64(e.level)*toc.Indent
+	right := mediaBox.Width() - toc.Margin
+	numW := font.TextWidth(e.label, fontName, fontSize)
+	gap := fs / 2
+
+	// Keep at least 4 font sizes for the title.
+	x = math.Min(x, right-numW-gap-4*fs)
+
+	title := truncateTextWidth(e.title, fontName, fontSize, right-x-numW-2*gap)
+	titleW := font.TextWidth(title, fontName, fontSize)
+
+	dotW := font.TextWidth(".", fontName, fontSize)
+	if dotW > 0 {
+		if dots := int((right - numW - x - titleW - 2*gap) / dotW); dots > 0 {
+			if mcid >= 0 {
+				model.BeginArtifact(w)
+			}
+			writeTOCText(ctx, w, mediaBox, toc, fontKey, strings.Repeat(".", dots), fontSize, right-numW-gap-float64(dots)*dotW, y)
+			if mcid >= 0 {
+				model.EndMarkedContent(w)
+			}
+		}
+	}
+
+	if mcid >= 0 {
+		model.BeginMarkedContent(w, "Link", mcid)
+	}
+	writeTOCText(ctx, w, mediaBox, toc, fontKey, title, fontSize, x, y)
+	writeTOCText(ctx, w, mediaBox, toc, fontKey, e.label, fontSize, right-numW, y)
+	if mcid >= 0 {
+		model.EndMarkedContent(w)
+	}
+
+	return types.NewRectangle(x, y, right, y+font.LineHeight(fontName, fontSize))
+}
+
+func tocDestination(e tocEntry) (*model.Destination, error) {
+	dest := &model.Destination{Typ: model.DestFit}
+	if e.dest != nil {
+		var err error
+		if dest, err = e.dest.destination(); err != nil {
+			return nil, err
+		}
+	}
+	dest.PageNr = e.pageNr
+	return dest, nil
+}
+
+// writeTOCPage renders ee onto the blank TOC page pageNr and links each entry to its destination.
+// For tagged TOCs each entry is tagged as TOCI containing a Link referring to the link annotation.
+func writeTOCPage(ctx *model.Context, pageNr int, toc *model.TOC, title bool, ee []tocEntry, tt *tocTags) error {
+	pageDict, pageDictIndRef, _, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return err
+	}
+
+	mediaBox, err := ctx.XRefTable.RectForArray(pageDict.ArrayEntry("MediaBox"))
+	if err != nil {
+		return err
+	}
+
+	fm := model.FontMap{}
+	fontKey := fm.EnsureKey(toc.FontName)
+
+	var buf bytes.Buffer
+
+	y := mediaBox.Height() - toc.Margin
+	if title {
+		tfs := toc.TitleFontSize()
+		y -= 2 * float64(tfs)
+		if tt != nil && tt.title != nil {
+			mcid, err := ctx.NewMCID(*tt.title, *pageDictIndRef, pageDict)
+			if err != nil {
+				return err
+			}
+			model.BeginMarkedContent(&buf, "H1", mcid)
+		}
+		writeTOCText(ctx, &buf, mediaBox, toc, fontKey, toc.Title, tfs, toc.Margin, y)
+		if tt != nil && tt.title != nil {
+			model.EndMarkedContent(&buf)
+		}
+	}
+
+	rr := make([]*types.Rectangle, len(ee))
+	links := make([]*types.IndirectRef, len(ee))
+	for i, e := range ee {
+		y -= toc.LineHeight()
+		mcid := -1
+		if tt != nil {
+			toci, err := ctx.AddStructElem(tt.toc, model.StructTag{Type: "TOCI"})
+			if err != nil {
+				return err
+			}
+			if links[i], err = ctx.AddStructElem(toci, model.StructTag{Type: "Link"}); err != nil {
+				return err
+			}
+			if mcid, err = ctx.NewMCID(*links[i], *pageDictIndRef, pageDict); err != nil {
+				return err
+			}
+		}
+		rr[i] = writeTOCEntry(ctx, &buf, mediaBox, toc, fontKey, e, y, mcid)
+	}
+
+	fontRes, err := pdffont.FontResources(ctx.XRefTable, fm)
+	if err != nil {
+		return err
+	}
+
+	sd, _ := ctx.XRefTable.NewStreamDictForBuf(buf.Bytes())
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(*sd)
+	if err != nil {
+		return err
+	}
+
+	pageDict["Resources"] = types.Dict(map[string]types.Object{"Font": fontRes})
+	pageDict["Contents"] = *ir
+
+	for i, e := range ee {
+		dest, err := tocDestination(e)
+		if err != nil {
+			return err
+		}
+		ann := model.NewLinkAnnotation(*rr[i], 0, "", "", "", 0, nil, dest, "", nil, false, 0, model.BSSolid)
+		annotIndRef, _, err := AddAnnotation(ctx, pageDictIndRef, pageDict, pageNr, ann, false)
+		if err != nil {
+			return err
+		}
+		if links[i] != nil {
+			if err := ctx.AddObjRef(*links[i], *pageDictIndRef, *annotIndRef); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func tocPageDim(ctx *model.Context, pageNr int) (*types.Dim, error) {
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+	if inhPAttrs.MediaBox == nil {
+		return nil, errors.Errorf("pdfcpu: toc: missing mediaBox for page %d", pageNr)
+	}
+	return &types.Dim{Width: inhPAttrs.MediaBox.Width(), Height: inhPAttrs.MediaBox.Height()}, nil
+}
+
+// AddTOC renders a table of contents for the outline of ctx onto new pages inserted before page toc.InsertAt.
+// Each entry links to its outline destination. Page labels are adjusted so that all other pages keep their labels.
+// Returns the number of TOC pages inserted.
+func AddTOC(ctx *model.Context, toc *model.TOC) (int, error) {
+	bms, err := Bookmarks(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if len(bms) == 0 {
+		return 0, errNoBookmarks
+	}
+
+	ee := []tocEntry{}
+	tocEntries(bms, 0, toc.MaxLevel, &ee)
+	if len(ee) == 0 {
+		return 0, errors.New("pdfcpu: toc: no outline entries available")
+	}
+
+	pageCount := ctx.PageCount
+
+	insertAt := toc.InsertAt
+	if insertAt <= 0 || insertAt > pageCount {
+		insertAt = pageCount + 1
+	}
+
+	dim, err := tocPageDim(ctx, min(insertAt, pageCount))
+	if err != nil {
+		return 0, err
+	}
+
+	n1, n, err := tocLayout(toc, dim)
+	if err != nil {
+		return 0, err
+	}
+
+	pages := 1
+	if len(ee) > n1 {
+		pages += (len(ee) - n1 + n - 1) / n
+	}
+
+	pls, err := PageLabels(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	for range pages {
+		if insertAt <= pageCount {
+			err = ctx.InsertBlankPages(types.IntSet{insertAt: true}, dim, true)
+		} else {
+			err = ctx.InsertBlankPages(types.IntSet{ctx.PageCount: true}, dim, false)
+		}
+		if err != nil {
+			return 0, err
+		}
+		ctx.PageCount++
+	}
+
+	for i := range ee {
+		if ee[i].pageNr >= insertAt {
+			ee[i].pageNr += pages
+		}
+		ee[i].label = strconv.Itoa(ee[i].pageNr)
+	}
+
+	if len(pls) > 0 || toc.PageLabels {
+		if err := SetPageLabels(ctx, tocPageLabels(pls, insertAt, pages, pageCount)); err != nil {
+			return 0, err
+		}
+		labels, err := PageLabelStrings(ctx)
+		if err != nil {
+			return 0, err
+		}
+		for i := range ee {
+			ee[i].label = labels[ee[i].pageNr-1]
+		}
+	}
+
+	tt, err := newTOCTags(ctx, toc.Title != "")
+	if err != nil {
+		return 0, err
+	}
+
+	for i := range pages {
+		j := n1 + (i-1)*n
+		k := j + n
+		if i == 0 {
+			j, k = 0, n1
+		}
+		k = min(k, len(ee))
+		if err := writeTOCPage(ctx, insertAt+i, toc, i == 0 && toc.Title != "", ee[j:k], tt); err != nil {
+			return 0, err
+		}
+	}
+
+	return pages, nil
+}
@@ -0,0 +1,378 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+type transitionParamMap map[string]func(string, *model.PageTransition) error
+
+var transParamMap = transitionParamMap{
+	"style":     parseTransitionStyle,
+	"duration":  parseTransitionDuration,
+	"dimension": parseTransitionDimension,
+	"motion":    parseTransitionMotion,
+	"direction": parseTransitionDirection,
+	"scale":     parseTransitionScale,
+	"opaque":    parseTransitionOpaque,
+	"advance":   parseTransitionAutoAdvance,
+}
+
+// Handle applies parameter completion and if successful
+// parses the parameter values into t.
+func (m transitionParamMap) Handle(paramPrefix, paramValueStr string, t *model.PageTransition) error {
+	var param string
+
+	// Completion support
+	for k := range m {
+		if !strings.HasPrefix(k, strings.ToLower(paramPrefix)) {
+			continue
+		}
+		if len(param) > 0 {
+			return errors.Errorf("pdfcpu: ambiguous parameter prefix \"%s\"", paramPrefix)
+		}
+		param = k
+	}
+
+	if param == "" {
+		return errors.Errorf("pdfcpu: unknown parameter prefix \"%s\"", paramPrefix)
+	}
+
+	return m[param](paramValueStr, t)
+}
+
+func parseTransitionStyle(s string, t *model.PageTransition) error {
+	if strings.ToLower(s) == "replace" {
+		s = "R"
+	}
+	for _, style := range model.TransitionStyles {
+		if strings.EqualFold(style, s) {
+			t.Style = style
+			return nil
+		}
+	}
+	return errors.Errorf("pdfcpu: unknown transition style: %s", s)
+}
+
+func parseTransitionSeconds(s, name string) (float64, error) {
+	f, err := strconv.ParseFloat(strings.TrimSuffix(s, "s"), 64)
+	if err != nil || f <= 0 {
+		return 0, errors.Errorf("pdfcpu: transition %s must be a positive number of seconds: %s", name, s)
+	}
+	return f, nil
+}
+
+func parseTransitionDuration(s string, t *model.PageTransition) (err error) {
+	t.Duration, err = parseTransitionSeconds(s, "duration")
+	return err
+}
+
+func parseTransitionAutoAdvance(s string, t *model.PageTransition) (err error) {
+	t.AutoAdvance, err = parseTransitionSeconds(s, "advance")
+	return err
+}
+
+func parseTransitionDimension(s string, t *model.PageTransition) error {
+	switch strings.ToLower(s) {
+	case "h", "horizontal":
+		t.Dimension = "H"
+	case "v", "vertical":
+		t.Dimension = "V"
+	default:
+		return errors.Errorf("pdfcpu: transition dimension must be h(orizontal) or v(ertical): %s", s)
+	}
+	return nil
+}
+
+func parseTransitionMotion(s string, t *model.PageTransition) error {
+	switch strings.ToLower(s) {
+	case "i", "inward":
+		t.Motion = "I"
+	case "o", "outward":
+		t.Motion = "O"
+	default:
+		return errors.Errorf("pdfcpu: transition motion must be i(nward) or o(utward): %s", s)
+	}
+	return nil
+}
+
+func parseTransitionDirection(s string, t *model.PageTransition) error {
+	if strings.ToLower(s) == "none" {
+		t.Direction = model.TransitionDirectionNone
+		return nil
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil || !types.IntMemberOf(i, []int{0, 90, 180, 270, 315}) {
+		return errors.Errorf("pdfcpu: transition direction must be one of 0, 90, 180, 270, 315, none: %s", s)
+	}
+	t.Direction = i
+	return nil
+}
+
+func parseTransitionScale(s string, t *model.PageTransition) error {
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f <= 0 {
+		return errors.Errorf("pdfcpu: transition scale must be a positive number: %s", s)
+	}
+	t.Scale = f
+	return nil
+}
+
+func parseTransitionOpaque(s string, t *model.PageTransition) error {
+	switch strings.ToLower(s) {
+	case "on", "true", "t":
+		t.Opaque = true
+	case "off", "false", "f":
+		t.Opaque = false
+	default:
+		return errors.New("pdfcpu: transition opaque, please provide one of: on/off true/false t/f")
+	}
+	return nil
+}
+
+// ParsePageTransition parses a page transition command string into an internal structure.
+func ParsePageTransition(s string) (*model.PageTransition, error) {
+	if s == "" {
+		return nil, errors.New("pdfcpu: missing page transition")
+	}
+
+	t := &model.PageTransition{}
+
+	for _, s := range strings.Split(s, ",") {
+		ss := strings.Split(s, ":")
+		if len(ss) != 2 {
+			return nil, errors.New("pdfcpu: Invalid page transition string. Please consult pdfcpu help transitions")
+		}
+		paramPrefix := strings.TrimSpace(ss[0])
+		paramValueStr := strings.TrimSpace(ss[1])
+		if err := transParamMap.Handle(paramPrefix, paramValueStr, t); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func pageTransition(ctx *model.Context, pageDict types.Dict) (*model.PageTransition, error) {
+	t := &model.PageTransition{}
+
+	if o, found := pageDict.Find("Dur"); found {
+		f, err := ctx.DereferenceNumber(o)
+		if err != nil {
+			return nil, err
+		}
+		t.AutoAdvance = f
+	}
+
+	d, err := ctx.DereferenceDict(pageDict["Trans"])
+	if err != nil {
+		return nil, err
+	}
+
+	if d == nil {
+		if t.AutoAdvance == 0 {
+			return nil, nil
+		}
+		return t, nil
+	}
+
+	t.Style = "R"
+	if n, err := ctx.DereferenceName(d["S"], model.V10, nil); err == nil && n != "" {
+		t.Style = n.Value()
+	}
+
+	if o, found := d.Find("D"); found {
+		if t.Duration, err = ctx.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if n, err := ctx.DereferenceName(d["Dm"], model.V10, nil); err == nil {
+		t.Dimension = n.Value()
+	}
+
+	if n, err := ctx.DereferenceName(d["M"], model.V10, nil); err == nil {
+		t.Motion = n.Value()
+	}
+
+	if o, _ := ctx.Dereference(d["Di"]); o != nil {
+		switch o := o.(type) {
+		case types.Integer:
+			t.Direction = o.Value()
+		case types.Name:
+			t.Direction = model.TransitionDirectionNone
+		}
+	}
+
+	if o, found := d.Find("SS"); found {
+		if t.Scale, err = ctx.DereferenceNumber(o); err != nil {
+			return nil, err
+		}
+	}
+
+	if b, err := ctx.DereferenceBoolean(d["B"], model.V15); err == nil && b != nil {
+		t.Opaque = b.Value()
+	}
+
+	return t, nil
+}
+
+// PageTransitions returns the page transitions of selected pages mapped by page number.
+func PageTransitions(ctx *model.Context, selectedPages types.IntSet) (map[int]model.PageTransition, error) {
+	m := map[int]model.PageTransition{}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return nil, err
+		}
+
+		t, err := pageTransition(ctx, pageDict)
+		if err != nil {
+			return nil, errors.Wrapf(err, "page %d", pageNr)
+		}
+		if t != nil {
+			m[pageNr] = *t
+		}
+	}
+
+	return m, nil
+}
+
+// ListPageTransitions returns a formatted list of the page transitions of selected pages.
+func ListPageTransitions(ctx *model.Context, selectedPages types.IntSet) ([]string, error) {
+	m, err := PageTransitions(ctx, selectedPages)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(m) == 0 {
+		return []string{"no page transitions available"}, nil
+	}
+
+	ss := []string{}
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if t, ok := m[pageNr]; ok {
+			ss = append(ss, fmt.Sprintf("page %3d: %s", pageNr, t))
+		}
+	}
+
+	return ss, nil
+}
+
+// SetPageTransitions applies t to selected pages.
+// A transition style replaces an existing transition dict, an auto advance duration replaces an existing page duration.
+func SetPageTransitions(ctx *model.Context, selectedPages types.IntSet, t model.PageTransition) error {
+	if err := t.Validate(); err != nil {
+		return err
+	}
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return err
+		}
+
+		if t.Style != "" {
+			pageDict["Trans"] = t.Dict()
+		}
+		if t.AutoAdvance > 0 {
+			pageDict["Dur"] = types.Float(t.AutoAdvance)
+		}
+	}
+
+	// Fly, Push, Cover, Uncover and Fade need PDF 1.5.
+	ctx.EnsureVersionForWriting()
+
+	return nil
+}
+
+// RemovePageTransitions removes transitions and auto advance durations from selected pages.
+// RemovePageTransitions returns true if anything was removed.
+func RemovePageTransitions(ctx *model.Context, selectedPages types.IntSet) (bool, error) {
+	var removed bool
+
+	for pageNr := 1; pageNr <= ctx.PageCount; pageNr++ {
+		if selectedPages != nil && !selectedPages[pageNr] {
+			continue
+		}
+
+		pageDict, _, _, err := ctx.PageDict(pageNr, false)
+		if err != nil {
+			return false, err
+		}
+
+		for _, k := range []string{"Trans", "Dur"} {
+			if o := pageDict.Delete(k); o != nil {
+				if ir, ok := o.(types.IndirectRef); ok {
+					if err := ctx.DeleteObject(ir); err != nil {
+						return false, err
+					}
+				}
+				removed = true
+			}
+		}
+	}
+
+	return removed, nil
+}
+
+// SetFullScreen opens the document in full screen mode as used for presentations.
+// Leaving full screen mode the document is displayed using the current page mode or none.
+func SetFullScreen(ctx *model.Context) {
+	pm := model.PageModeUseNone
+	if n := ctx.RootDict.NameEntry("PageMode"); n != nil {
+		if pm1 := model.PageModeFor(*n); pm1 != nil && *pm1 != model.PageModeFullScreen {
+			pm = *pm1
+		}
+	}
+
+	if pm == model.PageModeUseAttachments {
+		// Not allowed for NonFullScreenPageMode.
+		pm = model.PageModeUseNone
+	}
+
+	fs := model.PageModeFullScreen
+	ctx.RootDict["PageMode"] = types.Name(fs.String())
+
+	if ctx.ViewerPref == nil {
+		ctx.ViewerPref = &model.ViewerPreferences{}
+	}
+	nfspm := model.NonFullScreenPageMode(pm)
+	ctx.ViewerPref.NonFullScreenPageMode = &nfspm
+
+	ctx.XRefTable.BindViewerPreferences()
+}
@@ -51,6 +51,16 @@ func (d Dict) Clone() Object {
 	return d1
 }
 
+// Keys returns the keys of d in sorted order.
+func (d Dict) Keys() []string {
+	keys := make([]string, 0, len(d))
+	for k := range d {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
 // Insert adds a new entry to this PDFDict.
 func (d Dict) Insert(k string, v Object) bool {
 	if _, found := d.Find(k); !found {
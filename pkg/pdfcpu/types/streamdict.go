@@ -30,8 +30,9 @@ import (
 
 // PDFFilter represents a PDF stream filter object.
 type PDFFilter struct {
-	Name        string
-	DecodeParms Dict
+	Name         string
+	DecodeParms  Dict
+	JBIG2Globals []byte // Decoded JBIG2Globals stream referenced by DecodeParms.
 }
 
 // StreamDict represents a PDF stream dict object.
@@ -325,9 +326,25 @@ func (sd *StreamDict) Decode() error {
 	return err
 }
 
-func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
-	var b, c io.Reader
-	b = bytes.NewReader(sd.Raw)
+// pipelineReader reads the output of a filter pipeline.
+type pipelineReader struct {
+	io.Reader
+	closers []io.Closer
+}
+
+// Close closes all filter readers of the pipeline.
+func (pr *pipelineReader) Close() error {
+	var err error
+	for i := len(pr.closers) - 1; i >= 0; i-- {
+		if err1 := pr.closers[i].Close(); err1 != nil && err == nil {
+			err = err1
+		}
+	}
+	return err
+}
+
+func (sd *StreamDict) decodeReader(maxLen int64) (*pipelineReader, error) {
+	pr := &pipelineReader{Reader: bytes.NewReader(sd.Raw)}
 
 	// Apply each filter in the pipeline to result of preceding filter.
 	for idx, f := range sd.FilterPipeline {
@@ -355,37 +372,67 @@ func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
 
 		parms := parmsForFilter(f.DecodeParms)
 		if err := fixParms(f, parms, sd); err != nil {
+			pr.Close()
 			return nil, err
 		}
 
 		fi, err := filter.NewFilter(f.Name, parms)
 		if err != nil {
+			pr.Close()
 			return nil, err
 		}
 
+		if f.Name == filter.JBIG2 {
+			if gf, ok := fi.(filter.JBIG2GlobalsFilter); ok {
+				fi = gf.WithJBIG2Globals(f.JBIG2Globals)
+			}
+		}
+
+		var rc io.ReadCloser
 		if maxLen >= 0 && idx == len(sd.FilterPipeline)-1 {
-			c, err = fi.DecodeLength(b, maxLen)
+			rc, err = fi.DecodeLength(pr.Reader, maxLen)
 		} else {
-			c, err = fi.Decode(b)
+			rc, err = fi.Decode(pr.Reader)
 		}
 		if err != nil {
+			pr.Close()
 			return nil, err
 		}
 
-		//fmt.Printf("decodedStream after:%s\n%s\n", f.Name, hex.Dump(c.Bytes()))
-		b = c
+		pr.Reader = rc
+		pr.closers = append(pr.closers, rc)
 	}
 
+	return pr, nil
+}
+
+// DecodeReader returns a reader applying sd's filter pipeline to sd.Raw while being consumed.
+// Unlike Decode it leaves sd.Content alone so large streams can be processed with bounded memory.
+// The caller is responsible for closing the returned reader.
+func (sd *StreamDict) DecodeReader() (io.ReadCloser, error) {
+	if sd.Content != nil {
+		// This stream has already been decoded.
+		return io.NopCloser(bytes.NewReader(sd.Content)), nil
+	}
+
+	return sd.decodeReader(-1)
+}
+
+func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
+	pr, err := sd.decodeReader(maxLen)
+	if err != nil {
+		return nil, err
+	}
+	defer pr.Close()
+
 	var data []byte
-	if bb, ok := c.(*bytes.Buffer); ok {
+	if bb, ok := pr.Reader.(interface{ Bytes() []byte }); ok {
+		// The last filter has decoded the whole stream at once.
 		data = bb.Bytes()
 	} else {
-		var buf bytes.Buffer
-		if _, err := io.Copy(&buf, c); err != nil {
+		if data, err = io.ReadAll(pr); err != nil {
 			return nil, err
 		}
-
-		data = buf.Bytes()
 	}
 
 	if maxLen < 0 {
@@ -393,7 +440,11 @@ func (sd *StreamDict) decodeLength(maxLen int64) ([]byte, error) {
 		return data, nil
 	}
 
-	return data[:maxLen], nil
+	if int64(len(data)) > maxLen {
+		data = data[:maxLen]
+	}
+
+	return data, nil
 }
 
 func (sd *StreamDict) DecodeLength(maxLen int64) ([]byte, error) {
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package types
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+)
+
+func TestStreamDictDecodeReader(t *testing.T) {
+	content := bytes.Repeat([]byte("0 0 m 100 100 l S\n"), 10000)
+
+	fpl := []PDFFilter{{Name: filter.ASCIIHex}, {Name: filter.Flate}}
+	sd := NewStreamDict(Dict{}, 0, nil, nil, fpl)
+	sd.Content = content
+	if err := sd.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	sd.Content = nil
+	rc, err := sd.DecodeReader()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := io.ReadAll(rc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rc.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, content) {
+		t.Fatal("DecodeReader: content mismatch")
+	}
+	if sd.Content != nil {
+		t.Fatal("DecodeReader: unexpected Content")
+	}
+
+	bb, err := sd.DecodeLength(100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(bb, content[:100]) {
+		t.Fatal("DecodeLength: content mismatch")
+	}
+
+	if err := sd.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sd.Content, content) {
+		t.Fatal("Decode: content mismatch")
+	}
+}
+
+// jbig2 is a JBIG2Decode filter prepending the JBIG2 globals to its input.
+type jbig2 struct {
+	globals []byte
+}
+
+func (f jbig2) Encode(r io.Reader) (io.Reader, error) {
+	return r, nil
+}
+
+func (f jbig2) Decode(r io.Reader) (io.ReadCloser, error) {
+	return f.DecodeLength(r, -1)
+}
+
+func (f jbig2) DecodeLength(r io.Reader, maxLen int64) (io.ReadCloser, error) {
+	return io.NopCloser(io.MultiReader(bytes.NewReader(f.globals), r)), nil
+}
+
+func (f jbig2) WithJBIG2Globals(globals []byte) filter.Filter {
+	f.globals = globals
+	return f
+}
+
+func TestStreamDictDecodeRegisteredFilter(t *testing.T) {
+	filter.Register(filter.JBIG2, func(parms map[string]int) (filter.Filter, error) {
+		return jbig2{}, nil
+	})
+	defer filter.Register(filter.JBIG2, nil)
+
+	fpl := []PDFFilter{{Name: filter.Flate}, {Name: filter.JBIG2, JBIG2Globals: []byte("globals,")}}
+	sd := NewStreamDict(Dict{}, 0, nil, nil, fpl)
+	sd.Content = []byte("page")
+	if err := sd.Encode(); err != nil {
+		t.Fatal(err)
+	}
+
+	sd.Content = nil
+	if err := sd.Decode(); err != nil {
+		t.Fatal(err)
+	}
+	if string(sd.Content) != "globals,page" {
+		t.Fatalf("want globals,page, got %s\n", sd.Content)
+	}
+}
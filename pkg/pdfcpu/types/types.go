@@ -255,7 +255,7 @@ func (r Rectangle) Portrait() bool {
 
 // Contains returns true if rectangle r contains point p.
 func (r Rectangle) Contains(p Point) bool {
-	return p.X >= r.LL.X && p.X <= r.UR.X && p.Y >= r.LL.Y && p.Y <= r.LL.Y
+	return p.X >= r.LL.X && p.X <= r.UR.X && p.Y >= r.LL.Y && p.Y <= r.UR.Y
 }
 
 // ScaledWidth returns the width for given height according to r's aspect ratio.
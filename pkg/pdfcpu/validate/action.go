@@ -963,7 +963,8 @@ func validateAdditionalActions(xRefTable *model.XRefTable, dict types.Dict, dict
 		return false
 	}
 
-	for k, v := range d {
+	for _, k := range d.Keys() {
+		v := d[k]
 
 		if !validateAdditionalAction(k, source) {
 			return errors.Errorf("validateAdditionalActions: action %s not allowed for source %s", k, source)
@@ -1055,6 +1055,37 @@ func validateAnnotationDictWatermark(xRefTable *model.XRefTable, d types.Dict, d
 	return err
 }
 
+func validate3DActivationDictEntry(xRefTable *model.XRefTable, d types.Dict, dictName, entryName string, required bool, sinceVersion model.Version) error {
+
+	// see 13.6.3
+
+	d1, err := validateDictEntry(xRefTable, d, dictName, entryName, required, sinceVersion, nil)
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	dictName = "3DActivationDict"
+
+	for k, vv := range map[string][]string{
+		"A":   {"PO", "PV", "XA"},
+		"AIS": {"I", "L"},
+		"D":   {"PC", "PI", "XD"},
+		"DIS": {"U", "I", "L"},
+	} {
+		if _, err := validateNameEntry(xRefTable, d1, dictName, k, OPTIONAL, sinceVersion, func(s string) bool { return types.MemberOf(s, vv) }); err != nil {
+			return err
+		}
+	}
+
+	for _, k := range []string{"TB", "NP"} {
+		if _, err := validateBooleanEntry(xRefTable, d1, dictName, k, OPTIONAL, model.V17, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 func validateAnnotationDict3D(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
 
 	// see 13.6.2
@@ -1072,7 +1103,7 @@ func validateAnnotationDict3D(xRefTable *model.XRefTable, d types.Dict, dictName
 	}
 
 	// 3DA, optional, activation dict
-	if _, err := validateDictEntry(xRefTable, d, dictName, "3DA", OPTIONAL, model.V16, nil); err != nil {
+	if err := validate3DActivationDictEntry(xRefTable, d, dictName, "3DA", OPTIONAL, model.V16); err != nil {
 		return err
 	}
 
@@ -1168,11 +1199,111 @@ func validateAnnotationDictRedact(xRefTable *model.XRefTable, d types.Dict, dict
 	return err
 }
 
-func validateRichMediaAnnotation(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
-	// TODO See extension level 3.
+func validateRichMediaAssets(xRefTable *model.XRefTable, d types.Dict) error {
+	// Assets, optional, name tree of file specifications.
+	d1, err := validateDictEntry(xRefTable, d, "richMediaContentDict", "Assets", OPTIONAL, model.V17, nil)
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	a, err := validateArrayEntry(xRefTable, d1, "richMediaAssets", "Names", OPTIONAL, model.V17, func(a types.Array) bool { return len(a)%2 == 0 })
+	if err != nil {
+		return err
+	}
+
+	for i := 1; i < len(a); i += 2 {
+		if _, err := validateFileSpecification(xRefTable, a[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRichMediaConfigurations(xRefTable *model.XRefTable, d types.Dict) error {
+	// Configurations, optional, array of configuration dicts.
+	a, err := validateArrayEntry(xRefTable, d, "richMediaContentDict", "Configurations", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, o := range a {
+		d1, err := xRefTable.DereferenceDict(o)
+		if err != nil || d1 == nil {
+			return errors.New("pdfcpu: validateRichMediaConfigurations: corrupt configuration dict")
+		}
+
+		dictName := "richMediaConfigurationDict"
+		if _, err := validateNameEntry(xRefTable, d1, dictName, "Type", OPTIONAL, model.V17, func(s string) bool { return s == "RichMediaConfiguration" }); err != nil {
+			return err
+		}
+
+		a1, err := validateArrayEntry(xRefTable, d1, dictName, "Instances", OPTIONAL, model.V17, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, o := range a1 {
+			d2, err := xRefTable.DereferenceDict(o)
+			if err != nil || d2 == nil {
+				return errors.New("pdfcpu: validateRichMediaConfigurations: corrupt instance dict")
+			}
+			if _, err := validateFileSpecEntry(xRefTable, d2, "richMediaInstanceDict", "Asset", OPTIONAL, model.V17); err != nil {
+				return err
+			}
+		}
+	}
+
 	return nil
 }
 
+func validateRichMediaSettings(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+	// RichMediaSettings, optional, dict
+	d1, err := validateDictEntry(xRefTable, d, dictName, "RichMediaSettings", OPTIONAL, model.V17, nil)
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	for k, conditions := range map[string][]string{
+		"Activation":   {"XA", "PO", "PV"},
+		"Deactivation": {"XD", "PC", "PI"},
+	} {
+		d2, err := validateDictEntry(xRefTable, d1, "richMediaSettingsDict", k, OPTIONAL, model.V17, nil)
+		if err != nil {
+			return err
+		}
+		if d2 == nil {
+			continue
+		}
+		if _, err := validateNameEntry(xRefTable, d2, k, "Condition", OPTIONAL, model.V17, func(s string) bool { return types.MemberOf(s, conditions) }); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func validateRichMediaAnnotation(xRefTable *model.XRefTable, d types.Dict, dictName string) error {
+
+	// see Adobe Supplement to ISO 32000, BaseVersion 1.7, ExtensionLevel 3
+
+	// RichMediaContent, required, dict
+	d1, err := validateDictEntry(xRefTable, d, dictName, "RichMediaContent", REQUIRED, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := validateRichMediaAssets(xRefTable, d1); err != nil {
+		return err
+	}
+
+	if err := validateRichMediaConfigurations(xRefTable, d1); err != nil {
+		return err
+	}
+
+	return validateRichMediaSettings(xRefTable, d, dictName)
+}
+
 func validateExDataDict(xRefTable *model.XRefTable, d types.Dict) error {
 
 	dictName := "ExData"
@@ -1810,7 +1941,9 @@ func validatePagesAnnotations(xRefTable *model.XRefTable, d types.Dict, curPage
 			curPage++
 			xRefTable.CurPage = curPage
 			if err = validatePageAnnotations(xRefTable, d); err != nil {
-				return curPage, err
+				if err = xRefTable.ValidationError(err, curPage, "12.5"); err != nil {
+					return curPage, err
+				}
 			}
 
 		default:
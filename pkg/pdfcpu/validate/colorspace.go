@@ -321,7 +321,8 @@ func validateSeparationColorSpace(xRefTable *model.XRefTable, a types.Array, sin
 
 func validateDeviceNColorSpaceColorantsDict(xRefTable *model.XRefTable, d types.Dict) error {
 
-	for _, obj := range d {
+	for _, k := range d.Keys() {
+		obj := d[k]
 
 		a, err := xRefTable.DereferenceArray(obj)
 		if err != nil {
@@ -356,7 +357,8 @@ func validateDeviceNColorSpaceProcessDict(xRefTable *model.XRefTable, d types.Di
 
 func validateDeviceNColorSpaceSoliditiesDict(xRefTable *model.XRefTable, d types.Dict) error {
 
-	for _, obj := range d {
+	for _, k := range d.Keys() {
+		obj := d[k]
 		_, err := validateFloat(xRefTable, obj, func(f float64) bool { return f >= 0.0 && f <= 1.0 })
 		if err != nil {
 			return err
@@ -368,7 +370,8 @@ func validateDeviceNColorSpaceSoliditiesDict(xRefTable *model.XRefTable, d types
 
 func validateDeviceNColorSpaceDotGainDict(xRefTable *model.XRefTable, d types.Dict) error {
 
-	for _, obj := range d {
+	for _, k := range d.Keys() {
+		obj := d[k]
 		err := validateFunction(xRefTable, obj)
 		if err != nil {
 			return err
@@ -683,7 +686,8 @@ func validateColorSpaceResourceDict(xRefTable *model.XRefTable, o types.Object,
 	}
 
 	// Iterate over colorspace resource dictionary
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		// Process colorspace
 		err = validateColorSpace(xRefTable, o, IncludePatternCS)
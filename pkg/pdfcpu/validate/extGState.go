@@ -1027,7 +1027,8 @@ func validateExtGStateResourceDict(xRefTable *model.XRefTable, o types.Object, s
 	}
 
 	// Iterate over extGState resource dictionary
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		// Process extGStateDict
 		err = validateExtGStateDict(xRefTable, o)
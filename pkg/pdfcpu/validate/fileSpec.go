@@ -143,7 +143,8 @@ func validateFileSpecDictEntriesEFAndRFKeys(k string) bool {
 }
 
 func validateFileSpecDictEntryEFDict(xRefTable *model.XRefTable, d types.Dict) error {
-	for k, obj := range d {
+	for _, k := range d.Keys() {
+		obj := d[k]
 
 		if !validateFileSpecDictEntriesEFAndRFKeys(k) {
 			return errors.Errorf("validateFileSpecEntriesEFAndRF: invalid key: %s", k)
@@ -1156,7 +1156,8 @@ func validateFontResourceDict(xRefTable *model.XRefTable, o types.Object, sinceV
 	}
 
 	// Iterate over font resource dict
-	for id, obj := range d {
+	for _, id := range d.Keys() {
+		obj := d[id]
 
 		// Process fontDict
 		err = validateFontDict(xRefTable, obj)
@@ -44,7 +44,8 @@ import (
 func validateAppearanceSubDict(xRefTable *model.XRefTable, d types.Dict) error {
 
 	// dict of xobjects
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		if xRefTable.ValidationMode == model.ValidationRelaxed {
 			if d, ok := o.(types.Dict); ok && len(d) == 0 {
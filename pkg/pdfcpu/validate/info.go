@@ -172,7 +172,8 @@ func validateDocumentInfoDict(xRefTable *model.XRefTable, obj types.Object) (boo
 
 	hasModDate := false
 
-	for k, v := range d {
+	for _, k := range d.Keys() {
+		v := d[k]
 
 		hmd, err := validateDocInfoDictEntry(xRefTable, k, v)
 
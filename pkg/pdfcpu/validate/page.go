@@ -32,19 +32,20 @@ func validateResourceDict(xRefTable *model.XRefTable, o types.Object) (hasResour
 		return false, err
 	}
 
-	for k, v := range map[string]struct {
+	for _, v := range []struct {
+		key          string
 		validate     func(xRefTable *model.XRefTable, o types.Object, sinceVersion model.Version) error
 		sinceVersion model.Version
 	}{
-		"ExtGState":  {validateExtGStateResourceDict, model.V10},
-		"Font":       {validateFontResourceDict, model.V10},
-		"XObject":    {validateXObjectResourceDict, model.V10},
-		"Properties": {validatePropertiesResourceDict, model.V10},
-		"ColorSpace": {validateColorSpaceResourceDict, model.V10},
-		"Pattern":    {validatePatternResourceDict, model.V10},
-		"Shading":    {validateShadingResourceDict, model.V13},
+		{"ExtGState", validateExtGStateResourceDict, model.V10},
+		{"Font", validateFontResourceDict, model.V10},
+		{"XObject", validateXObjectResourceDict, model.V10},
+		{"Properties", validatePropertiesResourceDict, model.V10},
+		{"ColorSpace", validateColorSpaceResourceDict, model.V10},
+		{"Pattern", validatePatternResourceDict, model.V10},
+		{"Shading", validateShadingResourceDict, model.V13},
 	} {
-		if o, ok := d.Find(k); ok {
+		if o, ok := d.Find(v.key); ok {
 			err = v.validate(xRefTable, o, v.sinceVersion)
 			if err != nil {
 				return false, err
@@ -668,6 +669,10 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 		return err
 	}
 
+	if *coordSys == "GEO" {
+		return validateGeoMeasureDict(xRefTable, d, sinceVersion)
+	}
+
 	if *coordSys != "RL" {
 		if xRefTable.Version() > sinceVersion {
 			// unknown coord system
@@ -733,6 +738,72 @@ func validateMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion
 	return nil
 }
 
+func validateGeoCoordSysDict(xRefTable *model.XRefTable, d types.Dict, dictName, entryName string, required bool, sinceVersion model.Version) error {
+
+	d1, err := validateDictEntry(xRefTable, d, dictName, entryName, required, sinceVersion, nil)
+	if err != nil || d1 == nil {
+		return err
+	}
+
+	dictName = "geoCoordSysDict"
+
+	_, err = validateNameEntry(xRefTable, d1, dictName, "Type", REQUIRED, sinceVersion, func(s string) bool { return s == "GEOGCS" || s == "PROJCS" })
+	if err != nil {
+		return err
+	}
+
+	epsg, err := validateIntegerEntry(xRefTable, d1, dictName, "EPSG", OPTIONAL, sinceVersion, nil)
+	if err != nil {
+		return err
+	}
+
+	// WKT, required unless EPSG is present.
+	_, err = validateStringEntry(xRefTable, d1, dictName, "WKT", epsg == nil, sinceVersion, nil)
+
+	return err
+}
+
+func validateGeoMeasureDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
+
+	// see 12.10 Geospatial features, PDF 1.7 extension level 3
+
+	dictName := "geoMeasureDict"
+
+	pairs := func(a types.Array) bool { return len(a) > 0 && len(a)%2 == 0 }
+
+	_, err := validateNumberArrayEntry(xRefTable, d, dictName, "Bounds", OPTIONAL, sinceVersion, pairs)
+	if err != nil {
+		return err
+	}
+
+	// GCS, dict, required, the geographic or projected coordinate system of GPTS.
+	if err = validateGeoCoordSysDict(xRefTable, d, dictName, "GCS", REQUIRED, sinceVersion); err != nil {
+		return err
+	}
+
+	// DCS, dict, optional, the coordinate system used for displaying positions.
+	if err = validateGeoCoordSysDict(xRefTable, d, dictName, "DCS", OPTIONAL, sinceVersion); err != nil {
+		return err
+	}
+
+	// PDU, name array, optional, preferred linear, area and angular display units.
+	_, err = validateNameArrayEntry(xRefTable, d, dictName, "PDU", OPTIONAL, sinceVersion, func(a types.Array) bool { return len(a) == 3 })
+	if err != nil {
+		return err
+	}
+
+	// GPTS, number array, required, latitude/longitude pairs.
+	_, err = validateNumberArrayEntry(xRefTable, d, dictName, "GPTS", REQUIRED, sinceVersion, pairs)
+	if err != nil {
+		return err
+	}
+
+	// LPTS, number array, optional, unit square points corresponding to GPTS.
+	_, err = validateNumberArrayEntry(xRefTable, d, dictName, "LPTS", OPTIONAL, sinceVersion, pairs)
+
+	return err
+}
+
 func validateViewportDict(xRefTable *model.XRefTable, d types.Dict, sinceVersion model.Version) error {
 
 	dictName := "viewportDict"
@@ -1039,8 +1110,12 @@ func processPagesKids(xRefTable *model.XRefTable, kids types.Array, objNr int, h
 		case "Page":
 			*curPage++
 			xRefTable.CurPage = *curPage
+			xRefTable.LogDebug("validatePageDict", log.KeyPage, *curPage, log.KeyObj, objNumber)
 			if err = validatePageDict(xRefTable, pageNodeDict, hasMediaBox); err != nil {
-				return nil, err
+				if err = xRefTable.ValidationError(err, *curPage, "7.7.3.3"); err != nil {
+					return nil, err
+				}
+				continue
 			}
 			if err := xRefTable.SetValid(ir); err != nil {
 				return nil, err
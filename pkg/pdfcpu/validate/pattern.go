@@ -160,7 +160,8 @@ func validatePatternResourceDict(xRefTable *model.XRefTable, o types.Object, sin
 	}
 
 	// Iterate over pattern resource dictionary
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		// Process pattern
 		if err = validatePattern(xRefTable, o); err != nil {
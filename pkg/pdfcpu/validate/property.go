@@ -54,7 +54,8 @@ func validatePropertiesDict(xRefTable *model.XRefTable, o types.Object) error {
 		return err
 	}
 
-	for key, val := range d {
+	for _, key := range d.Keys() {
+		val := d[key]
 
 		switch key {
 
@@ -110,7 +111,8 @@ func validatePropertiesResourceDict(xRefTable *model.XRefTable, o types.Object,
 	}
 
 	// Iterate over properties resource dict
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 		if err = validatePropertiesDict(xRefTable, o); err != nil {
 			return err
 		}
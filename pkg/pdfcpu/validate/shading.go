@@ -338,7 +338,8 @@ func validateShadingResourceDict(xRefTable *model.XRefTable, obj types.Object, s
 	}
 
 	// Iterate over shading resource dictionary
-	for _, obj := range d {
+	for _, k := range d.Keys() {
+		obj := d[k]
 
 		// Process shading
 		err = validateShading(xRefTable, obj)
@@ -574,7 +574,8 @@ func validateStructTreeRootDictEntryK(xRefTable *model.XRefTable, o types.Object
 
 func processStructTreeClassMapDict(xRefTable *model.XRefTable, d types.Dict) error {
 
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		// Process dict or array of dicts.
 
@@ -309,7 +309,8 @@ func validateOPIVersionDict(xRefTable *model.XRefTable, d types.Dict) error {
 
 	validateOPIVersion := func(s string) bool { return types.MemberOf(s, []string{"1.3", "2.0"}) }
 
-	for opiVersion, obj := range d {
+	for _, opiVersion := range d.Keys() {
+		obj := d[opiVersion]
 
 		if !validateOPIVersion(opiVersion) {
 			return errors.New("pdfcpu: validateOPIVersionDict: invalid OPI version")
@@ -866,7 +867,8 @@ func validateXObjectResourceDict(xRefTable *model.XRefTable, o types.Object, sin
 	//fmt.Printf("XObjResDict:\n%s\n", d)
 
 	// Iterate over XObject resource dictionary
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		// Process XObject dict
 		err = validateXObjectStreamDict(xRefTable, o)
@@ -34,9 +34,7 @@ import (
 
 // XRefTable validates a PDF cross reference table obeying the validation mode.
 func XRefTable(ctx *model.Context) error {
-	if log.InfoEnabled() {
-		log.Info.Println("validating")
-	}
+	ctx.LogInfo("validating", "mode", ctx.XRefTable.ValidationMode)
 	if log.ValidateEnabled() {
 		log.Validate.Println("*** validateXRefTable begin ***")
 	}
@@ -52,7 +50,7 @@ func XRefTable(ctx *model.Context) error {
 		// if both info dict and catalog metadata present and metadata modification date after infodict modification date
 		// validate document information dictionary before catalog metadata.
 		err := validateDocumentInfoObject(xRefTable)
-		if err != nil {
+		if err = xRefTable.ValidationError(err, 0, "14.3.3"); err != nil {
 			return err
 		}
 	}
@@ -66,7 +64,7 @@ func XRefTable(ctx *model.Context) error {
 	if !metaDataAuthoritative {
 		// Validate document information dictionary after catalog metadata.
 		err = validateDocumentInfoObject(xRefTable)
-		if err != nil {
+		if err = xRefTable.ValidationError(err, 0, "14.3.3"); err != nil {
 			return err
 		}
 	}
@@ -228,7 +226,8 @@ func validateNames(xRefTable *model.XRefTable, rootDict types.Dict, required boo
 
 	d1 := types.Dict{}
 
-	for treeName, value := range d {
+	for _, treeName := range d.Keys() {
+		value := d[treeName]
 
 		if ok := validateNameTreeName(treeName); !ok {
 			if xRefTable.ValidationMode == model.ValidationStrict {
@@ -580,7 +579,8 @@ func validateOutputIntents(xRefTable *model.XRefTable, rootDict types.Dict, requ
 func validatePieceDict(xRefTable *model.XRefTable, d types.Dict) error {
 	dictName := "pieceDict"
 
-	for _, o := range d {
+	for _, k := range d.Keys() {
+		o := d[k]
 
 		d1, err := xRefTable.DereferenceDict(o)
 		if err != nil {
@@ -786,7 +786,8 @@ func validateCollectionFieldDict(xRefTable *model.XRefTable, d types.Dict) error
 }
 
 func validateCollectionSchemaDict(xRefTable *model.XRefTable, d types.Dict) error {
-	for k, v := range d {
+	for _, k := range d.Keys() {
+		v := d[k]
 
 		if k == "Type" {
 
@@ -837,6 +838,66 @@ func validateCollectionSortDict(xRefTable *model.XRefTable, d types.Dict) error
 	return err
 }
 
+func validateCollectionFolderDict(xRefTable *model.XRefTable, indRef types.IndirectRef, visited map[int]bool) error {
+	// => 12.3.5.4 Folders
+
+	if visited[indRef.ObjectNumber.Value()] {
+		return errors.New("pdfcpu: validateCollectionFolderDict: circular folder structure")
+	}
+	visited[indRef.ObjectNumber.Value()] = true
+
+	d, err := xRefTable.DereferenceDict(indRef)
+	if err != nil || d == nil {
+		return err
+	}
+
+	dictName := "colFolderDict"
+
+	_, err = validateNameEntry(xRefTable, d, dictName, "Type", OPTIONAL, model.V17, func(s string) bool { return s == "Folder" })
+	if err != nil {
+		return err
+	}
+
+	// ID, required integer
+	_, err = validateIntegerEntry(xRefTable, d, dictName, "ID", REQUIRED, model.V17, func(i int) bool { return i >= 0 })
+	if err != nil {
+		return err
+	}
+
+	// Name, required text string
+	_, err = validateStringEntry(xRefTable, d, dictName, "Name", REQUIRED, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	// Desc, optional text string
+	_, err = validateStringEntry(xRefTable, d, dictName, "Desc", OPTIONAL, model.V17, nil)
+	if err != nil {
+		return err
+	}
+
+	// Free, optional array of integers
+	_, err = validateIntegerArrayEntry(xRefTable, d, dictName, "Free", OPTIONAL, model.V17, func(a types.Array) bool { return len(a)%2 == 0 })
+	if err != nil {
+		return err
+	}
+
+	// Child, Next, optional indirect references to folder dicts
+	for _, entryName := range []string{"Child", "Next"} {
+		ir, err := validateIndRefEntry(xRefTable, d, dictName, entryName, OPTIONAL, model.V17)
+		if err != nil {
+			return err
+		}
+		if ir != nil {
+			if err := validateCollectionFolderDict(xRefTable, *ir, visited); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func validateInitialView(s string) bool { return s == "D" || s == "T" || s == "H" || s == "C" }
 
 func validateCollection(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -890,7 +951,13 @@ func validateCollection(xRefTable *model.XRefTable, rootDict types.Dict, require
 		}
 	}
 
-	return nil
+	// Folders, optional indirect reference to the root folder dict
+	ir, err := validateIndRefEntry(xRefTable, d, dictName, "Folders", OPTIONAL, model.V17)
+	if err != nil || ir == nil {
+		return err
+	}
+
+	return validateCollectionFolderDict(xRefTable, *ir, map[int]bool{})
 }
 
 func validateNeedsRendering(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -914,12 +981,31 @@ func validateDSS(xRefTable *model.XRefTable, rootDict types.Dict, required bool,
 func validateAF(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
 	// => 14.13 Associated Files
 
+	// PDF/A-3 (ISO 19005-3) uses associated files with PDF 1.7, eg. for hybrid invoices.
+	// Strict validation ignores them prior to PDF 2.0.
+	if xRefTable.ValidationMode == model.ValidationStrict && xRefTable.Version() < model.V20 {
+		return nil
+	}
+
 	a, err := validateArrayEntry(xRefTable, rootDict, "rootDict", "AF", required, sinceVersion, nil)
 	if err != nil || len(a) == 0 {
 		return err
 	}
 
-	return errors.New("pdfcpu: PDF2.0 \"AF\" not supported")
+	for _, o := range a {
+		d, err := xRefTable.DereferenceDict(o)
+		if err != nil {
+			return err
+		}
+		if d == nil {
+			return errors.New("pdfcpu: validateAF: missing file specification dict")
+		}
+		if err := validateFileSpecDict(xRefTable, d); err != nil {
+			return err
+		}
+	}
+
+	return nil
 }
 
 func validateDPartRoot(xRefTable *model.XRefTable, rootDict types.Dict, required bool, sinceVersion model.Version) error {
@@ -1087,51 +1173,52 @@ func validateRootObject(ctx *model.Context) error {
 
 	// Type
 	_, err = validateNameEntry(xRefTable, d, "rootDict", "Type", REQUIRED, model.V10, func(s string) bool { return s == "Catalog" })
-	if err != nil {
+	if err = xRefTable.ValidationError(err, 0, "7.7.2"); err != nil {
 		return err
 	}
 
 	// Pages
 	rootPageNodeDict, err := validatePages(xRefTable, d)
 	if err != nil {
-		return err
+		return xRefTable.ValidationError(err, 0, "7.7.3")
 	}
 
 	for _, f := range []struct {
 		validate     func(xRefTable *model.XRefTable, d types.Dict, required bool, sinceVersion model.Version) (err error)
 		required     bool
 		sinceVersion model.Version
+		specRef      string
 	}{
-		{validateRootVersion, OPTIONAL, model.V14},
-		{validateExtensions, OPTIONAL, model.V10},
-		{validatePageLabels, OPTIONAL, model.V13},
-		{validateNames, OPTIONAL, model.V11}, //model.V12},
-		{validateNamedDestinations, OPTIONAL, model.V11},
-		{validateViewerPreferences, OPTIONAL, model.V12},
-		{validatePageLayout, OPTIONAL, model.V10},
-		{validatePageMode, OPTIONAL, model.V10},
-		{validateOutlines, OPTIONAL, model.V10},
-		{validateThreads, OPTIONAL, model.V11},
-		{validateOpenAction, OPTIONAL, model.V11},
-		{validateRootAdditionalActions, OPTIONAL, model.V14},
-		{validateURI, OPTIONAL, model.V11},
-		{validateForm, OPTIONAL, model.V12},
-		{validateRootMetadata, OPTIONAL, model.V14},
-		{validateStructTree, OPTIONAL, model.V13},
-		{validateMarkInfo, OPTIONAL, model.V14},
-		{validateLang, OPTIONAL, model.V10},
-		{validateSpiderInfo, OPTIONAL, model.V13},
-		{validateOutputIntents, OPTIONAL, model.V14},
-		{validateRootPieceInfo, OPTIONAL, model.V14},
-		{validateOCProperties, OPTIONAL, model.V15},
-		{validatePermissions, OPTIONAL, model.V15},
-		{validateLegal, OPTIONAL, model.V17},
-		{validateRequirements, OPTIONAL, model.V17},
-		{validateCollection, OPTIONAL, model.V17},
-		{validateNeedsRendering, OPTIONAL, model.V17},
-		{validateDSS, OPTIONAL, model.V17},
-		{validateAF, OPTIONAL, model.V20},
-		{validateDPartRoot, OPTIONAL, model.V20},
+		{validateRootVersion, OPTIONAL, model.V14, "7.7.2"},
+		{validateExtensions, OPTIONAL, model.V10, "7.12"},
+		{validatePageLabels, OPTIONAL, model.V13, "12.4.2"},
+		{validateNames, OPTIONAL, model.V11, "7.7.4"}, //model.V12},
+		{validateNamedDestinations, OPTIONAL, model.V11, "12.3.2.3"},
+		{validateViewerPreferences, OPTIONAL, model.V12, "12.2"},
+		{validatePageLayout, OPTIONAL, model.V10, "7.7.2"},
+		{validatePageMode, OPTIONAL, model.V10, "7.7.2"},
+		{validateOutlines, OPTIONAL, model.V10, "12.3.3"},
+		{validateThreads, OPTIONAL, model.V11, "12.4.3"},
+		{validateOpenAction, OPTIONAL, model.V11, "12.6"},
+		{validateRootAdditionalActions, OPTIONAL, model.V14, "12.6.3"},
+		{validateURI, OPTIONAL, model.V11, "12.6.4.7"},
+		{validateForm, OPTIONAL, model.V12, "12.7.2"},
+		{validateRootMetadata, OPTIONAL, model.V14, "14.3.2"},
+		{validateStructTree, OPTIONAL, model.V13, "14.7.2"},
+		{validateMarkInfo, OPTIONAL, model.V14, "14.7"},
+		{validateLang, OPTIONAL, model.V10, "14.9.2"},
+		{validateSpiderInfo, OPTIONAL, model.V13, "14.10.2"},
+		{validateOutputIntents, OPTIONAL, model.V14, "14.11.5"},
+		{validateRootPieceInfo, OPTIONAL, model.V14, "14.5"},
+		{validateOCProperties, OPTIONAL, model.V15, "8.11.4"},
+		{validatePermissions, OPTIONAL, model.V15, "12.8.4"},
+		{validateLegal, OPTIONAL, model.V17, "12.8.5"},
+		{validateRequirements, OPTIONAL, model.V17, "12.10"},
+		{validateCollection, OPTIONAL, model.V17, "12.3.5"},
+		{validateNeedsRendering, OPTIONAL, model.V17, "7.7.2"},
+		{validateDSS, OPTIONAL, model.V17, "12.8.4.3"},
+		{validateAF, OPTIONAL, model.V17, "14.3"},
+		{validateDPartRoot, OPTIONAL, model.V20, "14.12"},
 	} {
 		if !f.required && xRefTable.Version() < f.sinceVersion {
 			// Ignore optional fields if currentVersion < sinceVersion
@@ -1139,7 +1226,7 @@ func validateRootObject(ctx *model.Context) error {
 			continue
 		}
 		err = f.validate(xRefTable, d, f.required, f.sinceVersion)
-		if err != nil {
+		if err = xRefTable.ValidationError(err, 0, f.specRef); err != nil {
 			return err
 		}
 	}
@@ -1151,7 +1238,8 @@ func validateRootObject(ctx *model.Context) error {
 
 	// Validate form fields against page annotations.
 	if xRefTable.Form != nil {
-		if err := validateFormFieldsAgainstPageAnnotations(xRefTable); err != nil {
+		err := validateFormFieldsAgainstPageAnnotations(xRefTable)
+		if err = xRefTable.ValidationError(err, 0, "12.7.4"); err != nil {
 			return err
 		}
 	}
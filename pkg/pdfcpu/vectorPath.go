@@ -0,0 +1,427 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// vectorState represents the graphics state parameters relevant for path painting.
+type vectorState struct {
+	ctm               matrix.Matrix
+	fill, stroke      model.PathColor
+	lineWidth         float64
+	lineCap, lineJoin int
+	miterLimit        float64
+	dash              []float64
+	dashPhase         float64
+}
+
+type vectorExtractor struct {
+	ctx   *model.Context
+	cc    *colorConverter
+	stack []vectorState
+	vectorState
+	segments   []model.PathSegment
+	start, cur types.Point // Start of the current subpath and current point in page space.
+	clip       bool
+	evenOddClp bool
+	forms      types.IntSet // Form XObjects being processed.
+	paths      []model.VectorPath
+}
+
+func initialPathColor(f colorFamily) model.PathColor {
+	switch f {
+	case colorFamilyGray:
+		return model.PathColor{Space: model.DeviceGrayCS, Values: []float64{0}}
+	case colorFamilyRGB:
+		return model.PathColor{Space: model.DeviceRGBCS, Values: []float64{0, 0, 0}}
+	}
+	return model.PathColor{Space: model.DeviceCMYKCS, Values: []float64{0, 0, 0, 1}}
+}
+
+// pathColorSpace returns the initial color of color space operand of cs or CS.
+func (ve *vectorExtractor) pathColorSpace(operand string, resDict types.Dict) model.PathColor {
+	o := ve.cc.contentColorSpace(operand, resDict)
+	if f := ve.cc.family(o); f != colorFamilyNone {
+		return initialPathColor(f)
+	}
+
+	o, _ = ve.ctx.Dereference(o)
+
+	switch cs := o.(type) {
+	case types.Name:
+		return model.PathColor{Space: cs.Value()}
+	case types.Array:
+		if len(cs) > 0 {
+			if n, ok := cs[0].(types.Name); ok {
+				pc := model.PathColor{Space: n.Value()}
+				if n == model.SeparationCS || n == model.DeviceNCS {
+					pc.Values = []float64{1}
+				}
+				return pc
+			}
+		}
+	}
+
+	name, _ := types.DecodeName(strings.TrimPrefix(operand, "/"))
+
+	return model.PathColor{Space: name}
+}
+
+func (vs *vectorState) scale() float64 {
+	return math.Sqrt(math.Abs(vs.ctm[0][0]*vs.ctm[1][1] - vs.ctm[0][1]*vs.ctm[1][0]))
+}
+
+func (ve *vectorExtractor) point(x, y float64) types.Point {
+	return ve.ctm.Transform(types.Point{X: x, Y: y})
+}
+
+func (ve *vectorExtractor) moveTo(p types.Point) {
+	ve.segments = append(ve.segments, model.PathSegment{Op: "m", Points: []types.Point{p}})
+	ve.start, ve.cur = p, p
+}
+
+func (ve *vectorExtractor) lineTo(p types.Point) {
+	ve.segments = append(ve.segments, model.PathSegment{Op: "l", Points: []types.Point{p}})
+	ve.cur = p
+}
+
+func (ve *vectorExtractor) curveTo(p1, p2, p3 types.Point) {
+	ve.segments = append(ve.segments, model.PathSegment{Op: "c", Points: []types.Point{p1, p2, p3}})
+	ve.cur = p3
+}
+
+func (ve *vectorExtractor) closePath() {
+	ve.segments = append(ve.segments, model.PathSegment{Op: "h"})
+	ve.cur = ve.start
+}
+
+func (ve *vectorExtractor) rectangle(x, y, w, h float64) {
+	ve.moveTo(ve.point(x, y))
+	ve.lineTo(ve.point(x+w, y))
+	ve.lineTo(ve.point(x+w, y+h))
+	ve.lineTo(ve.point(x, y+h))
+	ve.closePath()
+}
+
+func copyPathColor(c model.PathColor) *model.PathColor {
+	c.Values = append([]float64(nil), c.Values...)
+	return &c
+}
+
+// paint ends the current path.
+func (ve *vectorExtractor) paint(fill, stroke, evenOdd bool) {
+	if len(ve.segments) > 0 && (fill || stroke || ve.clip) {
+		vp := model.VectorPath{
+			Segments: ve.segments,
+			Fill:     fill,
+			Stroke:   stroke,
+			EvenOdd:  (fill && evenOdd) || (!fill && ve.clip && ve.evenOddClp),
+			Clip:     ve.clip,
+		}
+		if fill {
+			vp.FillColor = copyPathColor(ve.fill)
+		}
+		if stroke {
+			s := ve.scale()
+			vp.StrokeColor = copyPathColor(ve.stroke)
+			vp.LineWidth = ve.lineWidth * s
+			vp.LineCap, vp.LineJoin, vp.MiterLimit = ve.lineCap, ve.lineJoin, ve.miterLimit
+			for _, f := range ve.dash {
+				vp.Dash = append(vp.Dash, f*s)
+			}
+			vp.DashPhase = ve.dashPhase * s
+		}
+		ve.paths = append(ve.paths, vp)
+	}
+	ve.segments, ve.clip, ve.evenOddClp = nil, false, false
+}
+
+func (ve *vectorExtractor) setDash(operands []string) {
+	if len(operands) != 2 {
+		return
+	}
+	s := operands[0]
+	o, err := model.ParseObject(&s)
+	if err != nil {
+		return
+	}
+	ve.dash = numberArray(ve.ctx, o)
+	ve.dashPhase = operandNumbers(operands[1:])[0]
+}
+
+func (ve *vectorExtractor) setExtGState(operand string, resDict types.Dict) {
+	if resDict == nil {
+		return
+	}
+	d, err := ve.ctx.DereferenceDict(resDict["ExtGState"])
+	if err != nil || d == nil {
+		return
+	}
+	name, err := types.DecodeName(strings.TrimPrefix(operand, "/"))
+	if err != nil {
+		return
+	}
+	gs, err := ve.ctx.DereferenceDict(d[name])
+	if err != nil || gs == nil {
+		return
+	}
+	if f, err := ve.ctx.DereferenceNumber(gs["LW"]); err == nil && gs["LW"] != nil {
+		ve.lineWidth = f
+	}
+	if i := gs.IntEntry("LC"); i != nil {
+		ve.lineCap = *i
+	}
+	if i := gs.IntEntry("LJ"); i != nil {
+		ve.lineJoin = *i
+	}
+	if f, err := ve.ctx.DereferenceNumber(gs["ML"]); err == nil && gs["ML"] != nil {
+		ve.miterLimit = f
+	}
+	if a, err := ve.ctx.DereferenceArray(gs["D"]); err == nil && len(a) == 2 {
+		ve.dash = numberArray(ve.ctx, a[0])
+		ve.dashPhase, _ = ve.ctx.DereferenceNumber(a[1])
+	}
+}
+
+// setColor handles sc, scn, SC and SCN.
+func (ve *vectorExtractor) setColor(c *model.PathColor, operands []string) {
+	var ff []float64
+	for _, s := range operands {
+		if strings.HasPrefix(s, "/") {
+			// Pattern name
+			continue
+		}
+		ff = append(ff, operandNumbers([]string{s})[0])
+	}
+	c.Values = ff
+}
+
+// formXObject processes the form XObject denoted by operand of Do.
+func (ve *vectorExtractor) formXObject(operand string, resDict types.Dict) {
+	if resDict == nil {
+		return
+	}
+	d, err := ve.ctx.DereferenceDict(resDict["XObject"])
+	if err != nil || d == nil {
+		return
+	}
+	name, err := types.DecodeName(strings.TrimPrefix(operand, "/"))
+	if err != nil {
+		return
+	}
+	indRef := d.IndirectRefEntry(name)
+	if indRef == nil || ve.forms[indRef.ObjectNumber.Value()] {
+		return
+	}
+	sd, _, err := ve.ctx.DereferenceStreamDict(*indRef)
+	if err != nil || sd == nil || sd.Subtype() == nil || *sd.Subtype() != "Form" {
+		return
+	}
+	if err := sd.Decode(); err != nil {
+		return
+	}
+
+	res, err := ve.ctx.DereferenceDict(sd.Dict["Resources"])
+	if err != nil {
+		return
+	}
+	if res == nil {
+		res = resDict
+	}
+
+	objNr := indRef.ObjectNumber.Value()
+	ve.forms[objNr] = true
+
+	ve.stack = append(ve.stack, ve.vectorState)
+	if m := numberArray(ve.ctx, sd.Dict["Matrix"]); len(m) == 6 {
+		ve.ctm = matrixFromOperands(m).Multiply(ve.ctm)
+	}
+	ve.process(parseContentOps(sd.Content), res)
+	n := len(ve.stack)
+	ve.vectorState, ve.stack = ve.stack[n-1], ve.stack[:n-1]
+
+	delete(ve.forms, objNr)
+}
+
+func (ve *vectorExtractor) process(ops []contentOp, resDict types.Dict) {
+	for _, op := range ops {
+		ff := func(n int) ([]float64, bool) {
+			if len(op.operands) < n {
+				return nil, false
+			}
+			return operandNumbers(op.operands[len(op.operands)-n:]), true
+		}
+
+		switch op.name {
+
+		case "q":
+			vs := ve.vectorState
+			vs.dash = append([]float64(nil), vs.dash...)
+			ve.stack = append(ve.stack, vs)
+		case "Q":
+			if n := len(ve.stack); n > 0 {
+				ve.vectorState, ve.stack = ve.stack[n-1], ve.stack[:n-1]
+			}
+		case "cm":
+			if f, ok := ff(6); ok {
+				ve.ctm = matrixFromOperands(f).Multiply(ve.ctm)
+			}
+
+		case "w":
+			if f, ok := ff(1); ok {
+				ve.lineWidth = f[0]
+			}
+		case "J":
+			if f, ok := ff(1); ok {
+				ve.lineCap = int(f[0])
+			}
+		case "j":
+			if f, ok := ff(1); ok {
+				ve.lineJoin = int(f[0])
+			}
+		case "M":
+			if f, ok := ff(1); ok {
+				ve.miterLimit = f[0]
+			}
+		case "d":
+			ve.setDash(op.operands)
+		case "gs":
+			if len(op.operands) == 1 {
+				ve.setExtGState(op.operands[0], resDict)
+			}
+
+		case "g", "rg", "k", "G", "RG", "K":
+			f := colorOperatorFamilies[strings.ToLower(op.name)]
+			if x, ok := numericOperands(op.operands, f.components()); ok {
+				c := model.PathColor{Space: f.colorSpace().Value(), Values: x}
+				if op.name == strings.ToLower(op.name) {
+					ve.fill = c
+				} else {
+					ve.stroke = c
+				}
+			}
+		case "cs":
+			if len(op.operands) == 1 {
+				ve.fill = ve.pathColorSpace(op.operands[0], resDict)
+			}
+		case "CS":
+			if len(op.operands) == 1 {
+				ve.stroke = ve.pathColorSpace(op.operands[0], resDict)
+			}
+		case "sc", "scn":
+			ve.setColor(&ve.fill, op.operands)
+		case "SC", "SCN":
+			ve.setColor(&ve.stroke, op.operands)
+
+		case "m":
+			if f, ok := ff(2); ok {
+				ve.moveTo(ve.point(f[0], f[1]))
+			}
+		case "l":
+			if f, ok := ff(2); ok {
+				ve.lineTo(ve.point(f[0], f[1]))
+			}
+		case "c":
+			if f, ok := ff(6); ok {
+				ve.curveTo(ve.point(f[0], f[1]), ve.point(f[2], f[3]), ve.point(f[4], f[5]))
+			}
+		case "v":
+			if f, ok := ff(4); ok {
+				ve.curveTo(ve.cur, ve.point(f[0], f[1]), ve.point(f[2], f[3]))
+			}
+		case "y":
+			if f, ok := ff(4); ok {
+				p := ve.point(f[2], f[3])
+				ve.curveTo(ve.point(f[0], f[1]), p, p)
+			}
+		case "h":
+			ve.closePath()
+		case "re":
+			if f, ok := ff(4); ok {
+				ve.rectangle(f[0], f[1], f[2], f[3])
+			}
+
+		case "W", "W*":
+			ve.clip, ve.evenOddClp = true, op.name == "W*"
+
+		case "S":
+			ve.paint(false, true, false)
+		case "s":
+			ve.closePath()
+			ve.paint(false, true, false)
+		case "f", "F":
+			ve.paint(true, false, false)
+		case "f*":
+			ve.paint(true, false, true)
+		case "B":
+			ve.paint(true, true, false)
+		case "B*":
+			ve.paint(true, true, true)
+		case "b":
+			ve.closePath()
+			ve.paint(true, true, false)
+		case "b*":
+			ve.closePath()
+			ve.paint(true, true, true)
+		case "n":
+			ve.paint(false, false, false)
+
+		case "Do":
+			if len(op.operands) == 1 {
+				ve.formXObject(op.operands[0], resDict)
+			}
+		}
+	}
+}
+
+// PageVectorPaths returns the vector paths of page pageNr including paths drawn by form XObjects.
+// Points are transformed into default user space. Page rotation is not applied.
+func PageVectorPaths(ctx *model.Context, pageNr int) (*model.PageVectorPaths, error) {
+	_, _, inhPAttrs, err := ctx.PageDict(pageNr, false)
+	if err != nil {
+		return nil, err
+	}
+
+	_, bb, resDict, err := pageContentAndResources(ctx, pageNr)
+	if err != nil {
+		return nil, err
+	}
+
+	ve := vectorExtractor{
+		ctx: ctx,
+		cc:  &colorConverter{ctx: ctx},
+		vectorState: vectorState{
+			ctm:        matrix.IdentMatrix,
+			fill:       initialPathColor(colorFamilyGray),
+			stroke:     initialPathColor(colorFamilyGray),
+			lineWidth:  1,
+			miterLimit: 10,
+		},
+		forms: types.IntSet{},
+	}
+
+	ve.process(parseContentOps(bb), resDict)
+
+	return &model.PageVectorPaths{PageNr: pageNr, MediaBox: inhPAttrs.MediaBox, Paths: ve.paths}, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func TestVectorPaths(t *testing.T) {
+	in := "q 2 0 0 2 10 20 cm 0.5 w 1 J [3 1] 0 d 1 0 0 RG 0 0 10 5 re S Q\n" +
+		"0 0 1 rg 0 0 m 10 0 l 10 10 5 10 v h f*\n" +
+		"0 0 m 100 100 l W n\n" +
+		"0 0 m 1 1 l"
+
+	ve := vectorExtractor{
+		ctx:         &model.Context{},
+		vectorState: vectorState{ctm: matrix.IdentMatrix, lineWidth: 1},
+		forms:       types.IntSet{},
+	}
+	ve.cc = &colorConverter{ctx: ve.ctx}
+	ve.process(parseContentOps([]byte(in)), nil)
+
+	if len(ve.paths) != 3 {
+		t.Fatalf("got %d paths, want 3\n", len(ve.paths))
+	}
+
+	p := ve.paths[0]
+	if !p.Stroke || p.Fill || len(p.Segments) != 5 {
+		t.Fatalf("rectangle: got %+v\n", p)
+	}
+	if pt := p.Segments[2].Points[0]; pt.X != 30 || pt.Y != 30 {
+		t.Fatalf("rectangle: got corner %v, want (30,30)\n", pt)
+	}
+	if p.LineWidth != 1 || p.LineCap != 1 || len(p.Dash) != 2 || p.Dash[0] != 6 {
+		t.Fatalf("line style: got %+v\n", p)
+	}
+	if p.StrokeColor.Space != model.DeviceRGBCS || p.StrokeColor.Values[0] != 1 {
+		t.Fatalf("stroke color: got %+v\n", p.StrokeColor)
+	}
+
+	p = ve.paths[1]
+	if !p.Fill || !p.EvenOdd || p.FillColor.Values[2] != 1 {
+		t.Fatalf("fill: got %+v\n", p)
+	}
+	if seg := p.Segments[2]; seg.Op != "c" || seg.Points[0] != (types.Point{X: 10, Y: 0}) {
+		t.Fatalf("v: got %+v\n", seg)
+	}
+
+	if p = ve.paths[2]; !p.Clip || p.Fill || p.Stroke || p.SVG() != "" {
+		t.Fatalf("clip: got %+v\n", p)
+	}
+}
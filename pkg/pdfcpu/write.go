@@ -44,6 +44,7 @@ func writeObjects(ctx *model.Context) error {
 	}
 
 	// Write document information dictionary.
+	packObjects(ctx)
 	if err := writeDocumentInfoDict(ctx); err != nil {
 		return err
 	}
@@ -57,6 +58,13 @@ func writeObjects(ctx *model.Context) error {
 		return err
 	}
 
+	if ctx.Write.WriteToObjectStream {
+		if err := stopObjectStream(ctx); err != nil {
+			return err
+		}
+	}
+
+	// The encryption dict must not go into an object stream.
 	return writeEncryptDict(ctx)
 }
 
@@ -173,7 +181,13 @@ func prepareContextForWriting(ctx *model.Context) error {
 		return err
 	}
 
-	return handleEncryption(ctx)
+	if err := handleEncryption(ctx); err != nil {
+		return err
+	}
+
+	applyObjectStreamMode(ctx)
+
+	return nil
 }
 
 func writeAdditionalStreams(ctx *model.Context) error {
@@ -221,8 +235,25 @@ func ensureInfoDictAndFileID(ctx *model.Context) error {
 	return ensureFileID(ctx)
 }
 
+// packObjects turns on writing to object streams if all eligible objects are supposed to be packed.
+func packObjects(ctx *model.Context) {
+	if ctx.ObjectStreamMode == model.ObjectStreamsPack {
+		ctx.Write.WriteToObjectStream = true
+	}
+}
+
+// stopObjectStreamUnlessPacking finishes the current object stream unless all eligible objects are supposed to be packed.
+func stopObjectStreamUnlessPacking(ctx *model.Context) error {
+	if ctx.ObjectStreamMode == model.ObjectStreamsPack {
+		return nil
+	}
+	return stopObjectStream(ctx)
+}
+
 // Write root entry to disk.
 func writeRootEntry(ctx *model.Context, d types.Dict, dictName, entryName string, statsAttr int) error {
+	packObjects(ctx)
+
 	o, err := writeEntry(ctx, d, dictName, entryName)
 	if err != nil {
 		return err
@@ -243,7 +274,7 @@ func writeRootEntryToObjStream(ctx *model.Context, d types.Dict, dictName, entry
 		return err
 	}
 
-	return stopObjectStream(ctx)
+	return stopObjectStreamUnlessPacking(ctx)
 }
 
 // Write page tree.
@@ -263,7 +294,7 @@ func writePages(ctx *model.Context, rootDict types.Dict) error {
 		return err
 	}
 
-	return stopObjectStream(ctx)
+	return stopObjectStreamUnlessPacking(ctx)
 }
 
 func writeRootAttrsBatch1(ctx *model.Context, d types.Dict, dictName string) error {
@@ -337,7 +368,7 @@ func writeRootObject(ctx *model.Context) error {
 	}
 
 	// Ensure corresponding and accurate name tree object graphs.
-	if !ctx.ApplyReducedFeatureSet() {
+	if !ctx.ApplyReducedFeatureSet() || ctx.PreserveNamedDests() {
 		if err := ctx.BindNameTrees(); err != nil {
 			return err
 		}
@@ -356,14 +387,18 @@ func writeRootObject(ctx *model.Context) error {
 
 	if ctx.ApplyReducedFeatureSet() {
 		log.Write.Println("writeRootObject - reducedFeatureSet:exclude complex entries.")
-		d.Delete("Names")
-		d.Delete("Dests")
+		if !ctx.PreserveNamedDests() {
+			d.Delete("Names")
+			d.Delete("Dests")
+		}
 		d.Delete("Outlines")
 		d.Delete("OpenAction")
 		d.Delete("StructTreeRoot")
 		d.Delete("OCProperties")
 	}
 
+	packObjects(ctx)
+
 	if err = writeDictObject(ctx, objNumber, genNumber, d); err != nil {
 		return err
 	}
@@ -920,6 +955,10 @@ func setupEncryption(ctx *model.Context) error {
 		return errors.New("pdfcpu: unsupported encryption algorithm (PDF 2.0 assumes AES/256)")
 	}
 
+	if len(ctx.Recipients) > 0 {
+		return setupPubSecEncryption(ctx)
+	}
+
 	d := newEncryptDict(
 		ctx.XRefTable.Version(),
 		ctx.EncryptUsingAES,
@@ -970,6 +1009,10 @@ func updateEncryption(ctx *model.Context) error {
 		return err
 	}
 
+	if len(ctx.E.Recipients) > 0 {
+		return errors.New("pdfcpu: changing passwords or permissions is not supported for files encrypted for recipients")
+	}
+
 	if ctx.Cmd == model.SETPERMISSIONS {
 		//fmt.Printf("updating permissions to: %v\n", ctx.UserAccessPermissions)
 		ctx.E.P = int(ctx.Permissions)
@@ -1050,7 +1093,7 @@ func handleEncryption(ctx *model.Context) error {
 	}
 
 	// write xrefstream if using xrefstream only.
-	if ctx.Encrypt != nil && ctx.EncKey != nil && !ctx.Read.UsingXRefStreams {
+	if ctx.Encrypt != nil && ctx.EncKey != nil && (ctx.Read == nil || !ctx.Read.UsingXRefStreams) {
 		ctx.WriteObjectStream = false
 		ctx.WriteXRefStream = false
 	}
@@ -1058,6 +1101,18 @@ func handleEncryption(ctx *model.Context) error {
 	return nil
 }
 
+// applyObjectStreamMode overrides WriteObjectStream and WriteXRefStream for an explicit object stream mode.
+func applyObjectStreamMode(ctx *model.Context) {
+	switch ctx.ObjectStreamMode {
+	case model.ObjectStreamsPack:
+		ctx.WriteObjectStream = true
+		ctx.WriteXRefStream = true
+	case model.ObjectStreamsExpand:
+		ctx.WriteObjectStream = false
+		ctx.WriteXRefStream = false
+	}
+}
+
 func writeXRef(ctx *model.Context) error {
 	if ctx.WriteXRefStream {
 		// Write cross reference stream and generate objectstreams.
@@ -23,6 +23,8 @@ import (
 	"image/color"
 	"image/png"
 	"io"
+	"log/slog"
+	"math"
 	"os"
 	"strings"
 
@@ -46,17 +48,26 @@ type colValRange struct {
 
 // PDFImage represents a XObject of subtype image.
 type PDFImage struct {
+	xRefTable *model.XRefTable
 	objNr     int
 	sd        *types.StreamDict
 	comp      int
 	bpc       int
 	w, h      int
-	softMask  []byte
+	softMask  *pdfSoftMask
 	decode    []colValRange
 	imageMask bool
 	thumb     bool
 }
 
+func (im *PDFImage) logRender(msg string, args ...any) {
+	if im.xRefTable == nil || !im.xRefTable.LogEnabled(slog.LevelDebug) {
+		return
+	}
+	args = append([]any{log.KeyObj, im.objNr, "w", im.w, "h", im.h, "bpc", im.bpc, "buflen", len(im.sd.Content)}, args...)
+	im.xRefTable.LogDebug(msg, args...)
+}
+
 func decodeArr(a types.Array) []colValRange {
 	if a == nil {
 		return nil
@@ -124,6 +135,7 @@ func pdfImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, objN
 	}
 
 	return &PDFImage{
+		xRefTable: xRefTable,
 		objNr:     objNr,
 		sd:        sd,
 		comp:      comp,
@@ -150,7 +162,7 @@ func colorLookupTable(xRefTable *model.XRefTable, o types.Object) ([]byte, error
 		return o.Bytes()
 
 	case types.StreamDict:
-		return streamBytes(&o)
+		return streamBytes(xRefTable, &o)
 
 	}
 
@@ -161,19 +173,39 @@ func maxValForBits(bpc int) int {
 	return 1<<bpc - 1
 }
 
-// Decode v into the bpc deep color image space using the applicable DecodeArray component.
-func decodePixelValue(v uint8, bpc int, r colValRange) uint8 {
-	q := float64(maxValForBits(bpc))
-	f := r.min + (float64(v) * (r.max - r.min) / q)
-	return uint8(f * q)
+// sampleAt returns the value of the c'th of n color components of pixel x in row y of bb.
+// Rows start at byte boundaries.
+func sampleAt(bb []byte, w, n, bpc, x, y, c int) int {
+	bit := (x*n + c) * bpc
+	i := y*((w*n*bpc+7)/8) + bit/8
+
+	switch bpc {
+	case 8:
+		return int(bb[i])
+	case 16:
+		return int(bb[i])<<8 | int(bb[i+1])
+	}
+
+	return int(bb[i]>>(8-bpc-bit%8)) & maxValForBits(bpc)
+}
+
+// Decode v into the range r using the applicable DecodeArray component.
+func decodeSample(v, bpc int, r colValRange) float64 {
+	return r.min + float64(v)*(r.max-r.min)/float64(maxValForBits(bpc))
 }
 
-func streamBytes(sd *types.StreamDict) ([]byte, error) {
+func toBPC8(f float64) uint8 {
+	return uint8(math.Round(clamp01(f) * 255))
+}
+
+func toBPC16(f float64) uint16 {
+	return uint16(math.Round(clamp01(f) * 65535))
+}
+
+func streamBytes(xRefTable *model.XRefTable, sd *types.StreamDict) ([]byte, error) {
 	fpl := sd.FilterPipeline
 	if fpl == nil {
-		if log.InfoEnabled() {
-			log.Info.Printf("streamBytes: no filter pipeline\n")
-		}
+		xRefTable.LogInfo("streamBytes: no filter pipeline")
 		if err := sd.Decode(); err != nil {
 			return nil, err
 		}
@@ -192,30 +224,80 @@ func streamBytes(sd *types.StreamDict) ([]byte, error) {
 
 	switch f {
 
-	case filter.DCT, filter.Flate, filter.CCITTFax, filter.ASCII85, filter.RunLength:
+	case filter.DCT, filter.Flate, filter.CCITTFax, filter.JBIG2, filter.ASCII85, filter.RunLength:
 		// If color space is CMYK then write .tif else write .png
 		if err := sd.Decode(); err != nil {
 			return nil, err
 		}
 
 	case filter.JPX:
-		//imageObj.Extension = "jpx"
+		img, err := filter.DecodeJPX(bytes.NewReader(sd.Raw))
+		if err != nil {
+			return nil, err
+		}
+		return jpxSamples8(img), nil
 
 	default:
-		if log.DebugEnabled() {
-			log.Debug.Printf("streamBytes: skip img, filter %s unsupported\n", filters)
+		if !filter.Registered(f) {
+			xRefTable.LogDebug("streamBytes: skip img, unsupported filter", "filter", filters)
+			return nil, nil
+		}
+		if err := sd.Decode(); err != nil {
+			if err == filter.ErrUnsupportedFilter {
+				return nil, nil
+			}
+			return nil, err
 		}
-		return nil, nil
 	}
 
 	return sd.Content, nil
 }
 
-// Return the soft mask for this image or nil.
-func softMask(xRefTable *model.XRefTable, d *types.StreamDict, w, h, objNr int) ([]byte, error) {
+// pdfSoftMask represents the decoded samples of an image's soft mask.
+type pdfSoftMask struct {
+	w, h, bpc int
+	pix       []byte
+	decode    colValRange
+	matte     []float64 // the color the parent image has been preblended with
+}
 
-	// TODO Process optional "Matte".
+// alpha returns the opacity of pixel x,y of an image with dimensions w x h.
+func (sm *pdfSoftMask) alpha(x, y, w, h int) float64 {
+	// A soft mask may have a resolution different from its parent image.
+	x, y = x*sm.w/w, y*sm.h/h
+	return clamp01(decodeSample(sampleAt(sm.pix, sm.w, 1, sm.bpc, x, y, 0), sm.bpc, sm.decode))
+}
 
+func softMaskBytes(xRefTable *model.XRefTable, sd *types.StreamDict, w, h int) ([]byte, int, error) {
+	fpl := sd.FilterPipeline
+	if len(fpl) > 0 && fpl[len(fpl)-1].Name == filter.DCT {
+		if err := sd.Decode(); err != nil {
+			return nil, 0, err
+		}
+		bb, err := dctSamples(sd.Content, w, h, 1)
+		return bb, 8, err
+	}
+
+	bb, err := streamBytes(xRefTable, sd)
+	if err != nil || bb == nil {
+		return nil, 0, err
+	}
+
+	if len(fpl) > 0 && fpl[len(fpl)-1].Name == filter.JPX {
+		// JPX samples are scaled to 8 bits.
+		return bb, 8, nil
+	}
+
+	bpc := sd.IntEntry("BitsPerComponent")
+	if bpc == nil {
+		return nil, 0, nil
+	}
+
+	return bb, *bpc, nil
+}
+
+// Return the soft mask for this image or nil.
+func softMask(xRefTable *model.XRefTable, d *types.StreamDict, w, h, objNr int) (*pdfSoftMask, error) {
 	o, _ := d.Find("SMask")
 	if o == nil {
 		// No soft mask available.
@@ -225,148 +307,143 @@ func softMask(xRefTable *model.XRefTable, d *types.StreamDict, w, h, objNr int)
 	// Soft mask present.
 
 	sd, _, err := xRefTable.DereferenceStreamDict(o)
-	if err != nil {
+	if err != nil || sd == nil {
 		return nil, err
 	}
 
-	sm, err := streamBytes(sd)
+	sm := &pdfSoftMask{w: w, h: h, decode: colValRange{0, 1}}
+	if i := sd.IntEntry("Width"); i != nil && *i > 0 {
+		sm.w = *i
+	}
+	if i := sd.IntEntry("Height"); i != nil && *i > 0 {
+		sm.h = *i
+	}
+
+	sm.pix, sm.bpc, err = softMaskBytes(xRefTable, sd, sm.w, sm.h)
 	if err != nil {
-		return nil, err
+		xRefTable.LogInfo("softMask: ignoring corrupt softmask", log.KeyObj, objNr, "err", err)
+		return nil, nil
 	}
 
-	bpc := sd.IntEntry("BitsPerComponent")
-	if bpc == nil {
-		if log.InfoEnabled() {
-			log.Info.Printf("softMask: obj#%d - ignoring soft mask without bpc\n%s\n", objNr, sd)
-		}
+	if sm.pix == nil {
+		xRefTable.LogInfo("softMask: ignoring soft mask without bpc", log.KeyObj, objNr)
 		return nil, nil
 	}
 
-	// TODO support soft masks with bpc != 8
-	// Will need to return the softmask bpc to caller.
-	if *bpc != 8 {
-		if log.InfoEnabled() {
-			log.Info.Printf("softMask: obj#%d - ignoring soft mask with bpc=%d\n", objNr, *bpc)
-		}
+	if !types.IntMemberOf(sm.bpc, []int{1, 2, 4, 8, 16}) || len(sm.pix) < (sm.bpc*sm.w+7)/8*sm.h {
+		xRefTable.LogInfo("softMask: ignoring corrupt softmask", log.KeyObj, objNr)
 		return nil, nil
 	}
 
-	if sm != nil {
-		if len(sm) != (*bpc*w*h+7)/8 {
-			if log.InfoEnabled() {
-				log.Info.Printf("softMask: obj#%d - ignoring corrupt softmask\n%s\n", objNr, sd)
-			}
-			return nil, nil
+	if decode := decodeArr(sd.ArrayEntry("Decode")); len(decode) > 0 {
+		sm.decode = decode[0]
+	}
+
+	for _, o := range sd.ArrayEntry("Matte") {
+		f, err := xRefTable.DereferenceNumber(o)
+		if err != nil {
+			return nil, err
 		}
+		sm.matte = append(sm.matte, f)
 	}
 
 	return sm, nil
 }
 
-func imageForCMYKWithoutSoftMask(im *PDFImage) image.Image {
-
-	// Preserve CMYK color model for print applications.
-
-	// TODO support bpc, decode.
-
-	img := image.NewCMYK(image.Rect(0, 0, im.w, im.h))
-	b := im.sd.Content
-	i := 0
-
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; x++ {
-			img.Set(x, y, color.CMYK{C: b[i], M: b[i+1], Y: b[i+2], K: b[i+3]})
-			i += im.comp
-		}
-	}
+// pixelFunc decodes the color components of pixel x,y.
+type pixelFunc func(x, y int, c []float64)
 
-	return img
+// rgbFunc converts decoded color components to RGB.
+type rgbFunc func(c []float64) (r, g, b float64)
 
+func grayToRGB(c []float64) (float64, float64, float64) {
+	return c[0], c[0], c[0]
 }
 
-func imageForCMYKWithSoftMask(im *PDFImage) image.Image {
-
-	// TODO support bpc, decode.
+func rgbToRGB(c []float64) (float64, float64, float64) {
+	return c[0], c[1], c[2]
+}
 
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
-	b := im.sd.Content
-	i := 0
+func cmykToRGB(c []float64) (float64, float64, float64) {
+	k := 1 - c[3]
+	return (1 - c[0]) * k, (1 - c[1]) * k, (1 - c[2]) * k
+}
 
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; x++ {
-			cr, cg, cb := color.CMYKToRGB(b[i], b[i+1], b[i+2], b[i+3])
-			alpha := im.softMask[y*im.w+x]
-			img.Set(x, y, color.NRGBA{cr, cg, cb, alpha})
-			i += 4
+// components decodes the color components of pixel x,y applying the Decode array.
+func (im *PDFImage) components(x, y int, c []float64) {
+	for i := range c {
+		r := colValRange{0, 1}
+		if i < len(im.decode) {
+			r = im.decode[i]
 		}
+		c[i] = decodeSample(sampleAt(im.sd.Content, im.w, im.comp, im.bpc, x, y, i), im.bpc, r)
 	}
-
-	return img
 }
 
-func renderDeviceCMYKToTIFF(im *PDFImage) (io.Reader, string, error) {
-	b := im.sd.Content
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderDeviceCMYKToTIFF: CMYK objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-	}
-
-	var img image.Image
-	if im.softMask != nil {
-		img = imageForCMYKWithSoftMask(im)
-	} else {
-		img = imageForCMYKWithoutSoftMask(im)
+// lookup returns a pixelFunc resolving the pixels of an indexed image
+// against a color table with n components per entry.
+func (im *PDFImage) lookup(lookup []byte, n, maxInd int) pixelFunc {
+	if l := len(lookup)/n - 1; l < maxInd {
+		maxInd = l
 	}
 
-	var buf bytes.Buffer
-	if err := tiff.Encode(&buf, img, nil); err != nil {
-		return nil, "", err
+	return func(x, y int, c []float64) {
+		ind := sampleAt(im.sd.Content, im.w, 1, im.bpc, x, y, 0)
+		if len(im.decode) > 0 {
+			// The Decode array maps samples to color table indices.
+			ind = int(math.Round(decodeSample(ind, im.bpc, im.decode[0])))
+		}
+		ind = max(0, min(ind, maxInd))
+		for i := range c {
+			c[i] = float64(lookup[ind*n+i]) / 255
+		}
 	}
-
-	return &buf, "tif", nil
 }
 
-func scaleToBPC8(v uint8, bpc int) uint8 {
-	return uint8(float64(v) * 255.0 / float64(maxValForBits(bpc)))
+// validate checks the sample buffer of im which holds n components per pixel.
+func (im *PDFImage) validate(n int) bool {
+	// Sometimes there is a trailing 0x0A in addition to the imagebytes.
+	return types.IntMemberOf(im.bpc, []int{1, 2, 4, 8, 16}) && len(im.sd.Content) >= (n*im.bpc*im.w+7)/8*im.h
 }
 
-func renderDeviceGrayToPNG(im *PDFImage) (io.Reader, string, error) {
-	b := im.sd.Content
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderDeviceGrayToPNG: objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-	}
+// renderPNG encodes im as PNG using the soft mask as alpha channel.
+// Images with 16 bits per component result in 16 bit PNG files.
+func (im *PDFImage) renderPNG(n int, pixel pixelFunc, rgb rgbFunc, matte bool) (io.Reader, string, error) {
+	r := image.Rect(0, 0, im.w, im.h)
+	sm := im.softMask
 
-	// Validate buflen.
-	// For streams not using compression there is a trailing 0x0A in addition to the imagebytes.
-	if len(b) < (im.bpc*im.w*im.h+7)/8 {
-		return nil, "", errors.Errorf("pdfcpu: renderDeviceGrayToPNG: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
-	}
-
-	cvr := colValRange{0, 1}
-	if im.decode != nil {
-		cvr = im.decode[0]
+	var img image.Image
+	var img8 *image.NRGBA
+	var img16 *image.NRGBA64
+	if im.bpc == 16 || sm != nil && sm.bpc == 16 {
+		img16 = image.NewNRGBA64(r)
+		img = img16
+	} else {
+		img8 = image.NewNRGBA(r)
+		img = img8
 	}
 
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
+	c := make([]float64, n)
 
-	i := 0
 	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; {
-			p := b[i]
-			for j := 0; j < 8/im.bpc && x < im.w; j++ {
-				pix := p >> (8 - uint8(im.bpc))
-				v := decodePixelValue(pix, im.bpc, cvr)
-				if im.bpc < 8 {
-					v = scaleToBPC8(v, im.bpc)
-				}
-				alpha := uint8(255)
-				if im.softMask != nil {
-					alpha = im.softMask[y*im.w+x]
+		for x := 0; x < im.w; x++ {
+			pixel(x, y, c)
+			a := 1.
+			if sm != nil {
+				a = sm.alpha(x, y, im.w, im.h)
+				if matte && len(sm.matte) >= n && a > 0 {
+					// Undo the preblending with the matte color (11.6.5.3).
+					for i := range c {
+						c[i] = sm.matte[i] + (c[i]-sm.matte[i])/a
+					}
 				}
-				img.Set(x, y, color.NRGBA{R: v, G: v, B: v, A: alpha})
-				p <<= uint8(im.bpc)
-				x++
 			}
-			i++
+			cr, cg, cb := rgb(c)
+			if img16 != nil {
+				img16.SetNRGBA64(x, y, color.NRGBA64{R: toBPC16(cr), G: toBPC16(cg), B: toBPC16(cb), A: toBPC16(a)})
+				continue
+			}
+			img8.SetNRGBA(x, y, color.NRGBA{R: toBPC8(cr), G: toBPC8(cg), B: toBPC8(cb), A: toBPC8(a)})
 		}
 	}
 
@@ -378,71 +455,77 @@ func renderDeviceGrayToPNG(im *PDFImage) (io.Reader, string, error) {
 	return &buf, "png", nil
 }
 
-func renderDeviceRGBToPNG(im *PDFImage) (io.Reader, string, error) {
-	b := im.sd.Content
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderDeviceRGBToPNG: objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-	}
-
-	// Validate buflen.
-	// Sometimes there is a trailing 0x0A in addition to the imagebytes.
-	if len(b) < (3*im.bpc*im.w*im.h+7)/8 {
-		return nil, "", errors.Errorf("pdfcpu: renderDeviceRGBToPNG: objNr=%d corrupt image object\n", im.objNr)
+// renderCMYK encodes im as TIFF preserving the CMYK color model for print applications.
+// Images with a soft mask are converted to RGB and encoded as PNG.
+func (im *PDFImage) renderCMYK(pixel pixelFunc, matte bool) (io.Reader, string, error) {
+	if im.softMask != nil {
+		return im.renderPNG(4, pixel, cmykToRGB, matte)
 	}
 
-	// TODO Support bpc and decode.
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
+	img := image.NewCMYK(image.Rect(0, 0, im.w, im.h))
+	c := make([]float64, 4)
 
-	i := 0
 	for y := 0; y < im.h; y++ {
 		for x := 0; x < im.w; x++ {
-			alpha := uint8(255)
-			if im.softMask != nil {
-				alpha = im.softMask[y*im.w+x]
-			}
-			img.Set(x, y, color.NRGBA{R: b[i], G: b[i+1], B: b[i+2], A: alpha})
-			i += 3
+			pixel(x, y, c)
+			img.SetCMYK(x, y, color.CMYK{C: toBPC8(c[0]), M: toBPC8(c[1]), Y: toBPC8(c[2]), K: toBPC8(c[3])})
 		}
 	}
 
 	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
+	if err := tiff.Encode(&buf, img, nil); err != nil {
 		return nil, "", err
 	}
 
-	return &buf, "png", nil
+	return &buf, "tif", nil
 }
 
-func renderCalRGBToPNG(im *PDFImage) (io.Reader, string, error) {
-	b := im.sd.Content
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderCalRGBToPNG: objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
+func renderDeviceCMYKToTIFF(im *PDFImage) (io.Reader, string, error) {
+	im.logRender("renderDeviceCMYKToTIFF")
+
+	if !im.validate(im.comp) || im.comp < 4 {
+		return nil, "", errors.Errorf("pdfcpu: renderDeviceCMYKToTIFF: objNr=%d corrupt image object\n", im.objNr)
 	}
 
-	if len(b) < (3*im.bpc*im.w*im.h+7)/8 {
-		return nil, "", errors.Errorf("pdfcpu:renderCalRGBToPNG: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
+	return im.renderCMYK(im.components, true)
+}
+
+func renderDeviceGrayToPNG(im *PDFImage) (io.Reader, string, error) {
+	im.logRender("renderDeviceGrayToPNG")
+
+	// Validate buflen.
+	// For streams not using compression there is a trailing 0x0A in addition to the imagebytes.
+	if im.comp < 1 {
+		im.comp = 1
+	}
+	if !im.validate(im.comp) {
+		return nil, "", errors.Errorf("pdfcpu: renderDeviceGrayToPNG: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
 	}
 
-	// Optional int array "Range", length 2*N specifies min,max values of color components.
-	// This information can be validated against the iccProfile.
+	return im.renderPNG(1, im.components, grayToRGB, true)
+}
 
-	// RGB
-	// TODO Support bpc, decode and softmask.
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
-	i := 0
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; x++ {
-			img.Set(x, y, color.NRGBA{R: b[i], G: b[i+1], B: b[i+2], A: 255})
-			i += 3
-		}
+func renderDeviceRGBToPNG(im *PDFImage) (io.Reader, string, error) {
+	im.logRender("renderDeviceRGBToPNG")
+
+	if !im.validate(im.comp) || im.comp < 3 {
+		return nil, "", errors.Errorf("pdfcpu: renderDeviceRGBToPNG: objNr=%d corrupt image object\n", im.objNr)
 	}
 
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
-		return nil, "", err
+	return im.renderPNG(3, im.components, rgbToRGB, true)
+}
+
+func renderCalRGBToPNG(im *PDFImage) (io.Reader, string, error) {
+	im.logRender("renderCalRGBToPNG")
+
+	if !im.validate(3) {
+		return nil, "", errors.Errorf("pdfcpu:renderCalRGBToPNG: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
 	}
 
-	return &buf, "png", nil
+	// Optional int array "Range", length 2*N specifies min,max values of color components.
+	// This information can be validated against the iccProfile.
+
+	return im.renderPNG(3, im.components, rgbToRGB, true)
 }
 
 func renderICCBased(xRefTable *model.XRefTable, im *PDFImage, cs types.Array) (io.Reader, string, error) {
@@ -451,11 +534,7 @@ func renderICCBased(xRefTable *model.XRefTable, im *PDFImage, cs types.Array) (i
 
 	iccProfileStream, _, _ := xRefTable.DereferenceStreamDict(cs[1])
 
-	b := im.sd.Content
-
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderICCBasedToPNGFile: objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-	}
+	im.logRender("renderICCBased")
 
 	// 1,3 or 4 color components.
 	n := *iccProfileStream.IntEntry("N")
@@ -469,8 +548,7 @@ func renderICCBased(xRefTable *model.XRefTable, im *PDFImage, cs types.Array) (i
 	// regardless of a specified alternate color space.
 
 	// Validate buflen.
-	// Sometimes there is a trailing 0x0A in addition to the imagebytes.
-	if len(b) < (n*im.bpc*im.w*im.h+7)/8 {
+	if !im.validate(n) {
 		return nil, "", errors.Errorf("pdfcpu: renderICCBased: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
 	}
 
@@ -491,158 +569,19 @@ func renderICCBased(xRefTable *model.XRefTable, im *PDFImage, cs types.Array) (i
 	return nil, "", nil
 }
 
-func renderIndexedGrayToPNG(im *PDFImage, lookup []byte) (io.Reader, string, error) {
-	b := im.sd.Content
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderIndexedGrayToPNG: objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-	}
-
-	// Validate buflen.
-	// For streams not using compression there is a trailing 0x0A in addition to the imagebytes.
-	if len(b) < (im.bpc*im.w*im.h+7)/8 {
-		return nil, "", errors.Errorf("pdfcpu: renderIndexedGrayToPNG: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
-	}
-
-	cvr := colValRange{0, 1}
-	if im.decode != nil {
-		cvr = im.decode[0]
-	}
-
-	img := image.NewGray(image.Rect(0, 0, im.w, im.h))
+func renderIndexedGrayToPNG(im *PDFImage, lookup []byte, maxInd int) (io.Reader, string, error) {
+	im.logRender("renderIndexedGrayToPNG")
 
-	// TODO support softmask.
-	i := 0
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; {
-			p := b[i]
-			for j := 0; j < 8/im.bpc && x < im.w; j++ {
-				ind := p >> (8 - uint8(im.bpc))
-				v := decodePixelValue(lookup[ind], im.bpc, cvr)
-				if im.bpc < 8 {
-					v = scaleToBPC8(v, im.bpc)
-				}
-				//fmt.Printf("x=%d y=%d pix=#%02x v=#%02x\n", x, y, pix, v)
-				img.Set(x, y, color.Gray{Y: v})
-				p <<= uint8(im.bpc)
-				x++
-			}
-			i++
-		}
-	}
-
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
-		return nil, "", err
-	}
-
-	return &buf, "png", nil
+	return im.renderPNG(1, im.lookup(lookup, 1, maxInd), grayToRGB, false)
 }
 
-func renderIndexedRGBToPNG(im *PDFImage, lookup []byte) (io.Reader, string, error) {
-	b := im.sd.Content
-
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
-
-	i := 0
+func renderIndexedRGBToPNG(im *PDFImage, lookup []byte, maxInd int) (io.Reader, string, error) {
 	// TODO: For (some) Runlength encoded images the line sequence is reversed.
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; {
-			p := b[i]
-			for j := 0; j < 8/im.bpc && x < im.w; j++ {
-				ind := p >> (8 - uint8(im.bpc))
-				//fmt.Printf("x=%d y=%d i=%d j=%d p=#%02x ind=#%02x\n", x, y, i, j, p, ind)
-				alpha := uint8(255)
-				if im.softMask != nil {
-					alpha = im.softMask[y*im.w+x]
-				}
-				l := 3 * int(ind)
-				img.Set(x, y, color.NRGBA{R: lookup[l], G: lookup[l+1], B: lookup[l+2], A: alpha})
-				p <<= uint8(im.bpc)
-				x++
-			}
-			i++
-		}
-	}
-
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, img); err != nil {
-		return nil, "", err
-	}
-
-	return &buf, "png", nil
-}
-
-func imageForIndexedCMYKWithoutSoftMask(im *PDFImage, lookup []byte) image.Image {
-
-	// Preserve CMYK color model for print applications.
-
-	// TODO handle decode
-
-	img := image.NewCMYK(image.Rect(0, 0, im.w, im.h))
-	b := im.sd.Content
-	i := 0
-
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; {
-			p := b[i]
-			for j := 0; j < 8/im.bpc && x < im.w; j++ {
-				ind := p >> (8 - uint8(im.bpc))
-				//fmt.Printf("x=%d y=%d i=%d j=%d p=#%02x ind=#%02x\n", x, y, i, j, p, ind)
-				l := 4 * int(ind)
-				img.Set(x, y, color.CMYK{C: lookup[l], M: lookup[l+1], Y: lookup[l+2], K: lookup[l+3]})
-				p <<= uint8(im.bpc)
-				x++
-			}
-			i++
-		}
-	}
-
-	return img
-}
-
-func imageForIndexedCMYKWithSoftMask(im *PDFImage, lookup []byte) image.Image {
-
-	// TODO handle decode
-
-	img := image.NewNRGBA(image.Rect(0, 0, im.w, im.h))
-	b := im.sd.Content
-	i := 0
-
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; {
-			p := b[i]
-			for j := 0; j < 8/im.bpc && x < im.w; j++ {
-				ind := p >> (8 - uint8(im.bpc))
-				//fmt.Printf("x=%d y=%d i=%d j=%d p=#%02x ind=#%02x\n", x, y, i, j, p, ind)
-				l := 4 * int(ind)
-				cr, cg, cb := color.CMYKToRGB(lookup[l], lookup[l+1], lookup[l+2], lookup[l+3])
-				alpha := im.softMask[y*im.w+x]
-				img.Set(x, y, color.NRGBA{cr, cg, cb, alpha})
-				p <<= uint8(im.bpc)
-				x++
-			}
-			i++
-		}
-	}
-
-	return img
+	return im.renderPNG(3, im.lookup(lookup, 3, maxInd), rgbToRGB, false)
 }
 
-func renderIndexedCMYKToTIFF(im *PDFImage, lookup []byte) (io.Reader, string, error) {
-
-	var img image.Image
-	if im.softMask != nil {
-		img = imageForIndexedCMYKWithSoftMask(im, lookup)
-	} else {
-		img = imageForIndexedCMYKWithoutSoftMask(im, lookup)
-	}
-
-	var buf bytes.Buffer
-	if err := tiff.Encode(&buf, img, nil); err != nil {
-		return nil, "", err
-	}
-
-	return &buf, "tif", nil
+func renderIndexedCMYKToTIFF(im *PDFImage, lookup []byte, maxInd int) (io.Reader, string, error) {
+	return im.renderCMYK(im.lookup(lookup, 4, maxInd), false)
 }
 
 func renderIndexedNameCS(im *PDFImage, cs types.Name, maxInd int, lookup []byte) (io.Reader, string, error) {
@@ -652,31 +591,27 @@ func renderIndexedNameCS(im *PDFImage, cs types.Name, maxInd int, lookup []byte)
 		if len(lookup) < 1*(maxInd+1) {
 			return nil, "", errors.Errorf("pdfcpu: renderIndexedNameCS: objNr=%d, corrupt DeviceGray lookup table\n", im.objNr)
 		}
-		return renderIndexedGrayToPNG(im, lookup)
+		return renderIndexedGrayToPNG(im, lookup, maxInd)
 
 	case model.DeviceRGBCS:
 		if len(lookup) < 3*(maxInd+1) {
 			return nil, "", errors.Errorf("pdfcpu: renderIndexedNameCS: objNr=%d, corrupt DeviceRGB lookup table\n", im.objNr)
 		}
-		return renderIndexedRGBToPNG(im, lookup)
+		return renderIndexedRGBToPNG(im, lookup, maxInd)
 
 	case model.DeviceCMYKCS:
 		if len(lookup) < 4*(maxInd+1) {
 			return nil, "", errors.Errorf("pdfcpu: renderIndexedNameCS: objNr=%d, corrupt DeviceCMYK lookup table\n", im.objNr)
 		}
-		return renderIndexedCMYKToTIFF(im, lookup)
+		return renderIndexedCMYKToTIFF(im, lookup, maxInd)
 	}
 
-	if log.InfoEnabled() {
-		log.Info.Printf("renderIndexedNameCS: objNr=%d, unsupported base colorspace %s\n", im.objNr, cs.String())
-	}
+	im.xRefTable.LogInfo("renderIndexedNameCS: unsupported base colorspace", log.KeyObj, im.objNr, "cs", cs.String())
 
 	return nil, "", nil
 }
 
 func renderIndexedArrayCS(xRefTable *model.XRefTable, im *PDFImage, csa types.Array, maxInd int, lookup []byte) (io.Reader, string, error) {
-	b := im.sd.Content
-
 	cs, _ := csa[0].(types.Name)
 
 	switch cs {
@@ -684,7 +619,10 @@ func renderIndexedArrayCS(xRefTable *model.XRefTable, im *PDFImage, csa types.Ar
 	//case CalGrayCS:
 
 	case model.CalRGBCS:
-		return renderIndexedRGBToPNG(im, lookup)
+		if len(lookup) < 3*(maxInd+1) {
+			return nil, "", errors.Errorf("pdfcpu: renderIndexedArrayCS: objNr=%d, corrupt CalRGB lookup table\n", im.objNr)
+		}
+		return renderIndexedRGBToPNG(im, lookup, maxInd)
 
 	//case LabCS:
 	//	return renderIndexedRGBToPNG(im, resourceName, lookup)
@@ -711,38 +649,20 @@ func renderIndexedArrayCS(xRefTable *model.XRefTable, im *PDFImage, csa types.Ar
 		switch n {
 		case 1:
 			// Gray
-			// TODO use lookupTable!
-			// TODO handle bpc, decode and softmask.
-			img := image.NewGray(image.Rect(0, 0, im.w, im.h))
-			i := 0
-			for y := 0; y < im.h; y++ {
-				for x := 0; x < im.w; x++ {
-					img.Set(x, y, color.Gray{Y: b[i]})
-					i++
-				}
-			}
-			var buf bytes.Buffer
-			if err := png.Encode(&buf, img); err != nil {
-				return nil, "", err
-			}
-			return &buf, "png", nil
+			return renderIndexedGrayToPNG(im, lookup, maxInd)
 
 		case 3:
 			// RGB
-			return renderIndexedRGBToPNG(im, lookup)
+			return renderIndexedRGBToPNG(im, lookup, maxInd)
 
 		case 4:
 			// CMYK
-			if log.DebugEnabled() {
-				log.Debug.Printf("renderIndexedArrayCS: CMYK objNr=%d w=%d h=%d bpc=%d buflen=%d\n", im.objNr, im.w, im.h, im.bpc, len(b))
-			}
-			return renderIndexedCMYKToTIFF(im, lookup)
+			im.logRender("renderIndexedArrayCS")
+			return renderIndexedCMYKToTIFF(im, lookup, maxInd)
 		}
 	}
 
-	if log.InfoEnabled() {
-		log.Info.Printf("renderIndexedArrayCS: objNr=%d, unsupported base colorspace %s\n", im.objNr, csa)
-	}
+	im.xRefTable.LogInfo("renderIndexedArrayCS: unsupported base colorspace", log.KeyObj, im.objNr, "cs", csa)
 
 	return nil, "", nil
 }
@@ -765,16 +685,11 @@ func renderIndexed(xRefTable *model.XRefTable, im *PDFImage, cs types.Array) (io
 		return nil, "", errors.Errorf("pdfcpu: renderIndexed: objNr=%d IndexedCS with corrupt lookup table %s\n", im.objNr, cs)
 	}
 
-	b := im.sd.Content
-
-	if log.DebugEnabled() {
-		log.Debug.Printf("renderIndexed: objNr=%d w=%d h=%d bpc=%d buflen=%d maxInd=%d\n", im.objNr, im.w, im.h, im.bpc, len(b), maxInd)
-	}
+	im.logRender("renderIndexed", "maxInd", maxInd)
 
 	// Validate buflen.
 	// The image data is a sequence of index values for pixels.
-	// Sometimes there is a trailing 0x0A.
-	if len(b) < (im.bpc*im.w*im.h+7)/8 {
+	if !im.validate(1) {
 		return nil, "", errors.Errorf("pdfcpu: renderIndexed: objNr=%d corrupt image object %v\n", im.objNr, *im.sd)
 	}
 
@@ -856,9 +771,7 @@ func renderImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, o
 			return renderDeviceCMYKToTIFF(pdfImage)
 
 		default:
-			if log.InfoEnabled() {
-				log.Info.Printf("renderImage: objNr=%d, unsupported name colorspace %s\n", objNr, cs.String())
-			}
+			xRefTable.LogInfo("renderImage: unsupported name colorspace", log.KeyObj, objNr, "cs", cs.String())
 		}
 
 	case types.Array:
@@ -882,9 +795,7 @@ func renderImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, o
 			return renderDeviceN(pdfImage, cs)
 
 		default:
-			if log.InfoEnabled() {
-				log.Info.Printf("renderImage: objNr=%d, unsupported array colorspace %s\n", objNr, csn)
-			}
+			xRefTable.LogInfo("renderImage: unsupported array colorspace", log.KeyObj, objNr, "cs", csn)
 		}
 
 	}
@@ -892,53 +803,113 @@ func renderImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, o
 	return nil, "", nil
 }
 
-func decodeCMYK(c, m, y, k uint8, decode []colValRange) (uint8, uint8, uint8, uint8) {
-	if len(decode) == 0 {
-		return c, m, y, k
+// dctImage returns a version of the JPEG image sd holding its decoded 8 bit samples.
+func dctImage(xRefTable *model.XRefTable, sd *types.StreamDict, objNr int) (*types.StreamDict, error) {
+	w, h := sd.IntEntry("Width"), sd.IntEntry("Height")
+	if w == nil || h == nil {
+		return nil, errors.Errorf("pdfcpu: missing image dimensions obj#%d", objNr)
 	}
-	c = decodePixelValue(c, 8, decode[0])
-	m = decodePixelValue(m, 8, decode[1])
-	y = decodePixelValue(y, 8, decode[2])
-	k = decodePixelValue(k, 8, decode[3])
-	return c, m, y, k
-}
 
-func renderCMYKToPng(im *PDFImage) (io.Reader, string, error) {
-	bb := bytes.NewReader(im.sd.Content)
-	dec := gob.NewDecoder(bb)
+	sd1 := sd.Clone().(types.StreamDict)
+	sd1.FilterPipeline = nil
+	sd1.Update("BitsPerComponent", types.Integer(8))
 
-	var img image.CMYK
-	if err := dec.Decode(&img); err != nil {
-		return nil, "", err
+	if sd.CSComponents != 4 {
+		comp, err := ColorSpaceComponents(xRefTable, sd)
+		if err != nil {
+			return nil, err
+		}
+		if comp != 1 && comp != 3 {
+			return nil, errors.Errorf("pdfcpu: unsupported JPEG color space obj#%d", objNr)
+		}
+		if sd1.Content, err = dctSamples(sd.Raw, *w, *h, comp); err != nil {
+			return nil, err
+		}
+		return &sd1, nil
 	}
 
-	img1 := image.NewRGBA(image.Rect(0, 0, im.w, im.h))
+	// CMYK JPEG images have been decoded into a gob encoded image.CMYK.
+	var img image.CMYK
+	if err := gob.NewDecoder(bytes.NewReader(sd.Content)).Decode(&img); err != nil {
+		return nil, err
+	}
 
-	for y := 0; y < im.h; y++ {
-		for x := 0; x < im.w; x++ {
-			a := img.At(x, y).(color.CMYK)
-			cyan, mag, yel, blk := decodeCMYK(255-a.C, 255-a.M, 255-a.Y, 255-a.K, im.decode)
-			r, g, b := color.CMYKToRGB(cyan, mag, yel, blk)
-			// TODO Apply Decode array
-			img1.SetRGBA(x, y, color.RGBA{r, g, b, 255})
-		}
+	r := img.Bounds()
+	if r.Dx() != *w || r.Dy() != *h {
+		return nil, errors.Errorf("pdfcpu: image dimensions mismatch obj#%d", objNr)
 	}
 
-	var buf bytes.Buffer
-	if err := png.Encode(&buf, img1); err != nil {
-		return nil, "", err
+	// Adobe CMYK JPEGs are stored inverted.
+	bb := make([]byte, 4**w**h)
+	for y := 0; y < *h; y++ {
+		copy(bb[y*4**w:], img.Pix[y*img.Stride:y*img.Stride+4**w])
 	}
+	for i := range bb {
+		bb[i] = 255 - bb[i]
+	}
+	sd1.Content = bb
 
-	return &buf, "png", nil
+	return &sd1, nil
 }
 
+// renderDCTToPNG composites JPEG images with their soft mask and converts CMYK JPEG images to RGB.
 func renderDCTToPNG(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, objNr int) (io.Reader, string, error) {
-	im, err := pdfImage(xRefTable, sd, thumb, objNr)
+	sd1, err := dctImage(xRefTable, sd, objNr)
 	if err != nil {
 		return nil, "", err
 	}
 
-	return renderCMYKToPng(im)
+	im, err := pdfImage(xRefTable, sd1, thumb, objNr)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if sd.CSComponents == 4 {
+		im.comp = 4
+		return im.renderPNG(4, im.components, cmykToRGB, true)
+	}
+
+	return renderImage(xRefTable, sd1, thumb, objNr)
+}
+
+// jpxSamples8 returns the samples of img scaled down to 8 bits per component.
+func jpxSamples8(img *filter.JPXImage) []byte {
+	if img.BPC == 8 {
+		return img.Pix
+	}
+	bb := make([]byte, len(img.Pix)/2)
+	for i := range bb {
+		bb[i] = img.Pix[2*i]
+	}
+	return bb
+}
+
+func renderJPXToPNG(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, objNr int) (io.Reader, string, error) {
+	img, err := filter.DecodeJPX(bytes.NewReader(sd.Raw))
+	if err != nil {
+		xRefTable.LogInfo("renderJPXToPNG: writing raw JPX", log.KeyObj, objNr, "err", err)
+		return bytes.NewReader(sd.Raw), "jpx", nil
+	}
+
+	sd1 := sd.Clone().(types.StreamDict)
+	sd1.FilterPipeline = nil
+	sd1.Content = jpxSamples8(img)
+	sd1.Update("BitsPerComponent", types.Integer(8))
+
+	// The Decode array is ignored for JPX images unless ImageMask is true.
+	if im := sd.BooleanEntry("ImageMask"); im == nil || !*im {
+		sd1.Delete("Decode")
+	}
+
+	// The color space is optional and may be taken from the JPX data.
+	if comp, err := ColorSpaceComponents(xRefTable, &sd1); err != nil || comp != img.Components {
+		if img.ColorSpace == "" {
+			return bytes.NewReader(sd.Raw), "jpx", nil
+		}
+		sd1.Update("ColorSpace", types.Name(img.ColorSpace))
+	}
+
+	return renderImage(xRefTable, &sd1, thumb, objNr)
 }
 
 // RenderImage returns a reader for a decoded image stream.
@@ -953,17 +924,21 @@ func RenderImage(xRefTable *model.XRefTable, sd *types.StreamDict, thumb bool, r
 
 	switch f {
 
-	case filter.Flate, filter.LZW, filter.CCITTFax, filter.RunLength:
+	case filter.Flate, filter.LZW, filter.CCITTFax, filter.JBIG2, filter.RunLength:
 		return renderImage(xRefTable, sd, thumb, objNr)
 
 	case filter.DCT:
-		if sd.CSComponents == 4 {
+		if _, found := sd.Find("SMask"); found || sd.CSComponents == 4 {
 			return renderDCTToPNG(xRefTable, sd, thumb, objNr)
 		}
 		return bytes.NewReader(sd.Content), "jpg", nil
 
 	case filter.JPX:
-		return bytes.NewReader(sd.Content), "jpx", nil
+		return renderJPXToPNG(xRefTable, sd, thumb, objNr)
+	}
+
+	if filter.Registered(f) && sd.Content != nil {
+		return renderImage(xRefTable, sd, thumb, objNr)
 	}
 
 	return nil, "", nil
@@ -0,0 +1,179 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"github.com/pdfcpu/pdfcpu/pkg/filter"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+func testImageDict(w, h, bpc int, cs types.Object, content []byte) *types.StreamDict {
+	sd := &types.StreamDict{
+		Dict: types.Dict(
+			map[string]types.Object{
+				"Type":             types.Name("XObject"),
+				"Subtype":          types.Name("Image"),
+				"Width":            types.Integer(w),
+				"Height":           types.Integer(h),
+				"BitsPerComponent": types.Integer(bpc),
+				"ColorSpace":       cs,
+			},
+		),
+		Raw:     content,
+		Content: content,
+	}
+	return sd
+}
+
+func addTestSoftMask(t *testing.T, sd *types.StreamDict, w, h, bpc int, content []byte, d types.Dict) {
+	t.Helper()
+
+	sm := testImageDict(w, h, bpc, types.Name(model.DeviceGrayCS), content)
+	for k, v := range d {
+		sm.Insert(k, v)
+	}
+
+	indRef, err := xRefTable.IndRefForNewObject(*sm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sd.Insert("SMask", *indRef)
+}
+
+func renderTestImage(t *testing.T, sd *types.StreamDict) image.Image {
+	t.Helper()
+
+	r, typ, err := RenderImage(xRefTable, sd, false, "", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if typ != "png" {
+		t.Fatalf("got %s, want png", typ)
+	}
+
+	img, err := png.Decode(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return img
+}
+
+func checkPixel(t *testing.T, img image.Image, x, y int, want color.NRGBA) {
+	t.Helper()
+
+	if got := color.NRGBAModel.Convert(img.At(x, y)).(color.NRGBA); got != want {
+		t.Errorf("pixel %d,%d: got %v want %v", x, y, got, want)
+	}
+}
+
+func TestRenderImage16BPCWithSoftMask(t *testing.T) {
+	// 2x2 RGB with 16 bits per component and a soft mask of lower resolution.
+	content := []byte{
+		0xFF, 0xFF, 0x00, 0x00, 0x00, 0x00 /**/, 0x00, 0x00, 0xFF, 0xFF, 0x00, 0x00,
+		0x00, 0x00, 0x00, 0x00, 0xFF, 0xFF /**/, 0x12, 0x34, 0x56, 0x78, 0x9A, 0xBC,
+	}
+	sd := testImageDict(2, 2, 16, types.Name(model.DeviceRGBCS), content)
+	addTestSoftMask(t, sd, 1, 2, 8, []byte{0xFF, 0x80}, nil)
+
+	img := renderTestImage(t, sd)
+
+	if _, ok := img.(*image.NRGBA64); !ok {
+		t.Fatalf("got %T, want *image.NRGBA64", img)
+	}
+
+	checkPixel(t, img, 0, 0, color.NRGBA{R: 0xFF, A: 0xFF})
+	checkPixel(t, img, 1, 0, color.NRGBA{G: 0xFF, A: 0xFF})
+	checkPixel(t, img, 0, 1, color.NRGBA{B: 0xFF, A: 0x80})
+
+	if got := img.At(1, 1).(color.NRGBA64); got != (color.NRGBA64{R: 0x1234, G: 0x5678, B: 0x9ABC, A: 0x8080}) {
+		t.Errorf("pixel 1,1: got %v", got)
+	}
+}
+
+func TestRenderImageDecodeArrays(t *testing.T) {
+	// 4x1 inverted 2 bit gray with an inverted 1 bit soft mask.
+	sd := testImageDict(4, 1, 2, types.Name(model.DeviceGrayCS), []byte{0x1B})
+	sd.Insert("Decode", types.NewNumberArray(1, 0))
+	addTestSoftMask(t, sd, 4, 1, 1, []byte{0x50}, types.Dict{"Decode": types.NewNumberArray(1, 0)})
+
+	img := renderTestImage(t, sd)
+
+	checkPixel(t, img, 0, 0, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF})
+	checkPixel(t, img, 1, 0, color.NRGBA{R: 0xAA, G: 0xAA, B: 0xAA, A: 0x00})
+	checkPixel(t, img, 2, 0, color.NRGBA{R: 0x55, G: 0x55, B: 0x55, A: 0xFF})
+	checkPixel(t, img, 3, 0, color.NRGBA{A: 0x00})
+}
+
+func TestRenderIndexedImageWithSoftMask(t *testing.T) {
+	lookup := types.HexLiteral("FF000000FF000000FFFFFFFF")
+	cs := types.Array{types.Name(model.IndexedCS), types.Name(model.DeviceRGBCS), types.Integer(3), lookup}
+
+	// 2 bit indices 0,1,2,3 remapped by Decode [3 0] to 3,2,1,0.
+	sd := testImageDict(4, 1, 2, cs, []byte{0x1B})
+	sd.Insert("Decode", types.NewNumberArray(3, 0))
+	addTestSoftMask(t, sd, 4, 1, 8, []byte{0xFF, 0xC0, 0x80, 0x40}, nil)
+
+	img := renderTestImage(t, sd)
+
+	checkPixel(t, img, 0, 0, color.NRGBA{R: 0xFF, G: 0xFF, B: 0xFF, A: 0xFF})
+	checkPixel(t, img, 1, 0, color.NRGBA{B: 0xFF, A: 0xC0})
+	checkPixel(t, img, 2, 0, color.NRGBA{G: 0xFF, A: 0x80})
+	checkPixel(t, img, 3, 0, color.NRGBA{R: 0xFF, A: 0x40})
+}
+
+func TestRenderImageWithMatte(t *testing.T) {
+	// Black preblended with a white matte at 50% opacity.
+	sd := testImageDict(1, 1, 8, types.Name(model.DeviceRGBCS), []byte{0x7F, 0x7F, 0x7F})
+	addTestSoftMask(t, sd, 1, 1, 8, []byte{0x80}, types.Dict{"Matte": types.NewNumberArray(1, 1, 1)})
+
+	img := renderTestImage(t, sd)
+
+	checkPixel(t, img, 0, 0, color.NRGBA{A: 0x80})
+}
+
+func TestRenderDCTImageWithSoftMask(t *testing.T) {
+	src := image.NewGray(image.Rect(0, 0, 16, 16))
+	for i := range src.Pix {
+		src.Pix[i] = 0x40
+	}
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, src, &jpeg.Options{Quality: 100}); err != nil {
+		t.Fatal(err)
+	}
+
+	sd := testImageDict(16, 16, 8, types.Name(model.DeviceGrayCS), buf.Bytes())
+	sd.FilterPipeline = []types.PDFFilter{{Name: filter.DCT}}
+	sd.InsertName("Filter", filter.DCT)
+	addTestSoftMask(t, sd, 2, 2, 8, []byte{0xFF, 0x00, 0x00, 0xFF}, nil)
+
+	img := renderTestImage(t, sd)
+
+	checkPixel(t, img, 0, 0, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xFF})
+	checkPixel(t, img, 15, 0, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x00})
+	checkPixel(t, img, 0, 15, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0x00})
+	checkPixel(t, img, 15, 15, color.NRGBA{R: 0x40, G: 0x40, B: 0x40, A: 0xFF})
+}
@@ -294,7 +294,7 @@ func writeStringLiteralObject(ctx *model.Context, objNumber, genNumber int, sl t
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		sl1, err := encryptStringLiteral(sl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -316,7 +316,7 @@ func writeHexLiteralObject(ctx *model.Context, objNumber, genNumber int, hl type
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		hl1, err := encryptHexLiteral(hl, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -355,7 +355,7 @@ func writeDictObject(ctx *model.Context, objNumber, genNumber int, d types.Dict)
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		_, err := encryptDeepObject(d, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R)
 		if err != nil {
 			return err
@@ -375,7 +375,7 @@ func writeArrayObject(ctx *model.Context, objNumber, genNumber int, a types.Arra
 		return nil
 	}
 
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		if _, err := encryptDeepObject(a, objNumber, genNumber, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
@@ -472,15 +472,21 @@ func writeStreamDictObject(ctx *model.Context, objNr, genNr int, sd types.Stream
 		}
 	}
 
-	var err error
+	// Crypt filters only make sense for encrypted files using crypt filters.
+	if name, ok := streamCryptFilterName(&sd); ok {
+		if _, found := ctx.CryptFilters[name]; ctx.EncKey == nil || (!found && name != identityCryptFilter) {
+			removeCryptFilter(&sd)
+		}
+	}
 
 	// Unless the "Identity" crypt filter is used we have to encrypt.
-	isXRefStreamDict := sd.Type() != nil && *sd.Type() == "XRef"
-	if ctx.EncKey != nil &&
-		!isXRefStreamDict &&
-		!(len(sd.FilterPipeline) == 1 && sd.FilterPipeline[0].Name == "Crypt") {
+	encrypt, aes, err := streamEncryption(ctx, &sd)
+	if err != nil {
+		return err
+	}
 
-		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, ctx.AES4Streams, ctx.E.R); err != nil {
+	if encrypt {
+		if sd.Raw, err = encryptStream(sd.Raw, objNr, genNr, ctx.EncKey, aes, ctx.E.R); err != nil {
 			return err
 		}
 
@@ -594,7 +600,7 @@ func writeDeepDict(ctx *model.Context, d types.Dict, objNr, genNr int) error {
 }
 
 func writeDeepStreamDict(ctx *model.Context, sd *types.StreamDict, objNr, genNr int) error {
-	if ctx.EncKey != nil {
+	if stringEncryption(ctx) {
 		if _, err := encryptDeepObject(*sd, objNr, genNr, ctx.EncKey, ctx.AES4Strings, ctx.E.R); err != nil {
 			return err
 		}
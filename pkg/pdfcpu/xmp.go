@@ -0,0 +1,835 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+	"github.com/pkg/errors"
+)
+
+const (
+	xmpPacketHeader  = "<?xpacket begin=\"\xef\xbb\xbf\" id=\"W5M0MpCehiHzreSzNTczkc9d\"?>"
+	xmpPacketTrailer = "<?xpacket end=\"w\"?>"
+	nsXML            = "http://www.w3.org/XML/1998/namespace"
+)
+
+var xmpDateLayouts = []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02T15:04Z07:00", "2006-01-02T15:04", "2006-01-02", "2006-01", "2006"}
+
+// xmpNode represents a node of an XMP packet preserving prefixes and any content unknown to pdfcpu.
+type xmpNode struct {
+	elem  bool       // element or else raw markup
+	name  xml.Name   // Space holds the prefix.
+	ns    string     // resolved namespace
+	attrs []xml.Attr // Name.Space holds the prefix.
+	kids  []*xmpNode
+	scope xmpScope // namespace declarations in effect
+	data  string   // text for non element nodes, escaped on output if raw is false
+	raw   bool
+}
+
+func (n *xmpNode) qName() string {
+	if n.name.Space == "" {
+		return n.name.Local
+	}
+	return n.name.Space + ":" + n.name.Local
+}
+
+func (n *xmpNode) is(ns, local string) bool {
+	return n.elem && n.ns == ns && n.name.Local == local
+}
+
+func (n *xmpNode) attr(prefix, local string) (string, bool) {
+	for _, a := range n.attrs {
+		if a.Name.Space == prefix && a.Name.Local == local {
+			return a.Value, true
+		}
+	}
+	return "", false
+}
+
+// text returns the character data of n.
+func (n *xmpNode) text() string {
+	var sb strings.Builder
+	for _, kid := range n.kids {
+		if !kid.elem && !kid.raw {
+			sb.WriteString(kid.data)
+		}
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func (n *xmpNode) elements() []*xmpNode {
+	nn := []*xmpNode{}
+	for _, kid := range n.kids {
+		if kid.elem {
+			nn = append(nn, kid)
+		}
+	}
+	return nn
+}
+
+func (n *xmpNode) find(ns, local string) *xmpNode {
+	if n.is(ns, local) {
+		return n
+	}
+	for _, kid := range n.kids {
+		if n1 := kid.find(ns, local); n1 != nil {
+			return n1
+		}
+	}
+	return nil
+}
+
+func xmpEscaped(s string, attr bool) string {
+	r := strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	if attr {
+		r = strings.NewReplacer("&", "&amp;", "<", "&lt;", "\"", "&quot;")
+	}
+	return r.Replace(s)
+}
+
+func (n *xmpNode) write(w io.Writer) {
+	if !n.elem {
+		if n.raw {
+			io.WriteString(w, n.data)
+			return
+		}
+		io.WriteString(w, xmpEscaped(n.data, false))
+		return
+	}
+
+	fmt.Fprintf(w, "<%s", n.qName())
+	for _, a := range n.attrs {
+		qn := a.Name.Local
+		if a.Name.Space != "" {
+			qn = a.Name.Space + ":" + qn
+		}
+		fmt.Fprintf(w, " %s=\"%s\"", qn, xmpEscaped(a.Value, true))
+	}
+	if len(n.kids) == 0 {
+		io.WriteString(w, "/>")
+		return
+	}
+	io.WriteString(w, ">")
+	for _, kid := range n.kids {
+		kid.write(w)
+	}
+	fmt.Fprintf(w, "</%s>", n.qName())
+}
+
+// xmpScope resolves namespace prefixes.
+type xmpScope []map[string]string
+
+func (s xmpScope) resolve(prefix string) string {
+	if prefix == "xml" {
+		return nsXML
+	}
+	for i := len(s) - 1; i >= 0; i-- {
+		if ns, ok := s[i][prefix]; ok {
+			return ns
+		}
+	}
+	return ""
+}
+
+func rawMarkup(t xml.Token) string {
+	switch t := t.(type) {
+	case xml.ProcInst:
+		if len(t.Inst) == 0 {
+			return "<?" + t.Target + "?>"
+		}
+		return "<?" + t.Target + " " + string(t.Inst) + "?>"
+	case xml.Comment:
+		return "<!--" + string(t) + "-->"
+	case xml.Directive:
+		return "<!" + string(t) + ">"
+	}
+	return ""
+}
+
+// parseXMPPacket returns the node tree for the XMP packet bb.
+func parseXMPPacket(bb []byte) (*xmpNode, error) {
+	dec := xml.NewDecoder(bytes.NewReader(bb))
+	dec.Strict = false
+
+	doc := &xmpNode{elem: true}
+	stack := []*xmpNode{doc}
+	scope := xmpScope{}
+
+	for {
+		t, err := dec.RawToken()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "pdfcpu: invalid XMP packet")
+		}
+
+		parent := stack[len(stack)-1]
+
+		switch t := t.(type) {
+
+		case xml.StartElement:
+			m := map[string]string{}
+			for _, a := range t.Attr {
+				if a.Name.Space == "xmlns" {
+					m[a.Name.Local] = a.Value
+				}
+				if a.Name.Space == "" && a.Name.Local == "xmlns" {
+					m[""] = a.Value
+				}
+			}
+			scope = append(scope, m)
+			n := &xmpNode{elem: true, name: t.Name, ns: scope.resolve(t.Name.Space), attrs: append([]xml.Attr{}, t.Attr...), scope: append(xmpScope{}, scope...)}
+			parent.kids = append(parent.kids, n)
+			stack = append(stack, n)
+
+		case xml.EndElement:
+			if len(stack) == 1 {
+				return nil, errors.New("pdfcpu: invalid XMP packet: unexpected end element")
+			}
+			stack = stack[:len(stack)-1]
+			scope = scope[:len(scope)-1]
+
+		case xml.CharData:
+			parent.kids = append(parent.kids, &xmpNode{data: string(t)})
+
+		default:
+			parent.kids = append(parent.kids, &xmpNode{data: rawMarkup(t), raw: true})
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, errors.New("pdfcpu: invalid XMP packet: unexpected end of packet")
+	}
+
+	return doc, nil
+}
+
+func newXMPPacket() []byte {
+	return []byte(xmpPacketHeader + "\n" +
+		"<x:xmpmeta xmlns:x=\"adobe:ns:meta/\">\n" +
+		" <rdf:RDF xmlns:rdf=\"" + model.NSRDF + "\">\n" +
+		" </rdf:RDF>\n" +
+		"</x:xmpmeta>\n" +
+		xmpPacketTrailer)
+}
+
+func (doc *xmpNode) bytes() []byte {
+	var buf bytes.Buffer
+	for _, kid := range doc.kids {
+		kid.write(&buf)
+	}
+	return buf.Bytes()
+}
+
+// rdf returns the rdf:RDF element of doc.
+func (doc *xmpNode) rdf() (*xmpNode, error) {
+	n := doc.find(model.NSRDF, "RDF")
+	if n == nil {
+		return nil, errors.New("pdfcpu: invalid XMP packet: missing rdf:RDF")
+	}
+	return n, nil
+}
+
+func (doc *xmpNode) descriptions() []*xmpNode {
+	rdf, err := doc.rdf()
+	if err != nil {
+		return nil
+	}
+	nn := []*xmpNode{}
+	for _, n := range rdf.elements() {
+		if n.is(model.NSRDF, "Description") {
+			nn = append(nn, n)
+		}
+	}
+	return nn
+}
+
+// xmpValues returns the values of a property element.
+func xmpValues(n *xmpNode) []string {
+	for _, kid := range n.elements() {
+		if kid.ns != model.NSRDF {
+			continue
+		}
+		switch kid.name.Local {
+		case "Alt":
+			// Use the default language if available.
+			ss := []string{}
+			for _, li := range kid.elements() {
+				if !li.is(model.NSRDF, "li") {
+					continue
+				}
+				if lang, ok := li.attr("xml", "lang"); ok && lang == "x-default" {
+					return []string{li.text()}
+				}
+				ss = append(ss, li.text())
+			}
+			if len(ss) > 0 {
+				return ss[:1]
+			}
+			return nil
+		case "Seq", "Bag":
+			ss := []string{}
+			for _, li := range kid.elements() {
+				if li.is(model.NSRDF, "li") {
+					ss = append(ss, li.text())
+				}
+			}
+			return ss
+		}
+	}
+	return []string{n.text()}
+}
+
+// xmpProperties returns the values of all properties of doc by namespace and property name.
+func xmpProperties(doc *xmpNode) map[string]map[string][]string {
+	m := map[string]map[string][]string{}
+
+	add := func(ns, name string, ss []string) {
+		if m[ns] == nil {
+			m[ns] = map[string][]string{}
+		}
+		m[ns][name] = ss
+	}
+
+	for _, d := range doc.descriptions() {
+		for _, a := range d.attrs {
+			if a.Name.Space == "" || a.Name.Space == "xmlns" || a.Name.Space == "xml" {
+				continue
+			}
+			ns := d.scope.resolve(a.Name.Space)
+			if ns == model.NSRDF {
+				continue
+			}
+			add(ns, a.Name.Local, []string{a.Value})
+		}
+		for _, n := range d.elements() {
+			add(n.ns, n.name.Local, xmpValues(n))
+		}
+	}
+
+	return m
+}
+
+func xmpNamespaces(n *xmpNode) map[string]string {
+	m := map[string]string{}
+	for _, a := range n.attrs {
+		if a.Name.Space == "xmlns" {
+			m[a.Name.Local] = a.Value
+		}
+	}
+	return m
+}
+
+func parseXMPDate(s string) (*time.Time, bool) {
+	for _, layout := range xmpDateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return &t, true
+		}
+	}
+	return nil, false
+}
+
+func firstValue(ss []string) string {
+	if len(ss) == 0 {
+		return ""
+	}
+	return ss[0]
+}
+
+// xmpOf returns the typed Dublin Core, PDF and XMP basic properties of doc.
+func xmpOf(doc *xmpNode) *model.XMP {
+	m := xmpProperties(doc)
+
+	dc, pdf, xmp := m[model.NSDC], m[model.NSPDF], m[model.NSXMP]
+
+	x := &model.XMP{
+		Title:       firstValue(dc["title"]),
+		Creators:    dc["creator"],
+		Description: firstValue(dc["description"]),
+		Subjects:    dc["subject"],
+		Publishers:  dc["publisher"],
+		Rights:      firstValue(dc["rights"]),
+		Producer:    firstValue(pdf["Producer"]),
+		Keywords:    firstValue(pdf["Keywords"]),
+		CreatorTool: firstValue(xmp["CreatorTool"]),
+	}
+
+	if s := firstValue(pdf["Trapped"]); s != "" {
+		if b, err := strconv.ParseBool(s); err == nil {
+			x.Trapped = &b
+		}
+	}
+
+	x.CreateDate, _ = parseXMPDate(firstValue(xmp["CreateDate"]))
+	x.ModifyDate, _ = parseXMPDate(firstValue(xmp["ModifyDate"]))
+	x.MetadataDate, _ = parseXMPDate(firstValue(xmp["MetadataDate"]))
+
+	for ns := range m {
+		x.Schemas = append(x.Schemas, ns)
+	}
+	sort.Strings(x.Schemas)
+
+	return x
+}
+
+// xmpProperty represents a property to be set.
+type xmpProperty struct {
+	ns, name  string
+	container string // Alt, Seq, Bag or empty for simple values
+	values    []string
+}
+
+func xmpDate(t *time.Time) []string {
+	return []string{t.Format(time.RFC3339)}
+}
+
+func xmpPropertiesForUpdate(x *model.XMP) []xmpProperty {
+	pp := []xmpProperty{}
+
+	add := func(ns, name, container string, ss ...string) {
+		pp = append(pp, xmpProperty{ns: ns, name: name, container: container, values: ss})
+	}
+
+	if x.Title != "" {
+		add(model.NSDC, "title", "Alt", x.Title)
+	}
+	if len(x.Creators) > 0 {
+		add(model.NSDC, "creator", "Seq", x.Creators...)
+	}
+	if x.Description != "" {
+		add(model.NSDC, "description", "Alt", x.Description)
+	}
+	if len(x.Subjects) > 0 {
+		add(model.NSDC, "subject", "Bag", x.Subjects...)
+	}
+	if len(x.Publishers) > 0 {
+		add(model.NSDC, "publisher", "Bag", x.Publishers...)
+	}
+	if x.Rights != "" {
+		add(model.NSDC, "rights", "Alt", x.Rights)
+	}
+	if x.Producer != "" {
+		add(model.NSPDF, "Producer", "", x.Producer)
+	}
+	if x.Keywords != "" {
+		add(model.NSPDF, "Keywords", "", x.Keywords)
+	}
+	if x.Trapped != nil {
+		s := "False"
+		if *x.Trapped {
+			s = "True"
+		}
+		add(model.NSPDF, "Trapped", "", s)
+	}
+	if x.CreatorTool != "" {
+		add(model.NSXMP, "CreatorTool", "", x.CreatorTool)
+	}
+	if x.CreateDate != nil {
+		add(model.NSXMP, "CreateDate", "", xmpDate(x.CreateDate)...)
+	}
+	if x.ModifyDate != nil {
+		add(model.NSXMP, "ModifyDate", "", xmpDate(x.ModifyDate)...)
+	}
+
+	// The metadata date reflects the last change of the metadata.
+	t := time.Now()
+	if x.MetadataDate != nil {
+		t = *x.MetadataDate
+	}
+
+	return append(pp, xmpMetadataDate(t))
+}
+
+func xmpMetadataDate(t time.Time) xmpProperty {
+	return xmpProperty{ns: model.NSXMP, name: "MetadataDate", values: xmpDate(&t)}
+}
+
+// removeXMPProperty removes all occurrences of property p from the rdf:Description d.
+func removeXMPProperty(d *xmpNode, p xmpProperty) {
+	attrs := []xml.Attr{}
+	for _, a := range d.attrs {
+		if a.Name.Local == p.name && a.Name.Space != "" && a.Name.Space != "xmlns" && d.scope.resolve(a.Name.Space) == p.ns {
+			continue
+		}
+		attrs = append(attrs, a)
+	}
+	d.attrs = attrs
+
+	kids := []*xmpNode{}
+	for _, kid := range d.kids {
+		if kid.is(p.ns, p.name) {
+			continue
+		}
+		kids = append(kids, kid)
+	}
+	d.kids = kids
+}
+
+func newXMPElement(prefix, local string, ns string) *xmpNode {
+	return &xmpNode{elem: true, name: xml.Name{Space: prefix, Local: local}, ns: ns}
+}
+
+func (p xmpProperty) node(prefix string) *xmpNode {
+	n := newXMPElement(prefix, p.name, p.ns)
+
+	if p.container == "" {
+		n.kids = []*xmpNode{{data: firstValue(p.values)}}
+		return n
+	}
+
+	c := newXMPElement("rdf", p.container, model.NSRDF)
+	for _, s := range p.values {
+		li := newXMPElement("rdf", "li", model.NSRDF)
+		if p.container == "Alt" {
+			li.attrs = []xml.Attr{{Name: xml.Name{Space: "xml", Local: "lang"}, Value: "x-default"}}
+		}
+		li.kids = []*xmpNode{{data: s}}
+		c.kids = append(c.kids, li)
+	}
+	n.kids = []*xmpNode{c}
+
+	return n
+}
+
+// xmpTarget returns the rdf:Description declaring the preferred prefixes taking new properties.
+func xmpTarget(rdf *xmpNode, rdfPrefix string) *xmpNode {
+	about := ""
+
+	for _, d := range rdf.elements() {
+		if !d.is(model.NSRDF, "Description") {
+			continue
+		}
+		if s, ok := d.attr(rdfPrefix, "about"); ok {
+			about = s
+		}
+		m := xmpNamespaces(d)
+		ok := true
+		for ns, prefix := range model.XMPPrefixes {
+			if m[prefix] != ns {
+				ok = false
+				break
+			}
+		}
+		if ok {
+			return d
+		}
+	}
+
+	d := newXMPElement(rdfPrefix, "Description", model.NSRDF)
+	d.attrs = []xml.Attr{{Name: xml.Name{Space: rdfPrefix, Local: "about"}, Value: about}}
+	for _, ns := range []string{model.NSDC, model.NSPDF, model.NSXMP} {
+		d.attrs = append(d.attrs, xml.Attr{Name: xml.Name{Space: "xmlns", Local: model.XMPPrefixes[ns]}, Value: ns})
+	}
+	d.scope = append(append(xmpScope{}, rdf.scope...), xmpNamespaces(d))
+
+	rdf.kids = append(rdf.kids, &xmpNode{data: " "}, d, &xmpNode{data: "\n "})
+
+	return d
+}
+
+// emptyXMPDescription returns true if d carries no properties.
+func emptyXMPDescription(d *xmpNode) bool {
+	if len(d.elements()) > 0 {
+		return false
+	}
+	for _, a := range d.attrs {
+		if a.Name.Space != "xmlns" && !(a.Name.Space == "" && a.Name.Local == "xmlns") && a.Name.Local != "about" {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeXMP updates the XMP packet bb with the properties set in x.
+// Any other properties and schemas of bb are preserved.
+func mergeXMP(bb []byte, x *model.XMP) ([]byte, error) {
+	return updateXMPPacket(bb, xmpPropertiesForUpdate(x), nil)
+}
+
+// updateXMPPacket sets the properties set and removes the properties remove from the XMP packet bb.
+func updateXMPPacket(bb []byte, set, remove []xmpProperty) ([]byte, error) {
+	if len(bytes.TrimSpace(bb)) == 0 {
+		bb = newXMPPacket()
+	}
+
+	doc, err := parseXMPPacket(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	rdf, err := doc.rdf()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range doc.descriptions() {
+		for _, p := range append(append([]xmpProperty{}, set...), remove...) {
+			removeXMPProperty(d, p)
+		}
+	}
+
+	var target *xmpNode
+	if len(set) > 0 {
+		target = xmpTarget(rdf, rdf.name.Space)
+		for _, p := range set {
+			target.kids = append(target.kids, &xmpNode{data: "\n   "}, p.node(model.XMPPrefixes[p.ns]))
+		}
+		target.kids = append(target.kids, &xmpNode{data: "\n  "})
+	}
+
+	// Drop descriptions left without any properties.
+	kids := []*xmpNode{}
+	for _, kid := range rdf.kids {
+		if kid != target && kid.is(model.NSRDF, "Description") && emptyXMPDescription(kid) {
+			continue
+		}
+		kids = append(kids, kid)
+	}
+	rdf.kids = kids
+
+	return doc.bytes(), nil
+}
+
+// xmpMetadata returns the decoded XMP packet of ctx's catalog or nil.
+func xmpMetadata(ctx *model.Context) ([]byte, error) {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return nil, err
+	}
+
+	o, found := rootDict.Find("Metadata")
+	if !found {
+		return nil, nil
+	}
+
+	sd, _, err := ctx.DereferenceStreamDict(o)
+	if err != nil || sd == nil {
+		return nil, err
+	}
+
+	if err := sd.Decode(); err != nil {
+		return nil, err
+	}
+
+	return sd.Content, nil
+}
+
+// setXMPMetadata sets bb as ctx's XMP packet.
+func setXMPMetadata(ctx *model.Context, bb []byte) error {
+	rootDict, err := ctx.Catalog()
+	if err != nil {
+		return err
+	}
+
+	if indRef := rootDict.IndirectRefEntry("Metadata"); indRef != nil {
+		entry, ok := ctx.FindTableEntryForIndRef(indRef)
+		if !ok {
+			return errors.New("pdfcpu: corrupt document metadata")
+		}
+		sd, ok := entry.Object.(types.StreamDict)
+		if !ok {
+			return errors.New("pdfcpu: corrupt document metadata")
+		}
+		if err := sd.Decode(); err != nil {
+			return err
+		}
+		sd.Content = bb
+		if err := sd.Encode(); err != nil {
+			return err
+		}
+		entry.Object = sd
+		return nil
+	}
+
+	// Metadata streams are left uncompressed for the sake of non PDF aware tools (14.3.2 Metadata Streams).
+	sd := types.StreamDict{Dict: types.NewDict(), Content: bb}
+	sd.InsertName("Type", "Metadata")
+	sd.InsertName("Subtype", "XML")
+	if err := sd.Encode(); err != nil {
+		return err
+	}
+
+	ir, err := ctx.IndRefForNewObject(sd)
+	if err != nil {
+		return err
+	}
+
+	rootDict.Update("Metadata", *ir)
+
+	return nil
+}
+
+// XMP returns the Dublin Core, PDF and XMP basic properties of ctx's XMP metadata or nil.
+func XMP(ctx *model.Context) (*model.XMP, error) {
+	bb, err := xmpMetadata(ctx)
+	if err != nil || bb == nil {
+		return nil, err
+	}
+
+	doc, err := parseXMPPacket(bb)
+	if err != nil {
+		return nil, err
+	}
+
+	return xmpOf(doc), nil
+}
+
+// SetXMP merges the properties set in x into ctx's XMP metadata, creating the metadata stream if necessary.
+// Unknown schemas and properties are preserved, xmp:MetadataDate is updated.
+func SetXMP(ctx *model.Context, x *model.XMP) error {
+	if x == nil {
+		return errors.New("pdfcpu: SetXMP: missing x")
+	}
+
+	bb, err := xmpMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	if bb, err = mergeXMP(bb, x); err != nil {
+		return err
+	}
+
+	return setXMPMetadata(ctx, bb)
+}
+
+func parseXMPList(s string) []string {
+	ss := []string{}
+	for _, s := range strings.Split(s, ";") {
+		if s = strings.TrimSpace(s); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// ParseXMPProperties returns the XMP properties for a map of property names and values.
+// Multiple creators, subjects or publishers are separated by semicolons.
+func ParseXMPProperties(m map[string]string) (*model.XMP, error) {
+	x := &model.XMP{}
+
+	for k, v := range m {
+		switch strings.ToLower(k) {
+		case "title":
+			x.Title = v
+		case "creator", "creators", "author":
+			x.Creators = parseXMPList(v)
+		case "description":
+			x.Description = v
+		case "subject", "subjects":
+			x.Subjects = parseXMPList(v)
+		case "publisher", "publishers":
+			x.Publishers = parseXMPList(v)
+		case "rights":
+			x.Rights = v
+		case "producer":
+			x.Producer = v
+		case "keywords":
+			x.Keywords = v
+		case "trapped":
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.Errorf("pdfcpu: invalid XMP trapped value: %s", v)
+			}
+			x.Trapped = &b
+		case "creatortool":
+			x.CreatorTool = v
+		case "createdate", "modifydate", "metadatadate":
+			t, ok := parseXMPDate(v)
+			if !ok {
+				return nil, errors.Errorf("pdfcpu: invalid XMP date for %s: %s", k, v)
+			}
+			switch strings.ToLower(k) {
+			case "createdate":
+				x.CreateDate = t
+			case "modifydate":
+				x.ModifyDate = t
+			default:
+				x.MetadataDate = t
+			}
+		default:
+			return nil, errors.Errorf("pdfcpu: unsupported XMP property: %s", k)
+		}
+	}
+
+	return x, nil
+}
+
+// ListXMP returns a formatted list of ctx's XMP metadata.
+func ListXMP(ctx *model.Context) ([]string, error) {
+	x, err := XMP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if x == nil {
+		return []string{"no XMP metadata available"}, nil
+	}
+
+	ss := []string{}
+
+	add := func(k, v string) {
+		if v != "" {
+			ss = append(ss, fmt.Sprintf("%13s: %s", k, v))
+		}
+	}
+
+	date := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	add("Title", x.Title)
+	add("Creators", strings.Join(x.Creators, "; "))
+	add("Description", x.Description)
+	add("Subjects", strings.Join(x.Subjects, "; "))
+	add("Publishers", strings.Join(x.Publishers, "; "))
+	add("Rights", x.Rights)
+	add("Producer", x.Producer)
+	add("Keywords", x.Keywords)
+	if x.Trapped != nil {
+		add("Trapped", strconv.FormatBool(*x.Trapped))
+	}
+	add("CreatorTool", x.CreatorTool)
+	add("CreateDate", date(x.CreateDate))
+	add("ModifyDate", date(x.ModifyDate))
+	add("MetadataDate", date(x.MetadataDate))
+
+	for i, ns := range x.Schemas {
+		if i == 0 {
+			ss = append(ss, fmt.Sprintf("%13s: %s", "Schemas", ns))
+			continue
+		}
+		ss = append(ss, fmt.Sprintf("%13s  %s", "", ns))
+	}
+
+	return ss, nil
+}
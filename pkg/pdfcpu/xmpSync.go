@@ -0,0 +1,229 @@
+/*
+Copyright 2025 The pdfcpu Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pdfcpu
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/model"
+	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/types"
+)
+
+// infoXMPKeys are the info dict entries kept in sync with their XMP counterparts.
+var infoXMPKeys = []string{"Title", "Author", "Subject", "Keywords", "Creator"}
+
+func isInfoXMPKey(k string) bool {
+	return types.MemberOf(k, infoXMPKeys)
+}
+
+// splitKeywords returns the keywords of s separated by commas or semicolons.
+func splitKeywords(s string) []string {
+	ss := []string{}
+	for _, s := range strings.FieldsFunc(s, func(c rune) bool { return c == ',' || c == ';' || c == '\r' }) {
+		if s = strings.TrimSpace(s); s != "" {
+			ss = append(ss, s)
+		}
+	}
+	return ss
+}
+
+// infoXMPProperties returns the XMP properties corresponding to the info dict entry k.
+// Keywords also go into dc:subject which is where Acrobat looks for them.
+func infoXMPProperties(k, v string) []xmpProperty {
+	switch k {
+	case "Title":
+		return []xmpProperty{{ns: model.NSDC, name: "title", container: "Alt", values: []string{v}}}
+	case "Author":
+		return []xmpProperty{{ns: model.NSDC, name: "creator", container: "Seq", values: parseXMPList(v)}}
+	case "Subject":
+		return []xmpProperty{{ns: model.NSDC, name: "description", container: "Alt", values: []string{v}}}
+	case "Keywords":
+		return []xmpProperty{
+			{ns: model.NSPDF, name: "Keywords", values: []string{v}},
+			{ns: model.NSDC, name: "subject", container: "Bag", values: splitKeywords(v)},
+		}
+	case "Creator":
+		return []xmpProperty{{ns: model.NSXMP, name: "CreatorTool", values: []string{v}}}
+	}
+	return nil
+}
+
+// xmpValue returns the value of the XMP counterpart of the info dict entry k.
+func xmpValue(x *model.XMP, k string) string {
+	if x == nil {
+		return ""
+	}
+	switch k {
+	case "Title":
+		return x.Title
+	case "Author":
+		return strings.Join(x.Creators, "; ")
+	case "Subject":
+		return x.Description
+	case "Keywords":
+		return x.Keywords
+	case "Creator":
+		return x.CreatorTool
+	}
+	return ""
+}
+
+// updateInfoXMP updates the XMP counterparts of the info dict entries in m.
+// Empty values remove the corresponding XMP properties.
+func updateInfoXMP(ctx *model.Context, m map[string]string) error {
+	bb, err := xmpMetadata(ctx)
+	if err != nil {
+		return err
+	}
+
+	var set, remove []xmpProperty
+	for _, k := range infoXMPKeys {
+		v, ok := m[k]
+		if !ok {
+			continue
+		}
+		v = strings.TrimSpace(v)
+		for _, p := range infoXMPProperties(k, v) {
+			if len(p.values) == 0 || p.values[0] == "" {
+				remove = append(remove, p)
+				continue
+			}
+			set = append(set, p)
+		}
+	}
+
+	if len(set) == 0 && (len(remove) == 0 || bb == nil) {
+		return nil
+	}
+
+	set = append(set, xmpMetadataDate(time.Now()))
+
+	if bb, err = updateXMPPacket(bb, set, remove); err != nil {
+		return err
+	}
+
+	return setXMPMetadata(ctx, bb)
+}
+
+// syncInfoXMP propagates changes of info dict entries to the XMP metadata creating it if necessary.
+// In relaxed validation mode corrupt XMP metadata is left alone.
+func syncInfoXMP(ctx *model.Context, m map[string]string) error {
+	err := updateInfoXMP(ctx, m)
+	if err != nil && ctx.XRefTable.ValidationMode == model.ValidationRelaxed {
+		ctx.LogInfo("syncInfoXMP: skipping XMP metadata update", "err", err)
+		return nil
+	}
+	return err
+}
+
+func infoValue(ctx *model.Context, d types.Dict, k string) (string, error) {
+	if d == nil {
+		return "", nil
+	}
+	o, found := d.Find(k)
+	if !found {
+		return "", nil
+	}
+	s, err := ctx.DereferenceText(o)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(s), nil
+}
+
+func infoMatchesXMP(k, info, xmp string) bool {
+	switch k {
+	case "Author":
+		return strings.Join(parseXMPList(info), "; ") == xmp
+	case "Keywords":
+		return strings.Join(splitKeywords(info), "; ") == strings.Join(splitKeywords(xmp), "; ")
+	}
+	return info == xmp
+}
+
+// MetadataMismatches returns the info dict entries Title, Author, Subject, Keywords and Creator disagreeing with their XMP counterparts.
+func MetadataMismatches(ctx *model.Context) ([]model.MetadataMismatch, error) {
+	var d types.Dict
+	if ctx.Info != nil {
+		var err error
+		if d, err = ctx.DereferenceDict(*ctx.Info); err != nil {
+			return nil, err
+		}
+	}
+
+	x, err := XMP(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	mm := []model.MetadataMismatch{}
+
+	for _, k := range infoXMPKeys {
+		info, err := infoValue(ctx, d, k)
+		if err != nil {
+			return nil, err
+		}
+		xmp := xmpValue(x, k)
+		if !infoMatchesXMP(k, info, xmp) {
+			mm = append(mm, model.MetadataMismatch{Key: k, Info: info, XMP: xmp})
+		}
+	}
+
+	return mm, nil
+}
+
+// ReconcileMetadata fixes all mismatches between ctx's info dict and XMP metadata and returns them.
+// Missing values are taken from the other side.
+// Conflicting values are resolved in favor of the info dict unless preferXMP is set.
+func ReconcileMetadata(ctx *model.Context, preferXMP bool) ([]model.MetadataMismatch, error) {
+	mm, err := MetadataMismatches(ctx)
+	if err != nil || len(mm) == 0 {
+		return mm, err
+	}
+
+	if err := ensureInfoDictAndFileID(ctx); err != nil {
+		return nil, err
+	}
+
+	d, err := ctx.DereferenceDict(*ctx.Info)
+	if err != nil {
+		return nil, err
+	}
+
+	m := map[string]string{}
+
+	for _, mismatch := range mm {
+		if mismatch.Info != "" && (mismatch.XMP == "" || !preferXMP) {
+			m[mismatch.Key] = mismatch.Info
+			continue
+		}
+		s, err := types.EscapedUTF16String(mismatch.XMP)
+		if err != nil {
+			return nil, err
+		}
+		d[mismatch.Key] = types.StringLiteral(*s)
+	}
+
+	if len(m) > 0 {
+		if err := updateInfoXMP(ctx, m); err != nil {
+			return nil, err
+		}
+	}
+
+	return mm, nil
+}
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/pdfcpu/pdfcpu/pkg/log"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/color"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/draw"
 	"github.com/pdfcpu/pdfcpu/pkg/pdfcpu/matrix"
@@ -161,9 +160,7 @@ func zoomPage(ctx *model.Context, pageNr int, zoom *model.Zoom) error {
 }
 
 func Zoom(ctx *model.Context, selectedPages types.IntSet, zoom *model.Zoom) error {
-	if log.DebugEnabled() {
-		log.Debug.Printf("Zoom:\n%s\n", zoom)
-	}
+	ctx.LogDebug("Zoom", "zoom", zoom)
 
 	if len(selectedPages) == 0 {
 		selectedPages = types.IntSet{}